@@ -2,29 +2,50 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/server"
-)
-
-var (
-	// Version is set during build via -ldflags
-	Version = "0.1.0-dev"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/buildinfo"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export-tools" {
+		os.Exit(runExportTools(os.Args[2:]))
+	}
+
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "Path to YAML configuration file (env: CONFIG_FILE)")
+	validateOnly := flag.Bool("validate-config", false, "Load and validate the configuration, print the effective settings, and exit")
+	showVersion := flag.Bool("version", false, "Print the version, commit, build date and Go version, and exit")
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Println(buildinfo.Get())
+		return
+	}
+
+	if *validateOnly {
+		os.Exit(runValidateConfig(*configPath))
+	}
+
 	fmt.Println("╔═══════════════════════════════════════════════════════════╗")
 	fmt.Println("║  OpenShift Cluster Health MCP Server                     ║")
-	fmt.Printf("║  Version: %-48s║\n", Version)
+	fmt.Printf("║  Version: %-48s║\n", buildinfo.Version)
 	fmt.Println("╚═══════════════════════════════════════════════════════════╝")
 	fmt.Println()
 
-	// Load configuration from environment
-	config := server.NewConfig()
+	// Load configuration from file (if any) with environment overrides
+	config, err := server.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Configuration error: %v", err)
+	}
 
 	// Display configuration
 	printConfig(config)
@@ -34,20 +55,33 @@ func main() {
 		log.Fatalf("Configuration error: %v", err)
 	}
 
+	// Drop log lines below LOG_LEVEL from here on
+	log.SetOutput(server.LogWriter(os.Stderr))
+
+	// Set up tracing before any instrumented client is created
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Endpoint:       config.OTLPEndpoint,
+		ServiceName:    config.Name,
+		ServiceVersion: config.Version,
+	})
+	if err != nil {
+		log.Fatalf("ERROR: failed to set up tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			log.Printf("ERROR: flushing traces: %v", err)
+		}
+	}()
+
 	// Create MCP server
 	mcpServer, err := server.NewMCPServer(config)
 	if err != nil {
-		log.Fatalf("Failed to create MCP server: %v", err)
+		log.Fatalf("ERROR: failed to create MCP server: %v", err)
 	}
 
-	// TODO Phase 1.3-1.4: Register MCP tools
-	// tools := []mcp.Tool{
-	//     NewClusterHealthTool(),
-	//     NewListPodsTool(),
-	// }
-	// if err := mcpServer.RegisterTools(tools); err != nil {
-	//     log.Fatalf("Failed to register tools: %v", err)
-	// }
+	// The built-in tools and resources are registered by NewMCPServer.
+	// Builds that add their own do so here, with mcpServer.RegisterTool and
+	// mcpServer.RegisterResource, before Start.
 
 	log.Println("MCP Server starting...")
 
@@ -66,9 +100,20 @@ func main() {
 		cancel()
 	}()
 
+	// Reload the config file on SIGHUP without dropping sessions
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+
+	go func() {
+		for range hupChan {
+			log.Println("Received SIGHUP, reloading configuration...")
+			mcpServer.ReloadAndLog()
+		}
+	}()
+
 	// Start the MCP server
 	if err := mcpServer.Start(ctx); err != nil {
-		log.Fatalf("Server error: %v", err)
+		log.Fatalf("ERROR: server error: %v", err)
 	}
 
 	log.Println("MCP Server stopped")
@@ -80,12 +125,16 @@ func printConfig(cfg *server.Config) {
 	fmt.Println("──────────────────────────────────────────────────────────")
 	fmt.Printf("  Transport:           %s\n", cfg.Transport)
 
-	if cfg.Transport == server.TransportHTTP {
+	if cfg.Transport.ServesHTTP() {
 		fmt.Printf("  HTTP Address:        %s\n", cfg.GetHTTPAddr())
 	}
 
 	fmt.Printf("  Cache TTL:           %v\n", cfg.CacheTTL)
 	fmt.Printf("  Request Timeout:     %v\n", cfg.RequestTimeout)
+	fmt.Printf("  Log Level:           %s\n", cfg.LogLevel)
+	if cfg.OTLPEndpoint != "" {
+		fmt.Printf("  Tracing (OTLP):      %s\n", cfg.OTLPEndpoint)
+	}
 	fmt.Println()
 
 	fmt.Println("Integrations:")
@@ -110,3 +159,79 @@ func printConfig(cfg *server.Config) {
 	fmt.Println("──────────────────────────────────────────────────────────")
 	fmt.Println()
 }
+
+// runValidateConfig loads and validates the configuration, prints the
+// effective settings (secrets redacted) and a validation report, and
+// returns the process exit code.
+func runValidateConfig(configPath string) int {
+	cfg, err := server.LoadConfig(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration load failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Println("Effective configuration:")
+	fmt.Println("──────────────────────────────────────────────────────────")
+	for _, setting := range cfg.EffectiveSettings() {
+		fmt.Printf("  %-28s %s\n", setting.Key+":", setting.Value)
+	}
+	fmt.Println()
+
+	if err := cfg.Validate(); err != nil {
+		var validationErr *server.ValidationError
+		if errors.As(err, &validationErr) {
+			fmt.Printf("Configuration is INVALID (%d problems):\n", len(validationErr.Problems))
+			for _, problem := range validationErr.Problems {
+				fmt.Printf("  - %s\n", problem)
+			}
+		} else {
+			fmt.Printf("Configuration is INVALID: %v\n", err)
+		}
+		return 1
+	}
+
+	fmt.Println("Configuration is valid")
+	return 0
+}
+
+// runExportTools writes the tool manifest to stdout and returns the process
+// exit code. No cluster or integration is contacted, so it runs anywhere,
+// such as in CI to diff the catalog between builds.
+func runExportTools(args []string) int {
+	flags := flag.NewFlagSet("export-tools", flag.ContinueOnError)
+	configPath := flags.String("config", os.Getenv("CONFIG_FILE"), "Path to YAML configuration file (env: CONFIG_FILE)")
+	format := flags.String("format", server.ManifestFormatJSON, "Output format: json or yaml")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != server.ManifestFormatJSON && *format != server.ManifestFormatYAML {
+		fmt.Fprintf(os.Stderr, "Unsupported format %q (use json or yaml)\n", *format)
+		return 2
+	}
+
+	cfg, err := server.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration load failed: %v\n", err)
+		return 1
+	}
+
+	// Registering the tools logs every one of them; only the manifest is output
+	log.SetOutput(io.Discard)
+	manifest, err := server.BuildToolManifest(cfg)
+	log.SetOutput(os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Building the tool manifest failed: %v\n", err)
+		return 1
+	}
+
+	data, err := server.MarshalToolManifest(manifest, *format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Encoding the tool manifest failed: %v\n", err)
+		return 1
+	}
+	if _, err := os.Stdout.Write(data); err != nil {
+		fmt.Fprintf(os.Stderr, "Writing the tool manifest failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
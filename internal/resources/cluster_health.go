@@ -3,26 +3,38 @@ package resources
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
 )
 
+// incidentsTimeout bounds the Coordination Engine read of cluster://health,
+// so a slow engine cannot hold up the Kubernetes-derived health
+const incidentsTimeout = 3 * time.Second
+
 // ClusterHealthResource provides the cluster://health MCP resource
 type ClusterHealthResource struct {
-	k8sClient *clients.K8sClient
-	ceClient  *clients.CoordinationEngineClient
-	cache     *cache.MemoryCache
+	k8sClient        *clients.K8sClient
+	ceClient         *clients.CoordinationEngineClient
+	cache            *cache.MemoryCache
+	lastKnown        clients.LastKnownHealthLookup // nil when the health history is disabled
+	incidentsTimeout time.Duration
 }
 
-// NewClusterHealthResource creates a new cluster health resource
-func NewClusterHealthResource(k8sClient *clients.K8sClient, ceClient *clients.CoordinationEngineClient, cache *cache.MemoryCache) *ClusterHealthResource {
+// NewClusterHealthResource creates a new cluster health resource. While the
+// API server cannot be reached it serves lastKnown's health, if any.
+func NewClusterHealthResource(k8sClient *clients.K8sClient, ceClient *clients.CoordinationEngineClient, cache *cache.MemoryCache, lastKnown clients.LastKnownHealthLookup) *ClusterHealthResource {
 	return &ClusterHealthResource{
-		k8sClient: k8sClient,
-		ceClient:  ceClient,
-		cache:     cache,
+		k8sClient:        k8sClient,
+		ceClient:         ceClient,
+		cache:            cache,
+		lastKnown:        lastKnown,
+		incidentsTimeout: incidentsTimeout,
 	}
 }
 
@@ -57,9 +69,21 @@ type ClusterHealthData struct {
 		CPU    ResourceUsageDetail `json:"cpu"`
 		Memory ResourceUsageDetail `json:"memory"`
 	} `json:"resource_usage"`
-	ActiveIssues int      `json:"active_issues"`
-	Warnings     []string `json:"warnings,omitempty"`
-	Message      string   `json:"message"`
+	Operators    *clients.OperatorHealth `json:"operators,omitempty"` // OpenShift only
+	Incidents    *HealthIncidents        `json:"incidents,omitempty"` // Only with a Coordination Engine
+	ActiveIssues int                     `json:"active_issues"`
+	Warnings     []string                `json:"warnings,omitempty"`
+	Message      string                  `json:"message"`
+	// Reasons are the health rules behind a degraded or unhealthy status
+	Reasons []clients.HealthReason `json:"reasons,omitempty"`
+	// Partial is set when some sections could not be read; such data is not cached
+	Partial bool `json:"partial,omitempty"`
+	// LastKnown is the last complete reading of the health history, served
+	// with status "unknown" while the API server cannot be reached
+	LastKnown           *clients.LastKnownHealth `json:"last_known,omitempty"`
+	DataAgeSeconds      float64                  `json:"data_age_seconds,omitempty"`
+	Error               string                   `json:"error,omitempty"`
+	ConsecutiveFailures int                      `json:"consecutive_failures,omitempty"`
 }
 
 // NodeStats represents node statistics
@@ -78,6 +102,16 @@ type PodStats struct {
 	Succeeded int `json:"succeeded"`
 }
 
+// HealthIncidents summarizes the active incidents of the Coordination Engine.
+// The engine is optional: when it cannot be read Available is false, Error
+// says why, and the rest of the health is still returned.
+type HealthIncidents struct {
+	Available bool            `json:"available"`
+	Error     string          `json:"error,omitempty"`
+	Active    int             `json:"active"`
+	Summary   IncidentSummary `json:"summary"`
+}
+
 // ResourceUsageDetail represents resource usage details
 type ResourceUsageDetail struct {
 	Used       string  `json:"used"`
@@ -88,11 +122,18 @@ type ResourceUsageDetail struct {
 // Read retrieves the cluster health resource
 func (r *ClusterHealthResource) Read(ctx context.Context) (string, error) {
 	// Check cache first (10 second TTL as per PRD)
-	cacheKey := "resource:cluster:health"
-	if cached, found := r.cache.Get(cacheKey); found {
-		if data, ok := cached.(string); ok {
-			return data, nil
-		}
+	cacheKey := cache.Key("resource", "cluster", "health")
+	if data, found := cache.GetTyped[string](r.cache, cacheKey); found {
+		return data, nil
+	}
+
+	// The incidents are read from the Coordination Engine alongside the
+	// cluster; only the Kubernetes API is required for the health itself
+	incidents := make(chan *HealthIncidents, 1)
+	if r.ceClient != nil {
+		go func() { incidents <- r.fetchIncidents(ctx) }()
+	} else {
+		incidents <- nil
 	}
 
 	// Fetch from Kubernetes API
@@ -100,9 +141,18 @@ func (r *ClusterHealthResource) Read(ctx context.Context) (string, error) {
 	// so we use K8s API directly for comprehensive health data
 	data, err := r.fetchFromKubernetesAPI(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch cluster health: %w", err)
+		var ok bool
+		if data, ok = r.lastKnownData(err); !ok {
+			return "", fmt.Errorf("failed to fetch cluster health: %w", err)
+		}
+	} else {
+		data.Source = "kubernetes-api"
+	}
+
+	data.Incidents = <-incidents
+	if data.Incidents != nil && !data.Incidents.Available {
+		data.Warnings = append(data.Warnings, "could not read incidents from the Coordination Engine")
 	}
-	data.Source = "kubernetes-api"
 
 	return r.cacheAndReturn(cacheKey, data)
 }
@@ -131,6 +181,9 @@ func (r *ClusterHealthResource) fetchFromKubernetesAPI(ctx context.Context) (Clu
 	data.Pods.Pending = health.Pods.Pending
 	data.Pods.Failed = health.Pods.Failed
 	data.Pods.Succeeded = health.Pods.Succeeded
+	data.Operators = health.Operators
+	data.Partial = health.Partial
+	data.Reasons = health.Reasons
 
 	// Note: Resource usage metrics would come from Prometheus integration (Phase 3)
 	// For now, resource usage fields will be empty
@@ -148,10 +201,85 @@ func (r *ClusterHealthResource) fetchFromKubernetesAPI(ctx context.Context) (Clu
 	if health.Pods.Pending > 0 {
 		data.Warnings = append(data.Warnings, fmt.Sprintf("%d pods are pending", health.Pods.Pending))
 	}
+	if health.Operators != nil && health.Operators.Degraded > 0 {
+		data.Warnings = append(data.Warnings, fmt.Sprintf("%d cluster operators are degraded", health.Operators.Degraded))
+	}
+	if health.Operators != nil && health.Operators.Unavailable > 0 {
+		data.Warnings = append(data.Warnings, fmt.Sprintf("%d cluster operators are unavailable", health.Operators.Unavailable))
+	}
+	for _, section := range health.UnreadSections() {
+		data.Warnings = append(data.Warnings, "could not read "+section)
+	}
 
 	return data, nil
 }
 
+// lastKnownData describes the last known health when err means the API
+// server could not be reached, and reports false when it cannot
+func (r *ClusterHealthResource) lastKnownData(err error) (ClusterHealthData, bool) {
+	if r.lastKnown == nil || !clients.IsConnectivityError(err) {
+		return ClusterHealthData{}, false
+	}
+	lastKnown, ok := r.lastKnown()
+	if !ok {
+		return ClusterHealthData{}, false
+	}
+
+	now := time.Now()
+	reachability := r.k8sClient.APIReachability()
+	outage := "API server unreachable"
+	if reachability.Unreachable() {
+		outage = reachability.Describe(now)
+	}
+	age := now.Sub(lastKnown.Timestamp)
+	return ClusterHealthData{
+		Status:    "unknown",
+		Timestamp: now.UTC().Format(time.RFC3339),
+		Source:    "health-history",
+		Warnings:  []string{outage},
+		Message: fmt.Sprintf("%s; last known health from %s ago: %s, %d/%d nodes ready, %d/%d pods running",
+			outage, age.Truncate(time.Second), lastKnown.Status,
+			lastKnown.NodesReady, lastKnown.NodesTotal, lastKnown.PodsRunning, lastKnown.PodsTotal),
+		LastKnown:           &lastKnown,
+		DataAgeSeconds:      math.Round(age.Seconds()*1000) / 1000,
+		Error:               err.Error(),
+		ConsecutiveFailures: reachability.ConsecutiveFailures,
+	}, true
+}
+
+// fetchIncidents reads the active incidents under their own timeout. Errors
+// are reported in the result rather than returned.
+func (r *ClusterHealthResource) fetchIncidents(ctx context.Context) *HealthIncidents {
+	ctx, cancel := context.WithTimeout(ctx, r.incidentsTimeout)
+	defer cancel()
+
+	resp, err := r.ceClient.ListIncidents(ctx, "active", "all", 100, 0)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return &HealthIncidents{Error: fmt.Sprintf("coordination engine did not respond within %s", r.incidentsTimeout)}
+		}
+		return &HealthIncidents{Error: err.Error()}
+	}
+
+	incidents := &HealthIncidents{Available: true, Active: resp.Summary.Active}
+	if incidents.Active == 0 {
+		incidents.Active = len(resp.Incidents)
+	}
+	for _, incident := range resp.Incidents {
+		switch incident.Severity {
+		case "critical":
+			incidents.Summary.Critical++
+		case "high":
+			incidents.Summary.High++
+		case "medium":
+			incidents.Summary.Medium++
+		case "low":
+			incidents.Summary.Low++
+		}
+	}
+	return incidents
+}
+
 // cacheAndReturn caches the data and returns as JSON string
 func (r *ClusterHealthResource) cacheAndReturn(cacheKey string, data ClusterHealthData) (string, error) {
 	// Marshal to JSON
@@ -162,8 +290,11 @@ func (r *ClusterHealthResource) cacheAndReturn(cacheKey string, data ClusterHeal
 
 	jsonStr := string(jsonData)
 
-	// Cache for 10 seconds (as per PRD)
-	r.cache.SetWithTTL(cacheKey, jsonStr, 10*time.Second)
+	// Cache for 10 seconds (as per PRD); partial data, missing incidents and
+	// the last known health are retried on the next read
+	if !data.Partial && data.LastKnown == nil && (data.Incidents == nil || data.Incidents.Available) {
+		r.cache.SetWithTTL(cacheKey, jsonStr, 10*time.Second)
+	}
 
 	return jsonStr, nil
 }
@@ -189,6 +320,11 @@ func generateHealthMessage(health *clients.ClusterHealth) string {
 			return fmt.Sprintf("Cluster is degraded: %v", issues)
 		}
 		return "Cluster is degraded"
+	case "unknown":
+		if health.Partial {
+			return "Cluster health is unknown, could not read " + strings.Join(health.UnreadSections(), "; ")
+		}
+		return "Cluster health is unknown"
 	default:
 		return "Cluster status: " + health.Status
 	}
@@ -3,12 +3,19 @@ package resources
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
 )
@@ -18,7 +25,7 @@ func TestClusterHealthResource_URI(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	resource := NewClusterHealthResource(k8sClient, nil, memCache)
+	resource := NewClusterHealthResource(k8sClient, nil, memCache, nil)
 	assert.Equal(t, "cluster://health", resource.URI())
 }
 
@@ -27,7 +34,7 @@ func TestClusterHealthResource_Name(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	resource := NewClusterHealthResource(k8sClient, nil, memCache)
+	resource := NewClusterHealthResource(k8sClient, nil, memCache, nil)
 	assert.Equal(t, "Cluster Health", resource.Name())
 }
 
@@ -36,7 +43,7 @@ func TestClusterHealthResource_Description(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	resource := NewClusterHealthResource(k8sClient, nil, memCache)
+	resource := NewClusterHealthResource(k8sClient, nil, memCache, nil)
 	assert.Contains(t, resource.Description(), "Real-time cluster health")
 }
 
@@ -45,7 +52,7 @@ func TestClusterHealthResource_MimeType(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	resource := NewClusterHealthResource(k8sClient, nil, memCache)
+	resource := NewClusterHealthResource(k8sClient, nil, memCache, nil)
 	assert.Equal(t, "application/json", resource.MimeType())
 }
 
@@ -58,7 +65,7 @@ func TestClusterHealthResource_Read(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	resource := NewClusterHealthResource(k8sClient, nil, memCache)
+	resource := NewClusterHealthResource(k8sClient, nil, memCache, nil)
 
 	ctx := context.Background()
 	data, err := resource.Read(ctx)
@@ -103,7 +110,7 @@ func TestClusterHealthResource_CacheUsage(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	resource := NewClusterHealthResource(k8sClient, nil, memCache)
+	resource := NewClusterHealthResource(k8sClient, nil, memCache, nil)
 
 	ctx := context.Background()
 
@@ -139,7 +146,7 @@ func TestClusterHealthResource_CacheExpiration(t *testing.T) {
 	memCache := cache.NewMemoryCache(1 * time.Second)
 	defer memCache.Close()
 
-	resource := NewClusterHealthResource(k8sClient, nil, memCache)
+	resource := NewClusterHealthResource(k8sClient, nil, memCache, nil)
 
 	ctx := context.Background()
 
@@ -241,9 +248,9 @@ func TestClusterHealthResource_WithCoordinationEngine(t *testing.T) {
 	defer memCache.Close()
 
 	// Create CE client (will not be used in actual call since CE isn't running)
-	ceClient := clients.NewCoordinationEngineClient("http://localhost:8080")
+	ceClient := testutil.NewCoordinationEngineClient(t, "http://localhost:8080")
 
-	resource := NewClusterHealthResource(k8sClient, ceClient, memCache)
+	resource := NewClusterHealthResource(k8sClient, ceClient, memCache, nil)
 
 	ctx := context.Background()
 	data, err := resource.Read(ctx)
@@ -256,3 +263,90 @@ func TestClusterHealthResource_WithCoordinationEngine(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "kubernetes-api", healthData.Source)
 }
+
+// newFakeClusterHealthResource reads a fake cluster with one ready node and
+// one running pod, and the Coordination Engine served by ce
+func newFakeClusterHealthResource(t *testing.T, ce http.Handler) *ClusterHealthResource {
+	t.Helper()
+	engine := httptest.NewServer(ce)
+	t.Cleanup(engine.Close)
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	t.Cleanup(memCache.Close)
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		}},
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-0"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+	k8sClient := clients.NewK8sClientWithClientset(fake.NewClientset(node, pod))
+	return NewClusterHealthResource(k8sClient, testutil.NewCoordinationEngineClient(t, engine.URL), memCache, nil)
+}
+
+func readClusterHealth(t *testing.T, resource *ClusterHealthResource) ClusterHealthData {
+	t.Helper()
+	data, err := resource.Read(context.Background())
+	require.NoError(t, err, "a failing Coordination Engine must not fail the read")
+	var health ClusterHealthData
+	require.NoError(t, json.Unmarshal([]byte(data), &health))
+	return health
+}
+
+func TestClusterHealthResource_CoordinationEngineError(t *testing.T) {
+	var calls atomic.Int32
+	resource := newFakeClusterHealthResource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "database unavailable", http.StatusInternalServerError)
+	}))
+
+	health := readClusterHealth(t, resource)
+	assert.Equal(t, "healthy", health.Status, "the health comes from the cluster")
+	assert.Equal(t, 1, health.Nodes.Ready)
+	assert.Equal(t, 1, health.Pods.Running)
+	require.NotNil(t, health.Incidents)
+	assert.False(t, health.Incidents.Available)
+	assert.Contains(t, health.Incidents.Error, "500")
+	assert.Contains(t, health.Warnings, "could not read incidents from the Coordination Engine")
+	assert.False(t, health.Partial, "the cluster sections were all read")
+
+	readClusterHealth(t, resource)
+	assert.Equal(t, int32(2), calls.Load(), "health without incidents is not cached")
+}
+
+func TestClusterHealthResource_CoordinationEngineTimeout(t *testing.T) {
+	release := make(chan struct{})
+	resource := newFakeClusterHealthResource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer close(release)
+	resource.incidentsTimeout = 50 * time.Millisecond
+
+	start := time.Now()
+	health := readClusterHealth(t, resource)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	require.NotNil(t, health.Incidents)
+	assert.False(t, health.Incidents.Available)
+	assert.Equal(t, "coordination engine did not respond within 50ms", health.Incidents.Error)
+	assert.Equal(t, "healthy", health.Status)
+}
+
+func TestClusterHealthResource_CoordinationEngineIncidents(t *testing.T) {
+	resource := newFakeClusterHealthResource(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"incidents": [{"id": "inc-1", "severity": "critical", "status": "active"},
+			{"id": "inc-2", "severity": "low", "status": "active"}], "summary": {"total": 2, "active": 2}}`))
+	}))
+
+	health := readClusterHealth(t, resource)
+	require.NotNil(t, health.Incidents)
+	assert.True(t, health.Incidents.Available)
+	assert.Empty(t, health.Incidents.Error)
+	assert.Equal(t, 2, health.Incidents.Active)
+	assert.Equal(t, 1, health.Incidents.Summary.Critical)
+	assert.Equal(t, 1, health.Incidents.Summary.Low)
+	assert.Empty(t, health.Warnings)
+}
@@ -0,0 +1,319 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// EventSeverity is the bucket an event is sorted into
+type EventSeverity string
+
+const (
+	SeverityCritical EventSeverity = "critical"
+	SeverityWarning  EventSeverity = "warning"
+	SeverityInfo     EventSeverity = "info"
+)
+
+// defaultEventSeverityRules are the event reasons bucketed by reason rather
+// than by event type. Several of them (NodeNotReady, Evicted) are emitted as
+// Normal events even though they mean workloads were disrupted.
+var defaultEventSeverityRules = map[string]EventSeverity{
+	"FailedScheduling":   SeverityCritical,
+	"OOMKilling":         SeverityCritical,
+	"SystemOOM":          SeverityCritical,
+	"NodeNotReady":       SeverityCritical,
+	"Evicted":            SeverityCritical,
+	"FailedAttachVolume": SeverityCritical,
+}
+
+// ParseEventSeverityRules turns "Reason=severity" entries into a rule map
+func ParseEventSeverityRules(entries []string) (map[string]EventSeverity, error) {
+	rules := make(map[string]EventSeverity, len(entries))
+	for _, entry := range entries {
+		reason, severity, ok := strings.Cut(entry, "=")
+		reason = strings.TrimSpace(reason)
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if !ok || reason == "" {
+			return nil, fmt.Errorf("%q must be \"Reason=severity\"", entry)
+		}
+		switch EventSeverity(severity) {
+		case SeverityCritical, SeverityWarning, SeverityInfo:
+			rules[reason] = EventSeverity(severity)
+		default:
+			return nil, fmt.Errorf("%q: severity must be critical, warning or info", entry)
+		}
+	}
+	return rules, nil
+}
+
+// EventsResource provides the cluster://events MCP resource: recent events
+// bucketed by severity, each bucket capped on its own
+type EventsResource struct {
+	k8sClient         *clients.K8sClient
+	cache             *cache.MemoryCache
+	allowedNamespaces func() []string          // Namespaces listed (nil or empty = all), read on every call
+	rules             map[string]EventSeverity // Severity by reason, the defaults with overrides applied
+	caps              map[EventSeverity]int    // Events listed per bucket; the rest are only counted
+}
+
+// NewEventsResource creates a new events resource. Only events in the
+// namespaces allowedNamespaces returns are listed when it returns any; it is
+// called on every read. overrides replace or add to the built-in severity
+// rules. Up to maxPerBucket critical and warning
+// events are listed; info events are listed only when includeInfo is set,
+// and counted otherwise.
+func NewEventsResource(k8sClient *clients.K8sClient, cache *cache.MemoryCache, allowedNamespaces func() []string, overrides map[string]EventSeverity, maxPerBucket int, includeInfo bool) *EventsResource {
+	rules := maps.Clone(defaultEventSeverityRules)
+	maps.Copy(rules, overrides)
+
+	caps := map[EventSeverity]int{SeverityCritical: maxPerBucket, SeverityWarning: maxPerBucket}
+	if includeInfo {
+		caps[SeverityInfo] = maxPerBucket
+	}
+	return &EventsResource{
+		k8sClient:         k8sClient,
+		cache:             cache,
+		allowedNamespaces: allowedNamespaces,
+		rules:             rules,
+		caps:              caps,
+	}
+}
+
+// URI returns the resource URI
+func (r *EventsResource) URI() string {
+	return "cluster://events"
+}
+
+// Name returns the resource name
+func (r *EventsResource) Name() string {
+	return "Cluster Events"
+}
+
+// Description returns the resource description
+func (r *EventsResource) Description() string {
+	return "Recent Kubernetes events in critical, warning and info buckets, newest first. Each bucket is capped on its own and shares its slots fairly between reasons, so a flood of one reason cannot crowd out the others; events over the cap are counted in suppressed. Info events are only counted by default."
+}
+
+// MimeType returns the MIME type of the resource
+func (r *EventsResource) MimeType() string {
+	return "application/json"
+}
+
+// EventsData represents the events resource data
+type EventsData struct {
+	Timestamp string      `json:"timestamp"`
+	Critical  EventBucket `json:"critical"`
+	Warning   EventBucket `json:"warning"`
+	Info      EventBucket `json:"info"`
+}
+
+// EventBucket holds the events of one severity
+type EventBucket struct {
+	Total      int `json:"total"`
+	Suppressed int `json:"suppressed"` // Events over the cap, counted but not listed
+	// SuppressedByReason breaks Suppressed down by event reason
+	SuppressedByReason map[string]int `json:"suppressed_by_reason,omitempty"`
+	Events             []ClusterEvent `json:"events"`
+}
+
+// ClusterEvent is one Kubernetes event
+type ClusterEvent struct {
+	Namespace string    `json:"namespace,omitempty"`
+	Object    string    `json:"object"` // Kind/name of the involved object
+	Type      string    `json:"type"`
+	Reason    string    `json:"reason"`
+	Message   string    `json:"message"`
+	Count     int32     `json:"count"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// eventsSnapshot is the cached event list, bucketed on each read so that
+// callers scoped to namespaces share one list call
+type eventsSnapshot struct {
+	Timestamp string
+	Events    []ClusterEvent
+}
+
+// NamespaceScoped reports that reads are filtered to the caller's namespaces
+// when it is scoped
+func (r *EventsResource) NamespaceScoped() bool {
+	return true
+}
+
+// Read retrieves the events resource
+func (r *EventsResource) Read(ctx context.Context) (string, error) {
+	cacheKey := cache.Key("resource", "cluster", "events")
+	snapshot, err := cache.GetOrSetTyped(ctx, r.cache, cacheKey, r.cache.DefaultTTL(), func() (*eventsSnapshot, error) {
+		eventList, err := r.k8sClient.ListEvents(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+
+		events := make([]ClusterEvent, 0, len(eventList.Items))
+		for _, event := range eventList.Items {
+			events = append(events, toClusterEvent(&event))
+		}
+		return &eventsSnapshot{Timestamp: time.Now().UTC().Format(time.RFC3339), Events: events}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	events := snapshot.Events
+	allowed := allowedNamespaceList(r.allowedNamespaces)
+	if _, scoped := clients.NamespaceScopeFromContext(ctx); scoped || len(allowed) > 0 {
+		events = slices.DeleteFunc(slices.Clone(events), func(event ClusterEvent) bool {
+			return !namespaceAllowed(allowed, event.Namespace) || !clients.InNamespaceScope(ctx, event.Namespace)
+		})
+	}
+	data := bucketEvents(events, r.rules, r.caps)
+	data.Timestamp = snapshot.Timestamp
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal events data: %w", err)
+	}
+	return string(jsonData), nil
+}
+
+// toClusterEvent converts a Kubernetes event
+func toClusterEvent(event *corev1.Event) ClusterEvent {
+	count := event.Count
+	if count == 0 && event.Series != nil {
+		count = event.Series.Count
+	}
+	if count == 0 {
+		count = 1
+	}
+	return ClusterEvent{
+		Namespace: event.Namespace,
+		Object:    event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Message:   event.Message,
+		Count:     count,
+		LastSeen:  eventLastSeen(event),
+	}
+}
+
+// eventLastSeen returns when an event last occurred, whichever of the
+// legacy and events.k8s.io timestamps its emitter set
+func eventLastSeen(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// eventSeverity buckets an event: by its reason when a rule names it, else
+// warning for Warning events and info for the rest
+func eventSeverity(event ClusterEvent, rules map[string]EventSeverity) EventSeverity {
+	if severity, ok := rules[event.Reason]; ok {
+		return severity
+	}
+	if event.Type == corev1.EventTypeWarning {
+		return SeverityWarning
+	}
+	return SeverityInfo
+}
+
+// bucketEvents sorts events into severity buckets and keeps at most
+// caps[severity] of each (a missing cap keeps none). Within a bucket the
+// slots are dealt out round-robin between reasons, newest event first, so
+// a reason flooding the bucket only takes the slots the others leave free.
+func bucketEvents(events []ClusterEvent, rules map[string]EventSeverity, caps map[EventSeverity]int) *EventsData {
+	bySeverity := make(map[EventSeverity][]ClusterEvent)
+	for _, event := range events {
+		severity := eventSeverity(event, rules)
+		bySeverity[severity] = append(bySeverity[severity], event)
+	}
+	return &EventsData{
+		Critical: capBucket(bySeverity[SeverityCritical], caps[SeverityCritical]),
+		Warning:  capBucket(bySeverity[SeverityWarning], caps[SeverityWarning]),
+		Info:     capBucket(bySeverity[SeverityInfo], caps[SeverityInfo]),
+	}
+}
+
+// capBucket keeps up to limit events, shared fairly between reasons
+func capBucket(events []ClusterEvent, limit int) EventBucket {
+	byReason := make(map[string][]ClusterEvent)
+	for _, event := range events {
+		byReason[event.Reason] = append(byReason[event.Reason], event)
+	}
+	reasons := make([]string, 0, len(byReason))
+	for reason, reasonEvents := range byReason {
+		sortEventsNewestFirst(reasonEvents)
+		reasons = append(reasons, reason)
+	}
+	// Reasons with the most recent activity get the first pick in every round
+	sort.Slice(reasons, func(i, j int) bool {
+		a, b := byReason[reasons[i]][0].LastSeen, byReason[reasons[j]][0].LastSeen
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return reasons[i] < reasons[j]
+	})
+
+	bucket := EventBucket{Total: len(events), Events: []ClusterEvent{}}
+	kept := make(map[string]int)
+	for round := 0; len(bucket.Events) < limit; round++ {
+		taken := false
+		for _, reason := range reasons {
+			if round < len(byReason[reason]) && len(bucket.Events) < limit {
+				bucket.Events = append(bucket.Events, byReason[reason][round])
+				kept[reason]++
+				taken = true
+			}
+		}
+		if !taken {
+			break
+		}
+	}
+	sortEventsNewestFirst(bucket.Events)
+
+	bucket.Suppressed = len(events) - len(bucket.Events)
+	if bucket.Suppressed > 0 {
+		bucket.SuppressedByReason = make(map[string]int)
+		for reason, reasonEvents := range byReason {
+			if suppressed := len(reasonEvents) - kept[reason]; suppressed > 0 {
+				bucket.SuppressedByReason[reason] = suppressed
+			}
+		}
+	}
+	return bucket
+}
+
+// sortEventsNewestFirst orders events by last occurrence, newest first, with
+// ties broken by reason, namespace and object for a stable output
+func sortEventsNewestFirst(events []ClusterEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.LastSeen.Equal(b.LastSeen) {
+			return a.LastSeen.After(b.LastSeen)
+		}
+		if a.Reason != b.Reason {
+			return a.Reason < b.Reason
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Object < b.Object
+	})
+}
@@ -0,0 +1,225 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+var eventsEpoch = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// clusterEvents returns n events with the given reason, the i-th one seen
+// i minutes after start
+func clusterEvents(reason, eventType string, n int, start time.Time) []ClusterEvent {
+	events := make([]ClusterEvent, n)
+	for i := range events {
+		events[i] = ClusterEvent{
+			Namespace: "shop",
+			Object:    fmt.Sprintf("Pod/%s-%d", reason, i),
+			Type:      eventType,
+			Reason:    reason,
+			Count:     1,
+			LastSeen:  start.Add(time.Duration(i) * time.Minute),
+		}
+	}
+	return events
+}
+
+func countByReason(events []ClusterEvent) map[string]int {
+	counts := make(map[string]int)
+	for _, event := range events {
+		counts[event.Reason]++
+	}
+	return counts
+}
+
+func TestBucketEvents_Severity(t *testing.T) {
+	events := []ClusterEvent{
+		{Reason: "FailedScheduling", Type: corev1.EventTypeWarning, Object: "Pod/a"},
+		{Reason: "NodeNotReady", Type: corev1.EventTypeNormal, Object: "Node/b"},
+		{Reason: "BackOff", Type: corev1.EventTypeWarning, Object: "Pod/c"},
+		{Reason: "Pulled", Type: corev1.EventTypeNormal, Object: "Pod/d"},
+		{Reason: "ProbeWarning", Type: corev1.EventTypeWarning, Object: "Pod/e"},
+	}
+	rules := map[string]EventSeverity{"FailedScheduling": SeverityCritical, "NodeNotReady": SeverityCritical, "ProbeWarning": SeverityInfo}
+	caps := map[EventSeverity]int{SeverityCritical: 10, SeverityWarning: 10, SeverityInfo: 10}
+
+	data := bucketEvents(events, rules, caps)
+	assert.Equal(t, map[string]int{"FailedScheduling": 1, "NodeNotReady": 1}, countByReason(data.Critical.Events), "rules apply whatever the event type")
+	assert.Equal(t, map[string]int{"BackOff": 1}, countByReason(data.Warning.Events))
+	assert.Equal(t, map[string]int{"Pulled": 1, "ProbeWarning": 1}, countByReason(data.Info.Events), "a rule can demote a noisy Warning reason")
+}
+
+func TestBucketEvents_InfoSuppressedWithoutCap(t *testing.T) {
+	events := clusterEvents("Pulled", corev1.EventTypeNormal, 3, eventsEpoch)
+	data := bucketEvents(events, nil, map[EventSeverity]int{SeverityCritical: 10, SeverityWarning: 10})
+
+	assert.Equal(t, 3, data.Info.Total)
+	assert.Equal(t, 3, data.Info.Suppressed)
+	assert.Equal(t, map[string]int{"Pulled": 3}, data.Info.SuppressedByReason)
+	assert.Empty(t, data.Info.Events)
+}
+
+func TestBucketEvents_FloodDoesNotEvictOtherReasons(t *testing.T) {
+	// A burst of 100 BackOff events newer than everything else
+	var events []ClusterEvent
+	events = append(events, clusterEvents("FailedMount", corev1.EventTypeWarning, 3, eventsEpoch)...)
+	events = append(events, clusterEvents("Unhealthy", corev1.EventTypeWarning, 1, eventsEpoch)...)
+	events = append(events, clusterEvents("BackOff", corev1.EventTypeWarning, 100, eventsEpoch.Add(time.Hour))...)
+
+	data := bucketEvents(events, nil, map[EventSeverity]int{SeverityWarning: 10})
+	bucket := data.Warning
+	assert.Equal(t, 104, bucket.Total)
+	require.Len(t, bucket.Events, 10)
+	assert.Equal(t, map[string]int{"BackOff": 6, "FailedMount": 3, "Unhealthy": 1}, countByReason(bucket.Events),
+		"the quiet reasons keep their events; the flood gets the slots left over")
+	assert.Equal(t, 94, bucket.Suppressed)
+	assert.Equal(t, map[string]int{"BackOff": 94}, bucket.SuppressedByReason)
+
+	// The flood keeps its newest events, and the list is newest first
+	assert.Equal(t, "Pod/BackOff-99", bucket.Events[0].Object)
+	for i := 1; i < len(bucket.Events); i++ {
+		assert.False(t, bucket.Events[i].LastSeen.After(bucket.Events[i-1].LastSeen))
+	}
+}
+
+func TestBucketEvents_SlotsSharedEvenly(t *testing.T) {
+	var events []ClusterEvent
+	for _, reason := range []string{"BackOff", "FailedMount", "Unhealthy"} {
+		events = append(events, clusterEvents(reason, corev1.EventTypeWarning, 20, eventsEpoch)...)
+	}
+
+	data := bucketEvents(events, nil, map[EventSeverity]int{SeverityWarning: 7})
+	counts := countByReason(data.Warning.Events)
+	assert.Len(t, data.Warning.Events, 7)
+	for _, reason := range []string{"BackOff", "FailedMount", "Unhealthy"} {
+		assert.GreaterOrEqual(t, counts[reason], 2, reason)
+		assert.LessOrEqual(t, counts[reason], 3, reason)
+	}
+	assert.Equal(t, 53, data.Warning.Suppressed)
+}
+
+func TestBucketEvents_CapsAreIndependent(t *testing.T) {
+	var events []ClusterEvent
+	events = append(events, clusterEvents("FailedScheduling", corev1.EventTypeWarning, 5, eventsEpoch)...)
+	events = append(events, clusterEvents("BackOff", corev1.EventTypeWarning, 50, eventsEpoch)...)
+
+	data := bucketEvents(events, defaultEventSeverityRules, map[EventSeverity]int{SeverityCritical: 5, SeverityWarning: 5})
+	assert.Len(t, data.Critical.Events, 5, "warnings never take critical slots")
+	assert.Zero(t, data.Critical.Suppressed)
+	assert.Len(t, data.Warning.Events, 5)
+	assert.Equal(t, 45, data.Warning.Suppressed)
+}
+
+func TestBucketEvents_Deterministic(t *testing.T) {
+	var events []ClusterEvent
+	for _, reason := range []string{"BackOff", "FailedMount", "Unhealthy"} {
+		// All seen at the same instant
+		events = append(events, clusterEvents(reason, corev1.EventTypeWarning, 1, eventsEpoch)...)
+	}
+	reversed := []ClusterEvent{events[2], events[1], events[0]}
+	caps := map[EventSeverity]int{SeverityWarning: 2}
+
+	assert.Equal(t, bucketEvents(events, nil, caps), bucketEvents(reversed, nil, caps))
+	assert.Equal(t, map[string]int{"BackOff": 1, "FailedMount": 1}, countByReason(bucketEvents(events, nil, caps).Warning.Events))
+}
+
+func TestParseEventSeverityRules(t *testing.T) {
+	rules, err := ParseEventSeverityRules([]string{"BackOff=critical", " ProbeWarning = Info "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]EventSeverity{"BackOff": SeverityCritical, "ProbeWarning": SeverityInfo}, rules)
+
+	_, err = ParseEventSeverityRules([]string{"BackOff"})
+	assert.ErrorContains(t, err, `must be "Reason=severity"`)
+	_, err = ParseEventSeverityRules([]string{"BackOff=urgent"})
+	assert.ErrorContains(t, err, "severity must be critical, warning or info")
+}
+
+func newEvent(namespace, name, reason, eventType string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: namespace, Name: name},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: name},
+		Reason:         reason,
+		Type:           eventType,
+		Message:        reason + " for " + name,
+		LastTimestamp:  metav1.NewTime(lastSeen),
+	}
+}
+
+func readEvents(t *testing.T, resource *EventsResource) EventsData {
+	t.Helper()
+	data, err := resource.Read(context.Background())
+	require.NoError(t, err)
+	var eventsData EventsData
+	require.NoError(t, json.Unmarshal([]byte(data), &eventsData))
+	return eventsData
+}
+
+func newEventsResource(t *testing.T, allowed []string, overrides map[string]EventSeverity, includeInfo bool, objects ...runtime.Object) *EventsResource {
+	t.Helper()
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	t.Cleanup(memCache.Close)
+	return NewEventsResource(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)), memCache, func() []string { return allowed }, overrides, 25, includeInfo)
+}
+
+func TestEventsResource_Read(t *testing.T) {
+	resource := newEventsResource(t, nil, map[string]EventSeverity{"BackOff": SeverityCritical}, false,
+		newEvent("shop", "api-0", "FailedScheduling", corev1.EventTypeWarning, eventsEpoch),
+		newEvent("shop", "api-1", "BackOff", corev1.EventTypeWarning, eventsEpoch.Add(time.Minute)),
+		newEvent("shop", "api-2", "Unhealthy", corev1.EventTypeWarning, eventsEpoch),
+		newEvent("shop", "api-3", "Pulled", corev1.EventTypeNormal, eventsEpoch),
+	)
+	assert.Equal(t, "cluster://events", resource.URI())
+
+	data := readEvents(t, resource)
+	require.Len(t, data.Critical.Events, 2)
+	assert.Equal(t, "BackOff", data.Critical.Events[0].Reason, "overrides add to the built-in rules; newest first")
+	assert.Equal(t, "Pod/api-1", data.Critical.Events[0].Object)
+	assert.Equal(t, int32(1), data.Critical.Events[0].Count)
+	assert.True(t, data.Critical.Events[0].LastSeen.Equal(eventsEpoch.Add(time.Minute)))
+	assert.Equal(t, map[string]int{"Unhealthy": 1}, countByReason(data.Warning.Events))
+	assert.Equal(t, 1, data.Info.Total)
+	assert.Equal(t, 1, data.Info.Suppressed, "info is only counted by default")
+	assert.NotEmpty(t, data.Timestamp)
+}
+
+func TestEventsResource_IncludeInfoAndAllowedNamespaces(t *testing.T) {
+	resource := newEventsResource(t, []string{"shop"}, nil, true,
+		newEvent("shop", "api-0", "Pulled", corev1.EventTypeNormal, eventsEpoch),
+		newEvent("billing", "db-0", "Pulled", corev1.EventTypeNormal, eventsEpoch),
+	)
+
+	data := readEvents(t, resource)
+	require.Len(t, data.Info.Events, 1)
+	assert.Equal(t, "shop", data.Info.Events[0].Namespace)
+	assert.Zero(t, data.Info.Suppressed)
+}
+
+func TestEventsResource_NamespaceScope(t *testing.T) {
+	resource := newEventsResource(t, nil, nil, false,
+		newEvent("shop", "api-0", "BackOff", corev1.EventTypeWarning, eventsEpoch),
+		newEvent("billing", "db-0", "BackOff", corev1.EventTypeWarning, eventsEpoch),
+	)
+
+	scoped, err := resource.Read(clients.WithNamespaceScope(context.Background(), []string{"shop"}))
+	require.NoError(t, err)
+	var data EventsData
+	require.NoError(t, json.Unmarshal([]byte(scoped), &data))
+	require.Len(t, data.Warning.Events, 1)
+	assert.Equal(t, "shop", data.Warning.Events[0].Namespace)
+
+	// The cached list still serves unscoped callers in full
+	assert.Equal(t, 2, readEvents(t, resource).Warning.Total)
+}
@@ -77,6 +77,11 @@ type IncidentInfo struct {
 	AssignedActions  int    `json:"assigned_actions,omitempty"`
 }
 
+// incidentsCacheKey is where the last incidents read is cached, for 5 seconds (as per PRD)
+var incidentsCacheKey = cache.Key("resource", "cluster", "incidents")
+
+const incidentsCacheTTL = 5 * time.Second
+
 // Read retrieves the incidents resource
 func (r *IncidentsResource) Read(ctx context.Context) (string, error) {
 	// Check if Coordination Engine client is available
@@ -84,82 +89,142 @@ func (r *IncidentsResource) Read(ctx context.Context) (string, error) {
 		return r.getEmptyIncidentsResponse()
 	}
 
-	// Check cache first (5 second TTL as per PRD)
-	cacheKey := "resource:cluster:incidents"
-	if cached, found := r.cache.Get(cacheKey); found {
-		if data, ok := cached.(string); ok {
-			return data, nil
-		}
+	// Check cache first
+	if data, found := cache.GetTyped[string](r.cache, incidentsCacheKey); found {
+		return data, nil
 	}
 
-	// Fetch incidents from Coordination Engine
+	_, jsonStr, err := r.refresh(ctx)
+	return jsonStr, err
+}
+
+// Refresh reads the active incidents from the Coordination Engine and
+// replaces the cached resource with them, for callers following incidents
+// by polling
+func (r *IncidentsResource) Refresh(ctx context.Context) (IncidentsData, error) {
+	if r.ceClient == nil {
+		return IncidentsData{}, fmt.Errorf("coordination engine not enabled")
+	}
+	data, _, err := r.refresh(ctx)
+	return data, err
+}
+
+func (r *IncidentsResource) refresh(ctx context.Context) (IncidentsData, string, error) {
 	// Get active incidents (status=active)
 	resp, err := r.ceClient.ListIncidents(ctx, "active", "all", 100, 0)
 	if err != nil {
-		return "", fmt.Errorf("failed to list incidents: %w", err)
+		return IncidentsData{}, "", fmt.Errorf("failed to list incidents: %w", err)
 	}
 
 	// Build incidents data
 	data := IncidentsData{
-		Timestamp:       time.Now().UTC().Format(time.RFC3339),
-		TotalIncidents:  resp.Summary.Total,
-		ActiveIncidents: len(resp.Incidents),
-		Source:          "coordination-engine",
-		Incidents:       make([]IncidentInfo, 0, len(resp.Incidents)),
+		Timestamp:      time.Now().UTC().Format(time.RFC3339),
+		TotalIncidents: resp.Summary.Total,
+		Source:         "coordination-engine",
+		Incidents:      make([]IncidentInfo, 0, len(resp.Incidents)),
 	}
-
-	// Process each incident
 	for _, incident := range resp.Incidents {
-		incidentInfo := IncidentInfo{
-			ID:               incident.ID,
-			Severity:         incident.Severity,
-			Status:           incident.Status,
-			Type:             incident.ActionType,
-			Description:      incident.Description,
-			AffectedResource: incident.Target,
-			CreatedAt:        incident.CreatedAt,
-		}
+		data.Incidents = append(data.Incidents, newIncidentInfo(incident))
+	}
+	data.summarize()
 
-		// Add optional fields if available
-		if incident.StartedAt != nil {
-			incidentInfo.UpdatedAt = *incident.StartedAt
-		}
-		switch incident.Status {
-		case "running":
-			incidentInfo.RemediationState = "in_progress"
-		case "completed":
-			incidentInfo.RemediationState = "completed"
-		default:
-			incidentInfo.RemediationState = "pending"
-		}
+	jsonStr, err := r.store(data)
+	return data, jsonStr, err
+}
 
-		// Update summary counts
-		switch incident.Severity {
-		case "critical":
-			data.Summary.Critical++
-		case "high":
-			data.Summary.High++
-		case "medium":
-			data.Summary.Medium++
-		case "low":
-			data.Summary.Low++
-		}
+// Apply updates the cached resource with an incident the Coordination Engine
+// pushed: an opened or updated incident is added or replaced, a closed one
+// removed. It reports whether there was a cached resource to update; when
+// there is none, the next read fetches the incidents anew.
+func (r *IncidentsResource) Apply(event *clients.IncidentEvent) bool {
+	cached, found := cache.GetTyped[string](r.cache, incidentsCacheKey)
+	if !found {
+		return false
+	}
+	var data IncidentsData
+	if err := json.Unmarshal([]byte(cached), &data); err != nil {
+		return false
+	}
 
-		data.Incidents = append(data.Incidents, incidentInfo)
+	incidents := make([]IncidentInfo, 0, len(data.Incidents)+1)
+	known := false
+	for _, incident := range data.Incidents {
+		if incident.ID != event.Incident.ID {
+			incidents = append(incidents, incident)
+			continue
+		}
+		known = true
+		if !event.Closes() {
+			incidents = append(incidents, newIncidentInfo(event.Incident))
+		}
+	}
+	if !known && !event.Closes() {
+		incidents = append(incidents, newIncidentInfo(event.Incident))
+		data.TotalIncidents++
 	}
 
-	// Marshal to JSON
+	data.Incidents = incidents
+	data.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	data.summarize()
+	_, err := r.store(data)
+	return err == nil
+}
+
+// store caches data as the resource's JSON
+func (r *IncidentsResource) store(data IncidentsData) (string, error) {
 	jsonData, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal incidents data: %w", err)
 	}
 
 	jsonStr := string(jsonData)
+	r.cache.SetWithTTL(incidentsCacheKey, jsonStr, incidentsCacheTTL)
+	return jsonStr, nil
+}
 
-	// Cache for 5 seconds (as per PRD)
-	r.cache.SetWithTTL(cacheKey, jsonStr, 5*time.Second)
+// newIncidentInfo summarizes a Coordination Engine incident
+func newIncidentInfo(incident clients.Incident) IncidentInfo {
+	info := IncidentInfo{
+		ID:               incident.ID,
+		Severity:         incident.Severity,
+		Status:           incident.Status,
+		Type:             incident.ActionType,
+		Description:      incident.Description,
+		AffectedResource: incident.Target,
+		CreatedAt:        incident.CreatedAt,
+	}
 
-	return jsonStr, nil
+	// Add optional fields if available
+	if incident.StartedAt != nil {
+		info.UpdatedAt = *incident.StartedAt
+	}
+	switch incident.Status {
+	case "running":
+		info.RemediationState = "in_progress"
+	case "completed":
+		info.RemediationState = "completed"
+	default:
+		info.RemediationState = "pending"
+	}
+	return info
+}
+
+// summarize recounts the active incidents and their severities
+func (d *IncidentsData) summarize() {
+	d.ActiveIncidents = len(d.Incidents)
+	d.Summary = IncidentSummary{}
+	for _, incident := range d.Incidents {
+		switch incident.Severity {
+		case "critical":
+			d.Summary.Critical++
+		case "high":
+			d.Summary.High++
+		case "medium":
+			d.Summary.Medium++
+		case "low":
+			d.Summary.Low++
+		}
+	}
 }
 
 // getEmptyIncidentsResponse returns an empty response when CE is not available
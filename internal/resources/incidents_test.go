@@ -3,21 +3,25 @@ package resources
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	testclock "k8s.io/utils/clock/testing"
 
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
-	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
 )
 
 func TestIncidentsResource_URI(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	ceClient := clients.NewCoordinationEngineClient("http://localhost:8080")
+	ceClient := testutil.NewCoordinationEngineClient(t, "http://localhost:8080")
 	resource := NewIncidentsResource(ceClient, memCache)
 	assert.Equal(t, "cluster://incidents", resource.URI())
 }
@@ -26,7 +30,7 @@ func TestIncidentsResource_Name(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	ceClient := clients.NewCoordinationEngineClient("http://localhost:8080")
+	ceClient := testutil.NewCoordinationEngineClient(t, "http://localhost:8080")
 	resource := NewIncidentsResource(ceClient, memCache)
 	assert.Equal(t, "Active Incidents", resource.Name())
 }
@@ -35,7 +39,7 @@ func TestIncidentsResource_Description(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	ceClient := clients.NewCoordinationEngineClient("http://localhost:8080")
+	ceClient := testutil.NewCoordinationEngineClient(t, "http://localhost:8080")
 	resource := NewIncidentsResource(ceClient, memCache)
 	assert.Contains(t, resource.Description(), "active and recent incidents")
 }
@@ -44,7 +48,7 @@ func TestIncidentsResource_MimeType(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	ceClient := clients.NewCoordinationEngineClient("http://localhost:8080")
+	ceClient := testutil.NewCoordinationEngineClient(t, "http://localhost:8080")
 	resource := NewIncidentsResource(ceClient, memCache)
 	assert.Equal(t, "application/json", resource.MimeType())
 }
@@ -85,7 +89,7 @@ func TestIncidentsResource_Read_WithCEClient_Unavailable(t *testing.T) {
 	defer memCache.Close()
 
 	// Create CE client pointing to non-existent server
-	ceClient := clients.NewCoordinationEngineClient("http://localhost:9999")
+	ceClient := testutil.NewCoordinationEngineClient(t, "http://localhost:9999")
 	resource := NewIncidentsResource(ceClient, memCache)
 
 	ctx := context.Background()
@@ -185,10 +189,18 @@ func TestIncidentInfo_Structure(t *testing.T) {
 }
 
 func TestIncidentsResource_CacheTTL(t *testing.T) {
-	memCache := cache.NewMemoryCache(1 * time.Second)
+	var calls atomic.Int32
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"incidents": [], "summary": {"total": 0}}`))
+	}))
+	t.Cleanup(engine.Close)
+
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	memCache := cache.NewMemoryCacheWithClock(30*time.Second, clk)
 	defer memCache.Close()
 
-	resource := NewIncidentsResource(nil, memCache)
+	resource := NewIncidentsResource(testutil.NewCoordinationEngineClient(t, engine.URL), memCache)
 
 	ctx := context.Background()
 
@@ -196,29 +208,18 @@ func TestIncidentsResource_CacheTTL(t *testing.T) {
 	data1, err := resource.Read(ctx)
 	require.NoError(t, err)
 
-	// Immediate second read - should come from cache
+	// Second read within the TTL - should come from cache
+	clk.Step(incidentsCacheTTL - time.Second)
 	data2, err := resource.Read(ctx)
 	require.NoError(t, err)
 	assert.Equal(t, data1, data2, "Should return cached data")
-
-	// Parse timestamps to verify they're the same
-	var incidents1, incidents2 IncidentsData
-	require.NoError(t, json.Unmarshal([]byte(data1), &incidents1))
-	require.NoError(t, json.Unmarshal([]byte(data2), &incidents2))
-	assert.Equal(t, incidents1.Timestamp, incidents2.Timestamp, "Timestamps should match (cached)")
-
-	// Wait for cache to expire
-	time.Sleep(2 * time.Second)
+	assert.Equal(t, int32(1), calls.Load(), "Coordination Engine asked once")
 
 	// Third read - cache expired, should get new data
-	data3, err := resource.Read(ctx)
+	clk.Step(2 * time.Second)
+	_, err = resource.Read(ctx)
 	require.NoError(t, err)
-
-	var incidents3 IncidentsData
-	require.NoError(t, json.Unmarshal([]byte(data3), &incidents3))
-
-	// Timestamps should be different (new fetch)
-	assert.NotEqual(t, incidents1.Timestamp, incidents3.Timestamp, "New timestamp after cache expiration")
+	assert.Equal(t, int32(2), calls.Load(), "New fetch after cache expiration")
 }
 
 func TestIncidentsData_Marshaling(t *testing.T) {
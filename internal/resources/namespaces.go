@@ -0,0 +1,335 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// quotaPressureThreshold is the share of a quota's hard limit at which a
+// namespace is flagged as under quota pressure
+const quotaPressureThreshold = 0.9
+
+// NamespacesResource provides the cluster://namespaces MCP resource: every
+// namespace with a compact health rollup, the index to scan before drilling
+// into one namespace
+type NamespacesResource struct {
+	k8sClient         *clients.K8sClient
+	cache             *cache.MemoryCache
+	allowedNamespaces func() []string // Namespaces listed (nil or empty = all), read on every call
+	maxEntries        int             // Namespaces listed in full before healthy ones are only counted
+}
+
+// NewNamespacesResource creates a new namespaces resource. Only the
+// namespaces allowedNamespaces returns are listed when it returns any; it is
+// called on every read, so a reloaded allowlist applies at once. Above
+// maxEntries namespaces, healthy ones are left out and counted instead.
+func NewNamespacesResource(k8sClient *clients.K8sClient, cache *cache.MemoryCache, allowedNamespaces func() []string, maxEntries int) *NamespacesResource {
+	return &NamespacesResource{
+		k8sClient:         k8sClient,
+		cache:             cache,
+		allowedNamespaces: allowedNamespaces,
+		maxEntries:        maxEntries,
+	}
+}
+
+// URI returns the resource URI
+func (r *NamespacesResource) URI() string {
+	return "cluster://namespaces"
+}
+
+// Name returns the resource name
+func (r *NamespacesResource) Name() string {
+	return "Cluster Namespaces"
+}
+
+// Description returns the resource description
+func (r *NamespacesResource) Description() string {
+	return "Every namespace with a health rollup: phase, pod counts by phase, failing workloads, quota pressure, and age. On OpenShift, each project's display name, description and requester are included. Unhealthy namespaces are always listed; on large clusters healthy ones may only be counted."
+}
+
+// MimeType returns the MIME type of the resource
+func (r *NamespacesResource) MimeType() string {
+	return "application/json"
+}
+
+// NamespacesData represents the namespaces resource data
+type NamespacesData struct {
+	Timestamp           string             `json:"timestamp"`
+	TotalNamespaces     int                `json:"total_namespaces"`
+	UnhealthyNamespaces int                `json:"unhealthy_namespaces"`
+	Namespaces          []NamespaceSummary `json:"namespaces"`
+	// OmittedHealthy is how many healthy namespaces were left out to stay within the size cap
+	OmittedHealthy int `json:"omitted_healthy,omitempty"`
+	// Warnings lists inputs that could not be read, e.g. a workload kind the server may not list
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// NamespaceSummary is the health rollup of one namespace
+type NamespaceSummary struct {
+	Name             string            `json:"name"`
+	Phase            string            `json:"phase"`
+	Healthy          bool              `json:"healthy"`
+	Pods             NamespacePodCount `json:"pods"`
+	FailingWorkloads int               `json:"failing_workloads"` // Deployments, StatefulSets and DaemonSets short of ready replicas
+	QuotaPressure    bool              `json:"quota_pressure"`    // A ResourceQuota is at 90% or more of a hard limit
+	Age              string            `json:"age"`
+
+	// Project metadata on OpenShift, empty for plain namespaces. Label is
+	// "Display Name (namespace)", set when the project has a display name.
+	clients.ProjectInfo
+	Label string `json:"label,omitempty"`
+}
+
+// NamespacePodCount counts a namespace's pods by phase
+type NamespacePodCount struct {
+	Total     int `json:"total"`
+	Running   int `json:"running"`
+	Pending   int `json:"pending"`
+	Failed    int `json:"failed"`
+	Succeeded int `json:"succeeded"`
+	Unknown   int `json:"unknown"`
+}
+
+// NamespaceScoped reports that reads are filtered to the caller's namespaces
+// when it is scoped
+func (r *NamespacesResource) NamespaceScoped() bool {
+	return true
+}
+
+// Read retrieves the namespaces resource. Every namespace is cached; the
+// allowlist, the caller's scope and the size cap are applied on each read.
+func (r *NamespacesResource) Read(ctx context.Context) (string, error) {
+	cacheKey := cache.Key("resource", "cluster", "namespaces")
+	cached, err := cache.GetOrSetTyped(ctx, r.cache, cacheKey, r.cache.DefaultTTL(), func() (*NamespacesData, error) {
+		return r.listNamespaces(ctx)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	data := *cached
+	allowed := allowedNamespaceList(r.allowedNamespaces)
+	data.Namespaces = slices.DeleteFunc(slices.Clone(cached.Namespaces), func(summary NamespaceSummary) bool {
+		return !namespaceAllowed(allowed, summary.Name) || !clients.InNamespaceScope(ctx, summary.Name)
+	})
+	data.TotalNamespaces = len(data.Namespaces)
+	for _, summary := range data.Namespaces {
+		if !summary.Healthy {
+			data.UnhealthyNamespaces++
+		}
+	}
+	data.Namespaces, data.OmittedHealthy = capNamespaces(data.Namespaces, r.maxEntries)
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal namespaces data: %w", err)
+	}
+	return string(jsonData), nil
+}
+
+// allowedNamespaceList returns the current allowlist of a provider, nil for all
+func allowedNamespaceList(allowedNamespaces func() []string) []string {
+	if allowedNamespaces == nil {
+		return nil
+	}
+	return allowedNamespaces()
+}
+
+// namespaceAllowed reports whether allowed, an allowlist where empty means
+// all, admits namespace
+func namespaceAllowed(allowed []string, namespace string) bool {
+	return len(allowed) == 0 || slices.Contains(allowed, namespace)
+}
+
+// listNamespaces builds the namespaces resource data from cluster-wide
+// lists, with every namespace and no totals
+func (r *NamespacesResource) listNamespaces(ctx context.Context) (*NamespacesData, error) {
+	namespaceList, err := r.k8sClient.ListNamespaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	podList, err := r.k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	summaries := make(map[string]*NamespaceSummary)
+	for _, namespace := range namespaceList.Items {
+		summaries[namespace.Name] = &NamespaceSummary{
+			Name:  namespace.Name,
+			Phase: string(namespace.Status.Phase),
+			Age:   formatAge(namespace.CreationTimestamp.Time),
+		}
+	}
+
+	for _, pod := range podList.Items {
+		summary, ok := summaries[pod.Namespace]
+		if !ok {
+			continue
+		}
+		summary.Pods.Total++
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			summary.Pods.Running++
+		case corev1.PodPending:
+			summary.Pods.Pending++
+		case corev1.PodFailed:
+			summary.Pods.Failed++
+		case corev1.PodSucceeded:
+			summary.Pods.Succeeded++
+		default:
+			summary.Pods.Unknown++
+		}
+	}
+
+	data := &NamespacesData{Timestamp: time.Now().UTC().Format(time.RFC3339)}
+	data.Warnings = r.countFailingWorkloads(ctx, summaries)
+	if err := r.addProjects(ctx, summaries); err != nil {
+		data.Warnings = append(data.Warnings, err.Error())
+	}
+	if err := r.flagQuotaPressure(ctx, summaries); err != nil {
+		data.Warnings = append(data.Warnings, err.Error())
+	}
+
+	data.Namespaces = make([]NamespaceSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		summary.Healthy = summary.Phase == string(corev1.NamespaceActive) &&
+			summary.Pods.Failed == 0 && summary.FailingWorkloads == 0 && !summary.QuotaPressure
+		data.Namespaces = append(data.Namespaces, *summary)
+	}
+	return data, nil
+}
+
+// capNamespaces orders namespaces unhealthy first, then by name, and keeps at
+// most limit of them. Unhealthy namespaces are always kept, even beyond limit;
+// only healthy ones are dropped, and their number returned.
+func capNamespaces(namespaces []NamespaceSummary, limit int) ([]NamespaceSummary, int) {
+	sort.Slice(namespaces, func(i, j int) bool {
+		if namespaces[i].Healthy != namespaces[j].Healthy {
+			return !namespaces[i].Healthy
+		}
+		return namespaces[i].Name < namespaces[j].Name
+	})
+	if limit <= 0 || len(namespaces) <= limit {
+		return namespaces, 0
+	}
+
+	keep := limit
+	for keep < len(namespaces) && !namespaces[keep].Healthy {
+		keep++
+	}
+	return namespaces[:keep], len(namespaces) - keep
+}
+
+// countFailingWorkloads counts the Deployments, StatefulSets and DaemonSets
+// short of ready replicas per namespace. Lists that fail are returned as
+// warnings rather than failing the whole resource.
+func (r *NamespacesResource) countFailingWorkloads(ctx context.Context, summaries map[string]*NamespaceSummary) []string {
+	apps := r.k8sClient.Clientset().AppsV1()
+	count := func(namespace string, failing bool) {
+		if summary, ok := summaries[namespace]; ok && failing {
+			summary.FailingWorkloads++
+		}
+	}
+
+	var warnings []string
+	if deployments, err := apps.Deployments("").List(ctx, metav1.ListOptions{}); err != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to list deployments: %v", err))
+	} else {
+		for _, deployment := range deployments.Items {
+			count(deployment.Namespace, deploymentFailing(&deployment))
+		}
+	}
+	if statefulSets, err := apps.StatefulSets("").List(ctx, metav1.ListOptions{}); err != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to list statefulsets: %v", err))
+	} else {
+		for _, statefulSet := range statefulSets.Items {
+			count(statefulSet.Namespace, statefulSet.Status.ReadyReplicas < replicasOf(statefulSet.Spec.Replicas))
+		}
+	}
+	if daemonSets, err := apps.DaemonSets("").List(ctx, metav1.ListOptions{}); err != nil {
+		warnings = append(warnings, fmt.Sprintf("failed to list daemonsets: %v", err))
+	} else {
+		for _, daemonSet := range daemonSets.Items {
+			count(daemonSet.Namespace, daemonSet.Status.NumberUnavailable > 0)
+		}
+	}
+	return warnings
+}
+
+// deploymentFailing reports whether a Deployment has fewer available replicas
+// than desired, other than during a rollout within its progress deadline
+func deploymentFailing(deployment *appsv1.Deployment) bool {
+	if deployment.Status.AvailableReplicas >= replicasOf(deployment.Spec.Replicas) {
+		return false
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionTrue && condition.Reason == "ReplicaSetUpdated" {
+			return false
+		}
+	}
+	return true
+}
+
+// replicasOf returns the desired replica count, which defaults to 1
+func replicasOf(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// addProjects adds the OpenShift Project metadata of each namespace; on
+// vanilla clusters there is none to add
+func (r *NamespacesResource) addProjects(ctx context.Context, summaries map[string]*NamespaceSummary) error {
+	projects, err := r.k8sClient.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+	for name, project := range projects {
+		if summary, ok := summaries[name]; ok {
+			summary.ProjectInfo = project
+			if label := project.Label(name); label != name {
+				summary.Label = label
+			}
+		}
+	}
+	return nil
+}
+
+// flagQuotaPressure marks namespaces with a ResourceQuota at or above
+// quotaPressureThreshold of any hard limit
+func (r *NamespacesResource) flagQuotaPressure(ctx context.Context, summaries map[string]*NamespaceSummary) error {
+	quotas, err := r.k8sClient.Clientset().CoreV1().ResourceQuotas("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list resourcequotas: %w", err)
+	}
+	for _, quota := range quotas.Items {
+		summary, ok := summaries[quota.Namespace]
+		if !ok {
+			continue
+		}
+		for name, hard := range quota.Status.Hard {
+			used, ok := quota.Status.Used[name]
+			if !ok || hard.IsZero() {
+				continue
+			}
+			if used.AsApproximateFloat64() >= quotaPressureThreshold*hard.AsApproximateFloat64() {
+				summary.QuotaPressure = true
+				break
+			}
+		}
+	}
+	return nil
+}
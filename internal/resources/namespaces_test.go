@@ -0,0 +1,275 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func newNamespace(name string, phase corev1.NamespacePhase) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(time.Now().Add(-48 * time.Hour))},
+		Status:     corev1.NamespaceStatus{Phase: phase},
+	}
+}
+
+func newPhasePod(namespace, name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func readNamespaces(t *testing.T, resource *NamespacesResource) NamespacesData {
+	t.Helper()
+	data, err := resource.Read(context.Background())
+	require.NoError(t, err)
+	var namespacesData NamespacesData
+	require.NoError(t, json.Unmarshal([]byte(data), &namespacesData))
+	return namespacesData
+}
+
+func newNamespacesResource(t *testing.T, allowed []string, maxEntries int, objects ...runtime.Object) *NamespacesResource {
+	t.Helper()
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	t.Cleanup(memCache.Close)
+	return NewNamespacesResource(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)), memCache, func() []string { return allowed }, maxEntries)
+}
+
+func TestNamespacesResource_Metadata(t *testing.T) {
+	resource := newNamespacesResource(t, nil, 200)
+	assert.Equal(t, "cluster://namespaces", resource.URI())
+	assert.Equal(t, "Cluster Namespaces", resource.Name())
+	assert.Contains(t, resource.Description(), "health rollup")
+	assert.Equal(t, "application/json", resource.MimeType())
+}
+
+func TestNamespacesResource_Read(t *testing.T) {
+	three := int32(3)
+	namespaces := newNamespacesResource(t, nil, 200,
+		newNamespace("payments", corev1.NamespaceActive),
+		newNamespace("web", corev1.NamespaceActive),
+		newNamespace("batch", corev1.NamespaceActive),
+		newNamespace("quota", corev1.NamespaceActive),
+		newNamespace("old-project", corev1.NamespaceTerminating),
+		newPhasePod("payments", "api-1", corev1.PodRunning),
+		newPhasePod("payments", "api-2", corev1.PodPending),
+		newPhasePod("web", "frontend-1", corev1.PodRunning),
+		newPhasePod("batch", "job-1", corev1.PodSucceeded),
+		newPhasePod("batch", "job-2", corev1.PodFailed),
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "api"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &three},
+			Status:     appsv1.DeploymentStatus{AvailableReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "frontend"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &three},
+			Status: appsv1.DeploymentStatus{AvailableReplicas: 2, Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "ReplicaSetUpdated"},
+			}},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "db"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &three},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: 2},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "web", Name: "agent"},
+			Status:     appsv1.DaemonSetStatus{NumberUnavailable: 0},
+		},
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "quota", Name: "compute"},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+				Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("9")},
+			},
+		},
+	)
+
+	data := readNamespaces(t, namespaces)
+	assert.Equal(t, 5, data.TotalNamespaces)
+	assert.Equal(t, 4, data.UnhealthyNamespaces)
+	assert.Zero(t, data.OmittedHealthy)
+	assert.Empty(t, data.Warnings)
+
+	// Unhealthy first, each group by name
+	names := make([]string, 0, len(data.Namespaces))
+	for _, namespace := range data.Namespaces {
+		names = append(names, namespace.Name)
+	}
+	assert.Equal(t, []string{"batch", "old-project", "payments", "quota", "web"}, names)
+
+	batch := data.Namespaces[0]
+	assert.False(t, batch.Healthy, "a failed pod")
+	assert.Equal(t, NamespacePodCount{Total: 2, Succeeded: 1, Failed: 1}, batch.Pods)
+	assert.Equal(t, "2d0h", batch.Age)
+
+	assert.Equal(t, "Terminating", data.Namespaces[1].Phase)
+	assert.False(t, data.Namespaces[1].Healthy)
+
+	payments := data.Namespaces[2]
+	assert.Equal(t, 2, payments.FailingWorkloads, "the api deployment and db statefulset")
+	assert.Equal(t, NamespacePodCount{Total: 2, Running: 1, Pending: 1}, payments.Pods)
+
+	assert.True(t, data.Namespaces[3].QuotaPressure)
+
+	web := data.Namespaces[4]
+	assert.True(t, web.Healthy, "a rollout in progress is not failing")
+	assert.Zero(t, web.FailingWorkloads)
+}
+
+func newProject(name, displayName, requester string) *unstructured.Unstructured {
+	project := &unstructured.Unstructured{}
+	project.SetAPIVersion("project.openshift.io/v1")
+	project.SetKind("Project")
+	project.SetName(name)
+	project.SetAnnotations(map[string]string{
+		clients.ProjectDisplayNameAnnotation: displayName,
+		clients.ProjectRequesterAnnotation:   requester,
+	})
+	return project
+}
+
+func TestNamespacesResource_OpenShiftProjects(t *testing.T) {
+	clientset := fake.NewClientset(newNamespace("payments-prod", corev1.NamespaceActive), newNamespace("scratch", corev1.NamespaceActive))
+	clientset.Resources = []*metav1.APIResourceList{{GroupVersion: "project.openshift.io/v1"}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Group: "project.openshift.io", Version: "v1", Resource: "projects"}: "ProjectList"},
+		newProject("payments-prod", "Payments Team", "alice"),
+		newProject("scratch", "", "bob"),
+	)
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	defer memCache.Close()
+	resource := NewNamespacesResource(clients.NewK8sClientWithClients(clientset, dynamicClient, nil), memCache, nil, 200)
+
+	data := readNamespaces(t, resource)
+	require.Len(t, data.Namespaces, 2)
+	assert.Empty(t, data.Warnings)
+	payments := data.Namespaces[0]
+	assert.Equal(t, "Payments Team (payments-prod)", payments.Label)
+	assert.Equal(t, "Payments Team", payments.DisplayName)
+	assert.Equal(t, "alice", payments.Requester)
+	scratch := data.Namespaces[1]
+	assert.Empty(t, scratch.Label, "no display name to show")
+	assert.Equal(t, "bob", scratch.Requester)
+
+	// Vanilla clusters serve no projects: plain namespaces, without a warning
+	plain := readNamespaces(t, newNamespacesResource(t, nil, 200, newNamespace("payments-prod", corev1.NamespaceActive)))
+	assert.Empty(t, plain.Warnings)
+	assert.Equal(t, clients.ProjectInfo{}, plain.Namespaces[0].ProjectInfo)
+}
+
+func TestNamespacesResource_AllowedNamespaces(t *testing.T) {
+	resource := newNamespacesResource(t, []string{"web"}, 200,
+		newNamespace("web", corev1.NamespaceActive),
+		newNamespace("kube-system", corev1.NamespaceActive),
+		newPhasePod("kube-system", "etcd", corev1.PodFailed),
+	)
+
+	data := readNamespaces(t, resource)
+	assert.Equal(t, 1, data.TotalNamespaces)
+	require.Len(t, data.Namespaces, 1)
+	assert.Equal(t, "web", data.Namespaces[0].Name)
+	assert.Zero(t, data.UnhealthyNamespaces)
+}
+
+func TestNamespacesResource_AllowlistReadPerCall(t *testing.T) {
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	t.Cleanup(memCache.Close)
+	allowed := []string{"web"}
+	clientset := fake.NewClientset(newNamespace("web", corev1.NamespaceActive), newNamespace("kube-system", corev1.NamespaceActive))
+	resource := NewNamespacesResource(clients.NewK8sClientWithClientset(clientset), memCache, func() []string { return allowed }, 200)
+	assert.Equal(t, 1, readNamespaces(t, resource).TotalNamespaces)
+
+	// A reloaded allowlist applies to the cached listing too
+	allowed = nil
+	assert.Equal(t, 2, readNamespaces(t, resource).TotalNamespaces)
+}
+
+func TestNamespacesResource_CapKeepsUnhealthy(t *testing.T) {
+	resource := newNamespacesResource(t, nil, 2,
+		newNamespace("a", corev1.NamespaceActive),
+		newNamespace("b", corev1.NamespaceActive),
+		newNamespace("c", corev1.NamespaceActive),
+		newNamespace("x", corev1.NamespaceTerminating),
+		newNamespace("y", corev1.NamespaceTerminating),
+		newNamespace("z", corev1.NamespaceTerminating),
+	)
+
+	data := readNamespaces(t, resource)
+	assert.Equal(t, 6, data.TotalNamespaces)
+	assert.Equal(t, 3, data.UnhealthyNamespaces)
+	assert.Equal(t, 3, data.OmittedHealthy)
+	require.Len(t, data.Namespaces, 3, "all unhealthy namespaces even beyond the cap")
+	for _, namespace := range data.Namespaces {
+		assert.False(t, namespace.Healthy, namespace.Name)
+	}
+}
+
+func TestCapNamespaces(t *testing.T) {
+	namespaces := []NamespaceSummary{
+		{Name: "c", Healthy: true},
+		{Name: "b", Healthy: false},
+		{Name: "a", Healthy: true},
+	}
+
+	kept, omitted := capNamespaces(namespaces, 2)
+	assert.Equal(t, []NamespaceSummary{{Name: "b"}, {Name: "a", Healthy: true}}, kept)
+	assert.Equal(t, 1, omitted)
+
+	kept, omitted = capNamespaces(namespaces, 3)
+	assert.Len(t, kept, 3)
+	assert.Zero(t, omitted)
+}
+
+func TestNamespacesResource_Cached(t *testing.T) {
+	clientset := fake.NewClientset(newNamespace("web", corev1.NamespaceActive))
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	defer memCache.Close()
+	resource := NewNamespacesResource(clients.NewK8sClientWithClientset(clientset), memCache, nil, 200)
+
+	first := readNamespaces(t, resource)
+	_, err := clientset.CoreV1().Namespaces().Create(context.Background(), newNamespace("api", corev1.NamespaceActive), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	cached := readNamespaces(t, resource)
+	assert.Equal(t, first, cached, "served from the cache within the TTL")
+}
+
+func TestNamespacesResource_NamespaceScope(t *testing.T) {
+	resource := newNamespacesResource(t, nil, 200,
+		newNamespace("web", corev1.NamespaceActive),
+		newNamespace("kube-system", corev1.NamespaceActive),
+		newPhasePod("kube-system", "etcd", corev1.PodFailed),
+	)
+
+	scoped, err := resource.Read(clients.WithNamespaceScope(context.Background(), []string{"web"}))
+	require.NoError(t, err)
+	var data NamespacesData
+	require.NoError(t, json.Unmarshal([]byte(scoped), &data))
+	assert.Equal(t, 1, data.TotalNamespaces)
+	assert.Zero(t, data.UnhealthyNamespaces, "totals only count the caller's namespaces")
+	require.Len(t, data.Namespaces, 1)
+	assert.Equal(t, "web", data.Namespaces[0].Name)
+
+	all := readNamespaces(t, resource)
+	assert.Equal(t, 2, all.TotalNamespaces)
+	assert.Equal(t, 1, all.UnhealthyNamespaces)
+}
@@ -4,9 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
@@ -38,7 +44,7 @@ func (r *NodesResource) Name() string {
 
 // Description returns the resource description
 func (r *NodesResource) Description() string {
-	return "Information about all nodes in the cluster including status, roles, capacity, and resource allocations"
+	return "Information about all nodes in the cluster including status, pressure conditions, cordon state, taints, roles, capacity, pod counts, and current utilization"
 }
 
 // MimeType returns the MIME type of the resource
@@ -48,23 +54,56 @@ func (r *NodesResource) MimeType() string {
 
 // NodesData represents the nodes resource data
 type NodesData struct {
-	Timestamp  string     `json:"timestamp"`
-	TotalNodes int        `json:"total_nodes"`
-	ReadyNodes int        `json:"ready_nodes"`
-	Nodes      []NodeInfo `json:"nodes"`
+	Timestamp  string              `json:"timestamp"`
+	TotalNodes int                 `json:"total_nodes"`
+	ReadyNodes int                 `json:"ready_nodes"`
+	Filter     *clients.NodeFilter `json:"filter,omitempty"` // Set when limited to some nodes; the counts only cover those
+	Summary    NodesSummary        `json:"summary"`          // Ahead of the nodes, so readers can stop early
+	Nodes      []NodeInfo          `json:"nodes"`
+	// DataAgeSeconds is how long ago the nodes were listed; only reported to ReadWithMaxAge
+	DataAgeSeconds *float64 `json:"data_age_seconds,omitempty"`
+}
+
+// NodesSummary counts the nodes that need attention
+type NodesSummary struct {
+	Ready         string   `json:"ready"` // ready/total
+	Cordoned      int      `json:"cordoned"`
+	UnderPressure int      `json:"under_pressure"`
+	Attention     []string `json:"attention,omitempty"` // Names of the nodes that are not ready, cordoned, or under pressure
+	// UtilizationAvailable is false when metrics.k8s.io could not be read
+	UtilizationAvailable bool `json:"utilization_available"`
 }
 
 // NodeInfo represents information about a single node
 type NodeInfo struct {
-	Name        string            `json:"name"`
-	Status      string            `json:"status"`
-	Roles       []string          `json:"roles"`
-	Version     string            `json:"version"`
-	Capacity    NodeResources     `json:"capacity"`
-	Allocatable NodeResources     `json:"allocatable"`
-	Conditions  []NodeCondition   `json:"conditions,omitempty"`
-	Labels      map[string]string `json:"labels,omitempty"`
-	Age         string            `json:"age"`
+	Name          string            `json:"name"`
+	Status        string            `json:"status"`
+	Unschedulable bool              `json:"unschedulable"` // Cordoned
+	Pressure      []string          `json:"pressure,omitempty"`
+	Roles         []string          `json:"roles"`
+	Version       string            `json:"version"` // Kubelet version
+	Capacity      NodeResources     `json:"capacity"`
+	Allocatable   NodeResources     `json:"allocatable"`
+	PodCount      int               `json:"pod_count"` // Pods scheduled on the node that have not terminated
+	MaxPods       int64             `json:"max_pods"`
+	Utilization   *NodeUtilization  `json:"utilization,omitempty"`
+	Taints        []NodeTaint       `json:"taints,omitempty"`
+	Conditions    []NodeCondition   `json:"conditions,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Age           string            `json:"age"`
+}
+
+// NodeUtilization is the current usage of a node as a percentage of its allocatable resources
+type NodeUtilization struct {
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryPercent float64 `json:"memory_percent"`
+}
+
+// NodeTaint represents a node taint
+type NodeTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
 }
 
 // NodeResources represents node resource information
@@ -84,35 +123,101 @@ type NodeCondition struct {
 
 // Read retrieves the nodes resource
 func (r *NodesResource) Read(ctx context.Context) (string, error) {
+	return r.ReadWithMaxAge(ctx, 0)
+}
+
+// ReadWithMaxAge retrieves the nodes resource, listing the nodes again if the
+// cached list is older than maxAge (maxAge <= 0 serves anything within the
+// cache TTL). With a maxAge, the result reports its data_age_seconds.
+func (r *NodesResource) ReadWithMaxAge(ctx context.Context, maxAge time.Duration) (string, error) {
+	return r.ReadFiltered(ctx, maxAge, clients.NodeFilter{})
+}
+
+// ReadFiltered is ReadWithMaxAge limited to the nodes filter selects. Each
+// filter's listing is cached apart from the others.
+func (r *NodesResource) ReadFiltered(ctx context.Context, maxAge time.Duration, filter clients.NodeFilter) (string, error) {
 	// Check cache first (30 second TTL as per PRD)
-	cacheKey := "resource:cluster:nodes"
-	if cached, found := r.cache.Get(cacheKey); found {
-		if data, ok := cached.(string); ok {
-			return data, nil
-		}
+	cacheKey := cache.Key("resource", "cluster", "nodes")
+	if !filter.IsZero() {
+		cacheKey = cache.Key("resource", "cluster", "nodes", cache.Hash(filter.String()))
+	}
+	data, age, err := cache.GetOrSetTypedWithMaxAge(ctx, r.cache, cacheKey, 30*time.Second, maxAge, func() (*NodesData, error) {
+		return r.listNodes(ctx, filter)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if maxAge > 0 {
+		// A copy: the cached data is shared with concurrent readers
+		withAge := *data
+		ageSeconds := math.Round(age.Seconds()*1000) / 1000
+		withAge.DataAgeSeconds = &ageSeconds
+		data = &withAge
+	}
+
+	// Marshal to JSON
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal nodes data: %w", err)
 	}
 
-	// Fetch nodes from Kubernetes API
-	nodeList, err := r.k8sClient.ListNodes(ctx)
+	return string(jsonData), nil
+}
+
+// listNodes builds the nodes resource data from the Kubernetes API
+func (r *NodesResource) listNodes(ctx context.Context, filter clients.NodeFilter) (*NodesData, error) {
+	nodeList, err := r.k8sClient.ListNodesMatching(ctx, filter)
 	if err != nil {
-		return "", fmt.Errorf("failed to list nodes: %w", err)
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	podList, err := r.k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	podsByNode := make(map[string]int)
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != "" && pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			podsByNode[pod.Spec.NodeName]++
+		}
 	}
 
+	// Utilization is optional: without metrics-server the nodes are listed without it
+	usage, err := r.listNodeUsage(ctx)
+
 	// Build nodes data
 	data := NodesData{
 		Timestamp:  time.Now().UTC().Format(time.RFC3339),
 		TotalNodes: len(nodeList.Items),
 		Nodes:      make([]NodeInfo, 0, len(nodeList.Items)),
 	}
+	if !filter.IsZero() {
+		data.Filter = &filter
+	}
+	data.Summary.UtilizationAvailable = err == nil
 
 	// Process each node
 	for _, node := range nodeList.Items {
 		nodeInfo := NodeInfo{
-			Name:    node.Name,
-			Status:  getNodeStatus(&node),
-			Roles:   getNodeRoles(node.Labels),
-			Version: node.Status.NodeInfo.KubeletVersion,
-			Age:     formatAge(node.CreationTimestamp.Time),
+			Name:          node.Name,
+			Status:        getNodeStatus(&node),
+			Unschedulable: node.Spec.Unschedulable,
+			Pressure:      getNodePressure(&node),
+			Roles:         getNodeRoles(node.Labels),
+			Version:       node.Status.NodeInfo.KubeletVersion,
+			PodCount:      podsByNode[node.Name],
+			MaxPods:       node.Status.Allocatable.Pods().Value(),
+			Age:           formatAge(node.CreationTimestamp.Time),
+		}
+		if u, ok := usage[node.Name]; ok {
+			nodeInfo.Utilization = &NodeUtilization{
+				CPUPercent:    percentOf(u.Cpu().MilliValue(), node.Status.Allocatable.Cpu().MilliValue()),
+				MemoryPercent: percentOf(u.Memory().Value(), node.Status.Allocatable.Memory().Value()),
+			}
+		}
+		for _, taint := range node.Spec.Taints {
+			nodeInfo.Taints = append(nodeInfo.Taints, NodeTaint{Key: taint.Key, Value: taint.Value, Effect: string(taint.Effect)})
 		}
 
 		// Extract capacity
@@ -125,16 +230,14 @@ func (r *NodesResource) Read(ctx context.Context) (string, error) {
 		nodeInfo.Allocatable.Memory = formatMemory(node.Status.Allocatable.Memory().Value())
 		nodeInfo.Allocatable.Pods = node.Status.Allocatable.Pods().String()
 
-		// Extract conditions (limit to important ones)
+		// Extract conditions
 		for _, condition := range node.Status.Conditions {
-			if condition.Type == "Ready" || condition.Status != "False" {
-				nodeInfo.Conditions = append(nodeInfo.Conditions, NodeCondition{
-					Type:    string(condition.Type),
-					Status:  string(condition.Status),
-					Reason:  condition.Reason,
-					Message: condition.Message,
-				})
-			}
+			nodeInfo.Conditions = append(nodeInfo.Conditions, NodeCondition{
+				Type:    string(condition.Type),
+				Status:  string(condition.Status),
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			})
 		}
 
 		// Extract selected labels
@@ -150,22 +253,66 @@ func (r *NodesResource) Read(ctx context.Context) (string, error) {
 		if nodeInfo.Status == "Ready" {
 			data.ReadyNodes++
 		}
+		if nodeInfo.Unschedulable {
+			data.Summary.Cordoned++
+		}
+		if len(nodeInfo.Pressure) > 0 {
+			data.Summary.UnderPressure++
+		}
+		if nodeInfo.Status != "Ready" || nodeInfo.Unschedulable || len(nodeInfo.Pressure) > 0 {
+			data.Summary.Attention = append(data.Summary.Attention, node.Name)
+		}
 
 		data.Nodes = append(data.Nodes, nodeInfo)
 	}
+	data.Summary.Ready = fmt.Sprintf("%d/%d", data.ReadyNodes, data.TotalNodes)
+	sort.Slice(data.Nodes, func(i, j int) bool { return data.Nodes[i].Name < data.Nodes[j].Name })
+	sort.Strings(data.Summary.Attention)
 
-	// Marshal to JSON
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	return &data, nil
+}
+
+// listNodeUsage reads the current usage of every node from the metrics.k8s.io API
+func (r *NodesResource) listNodeUsage(ctx context.Context) (map[string]corev1.ResourceList, error) {
+	list, err := r.k8sClient.ListResources(ctx, "metrics.k8s.io/v1beta1", "NodeMetrics", "", metav1.ListOptions{})
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal nodes data: %w", err)
+		return nil, err
 	}
 
-	jsonStr := string(jsonData)
+	usage := make(map[string]corev1.ResourceList, len(list.Items))
+	for _, item := range list.Items {
+		resources := corev1.ResourceList{}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			value, _, _ := unstructured.NestedString(item.Object, "usage", string(name))
+			if quantity, err := resource.ParseQuantity(value); err == nil {
+				resources[name] = quantity
+			}
+		}
+		usage[item.GetName()] = resources
+	}
+	return usage, nil
+}
 
-	// Cache for 30 seconds (as per PRD)
-	r.cache.SetWithTTL(cacheKey, jsonStr, 30*time.Second)
+// getNodePressure returns the pressure conditions a node reports, e.g. MemoryPressure
+func getNodePressure(node *corev1.Node) []string {
+	var pressure []string
+	for _, condition := range node.Status.Conditions {
+		switch condition.Type {
+		case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+			if condition.Status == corev1.ConditionTrue {
+				pressure = append(pressure, string(condition.Type))
+			}
+		}
+	}
+	return pressure
+}
 
-	return jsonStr, nil
+// percentOf returns used as a percentage of total, to one decimal place
+func percentOf(used, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(used)/float64(total)*1000) / 10
 }
 
 // getNodeStatus determines the overall status of a node
@@ -181,22 +328,28 @@ func getNodeStatus(node *corev1.Node) string {
 	return "Unknown"
 }
 
-// getNodeRoles extracts node roles from labels
+// getNodeRoles extracts node roles from node-role.kubernetes.io/<role> labels,
+// reporting master as control-plane
 func getNodeRoles(labels map[string]string) []string {
+	seen := make(map[string]bool)
 	roles := []string{}
 	for key := range labels {
-		switch key {
-		case "node-role.kubernetes.io/master", "node-role.kubernetes.io/control-plane":
-			roles = append(roles, "control-plane")
-		case "node-role.kubernetes.io/worker":
-			roles = append(roles, "worker")
-		case "node-role.kubernetes.io/infra":
-			roles = append(roles, "infra")
+		role, ok := strings.CutPrefix(key, "node-role.kubernetes.io/")
+		if !ok || role == "" {
+			continue
+		}
+		if role == "master" {
+			role = "control-plane"
+		}
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
 		}
 	}
 	if len(roles) == 0 {
 		roles = append(roles, "worker") // Default role
 	}
+	sort.Strings(roles)
 	return roles
 }
 
@@ -8,6 +8,16 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	testclock "k8s.io/utils/clock/testing"
 
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
@@ -216,6 +226,23 @@ func TestGetNodeRoles(t *testing.T) {
 			},
 			expected: []string{"control-plane"},
 		},
+		{
+			name: "master and control-plane",
+			labels: map[string]string{
+				"node-role.kubernetes.io/master":        "",
+				"node-role.kubernetes.io/control-plane": "",
+			},
+			expected: []string{"control-plane"},
+		},
+		{
+			name: "custom roles",
+			labels: map[string]string{
+				"node-role.kubernetes.io/gpu":    "",
+				"node-role.kubernetes.io/worker": "",
+				"kubernetes.io/os":               "linux",
+			},
+			expected: []string{"gpu", "worker"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -278,3 +305,199 @@ func TestNodesResource_JSONStructure(t *testing.T) {
 	_, err = time.Parse(time.RFC3339, nodesData.Timestamp)
 	require.NoError(t, err, "Timestamp should be in RFC3339 format")
 }
+
+func TestNodesResource_ReadWithMaxAge(t *testing.T) {
+	k8sClient := clients.NewK8sClientWithClientset(fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+	}))
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	memCache := cache.NewMemoryCacheWithClock(30*time.Second, clk)
+	defer memCache.Close()
+
+	resource := NewNodesResource(k8sClient, memCache)
+	ctx := context.Background()
+
+	read := func(maxAge time.Duration) NodesData {
+		t.Helper()
+		data, err := resource.ReadWithMaxAge(ctx, maxAge)
+		require.NoError(t, err)
+		var nodesData NodesData
+		require.NoError(t, json.Unmarshal([]byte(data), &nodesData))
+		return nodesData
+	}
+
+	// Plain reads do not report an age, so cached reads stay identical
+	first := read(0)
+	assert.Equal(t, 1, first.TotalNodes)
+	assert.Nil(t, first.DataAgeSeconds)
+
+	clk.Step(100 * time.Millisecond)
+
+	cached := read(time.Minute)
+	require.NotNil(t, cached.DataAgeSeconds)
+	assert.Equal(t, 0.1, *cached.DataAgeSeconds)
+	assert.Equal(t, first.Timestamp, cached.Timestamp)
+
+	fresh := read(50 * time.Millisecond)
+	require.NotNil(t, fresh.DataAgeSeconds)
+	assert.Zero(t, *fresh.DataAgeSeconds, "an entry older than the bound is re-read")
+
+	// The age is only added to the response, never to the cached data
+	assert.Nil(t, read(0).DataAgeSeconds)
+}
+
+// newEnrichmentFixture returns a ready control-plane node with two pods, a
+// cordoned and tainted worker, and a worker under memory pressure
+func newEnrichmentFixture() []runtime.Object {
+	node := func(name string, labels map[string]string, conditions ...corev1.NodeCondition) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: corev1.NodeStatus{
+				Conditions: append([]corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}, conditions...),
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.31.4"},
+			},
+		}
+	}
+	pod := func(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	master := node("master-0", map[string]string{
+		"node-role.kubernetes.io/master":        "",
+		"node-role.kubernetes.io/control-plane": "",
+	}, corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse})
+	cordoned := node("worker-1", map[string]string{"node-role.kubernetes.io/worker": ""})
+	cordoned.Spec.Unschedulable = true
+	cordoned.Spec.Taints = []corev1.Taint{{Key: "node.kubernetes.io/unschedulable", Effect: corev1.TaintEffectNoSchedule}}
+	pressured := node("worker-2", map[string]string{"node-role.kubernetes.io/gpu": ""},
+		corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasInsufficientMemory"},
+		corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse})
+
+	return []runtime.Object{master, cordoned, pressured,
+		pod("api-0", "master-0", corev1.PodRunning),
+		pod("api-1", "master-0", corev1.PodPending),
+		pod("migrate", "master-0", corev1.PodSucceeded),
+		pod("unscheduled", "", corev1.PodPending),
+	}
+}
+
+func readNodes(t *testing.T, k8sClient *clients.K8sClient) NodesData {
+	t.Helper()
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	t.Cleanup(memCache.Close)
+	data, err := NewNodesResource(k8sClient, memCache).Read(context.Background())
+	require.NoError(t, err)
+	var nodesData NodesData
+	require.NoError(t, json.Unmarshal([]byte(data), &nodesData))
+	return nodesData
+}
+
+func TestNodesResource_Enrichment(t *testing.T) {
+	data := readNodes(t, clients.NewK8sClientWithClientset(fake.NewClientset(newEnrichmentFixture()...)))
+
+	assert.Equal(t, 3, data.TotalNodes, "existing fields are kept")
+	assert.Equal(t, 3, data.ReadyNodes)
+	assert.Equal(t, NodesSummary{Ready: "3/3", Cordoned: 1, UnderPressure: 1, Attention: []string{"worker-1", "worker-2"}}, data.Summary)
+	require.Len(t, data.Nodes, 3)
+
+	master := data.Nodes[0]
+	assert.Equal(t, []string{"control-plane"}, master.Roles, "master and control-plane are one role")
+	assert.Equal(t, "v1.31.4", master.Version)
+	assert.Equal(t, 2, master.PodCount, "terminated pods do not count")
+	assert.Equal(t, int64(110), master.MaxPods)
+	assert.False(t, master.Unschedulable)
+	assert.Empty(t, master.Pressure)
+	assert.Len(t, master.Conditions, 2, "conditions that are False are listed too")
+	assert.Nil(t, master.Utilization, "no utilization without metrics-server")
+
+	cordoned := data.Nodes[1]
+	assert.True(t, cordoned.Unschedulable)
+	assert.Equal(t, []NodeTaint{{Key: "node.kubernetes.io/unschedulable", Effect: "NoSchedule"}}, cordoned.Taints)
+	assert.Zero(t, cordoned.PodCount)
+
+	pressured := data.Nodes[2]
+	assert.Equal(t, []string{"gpu"}, pressured.Roles)
+	assert.Equal(t, []string{"MemoryPressure"}, pressured.Pressure)
+}
+
+func TestNodesResource_Utilization(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}
+	gvr := gvk.GroupVersion().WithResource("nodes")
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.AddSpecific(gvk, gvr, gvk.GroupVersion().WithResource("node"), meta.RESTScopeRoot)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{gvr: "NodeMetricsList"})
+
+	metrics := &unstructured.Unstructured{Object: map[string]interface{}{
+		"usage": map[string]interface{}{"cpu": "1500m", "memory": "4Gi"},
+	}}
+	metrics.SetGroupVersionKind(gvk)
+	metrics.SetName("master-0")
+	// Metrics are served as "nodes", which the tracker cannot guess from the kind
+	require.NoError(t, dynamicClient.Tracker().Create(gvr, metrics, ""))
+
+	data := readNodes(t, clients.NewK8sClientWithClients(fake.NewClientset(newEnrichmentFixture()...), dynamicClient, mapper))
+	assert.True(t, data.Summary.UtilizationAvailable)
+	require.NotNil(t, data.Nodes[0].Utilization)
+	assert.Equal(t, NodeUtilization{CPUPercent: 37.5, MemoryPercent: 25}, *data.Nodes[0].Utilization)
+	assert.Nil(t, data.Nodes[1].Utilization, "nodes without metrics are listed without utilization")
+}
+
+func TestNodesResource_StableOutput(t *testing.T) {
+	k8sClient := clients.NewK8sClientWithClientset(fake.NewClientset(newEnrichmentFixture()...))
+	render := func() string {
+		nodesData := readNodes(t, k8sClient)
+		nodesData.Timestamp = "" // The only field that changes between reads
+		data, err := json.MarshalIndent(nodesData, "", "  ")
+		require.NoError(t, err)
+		return string(data) + "\n"
+	}
+
+	first := render()
+	for i := 0; i < 10; i++ {
+		require.Equal(t, first, render())
+	}
+	assertGolden(t, "nodes.golden.json", first)
+}
+
+func TestNodesResource_ReadFiltered(t *testing.T) {
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	defer memCache.Close()
+	resource := NewNodesResource(clients.NewK8sClientWithClientset(fake.NewClientset(newEnrichmentFixture()...)), memCache)
+
+	read := func(filter clients.NodeFilter) NodesData {
+		t.Helper()
+		data, err := resource.ReadFiltered(context.Background(), 0, filter)
+		require.NoError(t, err)
+		var nodesData NodesData
+		require.NoError(t, json.Unmarshal([]byte(data), &nodesData))
+		return nodesData
+	}
+
+	workers, err := clients.NewNodeFilter("", "", "worker")
+	require.NoError(t, err)
+	data := read(workers)
+	assert.Equal(t, 1, data.TotalNodes)
+	require.Len(t, data.Nodes, 1)
+	assert.Equal(t, "worker-1", data.Nodes[0].Name)
+	assert.Equal(t, &clients.NodeFilter{Role: "worker"}, data.Filter, "the filter is echoed")
+	assert.Equal(t, "1/1", data.Summary.Ready, "the summary only counts the selected nodes")
+
+	prefixed, err := clients.NewNodeFilter("", "worker-", "")
+	require.NoError(t, err)
+	data = read(prefixed)
+	assert.Equal(t, 2, data.TotalNodes)
+
+	// Each filter is cached apart; the unfiltered listing is unchanged
+	data = read(clients.NodeFilter{})
+	assert.Equal(t, 3, data.TotalNodes)
+	assert.Nil(t, data.Filter)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
@@ -100,11 +101,9 @@ type RemediationPattern struct {
 // Read retrieves the remediation history resource
 func (r *RemediationHistoryResource) Read(ctx context.Context) (string, error) {
 	// Check cache first (15 second TTL as per plan)
-	cacheKey := "resource:cluster:remediation-history"
-	if cached, found := r.cache.Get(cacheKey); found {
-		if data, ok := cached.(string); ok {
-			return data, nil
-		}
+	cacheKey := cache.Key("resource", "cluster", "remediation-history")
+	if data, found := cache.GetTyped[string](r.cache, cacheKey); found {
+		return data, nil
 	}
 
 	// Fetch completed and failed incidents from Coordination Engine
@@ -239,9 +238,17 @@ func analyzeRemediationHistory(incidents []clients.Incident) (RemediationSummary
 
 	for _, stats := range actionStats {
 		stats.SuccessRate = (float64(stats.Successful) / float64(stats.TotalExecutions)) * 100
-
 		topActions = append(topActions, *stats)
+	}
+	// Most executed first, ties by name, so equal histories read identically
+	sort.Slice(topActions, func(i, j int) bool {
+		if topActions[i].TotalExecutions != topActions[j].TotalExecutions {
+			return topActions[i].TotalExecutions > topActions[j].TotalExecutions
+		}
+		return topActions[i].ActionType < topActions[j].ActionType
+	})
 
+	for _, stats := range topActions {
 		// Track most common
 		if stats.TotalExecutions > mostCommonCount {
 			mostCommonCount = stats.TotalExecutions
@@ -255,7 +262,7 @@ func analyzeRemediationHistory(incidents []clients.Incident) (RemediationSummary
 		}
 	}
 
-	// Build patterns (top 5)
+	// Build patterns (top 5 by frequency)
 	patterns := make([]RemediationPattern, 0, 5)
 	for pattern, freq := range patternFreq {
 		if freq >= 3 { // Only include patterns that occurred 3+ times
@@ -266,9 +273,15 @@ func analyzeRemediationHistory(incidents []clients.Incident) (RemediationSummary
 				Description: fmt.Sprintf("Recurring remediation: %s (%d times)", pattern, freq),
 			})
 		}
-		if len(patterns) >= 5 {
-			break
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		if patterns[i].Frequency != patterns[j].Frequency {
+			return patterns[i].Frequency > patterns[j].Frequency
 		}
+		return patterns[i].Pattern < patterns[j].Pattern
+	})
+	if len(patterns) > 5 {
+		patterns = patterns[:5]
 	}
 
 	// Build summary
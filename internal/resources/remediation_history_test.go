@@ -0,0 +1,90 @@
+package resources
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// assertGolden compares got with testdata/name, rewriting the file with -update
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test -update to create %s", path)
+	assert.Equal(t, string(want), got)
+}
+
+// remediationFixture has two equally common and equally successful actions
+// and six recurring patterns of the same frequency, so every ordering in the
+// analysis comes down to its tie-breaks
+func remediationFixture() []clients.Incident {
+	var incidents []clients.Incident
+	for i, target := range []string{"shop", "payments", "auth", "search", "cart", "web"} {
+		action := "scale-deployment"
+		if i%2 == 0 {
+			action = "restart-pod"
+		}
+		for j := 0; j < 3; j++ {
+			duration := float64(10 * (j + 1))
+			completedAt := fmt.Sprintf("2026-10-15T07:%02d:00Z", i*3+j)
+			incidents = append(incidents, clients.Incident{
+				ID:              fmt.Sprintf("inc-%s-%d", target, j),
+				ActionType:      action,
+				Target:          target,
+				Status:          "completed",
+				CompletedAt:     &completedAt,
+				DurationSeconds: &duration,
+			})
+		}
+	}
+	return incidents
+}
+
+func TestAnalyzeRemediationHistory_Deterministic(t *testing.T) {
+	render := func() string {
+		summary, topActions, recentActions, patterns := analyzeRemediationHistory(remediationFixture())
+		data, err := json.MarshalIndent(RemediationHistoryData{
+			Status:        "ok",
+			Summary:       summary,
+			TopActions:    topActions,
+			RecentActions: recentActions,
+			Patterns:      patterns,
+		}, "", "  ")
+		require.NoError(t, err)
+		return string(data) + "\n"
+	}
+
+	first := render()
+	for i := 0; i < 10; i++ {
+		require.Equal(t, first, render(), "the analysis must not depend on map iteration order")
+	}
+	assertGolden(t, "remediation_history.golden.json", first)
+}
+
+func TestAnalyzeRemediationHistory_TieBreaks(t *testing.T) {
+	summary, topActions, _, patterns := analyzeRemediationHistory(remediationFixture())
+
+	assert.Equal(t, "restart-pod", summary.MostCommonAction, "ties go to the first action by name")
+	assert.Equal(t, "restart-pod", summary.MostSuccessfulAction)
+	require.Len(t, topActions, 2)
+	assert.Equal(t, "restart-pod", topActions[0].ActionType)
+
+	require.Len(t, patterns, 5, "the top five of six")
+	assert.Equal(t, "restart-pod on auth", patterns[0].Pattern)
+	assert.Equal(t, "scale-deployment on search", patterns[4].Pattern, "the last by name is dropped")
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// actionEventReason is the reason of the Kubernetes Events recording
+// mutating tool calls, so that `oc get events --field-selector
+// reason=MCPRemediation` lists everything the server did
+const actionEventReason = "MCPRemediation"
+
+// Annotations of action events, for tooling that reads them
+const (
+	actionEventToolAnnotation    = "mcp.openshift-aiops.io/tool"
+	actionEventUserAnnotation    = "mcp.openshift-aiops.io/user"
+	actionEventOutcomeAnnotation = "mcp.openshift-aiops.io/outcome"
+)
+
+// EventTargetTool is implemented by mutating tools that act on one
+// Kubernetes object, which the action event of a call is recorded on.
+// Events of other mutating tools go to ActionEventsNamespace.
+type EventTargetTool interface {
+	EventTarget(args map[string]interface{}) *corev1.ObjectReference
+}
+
+// actionEventTarget returns the object the action event of a call to tool
+// is recorded on: the object it acted on, or else the events namespace
+func actionEventTarget(tool Tool, args map[string]interface{}, namespace string) *corev1.ObjectReference {
+	if targeted, ok := tool.(EventTargetTool); ok {
+		if target := targeted.EventTarget(args); target != nil && target.Name != "" {
+			return target
+		}
+	}
+	return &corev1.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: namespace, Namespace: namespace}
+}
+
+// emitActionEvent records a mutating tool call that ran, successful or not,
+// as a Kubernetes Event. It is best-effort: the call's result never depends
+// on it, and events beyond the per-object rate limit are dropped.
+func (s *MCPServer) emitActionEvent(ctx context.Context, tool Tool, args map[string]interface{}, err error) {
+	cfg := s.currentConfig()
+	if !cfg.EmitActionEvents || s.k8sClient == nil {
+		return
+	}
+
+	caller := "an unauthenticated caller"
+	user := ""
+	if principal, ok := clients.PrincipalFromContext(ctx); ok && principal.User != "" {
+		caller, user = principal.User, principal.User
+	}
+	outcome := classifyToolOutcome(err)
+	eventType := corev1.EventTypeNormal
+	message := fmt.Sprintf("%s called by %s succeeded", tool.Name(), caller)
+	if err != nil {
+		eventType = corev1.EventTypeWarning
+		message = fmt.Sprintf("%s called by %s failed: %v", tool.Name(), caller, err)
+	}
+	annotations := map[string]string{
+		actionEventToolAnnotation:    tool.Name(),
+		actionEventUserAnnotation:    user,
+		actionEventOutcomeAnnotation: outcome,
+	}
+
+	target := actionEventTarget(tool, args, cfg.ActionEventsNamespace)
+	if !s.k8sClient.RecordEvent(target, annotations, eventType, actionEventReason, message) {
+		log.Printf("Action event for %s on %s %s/%s not emitted (rate limited or no recorder)", tool.Name(), target.Kind, target.Namespace, target.Name)
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newActionEventServer returns a server whose Kubernetes Events go to the
+// returned fake recorder
+func newActionEventServer(t *testing.T) (*MCPServer, *record.FakeRecorder) {
+	t.Helper()
+	server := newStubToolServer(t, NewConfig())
+	recorder := record.NewFakeRecorder(20)
+	recorder.IncludeObject = true
+	server.k8sClient = clients.NewK8sClientWithClientset(fake.NewClientset())
+	server.k8sClient.SetEventRecorder(recorder)
+	return server, recorder
+}
+
+// recordedEvents drains the events recorded so far
+func recordedEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestActionEvents_OnTargetObject(t *testing.T) {
+	server, recorder := newActionEventServer(t)
+	rollback := tools.NewRollbackDeploymentTool(server.k8sClient)
+	alice := clients.WithPrincipal(context.Background(), clients.Principal{User: "alice"})
+
+	// The Deployment does not exist, so the rollback fails after running
+	_, _, err := server.executeTool(alice, rollback, map[string]interface{}{"namespace": "shop", "name": "api"})
+	require.Error(t, err)
+
+	events := recordedEvents(recorder)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], "Warning MCPRemediation rollback-deployment called by alice failed: ")
+	assert.Contains(t, events[0], "involvedObject{kind=Deployment,apiVersion=apps/v1}")
+	assert.Contains(t, events[0], "mcp.openshift-aiops.io/outcome:upstream_error")
+	assert.Contains(t, events[0], "mcp.openshift-aiops.io/user:alice")
+}
+
+func TestActionEvents_ClusterLevelActionInServerNamespace(t *testing.T) {
+	server, recorder := newActionEventServer(t)
+	tool := &mutatingStubTool{stubTool: stubTool{name: "trigger-must-gather"}}
+
+	_, _, err := server.executeTool(context.Background(), tool, nil)
+	require.NoError(t, err)
+
+	events := recordedEvents(recorder)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], "Normal MCPRemediation trigger-must-gather called by an unauthenticated caller succeeded")
+	assert.Contains(t, events[0], "involvedObject{kind=Namespace,apiVersion=v1}")
+	assert.Equal(t, "self-healing-platform", actionEventTarget(tool, nil, server.config.ActionEventsNamespace).Namespace)
+}
+
+func TestActionEvents_NotEmitted(t *testing.T) {
+	server, recorder := newActionEventServer(t)
+	mutating := &mutatingStubTool{stubTool: stubTool{name: "create-incident"}}
+
+	// Read-only tools
+	_, _, err := server.executeTool(context.Background(), &stubTool{name: "list-pods"}, nil)
+	require.NoError(t, err)
+	// Calls refused before the tool runs
+	cfg := *server.config
+	cfg.ReadOnly = true
+	server.liveConfig.Store(&cfg)
+	_, _, err = server.executeTool(context.Background(), mutating, nil)
+	require.ErrorIs(t, err, errReadOnly)
+	// Calls rejected for their arguments
+	cfg.ReadOnly = false
+	failing := &mutatingStubTool{stubTool: stubTool{name: "create-incident", err: tools.ErrInvalidArgs}}
+	_, _, err = server.executeTool(context.Background(), failing, nil)
+	require.Error(t, err)
+	// Disabled
+	cfg.EmitActionEvents = false
+	_, _, err = server.executeTool(context.Background(), mutating, nil)
+	require.NoError(t, err)
+
+	assert.Empty(t, recordedEvents(recorder))
+}
+
+func TestActionEvents_BestEffort(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.k8sClient = clients.NewK8sClientWithClientset(nil) // No recorder can be built
+	tool := &mutatingStubTool{stubTool: stubTool{name: "create-incident", err: errors.New("engine down")}}
+
+	_, _, err := server.executeTool(context.Background(), tool, nil)
+	assert.EqualError(t, err, "engine down", "the tool's own error is returned")
+}
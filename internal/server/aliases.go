@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// AliasedTool is implemented by renamed tools that still answer to their
+// former names. Aliases are registered alongside the tool, over MCP and
+// REST, and calls made through them carry a deprecation warning.
+type AliasedTool interface {
+	Aliases() []tools.Alias
+}
+
+// toolAliases returns the aliases tool declares, if any
+func toolAliases(tool Tool) []tools.Alias {
+	if aliased, ok := tool.(AliasedTool); ok {
+		return aliased.Aliases()
+	}
+	return nil
+}
+
+// toolAlias returns the alias of tool with the given name
+func toolAlias(tool Tool, name string) (tools.Alias, bool) {
+	for _, alias := range toolAliases(tool) {
+		if alias.Name == name {
+			return alias, true
+		}
+	}
+	return tools.Alias{}, false
+}
+
+// toolAliasKey carries the alias a tool was called under
+type toolAliasKey struct{}
+
+// withToolAlias records that the tool of this call was called as alias
+func withToolAlias(ctx context.Context, alias string) context.Context {
+	return context.WithValue(ctx, toolAliasKey{}, alias)
+}
+
+// toolAliasFromContext returns the alias the tool was called under, empty
+// when it was called by its name
+func toolAliasFromContext(ctx context.Context) string {
+	alias, _ := ctx.Value(toolAliasKey{}).(string)
+	return alias
+}
+
+// resolveTool returns the tool registered under name or aliased by it, and
+// the alias when name is one
+func (s *MCPServer) resolveTool(name string) (tool Tool, alias string, ok bool) {
+	tool, registeredName, ok := s.tools.Resolve(name)
+	if ok && registeredName != name {
+		alias = name
+	}
+	return tool, alias, ok
+}
+
+// DeprecationNotice is the _meta warning of a call made through an alias
+type DeprecationNotice struct {
+	Alias   string `json:"alias"`
+	Tool    string `json:"tool"`             // Name to call instead
+	Sunset  string `json:"sunset,omitempty"` // Date after which the alias may be removed
+	Message string `json:"message"`
+}
+
+// newDeprecationNotice describes a call to tool through alias
+func newDeprecationNotice(tool Tool, alias string) *DeprecationNotice {
+	notice := &DeprecationNotice{Alias: alias, Tool: tool.Name()}
+	if declared, ok := toolAlias(tool, alias); ok {
+		notice.Sunset = declared.SunsetDate()
+	}
+	notice.Message = fmt.Sprintf("tool %q is deprecated, call %q instead", alias, tool.Name())
+	if notice.Sunset != "" {
+		notice.Message += fmt.Sprintf("; the old name stops working after %s", notice.Sunset)
+	}
+	return notice
+}
+
+// aliasDescription is the MCP description of an alias, so that clients
+// listing tools see which name to move to
+func aliasDescription(tool Tool, alias tools.Alias) string {
+	description := fmt.Sprintf("Deprecated alias of %s", tool.Name())
+	if sunset := alias.SunsetDate(); sunset != "" {
+		description += fmt.Sprintf(" (removed after %s)", sunset)
+	}
+	return description + ". " + tool.Description()
+}
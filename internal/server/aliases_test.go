@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// aliasedStubTool is a stub tool that also answers to former names
+type aliasedStubTool struct {
+	stubTool
+	aliases []tools.Alias
+}
+
+func (t *aliasedStubTool) Aliases() []tools.Alias { return t.aliases }
+
+// renamedListPods is list-pods renamed to list-workload-pods
+func renamedListPods() *aliasedStubTool {
+	return &aliasedStubTool{
+		stubTool: stubTool{name: "list-workload-pods"},
+		aliases:  []tools.Alias{{Name: "list-pods", Sunset: time.Date(2027, 1, 31, 0, 0, 0, 0, time.UTC)}},
+	}
+}
+
+func TestRegistry_Aliases(t *testing.T) {
+	registry := NewToolRegistry()
+	tool := &stubTool{name: "list-workload-pods"}
+	require.NoError(t, registry.Register("list-workload-pods", tool, "list-pods", "get-pods"))
+
+	resolved, name, ok := registry.Resolve("list-pods")
+	require.True(t, ok)
+	assert.Same(t, tool, resolved)
+	assert.Equal(t, "list-workload-pods", name)
+	_, name, ok = registry.Resolve("list-workload-pods")
+	assert.True(t, ok)
+	assert.Equal(t, "list-workload-pods", name)
+	_, ok = registry.Get("list-pods")
+	assert.False(t, ok, "Get does not resolve aliases")
+	assert.Equal(t, []string{"list-workload-pods"}, registry.Names(), "aliases are not entries")
+	assert.Equal(t, map[string]string{"list-pods": "list-workload-pods", "get-pods": "list-workload-pods"}, registry.Aliases())
+
+	// Deregistering the tool frees its aliases
+	assert.True(t, registry.Deregister("list-workload-pods"))
+	_, _, ok = registry.Resolve("list-pods")
+	assert.False(t, ok)
+	assert.NoError(t, registry.Register("list-pods", &stubTool{name: "list-pods"}))
+}
+
+func TestRegistry_AliasConflicts(t *testing.T) {
+	registry := NewToolRegistry()
+	require.NoError(t, registry.Register("list-pods", &stubTool{name: "list-pods"}))
+
+	err := registry.Register("list-workload-pods", &stubTool{name: "list-workload-pods"}, "list-pods")
+	assert.ErrorIs(t, err, ErrAlreadyRegistered)
+	assert.EqualError(t, err, `alias "list-pods" of tool "list-workload-pods" is already registered`)
+	_, ok := registry.Get("list-workload-pods")
+	assert.False(t, ok, "nothing is registered when an alias conflicts")
+
+	require.NoError(t, registry.Register("list-workload-pods", &stubTool{name: "list-workload-pods"}, "get-pods"))
+	assert.ErrorIs(t, registry.Register("get-pods", &stubTool{name: "get-pods"}), ErrAlreadyRegistered, "a tool cannot take an alias's name")
+	assert.ErrorIs(t, registry.Register("pods", &stubTool{name: "pods"}, "get-pods"), ErrAlreadyRegistered, "nor can another alias")
+	assert.ErrorIs(t, registry.Register("nodes", &stubTool{name: "nodes"}, "nodes"), ErrAlreadyRegistered, "an alias cannot repeat the name")
+	assert.ErrorIs(t, registry.Register("nodes", &stubTool{name: "nodes"}, "n", "n"), ErrAlreadyRegistered)
+}
+
+func TestRegisterTool_AliasConflictsWithTool(t *testing.T) {
+	server := newStubToolServer(t, NewConfig(), "list-pods")
+
+	assert.ErrorIs(t, server.RegisterTool(renamedListPods()), ErrAlreadyRegistered)
+	_, ok := server.lookupTool("list-workload-pods")
+	assert.False(t, ok)
+}
+
+func TestToolAlias_RESTCall(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	require.NoError(t, server.RegisterTool(renamedListPods()))
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+
+	w := authRequest(server, http.MethodPost, "/mcp/tools/list-pods/call?sessionid="+session.ID, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Result map[string]string `json:"result"`
+		Meta   ResponseMeta      `json:"_meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "list-workload-pods", body.Result["tool"], "the alias runs the canonical tool")
+	require.NotNil(t, body.Meta.Deprecation)
+	assert.Equal(t, DeprecationNotice{
+		Alias:   "list-pods",
+		Tool:    "list-workload-pods",
+		Sunset:  "2027-01-31",
+		Message: `tool "list-pods" is deprecated, call "list-workload-pods" instead; the old name stops working after 2027-01-31`,
+	}, *body.Meta.Deprecation)
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Sun, 31 Jan 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+
+	// Calls by the canonical name carry no warning
+	w = authRequest(server, http.MethodPost, "/mcp/tools/list-workload-pods/call?sessionid="+session.ID, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "deprecation")
+	assert.Empty(t, w.Header().Get("Deprecation"))
+}
+
+func TestToolAlias_MCPCall(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	require.NoError(t, server.RegisterTool(renamedListPods()))
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.mcpServer.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	client, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	listed, err := client.ListTools(ctx, nil)
+	require.NoError(t, err)
+	descriptions := make(map[string]string)
+	for _, tool := range listed.Tools {
+		descriptions[tool.Name] = tool.Description
+	}
+	require.Contains(t, descriptions, "list-pods")
+	assert.Contains(t, descriptions["list-pods"], "Deprecated alias of list-workload-pods (removed after 2027-01-31)")
+
+	result, err := client.CallTool(ctx, &mcp.CallToolParams{Name: "list-pods"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	deprecation, ok := result.Meta["deprecation"].(map[string]interface{})
+	require.True(t, ok, "_meta = %v", result.Meta)
+	assert.Equal(t, "list-workload-pods", deprecation["tool"])
+	assert.Equal(t, "2027-01-31", deprecation["sunset"])
+
+	result, err = client.CallTool(ctx, &mcp.CallToolParams{Name: "list-workload-pods"})
+	require.NoError(t, err)
+	assert.NotContains(t, result.Meta, "deprecation")
+}
+
+func TestToolAlias_ListedAndDocumented(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	require.NoError(t, server.RegisterTool(renamedListPods()))
+
+	w := authRequest(server, http.MethodGet, "/mcp/tools", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listing struct {
+		Tools []struct {
+			Name    string              `json:"name"`
+			Aliases []map[string]string `json:"aliases"`
+		} `json:"tools"`
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listing))
+	require.Equal(t, 1, listing.Count, "aliases are listed with their tool, not as tools")
+	assert.Equal(t, "list-workload-pods", listing.Tools[0].Name)
+	assert.Equal(t, []map[string]string{{"name": "list-pods", "sunset": "2027-01-31"}}, listing.Tools[0].Aliases)
+
+	spec := server.buildOpenAPISpec()
+	paths := spec["paths"].(map[string]interface{})
+	require.Contains(t, paths, "/mcp/tools/list-pods/call")
+	operation := paths["/mcp/tools/list-pods/call"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, true, operation["deprecated"])
+}
+
+func TestToolAlias_AuditRecordsCanonicalNameAndAlias(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.toolMetrics = newToolMetrics()
+	logs := captureLog(t)
+	tool := &mutatingStubTool{stubTool: stubTool{name: "restart-workload"}}
+
+	_, _, err := server.executeTool(withToolAlias(context.Background(), "restart-deployment"), tool, nil)
+	require.NoError(t, err)
+
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, "restart-workload", entries[0].Tool)
+	assert.Equal(t, "restart-deployment", entries[0].Alias)
+
+	stats := server.toolMetrics.snapshot()
+	require.Len(t, stats, 1)
+	assert.Equal(t, "restart-workload", stats[0].Tool, "metrics are kept under the canonical name")
+}
@@ -0,0 +1,470 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+const (
+	// artifactURIPrefix is the resource URI scheme of stored artifacts
+	artifactURIPrefix = "artifact://"
+	// artifactCleanupInterval is how often expired artifacts are deleted
+	artifactCleanupInterval = 5 * time.Minute
+	// artifactMetaSuffix names the metadata file stored next to each artifact
+	artifactMetaSuffix = ".json"
+)
+
+// errArtifactNotFound is returned for unknown and expired artifacts
+var errArtifactNotFound = errors.New("artifact not found")
+
+// ArtifactRef is returned in place of a stored artifact's content
+type ArtifactRef struct {
+	ID          string    `json:"id"`
+	URI         string    `json:"uri"` // Resource URI, readable via /mcp/resources/{uri}/read
+	URL         string    `json:"url"` // Path to download the artifact from
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// artifactMeta describes a stored artifact. It is written next to the
+// content so that artifacts survive restarts when the directory is a PVC.
+type artifactMeta struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Tool        string    `json:"tool"`
+	Tenant      string    `json:"tenant,omitempty"` // Tenant profile of the call that produced it
+	Size        int64     `json:"size"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// ref returns the reference a tool result carries for the artifact
+func (m *artifactMeta) ref() ArtifactRef {
+	return ArtifactRef{
+		ID:          m.ID,
+		URI:         artifactURIPrefix + m.ID,
+		URL:         "/artifacts/" + m.ID,
+		Name:        m.Name,
+		ContentType: m.ContentType,
+		SizeBytes:   m.Size,
+		ExpiresAt:   m.ExpiresAt,
+	}
+}
+
+// artifactStore keeps tool-result artifacts in a directory until their TTL
+// runs out. The total size is capped at maxBytes: storing past it evicts the
+// oldest artifacts, and a single artifact larger than the cap is rejected.
+type artifactStore struct {
+	dir      string
+	ttl      time.Duration
+	maxBytes int64
+	clock    clock.WithTicker
+
+	mu        sync.Mutex
+	artifacts map[string]*artifactMeta
+	used      int64
+}
+
+// newArtifactStore creates the store in dir, picking up the artifacts a
+// previous run left there
+func newArtifactStore(dir string, ttl time.Duration, maxBytes int64, clk clock.WithTicker) (*artifactStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+	store := &artifactStore{
+		dir:       dir,
+		ttl:       ttl,
+		maxBytes:  maxBytes,
+		clock:     clk,
+		artifacts: make(map[string]*artifactMeta),
+	}
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+	store.cleanup()
+	return store, nil
+}
+
+// load reads the metadata of the artifacts already in the directory.
+// Metadata without content, left by an interrupted write or removal, is dropped.
+func (a *artifactStore) load() error {
+	paths, err := filepath.Glob(filepath.Join(a.dir, "*"+artifactMetaSuffix))
+	if err != nil {
+		return fmt.Errorf("failed to list artifacts: %w", err)
+	}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path) //nolint:gosec // Path is inside the artifact directory
+		if err != nil {
+			return fmt.Errorf("failed to read artifact metadata: %w", err)
+		}
+		var meta artifactMeta
+		if err := json.Unmarshal(raw, &meta); err != nil || meta.ID+artifactMetaSuffix != filepath.Base(path) {
+			log.Printf("Ignoring invalid artifact metadata %s", path)
+			continue
+		}
+		info, err := os.Stat(a.contentPath(meta.ID))
+		if err != nil {
+			os.Remove(path) //nolint:errcheck,gosec // Best effort
+			continue
+		}
+		meta.Size = info.Size() // An artifact still being appended to when the server stopped is larger than recorded
+		a.artifacts[meta.ID] = &meta
+		a.used += meta.Size
+	}
+	return nil
+}
+
+// put stores an artifact produced by tool, called under the tenant profile
+// named tenant ("" for none), and returns its metadata
+func (a *artifactStore) put(tool, tenant string, artifact tools.Artifact) (*artifactMeta, error) {
+	size := int64(len(artifact.Content))
+	if size > a.maxBytes {
+		return nil, fmt.Errorf("artifact %s is %d bytes, larger than the artifact quota of %d bytes", artifact.Name, size, a.maxBytes)
+	}
+	id, err := generateSessionID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate artifact ID: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.clock.Now()
+	a.removeExpired(now)
+	for a.used+size > a.maxBytes {
+		oldest := a.oldest("")
+		log.Printf("Evicting artifact %s (%s, %d bytes) to stay within the artifact quota", oldest.ID, oldest.Name, oldest.Size)
+		a.remove(oldest)
+	}
+
+	meta := &artifactMeta{
+		ID:          id,
+		Name:        artifact.Name,
+		ContentType: artifact.ContentType,
+		Tool:        tool,
+		Tenant:      tenant,
+		Size:        size,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(a.ttl),
+	}
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode artifact metadata: %w", err)
+	}
+	// The metadata is written last: it is what makes the artifact exist after a restart
+	if err := writeFileAtomic(a.contentPath(id), artifact.Content); err != nil {
+		return nil, err
+	}
+	if err := writeFileAtomic(a.contentPath(id)+artifactMetaSuffix, raw); err != nil {
+		os.Remove(a.contentPath(id)) //nolint:errcheck,gosec // Best effort
+		return nil, err
+	}
+	a.artifacts[id] = meta
+	a.used += size
+	return meta, nil
+}
+
+// get returns a copy of the metadata of an artifact that has not expired
+func (a *artifactStore) get(id string) (*artifactMeta, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	meta, ok := a.artifacts[id]
+	if !ok || !a.clock.Now().Before(meta.ExpiresAt) {
+		return nil, false
+	}
+	copied := *meta // The size of an artifact being appended to changes under a.mu
+	return &copied, true
+}
+
+// artifactWriter appends to an artifact as a background job produces it;
+// readers see the content written so far
+type artifactWriter struct {
+	store *artifactStore
+	meta  *artifactMeta
+	file  *os.File
+}
+
+// create stores an empty artifact for tool and returns a writer appending to
+// it. Each write counts against the quota like a stored artifact does,
+// evicting the oldest other artifacts; closing the writer records the final
+// size in the metadata.
+func (a *artifactStore) create(tool, tenant, name, contentType string) (*artifactMeta, *artifactWriter, error) {
+	meta, err := a.put(tool, tenant, tools.Artifact{Name: name, ContentType: contentType})
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := os.OpenFile(a.contentPath(meta.ID), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		a.mu.Lock()
+		a.remove(meta)
+		a.mu.Unlock()
+		return nil, nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	copied := *meta
+	return &copied, &artifactWriter{store: a, meta: meta, file: file}, nil
+}
+
+// Write appends p, failing once the artifact expired or was evicted, or
+// when it would no longer fit in the quota by itself
+func (w *artifactWriter) Write(p []byte) (int, error) {
+	a := w.store
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.artifacts[w.meta.ID] != w.meta {
+		return 0, errArtifactNotFound
+	}
+	size := int64(len(p))
+	if w.meta.Size+size > a.maxBytes {
+		return 0, fmt.Errorf("artifact %s would grow past the artifact quota of %d bytes", w.meta.Name, a.maxBytes)
+	}
+	for a.used+size > a.maxBytes {
+		oldest := a.oldest(w.meta.ID)
+		log.Printf("Evicting artifact %s (%s, %d bytes) to stay within the artifact quota", oldest.ID, oldest.Name, oldest.Size)
+		a.remove(oldest)
+	}
+	n, err := w.file.Write(p)
+	w.meta.Size += int64(n)
+	a.used += int64(n)
+	return n, err
+}
+
+// Close stops appending and records the final size in the metadata
+func (w *artifactWriter) Close() error {
+	a := w.store
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	err := w.file.Close()
+	if a.artifacts[w.meta.ID] != w.meta {
+		return err // Expired or evicted meanwhile
+	}
+	raw, marshalErr := json.Marshal(w.meta)
+	if marshalErr != nil {
+		return marshalErr
+	}
+	if writeErr := writeFileAtomic(a.contentPath(w.meta.ID)+artifactMetaSuffix, raw); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+// open returns an artifact's metadata and its content for reading
+func (a *artifactStore) open(id string) (*artifactMeta, *os.File, error) {
+	meta, ok := a.get(id)
+	if !ok {
+		return nil, nil, errArtifactNotFound
+	}
+	// Still readable if removed meanwhile: the open file outlives the unlink
+	file, err := os.Open(a.contentPath(meta.ID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, errArtifactNotFound
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open artifact: %w", err)
+	}
+	return meta, file, nil
+}
+
+// list returns the artifacts that have not expired, oldest first
+func (a *artifactStore) list() []artifactMeta {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.clock.Now()
+	list := make([]artifactMeta, 0, len(a.artifacts))
+	for _, meta := range a.artifacts {
+		if now.Before(meta.ExpiresAt) {
+			list = append(list, *meta)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.Before(list[j].CreatedAt)
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// usage returns the number of stored artifacts and their total size
+func (a *artifactStore) usage() (int, int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.artifacts), a.used
+}
+
+// cleanup deletes the expired artifacts and returns how many it deleted
+func (a *artifactStore) cleanup() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.removeExpired(a.clock.Now())
+}
+
+// run deletes expired artifacts every artifactCleanupInterval until ctx is done
+func (a *artifactStore) run(ctx context.Context) {
+	ticker := a.clock.NewTicker(artifactCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+			if removed := a.cleanup(); removed > 0 {
+				log.Printf("Deleted %d expired artifacts", removed)
+			}
+		}
+	}
+}
+
+// removeExpired deletes the artifacts expired at now. Callers hold a.mu.
+func (a *artifactStore) removeExpired(now time.Time) int {
+	removed := 0
+	for _, meta := range a.artifacts {
+		if !now.Before(meta.ExpiresAt) {
+			a.remove(meta)
+			removed++
+		}
+	}
+	return removed
+}
+
+// oldest returns the artifact stored first, other than the one with ID
+// except. Callers hold a.mu and make sure there is one.
+func (a *artifactStore) oldest(except string) *artifactMeta {
+	var oldest *artifactMeta
+	for _, meta := range a.artifacts {
+		if meta.ID == except {
+			continue
+		}
+		if oldest == nil || meta.CreatedAt.Before(oldest.CreatedAt) ||
+			(meta.CreatedAt.Equal(oldest.CreatedAt) && meta.ID < oldest.ID) {
+			oldest = meta
+		}
+	}
+	return oldest
+}
+
+// remove deletes an artifact. The metadata goes first, so a failure midway
+// leaves content that load ignores rather than metadata without content.
+// Callers hold a.mu.
+func (a *artifactStore) remove(meta *artifactMeta) {
+	path := a.contentPath(meta.ID)
+	for _, file := range []string{path + artifactMetaSuffix, path} {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Warning: failed to delete artifact file %s: %v", file, err)
+		}
+	}
+	delete(a.artifacts, meta.ID)
+	a.used -= meta.Size
+}
+
+// contentPath returns the file holding an artifact's content. IDs are
+// generated hex strings, so they are safe as file names.
+func (a *artifactStore) contentPath(id string) string {
+	return filepath.Join(a.dir, id)
+}
+
+// storeArtifacts stores the artifacts of a tool result, owned by the call's
+// tenant, and returns the result the caller gets instead: the tool's summary
+// and a reference to each artifact. Nothing is kept when one of the
+// artifacts cannot be stored.
+func (s *MCPServer) storeArtifacts(ctx context.Context, tool string, result tools.ArtifactResult) (map[string]interface{}, error) {
+	refs := make([]ArtifactRef, 0, len(result.Artifacts))
+	var stored []*artifactMeta
+	for _, artifact := range result.Artifacts {
+		meta, err := s.artifacts.put(tool, artifactTenant(ctx), artifact)
+		if err != nil {
+			s.artifacts.mu.Lock()
+			for _, meta := range stored {
+				s.artifacts.remove(meta)
+			}
+			s.artifacts.mu.Unlock()
+			return nil, fmt.Errorf("failed to store artifact: %w", err)
+		}
+		stored = append(stored, meta)
+		refs = append(refs, meta.ref())
+	}
+	return map[string]interface{}{
+		"summary":   result.Summary,
+		"artifacts": refs,
+	}, nil
+}
+
+// artifactTenant returns the name of the tenant profile a call producing an
+// artifact was made under, "" for none
+func artifactTenant(ctx context.Context) string {
+	if tenant := tenantFromContext(ctx); tenant != nil {
+		return tenant.Name
+	}
+	return ""
+}
+
+// visibleTo reports whether a caller under tenant may read the artifact:
+// callers without a profile read every artifact, tenants only their own
+func (m *artifactMeta) visibleTo(tenant *TenantProfile) bool {
+	return tenant == nil || m.Tenant == tenant.Name
+}
+
+// artifactFromURI returns the stored artifact a resource URI names, if any
+func (s *MCPServer) artifactFromURI(uri string) (*artifactMeta, bool) {
+	if s.artifacts == nil || !strings.HasPrefix(uri, artifactURIPrefix) {
+		return nil, false
+	}
+	return s.artifacts.get(strings.TrimPrefix(uri, artifactURIPrefix))
+}
+
+// handleArtifact serves a stored artifact with its content type
+// GET /artifacts/{id}
+func (s *MCPServer) handleArtifact(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed - use GET")
+		return
+	}
+	if s.artifacts == nil {
+		writeJSONError(w, http.StatusNotFound, "Artifacts are disabled (ARTIFACT_DIRECTORY is not set)")
+		return
+	}
+	s.serveArtifact(w, r, strings.TrimPrefix(r.URL.Path, "/artifacts/"))
+}
+
+// serveArtifact writes the content of an artifact. Artifacts may hold HTML
+// rendered from cluster data, so browsers get it sandboxed and unsniffed.
+// Tenants get other callers' artifacts reported as not found.
+func (s *MCPServer) serveArtifact(w http.ResponseWriter, r *http.Request, id string) {
+	meta, file, err := s.artifacts.open(id)
+	if err == nil && !meta.visibleTo(s.tenantFor(r.Context())) {
+		file.Close() //nolint:errcheck,gosec // Read-only
+		err = errArtifactNotFound
+	}
+	if errors.Is(err, errArtifactNotFound) {
+		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("artifact '%s' not found or expired", id))
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer file.Close() //nolint:errcheck // Read-only
+
+	w.Header().Set("Content-Type", meta.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", meta.Name))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.Header().Set("Expires", meta.ExpiresAt.UTC().Format(http.TimeFormat))
+	http.ServeContent(w, r, meta.Name, meta.CreatedAt, file)
+}
@@ -0,0 +1,355 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// newTestArtifactStore returns a store in a temporary directory on a fake clock
+func newTestArtifactStore(t *testing.T, maxBytes int64) (*artifactStore, *testclock.FakeClock) {
+	t.Helper()
+	clk := testclock.NewFakeClock(time.Date(2026, 3, 2, 6, 30, 0, 0, time.UTC))
+	store, err := newArtifactStore(t.TempDir(), time.Hour, maxBytes, clk)
+	require.NoError(t, err)
+	return store, clk
+}
+
+func textArtifact(name, content string) tools.Artifact {
+	return tools.Artifact{Name: name, ContentType: "text/markdown; charset=utf-8", Content: []byte(content)}
+}
+
+func TestArtifactStore_PutAndOpen(t *testing.T) {
+	store, clk := newTestArtifactStore(t, 1024)
+
+	meta, err := store.put("generate-health-report", "", textArtifact("report.md", "# Cluster health"))
+	require.NoError(t, err)
+	assert.Len(t, meta.ID, 32)
+	assert.Equal(t, int64(16), meta.Size)
+	assert.Equal(t, clk.Now().Add(time.Hour), meta.ExpiresAt)
+
+	got, file, err := store.open(meta.ID)
+	require.NoError(t, err)
+	defer file.Close() //nolint:errcheck
+	content, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "# Cluster health", string(content))
+	assert.Equal(t, "generate-health-report", got.Tool)
+
+	_, _, err = store.open("../" + meta.ID)
+	assert.ErrorIs(t, err, errArtifactNotFound)
+}
+
+func TestArtifactStore_Cleanup(t *testing.T) {
+	store, clk := newTestArtifactStore(t, 1024)
+
+	old, err := store.put("tool", "", textArtifact("old.md", "old"))
+	require.NoError(t, err)
+	clk.Step(40 * time.Minute)
+	recent, err := store.put("tool", "", textArtifact("recent.md", "recent"))
+	require.NoError(t, err)
+
+	clk.Step(30 * time.Minute)
+	_, ok := store.get(old.ID)
+	assert.False(t, ok, "expired artifacts are not served before cleanup runs")
+	assert.Len(t, store.list(), 1)
+
+	assert.Equal(t, 1, store.cleanup())
+	count, used := store.usage()
+	assert.Equal(t, 1, count)
+	assert.Equal(t, recent.Size, used)
+	assert.NoFileExists(t, store.contentPath(old.ID))
+	assert.NoFileExists(t, store.contentPath(old.ID)+artifactMetaSuffix)
+	assert.FileExists(t, store.contentPath(recent.ID))
+}
+
+func TestArtifactStore_RunCleansUpPeriodically(t *testing.T) {
+	store, clk := newTestArtifactStore(t, 1024)
+	_, err := store.put("tool", "", textArtifact("report.md", "content"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.run(ctx)
+
+	require.Eventually(t, clk.HasWaiters, time.Second, time.Millisecond)
+	clk.Step(time.Hour)
+	require.Eventually(t, func() bool {
+		count, _ := store.usage()
+		return count == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestArtifactStore_Quota(t *testing.T) {
+	store, clk := newTestArtifactStore(t, 10)
+
+	_, err := store.put("tool", "", textArtifact("huge.md", strings.Repeat("x", 11)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "larger than the artifact quota of 10 bytes")
+
+	first, err := store.put("tool", "", textArtifact("first.md", "aaaa"))
+	require.NoError(t, err)
+	clk.Step(time.Minute)
+	second, err := store.put("tool", "", textArtifact("second.md", "bbbb"))
+	require.NoError(t, err)
+	clk.Step(time.Minute)
+	third, err := store.put("tool", "", textArtifact("third.md", "cccc"))
+	require.NoError(t, err)
+
+	_, ok := store.get(first.ID)
+	assert.False(t, ok, "the oldest artifact is evicted to make room")
+	for _, meta := range []*artifactMeta{second, third} {
+		_, ok := store.get(meta.ID)
+		assert.True(t, ok, meta.Name)
+	}
+	_, used := store.usage()
+	assert.Equal(t, int64(8), used)
+}
+
+func TestArtifactStore_Append(t *testing.T) {
+	store, clk := newTestArtifactStore(t, 10)
+	older, err := store.put("tool", "", textArtifact("older.md", "aaaa"))
+	require.NoError(t, err)
+	clk.Step(time.Minute)
+
+	meta, writer, err := store.create("follow-pod-logs", "", "logs.log", "text/plain; charset=utf-8")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), meta.Size)
+	_, err = writer.Write([]byte("12345"))
+	require.NoError(t, err)
+	got, ok := store.get(meta.ID)
+	require.True(t, ok)
+	assert.Equal(t, int64(5), got.Size, "readers see the content written so far")
+
+	_, err = writer.Write([]byte("678"))
+	require.NoError(t, err)
+	_, ok = store.get(older.ID)
+	assert.False(t, ok, "growing past the quota evicts other artifacts")
+
+	_, err = writer.Write([]byte("901"))
+	assert.ErrorContains(t, err, "would grow past the artifact quota")
+	require.NoError(t, writer.Close())
+
+	reopened, err := newArtifactStore(store.dir, time.Hour, 10, clk)
+	require.NoError(t, err)
+	got, ok = reopened.get(meta.ID)
+	require.True(t, ok)
+	assert.Equal(t, int64(8), got.Size)
+}
+
+func TestArtifactStore_SurvivesRestart(t *testing.T) {
+	store, clk := newTestArtifactStore(t, 1024)
+	kept, err := store.put("tool", "", textArtifact("kept.md", "kept"))
+	require.NoError(t, err)
+	expired, err := store.put("tool", "", textArtifact("expired.md", "expired"))
+	require.NoError(t, err)
+	// Content lost without its metadata, as after a crash mid-removal
+	orphan, err := store.put("tool", "", textArtifact("orphan.md", "orphan"))
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(store.contentPath(orphan.ID)))
+
+	// An artifact that expires while the server is down
+	expiredMeta := *expired
+	expiredMeta.ExpiresAt = clk.Now().Add(time.Minute)
+	raw, err := json.Marshal(expiredMeta)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(store.contentPath(expired.ID)+artifactMetaSuffix, raw, 0o600))
+	clk.Step(2 * time.Minute)
+
+	reopened, err := newArtifactStore(store.dir, time.Hour, 1024, clk)
+	require.NoError(t, err)
+	list := reopened.list()
+	require.Len(t, list, 1)
+	assert.Equal(t, kept.ID, list[0].ID)
+	_, used := reopened.usage()
+	assert.Equal(t, kept.Size, used)
+	assert.NoFileExists(t, filepath.Join(store.dir, orphan.ID+artifactMetaSuffix))
+	assert.NoFileExists(t, filepath.Join(store.dir, expired.ID))
+}
+
+// newArtifactServer returns a stub tool server with an artifact store
+func newArtifactServer(t *testing.T, maxBytes int64) (*MCPServer, *testclock.FakeClock) {
+	t.Helper()
+	server := newStubToolServer(t, NewConfig())
+	var clk *testclock.FakeClock
+	server.artifacts, clk = newTestArtifactStore(t, maxBytes)
+	return server, clk
+}
+
+func TestExecuteTool_StoresArtifacts(t *testing.T) {
+	server, _ := newArtifactServer(t, 1<<20)
+	tool := &stubTool{name: "render", result: tools.ArtifactResult{
+		Summary:   map[string]string{"status": "healthy"},
+		Artifacts: []tools.Artifact{textArtifact("report.md", "# Report")},
+	}}
+
+	result, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{})
+	require.NoError(t, err)
+	object := result.(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"status": "healthy"}, object["summary"])
+
+	refs := object["artifacts"].([]interface{})
+	require.Len(t, refs, 1)
+	ref := refs[0].(map[string]interface{})
+	id := ref["id"].(string)
+	assert.Equal(t, "artifact://"+id, ref["uri"])
+	assert.Equal(t, "/artifacts/"+id, ref["url"])
+	assert.Equal(t, "report.md", ref["name"])
+	assert.EqualValues(t, 8, ref["size_bytes"])
+
+	meta, ok := server.artifacts.get(id)
+	require.True(t, ok)
+	assert.Equal(t, "render", meta.Tool)
+}
+
+func TestExecuteTool_ArtifactQuotaKeepsNothing(t *testing.T) {
+	server, _ := newArtifactServer(t, 10)
+	tool := &stubTool{name: "render", result: tools.ArtifactResult{
+		Artifacts: []tools.Artifact{textArtifact("report.md", "12345"), textArtifact("report.html", strings.Repeat("x", 20))},
+	}}
+
+	_, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "artifact quota")
+	count, used := server.artifacts.usage()
+	assert.Zero(t, count, "artifacts stored before the failure are removed")
+	assert.Zero(t, used)
+}
+
+func TestHandleArtifact(t *testing.T) {
+	server, clk := newArtifactServer(t, 1<<20)
+	meta, err := server.artifacts.put("render", "", tools.Artifact{Name: "report.html", ContentType: "text/html; charset=utf-8", Content: []byte("<h1>Report</h1>")})
+	require.NoError(t, err)
+
+	w := authRequest(server, http.MethodGet, "/artifacts/"+meta.ID, "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "<h1>Report</h1>", w.Body.String())
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "15", w.Header().Get("Content-Length"))
+	assert.Equal(t, `inline; filename="report.html"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "sandbox", w.Header().Get("Content-Security-Policy"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+
+	w = authRequest(server, http.MethodGet, "/artifacts/unknown", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = authRequest(server, http.MethodDelete, "/artifacts/"+meta.ID, "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	clk.Step(time.Hour)
+	w = authRequest(server, http.MethodGet, "/artifacts/"+meta.ID, "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code, "expired artifacts are gone")
+}
+
+func TestHandleArtifact_Disabled(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	w := authRequest(server, http.MethodGet, "/artifacts/0123", "", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "ARTIFACT_DIRECTORY")
+}
+
+func TestHandleArtifact_RequiresAuth(t *testing.T) {
+	server, _, _ := newAuthServer(t, true)
+	server.artifacts, _ = newTestArtifactStore(t, 1<<20)
+	meta, err := server.artifacts.put("render", "", textArtifact("report.md", "# Report"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusUnauthorized, authRequest(server, http.MethodGet, "/artifacts/"+meta.ID, "", nil).Code)
+	assert.Equal(t, http.StatusOK, authRequest(server, http.MethodGet, "/artifacts/"+meta.ID, "alice-token", nil).Code)
+}
+
+func TestHandleArtifact_TenantOwned(t *testing.T) {
+	server, _, _ := newAuthServer(t, true)
+	cfg := *server.config
+	cfg.TenantProfiles = []TenantProfile{{Name: "bob-team", Users: []string{"bob"}, Namespaces: []string{"bob-ns"}}}
+	server.liveConfig.Store(&cfg)
+	server.artifacts, _ = newTestArtifactStore(t, 1<<20)
+
+	// Artifacts are owned by the tenant of the call that produced them
+	tool := &stubTool{name: "render", result: tools.ArtifactResult{Artifacts: []tools.Artifact{textArtifact("report.md", "# Report")}}}
+	result, _, err := server.executeTool(withTenant(context.Background(), &cfg.TenantProfiles[0]), tool, map[string]interface{}{})
+	require.NoError(t, err)
+	id := result.(map[string]interface{})["artifacts"].([]interface{})[0].(map[string]interface{})["id"].(string)
+	owned, ok := server.artifacts.get(id)
+	require.True(t, ok)
+	assert.Equal(t, "bob-team", owned.Tenant)
+	other, err := server.artifacts.put("follow-pod-logs", "other-team", textArtifact("logs.log", "other"))
+	require.NoError(t, err)
+	shared, err := server.artifacts.put("render", "", textArtifact("report.md", "# Cluster"))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, authRequest(server, http.MethodGet, "/artifacts/"+owned.ID, "bob-token", nil).Code)
+	w := authRequest(server, http.MethodGet, "/artifacts/"+other.ID, "bob-token", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code, "other tenants' artifacts look expired")
+	assert.Contains(t, w.Body.String(), "not found or expired")
+	assert.Equal(t, http.StatusNotFound, authRequest(server, http.MethodGet, "/artifacts/"+shared.ID, "bob-token", nil).Code)
+	for _, meta := range []*artifactMeta{owned, other, shared} {
+		assert.Equal(t, http.StatusOK, authRequest(server, http.MethodGet, "/artifacts/"+meta.ID, "alice-token", nil).Code)
+	}
+
+	// Tenants list and read their own artifacts as resources
+	w = authRequest(server, http.MethodGet, "/mcp/resources", "bob-token", nil)
+	assert.Contains(t, w.Body.String(), "artifact://"+owned.ID)
+	assert.NotContains(t, w.Body.String(), "artifact://"+other.ID)
+	assert.NotContains(t, w.Body.String(), "artifact://"+shared.ID)
+	session := createSession(t, server, "bob-token", nil)
+	w = authRequest(server, http.MethodGet, "/mcp/resources/artifact%3A%2F%2F"+owned.ID+"/read?sessionid="+session, "bob-token", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "# Report", w.Body.String())
+	w = authRequest(server, http.MethodGet, "/mcp/resources/artifact%3A%2F%2F"+other.ID+"/read?sessionid="+session, "bob-token", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestArtifactResources(t *testing.T) {
+	server, clk := newArtifactServer(t, 1<<20)
+	meta, err := server.artifacts.put("generate-health-report", "", textArtifact("report.md", "# Report"))
+	require.NoError(t, err)
+
+	w := authRequest(server, http.MethodGet, "/mcp/resources", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Resources []struct {
+			URI       string     `json:"uri"`
+			Name      string     `json:"name"`
+			MimeType  string     `json:"mime_type"`
+			ExpiresAt *time.Time `json:"expires_at"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Resources, 1)
+	assert.Equal(t, "artifact://"+meta.ID, body.Resources[0].URI)
+	assert.Equal(t, "report.md", body.Resources[0].Name)
+	assert.Equal(t, "text/markdown; charset=utf-8", body.Resources[0].MimeType)
+	require.NotNil(t, body.Resources[0].ExpiresAt)
+	assert.True(t, meta.ExpiresAt.Equal(*body.Resources[0].ExpiresAt))
+
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+	read := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/mcp/resources/artifact%3A%2F%2F"+meta.ID+"/read?sessionid="+session.ID, nil)
+		w := httptest.NewRecorder()
+		server.httpHandler().ServeHTTP(w, req)
+		return w
+	}
+	w = read()
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "# Report", w.Body.String(), "artifacts are read as stored")
+	assert.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+
+	clk.Step(time.Hour)
+	assert.Equal(t, http.StatusNotFound, read().Code)
+	w = authRequest(server, http.MethodGet, "/mcp/resources", "", nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Empty(t, body.Resources, "expired artifacts are no longer listed")
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// errReadOnly is returned for mutating tools while READ_ONLY is set
+var errReadOnly = errors.New("server is in read-only mode")
+
+// MutatingTool is implemented by tools that change cluster or incident state.
+// Such tools are refused in read-only mode and every call is audited.
+type MutatingTool interface {
+	Mutating() bool
+}
+
+// isMutating reports whether tool declares itself as mutating
+func isMutating(tool Tool) bool {
+	mutating, ok := tool.(MutatingTool)
+	return ok && mutating.Mutating()
+}
+
+// AuditedTool is implemented by tools that only read but whose every call is
+// audited anyway, such as raw API access
+type AuditedTool interface {
+	Audited() bool
+}
+
+// isAudited reports whether calls to tool are written to the audit log
+func isAudited(tool Tool) bool {
+	if isMutating(tool) {
+		return true
+	}
+	audited, ok := tool.(AuditedTool)
+	return ok && audited.Audited()
+}
+
+// checkReadOnly refuses mutating tools while the server is read-only. Dry-runs
+// change nothing and are allowed.
+func (s *MCPServer) checkReadOnly(ctx context.Context, tool Tool) error {
+	if isMutating(tool) && !tools.IsDryRun(ctx) && s.currentConfig().ReadOnly {
+		return fmt.Errorf("%w: tool %s is not allowed", errReadOnly, tool.Name())
+	}
+	return nil
+}
+
+// AuditEntry records one call to a mutating or audited tool, or any call
+// made under a tenant profile
+type AuditEntry struct {
+	Time      time.Time   `json:"time"`
+	RequestID string      `json:"request_id,omitempty"`
+	User      string      `json:"user,omitempty"`   // Authenticated caller, when auth is enabled
+	Groups    []string    `json:"groups,omitempty"` // Groups of the authenticated caller
+	Tool      string      `json:"tool"`
+	Alias     string      `json:"alias,omitempty"` // Name the tool was called under, when it was an alias
+	Args      interface{} `json:"args"`
+	Outcome   string      `json:"outcome"`
+	Error     string      `json:"error,omitempty"`
+	DryRun    bool        `json:"dry_run,omitempty"` // The call only predicted its effect
+	// Tenant is the tenant profile the caller was scoped by, with its namespaces
+	Tenant           string   `json:"tenant,omitempty"`
+	TenantNamespaces []string `json:"tenant_namespaces,omitempty"`
+	// Policy is the remediation policy that acted, for actions no caller asked for
+	Policy string `json:"policy,omitempty"`
+}
+
+// auditToolCall writes an audit entry for a mutating or audited tool call.
+// Arguments are sanitized so credentials never reach the audit trail.
+func (s *MCPServer) auditToolCall(ctx context.Context, tool string, args map[string]interface{}, err error) {
+	entry := AuditEntry{
+		Time:      time.Now().UTC(),
+		RequestID: requestIDFromContext(ctx),
+		Tool:      tool,
+		Alias:     toolAliasFromContext(ctx),
+		Args:      args,
+		Outcome:   classifyToolOutcome(err),
+		DryRun:    tools.IsDryRun(ctx),
+	}
+	if principal, ok := clients.PrincipalFromContext(ctx); ok {
+		entry.User = principal.User
+		entry.Groups = principal.Groups
+	}
+	if tenant := tenantFromContext(ctx); tenant != nil {
+		entry.Tenant = tenant.Name
+		entry.TenantNamespaces = tenant.Namespaces
+	}
+	if s.sanitizer != nil {
+		if sanitized, sanitizeErr := s.sanitizer.Sanitize(args); sanitizeErr == nil {
+			entry.Args = sanitized
+		}
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	writeAuditEntry(entry)
+}
+
+// writeAuditEntry writes entry to the audit log
+func writeAuditEntry(entry AuditEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("AUDIT tool=%s outcome=%s (failed to encode entry: %v)", entry.Tool, entry.Outcome, err)
+		return
+	}
+	log.Printf("AUDIT %s", data)
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// mutatingStubTool is a stub tool that declares itself mutating and counts calls
+type mutatingStubTool struct {
+	stubTool
+	calls int
+}
+
+func (t *mutatingStubTool) Mutating() bool { return true }
+func (t *mutatingStubTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	t.calls++
+	return t.stubTool.Execute(ctx, args)
+}
+
+// captureLog redirects the standard logger for the duration of the test
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+	return &buf
+}
+
+// auditEntries decodes the AUDIT lines written to the log
+func auditEntries(t *testing.T, logs string) []AuditEntry {
+	t.Helper()
+	var entries []AuditEntry
+	for _, line := range strings.Split(logs, "\n") {
+		idx := strings.Index(line, "AUDIT ")
+		if idx < 0 {
+			continue
+		}
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal([]byte(line[idx+len("AUDIT "):]), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestExecuteTool_ReadOnlyBlocksMutatingTools(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ReadOnly = true
+	server := newStubToolServer(t, cfg)
+	server.toolMetrics = newToolMetrics()
+	logs := captureLog(t)
+
+	tool := &mutatingStubTool{stubTool: stubTool{name: "rollback-deployment"}}
+	_, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{"name": "web"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errReadOnly))
+	assert.Equal(t, 0, tool.calls)
+
+	// Read-only tools still run
+	_, _, err = server.executeTool(context.Background(), &stubTool{name: "list-pods"}, nil)
+	require.NoError(t, err)
+
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, "rollback-deployment", entries[0].Tool)
+	assert.Equal(t, outcomeBlocked, entries[0].Outcome)
+	assert.Contains(t, entries[0].Error, "read-only")
+
+	stats := server.toolMetrics.snapshot()
+	require.Len(t, stats, 2)
+	assert.Equal(t, int64(1), stats[1].Outcomes[outcomeBlocked])
+}
+
+func TestExecuteTool_AuditsMutatingTools(t *testing.T) {
+	cfg := NewConfig()
+	server := newStubToolServer(t, cfg)
+	server.sanitizer = tools.NewSanitizer(nil)
+	logs := captureLog(t)
+
+	tool := &mutatingStubTool{stubTool: stubTool{name: "rollback-deployment"}}
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-42")
+	_, _, err := server.executeTool(ctx, tool, map[string]interface{}{"name": "web", "token": "s3cr3t"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, tool.calls)
+
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, outcomeSuccess, entries[0].Outcome)
+	assert.Equal(t, "req-42", entries[0].RequestID)
+
+	args := entries[0].Args.(map[string]interface{})
+	assert.Equal(t, "web", args["name"])
+	assert.Equal(t, "<redacted>", args["token"])
+	assert.NotContains(t, logs.String(), "s3cr3t")
+}
+
+// auditedStubTool is a read-only stub tool that asks for its calls to be audited
+type auditedStubTool struct {
+	stubTool
+}
+
+func (t *auditedStubTool) Audited() bool { return true }
+
+func TestExecuteTool_AuditsAuditedReadOnlyTools(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ReadOnly = true
+	server := newStubToolServer(t, cfg)
+	logs := captureLog(t)
+
+	// Audited tools only read, so read-only mode does not block them
+	tool := &auditedStubTool{stubTool: stubTool{name: "raw-get"}}
+	_, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{"path": "/api/v1/nodes"})
+	require.NoError(t, err)
+
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, "raw-get", entries[0].Tool)
+	assert.Equal(t, outcomeSuccess, entries[0].Outcome)
+}
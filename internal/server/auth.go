@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// authCacheTTL bounds how long a reviewed token is trusted before the API
+// server is asked again, so revoked tokens stop working within a minute
+const authCacheTTL = time.Minute
+
+// Session metadata keys holding the user a session was created by
+const (
+	sessionUserKey   = "user"
+	sessionGroupsKey = "groups"
+)
+
+// isPublicPath reports whether path is served without authentication: the
+// probes, metrics, the API description, and the admin endpoints with their own token
+func isPublicPath(path string) bool {
+	switch path {
+	case "/health", "/ready", "/metrics", "/openapi.json", "/admin/reload", "/mcp/sessions":
+		return true
+	}
+	return false
+}
+
+// withAuth requires a bearer token on every non-public route when ENABLE_AUTH
+// is set. The token is checked with a TokenReview, and the user it belongs to
+// travels in the request context to sessions, the audit log, and CE calls.
+func (s *MCPServer) withAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg := s.currentConfig()
+		if !cfg.EnableAuth || isPublicPath(r.URL.Path) || r.URL.Path == cfg.CEWebhookPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSONError(w, http.StatusUnauthorized, "bearer token required")
+			return
+		}
+
+		principal, err := s.authenticate(r.Context(), token)
+		if errors.Is(err, clients.ErrUnauthenticated) {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeJSONError(w, http.StatusUnauthorized, "invalid bearer token")
+			return
+		}
+		if err != nil {
+			log.Printf("WARNING: unable to verify bearer token: %v", err)
+			writeJSONError(w, http.StatusServiceUnavailable, "unable to verify bearer token")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(clients.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// authenticate reviews token, caching accepted tokens for authCacheTTL.
+// Tokens are cached by their full SHA-256, never in the clear.
+func (s *MCPServer) authenticate(ctx context.Context, token string) (clients.Principal, error) {
+	review := func() (clients.Principal, error) {
+		return s.k8sClient.ReviewToken(ctx, token)
+	}
+	if s.cache == nil {
+		return review()
+	}
+	sum := sha256.Sum256([]byte(token))
+	return cache.GetOrSetTyped(ctx, s.cache, cache.Key("auth", hex.EncodeToString(sum[:])), authCacheTTL, review)
+}
+
+// bindSessionToPrincipal records the authenticated user in new session metadata,
+// replacing whatever the client sent under the same keys
+func bindSessionToPrincipal(ctx context.Context, metadata map[string]interface{}) map[string]interface{} {
+	principal, ok := clients.PrincipalFromContext(ctx)
+	if !ok {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[sessionUserKey] = principal.User
+	metadata[sessionGroupsKey] = principal.Groups
+	return metadata
+}
+
+// sessionOwnedByCaller reports whether the authenticated caller created the
+// session. Without authentication every caller may use every session.
+func (s *MCPServer) sessionOwnedByCaller(ctx context.Context, sessionID string) bool {
+	principal, ok := clients.PrincipalFromContext(ctx)
+	if !ok {
+		return true
+	}
+	session := s.sessionManager.GetSession(sessionID)
+	if session == nil {
+		return true // Reported as not found by the caller
+	}
+	user, _ := session.Metadata[sessionUserKey].(string)
+	return user == principal.User
+}
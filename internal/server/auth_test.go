@@ -0,0 +1,161 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newAuthServer serves create-incident against a Coordination Engine that
+// records the X-On-Behalf-Of header of each request. Tokens "<user>-token"
+// authenticate as <user>.
+func newAuthServer(t *testing.T, enableAuth bool) (server *MCPServer, onBehalfOf *[]string, reviews *atomic.Int32) {
+	t.Helper()
+	onBehalfOf = &[]string{}
+	ce := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*onBehalfOf = append(*onBehalfOf, r.Header.Get(clients.OnBehalfOfHeader))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"incident_id":"inc-1","status":"pending"}`))
+	}))
+	t.Cleanup(ce.Close)
+
+	reviews = &atomic.Int32{}
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews.Add(1)
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		switch review.Spec.Token {
+		case "alice-token":
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"sre"}}
+		case "bob-token":
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "bob"}
+		}
+		return true, review, nil
+	})
+
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	t.Cleanup(memoryCache.Close)
+
+	cfg := NewConfig()
+	cfg.EnableAuth = enableAuth
+	server = newStubToolServer(t, cfg)
+	server.k8sClient = clients.NewK8sClientWithClientset(clientset)
+	server.cache = memoryCache
+	server.registerTool(tools.NewCreateIncidentTool(testutil.NewCoordinationEngineClient(t, ce.URL)))
+	return server, onBehalfOf, reviews
+}
+
+// authRequest sends a request through the full handler chain
+func authRequest(server *MCPServer, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	server.httpHandler().ServeHTTP(w, req)
+	return w
+}
+
+// createSession opens a REST session and returns its ID
+func createSession(t *testing.T, server *MCPServer, token string, metadata map[string]interface{}) string {
+	t.Helper()
+	w := authRequest(server, http.MethodPost, "/mcp/session", token, metadata)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	return w.Header().Get("X-MCP-Session-ID")
+}
+
+var incidentArgs = map[string]interface{}{
+	"title":       "Crash loop in payments",
+	"description": "api restarts every minute",
+	"severity":    "high",
+}
+
+func TestWithAuth_RejectsMissingAndInvalidTokens(t *testing.T) {
+	server, _, _ := newAuthServer(t, true)
+
+	w := authRequest(server, http.MethodGet, "/mcp/tools", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+
+	w = authRequest(server, http.MethodGet, "/mcp/tools", "forged-token", nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Header().Get("WWW-Authenticate"), "invalid_token")
+
+	// Probes stay open for the kubelet
+	for _, path := range []string{"/health", "/ready"} {
+		assert.Equal(t, http.StatusOK, authRequest(server, http.MethodGet, path, "", nil).Code, path)
+	}
+
+	assert.Equal(t, http.StatusOK, authRequest(server, http.MethodGet, "/mcp/tools", "alice-token", nil).Code)
+}
+
+func TestWithAuth_PropagatesIdentity(t *testing.T) {
+	server, onBehalfOf, reviews := newAuthServer(t, true)
+	logs := captureLog(t)
+
+	// The session records the authenticated user, not what the client claims
+	sessionID := createSession(t, server, "alice-token", map[string]interface{}{"user": "mallory", "client": "lightspeed"})
+	session := server.sessionManager.GetSession(sessionID)
+	require.NotNil(t, session)
+	assert.Equal(t, "alice", session.Metadata[sessionUserKey])
+	assert.Equal(t, []string{"sre"}, session.Metadata[sessionGroupsKey])
+	assert.Equal(t, "lightspeed", session.Metadata["client"])
+
+	w := authRequest(server, http.MethodPost, "/mcp/tools/create-incident/call?sessionid="+sessionID, "alice-token", incidentArgs)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	// The Coordination Engine and the audit log both see the human behind the call
+	assert.Equal(t, []string{"alice"}, *onBehalfOf)
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, "alice", entries[0].User)
+	assert.Equal(t, []string{"sre"}, entries[0].Groups)
+
+	// Another user cannot ride on alice's session
+	w = authRequest(server, http.MethodPost, "/mcp/tools/create-incident/call?sessionid="+sessionID, "bob-token", incidentArgs)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = authRequest(server, http.MethodDelete, "/mcp/session/"+sessionID, "bob-token", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Len(t, *onBehalfOf, 1)
+
+	// Accepted tokens are reviewed once per authCacheTTL
+	assert.Equal(t, int32(2), reviews.Load(), "one review each for alice and bob")
+}
+
+func TestWithAuth_DisabledSendsNoIdentity(t *testing.T) {
+	server, onBehalfOf, reviews := newAuthServer(t, false)
+	logs := captureLog(t)
+
+	sessionID := createSession(t, server, "", nil)
+	w := authRequest(server, http.MethodPost, "/mcp/tools/create-incident/call?sessionid="+sessionID, "", incidentArgs)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	assert.Equal(t, []string{""}, *onBehalfOf, "no X-On-Behalf-Of header without auth")
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Empty(t, entries[0].User)
+	assert.Zero(t, reviews.Load())
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+const (
+	// baselineSubdirectory is where baselines are kept inside ARTIFACT_DIRECTORY
+	baselineSubdirectory = "baselines"
+	// maxBaselines bounds the number of stored baselines; replacing one is always allowed
+	maxBaselines = 50
+)
+
+// baselineStore keeps the named baselines of capture-baseline. With a
+// directory they are written there as JSON and outlive restarts; without one
+// they are kept in memory only. Unlike artifacts they never expire.
+type baselineStore struct {
+	dir string // Empty keeps baselines in memory
+
+	mu        sync.Mutex
+	baselines map[string]tools.ClusterBaseline
+}
+
+// baselineDirectory returns where baselines are persisted: a subdirectory of
+// ARTIFACT_DIRECTORY, or "" to keep them in memory when it is unset
+func baselineDirectory(cfg *Config) string {
+	if cfg.ArtifactDirectory == "" {
+		return ""
+	}
+	return filepath.Join(cfg.ArtifactDirectory, baselineSubdirectory)
+}
+
+// newBaselineStore creates the store in dir, picking up the baselines a
+// previous run left there. An empty dir keeps baselines in memory.
+func newBaselineStore(dir string) (*baselineStore, error) {
+	store := &baselineStore{dir: dir, baselines: make(map[string]tools.ClusterBaseline)}
+	if dir == "" {
+		return store, nil
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create baseline directory: %w", err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list baselines: %w", err)
+	}
+	for _, path := range paths {
+		raw, err := os.ReadFile(path) //nolint:gosec // Path is inside the baseline directory
+		if err != nil {
+			return nil, fmt.Errorf("failed to read baseline: %w", err)
+		}
+		var baseline tools.ClusterBaseline
+		if err := json.Unmarshal(raw, &baseline); err != nil || baseline.Name+".json" != filepath.Base(path) {
+			log.Printf("Ignoring invalid baseline %s", path)
+			continue
+		}
+		store.baselines[baseline.Name] = baseline
+	}
+	return store, nil
+}
+
+// SaveBaseline stores a baseline, replacing the one of the same name.
+// Names are validated by the tools, so they are safe as file names.
+func (b *baselineStore) SaveBaseline(baseline tools.ClusterBaseline) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, exists := b.baselines[baseline.Name]; !exists && len(b.baselines) >= maxBaselines {
+		return &tools.InvalidArgumentsError{Err: fmt.Errorf(
+			"%d baselines are stored, the maximum: capture under an existing name to replace one", maxBaselines)}
+	}
+	if b.dir != "" {
+		raw, err := json.Marshal(baseline)
+		if err != nil {
+			return fmt.Errorf("failed to encode baseline: %w", err)
+		}
+		if err := writeFileAtomic(filepath.Join(b.dir, baseline.Name+".json"), raw); err != nil {
+			return fmt.Errorf("failed to store baseline: %w", err)
+		}
+	}
+	b.baselines[baseline.Name] = baseline
+	return nil
+}
+
+// LoadBaseline returns the named baseline
+func (b *baselineStore) LoadBaseline(name string) (*tools.ClusterBaseline, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	baseline, ok := b.baselines[name]
+	if !ok {
+		stored := "none are stored; call capture-baseline first"
+		if len(b.baselines) > 0 {
+			stored = "stored: " + strings.Join(b.names(), ", ")
+		}
+		return nil, fmt.Errorf("%w: no baseline named %q (%s)", tools.ErrNotFound, name, stored)
+	}
+	return &baseline, nil
+}
+
+// BaselineNames returns the names of the stored baselines, sorted
+func (b *baselineStore) BaselineNames() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.names()
+}
+
+// names returns the sorted baseline names. Callers hold b.mu.
+func (b *baselineStore) names() []string {
+	names := make([]string, 0, len(b.baselines))
+	for name := range b.baselines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+func testBaseline(name string, workers string) tools.ClusterBaseline {
+	return tools.ClusterBaseline{
+		Name:       name,
+		CapturedAt: time.Date(2026, 3, 2, 6, 30, 0, 0, time.UTC),
+		Facts:      map[string]map[string]string{tools.BaselineFactNodeRoles: {"worker": workers}},
+	}
+}
+
+func TestBaselineStore_SurvivesRestart(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ArtifactDirectory = t.TempDir()
+	dir := baselineDirectory(cfg)
+	assert.Equal(t, filepath.Join(cfg.ArtifactDirectory, "baselines"), dir)
+
+	store, err := newBaselineStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.SaveBaseline(testBaseline("pre-upgrade", "3")))
+	require.NoError(t, store.SaveBaseline(testBaseline("default", "3")))
+	require.NoError(t, store.SaveBaseline(testBaseline("pre-upgrade", "5")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o600))
+
+	reopened, err := newBaselineStore(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "pre-upgrade"}, reopened.BaselineNames())
+	baseline, err := reopened.LoadBaseline("pre-upgrade")
+	require.NoError(t, err)
+	assert.Equal(t, testBaseline("pre-upgrade", "5"), *baseline)
+
+	// Baselines are not artifacts: the artifact store does not pick them up
+	artifacts, err := newArtifactStore(cfg.ArtifactDirectory, time.Hour, 1024, clock.RealClock{})
+	require.NoError(t, err)
+	count, _ := artifacts.usage()
+	assert.Equal(t, 0, count)
+}
+
+func TestBaselineStore_Memory(t *testing.T) {
+	store, err := newBaselineStore(baselineDirectory(NewConfig()))
+	require.NoError(t, err)
+
+	_, err = store.LoadBaseline("default")
+	assert.ErrorIs(t, err, tools.ErrNotFound)
+	assert.Contains(t, err.Error(), "call capture-baseline first")
+
+	for i := 0; i < maxBaselines; i++ {
+		require.NoError(t, store.SaveBaseline(testBaseline(fmt.Sprintf("b%d", i), "3")))
+	}
+	err = store.SaveBaseline(testBaseline("one-too-many", "3"))
+	assert.True(t, tools.IsInvalidArguments(err))
+	assert.NoError(t, store.SaveBaseline(testBaseline("b0", "4")), "replacing a baseline is always allowed")
+
+	_, err = store.LoadBaseline("missing")
+	assert.ErrorIs(t, err, tools.ErrNotFound)
+	assert.Contains(t, err.Error(), "stored: b0, b1")
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Integrations whose tools and resources follow their availability
+const (
+	integrationCoordinationEngine = "coordination_engine"
+	integrationKServe             = "kserve"
+)
+
+// capabilityProbeTimeout bounds each integration probe
+const capabilityProbeTimeout = 5 * time.Second
+
+// integration is a dependency enabled in the configuration whose tools and
+// resources are registered only while its probe succeeds
+type integration struct {
+	name      string
+	probe     func(ctx context.Context) error
+	tools     []Tool
+	resources []Resource
+	available bool
+	lastErr   error // Why the last probe failed
+}
+
+// newIntegrations returns the enabled integrations to probe, or nil when
+// probing is disabled and their tools are registered unconditionally
+func (s *MCPServer) newIntegrations() map[string]*integration {
+	if s.config.CapabilityProbeInterval <= 0 {
+		return nil
+	}
+
+	integrations := make(map[string]*integration)
+	if s.ceClient != nil {
+		integrations[integrationCoordinationEngine] = &integration{
+			name:  integrationCoordinationEngine,
+			probe: s.ceClient.HealthCheck,
+		}
+	}
+	if s.kserve != nil && s.k8sClient != nil {
+		integrations[integrationKServe] = &integration{
+			name: integrationKServe,
+			probe: func(ctx context.Context) error {
+				// The predictors live in the KServe namespace, so it must exist first
+				_, err := s.k8sClient.Clientset().CoreV1().Namespaces().Get(ctx, s.kserve.GetNamespace(), metav1.GetOptions{})
+				return err
+			},
+		}
+	}
+	return integrations
+}
+
+// probeCoordinationEngine checks at startup that the Coordination Engine
+// answers, unless the integration probe just did, and logs the exact error
+// when it does not, so that a wrong COORDINATION_ENGINE_URL shows up before
+// the first tool call times out
+func (s *MCPServer) probeCoordinationEngine(ctx context.Context) {
+	if s.ceClient == nil {
+		return
+	}
+	if _, checked := s.ceClient.Reachability(); !checked {
+		probeCtx, cancel := context.WithTimeout(ctx, capabilityProbeTimeout)
+		_ = s.ceClient.HealthCheck(probeCtx) // Recorded in Reachability
+		cancel()
+	}
+	if reachability, checked := s.ceClient.Reachability(); checked && !reachability.Reachable {
+		log.Printf("WARNING: Coordination Engine at %s is unreachable (%s): %s", reachability.URL, reachability.Stage, reachability.Error)
+	}
+}
+
+// registerIntegrationTool registers a tool of the named integration now if
+// the integration is available, and otherwise once its probe succeeds
+func (s *MCPServer) registerIntegrationTool(name string, tool Tool) {
+	s.integrationsMu.Lock()
+	integration := s.integrations[name]
+	if integration == nil || !s.config.IsToolEnabled(tool.Name()) {
+		s.integrationsMu.Unlock()
+		s.registerTool(tool)
+		return
+	}
+	integration.tools = append(integration.tools, tool)
+	available := integration.available
+	s.integrationsMu.Unlock()
+
+	if available {
+		s.registerTool(tool)
+	} else {
+		log.Printf("Skipping tool: %s (%s integration currently unavailable)", tool.Name(), name)
+	}
+}
+
+// registerIntegrationResource is registerIntegrationTool for resources
+func (s *MCPServer) registerIntegrationResource(name string, resource Resource) {
+	s.integrationsMu.Lock()
+	integration := s.integrations[name]
+	if integration == nil {
+		s.integrationsMu.Unlock()
+		s.registerResource(resource)
+		return
+	}
+	integration.resources = append(integration.resources, resource)
+	available := integration.available
+	s.integrationsMu.Unlock()
+
+	if available {
+		s.registerResource(resource)
+	} else {
+		log.Printf("Skipping resource: %s (%s integration currently unavailable)", resource.URI(), name)
+	}
+}
+
+// probeIntegrations probes every integration, registering the tools and
+// resources of those that came online and deregistering those that went
+// away. It returns whether any registration changed. Probes run without the
+// lock so that a slow dependency does not hold up tool calls.
+func (s *MCPServer) probeIntegrations(ctx context.Context) bool {
+	// The map is fixed once the server is built; only its entries change
+	names := make([]string, 0, len(s.integrations))
+	for name := range s.integrations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changed := false
+	for _, name := range names {
+		integration := s.integrations[name]
+		probeCtx, cancel := context.WithTimeout(ctx, capabilityProbeTimeout)
+		err := integration.probe(probeCtx)
+		cancel()
+
+		s.integrationsMu.Lock()
+		integration.lastErr = err
+		if available := err == nil; available != integration.available {
+			integration.available = available
+			changed = true
+			if available {
+				log.Printf("%s integration is available, registering its tools and resources", name)
+				s.registerIntegration(integration)
+			} else {
+				log.Printf("WARNING: %s integration is unavailable, deregistering its tools and resources: %v", name, err)
+				s.deregisterIntegration(integration)
+			}
+		}
+		s.integrationsMu.Unlock()
+	}
+	return changed
+}
+
+// registerIntegration registers the tools and resources of an integration
+func (s *MCPServer) registerIntegration(integration *integration) {
+	for _, tool := range integration.tools {
+		s.registerTool(tool)
+	}
+	for _, resource := range integration.resources {
+		s.registerResource(resource)
+	}
+}
+
+// deregisterIntegration removes the tools and resources of an integration
+// from the registries and the MCP SDK
+func (s *MCPServer) deregisterIntegration(integration *integration) {
+	names := make([]string, 0, len(integration.tools))
+	for _, tool := range integration.tools {
+		s.tools.Deregister(tool.Name())
+		names = append(names, tool.Name())
+		for _, alias := range toolAliases(tool) {
+			names = append(names, alias.Name)
+		}
+	}
+	for _, resource := range integration.resources {
+		s.resources.Deregister(resource.URI())
+	}
+
+	if len(names) > 0 {
+		s.mcpServer.RemoveTools(names...)
+	}
+}
+
+// downIntegration returns the unavailable integration providing the tool or
+// resource with the given name, if any
+func (s *MCPServer) downIntegration(name string) (string, bool) {
+	s.integrationsMu.Lock()
+	defer s.integrationsMu.Unlock()
+	for _, integration := range s.integrations {
+		if integration.available {
+			continue
+		}
+		for _, tool := range integration.tools {
+			if _, aliased := toolAlias(tool, name); tool.Name() == name || aliased {
+				return integration.name, true
+			}
+		}
+		for _, resource := range integration.resources {
+			if resource.URI() == name {
+				return integration.name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// unavailableIntegrations returns the last probe error of each unavailable integration
+func (s *MCPServer) unavailableIntegrations() map[string]string {
+	s.integrationsMu.Lock()
+	defer s.integrationsMu.Unlock()
+	var unavailable map[string]string
+	for name, integration := range s.integrations {
+		if integration.available {
+			continue
+		}
+		if unavailable == nil {
+			unavailable = make(map[string]string)
+		}
+		reason := "not probed yet"
+		if integration.lastErr != nil {
+			reason = integration.lastErr.Error()
+		}
+		unavailable[name] = reason
+	}
+	return unavailable
+}
+
+// writeIntegrationUnavailable reports a tool or resource whose integration is
+// down: unlike an unknown name, calling it again may succeed once the
+// integration is probed again, every probeInterval
+func writeIntegrationUnavailable(w http.ResponseWriter, kind, name, integration string, probeInterval time.Duration) {
+	w.Header().Set("Content-Type", "application/json")
+	if probeInterval <= 0 {
+		probeInterval = defaultRetryAfter
+	}
+	setRetryAfter(w, probeInterval)
+	w.WriteHeader(http.StatusServiceUnavailable)
+	response := map[string]interface{}{
+		"success":     false,
+		"error":       fmt.Sprintf("%s '%s' unavailable: %s integration currently unavailable", kind, name, integration),
+		"error_class": "integration_unavailable",
+		"retryable":   true,
+	}
+	if err := writeJSON(w, response); err != nil {
+		log.Printf("Error writing error response: %v", err)
+	}
+}
+
+// probeCapabilities re-checks the integrations and API discovery every
+// interval until ctx is done
+func (s *MCPServer) probeCapabilities(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.probeOnce(ctx)
+		}
+	}
+}
+
+// probeOnce runs one capability probe and rebuilds the OpenAPI document when
+// the registries changed
+func (s *MCPServer) probeOnce(ctx context.Context) {
+	changed := s.probeIntegrations(ctx)
+	if s.platform.hasPending() && s.registerServedTools(ctx) > 0 {
+		return // registerServedTools refreshed the document
+	}
+	if changed {
+		s.refreshOpenAPISpec()
+	}
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/resources"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newIntegrationServer serves one Coordination Engine tool and resource
+// against a fake Coordination Engine that answers health checks while up is set
+func newIntegrationServer(t *testing.T, cfg *Config) (*MCPServer, *atomic.Bool) {
+	t.Helper()
+	up := &atomic.Bool{}
+	ce := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(ce.Close)
+
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	t.Cleanup(memoryCache.Close)
+
+	server := newStubToolServer(t, cfg)
+	server.cache = memoryCache
+	server.ceClient = testutil.NewCoordinationEngineClient(t, ce.URL)
+	server.integrations = server.newIntegrations()
+	server.probeIntegrations(context.Background())
+
+	server.registerIntegrationTool(integrationCoordinationEngine, &stubTool{name: "list-incidents", result: "ok"})
+	server.registerIntegrationResource(integrationCoordinationEngine, resources.NewIncidentsResource(server.ceClient, memoryCache))
+	return server, up
+}
+
+func callTool(t *testing.T, server *MCPServer, sessionID, name string) *httptest.ResponseRecorder {
+	t.Helper()
+	return authRequest(server, http.MethodPost, "/mcp/tools/"+name+"/call?sessionid="+sessionID, "", map[string]interface{}{})
+}
+
+func TestCapabilityProbe_FollowsCoordinationEngine(t *testing.T) {
+	server, up := newIntegrationServer(t, NewConfig())
+	sessionID := createSession(t, server, "", nil)
+	incidentsPath := "/mcp/resources/" + url.PathEscape("cluster://incidents") + "/read?sessionid=" + sessionID
+
+	assertUnavailable := func() {
+		t.Helper()
+		_, ok := server.lookupTool("list-incidents")
+		assert.False(t, ok)
+
+		w := callTool(t, server, sessionID, "list-incidents")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "coordination_engine integration currently unavailable")
+		assert.Contains(t, w.Body.String(), `"error_class": "integration_unavailable"`)
+
+		w = authRequest(server, http.MethodGet, incidentsPath, "", nil)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, w.Body.String())
+
+		w = authRequest(server, http.MethodGet, "/mcp/info", "", nil)
+		var info MCPInfoResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+		assert.Contains(t, info.Unavailable[integrationCoordinationEngine], "status 503")
+	}
+
+	// Down at startup: nothing is registered, but callers learn why
+	assertUnavailable()
+	assert.Equal(t, http.StatusNotFound, callTool(t, server, sessionID, "no-such-tool").Code)
+
+	// The Coordination Engine is deployed
+	up.Store(true)
+	server.probeOnce(context.Background())
+	assert.Equal(t, http.StatusOK, callTool(t, server, sessionID, "list-incidents").Code)
+	w := authRequest(server, http.MethodGet, "/mcp/tools", "", nil)
+	assert.Contains(t, w.Body.String(), "list-incidents")
+	w = authRequest(server, http.MethodGet, "/mcp/resources", "", nil)
+	assert.Contains(t, w.Body.String(), "cluster://incidents")
+	assert.Contains(t, server.openAPISpec["paths"], "/mcp/tools/list-incidents/call")
+	assert.Empty(t, server.unavailableIntegrations())
+
+	// and goes away again
+	up.Store(false)
+	server.probeOnce(context.Background())
+	assertUnavailable()
+	assert.NotContains(t, server.openAPISpec["paths"], "/mcp/tools/list-incidents/call")
+}
+
+func TestCapabilityProbe_DisabledRegistersUnconditionally(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CapabilityProbeInterval = 0
+	server, _ := newIntegrationServer(t, cfg)
+
+	assert.Nil(t, server.integrations)
+	_, ok := server.lookupTool("list-incidents")
+	assert.True(t, ok, "registered although the Coordination Engine is down")
+	_, ok = server.lookupResource("cluster://incidents")
+	assert.True(t, ok)
+}
+
+func TestCapabilityProbe_ConcurrentReads(t *testing.T) {
+	server, up := newIntegrationServer(t, NewConfig())
+	sessionID := createSession(t, server, "", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for ctx.Err() == nil {
+			up.Store(!up.Load())
+			server.probeOnce(ctx)
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		code := callTool(t, server, sessionID, "list-incidents").Code
+		assert.Contains(t, []int{http.StatusOK, http.StatusServiceUnavailable}, code)
+		authRequest(server, http.MethodGet, "/mcp/tools", "", nil)
+		authRequest(server, http.MethodGet, "/mcp/resources", "", nil)
+		authRequest(server, http.MethodGet, "/openapi.json", "", nil)
+	}
+	cancel()
+	wg.Wait()
+}
+
+func TestProbeCoordinationEngine_ReportsUnreachable(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	ceClient, err := clients.NewCoordinationEngineClient("http://coordination-engin:8080/", clients.CoordinationEngineOptions{
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: &net.DNSError{Err: "no such host", Name: "coordination-engin", IsNotFound: true}}
+		},
+	})
+	require.NoError(t, err)
+	server.ceClient = ceClient
+	logs := captureLog(t)
+
+	server.probeCoordinationEngine(context.Background())
+	assert.Contains(t, logs.String(), "WARNING: Coordination Engine at http://coordination-engin:8080 is unreachable (dns)")
+	assert.Contains(t, logs.String(), "lookup coordination-engin: no such host")
+
+	// The liveness probe still passes, but says why the engine is missing
+	w := authRequest(server, http.MethodGet, "/health", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Body.String(), "OK\n"))
+	assert.Contains(t, w.Body.String(), "coordination_engine: unreachable (dns)")
+
+	w = authRequest(server, http.MethodGet, "/mcp/info", "", nil)
+	var info MCPInfoResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	require.NotNil(t, info.CoordinationEngine)
+	assert.False(t, info.CoordinationEngine.Reachable)
+	assert.Equal(t, "dns", info.CoordinationEngine.Stage)
+	assert.Contains(t, info.CoordinationEngine.Error, "lookup coordination-engin: no such host")
+}
+
+func TestHandleHealth_FollowsCoordinationEngine(t *testing.T) {
+	server, up := newIntegrationServer(t, NewConfig())
+	captureLog(t)
+
+	// Down when the integration probe ran at startup
+	w := authRequest(server, http.MethodGet, "/health", "", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "coordination_engine: unreachable (http): health check failed with status 503")
+
+	// Any later health check refreshes what is reported
+	up.Store(true)
+	require.NoError(t, server.ceClient.HealthCheck(context.Background()))
+	w = authRequest(server, http.MethodGet, "/health", "", nil)
+	assert.Equal(t, "OK", w.Body.String())
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// errCallAborted is what waiting callers get when the execution they share
+// ended without an outcome, e.g. by panicking
+var errCallAborted = errors.New("shared tool execution aborted")
+
+// sharedCall is one execution of a read-only tool that identical concurrent
+// calls wait for
+type sharedCall struct {
+	done    chan struct{} // Closed once the outcome is set
+	waiters int           // Calls waiting for the outcome, guarded by callCoalescer.mu
+	result  interface{}
+	meta    *ResponseMeta
+	err     error
+}
+
+// callCoalescer lets concurrent identical calls of read-only tools share one
+// execution, for agents that fan the same call out several times. Only calls
+// in flight are shared; nothing is kept once they complete. The zero value
+// is ready to use.
+type callCoalescer struct {
+	mu    sync.Mutex
+	calls map[string]*sharedCall
+}
+
+// waiting returns how many calls wait for the execution in flight for key
+func (c *callCoalescer) waiting(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if call, ok := c.calls[key]; ok {
+		return call.waiters
+	}
+	return 0
+}
+
+// do runs execute unless a call with the same key is in flight, in which
+// case it waits for that call's outcome instead and reports coalesced. A
+// waiting caller whose own context ends returns early. When the shared
+// execution was canceled by its own caller, waiting callers run execute
+// themselves rather than inherit the cancellation.
+func (c *callCoalescer) do(ctx context.Context, key string, execute func() (interface{}, *ResponseMeta, error)) (result interface{}, meta *ResponseMeta, coalesced bool, err error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		call.waiters++
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, nil, false, ctx.Err()
+		}
+		if errors.Is(call.err, context.Canceled) && ctx.Err() == nil {
+			result, meta, err = execute()
+			return result, meta, false, err
+		}
+		return call.result, call.meta, true, call.err
+	}
+
+	call := &sharedCall{done: make(chan struct{}), err: errCallAborted}
+	if c.calls == nil {
+		c.calls = make(map[string]*sharedCall)
+	}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.calls, key)
+		c.mu.Unlock()
+		close(call.done)
+	}()
+	call.result, call.meta, call.err = execute()
+	return call.result, call.meta, false, call.err
+}
+
+// coalesceKey identifies the calls that may share an execution: the same
+// tool with the same arguments, for the same caller and tenant, whose
+// results could otherwise differ
+func coalesceKey(ctx context.Context, tool Tool, args map[string]interface{}) string {
+	data, err := json.Marshal(args) // Sorts map keys, so argument order does not matter
+	if err != nil {
+		return "" // Not shared
+	}
+	user, tenant := "", ""
+	if principal, ok := clients.PrincipalFromContext(ctx); ok {
+		user = principal.User
+	}
+	if profile := tenantFromContext(ctx); profile != nil {
+		tenant = profile.Name
+	}
+	// A background call may be served stale data an interactive one must not share
+	return cache.Key(tool.Name(), user, tenant, string(cache.PriorityFromContext(ctx)), cache.Hash(string(data)))
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingTool counts its executions, which block until release is closed
+type blockingTool struct {
+	stubTool
+	executions atomic.Int32
+	release    chan struct{}
+	mutating   bool
+}
+
+func (t *blockingTool) Mutating() bool { return t.mutating }
+func (t *blockingTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	t.executions.Add(1)
+	<-t.release
+	return map[string]interface{}{"namespace": args["namespace"]}, nil
+}
+
+// callConcurrently makes n calls of tool with args, releasing the tool once
+// the other n-1 wait for the first, and returns each call's meta
+func callConcurrently(t *testing.T, server *MCPServer, tool *blockingTool, n int, args func(i int) map[string]interface{}, waiters int) []*ResponseMeta {
+	t.Helper()
+	metas := make([]*ResponseMeta, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, meta, err := server.executeTool(context.Background(), tool, args(i))
+			assert.NoError(t, err)
+			assert.Equal(t, args(i)["namespace"], result.(map[string]interface{})["namespace"])
+			metas[i] = meta
+		}()
+	}
+	key := coalesceKey(context.Background(), tool, args(0))
+	require.Eventually(t, func() bool { return server.coalescer.waiting(key) == waiters }, 5*time.Second, time.Millisecond)
+	close(tool.release)
+	wg.Wait()
+	return metas
+}
+
+func TestCoalesce_IdenticalCallsShareOneExecution(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	tool := &blockingTool{stubTool: stubTool{name: "list-pods"}, release: make(chan struct{})}
+	args := func(int) map[string]interface{} { return map[string]interface{}{"namespace": "shop"} }
+
+	metas := callConcurrently(t, server, tool, 5, args, 4)
+	assert.Equal(t, int32(1), tool.executions.Load())
+	coalesced := 0
+	for _, meta := range metas {
+		if meta.Coalesced {
+			coalesced++
+		}
+	}
+	assert.Equal(t, 4, coalesced, "every caller but the first is marked coalesced")
+	assert.Empty(t, server.coalescer.calls, "nothing is kept once the calls complete")
+}
+
+func TestCoalesce_DifferentArgsExecuteSeparately(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	tool := &blockingTool{stubTool: stubTool{name: "list-pods"}, release: make(chan struct{})}
+	namespaces := []string{"shop", "payments", "search"}
+	args := func(i int) map[string]interface{} { return map[string]interface{}{"namespace": namespaces[i]} }
+
+	metas := callConcurrently(t, server, tool, 3, args, 0)
+	require.Eventually(t, func() bool { return tool.executions.Load() == 3 }, 5*time.Second, time.Millisecond)
+	for _, meta := range metas {
+		assert.False(t, meta.Coalesced)
+	}
+}
+
+func TestCoalesce_MutatingToolsAreNeverShared(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	tool := &blockingTool{stubTool: stubTool{name: "rollback-deployment"}, release: make(chan struct{}), mutating: true}
+	close(tool.release)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, meta, err := server.executeTool(context.Background(), tool, map[string]interface{}{"namespace": "shop"})
+			assert.NoError(t, err)
+			assert.False(t, meta.Coalesced)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(3), tool.executions.Load())
+}
+
+func TestCoalescer_CanceledLeaderDoesNotFailFollowers(t *testing.T) {
+	var coalescer callCoalescer
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	started, executions := make(chan struct{}), atomic.Int32{}
+
+	leaderDone := make(chan error)
+	go func() {
+		_, _, _, err := coalescer.do(leaderCtx, "key", func() (interface{}, *ResponseMeta, error) {
+			executions.Add(1)
+			close(started)
+			<-leaderCtx.Done()
+			return nil, nil, leaderCtx.Err()
+		})
+		leaderDone <- err
+	}()
+	<-started
+
+	followerDone := make(chan bool)
+	go func() {
+		result, _, coalesced, err := coalescer.do(context.Background(), "key", func() (interface{}, *ResponseMeta, error) {
+			executions.Add(1)
+			return "fresh", &ResponseMeta{}, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "fresh", result)
+		followerDone <- coalesced
+	}()
+	require.Eventually(t, func() bool { return coalescer.waiting("key") == 1 }, 5*time.Second, time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-leaderDone, context.Canceled)
+	assert.False(t, <-followerDone, "the follower ran the call itself")
+	assert.Equal(t, int32(2), executions.Load())
+}
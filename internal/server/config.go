@@ -1,10 +1,25 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/resources"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/policy"
 )
 
 // TransportType defines the MCP transport protocol
@@ -18,25 +33,69 @@ const (
 	// DEPRECATED: stdio transport is no longer supported as of 2025-12-17
 	// Use HTTP transport for all use cases including local development
 	TransportStdio TransportType = "stdio"
+	// TransportWebSocket serves everything TransportHTTP does, plus the MCP
+	// protocol over a WebSocket at /mcp/ws for clients behind proxies that
+	// buffer or cut long-lived SSE streams
+	TransportWebSocket TransportType = "websocket"
+)
+
+// ServesHTTP reports whether the transport listens on the HTTP address
+func (t TransportType) ServesHTTP() bool {
+	return t == TransportHTTP || t == TransportWebSocket
+}
+
+// Cluster backends the tools can read
+const (
+	BackendKubernetes = "kubernetes" // The cluster of the kubeconfig or service account
+	BackendMock       = "mock"       // A simulated cluster, see pkg/mockcluster
 )
 
 // Config holds the MCP server configuration
 type Config struct {
-	// Transport specifies the protocol (http or stdio)
+	// Transport specifies the protocol (http, websocket or stdio)
 	// Default: http (for OpenShift Lightspeed)
 	Transport TransportType
 
+	// Backend is the cluster tools and resources read: the real one, or a
+	// simulated one for demos and tests that scenarios mutate at runtime
+	Backend         string // Default: "kubernetes"
+	MockFixturesDir string // Fixture files seeding the mock backend ("" uses the built-in fixtures)
+
 	// HTTP Transport Settings
 	HTTPHost string // Default: "0.0.0.0"
 	HTTPPort int    // Default: 8080
 
+	// HTTP Server Limits
+	// There is deliberately no write timeout: SSE streams stay open indefinitely.
+	HTTPReadHeaderTimeout time.Duration // Max time to read request headers
+	HTTPReadTimeout       time.Duration // Max time to read the full request including body
+	HTTPIdleTimeout       time.Duration // Max keep-alive idle time between requests
+	MaxRequestBodyBytes   int64         // Larger request bodies are rejected with 413
+
+	// WebSocket Transport Settings (applied to new connections)
+	WebSocketMaxMessageBytes int           // Connections sending a larger message are closed
+	WebSocketPingInterval    time.Duration // How often idle connections are pinged (0 disables keepalives)
+
+	// Event Streams (/stream/health and /stream/events)
+	StreamHeartbeatInterval time.Duration // How often idle streams get a comment line (0 disables heartbeats)
+	StreamReplaySize        int           // Events kept per stream for clients reconnecting with Last-Event-ID
+
+	// Pod Log Streams (/mcp/stream/logs and follow-pod-logs)
+	LogStreamMaxConcurrent int           // Log streams followed at once, SSE and jobs together (0 disables log streaming)
+	LogStreamMaxDuration   time.Duration // A log stream ends after this long
+	LogStreamMaxBytes      int64         // A log stream ends after relaying this many bytes of log
+
 	// Server Metadata
 	Name    string // Default: "openshift-cluster-health"
 	Version string // Default: "0.1.0"
 
+	// Logging
+	LogLevel string // Lowest level logged: debug, info, warn or error
+
 	// Integration Endpoints
 	CoordinationEngineURL string // Coordination Engine base URL
 	PrometheusURL         string // Prometheus API URL
+	AlertmanagerURL       string // Alertmanager API URL
 	KServeNamespace       string // KServe models namespace
 	KServePredictorPort   int    // KServe predictor port (8080 for RawDeployment, 80 for Serverless)
 
@@ -44,67 +103,1677 @@ type Config struct {
 	EnableCoordinationEngine bool // Enable Coordination Engine integration
 	EnablePrometheus         bool // Enable Prometheus integration
 	EnableKServe             bool // Enable KServe ML model integration
+	EnableAlertmanager       bool // Enable Alertmanager integration (alert silences)
+
+	// Alert Silences (create-alert-silence)
+	AlertSilenceMaxDuration time.Duration // Longest silence that can be created
+
+	// Coordination Engine Requests (engines behind OpenShift OAuth or custom certificates)
+	CoordinationEngineBearerTokenFile string // Token file sent as a bearer token with every Coordination Engine request
+	CoordinationEngineCAFile          string // PEM bundle trusted for Coordination Engine routes with custom certificates
+
+	// KServe Requests (models behind OpenShift OAuth, Istio, or custom certificates)
+	KServeBearerTokenFile string        // Token file sent as a bearer token with every KServe request
+	KServeHeaders         []string      // Extra "Name: value" headers sent with every KServe request
+	KServeCAFile          string        // PEM bundle trusted for KServe routes with custom certificates
+	KServeMaxRetries      int           // Retries for inference calls on 429/502/503/504, timeouts, and refused connections
+	KServeRetryBudget     time.Duration // Cap on total inference time including retries
+	KServeForecastModel   string        // InferenceService forecast-capacity sends utilization series to
+
+	// Coordination Engine Incident Stream (pushes cluster://incidents changes to subscribed clients)
+	CEWebhookPath          string        // Path the engine posts signed incident events to
+	CEWebhookSecret        string        // HMAC secret incident events are signed with (empty disables the webhook)
+	CEIncidentPollInterval time.Duration // How often incidents are polled for changes, for engines without the webhook (0 disables)
 
 	// Performance Settings
 	CacheTTL           time.Duration // Cache TTL for Kubernetes API responses
 	RequestTimeout     time.Duration // HTTP client timeout
-	MaxConcurrentTools int           // Max concurrent tool executions
+	MaxConcurrentTools int           // Max concurrent executions of light tools
+	SlowToolThreshold  time.Duration // Log a warning for tool calls slower than this (0 disables)
+	MaxResultBytes     int           // Tool results larger than this are truncated (0 disables; _max_bytes overrides per call)
+	K8sClientQPS       float64       // Client-side rate limit for Kubernetes API requests
+	K8sClientBurst     int           // Requests allowed above K8sClientQPS in a burst
+	HealthConcurrency  int           // Cluster health sections read from the API server at once
+
+	// HealthRules are "rule=threshold[:status]" entries over the built-in
+	// cluster health status rules, see clients.ParseHealthRules
+	HealthRules []string
+
+	// Tool Scheduling: heavy tools (log searches, reports, forecasts) run in
+	// slots of their own, so they never hold those of light tools
+	MaxConcurrentHeavyTools int           // Max concurrent executions of heavy tools
+	ToolQueueTimeout        time.Duration // How long a call waits for a free slot of its class before it is refused (0 refuses at once)
+
+	// Dependency Failures
+	DependencyFailureTTL time.Duration // How long CE and KServe connection failures fail calls fast (0 disables)
+
+	// Health History (served by /export/health)
+	HealthHistoryInterval time.Duration // How often cluster health is sampled (0 disables history)
+	HealthHistorySize     int           // Samples kept; the oldest are dropped first
+
+	// Event History (aggregate-events source "history", beyond the API server's event TTL)
+	EventHistoryEnabled        bool          // Record cluster events into the local event history
+	EventHistoryRetention      time.Duration // How long recorded events are kept
+	EventHistoryMaxRecords     int           // Events kept; the oldest are evicted first
+	EventHistoryFile           string        // File the history is persisted to, on a persistent volume ("" = memory only)
+	EventHistoryLeaderElection bool          // Only the replica holding the event history Lease records events
+	EventHistoryLeaseNamespace string        // Namespace of the event history Lease
+
+	// Capability probing: registers the tools of integrations and API groups as they come and go
+	CapabilityProbeInterval time.Duration // How often integrations and API discovery are re-checked (0 disables)
+
+	// RBAC self-check: tools whose required permissions are all denied are refused until granted
+	PermissionCheckInterval time.Duration // How often the self-check is repeated after startup (0 checks only at startup)
+
+	// Authentication (bearer tokens checked with a Kubernetes TokenReview)
+	EnableAuth bool // Require a bearer token on every route except probes, metrics, and /openapi.json
+
+	// Cache Warm-up (pre-computes the expensive resources at startup)
+	EnableCacheWarmup  bool          // Warm the cluster health, nodes, and CE resources before the first request
+	CacheWarmupTimeout time.Duration // Total time budget for the warm-up; what is not warmed by then loads lazily
+	ReadyAfterWarmup   bool          // Report /ready only once the warm-up has finished
+
+	// Session Persistence (REST sessions survive restarts and rolling updates)
+	SessionStore          string // "" (memory only), "file", "configmap", or "secret"
+	SessionStorePath      string // Snapshot file of the file store, on a persistent volume
+	SessionStoreNamespace string // Namespace of the configmap or secret store
+	SessionStoreName      string // Name of the configmap or secret store
+	SessionStoreMaxBytes  int    // Persisted snapshot cap; the least recently used sessions are dropped first
+
+	// Tool Selection (names or glob patterns, e.g. "list-*")
+	EnabledTools  []string // Only register matching tools (empty = all tools)
+	DisabledTools []string // Never register matching tools
+
+	// ReadOnly refuses mutating tools (rollbacks, remediation) while keeping them listed
+	ReadOnly bool
+
+	// Kubernetes Events for mutating tool calls, on the affected object or in ActionEventsNamespace
+	EmitActionEvents      bool
+	ActionEventsNamespace string // Namespace of events for actions without one object, normally the server's own
+
+	// Output Redaction
+	RedactionPatterns []string // Extra key patterns masked in tool output (added to the built-in list)
+
+	// Resource Access
+	AllowedNamespaces   []string // Namespaces namespace-scoped tools and resources may read (empty = all)
+	ManifestDeniedKinds []string // Kinds get-resource-manifest refuses to return
+
+	// TenantProfiles scope matching callers to namespaces and tools (config file only)
+	TenantProfiles []TenantProfile
+
+	// Resources
+	NamespacesResourceMaxEntries int      // Namespaces cluster://namespaces lists before it only counts the healthy ones
+	EventSeverityRules           []string // "Reason=critical|warning|info" entries added to the cluster://events rules
+	EventsResourceMaxPerBucket   int      // Events cluster://events lists per severity before it only counts them
+	EventsResourceIncludeInfo    bool     // List info events in cluster://events instead of only counting them
+
+	// Raw API
+	RawAPIAllowedPrefixes []string // API paths raw-get may read under (empty = raw-get disabled)
+	RawAPIDeniedResources []string // Resources raw-get refuses in addition to secrets and tokenreviews
+
+	// Extended Resources
+	ExtendedResources []string // Resource names checked by get-extended-resource-health
+
+	// Noisy Neighbors
+	CriticalNamespaces []string // Namespaces whose pods detect-noisy-neighbors reports as affected
+
+	// Finalizer Audit
+	FinalizerAuditKinds []string // Extra "apiVersion/Kind" entries audit-finalizers scans for stuck deletions
+
+	// Kubelet Health
+	KubeletLeaseStaleAfter  time.Duration // Node lease age after which get-kubelet-health reports the kubelet as stale
+	KubeletStatusStaleAfter time.Duration // Node condition heartbeat age after which get-kubelet-health reports it as stale
+	KubeletHealthzProbe     bool          // Let get-kubelet-health call the kubelet /healthz through the API server node proxy
+
+	// Must-gather (OpenShift only)
+	MustGatherImage          string        // Image run by trigger-must-gather
+	MustGatherNamespace      string        // Namespace the must-gather Job runs in
+	MustGatherServiceAccount string        // Service account for the Job (needs cluster-wide read access)
+	MustGatherPVC            string        // PVC the archive is written to (empty = keep it in the pod)
+	MustGatherRetention      time.Duration // How long the pod keeps the archive when no PVC is set
+
+	// Scheduled health reports (no schedule = disabled)
+	ReportSchedule           string   // Cron expression ("0 7 * * 1-5") or descriptor ("@daily") the report is generated on
+	ReportSections           []string // Report sections to include (empty = all)
+	ReportWebhookURL         string   // Generic or Slack incoming webhook the report is posted to
+	ReportConfigMapNamespace string   // Namespace of the report ConfigMap
+	ReportConfigMapName      string   // ConfigMap holding the latest report, for GitOps pickup (empty = not written)
+	ReportDirectory          string   // Directory each report is written to as Markdown and HTML (empty = not written)
+	ReportLeaderElection     bool     // Only the replica holding the reports Lease generates reports
+	ReportLeaseNamespace     string   // Namespace of the reports Lease
+
+	// Automatic remediation policies, evaluated after each health history sample
+	RemediationPolicies        []policy.Rule // Condition-to-action rules (config file only)
+	RemediationPoliciesEnabled bool          // Kill switch: no policy acts while false
+	RemediationWebhookURL      string        // Generic or Slack incoming webhook every policy action is announced on (empty = Events and audit log only)
+	RemediationLeaderElection  bool          // Only the replica holding the remediation Lease acts
+	RemediationLeaseNamespace  string        // Namespace of the remediation Lease
+
+	// Tool-result artifacts (no directory = disabled)
+	ArtifactDirectory string        // Directory (e.g. a PVC mount) artifacts are stored in
+	ArtifactTTL       time.Duration // How long an artifact is kept
+	ArtifactMaxBytes  int64         // Total size of stored artifacts; the oldest are evicted past it
+
+	// Observability
+	OTLPEndpoint string // OTLP/HTTP trace collector URL (empty disables trace export)
+
+	// CORS for browser clients on /mcp routes (no allowed origins = CORS disabled)
+	CORSAllowedOrigins   []string      // Exact origins, or "*" for any origin
+	CORSAllowedMethods   []string      // Methods allowed in preflight responses
+	CORSAllowedHeaders   []string      // Request headers allowed in preflight responses
+	CORSMaxAge           time.Duration // How long browsers may cache preflight results
+	CORSAllowCredentials bool          // Allow cookies and Authorization headers
+
+	// Admin Settings
+	AdminToken string // Bearer token for /admin endpoints (empty disables them)
+
+	// ConfigFile is the YAML file the configuration was loaded from (empty if env-only)
+	ConfigFile string
 }
 
 // NewConfig creates a Config from environment variables with sensible defaults
 func NewConfig() *Config {
-	cfg := &Config{
+	cfg := defaultConfig()
+	applyEnvOverrides(cfg)
+	return cfg
+}
+
+// LoadConfig creates a Config from an optional YAML file, then applies
+// environment variable overrides. Precedence: env > file > defaults.
+// An empty path behaves like NewConfig.
+func LoadConfig(path string) (*Config, error) {
+	cfg := defaultConfig()
+
+	if path != "" {
+		if err := applyConfigFile(cfg, path); err != nil {
+			return nil, err
+		}
+		cfg.ConfigFile = path
+	}
+
+	applyEnvOverrides(cfg)
+	return cfg, nil
+}
+
+// defaultConfig returns the built-in configuration defaults
+func defaultConfig() *Config {
+	return &Config{
 		// Transport (default: HTTP for OpenShift Lightspeed)
 		// stdio transport DEPRECATED as of 2025-12-17
-		Transport: getEnvTransport("MCP_TRANSPORT", TransportHTTP),
+		Transport: TransportHTTP,
 
 		// HTTP Settings
-		HTTPHost: getEnv("MCP_HTTP_HOST", "0.0.0.0"),
-		HTTPPort: getEnvInt("MCP_HTTP_PORT", 8080),
+		HTTPHost: "0.0.0.0",
+		HTTPPort: 8080,
+
+		Backend: BackendKubernetes,
+
+		// HTTP Server Limits
+		HTTPReadHeaderTimeout: 10 * time.Second,
+		HTTPReadTimeout:       30 * time.Second,
+		HTTPIdleTimeout:       120 * time.Second,
+		MaxRequestBodyBytes:   1 << 20, // 1MB
+
+		// WebSocket Transport Settings
+		WebSocketMaxMessageBytes: 1 << 20, // 1MB
+		WebSocketPingInterval:    30 * time.Second,
+
+		// Below the 30 second idle timeout of OpenShift routers
+		StreamHeartbeatInterval: 15 * time.Second,
+		StreamReplaySize:        256,
+
+		LogStreamMaxConcurrent: 5,
+		LogStreamMaxDuration:   10 * time.Minute,
+		LogStreamMaxBytes:      10 << 20, // 10MB
 
 		// Server Metadata
-		Name:    getEnv("MCP_SERVER_NAME", "openshift-cluster-health"),
-		Version: getEnv("MCP_SERVER_VERSION", "0.1.0"),
+		Name:    "openshift-cluster-health",
+		Version: "0.1.0",
+
+		LogLevel: LogLevelInfo,
 
 		// Integration Endpoints
-		CoordinationEngineURL: getEnv("COORDINATION_ENGINE_URL", "http://coordination-engine:8080"),
-		PrometheusURL:         getEnv("PROMETHEUS_URL", "https://prometheus-k8s.openshift-monitoring.svc:9091"),
-		KServeNamespace:       getEnv("KSERVE_NAMESPACE", "self-healing-platform"),
-		KServePredictorPort:   getEnvInt("KSERVE_PREDICTOR_PORT", 8080), // Default 8080 for RawDeployment mode
+		CoordinationEngineURL: "http://coordination-engine:8080",
+		PrometheusURL:         "https://prometheus-k8s.openshift-monitoring.svc:9091",
+		AlertmanagerURL:       "https://alertmanager-main.openshift-monitoring.svc:9094",
+		KServeNamespace:       "self-healing-platform",
+		KServePredictorPort:   8080, // Default 8080 for RawDeployment mode
 
 		// Feature Flags
-		EnableCoordinationEngine: getEnvBool("ENABLE_COORDINATION_ENGINE", false), // Disabled by default (Phase 1)
-		EnablePrometheus:         getEnvBool("ENABLE_PROMETHEUS", false),          // Disabled by default (Phase 3)
-		EnableKServe:             getEnvBool("ENABLE_KSERVE", false),              // Disabled by default (Phase 4)
+		EnableCoordinationEngine: false, // Disabled by default (Phase 1)
+		EnablePrometheus:         false, // Disabled by default (Phase 3)
+		EnableKServe:             false, // Disabled by default (Phase 4)
+		EnableAlertmanager:       false, // Disabled by default: silencing alerts is opt-in
+
+		AlertSilenceMaxDuration: 24 * time.Hour,
+
+		KServeMaxRetries:    2,
+		KServeRetryBudget:   30 * time.Second,
+		KServeForecastModel: "capacity-forecast",
+
+		CEWebhookPath: "/webhooks/coordination-engine",
 
 		// Performance Settings
-		CacheTTL:           getEnvDuration("CACHE_TTL", 30*time.Second),
-		RequestTimeout:     getEnvDuration("REQUEST_TIMEOUT", 10*time.Second),
-		MaxConcurrentTools: getEnvInt("MAX_CONCURRENT_TOOLS", 10),
+		CacheTTL:           30 * time.Second,
+		RequestTimeout:     10 * time.Second,
+		MaxConcurrentTools: 16,
+		SlowToolThreshold:  5 * time.Second,
+		MaxResultBytes:     256 * 1024,
+		K8sClientQPS:       50,
+		K8sClientBurst:     100,
+		HealthConcurrency:  3,
+
+		MaxConcurrentHeavyTools: 2,
+		ToolQueueTimeout:        5 * time.Second,
+
+		// A dead dependency costs one timeout per 15 seconds
+		DependencyFailureTTL: 15 * time.Second,
+
+		// One day of health history at one sample per minute
+		HealthHistoryInterval: time.Minute,
+		HealthHistorySize:     1440,
+
+		// A day of events, well past the API server's one hour
+		EventHistoryRetention:      24 * time.Hour,
+		EventHistoryMaxRecords:     20000,
+		EventHistoryLeaderElection: true,
+		EventHistoryLeaseNamespace: "self-healing-platform",
+
+		NamespacesResourceMaxEntries: 200,
+		EventsResourceMaxPerBucket:   25,
+
+		// Tools of a Coordination Engine deployed after the server appear within 30 seconds
+		CapabilityProbeInterval: 30 * time.Second,
+
+		// Tools degraded for missing RBAC come back within 5 minutes of it being granted
+		PermissionCheckInterval: 5 * time.Minute,
+
+		// Warm-up is opt-in; when enabled it never delays startup more than 30 seconds
+		CacheWarmupTimeout: 30 * time.Second,
+
+		// Mutating tool calls show up in `oc get events`
+		EmitActionEvents:      true,
+		ActionEventsNamespace: "self-healing-platform",
+
+		// Sessions live in memory only unless a store is chosen; ConfigMaps and
+		// Secrets hold at most 1MiB
+		SessionStoreNamespace: "self-healing-platform",
+		SessionStoreName:      "cluster-health-mcp-sessions",
+		SessionStoreMaxBytes:  512 * 1024,
+
+		// CORS (disabled until origins are configured)
+		CORSAllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodOptions},
+		CORSAllowedHeaders: []string{"Content-Type", "Authorization", "X-MCP-Session-ID", "X-Request-ID", "If-None-Match", "X-MCP-Priority"},
+		CORSMaxAge:         10 * time.Minute,
+
+		// Extended resources (GPUs and huge pages)
+		ExtendedResources: []string{"nvidia.com/gpu", "hugepages-2Mi", "hugepages-1Gi"},
+
+		// Namespaces hosting latency-sensitive platform components
+		CriticalNamespaces: []string{"kube-system", "openshift-etcd", "openshift-kube-apiserver", "openshift-ingress", "openshift-dns"},
+
+		// Kubelets renew their lease every 10s but only rewrite unchanged node status every 5m
+		KubeletLeaseStaleAfter:  time.Minute,
+		KubeletStatusStaleAfter: 10 * time.Minute,
+
+		// Must-gather
+		MustGatherImage:     "quay.io/openshift/origin-must-gather:latest",
+		MustGatherNamespace: "self-healing-platform",
+		MustGatherRetention: time.Hour,
+
+		ReportConfigMapNamespace: "self-healing-platform",
+		ReportLeaseNamespace:     "self-healing-platform",
+
+		RemediationLeaderElection: true,
+		RemediationLeaseNamespace: "self-healing-platform",
+
+		ArtifactTTL:      24 * time.Hour,
+		ArtifactMaxBytes: 512 * 1024 * 1024,
 	}
+}
 
-	return cfg
+// applyEnvOverrides overwrites cfg fields with any environment variables that are set
+func applyEnvOverrides(cfg *Config) {
+	cfg.Transport = getEnvTransport("MCP_TRANSPORT", cfg.Transport)
+
+	cfg.Backend = getEnv("BACKEND", cfg.Backend)
+	cfg.MockFixturesDir = getEnv("MOCK_FIXTURES_DIR", cfg.MockFixturesDir)
+
+	cfg.HTTPHost = getEnv("MCP_HTTP_HOST", cfg.HTTPHost)
+	cfg.HTTPPort = getEnvInt("MCP_HTTP_PORT", cfg.HTTPPort)
+
+	cfg.HTTPReadHeaderTimeout = getEnvDuration("HTTP_READ_HEADER_TIMEOUT", cfg.HTTPReadHeaderTimeout)
+	cfg.HTTPReadTimeout = getEnvDuration("HTTP_READ_TIMEOUT", cfg.HTTPReadTimeout)
+	cfg.HTTPIdleTimeout = getEnvDuration("HTTP_IDLE_TIMEOUT", cfg.HTTPIdleTimeout)
+	cfg.MaxRequestBodyBytes = getEnvInt64("MAX_REQUEST_BODY_BYTES", cfg.MaxRequestBodyBytes)
+
+	cfg.WebSocketMaxMessageBytes = getEnvInt("WEBSOCKET_MAX_MESSAGE_BYTES", cfg.WebSocketMaxMessageBytes)
+	cfg.WebSocketPingInterval = getEnvDuration("WEBSOCKET_PING_INTERVAL", cfg.WebSocketPingInterval)
+
+	cfg.StreamHeartbeatInterval = getEnvDuration("STREAM_HEARTBEAT_INTERVAL", cfg.StreamHeartbeatInterval)
+	cfg.StreamReplaySize = getEnvInt("STREAM_REPLAY_SIZE", cfg.StreamReplaySize)
+
+	cfg.LogStreamMaxConcurrent = getEnvInt("LOG_STREAM_MAX_CONCURRENT", cfg.LogStreamMaxConcurrent)
+	cfg.LogStreamMaxDuration = getEnvDuration("LOG_STREAM_MAX_DURATION", cfg.LogStreamMaxDuration)
+	cfg.LogStreamMaxBytes = getEnvInt64("LOG_STREAM_MAX_BYTES", cfg.LogStreamMaxBytes)
+
+	cfg.Name = getEnv("MCP_SERVER_NAME", cfg.Name)
+	cfg.Version = getEnv("MCP_SERVER_VERSION", cfg.Version)
+	cfg.LogLevel = strings.ToLower(getEnv("LOG_LEVEL", cfg.LogLevel))
+
+	cfg.CoordinationEngineURL = getEnv("COORDINATION_ENGINE_URL", cfg.CoordinationEngineURL)
+	cfg.PrometheusURL = getEnv("PROMETHEUS_URL", cfg.PrometheusURL)
+	cfg.AlertmanagerURL = getEnv("ALERTMANAGER_URL", cfg.AlertmanagerURL)
+	cfg.KServeNamespace = getEnv("KSERVE_NAMESPACE", cfg.KServeNamespace)
+	cfg.KServePredictorPort = getEnvInt("KSERVE_PREDICTOR_PORT", cfg.KServePredictorPort)
+
+	cfg.EnableCoordinationEngine = getEnvBool("ENABLE_COORDINATION_ENGINE", cfg.EnableCoordinationEngine)
+	cfg.EnablePrometheus = getEnvBool("ENABLE_PROMETHEUS", cfg.EnablePrometheus)
+	cfg.EnableKServe = getEnvBool("ENABLE_KSERVE", cfg.EnableKServe)
+	cfg.EnableAlertmanager = getEnvBool("ENABLE_ALERTMANAGER", cfg.EnableAlertmanager)
+	cfg.AlertSilenceMaxDuration = getEnvDuration("ALERT_SILENCE_MAX_DURATION", cfg.AlertSilenceMaxDuration)
+
+	cfg.CoordinationEngineBearerTokenFile = getEnv("COORDINATION_ENGINE_BEARER_TOKEN_FILE", cfg.CoordinationEngineBearerTokenFile)
+	cfg.CoordinationEngineCAFile = getEnv("COORDINATION_ENGINE_CA_FILE", cfg.CoordinationEngineCAFile)
+
+	cfg.KServeBearerTokenFile = getEnv("KSERVE_BEARER_TOKEN_FILE", cfg.KServeBearerTokenFile)
+	cfg.KServeHeaders = getEnvList("KSERVE_HEADERS", cfg.KServeHeaders)
+	cfg.KServeCAFile = getEnv("KSERVE_CA_FILE", cfg.KServeCAFile)
+	cfg.KServeMaxRetries = getEnvInt("KSERVE_MAX_RETRIES", cfg.KServeMaxRetries)
+	cfg.KServeRetryBudget = getEnvDuration("KSERVE_RETRY_BUDGET", cfg.KServeRetryBudget)
+	cfg.KServeForecastModel = getEnv("KSERVE_FORECAST_MODEL", cfg.KServeForecastModel)
+
+	cfg.CEWebhookPath = getEnv("CE_WEBHOOK_PATH", cfg.CEWebhookPath)
+	cfg.CEWebhookSecret = getEnv("CE_WEBHOOK_SECRET", cfg.CEWebhookSecret)
+	cfg.CEIncidentPollInterval = getEnvDuration("CE_INCIDENT_POLL_INTERVAL", cfg.CEIncidentPollInterval)
+
+	cfg.CacheTTL = getEnvDuration("CACHE_TTL", cfg.CacheTTL)
+	cfg.RequestTimeout = getEnvDuration("REQUEST_TIMEOUT", cfg.RequestTimeout)
+	cfg.MaxConcurrentTools = getEnvInt("MAX_CONCURRENT_TOOLS", cfg.MaxConcurrentTools)
+	cfg.MaxConcurrentHeavyTools = getEnvInt("MAX_CONCURRENT_HEAVY_TOOLS", cfg.MaxConcurrentHeavyTools)
+	cfg.ToolQueueTimeout = getEnvDuration("TOOL_QUEUE_TIMEOUT", cfg.ToolQueueTimeout)
+	cfg.SlowToolThreshold = getEnvDuration("SLOW_TOOL_THRESHOLD", cfg.SlowToolThreshold)
+	cfg.MaxResultBytes = getEnvInt("MAX_RESULT_BYTES", cfg.MaxResultBytes)
+	cfg.K8sClientQPS = getEnvFloat("K8S_CLIENT_QPS", cfg.K8sClientQPS)
+	cfg.K8sClientBurst = getEnvInt("K8S_CLIENT_BURST", cfg.K8sClientBurst)
+	cfg.HealthConcurrency = getEnvInt("HEALTH_COLLECTION_CONCURRENCY", cfg.HealthConcurrency)
+	cfg.HealthRules = getEnvList("HEALTH_RULES", cfg.HealthRules)
+
+	cfg.DependencyFailureTTL = getEnvDuration("DEPENDENCY_FAILURE_TTL", cfg.DependencyFailureTTL)
+
+	cfg.HealthHistoryInterval = getEnvDuration("HEALTH_HISTORY_INTERVAL", cfg.HealthHistoryInterval)
+	cfg.HealthHistorySize = getEnvInt("HEALTH_HISTORY_SIZE", cfg.HealthHistorySize)
+
+	cfg.EventHistoryEnabled = getEnvBool("EVENT_HISTORY_ENABLED", cfg.EventHistoryEnabled)
+	cfg.EventHistoryRetention = getEnvDuration("EVENT_HISTORY_RETENTION", cfg.EventHistoryRetention)
+	cfg.EventHistoryMaxRecords = getEnvInt("EVENT_HISTORY_MAX_RECORDS", cfg.EventHistoryMaxRecords)
+	cfg.EventHistoryFile = getEnv("EVENT_HISTORY_FILE", cfg.EventHistoryFile)
+	cfg.EventHistoryLeaderElection = getEnvBool("EVENT_HISTORY_LEADER_ELECTION", cfg.EventHistoryLeaderElection)
+	cfg.EventHistoryLeaseNamespace = getEnv("EVENT_HISTORY_LEASE_NAMESPACE", cfg.EventHistoryLeaseNamespace)
+
+	cfg.CapabilityProbeInterval = getEnvDuration("CAPABILITY_PROBE_INTERVAL", cfg.CapabilityProbeInterval)
+	cfg.PermissionCheckInterval = getEnvDuration("PERMISSION_CHECK_INTERVAL", cfg.PermissionCheckInterval)
+
+	cfg.EnableAuth = getEnvBool("ENABLE_AUTH", cfg.EnableAuth)
+
+	cfg.EnableCacheWarmup = getEnvBool("ENABLE_CACHE_WARMUP", cfg.EnableCacheWarmup)
+	cfg.CacheWarmupTimeout = getEnvDuration("CACHE_WARMUP_TIMEOUT", cfg.CacheWarmupTimeout)
+	cfg.ReadyAfterWarmup = getEnvBool("READY_AFTER_WARMUP", cfg.ReadyAfterWarmup)
+
+	cfg.SessionStore = getEnv("SESSION_STORE", cfg.SessionStore)
+	cfg.SessionStorePath = getEnv("SESSION_STORE_PATH", cfg.SessionStorePath)
+	cfg.SessionStoreNamespace = getEnv("SESSION_STORE_NAMESPACE", cfg.SessionStoreNamespace)
+	cfg.SessionStoreName = getEnv("SESSION_STORE_NAME", cfg.SessionStoreName)
+	cfg.SessionStoreMaxBytes = getEnvInt("SESSION_STORE_MAX_BYTES", cfg.SessionStoreMaxBytes)
+
+	cfg.EnabledTools = getEnvList("ENABLED_TOOLS", cfg.EnabledTools)
+	cfg.DisabledTools = getEnvList("DISABLED_TOOLS", cfg.DisabledTools)
+
+	cfg.ReadOnly = getEnvBool("READ_ONLY", cfg.ReadOnly)
+	cfg.EmitActionEvents = getEnvBool("EMIT_ACTION_EVENTS", cfg.EmitActionEvents)
+	cfg.ActionEventsNamespace = getEnv("ACTION_EVENTS_NAMESPACE", cfg.ActionEventsNamespace)
+
+	cfg.RedactionPatterns = getEnvList("REDACTION_PATTERNS", cfg.RedactionPatterns)
+
+	cfg.AllowedNamespaces = getEnvList("ALLOWED_NAMESPACES", cfg.AllowedNamespaces)
+	cfg.ManifestDeniedKinds = getEnvList("MANIFEST_DENIED_KINDS", cfg.ManifestDeniedKinds)
+
+	cfg.NamespacesResourceMaxEntries = getEnvInt("NAMESPACES_RESOURCE_MAX_ENTRIES", cfg.NamespacesResourceMaxEntries)
+	cfg.EventSeverityRules = getEnvList("EVENT_SEVERITY_RULES", cfg.EventSeverityRules)
+	cfg.EventsResourceMaxPerBucket = getEnvInt("EVENTS_RESOURCE_MAX_PER_BUCKET", cfg.EventsResourceMaxPerBucket)
+	cfg.EventsResourceIncludeInfo = getEnvBool("EVENTS_RESOURCE_INCLUDE_INFO", cfg.EventsResourceIncludeInfo)
+
+	cfg.RawAPIAllowedPrefixes = getEnvList("RAW_API_ALLOWED_PREFIXES", cfg.RawAPIAllowedPrefixes)
+	cfg.RawAPIDeniedResources = getEnvList("RAW_API_DENIED_RESOURCES", cfg.RawAPIDeniedResources)
+
+	cfg.ExtendedResources = getEnvList("EXTENDED_RESOURCES", cfg.ExtendedResources)
+
+	cfg.CriticalNamespaces = getEnvList("CRITICAL_NAMESPACES", cfg.CriticalNamespaces)
+	cfg.FinalizerAuditKinds = getEnvList("FINALIZER_AUDIT_KINDS", cfg.FinalizerAuditKinds)
+	cfg.KubeletLeaseStaleAfter = getEnvDuration("KUBELET_LEASE_STALE_AFTER", cfg.KubeletLeaseStaleAfter)
+	cfg.KubeletStatusStaleAfter = getEnvDuration("KUBELET_STATUS_STALE_AFTER", cfg.KubeletStatusStaleAfter)
+	cfg.KubeletHealthzProbe = getEnvBool("ENABLE_KUBELET_HEALTHZ_PROBE", cfg.KubeletHealthzProbe)
+
+	cfg.MustGatherImage = getEnv("MUST_GATHER_IMAGE", cfg.MustGatherImage)
+	cfg.MustGatherNamespace = getEnv("MUST_GATHER_NAMESPACE", cfg.MustGatherNamespace)
+	cfg.MustGatherServiceAccount = getEnv("MUST_GATHER_SERVICE_ACCOUNT", cfg.MustGatherServiceAccount)
+	cfg.MustGatherPVC = getEnv("MUST_GATHER_PVC", cfg.MustGatherPVC)
+	cfg.MustGatherRetention = getEnvDuration("MUST_GATHER_RETENTION", cfg.MustGatherRetention)
+
+	cfg.ReportSchedule = getEnv("REPORT_SCHEDULE", cfg.ReportSchedule)
+	cfg.ReportSections = getEnvList("REPORT_SECTIONS", cfg.ReportSections)
+	cfg.ReportWebhookURL = getEnv("REPORT_WEBHOOK_URL", cfg.ReportWebhookURL)
+	cfg.ReportConfigMapNamespace = getEnv("REPORT_CONFIGMAP_NAMESPACE", cfg.ReportConfigMapNamespace)
+	cfg.ReportConfigMapName = getEnv("REPORT_CONFIGMAP_NAME", cfg.ReportConfigMapName)
+	cfg.ReportDirectory = getEnv("REPORT_DIRECTORY", cfg.ReportDirectory)
+	cfg.ReportLeaderElection = getEnvBool("REPORT_LEADER_ELECTION", cfg.ReportLeaderElection)
+	cfg.ReportLeaseNamespace = getEnv("REPORT_LEASE_NAMESPACE", cfg.ReportLeaseNamespace)
+
+	cfg.RemediationPoliciesEnabled = getEnvBool("REMEDIATION_POLICIES_ENABLED", cfg.RemediationPoliciesEnabled)
+	cfg.RemediationWebhookURL = getEnv("REMEDIATION_WEBHOOK_URL", cfg.RemediationWebhookURL)
+	cfg.RemediationLeaderElection = getEnvBool("REMEDIATION_LEADER_ELECTION", cfg.RemediationLeaderElection)
+	cfg.RemediationLeaseNamespace = getEnv("REMEDIATION_LEASE_NAMESPACE", cfg.RemediationLeaseNamespace)
+
+	cfg.ArtifactDirectory = getEnv("ARTIFACT_DIRECTORY", cfg.ArtifactDirectory)
+	cfg.ArtifactTTL = getEnvDuration("ARTIFACT_TTL", cfg.ArtifactTTL)
+	cfg.ArtifactMaxBytes = getEnvInt64("ARTIFACT_MAX_BYTES", cfg.ArtifactMaxBytes)
+
+	cfg.OTLPEndpoint = getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", cfg.OTLPEndpoint)
+
+	cfg.CORSAllowedOrigins = getEnvList("CORS_ALLOWED_ORIGINS", cfg.CORSAllowedOrigins)
+	cfg.CORSAllowedMethods = getEnvList("CORS_ALLOWED_METHODS", cfg.CORSAllowedMethods)
+	cfg.CORSAllowedHeaders = getEnvList("CORS_ALLOWED_HEADERS", cfg.CORSAllowedHeaders)
+	cfg.CORSMaxAge = getEnvDuration("CORS_MAX_AGE", cfg.CORSMaxAge)
+	cfg.CORSAllowCredentials = getEnvBool("CORS_ALLOW_CREDENTIALS", cfg.CORSAllowCredentials)
+
+	cfg.AdminToken = getEnv("ADMIN_TOKEN", cfg.AdminToken)
+}
+
+// fileConfig is the on-disk YAML representation of Config.
+// Pointer fields distinguish "not set" from zero values so that only keys
+// present in the file override the defaults.
+type fileConfig struct {
+	Transport *string `json:"transport"`
+
+	Backend         *string `json:"backend"`
+	MockFixturesDir *string `json:"mock_fixtures_dir"`
+
+	HTTPHost *string `json:"http_host"`
+	HTTPPort *int    `json:"http_port"`
+
+	HTTPReadHeaderTimeout *string `json:"http_read_header_timeout"`
+	HTTPReadTimeout       *string `json:"http_read_timeout"`
+	HTTPIdleTimeout       *string `json:"http_idle_timeout"`
+	MaxRequestBodyBytes   *int64  `json:"max_request_body_bytes"`
+
+	WebSocketMaxMessageBytes *int    `json:"websocket_max_message_bytes"`
+	WebSocketPingInterval    *string `json:"websocket_ping_interval"`
+
+	StreamHeartbeatInterval *string `json:"stream_heartbeat_interval"`
+	StreamReplaySize        *int    `json:"stream_replay_size"`
+
+	LogStreamMaxConcurrent *int    `json:"log_stream_max_concurrent"`
+	LogStreamMaxDuration   *string `json:"log_stream_max_duration"`
+	LogStreamMaxBytes      *int64  `json:"log_stream_max_bytes"`
+
+	Name    *string `json:"name"`
+	Version *string `json:"version"`
+
+	LogLevel *string `json:"log_level"`
+
+	CoordinationEngineURL *string `json:"coordination_engine_url"`
+	PrometheusURL         *string `json:"prometheus_url"`
+	AlertmanagerURL       *string `json:"alertmanager_url"`
+	KServeNamespace       *string `json:"kserve_namespace"`
+	KServePredictorPort   *int    `json:"kserve_predictor_port"`
+
+	EnableCoordinationEngine *bool `json:"enable_coordination_engine"`
+	EnablePrometheus         *bool `json:"enable_prometheus"`
+	EnableKServe             *bool `json:"enable_kserve"`
+	EnableAlertmanager       *bool `json:"enable_alertmanager"`
+
+	AlertSilenceMaxDuration *string `json:"alert_silence_max_duration"`
+
+	CoordinationEngineBearerTokenFile *string `json:"coordination_engine_bearer_token_file"`
+	CoordinationEngineCAFile          *string `json:"coordination_engine_ca_file"`
+
+	KServeBearerTokenFile *string   `json:"kserve_bearer_token_file"`
+	KServeHeaders         *[]string `json:"kserve_headers"`
+	KServeCAFile          *string   `json:"kserve_ca_file"`
+	KServeMaxRetries      *int      `json:"kserve_max_retries"`
+	KServeRetryBudget     *string   `json:"kserve_retry_budget"`
+	KServeForecastModel   *string   `json:"kserve_forecast_model"`
+
+	CEWebhookPath          *string `json:"ce_webhook_path"`
+	CEWebhookSecret        *string `json:"ce_webhook_secret"`
+	CEIncidentPollInterval *string `json:"ce_incident_poll_interval"`
+
+	CacheTTL           *string  `json:"cache_ttl"`
+	RequestTimeout     *string  `json:"request_timeout"`
+	MaxConcurrentTools *int     `json:"max_concurrent_tools"`
+	SlowToolThreshold  *string  `json:"slow_tool_threshold"`
+	MaxResultBytes     *int     `json:"max_result_bytes"`
+	K8sClientQPS       *float64 `json:"k8s_client_qps"`
+	K8sClientBurst     *int     `json:"k8s_client_burst"`
+	HealthConcurrency  *int     `json:"health_collection_concurrency"`
+
+	HealthRules *[]string `json:"health_rules"`
+
+	MaxConcurrentHeavyTools *int    `json:"max_concurrent_heavy_tools"`
+	ToolQueueTimeout        *string `json:"tool_queue_timeout"`
+
+	DependencyFailureTTL *string `json:"dependency_failure_ttl"`
+
+	HealthHistoryInterval *string `json:"health_history_interval"`
+	HealthHistorySize     *int    `json:"health_history_size"`
+
+	EventHistoryEnabled        *bool   `json:"event_history_enabled"`
+	EventHistoryRetention      *string `json:"event_history_retention"`
+	EventHistoryMaxRecords     *int    `json:"event_history_max_records"`
+	EventHistoryFile           *string `json:"event_history_file"`
+	EventHistoryLeaderElection *bool   `json:"event_history_leader_election"`
+	EventHistoryLeaseNamespace *string `json:"event_history_lease_namespace"`
+
+	CapabilityProbeInterval *string `json:"capability_probe_interval"`
+	PermissionCheckInterval *string `json:"permission_check_interval"`
+
+	EnableAuth *bool `json:"enable_auth"`
+
+	EnableCacheWarmup  *bool   `json:"enable_cache_warmup"`
+	CacheWarmupTimeout *string `json:"cache_warmup_timeout"`
+	ReadyAfterWarmup   *bool   `json:"ready_after_warmup"`
+
+	SessionStore          *string `json:"session_store"`
+	SessionStorePath      *string `json:"session_store_path"`
+	SessionStoreNamespace *string `json:"session_store_namespace"`
+	SessionStoreName      *string `json:"session_store_name"`
+	SessionStoreMaxBytes  *int    `json:"session_store_max_bytes"`
+
+	EnabledTools  *[]string `json:"enabled_tools"`
+	DisabledTools *[]string `json:"disabled_tools"`
+
+	ReadOnly *bool `json:"read_only"`
+
+	EmitActionEvents      *bool   `json:"emit_action_events"`
+	ActionEventsNamespace *string `json:"action_events_namespace"`
+
+	RedactionPatterns *[]string `json:"redaction_patterns"`
+
+	AllowedNamespaces   *[]string `json:"allowed_namespaces"`
+	ManifestDeniedKinds *[]string `json:"manifest_denied_kinds"`
+
+	TenantProfiles *[]TenantProfile `json:"tenant_profiles"`
+
+	NamespacesResourceMaxEntries *int      `json:"namespaces_resource_max_entries"`
+	EventSeverityRules           *[]string `json:"event_severity_rules"`
+	EventsResourceMaxPerBucket   *int      `json:"events_resource_max_per_bucket"`
+	EventsResourceIncludeInfo    *bool     `json:"events_resource_include_info"`
+
+	RawAPIAllowedPrefixes *[]string `json:"raw_api_allowed_prefixes"`
+	RawAPIDeniedResources *[]string `json:"raw_api_denied_resources"`
+
+	ExtendedResources *[]string `json:"extended_resources"`
+
+	CriticalNamespaces *[]string `json:"critical_namespaces"`
+
+	FinalizerAuditKinds *[]string `json:"finalizer_audit_kinds"`
+
+	KubeletLeaseStaleAfter  *string `json:"kubelet_lease_stale_after"`
+	KubeletStatusStaleAfter *string `json:"kubelet_status_stale_after"`
+	KubeletHealthzProbe     *bool   `json:"enable_kubelet_healthz_probe"`
+
+	MustGatherImage          *string `json:"must_gather_image"`
+	MustGatherNamespace      *string `json:"must_gather_namespace"`
+	MustGatherServiceAccount *string `json:"must_gather_service_account"`
+	MustGatherPVC            *string `json:"must_gather_pvc"`
+	MustGatherRetention      *string `json:"must_gather_retention"`
+
+	ReportSchedule           *string   `json:"report_schedule"`
+	ReportSections           *[]string `json:"report_sections"`
+	ReportWebhookURL         *string   `json:"report_webhook_url"`
+	ReportConfigMapNamespace *string   `json:"report_configmap_namespace"`
+	ReportConfigMapName      *string   `json:"report_configmap_name"`
+	ReportDirectory          *string   `json:"report_directory"`
+	ReportLeaderElection     *bool     `json:"report_leader_election"`
+	ReportLeaseNamespace     *string   `json:"report_lease_namespace"`
+
+	RemediationPolicies        *[]policy.Rule `json:"remediation_policies"`
+	RemediationPoliciesEnabled *bool          `json:"remediation_policies_enabled"`
+	RemediationWebhookURL      *string        `json:"remediation_webhook_url"`
+	RemediationLeaderElection  *bool          `json:"remediation_leader_election"`
+	RemediationLeaseNamespace  *string        `json:"remediation_lease_namespace"`
+
+	ArtifactDirectory *string `json:"artifact_directory"`
+	ArtifactTTL       *string `json:"artifact_ttl"`
+	ArtifactMaxBytes  *int64  `json:"artifact_max_bytes"`
+
+	OTLPEndpoint *string `json:"otlp_endpoint"`
+
+	CORSAllowedOrigins   *[]string `json:"cors_allowed_origins"`
+	CORSAllowedMethods   *[]string `json:"cors_allowed_methods"`
+	CORSAllowedHeaders   *[]string `json:"cors_allowed_headers"`
+	CORSMaxAge           *string   `json:"cors_max_age"`
+	CORSAllowCredentials *bool     `json:"cors_allow_credentials"`
+
+	AdminToken *string `json:"admin_token"`
+}
+
+// applyConfigFile reads a YAML config file and applies the keys it sets to cfg.
+// Unknown keys are rejected so that typos don't silently fall back to defaults.
+func applyConfigFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var fc fileConfig
+	if err := yaml.UnmarshalStrict(data, &fc); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if fc.Transport != nil {
+		cfg.Transport = TransportType(*fc.Transport)
+	}
+	if fc.Backend != nil {
+		cfg.Backend = *fc.Backend
+	}
+	if fc.MockFixturesDir != nil {
+		cfg.MockFixturesDir = *fc.MockFixturesDir
+	}
+	if fc.HTTPHost != nil {
+		cfg.HTTPHost = *fc.HTTPHost
+	}
+	if fc.HTTPPort != nil {
+		cfg.HTTPPort = *fc.HTTPPort
+	}
+	if fc.MaxRequestBodyBytes != nil {
+		cfg.MaxRequestBodyBytes = *fc.MaxRequestBodyBytes
+	}
+	if fc.WebSocketMaxMessageBytes != nil {
+		cfg.WebSocketMaxMessageBytes = *fc.WebSocketMaxMessageBytes
+	}
+	if fc.StreamReplaySize != nil {
+		cfg.StreamReplaySize = *fc.StreamReplaySize
+	}
+	if fc.LogStreamMaxConcurrent != nil {
+		cfg.LogStreamMaxConcurrent = *fc.LogStreamMaxConcurrent
+	}
+	if fc.LogStreamMaxBytes != nil {
+		cfg.LogStreamMaxBytes = *fc.LogStreamMaxBytes
+	}
+	if fc.Name != nil {
+		cfg.Name = *fc.Name
+	}
+	if fc.Version != nil {
+		cfg.Version = *fc.Version
+	}
+	if fc.LogLevel != nil {
+		cfg.LogLevel = strings.ToLower(*fc.LogLevel)
+	}
+	if fc.CoordinationEngineURL != nil {
+		cfg.CoordinationEngineURL = *fc.CoordinationEngineURL
+	}
+	if fc.PrometheusURL != nil {
+		cfg.PrometheusURL = *fc.PrometheusURL
+	}
+	if fc.AlertmanagerURL != nil {
+		cfg.AlertmanagerURL = *fc.AlertmanagerURL
+	}
+	if fc.KServeNamespace != nil {
+		cfg.KServeNamespace = *fc.KServeNamespace
+	}
+	if fc.KServePredictorPort != nil {
+		cfg.KServePredictorPort = *fc.KServePredictorPort
+	}
+	if fc.EnableCoordinationEngine != nil {
+		cfg.EnableCoordinationEngine = *fc.EnableCoordinationEngine
+	}
+	if fc.EnablePrometheus != nil {
+		cfg.EnablePrometheus = *fc.EnablePrometheus
+	}
+	if fc.EnableKServe != nil {
+		cfg.EnableKServe = *fc.EnableKServe
+	}
+	if fc.EnableAlertmanager != nil {
+		cfg.EnableAlertmanager = *fc.EnableAlertmanager
+	}
+	if fc.CoordinationEngineBearerTokenFile != nil {
+		cfg.CoordinationEngineBearerTokenFile = *fc.CoordinationEngineBearerTokenFile
+	}
+	if fc.CoordinationEngineCAFile != nil {
+		cfg.CoordinationEngineCAFile = *fc.CoordinationEngineCAFile
+	}
+	if fc.KServeBearerTokenFile != nil {
+		cfg.KServeBearerTokenFile = *fc.KServeBearerTokenFile
+	}
+	if fc.KServeHeaders != nil {
+		cfg.KServeHeaders = *fc.KServeHeaders
+	}
+	if fc.KServeCAFile != nil {
+		cfg.KServeCAFile = *fc.KServeCAFile
+	}
+	if fc.KServeMaxRetries != nil {
+		cfg.KServeMaxRetries = *fc.KServeMaxRetries
+	}
+	if fc.KServeForecastModel != nil {
+		cfg.KServeForecastModel = *fc.KServeForecastModel
+	}
+	if fc.CEWebhookPath != nil {
+		cfg.CEWebhookPath = *fc.CEWebhookPath
+	}
+	if fc.CEWebhookSecret != nil {
+		cfg.CEWebhookSecret = *fc.CEWebhookSecret
+	}
+	if fc.MaxConcurrentTools != nil {
+		cfg.MaxConcurrentTools = *fc.MaxConcurrentTools
+	}
+	if fc.MaxConcurrentHeavyTools != nil {
+		cfg.MaxConcurrentHeavyTools = *fc.MaxConcurrentHeavyTools
+	}
+	if fc.MaxResultBytes != nil {
+		cfg.MaxResultBytes = *fc.MaxResultBytes
+	}
+	if fc.K8sClientQPS != nil {
+		cfg.K8sClientQPS = *fc.K8sClientQPS
+	}
+	if fc.K8sClientBurst != nil {
+		cfg.K8sClientBurst = *fc.K8sClientBurst
+	}
+	if fc.HealthConcurrency != nil {
+		cfg.HealthConcurrency = *fc.HealthConcurrency
+	}
+	if fc.HealthRules != nil {
+		cfg.HealthRules = *fc.HealthRules
+	}
+	if fc.HealthHistorySize != nil {
+		cfg.HealthHistorySize = *fc.HealthHistorySize
+	}
+	if fc.EventHistoryEnabled != nil {
+		cfg.EventHistoryEnabled = *fc.EventHistoryEnabled
+	}
+	if fc.EventHistoryMaxRecords != nil {
+		cfg.EventHistoryMaxRecords = *fc.EventHistoryMaxRecords
+	}
+	if fc.EventHistoryFile != nil {
+		cfg.EventHistoryFile = *fc.EventHistoryFile
+	}
+	if fc.EventHistoryLeaderElection != nil {
+		cfg.EventHistoryLeaderElection = *fc.EventHistoryLeaderElection
+	}
+	if fc.EventHistoryLeaseNamespace != nil {
+		cfg.EventHistoryLeaseNamespace = *fc.EventHistoryLeaseNamespace
+	}
+	if fc.EnableAuth != nil {
+		cfg.EnableAuth = *fc.EnableAuth
+	}
+	if fc.EnableCacheWarmup != nil {
+		cfg.EnableCacheWarmup = *fc.EnableCacheWarmup
+	}
+	if fc.ReadyAfterWarmup != nil {
+		cfg.ReadyAfterWarmup = *fc.ReadyAfterWarmup
+	}
+	if fc.SessionStore != nil {
+		cfg.SessionStore = *fc.SessionStore
+	}
+	if fc.SessionStorePath != nil {
+		cfg.SessionStorePath = *fc.SessionStorePath
+	}
+	if fc.SessionStoreNamespace != nil {
+		cfg.SessionStoreNamespace = *fc.SessionStoreNamespace
+	}
+	if fc.SessionStoreName != nil {
+		cfg.SessionStoreName = *fc.SessionStoreName
+	}
+	if fc.SessionStoreMaxBytes != nil {
+		cfg.SessionStoreMaxBytes = *fc.SessionStoreMaxBytes
+	}
+	if fc.EnabledTools != nil {
+		cfg.EnabledTools = *fc.EnabledTools
+	}
+	if fc.DisabledTools != nil {
+		cfg.DisabledTools = *fc.DisabledTools
+	}
+	if fc.ReadOnly != nil {
+		cfg.ReadOnly = *fc.ReadOnly
+	}
+	if fc.EmitActionEvents != nil {
+		cfg.EmitActionEvents = *fc.EmitActionEvents
+	}
+	if fc.ActionEventsNamespace != nil {
+		cfg.ActionEventsNamespace = *fc.ActionEventsNamespace
+	}
+	if fc.RedactionPatterns != nil {
+		cfg.RedactionPatterns = *fc.RedactionPatterns
+	}
+	if fc.AllowedNamespaces != nil {
+		cfg.AllowedNamespaces = *fc.AllowedNamespaces
+	}
+	if fc.ManifestDeniedKinds != nil {
+		cfg.ManifestDeniedKinds = *fc.ManifestDeniedKinds
+	}
+	if fc.TenantProfiles != nil {
+		cfg.TenantProfiles = *fc.TenantProfiles
+	}
+	if fc.NamespacesResourceMaxEntries != nil {
+		cfg.NamespacesResourceMaxEntries = *fc.NamespacesResourceMaxEntries
+	}
+	if fc.EventSeverityRules != nil {
+		cfg.EventSeverityRules = *fc.EventSeverityRules
+	}
+	if fc.EventsResourceMaxPerBucket != nil {
+		cfg.EventsResourceMaxPerBucket = *fc.EventsResourceMaxPerBucket
+	}
+	if fc.EventsResourceIncludeInfo != nil {
+		cfg.EventsResourceIncludeInfo = *fc.EventsResourceIncludeInfo
+	}
+	if fc.RawAPIAllowedPrefixes != nil {
+		cfg.RawAPIAllowedPrefixes = *fc.RawAPIAllowedPrefixes
+	}
+	if fc.RawAPIDeniedResources != nil {
+		cfg.RawAPIDeniedResources = *fc.RawAPIDeniedResources
+	}
+	if fc.ExtendedResources != nil {
+		cfg.ExtendedResources = *fc.ExtendedResources
+	}
+	if fc.CriticalNamespaces != nil {
+		cfg.CriticalNamespaces = *fc.CriticalNamespaces
+	}
+	if fc.FinalizerAuditKinds != nil {
+		cfg.FinalizerAuditKinds = *fc.FinalizerAuditKinds
+	}
+	if fc.KubeletHealthzProbe != nil {
+		cfg.KubeletHealthzProbe = *fc.KubeletHealthzProbe
+	}
+	if fc.MustGatherImage != nil {
+		cfg.MustGatherImage = *fc.MustGatherImage
+	}
+	if fc.MustGatherNamespace != nil {
+		cfg.MustGatherNamespace = *fc.MustGatherNamespace
+	}
+	if fc.MustGatherServiceAccount != nil {
+		cfg.MustGatherServiceAccount = *fc.MustGatherServiceAccount
+	}
+	if fc.MustGatherPVC != nil {
+		cfg.MustGatherPVC = *fc.MustGatherPVC
+	}
+	if fc.ReportSchedule != nil {
+		cfg.ReportSchedule = *fc.ReportSchedule
+	}
+	if fc.ReportSections != nil {
+		cfg.ReportSections = *fc.ReportSections
+	}
+	if fc.ReportWebhookURL != nil {
+		cfg.ReportWebhookURL = *fc.ReportWebhookURL
+	}
+	if fc.ReportConfigMapNamespace != nil {
+		cfg.ReportConfigMapNamespace = *fc.ReportConfigMapNamespace
+	}
+	if fc.ReportConfigMapName != nil {
+		cfg.ReportConfigMapName = *fc.ReportConfigMapName
+	}
+	if fc.ReportDirectory != nil {
+		cfg.ReportDirectory = *fc.ReportDirectory
+	}
+	if fc.ReportLeaderElection != nil {
+		cfg.ReportLeaderElection = *fc.ReportLeaderElection
+	}
+	if fc.ReportLeaseNamespace != nil {
+		cfg.ReportLeaseNamespace = *fc.ReportLeaseNamespace
+	}
+	if fc.RemediationPolicies != nil {
+		cfg.RemediationPolicies = *fc.RemediationPolicies
+	}
+	if fc.RemediationPoliciesEnabled != nil {
+		cfg.RemediationPoliciesEnabled = *fc.RemediationPoliciesEnabled
+	}
+	if fc.RemediationWebhookURL != nil {
+		cfg.RemediationWebhookURL = *fc.RemediationWebhookURL
+	}
+	if fc.RemediationLeaderElection != nil {
+		cfg.RemediationLeaderElection = *fc.RemediationLeaderElection
+	}
+	if fc.RemediationLeaseNamespace != nil {
+		cfg.RemediationLeaseNamespace = *fc.RemediationLeaseNamespace
+	}
+	if fc.ArtifactDirectory != nil {
+		cfg.ArtifactDirectory = *fc.ArtifactDirectory
+	}
+	if fc.ArtifactMaxBytes != nil {
+		cfg.ArtifactMaxBytes = *fc.ArtifactMaxBytes
+	}
+	if fc.OTLPEndpoint != nil {
+		cfg.OTLPEndpoint = *fc.OTLPEndpoint
+	}
+	if fc.CORSAllowedOrigins != nil {
+		cfg.CORSAllowedOrigins = *fc.CORSAllowedOrigins
+	}
+	if fc.CORSAllowedMethods != nil {
+		cfg.CORSAllowedMethods = *fc.CORSAllowedMethods
+	}
+	if fc.CORSAllowedHeaders != nil {
+		cfg.CORSAllowedHeaders = *fc.CORSAllowedHeaders
+	}
+	if fc.CORSAllowCredentials != nil {
+		cfg.CORSAllowCredentials = *fc.CORSAllowCredentials
+	}
+	if fc.AdminToken != nil {
+		cfg.AdminToken = *fc.AdminToken
+	}
+
+	// Durations are written as strings ("30s", "1m") in the file
+	durations := []struct {
+		key   string
+		value *string
+		dst   *time.Duration
+	}{
+		{"http_read_header_timeout", fc.HTTPReadHeaderTimeout, &cfg.HTTPReadHeaderTimeout},
+		{"http_read_timeout", fc.HTTPReadTimeout, &cfg.HTTPReadTimeout},
+		{"http_idle_timeout", fc.HTTPIdleTimeout, &cfg.HTTPIdleTimeout},
+		{"websocket_ping_interval", fc.WebSocketPingInterval, &cfg.WebSocketPingInterval},
+		{"stream_heartbeat_interval", fc.StreamHeartbeatInterval, &cfg.StreamHeartbeatInterval},
+		{"log_stream_max_duration", fc.LogStreamMaxDuration, &cfg.LogStreamMaxDuration},
+		{"cache_ttl", fc.CacheTTL, &cfg.CacheTTL},
+		{"request_timeout", fc.RequestTimeout, &cfg.RequestTimeout},
+		{"tool_queue_timeout", fc.ToolQueueTimeout, &cfg.ToolQueueTimeout},
+		{"slow_tool_threshold", fc.SlowToolThreshold, &cfg.SlowToolThreshold},
+		{"cors_max_age", fc.CORSMaxAge, &cfg.CORSMaxAge},
+		{"must_gather_retention", fc.MustGatherRetention, &cfg.MustGatherRetention},
+		{"kubelet_lease_stale_after", fc.KubeletLeaseStaleAfter, &cfg.KubeletLeaseStaleAfter},
+		{"kubelet_status_stale_after", fc.KubeletStatusStaleAfter, &cfg.KubeletStatusStaleAfter},
+		{"kserve_retry_budget", fc.KServeRetryBudget, &cfg.KServeRetryBudget},
+		{"ce_incident_poll_interval", fc.CEIncidentPollInterval, &cfg.CEIncidentPollInterval},
+		{"alert_silence_max_duration", fc.AlertSilenceMaxDuration, &cfg.AlertSilenceMaxDuration},
+		{"dependency_failure_ttl", fc.DependencyFailureTTL, &cfg.DependencyFailureTTL},
+		{"health_history_interval", fc.HealthHistoryInterval, &cfg.HealthHistoryInterval},
+		{"event_history_retention", fc.EventHistoryRetention, &cfg.EventHistoryRetention},
+		{"capability_probe_interval", fc.CapabilityProbeInterval, &cfg.CapabilityProbeInterval},
+		{"permission_check_interval", fc.PermissionCheckInterval, &cfg.PermissionCheckInterval},
+		{"cache_warmup_timeout", fc.CacheWarmupTimeout, &cfg.CacheWarmupTimeout},
+		{"artifact_ttl", fc.ArtifactTTL, &cfg.ArtifactTTL},
+	}
+
+	var problems []string
+	for _, d := range durations {
+		if d.value == nil {
+			continue
+		}
+		parsed, err := time.ParseDuration(*d.value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid duration %q", d.key, *d.value))
+			continue
+		}
+		*d.dst = parsed
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config file %s: %w", path, &ValidationError{Problems: problems})
+	}
+
+	return nil
+}
+
+// ValidationError collects every problem found while validating a Config
+type ValidationError struct {
+	Problems []string
+}
+
+// Error joins all problems into a single message
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
 }
 
-// Validate checks if the configuration is valid
+// Validate checks if the configuration is valid.
+// All problems are reported at once as a *ValidationError.
 func (c *Config) Validate() error {
-	if c.Transport != TransportHTTP && c.Transport != TransportStdio {
-		return fmt.Errorf("invalid transport: %s (must be 'http' or 'stdio')", c.Transport)
+	var problems []string
+
+	if !c.Transport.ServesHTTP() && c.Transport != TransportStdio {
+		problems = append(problems, fmt.Sprintf("invalid transport: %s (must be 'http', 'websocket' or 'stdio')", c.Transport))
+	}
+	if _, ok := logLevels[c.LogLevel]; !ok {
+		problems = append(problems, fmt.Sprintf("invalid log level: %s (must be 'debug', 'info', 'warn' or 'error')", c.LogLevel))
+	}
+	switch c.Backend {
+	case BackendKubernetes:
+		if c.MockFixturesDir != "" {
+			problems = append(problems, "invalid mock fixtures directory: only used by the mock backend (set BACKEND=mock)")
+		}
+	case BackendMock:
+	default:
+		problems = append(problems, fmt.Sprintf("invalid backend: %q (must be 'kubernetes' or 'mock')", c.Backend))
 	}
 
-	if c.Transport == TransportHTTP {
+	if c.Transport.ServesHTTP() {
 		if c.HTTPPort < 1 || c.HTTPPort > 65535 {
-			return fmt.Errorf("invalid HTTP port: %d (must be 1-65535)", c.HTTPPort)
+			problems = append(problems, fmt.Sprintf("invalid HTTP port: %d (must be 1-65535)", c.HTTPPort))
+		}
+		if c.HTTPReadHeaderTimeout <= 0 {
+			problems = append(problems, fmt.Sprintf("invalid HTTP read header timeout: %v (must be positive)", c.HTTPReadHeaderTimeout))
+		}
+		if c.HTTPReadTimeout < 0 || c.HTTPIdleTimeout < 0 {
+			problems = append(problems, "HTTP read and idle timeouts must not be negative (0 disables)")
+		}
+		if c.MaxRequestBodyBytes < 1 {
+			problems = append(problems, fmt.Sprintf("invalid max request body size: %d (minimum 1 byte)", c.MaxRequestBodyBytes))
+		}
+	}
+
+	if c.Transport == TransportWebSocket {
+		if c.WebSocketMaxMessageBytes < 1 {
+			problems = append(problems, fmt.Sprintf("invalid WebSocket max message size: %d (minimum 1 byte)", c.WebSocketMaxMessageBytes))
+		}
+		if c.WebSocketPingInterval < 0 {
+			problems = append(problems, fmt.Sprintf("invalid WebSocket ping interval: %v (must not be negative, 0 disables)", c.WebSocketPingInterval))
 		}
 	}
 
+	if c.StreamHeartbeatInterval < 0 {
+		problems = append(problems, fmt.Sprintf("invalid stream heartbeat interval: %v (must not be negative, 0 disables)", c.StreamHeartbeatInterval))
+	}
+	if c.StreamReplaySize < 1 {
+		problems = append(problems, fmt.Sprintf("invalid stream replay size: %d (minimum 1)", c.StreamReplaySize))
+	}
+	if c.LogStreamMaxConcurrent < 0 {
+		problems = append(problems, fmt.Sprintf("invalid log stream max concurrent: %d (must not be negative, 0 disables)", c.LogStreamMaxConcurrent))
+	}
+	if c.LogStreamMaxDuration <= 0 {
+		problems = append(problems, fmt.Sprintf("invalid log stream max duration: %v (must be positive)", c.LogStreamMaxDuration))
+	}
+	if c.LogStreamMaxBytes < 1 {
+		problems = append(problems, fmt.Sprintf("invalid log stream max bytes: %d (minimum 1 byte)", c.LogStreamMaxBytes))
+	}
+
 	if c.CacheTTL < 1*time.Second {
-		return fmt.Errorf("cache TTL too low: %v (minimum 1s)", c.CacheTTL)
+		problems = append(problems, fmt.Sprintf("cache TTL too low: %v (minimum 1s)", c.CacheTTL))
+	}
+
+	if c.RequestTimeout <= 0 {
+		problems = append(problems, fmt.Sprintf("invalid request timeout: %v (must be positive)", c.RequestTimeout))
+	}
+
+	if c.MaxConcurrentTools < 1 {
+		problems = append(problems, fmt.Sprintf("invalid max concurrent tools: %d (minimum 1)", c.MaxConcurrentTools))
+	}
+	if c.MaxConcurrentHeavyTools < 1 {
+		problems = append(problems, fmt.Sprintf("invalid max concurrent heavy tools: %d (minimum 1)", c.MaxConcurrentHeavyTools))
+	}
+	if c.ToolQueueTimeout < 0 {
+		problems = append(problems, fmt.Sprintf("invalid tool queue timeout: %v (must not be negative)", c.ToolQueueTimeout))
+	}
+
+	if c.K8sClientQPS <= 0 {
+		problems = append(problems, fmt.Sprintf("invalid Kubernetes client QPS: %v (must be positive)", c.K8sClientQPS))
+	}
+	if c.K8sClientBurst < 1 {
+		problems = append(problems, fmt.Sprintf("invalid Kubernetes client burst: %d (minimum 1)", c.K8sClientBurst))
+	}
+	if c.HealthConcurrency < 1 {
+		problems = append(problems, fmt.Sprintf("invalid health collection concurrency: %d (minimum 1)", c.HealthConcurrency))
+	}
+	if _, err := clients.ParseHealthRules(c.HealthRules); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid health rules: %v", err))
+	}
+
+	if c.SlowToolThreshold < 0 {
+		problems = append(problems, fmt.Sprintf("invalid slow tool threshold: %v (must not be negative)", c.SlowToolThreshold))
+	}
+	if c.MaxResultBytes != 0 && c.MaxResultBytes < tools.MinResultBudget {
+		problems = append(problems, fmt.Sprintf("invalid max result bytes: %d (0 disables, minimum %d)", c.MaxResultBytes, tools.MinResultBudget))
+	}
+	if c.ArtifactDirectory != "" {
+		if c.ArtifactTTL <= 0 {
+			problems = append(problems, fmt.Sprintf("invalid artifact TTL: %v (must be positive)", c.ArtifactTTL))
+		}
+		if c.ArtifactMaxBytes <= 0 {
+			problems = append(problems, fmt.Sprintf("invalid artifact max bytes: %d (must be positive)", c.ArtifactMaxBytes))
+		}
+	}
+
+	if c.DependencyFailureTTL < 0 {
+		problems = append(problems, fmt.Sprintf("invalid dependency failure TTL: %v (must not be negative)", c.DependencyFailureTTL))
+	}
+
+	if c.HealthHistoryInterval != 0 && c.HealthHistoryInterval < time.Second {
+		problems = append(problems, fmt.Sprintf("invalid health history interval: %v (0 disables, otherwise minimum 1s)", c.HealthHistoryInterval))
+	}
+	if c.HealthHistorySize < 1 {
+		problems = append(problems, fmt.Sprintf("invalid health history size: %d (minimum 1)", c.HealthHistorySize))
+	}
+
+	if c.EventHistoryEnabled {
+		if c.EventHistoryRetention < time.Hour {
+			problems = append(problems, fmt.Sprintf("invalid event history retention: %v (minimum 1h, the API server's own event TTL)", c.EventHistoryRetention))
+		}
+		if c.EventHistoryMaxRecords < 1 {
+			problems = append(problems, fmt.Sprintf("invalid event history max records: %d (minimum 1)", c.EventHistoryMaxRecords))
+		}
+		if c.EventHistoryLeaderElection {
+			if errs := validation.IsDNS1123Label(c.EventHistoryLeaseNamespace); len(errs) > 0 {
+				problems = append(problems, fmt.Sprintf("invalid event history lease namespace %q: %s", c.EventHistoryLeaseNamespace, strings.Join(errs, "; ")))
+			}
+		}
+	}
+
+	if c.CapabilityProbeInterval != 0 && c.CapabilityProbeInterval < time.Second {
+		problems = append(problems, fmt.Sprintf("invalid capability probe interval: %v (0 disables, otherwise minimum 1s)", c.CapabilityProbeInterval))
+	}
+	if c.PermissionCheckInterval != 0 && c.PermissionCheckInterval < 10*time.Second {
+		problems = append(problems, fmt.Sprintf("invalid permission check interval: %v (0 checks only at startup, otherwise minimum 10s)", c.PermissionCheckInterval))
+	}
+
+	if c.EnableCacheWarmup && c.CacheWarmupTimeout <= 0 {
+		problems = append(problems, fmt.Sprintf("invalid cache warm-up timeout: %v (must be positive)", c.CacheWarmupTimeout))
+	}
+
+	switch c.SessionStore {
+	case "":
+	case "file":
+		if c.SessionStorePath == "" {
+			problems = append(problems, "session store path must be set for the file session store")
+		}
+	case "configmap", "secret":
+		if errs := validation.IsDNS1123Label(c.SessionStoreNamespace); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid session store namespace %q: %s", c.SessionStoreNamespace, strings.Join(errs, "; ")))
+		}
+		if errs := validation.IsDNS1123Subdomain(c.SessionStoreName); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid session store name %q: %s", c.SessionStoreName, strings.Join(errs, "; ")))
+		}
+		if c.SessionStoreMaxBytes > 1<<20 {
+			problems = append(problems, fmt.Sprintf("invalid session store size: %d (a %s holds at most 1MiB)", c.SessionStoreMaxBytes, c.SessionStore))
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("invalid session store: %q (must be file, configmap, or secret)", c.SessionStore))
+	}
+	if c.SessionStore != "" && c.SessionStoreMaxBytes < 1024 {
+		problems = append(problems, fmt.Sprintf("invalid session store size: %d (minimum 1024 bytes)", c.SessionStoreMaxBytes))
+	}
+
+	if c.EnableCoordinationEngine {
+		if _, err := clients.ParseCoordinationEngineURL(c.CoordinationEngineURL); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid Coordination Engine URL: %v", err))
+		}
+		for _, file := range []string{c.CoordinationEngineBearerTokenFile, c.CoordinationEngineCAFile} {
+			if file == "" {
+				continue
+			}
+			if _, err := os.Stat(file); err != nil {
+				problems = append(problems, fmt.Sprintf("invalid Coordination Engine credentials file: %v", err))
+			}
+		}
+	}
+
+	if c.EnablePrometheus {
+		if err := validateURL(c.PrometheusURL); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid Prometheus URL: %v", err))
+		}
+	}
+
+	if c.EnableAlertmanager {
+		if err := validateURL(c.AlertmanagerURL); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid Alertmanager URL: %v", err))
+		}
+	}
+	if c.AlertSilenceMaxDuration <= 0 {
+		problems = append(problems, fmt.Sprintf("invalid alert silence max duration: %v (must be positive)", c.AlertSilenceMaxDuration))
+	}
+
+	if c.OTLPEndpoint != "" {
+		if err := validateURL(c.OTLPEndpoint); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid OTLP endpoint: %v", err))
+		}
+	}
+
+	if c.EnableKServe {
+		if c.KServeNamespace == "" {
+			problems = append(problems, "KServe namespace must be set when KServe is enabled")
+		}
+		if c.KServePredictorPort < 1 || c.KServePredictorPort > 65535 {
+			problems = append(problems, fmt.Sprintf("invalid KServe predictor port: %d (must be 1-65535)", c.KServePredictorPort))
+		}
+		for _, file := range []string{c.KServeBearerTokenFile, c.KServeCAFile} {
+			if file == "" {
+				continue
+			}
+			if _, err := os.Stat(file); err != nil {
+				problems = append(problems, fmt.Sprintf("invalid KServe credentials file: %v", err))
+			}
+		}
+		if errs := validation.IsDNS1123Label(c.KServeForecastModel); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid KServe forecast model %q: %s", c.KServeForecastModel, strings.Join(errs, "; ")))
+		}
+	}
+	if !strings.HasPrefix(c.CEWebhookPath, "/webhooks/") {
+		problems = append(problems, fmt.Sprintf("invalid CE webhook path %q: must start with /webhooks/", c.CEWebhookPath))
+	}
+	if c.CEIncidentPollInterval != 0 && c.CEIncidentPollInterval < 5*time.Second {
+		problems = append(problems, fmt.Sprintf("invalid CE incident poll interval: %v (0 disables, otherwise minimum 5s)", c.CEIncidentPollInterval))
+	}
+	if _, err := parseHeaders(c.KServeHeaders); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid KServe headers: %v", err))
+	}
+	if c.KServeMaxRetries < 0 || c.KServeRetryBudget <= 0 {
+		problems = append(problems, fmt.Sprintf("invalid KServe retry settings: max retries %d, budget %v (retries must not be negative, budget must be positive)", c.KServeMaxRetries, c.KServeRetryBudget))
+	}
+
+	problems = append(problems, validateToolPatterns(c.EnabledTools, c.DisabledTools)...)
+	problems = append(problems, validateCORS(c)...)
+
+	for _, namespace := range c.AllowedNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid allowed namespace %q: %s", namespace, strings.Join(errs, "; ")))
+		}
+	}
+	problems = append(problems, c.validateTenantProfiles()...)
+	if c.EmitActionEvents {
+		if errs := validation.IsDNS1123Label(c.ActionEventsNamespace); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid action events namespace %q: %s", c.ActionEventsNamespace, strings.Join(errs, "; ")))
+		}
+	}
+	if c.NamespacesResourceMaxEntries < 1 {
+		problems = append(problems, fmt.Sprintf("invalid namespaces resource max entries: %d (minimum 1)", c.NamespacesResourceMaxEntries))
+	}
+	if _, err := resources.ParseEventSeverityRules(c.EventSeverityRules); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid event severity rules: %v", err))
+	}
+	if c.EventsResourceMaxPerBucket < 1 {
+		problems = append(problems, fmt.Sprintf("invalid events resource max per bucket: %d (minimum 1)", c.EventsResourceMaxPerBucket))
 	}
 
+	for _, prefix := range c.RawAPIAllowedPrefixes {
+		if err := tools.ValidateRawAPIPrefix(prefix); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid raw API prefix %q: %v", prefix, err))
+		}
+	}
+
+	for _, name := range c.ExtendedResources {
+		if errs := validation.IsQualifiedName(name); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid extended resource name %q: %s", name, strings.Join(errs, "; ")))
+		}
+	}
+
+	for _, namespace := range c.CriticalNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid critical namespace %q: %s", namespace, strings.Join(errs, "; ")))
+		}
+	}
+
+	for _, ref := range c.FinalizerAuditKinds {
+		slash := strings.LastIndex(ref, "/")
+		if slash <= 0 || slash == len(ref)-1 {
+			problems = append(problems, fmt.Sprintf("invalid finalizer audit kind %q (must be apiVersion/Kind, e.g. serving.kserve.io/v1beta1/InferenceService)", ref))
+			continue
+		}
+		if _, err := schema.ParseGroupVersion(ref[:slash]); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid finalizer audit kind %q: %v", ref, err))
+		}
+	}
+
+	if c.KubeletLeaseStaleAfter <= 0 || c.KubeletStatusStaleAfter <= 0 {
+		problems = append(problems, fmt.Sprintf("invalid kubelet staleness thresholds: lease %v, status %v (must be positive)", c.KubeletLeaseStaleAfter, c.KubeletStatusStaleAfter))
+	}
+
+	if c.MustGatherImage == "" {
+		problems = append(problems, "must-gather image must be set")
+	}
+	if errs := validation.IsDNS1123Label(c.MustGatherNamespace); len(errs) > 0 {
+		problems = append(problems, fmt.Sprintf("invalid must-gather namespace %q: %s", c.MustGatherNamespace, strings.Join(errs, "; ")))
+	}
+	if c.MustGatherRetention < 0 {
+		problems = append(problems, fmt.Sprintf("invalid must-gather retention: %v (must not be negative)", c.MustGatherRetention))
+	}
+
+	problems = append(problems, c.validateReportSchedule()...)
+	problems = append(problems, c.validateRemediationPolicies()...)
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
 	return nil
 }
 
+// validateToolPatterns checks tool selection patterns are well-formed and
+// that no enabled entry is also disabled, literally or by a disabled glob
+func validateToolPatterns(enabled, disabled []string) []string {
+	var problems []string
+
+	for _, pattern := range append(append([]string{}, enabled...), disabled...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid tool pattern %q: %v", pattern, err))
+		}
+	}
+
+	// An enabled entry that a disabled pattern covers would be dropped silently
+	for _, pattern := range enabled {
+		for _, disabledPattern := range disabled {
+			if pattern == disabledPattern {
+				problems = append(problems, fmt.Sprintf("tool %q is listed in both enabled and disabled tools", pattern))
+				break
+			}
+			if matched, _ := path.Match(disabledPattern, pattern); matched {
+				problems = append(problems, fmt.Sprintf("enabled tool %q is disabled by pattern %q", pattern, disabledPattern))
+				break
+			}
+		}
+	}
+
+	return problems
+}
+
+// IsToolEnabled reports whether a tool should be registered under the
+// EnabledTools/DisabledTools selection. Disabled patterns take precedence.
+func (c *Config) IsToolEnabled(name string) bool {
+	for _, pattern := range c.DisabledTools {
+		if matched, _ := path.Match(pattern, name); matched {
+			return false
+		}
+	}
+
+	if len(c.EnabledTools) == 0 {
+		return true
+	}
+	for _, pattern := range c.EnabledTools {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHeaders turns "Name: value" entries into a header map
+func parseHeaders(entries []string) (map[string]string, error) {
+	headers := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("%q must be \"Name: value\"", entry)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// headerNames lists the header names of "Name: value" entries without their values
+func headerNames(entries []string) string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name, _, _ := strings.Cut(entry, ":")
+		names = append(names, strings.TrimSpace(name))
+	}
+	return strings.Join(names, ",")
+}
+
+// validateReportSchedule checks the scheduled report settings when a schedule is set
+func (c *Config) validateReportSchedule() []string {
+	if c.ReportSchedule == "" {
+		return nil
+	}
+
+	var problems []string
+	if _, err := parseCron(c.ReportSchedule); err != nil {
+		problems = append(problems, fmt.Sprintf("invalid report schedule: %v", err))
+	}
+	for _, section := range c.ReportSections {
+		if !slices.Contains(tools.HealthReportSections(), section) {
+			problems = append(problems, fmt.Sprintf("invalid report section %q (must be one of %s)", section, strings.Join(tools.HealthReportSections(), ", ")))
+		}
+	}
+	if c.ReportWebhookURL == "" && c.ReportConfigMapName == "" && c.ReportDirectory == "" {
+		problems = append(problems, "report schedule is set but no sink is (REPORT_WEBHOOK_URL, REPORT_CONFIGMAP_NAME, or REPORT_DIRECTORY)")
+	}
+	if c.ReportWebhookURL != "" {
+		if err := validateURL(c.ReportWebhookURL); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid report webhook URL: %v", err))
+		}
+	}
+	if c.ReportConfigMapName != "" {
+		if errs := validation.IsDNS1123Label(c.ReportConfigMapNamespace); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid report configmap namespace %q: %s", c.ReportConfigMapNamespace, strings.Join(errs, "; ")))
+		}
+		if errs := validation.IsDNS1123Subdomain(c.ReportConfigMapName); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid report configmap name %q: %s", c.ReportConfigMapName, strings.Join(errs, "; ")))
+		}
+	}
+	if c.ReportLeaderElection {
+		if errs := validation.IsDNS1123Label(c.ReportLeaseNamespace); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid report lease namespace %q: %s", c.ReportLeaseNamespace, strings.Join(errs, "; ")))
+		}
+	}
+	return problems
+}
+
+// validateURL checks that raw is an absolute http(s) URL. Parse errors
+// quote the URL, credentials included, so only their cause is returned.
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must use http or https", redactURL(raw))
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", redactURL(raw))
+	}
+	return nil
+}
+
+// ConfigSetting is a single key/value pair of the effective configuration
+type ConfigSetting struct {
+	Key   string
+	Value string
+}
+
+// EffectiveSettings returns the effective configuration in a stable order
+// with credentials redacted, suitable for printing or logging.
+func (c *Config) EffectiveSettings() []ConfigSetting {
+	settings := []ConfigSetting{
+		{"config_file", c.ConfigFile},
+		{"transport", string(c.Transport)},
+		{"backend", c.Backend},
+		{"mock_fixtures_dir", c.MockFixturesDir},
+		{"http_host", c.HTTPHost},
+		{"http_port", strconv.Itoa(c.HTTPPort)},
+		{"http_read_header_timeout", c.HTTPReadHeaderTimeout.String()},
+		{"http_read_timeout", c.HTTPReadTimeout.String()},
+		{"http_idle_timeout", c.HTTPIdleTimeout.String()},
+		{"max_request_body_bytes", strconv.FormatInt(c.MaxRequestBodyBytes, 10)},
+		{"websocket_max_message_bytes", strconv.Itoa(c.WebSocketMaxMessageBytes)},
+		{"websocket_ping_interval", c.WebSocketPingInterval.String()},
+		{"stream_heartbeat_interval", c.StreamHeartbeatInterval.String()},
+		{"stream_replay_size", strconv.Itoa(c.StreamReplaySize)},
+		{"log_stream_max_concurrent", strconv.Itoa(c.LogStreamMaxConcurrent)},
+		{"log_stream_max_duration", c.LogStreamMaxDuration.String()},
+		{"log_stream_max_bytes", strconv.FormatInt(c.LogStreamMaxBytes, 10)},
+		{"name", c.Name},
+		{"version", c.Version},
+		{"log_level", c.LogLevel},
+		{"coordination_engine_url", c.CoordinationEngineURL},
+		{"prometheus_url", c.PrometheusURL},
+		{"alertmanager_url", c.AlertmanagerURL},
+		{"kserve_namespace", c.KServeNamespace},
+		{"kserve_predictor_port", strconv.Itoa(c.KServePredictorPort)},
+		{"enable_coordination_engine", strconv.FormatBool(c.EnableCoordinationEngine)},
+		{"enable_prometheus", strconv.FormatBool(c.EnablePrometheus)},
+		{"enable_kserve", strconv.FormatBool(c.EnableKServe)},
+		{"enable_alertmanager", strconv.FormatBool(c.EnableAlertmanager)},
+		{"alert_silence_max_duration", c.AlertSilenceMaxDuration.String()},
+		{"coordination_engine_bearer_token_file", c.CoordinationEngineBearerTokenFile},
+		{"coordination_engine_ca_file", c.CoordinationEngineCAFile},
+		{"kserve_bearer_token_file", c.KServeBearerTokenFile},
+		{"kserve_headers", headerNames(c.KServeHeaders)}, // Values may hold credentials
+		{"kserve_ca_file", c.KServeCAFile},
+		{"kserve_max_retries", strconv.Itoa(c.KServeMaxRetries)},
+		{"kserve_retry_budget", c.KServeRetryBudget.String()},
+		{"kserve_forecast_model", c.KServeForecastModel},
+		{"ce_webhook_path", c.CEWebhookPath},
+		{"ce_webhook_secret", c.CEWebhookSecret},
+		{"ce_incident_poll_interval", c.CEIncidentPollInterval.String()},
+		{"cache_ttl", c.CacheTTL.String()},
+		{"request_timeout", c.RequestTimeout.String()},
+		{"max_concurrent_tools", strconv.Itoa(c.MaxConcurrentTools)},
+		{"max_concurrent_heavy_tools", strconv.Itoa(c.MaxConcurrentHeavyTools)},
+		{"tool_queue_timeout", c.ToolQueueTimeout.String()},
+		{"slow_tool_threshold", c.SlowToolThreshold.String()},
+		{"max_result_bytes", strconv.Itoa(c.MaxResultBytes)},
+		{"k8s_client_qps", strconv.FormatFloat(c.K8sClientQPS, 'f', -1, 64)},
+		{"k8s_client_burst", strconv.Itoa(c.K8sClientBurst)},
+		{"health_collection_concurrency", strconv.Itoa(c.HealthConcurrency)},
+		{"health_rules", strings.Join(c.HealthRules, ",")},
+		{"dependency_failure_ttl", c.DependencyFailureTTL.String()},
+		{"health_history_interval", c.HealthHistoryInterval.String()},
+		{"health_history_size", strconv.Itoa(c.HealthHistorySize)},
+		{"event_history_enabled", strconv.FormatBool(c.EventHistoryEnabled)},
+		{"event_history_retention", c.EventHistoryRetention.String()},
+		{"event_history_max_records", strconv.Itoa(c.EventHistoryMaxRecords)},
+		{"event_history_file", c.EventHistoryFile},
+		{"event_history_leader_election", strconv.FormatBool(c.EventHistoryLeaderElection)},
+		{"event_history_lease_namespace", c.EventHistoryLeaseNamespace},
+		{"capability_probe_interval", c.CapabilityProbeInterval.String()},
+		{"permission_check_interval", c.PermissionCheckInterval.String()},
+		{"enable_auth", strconv.FormatBool(c.EnableAuth)},
+		{"enable_cache_warmup", strconv.FormatBool(c.EnableCacheWarmup)},
+		{"cache_warmup_timeout", c.CacheWarmupTimeout.String()},
+		{"ready_after_warmup", strconv.FormatBool(c.ReadyAfterWarmup)},
+		{"session_store", c.SessionStore},
+		{"session_store_path", c.SessionStorePath},
+		{"session_store_namespace", c.SessionStoreNamespace},
+		{"session_store_name", c.SessionStoreName},
+		{"session_store_max_bytes", strconv.Itoa(c.SessionStoreMaxBytes)},
+		{"enabled_tools", strings.Join(c.EnabledTools, ",")},
+		{"disabled_tools", strings.Join(c.DisabledTools, ",")},
+		{"read_only", strconv.FormatBool(c.ReadOnly)},
+		{"emit_action_events", strconv.FormatBool(c.EmitActionEvents)},
+		{"action_events_namespace", c.ActionEventsNamespace},
+		{"redaction_patterns", strings.Join(c.RedactionPatterns, ",")},
+		{"allowed_namespaces", strings.Join(c.AllowedNamespaces, ",")},
+		{"manifest_denied_kinds", strings.Join(c.ManifestDeniedKinds, ",")},
+		{"tenant_profiles", describeTenantProfiles(c.TenantProfiles)},
+		{"namespaces_resource_max_entries", strconv.Itoa(c.NamespacesResourceMaxEntries)},
+		{"event_severity_rules", strings.Join(c.EventSeverityRules, ",")},
+		{"events_resource_max_per_bucket", strconv.Itoa(c.EventsResourceMaxPerBucket)},
+		{"events_resource_include_info", strconv.FormatBool(c.EventsResourceIncludeInfo)},
+		{"raw_api_allowed_prefixes", strings.Join(c.RawAPIAllowedPrefixes, ",")},
+		{"raw_api_denied_resources", strings.Join(c.RawAPIDeniedResources, ",")},
+		{"extended_resources", strings.Join(c.ExtendedResources, ",")},
+		{"critical_namespaces", strings.Join(c.CriticalNamespaces, ",")},
+		{"finalizer_audit_kinds", strings.Join(c.FinalizerAuditKinds, ",")},
+		{"kubelet_lease_stale_after", c.KubeletLeaseStaleAfter.String()},
+		{"kubelet_status_stale_after", c.KubeletStatusStaleAfter.String()},
+		{"enable_kubelet_healthz_probe", strconv.FormatBool(c.KubeletHealthzProbe)},
+		{"must_gather_image", c.MustGatherImage},
+		{"must_gather_namespace", c.MustGatherNamespace},
+		{"must_gather_service_account", c.MustGatherServiceAccount},
+		{"must_gather_pvc", c.MustGatherPVC},
+		{"must_gather_retention", c.MustGatherRetention.String()},
+		{"report_schedule", c.ReportSchedule},
+		{"report_sections", strings.Join(c.ReportSections, ",")},
+		{"report_webhook_url", redactWebhookURL(c.ReportWebhookURL)},
+		{"report_configmap_namespace", c.ReportConfigMapNamespace},
+		{"report_configmap_name", c.ReportConfigMapName},
+		{"report_directory", c.ReportDirectory},
+		{"report_leader_election", strconv.FormatBool(c.ReportLeaderElection)},
+		{"report_lease_namespace", c.ReportLeaseNamespace},
+		{"remediation_policies", describeRemediationPolicies(c.RemediationPolicies)},
+		{"remediation_policies_enabled", strconv.FormatBool(c.RemediationPoliciesEnabled)},
+		{"remediation_webhook_url", redactWebhookURL(c.RemediationWebhookURL)},
+		{"remediation_leader_election", strconv.FormatBool(c.RemediationLeaderElection)},
+		{"remediation_lease_namespace", c.RemediationLeaseNamespace},
+		{"artifact_directory", c.ArtifactDirectory},
+		{"artifact_ttl", c.ArtifactTTL.String()},
+		{"artifact_max_bytes", strconv.FormatInt(c.ArtifactMaxBytes, 10)},
+		{"otlp_endpoint", c.OTLPEndpoint},
+		{"cors_allowed_origins", strings.Join(c.CORSAllowedOrigins, ",")},
+		{"cors_allowed_methods", strings.Join(c.CORSAllowedMethods, ",")},
+		{"cors_allowed_headers", strings.Join(c.CORSAllowedHeaders, ",")},
+		{"cors_max_age", c.CORSMaxAge.String()},
+		{"cors_allow_credentials", strconv.FormatBool(c.CORSAllowCredentials)},
+		{"admin_token", c.AdminToken},
+	}
+
+	for i := range settings {
+		settings[i].Value = redactSetting(settings[i].Key, settings[i].Value)
+	}
+	return settings
+}
+
+// redactedValue replaces secret material in printed configuration
+const redactedValue = "REDACTED"
+
+// sensitiveKeyParts mark setting or query parameter names holding secrets
+var sensitiveKeyParts = []string{"token", "password", "secret", "apikey", "api_key"}
+
+// isSensitiveKey reports whether a setting name refers to secret material
+func isSensitiveKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSetting hides secrets: sensitive keys are fully redacted and
+// URL values have embedded credentials removed.
+func redactSetting(key, value string) string {
+	if value == "" {
+		return value
+	}
+	if isSensitiveKey(key) {
+		return redactedValue
+	}
+	if strings.HasSuffix(key, "_url") {
+		return redactURL(value)
+	}
+	return value
+}
+
+// redactWebhookURL keeps only the scheme and host of a webhook URL: incoming
+// webhooks such as Slack's carry their secret in the path
+func redactWebhookURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Path == "" && u.RawQuery == "") {
+		return redactURL(raw)
+	}
+	return fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, redactedValue)
+}
+
+// unparseableURL stands in for a URL that cannot be parsed, and so cannot
+// have its credentials picked out
+const unparseableURL = "<redacted: unparseable URL>"
+
+// redactURL strips userinfo passwords and sensitive query parameters from a URL
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return unparseableURL
+	}
+
+	if u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			u.User = url.UserPassword(u.User.Username(), redactedValue)
+		} else {
+			u.User = url.User(redactedValue)
+		}
+	}
+
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key := range query {
+			if isSensitiveKey(key) {
+				query.Set(key, redactedValue)
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
 // GetHTTPAddr returns the HTTP listen address
 func (c *Config) GetHTTPAddr() string {
 	return fmt.Sprintf("%s:%d", c.HTTPHost, c.HTTPPort)
@@ -128,6 +1797,24 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvInt64(key string, defaultValue int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvBool(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		if boolValue, err := strconv.ParseBool(value); err == nil {
@@ -146,6 +1833,22 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+// getEnvList reads a comma-separated list, ignoring empty entries
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func getEnvTransport(key string, defaultValue TransportType) TransportType {
 	value := os.Getenv(key)
 	if value == "" {
@@ -153,7 +1856,7 @@ func getEnvTransport(key string, defaultValue TransportType) TransportType {
 	}
 
 	transport := TransportType(value)
-	if transport == TransportHTTP || transport == TransportStdio {
+	if transport.ServesHTTP() || transport == TransportStdio {
 		return transport
 	}
 
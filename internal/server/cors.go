@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// corsAnyOrigin allows every origin (not permitted together with credentials)
+const corsAnyOrigin = "*"
+
+// withCORS applies the configured CORS policy to /mcp routes.
+// With no allowed origins configured no CORS headers are ever sent, so
+// browsers keep enforcing the same-origin policy.
+func (s *MCPServer) withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !isCORSRoute(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cfg := s.currentConfig()
+		w.Header().Add("Vary", "Origin")
+		isPreflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		if !corsOriginAllowed(cfg.CORSAllowedOrigins, origin) {
+			if isPreflight {
+				writeJSONError(w, http.StatusForbidden, fmt.Sprintf("origin %q is not allowed", origin))
+				return
+			}
+			// Serve the request without CORS headers; the browser withholds the response
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if slices.Contains(cfg.CORSAllowedOrigins, corsAnyOrigin) && !cfg.CORSAllowCredentials {
+			w.Header().Set("Access-Control-Allow-Origin", corsAnyOrigin)
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.CORSAllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !isPreflight {
+			w.Header().Set("Access-Control-Expose-Headers", "X-MCP-Session-ID, X-Request-ID, ETag")
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Access-Control-Request-Method")
+		w.Header().Add("Vary", "Access-Control-Request-Headers")
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(cfg.CORSAllowedMethods, ", "))
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join(cfg.CORSAllowedHeaders, ", "))
+		if cfg.CORSMaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(cfg.CORSMaxAge.Seconds())))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// isCORSRoute reports whether CORS applies to path (the /mcp routes only)
+func isCORSRoute(path string) bool {
+	return path == "/mcp" || strings.HasPrefix(path, "/mcp/")
+}
+
+// corsOriginAllowed reports whether origin matches the allowed list
+func corsOriginAllowed(allowed []string, origin string) bool {
+	for _, candidate := range allowed {
+		if candidate == corsAnyOrigin || strings.EqualFold(candidate, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateCORS checks the CORS settings for mistakes browsers would reject
+func validateCORS(c *Config) []string {
+	var problems []string
+
+	for _, origin := range c.CORSAllowedOrigins {
+		if origin == corsAnyOrigin {
+			if c.CORSAllowCredentials {
+				problems = append(problems, `CORS origin "*" cannot be combined with allowed credentials; list explicit origins`)
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			problems = append(problems, fmt.Sprintf("invalid CORS origin %q (expected scheme://host[:port])", origin))
+		}
+	}
+
+	if c.CORSMaxAge < 0 {
+		problems = append(problems, fmt.Sprintf("invalid CORS max age: %v (must not be negative)", c.CORSMaxAge))
+	}
+
+	return problems
+}
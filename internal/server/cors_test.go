@@ -0,0 +1,116 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// corsRequest sends a request with an Origin header through the full handler chain
+func corsRequest(server *MCPServer, method, path, origin string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	server.httpHandler().ServeHTTP(w, req)
+	return w
+}
+
+func preflightHeaders(method string) map[string]string {
+	return map[string]string{
+		"Access-Control-Request-Method":  method,
+		"Access-Control-Request-Headers": "authorization, content-type",
+	}
+}
+
+func TestCORS_DisabledByDefault(t *testing.T) {
+	server := newStubToolServer(t, NewConfig(), "list-pods")
+
+	w := corsRequest(server, http.MethodGet, "/mcp/tools", "https://dashboard.example.com", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = corsRequest(server, http.MethodOptions, "/mcp/tools/list-pods/call", "https://dashboard.example.com", preflightHeaders(http.MethodPost))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CORSAllowedOrigins = []string{"https://dashboard.example.com"}
+	server := newStubToolServer(t, cfg, "list-pods")
+
+	w := corsRequest(server, http.MethodGet, "/mcp/tools", "https://dashboard.example.com", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, w.Header().Values("Vary"), "Origin")
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+
+	// Preflight for a tool call carrying an Authorization header
+	w = corsRequest(server, http.MethodOptions, "/mcp/tools/list-pods/call", "https://dashboard.example.com", preflightHeaders(http.MethodPost))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), http.MethodPost)
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CORSAllowedOrigins = []string{"https://dashboard.example.com"}
+	server := newStubToolServer(t, cfg, "list-pods")
+
+	w := corsRequest(server, http.MethodGet, "/mcp/tools", "https://evil.example.com", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w = corsRequest(server, http.MethodOptions, "/mcp/tools/list-pods/call", "https://evil.example.com", preflightHeaders(http.MethodPost))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Headers"))
+}
+
+func TestCORS_Credentials(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CORSAllowedOrigins = []string{"https://dashboard.example.com"}
+	cfg.CORSAllowCredentials = true
+	server := newStubToolServer(t, cfg, "list-pods")
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+
+	w := corsRequest(server, http.MethodOptions, "/mcp/tools/list-pods/call", "https://dashboard.example.com", preflightHeaders(http.MethodPost))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+
+	w = corsRequest(server, http.MethodPost, "/mcp/tools/list-pods/call?sessionid="+session.ID, "https://dashboard.example.com",
+		map[string]string{"Authorization": "Bearer token", "Content-Type": "application/json"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://dashboard.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORS_WildcardOrigin(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CORSAllowedOrigins = []string{"*"}
+	server := newStubToolServer(t, cfg, "list-pods")
+
+	w := corsRequest(server, http.MethodGet, "/mcp/tools", "https://anywhere.example.com", nil)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_OnlyMCPRoutes(t *testing.T) {
+	cfg := NewConfig()
+	cfg.CORSAllowedOrigins = []string{"https://dashboard.example.com"}
+	server := newStubToolServer(t, cfg)
+
+	w := corsRequest(server, http.MethodGet, "/health", "https://dashboard.example.com", nil)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	// Thursday 2026-10-15 07:20 UTC
+	now := time.Date(2026, 10, 15, 7, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 10, 15, 7, 30, 0, 0, time.UTC)},
+		{"0 7 * * *", time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC)},
+		{"30 6 * * 1-5", time.Date(2026, 10, 16, 6, 30, 0, 0, time.UTC)},
+		{"0 9 * * 6,7", time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)},
+		{"5/20 7 * * *", time.Date(2026, 10, 15, 7, 25, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matches (the 20th or any Monday)
+		{"0 0 20 * 1", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := parseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.next(now))
+		})
+	}
+}
+
+func TestParseCron_NextKeepsLocation(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+	schedule, err := parseCron("0 * * * *")
+	require.NoError(t, err)
+
+	next := schedule.next(time.Date(2026, 10, 15, 7, 10, 0, 0, kolkata))
+	assert.Equal(t, time.Date(2026, 10, 15, 8, 0, 0, 0, kolkata), next)
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"@fortnightly",
+		"0 7 * * MON",
+	} {
+		_, err := parseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
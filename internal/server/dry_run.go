@@ -0,0 +1,65 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// DryRunTool is implemented by mutating tools that honor tools.IsDryRun:
+// they validate the call and predict its effect, using Kubernetes
+// server-side dry-run where the API supports it, without changing anything
+type DryRunTool interface {
+	SupportsDryRun() bool
+}
+
+// supportsDryRun reports whether tool accepts the dry_run argument
+func supportsDryRun(tool Tool) bool {
+	if !isMutating(tool) {
+		return false
+	}
+	dryRunTool, ok := tool.(DryRunTool)
+	return ok && dryRunTool.SupportsDryRun()
+}
+
+// takeDryRun removes the dry_run argument of a mutating tool call and reports
+// whether it was set. Tools that cannot dry-run refuse dry_run: true rather
+// than silently making the change.
+func takeDryRun(tool Tool, args map[string]interface{}) (map[string]interface{}, bool, error) {
+	value, ok := args[tools.DryRunArg]
+	if !ok || !isMutating(tool) {
+		return args, false, nil
+	}
+
+	dryRun, ok := value.(bool)
+	if !ok {
+		return nil, false, &tools.InvalidArgumentsError{Err: fmt.Errorf("%s must be a boolean", tools.DryRunArg)}
+	}
+	if dryRun && !supportsDryRun(tool) {
+		return nil, false, &tools.InvalidArgumentsError{Err: fmt.Errorf("tool %s does not support %s", tool.Name(), tools.DryRunArg)}
+	}
+
+	args = maps.Clone(args)
+	delete(args, tools.DryRunArg)
+	return args, dryRun, nil
+}
+
+// toolInputSchema is the input schema advertised for tool: its own schema,
+// plus the _max_bytes and dry_run arguments handled by the dispatcher
+func toolInputSchema(tool Tool) map[string]interface{} {
+	schema := tool.InputSchema()
+	properties, _ := schema["properties"].(map[string]interface{})
+	properties = maps.Clone(properties)
+	if properties == nil {
+		properties = make(map[string]interface{})
+	}
+	properties[tools.MaxBytesArg] = tools.MaxBytesProperty()
+	if supportsDryRun(tool) {
+		properties[tools.DryRunArg] = tools.DryRunProperty()
+	}
+
+	schema = maps.Clone(schema)
+	schema["properties"] = properties
+	return schema
+}
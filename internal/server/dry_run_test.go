@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// dryRunStubTool is a mutating stub tool that supports dry-run and records how it was called
+type dryRunStubTool struct {
+	mutatingStubTool
+	lastArgs   map[string]interface{}
+	lastDryRun bool
+}
+
+func (t *dryRunStubTool) SupportsDryRun() bool { return true }
+func (t *dryRunStubTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	t.lastArgs = args
+	t.lastDryRun = tools.IsDryRun(ctx)
+	return t.mutatingStubTool.Execute(ctx, args)
+}
+
+func TestExecuteTool_DryRun(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ReadOnly = true
+	server := newStubToolServer(t, cfg)
+	logs := captureLog(t)
+	tool := &dryRunStubTool{mutatingStubTool: mutatingStubTool{stubTool: stubTool{name: "rollback-deployment"}}}
+
+	// Dry-runs change nothing, so read-only mode allows them
+	args := map[string]interface{}{"name": "web", "dry_run": true}
+	_, _, err := server.executeTool(context.Background(), tool, args)
+	require.NoError(t, err)
+	assert.True(t, tool.lastDryRun)
+	assert.Equal(t, map[string]interface{}{"name": "web"}, tool.lastArgs, "the dispatcher consumes dry_run")
+	assert.Contains(t, args, "dry_run", "the caller's arguments are left alone")
+
+	_, _, err = server.executeTool(context.Background(), tool, map[string]interface{}{"name": "web", "dry_run": false})
+	assert.ErrorIs(t, err, errReadOnly)
+
+	_, _, err = server.executeTool(context.Background(), tool, map[string]interface{}{"name": "web", "dry_run": "yes"})
+	assert.True(t, tools.IsInvalidArguments(err))
+	assert.Equal(t, 1, tool.calls)
+
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 3)
+	assert.True(t, entries[0].DryRun)
+	assert.Equal(t, outcomeSuccess, entries[0].Outcome)
+	assert.False(t, entries[1].DryRun)
+	assert.Equal(t, outcomeBlocked, entries[1].Outcome)
+}
+
+func TestExecuteTool_DryRunUnsupported(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+
+	// A mutating tool that cannot dry-run must not make the change instead
+	tool := &mutatingStubTool{stubTool: stubTool{name: "scale-deployment"}}
+	_, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{"dry_run": true})
+	require.Error(t, err)
+	assert.True(t, tools.IsInvalidArguments(err))
+	assert.Zero(t, tool.calls)
+
+	_, _, err = server.executeTool(context.Background(), tool, map[string]interface{}{"dry_run": false})
+	require.NoError(t, err)
+	assert.Equal(t, 1, tool.calls)
+}
+
+func TestToolInputSchema_DryRun(t *testing.T) {
+	tool := &dryRunStubTool{mutatingStubTool: mutatingStubTool{stubTool: stubTool{name: "rollback-deployment"}}}
+	properties := toolInputSchema(tool)["properties"].(map[string]interface{})
+	assert.Contains(t, properties, tools.DryRunArg)
+	assert.NotContains(t, tool.InputSchema()["properties"], tools.DryRunArg)
+
+	for _, tool := range []Tool{&stubTool{name: "list-pods"}, &mutatingStubTool{stubTool: stubTool{name: "scale-deployment"}}} {
+		assert.NotContains(t, toolInputSchema(tool)["properties"], tools.DryRunArg, tool.Name())
+	}
+}
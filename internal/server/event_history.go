@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/eventhistory"
+)
+
+const (
+	// eventHistoryLeaseName is the Lease replicas compete for when EVENT_HISTORY_LEADER_ELECTION is on
+	eventHistoryLeaseName = "cluster-health-mcp-event-history"
+	// eventHistoryFlushInterval is how often the history is pruned and
+	// persisted, and how often followers re-read the persisted history
+	eventHistoryFlushInterval = time.Minute
+)
+
+// eventHistoryRecorder records cluster events into the event history. With
+// leader election only the replica holding the Lease records; the others
+// serve the history the leader persists, when it persists to a shared file.
+type eventHistoryRecorder struct {
+	store     *eventhistory.Store
+	k8sClient *clients.K8sClient
+	stream    *eventStream // Where recorded events are sent for /stream/events
+	recording atomic.Bool
+}
+
+// newEventHistory creates the event history store, loading what an earlier
+// run persisted. A history that cannot be loaded starts empty.
+func newEventHistory(config *Config, k8sClient *clients.K8sClient) *eventHistoryRecorder {
+	store, err := eventhistory.NewStore(eventhistory.Options{
+		MaxRecords: config.EventHistoryMaxRecords,
+		Retention:  config.EventHistoryRetention,
+		Path:       config.EventHistoryFile,
+	})
+	if err != nil {
+		log.Printf("WARNING: event history not loaded, starting empty: %v", err)
+	}
+	if store == nil {
+		return nil
+	}
+	log.Printf("Initialized event history (retention: %v, max: %d events, %d loaded)", config.EventHistoryRetention, config.EventHistoryMaxRecords, store.Stats().Records)
+	return &eventHistoryRecorder{store: store, k8sClient: k8sClient}
+}
+
+// run records events until ctx is done, competing for the event history
+// Lease first when leaderElection is on, and persists the history on the way
+func (r *eventHistoryRecorder) run(ctx context.Context, leaderElection bool, namespace string) {
+	if leaderElection {
+		go runLeaderElection(ctx, r.k8sClient.Clientset(), namespace, eventHistoryLeaseName, leaderIdentity(),
+			func(leaderCtx context.Context) {
+				log.Printf("Leading event history recording (lease %s/%s)", namespace, eventHistoryLeaseName)
+				r.record(leaderCtx)
+			},
+			func() {
+				log.Printf("No longer leading event history recording")
+			})
+	} else {
+		go r.record(ctx)
+	}
+	r.maintain(ctx, eventHistoryFlushInterval)
+}
+
+// record watches events in all namespaces and adds them to the store until
+// ctx is done. Deletions are ignored: the API server deleting an event after
+// its TTL is what the history outlives.
+func (r *eventHistoryRecorder) record(ctx context.Context) {
+	r.recording.Store(true)
+	defer r.recording.Store(false)
+
+	watcher := clients.NewWatchHelper[*corev1.Event](r.k8sClient.EventListWatch(""), nil)
+	for event := range watcher.Start(ctx) {
+		if event.Type != watch.Deleted {
+			r.store.Observe(event.Object)
+			r.stream.publish("event", event.Object.Namespace, eventhistory.NewRecord(event.Object))
+		}
+	}
+}
+
+// maintain prunes and persists the history every interval while recording,
+// and re-reads the persisted history while another replica records. The
+// history is persisted once more on shutdown.
+func (r *eventHistoryRecorder) maintain(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := r.store.Flush(); err != nil {
+				log.Printf("WARNING: %v", err)
+			}
+			return
+		case <-ticker.C:
+		}
+
+		if !r.recording.Load() {
+			if err := r.store.Reload(); err != nil {
+				log.Printf("WARNING: %v", err)
+			}
+			continue
+		}
+		r.store.Prune()
+		if err := r.store.Flush(); err != nil {
+			log.Printf("WARNING: %v", err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestEventHistoryRecorder_RecordsWhileLeading(t *testing.T) {
+	now := time.Now()
+	clientset := fake.NewClientset(&corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "api-0.1", Namespace: "shop", UID: "event-1"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "api-0"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "BackOff",
+		Count:          4,
+		FirstTimestamp: metav1.NewTime(now.Add(-10 * time.Minute)),
+		LastTimestamp:  metav1.NewTime(now.Add(-time.Minute)),
+	})
+	cfg := NewConfig()
+	cfg.EventHistoryEnabled = true
+	cfg.EventHistoryFile = filepath.Join(t.TempDir(), "events.json")
+	recorder := newEventHistory(cfg, clients.NewK8sClientWithClientset(clientset))
+	require.NotNil(t, recorder)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		recorder.run(ctx, true, "mcp")
+	}()
+	require.Eventually(t, func() bool { return recorder.store.Stats().Records == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, recorder.recording.Load())
+	lease, err := clientset.CoordinationV1().Leases("mcp").Get(context.Background(), eventHistoryLeaseName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, *lease.Spec.HolderIdentity)
+
+	// The API server expiring an event leaves its record in place
+	require.NoError(t, clientset.CoreV1().Events("shop").Delete(context.Background(), "api-0.1", metav1.DeleteOptions{}))
+	cancel()
+	<-done
+	events := recorder.store.Events("shop", time.Time{})
+	require.Len(t, events, 1)
+	assert.Equal(t, int32(4), events[0].Count)
+
+	// Persisted on shutdown, and loaded by the next run
+	reloaded := newEventHistory(cfg, clients.NewK8sClientWithClientset(fake.NewClientset()))
+	require.NotNil(t, reloaded)
+	assert.Equal(t, 1, reloaded.store.Stats().Records)
+}
@@ -0,0 +1,294 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// streamSubscriberBuffer is how many events a stream client may fall
+	// behind before it is disconnected; it catches up by reconnecting with
+	// Last-Event-ID, from the replay buffer
+	streamSubscriberBuffer = 64
+	// streamRetry is the reconnect delay suggested to clients
+	streamRetry = 2 * time.Second
+	// streamResyncReason is the data of the resync event sent to a client
+	// whose Last-Event-ID is no longer in the replay buffer
+	streamResyncReason = "replay window exceeded, full resync required"
+)
+
+// streamEvent is one event of an eventStream
+type streamEvent struct {
+	seq       uint64
+	kind      string // The SSE event field
+	namespace string // Namespace the event is about, "" for cluster-wide events
+	data      []byte
+}
+
+// streamSubscriber is one connected client. Its channel is closed when the
+// stream drops it for falling behind, or when the stream is closed.
+type streamSubscriber struct {
+	events chan streamEvent
+}
+
+// eventStream fans events out to SSE clients. Every event gets the next
+// sequence number; IDs are "<epoch>-<seq>", the epoch telling this process's
+// IDs from those of an earlier one. The last events are kept so that a
+// client reconnecting with Last-Event-ID gets what it missed.
+type eventStream struct {
+	epoch string
+
+	mu          sync.Mutex
+	buffer      []streamEvent // Oldest first, at most size
+	size        int
+	lastSeq     uint64
+	subscribers map[*streamSubscriber]struct{}
+	closed      bool
+}
+
+// newEventStream creates a stream replaying up to size events
+func newEventStream(size int) *eventStream {
+	return &eventStream{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		size:        size,
+		subscribers: make(map[*streamSubscriber]struct{}),
+	}
+}
+
+// id returns the SSE ID of the event numbered seq
+func (e *eventStream) id(seq uint64) string {
+	return e.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// publish sends v, encoded as JSON, to every client as an event of kind. A
+// nil stream drops it, so publishers need not check whether streams are on.
+func (e *eventStream) publish(kind, namespace string, v interface{}) {
+	if e == nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("WARNING: dropping %s stream event: %v", kind, err)
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	e.lastSeq++
+	event := streamEvent{seq: e.lastSeq, kind: kind, namespace: namespace, data: data}
+	if len(e.buffer) == e.size {
+		e.buffer = append(e.buffer[:0], e.buffer[1:]...)
+	}
+	e.buffer = append(e.buffer, event)
+
+	for subscriber := range e.subscribers {
+		select {
+		case subscriber.events <- event:
+		default:
+			// Blocking here would stall every client; this one replays on reconnect
+			close(subscriber.events)
+			delete(e.subscribers, subscriber)
+		}
+	}
+}
+
+// subscribe registers a client. With a lastEventID it also returns the
+// buffered events after it or, when they are no longer all buffered (or the
+// ID is from another process), the ID of the last event published for a
+// resync event. Replay and registration happen under one lock, so no event
+// is both replayed and delivered, or neither. A nil subscriber means the
+// stream is closed.
+func (e *eventStream) subscribe(lastEventID string) (subscriber *streamSubscriber, replay []streamEvent, resyncID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return nil, nil, ""
+	}
+
+	if lastEventID != "" {
+		var resync bool
+		if replay, resync = e.replayAfter(lastEventID); resync {
+			resyncID = e.id(e.lastSeq)
+		}
+	}
+	subscriber = &streamSubscriber{events: make(chan streamEvent, streamSubscriberBuffer)}
+	e.subscribers[subscriber] = struct{}{}
+	return subscriber, replay, resyncID
+}
+
+// replayAfter returns the buffered events after lastEventID, or resync when
+// some were evicted or the ID is not one this stream issued. Callers hold e.mu.
+func (e *eventStream) replayAfter(lastEventID string) ([]streamEvent, bool) {
+	epoch, seqText, ok := strings.Cut(lastEventID, "-")
+	seq, err := strconv.ParseUint(seqText, 10, 64)
+	if !ok || err != nil || epoch != e.epoch || seq > e.lastSeq {
+		return nil, true
+	}
+	oldest := e.lastSeq + 1
+	if len(e.buffer) > 0 {
+		oldest = e.buffer[0].seq
+	}
+	if seq+1 < oldest {
+		return nil, true
+	}
+	var replay []streamEvent
+	for _, event := range e.buffer {
+		if event.seq > seq {
+			replay = append(replay, event)
+		}
+	}
+	return replay, false
+}
+
+// unsubscribe removes a client, if the stream has not dropped it already
+func (e *eventStream) unsubscribe(subscriber *streamSubscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.subscribers[subscriber]; ok {
+		close(subscriber.events)
+		delete(e.subscribers, subscriber)
+	}
+}
+
+// close ends every client's stream and refuses new ones, so that HTTP
+// shutdown does not wait for streams that never end by themselves
+func (e *eventStream) close() {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	for subscriber := range e.subscribers {
+		close(subscriber.events)
+		delete(e.subscribers, subscriber)
+	}
+}
+
+// serveEventStream serves stream to one client as server-sent events until
+// the client goes away, the stream drops it, or the stream is closed. A
+// reconnecting client presenting Last-Event-ID (the header, or the
+// last_event_id query parameter for clients that cannot set headers) first
+// gets the events it missed, or a resync event. Idle streams get a comment
+// line every heartbeat so that proxies see traffic. Events whose namespace
+// allow rejects are skipped.
+func serveEventStream(w http.ResponseWriter, r *http.Request, stream *eventStream, heartbeat time.Duration, allow func(namespace string) bool) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSONError(w, http.StatusInternalServerError, "streaming is not supported by this connection")
+		return
+	}
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	subscriber, replay, resyncID := stream.subscribe(lastEventID)
+	if subscriber == nil {
+		writeJSONError(w, http.StatusServiceUnavailable, "server is shutting down")
+		return
+	}
+	defer stream.unsubscribe(subscriber)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Keep nginx-style proxies from buffering the stream
+	w.WriteHeader(http.StatusOK)
+
+	write := func(event streamEvent) error {
+		if !allow(event.namespace) {
+			return nil
+		}
+		_, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", stream.id(event.seq), event.kind, event.data)
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "retry: %d\n\n", streamRetry.Milliseconds())
+	if err == nil && resyncID != "" {
+		// Its ID moves the client past everything it cannot get back
+		data, _ := json.Marshal(map[string]string{"reason": streamResyncReason, "last_event_id": lastEventID})
+		_, err = fmt.Fprintf(w, "id: %s\nevent: resync\ndata: %s\n\n", resyncID, data)
+	}
+	for _, event := range replay {
+		if err != nil {
+			break
+		}
+		err = write(event)
+	}
+	if err != nil {
+		return
+	}
+	flusher.Flush()
+
+	var ticks <-chan time.Time
+	if heartbeat > 0 {
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-subscriber.events:
+			if !ok {
+				return
+			}
+			err = write(event)
+		case <-ticks:
+			_, err = fmt.Fprint(w, ": keepalive\n\n")
+		}
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// handleHealthStream streams health history samples as they are taken
+// GET /stream/health - Server-sent events of type "health", one per sample
+func (s *MCPServer) handleHealthStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed - use GET")
+		return
+	}
+	if s.healthStream == nil {
+		writeJSONError(w, http.StatusNotFound, "Health history is disabled (HEALTH_HISTORY_INTERVAL=0)")
+		return
+	}
+	serveEventStream(w, r, s.healthStream, s.currentConfig().StreamHeartbeatInterval, func(string) bool { return true })
+}
+
+// handleEventStream streams cluster events as the event history records them
+// GET /stream/events?namespace=... - Server-sent events of type "event"
+func (s *MCPServer) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed - use GET")
+		return
+	}
+	if s.eventStream == nil {
+		writeJSONError(w, http.StatusNotFound, "Event history is disabled (EVENT_HISTORY_ENABLED=false)")
+		return
+	}
+	namespace := r.URL.Query().Get("namespace")
+	allowed := s.currentConfig().AllowedNamespaces
+	if namespace != "" && len(allowed) > 0 && !slices.Contains(allowed, namespace) {
+		writeJSONError(w, http.StatusForbidden, fmt.Sprintf("namespace %q is not in the allowed namespaces", namespace))
+		return
+	}
+	serveEventStream(w, r, s.eventStream, s.currentConfig().StreamHeartbeatInterval, func(eventNamespace string) bool {
+		if namespace != "" {
+			return eventNamespace == namespace
+		}
+		return len(allowed) == 0 || slices.Contains(allowed, eventNamespace)
+	})
+}
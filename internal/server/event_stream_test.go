@@ -0,0 +1,260 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sseMessage is one event or comment read from a stream
+type sseMessage struct {
+	id, event, data, comment string
+}
+
+// sseClient reads server-sent events from one connection
+type sseClient struct {
+	resp   *http.Response
+	reader *bufio.Reader
+	cancel context.CancelFunc
+}
+
+// connectSSE opens url, presenting lastEventID when set
+func connectSSE(t *testing.T, url, lastEventID string) *sseClient {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	client := &sseClient{resp: resp, reader: bufio.NewReader(resp.Body), cancel: cancel}
+	t.Cleanup(client.close)
+	return client
+}
+
+func (c *sseClient) close() {
+	c.cancel()
+	_ = c.resp.Body.Close()
+}
+
+// next returns the next event or comment, skipping the retry hint
+func (c *sseClient) next(t *testing.T) sseMessage {
+	t.Helper()
+	var msg sseMessage
+	for {
+		line, err := c.reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if msg != (sseMessage{}) {
+				return msg
+			}
+		case strings.HasPrefix(line, ":"):
+			msg.comment = strings.TrimSpace(strings.TrimPrefix(line, ":"))
+		case strings.HasPrefix(line, "id: "):
+			msg.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			msg.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			msg.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+// nextData reads n events and returns their data
+func (c *sseClient) nextData(t *testing.T, n int) ([]string, string) {
+	t.Helper()
+	var data []string
+	var lastID string
+	for len(data) < n {
+		msg := c.next(t)
+		if msg.event == "" {
+			continue
+		}
+		data = append(data, msg.data)
+		lastID = msg.id
+	}
+	return data, lastID
+}
+
+// newStreamServer serves stream as serveEventStream does for the handlers
+func newStreamServer(t *testing.T, stream *eventStream, heartbeat time.Duration) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serveEventStream(w, r, stream, heartbeat, func(namespace string) bool { return namespace != "hidden" })
+	}))
+	t.Cleanup(func() {
+		stream.close()
+		server.Close()
+	})
+	return server
+}
+
+// waitForSubscribers waits until n clients are subscribed to stream
+func waitForSubscribers(t *testing.T, stream *eventStream, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		stream.mu.Lock()
+		defer stream.mu.Unlock()
+		return len(stream.subscribers) == n
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestEventStream_ReconnectWithoutGapsOrDuplicates(t *testing.T) {
+	stream := newEventStream(16)
+	server := newStreamServer(t, stream, 0)
+
+	client := connectSSE(t, server.URL, "")
+	waitForSubscribers(t, stream, 1)
+	for _, n := range []int{1, 2, 3} {
+		stream.publish("health", "", n)
+	}
+	data, lastID := client.nextData(t, 3)
+	assert.Equal(t, []string{"1", "2", "3"}, data)
+
+	// The connection drops mid-stream; events keep coming meanwhile
+	client.close()
+	waitForSubscribers(t, stream, 0)
+	for _, n := range []int{4, 5, 6} {
+		stream.publish("health", "", n)
+	}
+
+	reconnected := connectSSE(t, server.URL, lastID)
+	waitForSubscribers(t, stream, 1)
+	stream.publish("health", "", 7)
+	stream.publish("health", "hidden", 8) // Filtered out, but IDs stay increasing
+	stream.publish("health", "", 9)
+	data, lastID = reconnected.nextData(t, 5)
+	assert.Equal(t, []string{"4", "5", "6", "7", "9"}, data)
+	assert.Equal(t, stream.id(9), lastID)
+}
+
+func TestEventStream_ReplayWindowExceeded(t *testing.T) {
+	stream := newEventStream(2)
+	server := newStreamServer(t, stream, 0)
+
+	for n := 1; n <= 5; n++ {
+		stream.publish("event", "", n)
+	}
+	for _, lastEventID := range []string{
+		stream.id(1),        // Events 2 and 3 were evicted
+		stream.id(9),        // Not issued yet
+		"earlier-process-3", // Another process's ID
+		"not an id",
+	} {
+		client := connectSSE(t, server.URL, lastEventID)
+		msg := client.next(t)
+		assert.Equal(t, "resync", msg.event, lastEventID)
+		assert.Equal(t, stream.id(5), msg.id, "the resync event moves the client to the last event")
+		var body map[string]string
+		require.NoError(t, json.Unmarshal([]byte(msg.data), &body))
+		assert.Equal(t, streamResyncReason, body["reason"])
+		assert.Equal(t, lastEventID, body["last_event_id"])
+		client.close()
+	}
+
+	// Still in the window: a plain replay
+	client := connectSSE(t, server.URL, stream.id(3))
+	data, _ := client.nextData(t, 2)
+	assert.Equal(t, []string{"4", "5"}, data)
+}
+
+func TestEventStream_HeartbeatComments(t *testing.T) {
+	stream := newEventStream(4)
+	server := newStreamServer(t, stream, 10*time.Millisecond)
+
+	client := connectSSE(t, server.URL, "")
+	msg := client.next(t)
+	assert.Equal(t, sseMessage{comment: "keepalive"}, msg, "heartbeats are SSE comments, not events")
+}
+
+func TestEventStream_SlowSubscriberIsDropped(t *testing.T) {
+	stream := newEventStream(streamSubscriberBuffer * 2)
+	subscriber, _, _ := stream.subscribe("")
+
+	for n := 0; n <= streamSubscriberBuffer; n++ {
+		stream.publish("event", "", n)
+	}
+	received := 0
+	for range subscriber.events {
+		received++
+	}
+	assert.Equal(t, streamSubscriberBuffer, received, "the channel is closed once full")
+
+	// What it missed is still in the replay buffer
+	replay, resync := stream.replayAfter(stream.id(uint64(received)))
+	assert.False(t, resync)
+	assert.Len(t, replay, 1)
+	stream.unsubscribe(subscriber) // Already dropped: a no-op
+}
+
+func TestEventStream_Closed(t *testing.T) {
+	stream := newEventStream(4)
+	subscriber, _, _ := stream.subscribe("")
+	stream.close()
+
+	_, open := <-subscriber.events
+	assert.False(t, open)
+	subscriber, _, _ = stream.subscribe("")
+	assert.Nil(t, subscriber)
+	stream.publish("event", "", 1) // Dropped without panicking
+
+	var disabled *eventStream
+	disabled.publish("event", "", 1)
+	disabled.close()
+}
+
+func TestHandleStreams_Disabled(t *testing.T) {
+	cfg := NewConfig()
+	s := &MCPServer{config: cfg}
+	s.liveConfig.Store(cfg)
+
+	for path, handler := range map[string]http.HandlerFunc{
+		"/stream/health": s.handleHealthStream,
+		"/stream/events": s.handleEventStream,
+	} {
+		rec := httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code, path)
+
+		rec = httptest.NewRecorder()
+		handler(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code, path)
+	}
+}
+
+func TestHandleEventStream_AllowedNamespaces(t *testing.T) {
+	cfg := NewConfig()
+	cfg.AllowedNamespaces = []string{"shop"}
+	s := &MCPServer{config: cfg, eventStream: newEventStream(8)}
+	s.liveConfig.Store(cfg)
+	server := httptest.NewServer(http.HandlerFunc(s.handleEventStream))
+	t.Cleanup(func() {
+		s.eventStream.close()
+		server.Close()
+	})
+
+	resp, err := http.Get(server.URL + "?namespace=kube-system")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	client := connectSSE(t, server.URL, "")
+	waitForSubscribers(t, s.eventStream, 1)
+	s.eventStream.publish("event", "kube-system", "etcd")
+	s.eventStream.publish("event", "shop", "api")
+	data, _ := client.nextData(t, 1)
+	assert.Equal(t, []string{`"api"`}, data)
+}
@@ -0,0 +1,295 @@
+package server
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// exportFlushRows is how many rows are written between flushes to the client
+	exportFlushRows = 100
+	// defaultEventExportSince is the event window exported when since is omitted
+	defaultEventExportSince = time.Hour
+	// maxEventExportSince bounds the event window; the API server keeps events far less long
+	maxEventExportSince = 7 * 24 * time.Hour
+)
+
+// healthExportColumns is the CSV column set of /export/health, one row per sample
+var healthExportColumns = []string{
+	"timestamp", "status", "score",
+	"nodes_total", "nodes_ready", "nodes_not_ready",
+	"pods_total", "pods_running", "pods_pending", "pods_failed", "pods_succeeded", "pods_unknown",
+	"error", "previous_status", "reasons",
+}
+
+// eventExportColumns is the CSV column set of /export/events, one row per
+// reason and namespace group. top_objects is "Kind/name=count" joined by ";".
+var eventExportColumns = []string{
+	"namespace", "reason", "type", "count", "previous_count", "change_ratio", "spike",
+	"last_seen", "top_objects", "latest_message",
+}
+
+// exportStream writes rows to a download as they are produced, as CSV or as a
+// JSON array, flushing every exportFlushRows rows instead of buffering the body
+type exportStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher // nil when the writer cannot flush
+	csv     *csv.Writer  // nil for JSON
+	rows    int
+}
+
+// startExport writes the download headers and the CSV header row or opening bracket
+func startExport(w http.ResponseWriter, format, name string, columns []string) (*exportStream, error) {
+	stream := &exportStream{w: w}
+	stream.flusher, _ = w.(http.Flusher)
+
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Cache-Control", "no-store")
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		stream.csv = csv.NewWriter(w)
+		return stream, stream.csv.Write(columns)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err := fmt.Fprint(w, "[")
+	return stream, err
+}
+
+// write adds one row: fields for CSV, record for JSON
+func (e *exportStream) write(record interface{}, fields []string) error {
+	if e.csv != nil {
+		if err := e.csv.Write(fields); err != nil {
+			return err
+		}
+	} else {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		separator := "\n"
+		if e.rows > 0 {
+			separator = ",\n"
+		}
+		if _, err := fmt.Fprint(e.w, separator); err != nil {
+			return err
+		}
+		if _, err := e.w.Write(data); err != nil {
+			return err
+		}
+	}
+
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// flush pushes buffered rows to the client
+func (e *exportStream) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+	return nil
+}
+
+// close ends the JSON array and flushes what is left
+func (e *exportStream) close() error {
+	if e.csv == nil {
+		if _, err := fmt.Fprint(e.w, "\n]\n"); err != nil {
+			return err
+		}
+	}
+	return e.flush()
+}
+
+// exportFormat reads the format query parameter (json by default)
+func exportFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		return "json", nil
+	case "csv":
+		return "csv", nil
+	default:
+		return "", fmt.Errorf("unsupported format %q (use json or csv)", format)
+	}
+}
+
+// handleExportHealth streams the health history as a download
+// GET /export/health?format=json|csv
+func (s *MCPServer) handleExportHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed - use GET")
+		return
+	}
+	format, err := exportFormat(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if s.healthHistory == nil {
+		writeJSONError(w, http.StatusNotFound, "Health history is disabled (HEALTH_HISTORY_INTERVAL=0)")
+		return
+	}
+
+	samples := s.healthHistory.snapshot()
+	stream, err := startExport(w, format, "health-history", healthExportColumns)
+	for _, sample := range samples {
+		if err != nil || r.Context().Err() != nil {
+			break
+		}
+		err = stream.write(sample, healthSampleRow(sample))
+	}
+	if err == nil {
+		err = stream.close()
+	}
+	if err != nil {
+		log.Printf("Error streaming health export: %v", err)
+	}
+}
+
+// handleExportEvents streams events aggregated by reason and namespace as a download
+// GET /export/events?since=1h&namespace=...&format=json|csv
+func (s *MCPServer) handleExportEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed - use GET")
+		return
+	}
+	format, err := exportFormat(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	now := time.Now()
+	window, err := parseExportSince(r.URL.Query().Get("since"), now)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	namespace := r.URL.Query().Get("namespace")
+	allowed := s.currentConfig().AllowedNamespaces
+	if namespace != "" && len(allowed) > 0 && !slices.Contains(allowed, namespace) {
+		writeJSONError(w, http.StatusForbidden, fmt.Sprintf("namespace %q is not in the allowed namespaces", namespace))
+		return
+	}
+
+	eventList, err := s.k8sClient.ListEvents(r.Context(), namespace)
+	if err != nil {
+		writeJSONError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	events := eventList.Items
+	if len(allowed) > 0 {
+		events = slices.DeleteFunc(events, func(event corev1.Event) bool {
+			return !slices.Contains(allowed, event.Namespace)
+		})
+	}
+	aggregation := tools.AggregateEvents(events, now, window)
+
+	stream, err := startExport(w, format, "events", eventExportColumns)
+	for _, group := range aggregation.Groups {
+		if err != nil || r.Context().Err() != nil {
+			break
+		}
+		err = stream.write(group, eventGroupRow(group))
+	}
+	if err == nil {
+		err = stream.close()
+	}
+	if err != nil {
+		log.Printf("Error streaming events export: %v", err)
+	}
+}
+
+// parseExportSince reads since as a duration ("90m") or an RFC3339 time and
+// returns the window length ending at now
+func parseExportSince(since string, now time.Time) (time.Duration, error) {
+	if since == "" {
+		return defaultEventExportSince, nil
+	}
+	window, err := time.ParseDuration(since)
+	if err != nil {
+		start, timeErr := time.Parse(time.RFC3339, since)
+		if timeErr != nil {
+			return 0, fmt.Errorf("invalid since %q (use a duration such as 1h or an RFC3339 time)", since)
+		}
+		window = now.Sub(start)
+	}
+	if window <= 0 || window > maxEventExportSince {
+		return 0, fmt.Errorf("since must be in the past and within %v", maxEventExportSince)
+	}
+	return window, nil
+}
+
+// healthSampleRow flattens a sample into healthExportColumns order
+func healthSampleRow(sample HealthSample) []string {
+	return []string{
+		sample.Timestamp.UTC().Format(time.RFC3339),
+		sample.Status,
+		strconv.Itoa(sample.Score),
+		strconv.Itoa(sample.NodesTotal),
+		strconv.Itoa(sample.NodesReady),
+		strconv.Itoa(sample.NodesNotReady),
+		strconv.Itoa(sample.PodsTotal),
+		strconv.Itoa(sample.PodsRunning),
+		strconv.Itoa(sample.PodsPending),
+		strconv.Itoa(sample.PodsFailed),
+		strconv.Itoa(sample.PodsSucceeded),
+		strconv.Itoa(sample.PodsUnknown),
+		sample.Error,
+		sample.PreviousStatus,
+		strings.Join(reasonRules(sample.Reasons), ";"),
+	}
+}
+
+// reasonRules lists the rules of health reasons, for the reasons CSV column
+func reasonRules(reasons []clients.HealthReason) []string {
+	rules := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		rules = append(rules, reason.Rule)
+	}
+	return rules
+}
+
+// eventGroupRow flattens an event group into eventExportColumns order
+func eventGroupRow(group tools.EventGroup) []string {
+	objects := make([]string, 0, len(group.TopObjects))
+	for _, object := range group.TopObjects {
+		objects = append(objects, fmt.Sprintf("%s=%d", object.Object, object.Count))
+	}
+	return []string{
+		group.Namespace,
+		group.Reason,
+		group.Type,
+		strconv.Itoa(group.Count),
+		strconv.Itoa(group.PreviousCount),
+		strconv.FormatFloat(group.ChangeRatio, 'f', -1, 64),
+		strconv.FormatBool(group.Spike),
+		group.LastSeen.UTC().Format(time.RFC3339),
+		strings.Join(objects, ";"),
+		group.LatestMessage,
+	}
+}
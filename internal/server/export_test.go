@@ -0,0 +1,375 @@
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/resources"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// flushRecorder records the body length at every Flush, showing how much of
+// the response had reached the client at each point
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushedAt []int
+}
+
+func (f *flushRecorder) Flush() {
+	f.flushedAt = append(f.flushedAt, f.Body.Len())
+	f.ResponseRecorder.Flush()
+}
+
+func newFlushRecorder() *flushRecorder {
+	return &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+}
+
+// newHistoryServer has a health history holding n one-minute samples
+func newHistoryServer(t *testing.T, n int) *MCPServer {
+	server := newStubToolServer(t, NewConfig())
+	server.healthHistory = newHealthHistory(1440)
+	start := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		server.healthHistory.add(HealthSample{
+			Timestamp:  start.Add(time.Duration(i) * time.Minute),
+			Status:     "healthy",
+			Score:      100,
+			NodesTotal: 3,
+			NodesReady: 3,
+			PodsTotal:  40 + i,
+		})
+	}
+	return server
+}
+
+func TestHealthHistory_DropsOldest(t *testing.T) {
+	history := newHealthHistory(3)
+	assert.Empty(t, history.snapshot())
+
+	for i := 1; i <= 5; i++ {
+		history.add(HealthSample{PodsTotal: i})
+	}
+
+	var totals []int
+	for _, sample := range history.snapshot() {
+		totals = append(totals, sample.PodsTotal)
+	}
+	assert.Equal(t, []int{3, 4, 5}, totals)
+}
+
+func TestHealthHistory_BaselineAt(t *testing.T) {
+	history := newHealthHistory(10)
+	start := time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC)
+	history.add(HealthSample{Timestamp: start, Status: "healthy", NodesReady: 3})
+	history.add(HealthSample{Timestamp: start.Add(time.Hour), Status: "unknown", Error: "deadline exceeded"})
+	history.add(HealthSample{Timestamp: start.Add(2 * time.Hour), Status: "degraded", NodesReady: 2})
+
+	_, ok := history.baselineAt(start.Add(-time.Minute))
+	assert.False(t, ok, "nothing that early")
+
+	baseline, ok := history.baselineAt(start.Add(90 * time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, start, baseline.Timestamp, "failed samples are skipped")
+	assert.Equal(t, 3, baseline.NodesReady)
+
+	baseline, ok = history.baselineAt(start.Add(3 * time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, "degraded", baseline.Status)
+}
+
+func TestHealthHistory_KeepsTransitionReasons(t *testing.T) {
+	history := newHealthHistory(3)
+	start := time.Date(2026, 10, 14, 14, 30, 0, 0, time.UTC)
+	notReady := []clients.HealthReason{{Rule: "nodes_not_ready", Status: "degraded", Observed: 1, Objects: []string{"node/worker-2"}}}
+	history.add(HealthSample{Timestamp: start, Status: "healthy"})
+	history.add(HealthSample{Timestamp: start.Add(2 * time.Minute), Status: "degraded", Reasons: notReady})
+	history.add(HealthSample{Timestamp: start.Add(4 * time.Minute), Status: "degraded", Reasons: notReady})
+
+	samples := history.snapshot()
+	assert.Empty(t, samples[0].PreviousStatus, "the first sample has nothing to transition from")
+	assert.Equal(t, "healthy", samples[1].PreviousStatus, "the 14:32 sample records the transition")
+	assert.Equal(t, notReady, samples[1].Reasons)
+	assert.Empty(t, samples[2].PreviousStatus, "no transition while the status holds")
+
+	// Across the wrap of the ring buffer too
+	history.add(HealthSample{Timestamp: start.Add(6 * time.Minute), Status: "healthy"})
+	assert.Equal(t, "degraded", history.snapshot()[2].PreviousStatus)
+
+	row := healthSampleRow(samples[1])
+	assert.Equal(t, []string{"healthy", "nodes_not_ready"}, row[len(row)-2:])
+	lastKnown, ok := history.lastKnown()
+	require.True(t, ok)
+	assert.Empty(t, lastKnown.Reasons)
+}
+
+func TestHealthScore(t *testing.T) {
+	health := func(nodesTotal, nodesReady, podsTotal, podsOK int) *clients.ClusterHealth {
+		return &clients.ClusterHealth{
+			Nodes: clients.NodeHealth{Total: nodesTotal, Ready: nodesReady},
+			Pods:  clients.PodHealth{Total: podsTotal, Running: podsOK},
+		}
+	}
+	assert.Equal(t, 100, healthScore(health(3, 3, 10, 10)))
+	assert.Equal(t, 75, healthScore(health(2, 1, 10, 10)))
+	assert.Equal(t, 95, healthScore(health(3, 3, 10, 9)))
+	assert.Equal(t, 100, healthScore(health(3, 3, 0, 0)))
+	assert.Equal(t, 0, healthScore(health(0, 0, 10, 10)))
+}
+
+func TestSampleHealth(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.healthHistory = newHealthHistory(10)
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	server.healthHistory.clock = clk
+	server.k8sClient = clients.NewK8sClientWithClientset(fake.NewClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"},
+			Status:     corev1.PodStatus{Phase: corev1.PodFailed},
+		},
+	))
+
+	server.sampleHealth(context.Background(), time.Second)
+	samples := server.healthHistory.snapshot()
+	require.Len(t, samples, 1)
+	assert.Equal(t, clk.Now(), samples[0].Timestamp)
+	assert.Equal(t, "degraded", samples[0].Status)
+	assert.Equal(t, 1, samples[0].PodsFailed)
+	assert.Equal(t, 50, samples[0].Score)
+}
+
+func TestClusterHealth_FallsBackToLastKnownWhenUnreachable(t *testing.T) {
+	clientset := fake.NewClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	unreachable := false
+	clientset.PrependReactor("list", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if unreachable {
+			return true, nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: no route to host")}
+		}
+		return false, nil, nil
+	})
+
+	server := newStubToolServer(t, NewConfig())
+	server.healthHistory = newHealthHistory(10)
+	server.k8sClient = clients.NewK8sClientWithClientset(clientset)
+	tool := tools.NewClusterHealthTool(server.k8sClient, cache.NewMemoryCache(time.Minute), server.lastKnownHealthLookup())
+	resource := resources.NewClusterHealthResource(server.k8sClient, nil, cache.NewMemoryCache(time.Minute), server.lastKnownHealthLookup())
+
+	for i := 0; i < 3; i++ {
+		server.sampleHealth(context.Background(), time.Second)
+	}
+	unreachable = true
+	server.sampleHealth(context.Background(), time.Second)
+	assert.Equal(t, 1, server.k8sClient.APIReachability().ConsecutiveFailures)
+
+	result, err := tool.Execute(context.Background(), nil)
+	require.NoError(t, err)
+	output := result.(tools.ClusterHealthOutput)
+	assert.Equal(t, "unknown", output.Status)
+	require.NotNil(t, output.LastKnown)
+	assert.Equal(t, "healthy", output.LastKnown.Status)
+	assert.Equal(t, 1, output.LastKnown.PodsRunning)
+	assert.Contains(t, output.Error, "no route to host")
+	assert.Equal(t, 2, output.ConsecutiveFailures)
+	assert.True(t, strings.HasPrefix(output.Message, "API server unreachable for 0s (2 consecutive failures); last known health from "), output.Message)
+
+	content, err := resource.Read(context.Background())
+	require.NoError(t, err)
+	var data resources.ClusterHealthData
+	require.NoError(t, json.Unmarshal([]byte(content), &data))
+	assert.Equal(t, "unknown", data.Status)
+	assert.Equal(t, "health-history", data.Source)
+	require.NotNil(t, data.LastKnown)
+	assert.Equal(t, 1, data.LastKnown.NodesReady)
+	assert.Equal(t, []string{"API server unreachable for 0s (3 consecutive failures)"}, data.Warnings)
+	assert.Contains(t, data.Error, "no route to host")
+
+	unreachable = false
+	result, err = tool.Execute(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "healthy", result.(tools.ClusterHealthOutput).Status)
+	assert.False(t, server.k8sClient.APIReachability().Unreachable())
+}
+
+func TestClusterHealth_NoFallbackWithoutHistory(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("list", "*", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused")}
+	})
+	server := newStubToolServer(t, NewConfig())
+	server.healthHistory = newHealthHistory(10) // Empty: nothing to fall back to
+	server.k8sClient = clients.NewK8sClientWithClientset(clientset)
+	tool := tools.NewClusterHealthTool(server.k8sClient, cache.NewMemoryCache(time.Minute), server.lastKnownHealthLookup())
+
+	_, err := tool.Execute(context.Background(), nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, tools.ErrUpstreamUnavailable)
+}
+
+func TestHandleExportHealth_CSVStreamsIncrementally(t *testing.T) {
+	server := newHistoryServer(t, 250)
+
+	w := newFlushRecorder()
+	server.handleExportHealth(w, httptest.NewRequest(http.MethodGet, "/export/health?format=csv", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="health-history-\d{8}T\d{6}Z\.csv"$`, w.Header().Get("Content-Disposition"))
+
+	// Rows reach the client in batches while the export is still being written
+	require.Len(t, w.flushedAt, 3, "a flush after rows 100 and 200, and one at the end")
+	assert.Less(t, w.flushedAt[0], w.flushedAt[1])
+	assert.Less(t, w.flushedAt[1], w.Body.Len())
+	assert.Equal(t, w.Body.Len(), w.flushedAt[2])
+
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 251)
+	assert.Equal(t, healthExportColumns, records[0])
+	assert.Equal(t, []string{"2026-10-15T08:00:00Z", "healthy", "100", "3", "3", "0", "40", "0", "0", "0", "0", "0", "", "", ""}, records[1])
+	assert.Equal(t, "289", records[250][6])
+
+	// The first flush carried exactly the header and the first 100 rows
+	firstBatch, err := csv.NewReader(strings.NewReader(w.Body.String()[:w.flushedAt[0]])).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, firstBatch, 101)
+}
+
+func TestHandleExportHealth_JSON(t *testing.T) {
+	server := newHistoryServer(t, 3)
+
+	w := newFlushRecorder()
+	server.handleExportHealth(w, httptest.NewRequest(http.MethodGet, "/export/health", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), ".json")
+
+	var samples []HealthSample
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &samples))
+	require.Len(t, samples, 3)
+	assert.Equal(t, 40, samples[0].PodsTotal, "oldest first")
+	assert.Equal(t, 42, samples[2].PodsTotal)
+
+	// An empty history is still a valid document
+	w = newFlushRecorder()
+	newHistoryServer(t, 0).handleExportHealth(w, httptest.NewRequest(http.MethodGet, "/export/health", nil))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &samples))
+	assert.Empty(t, samples)
+}
+
+func TestHandleExportHealth_Errors(t *testing.T) {
+	server := newHistoryServer(t, 1)
+
+	w := httptest.NewRecorder()
+	server.handleExportHealth(w, httptest.NewRequest(http.MethodGet, "/export/health?format=xml", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	server.handleExportHealth(w, httptest.NewRequest(http.MethodPost, "/export/health", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	server.healthHistory = nil
+	w = httptest.NewRecorder()
+	server.handleExportHealth(w, httptest.NewRequest(http.MethodGet, "/export/health", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestHandleExportEvents_CSV(t *testing.T) {
+	now := time.Now()
+	event := func(name, namespace, reason string, count int32, lastSeen time.Time) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: name + "-pod"},
+			Reason:         reason,
+			Type:           corev1.EventTypeWarning,
+			Message:        "Back-off restarting failed container, \"api\"",
+			Count:          count,
+			FirstTimestamp: metav1.NewTime(lastSeen),
+			LastTimestamp:  metav1.NewTime(lastSeen),
+		}
+	}
+
+	cfg := NewConfig()
+	cfg.AllowedNamespaces = []string{"payments"}
+	server := newStubToolServer(t, cfg)
+	server.k8sClient = clients.NewK8sClientWithClientset(fake.NewClientset(
+		event("api-1", "payments", "BackOff", 6, now.Add(-10*time.Minute)),
+		event("old", "payments", "FailedMount", 1, now.Add(-5*time.Hour)),
+		event("db-1", "checkout", "BackOff", 2, now.Add(-5*time.Minute)),
+	))
+
+	w := newFlushRecorder()
+	server.handleExportEvents(w, httptest.NewRequest(http.MethodGet, "/export/events?since=1h&format=csv", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Regexp(t, `^attachment; filename="events-.*\.csv"$`, w.Header().Get("Content-Disposition"))
+	assert.NotEmpty(t, w.flushedAt)
+
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2, "header plus the payments BackOff group; checkout is not allowed and FailedMount is too old")
+	assert.Equal(t, eventExportColumns, records[0])
+	row := records[1]
+	assert.Equal(t, []string{"payments", "BackOff", "Warning", "6", "0", "0", "true"}, row[:7])
+	assert.Equal(t, "Pod/api-1-pod=6", row[8])
+	assert.Equal(t, `Back-off restarting failed container, "api"`, row[9])
+
+	w = newFlushRecorder()
+	server.handleExportEvents(w, httptest.NewRequest(http.MethodGet, "/export/events?namespace=checkout", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestParseExportSince(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	window, err := parseExportSince("", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, window)
+
+	window, err = parseExportSince("90m", now)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, window)
+
+	window, err = parseExportSince("2026-10-15T09:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Hour, window)
+
+	for _, since := range []string{"yesterday", "-1h", "2026-10-16T00:00:00Z", "720h"} {
+		_, err := parseExportSince(since, now)
+		assert.Error(t, err, since)
+	}
+}
@@ -0,0 +1,223 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"k8s.io/utils/clock"
+)
+
+// HealthSample is one cluster health reading kept in the health history
+type HealthSample struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Status        string    `json:"status"` // healthy, degraded, unhealthy, or unknown when sampling failed in part or in full
+	Score         int       `json:"score"`  // 0-100, see healthScore
+	NodesTotal    int       `json:"nodes_total"`
+	NodesReady    int       `json:"nodes_ready"`
+	NodesNotReady int       `json:"nodes_not_ready"`
+	PodsTotal     int       `json:"pods_total"`
+	PodsRunning   int       `json:"pods_running"`
+	PodsPending   int       `json:"pods_pending"`
+	PodsFailed    int       `json:"pods_failed"`
+	PodsSucceeded int       `json:"pods_succeeded"`
+	PodsUnknown   int       `json:"pods_unknown"`
+	Error         string    `json:"error,omitempty"`
+
+	// Reasons are the health rules that set the status. PreviousStatus is set
+	// on the first sample after the status changed, so the reasons of each
+	// transition stay in the history as long as the sample does.
+	Reasons        []clients.HealthReason `json:"reasons,omitempty"`
+	PreviousStatus string                 `json:"previous_status,omitempty"`
+}
+
+// newHealthSample flattens a cluster health summary into a sample
+func newHealthSample(at time.Time, health *clients.ClusterHealth) HealthSample {
+	return HealthSample{
+		Timestamp:     at,
+		Status:        health.Status,
+		Score:         healthScore(health),
+		NodesTotal:    health.Nodes.Total,
+		NodesReady:    health.Nodes.Ready,
+		NodesNotReady: health.Nodes.NotReady,
+		PodsTotal:     health.Pods.Total,
+		PodsRunning:   health.Pods.Running,
+		PodsPending:   health.Pods.Pending,
+		PodsFailed:    health.Pods.Failed,
+		PodsSucceeded: health.Pods.Succeeded,
+		PodsUnknown:   health.Pods.Unknown,
+		Error:         strings.Join(health.UnreadSections(), "; "),
+		Reasons:       health.Reasons,
+	}
+}
+
+// healthScore rates cluster health from 0 to 100: half from the share of
+// ready nodes, half from the share of pods that are running or succeeded.
+// A cluster without nodes scores 0; one without pods gets the full pod half.
+// A partial result scores 0 like a failed sample, its counts being incomplete.
+func healthScore(health *clients.ClusterHealth) int {
+	if health.Nodes.Total == 0 || health.Partial {
+		return 0
+	}
+	nodes := float64(health.Nodes.Ready) / float64(health.Nodes.Total)
+	pods := 1.0
+	if health.Pods.Total > 0 {
+		pods = float64(health.Pods.Running+health.Pods.Succeeded) / float64(health.Pods.Total)
+	}
+	return int(math.Round(50*nodes + 50*pods))
+}
+
+// healthHistory is a fixed-size ring buffer of health samples
+type healthHistory struct {
+	mu      sync.Mutex
+	samples []HealthSample
+	next    int // Index the next sample is written to
+	full    bool
+	clock   clock.WithTicker // Times and timestamps the samples
+}
+
+// newHealthHistory creates a history keeping the last size samples
+func newHealthHistory(size int) *healthHistory {
+	return &healthHistory{samples: make([]HealthSample, size), clock: clock.RealClock{}}
+}
+
+// add records a sample, dropping the oldest once the buffer is full, and
+// marks it a transition when its status differs from the previous sample's.
+// It returns the sample as recorded.
+func (h *healthHistory) add(sample HealthSample) HealthSample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.next > 0 || h.full {
+		previous := h.samples[(h.next+len(h.samples)-1)%len(h.samples)]
+		if previous.Status != sample.Status {
+			sample.PreviousStatus = previous.Status
+		}
+	}
+	h.samples[h.next] = sample
+	h.next = (h.next + 1) % len(h.samples)
+	if h.next == 0 {
+		h.full = true
+	}
+	return sample
+}
+
+// snapshot returns the samples oldest first
+func (h *healthHistory) snapshot() []HealthSample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.full {
+		return append([]HealthSample(nil), h.samples[:h.next]...)
+	}
+	result := make([]HealthSample, 0, len(h.samples))
+	result = append(result, h.samples[h.next:]...)
+	return append(result, h.samples[:h.next]...)
+}
+
+// baselineAt returns the last complete sample taken at or before at, for
+// generate-health-report's compare_to
+func (h *healthHistory) baselineAt(at time.Time) (tools.HealthBaseline, bool) {
+	samples := h.snapshot()
+	for i := len(samples) - 1; i >= 0; i-- {
+		sample := samples[i]
+		if sample.Timestamp.After(at) || sample.Error != "" {
+			continue
+		}
+		return tools.HealthBaseline{
+			Timestamp:     sample.Timestamp,
+			Status:        sample.Status,
+			NodesTotal:    sample.NodesTotal,
+			NodesReady:    sample.NodesReady,
+			PodsTotal:     sample.PodsTotal,
+			PodsRunning:   sample.PodsRunning,
+			PodsPending:   sample.PodsPending,
+			PodsFailed:    sample.PodsFailed,
+			PodsSucceeded: sample.PodsSucceeded,
+		}, true
+	}
+	return tools.HealthBaseline{}, false
+}
+
+// lastKnown returns the last complete sample, which get-cluster-health and
+// cluster://health fall back to while the API server cannot be reached
+func (h *healthHistory) lastKnown() (clients.LastKnownHealth, bool) {
+	samples := h.snapshot()
+	for i := len(samples) - 1; i >= 0; i-- {
+		sample := samples[i]
+		if sample.Error != "" {
+			continue
+		}
+		return clients.LastKnownHealth{
+			Timestamp:     sample.Timestamp,
+			Status:        sample.Status,
+			NodesTotal:    sample.NodesTotal,
+			NodesReady:    sample.NodesReady,
+			NodesNotReady: sample.NodesNotReady,
+			PodsTotal:     sample.PodsTotal,
+			PodsRunning:   sample.PodsRunning,
+			PodsPending:   sample.PodsPending,
+			PodsFailed:    sample.PodsFailed,
+			PodsSucceeded: sample.PodsSucceeded,
+			Reasons:       sample.Reasons,
+		}, true
+	}
+	return clients.LastKnownHealth{}, false
+}
+
+// lastKnownHealthLookup returns the history's lastKnown, or nil when the
+// health history is disabled
+func (s *MCPServer) lastKnownHealthLookup() clients.LastKnownHealthLookup {
+	if s.healthHistory == nil {
+		return nil
+	}
+	return s.healthHistory.lastKnown
+}
+
+// recordHealthHistory samples cluster health every interval until ctx is
+// done, evaluating the remediation policies after each sample
+func (s *MCPServer) recordHealthHistory(ctx context.Context, interval time.Duration) {
+	ticker := s.healthHistory.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.sampleHealth(ctx, interval)
+		s.evaluateRemediationPolicies(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// sampleHealth records one health sample, bounded by the sampling interval.
+// Failures are recorded too so gaps in the history are visible.
+func (s *MCPServer) sampleHealth(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	now := s.healthHistory.clock.Now()
+	health, err := s.k8sClient.GetClusterHealth(ctx)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return // Shutting down
+		}
+		log.Printf("WARNING: health history sample failed: %v", err)
+		s.recordHealthSample(HealthSample{Timestamp: now, Status: "unknown", Error: err.Error()})
+		return
+	}
+	s.recordHealthSample(newHealthSample(now, health))
+}
+
+// recordHealthSample adds a sample to the history and sends it to /stream/health
+func (s *MCPServer) recordHealthSample(sample HealthSample) {
+	sample = s.healthHistory.add(sample)
+	s.healthStream.publish("health", "", sample)
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// limitRequestBody caps every request body at the configured size.
+// Handlers see an *http.MaxBytesError once the limit is exceeded.
+func (s *MCPServer) limitRequestBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = http.MaxBytesReader(w, r.Body, s.currentConfig().MaxRequestBodyBytes)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// decodeRequestArgs decodes an optional JSON object from the request body.
+// Empty or malformed bodies yield empty args so tools fall back to their
+// defaults; an oversized body is returned as an *http.MaxBytesError.
+func decodeRequestArgs(r *http.Request) (map[string]interface{}, error) {
+	args := make(map[string]interface{})
+	if r.Body == nil {
+		return args, nil
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, maxBytesErr
+		}
+		if !errors.Is(err, io.EOF) {
+			// Drain the rest so an oversized body is still detected
+			if _, drainErr := io.Copy(io.Discard, r.Body); errors.As(drainErr, &maxBytesErr) {
+				return nil, maxBytesErr
+			}
+		}
+		return make(map[string]interface{}), nil
+	}
+	return args, nil
+}
+
+// writeBodyDecodeError answers 413 for oversized bodies and 400 otherwise
+func writeBodyDecodeError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeJSONError(w, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body too large (limit %d bytes)", maxBytesErr.Limit))
+		return
+	}
+	writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+}
+
+// requireJSONContentType rejects requests declaring a non-JSON body with 415.
+// A missing Content-Type is accepted so that body-less calls keep working.
+func requireJSONContentType(w http.ResponseWriter, r *http.Request) bool {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) {
+		return true
+	}
+
+	writeJSONError(w, http.StatusUnsupportedMediaType,
+		fmt.Sprintf("unsupported Content-Type %q (use application/json)", contentType))
+	return false
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postToolCall sends a tool call through the full HTTP handler chain
+func postToolCall(t *testing.T, server *MCPServer, body []byte, contentType string) *httptest.ResponseRecorder {
+	t.Helper()
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp/tools/list-pods/call?sessionid="+session.ID, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	server.httpHandler().ServeHTTP(w, req)
+	return w
+}
+
+func TestToolCall_OversizedBody(t *testing.T) {
+	cfg := NewConfig()
+	cfg.MaxRequestBodyBytes = 64
+	server := newStubToolServer(t, cfg, "list-pods")
+
+	body := []byte(`{"namespace":"` + strings.Repeat("a", 128) + `"}`)
+	w := postToolCall(t, server, body, "application/json")
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var envelope map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+	assert.Equal(t, false, envelope["success"])
+	assert.Contains(t, envelope["error"], "limit 64 bytes")
+}
+
+func TestToolCall_OversizedInvalidJSON(t *testing.T) {
+	cfg := NewConfig()
+	cfg.MaxRequestBodyBytes = 64
+	server := newStubToolServer(t, cfg, "list-pods")
+
+	// Malformed JSON must not hide the size violation
+	body := []byte("not json " + strings.Repeat("x", 128))
+	w := postToolCall(t, server, body, "application/json")
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestToolCall_ContentType(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		expectedStatus int
+	}{
+		{"json", "application/json", http.StatusOK},
+		{"json with charset", "application/json; charset=utf-8", http.StatusOK},
+		{"json suffix", "application/merge-patch+json", http.StatusOK},
+		{"missing", "", http.StatusOK},
+		{"form", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"text", "text/plain", http.StatusUnsupportedMediaType},
+		{"malformed", "application/", http.StatusUnsupportedMediaType},
+	}
+
+	server := newStubToolServer(t, NewConfig(), "list-pods")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := postToolCall(t, server, []byte(`{}`), tt.contentType)
+			assert.Equal(t, tt.expectedStatus, w.Code, w.Body.String())
+
+			if tt.expectedStatus == http.StatusUnsupportedMediaType {
+				var envelope map[string]interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+				assert.Equal(t, false, envelope["success"])
+			}
+		})
+	}
+}
+
+func TestDecodeRequestArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected map[string]interface{}
+	}{
+		{"empty body", "", map[string]interface{}{}},
+		{"invalid json", "{not json", map[string]interface{}{}},
+		{"object", `{"namespace":"default"}`, map[string]interface{}{"namespace": "default"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			args, err := decodeRequestArgs(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, args)
+		})
+	}
+}
+
+func TestCreateSession_OversizedBody(t *testing.T) {
+	cfg := NewConfig()
+	cfg.MaxRequestBodyBytes = 16
+	server := newStubToolServer(t, cfg)
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp/session", strings.NewReader(`{"client":"`+strings.Repeat("a", 64)+`"}`))
+	w := httptest.NewRecorder()
+	server.httpHandler().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/resources"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// handleCEWebhook receives the incident events the Coordination Engine
+// pushes, updates the cached cluster://incidents resource, and notifies
+// its subscribers. Events must be signed with CE_WEBHOOK_SECRET.
+// POST <CE_WEBHOOK_PATH> - Requires X-CE-Signature: sha256=<hex HMAC of the body>
+func (s *MCPServer) handleCEWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed - use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	secret := s.currentConfig().CEWebhookSecret
+	if secret == "" || s.incidents == nil {
+		writeJSONError(w, http.StatusNotFound, "incident webhook is disabled (set CE_WEBHOOK_SECRET and ENABLE_COORDINATION_ENGINE to enable)")
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+	event, err := clients.ParseIncidentEvent(body, r.Header.Get(clients.IncidentSignatureHeader), secret)
+	if errors.Is(err, clients.ErrInvalidSignature) {
+		log.Printf("WARNING: rejected incident webhook from %s: %v", r.RemoteAddr, err)
+		writeJSONError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("WARNING: rejected incident webhook from %s: %v", r.RemoteAddr, err)
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.incidents.Apply(event)
+	log.Printf("Incident %s: %s", event.Incident.ID, event.Event)
+	s.notifyResourceUpdated(r.Context(), s.incidents.URI())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pollIncidents follows the incidents of an engine that cannot push them:
+// every interval it refreshes cluster://incidents and notifies subscribers
+// when incidents opened, changed status, or closed since the last poll
+func (s *MCPServer) pollIncidents(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous map[string]string // Incident ID -> status, nil before the first poll
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pollCtx, cancel := context.WithTimeout(ctx, interval)
+		data, err := s.incidents.Refresh(pollCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("WARNING: incident poll failed: %v", err)
+			}
+			continue
+		}
+
+		current := incidentStatuses(data)
+		if previous != nil && incidentsChanged(previous, current) {
+			s.notifyResourceUpdated(ctx, s.incidents.URI())
+		}
+		previous = current
+	}
+}
+
+// incidentStatuses maps the incidents of data to their status
+func incidentStatuses(data resources.IncidentsData) map[string]string {
+	statuses := make(map[string]string, len(data.Incidents))
+	for _, incident := range data.Incidents {
+		statuses[incident.ID] = incident.Status
+	}
+	return statuses
+}
+
+// incidentsChanged reports whether incidents opened, closed, or changed
+// status between two polls
+func incidentsChanged(previous, current map[string]string) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+	for id, status := range current {
+		if before, ok := previous[id]; !ok || before != status {
+			return true
+		}
+	}
+	return false
+}
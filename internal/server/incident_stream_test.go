@@ -0,0 +1,208 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/resources"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+const testWebhookSecret = "webhook-secret"
+
+// stubIncidentEngine serves the active incidents it holds
+type stubIncidentEngine struct {
+	mu        sync.Mutex
+	incidents []clients.Incident
+}
+
+func (e *stubIncidentEngine) set(incidents ...clients.Incident) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.incidents = incidents
+}
+
+func (e *stubIncidentEngine) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	resp := clients.IncidentListResponse{Incidents: e.incidents}
+	resp.Summary.Total = len(e.incidents)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// newIncidentStreamServer builds a server with cluster://incidents backed
+// by a stub engine holding inc-1
+func newIncidentStreamServer(t *testing.T) (*MCPServer, *stubIncidentEngine) {
+	t.Helper()
+	engine := &stubIncidentEngine{}
+	engine.set(clients.Incident{ID: "inc-1", Severity: "high", Status: "running"})
+	ce := httptest.NewServer(engine)
+	t.Cleanup(ce.Close)
+
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	t.Cleanup(memoryCache.Close)
+
+	cfg := NewConfig()
+	cfg.CEWebhookSecret = testWebhookSecret
+	cfg.EnableAuth = true // The webhook is authenticated by its signature instead
+	server := newStubToolServer(t, cfg)
+	server.mcpServer = mcp.NewServer(&mcp.Implementation{Name: cfg.Name, Version: cfg.Version}, mcpServerOptions(server.resources))
+	server.cache = memoryCache
+	server.ceClient = testutil.NewCoordinationEngineClient(t, ce.URL)
+	server.incidents = resources.NewIncidentsResource(server.ceClient, memoryCache)
+	server.registerResource(server.incidents)
+	return server, engine
+}
+
+// postIncidentEvent posts body to the webhook with signature
+func postIncidentEvent(server *MCPServer, body []byte, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/coordination-engine", bytes.NewReader(body))
+	if signature != "" {
+		req.Header.Set(clients.IncidentSignatureHeader, signature)
+	}
+	w := httptest.NewRecorder()
+	server.httpHandler().ServeHTTP(w, req)
+	return w
+}
+
+func incidentEventBody(t *testing.T, event string, incident clients.Incident) []byte {
+	t.Helper()
+	body, err := json.Marshal(clients.IncidentEvent{Event: event, Incident: incident})
+	require.NoError(t, err)
+	return body
+}
+
+// cachedIncidentIDs reads cluster://incidents, from the cache when fresh
+func cachedIncidentIDs(t *testing.T, server *MCPServer) []string {
+	t.Helper()
+	content, err := server.incidents.Read(context.Background())
+	require.NoError(t, err)
+	var data resources.IncidentsData
+	require.NoError(t, json.Unmarshal([]byte(content), &data))
+	ids := []string{}
+	for _, incident := range data.Incidents {
+		ids = append(ids, incident.ID)
+	}
+	return ids
+}
+
+func TestCEWebhook_UpdatesIncidentsOnlyForValidEvents(t *testing.T) {
+	server, _ := newIncidentStreamServer(t)
+	require.Equal(t, []string{"inc-1"}, cachedIncidentIDs(t, server))
+
+	opened := incidentEventBody(t, clients.IncidentOpened, clients.Incident{ID: "inc-2", Severity: "critical", Status: "pending"})
+	for name, signature := range map[string]string{
+		"unsigned":     "",
+		"wrong secret": clients.SignIncidentEvent(opened, "guess"),
+	} {
+		w := postIncidentEvent(server, opened, signature)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, name)
+	}
+	malformed := []byte(`{"event":"incident.opened","incident":`)
+	w := postIncidentEvent(server, malformed, clients.SignIncidentEvent(malformed, testWebhookSecret))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, []string{"inc-1"}, cachedIncidentIDs(t, server), "rejected events leave the cache alone")
+
+	// The engine still lists inc-1 only: the cache is updated from the event
+	w = postIncidentEvent(server, opened, clients.SignIncidentEvent(opened, testWebhookSecret))
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	assert.Equal(t, []string{"inc-1", "inc-2"}, cachedIncidentIDs(t, server))
+
+	closed := incidentEventBody(t, clients.IncidentClosed, clients.Incident{ID: "inc-1", Status: "completed"})
+	w = postIncidentEvent(server, closed, clients.SignIncidentEvent(closed, testWebhookSecret))
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+	assert.Equal(t, []string{"inc-2"}, cachedIncidentIDs(t, server))
+}
+
+func TestCEWebhook_DisabledWithoutSecret(t *testing.T) {
+	server, _ := newIncidentStreamServer(t)
+	cfg := *server.currentConfig()
+	cfg.CEWebhookSecret = ""
+	server.liveConfig.Store(&cfg)
+
+	body := incidentEventBody(t, clients.IncidentOpened, clients.Incident{ID: "inc-2"})
+	w := postIncidentEvent(server, body, clients.SignIncidentEvent(body, ""))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "CE_WEBHOOK_SECRET")
+}
+
+// subscribeIncidents connects an MCP client subscribed to cluster://incidents
+// and returns the channel its update notifications arrive on
+func subscribeIncidents(t *testing.T, server *MCPServer) <-chan string {
+	t.Helper()
+	updates := make(chan string, 10)
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.mcpServer.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	client, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updates <- req.Params.URI
+		},
+	}).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	assert.Error(t, client.Subscribe(ctx, &mcp.SubscribeParams{URI: "cluster://unknown"}))
+	require.NoError(t, client.Subscribe(ctx, &mcp.SubscribeParams{URI: "cluster://incidents"}))
+	return updates
+}
+
+func TestCEWebhook_NotifiesSubscribers(t *testing.T) {
+	server, _ := newIncidentStreamServer(t)
+	updates := subscribeIncidents(t, server)
+
+	body := incidentEventBody(t, clients.IncidentUpdated, clients.Incident{ID: "inc-1", Status: "completed"})
+	require.Equal(t, http.StatusNoContent, postIncidentEvent(server, body, clients.SignIncidentEvent(body, testWebhookSecret)).Code)
+
+	select {
+	case uri := <-updates:
+		assert.Equal(t, "cluster://incidents", uri)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no resource update notification")
+	}
+}
+
+func TestPollIncidents_NotifiesOnChange(t *testing.T) {
+	server, engine := newIncidentStreamServer(t)
+	updates := subscribeIncidents(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.pollIncidents(ctx, 20*time.Millisecond)
+
+	select {
+	case <-updates:
+		t.Fatal("notified before any incident changed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	engine.set(clients.Incident{ID: "inc-1", Severity: "high", Status: "running"}, clients.Incident{ID: "inc-3", Severity: "low", Status: "pending"})
+	select {
+	case uri := <-updates:
+		assert.Equal(t, "cluster://incidents", uri)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no resource update notification")
+	}
+	assert.Equal(t, []string{"inc-1", "inc-3"}, cachedIncidentIDs(t, server))
+}
+
+func TestIncidentsChanged(t *testing.T) {
+	previous := map[string]string{"inc-1": "running"}
+	assert.False(t, incidentsChanged(previous, map[string]string{"inc-1": "running"}))
+	assert.True(t, incidentsChanged(previous, map[string]string{"inc-1": "pending"}))
+	assert.True(t, incidentsChanged(previous, map[string]string{"inc-2": "running"}))
+	assert.True(t, incidentsChanged(previous, map[string]string{}))
+}
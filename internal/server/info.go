@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/buildinfo"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// clusterInfoCacheTTL bounds how long /mcp/info reports a cluster version
+// after an upgrade; discovery is too slow to repeat on every request
+const clusterInfoCacheTTL = 5 * time.Minute
+
+// MCPInfoResponse is the body of GET /mcp/info
+type MCPInfoResponse struct {
+	Name           string               `json:"name"`
+	Version        string               `json:"version"`
+	Transport      string               `json:"transport"`
+	ToolsCount     int                  `json:"tools_count"`
+	ResourcesCount int                  `json:"resources_count"`
+	Build          buildinfo.Info       `json:"build"`
+	Integrations   map[string]bool      `json:"integrations"`
+	Unavailable    map[string]string    `json:"unavailable_integrations,omitempty"` // Enabled but failing their probe, with the reason
+	ReadOnly       bool                 `json:"read_only"`
+	Platform       PlatformInfo         `json:"platform"` // Detected API groups and the tools deferred for missing ones
+	Cluster        *clients.ClusterInfo `json:"cluster,omitempty"`
+	ClusterError   string               `json:"cluster_error,omitempty"` // Why cluster is missing
+
+	// CoordinationEngine is the outcome of the engine's last health check, from the startup probe on
+	CoordinationEngine *clients.CoordinationEngineReachability `json:"coordination_engine,omitempty"`
+}
+
+// handleMCPInfo returns server info: build, enabled integrations, and the connected cluster
+func (s *MCPServer) handleMCPInfo(w http.ResponseWriter, r *http.Request) {
+	cfg := s.currentConfig()
+	response := MCPInfoResponse{
+		Name:           cfg.Name,
+		Version:        cfg.Version,
+		Transport:      "http/sse", // The only transport served since stdio was retired
+		ToolsCount:     s.toolCount(),
+		ResourcesCount: s.resourceCount(),
+		Build:          buildinfo.Get(),
+		Integrations: map[string]bool{
+			"coordination_engine": cfg.EnableCoordinationEngine,
+			"kserve":              cfg.EnableKServe,
+			"prometheus":          cfg.EnablePrometheus,
+		},
+		Unavailable: s.unavailableIntegrations(),
+		ReadOnly:    cfg.ReadOnly,
+		Platform:    s.platform.info(),
+	}
+
+	if s.ceClient != nil {
+		if reachability, checked := s.ceClient.Reachability(); checked {
+			response.CoordinationEngine = &reachability
+		}
+	}
+
+	cluster, err := s.clusterInfo(r.Context())
+	if err != nil {
+		response.ClusterError = err.Error()
+	} else {
+		response.Cluster = cluster
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := writeJSON(w, response); err != nil {
+		log.Printf("Error writing MCP info response: %v", err)
+	}
+}
+
+// clusterInfo returns the connected cluster's version and platform, cached for clusterInfoCacheTTL
+func (s *MCPServer) clusterInfo(ctx context.Context) (*clients.ClusterInfo, error) {
+	if s.k8sClient == nil {
+		return nil, errors.New("no Kubernetes client configured")
+	}
+	lookup := func() (*clients.ClusterInfo, error) {
+		return s.k8sClient.GetClusterInfo(ctx)
+	}
+	if s.cache == nil {
+		return lookup()
+	}
+	return cache.GetOrSetTyped(ctx, s.cache, cache.Key("cluster", "info"), clusterInfoCacheTTL, lookup)
+}
+
+// handleHealth answers the liveness probe. An unreachable Coordination
+// Engine is reported on a second line, but a dependency never fails the
+// probe: restarting the server would not bring the engine back.
+func (s *MCPServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	body := "OK"
+	if s.ceClient != nil {
+		if reachability, checked := s.ceClient.Reachability(); checked && !reachability.Reachable {
+			body += fmt.Sprintf("\ncoordination_engine: unreachable (%s): %s", reachability.Stage, reachability.Error)
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprint(w, body); err != nil {
+		log.Printf("Error writing health response: %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"sync/atomic"
+)
+
+// Log levels of LOG_LEVEL
+const (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// logLevels orders the log levels, lowest first
+var logLevels = map[string]int32{
+	LogLevelDebug: 0,
+	LogLevelInfo:  1,
+	LogLevelWarn:  2,
+	LogLevelError: 3,
+}
+
+// Line prefixes that set a log line's level; other lines are info. AUDIT
+// lines are always written, whatever the level.
+var (
+	debugLinePrefix   = []byte("DEBUG:")
+	warningLinePrefix = []byte("WARNING:")
+	errorLinePrefix   = []byte("ERROR:")
+	auditLinePrefix   = []byte("AUDIT ")
+)
+
+// minLogLevel is the lowest level LogWriter writes, set from LOG_LEVEL when
+// the server is created and again on reload
+var minLogLevel atomic.Int32
+
+func init() {
+	minLogLevel.Store(logLevels[LogLevelInfo])
+}
+
+// setLogLevel makes LogWriter drop lines below level; unknown levels, which
+// Validate rejects, are ignored
+func setLogLevel(level string) {
+	if rank, ok := logLevels[level]; ok {
+		minLogLevel.Store(rank)
+	}
+}
+
+// LogWriter wraps out, the output of the standard logger, to drop lines
+// below LOG_LEVEL. The server logs with the standard logger; a line's level
+// comes from its prefix: "DEBUG:", "WARNING:" or "ERROR:", else info.
+func LogWriter(out io.Writer) io.Writer {
+	return logLevelWriter{out: out}
+}
+
+// logLevelWriter is the writer LogWriter returns
+type logLevelWriter struct {
+	out io.Writer
+}
+
+// Write writes line, one log entry, unless its level is below LOG_LEVEL
+func (w logLevelWriter) Write(line []byte) (int, error) {
+	if lineLogLevel(line) < minLogLevel.Load() {
+		return len(line), nil
+	}
+	return w.out.Write(line)
+}
+
+// lineLogLevel returns the level of a line written by the standard logger
+func lineLogLevel(line []byte) int32 {
+	// Skip the date and time the logger starts lines with
+	for _, flag := range []int{log.Ldate, log.Ltime} {
+		if log.Flags()&flag == 0 {
+			continue
+		}
+		if i := bytes.IndexByte(line, ' '); i >= 0 {
+			line = line[i+1:]
+		}
+	}
+	switch {
+	case bytes.HasPrefix(line, auditLinePrefix), bytes.HasPrefix(line, errorLinePrefix):
+		return logLevels[LogLevelError]
+	case bytes.HasPrefix(line, warningLinePrefix):
+		return logLevels[LogLevelWarn]
+	case bytes.HasPrefix(line, debugLinePrefix):
+		return logLevels[LogLevelDebug]
+	}
+	return logLevels[LogLevelInfo]
+}
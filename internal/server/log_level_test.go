@@ -0,0 +1,46 @@
+package server
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogWriter_DropsLinesBelowLevel(t *testing.T) {
+	t.Cleanup(func() { setLogLevel(LogLevelInfo) })
+	var buf bytes.Buffer
+	logger := log.New(LogWriter(&buf), "", log.Flags())
+
+	setLogLevel(LogLevelWarn)
+	logger.Printf("DEBUG: cache miss")
+	logger.Printf("MCP Server starting...")
+	logger.Printf("WARNING: slow tool call")
+	logger.Printf("ERROR: server error")
+	logger.Printf(`AUDIT {"tool":"list-pods"}`)
+
+	assert.NotContains(t, buf.String(), "cache miss")
+	assert.NotContains(t, buf.String(), "starting")
+	assert.Contains(t, buf.String(), "slow tool call")
+	assert.Contains(t, buf.String(), "server error")
+	assert.Contains(t, buf.String(), "AUDIT ", "audit lines are always written")
+
+	buf.Reset()
+	setLogLevel(LogLevelDebug)
+	logger.Printf("DEBUG: cache miss")
+	assert.Contains(t, buf.String(), "cache miss")
+}
+
+func TestLogWriter_ErrorLevelKeepsAudit(t *testing.T) {
+	t.Cleanup(func() { setLogLevel(LogLevelInfo) })
+	var buf bytes.Buffer
+	logger := log.New(LogWriter(&buf), "", log.Flags())
+
+	setLogLevel(LogLevelError)
+	logger.Printf("WARNING: slow tool call")
+	logger.Printf(`AUDIT {"tool":"list-pods"}`)
+
+	assert.NotContains(t, buf.String(), "slow tool call")
+	assert.Contains(t, buf.String(), "AUDIT ")
+}
@@ -0,0 +1,402 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// logStreamMaxLine is the longest log line relayed; a longer one ends the stream
+const logStreamMaxLine = 1 << 20
+
+// Why a log stream ended, the reason of its end event
+const (
+	logStreamEndContainerExited = "container_exited" // The API server closed the stream
+	logStreamEndMaxDuration     = "max_duration"
+	logStreamEndMaxBytes        = "max_bytes"
+	logStreamEndCanceled        = "canceled" // The client went away
+	logStreamEndShutdown        = "shutdown"
+	logStreamEndError           = "error"
+)
+
+var (
+	// errLogStreamMaxDuration is the cause of a log stream running out of time
+	errLogStreamMaxDuration = errors.New("log stream reached LOG_STREAM_MAX_DURATION")
+	// errLogStreamShutdown is the cause of log streams ended by server shutdown
+	errLogStreamShutdown = errors.New("server is shutting down")
+)
+
+// podLogFollower opens followed container log streams. The Kubernetes
+// client implements it; tests substitute a fake.
+type podLogFollower interface {
+	FollowPodLogs(ctx context.Context, namespace, pod, container string, sinceSeconds int64) (io.ReadCloser, error)
+}
+
+// logLine is one relayed log line
+type logLine struct {
+	Timestamp string `json:"timestamp,omitempty"`
+	Line      string `json:"line"`
+}
+
+// logStreamEnd is how a log stream ended
+type logStreamEnd struct {
+	Lines  int64  `json:"lines"`
+	Bytes  int64  `json:"bytes"`
+	Reason string `json:"reason"`
+	Error  string `json:"error,omitempty"`
+}
+
+// logStreams bounds the log streams of /mcp/stream/logs and follow-pod-logs
+// together: at most as many run at once as there are slots, and all of them
+// end when the server shuts down
+type logStreams struct {
+	follower podLogFollower
+	slots    chan struct{}
+
+	ctx    context.Context // Canceled with errLogStreamShutdown by close
+	cancel context.CancelCauseFunc
+	jobs   sync.WaitGroup // Running follow-pod-logs jobs
+}
+
+// newLogStreams creates the log stream limits with maxConcurrent slots
+func newLogStreams(follower podLogFollower, maxConcurrent int) *logStreams {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &logStreams{
+		follower: follower,
+		slots:    make(chan struct{}, maxConcurrent),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// acquire takes a slot without waiting; ok is false when all are in use or
+// the server is shutting down
+func (l *logStreams) acquire() (release func(), ok bool) {
+	if l.ctx.Err() != nil {
+		return nil, false
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, true
+	default:
+		return nil, false
+	}
+}
+
+// busyError is returned to callers finding every slot in use
+func (l *logStreams) busyError() error {
+	if l.ctx.Err() != nil {
+		return errLogStreamShutdown
+	}
+	return fmt.Errorf("%w: all %d log stream slots are in use (LOG_STREAM_MAX_CONCURRENT)", errToolBusy, cap(l.slots))
+}
+
+// bound returns a context of parent that ends after maxDuration, or at
+// shutdown, with the matching cause
+func (l *logStreams) bound(parent context.Context, maxDuration time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	stop := context.AfterFunc(l.ctx, func() { cancel(context.Cause(l.ctx)) })
+	ctx, cancelTimeout := context.WithTimeoutCause(ctx, maxDuration, errLogStreamMaxDuration)
+	return ctx, func() {
+		cancelTimeout()
+		stop()
+		cancel(nil)
+	}
+}
+
+// close ends every log stream and waits for the jobs to record how they
+// ended, so that HTTP shutdown does not wait for streams that may run for
+// LOG_STREAM_MAX_DURATION. A nil logStreams has nothing to close.
+func (l *logStreams) close() {
+	if l == nil {
+		return
+	}
+	l.cancel(errLogStreamShutdown)
+	l.jobs.Wait()
+}
+
+// endReason returns why ctx ended a log stream
+func endReason(ctx context.Context) string {
+	switch context.Cause(ctx) {
+	case errLogStreamMaxDuration:
+		return logStreamEndMaxDuration
+	case errLogStreamShutdown:
+		return logStreamEndShutdown
+	default:
+		return logStreamEndCanceled
+	}
+}
+
+// relayLogLines passes each line of stream to emit until the stream ends,
+// ctx ends, or the next line would take the relayed bytes past maxBytes.
+// keepalive is called every heartbeat without a line (never when heartbeat
+// is 0). The stream is closed, and the goroutine reading it has exited, by
+// the time relayLogLines returns.
+func relayLogLines(ctx context.Context, stream io.ReadCloser, maxBytes int64, heartbeat time.Duration, emit func(logLine) error, keepalive func() error) logStreamEnd {
+	lines := make(chan string)
+	stopped := make(chan struct{})
+	finished := make(chan struct{})
+	var readErr error
+	go func() {
+		defer close(finished)
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 0, 64*1024), logStreamMaxLine)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-stopped:
+				return
+			}
+		}
+		readErr = scanner.Err()
+		if errors.Is(readErr, bufio.ErrTooLong) {
+			readErr = fmt.Errorf("log line longer than %d bytes", logStreamMaxLine)
+		}
+	}()
+	defer func() {
+		close(stopped)
+		stream.Close() //nolint:errcheck,gosec // Unblocks the reader; the stream is done either way
+		<-finished
+	}()
+
+	var ticks <-chan time.Time
+	if heartbeat > 0 {
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+	var end logStreamEnd
+	failed := func(err error) logStreamEnd {
+		end.Reason, end.Error = logStreamEndError, err.Error()
+		return end
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			end.Reason = endReason(ctx)
+			return end
+		case <-finished:
+			// Reading fails too once ctx ends the stream; ctx tells why
+			switch {
+			case ctx.Err() != nil:
+				end.Reason = endReason(ctx)
+			case readErr != nil:
+				return failed(readErr)
+			default:
+				end.Reason = logStreamEndContainerExited
+			}
+			return end
+		case raw := <-lines:
+			size := int64(len(raw)) + 1 // The newline counts
+			if end.Bytes+size > maxBytes {
+				end.Reason = logStreamEndMaxBytes
+				return end
+			}
+			timestamp, text := tools.SplitLogTimestamp(raw)
+			if err := emit(logLine{Timestamp: timestamp, Line: text}); err != nil {
+				return failed(err)
+			}
+			end.Lines++
+			end.Bytes += size
+		case <-ticks:
+			if err := keepalive(); err != nil {
+				return failed(err)
+			}
+		}
+	}
+}
+
+// checkLogNamespace refuses namespaces outside ALLOWED_NAMESPACES, or
+// outside the caller's tenant profile
+func (s *MCPServer) checkLogNamespace(ctx context.Context, namespace string) error {
+	allowed := s.currentConfig().AllowedNamespaces
+	if len(allowed) > 0 && !slices.Contains(allowed, namespace) {
+		return fmt.Errorf("%w: namespace %q is not in the allowed namespaces", tools.ErrForbidden, namespace)
+	}
+	if tenant := s.tenantFor(ctx); tenant != nil && !slices.Contains(tenant.Namespaces, namespace) {
+		return fmt.Errorf("%w: namespace %q is outside tenant %q", errTenantDenied, namespace, tenant.Name)
+	}
+	return nil
+}
+
+// logStreamStatus maps an error opening a log stream to the response status
+func logStreamStatus(err error) int {
+	switch {
+	case apierrors.IsNotFound(err):
+		return http.StatusNotFound
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return http.StatusForbidden
+	case apierrors.IsBadRequest(err):
+		return http.StatusBadRequest // e.g. no container named for a pod with several
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+// handleLogStream follows the log of one container as server-sent events
+// GET /mcp/stream/logs?namespace=...&pod=...&container=...&since_seconds=... -
+// Events of type "log", one per line, then one "end" saying why it ended
+func (s *MCPServer) handleLogStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed - use GET")
+		return
+	}
+	if s.logStreams == nil {
+		writeJSONError(w, http.StatusNotFound, "Log streaming is disabled (LOG_STREAM_MAX_CONCURRENT=0)")
+		return
+	}
+	query := r.URL.Query()
+	namespace, pod, container := query.Get("namespace"), query.Get("pod"), query.Get("container")
+	if namespace == "" || pod == "" {
+		writeJSONError(w, http.StatusBadRequest, "namespace and pod are required")
+		return
+	}
+	var sinceSeconds int64
+	if raw := query.Get("since_seconds"); raw != "" {
+		var err error
+		if sinceSeconds, err = strconv.ParseInt(raw, 10, 64); err != nil || sinceSeconds < 0 {
+			writeJSONError(w, http.StatusBadRequest, "since_seconds must be a non-negative integer")
+			return
+		}
+	}
+	if err := s.checkLogNamespace(r.Context(), namespace); err != nil {
+		writeJSONError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeJSONError(w, http.StatusInternalServerError, "streaming is not supported by this connection")
+		return
+	}
+
+	release, ok := s.logStreams.acquire()
+	if !ok {
+		err := s.logStreams.busyError()
+		if errors.Is(err, errLogStreamShutdown) {
+			writeJSONError(w, http.StatusServiceUnavailable, err.Error())
+		} else {
+			writeJSONError(w, http.StatusTooManyRequests, err.Error())
+		}
+		return
+	}
+	defer release()
+
+	cfg := s.currentConfig()
+	ctx, cancel := s.logStreams.bound(r.Context(), cfg.LogStreamMaxDuration)
+	defer cancel()
+	stream, err := s.logStreams.follower.FollowPodLogs(ctx, namespace, pod, container, sinceSeconds)
+	if err != nil {
+		writeJSONError(w, logStreamStatus(err), err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	writeEvent := func(kind string, v interface{}) error {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", kind, data); err != nil {
+			return err
+		}
+		flusher.Flush()
+		return nil
+	}
+	end := relayLogLines(ctx, stream, cfg.LogStreamMaxBytes, cfg.StreamHeartbeatInterval,
+		func(line logLine) error { return writeEvent("log", line) },
+		func() error {
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		})
+	if end.Reason != logStreamEndCanceled {
+		_ = writeEvent("end", end) //nolint:errcheck // The client may be gone already
+	}
+}
+
+// FollowLogs starts a follow-pod-logs job: the log of target is appended to
+// a new artifact in the background, until it ends the way a log stream
+// does. The call's context only carries values into the job; the job runs
+// on after the call returns.
+func (s *MCPServer) FollowLogs(ctx context.Context, target tools.LogFollowTarget) (*tools.LogFollowJob, error) {
+	if s.logStreams == nil || s.artifacts == nil {
+		return nil, fmt.Errorf("log following is disabled")
+	}
+	if err := s.checkLogNamespace(ctx, target.Namespace); err != nil {
+		return nil, err
+	}
+	release, ok := s.logStreams.acquire()
+	if !ok {
+		return nil, s.logStreams.busyError()
+	}
+
+	cfg := s.currentConfig()
+	started := time.Now()
+	jobCtx, cancel := s.logStreams.bound(context.WithoutCancel(ctx), cfg.LogStreamMaxDuration)
+	stream, err := s.logStreams.follower.FollowPodLogs(jobCtx, target.Namespace, target.Pod, target.Container, target.SinceSeconds)
+	if err != nil {
+		cancel()
+		release()
+		return nil, err
+	}
+	name := strings.Join([]string{"logs", target.Namespace, target.Pod, target.Container}, "-")
+	meta, writer, err := s.artifacts.create("follow-pod-logs", artifactTenant(ctx), strings.TrimSuffix(name, "-")+".log", "text/plain; charset=utf-8")
+	if err != nil {
+		stream.Close() //nolint:errcheck,gosec // Never read
+		cancel()
+		release()
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	s.logStreams.jobs.Add(1)
+	go func() {
+		defer s.logStreams.jobs.Done()
+		defer release()
+		defer cancel()
+		end := relayLogLines(jobCtx, stream, cfg.LogStreamMaxBytes, 0, func(line logLine) error {
+			text := line.Line
+			if line.Timestamp != "" {
+				text = line.Timestamp + " " + text
+			}
+			_, err := io.WriteString(writer, text+"\n")
+			return err
+		}, nil)
+		trailer := fmt.Sprintf("--- log follow ended: %s after %d lines, %d bytes", end.Reason, end.Lines, end.Bytes)
+		if end.Error != "" {
+			trailer += ": " + end.Error
+		}
+		if _, err := io.WriteString(writer, trailer+"\n"); err != nil {
+			log.Printf("WARNING: follow-pod-logs %s/%s: %v", target.Namespace, target.Pod, err)
+		}
+		if err := writer.Close(); err != nil {
+			log.Printf("WARNING: follow-pod-logs %s/%s: failed to finish artifact %s: %v", target.Namespace, target.Pod, meta.ID, err)
+		}
+	}()
+
+	return &tools.LogFollowJob{
+		Artifact: meta.ref(),
+		EndsBy:   started.Add(cfg.LogStreamMaxDuration),
+		MaxBytes: cfg.LogStreamMaxBytes,
+	}, nil
+}
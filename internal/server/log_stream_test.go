@@ -0,0 +1,364 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// fakeLogStream is a followed log that never ends by itself: reads block
+// until a line is sent, exit ends it with EOF, and Close unblocks them
+type fakeLogStream struct {
+	lines   chan string
+	exited  chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+	reading atomic.Int32 // Reads in progress, so tests can tell the reader exited
+	pending []byte
+}
+
+func newFakeLogStream() *fakeLogStream {
+	return &fakeLogStream{lines: make(chan string), exited: make(chan struct{}), closed: make(chan struct{})}
+}
+
+func (f *fakeLogStream) Read(p []byte) (int, error) {
+	f.reading.Add(1)
+	defer f.reading.Add(-1)
+	if len(f.pending) == 0 {
+		select {
+		case line := <-f.lines:
+			f.pending = []byte(line + "\n")
+		case <-f.exited:
+			return 0, io.EOF
+		case <-f.closed:
+			return 0, io.ErrClosedPipe
+		}
+	}
+	n := copy(p, f.pending)
+	f.pending = f.pending[n:]
+	return n, nil
+}
+
+func (f *fakeLogStream) Close() error {
+	f.once.Do(func() { close(f.closed) })
+	return nil
+}
+
+// send writes lines to the stream, failing the test if nobody reads them
+func (f *fakeLogStream) send(t *testing.T, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		select {
+		case f.lines <- line:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("nobody read log line %q", line)
+		}
+	}
+}
+
+// assertTornDown checks the stream was closed and nothing reads it anymore
+func (f *fakeLogStream) assertTornDown(t *testing.T) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		select {
+		case <-f.closed:
+			return f.reading.Load() == 0
+		default:
+			return false
+		}
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+// fakeLogFollower hands out fakeLogStreams, or err
+type fakeLogFollower struct {
+	mu      sync.Mutex
+	streams []*fakeLogStream
+	err     error
+}
+
+func (f *fakeLogFollower) FollowPodLogs(_ context.Context, _, _, _ string, _ int64) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	stream := newFakeLogStream()
+	f.streams = append(f.streams, stream)
+	return stream, nil
+}
+
+// stream waits for the nth stream opened
+func (f *fakeLogFollower) stream(t *testing.T, n int) *fakeLogStream {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return len(f.streams) > n
+	}, 5*time.Second, 5*time.Millisecond)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.streams[n]
+}
+
+func TestRelayLogLines_Ends(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxBytes  int64
+		duration  time.Duration
+		end       func(stream *fakeLogStream, cancel context.CancelFunc, streams *logStreams)
+		wantLines int64
+		want      string
+	}{
+		{
+			name:      "container exits",
+			maxBytes:  1 << 20,
+			end:       func(stream *fakeLogStream, _ context.CancelFunc, _ *logStreams) { close(stream.exited) },
+			wantLines: 2, want: logStreamEndContainerExited,
+		},
+		{
+			name:      "byte limit",
+			maxBytes:  45, // The first two lines are 39 bytes with their newlines, the third 11
+			end:       func(*fakeLogStream, context.CancelFunc, *logStreams) {},
+			wantLines: 2, want: logStreamEndMaxBytes,
+		},
+		{
+			name:      "duration limit",
+			maxBytes:  1 << 20,
+			duration:  50 * time.Millisecond,
+			end:       func(*fakeLogStream, context.CancelFunc, *logStreams) {},
+			wantLines: 2, want: logStreamEndMaxDuration,
+		},
+		{
+			name:      "client disconnects",
+			maxBytes:  1 << 20,
+			end:       func(_ *fakeLogStream, cancel context.CancelFunc, _ *logStreams) { cancel() },
+			wantLines: 2, want: logStreamEndCanceled,
+		},
+		{
+			name:      "server shuts down",
+			maxBytes:  1 << 20,
+			end:       func(_ *fakeLogStream, _ context.CancelFunc, streams *logStreams) { streams.close() },
+			wantLines: 2, want: logStreamEndShutdown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams := newLogStreams(&fakeLogFollower{}, 1)
+			duration := tt.duration
+			if duration == 0 {
+				duration = time.Minute
+			}
+			parent, cancelParent := context.WithCancel(context.Background())
+			defer cancelParent()
+			ctx, cancel := streams.bound(parent, duration)
+			defer cancel()
+
+			stream := newFakeLogStream()
+			var mu sync.Mutex
+			var got []logLine
+			emitted := func() int {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(got)
+			}
+			done := make(chan logStreamEnd)
+			go func() {
+				done <- relayLogLines(ctx, stream, tt.maxBytes, 0, func(line logLine) error {
+					mu.Lock()
+					defer mu.Unlock()
+					got = append(got, line)
+					return nil
+				}, nil)
+			}()
+			stream.send(t, "2026-10-15T12:00:00Z first", "second line")
+			require.Eventually(t, func() bool { return emitted() == 2 }, 5*time.Second, time.Millisecond)
+			if tt.name == "byte limit" {
+				stream.send(t, "third line")
+			}
+			tt.end(stream, cancelParent, streams)
+
+			var end logStreamEnd
+			select {
+			case end = <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("relayLogLines did not return")
+			}
+			assert.Equal(t, tt.want, end.Reason)
+			assert.Equal(t, tt.wantLines, end.Lines)
+			assert.Equal(t, []logLine{{Timestamp: "2026-10-15T12:00:00Z", Line: "first"}, {Line: "second line"}}, got)
+			stream.assertTornDown(t)
+		})
+	}
+}
+
+// newLogStreamServer serves /mcp/stream/logs with a fake follower and
+// room for one stream
+func newLogStreamServer(t *testing.T, cfg *Config) (*httptest.Server, *fakeLogFollower, *logStreams) {
+	t.Helper()
+	follower := &fakeLogFollower{}
+	s := &MCPServer{config: cfg, logStreams: newLogStreams(follower, 1)}
+	server := httptest.NewServer(http.HandlerFunc(s.handleLogStream))
+	t.Cleanup(func() {
+		s.logStreams.close()
+		server.Close()
+	})
+	return server, follower, s.logStreams
+}
+
+// waitForFreeSlots waits until no log stream holds a slot
+func waitForFreeSlots(t *testing.T, streams *logStreams) {
+	t.Helper()
+	require.Eventually(t, func() bool { return len(streams.slots) == 0 }, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestHandleLogStream(t *testing.T) {
+	cfg := defaultConfig()
+	server, follower, streams := newLogStreamServer(t, cfg)
+
+	client := connectSSE(t, server.URL+"?namespace=shop&pod=api-1", "")
+	stream := follower.stream(t, 0)
+	stream.send(t, "2026-10-15T12:00:00Z started", "listening on :8080")
+
+	msg := client.next(t)
+	assert.Equal(t, "log", msg.event)
+	assert.JSONEq(t, `{"timestamp":"2026-10-15T12:00:00Z","line":"started"}`, msg.data)
+	msg = client.next(t)
+	assert.JSONEq(t, `{"line":"listening on :8080"}`, msg.data)
+
+	// The only slot is taken
+	resp, err := http.Get(server.URL + "?namespace=shop&pod=api-2")
+	require.NoError(t, err)
+	resp.Body.Close() //nolint:errcheck
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	close(stream.exited)
+	msg = client.next(t)
+	assert.Equal(t, "end", msg.event)
+	var end logStreamEnd
+	require.NoError(t, json.Unmarshal([]byte(msg.data), &end))
+	assert.Equal(t, logStreamEndContainerExited, end.Reason)
+	assert.Equal(t, int64(2), end.Lines)
+	stream.assertTornDown(t)
+
+	// The slot is free again
+	client.close()
+	waitForFreeSlots(t, streams)
+	connectSSE(t, server.URL+"?namespace=shop&pod=api-2", "")
+}
+
+func TestHandleLogStream_ClientDisconnect(t *testing.T) {
+	server, follower, streams := newLogStreamServer(t, defaultConfig())
+
+	client := connectSSE(t, server.URL+"?namespace=shop&pod=api-1", "")
+	stream := follower.stream(t, 0)
+	stream.send(t, "hello")
+	assert.Equal(t, "log", client.next(t).event)
+
+	client.close()
+	stream.assertTornDown(t)
+	waitForFreeSlots(t, streams)
+	connectSSE(t, server.URL+"?namespace=shop&pod=api-1", "")
+}
+
+func TestHandleLogStream_Refused(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.AllowedNamespaces = []string{"shop"}
+	server, follower, _ := newLogStreamServer(t, cfg)
+
+	get := func(query string) int {
+		resp, err := http.Get(server.URL + query)
+		require.NoError(t, err)
+		resp.Body.Close() //nolint:errcheck
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusBadRequest, get("?namespace=shop"))
+	assert.Equal(t, http.StatusBadRequest, get("?namespace=shop&pod=api-1&since_seconds=-1"))
+	assert.Equal(t, http.StatusForbidden, get("?namespace=kube-system&pod=etcd-0"))
+
+	follower.err = apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "gone")
+	assert.Equal(t, http.StatusNotFound, get("?namespace=shop&pod=gone"))
+
+	disabled := httptest.NewServer(http.HandlerFunc((&MCPServer{config: cfg}).handleLogStream))
+	defer disabled.Close()
+	resp, err := http.Get(disabled.URL + "?namespace=shop&pod=api-1")
+	require.NoError(t, err)
+	resp.Body.Close() //nolint:errcheck
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestFollowLogs_AppendsToArtifact(t *testing.T) {
+	cfg := defaultConfig()
+	store, _ := newTestArtifactStore(t, 1<<20)
+	follower := &fakeLogFollower{}
+	s := &MCPServer{config: cfg, artifacts: store, logStreams: newLogStreams(follower, 1)}
+	defer s.logStreams.close()
+
+	job, err := s.FollowLogs(context.Background(), tools.LogFollowTarget{Namespace: "shop", Pod: "api-1", Container: "app"})
+	require.NoError(t, err)
+	ref := job.Artifact.(ArtifactRef)
+	assert.Equal(t, "logs-shop-api-1-app.log", ref.Name)
+	assert.Equal(t, cfg.LogStreamMaxBytes, job.MaxBytes)
+
+	// Every slot is taken by the job
+	_, err = s.FollowLogs(context.Background(), tools.LogFollowTarget{Namespace: "shop", Pod: "api-2"})
+	assert.ErrorIs(t, err, errToolBusy)
+
+	stream := follower.stream(t, 0)
+	stream.send(t, "2026-10-15T12:00:00Z started", "ready")
+	close(stream.exited)
+	stream.assertTornDown(t)
+	s.logStreams.jobs.Wait()
+
+	meta, ok := store.get(ref.ID)
+	require.True(t, ok)
+	content, err := os.ReadFile(store.contentPath(ref.ID))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-15T12:00:00Z started\nready\n--- log follow ended: container_exited after 2 lines, 35 bytes\n", string(content))
+	assert.Equal(t, int64(len(content)), meta.Size)
+
+	// The slot was released with the job
+	_, err = s.FollowLogs(context.Background(), tools.LogFollowTarget{Namespace: "shop", Pod: "api-2"})
+	require.NoError(t, err)
+}
+
+func TestFollowLogs_EndsAtShutdown(t *testing.T) {
+	store, _ := newTestArtifactStore(t, 1<<20)
+	follower := &fakeLogFollower{}
+	s := &MCPServer{config: defaultConfig(), artifacts: store, logStreams: newLogStreams(follower, 2)}
+
+	job, err := s.FollowLogs(context.Background(), tools.LogFollowTarget{Namespace: "shop", Pod: "api-1"})
+	require.NoError(t, err)
+	stream := follower.stream(t, 0)
+	stream.send(t, "working")
+	id := job.Artifact.(ArtifactRef).ID
+	require.Eventually(t, func() bool {
+		meta, ok := store.get(id)
+		return ok && meta.Size > 0
+	}, 5*time.Second, 5*time.Millisecond)
+
+	s.logStreams.close() // Waits for the job
+	stream.assertTornDown(t)
+	content, err := os.ReadFile(store.contentPath(id))
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(content), "--- log follow ended: shutdown after 1 lines, 8 bytes\n"), string(content))
+
+	_, err = s.FollowLogs(context.Background(), tools.LogFollowTarget{Namespace: "shop", Pod: "api-1"})
+	assert.ErrorIs(t, err, errLogStreamShutdown)
+}
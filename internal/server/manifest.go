@@ -0,0 +1,272 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// What conditionally registered tools require, as listed in a manifest entry's
+// "requires". A tool is served once all of its requirements are met.
+const (
+	requireCoordinationEngine = integrationCoordinationEngine // ENABLE_COORDINATION_ENGINE, while the engine answers
+	requireKServe             = integrationKServe             // ENABLE_KSERVE, while the predictors answer
+	requireOpenShift          = "openshift"                   // The cluster serves the OpenShift API groups
+	requirePrometheus         = "prometheus"                  // ENABLE_PROMETHEUS
+	requireRawAPI             = "raw_api"                     // RAW_API_ALLOWED_PREFIXES
+	requireHealthHistory      = "health_history"              // HEALTH_HISTORY_INTERVAL > 0
+	requireAlertmanager       = "alertmanager"                // ENABLE_ALERTMANAGER
+)
+
+// manifestRequirements are the requirements a manifest is built with, each
+// one also left out in turn to find the tools that depend on it
+var manifestRequirements = []string{
+	requireCoordinationEngine, requireKServe, requireOpenShift,
+	requirePrometheus, requireRawAPI, requireHealthHistory,
+	requireAlertmanager,
+}
+
+// Manifest output formats
+const (
+	ManifestFormatJSON = "json"
+	ManifestFormatYAML = "yaml"
+)
+
+// ToolManifest is the catalog of every built-in tool the server can serve,
+// for clients that register the tools before connecting. It is the tools/list
+// result of MCP, with each tool's flags and requirements alongside.
+type ToolManifest struct {
+	Tools []ToolManifestEntry `json:"tools"`
+	Count int                 `json:"count"`
+}
+
+// ToolManifestEntry describes one tool of a ToolManifest
+type ToolManifestEntry struct {
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description"`
+	InputSchema         map[string]interface{} `json:"inputSchema"`
+	Mutating            bool                   `json:"mutating"`
+	SupportsDryRun      bool                   `json:"supportsDryRun"`
+	RequiredPermissions []tools.PermissionRule `json:"requiredPermissions,omitempty"` // With or without the integrations
+	Aliases             []ToolManifestAlias    `json:"aliases,omitempty"`             // Deprecated names, registered as tools of their own
+	Requires            []string               `json:"requires,omitempty"`            // Empty for tools that are always served
+
+	tool Tool
+}
+
+// ToolManifestAlias is a deprecated name of a manifest tool
+type ToolManifestAlias struct {
+	Name   string `json:"name"`
+	Sunset string `json:"sunset,omitempty"`
+}
+
+// BuildToolManifest builds the catalog of the tools registered with cfg,
+// against a null backend: no cluster, Coordination Engine, KServe or
+// Prometheus is contacted, and every integration counts as available, so
+// that conditionally registered tools are listed with what they require.
+// Tools disabled in cfg are left out, as are the tools a build adds with
+// RegisterTool. The result is sorted and does not depend on the clock or
+// the environment beyond cfg.
+func BuildToolManifest(cfg *Config) (*ToolManifest, error) {
+	all := make(map[string]bool, len(manifestRequirements))
+	for _, requirement := range manifestRequirements {
+		all[requirement] = true
+	}
+	registered, err := manifestRegistry(cfg, all)
+	if err != nil {
+		return nil, err
+	}
+
+	// A tool requires whatever it is not registered without. Tools needing
+	// more RBAC without an integration, such as those reading kubelets in
+	// place of Prometheus, list the permissions of every variant.
+	requires := make(map[string][]string)
+	permissions := make(map[string][]tools.PermissionRule)
+	addPermissions := func(name string, tool Tool) {
+		requirer, ok := tool.(tools.PermissionRequirer)
+		if !ok {
+			return
+		}
+		for _, rule := range requirer.RequiredPermissions() {
+			if !slices.Contains(permissions[name], rule) {
+				permissions[name] = append(permissions[name], rule)
+			}
+		}
+	}
+	for name, tool := range registered.Snapshot() {
+		addPermissions(name, tool)
+	}
+	for _, requirement := range manifestRequirements {
+		all[requirement] = false
+		without, err := manifestRegistry(cfg, all)
+		if err != nil {
+			return nil, err
+		}
+		all[requirement] = true
+		for name := range registered.Snapshot() {
+			if tool, ok := without.Get(name); ok {
+				addPermissions(name, tool)
+			} else {
+				requires[name] = append(requires[name], requirement)
+			}
+		}
+	}
+
+	manifest := &ToolManifest{Tools: []ToolManifestEntry{}}
+	for name, tool := range registered.Snapshot() {
+		entry := ToolManifestEntry{
+			Name:                name,
+			Description:         tool.Description(),
+			InputSchema:         toolInputSchema(tool),
+			Mutating:            isMutating(tool),
+			SupportsDryRun:      supportsDryRun(tool),
+			RequiredPermissions: permissions[name],
+			Requires:            requires[name],
+			tool:                tool,
+		}
+		for _, alias := range toolAliases(tool) {
+			entry.Aliases = append(entry.Aliases, ToolManifestAlias{Name: alias.Name, Sunset: alias.SunsetDate()})
+		}
+		sort.Strings(entry.Requires)
+		manifest.Tools = append(manifest.Tools, entry)
+	}
+	sort.Slice(manifest.Tools, func(i, j int) bool { return manifest.Tools[i].Name < manifest.Tools[j].Name })
+	manifest.Count = len(manifest.Tools)
+	return manifest, nil
+}
+
+// manifestRegistry registers the built-in tools on a server with no
+// connections, given which requirements are met
+func manifestRegistry(cfg *Config, met map[string]bool) (*ToolRegistry, error) {
+	config := *cfg
+	config.RawAPIAllowedPrefixes = nil
+	if met[requireRawAPI] {
+		config.RawAPIAllowedPrefixes = cfg.RawAPIAllowedPrefixes
+		if len(config.RawAPIAllowedPrefixes) == 0 {
+			config.RawAPIAllowedPrefixes = []string{"$RAW_API_ALLOWED_PREFIXES"}
+		}
+	}
+
+	s := &MCPServer{
+		config:    &config,
+		mcpServer: mcp.NewServer(&mcp.Implementation{Name: config.Name, Version: config.Version}, nil),
+		tools:     NewToolRegistry(),
+		resources: NewResourceRegistry(),
+		prompts:   make(map[string]interface{}),
+	}
+	s.liveConfig.Store(&config)
+
+	if met[requireCoordinationEngine] {
+		// The URL is never dialed; the configured one may be unset or invalid
+		ceClient, err := clients.NewCoordinationEngineClient("http://coordination-engine:8080", clients.CoordinationEngineOptions{})
+		if err != nil {
+			return nil, err
+		}
+		s.ceClient = ceClient
+	}
+	if met[requireKServe] {
+		s.kserve = &clients.KServeClient{}
+	}
+	if met[requirePrometheus] {
+		s.prometheus = &clients.PrometheusClient{}
+	}
+	if met[requireAlertmanager] {
+		s.alertmanager = &clients.AlertmanagerClient{}
+	}
+	if met[requireOpenShift] {
+		s.platform.groups = make(map[string]bool)
+		for _, group := range append(slices.Clone(platformAPIGroups), "image.openshift.io", "build.openshift.io") {
+			s.platform.groups[group] = true
+		}
+	}
+	if met[requireHealthHistory] {
+		s.healthHistory = newHealthHistory(config.HealthHistorySize)
+		s.remediation = newRemediationEngine(s.currentConfig)
+	}
+
+	if err := s.registerTools(); err != nil {
+		return nil, fmt.Errorf("failed to register tools: %w", err)
+	}
+	return s.tools, nil
+}
+
+// MarshalToolManifest encodes a manifest as indented JSON or as YAML
+func MarshalToolManifest(manifest *ToolManifest, format string) ([]byte, error) {
+	switch format {
+	case ManifestFormatJSON, "":
+		data, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case ManifestFormatYAML:
+		return yaml.Marshal(manifest)
+	default:
+		return nil, fmt.Errorf("unsupported manifest format %q (use %s or %s)", format, ManifestFormatJSON, ManifestFormatYAML)
+	}
+}
+
+// toolManifest returns the manifest of the server's startup configuration,
+// built on first use
+func (s *MCPServer) toolManifest() (*ToolManifest, error) {
+	s.manifestOnce.Do(func() {
+		s.manifest, s.manifestErr = BuildToolManifest(s.config)
+	})
+	return s.manifest, s.manifestErr
+}
+
+// handleToolManifest serves the tool catalog, including the tools not served
+// now for a missing integration or platform; tenants only see the tools they
+// may call
+// GET /mcp/tools/manifest?format=json|yaml
+func (s *MCPServer) handleToolManifest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed - use GET", http.StatusMethodNotAllowed)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != ManifestFormatJSON && format != ManifestFormatYAML {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("unsupported format %q (use %s or %s)", format, ManifestFormatJSON, ManifestFormatYAML))
+		return
+	}
+
+	manifest, err := s.toolManifest()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if tenant := s.tenantFor(r.Context()); tenant != nil {
+		visible := &ToolManifest{Tools: []ToolManifestEntry{}}
+		for _, entry := range manifest.Tools {
+			if tenant.canCall(entry.tool) {
+				visible.Tools = append(visible.Tools, entry)
+			}
+		}
+		visible.Count = len(visible.Tools)
+		manifest = visible
+	}
+
+	data, err := MarshalToolManifest(manifest, format)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	contentType := mimeJSON
+	if format == ManifestFormatYAML {
+		contentType = mimeYAML
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		log.Printf("Error writing tool manifest: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+func TestBuildToolManifest_MatchesRegistry(t *testing.T) {
+	server := newFullRegistryServer(t)
+	manifest, err := BuildToolManifest(server.config)
+	require.NoError(t, err)
+
+	entries := make(map[string]ToolManifestEntry)
+	for _, entry := range manifest.Tools {
+		entries[entry.Name] = entry
+	}
+	assert.Equal(t, len(manifest.Tools), manifest.Count)
+
+	// Every tool the live registry serves is exported as registered
+	for name, tool := range server.GetTools() {
+		entry, ok := entries[name]
+		if !assert.True(t, ok, "tool %s missing from the manifest", name) {
+			continue
+		}
+		assert.Equal(t, tool.Description(), entry.Description, name)
+		assert.Equal(t, toolInputSchema(tool), entry.InputSchema, name)
+		assert.Equal(t, isMutating(tool), entry.Mutating, name)
+		assert.Equal(t, supportsDryRun(tool), entry.SupportsDryRun, name)
+		if requirer, ok := tool.(tools.PermissionRequirer); ok {
+			assert.Subset(t, entry.RequiredPermissions, requirer.RequiredPermissions(), name)
+		}
+	}
+
+	// The rest are the tools this registry was not given what they require
+	for name, entry := range entries {
+		if _, ok := server.GetTools()[name]; !ok {
+			assert.NotEmpty(t, entry.Requires, "tool %s is always registered but missing from the registry", name)
+		}
+	}
+}
+
+func TestBuildToolManifest_Requires(t *testing.T) {
+	manifest, err := BuildToolManifest(NewConfig())
+	require.NoError(t, err)
+
+	requires := make(map[string][]string)
+	for _, entry := range manifest.Tools {
+		requires[entry.Name] = entry.Requires
+	}
+	for name, want := range map[string][]string{
+		"get-cluster-health":      nil,
+		"list-incidents":          {requireCoordinationEngine},
+		"analyze-anomalies":       {requireCoordinationEngine, requireKServe},
+		"list-models":             {requireKServe},
+		"get-insights-report":     {requireOpenShift},
+		"get-registry-health":     {requireOpenShift},
+		"forecast-capacity":       {requirePrometheus},
+		"raw-get":                 {requireRawAPI},
+		"test-remediation-policy": {requireHealthHistory},
+		"create-alert-silence":    {requireAlertmanager},
+		"list-alert-silences":     {requireAlertmanager},
+	} {
+		got, ok := requires[name]
+		if assert.True(t, ok, "tool %s missing from the manifest", name) {
+			assert.Equal(t, want, got, name)
+		}
+	}
+
+	var mutating []string
+	for _, entry := range manifest.Tools {
+		if entry.Mutating {
+			mutating = append(mutating, entry.Name)
+		}
+	}
+	assert.Contains(t, mutating, "trigger-remediation")
+	assert.Contains(t, mutating, "create-alert-silence")
+	assert.NotContains(t, mutating, "get-cluster-health")
+}
+
+func TestBuildToolManifest_Deterministic(t *testing.T) {
+	cfg := NewConfig()
+	first, err := BuildToolManifest(cfg)
+	require.NoError(t, err)
+	second, err := BuildToolManifest(cfg)
+	require.NoError(t, err)
+
+	for _, format := range []string{ManifestFormatJSON, ManifestFormatYAML} {
+		a, err := MarshalToolManifest(first, format)
+		require.NoError(t, err)
+		b, err := MarshalToolManifest(second, format)
+		require.NoError(t, err)
+		assert.Equal(t, string(a), string(b), format)
+	}
+
+	for i := 1; i < len(first.Tools); i++ {
+		assert.Less(t, first.Tools[i-1].Name, first.Tools[i].Name, "sorted by name")
+	}
+
+	_, err = MarshalToolManifest(first, "xml")
+	assert.ErrorContains(t, err, `unsupported manifest format "xml"`)
+}
+
+func TestBuildToolManifest_DisabledTools(t *testing.T) {
+	cfg := NewConfig()
+	cfg.DisabledTools = []string{"list-*", "raw-get"}
+	manifest, err := BuildToolManifest(cfg)
+	require.NoError(t, err)
+
+	for _, entry := range manifest.Tools {
+		assert.NotEqual(t, "raw-get", entry.Name)
+		assert.NotContains(t, entry.Name, "list-")
+	}
+	assert.NotZero(t, manifest.Count)
+}
+
+func TestHandleToolManifest(t *testing.T) {
+	server := newFullRegistryServer(t)
+	handler := server.httpHandler()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/mcp/tools/manifest", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var fromJSON ToolManifest
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &fromJSON))
+	assert.Equal(t, len(fromJSON.Tools), fromJSON.Count)
+	assert.GreaterOrEqual(t, fromJSON.Count, server.tools.Len(), "tools not registered now are listed too")
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/mcp/tools/manifest?format=yaml", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	var fromYAML ToolManifest
+	require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &fromYAML))
+	assert.Equal(t, fromJSON, fromYAML)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/mcp/tools/manifest?format=xml", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/mcp/tools/manifest", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/mockcluster"
+)
+
+// mockScenarioRequest is the body of POST /mock/scenario
+type mockScenarioRequest struct {
+	Scenario string            `json:"scenario"`
+	Params   map[string]string `json:"params,omitempty"`
+}
+
+// handleMockScenario lists the scenarios of the simulated cluster, or
+// applies one. Cached results are dropped and resource subscribers notified,
+// so the next reads see the change. Unavailable unless BACKEND=mock.
+// GET /mock/scenario - List scenarios
+// POST /mock/scenario - Apply one: {"scenario": "node-not-ready", "params": {"node": "worker-1"}}
+func (s *MCPServer) handleMockScenario(w http.ResponseWriter, r *http.Request) {
+	if s.mock == nil {
+		writeJSONError(w, http.StatusNotFound, "scenarios are only available with the simulated cluster (BACKEND=mock)")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		if err := writeJSON(w, map[string]interface{}{"scenarios": mockcluster.Scenarios()}); err != nil {
+			log.Printf("Error writing scenarios: %v", err)
+		}
+		return
+	case http.MethodPost:
+	default:
+		http.Error(w, "Method not allowed - use GET or POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if !requireJSONContentType(w, r) {
+		return
+	}
+
+	var request mockScenarioRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeBodyDecodeError(w, err)
+		return
+	}
+	if request.Scenario == "" {
+		writeJSONError(w, http.StatusBadRequest, "scenario is required")
+		return
+	}
+	summary, err := s.mock.Apply(request.Scenario, request.Params)
+	if errors.Is(err, mockcluster.ErrUnknownScenario) {
+		writeJSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	log.Printf("Mock scenario %s applied: %s", request.Scenario, summary)
+	s.cache.Clear()
+	for _, uri := range s.resources.Names() {
+		s.notifyResourceUpdated(r.Context(), uri)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := writeJSON(w, map[string]interface{}{"success": true, "scenario": request.Scenario, "summary": summary}); err != nil {
+		log.Printf("Error writing scenario response: %v", err)
+	}
+}
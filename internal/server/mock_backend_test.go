@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// newMockBackendServer creates a server reading the built-in simulated cluster
+func newMockBackendServer(t *testing.T) *MCPServer {
+	t.Helper()
+	cfg := defaultConfig()
+	cfg.Backend = BackendMock
+	server, err := NewMCPServer(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = server.Stop() })
+	return server
+}
+
+// mockToolArgs are arguments for the tools that need some, naming objects
+// of the built-in fixtures
+var mockToolArgs = map[string]map[string]interface{}{
+	"get-resource-manifest": {"api_version": "apps/v1", "kind": "Deployment", "namespace": "demo-shop", "name": "frontend"},
+	"get-rollout-status":    {"namespace": "demo-shop", "name": "frontend"},
+	"rollback-deployment":   {"namespace": "demo-shop", "name": "frontend"},
+	"search-logs":           {"namespace": "demo-shop", "workload": "frontend", "pattern": "error"},
+}
+
+func TestMockBackend_ToolSuite(t *testing.T) {
+	server := newMockBackendServer(t)
+	ctx := context.Background()
+
+	require.NotEmpty(t, server.tools.List())
+	for _, tool := range server.tools.List() {
+		t.Run(tool.Name(), func(t *testing.T) {
+			args, ok := mockToolArgs[tool.Name()]
+			if !ok {
+				args = map[string]interface{}{}
+			}
+			_, _, err := server.executeTool(ctx, tool, args)
+			switch tool.Name() {
+			case "get-must-gather-status", "rollback-deployment":
+				// No must-gather ran yet, and the deployment has no earlier revision
+				assert.True(t, tools.IsInvalidArguments(err), "want invalid arguments, got %v", err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	for _, resource := range server.resources.List() {
+		t.Run(resource.URI(), func(t *testing.T) {
+			content, err := resource.Read(ctx)
+			require.NoError(t, err)
+			assert.NotEmpty(t, content)
+		})
+	}
+}
+
+func TestMockBackend_Scenario(t *testing.T) {
+	server := newMockBackendServer(t)
+	healthTool, ok := server.tools.Get("get-cluster-health")
+	require.True(t, ok)
+	readHealth := func() tools.ClusterHealthOutput {
+		result, _, err := server.executeTool(context.Background(), healthTool, map[string]interface{}{})
+		require.NoError(t, err)
+		data, err := json.Marshal(result)
+		require.NoError(t, err)
+		var output tools.ClusterHealthOutput
+		require.NoError(t, json.Unmarshal(data, &output))
+		return output
+	}
+
+	before := readHealth()
+	require.Equal(t, 0, before.Nodes.NotReady)
+
+	w := authRequest(server, http.MethodGet, "/mock/scenario", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "node-not-ready")
+	assert.Contains(t, w.Body.String(), "namespace-crashloop")
+
+	w = authRequest(server, http.MethodPost, "/mock/scenario", "", map[string]interface{}{"scenario": "node-not-ready", "params": map[string]string{"node": "worker-1"}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "node worker-1 is NotReady")
+
+	// The cached health is dropped, so the change shows at once
+	after := readHealth()
+	assert.Equal(t, 1, after.Nodes.NotReady)
+	assert.Equal(t, 2, after.Nodes.Ready)
+
+	w = authRequest(server, http.MethodPost, "/mock/scenario", "", map[string]interface{}{"scenario": "reset"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, 0, readHealth().Nodes.NotReady)
+}
+
+func TestMockBackend_ScenarioErrors(t *testing.T) {
+	server := newMockBackendServer(t)
+
+	tests := []struct {
+		name string
+		body interface{}
+		want int
+	}{
+		{"unknown scenario", map[string]interface{}{"scenario": "meteor-strike"}, http.StatusNotFound},
+		{"missing scenario", map[string]interface{}{}, http.StatusBadRequest},
+		{"unknown parameter", map[string]interface{}{"scenario": "node-not-ready", "params": map[string]string{"zone": "a"}}, http.StatusBadRequest},
+		{"missing node", map[string]interface{}{"scenario": "node-not-ready", "params": map[string]string{"node": "worker-9"}}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := authRequest(server, http.MethodPost, "/mock/scenario", "", tt.body)
+			assert.Equal(t, tt.want, w.Code, w.Body.String())
+		})
+	}
+
+	w := authRequest(server, http.MethodDelete, "/mock/scenario", "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestMockBackend_ScenarioRouteOnlyInMockMode(t *testing.T) {
+	server := newStubToolServer(t, defaultConfig())
+
+	w := authRequest(server, http.MethodPost, "/mock/scenario", "", map[string]interface{}{"scenario": "reset"})
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"k8s.io/utils/clock"
 )
 
 // TestOLSMCPServerExpectations validates that our MCP server meets
@@ -219,7 +220,7 @@ func TestToolDiscovery(t *testing.T) {
 	}
 
 	for _, toolName := range expectedTools {
-		if _, exists := server.tools[toolName]; !exists {
+		if _, exists := server.tools.Get(toolName); !exists {
 			t.Errorf("Expected tool %s to be registered", toolName)
 		}
 	}
@@ -267,8 +268,9 @@ func setupOLSBenchServer(b *testing.B) *MCPServer {
 		mcpServer: mcpServer,
 		k8sClient: k8sClient,
 		cache:     memoryCache,
-		tools:     make(map[string]Tool),
-		resources: make(map[string]interface{}),
+		tools:     NewToolRegistry(),
+		resources: NewResourceRegistry(),
+		clock:     clock.RealClock{},
 	}
 
 	if err := server.registerTools(); err != nil {
@@ -0,0 +1,815 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"sort"
+)
+
+// openAPIVersion is the OpenAPI specification version of the generated document
+const openAPIVersion = "3.0.3"
+
+// describedResource is implemented by every registered resource
+type describedResource interface {
+	Name() string
+	Description() string
+}
+
+// buildOpenAPISpec generates the OpenAPI document for the REST surface.
+// Tools and resources are registered dynamically, so the document is built
+// from the registries rather than written by hand.
+func (s *MCPServer) buildOpenAPISpec() map[string]interface{} {
+	paths := map[string]interface{}{
+		"/health": map[string]interface{}{
+			"get": textOperation("Liveness probe; a second line reports an unreachable Coordination Engine without failing the probe", "OK"),
+		},
+		"/ready": map[string]interface{}{
+			"get": textOperation("Readiness probe (503 while the cache warms up with READY_AFTER_WARMUP)", "READY"),
+		},
+		"/openapi.json": map[string]interface{}{
+			"get": jsonOperation("OpenAPI document for this server", objectSchema()),
+		},
+		"/mcp": map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary": "Open an MCP SSE stream (Model Context Protocol transport)",
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "Server-sent event stream",
+						"content": map[string]interface{}{
+							"text/event-stream": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+						},
+					},
+				},
+			},
+			"post": map[string]interface{}{
+				"summary":     "Send an MCP JSON-RPC message to an open SSE session",
+				"parameters":  []interface{}{sessionIDQueryParam(true)},
+				"requestBody": jsonRequestBody(objectSchema(), true),
+				"responses": map[string]interface{}{
+					"202": map[string]interface{}{"description": "Message accepted; the reply is delivered on the SSE stream"},
+					"400": errorResponse("Invalid message or missing session"),
+				},
+			},
+		},
+		"/mcp/capabilities": map[string]interface{}{
+			"get": jsonOperation("MCP server capabilities", objectSchema()),
+		},
+		"/mcp/info": map[string]interface{}{
+			"get": jsonOperation("Server name, build, integrations, and connected cluster", schemaRef("MCPInfoResponse")),
+		},
+		"/mcp/tools": map[string]interface{}{
+			"get": jsonOperation("List available tools", objectSchema()),
+		},
+		"/mcp/tools/manifest": map[string]interface{}{
+			"get": withParameters(jsonOperation("Catalog of every tool, with what the conditionally registered ones require", objectSchema()), []interface{}{
+				queryParam("format", "json (default) or yaml", map[string]interface{}{"type": "string", "enum": []interface{}{ManifestFormatJSON, ManifestFormatYAML}}),
+			}),
+		},
+		"/mcp/resources": map[string]interface{}{
+			"get": jsonOperation("List available resources", objectSchema()),
+		},
+		"/mcp/prompts": map[string]interface{}{
+			"get": jsonOperation("List available prompts", objectSchema()),
+		},
+		"/mcp/session": map[string]interface{}{
+			"post": map[string]interface{}{
+				"summary":     "Create a REST session",
+				"requestBody": jsonRequestBody(objectSchema(), false),
+				"responses": map[string]interface{}{
+					"201": jsonResponse("Session created", schemaRef("SessionCreated")),
+					"503": errorResponse("Session limit reached"),
+				},
+			},
+			"get": map[string]interface{}{
+				"summary":    "Get the current session",
+				"parameters": sessionIDParams(),
+				"responses": map[string]interface{}{
+					"200": jsonResponse("Session info", objectSchema()),
+					"400": errorResponse("Session ID missing"),
+					"404": errorResponse("Session not found or expired"),
+				},
+			},
+		},
+		"/mcp/session/{sessionid}": map[string]interface{}{
+			"parameters": []interface{}{map[string]interface{}{
+				"name":     "sessionid",
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			}},
+			"get": map[string]interface{}{
+				"summary": "Get a session by ID",
+				"responses": map[string]interface{}{
+					"200": jsonResponse("Session info", objectSchema()),
+					"404": errorResponse("Session not found or expired"),
+				},
+			},
+			"delete": map[string]interface{}{
+				"summary": "Delete a session",
+				"responses": map[string]interface{}{
+					"200": jsonResponse("Session deleted", objectSchema()),
+					"404": errorResponse("Session not found"),
+				},
+			},
+		},
+		"/mcp/sessions": map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary":  "List active sessions of all users",
+				"security": []interface{}{map[string]interface{}{"adminToken": []interface{}{}}},
+				"parameters": append(sessionFilterParams(),
+					queryParam("sort", "Order by creation (oldest first) or by last use (most recent first)",
+						map[string]interface{}{"type": "string", "enum": []interface{}{"created", "last_used"}, "default": "created"}),
+					queryParam("limit", "Sessions per page",
+						map[string]interface{}{"type": "integer", "minimum": 1, "maximum": maxSessionPageSize, "default": defaultSessionPageSize}),
+					queryParam("offset", "Sessions to skip (next_offset of the previous page)",
+						map[string]interface{}{"type": "integer", "minimum": 0, "default": 0}),
+				),
+				"responses": map[string]interface{}{
+					"200": jsonResponse("One page of sessions", objectSchema()),
+					"400": errorResponse("Invalid parameters"),
+					"401": errorResponse("Invalid or missing admin token"),
+					"403": errorResponse("Admin endpoints disabled"),
+				},
+			},
+			"delete": map[string]interface{}{
+				"summary":  "Revoke the sessions matching a metadata filter, or every session with all=true",
+				"security": []interface{}{map[string]interface{}{"adminToken": []interface{}{}}},
+				"parameters": append(sessionFilterParams(),
+					queryParam("all", "Required to revoke every session when no filter is given",
+						map[string]interface{}{"type": "boolean"}),
+				),
+				"responses": map[string]interface{}{
+					"200": jsonResponse("Number of sessions revoked", objectSchema()),
+					"400": errorResponse("Neither a filter nor all=true given"),
+					"401": errorResponse("Invalid or missing admin token"),
+					"403": errorResponse("Admin endpoints disabled"),
+				},
+			},
+		},
+		"/mcp/sessions/stats": map[string]interface{}{
+			"get": jsonOperation("Session manager statistics", objectSchema()),
+		},
+		"/cache/stats": map[string]interface{}{
+			"get": withParameters(jsonOperation("Cache statistics", schemaRef("CacheStatistics")), []interface{}{
+				map[string]interface{}{
+					"name":        "by",
+					"in":          "query",
+					"description": "Break the statistics down by the first cache key segment, or by request priority (X-MCP-Priority)",
+					"schema":      map[string]interface{}{"type": "string", "enum": []interface{}{"prefix", "priority"}},
+				},
+			}),
+		},
+		"/export/health": map[string]interface{}{
+			"get": exportOperation("Download the sampled cluster health history", nil),
+		},
+		"/export/events": map[string]interface{}{
+			"get": exportOperation("Download events aggregated by reason and namespace", []interface{}{
+				map[string]interface{}{
+					"name":        "since",
+					"in":          "query",
+					"description": "Window to export, as a duration (1h) or RFC3339 time (default 1h)",
+					"schema":      map[string]interface{}{"type": "string"},
+				},
+				map[string]interface{}{
+					"name":        "namespace",
+					"in":          "query",
+					"description": "Only export events in this namespace",
+					"schema":      map[string]interface{}{"type": "string"},
+				},
+			}),
+		},
+		"/stream/health": map[string]interface{}{
+			"get": streamOperation("Stream cluster health samples as they are taken", nil),
+		},
+		"/stream/events": map[string]interface{}{
+			"get": streamOperation("Stream cluster events as the event history records them", []interface{}{
+				map[string]interface{}{
+					"name":        "namespace",
+					"in":          "query",
+					"description": "Only stream events in this namespace",
+					"schema":      map[string]interface{}{"type": "string"},
+				},
+			}),
+		},
+		"/mcp/stream/logs": map[string]interface{}{
+			"get": logStreamOperation(),
+		},
+		"/artifacts/{id}": map[string]interface{}{
+			"parameters": []interface{}{map[string]interface{}{
+				"name":     "id",
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			}},
+			"get": map[string]interface{}{
+				"summary": "Download a stored tool-result artifact",
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "The artifact, with the content type it was stored with",
+						"content": map[string]interface{}{
+							"*/*": map[string]interface{}{"schema": map[string]interface{}{"type": "string", "format": "binary"}},
+						},
+					},
+					"404": errorResponse("Artifact not found or expired, or artifacts are disabled"),
+				},
+			},
+		},
+		"/admin/reload": map[string]interface{}{
+			"post": map[string]interface{}{
+				"summary":  "Reload the configuration file (safe subset of keys)",
+				"security": []interface{}{map[string]interface{}{"adminToken": []interface{}{}}},
+				"responses": map[string]interface{}{
+					"200": jsonResponse("Reload result", objectSchema()),
+					"401": errorResponse("Invalid or missing admin token"),
+					"403": errorResponse("Admin endpoints disabled"),
+					"422": jsonResponse("Reload failed validation", objectSchema()),
+				},
+			},
+		},
+	}
+
+	// One call path per tool so that each request schema is the tool's own InputSchema
+	registered := s.GetTools()
+	toolNames := make([]string, 0, len(registered))
+	for name := range registered {
+		toolNames = append(toolNames, name)
+	}
+	sort.Strings(toolNames)
+
+	for _, name := range toolNames {
+		tool := registered[name]
+		paths["/mcp/tools/"+name+"/call"] = toolCallPath(tool, name, tool.Description())
+		// Aliases are callable too, and marked deprecated
+		for _, alias := range toolAliases(tool) {
+			path := toolCallPath(tool, alias.Name, aliasDescription(tool, alias))
+			path["post"].(map[string]interface{})["deprecated"] = true
+			paths["/mcp/tools/"+alias.Name+"/call"] = path
+		}
+	}
+
+	// Resources share one read endpoint; the registered URIs are listed as an enum
+	registeredResources := s.GetResources()
+	resourceURIs := make([]string, 0, len(registeredResources))
+	for uri := range registeredResources {
+		resourceURIs = append(resourceURIs, uri)
+	}
+	sort.Strings(resourceURIs)
+
+	uriEnum := make([]interface{}, 0, len(resourceURIs))
+	resourceDocs := ""
+	for _, uri := range resourceURIs {
+		uriEnum = append(uriEnum, uri)
+		if res, ok := registeredResources[uri].(describedResource); ok {
+			resourceDocs += "\n- `" + uri + "`: " + res.Description()
+		}
+	}
+
+	uriSchema := map[string]interface{}{"type": "string"}
+	if len(uriEnum) > 0 {
+		uriSchema["enum"] = uriEnum
+	}
+
+	resourceRead := func(method string) map[string]interface{} {
+		return map[string]interface{}{
+			"operationId": method + "-resource",
+			"summary":     "Read a resource",
+			"description": "The URI must be URL-encoded in the path." + resourceDocs,
+			"tags":        []interface{}{"resources"},
+			"parameters": append(sessionIDParams(), map[string]interface{}{
+				"name":        "max_age_seconds",
+				"in":          "query",
+				"description": "Re-read if the cached data is older than this many seconds (cluster://nodes only)",
+				"schema":      map[string]interface{}{"type": "integer", "minimum": 0},
+			}, map[string]interface{}{
+				"name":        "Accept",
+				"in":          "header",
+				"description": "application/json (default), application/yaml for the content alone as YAML, or text/plain for a compact summary; other types get JSON with a Warning header",
+				"schema":      map[string]interface{}{"type": "string"},
+			}, map[string]interface{}{
+				"name":        "If-None-Match",
+				"in":          "header",
+				"description": "ETag of a previous read; answered with 304 while the content is unchanged",
+				"schema":      map[string]interface{}{"type": "string"},
+			}),
+			"responses": map[string]interface{}{
+				"200": withETag(resourceReadResponse()),
+				"304": withETag(map[string]interface{}{"description": "Content unchanged since the If-None-Match ETag"}),
+				"400": errorResponse("Session ID or URI missing, or invalid max_age_seconds"),
+				"401": errorResponse("Invalid or expired session"),
+				"404": errorResponse("Resource not found"),
+				"503": errorResponse("The resource's integration is currently unavailable (error_class integration_unavailable, retryable)"),
+				"500": errorResponse("Resource read failed"),
+			},
+		}
+	}
+	paths["/mcp/resources/{uri}/read"] = map[string]interface{}{
+		"parameters": []interface{}{map[string]interface{}{
+			"name":     "uri",
+			"in":       "path",
+			"required": true,
+			"schema":   uriSchema,
+		}},
+		"get":  resourceRead("get"),
+		"post": resourceRead("post"),
+	}
+	paths["/mcp/resources/{uri}/metadata"] = map[string]interface{}{
+		"parameters": []interface{}{map[string]interface{}{
+			"name":     "uri",
+			"in":       "path",
+			"required": true,
+			"schema":   uriSchema,
+		}},
+		"get": map[string]interface{}{
+			"operationId": "get-resource-metadata",
+			"summary":     "Report whether a resource changed, without its content",
+			"tags":        []interface{}{"resources"},
+			"parameters": append(sessionIDParams(),
+				queryParam("known_hash", "content_hash of a previous read; changed is false while it still matches", map[string]interface{}{"type": "string"}),
+			),
+			"responses": map[string]interface{}{
+				"200": withETag(jsonResponse("Resource metadata", schemaRef("ResourceMetadataResponse"))),
+				"400": errorResponse("Session ID or URI missing"),
+				"401": errorResponse("Invalid or expired session"),
+				"404": errorResponse("Resource not found"),
+				"503": errorResponse("The resource's integration is currently unavailable (error_class integration_unavailable, retryable)"),
+				"500": errorResponse("Resource read failed"),
+			},
+		},
+	}
+
+	return map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":       s.config.Name,
+			"version":     s.config.Version,
+			"description": "REST surface of the OpenShift Cluster Health MCP server",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"Error": map[string]interface{}{
+					"type":     "object",
+					"required": []interface{}{"success", "error"},
+					"properties": map[string]interface{}{
+						"success": map[string]interface{}{"type": "boolean"},
+						"error":   map[string]interface{}{"type": "string"},
+						"error_class": map[string]interface{}{
+							"type":        "string",
+							"description": "Failure class of a tool call",
+							"enum":        []interface{}{"invalid_arguments", "forbidden", "not_found", "busy", "upstream_unavailable", "integration_unavailable", "timeout", "internal"},
+						},
+						"retryable": map[string]interface{}{
+							"type":        "boolean",
+							"description": "Whether repeating the tool call unchanged may succeed",
+						},
+					},
+				},
+				"ToolCallResponse": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"success":    map[string]interface{}{"type": "boolean"},
+						"tool":       map[string]interface{}{"type": "string"},
+						"session_id": map[string]interface{}{"type": "string"},
+						"result":     map[string]interface{}{},
+						"_meta":      schemaRef("ResponseMeta"),
+					},
+				},
+				"ResponseMeta": map[string]interface{}{
+					"type":        "object",
+					"description": "Where the result's data came from and how long it may be reused",
+					"properties": map[string]interface{}{
+						"source":                        map[string]interface{}{"type": "string", "enum": []interface{}{"cache", "live"}},
+						"data_age_seconds":              map[string]interface{}{"type": "number"},
+						"revalidate_after_seconds":      map[string]interface{}{"type": "integer", "description": "0 means the result should not be reused"},
+						"volatility":                    map[string]interface{}{"type": "string", "enum": []interface{}{"static", "slow", "fast", "realtime"}},
+						"cluster":                       map[string]interface{}{"type": "string", "description": "API server the data came from"},
+						"throttled":                     map[string]interface{}{"type": "boolean", "description": "Set when the call was rate limited; slow down"},
+						"suggested_retry_after_seconds": map[string]interface{}{"type": "integer", "description": "Delay to wait before calling again when throttled"},
+						"deprecation": map[string]interface{}{
+							"type":        "object",
+							"description": "Set when the tool was called through a deprecated alias",
+							"properties": map[string]interface{}{
+								"alias":   map[string]interface{}{"type": "string"},
+								"tool":    map[string]interface{}{"type": "string", "description": "Name to call instead"},
+								"sunset":  map[string]interface{}{"type": "string", "format": "date"},
+								"message": map[string]interface{}{"type": "string"},
+							},
+						},
+					},
+				},
+				"ResourceReadResponse": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"success":    map[string]interface{}{"type": "boolean"},
+						"uri":        map[string]interface{}{"type": "string"},
+						"session_id": map[string]interface{}{"type": "string"},
+						"content":    map[string]interface{}{"type": "string"},
+					},
+				},
+				"MCPInfoResponse": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name":            map[string]interface{}{"type": "string"},
+						"version":         map[string]interface{}{"type": "string"},
+						"transport":       map[string]interface{}{"type": "string"},
+						"tools_count":     map[string]interface{}{"type": "integer"},
+						"resources_count": map[string]interface{}{"type": "integer"},
+						"build": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"version":    map[string]interface{}{"type": "string"},
+								"commit":     map[string]interface{}{"type": "string"},
+								"build_date": map[string]interface{}{"type": "string"},
+								"go_version": map[string]interface{}{"type": "string"},
+								"modified":   map[string]interface{}{"type": "boolean"},
+							},
+						},
+						"integrations": map[string]interface{}{
+							"type":                 "object",
+							"additionalProperties": map[string]interface{}{"type": "boolean"},
+						},
+						"unavailable_integrations": map[string]interface{}{
+							"type":                 "object",
+							"additionalProperties": map[string]interface{}{"type": "string"},
+						},
+						"read_only": map[string]interface{}{"type": "boolean"},
+						"coordination_engine": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"url":        map[string]interface{}{"type": "string"},
+								"checked_at": map[string]interface{}{"type": "string", "format": "date-time"},
+								"reachable":  map[string]interface{}{"type": "boolean"},
+								"stage":      map[string]interface{}{"type": "string", "enum": []string{"dns", "connect", "tls", "timeout", "http"}},
+								"error":      map[string]interface{}{"type": "string"},
+							},
+						},
+						"cluster": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"server_version": map[string]interface{}{"type": "string"},
+								"platform":       map[string]interface{}{"type": "string", "enum": []string{"openshift", "kubernetes"}},
+							},
+						},
+						"cluster_error": map[string]interface{}{"type": "string"},
+						"platform": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"platform": map[string]interface{}{"type": "string", "enum": []string{"openshift", "kubernetes", "unknown"}},
+								"api_groups": map[string]interface{}{
+									"type":                 "object",
+									"additionalProperties": map[string]interface{}{"type": "boolean"},
+								},
+								"detection_error": map[string]interface{}{"type": "string"},
+								"skipped_tools": map[string]interface{}{
+									"type": "array",
+									"items": map[string]interface{}{
+										"type": "object",
+										"properties": map[string]interface{}{
+											"name":           map[string]interface{}{"type": "string"},
+											"missing_groups": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"ResourceMetadataResponse": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"success":      map[string]interface{}{"type": "boolean"},
+						"uri":          map[string]interface{}{"type": "string"},
+						"content_hash": map[string]interface{}{"type": "string"},
+						"generated_at": map[string]interface{}{"type": "string", "format": "date-time"},
+						"changed":      map[string]interface{}{"type": "boolean"},
+					},
+				},
+				"SessionCreated": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"session_id":  map[string]interface{}{"type": "string"},
+						"created_at":  map[string]interface{}{"type": "string", "format": "date-time"},
+						"expires_at":  map[string]interface{}{"type": "string", "format": "date-time"},
+						"ttl_seconds": map[string]interface{}{"type": "integer"},
+					},
+				},
+				"CacheStatistics": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"hits":          map[string]interface{}{"type": "integer"},
+						"misses":        map[string]interface{}{"type": "integer"},
+						"negative_hits": map[string]interface{}{"type": "integer"},
+						"stale_hits":    map[string]interface{}{"type": "integer"},
+						"evictions":     map[string]interface{}{"type": "integer"},
+						"entries":       map[string]interface{}{"type": "integer"},
+						"hit_rate":      map[string]interface{}{"type": "number"},
+						"by_prefix": map[string]interface{}{
+							"type": "object",
+							"additionalProperties": map[string]interface{}{
+								"type": "object",
+								"properties": map[string]interface{}{
+									"hits":          map[string]interface{}{"type": "integer"},
+									"misses":        map[string]interface{}{"type": "integer"},
+									"negative_hits": map[string]interface{}{"type": "integer"},
+									"entries":       map[string]interface{}{"type": "integer"},
+									"hit_rate":      map[string]interface{}{"type": "number"},
+								},
+							},
+						},
+						"by_priority": map[string]interface{}{
+							"type": "object",
+							"additionalProperties": map[string]interface{}{
+								"type": "object",
+								"properties": map[string]interface{}{
+									"hits":       map[string]interface{}{"type": "integer"},
+									"misses":     map[string]interface{}{"type": "integer"},
+									"stale_hits": map[string]interface{}{"type": "integer"},
+									"hit_rate":   map[string]interface{}{"type": "number"},
+								},
+							},
+						},
+					},
+				},
+			},
+			"securitySchemes": map[string]interface{}{
+				"adminToken": map[string]interface{}{
+					"type":   "http",
+					"scheme": "bearer",
+				},
+			},
+		},
+	}
+}
+
+// refreshOpenAPISpec rebuilds the OpenAPI document from the current registries
+func (s *MCPServer) refreshOpenAPISpec() {
+	spec := s.buildOpenAPISpec()
+	s.registryMu.Lock()
+	s.openAPISpec = spec
+	s.registryMu.Unlock()
+}
+
+// handleOpenAPI serves the generated OpenAPI document
+// GET /openapi.json
+func (s *MCPServer) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed - use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.registryMu.RLock()
+	spec := s.openAPISpec
+	s.registryMu.RUnlock()
+	if spec == nil {
+		spec = s.buildOpenAPISpec()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := writeJSON(w, spec); err != nil {
+		log.Printf("Error writing OpenAPI response: %v", err)
+	}
+}
+
+// objectSchema is a schema for an arbitrary JSON object
+func objectSchema() map[string]interface{} {
+	return map[string]interface{}{"type": "object"}
+}
+
+// schemaRef references a schema under components/schemas
+func schemaRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// jsonResponse describes a JSON response body
+func jsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// resourceReadResponse describes the representations of a resource read
+func resourceReadResponse() map[string]interface{} {
+	response := jsonResponse("Resource content", schemaRef("ResourceReadResponse"))
+	content := response["content"].(map[string]interface{})
+	content[mimeYAML] = map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}
+	content[mimeText] = map[string]interface{}{"schema": map[string]interface{}{"type": "string"}}
+	return response
+}
+
+// withETag documents the ETag header of a response
+func withETag(response map[string]interface{}) map[string]interface{} {
+	response["headers"] = map[string]interface{}{
+		"ETag": map[string]interface{}{
+			"description": "Hash of the resource content, excluding timestamps",
+			"schema":      map[string]interface{}{"type": "string"},
+		},
+	}
+	return response
+}
+
+// errorResponse describes the standard {"success":false,"error":...} envelope
+func errorResponse(description string) map[string]interface{} {
+	return jsonResponse(description, schemaRef("Error"))
+}
+
+// jsonRequestBody describes a JSON request body
+func jsonRequestBody(schema map[string]interface{}, required bool) map[string]interface{} {
+	return map[string]interface{}{
+		"required": required,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// jsonOperation is a GET operation returning a JSON body
+func jsonOperation(summary string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"summary": summary,
+		"responses": map[string]interface{}{
+			"200": jsonResponse(summary, schema),
+		},
+	}
+}
+
+// withParameters adds query parameters to an operation
+func withParameters(operation map[string]interface{}, params []interface{}) map[string]interface{} {
+	operation["parameters"] = params
+	return operation
+}
+
+// exportOperation is a GET download available as JSON or CSV
+func exportOperation(summary string, params []interface{}) map[string]interface{} {
+	params = append(params, map[string]interface{}{
+		"name":   "format",
+		"in":     "query",
+		"schema": map[string]interface{}{"type": "string", "enum": []interface{}{"json", "csv"}, "default": "json"},
+	})
+	return map[string]interface{}{
+		"summary":    summary,
+		"parameters": params,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": summary,
+				"content": map[string]interface{}{
+					"application/json":        map[string]interface{}{"schema": map[string]interface{}{"type": "array", "items": objectSchema()}},
+					"text/csv; charset=utf-8": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+				},
+			},
+			"400": errorResponse("Invalid format or parameters"),
+		},
+	}
+}
+
+// streamOperation is a GET operation answering with server-sent events that
+// a client resumes with Last-Event-ID
+func streamOperation(summary string, params []interface{}) map[string]interface{} {
+	params = append(params,
+		map[string]interface{}{
+			"name":        "Last-Event-ID",
+			"in":          "header",
+			"description": "ID of the last event received; the events after it are replayed, or a resync event is sent",
+			"schema":      map[string]interface{}{"type": "string"},
+		},
+		map[string]interface{}{
+			"name":        "last_event_id",
+			"in":          "query",
+			"description": "Last-Event-ID for clients that cannot set headers",
+			"schema":      map[string]interface{}{"type": "string"},
+		})
+	return map[string]interface{}{
+		"summary":    summary,
+		"parameters": params,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": summary,
+				"content": map[string]interface{}{
+					"text/event-stream": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+				},
+			},
+			"404": errorResponse("The history the stream follows is disabled"),
+		},
+	}
+}
+
+// logStreamOperation is GET /mcp/stream/logs, which follows one container's
+// log and cannot resume, so takes no Last-Event-ID
+func logStreamOperation() map[string]interface{} {
+	param := func(name, description, kind string, required bool) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        name,
+			"in":          "query",
+			"required":    required,
+			"description": description,
+			"schema":      map[string]interface{}{"type": kind},
+		}
+	}
+	summary := "Follow a container's log: one log event per line, then an end event saying why the stream ended"
+	return map[string]interface{}{
+		"summary": summary,
+		"parameters": []interface{}{
+			param("namespace", "Namespace of the pod", "string", true),
+			param("pod", "Name of the pod", "string", true),
+			param("container", "Container to follow; required when the pod has several", "string", false),
+			param("since_seconds", "Start with the lines of the last N seconds (default: only new lines)", "integer", false),
+		},
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": summary,
+				"content": map[string]interface{}{
+					"text/event-stream": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+				},
+			},
+			"403": errorResponse("The namespace is not allowed"),
+			"404": errorResponse("Log streaming is disabled, or the pod does not exist"),
+			"429": errorResponse("Every log stream slot is in use"),
+		},
+	}
+}
+
+// textOperation is a GET operation returning a fixed plain-text body
+func textOperation(summary, body string) map[string]interface{} {
+	return map[string]interface{}{
+		"summary": summary,
+		"responses": map[string]interface{}{
+			"200": map[string]interface{}{
+				"description": "Returns " + body,
+				"content": map[string]interface{}{
+					"text/plain": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+				},
+			},
+		},
+	}
+}
+
+// queryParam is an optional query parameter
+func queryParam(name, description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        name,
+		"in":          "query",
+		"description": description,
+		"schema":      schema,
+	}
+}
+
+// sessionFilterParams select sessions by metadata for the admin session endpoints
+func sessionFilterParams() []interface{} {
+	return []interface{}{
+		queryParam("metadata_key", "Only sessions with this metadata key (e.g. user)", map[string]interface{}{"type": "string"}),
+		queryParam("metadata_value", "Only sessions whose metadata_key has this value", map[string]interface{}{"type": "string"}),
+	}
+}
+
+// sessionIDQueryParam is the sessionid query parameter
+func sessionIDQueryParam(required bool) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        "sessionid",
+		"in":          "query",
+		"required":    required,
+		"description": "Session ID from POST /mcp/session",
+		"schema":      map[string]interface{}{"type": "string"},
+	}
+}
+
+// sessionIDParams lists the accepted ways of passing a REST session ID
+func sessionIDParams() []interface{} {
+	return []interface{}{
+		sessionIDQueryParam(false),
+		map[string]interface{}{
+			"name":        "X-MCP-Session-ID",
+			"in":          "header",
+			"required":    false,
+			"description": "Alternative to the sessionid query parameter",
+			"schema":      map[string]interface{}{"type": "string"},
+		},
+	}
+}
+
+// toolCallPath documents calling tool under name
+func toolCallPath(tool Tool, name, summary string) map[string]interface{} {
+	return map[string]interface{}{
+		"post": map[string]interface{}{
+			"operationId": "call-" + name,
+			"summary":     summary,
+			"tags":        []interface{}{"tools"},
+			"parameters":  sessionIDParams(),
+			"requestBody": jsonRequestBody(toolInputSchema(tool), false),
+			"responses": map[string]interface{}{
+				"200": jsonResponse("Tool result", schemaRef("ToolCallResponse")),
+				"400": errorResponse("Session ID missing or invalid arguments (error_class invalid_arguments)"),
+				"401": errorResponse("Invalid or expired session"),
+				"403": errorResponse("Session of another user, read-only mode, a tool degraded for missing RBAC, or access denied upstream (error_class forbidden)"),
+				"404": errorResponse("Tool not found, or the object it reads does not exist (error_class not_found)"),
+				"429": errorResponse("No execution slot of the tool's weight class freed up in time (error_class busy, retryable)"),
+				"500": errorResponse("Tool execution failed (error_class internal)"),
+				"502": errorResponse("Kubernetes API or a dependency unavailable (error_class upstream_unavailable, retryable)"),
+				"503": errorResponse("The tool's integration is currently unavailable (error_class integration_unavailable, retryable)"),
+				"504": errorResponse("Upstream call timed out (error_class timeout, retryable)"),
+			},
+		},
+	}
+}
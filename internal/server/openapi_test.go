@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newFullRegistryServer registers every tool and resource without cluster access.
+// Clients are only stored by the constructors, so unconnected instances suffice.
+func newFullRegistryServer(t *testing.T) *MCPServer {
+	t.Helper()
+	cfg := NewConfig()
+
+	memoryCache := cache.NewMemoryCache(cfg.CacheTTL)
+	t.Cleanup(memoryCache.Close)
+
+	server := &MCPServer{
+		config:    cfg,
+		mcpServer: mcp.NewServer(&mcp.Implementation{Name: cfg.Name, Version: cfg.Version}, nil),
+		ceClient:  testutil.NewCoordinationEngineClient(t, "http://coordination-engine:8080"),
+		kserve:    &clients.KServeClient{},
+		cache:     memoryCache,
+		tools:     NewToolRegistry(),
+		resources: NewResourceRegistry(),
+		prompts:   make(map[string]interface{}),
+	}
+	server.liveConfig.Store(cfg)
+
+	require.NoError(t, server.registerTools())
+	require.NoError(t, server.registerResources())
+	return server
+}
+
+// loadOpenAPIDoc fetches /openapi.json and parses it with an OpenAPI 3 loader
+func loadOpenAPIDoc(t *testing.T, server *MCPServer) (*openapi3.T, []byte) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	server.handleOpenAPI(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	doc, err := openapi3.NewLoader().LoadFromData(w.Body.Bytes())
+	require.NoError(t, err)
+	return doc, w.Body.Bytes()
+}
+
+func TestOpenAPISpec_ValidatesAndCoversTools(t *testing.T) {
+	server := newFullRegistryServer(t)
+	require.NotZero(t, server.tools.Len())
+
+	doc, _ := loadOpenAPIDoc(t, server)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	for name, tool := range server.GetTools() {
+		item := doc.Paths.Find("/mcp/tools/" + name + "/call")
+		require.NotNil(t, item, "missing path for tool %s", name)
+		require.NotNil(t, item.Post)
+
+		// The request schema is the tool's own InputSchema
+		schema := item.Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+		properties, _ := tool.InputSchema()["properties"].(map[string]interface{})
+		for property := range properties {
+			assert.Contains(t, schema.Properties, property, "tool %s", name)
+		}
+	}
+
+	for _, path := range []string{
+		"/mcp", "/mcp/tools", "/mcp/resources", "/mcp/session", "/mcp/session/{sessionid}",
+		"/mcp/resources/{uri}/read", "/cache/stats", "/health", "/ready", "/export/health", "/export/events",
+	} {
+		assert.NotNil(t, doc.Paths.Find(path), "missing path %s", path)
+	}
+}
+
+func TestOpenAPISpec_ResourceURIs(t *testing.T) {
+	server := newFullRegistryServer(t)
+	doc, _ := loadOpenAPIDoc(t, server)
+
+	item := doc.Paths.Find("/mcp/resources/{uri}/read")
+	require.NotNil(t, item)
+	require.Len(t, item.Parameters, 1)
+
+	enum := item.Parameters[0].Value.Schema.Value.Enum
+	for uri := range server.GetResources() {
+		assert.Contains(t, enum, uri)
+	}
+}
+
+func TestOpenAPISpec_FollowsToolSelection(t *testing.T) {
+	cfg := NewConfig()
+	cfg.DisabledTools = []string{"trigger-*"}
+	server := newStubToolServer(t, cfg, "get-cluster-health", "trigger-remediation")
+
+	_, body := loadOpenAPIDoc(t, server)
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &spec))
+	paths := spec["paths"].(map[string]interface{})
+	assert.Contains(t, paths, "/mcp/tools/get-cluster-health/call")
+	assert.NotContains(t, paths, "/mcp/tools/trigger-remediation/call")
+}
+
+func TestHandleOpenAPI_MethodNotAllowed(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+
+	w := httptest.NewRecorder()
+	server.handleOpenAPI(w, httptest.NewRequest(http.MethodPost, "/openapi.json", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
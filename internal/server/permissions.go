@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// permissionCheckTimeout bounds one RBAC self-check
+const permissionCheckTimeout = 30 * time.Second
+
+// missingPermissionsError is returned for calls of a tool the RBAC self-check
+// found degraded, before the tool runs into Forbidden inside client-go
+type missingPermissionsError struct {
+	tool    string
+	missing []tools.PermissionRule
+}
+
+func (e *missingPermissionsError) Error() string {
+	return fmt.Sprintf("tool %s is unavailable: %s; call check-permissions for a Role granting it", e.tool, missingRBACReason(e.missing))
+}
+
+// Unwrap classifies the error as forbidden
+func (e *missingPermissionsError) Unwrap() error {
+	return tools.ErrForbidden
+}
+
+// missingRBACReason describes missing rules, e.g. "missing RBAC: list nodes (cluster-wide)"
+func missingRBACReason(missing []tools.PermissionRule) string {
+	rules := make([]string, 0, len(missing))
+	for _, rule := range missing {
+		rules = append(rules, rule.String())
+	}
+	return "missing RBAC: " + strings.Join(rules, ", ")
+}
+
+// toolPermissionRequirements collects the declared RBAC rules of registered tools
+func (s *MCPServer) toolPermissionRequirements() map[string][]tools.PermissionRule {
+	requirements := make(map[string][]tools.PermissionRule)
+	for name, tool := range s.GetTools() {
+		requirer, ok := tool.(tools.PermissionRequirer)
+		if !ok {
+			continue
+		}
+		if rules := requirer.RequiredPermissions(); len(rules) > 0 {
+			requirements[name] = rules
+		}
+	}
+	return requirements
+}
+
+// watchPermissions runs the RBAC self-check at startup and then every
+// interval until ctx is done, so tools degraded for missing RBAC become
+// available again once it is granted; an interval of 0 checks only once
+func (s *MCPServer) watchPermissions(ctx context.Context, interval time.Duration) {
+	s.checkPermissions(ctx)
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.checkPermissions(ctx)
+		}
+	}
+}
+
+// checkPermissions runs the RBAC self-check and updates the degraded tools.
+// The first successful check logs every tool that is not fully usable; later
+// ones only log tools changing state. A failed check leaves the degraded
+// tools as they were.
+func (s *MCPServer) checkPermissions(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, permissionCheckTimeout)
+	defer cancel()
+
+	report, err := s.permissions.Check(ctx, true)
+	if err != nil {
+		log.Printf("WARNING: RBAC self-check failed: %v", err)
+		return
+	}
+
+	if s.degradedTools.Load() == nil {
+		logPermissionSummary(report)
+	}
+	s.applyPermissionReport(report)
+}
+
+// logPermissionSummary logs tools that are not fully usable
+func logPermissionSummary(report *tools.PermissionReport) {
+	log.Printf("RBAC self-check: %d tools usable, %d partially usable, %d blocked",
+		report.Usable, report.Partial, report.Blocked)
+	for _, status := range report.Tools {
+		if status.Status == tools.PermissionStatusUsable {
+			continue
+		}
+		log.Printf("WARNING: tool %s is %s: %s", status.Tool, status.Status, missingRBACReason(status.Missing))
+	}
+	if report.Blocked+report.Partial > 0 {
+		log.Printf("Call the check-permissions tool for a ready-to-apply Role snippet")
+	}
+}
+
+// applyPermissionReport marks the tools the report found blocked as degraded
+// and promotes the others back to available. Partially permitted tools stay
+// available: several use their optional permissions only when granted. So do
+// namespace-scoped tools under ALLOWED_NAMESPACES, which list namespace by
+// namespace and may work without the cluster-wide access that was checked.
+func (s *MCPServer) applyPermissionReport(report *tools.PermissionReport) {
+	namespaced := len(s.currentConfig().AllowedNamespaces) > 0
+	degraded := make(map[string][]tools.PermissionRule)
+	for _, status := range report.Tools {
+		if status.Status != tools.PermissionStatusBlocked {
+			continue
+		}
+		if tool, ok := s.lookupTool(status.Tool); ok && namespaced && isNamespaceScoped(tool) {
+			continue
+		}
+		degraded[status.Tool] = status.Missing
+	}
+
+	previous := s.degradedTools.Swap(&degraded)
+	if previous == nil {
+		return // checkPermissions logged the summary
+	}
+	for name, missing := range degraded {
+		if _, was := (*previous)[name]; !was {
+			log.Printf("WARNING: tool %s is now unavailable: %s", name, missingRBACReason(missing))
+		}
+	}
+	for name := range *previous {
+		if _, still := degraded[name]; !still {
+			log.Printf("Tool %s is available again: its RBAC permissions were granted", name)
+		}
+	}
+}
+
+// toolDegradation returns the rules a degraded tool is missing, or nil when
+// the tool is available or RBAC has not been checked yet
+func (s *MCPServer) toolDegradation(name string) []tools.PermissionRule {
+	degraded := s.degradedTools.Load()
+	if degraded == nil {
+		return nil
+	}
+	return slices.Clone((*degraded)[name])
+}
+
+// checkToolPermissions refuses calls of a tool degraded for missing RBAC
+func (s *MCPServer) checkToolPermissions(tool Tool) error {
+	if missing := s.toolDegradation(tool.Name()); len(missing) > 0 {
+		return &missingPermissionsError{tool: tool.Name(), missing: missing}
+	}
+	return nil
+}
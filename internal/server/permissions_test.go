@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// permissionedTool is a stub tool declaring RBAC requirements
+type permissionedTool struct {
+	stubTool
+	rules      []tools.PermissionRule
+	namespaced bool
+}
+
+func (t *permissionedTool) RequiredPermissions() []tools.PermissionRule { return t.rules }
+func (t *permissionedTool) NamespaceScoped() bool                       { return t.namespaced }
+
+// sarResponder answers SelfSubjectAccessReviews from a set of allowed rules
+// that tests change between checks, or fails them all while err is set
+type sarResponder struct {
+	mu      sync.Mutex
+	allowed map[tools.PermissionRule]bool
+	err     error
+}
+
+func (r *sarResponder) allow(rule tools.PermissionRule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.allowed[rule] = true
+}
+
+func (r *sarResponder) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+}
+
+func (r *sarResponder) react(action k8stesting.Action) (bool, runtime.Object, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return true, nil, r.err
+	}
+	review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+	attrs := review.Spec.ResourceAttributes
+	review.Status.Allowed = r.allowed[tools.PermissionRule{
+		Group:       attrs.Group,
+		Resource:    attrs.Resource,
+		Subresource: attrs.Subresource,
+		Verb:        attrs.Verb,
+		Namespace:   attrs.Namespace,
+	}]
+	return true, review, nil
+}
+
+var (
+	listNodesRule = tools.PermissionRule{Resource: "nodes", Verb: "list"}
+	listPodsRule  = tools.PermissionRule{Resource: "pods", Verb: "list"}
+)
+
+// newPermissionServer builds a server whose RBAC self-check is answered by a
+// sarResponder allowing the given rules
+func newPermissionServer(t *testing.T, cfg *Config, allowed ...tools.PermissionRule) (*MCPServer, *sarResponder) {
+	t.Helper()
+	responder := &sarResponder{allowed: make(map[tools.PermissionRule]bool)}
+	for _, rule := range allowed {
+		responder.allow(rule)
+	}
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", responder.react)
+
+	server := newStubToolServer(t, cfg)
+	server.permissions = tools.NewCheckPermissionsTool(clients.NewK8sClientWithClientset(clientset), server.toolPermissionRequirements)
+	server.registerTool(server.permissions)
+	server.registerTool(&permissionedTool{stubTool: stubTool{name: "get-nodes"}, rules: []tools.PermissionRule{listNodesRule}})
+	server.registerTool(&permissionedTool{stubTool: stubTool{name: "get-workloads"}, rules: []tools.PermissionRule{listNodesRule, listPodsRule}})
+	server.registerTool(&permissionedTool{stubTool: stubTool{name: "list-pods"}, rules: []tools.PermissionRule{listPodsRule}, namespaced: true})
+	return server, responder
+}
+
+// listedTools returns the tools of /mcp/tools by name
+func listedTools(t *testing.T, server *MCPServer) map[string]map[string]interface{} {
+	t.Helper()
+	w := httptest.NewRecorder()
+	server.handleListTools(w, httptest.NewRequest(http.MethodGet, "/mcp/tools", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list struct {
+		Tools []map[string]interface{} `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	byName := make(map[string]map[string]interface{}, len(list.Tools))
+	for _, tool := range list.Tools {
+		byName[tool["name"].(string)] = tool
+	}
+	return byName
+}
+
+func TestPermissionCheck_DegradesAndRecovers(t *testing.T) {
+	ctx := context.Background()
+	server, responder := newPermissionServer(t, NewConfig(), listPodsRule)
+
+	// Before the first check every tool is available
+	assert.Equal(t, true, listedTools(t, server)["get-nodes"]["available"])
+
+	server.checkPermissions(ctx)
+
+	listed := listedTools(t, server)
+	assert.Equal(t, false, listed["get-nodes"]["available"])
+	assert.Equal(t, "missing RBAC: list nodes (cluster-wide)", listed["get-nodes"]["reason"])
+	assert.Equal(t, true, listed["get-workloads"]["available"], "partially permitted tools stay available")
+	assert.NotContains(t, listed["get-workloads"], "reason")
+	assert.Equal(t, true, listed["check-permissions"]["available"])
+
+	nodesTool, _ := server.lookupTool("get-nodes")
+	_, _, err := server.executeTool(ctx, nodesTool, map[string]interface{}{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, tools.ErrForbidden)
+	assert.Contains(t, err.Error(), "missing RBAC: list nodes (cluster-wide)")
+	assert.Equal(t, http.StatusForbidden, classifyToolError(err).status)
+
+	// A failing re-check keeps the tool degraded rather than guessing
+	responder.fail(errors.New("connection refused"))
+	server.checkPermissions(ctx)
+	assert.Equal(t, false, listedTools(t, server)["get-nodes"]["available"])
+
+	// Granting the permission promotes the tool on the next check, without a restart
+	responder.fail(nil)
+	responder.allow(listNodesRule)
+	server.checkPermissions(ctx)
+
+	assert.Equal(t, true, listedTools(t, server)["get-nodes"]["available"])
+	result, _, err := server.executeTool(ctx, nodesTool, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"tool": "get-nodes"}, result)
+}
+
+func TestPermissionCheck_NamespaceScopedToolsUnderAllowList(t *testing.T) {
+	cfg := NewConfig()
+	server, _ := newPermissionServer(t, cfg)
+	server.checkPermissions(context.Background())
+	assert.Equal(t, false, listedTools(t, server)["list-pods"]["available"])
+
+	// With ALLOWED_NAMESPACES the tool lists per namespace; cluster-wide access was not needed
+	cfg = NewConfig()
+	cfg.AllowedNamespaces = []string{"shop"}
+	server, _ = newPermissionServer(t, cfg)
+	server.checkPermissions(context.Background())
+
+	listed := listedTools(t, server)
+	assert.Equal(t, true, listed["list-pods"]["available"])
+	assert.Equal(t, false, listed["get-nodes"]["available"])
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// API groups of the OpenShift features that tools depend on
+const (
+	routeAPIGroup         = "route.openshift.io"
+	machineConfigAPIGroup = "machineconfiguration.openshift.io"
+	olmAPIGroup           = "operators.coreos.com"
+)
+
+// platformAPIGroups are the groups reported by /mcp/info and the startup log
+var platformAPIGroups = []string{clients.OpenShiftAPIGroup, routeAPIGroup, machineConfigAPIGroup, olmAPIGroup}
+
+// platformUnknown is reported until API discovery first succeeds
+const platformUnknown = "unknown"
+
+// PlatformInfo is the detected platform as reported by /mcp/info
+type PlatformInfo struct {
+	Platform       string          `json:"platform"`   // openshift, kubernetes, or unknown until discovery succeeds
+	APIGroups      map[string]bool `json:"api_groups"` // Whether each of platformAPIGroups is served
+	DetectionError string          `json:"detection_error,omitempty"`
+	SkippedTools   []SkippedTool   `json:"skipped_tools,omitempty"`
+}
+
+// SkippedTool is a tool held back until the cluster serves its API groups
+type SkippedTool struct {
+	Name          string   `json:"name"`
+	MissingGroups []string `json:"missing_groups"`
+}
+
+// gatedTool is a tool waiting for the API groups it needs
+type gatedTool struct {
+	tool   Tool
+	groups []string
+}
+
+// platformState holds the API groups found by discovery and the tools
+// deferred because their groups were missing. The zero value is a platform
+// that has not been detected yet.
+type platformState struct {
+	mu      sync.Mutex
+	groups  map[string]bool // nil until discovery first succeeds
+	err     error           // Last discovery failure, cleared by a success
+	pending []gatedTool
+}
+
+// detect refreshes the served API groups. A failure keeps the groups of the
+// last successful discovery.
+func (p *platformState) detect(ctx context.Context, client *clients.K8sClient) error {
+	var groups map[string]bool
+	err := errors.New("no Kubernetes client configured")
+	if client != nil {
+		groups, err = client.APIGroups(ctx)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+	if err == nil {
+		p.groups = groups
+	}
+	return err
+}
+
+// served reports whether every group is served. Nothing is served until
+// discovery has succeeded once.
+func (p *platformState) served(groups ...string) bool {
+	return len(p.missing(groups)) == 0
+}
+
+// missing returns the groups that are not served
+func (p *platformState) missing(groups []string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var missing []string
+	for _, group := range groups {
+		if !p.groups[group] {
+			missing = append(missing, group)
+		}
+	}
+	return missing
+}
+
+// deferTool records a tool to register once its groups are served
+func (p *platformState) deferTool(gated gatedTool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = append(p.pending, gated)
+}
+
+// hasPending reports whether any tool is still waiting for its groups
+func (p *platformState) hasPending() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pending) > 0
+}
+
+// takeReady removes and returns the deferred tools whose groups are now served
+func (p *platformState) takeReady() []gatedTool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var ready, waiting []gatedTool
+	for _, gated := range p.pending {
+		if p.servesLocked(gated.groups) {
+			ready = append(ready, gated)
+		} else {
+			waiting = append(waiting, gated)
+		}
+	}
+	p.pending = waiting
+	return ready
+}
+
+func (p *platformState) servesLocked(groups []string) bool {
+	for _, group := range groups {
+		if !p.groups[group] {
+			return false
+		}
+	}
+	return true
+}
+
+// info reports the detected platform and the tools still deferred
+func (p *platformState) info() PlatformInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info := PlatformInfo{Platform: platformUnknown, APIGroups: make(map[string]bool, len(platformAPIGroups))}
+	for _, group := range platformAPIGroups {
+		info.APIGroups[group] = p.groups[group]
+	}
+	if p.groups != nil {
+		info.Platform = clients.PlatformKubernetes
+		if p.groups[clients.OpenShiftAPIGroup] {
+			info.Platform = clients.PlatformOpenShift
+		}
+	}
+	if p.err != nil {
+		info.DetectionError = p.err.Error()
+	}
+	for _, gated := range p.pending {
+		skipped := SkippedTool{Name: gated.tool.Name()}
+		for _, group := range gated.groups {
+			if !p.groups[group] {
+				skipped.MissingGroups = append(skipped.MissingGroups, group)
+			}
+		}
+		info.SkippedTools = append(info.SkippedTools, skipped)
+	}
+	slices.SortFunc(info.SkippedTools, func(a, b SkippedTool) int { return strings.Compare(a.Name, b.Name) })
+	return info
+}
+
+// registerToolIfServed registers tool when the cluster serves all of groups.
+// Otherwise the tool is deferred until a capability probe sees the groups
+// appear, e.g. after OLM is installed or a failed discovery succeeds.
+func (s *MCPServer) registerToolIfServed(tool Tool, groups ...string) {
+	if !s.config.IsToolEnabled(tool.Name()) {
+		s.registerTool(tool) // Logs the skip
+		return
+	}
+	if missing := s.platform.missing(groups); len(missing) > 0 {
+		s.platform.deferTool(gatedTool{tool: tool, groups: groups})
+		log.Printf("Skipping tool: %s (API groups not served: %s)", tool.Name(), strings.Join(missing, ", "))
+		return
+	}
+	s.registerTool(tool)
+}
+
+// logPlatform reports the detected platform and the tools deferred at startup
+func (s *MCPServer) logPlatform() {
+	info := s.platform.info()
+	groups := make([]string, 0, len(platformAPIGroups))
+	for _, group := range platformAPIGroups {
+		if info.APIGroups[group] {
+			groups = append(groups, group)
+		}
+	}
+	log.Printf("Detected platform: %s (platform API groups served: [%s])", info.Platform, strings.Join(groups, ", "))
+	if info.DetectionError != "" {
+		log.Printf("WARNING: API discovery failed, retrying with each capability probe: %s", info.DetectionError)
+	}
+	for _, skipped := range info.SkippedTools {
+		log.Printf("Deferred tool %s until API groups are served: %s", skipped.Name, strings.Join(skipped.MissingGroups, ", "))
+	}
+}
+
+// registerServedTools repeats discovery and registers the deferred tools
+// whose API groups are now served. It returns how many were registered.
+func (s *MCPServer) registerServedTools(ctx context.Context) int {
+	if err := s.platform.detect(ctx, s.k8sClient); err != nil {
+		log.Printf("WARNING: API discovery failed, deferred tools stay unregistered: %v", err)
+		return 0
+	}
+
+	ready := s.platform.takeReady()
+	for _, gated := range ready {
+		log.Printf("API groups %s are now served", strings.Join(gated.groups, ", "))
+		s.registerTool(gated.tool)
+	}
+	if len(ready) > 0 {
+		s.refreshOpenAPISpec()
+	}
+	return len(ready)
+}
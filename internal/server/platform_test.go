@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newPlatformServer serves the given API groups; discovery fails while failing is set
+func newPlatformServer(t *testing.T, cfg *Config, groupVersions ...string) (*MCPServer, *fake.Clientset, *atomic.Bool) {
+	t.Helper()
+	clientset := fake.NewClientset()
+	for _, groupVersion := range groupVersions {
+		clientset.Resources = append(clientset.Resources, &metav1.APIResourceList{GroupVersion: groupVersion})
+	}
+	failing := &atomic.Bool{}
+	clientset.PrependReactor("get", "group", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if failing.Load() {
+			return true, nil, errors.New("the server is currently unable to handle the request")
+		}
+		return false, nil, nil
+	})
+
+	server := newStubToolServer(t, cfg)
+	server.k8sClient = clients.NewK8sClientWithClientset(clientset)
+	return server, clientset, failing
+}
+
+func platformInfo(t *testing.T, server *MCPServer) PlatformInfo {
+	t.Helper()
+	w := httptest.NewRecorder()
+	server.handleMCPInfo(w, httptest.NewRequest(http.MethodGet, "/mcp/info", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var info MCPInfoResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	return info.Platform
+}
+
+func TestPlatformDetection(t *testing.T) {
+	tests := []struct {
+		name          string
+		groupVersions []string
+		want          string
+	}{
+		{name: "vanilla Kubernetes", groupVersions: []string{"apps/v1"}, want: clients.PlatformKubernetes},
+		{name: "OpenShift", groupVersions: []string{"apps/v1", "config.openshift.io/v1", "route.openshift.io/v1"}, want: clients.PlatformOpenShift},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _, _ := newPlatformServer(t, NewConfig(), tt.groupVersions...)
+			assert.Equal(t, platformUnknown, platformInfo(t, server).Platform, "nothing detected yet")
+
+			require.NoError(t, server.platform.detect(context.Background(), server.k8sClient))
+			info := platformInfo(t, server)
+			assert.Equal(t, tt.want, info.Platform)
+			assert.Len(t, info.APIGroups, len(platformAPIGroups))
+			assert.Equal(t, tt.want == clients.PlatformOpenShift, info.APIGroups[routeAPIGroup])
+			assert.False(t, info.APIGroups[olmAPIGroup])
+		})
+	}
+}
+
+func TestRegisterToolIfServed_DefersUntilGroupAppears(t *testing.T) {
+	cfg := NewConfig()
+	cfg.DisabledTools = []string{"get-machine-configs"}
+	server, clientset, _ := newPlatformServer(t, cfg, "apps/v1", "config.openshift.io/v1")
+	require.NoError(t, server.platform.detect(context.Background(), server.k8sClient))
+
+	server.registerToolIfServed(&stubTool{name: "get-cluster-operators"}, clients.OpenShiftAPIGroup)
+	server.registerToolIfServed(&stubTool{name: "list-operator-subscriptions"}, clients.OpenShiftAPIGroup, olmAPIGroup)
+	server.registerToolIfServed(&stubTool{name: "get-machine-configs"}, machineConfigAPIGroup)
+
+	_, ok := server.lookupTool("get-cluster-operators")
+	assert.True(t, ok)
+	_, ok = server.lookupTool("list-operator-subscriptions")
+	assert.False(t, ok)
+	assert.Equal(t, []SkippedTool{{Name: "list-operator-subscriptions", MissingGroups: []string{olmAPIGroup}}},
+		platformInfo(t, server).SkippedTools, "tools disabled by configuration are not deferred")
+
+	// Nothing changes while the group is still missing
+	assert.Zero(t, server.registerServedTools(context.Background()))
+
+	// OLM gets installed
+	clientset.Resources = append(clientset.Resources, &metav1.APIResourceList{GroupVersion: "operators.coreos.com/v1alpha1"})
+	assert.Equal(t, 1, server.registerServedTools(context.Background()))
+	assert.False(t, server.platform.hasPending())
+	assert.Empty(t, platformInfo(t, server).SkippedTools)
+
+	w := httptest.NewRecorder()
+	server.handleListTools(w, httptest.NewRequest(http.MethodGet, "/mcp/tools", nil))
+	assert.Contains(t, w.Body.String(), "list-operator-subscriptions")
+	assert.Contains(t, server.openAPISpec["paths"], "/mcp/tools/list-operator-subscriptions/call")
+}
+
+func TestProbeCapabilities_RegistersAfterDiscoveryRecovers(t *testing.T) {
+	server, _, failing := newPlatformServer(t, NewConfig(), "apps/v1", "config.openshift.io/v1")
+	failing.Store(true)
+
+	// Discovery is down at startup, so even OpenShift tools wait
+	require.Error(t, server.platform.detect(context.Background(), server.k8sClient))
+	server.registerToolIfServed(&stubTool{name: "get-network-health"}, clients.OpenShiftAPIGroup)
+	info := platformInfo(t, server)
+	assert.Equal(t, platformUnknown, info.Platform)
+	assert.NotEmpty(t, info.DetectionError)
+	require.Len(t, info.SkippedTools, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go server.probeCapabilities(ctx, 10*time.Millisecond)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Zero(t, server.toolCount(), "failed discovery registers nothing")
+
+	failing.Store(false)
+	require.Eventually(t, func() bool {
+		_, ok := server.lookupTool("get-network-health")
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, server.platform.hasPending())
+	info = platformInfo(t, server)
+	assert.Equal(t, clients.PlatformOpenShift, info.Platform)
+	assert.Empty(t, info.DetectionError)
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+)
+
+// priorityHeader selects the cache freshness of a request: interactive (the
+// default) or background, for pollers that tolerate older data
+const priorityHeader = "X-MCP-Priority"
+
+type priorityKey struct{}
+
+// withPriority stores the X-MCP-Priority of a request in its context and
+// refuses unknown values. MCP sessions over SSE or a WebSocket keep the
+// priority of the request that opened them.
+func withPriority(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		priority, err := cache.ParsePriority(r.Header.Get(priorityHeader))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, priorityHeader+": "+err.Error())
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), priorityKey{}, priority)))
+	})
+}
+
+// priorityFromContext returns the priority of the request, interactive
+// outside an HTTP request
+func priorityFromContext(ctx context.Context) cache.Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(cache.Priority); ok {
+		return priority
+	}
+	return cache.PriorityInteractive
+}
+
+// callContext returns the context of a tool call, with the request's priority
+// for its cache reads and the timeout (none when 0), and the function to call
+// once the call returns. Background calls serve stale values while they are
+// revalidated, so their context outlives the call, and the request, until the
+// revalidations are done, for at most the request timeout.
+func (s *MCPServer) callContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	priority := priorityFromContext(ctx)
+	if priority != cache.PriorityBackground {
+		ctx, _ = cache.WithPriority(ctx, priority)
+		if timeout > 0 {
+			return context.WithTimeout(ctx, timeout)
+		}
+		return context.WithCancel(ctx)
+	}
+
+	if timeout <= 0 {
+		timeout = s.currentConfig().RequestTimeout
+	}
+	detached, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	detached, revalidated := cache.WithPriority(detached, priority)
+	stop := context.AfterFunc(ctx, cancel) // The caller going away still cancels the call itself
+	return detached, func() {
+		stop()
+		go func() {
+			revalidated()
+			cancel()
+		}()
+	}
+}
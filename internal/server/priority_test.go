@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+)
+
+func TestWithPriority(t *testing.T) {
+	var seen cache.Priority
+	handler := withPriority(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = priorityFromContext(r.Context())
+	}))
+
+	for header, want := range map[string]cache.Priority{
+		"":            cache.PriorityInteractive,
+		"interactive": cache.PriorityInteractive,
+		"background":  cache.PriorityBackground,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/mcp/tools", nil)
+		req.Header.Set(priorityHeader, header)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, header)
+		assert.Equal(t, want, seen, header)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/mcp/tools", nil)
+	req.Header.Set(priorityHeader, "urgent")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "X-MCP-Priority")
+}
+
+func TestCallContext_BackgroundOutlivesRequestUntilRevalidated(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	memoryCache := cache.NewMemoryCacheWithClock(time.Minute, clk)
+	t.Cleanup(memoryCache.Close)
+	memoryCache.SetWithTTL("cluster-health", "stale", 20*time.Millisecond)
+	clk.Step(30 * time.Millisecond)
+
+	request, endRequest := context.WithCancel(context.WithValue(context.Background(), priorityKey{}, cache.PriorityBackground))
+	ctx, done := server.callContext(request, 0)
+	assert.Equal(t, cache.PriorityBackground, cache.PriorityFromContext(ctx))
+
+	proceed := make(chan struct{})
+	value, err := memoryCache.GetOrSetWithTTL(ctx, "cluster-health", time.Minute, func() (interface{}, error) {
+		<-proceed
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return "revalidated", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "stale", value, "served without waiting for the revalidation")
+
+	// The response is written and the request ends before the revalidation does
+	done()
+	endRequest()
+	close(proceed)
+
+	assert.Eventually(t, func() bool {
+		value, _ := memoryCache.Get("cluster-health")
+		return value == "revalidated"
+	}, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, 5*time.Millisecond, "released once revalidated")
+}
+
+func TestCallContext_Interactive(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+
+	ctx, done := server.callContext(context.Background(), time.Minute)
+	assert.Equal(t, cache.PriorityInteractive, cache.PriorityFromContext(ctx))
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	done()
+	assert.Error(t, ctx.Err())
+
+	// A background call is still cancelled when its caller goes away mid-call
+	request, endRequest := context.WithCancel(context.WithValue(context.Background(), priorityKey{}, cache.PriorityBackground))
+	ctx, done = server.callContext(request, 0)
+	defer done()
+	endRequest()
+	assert.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, time.Millisecond)
+}
+
+func TestCoalesceKey_SeparatesPriorities(t *testing.T) {
+	tool := &stubTool{name: "get-cluster-health"}
+	interactive, _ := cache.WithPriority(context.Background(), cache.PriorityInteractive)
+	background, _ := cache.WithPriority(context.Background(), cache.PriorityBackground)
+
+	assert.Equal(t, coalesceKey(context.Background(), tool, nil), coalesceKey(interactive, tool, nil))
+	assert.NotEqual(t, coalesceKey(interactive, tool, nil), coalesceKey(background, tool, nil))
+}
@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ErrAlreadyRegistered is returned when registering a tool or resource under
+// a name that is already taken
+var ErrAlreadyRegistered = errors.New("already registered")
+
+// ErrServerStarted is returned by RegisterTool and RegisterResource once Start
+// has been called
+var ErrServerStarted = errors.New("registration is closed once the server has started")
+
+// Resource is implemented by every resource served under /mcp/resources
+type Resource interface {
+	URI() string
+	Name() string
+	Description() string
+	MimeType() string
+	Read(ctx context.Context) (string, error)
+}
+
+// Registry is a set of named entries, safe for concurrent use. Tools and
+// resources are registered at startup and then come and go with the
+// integrations and API groups they depend on, while handlers read them.
+// An entry may also be reachable under aliases, which share the namespace of
+// the registered names.
+type Registry[T any] struct {
+	kind    string // "tool" or "resource", for errors
+	mu      sync.RWMutex
+	entries map[string]T
+	aliases map[string]string // Alias -> name of the entry it resolves to
+}
+
+// ToolRegistry holds the registered tools by name
+type ToolRegistry = Registry[Tool]
+
+// ResourceRegistry holds the registered resources by URI
+type ResourceRegistry = Registry[Resource]
+
+// NewToolRegistry returns an empty tool registry
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{kind: "tool", entries: make(map[string]Tool), aliases: make(map[string]string)}
+}
+
+// NewResourceRegistry returns an empty resource registry
+func NewResourceRegistry() *ResourceRegistry {
+	return &ResourceRegistry{kind: "resource", entries: make(map[string]Resource), aliases: make(map[string]string)}
+}
+
+// Register adds entry under name and its aliases, failing with
+// ErrAlreadyRegistered if any of them is taken by another entry or alias.
+// Either all names are registered or none is.
+func (r *Registry[T]) Register(name string, entry T, aliases ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.taken(name) {
+		return fmt.Errorf("%s %q is %w", r.kind, name, ErrAlreadyRegistered)
+	}
+	for i, alias := range aliases {
+		if alias == name || slices.Contains(aliases[:i], alias) || r.taken(alias) {
+			return fmt.Errorf("alias %q of %s %q is %w", alias, r.kind, name, ErrAlreadyRegistered)
+		}
+	}
+	r.entries[name] = entry
+	for _, alias := range aliases {
+		r.aliases[alias] = name
+	}
+	return nil
+}
+
+// taken reports whether name is registered as an entry or an alias
+func (r *Registry[T]) taken(name string) bool {
+	_, entry := r.entries[name]
+	_, alias := r.aliases[name]
+	return entry || alias
+}
+
+// Deregister removes the entry registered under name, along with its
+// aliases, and reports whether there was one
+func (r *Registry[T]) Deregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, exists := r.entries[name]
+	delete(r.entries, name)
+	for alias, target := range r.aliases {
+		if target == name {
+			delete(r.aliases, alias)
+		}
+	}
+	return exists
+}
+
+// Resolve returns the entry registered under name or aliased by it, and
+// the name it is registered under
+func (r *Registry[T]) Resolve(name string) (entry T, registeredName string, ok bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if target, isAlias := r.aliases[name]; isAlias {
+		name = target
+	}
+	entry, ok = r.entries[name]
+	return entry, name, ok
+}
+
+// Aliases returns a copy of the aliases, each mapped to the name it resolves to
+func (r *Registry[T]) Aliases() map[string]string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return maps.Clone(r.aliases)
+}
+
+// Get returns the entry registered under name; aliases are not resolved
+func (r *Registry[T]) Get(name string) (T, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, ok := r.entries[name]
+	return entry, ok
+}
+
+// Names returns the registered names in sorted order
+func (r *Registry[T]) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.entries))
+}
+
+// List returns the registered entries sorted by name
+func (r *Registry[T]) List() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]T, 0, len(r.entries))
+	for _, name := range slices.Sorted(maps.Keys(r.entries)) {
+		list = append(list, r.entries[name])
+	}
+	return list
+}
+
+// Snapshot returns a copy of the registry as a map
+func (r *Registry[T]) Snapshot() map[string]T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return maps.Clone(r.entries)
+}
+
+// Len returns the number of registered entries
+func (r *Registry[T]) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.entries)
+}
@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RejectsDuplicates(t *testing.T) {
+	registry := NewToolRegistry()
+	require.NoError(t, registry.Register("list-pods", &stubTool{name: "list-pods"}))
+
+	err := registry.Register("list-pods", &stubTool{name: "list-pods"})
+	assert.ErrorIs(t, err, ErrAlreadyRegistered)
+	assert.EqualError(t, err, `tool "list-pods" is already registered`)
+
+	assert.True(t, registry.Deregister("list-pods"))
+	assert.False(t, registry.Deregister("list-pods"))
+	assert.NoError(t, registry.Register("list-pods", &stubTool{name: "list-pods"}), "a deregistered name can be taken again")
+}
+
+func TestRegistry_SortedListing(t *testing.T) {
+	registry := NewToolRegistry()
+	for _, name := range []string{"list-pods", "analyze-anomalies", "get-cluster-health"} {
+		require.NoError(t, registry.Register(name, &stubTool{name: name}))
+	}
+
+	assert.Equal(t, []string{"analyze-anomalies", "get-cluster-health", "list-pods"}, registry.Names())
+	list := registry.List()
+	require.Len(t, list, 3)
+	assert.Equal(t, "analyze-anomalies", list[0].Name())
+	assert.Equal(t, 3, registry.Len())
+}
+
+func TestRegistry_ConcurrentReadAndRegister(t *testing.T) {
+	registry := NewToolRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				name := fmt.Sprintf("tool-%d-%d", i, j)
+				assert.NoError(t, registry.Register(name, &stubTool{name: name}))
+				if j%2 == 0 {
+					registry.Deregister(name)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				registry.Get(fmt.Sprintf("tool-%d-%d", i, j))
+				_ = registry.List()
+				_ = registry.Snapshot()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 8*25, registry.Len())
+}
+
+func TestRegisterTool_ExternalTool(t *testing.T) {
+	server := newStubToolServer(t, NewConfig(), "get-cluster-health")
+	external := &stubTool{name: "team-billing-report", result: map[string]int{"invoices": 3}}
+
+	require.NoError(t, server.RegisterTool(external))
+	assert.ErrorIs(t, server.RegisterTool(&stubTool{name: "get-cluster-health"}), ErrAlreadyRegistered, "built-in names are taken")
+
+	// Listed and documented like the built-in tools
+	w := authRequest(server, http.MethodGet, "/mcp/tools", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"team-billing-report"`)
+	w = authRequest(server, http.MethodGet, "/openapi.json", "", nil)
+	assert.Contains(t, w.Body.String(), "/mcp/tools/team-billing-report/call")
+
+	// Callable over REST
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+	w = authRequest(server, http.MethodPost, "/mcp/tools/team-billing-report/call?sessionid="+session.ID, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Result map[string]int `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 3, body.Result["invoices"])
+
+	// And over MCP
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err = server.mcpServer.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	client, err := mcp.NewClient(&mcp.Implementation{Name: "test"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+	result, err := client.CallTool(ctx, &mcp.CallToolParams{Name: "team-billing-report"})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func TestRegisterTool_ClosedAfterStart(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.started.Store(true)
+
+	assert.ErrorIs(t, server.RegisterTool(&stubTool{name: "late"}), ErrServerStarted)
+	_, ok := server.lookupTool("late")
+	assert.False(t, ok)
+}
+
+func TestRegisterResource_ExternalResource(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	require.NoError(t, server.RegisterResource(&stubResource{uri: "team://billing"}))
+	assert.ErrorIs(t, server.RegisterResource(&stubResource{uri: "team://billing"}), ErrAlreadyRegistered)
+
+	w := authRequest(server, http.MethodGet, "/mcp/resources", "", nil)
+	assert.Contains(t, w.Body.String(), `"team://billing"`)
+
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+	w = authRequest(server, http.MethodGet, "/mcp/resources/team%3A%2F%2Fbilling/read?sessionid="+session.ID, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "invoices")
+}
+
+// stubResource is a resource serving fixed JSON
+type stubResource struct {
+	uri string
+}
+
+func (r *stubResource) URI() string         { return r.uri }
+func (r *stubResource) Name() string        { return "stub resource " + r.uri }
+func (r *stubResource) Description() string { return "Fixed data for tests" }
+func (r *stubResource) MimeType() string    { return "application/json" }
+func (r *stubResource) Read(ctx context.Context) (string, error) {
+	return `{"invoices": 3}`, nil
+}
@@ -0,0 +1,367 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ReloadResult reports the outcome of a configuration reload
+type ReloadResult struct {
+	Applied  []string `json:"applied"`  // Keys whose new values are now live
+	Rejected []string `json:"rejected"` // Keys that changed but require a restart
+	Errors   []string `json:"errors"`   // Load or validation problems (nothing applied)
+}
+
+// Success reports whether the reload completed without errors
+func (r *ReloadResult) Success() bool {
+	return len(r.Errors) == 0
+}
+
+// reloadableKey describes how a config key behaves on reload
+type reloadableKey struct {
+	name            string
+	requiresRestart bool
+	changed         func(running, loaded *Config) bool
+	apply           func(dst, src *Config)
+}
+
+// reloadKeys lists every config key and whether it can be applied without a restart.
+// Listeners, server identity, and integration clients are built once at startup,
+// so changes to them are rejected.
+var reloadKeys = []reloadableKey{
+	{"transport", true, func(a, b *Config) bool { return a.Transport != b.Transport }, nil},
+	{"backend", true, func(a, b *Config) bool { return a.Backend != b.Backend }, nil},
+	{"mock_fixtures_dir", true, func(a, b *Config) bool { return a.MockFixturesDir != b.MockFixturesDir }, nil},
+	{"http_host", true, func(a, b *Config) bool { return a.HTTPHost != b.HTTPHost }, nil},
+	{"http_port", true, func(a, b *Config) bool { return a.HTTPPort != b.HTTPPort }, nil},
+	{"http_read_header_timeout", true, func(a, b *Config) bool { return a.HTTPReadHeaderTimeout != b.HTTPReadHeaderTimeout }, nil},
+	{"http_read_timeout", true, func(a, b *Config) bool { return a.HTTPReadTimeout != b.HTTPReadTimeout }, nil},
+	{"http_idle_timeout", true, func(a, b *Config) bool { return a.HTTPIdleTimeout != b.HTTPIdleTimeout }, nil},
+	{"name", true, func(a, b *Config) bool { return a.Name != b.Name }, nil},
+	{"version", true, func(a, b *Config) bool { return a.Version != b.Version }, nil},
+	{"coordination_engine_url", true, func(a, b *Config) bool { return a.CoordinationEngineURL != b.CoordinationEngineURL }, nil},
+	{"prometheus_url", true, func(a, b *Config) bool { return a.PrometheusURL != b.PrometheusURL }, nil},
+	{"alertmanager_url", true, func(a, b *Config) bool { return a.AlertmanagerURL != b.AlertmanagerURL }, nil},
+	{"kserve_namespace", true, func(a, b *Config) bool { return a.KServeNamespace != b.KServeNamespace }, nil},
+	{"kserve_predictor_port", true, func(a, b *Config) bool { return a.KServePredictorPort != b.KServePredictorPort }, nil},
+	{"enable_coordination_engine", true, func(a, b *Config) bool { return a.EnableCoordinationEngine != b.EnableCoordinationEngine }, nil},
+	{"coordination_engine_bearer_token_file", true, func(a, b *Config) bool {
+		return a.CoordinationEngineBearerTokenFile != b.CoordinationEngineBearerTokenFile
+	}, nil},
+	{"coordination_engine_ca_file", true, func(a, b *Config) bool { return a.CoordinationEngineCAFile != b.CoordinationEngineCAFile }, nil},
+	{"enable_prometheus", true, func(a, b *Config) bool { return a.EnablePrometheus != b.EnablePrometheus }, nil},
+	{"enable_kserve", true, func(a, b *Config) bool { return a.EnableKServe != b.EnableKServe }, nil},
+	{"enable_alertmanager", true, func(a, b *Config) bool { return a.EnableAlertmanager != b.EnableAlertmanager }, nil},
+	{"alert_silence_max_duration", true, func(a, b *Config) bool { return a.AlertSilenceMaxDuration != b.AlertSilenceMaxDuration }, nil},
+	{"kserve_bearer_token_file", true, func(a, b *Config) bool { return a.KServeBearerTokenFile != b.KServeBearerTokenFile }, nil},
+	{"kserve_headers", true, func(a, b *Config) bool { return !slices.Equal(a.KServeHeaders, b.KServeHeaders) }, nil},
+	{"kserve_ca_file", true, func(a, b *Config) bool { return a.KServeCAFile != b.KServeCAFile }, nil},
+	{"kserve_max_retries", true, func(a, b *Config) bool { return a.KServeMaxRetries != b.KServeMaxRetries }, nil},
+	{"kserve_retry_budget", true, func(a, b *Config) bool { return a.KServeRetryBudget != b.KServeRetryBudget }, nil},
+	{"kserve_forecast_model", true, func(a, b *Config) bool { return a.KServeForecastModel != b.KServeForecastModel }, nil},
+	{"ce_webhook_path", true, func(a, b *Config) bool { return a.CEWebhookPath != b.CEWebhookPath }, nil},
+	{"ce_incident_poll_interval", true, func(a, b *Config) bool { return a.CEIncidentPollInterval != b.CEIncidentPollInterval }, nil},
+	{"k8s_client_qps", true, func(a, b *Config) bool { return a.K8sClientQPS != b.K8sClientQPS }, nil},
+	{"k8s_client_burst", true, func(a, b *Config) bool { return a.K8sClientBurst != b.K8sClientBurst }, nil},
+	{"health_collection_concurrency", true, func(a, b *Config) bool { return a.HealthConcurrency != b.HealthConcurrency }, nil},
+	{"health_rules", true, func(a, b *Config) bool { return !slices.Equal(a.HealthRules, b.HealthRules) }, nil},
+	{"dependency_failure_ttl", true, func(a, b *Config) bool { return a.DependencyFailureTTL != b.DependencyFailureTTL }, nil},
+	{"health_history_interval", true, func(a, b *Config) bool { return a.HealthHistoryInterval != b.HealthHistoryInterval }, nil},
+	{"health_history_size", true, func(a, b *Config) bool { return a.HealthHistorySize != b.HealthHistorySize }, nil},
+	{"capability_probe_interval", true, func(a, b *Config) bool { return a.CapabilityProbeInterval != b.CapabilityProbeInterval }, nil},
+	{"permission_check_interval", true, func(a, b *Config) bool { return a.PermissionCheckInterval != b.PermissionCheckInterval }, nil},
+	{"enable_auth", true, func(a, b *Config) bool { return a.EnableAuth != b.EnableAuth }, nil},
+	{"enable_cache_warmup", true, func(a, b *Config) bool { return a.EnableCacheWarmup != b.EnableCacheWarmup }, nil},
+	{"cache_warmup_timeout", true, func(a, b *Config) bool { return a.CacheWarmupTimeout != b.CacheWarmupTimeout }, nil},
+	{"ready_after_warmup", true, func(a, b *Config) bool { return a.ReadyAfterWarmup != b.ReadyAfterWarmup }, nil},
+	{"session_store", true, func(a, b *Config) bool { return a.SessionStore != b.SessionStore }, nil},
+	{"session_store_path", true, func(a, b *Config) bool { return a.SessionStorePath != b.SessionStorePath }, nil},
+	{"session_store_namespace", true, func(a, b *Config) bool { return a.SessionStoreNamespace != b.SessionStoreNamespace }, nil},
+	{"session_store_name", true, func(a, b *Config) bool { return a.SessionStoreName != b.SessionStoreName }, nil},
+	{"session_store_max_bytes", true, func(a, b *Config) bool { return a.SessionStoreMaxBytes != b.SessionStoreMaxBytes }, nil},
+	{"enabled_tools", true, func(a, b *Config) bool { return !slices.Equal(a.EnabledTools, b.EnabledTools) }, nil},
+	{"disabled_tools", true, func(a, b *Config) bool { return !slices.Equal(a.DisabledTools, b.DisabledTools) }, nil},
+	{"redaction_patterns", true, func(a, b *Config) bool { return !slices.Equal(a.RedactionPatterns, b.RedactionPatterns) }, nil},
+	{"manifest_denied_kinds", true, func(a, b *Config) bool { return !slices.Equal(a.ManifestDeniedKinds, b.ManifestDeniedKinds) }, nil},
+	{"namespaces_resource_max_entries", true, func(a, b *Config) bool { return a.NamespacesResourceMaxEntries != b.NamespacesResourceMaxEntries }, nil},
+	{"event_severity_rules", true, func(a, b *Config) bool { return !slices.Equal(a.EventSeverityRules, b.EventSeverityRules) }, nil},
+	{"events_resource_max_per_bucket", true, func(a, b *Config) bool { return a.EventsResourceMaxPerBucket != b.EventsResourceMaxPerBucket }, nil},
+	{"events_resource_include_info", true, func(a, b *Config) bool { return a.EventsResourceIncludeInfo != b.EventsResourceIncludeInfo }, nil},
+	{"raw_api_allowed_prefixes", true, func(a, b *Config) bool { return !slices.Equal(a.RawAPIAllowedPrefixes, b.RawAPIAllowedPrefixes) }, nil},
+	{"raw_api_denied_resources", true, func(a, b *Config) bool { return !slices.Equal(a.RawAPIDeniedResources, b.RawAPIDeniedResources) }, nil},
+	{"extended_resources", true, func(a, b *Config) bool { return !slices.Equal(a.ExtendedResources, b.ExtendedResources) }, nil},
+	{"critical_namespaces", true, func(a, b *Config) bool { return !slices.Equal(a.CriticalNamespaces, b.CriticalNamespaces) }, nil},
+	{"finalizer_audit_kinds", true, func(a, b *Config) bool { return !slices.Equal(a.FinalizerAuditKinds, b.FinalizerAuditKinds) }, nil},
+	{"kubelet_lease_stale_after", true, func(a, b *Config) bool { return a.KubeletLeaseStaleAfter != b.KubeletLeaseStaleAfter }, nil},
+	{"kubelet_status_stale_after", true, func(a, b *Config) bool { return a.KubeletStatusStaleAfter != b.KubeletStatusStaleAfter }, nil},
+	{"enable_kubelet_healthz_probe", true, func(a, b *Config) bool { return a.KubeletHealthzProbe != b.KubeletHealthzProbe }, nil},
+	{"report_schedule", true, func(a, b *Config) bool { return a.ReportSchedule != b.ReportSchedule }, nil},
+	{"report_sections", true, func(a, b *Config) bool { return !slices.Equal(a.ReportSections, b.ReportSections) }, nil},
+	{"report_configmap_namespace", true, func(a, b *Config) bool { return a.ReportConfigMapNamespace != b.ReportConfigMapNamespace }, nil},
+	{"report_configmap_name", true, func(a, b *Config) bool { return a.ReportConfigMapName != b.ReportConfigMapName }, nil},
+	{"report_directory", true, func(a, b *Config) bool { return a.ReportDirectory != b.ReportDirectory }, nil},
+	{"report_leader_election", true, func(a, b *Config) bool { return a.ReportLeaderElection != b.ReportLeaderElection }, nil},
+	{"report_lease_namespace", true, func(a, b *Config) bool { return a.ReportLeaseNamespace != b.ReportLeaseNamespace }, nil},
+	{"remediation_leader_election", true, func(a, b *Config) bool { return a.RemediationLeaderElection != b.RemediationLeaderElection }, nil},
+	{"remediation_lease_namespace", true, func(a, b *Config) bool { return a.RemediationLeaseNamespace != b.RemediationLeaseNamespace }, nil},
+	{"event_history_enabled", true, func(a, b *Config) bool { return a.EventHistoryEnabled != b.EventHistoryEnabled }, nil},
+	{"event_history_retention", true, func(a, b *Config) bool { return a.EventHistoryRetention != b.EventHistoryRetention }, nil},
+	{"event_history_max_records", true, func(a, b *Config) bool { return a.EventHistoryMaxRecords != b.EventHistoryMaxRecords }, nil},
+	{"event_history_file", true, func(a, b *Config) bool { return a.EventHistoryFile != b.EventHistoryFile }, nil},
+	{"event_history_leader_election", true, func(a, b *Config) bool { return a.EventHistoryLeaderElection != b.EventHistoryLeaderElection }, nil},
+	{"event_history_lease_namespace", true, func(a, b *Config) bool { return a.EventHistoryLeaseNamespace != b.EventHistoryLeaseNamespace }, nil},
+	{"artifact_directory", true, func(a, b *Config) bool { return a.ArtifactDirectory != b.ArtifactDirectory }, nil},
+	{"artifact_ttl", true, func(a, b *Config) bool { return a.ArtifactTTL != b.ArtifactTTL }, nil},
+	{"artifact_max_bytes", true, func(a, b *Config) bool { return a.ArtifactMaxBytes != b.ArtifactMaxBytes }, nil},
+	{"otlp_endpoint", true, func(a, b *Config) bool { return a.OTLPEndpoint != b.OTLPEndpoint }, nil},
+	{"must_gather_image", true, func(a, b *Config) bool { return a.MustGatherImage != b.MustGatherImage }, nil},
+	{"must_gather_namespace", true, func(a, b *Config) bool { return a.MustGatherNamespace != b.MustGatherNamespace }, nil},
+	{"must_gather_service_account", true, func(a, b *Config) bool { return a.MustGatherServiceAccount != b.MustGatherServiceAccount }, nil},
+	{"must_gather_pvc", true, func(a, b *Config) bool { return a.MustGatherPVC != b.MustGatherPVC }, nil},
+	{"must_gather_retention", true, func(a, b *Config) bool { return a.MustGatherRetention != b.MustGatherRetention }, nil},
+
+	{"log_level", false,
+		func(a, b *Config) bool { return a.LogLevel != b.LogLevel },
+		func(dst, src *Config) { dst.LogLevel = src.LogLevel }},
+	{"cache_ttl", false,
+		func(a, b *Config) bool { return a.CacheTTL != b.CacheTTL },
+		func(dst, src *Config) { dst.CacheTTL = src.CacheTTL }},
+	{"request_timeout", false,
+		func(a, b *Config) bool { return a.RequestTimeout != b.RequestTimeout },
+		func(dst, src *Config) { dst.RequestTimeout = src.RequestTimeout }},
+	{"max_concurrent_tools", false,
+		func(a, b *Config) bool { return a.MaxConcurrentTools != b.MaxConcurrentTools },
+		func(dst, src *Config) { dst.MaxConcurrentTools = src.MaxConcurrentTools }},
+	{"max_concurrent_heavy_tools", false,
+		func(a, b *Config) bool { return a.MaxConcurrentHeavyTools != b.MaxConcurrentHeavyTools },
+		func(dst, src *Config) { dst.MaxConcurrentHeavyTools = src.MaxConcurrentHeavyTools }},
+	{"tool_queue_timeout", false,
+		func(a, b *Config) bool { return a.ToolQueueTimeout != b.ToolQueueTimeout },
+		func(dst, src *Config) { dst.ToolQueueTimeout = src.ToolQueueTimeout }},
+	{"max_request_body_bytes", false,
+		func(a, b *Config) bool { return a.MaxRequestBodyBytes != b.MaxRequestBodyBytes },
+		func(dst, src *Config) { dst.MaxRequestBodyBytes = src.MaxRequestBodyBytes }},
+	{"websocket_max_message_bytes", false,
+		func(a, b *Config) bool { return a.WebSocketMaxMessageBytes != b.WebSocketMaxMessageBytes },
+		func(dst, src *Config) { dst.WebSocketMaxMessageBytes = src.WebSocketMaxMessageBytes }},
+	{"websocket_ping_interval", false,
+		func(a, b *Config) bool { return a.WebSocketPingInterval != b.WebSocketPingInterval },
+		func(dst, src *Config) { dst.WebSocketPingInterval = src.WebSocketPingInterval }},
+	{"stream_heartbeat_interval", false,
+		func(a, b *Config) bool { return a.StreamHeartbeatInterval != b.StreamHeartbeatInterval },
+		func(dst, src *Config) { dst.StreamHeartbeatInterval = src.StreamHeartbeatInterval }},
+	{"stream_replay_size", true, func(a, b *Config) bool { return a.StreamReplaySize != b.StreamReplaySize }, nil},
+	{"log_stream_max_concurrent", true, func(a, b *Config) bool { return a.LogStreamMaxConcurrent != b.LogStreamMaxConcurrent }, nil},
+	{"log_stream_max_duration", false,
+		func(a, b *Config) bool { return a.LogStreamMaxDuration != b.LogStreamMaxDuration },
+		func(dst, src *Config) { dst.LogStreamMaxDuration = src.LogStreamMaxDuration }},
+	{"log_stream_max_bytes", false,
+		func(a, b *Config) bool { return a.LogStreamMaxBytes != b.LogStreamMaxBytes },
+		func(dst, src *Config) { dst.LogStreamMaxBytes = src.LogStreamMaxBytes }},
+	{"read_only", false,
+		func(a, b *Config) bool { return a.ReadOnly != b.ReadOnly },
+		func(dst, src *Config) { dst.ReadOnly = src.ReadOnly }},
+	{"emit_action_events", false,
+		func(a, b *Config) bool { return a.EmitActionEvents != b.EmitActionEvents },
+		func(dst, src *Config) { dst.EmitActionEvents = src.EmitActionEvents }},
+	{"action_events_namespace", false,
+		func(a, b *Config) bool { return a.ActionEventsNamespace != b.ActionEventsNamespace },
+		func(dst, src *Config) { dst.ActionEventsNamespace = src.ActionEventsNamespace }},
+	{"remediation_policies_enabled", false,
+		func(a, b *Config) bool { return a.RemediationPoliciesEnabled != b.RemediationPoliciesEnabled },
+		func(dst, src *Config) { dst.RemediationPoliciesEnabled = src.RemediationPoliciesEnabled }},
+	{"remediation_policies", false,
+		func(a, b *Config) bool { return !slices.Equal(a.RemediationPolicies, b.RemediationPolicies) },
+		func(dst, src *Config) { dst.RemediationPolicies = src.RemediationPolicies }},
+	{"remediation_webhook_url", false,
+		func(a, b *Config) bool { return a.RemediationWebhookURL != b.RemediationWebhookURL },
+		func(dst, src *Config) { dst.RemediationWebhookURL = src.RemediationWebhookURL }},
+	{"report_webhook_url", false,
+		func(a, b *Config) bool { return a.ReportWebhookURL != b.ReportWebhookURL },
+		func(dst, src *Config) { dst.ReportWebhookURL = src.ReportWebhookURL }},
+	{"tenant_profiles", false,
+		func(a, b *Config) bool {
+			return !slices.EqualFunc(a.TenantProfiles, b.TenantProfiles, TenantProfile.equal)
+		},
+		func(dst, src *Config) { dst.TenantProfiles = src.TenantProfiles }},
+	{"slow_tool_threshold", false,
+		func(a, b *Config) bool { return a.SlowToolThreshold != b.SlowToolThreshold },
+		func(dst, src *Config) { dst.SlowToolThreshold = src.SlowToolThreshold }},
+	{"max_result_bytes", false,
+		func(a, b *Config) bool { return a.MaxResultBytes != b.MaxResultBytes },
+		func(dst, src *Config) { dst.MaxResultBytes = src.MaxResultBytes }},
+	{"cors_allowed_origins", false,
+		func(a, b *Config) bool { return !slices.Equal(a.CORSAllowedOrigins, b.CORSAllowedOrigins) },
+		func(dst, src *Config) { dst.CORSAllowedOrigins = src.CORSAllowedOrigins }},
+	{"cors_allowed_methods", false,
+		func(a, b *Config) bool { return !slices.Equal(a.CORSAllowedMethods, b.CORSAllowedMethods) },
+		func(dst, src *Config) { dst.CORSAllowedMethods = src.CORSAllowedMethods }},
+	{"cors_allowed_headers", false,
+		func(a, b *Config) bool { return !slices.Equal(a.CORSAllowedHeaders, b.CORSAllowedHeaders) },
+		func(dst, src *Config) { dst.CORSAllowedHeaders = src.CORSAllowedHeaders }},
+	{"cors_max_age", false,
+		func(a, b *Config) bool { return a.CORSMaxAge != b.CORSMaxAge },
+		func(dst, src *Config) { dst.CORSMaxAge = src.CORSMaxAge }},
+	{"cors_allow_credentials", false,
+		func(a, b *Config) bool { return a.CORSAllowCredentials != b.CORSAllowCredentials },
+		func(dst, src *Config) { dst.CORSAllowCredentials = src.CORSAllowCredentials }},
+	{"ce_webhook_secret", false,
+		func(a, b *Config) bool { return a.CEWebhookSecret != b.CEWebhookSecret },
+		func(dst, src *Config) { dst.CEWebhookSecret = src.CEWebhookSecret }},
+	{"allowed_namespaces", false,
+		func(a, b *Config) bool { return !slices.Equal(a.AllowedNamespaces, b.AllowedNamespaces) },
+		func(dst, src *Config) { dst.AllowedNamespaces = src.AllowedNamespaces }},
+	{"admin_token", false,
+		func(a, b *Config) bool { return a.AdminToken != b.AdminToken },
+		func(dst, src *Config) { dst.AdminToken = src.AdminToken }},
+}
+
+// mergeReloadedConfig builds the next live config from the running one,
+// taking only the safe subset of changes from loaded.
+func mergeReloadedConfig(running, loaded *Config) (*Config, *ReloadResult) {
+	next := *running
+	result := &ReloadResult{Applied: []string{}, Rejected: []string{}, Errors: []string{}}
+
+	for _, key := range reloadKeys {
+		if !key.changed(running, loaded) {
+			continue
+		}
+		if key.requiresRestart {
+			result.Rejected = append(result.Rejected, key.name)
+			continue
+		}
+		key.apply(&next, loaded)
+		result.Applied = append(result.Applied, key.name)
+	}
+
+	return &next, result
+}
+
+// allowedNamespaces returns the live ALLOWED_NAMESPACES, for the tools and
+// resources that enforce it on every call (empty = all)
+func (s *MCPServer) allowedNamespaces() []string {
+	return s.currentConfig().AllowedNamespaces
+}
+
+// currentConfig returns the live configuration.
+// Readers get a consistent snapshot: reloads swap the whole pointer.
+func (s *MCPServer) currentConfig() *Config {
+	if cfg := s.liveConfig.Load(); cfg != nil {
+		return cfg
+	}
+	return s.config
+}
+
+// Reload re-reads the config file, applies reloadable changes atomically,
+// and rejects changes that require a restart.
+func (s *MCPServer) Reload() *ReloadResult {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	running := s.currentConfig()
+	if running.ConfigFile == "" {
+		return &ReloadResult{
+			Applied:  []string{},
+			Rejected: []string{},
+			Errors:   []string{"no config file configured (start with --config or CONFIG_FILE to enable reload)"},
+		}
+	}
+
+	loaded, err := LoadConfig(running.ConfigFile)
+	if err != nil {
+		return &ReloadResult{Applied: []string{}, Rejected: []string{}, Errors: []string{err.Error()}}
+	}
+
+	if err := loaded.Validate(); err != nil {
+		result := &ReloadResult{Applied: []string{}, Rejected: []string{}}
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			result.Errors = validationErr.Problems
+		} else {
+			result.Errors = []string{err.Error()}
+		}
+		return result
+	}
+
+	next, result := mergeReloadedConfig(running, loaded)
+	if len(result.Applied) > 0 {
+		s.liveConfig.Store(next)
+		if s.cache != nil {
+			s.cache.SetDefaultTTL(next.CacheTTL)
+		}
+		setLogLevel(next.LogLevel)
+	}
+
+	return result
+}
+
+// logReloadResult writes a reload outcome to the server log
+func logReloadResult(result *ReloadResult) {
+	if !result.Success() {
+		log.Printf("Config reload failed: %s", strings.Join(result.Errors, "; "))
+		return
+	}
+	if len(result.Applied) == 0 && len(result.Rejected) == 0 {
+		log.Printf("Config reload: no changes")
+		return
+	}
+	if len(result.Applied) > 0 {
+		log.Printf("Config reload applied: %s", strings.Join(result.Applied, ", "))
+	}
+	if len(result.Rejected) > 0 {
+		log.Printf("Config reload rejected (restart required): %s", strings.Join(result.Rejected, ", "))
+	}
+}
+
+// ReloadAndLog reloads the configuration and logs the result (used for SIGHUP)
+func (s *MCPServer) ReloadAndLog() *ReloadResult {
+	result := s.Reload()
+	logReloadResult(result)
+	return result
+}
+
+// requireAdmin checks the bearer token for /admin endpoints.
+// Admin endpoints are disabled when no ADMIN_TOKEN is configured.
+func (s *MCPServer) requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	token := s.currentConfig().AdminToken
+	if token == "" {
+		writeJSONError(w, http.StatusForbidden, "admin endpoints are disabled (set ADMIN_TOKEN to enable)")
+		return false
+	}
+
+	provided := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+		writeJSONError(w, http.StatusUnauthorized, "invalid or missing admin token")
+		return false
+	}
+	return true
+}
+
+// handleAdminReload reloads the configuration file
+// POST /admin/reload - Requires Authorization: Bearer <ADMIN_TOKEN>
+func (s *MCPServer) handleAdminReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed - use POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.requireAdmin(w, r) {
+		return
+	}
+
+	result := s.ReloadAndLog()
+
+	status := http.StatusOK
+	if !result.Success() {
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := writeJSON(w, result); err != nil {
+		log.Printf("Error writing reload response: %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+)
+
+// newReloadTestServer builds a server from a config file without cluster access
+func newReloadTestServer(t *testing.T, content string) (*MCPServer, string) {
+	t.Helper()
+	path := writeConfigFile(t, content)
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	memoryCache := cache.NewMemoryCache(cfg.CacheTTL)
+	t.Cleanup(memoryCache.Close)
+
+	s := &MCPServer{config: cfg, cache: memoryCache}
+	s.liveConfig.Store(cfg)
+	return s, path
+}
+
+func TestReload_AppliesSafeKeys(t *testing.T) {
+	s, path := newReloadTestServer(t, "cache_ttl: 30s\nrequest_timeout: 10s\n")
+	original := s.currentConfig()
+
+	require.NoError(t, os.WriteFile(path, []byte("cache_ttl: 1m\nrequest_timeout: 20s\n"), 0o600))
+
+	result := s.Reload()
+	require.True(t, result.Success(), "errors: %v", result.Errors)
+	assert.ElementsMatch(t, []string{"cache_ttl", "request_timeout"}, result.Applied)
+	assert.Empty(t, result.Rejected)
+
+	assert.Equal(t, time.Minute, s.currentConfig().CacheTTL)
+	assert.Equal(t, 20*time.Second, s.currentConfig().RequestTimeout)
+	assert.Equal(t, time.Minute, s.cache.DefaultTTL())
+
+	// The previous snapshot is never mutated in place
+	assert.Equal(t, 30*time.Second, original.CacheTTL)
+}
+
+func TestReload_RejectsRestartKeys(t *testing.T) {
+	s, path := newReloadTestServer(t, "http_port: 8080\ncache_ttl: 30s\n")
+
+	require.NoError(t, os.WriteFile(path, []byte("http_port: 9090\ntransport: stdio\ncache_ttl: 45s\n"), 0o600))
+
+	result := s.Reload()
+	require.True(t, result.Success(), "errors: %v", result.Errors)
+	assert.Equal(t, []string{"cache_ttl"}, result.Applied)
+	assert.ElementsMatch(t, []string{"http_port", "transport"}, result.Rejected)
+
+	assert.Equal(t, 8080, s.currentConfig().HTTPPort)
+	assert.Equal(t, TransportHTTP, s.currentConfig().Transport)
+	assert.Equal(t, 45*time.Second, s.currentConfig().CacheTTL)
+}
+
+func TestReload_AllowedNamespaces(t *testing.T) {
+	s, path := newReloadTestServer(t, "allowed_namespaces: [web]\n")
+	policy := tools.ManifestPolicy{AllowedNamespaces: s.allowedNamespaces}
+	require.Error(t, s.checkLogNamespace(context.Background(), "payments"))
+
+	require.NoError(t, os.WriteFile(path, []byte("allowed_namespaces: [web, payments]\n"), 0o600))
+	result := s.Reload()
+	require.True(t, result.Success(), "errors: %v", result.Errors)
+	assert.Equal(t, []string{"allowed_namespaces"}, result.Applied)
+
+	// Policies built before the reload follow it
+	assert.Equal(t, []string{"web", "payments"}, policy.AllowedNamespaces())
+	assert.NoError(t, s.checkLogNamespace(context.Background(), "payments"))
+}
+
+func TestReload_LogLevel(t *testing.T) {
+	s, path := newReloadTestServer(t, "log_level: info\n")
+	t.Cleanup(func() { setLogLevel(LogLevelInfo) })
+	buf := captureLog(t)
+	log.SetOutput(LogWriter(buf))
+
+	log.Printf("DEBUG: before reload")
+	require.NoError(t, os.WriteFile(path, []byte("log_level: debug\n"), 0o600))
+	result := s.Reload()
+	require.True(t, result.Success(), "errors: %v", result.Errors)
+	assert.Equal(t, []string{"log_level"}, result.Applied)
+	log.Printf("DEBUG: after reload")
+
+	assert.NotContains(t, buf.String(), "before reload")
+	assert.Contains(t, buf.String(), "after reload")
+}
+
+func TestReload_InvalidFileAppliesNothing(t *testing.T) {
+	s, path := newReloadTestServer(t, "cache_ttl: 30s\n")
+
+	require.NoError(t, os.WriteFile(path, []byte("cache_ttl: 100ms\nmax_concurrent_tools: 0\n"), 0o600))
+
+	result := s.Reload()
+	assert.False(t, result.Success())
+	assert.Len(t, result.Errors, 2)
+	assert.Empty(t, result.Applied)
+	assert.Equal(t, 30*time.Second, s.currentConfig().CacheTTL)
+}
+
+func TestReload_NoConfigFile(t *testing.T) {
+	cfg := NewConfig()
+	s := &MCPServer{config: cfg}
+
+	result := s.Reload()
+	assert.False(t, result.Success())
+	assert.Contains(t, result.Errors[0], "no config file")
+}
+
+func TestHandleAdminReload_Auth(t *testing.T) {
+	s, _ := newReloadTestServer(t, "admin_token: s3cr3t\n")
+
+	tests := []struct {
+		name           string
+		method         string
+		authorization  string
+		expectedStatus int
+	}{
+		{"missing token", http.MethodPost, "", http.StatusUnauthorized},
+		{"wrong token", http.MethodPost, "Bearer nope", http.StatusUnauthorized},
+		{"valid token", http.MethodPost, "Bearer s3cr3t", http.StatusOK},
+		{"wrong method", http.MethodGet, "Bearer s3cr3t", http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/admin/reload", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+
+			s.handleAdminReload(w, req)
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var result ReloadResult
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Empty(t, result.Errors)
+			}
+		})
+	}
+}
+
+func TestHandleAdminReload_DisabledWithoutToken(t *testing.T) {
+	s, _ := newReloadTestServer(t, "cache_ttl: 30s\n")
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/reload", nil)
+	req.Header.Set("Authorization", "Bearer anything")
+	w := httptest.NewRecorder()
+
+	s.handleAdminReload(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,306 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/policy"
+)
+
+const (
+	// remediationLeaseName is the Lease replicas compete for when REMEDIATION_LEADER_ELECTION is on
+	remediationLeaseName = "cluster-health-mcp-remediation"
+	// remediationPolicyActor is the tool name of policy actions in the audit log and Events
+	remediationPolicyActor = "remediation-policy"
+	// remediationActionTimeout bounds reading the cluster state and each action, webhook announcement included
+	remediationActionTimeout = 30 * time.Second
+	// restartedAtAnnotation is the pod template annotation `oc rollout restart` sets
+	restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+	// actionEventPolicyAnnotation names the policy on the Events of policy actions
+	actionEventPolicyAnnotation = "mcp.openshift-aiops.io/policy"
+)
+
+// validateRemediationPolicies checks the rules and what their actions need
+func (c *Config) validateRemediationPolicies() []string {
+	problems := policy.Validate(c.RemediationPolicies)
+	for _, rule := range c.RemediationPolicies {
+		if rule.Action.Type == policy.ActionTriggerPlaybook && !c.EnableCoordinationEngine {
+			problems = append(problems, fmt.Sprintf("remediation policy %q: trigger_playbook requires the Coordination Engine (ENABLE_COORDINATION_ENGINE)", rule.Name))
+		}
+	}
+	if c.RemediationPoliciesEnabled && c.HealthHistoryInterval <= 0 {
+		problems = append(problems, "remediation policies are evaluated after each health history sample and require HEALTH_HISTORY_INTERVAL")
+	}
+	if c.RemediationWebhookURL != "" {
+		if err := validateURL(c.RemediationWebhookURL); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid remediation webhook URL: %v", err))
+		}
+	}
+	if c.RemediationPoliciesEnabled && c.RemediationLeaderElection {
+		if errs := validation.IsDNS1123Label(c.RemediationLeaseNamespace); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid remediation lease namespace %q: %s", c.RemediationLeaseNamespace, strings.Join(errs, "; ")))
+		}
+	}
+	return problems
+}
+
+// describeRemediationPolicies summarizes rules for the effective settings as
+// "name=condition->action" entries
+func describeRemediationPolicies(rules []policy.Rule) string {
+	entries := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		entries = append(entries, rule.Name+"="+rule.Condition.Type+"->"+rule.Action.Type)
+	}
+	return strings.Join(entries, ",")
+}
+
+// remediationEngine acts on the remediation policies after each health
+// history sample. Its evaluator keeps the cooldowns and the OOM kills seen,
+// in memory: a replica taking over the Lease starts with neither.
+type remediationEngine struct {
+	config    func() *Config
+	evaluator *policy.Evaluator
+	now       func() time.Time
+
+	leader   atomic.Bool // Always true without leader election
+	election sync.Once   // Starts the election the first time the engine is enabled
+}
+
+// newRemediationEngine creates the engine of the server's policies
+func newRemediationEngine(config func() *Config) *remediationEngine {
+	return &remediationEngine{config: config, evaluator: policy.NewEvaluator(), now: time.Now}
+}
+
+// RemediationPolicies returns the configured rules and the engine's evaluator,
+// for test-remediation-policy
+func (e *remediationEngine) RemediationPolicies() ([]policy.Rule, *policy.Evaluator) {
+	return e.config().RemediationPolicies, e.evaluator
+}
+
+// RemediationBlocked returns why the engine would not act now
+func (e *remediationEngine) RemediationBlocked() string {
+	cfg := e.config()
+	switch {
+	case !cfg.RemediationPoliciesEnabled:
+		return "remediation policies are disabled (REMEDIATION_POLICIES_ENABLED=false)"
+	case cfg.ReadOnly:
+		return "the server is in read-only mode"
+	case cfg.RemediationLeaderElection && !e.leader.Load():
+		return "this replica does not hold the remediation Lease"
+	}
+	return ""
+}
+
+// electLeader makes the engine act only while this replica holds the
+// remediation Lease in namespace, until ctx is done
+func (e *remediationEngine) electLeader(ctx context.Context, client kubernetes.Interface, namespace, identity string) {
+	runLeaderElection(ctx, client, namespace, remediationLeaseName, identity,
+		func(context.Context) {
+			log.Printf("Leading remediation policies (lease %s/%s)", namespace, remediationLeaseName)
+			e.leader.Store(true)
+		},
+		func() {
+			log.Printf("No longer leading remediation policies")
+			e.leader.Store(false)
+		})
+}
+
+// evaluateRemediationPolicies matches the policies against the cluster and
+// acts on what matched, unless the engine is switched off, the server is
+// read-only, or another replica leads. Every rule and target acted on
+// enters the rule's cooldown, whether the action succeeded or not, so that
+// a failing action is not retried on every sample.
+func (s *MCPServer) evaluateRemediationPolicies(ctx context.Context) {
+	e := s.remediation
+	if e == nil {
+		return
+	}
+	cfg := e.config()
+	if !cfg.RemediationPoliciesEnabled || len(cfg.RemediationPolicies) == 0 {
+		return
+	}
+	if cfg.RemediationLeaderElection {
+		// Started on first use, so that replicas without policies never take the Lease
+		e.election.Do(func() {
+			go e.electLeader(ctx, s.k8sClient.Clientset(), cfg.RemediationLeaseNamespace, leaderIdentity())
+		})
+	} else {
+		e.leader.Store(true)
+	}
+	if e.RemediationBlocked() != "" {
+		return
+	}
+
+	rules := cfg.RemediationPolicies
+	collectCtx, cancel := context.WithTimeout(ctx, remediationActionTimeout)
+	snapshot, err := tools.CollectPolicySnapshot(collectCtx, s.k8sClient, rules)
+	cancel()
+	if err != nil {
+		log.Printf("WARNING: remediation policies not evaluated: %v", err)
+		return
+	}
+	now := e.now()
+	e.evaluator.Forget(rules)
+	e.evaluator.Observe(now, snapshot, rules)
+	for _, decision := range e.evaluator.Evaluate(now, snapshot, rules) {
+		if !decision.Acts() {
+			continue
+		}
+		e.evaluator.Acted(decision, now)
+		s.runPolicyAction(ctx, cfg, decision)
+	}
+}
+
+// runPolicyAction carries out one decision, announces it on the webhook,
+// and records it in the audit log and as a Kubernetes Event
+func (s *MCPServer) runPolicyAction(ctx context.Context, cfg *Config, decision policy.Decision) {
+	ctx, cancel := context.WithTimeout(ctx, remediationActionTimeout)
+	defer cancel()
+
+	var err error
+	var done string
+	switch decision.Action.Type {
+	case policy.ActionRestartWorkload:
+		err = restartWorkload(ctx, s.k8sClient.Clientset(), decision.Target, s.remediation.now())
+		done = "restarted " + decision.Target.String()
+	case policy.ActionTriggerPlaybook:
+		err = s.triggerPolicyPlaybook(ctx, decision)
+		done = fmt.Sprintf("triggered playbook %s on %s", decision.Action.Playbook, decision.Target)
+	default:
+		done = "matched " + decision.Target.String()
+	}
+	message := fmt.Sprintf("remediation policy %s %s: %s", decision.Rule, done, decision.Reason)
+	if err != nil {
+		message = fmt.Sprintf("remediation policy %s failed to act on %s (%s): %v", decision.Rule, decision.Target, decision.Reason, err)
+		log.Printf("WARNING: %s", message)
+	} else {
+		log.Printf("%s", message)
+	}
+
+	if cfg.RemediationWebhookURL != "" {
+		status := "succeeded"
+		if err != nil {
+			status = "failed"
+		}
+		notifier := clients.NewWebhookNotifier(cfg.RemediationWebhookURL, remediationActionTimeout)
+		if notifyErr := notifier.Notify(ctx, clients.WebhookMessage{Text: message, Title: "Remediation policy " + decision.Rule, Status: status}); notifyErr != nil {
+			log.Printf("WARNING: remediation policy %s not announced on the webhook: %v", decision.Rule, notifyErr)
+		}
+	}
+
+	var args map[string]interface{}
+	if data, marshalErr := json.Marshal(decision); marshalErr == nil {
+		_ = json.Unmarshal(data, &args) //nolint:errcheck // The decision was just marshaled
+	}
+	entry := AuditEntry{
+		Time:    s.remediation.now().UTC(),
+		Tool:    remediationPolicyActor,
+		Policy:  decision.Rule,
+		Args:    args,
+		Outcome: classifyToolOutcome(err),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	writeAuditEntry(entry)
+
+	eventType := corev1.EventTypeNormal
+	if err != nil {
+		eventType = corev1.EventTypeWarning
+	}
+	annotations := map[string]string{
+		actionEventToolAnnotation:    remediationPolicyActor,
+		actionEventPolicyAnnotation:  decision.Rule,
+		actionEventOutcomeAnnotation: entry.Outcome,
+	}
+	target := policyEventTarget(decision.Target)
+	if !s.k8sClient.RecordEvent(target, annotations, eventType, actionEventReason, message) {
+		log.Printf("Action event of remediation policy %s on %s not emitted (rate limited or no recorder)", decision.Rule, decision.Target)
+	}
+}
+
+// policyEventTarget returns the object reference Events of a policy action
+// on target are recorded on
+func policyEventTarget(target policy.Target) *corev1.ObjectReference {
+	apiVersion := "v1"
+	switch target.Kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		apiVersion = "apps/v1"
+	case "Job":
+		apiVersion = "batch/v1"
+	}
+	return &corev1.ObjectReference{APIVersion: apiVersion, Kind: target.Kind, Namespace: target.Namespace, Name: target.Name}
+}
+
+// restartWorkload rolls a Deployment, StatefulSet or DaemonSet the way `oc
+// rollout restart` does: by stamping its pod template, which makes the
+// controller replace the pods under its rollout strategy
+func restartWorkload(ctx context.Context, client kubernetes.Interface, target policy.Target, at time.Time) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{restartedAtAnnotation: at.UTC().Format(time.RFC3339)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	apps := client.AppsV1()
+	switch target.Kind {
+	case "Deployment":
+		_, err = apps.Deployments(target.Namespace).Patch(ctx, target.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = apps.StatefulSets(target.Namespace).Patch(ctx, target.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	case "DaemonSet":
+		_, err = apps.DaemonSets(target.Namespace).Patch(ctx, target.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	default:
+		return fmt.Errorf("%s cannot be restarted: only Deployments, StatefulSets and DaemonSets can", target)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to restart %s: %w", target, err)
+	}
+	return nil
+}
+
+// triggerPolicyPlaybook runs the decision's playbook through the Coordination Engine
+func (s *MCPServer) triggerPolicyPlaybook(ctx context.Context, decision policy.Decision) error {
+	if s.ceClient == nil {
+		return fmt.Errorf("the Coordination Engine is not enabled")
+	}
+	req := &clients.TriggerRemediationRequest{
+		IncidentID: "policy-" + decision.Rule,
+		Namespace:  decision.Target.Namespace,
+		Playbook:   decision.Action.Playbook,
+	}
+	req.Resource.Kind = decision.Target.Kind
+	req.Resource.Name = decision.Target.Name
+	req.Issue.Type = decision.Condition
+	if decision.Condition == policy.ConditionPodOOMKilled {
+		req.Issue.Type = "oom_kill" // The engine's name for it
+	}
+	req.Issue.Severity = "high"
+	req.Issue.Description = decision.Reason
+	resp, err := s.ceClient.TriggerRemediation(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to trigger playbook %s: %w", decision.Action.Playbook, err)
+	}
+	log.Printf("Remediation policy %s started workflow %s", decision.Rule, resp.WorkflowID)
+	return nil
+}
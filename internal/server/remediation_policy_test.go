@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/policy"
+)
+
+var remediationTestNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+func apiDownRule() policy.Rule {
+	return policy.Rule{
+		Name:      "api-down",
+		Condition: policy.Condition{Type: policy.ConditionDeploymentUnavailable, Namespace: "shop", Name: "api", For: policy.Duration(10 * time.Minute)},
+		Action:    policy.Action{Type: policy.ActionRestartWorkload},
+		Cooldown:  policy.Duration(time.Hour),
+	}
+}
+
+// newRemediationServer returns a server acting on rules against a fake
+// cluster with the shop/api Deployment unavailable for twenty minutes and
+// the worker-1 node NotReady for an hour
+func newRemediationServer(t *testing.T, rules ...policy.Rule) (*MCPServer, *fake.Clientset, *record.FakeRecorder) {
+	t.Helper()
+	cfg := NewConfig()
+	cfg.RemediationPolicies = rules
+	cfg.RemediationPoliciesEnabled = true
+	cfg.RemediationLeaderElection = false
+	server := newStubToolServer(t, cfg)
+
+	clientset := fake.NewClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api"},
+			Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(remediationTestNow.Add(-20 * time.Minute))},
+			}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(remediationTestNow.Add(-time.Hour))},
+			}},
+		},
+	)
+	recorder := record.NewFakeRecorder(20)
+	recorder.IncludeObject = true
+	server.k8sClient = clients.NewK8sClientWithClientset(clientset)
+	server.k8sClient.SetEventRecorder(recorder)
+
+	server.remediation = newRemediationEngine(server.currentConfig)
+	server.remediation.now = func() time.Time { return remediationTestNow }
+	return server, clientset, recorder
+}
+
+// restartedAt returns the restart annotation of the shop/api pod template
+func restartedAt(t *testing.T, clientset *fake.Clientset) string {
+	t.Helper()
+	deployment, err := clientset.AppsV1().Deployments("shop").Get(context.Background(), "api", metav1.GetOptions{})
+	require.NoError(t, err)
+	return deployment.Spec.Template.Annotations[restartedAtAnnotation]
+}
+
+// countPatches counts the patch requests sent to the fake cluster
+func countPatches(clientset *fake.Clientset) int {
+	n := 0
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "patch" {
+			n++
+		}
+	}
+	return n
+}
+
+func TestEvaluateRemediationPolicies_RestartsWorkload(t *testing.T) {
+	server, clientset, recorder := newRemediationServer(t, apiDownRule())
+	logs := captureLog(t)
+
+	server.evaluateRemediationPolicies(context.Background())
+
+	assert.Equal(t, "2026-10-15T12:00:00Z", restartedAt(t, clientset))
+
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 1)
+	assert.Equal(t, remediationPolicyActor, entries[0].Tool)
+	assert.Equal(t, remediationTestNow, entries[0].Time)
+	assert.Equal(t, "api-down", entries[0].Policy)
+	assert.Equal(t, outcomeSuccess, entries[0].Outcome)
+	assert.Equal(t, "restart_workload", entries[0].Args.(map[string]interface{})["action"].(map[string]interface{})["type"])
+
+	events := recordedEvents(recorder)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], "Normal MCPRemediation remediation policy api-down restarted Deployment shop/api: unavailable for 20m0s")
+	assert.Contains(t, events[0], "involvedObject{kind=Deployment,apiVersion=apps/v1}")
+	assert.Contains(t, events[0], "mcp.openshift-aiops.io/policy:api-down")
+}
+
+func TestEvaluateRemediationPolicies_Cooldown(t *testing.T) {
+	server, clientset, recorder := newRemediationServer(t, apiDownRule())
+	captureLog(t)
+
+	server.evaluateRemediationPolicies(context.Background())
+	require.Equal(t, 1, countPatches(clientset))
+
+	// Still unavailable on the next samples, but within the hour's cooldown
+	for _, later := range []time.Duration{time.Minute, 59 * time.Minute} {
+		server.remediation.now = func() time.Time { return remediationTestNow.Add(later) }
+		server.evaluateRemediationPolicies(context.Background())
+	}
+	assert.Equal(t, 1, countPatches(clientset))
+
+	server.remediation.now = func() time.Time { return remediationTestNow.Add(time.Hour) }
+	server.evaluateRemediationPolicies(context.Background())
+	assert.Equal(t, 2, countPatches(clientset))
+	assert.Len(t, recordedEvents(recorder), 2)
+}
+
+func TestEvaluateRemediationPolicies_Blocked(t *testing.T) {
+	for name, block := range map[string]func(*Config){
+		"kill switch": func(cfg *Config) { cfg.RemediationPoliciesEnabled = false },
+		"read-only":   func(cfg *Config) { cfg.ReadOnly = true },
+		"no rules":    func(cfg *Config) { cfg.RemediationPolicies = nil },
+	} {
+		server, clientset, recorder := newRemediationServer(t, apiDownRule())
+		logs := captureLog(t)
+		cfg := *server.currentConfig()
+		block(&cfg)
+		server.liveConfig.Store(&cfg)
+
+		server.evaluateRemediationPolicies(context.Background())
+
+		assert.Empty(t, clientset.Actions(), name)
+		assert.Empty(t, auditEntries(t, logs.String()), name)
+		assert.Empty(t, recordedEvents(recorder), name)
+	}
+
+	disabled := &MCPServer{}
+	disabled.evaluateRemediationPolicies(context.Background()) // No health history: a no-op
+}
+
+func TestEvaluateRemediationPolicies_NotLeader(t *testing.T) {
+	server, _, _ := newRemediationServer(t, apiDownRule())
+	cfg := *server.currentConfig()
+	cfg.RemediationLeaderElection = true
+	server.liveConfig.Store(&cfg)
+
+	assert.Equal(t, "this replica does not hold the remediation Lease", server.remediation.RemediationBlocked())
+	server.remediation.leader.Store(true)
+	assert.Empty(t, server.remediation.RemediationBlocked())
+}
+
+func TestEvaluateRemediationPolicies_NotifyOnWebhook(t *testing.T) {
+	var received []map[string]interface{}
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		received = append(received, body)
+	}))
+	t.Cleanup(webhook.Close)
+
+	server, clientset, recorder := newRemediationServer(t, policy.Rule{
+		Name:      "node-down",
+		Condition: policy.Condition{Type: policy.ConditionNodeNotReady, For: policy.Duration(15 * time.Minute)},
+		Action:    policy.Action{Type: policy.ActionNotify},
+	})
+	cfg := *server.currentConfig()
+	cfg.RemediationWebhookURL = webhook.URL
+	server.liveConfig.Store(&cfg)
+	captureLog(t)
+
+	server.evaluateRemediationPolicies(context.Background())
+
+	assert.Zero(t, countPatches(clientset), "notify changes nothing")
+	require.Len(t, received, 1)
+	assert.Contains(t, received[0]["text"], "remediation policy node-down matched Node worker-1: NotReady for 1h0m0s")
+	events := recordedEvents(recorder)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], "involvedObject{kind=Node,apiVersion=v1}")
+}
+
+func TestEvaluateRemediationPolicies_FailedActionStillCoolsDown(t *testing.T) {
+	server, _, recorder := newRemediationServer(t, policy.Rule{
+		Name:      "api-playbook",
+		Condition: apiDownRule().Condition,
+		Action:    policy.Action{Type: policy.ActionTriggerPlaybook, Playbook: "restart-api"},
+	})
+	logs := captureLog(t)
+
+	server.evaluateRemediationPolicies(context.Background()) // No Coordination Engine client
+	server.evaluateRemediationPolicies(context.Background())
+
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 1, "a failed action is not retried within the cooldown")
+	assert.Equal(t, outcomeUpstreamError, entries[0].Outcome)
+	assert.Contains(t, entries[0].Error, "Coordination Engine is not enabled")
+	events := recordedEvents(recorder)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], "Warning MCPRemediation remediation policy api-playbook failed to act on Deployment shop/api")
+}
+
+func TestRestartWorkload_UnsupportedKind(t *testing.T) {
+	err := restartWorkload(context.Background(), fake.NewClientset(), policy.Target{Kind: "Pod", Namespace: "shop", Name: "api-0"}, remediationTestNow)
+	assert.EqualError(t, err, "Pod shop/api-0 cannot be restarted: only Deployments, StatefulSets and DaemonSets can")
+
+	err = restartWorkload(context.Background(), fake.NewClientset(), policy.Target{Kind: "StatefulSet", Namespace: "shop", Name: "db"}, remediationTestNow)
+	assert.ErrorContains(t, err, "failed to restart StatefulSet shop/db")
+}
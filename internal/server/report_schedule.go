@@ -0,0 +1,463 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+const (
+	// reportLeaseName is the Lease replicas compete for when REPORT_LEADER_ELECTION is on
+	reportLeaseName = "cluster-health-mcp-reports"
+	// reportGenerateTimeout bounds the cluster reads of one scheduled report
+	reportGenerateTimeout = 2 * time.Minute
+	// reportDeliveryTimeout bounds each delivery attempt
+	reportDeliveryTimeout = 30 * time.Second
+)
+
+// Outcomes of a scheduled report run
+const (
+	reportRunRunning   = "running"
+	reportRunSucceeded = "succeeded"
+	reportRunPartial   = "partial" // Generated, but some sinks failed
+	reportRunFailed    = "failed"
+)
+
+// ReportScheduleStatus is the body of GET /reports/status
+type ReportScheduleStatus struct {
+	Schedule    string     `json:"schedule"`
+	Sinks       []string   `json:"sinks"`
+	Leader      bool       `json:"leader"` // Whether this replica runs the schedule
+	NextRun     *time.Time `json:"next_run,omitempty"`
+	SkippedRuns int        `json:"skipped_runs"` // Runs skipped because the previous one was still going
+	LastRun     *ReportRun `json:"last_run,omitempty"`
+}
+
+// ReportRun is one scheduled report and its deliveries
+type ReportRun struct {
+	ScheduledAt  time.Time        `json:"scheduled_at"`
+	StartedAt    time.Time        `json:"started_at"`
+	FinishedAt   *time.Time       `json:"finished_at,omitempty"`
+	Status       string           `json:"status"`                  // running, succeeded, partial, or failed
+	HealthStatus string           `json:"health_status,omitempty"` // Cluster health in the report
+	Error        string           `json:"error,omitempty"`
+	Deliveries   []ReportDelivery `json:"deliveries,omitempty"`
+}
+
+// ReportDelivery is the outcome of delivering a report to one sink
+type ReportDelivery struct {
+	Sink     string `json:"sink"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error,omitempty"`
+}
+
+// scheduledReport is a generated report ready for delivery
+type scheduledReport struct {
+	GeneratedAt  time.Time
+	HealthStatus string
+	Markdown     string
+	HTML         string
+	Findings     []findings.Finding
+}
+
+// reportSink is a destination scheduled reports are delivered to
+type reportSink interface {
+	String() string
+	deliver(ctx context.Context, report *scheduledReport) error
+}
+
+// reportScheduler generates the health report on a cron schedule and
+// delivers it to every sink, retrying failed deliveries with backoff.
+// A run still going when the next one is due makes that one skip.
+type reportScheduler struct {
+	expr     string
+	schedule *cronSchedule
+	clock    clock.Clock
+	generate func(ctx context.Context) (*tools.GenerateHealthReportOutput, error)
+	sinks    func() []reportSink // Resolved for every run, so reloaded sink settings apply to the next one
+	retry    *clients.RetryConfig
+
+	leader  atomic.Bool // Always true without leader election
+	running atomic.Bool
+
+	mu     sync.Mutex
+	status ReportScheduleStatus
+}
+
+// newReportScheduler creates a scheduler for the cron expression expr, which
+// Validate has already checked. sinks is called at the start of every run.
+func newReportScheduler(expr string, clk clock.Clock, generate func(ctx context.Context) (*tools.GenerateHealthReportOutput, error), sinks func() []reportSink) (*reportScheduler, error) {
+	schedule, err := parseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &reportScheduler{
+		expr:     expr,
+		schedule: schedule,
+		clock:    clk,
+		generate: generate,
+		sinks:    sinks,
+		retry:    clients.DefaultRetryConfig(),
+		status:   ReportScheduleStatus{Schedule: expr, Sinks: reportSinkNames(sinks())},
+	}, nil
+}
+
+// reportSinkNames lists sinks as the status reports them
+func reportSinkNames(sinks []reportSink) []string {
+	names := make([]string, len(sinks))
+	for i, sink := range sinks {
+		names[i] = sink.String()
+	}
+	return names
+}
+
+// newReportScheduler schedules the health report configured by the REPORT_*
+// settings. It keeps its own report tool so that the trend of each scheduled
+// report covers the time since the previous scheduled one.
+func (s *MCPServer) newReportScheduler() (*reportScheduler, error) {
+	var history tools.HealthHistoryLookup
+	if s.healthHistory != nil {
+		history = s.healthHistory.baselineAt
+	}
+	reportTool := tools.NewGenerateHealthReportTool(s.k8sClient, s.prometheus, s.platform.served(clients.OpenShiftAPIGroup), history)
+	sections := make([]interface{}, len(s.config.ReportSections))
+	for i, section := range s.config.ReportSections {
+		sections[i] = section
+	}
+	generate := func(ctx context.Context) (*tools.GenerateHealthReportOutput, error) {
+		args := map[string]interface{}{"html": true}
+		if len(sections) > 0 {
+			args["sections"] = sections
+		}
+		result, err := reportTool.Execute(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		output := result.(tools.GenerateHealthReportOutput)
+		return &output, nil
+	}
+
+	scheduler, err := newReportScheduler(s.config.ReportSchedule, clock.RealClock{}, generate, s.reportSinks)
+	if err != nil {
+		return nil, err
+	}
+	if !s.config.ReportLeaderElection {
+		scheduler.leader.Store(true)
+	}
+	log.Printf("Scheduled health reports on %q to %s", s.config.ReportSchedule, strings.Join(scheduler.status.Sinks, ", "))
+	return scheduler, nil
+}
+
+// reportSinks returns the sinks of the live config
+func (s *MCPServer) reportSinks() []reportSink {
+	return newReportSinks(s.currentConfig(), s.k8sClient)
+}
+
+// newReportSinks returns the sinks configured by REPORT_WEBHOOK_URL,
+// REPORT_CONFIGMAP_NAME and REPORT_DIRECTORY
+func newReportSinks(config *Config, k8sClient *clients.K8sClient) []reportSink {
+	var sinks []reportSink
+	if config.ReportWebhookURL != "" {
+		sinks = append(sinks, &webhookReportSink{notifier: clients.NewWebhookNotifier(config.ReportWebhookURL, reportDeliveryTimeout)})
+	}
+	if config.ReportConfigMapName != "" {
+		sinks = append(sinks, &configMapReportSink{client: k8sClient.Clientset(), namespace: config.ReportConfigMapNamespace, name: config.ReportConfigMapName})
+	}
+	if config.ReportDirectory != "" {
+		sinks = append(sinks, &directoryReportSink{dir: config.ReportDirectory})
+	}
+	return sinks
+}
+
+// run fires the schedule until ctx is done
+func (r *reportScheduler) run(ctx context.Context) {
+	for {
+		now := r.clock.Now()
+		next := r.schedule.next(now)
+		if next.IsZero() {
+			log.Printf("WARNING: report schedule %q never fires; no reports will be generated", r.expr)
+			return
+		}
+		r.mu.Lock()
+		r.status.NextRun = &next
+		r.mu.Unlock()
+
+		timer := r.clock.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+		r.trigger(ctx, next)
+	}
+}
+
+// trigger starts the run scheduled at scheduledAt unless another replica
+// leads or the previous run has not finished
+func (r *reportScheduler) trigger(ctx context.Context, scheduledAt time.Time) {
+	if !r.leader.Load() {
+		return
+	}
+	if !r.running.CompareAndSwap(false, true) {
+		log.Printf("WARNING: skipping the health report scheduled at %s: the previous run is still in progress", scheduledAt.Format(time.RFC3339))
+		r.mu.Lock()
+		r.status.SkippedRuns++
+		r.mu.Unlock()
+		return
+	}
+	go func() {
+		defer r.running.Store(false)
+		r.runOnce(ctx, scheduledAt)
+	}()
+}
+
+// runOnce generates one report and delivers it to every sink
+func (r *reportScheduler) runOnce(ctx context.Context, scheduledAt time.Time) {
+	run := ReportRun{ScheduledAt: scheduledAt, StartedAt: r.clock.Now(), Status: reportRunRunning}
+	r.setLastRun(run)
+
+	report, err := r.generateReport(ctx)
+	if err != nil {
+		log.Printf("WARNING: scheduled health report failed: %v", err)
+		run.Status = reportRunFailed
+		run.Error = err.Error()
+		r.finish(run)
+		return
+	}
+	run.HealthStatus = report.HealthStatus
+
+	sinks := r.sinks()
+	r.mu.Lock()
+	r.status.Sinks = reportSinkNames(sinks)
+	r.mu.Unlock()
+	delivered := 0
+	for _, sink := range sinks {
+		delivery := ReportDelivery{Sink: sink.String()}
+		err := clients.RetryWithBackoff(ctx, r.retry, func() error {
+			delivery.Attempts++
+			deliverCtx, cancel := context.WithTimeout(ctx, reportDeliveryTimeout)
+			defer cancel()
+			return sink.deliver(deliverCtx, report)
+		})
+		if err != nil {
+			delivery.Error = err.Error()
+			log.Printf("WARNING: health report delivery to %s failed after %d attempts: %v", sink.String(), delivery.Attempts, err)
+		} else {
+			delivered++
+			log.Printf("Delivered the health report to %s (health %s)", sink.String(), report.HealthStatus)
+		}
+		run.Deliveries = append(run.Deliveries, delivery)
+	}
+
+	switch delivered {
+	case len(sinks):
+		run.Status = reportRunSucceeded
+	case 0:
+		run.Status = reportRunFailed
+	default:
+		run.Status = reportRunPartial
+	}
+	r.finish(run)
+}
+
+// generateReport renders the report with its HTML form for the sinks that keep it
+func (r *reportScheduler) generateReport(ctx context.Context) (*scheduledReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, reportGenerateTimeout)
+	defer cancel()
+
+	output, err := r.generate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &scheduledReport{
+		GeneratedAt: output.Report.GeneratedAt,
+		Markdown:    output.Markdown,
+		HTML:        output.HTML,
+		Findings:    output.Report.Findings,
+	}
+	if output.Report.Health != nil {
+		report.HealthStatus = output.Report.Health.Status
+	}
+	return report, nil
+}
+
+// setLastRun records run as the latest one
+func (r *reportScheduler) setLastRun(run ReportRun) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.LastRun = &run
+}
+
+// finish records the end of run
+func (r *reportScheduler) finish(run ReportRun) {
+	finished := r.clock.Now()
+	run.FinishedAt = &finished
+	r.setLastRun(run)
+}
+
+// snapshot returns the scheduler status for /reports/status
+func (r *reportScheduler) snapshot() ReportScheduleStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := r.status
+	status.Leader = r.leader.Load()
+	if status.LastRun != nil {
+		lastRun := *status.LastRun
+		lastRun.Deliveries = append([]ReportDelivery(nil), lastRun.Deliveries...)
+		status.LastRun = &lastRun
+	}
+	return status
+}
+
+// electLeader makes the scheduler run only while this replica holds the
+// reports Lease in namespace, until ctx is done
+func (r *reportScheduler) electLeader(ctx context.Context, client kubernetes.Interface, namespace, identity string) {
+	runLeaderElection(ctx, client, namespace, reportLeaseName, identity,
+		func(context.Context) {
+			log.Printf("Leading scheduled health reports (lease %s/%s)", namespace, reportLeaseName)
+			r.leader.Store(true)
+		},
+		func() {
+			log.Printf("No longer leading scheduled health reports")
+			r.leader.Store(false)
+		})
+}
+
+// webhookReportSink posts the report to a generic or Slack webhook
+type webhookReportSink struct {
+	notifier *clients.WebhookNotifier
+}
+
+func (s *webhookReportSink) String() string { return "webhook" }
+
+func (s *webhookReportSink) deliver(ctx context.Context, report *scheduledReport) error {
+	title := fmt.Sprintf("Cluster health report %s", report.GeneratedAt.UTC().Format(time.RFC3339))
+	summary := title
+	if report.HealthStatus != "" {
+		summary = fmt.Sprintf("%s: %s", title, report.HealthStatus)
+	}
+	if len(report.Findings) > 0 {
+		summary += " (" + findings.Summarize(report.Findings) + ")"
+	}
+	return s.notifier.Notify(ctx, clients.WebhookMessage{
+		Text:     summary + "\n\n" + report.Markdown,
+		Title:    title,
+		Status:   report.HealthStatus,
+		Markdown: report.Markdown,
+		Findings: report.Findings,
+	})
+}
+
+// reportConfigMapLabels mark the ConfigMap the server writes its reports to
+var reportConfigMapLabels = map[string]string{
+	"app.kubernetes.io/name":      "openshift-cluster-health-mcp",
+	"app.kubernetes.io/component": "health-report",
+}
+
+// configMapReportSink keeps the latest report in a ConfigMap for GitOps tooling to pick up
+type configMapReportSink struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func (s *configMapReportSink) String() string {
+	return fmt.Sprintf("configmap/%s/%s", s.namespace, s.name)
+}
+
+func (s *configMapReportSink) deliver(ctx context.Context, report *scheduledReport) error {
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace, Labels: reportConfigMapLabels},
+		Data: map[string]string{
+			"generated-at": report.GeneratedAt.UTC().Format(time.RFC3339),
+			"status":       report.HealthStatus,
+			"report.md":    report.Markdown,
+			"report.html":  report.HTML,
+		},
+	}
+	_, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save configmap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
+
+// directoryReportSink writes each report as Markdown and HTML files named
+// after its generation time
+type directoryReportSink struct {
+	dir string
+}
+
+func (s *directoryReportSink) String() string { return "directory " + s.dir }
+
+func (s *directoryReportSink) deliver(ctx context.Context, report *scheduledReport) error {
+	base := filepath.Join(s.dir, "health-report-"+report.GeneratedAt.UTC().Format("20060102T150405Z"))
+	if err := writeFileAtomic(base+".md", []byte(report.Markdown)); err != nil {
+		return err
+	}
+	return writeFileAtomic(base+".html", []byte(report.HTML))
+}
+
+// writeFileAtomic writes a file through a temporary file and a rename so
+// readers never see it partially written
+func writeFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // Already renamed on success
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // The write error is reported
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// handleReportStatus returns the schedule and the outcome of the last scheduled report
+// GET /reports/status
+func (s *MCPServer) handleReportStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeJSONError(w, http.StatusMethodNotAllowed, "Method not allowed - use GET")
+		return
+	}
+	if s.reports == nil {
+		writeJSONError(w, http.StatusNotFound, "Scheduled reports are disabled (REPORT_SCHEDULE is not set)")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := writeJSON(w, s.reports.snapshot()); err != nil {
+		log.Printf("Error writing JSON response: %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+// stubReportSink records deliveries and fails the first failures of them
+type stubReportSink struct {
+	label    string
+	mu       sync.Mutex
+	failures []error
+	reports  []*scheduledReport
+	block    chan struct{} // When set, deliveries wait for it to close
+}
+
+func (s *stubReportSink) String() string { return s.label }
+
+func (s *stubReportSink) deliver(ctx context.Context, report *scheduledReport) error {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.failures) > 0 {
+		err := s.failures[0]
+		s.failures = s.failures[1:]
+		return err
+	}
+	s.reports = append(s.reports, report)
+	return nil
+}
+
+func (s *stubReportSink) delivered() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.reports)
+}
+
+// stubReportOutput is a generated report of a degraded cluster
+func stubReportOutput(ctx context.Context) (*tools.GenerateHealthReportOutput, error) {
+	return &tools.GenerateHealthReportOutput{
+		Report: tools.HealthReport{
+			GeneratedAt: time.Date(2026, 10, 15, 7, 0, 3, 0, time.UTC),
+			Health:      &tools.ReportHealth{Status: "degraded"},
+		},
+		Markdown: "# Cluster Health Report\n",
+		HTML:     "<h1>Cluster Health Report</h1>\n",
+	}, nil
+}
+
+// startReportScheduler runs a leading scheduler on a fake clock set to start
+func startReportScheduler(t *testing.T, expr string, start time.Time, sinks ...reportSink) (*reportScheduler, *testclock.FakeClock) {
+	t.Helper()
+	fakeClock := testclock.NewFakeClock(start)
+	scheduler, err := newReportScheduler(expr, fakeClock, stubReportOutput, func() []reportSink { return sinks })
+	require.NoError(t, err)
+	scheduler.retry = &clients.RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 1}
+	scheduler.leader.Store(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go scheduler.run(ctx)
+	return scheduler, fakeClock
+}
+
+// stepToNextRun advances the fake clock once the scheduler waits for its next run
+func stepToNextRun(t *testing.T, fakeClock *testclock.FakeClock, d time.Duration) {
+	t.Helper()
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	fakeClock.Step(d)
+}
+
+// waitForRun waits until the last run has finished
+func waitForRun(t *testing.T, scheduler *reportScheduler) ReportRun {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		lastRun := scheduler.snapshot().LastRun
+		return lastRun != nil && lastRun.FinishedAt != nil
+	}, 5*time.Second, time.Millisecond)
+	return *scheduler.snapshot().LastRun
+}
+
+func TestReportScheduler_DeliversOnSchedule(t *testing.T) {
+	clientset := fake.NewClientset()
+	dir := t.TempDir()
+	unavailable := apierrors.NewServiceUnavailable("webhook restarting")
+	flaky := &stubReportSink{label: "webhook", failures: []error{unavailable}}
+	start := time.Date(2026, 10, 15, 6, 59, 0, 0, time.UTC)
+	scheduler, fakeClock := startReportScheduler(t, "0 7 * * *", start,
+		flaky,
+		&configMapReportSink{client: clientset, namespace: "reports", name: "cluster-health-report"},
+		&directoryReportSink{dir: dir},
+	)
+
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	status := scheduler.snapshot()
+	assert.Equal(t, []string{"webhook", "configmap/reports/cluster-health-report", "directory " + dir}, status.Sinks)
+	require.NotNil(t, status.NextRun)
+	assert.Equal(t, time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC), *status.NextRun)
+	assert.Nil(t, status.LastRun)
+
+	fakeClock.Step(30 * time.Second)
+	assert.Nil(t, scheduler.snapshot().LastRun, "not due yet")
+
+	stepToNextRun(t, fakeClock, 30*time.Second)
+	run := waitForRun(t, scheduler)
+	assert.Equal(t, reportRunSucceeded, run.Status)
+	assert.Equal(t, "degraded", run.HealthStatus)
+	assert.Equal(t, time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC), run.ScheduledAt)
+	require.Len(t, run.Deliveries, 3)
+	assert.Equal(t, ReportDelivery{Sink: "webhook", Attempts: 2}, run.Deliveries[0], "retried with backoff")
+	assert.Equal(t, 1, run.Deliveries[1].Attempts)
+
+	configMap, err := clientset.CoreV1().ConfigMaps("reports").Get(context.Background(), "cluster-health-report", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "# Cluster Health Report\n", configMap.Data["report.md"])
+	assert.Equal(t, "degraded", configMap.Data["status"])
+	assert.Equal(t, "health-report", configMap.Labels["app.kubernetes.io/component"])
+
+	markdown, err := os.ReadFile(filepath.Join(dir, "health-report-20261015T070003Z.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Cluster Health Report\n", string(markdown))
+	assert.FileExists(t, filepath.Join(dir, "health-report-20261015T070003Z.html"))
+
+	// The next run is a day later
+	require.Eventually(t, func() bool {
+		next := scheduler.snapshot().NextRun
+		return next != nil && next.Equal(time.Date(2026, 10, 16, 7, 0, 0, 0, time.UTC))
+	}, time.Second, time.Millisecond)
+}
+
+func TestWebhookReportSink_Findings(t *testing.T) {
+	var got clients.WebhookMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	t.Cleanup(server.Close)
+
+	sink := &webhookReportSink{notifier: clients.NewWebhookNotifier(server.URL, time.Second)}
+	report := &scheduledReport{
+		GeneratedAt:  time.Date(2026, 10, 15, 7, 0, 3, 0, time.UTC),
+		HealthStatus: "degraded",
+		Markdown:     "# Cluster Health Report\n",
+		Findings: []findings.Finding{
+			{Severity: findings.SeverityCritical, Category: findings.CategoryNode, Resource: findings.ResourceRef{Kind: "node", Name: "worker-2"}, Title: "Node is NotReady"},
+			{Severity: findings.SeverityWarning, Category: findings.CategoryNode, Resource: findings.ResourceRef{Kind: "node", Name: "worker-4"}, Title: "Node is cordoned"},
+		},
+	}
+	require.NoError(t, sink.deliver(context.Background(), report))
+
+	assert.Equal(t, report.Findings, got.Findings)
+	assert.Contains(t, got.Text, "degraded (1 critical, 1 warning)")
+}
+
+func TestReportScheduler_ReloadedWebhookURL(t *testing.T) {
+	webhook := func(received *int) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { *received++ }))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+	var oldReceived, newReceived int
+	oldURL, newURL := webhook(&oldReceived), webhook(&newReceived)
+
+	s, path := newReloadTestServer(t, "report_schedule: \"0 7 * * *\"\nreport_webhook_url: "+oldURL+"\n")
+	scheduler, err := newReportScheduler(s.config.ReportSchedule, testclock.NewFakeClock(time.Now()), stubReportOutput, s.reportSinks)
+	require.NoError(t, err)
+	scheduler.runOnce(context.Background(), time.Now())
+	assert.Equal(t, 1, oldReceived)
+
+	require.NoError(t, os.WriteFile(path, []byte("report_schedule: \"0 7 * * *\"\nreport_webhook_url: "+newURL+"\n"), 0o600))
+	result := s.Reload()
+	require.True(t, result.Success(), "errors: %v", result.Errors)
+	assert.Equal(t, []string{"report_webhook_url"}, result.Applied)
+
+	// The next run goes to the reloaded webhook
+	scheduler.runOnce(context.Background(), time.Now())
+	assert.Equal(t, 1, oldReceived)
+	assert.Equal(t, 1, newReceived)
+	assert.Equal(t, reportRunSucceeded, scheduler.snapshot().LastRun.Status)
+}
+
+func TestReportScheduler_PartialAndFailedDeliveries(t *testing.T) {
+	working := &stubReportSink{label: "directory /reports"}
+	broken := &stubReportSink{label: "configmap/reports/cluster-health-report", failures: []error{
+		apierrors.NewForbidden(schema.GroupResource{Resource: "configmaps"}, "cluster-health-report", errors.New("no RBAC")),
+	}}
+	scheduler, fakeClock := startReportScheduler(t, "*/30 * * * *", time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC), working, broken)
+
+	stepToNextRun(t, fakeClock, 30*time.Minute)
+	run := waitForRun(t, scheduler)
+	assert.Equal(t, reportRunPartial, run.Status)
+	require.Len(t, run.Deliveries, 2)
+	assert.Empty(t, run.Deliveries[0].Error)
+	assert.Equal(t, 1, run.Deliveries[1].Attempts, "forbidden is not retried")
+	assert.Contains(t, run.Deliveries[1].Error, "no RBAC")
+}
+
+func TestReportScheduler_SkipsOverlappingRun(t *testing.T) {
+	logs := captureLog(t)
+	slow := &stubReportSink{label: "webhook", block: make(chan struct{})}
+	scheduler, fakeClock := startReportScheduler(t, "*/5 * * * *", time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC), slow)
+
+	stepToNextRun(t, fakeClock, 5*time.Minute)
+	require.Eventually(t, func() bool {
+		lastRun := scheduler.snapshot().LastRun
+		return lastRun != nil && lastRun.Status == reportRunRunning
+	}, time.Second, time.Millisecond)
+
+	// The 07:10 run comes while 07:05 is still delivering
+	stepToNextRun(t, fakeClock, 5*time.Minute)
+	require.Eventually(t, func() bool { return scheduler.snapshot().SkippedRuns == 1 }, time.Second, time.Millisecond)
+
+	close(slow.block)
+	run := waitForRun(t, scheduler)
+	assert.Equal(t, reportRunSucceeded, run.Status)
+	assert.Equal(t, time.Date(2026, 10, 15, 7, 5, 0, 0, time.UTC), run.ScheduledAt)
+	assert.Equal(t, 1, slow.delivered())
+	assert.Contains(t, logs.String(), "WARNING: skipping the health report scheduled at 2026-10-15T07:10:00Z")
+
+	// Once finished, the next run goes ahead
+	stepToNextRun(t, fakeClock, 5*time.Minute)
+	require.Eventually(t, func() bool { return slow.delivered() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestReportScheduler_OnlyLeaderRuns(t *testing.T) {
+	sink := &stubReportSink{label: "webhook"}
+	scheduler, fakeClock := startReportScheduler(t, "@hourly", time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC), sink)
+	scheduler.leader.Store(false)
+
+	stepToNextRun(t, fakeClock, time.Hour)
+	require.Eventually(t, func() bool {
+		next := scheduler.snapshot().NextRun
+		return next != nil && next.Hour() == 9
+	}, time.Second, time.Millisecond)
+	status := scheduler.snapshot()
+	assert.False(t, status.Leader)
+	assert.Nil(t, status.LastRun)
+	assert.Zero(t, sink.delivered())
+}
+
+func TestReportScheduler_ElectLeader(t *testing.T) {
+	clientset := fake.NewClientset()
+	scheduler, err := newReportScheduler("@daily", testclock.NewFakeClock(time.Now()), stubReportOutput, func() []reportSink { return nil })
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.electLeader(ctx, clientset, "reports", "mcp-0")
+	}()
+	require.Eventually(t, scheduler.leader.Load, 5*time.Second, 10*time.Millisecond)
+
+	lease, err := clientset.CoordinationV1().Leases("reports").Get(context.Background(), reportLeaseName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "mcp-0", *lease.Spec.HolderIdentity)
+
+	cancel()
+	<-done
+	assert.False(t, scheduler.leader.Load())
+}
+
+func TestHandleReportStatus(t *testing.T) {
+	server := &MCPServer{}
+	recorder := httptest.NewRecorder()
+	server.handleReportStatus(recorder, httptest.NewRequest(http.MethodGet, "/reports/status", nil))
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "REPORT_SCHEDULE")
+
+	sink := &stubReportSink{label: "webhook"}
+	scheduler, fakeClock := startReportScheduler(t, "@hourly", time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC), sink)
+	stepToNextRun(t, fakeClock, time.Hour)
+	waitForRun(t, scheduler)
+	server.reports = scheduler
+
+	recorder = httptest.NewRecorder()
+	server.handleReportStatus(recorder, httptest.NewRequest(http.MethodGet, "/reports/status", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var status ReportScheduleStatus
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, "@hourly", status.Schedule)
+	assert.True(t, status.Leader)
+	require.NotNil(t, status.LastRun)
+	assert.Equal(t, reportRunSucceeded, status.LastRun.Status)
+	assert.Equal(t, []ReportDelivery{{Sink: "webhook", Attempts: 1}}, status.LastRun.Deliveries)
+
+	recorder = httptest.NewRecorder()
+	server.handleReportStatus(recorder, httptest.NewRequest(http.MethodPost, "/reports/status", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// volatileResourceFields change on every read without the cluster changing,
+// so they are left out of the content hash
+var volatileResourceFields = []string{"timestamp", "data_age_seconds", "content_hash", "generated_at"}
+
+// stampedResource is a resource read with its content hash
+type stampedResource struct {
+	Content     string // JSON with content_hash and generated_at added
+	Hash        string
+	GeneratedAt time.Time
+}
+
+// stampResource hashes a resource's JSON content and adds the hash and the
+// time of the read to it, so consumers outside HTTP can tell whether it changed.
+// Identical cluster state hashes identically whatever the volatile fields say.
+func stampResource(content string, now time.Time) (*stampedResource, error) {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &object); err != nil {
+		return nil, fmt.Errorf("resource content is not a JSON object: %w", err)
+	}
+	for _, field := range volatileResourceFields {
+		delete(object, field)
+	}
+
+	// Marshal sorts map keys, which makes the encoding canonical
+	canonical, err := json.Marshal(object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash resource content: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	stamped := &stampedResource{Hash: hex.EncodeToString(sum[:8]), GeneratedAt: now.UTC()}
+
+	// Put the volatile fields back next to the stamp
+	if err := json.Unmarshal([]byte(content), &object); err != nil {
+		return nil, err
+	}
+	object["content_hash"] = stamped.Hash
+	object["generated_at"] = stamped.GeneratedAt.Format(time.RFC3339)
+	data, err := json.MarshalIndent(object, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to stamp resource content: %w", err)
+	}
+	stamped.Content = string(data)
+	return stamped, nil
+}
+
+// ETag returns the hash as a strong entity tag
+func (r *stampedResource) ETag() string {
+	return `"` + r.Hash + `"`
+}
+
+// ETagFor returns the entity tag of one representation of the resource;
+// JSON uses the plain hash
+func (r *stampedResource) ETagFor(format string) string {
+	if format == mimeJSON {
+		return r.ETag()
+	}
+	return `"` + r.Hash + "-" + path.Base(format) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header matches etag. Weak
+// tags compare by value, as RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/resources"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestStampResource_IgnoresVolatileFields(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	first, err := stampResource(`{"timestamp":"2026-01-02T03:04:05Z","total_nodes":3,"data_age_seconds":1.5}`, now)
+	require.NoError(t, err)
+	second, err := stampResource(`{"timestamp":"2026-01-02T03:09:00Z","total_nodes":3,"data_age_seconds":12}`, now.Add(time.Minute))
+	require.NoError(t, err)
+	changed, err := stampResource(`{"timestamp":"2026-01-02T03:09:00Z","total_nodes":4}`, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Hash, second.Hash, "identical state hashes identically")
+	assert.NotEqual(t, first.Hash, changed.Hash)
+
+	var content map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(first.Content), &content))
+	assert.Equal(t, first.Hash, content["content_hash"])
+	assert.Equal(t, "2026-01-02T03:04:05Z", content["generated_at"])
+	assert.Equal(t, float64(3), content["total_nodes"])
+
+	// Hashing stamped content gives the same hash again
+	restamped, err := stampResource(first.Content, now)
+	require.NoError(t, err)
+	assert.Equal(t, first.Hash, restamped.Hash)
+
+	_, err = stampResource(`["not", "an", "object"]`, now)
+	assert.Error(t, err)
+}
+
+func TestEtagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"xyz", W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(`"abd"`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+}
+
+// newResourceServer serves cluster://nodes from a fake cluster with one ready node
+func newResourceServer(t *testing.T) (*MCPServer, *fake.Clientset, *cache.MemoryCache) {
+	t.Helper()
+	clientset := fake.NewClientset(newReadyNode("worker-1"))
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	t.Cleanup(memoryCache.Close)
+
+	server := newStubToolServer(t, NewConfig())
+	server.clock = testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	nodes := resources.NewNodesResource(clients.NewK8sClientWithClientset(clientset), memoryCache)
+	server.registerResource(nodes)
+	return server, clientset, memoryCache
+}
+
+func newReadyNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		}},
+	}
+}
+
+func readResource(server *MCPServer, sessionID, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/mcp/resources/cluster%3A%2F%2Fnodes/read?sessionid="+sessionID, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	w := httptest.NewRecorder()
+	server.httpHandler().ServeHTTP(w, req)
+	return w
+}
+
+func TestHandleResourceRead_ETag(t *testing.T) {
+	server, clientset, memoryCache := newResourceServer(t)
+	sessionID := createSession(t, server, "", nil)
+
+	// 200 with an ETag
+	w := readResource(server, sessionID, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	var body struct {
+		Content string `json:"content"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	var content map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(body.Content), &content))
+	assert.Equal(t, `"`+content["content_hash"].(string)+`"`, etag, "the content carries the same hash")
+	assert.NotEmpty(t, content["generated_at"])
+
+	// 304 while unchanged, even after the cached data (and its timestamp) is refreshed
+	w = readResource(server, sessionID, etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	server.clock.(*testclock.FakeClock).Step(time.Minute) // A new generated_at
+	memoryCache.Clear()
+	assert.Equal(t, http.StatusNotModified, readResource(server, sessionID, etag).Code)
+	w = readResource(server, sessionID, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	var restamped map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(body.Content), &restamped))
+	assert.Equal(t, "2026-10-15T12:01:00Z", restamped["generated_at"])
+
+	// 200 with a new ETag once the cluster changes
+	_, err := clientset.CoreV1().Nodes().Create(context.Background(), newReadyNode("worker-2"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	memoryCache.Clear()
+
+	w = readResource(server, sessionID, etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
+
+func TestHandleResourceRead_NodeFilter(t *testing.T) {
+	server, clientset, _ := newResourceServer(t)
+	infra := newReadyNode("infra-1")
+	infra.Labels = map[string]string{"node-role.kubernetes.io/infra": ""}
+	_, err := clientset.CoreV1().Nodes().Create(context.Background(), infra, metav1.CreateOptions{})
+	require.NoError(t, err)
+	sessionID := createSession(t, server, "", nil)
+
+	read := func(query string) *httptest.ResponseRecorder {
+		return authRequest(server, http.MethodGet, "/mcp/resources/cluster%3A%2F%2Fnodes/read?sessionid="+sessionID+"&"+query, "", nil)
+	}
+
+	w := read("role=infra")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Content string `json:"content"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	var data resources.NodesData
+	require.NoError(t, json.Unmarshal([]byte(body.Content), &data))
+	assert.Equal(t, 1, data.TotalNodes)
+	require.NotNil(t, data.Filter)
+	assert.Equal(t, "infra", data.Filter.Role)
+
+	// Invalid filters are the caller's error
+	for _, query := range []string{"label_selector=zone+in+(a", "role=gpu"} {
+		w := read(query)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		assert.Contains(t, w.Body.String(), "invalid node filter", query)
+	}
+}
+
+func TestHandleResourceMetadata(t *testing.T) {
+	server, clientset, memoryCache := newResourceServer(t)
+	sessionID := createSession(t, server, "", nil)
+
+	metadata := func(knownHash string) map[string]interface{} {
+		t.Helper()
+		w := authRequest(server, http.MethodGet, "/mcp/resources/cluster%3A%2F%2Fnodes/metadata?sessionid="+sessionID+"&known_hash="+knownHash, "", nil)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.NotContains(t, body, "content")
+		return body
+	}
+
+	first := metadata("")
+	assert.Equal(t, true, first["changed"], "no known hash counts as changed")
+	hash := first["content_hash"].(string)
+	require.NotEmpty(t, hash)
+
+	assert.Equal(t, false, metadata(hash)["changed"])
+
+	_, err := clientset.CoreV1().Nodes().Create(context.Background(), newReadyNode("worker-2"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	memoryCache.Clear()
+	assert.Equal(t, true, metadata(hash)["changed"])
+
+	w := authRequest(server, http.MethodPost, "/mcp/resources/cluster%3A%2F%2Fnodes/metadata?sessionid="+sessionID, "", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestHandleResourceRead_ClusterHealthWithFailingCoordinationEngine(t *testing.T) {
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream timeout", http.StatusGatewayTimeout)
+	}))
+	t.Cleanup(engine.Close)
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	t.Cleanup(memoryCache.Close)
+
+	server := newStubToolServer(t, NewConfig())
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-0"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+	k8sClient := clients.NewK8sClientWithClientset(fake.NewClientset(newReadyNode("worker-1"), pod))
+	health := resources.NewClusterHealthResource(k8sClient, testutil.NewCoordinationEngineClient(t, engine.URL), memoryCache, nil)
+	server.registerResource(health)
+	sessionID := createSession(t, server, "", nil)
+
+	w := authRequest(server, http.MethodGet, "/mcp/resources/cluster%3A%2F%2Fhealth/read?sessionid="+sessionID, "", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Content string `json:"content"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	var content resources.ClusterHealthData
+	require.NoError(t, json.Unmarshal([]byte(body.Content), &content))
+	assert.Equal(t, "healthy", content.Status)
+	require.NotNil(t, content.Incidents)
+	assert.False(t, content.Incidents.Available)
+	assert.NotEmpty(t, content.Incidents.Error)
+}
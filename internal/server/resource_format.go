@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Representations of a resource read, chosen with the Accept header
+const (
+	mimeJSON = "application/json"
+	mimeYAML = "application/yaml"
+	mimeText = "text/plain"
+)
+
+// resourceMimeTypes are the representations every resource can be read in,
+// the default first
+var resourceMimeTypes = []string{mimeJSON, mimeYAML, mimeText}
+
+// mimeAliases maps other spellings of a representation to its canonical type
+var mimeAliases = map[string]string{
+	"application/x-yaml": mimeYAML,
+	"text/yaml":          mimeYAML,
+	"text/x-yaml":        mimeYAML,
+	"*/*":                mimeJSON,
+	"application/*":      mimeJSON,
+	"text/*":             mimeText,
+}
+
+// negotiateResourceFormat picks the representation with the highest quality in
+// an Accept header. ok is false when the header names only unsupported types,
+// in which case JSON is served anyway.
+func negotiateResourceFormat(accept string) (format string, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		return mimeJSON, true
+	}
+
+	best, bestQuality := "", 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if alias, ok := mimeAliases[mediaType]; ok {
+			mediaType = alias
+		}
+		if !slices.Contains(resourceMimeTypes, mediaType) {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = mediaType, quality
+		}
+	}
+	if best == "" {
+		return mimeJSON, false
+	}
+	return best, true
+}
+
+// renderResource re-encodes a resource's JSON content as YAML or as a compact
+// text summary
+func renderResource(content, format string) ([]byte, error) {
+	var object interface{}
+	if err := json.Unmarshal([]byte(content), &object); err != nil {
+		return nil, fmt.Errorf("resource content is not JSON: %w", err)
+	}
+
+	switch format {
+	case mimeYAML:
+		return yaml.Marshal(object)
+	case mimeText:
+		var b strings.Builder
+		writeTextSummary(&b, "", object)
+		return []byte(b.String()), nil
+	}
+	return nil, fmt.Errorf("unsupported resource format %q", format)
+}
+
+// writeTextSummary writes one "key: value" line per scalar, flattening nested
+// objects into dotted keys. Lists are summarized by their length, so that the
+// summary stays readable for large clusters.
+func writeTextSummary(b *strings.Builder, prefix string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			writeTextSummary(b, name, v[key])
+		}
+	case []interface{}:
+		fmt.Fprintf(b, "%s: %d items\n", prefix, len(v))
+	case nil:
+		// Absent values add nothing to a summary
+	default:
+		fmt.Fprintf(b, "%s: %v\n", prefix, v)
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestNegotiateResourceFormat(t *testing.T) {
+	tests := []struct {
+		accept        string
+		wantFormat    string
+		wantSupported bool
+	}{
+		{accept: "", wantFormat: mimeJSON, wantSupported: true},
+		{accept: "application/json", wantFormat: mimeJSON, wantSupported: true},
+		{accept: "application/yaml", wantFormat: mimeYAML, wantSupported: true},
+		{accept: "application/x-yaml", wantFormat: mimeYAML, wantSupported: true},
+		{accept: "text/plain", wantFormat: mimeText, wantSupported: true},
+		{accept: "*/*", wantFormat: mimeJSON, wantSupported: true},
+		{accept: "text/html, text/plain;q=0.5, application/yaml;q=0.8", wantFormat: mimeYAML, wantSupported: true},
+		{accept: "application/json;q=0, text/plain", wantFormat: mimeText, wantSupported: true},
+		{accept: "text/html", wantFormat: mimeJSON, wantSupported: false},
+		{accept: "image/png, application/xml", wantFormat: mimeJSON, wantSupported: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			format, supported := negotiateResourceFormat(tt.accept)
+			assert.Equal(t, tt.wantFormat, format)
+			assert.Equal(t, tt.wantSupported, supported)
+		})
+	}
+}
+
+func TestHandleResourceRead_ContentNegotiation(t *testing.T) {
+	server, _, _ := newResourceServer(t)
+	sessionID := createSession(t, server, "", nil)
+
+	read := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/mcp/resources/cluster%3A%2F%2Fnodes/read?sessionid="+sessionID, nil)
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		server.httpHandler().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Header().Values("Vary"), "Accept")
+		return w
+	}
+
+	t.Run("json", func(t *testing.T) {
+		w := read("application/json")
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Header().Get("Warning"))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "cluster://nodes", body["uri"])
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		w := read("application/yaml")
+		assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
+		var content map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(w.Body.Bytes(), &content))
+		assert.Equal(t, float64(1), content["total_nodes"])
+		assert.NotEmpty(t, content["content_hash"])
+		assert.Len(t, content["nodes"], 1)
+	})
+
+	t.Run("text", func(t *testing.T) {
+		w := read("text/plain")
+		assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		text := w.Body.String()
+		assert.Contains(t, text, "total_nodes: 1\n")
+		assert.Contains(t, text, "ready_nodes: 1\n")
+		assert.Contains(t, text, "nodes: 1 items\n")
+		assert.NotContains(t, text, "{")
+	})
+
+	t.Run("unsupported type falls back to json", func(t *testing.T) {
+		w := read("text/html")
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Warning"), "unsupported Accept")
+	})
+
+	t.Run("representations have distinct etags", func(t *testing.T) {
+		etags := map[string]bool{}
+		for _, accept := range []string{"application/json", "application/yaml", "text/plain"} {
+			etags[read(accept).Header().Get("ETag")] = true
+		}
+		assert.Len(t, etags, 3)
+
+		req := httptest.NewRequest(http.MethodGet, "/mcp/resources/cluster%3A%2F%2Fnodes/read?sessionid="+sessionID, nil)
+		req.Header.Set("Accept", "application/yaml")
+		req.Header.Set("If-None-Match", read("application/yaml").Header().Get("ETag"))
+		w := httptest.NewRecorder()
+		server.httpHandler().ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+}
+
+func TestHandleListResources_AdvertisesMimeTypes(t *testing.T) {
+	server, _, _ := newResourceServer(t)
+
+	w := authRequest(server, http.MethodGet, "/mcp/resources", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Resources []struct {
+			URI       string   `json:"uri"`
+			MimeTypes []string `json:"mime_types"`
+		} `json:"resources"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Resources, 1)
+	assert.Equal(t, []string{"application/json", "application/yaml", "text/plain"}, body.Resources[0].MimeTypes)
+}
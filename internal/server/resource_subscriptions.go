@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mcpServerOptions lets MCP clients subscribe to the registered resources,
+// to be sent notifications/resources/updated when one changes (see
+// notifyResourceUpdated)
+func mcpServerOptions(registry *ResourceRegistry) *mcp.ServerOptions {
+	return &mcp.ServerOptions{
+		SubscribeHandler: func(_ context.Context, req *mcp.SubscribeRequest) error {
+			if _, ok := registry.Get(req.Params.URI); !ok {
+				return fmt.Errorf("resource %s not found", req.Params.URI)
+			}
+			return nil
+		},
+		UnsubscribeHandler: func(context.Context, *mcp.UnsubscribeRequest) error {
+			return nil
+		},
+	}
+}
+
+// notifyResourceUpdated tells the MCP clients subscribed to uri that the
+// resource changed, so they read it again
+func (s *MCPServer) notifyResourceUpdated(ctx context.Context, uri string) {
+	if err := s.mcpServer.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+		log.Printf("WARNING: failed to notify subscribers of %s: %v", uri, err)
+	}
+}
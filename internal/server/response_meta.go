@@ -0,0 +1,136 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Data sources of a tool response
+const (
+	sourceCache = "cache" // At least part of the data was served from the cache
+	sourceLive  = "live"  // Everything was read from the cluster for this call
+)
+
+// VolatileTool is implemented by tools whose data goes stale at a rate other
+// than tools.DefaultVolatility
+type VolatileTool interface {
+	Volatility() tools.Volatility
+}
+
+// toolVolatility returns how quickly tool's results go stale. Mutating tools
+// report what they just did, which is never worth reusing.
+func toolVolatility(tool Tool) tools.Volatility {
+	if isMutating(tool) {
+		return tools.VolatilityRealtime
+	}
+	if volatile, ok := tool.(VolatileTool); ok {
+		return volatile.Volatility()
+	}
+	return tools.DefaultVolatility
+}
+
+// ResponseMeta is the _meta block attached to every tool response, telling
+// clients whether the result is safe to reuse
+type ResponseMeta struct {
+	Source                 string           `json:"source"`           // cache or live
+	DataAgeSeconds         float64          `json:"data_age_seconds"` // Age of the oldest cached data in the result
+	RevalidateAfterSeconds int              `json:"revalidate_after_seconds"`
+	Volatility             tools.Volatility `json:"volatility"`
+	Cluster                string           `json:"cluster,omitempty"` // API server the data came from
+	// Deprecation is set when the tool was called through an alias
+	Deprecation *DeprecationNotice `json:"deprecation,omitempty"`
+	// Coalesced is set when the result is that of an identical call in flight
+	Coalesced bool `json:"coalesced,omitempty"`
+	// Throttled is set when the call was rate limited on its way to the
+	// cluster or a dependency; callers should slow down by the suggested delay
+	Throttled                  bool `json:"throttled,omitempty"`
+	SuggestedRetryAfterSeconds int  `json:"suggested_retry_after_seconds,omitempty"`
+}
+
+// newResponseMeta describes a result of tool computed with the cache reads
+// in reads and the rate limiting in throttles. Data read through the cache
+// may be reused until the first of it expires; other data for as long as the
+// tool's volatility allows.
+func (s *MCPServer) newResponseMeta(tool Tool, reads *cache.ReadLog, throttles *clients.ThrottleLog) *ResponseMeta {
+	volatility := toolVolatility(tool)
+	meta := &ResponseMeta{
+		Source:         sourceLive,
+		DataAgeSeconds: dataAgeSeconds(reads.Age()),
+		Volatility:     volatility,
+		Cluster:        s.clusterServer(),
+	}
+	if reads.Hits() > 0 {
+		meta.Source = sourceCache
+	}
+
+	revalidateAfter := volatility.RevalidateAfter()
+	if expiresIn, ok := reads.ExpiresIn(); ok && volatility != tools.VolatilityRealtime {
+		revalidateAfter = expiresIn
+	}
+	meta.RevalidateAfterSeconds = int(math.Ceil(revalidateAfter.Seconds()))
+	if throttles.Throttled() {
+		meta.Throttled = true
+		meta.SuggestedRetryAfterSeconds = retryAfterSeconds(throttles.RetryAfter())
+	}
+	return meta
+}
+
+// clusterServer returns the URL of the API server the server reads from,
+// empty for clients built around an injected clientset
+func (s *MCPServer) clusterServer() string {
+	if s.k8sClient == nil || s.k8sClient.GetConfig() == nil {
+		return ""
+	}
+	return s.k8sClient.GetConfig().Host
+}
+
+// mcpMeta returns the block as the _meta of an MCP CallToolResult
+func (m *ResponseMeta) mcpMeta() mcp.Meta {
+	meta := mcp.Meta{
+		"source":                   m.Source,
+		"data_age_seconds":         m.DataAgeSeconds,
+		"revalidate_after_seconds": m.RevalidateAfterSeconds,
+		"volatility":               string(m.Volatility),
+	}
+	if m.Cluster != "" {
+		meta["cluster"] = m.Cluster
+	}
+	if m.Deprecation != nil {
+		meta["deprecation"] = m.Deprecation
+	}
+	if m.Coalesced {
+		meta["coalesced"] = true
+	}
+	if m.Throttled {
+		meta["throttled"] = true
+		meta["suggested_retry_after_seconds"] = m.SuggestedRetryAfterSeconds
+	}
+	return meta
+}
+
+// setCacheHeaders maps the block onto the Cache-Control header of a REST
+// tool response. Responses carry per-caller data, so only private caches may
+// keep them.
+func setCacheHeaders(w http.ResponseWriter, meta *ResponseMeta) {
+	if meta == nil {
+		return
+	}
+	if meta.RevalidateAfterSeconds == 0 {
+		w.Header().Set("Cache-Control", "no-store")
+		return
+	}
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", meta.RevalidateAfterSeconds))
+}
+
+// dataAgeSeconds reports an age in seconds, to the millisecond
+func dataAgeSeconds(age time.Duration) float64 {
+	return math.Round(age.Seconds()*1000) / 1000
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	testclock "k8s.io/utils/clock/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newClusterHealthServer serves get-cluster-health from a cache with the
+// given TTL, on the returned fake clock
+func newClusterHealthServer(t *testing.T, ttl time.Duration) (*MCPServer, *testclock.FakeClock) {
+	t.Helper()
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	memoryCache := cache.NewMemoryCacheWithClock(ttl, clk)
+	t.Cleanup(memoryCache.Close)
+
+	server := newStubToolServer(t, NewConfig())
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-0"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+	k8sClient := clients.NewK8sClientWithClientset(fake.NewClientset(newReadyNode("worker-1"), pod))
+	server.registerTool(tools.NewClusterHealthTool(k8sClient, memoryCache, nil))
+	return server, clk
+}
+
+func TestExecuteTool_MetaForFreshAndCachedResults(t *testing.T) {
+	server, clk := newClusterHealthServer(t, time.Minute)
+	tool, ok := server.lookupTool("get-cluster-health")
+	require.True(t, ok)
+
+	_, meta, err := server.executeTool(context.Background(), tool, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sourceLive, meta.Source, "the first call computes the health")
+	assert.Zero(t, meta.DataAgeSeconds)
+	assert.Equal(t, 60, meta.RevalidateAfterSeconds, "reusable for the cache TTL")
+	assert.Equal(t, tools.VolatilityFast, meta.Volatility)
+
+	clk.Step(20 * time.Second)
+	_, meta, err = server.executeTool(context.Background(), tool, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sourceCache, meta.Source, "the second call is served from the cache")
+	assert.Equal(t, 20.0, meta.DataAgeSeconds)
+	assert.Equal(t, 40, meta.RevalidateAfterSeconds, "reusable for what is left of the cache TTL")
+}
+
+func TestExecuteTool_MetaFromVolatility(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+
+	_, meta, err := server.executeTool(context.Background(), &stubTool{name: "list-things"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sourceLive, meta.Source)
+	assert.Equal(t, tools.DefaultVolatility, meta.Volatility, "tools that declare nothing")
+	assert.Equal(t, 30, meta.RevalidateAfterSeconds)
+
+	_, meta, err = server.executeTool(context.Background(), &volatileStubTool{stubTool{name: "catalog"}, tools.VolatilityStatic}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, tools.VolatilityStatic, meta.Volatility)
+	assert.Equal(t, 3600, meta.RevalidateAfterSeconds)
+
+	_, meta, err = server.executeTool(context.Background(), &mutatingStubTool{stubTool: stubTool{name: "restart"}}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, tools.VolatilityRealtime, meta.Volatility, "mutations are never reused")
+	assert.Zero(t, meta.RevalidateAfterSeconds)
+}
+
+func TestHandleToolCall_MetaAndCacheControl(t *testing.T) {
+	server, _ := newClusterHealthServer(t, time.Minute)
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+
+	call := func() (*httptest.ResponseRecorder, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodPost, "/mcp/tools/get-cluster-health/call?sessionid="+session.ID, nil)
+		w := httptest.NewRecorder()
+		server.httpHandler().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w, body["_meta"].(map[string]interface{})
+	}
+
+	w, meta := call()
+	assert.Equal(t, "private, max-age=60", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "live", meta["source"])
+	assert.Equal(t, "fast", meta["volatility"])
+
+	_, meta = call()
+	assert.Equal(t, "cache", meta["source"])
+}
+
+func TestSetCacheHeaders_NoStore(t *testing.T) {
+	w := httptest.NewRecorder()
+	setCacheHeaders(w, &ResponseMeta{Volatility: tools.VolatilityRealtime})
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+}
+
+func TestResponseMeta_MCPMeta(t *testing.T) {
+	meta := (&ResponseMeta{Source: sourceCache, DataAgeSeconds: 1.5, RevalidateAfterSeconds: 28, Volatility: tools.VolatilityFast}).mcpMeta()
+	assert.Equal(t, "cache", meta["source"])
+	assert.Equal(t, 1.5, meta["data_age_seconds"])
+	assert.Equal(t, 28, meta["revalidate_after_seconds"])
+	assert.Equal(t, "fast", meta["volatility"])
+	assert.NotContains(t, meta, "cluster", "omitted without a kubeconfig")
+}
+
+// volatileStubTool is a stub tool declaring its volatility
+type volatileStubTool struct {
+	stubTool
+	volatility tools.Volatility
+}
+
+func (t *volatileStubTool) Volatility() tools.Volatility { return t.volatility }
+
+func TestExecuteTool_MetaWhenThrottled(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-0"}, Status: corev1.PodStatus{Phase: corev1.PodRunning}}
+	clientset := fake.NewClientset(newReadyNode("worker-1"), pod)
+	rejected := false
+	clientset.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if rejected {
+			return false, nil, nil
+		}
+		rejected = true // API Priority and Fairness turns the first list away
+		return true, nil, apierrors.NewTooManyRequests("too many requests, please try again later", 0)
+	})
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	t.Cleanup(memoryCache.Close)
+	tool := tools.NewClusterHealthTool(clients.NewK8sClientWithClientset(clientset), memoryCache, nil)
+
+	_, meta, err := server.executeTool(context.Background(), tool, nil)
+	require.NoError(t, err, "the retry succeeds")
+	assert.True(t, meta.Throttled)
+	assert.Equal(t, 1, meta.SuggestedRetryAfterSeconds)
+	assert.Equal(t, true, meta.mcpMeta()["throttled"])
+
+	// Waits and delays asked for by a server are rounded up
+	throttling := &throttlingStubTool{stubTool{name: "slow-things"}, 2500 * time.Millisecond}
+	_, meta, err = server.executeTool(context.Background(), throttling, nil)
+	require.NoError(t, err)
+	assert.True(t, meta.Throttled)
+	assert.Equal(t, 3, meta.SuggestedRetryAfterSeconds)
+	assert.Equal(t, 3, meta.mcpMeta()["suggested_retry_after_seconds"])
+
+	_, meta, err = server.executeTool(context.Background(), &stubTool{name: "list-things"}, nil)
+	require.NoError(t, err)
+	assert.False(t, meta.Throttled)
+	assert.NotContains(t, meta.mcpMeta(), "throttled")
+}
+
+// throttlingStubTool is rate limited for delay on every call, as a client
+// whose requests were held back would be
+type throttlingStubTool struct {
+	stubTool
+	delay time.Duration
+}
+
+func (t *throttlingStubTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	clients.RecordThrottle(ctx, t.delay)
+	return t.stubTool.Execute(ctx, args)
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// truncationReserve is the part of the budget kept free for the truncation
+// metadata attached to a trimmed result
+const truncationReserve = 512
+
+// TruncatingTool is implemented by tools that know which parts of their
+// result matter most. Truncate returns result cut down to at most budget
+// bytes of JSON and how many items it left out per field; list tools drop
+// healthy items before unhealthy ones.
+type TruncatingTool interface {
+	Truncate(result interface{}, budget int) (interface{}, map[string]int)
+}
+
+// takeMaxBytes removes the _max_bytes argument and returns the result budget
+// of the call: the argument when given, otherwise the configured default.
+// 0 means no budget.
+func takeMaxBytes(args map[string]interface{}, defaultBudget int) (map[string]interface{}, int, error) {
+	value, ok := args[tools.MaxBytesArg]
+	if !ok {
+		return args, defaultBudget, nil
+	}
+
+	number, ok := value.(float64)
+	if !ok || number != math.Trunc(number) || number < 0 || (number > 0 && number < tools.MinResultBudget) {
+		return nil, 0, &tools.InvalidArgumentsError{Err: fmt.Errorf("%s must be 0 or an integer of at least %d", tools.MaxBytesArg, tools.MinResultBudget)}
+	}
+
+	args = maps.Clone(args)
+	delete(args, tools.MaxBytesArg)
+	return args, int(number), nil
+}
+
+// applyResultBudget returns the sanitized result of tool, truncated to budget
+// bytes of JSON when it is larger. result is the tool's own result, which a
+// TruncatingTool trims; generic trimming of the longest lists covers other
+// tools and whatever a tool's own truncation left over budget. Truncated
+// results are marked with "truncated": true, the items omitted per field,
+// and a hint on how to see them.
+func applyResultBudget(tool Tool, result interface{}, sanitized interface{}, budget int, sanitizer *tools.Sanitizer) (interface{}, error) {
+	if budget <= 0 || resultSize(sanitized) <= budget {
+		return sanitized, nil
+	}
+
+	limit := budget - truncationReserve
+	omitted := make(map[string]int)
+	if truncating, ok := tool.(TruncatingTool); ok {
+		truncated, counts := truncating.Truncate(result, limit)
+		var err error
+		if sanitized, err = sanitizer.Sanitize(truncated); err != nil {
+			return nil, err
+		}
+		for field, count := range counts {
+			omitted[field] += count
+		}
+	}
+	if resultSize(sanitized) > limit {
+		var counts map[string]int
+		sanitized, counts = trimLists(sanitized, limit)
+		for field, count := range counts {
+			omitted[field] += count
+		}
+	}
+	maps.DeleteFunc(omitted, func(_ string, count int) bool { return count == 0 })
+
+	object, ok := sanitized.(map[string]interface{})
+	if !ok {
+		// Scalars cannot be trimmed; return them as they are
+		return sanitized, nil
+	}
+	object["truncated"] = true
+	object["omitted_items"] = omitted
+	object["truncation_hint"] = truncationHint(budget, omitted)
+	return object, nil
+}
+
+// trimLists trims lists of a decoded JSON result from the tail, largest list
+// first, until the result fits within budget bytes. A result that is itself
+// a list is wrapped as {"items": [...]} so the truncation metadata has a place.
+func trimLists(value interface{}, budget int) (interface{}, map[string]int) {
+	if list, ok := value.([]interface{}); ok {
+		value = map[string]interface{}{"items": list}
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return value, nil
+	}
+
+	var fields []string
+	for field, child := range object {
+		if _, ok := child.([]interface{}); ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		si, sj := resultSize(object[fields[i]]), resultSize(object[fields[j]])
+		if si != sj {
+			return si > sj
+		}
+		return fields[i] < fields[j]
+	})
+
+	omitted := make(map[string]int)
+	for _, field := range fields {
+		if resultSize(object) <= budget {
+			break
+		}
+		list := object[field].([]interface{})
+		keep := sort.Search(len(list)+1, func(n int) bool {
+			object[field] = list[:n]
+			return resultSize(object) > budget
+		}) - 1
+		keep = max(keep, 0)
+		object[field] = list[:keep]
+		omitted[field] = len(list) - keep
+	}
+	return object, omitted
+}
+
+// truncationHint tells the caller how to see the omitted items
+func truncationHint(budget int, omitted map[string]int) string {
+	fields := slices.Sorted(maps.Keys(omitted))
+	parts := make([]string, 0, len(fields))
+	for _, field := range fields {
+		parts = append(parts, fmt.Sprintf("%d %s", omitted[field], field))
+	}
+	summary := "some items"
+	if len(parts) > 0 {
+		summary = strings.Join(parts, ", ")
+	}
+	return fmt.Sprintf("The result exceeded the %d byte budget and %s were omitted, least important first. "+
+		"Narrow the query (e.g. namespace, label_selector, limit) to page through the rest, or raise %s.",
+		budget, summary, tools.MaxBytesArg)
+}
+
+// resultSize returns the length of v encoded as compact JSON, as tool
+// results are sent
+func resultSize(v interface{}) int {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(raw)
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestTakeMaxBytes(t *testing.T) {
+	args, budget, err := takeMaxBytes(map[string]interface{}{"namespace": "shop"}, 4096)
+	require.NoError(t, err)
+	assert.Equal(t, 4096, budget, "the configured default")
+	assert.Equal(t, map[string]interface{}{"namespace": "shop"}, args)
+
+	callArgs := map[string]interface{}{"namespace": "shop", "_max_bytes": float64(2048)}
+	args, budget, err = takeMaxBytes(callArgs, 4096)
+	require.NoError(t, err)
+	assert.Equal(t, 2048, budget)
+	assert.Equal(t, map[string]interface{}{"namespace": "shop"}, args, "the dispatcher consumes _max_bytes")
+	assert.Contains(t, callArgs, "_max_bytes", "the caller's arguments are left alone")
+
+	_, budget, err = takeMaxBytes(map[string]interface{}{"_max_bytes": float64(0)}, 4096)
+	require.NoError(t, err)
+	assert.Zero(t, budget, "0 disables the budget for the call")
+
+	for _, value := range []interface{}{float64(100), float64(-1), 2048.5, "2048"} {
+		_, _, err := takeMaxBytes(map[string]interface{}{"_max_bytes": value}, 4096)
+		assert.True(t, tools.IsInvalidArguments(err), "%v", value)
+	}
+}
+
+func TestExecuteTool_ResultBudgetTrimsLists(t *testing.T) {
+	cfg := NewConfig()
+	cfg.MaxResultBytes = 2048
+	server := newStubToolServer(t, cfg)
+
+	items := make([]string, 200)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%03d", i)
+	}
+	tool := &stubTool{name: "list-things", result: map[string]interface{}{"items": items, "total": 200, "notes": []string{"a", "b"}}}
+
+	result, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, resultSize(result), 2048)
+
+	object := result.(map[string]interface{})
+	assert.Equal(t, true, object["truncated"])
+	kept := object["items"].([]interface{})
+	assert.Equal(t, "item-000", kept[0], "lists are trimmed from the tail")
+	assert.Equal(t, map[string]int{"items": 200 - len(kept)}, object["omitted_items"])
+	assert.Len(t, object["notes"], 2, "smaller lists are left alone once the result fits")
+	assert.Contains(t, object["truncation_hint"], "2048 byte budget")
+	assert.Contains(t, object["truncation_hint"], "_max_bytes")
+
+	// A larger budget for the call returns the whole result
+	result, _, err = server.executeTool(context.Background(), tool, map[string]interface{}{"_max_bytes": float64(64 * 1024)})
+	require.NoError(t, err)
+	assert.NotContains(t, result.(map[string]interface{}), "truncated")
+	assert.Len(t, result.(map[string]interface{})["items"], 200)
+}
+
+func TestExecuteTool_ResultBudgetWrapsLists(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	tool := &stubTool{name: "list-things", result: make([]string, 1000)}
+
+	result, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{"_max_bytes": float64(1024)})
+	require.NoError(t, err)
+	object := result.(map[string]interface{})
+	assert.Equal(t, true, object["truncated"])
+	assert.Equal(t, map[string]int{"items": 1000 - len(object["items"].([]interface{}))}, object["omitted_items"])
+}
+
+func TestExecuteTool_ResultBudgetKeepsUnhealthyPods(t *testing.T) {
+	objects := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-0"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "main", RestartCount: 12, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "worker-0"},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending},
+		},
+	}
+	for i := 0; i < 100; i++ {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: fmt.Sprintf("web-%03d", i), Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "main", Ready: true, Image: "registry.example.com/web:1.0", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}}},
+		})
+	}
+	server := newStubToolServer(t, NewConfig())
+	tool := tools.NewListPodsTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)), nil)
+
+	result, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{"namespace": "shop", "limit": float64(0), "_max_bytes": float64(8192)})
+	require.NoError(t, err)
+	assert.LessOrEqual(t, resultSize(result), 8192)
+
+	object := result.(map[string]interface{})
+	assert.Equal(t, true, object["truncated"])
+	assert.EqualValues(t, 102, object["count"], "the count still covers every listed pod")
+	pods := object["pods"].([]interface{})
+	require.Greater(t, len(pods), 2)
+	assert.Equal(t, map[string]int{"pods": 102 - len(pods)}, object["omitted_items"])
+
+	var names []string
+	for _, pod := range pods {
+		names = append(names, pod.(map[string]interface{})["name"].(string))
+	}
+	assert.Equal(t, []string{"api-0", "worker-0"}, names[:2], "unhealthy pods survive truncation")
+	for _, name := range names[2:] {
+		assert.True(t, strings.HasPrefix(name, "web-"), name)
+	}
+}
+
+func TestToolInputSchema_MaxBytes(t *testing.T) {
+	properties := toolInputSchema(&stubTool{name: "list-things"})["properties"].(map[string]interface{})
+	assert.Contains(t, properties, tools.MaxBytesArg)
+	assert.NotContains(t, (&stubTool{}).InputSchema()["properties"], tools.MaxBytesArg, "the tool's own schema is left alone")
+}
@@ -6,7 +6,11 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -15,6 +19,11 @@ import (
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/eventhistory"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/mockcluster"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/utils/clock"
 )
 
 // MCPServer wraps the official MCP SDK server
@@ -25,11 +34,48 @@ type MCPServer struct {
 	k8sClient      *clients.K8sClient
 	ceClient       *clients.CoordinationEngineClient
 	kserve         *clients.KServeClient
+	prometheus     *clients.PrometheusClient
+	alertmanager   *clients.AlertmanagerClient
 	cache          *cache.MemoryCache
-	sessionManager *SessionManager          // Session manager for REST API clients
-	tools          map[string]Tool          // Registry of available tools (typed for type safety)
-	resources      map[string]interface{}   // Registry of available resources
-	prompts        map[string]interface{}   // Registry of available prompts
+	sessionManager *SessionManager              // Session manager for REST API clients
+	tools          *ToolRegistry                // Registry of available tools
+	registryMu     sync.RWMutex                 // Guards openAPISpec, which is rebuilt as tools and resources come and go
+	platform       platformState                // API groups found by discovery and the tools waiting for them
+	integrations   map[string]*integration      // Enabled integrations probed for availability (nil when probing is off)
+	integrationsMu sync.Mutex                   // Guards the entries of integrations
+	resources      *ResourceRegistry            // Registry of available resources
+	prompts        map[string]interface{}       // Registry of available prompts
+	liveConfig     atomic.Pointer[Config]       // Live config, swapped atomically on reload
+	reloadMu       sync.Mutex                   // Serializes config reloads
+	sanitizer      *tools.Sanitizer             // Masks secret material in tool results
+	openAPISpec    map[string]interface{}       // OpenAPI document built from the registries, rebuilt when they change
+	toolMetrics    *toolMetrics                 // Per-tool latency, outcome, and result size metrics
+	permissions    *tools.CheckPermissionsTool  // RBAC self-check, also run at startup and every PERMISSION_CHECK_INTERVAL
+	healthHistory  *healthHistory               // Sampled cluster health for /export/health (nil when disabled)
+	eventHistory   *eventHistoryRecorder        // Recorded cluster events for aggregate-events (nil when disabled)
+	healthStream   *eventStream                 // Health samples for /stream/health (nil without health history)
+	remediation    *remediationEngine           // Remediation policies, evaluated after each health sample (nil without health history)
+	eventStream    *eventStream                 // Recorded events for /stream/events (nil without event history)
+	incidents      *resources.IncidentsResource // cluster://incidents, kept current by the CE webhook or poller (nil without the CE)
+	warmupDone     chan struct{}                // Closed once the cache warm-up finishes (nil when disabled)
+	reports        *reportScheduler             // Scheduled health reports (nil when REPORT_SCHEDULE is unset)
+	artifacts      *artifactStore               // Stored tool-result artifacts (nil when ARTIFACT_DIRECTORY is unset)
+	logStreams     *logStreams                  // Limits of /mcp/stream/logs and follow-pod-logs (nil when LOG_STREAM_MAX_CONCURRENT=0)
+	baselines      *baselineStore               // Named baselines of capture-baseline, compared by check-drift
+	started        atomic.Bool                  // Set by Start, which closes RegisterTool and RegisterResource
+	websockets     websocketHub                 // Open MCP WebSocket connections, closed on shutdown
+	coalescer      callCoalescer                // In-flight read-only tool calls shared by identical concurrent calls
+	scheduler      toolScheduler                // Execution slots of light and heavy tool calls
+	mock           *mockcluster.Cluster         // Simulated cluster behind k8sClient (nil unless BACKEND=mock)
+	clock          clock.PassiveClock           // "Now" of relative time arguments and of resource reads (generated_at)
+
+	// Tools refused for missing RBAC, with the rules they miss (nil until the first self-check)
+	degradedTools atomic.Pointer[map[string][]tools.PermissionRule]
+
+	// Catalog of /mcp/tools/manifest, built on first request
+	manifestOnce sync.Once
+	manifest     *ToolManifest
+	manifestErr  error
 }
 
 // NewMCPServer creates a new MCP server instance
@@ -37,11 +83,29 @@ func NewMCPServer(config *Config) (*MCPServer, error) {
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
+	setLogLevel(config.LogLevel)
 
-	// Initialize Kubernetes client
-	k8sClient, err := clients.NewK8sClient(nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	// Initialize Kubernetes client, or the simulated cluster standing in for it
+	var k8sClient *clients.K8sClient
+	var mock *mockcluster.Cluster
+	var err error
+	if config.Backend == BackendMock {
+		if mock, err = mockcluster.New(config.MockFixturesDir); err != nil {
+			return nil, fmt.Errorf("failed to create mock cluster: %w", err)
+		}
+		k8sClient = mock.Client()
+		log.Printf("WARNING: serving a simulated cluster (BACKEND=mock); scenarios can be applied at /mock/scenario")
+	} else {
+		healthRules, _ := clients.ParseHealthRules(config.HealthRules) // Checked by Validate
+		k8sClient, err = clients.NewK8sClient(&clients.K8sClientConfig{
+			QPS:               float32(config.K8sClientQPS),
+			Burst:             config.K8sClientBurst,
+			HealthConcurrency: config.HealthConcurrency,
+			HealthRules:       healthRules,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+		}
 	}
 
 	// Verify cluster connectivity
@@ -61,57 +125,162 @@ func NewMCPServer(config *Config) (*MCPServer, error) {
 	// Initialize Coordination Engine client if enabled
 	var ceClient *clients.CoordinationEngineClient
 	if config.EnableCoordinationEngine {
-		ceClient = clients.NewCoordinationEngineClient(config.CoordinationEngineURL)
+		var err error
+		ceClient, err = clients.NewCoordinationEngineClient(config.CoordinationEngineURL, clients.CoordinationEngineOptions{
+			BearerTokenFile: config.CoordinationEngineBearerTokenFile,
+			CAFile:          config.CoordinationEngineCAFile,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ceClient.SetFailureCache(memoryCache, config.DependencyFailureTTL)
 		log.Printf("Initialized Coordination Engine client: %s", config.CoordinationEngineURL)
 	} else {
 		log.Printf("Coordination Engine integration disabled (use ENABLE_COORDINATION_ENGINE=true to enable)")
 	}
 
+	// Created before the KServe client, which reports inference calls to it
+	metrics := newToolMetrics()
+
 	// Initialize KServe client if enabled
 	var kserveClient *clients.KServeClient
 	if config.EnableKServe {
+		headers, _ := parseHeaders(config.KServeHeaders) // Checked by Validate
 		kserveClient = clients.NewKServeClient(clients.KServeConfig{
-			Namespace:     config.KServeNamespace,
-			PredictorPort: config.KServePredictorPort,
-			Timeout:       config.RequestTimeout,
-			Enabled:       true,
-			RestConfig:    k8sClient.GetConfig(), // Pass Kubernetes config for CRD access
+			Namespace:       config.KServeNamespace,
+			PredictorPort:   config.KServePredictorPort,
+			Timeout:         config.RequestTimeout,
+			Enabled:         true,
+			RestConfig:      k8sClient.GetConfig(), // Pass Kubernetes config for CRD access
+			BearerTokenFile: config.KServeBearerTokenFile,
+			Headers:         headers,
+			CAFile:          config.KServeCAFile,
+			MaxRetries:      config.KServeMaxRetries,
+			RetryBudget:     config.KServeRetryBudget,
+			OnInference:     metrics.recordInference,
+			FailureCache:    memoryCache,
+			FailureTTL:      config.DependencyFailureTTL,
 		})
 		log.Printf("Initialized KServe client for namespace: %s (predictor port: %d)", config.KServeNamespace, config.KServePredictorPort)
 	} else {
 		log.Printf("KServe integration disabled (use ENABLE_KSERVE=true to enable)")
 	}
 
+	// Initialize Prometheus client if enabled
+	var prometheusClient *clients.PrometheusClient
+	if config.EnablePrometheus {
+		prometheusClient = clients.NewPrometheusClient(clients.PrometheusConfig{
+			URL:        config.PrometheusURL,
+			Timeout:    config.RequestTimeout,
+			RestConfig: k8sClient.GetConfig(), // Service account token authenticates to prometheus-k8s
+		})
+		log.Printf("Initialized Prometheus client: %s", config.PrometheusURL)
+	} else {
+		log.Printf("Prometheus integration disabled (use ENABLE_PROMETHEUS=true to enable)")
+	}
+
+	// Initialize Alertmanager client if enabled
+	var alertmanagerClient *clients.AlertmanagerClient
+	if config.EnableAlertmanager {
+		alertmanagerClient = clients.NewAlertmanagerClient(clients.AlertmanagerConfig{
+			URL:        config.AlertmanagerURL,
+			Timeout:    config.RequestTimeout,
+			RestConfig: k8sClient.GetConfig(), // Service account token authenticates to alertmanager-main
+		})
+		log.Printf("Initialized Alertmanager client: %s", config.AlertmanagerURL)
+	} else {
+		log.Printf("Alertmanager integration disabled (use ENABLE_ALERTMANAGER=true to enable)")
+	}
+
 	// Create MCP server with metadata
 	impl := &mcp.Implementation{
 		Name:    config.Name,
 		Version: config.Version,
 	}
 
-	mcpServer := mcp.NewServer(impl, nil)
+	resourceRegistry := NewResourceRegistry()
+	mcpServer := mcp.NewServer(impl, mcpServerOptions(resourceRegistry))
 
 	// Initialize session manager for REST API clients
 	// Default TTL: 30 minutes, Max sessions: 1000
-	sessionManager := NewSessionManager(30*time.Minute, 1000)
-	log.Printf("Initialized session manager (TTL: 30m, max: 1000 sessions)")
+	var sessionManager *SessionManager
+	if store := newSessionStore(config, k8sClient); store != nil {
+		sessionManager = NewPersistentSessionManager(30*time.Minute, 1000, store, config.SessionStoreMaxBytes)
+		log.Printf("Initialized session manager (TTL: 30m, max: 1000 sessions, persisted to %s store)", config.SessionStore)
+	} else {
+		sessionManager = NewSessionManager(30*time.Minute, 1000)
+		log.Printf("Initialized session manager (TTL: 30m, max: 1000 sessions)")
+	}
 
 	server := &MCPServer{
 		config:         config,
 		mcpServer:      mcpServer,
 		k8sClient:      k8sClient,
+		mock:           mock,
 		ceClient:       ceClient,
 		kserve:         kserveClient,
+		prometheus:     prometheusClient,
+		alertmanager:   alertmanagerClient,
 		cache:          memoryCache,
 		sessionManager: sessionManager,
-		tools:          make(map[string]Tool),
-		resources:      make(map[string]interface{}),
+		tools:          NewToolRegistry(),
+		sanitizer:      tools.NewSanitizer(config.RedactionPatterns),
+		toolMetrics:    metrics,
+		resources:      resourceRegistry,
 		prompts:        make(map[string]interface{}),
+		clock:          clock.RealClock{},
+	}
+	server.liveConfig.Store(config)
+
+	if config.HealthHistoryInterval > 0 {
+		server.healthHistory = newHealthHistory(config.HealthHistorySize)
+		server.healthStream = newEventStream(config.StreamReplaySize)
+		server.remediation = newRemediationEngine(server.currentConfig)
+	}
+	if config.EventHistoryEnabled {
+		server.eventHistory = newEventHistory(config, k8sClient)
+	}
+	if server.eventHistory != nil {
+		server.eventStream = newEventStream(config.StreamReplaySize)
+		server.eventHistory.stream = server.eventStream
+	}
+	if config.LogStreamMaxConcurrent > 0 {
+		server.logStreams = newLogStreams(k8sClient, config.LogStreamMaxConcurrent)
+	}
+	if config.EnableCacheWarmup {
+		server.warmupDone = make(chan struct{})
+	}
+	if server.baselines, err = newBaselineStore(baselineDirectory(config)); err != nil {
+		return nil, fmt.Errorf("failed to open baseline store: %w", err)
 	}
 
+	// Detect the platform and probe the integrations before registering the
+	// tools that depend on them. Whatever is missing is registered by a later
+	// capability probe (see probeCapabilities).
+	_ = server.platform.detect(ctx, k8sClient)
+	server.integrations = server.newIntegrations()
+	server.probeIntegrations(ctx)
+	server.probeCoordinationEngine(ctx)
+
 	// Register tools
 	if err := server.registerTools(); err != nil {
 		return nil, fmt.Errorf("failed to register tools: %w", err)
 	}
+	server.logPlatform()
+
+	if config.ReportSchedule != "" {
+		if server.reports, err = server.newReportScheduler(); err != nil {
+			return nil, fmt.Errorf("failed to schedule health reports: %w", err)
+		}
+	}
+
+	if config.ArtifactDirectory != "" {
+		if server.artifacts, err = newArtifactStore(config.ArtifactDirectory, config.ArtifactTTL, config.ArtifactMaxBytes, clock.RealClock{}); err != nil {
+			return nil, fmt.Errorf("failed to open artifact store: %w", err)
+		}
+		count, used := server.artifacts.usage()
+		log.Printf("Storing tool-result artifacts in %s (%d stored, %d of %d bytes used)", config.ArtifactDirectory, count, used, config.ArtifactMaxBytes)
+	}
 
 	// Register resources
 	if err := server.registerResources(); err != nil {
@@ -123,6 +292,9 @@ func NewMCPServer(config *Config) (*MCPServer, error) {
 		return nil, fmt.Errorf("failed to register prompts: %w", err)
 	}
 
+	// Build the OpenAPI document once the registries are populated
+	server.refreshOpenAPISpec()
+
 	log.Printf("MCP Server initialized: %s v%s", config.Name, config.Version)
 	log.Printf("Transport: %s", config.Transport)
 
@@ -131,41 +303,222 @@ func NewMCPServer(config *Config) (*MCPServer, error) {
 
 // registerTools initializes and registers all MCP tools
 func (s *MCPServer) registerTools() error {
-	// Register cluster health tool (with cache)
-	clusterHealthTool := tools.NewClusterHealthTool(s.k8sClient, s.cache)
+	// Register cluster health tool (with cache, and the health history's last sample while the API server is unreachable)
+	clusterHealthTool := tools.NewClusterHealthTool(s.k8sClient, s.cache, s.lastKnownHealthLookup())
 	s.registerTool(clusterHealthTool)
 
-	// Register list-pods tool (no cache - results change frequently)
-	listPodsTool := tools.NewListPodsTool(s.k8sClient)
+	// Register list-pods tool (listings are not cached; the cache only holds delta snapshots)
+	listPodsTool := tools.NewListPodsTool(s.k8sClient, s.cache)
 	s.registerTool(listPodsTool)
 
 	// Register calculate-pod-capacity tool (capacity planning)
 	calculatePodCapacityTool := tools.NewCalculatePodCapacityTool(s.k8sClient)
 	s.registerTool(calculatePodCapacityTool)
 
-	// Register Coordination Engine tools if enabled
+	// Register get-resource-manifest tool (live YAML for any kind, including CRDs)
+	getResourceManifestTool := tools.NewGetResourceManifestTool(s.k8sClient, s.sanitizer, tools.ManifestPolicy{
+		AllowedNamespaces: s.allowedNamespaces,
+		DeniedKinds:       s.config.ManifestDeniedKinds,
+	})
+	s.registerTool(getResourceManifestTool)
+
+	// Register raw-get only when API path prefixes are allowlisted (every call is audited)
+	if len(s.config.RawAPIAllowedPrefixes) > 0 {
+		rawGetTool := tools.NewRawGetTool(s.k8sClient, s.sanitizer, tools.RawAPIPolicy{
+			AllowedPrefixes:   s.config.RawAPIAllowedPrefixes,
+			AllowedNamespaces: s.allowedNamespaces,
+			DeniedResources:   s.config.RawAPIDeniedResources,
+		})
+		s.registerTool(rawGetTool)
+	} else {
+		log.Printf("Skipping raw-get tool (RAW_API_ALLOWED_PREFIXES not set)")
+	}
+
+	// Register rollout tools (rollback is mutating: refused in read-only mode and audited)
+	getRolloutStatusTool := tools.NewGetRolloutStatusTool(s.k8sClient)
+	s.registerTool(getRolloutStatusTool)
+
+	rollbackDeploymentTool := tools.NewRollbackDeploymentTool(s.k8sClient)
+	s.registerTool(rollbackDeploymentTool)
+
+	// Register check-permissions tool (RBAC self-check against declared tool requirements)
+	s.permissions = tools.NewCheckPermissionsTool(s.k8sClient, s.toolPermissionRequirements)
+	s.registerTool(s.permissions)
+
+	// Register aggregate-events tool (event grouping with spike detection)
+	var eventHistory *eventhistory.Store
+	if s.eventHistory != nil {
+		eventHistory = s.eventHistory.store
+	}
+	aggregateEventsTool := tools.NewAggregateEventsTool(s.k8sClient, eventHistory)
+	s.registerTool(aggregateEventsTool)
+
+	// Register search-logs tool (regex search across a workload's pod logs, with hard read caps)
+	searchLogsTool := tools.NewSearchLogsTool(s.k8sClient)
+	s.registerTool(searchLogsTool)
+
+	// Register follow-pod-logs when followed logs have an artifact to go to
+	if s.config.ArtifactDirectory != "" && s.logStreams != nil {
+		followPodLogsTool := tools.NewFollowPodLogsTool(s)
+		s.registerTool(followPodLogsTool)
+	} else {
+		log.Printf("Skipping follow-pod-logs tool (requires ARTIFACT_DIRECTORY and LOG_STREAM_MAX_CONCURRENT > 0)")
+	}
+
+	// Register extended resource health tool (GPU / huge pages accounting and device plugin health)
+	getExtendedResourceHealthTool := tools.NewGetExtendedResourceHealthTool(s.k8sClient, s.config.ExtendedResources)
+	s.registerTool(getExtendedResourceHealthTool)
+
+	// Register APF status tool (live values from Prometheus when enabled)
+	getAPFStatusTool := tools.NewGetAPFStatusTool(s.k8sClient, s.prometheus)
+	s.registerTool(getAPFStatusTool)
+
+	// Register topology spread tool (zone balance of nodes, capacity, and replicas)
+	analyzeTopologySpreadTool := tools.NewAnalyzeTopologySpreadTool(s.k8sClient)
+	s.registerTool(analyzeTopologySpreadTool)
+
+	// Register pod churn tool (restart-storm detection)
+	getPodChurnTool := tools.NewGetPodChurnTool(s.k8sClient)
+	s.registerTool(getPodChurnTool)
+
+	// Register right-sizing tool (p95 usage from Prometheus when enabled, else metrics-server)
+	getRightsizingTool := tools.NewGetRightsizingRecommendationsTool(s.k8sClient, s.prometheus)
+	s.registerTool(getRightsizingTool)
+
+	// Register volume usage tool (kubelet_volume_stats from Prometheus when enabled, else the kubelet stats summary)
+	getVolumeUsageTool := tools.NewGetVolumeUsageTool(s.k8sClient, s.prometheus)
+	s.registerTool(getVolumeUsageTool)
+
+	// Register namespace footprint tool (quota-free accounting; usage from metrics-server when served)
+	getNamespaceFootprintTool := tools.NewGetNamespaceFootprintTool(s.k8sClient, s.cache)
+	s.registerTool(getNamespaceFootprintTool)
+
+	// Register pod security tool (Pod Security Standards checks run locally, so always available)
+	getPodSecurityViolationsTool := tools.NewGetPodSecurityViolationsTool(s.k8sClient)
+	s.registerTool(getPodSecurityViolationsTool)
+
+	// Register CSI health tool (core storage APIs, so always available)
+	getCSIHealthTool := tools.NewGetCSIHealthTool(s.k8sClient)
+	s.registerTool(getCSIHealthTool)
+
+	// Register baseline tools (baselines persist under ARTIFACT_DIRECTORY; OpenShift facts only when served)
+	captureBaselineTool := tools.NewCaptureBaselineTool(s.k8sClient, s.baselines, s.platform.served(clients.OpenShiftAPIGroup))
+	s.registerTool(captureBaselineTool)
+
+	checkDriftTool := tools.NewCheckDriftTool(s.k8sClient, s.baselines, s.platform.served(clients.OpenShiftAPIGroup))
+	s.registerTool(checkDriftTool)
+
+	// Register noisy neighbor tool (pods in critical namespaces are reported as affected)
+	detectNoisyNeighborsTool := tools.NewDetectNoisyNeighborsTool(s.k8sClient, s.config.CriticalNamespaces)
+	s.registerTool(detectNoisyNeighborsTool)
+
+	// Register upgrade readiness tool (OpenShift-only checks and alerts are skipped when unavailable)
+	assessUpgradeReadinessTool := tools.NewAssessUpgradeReadinessTool(s.k8sClient, s.prometheus, s.platform.served(clients.OpenShiftAPIGroup))
+	s.registerTool(assessUpgradeReadinessTool)
+
+	// Register finalizer audit tool (scans FINALIZER_AUDIT_KINDS on top of namespaces, PVCs and CRDs)
+	auditFinalizersTool := tools.NewAuditFinalizersTool(s.k8sClient, s.config.FinalizerAuditKinds)
+	s.registerTool(auditFinalizersTool)
+
+	// Register kubelet health tool (the /healthz probe needs ENABLE_KUBELET_HEALTHZ_PROBE)
+	getKubeletHealthTool := tools.NewGetKubeletHealthTool(s.k8sClient, tools.KubeletHealthConfig{
+		LeaseStaleAfter:  s.config.KubeletLeaseStaleAfter,
+		StatusStaleAfter: s.config.KubeletStatusStaleAfter,
+		HealthzProbe:     s.config.KubeletHealthzProbe,
+	})
+	s.registerTool(getKubeletHealthTool)
+
+	// Register autoscaler status tool (MachineSet, Machine and autoscaler CR checks only when their groups are served)
+	getAutoscalerStatusTool := tools.NewGetAutoscalerStatusTool(s.k8sClient, s.platform.served("machine.openshift.io"), s.platform.served("autoscaling.openshift.io"))
+	s.registerTool(getAutoscalerStatusTool)
+
+	// Register health report tool (operators on OpenShift, alerts with Prometheus, compare_to with the health history)
+	var healthHistoryLookup tools.HealthHistoryLookup
+	if s.healthHistory != nil {
+		healthHistoryLookup = s.healthHistory.baselineAt
+	}
+	generateHealthReportTool := tools.NewGenerateHealthReportTool(s.k8sClient, s.prometheus, s.platform.served(clients.OpenShiftAPIGroup), healthHistoryLookup)
+	s.registerTool(generateHealthReportTool)
+
+	// Register remediation policy dry-run (the policies are evaluated after each health history sample)
+	if s.remediation != nil {
+		testRemediationPolicyTool := tools.NewTestRemediationPolicyTool(s.k8sClient, s.remediation)
+		s.registerTool(testRemediationPolicyTool)
+	}
+
+	// Register capacity forecast tool (history from Prometheus; KServe forecasting model when enabled, else the local forecaster)
+	if s.prometheus != nil {
+		forecastCapacityTool := tools.NewForecastCapacityTool(s.k8sClient, s.prometheus, s.kserve, s.config.KServeForecastModel)
+		s.registerTool(forecastCapacityTool)
+	} else {
+		log.Printf("Skipping forecast-capacity tool (requires Prometheus)")
+	}
+
+	// Register alert silence tools (create-alert-silence is mutating: audited, refused in read-only mode)
+	if s.alertmanager != nil {
+		createAlertSilenceTool := tools.NewCreateAlertSilenceTool(s.alertmanager, s.config.AlertSilenceMaxDuration)
+		s.registerTool(createAlertSilenceTool)
+
+		listAlertSilencesTool := tools.NewListAlertSilencesTool(s.alertmanager)
+		s.registerTool(listAlertSilencesTool)
+	} else {
+		log.Printf("Skipping alert silence tools (requires Alertmanager)")
+	}
+
+	// Register OpenShift-only tools (Insights report, must-gather, network health).
+	// On other clusters they are deferred until config.openshift.io is served.
+	getInsightsReportTool := tools.NewGetInsightsReportTool(s.k8sClient)
+	s.registerToolIfServed(getInsightsReportTool, clients.OpenShiftAPIGroup)
+
+	mustGatherConfig := tools.MustGatherConfig{
+		Image:          s.config.MustGatherImage,
+		Namespace:      s.config.MustGatherNamespace,
+		ServiceAccount: s.config.MustGatherServiceAccount,
+		PVC:            s.config.MustGatherPVC,
+		Retention:      s.config.MustGatherRetention,
+	}
+	triggerMustGatherTool := tools.NewTriggerMustGatherTool(s.k8sClient, mustGatherConfig)
+	s.registerToolIfServed(triggerMustGatherTool, clients.OpenShiftAPIGroup)
+
+	getMustGatherStatusTool := tools.NewGetMustGatherStatusTool(s.k8sClient, mustGatherConfig.Namespace)
+	s.registerToolIfServed(getMustGatherStatusTool, clients.OpenShiftAPIGroup)
+
+	getNetworkHealthTool := tools.NewGetNetworkHealthTool(s.k8sClient)
+	s.registerToolIfServed(getNetworkHealthTool, clients.OpenShiftAPIGroup)
+
+	// Register registry health tool when the cluster serves ImageStreams (Builds are optional)
+	getRegistryHealthTool := tools.NewGetRegistryHealthTool(s.k8sClient, s.platform.served("build.openshift.io"))
+	s.registerToolIfServed(getRegistryHealthTool, "image.openshift.io")
+
+	// Register Coordination Engine tools if enabled (while its health endpoint answers)
 	if s.ceClient != nil {
 		listIncidentsTool := tools.NewListIncidentsTool(s.ceClient)
-		s.registerTool(listIncidentsTool)
+		s.registerIntegrationTool(integrationCoordinationEngine, listIncidentsTool)
+
+		triggerRemediationTool := tools.NewTriggerRemediationTool(s.ceClient, s.cache)
+		s.registerIntegrationTool(integrationCoordinationEngine, triggerRemediationTool)
 
-		triggerRemediationTool := tools.NewTriggerRemediationTool(s.ceClient)
-		s.registerTool(triggerRemediationTool)
+		listPlaybooksTool := tools.NewListRemediationPlaybooksTool(s.ceClient, s.cache)
+		s.registerIntegrationTool(integrationCoordinationEngine, listPlaybooksTool)
 
 		// NEW: Remediation recommendations tool (ML predictions)
 		remediationRecsTool := tools.NewGetRemediationRecommendationsTool(s.ceClient)
-		s.registerTool(remediationRecsTool)
+		s.registerIntegrationTool(integrationCoordinationEngine, remediationRecsTool)
 
 		// NEW: Create incident tool
 		createIncidentTool := tools.NewCreateIncidentTool(s.ceClient)
-		s.registerTool(createIncidentTool)
+		s.registerIntegrationTool(integrationCoordinationEngine, createIncidentTool)
+
+		correlateIncidentTool := tools.NewCorrelateIncidentTool(s.ceClient, s.k8sClient, s.prometheus)
+		s.registerIntegrationTool(integrationCoordinationEngine, correlateIncidentTool)
 
 		// NEW: Predict resource usage tool (time-specific forecasting)
 		predictResourceUsageTool := tools.NewPredictResourceUsageTool(s.ceClient, s.k8sClient)
-		s.registerTool(predictResourceUsageTool)
+		s.registerIntegrationTool(integrationCoordinationEngine, predictResourceUsageTool)
 
 		// NEW: Analyze scaling impact tool (capacity planning)
 		analyzeScalingImpactTool := tools.NewAnalyzeScalingImpactTool(s.ceClient, s.k8sClient)
-		s.registerTool(analyzeScalingImpactTool)
+		s.registerIntegrationTool(integrationCoordinationEngine, analyzeScalingImpactTool)
 	} else {
 		log.Printf("Skipping Coordination Engine tools (not enabled)")
 	}
@@ -173,28 +526,35 @@ func (s *MCPServer) registerTools() error {
 	// Register KServe tools if enabled
 	if s.kserve != nil && s.ceClient != nil {
 		// analyze-anomalies requires both KServe and Coordination Engine
-		// The Coordination Engine handles feature engineering (45 features) and calls KServe
+		// The Coordination Engine handles feature engineering (45 features) and calls KServe,
+		// so the tool follows the Coordination Engine's availability
 		analyzeAnomaliesTool := tools.NewAnalyzeAnomaliesTool(s.kserve, s.ceClient)
-		s.registerTool(analyzeAnomaliesTool)
+		s.registerIntegrationTool(integrationCoordinationEngine, analyzeAnomaliesTool)
 
 		getModelStatusTool := tools.NewGetModelStatusTool(s.kserve)
-		s.registerTool(getModelStatusTool)
+		s.registerIntegrationTool(integrationKServe, getModelStatusTool)
 
 		listModelsTool := tools.NewListModelsTool(s.kserve)
-		s.registerTool(listModelsTool)
+		s.registerIntegrationTool(integrationKServe, listModelsTool)
+
+		getModelMetricsTool := tools.NewGetModelMetricsTool(s.kserve, s.prometheus)
+		s.registerIntegrationTool(integrationKServe, getModelMetricsTool)
 	} else if s.kserve != nil && s.ceClient == nil {
 		log.Printf("Skipping analyze-anomalies tool (requires Coordination Engine for feature engineering)")
 		// Register other KServe tools that don't require Coordination Engine
 		getModelStatusTool := tools.NewGetModelStatusTool(s.kserve)
-		s.registerTool(getModelStatusTool)
+		s.registerIntegrationTool(integrationKServe, getModelStatusTool)
 
 		listModelsTool := tools.NewListModelsTool(s.kserve)
-		s.registerTool(listModelsTool)
+		s.registerIntegrationTool(integrationKServe, listModelsTool)
+
+		getModelMetricsTool := tools.NewGetModelMetricsTool(s.kserve, s.prometheus)
+		s.registerIntegrationTool(integrationKServe, getModelMetricsTool)
 	} else {
 		log.Printf("Skipping KServe tools (not enabled)")
 	}
 
-	log.Printf("Total tools registered: %d", len(s.tools))
+	log.Printf("Total tools registered: %d", s.toolCount())
 	return nil
 }
 
@@ -206,26 +566,81 @@ type Tool interface {
 	Execute(ctx context.Context, args map[string]interface{}) (interface{}, error)
 }
 
-// registerTool registers a tool with both our internal map and the MCP SDK
+// RegisterTool adds a tool to the server, to be served over MCP and REST
+// exactly like the built-in tools. It lets programs embedding the server
+// contribute their own tools, and must be called before Start. Tools
+// excluded by ENABLED_TOOLS/DISABLED_TOOLS are skipped without an error.
+func (s *MCPServer) RegisterTool(tool Tool) error {
+	if s.started.Load() {
+		return ErrServerStarted
+	}
+	if err := s.addTool(tool); err != nil {
+		return err
+	}
+	s.refreshOpenAPISpec()
+	return nil
+}
+
+// registerTool registers a built-in tool, logging instead of failing when
+// its name is taken
 func (s *MCPServer) registerTool(tool Tool) {
-	// Store in our internal map
-	s.tools[tool.Name()] = tool
+	if err := s.addTool(tool); err != nil {
+		log.Printf("WARNING: not registering tool: %v", err)
+	}
+}
 
-	// Create MCP tool definition
-	mcpTool := &mcp.Tool{
+// addTool registers a tool with both our registry and the MCP SDK
+// Tools excluded by ENABLED_TOOLS/DISABLED_TOOLS are skipped entirely
+func (s *MCPServer) addTool(tool Tool) error {
+	if !s.config.IsToolEnabled(tool.Name()) {
+		log.Printf("Skipping tool: %s (disabled by configuration)", tool.Name())
+		return nil
+	}
+
+	aliases := toolAliases(tool)
+	aliasNames := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		aliasNames = append(aliasNames, alias.Name)
+	}
+	if err := s.tools.Register(tool.Name(), tool, aliasNames...); err != nil {
+		return err
+	}
+
+	// Register with MCP SDK, each alias as a tool of its own
+	mcp.AddTool(s.mcpServer, &mcp.Tool{
 		Name:        tool.Name(),
 		Description: tool.Description(),
-		InputSchema: tool.InputSchema(),
+		InputSchema: toolInputSchema(tool),
+	}, s.mcpToolHandler(tool, ""))
+	for _, alias := range aliases {
+		mcp.AddTool(s.mcpServer, &mcp.Tool{
+			Name:        alias.Name,
+			Description: aliasDescription(tool, alias),
+			InputSchema: toolInputSchema(tool),
+		}, s.mcpToolHandler(tool, alias.Name))
 	}
 
-	// Create handler function that wraps our tool's Execute method
-	handler := func(ctx context.Context, req *mcp.CallToolRequest, params map[string]interface{}) (*mcp.CallToolResult, any, error) {
+	log.Printf("Registered tool: %s - %s", tool.Name(), tool.Description())
+	if len(aliasNames) > 0 {
+		log.Printf("Registered aliases of %s: %s", tool.Name(), strings.Join(aliasNames, ", "))
+	}
+	return nil
+}
+
+// mcpToolHandler returns the MCP SDK handler of tool, called under alias
+// when it is not empty
+func (s *MCPServer) mcpToolHandler(tool Tool, alias string) mcp.ToolHandlerFor[map[string]interface{}, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, params map[string]interface{}) (*mcp.CallToolResult, any, error) {
+		if alias != "" {
+			ctx = withToolAlias(ctx, alias)
+		}
+
 		// Add timeout enforcement to prevent hanging on slow operations
-		timeoutCtx, cancel := context.WithTimeout(ctx, s.config.RequestTimeout)
+		timeoutCtx, cancel := s.callContext(ctx, s.currentConfig().RequestTimeout)
 		defer cancel()
 
 		// Execute the tool with timeout context
-		result, err := tool.Execute(timeoutCtx, params)
+		result, meta, err := s.executeTool(timeoutCtx, tool, params)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -238,6 +653,7 @@ func (s *MCPServer) registerTool(tool Tool) {
 
 		// Return as MCP CallToolResult
 		return &mcp.CallToolResult{
+			Meta: meta.mcpMeta(),
 			Content: []mcp.Content{
 				&mcp.TextContent{
 					Text: string(resultJSON),
@@ -245,40 +661,203 @@ func (s *MCPServer) registerTool(tool Tool) {
 			},
 		}, nil, nil
 	}
+}
 
-	// Register with MCP SDK
-	mcp.AddTool(s.mcpServer, mcpTool, handler)
+// executeTool runs a tool inside a trace span, handles the dry_run argument of mutating
+// tools, enforces read-only mode, records its metrics (and an audit entry for mutating
+// and audited tools), sanitizes its result, and truncates it to the result budget.
+// Every dispatch path goes through here so secret material never reaches clients.
+// The returned meta is the response's _meta block: where the data came from and
+// how long it may be reused. Calls made through an alias (see withToolAlias) are
+// recorded under the tool's name and get a deprecation warning in their meta.
+func (s *MCPServer) executeTool(ctx context.Context, tool Tool, args map[string]interface{}) (result interface{}, meta *ResponseMeta, err error) {
+	attributes := []attribute.KeyValue{attribute.String("mcp.tool.name", tool.Name())}
+	alias := toolAliasFromContext(ctx)
+	if alias != "" {
+		attributes = append(attributes, attribute.String("mcp.tool.alias", alias))
+	}
+	// Every call of a tenant is audited, denials included
+	tenant := s.tenantFor(ctx)
+	if tenant != nil {
+		attributes = append(attributes, attribute.String("mcp.tenant", tenant.Name))
+		ctx = withTenant(ctx, tenant)
+	}
+	ctx, span := tracing.StartSpan(ctx, "tool "+tool.Name(), attributes...)
+	start := time.Now()
+	executed := false // Set once the tool runs, past the dispatcher's checks
+	defer func() {
+		s.observeToolCall(ctx, tool.Name(), args, time.Since(start), result, err)
+		if isAudited(tool) || tenant != nil {
+			s.auditToolCall(ctx, tool.Name(), args, err)
+		}
+		if executed && isMutating(tool) && !tools.IsDryRun(ctx) && !tools.IsInvalidArguments(err) {
+			s.emitActionEvent(ctx, tool, args, err)
+		}
+		tracing.EndSpan(span, err)
+	}()
 
-	log.Printf("Registered tool: %s - %s", tool.Name(), tool.Description())
+	callArgs, dryRun, err := takeDryRun(tool, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dryRun {
+		ctx = tools.WithDryRun(ctx)
+	}
+	if s.artifacts != nil {
+		ctx = tools.WithArtifacts(ctx)
+	}
+	callArgs, budget, err := takeMaxBytes(callArgs, s.currentConfig().MaxResultBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Durations and times arrive as strings such as "15m" or "now-1h"
+	if callArgs, err = tools.NormalizeTimeArgs(tool.InputSchema(), callArgs, s.clock.Now()); err != nil {
+		return nil, nil, err
+	}
+	if err = s.checkReadOnly(ctx, tool); err != nil {
+		return nil, nil, err
+	}
+	if err = s.checkToolPermissions(tool); err != nil {
+		return nil, nil, err
+	}
+	if tenant != nil {
+		if ctx, err = s.checkTenant(ctx, tenant, tool, callArgs); err != nil {
+			return nil, nil, err
+		}
+	}
+	if ctx, err = s.checkAllowedNamespaces(ctx, tool, callArgs); err != nil {
+		return nil, nil, err
+	}
+
+	executed = true
+	execute := func() (interface{}, *ResponseMeta, error) {
+		// Coalesced calls wait for the leader's execution, not for a slot
+		cfg := s.currentConfig()
+		weight := toolWeight(tool)
+		release, waited, err := s.scheduler.acquire(ctx, weight, cfg.toolLimit(weight), cfg.ToolQueueTimeout, s.toolMetrics)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer release()
+		span.SetAttributes(attribute.String("mcp.tool.weight", string(weight)), attribute.Int64("mcp.tool.queue_wait_ms", waited.Milliseconds()))
+		return s.runTool(ctx, tool, callArgs, budget)
+	}
+	// Identical concurrent calls of read-only tools share one execution
+	coalesced := false
+	if key := coalesceKey(ctx, tool, args); isMutating(tool) || key == "" {
+		result, meta, err = execute()
+	} else {
+		result, meta, coalesced, err = s.coalescer.do(ctx, key, execute)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if coalesced || alias != "" {
+		shared := *meta // The leader's meta is shared with its followers
+		meta = &shared
+		meta.Coalesced = coalesced
+	}
+	if alias != "" {
+		meta.Deprecation = newDeprecationNotice(tool, alias)
+	}
+	return result, meta, nil
+}
+
+// runTool executes tool once the dispatcher's checks passed, and returns its
+// sanitized result, cut to budget, with the response's _meta block
+func (s *MCPServer) runTool(ctx context.Context, tool Tool, args map[string]interface{}, budget int) (interface{}, *ResponseMeta, error) {
+	ctx, reads := cache.WithReadLog(ctx)
+	ctx, throttles := clients.WithThrottleLog(ctx)
+	// Helpers of composite tools share the API reads of this call, and only of this call
+	ctx = clients.WithRequestCache(ctx)
+	result, err := tool.Execute(ctx, args)
+	if err != nil {
+		return nil, nil, withThrottling(err, throttles)
+	}
+	// Tools only return artifacts when the store is enabled
+	if artifacts, ok := result.(tools.ArtifactResult); ok && s.artifacts != nil {
+		if result, err = s.storeArtifacts(ctx, tool.Name(), artifacts); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sanitizer := s.sanitizer
+	if sanitizer == nil {
+		sanitizer = tools.NewSanitizer(nil)
+	}
+	sanitized, err := sanitizer.Sanitize(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result, err = applyResultBudget(tool, result, sanitized, budget, sanitizer); err != nil {
+		return nil, nil, err
+	}
+	return result, s.newResponseMeta(tool, reads, throttles), nil
 }
 
 // registerResources initializes and registers all MCP resources
 func (s *MCPServer) registerResources() error {
 	// Register cluster://health resource (always available)
-	clusterHealthResource := resources.NewClusterHealthResource(s.k8sClient, s.ceClient, s.cache)
-	s.resources[clusterHealthResource.URI()] = clusterHealthResource
-	log.Printf("Registered resource: %s - %s", clusterHealthResource.URI(), clusterHealthResource.Name())
+	clusterHealthResource := resources.NewClusterHealthResource(s.k8sClient, s.ceClient, s.cache, s.lastKnownHealthLookup())
+	s.registerResource(clusterHealthResource)
 
 	// Register cluster://nodes resource (always available)
 	nodesResource := resources.NewNodesResource(s.k8sClient, s.cache)
-	s.resources[nodesResource.URI()] = nodesResource
-	log.Printf("Registered resource: %s - %s", nodesResource.URI(), nodesResource.Name())
+	s.registerResource(nodesResource)
 
-	// Register cluster://incidents resource (if Coordination Engine enabled)
+	// Register cluster://namespaces resource (always available, limited to ALLOWED_NAMESPACES)
+	namespacesResource := resources.NewNamespacesResource(s.k8sClient, s.cache, s.allowedNamespaces, s.config.NamespacesResourceMaxEntries)
+	s.registerResource(namespacesResource)
+
+	// Register cluster://events resource (always available, limited to ALLOWED_NAMESPACES)
+	eventSeverityRules, _ := resources.ParseEventSeverityRules(s.config.EventSeverityRules) // Checked by Validate
+	eventsResource := resources.NewEventsResource(s.k8sClient, s.cache, s.allowedNamespaces, eventSeverityRules, s.config.EventsResourceMaxPerBucket, s.config.EventsResourceIncludeInfo)
+	s.registerResource(eventsResource)
+
+	// Register cluster://incidents resource (if Coordination Engine enabled, while it is reachable)
 	if s.ceClient != nil {
-		incidentsResource := resources.NewIncidentsResource(s.ceClient, s.cache)
-		s.resources[incidentsResource.URI()] = incidentsResource
-		log.Printf("Registered resource: %s - %s", incidentsResource.URI(), incidentsResource.Name())
+		s.incidents = resources.NewIncidentsResource(s.ceClient, s.cache)
+		s.registerIntegrationResource(integrationCoordinationEngine, s.incidents)
 
 		// NEW: Remediation history resource
 		remediationHistoryResource := resources.NewRemediationHistoryResource(s.ceClient, s.cache)
-		s.resources[remediationHistoryResource.URI()] = remediationHistoryResource
-		log.Printf("Registered resource: %s - %s", remediationHistoryResource.URI(), remediationHistoryResource.Name())
+		s.registerIntegrationResource(integrationCoordinationEngine, remediationHistoryResource)
 	} else {
 		log.Printf("Skipping cluster://incidents resource (Coordination Engine not enabled)")
 	}
 
-	log.Printf("Total resources registered: %d", len(s.resources))
+	log.Printf("Total resources registered: %d", s.resourceCount())
+	return nil
+}
+
+// RegisterResource adds a resource to the server, to be listed and read
+// like the built-in resources. Like RegisterTool, it must be called before
+// Start.
+func (s *MCPServer) RegisterResource(resource Resource) error {
+	if s.started.Load() {
+		return ErrServerStarted
+	}
+	if err := s.addResource(resource); err != nil {
+		return err
+	}
+	s.refreshOpenAPISpec()
+	return nil
+}
+
+// registerResource registers a built-in resource, logging instead of
+// failing when its URI is taken
+func (s *MCPServer) registerResource(resource Resource) {
+	if err := s.addResource(resource); err != nil {
+		log.Printf("WARNING: not registering resource: %v", err)
+	}
+}
+
+// addResource adds a resource to the registry
+func (s *MCPServer) addResource(resource Resource) error {
+	if err := s.resources.Register(resource.URI(), resource); err != nil {
+		return err
+	}
+	log.Printf("Registered resource: %s - %s", resource.URI(), resource.Name())
 	return nil
 }
 
@@ -340,27 +919,88 @@ func (s *MCPServer) registerPrompt(prompt prompts.Prompt) {
 	log.Printf("Registered prompt: %s - %s", prompt.Name(), prompt.Description())
 }
 
-// GetTools returns all registered tools
+// GetTools returns a snapshot of the registered tools
 func (s *MCPServer) GetTools() map[string]Tool {
-	return s.tools
+	return s.tools.Snapshot()
+}
+
+// lookupTool returns the registered tool with the given name
+func (s *MCPServer) lookupTool(name string) (Tool, bool) {
+	return s.tools.Get(name)
+}
+
+// toolCount returns the number of registered tools
+func (s *MCPServer) toolCount() int {
+	return s.tools.Len()
 }
 
-// GetResources returns all registered resources
-func (s *MCPServer) GetResources() map[string]interface{} {
-	return s.resources
+// GetResources returns a snapshot of the registered resources
+func (s *MCPServer) GetResources() map[string]Resource {
+	return s.resources.Snapshot()
+}
+
+// lookupResource returns the registered resource with the given URI
+func (s *MCPServer) lookupResource(uri string) (Resource, bool) {
+	return s.resources.Get(uri)
+}
+
+// resourceCount returns the number of registered resources
+func (s *MCPServer) resourceCount() int {
+	return s.resources.Len()
 }
 
 // Start begins serving MCP requests using the configured transport
-// As of 2025-12-17, only HTTP/SSE transport is supported (stdio DEPRECATED)
+// As of 2025-12-17, stdio is DEPRECATED; websocket adds /mcp/ws to the HTTP/SSE transport
 func (s *MCPServer) Start(ctx context.Context) error {
+	s.started.Store(true)
+
+	// Report missing RBAC early, and refuse calls of tools lacking it, instead of
+	// failing with Forbidden deep inside client-go
+	if s.permissions != nil {
+		go s.watchPermissions(ctx, s.config.PermissionCheckInterval)
+	}
+
+	if s.healthHistory != nil {
+		go s.recordHealthHistory(ctx, s.config.HealthHistoryInterval)
+	}
+	// Follow CE incidents by polling when the engine does not push them
+	if s.incidents != nil && s.config.CEIncidentPollInterval > 0 {
+		go s.pollIncidents(ctx, s.config.CEIncidentPollInterval)
+	}
+
+	if s.eventHistory != nil {
+		go s.eventHistory.run(ctx, s.config.EventHistoryLeaderElection, s.config.EventHistoryLeaseNamespace)
+	}
+
+	// Follow integrations and API groups as they come and go
+	if s.config.CapabilityProbeInterval > 0 {
+		go s.probeCapabilities(ctx, s.config.CapabilityProbeInterval)
+	}
+
+	if s.reports != nil {
+		if s.config.ReportLeaderElection {
+			go s.reports.electLeader(ctx, s.k8sClient.Clientset(), s.config.ReportLeaseNamespace, leaderIdentity())
+		}
+		go s.reports.run(ctx)
+	}
+
+	if s.artifacts != nil {
+		go s.artifacts.run(ctx)
+	}
+
+	// Pre-compute the expensive reads so the first request does not pay for them
+	if s.warmupDone != nil {
+		go s.warmCache(ctx, s.config.CacheWarmupTimeout)
+	}
+
 	switch s.config.Transport {
-	case TransportHTTP:
+	case TransportHTTP, TransportWebSocket:
 		return s.startHTTPTransport(ctx)
 	case TransportStdio:
 		// stdio transport DEPRECATED - return error
 		return s.startStdioTransport(ctx)
 	default:
-		return fmt.Errorf("unsupported transport: %s (only 'http' is supported)", s.config.Transport)
+		return fmt.Errorf("unsupported transport: %s (only 'http' and 'websocket' are supported)", s.config.Transport)
 	}
 }
 
@@ -369,6 +1009,47 @@ func (s *MCPServer) startHTTPTransport(ctx context.Context) error {
 	addr := s.config.GetHTTPAddr()
 	log.Printf("Starting HTTP transport on %s", addr)
 
+	s.httpServer = &http.Server{
+		Addr:              addr,
+		Handler:           s.httpHandler(),
+		ReadHeaderTimeout: s.config.HTTPReadHeaderTimeout,
+		ReadTimeout:       s.config.HTTPReadTimeout,
+		IdleTimeout:       s.config.HTTPIdleTimeout,
+	}
+	// WebSocket connections are hijacked, so Shutdown does not wait for them
+	s.httpServer.RegisterOnShutdown(s.websockets.closeAll)
+	// Event streams never end by themselves, so Shutdown would wait for them
+	s.httpServer.RegisterOnShutdown(func() {
+		s.healthStream.close()
+		s.eventStream.close()
+		s.logStreams.close()
+	})
+
+	// Start server in goroutine
+	errChan := make(chan error, 1)
+	go func() {
+		log.Printf("MCP Server listening on %s", addr)
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- fmt.Errorf("HTTP server error: %w", err)
+		}
+	}()
+
+	// Wait for context cancellation or error
+	select {
+	case <-ctx.Done():
+		log.Println("Shutting down HTTP server...")
+		// Add timeout to graceful shutdown
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return s.httpServer.Shutdown(shutdownCtx)
+	case err := <-errChan:
+		return err
+	}
+}
+
+// httpHandler builds the HTTP handler serving MCP (SSE), REST, and health endpoints.
+// Every request gets a server span; incoming traceparent headers are honored.
+func (s *MCPServer) httpHandler() http.Handler {
 	// Create the MCP SSE handler (handles SSE transport for OpenShift Lightspeed compatibility)
 	// OpenShift Lightspeed expects SSE transport at the root endpoint
 	// Reference: https://github.com/openshift/lightspeed-service/
@@ -381,27 +1062,58 @@ func (s *MCPServer) startHTTPTransport(ctx context.Context) error {
 		// Route specific endpoints to their handlers
 		switch {
 		case r.URL.Path == "/health":
-			w.WriteHeader(http.StatusOK)
-			if _, err := fmt.Fprint(w, "OK"); err != nil {
-				log.Printf("Error writing health response: %v", err)
-			}
+			s.handleHealth(w, r)
 			return
 		case r.URL.Path == "/ready":
+			if s.warmingUp() {
+				setRetryAfter(w, defaultRetryAfter)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				if _, err := fmt.Fprint(w, "WARMING UP"); err != nil {
+					log.Printf("Error writing ready response: %v", err)
+				}
+				return
+			}
 			w.WriteHeader(http.StatusOK)
 			if _, err := fmt.Fprint(w, "READY"); err != nil {
 				log.Printf("Error writing ready response: %v", err)
 			}
 			return
 		case r.URL.Path == "/metrics":
-			// TODO: Implement Prometheus metrics in Phase 3
-			w.WriteHeader(http.StatusOK)
-			if _, err := fmt.Fprint(w, "# Metrics endpoint (Phase 3)\n"); err != nil {
-				log.Printf("Error writing metrics response: %v", err)
-			}
+			s.handleMetrics(w, r)
 			return
 		case r.URL.Path == "/cache/stats":
 			s.handleCacheStats(w, r)
 			return
+		case r.URL.Path == "/openapi.json":
+			s.handleOpenAPI(w, r)
+			return
+		case r.URL.Path == "/admin/reload":
+			s.handleAdminReload(w, r)
+			return
+		case r.URL.Path == "/export/health":
+			s.handleExportHealth(w, r)
+			return
+		case r.URL.Path == "/export/events":
+			s.handleExportEvents(w, r)
+			return
+		case r.URL.Path == "/stream/health":
+			s.handleHealthStream(w, r)
+			return
+		case r.URL.Path == "/stream/events":
+			s.handleEventStream(w, r)
+			return
+		case r.URL.Path == "/reports/status":
+			s.handleReportStatus(w, r)
+			return
+		case r.URL.Path == "/mock/scenario":
+			s.handleMockScenario(w, r)
+			return
+		case r.URL.Path == s.config.CEWebhookPath:
+			s.handleCEWebhook(w, r)
+			return
+		case strings.HasPrefix(r.URL.Path, "/artifacts/"):
+			s.handleArtifact(w, r)
+			return
 		case r.URL.Path == "/mcp/capabilities":
 			s.handleMCPCapabilities(w, r)
 			return
@@ -411,9 +1123,18 @@ func (s *MCPServer) startHTTPTransport(ctx context.Context) error {
 		case r.URL.Path == "/mcp/tools":
 			s.handleListTools(w, r)
 			return
+		case r.URL.Path == "/mcp/tools/stats":
+			s.handleToolStats(w, r)
+			return
+		case r.URL.Path == "/mcp/tools/manifest":
+			s.handleToolManifest(w, r)
+			return
 		case r.URL.Path == "/mcp/resources":
 			s.handleListResources(w, r)
 			return
+		case r.URL.Path == "/mcp/stream/logs":
+			s.handleLogStream(w, r)
+			return
 		case r.URL.Path == "/mcp/prompts":
 			s.handleListPrompts(w, r)
 			return
@@ -421,6 +1142,12 @@ func (s *MCPServer) startHTTPTransport(ctx context.Context) error {
 		case r.URL.Path == "/mcp/session":
 			s.handleSession(w, r)
 			return
+		case r.URL.Path == "/mcp/sessions":
+			s.handleSessions(w, r)
+			return
+		case r.URL.Path == "/mcp/ws":
+			s.handleWebSocket(w, r)
+			return
 		case r.URL.Path == "/mcp/sessions/stats":
 			s.handleSessionStats(w, r)
 			return
@@ -435,6 +1162,9 @@ func (s *MCPServer) startHTTPTransport(ctx context.Context) error {
 		case strings.HasPrefix(r.URL.Path, "/mcp/resources/") && strings.HasSuffix(r.URL.Path, "/read"):
 			s.handleResourceRead(w, r)
 			return
+		case strings.HasPrefix(r.URL.Path, "/mcp/resources/") && strings.HasSuffix(r.URL.Path, "/metadata"):
+			s.handleResourceMetadata(w, r)
+			return
 		default:
 			// All other paths go to MCP handler (including root "/")
 			// This supports GET (SSE) and POST (messages) for MCP protocol
@@ -443,31 +1173,26 @@ func (s *MCPServer) startHTTPTransport(ctx context.Context) error {
 		}
 	})
 
-	s.httpServer = &http.Server{
-		Addr:    addr,
-		Handler: mainHandler,
-	}
-
-	// Start server in goroutine
-	errChan := make(chan error, 1)
-	go func() {
-		log.Printf("MCP Server listening on %s", addr)
-		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			errChan <- fmt.Errorf("HTTP server error: %w", err)
-		}
-	}()
+	return tracing.WrapHandler(withRequestID(withPriority(s.withCORS(s.withAuth(s.withTenantRoutes(s.limitRequestBody(mainHandler)))))), "mcp-server", spanRouteName)
+}
 
-	// Wait for context cancellation or error
-	select {
-	case <-ctx.Done():
-		log.Println("Shutting down HTTP server...")
-		// Add timeout to graceful shutdown
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		return s.httpServer.Shutdown(shutdownCtx)
-	case err := <-errChan:
-		return err
-	}
+// spanRouteName names request spans by route so that IDs in the path
+// don't create one span name per session, tool, or resource
+func spanRouteName(r *http.Request) string {
+	path := r.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/mcp/session/"):
+		path = "/mcp/session/{sessionid}"
+	case strings.HasPrefix(path, "/artifacts/"):
+		path = "/artifacts/{id}"
+	case strings.HasPrefix(path, "/mcp/tools/") && strings.HasSuffix(path, "/call"):
+		path = "/mcp/tools/{tool}/call"
+	case strings.HasPrefix(path, "/mcp/resources/") && strings.HasSuffix(path, "/read"):
+		path = "/mcp/resources/{uri}/read"
+	case strings.HasPrefix(path, "/mcp/resources/") && strings.HasSuffix(path, "/metadata"):
+		path = "/mcp/resources/{uri}/metadata"
+	}
+	return r.Method + " " + path
 }
 
 // startStdioTransport is DEPRECATED as of 2025-12-17
@@ -501,8 +1226,8 @@ func (s *MCPServer) handleMCPCapabilities(w http.ResponseWriter, r *http.Request
 		"name":    s.config.Name,
 		"version": s.config.Version,
 		"capabilities": map[string]bool{
-			"tools":     len(s.tools) > 0,
-			"resources": len(s.resources) > 0,
+			"tools":     s.toolCount() > 0,
+			"resources": s.resourceCount() > 0,
 			"prompts":   len(s.prompts) > 0,
 		},
 	}
@@ -515,16 +1240,6 @@ func (s *MCPServer) handleMCPCapabilities(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// handleMCPInfo returns server info
-func (s *MCPServer) handleMCPInfo(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	if _, err := fmt.Fprintf(w, `{"name":"%s","version":"%s","transport":"http/sse","tools_count":%d,"resources_count":%d}`,
-		s.config.Name, s.config.Version, len(s.tools), len(s.resources)); err != nil {
-		log.Printf("Error writing MCP info response: %v", err)
-	}
-}
-
 // handleListTools returns all available tools
 func (s *MCPServer) handleListTools(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -533,21 +1248,43 @@ func (s *MCPServer) handleListTools(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Build tools list response
+	type AliasInfo struct {
+		Name   string `json:"name"`
+		Sunset string `json:"sunset,omitempty"`
+	}
 	type ToolInfo struct {
 		Name        string                 `json:"name"`
 		Description string                 `json:"description"`
 		InputSchema map[string]interface{} `json:"input_schema"`
+		Aliases     []AliasInfo            `json:"aliases,omitempty"` // Deprecated names the tool also answers to
+		Available   bool                   `json:"available"`         // False while the RBAC self-check finds every required permission denied
+		Reason      string                 `json:"reason,omitempty"`  // Why the tool is unavailable
 	}
 
+	// Tenants only see the tools they may call
+	tenant := s.tenantFor(r.Context())
 	toolsList := []ToolInfo{}
-	for _, tool := range s.tools {
+	for _, tool := range s.GetTools() {
+		if tenant != nil && !tenant.canCall(tool) {
+			continue
+		}
 		// No type assertion needed - tools map is now typed as map[string]Tool
-		toolsList = append(toolsList, ToolInfo{
+		info := ToolInfo{
 			Name:        tool.Name(),
 			Description: tool.Description(),
-			InputSchema: tool.InputSchema(),
-		})
+			InputSchema: toolInputSchema(tool),
+			Available:   true,
+		}
+		if missing := s.toolDegradation(tool.Name()); len(missing) > 0 {
+			info.Available = false
+			info.Reason = missingRBACReason(missing)
+		}
+		for _, alias := range toolAliases(tool) {
+			info.Aliases = append(info.Aliases, AliasInfo{Name: alias.Name, Sunset: alias.SunsetDate()})
+		}
+		toolsList = append(toolsList, info)
 	}
+	sort.Slice(toolsList, func(i, j int) bool { return toolsList[i].Name < toolsList[j].Name })
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -570,44 +1307,37 @@ func (s *MCPServer) handleClusterHealthTool(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Get the tool
-	tool, ok := s.tools["get-cluster-health"].(*tools.ClusterHealthTool)
+	registered, _ := s.lookupTool("get-cluster-health")
+	tool, ok := registered.(*tools.ClusterHealthTool)
 	if !ok {
 		http.Error(w, "Tool not found", http.StatusNotFound)
 		return
 	}
 
-	// Parse request body for arguments
-	var args map[string]interface{}
-	if r.Body != nil {
-		defer func() {
-			if err := r.Body.Close(); err != nil {
-				log.Printf("Error closing request body: %v", err)
-			}
-		}()
-		decoder := json.NewDecoder(r.Body)
-		if err := decoder.Decode(&args); err != nil {
-			// If no body or invalid JSON, use empty args
-			args = make(map[string]interface{})
-		}
-	} else {
-		args = make(map[string]interface{})
+	// Parse request body for arguments (empty or invalid JSON uses tool defaults)
+	args, err := decodeRequestArgs(r)
+	if err != nil {
+		writeBodyDecodeError(w, err)
+		return
 	}
 
 	// Execute the tool
 	ctx := r.Context()
-	result, err := tool.Execute(ctx, args)
+	result, meta, err := s.executeTool(ctx, tool, args)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Tool execution failed: %v", err), http.StatusInternalServerError)
+		writeToolError(w, err)
 		return
 	}
 
 	// Return result
 	w.Header().Set("Content-Type", "application/json")
+	setCacheHeaders(w, meta)
 	w.WriteHeader(http.StatusOK)
 
 	response := map[string]interface{}{
 		"success": true,
 		"result":  result,
+		"_meta":   meta,
 	}
 
 	if err := writeJSON(w, response); err != nil {
@@ -623,44 +1353,37 @@ func (s *MCPServer) handleListPodsTool(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the tool
-	tool, ok := s.tools["list-pods"].(*tools.ListPodsTool)
+	registered, _ := s.lookupTool("list-pods")
+	tool, ok := registered.(*tools.ListPodsTool)
 	if !ok {
 		http.Error(w, "Tool not found", http.StatusNotFound)
 		return
 	}
 
-	// Parse request body for arguments
-	var args map[string]interface{}
-	if r.Body != nil {
-		defer func() {
-			if err := r.Body.Close(); err != nil {
-				log.Printf("Error closing request body: %v", err)
-			}
-		}()
-		decoder := json.NewDecoder(r.Body)
-		if err := decoder.Decode(&args); err != nil {
-			// If no body or invalid JSON, use empty args
-			args = make(map[string]interface{})
-		}
-	} else {
-		args = make(map[string]interface{})
+	// Parse request body for arguments (empty or invalid JSON uses tool defaults)
+	args, err := decodeRequestArgs(r)
+	if err != nil {
+		writeBodyDecodeError(w, err)
+		return
 	}
 
 	// Execute the tool
 	ctx := r.Context()
-	result, err := tool.Execute(ctx, args)
+	result, meta, err := s.executeTool(ctx, tool, args)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Tool execution failed: %v", err), http.StatusInternalServerError)
+		writeToolError(w, err)
 		return
 	}
 
 	// Return result
 	w.Header().Set("Content-Type", "application/json")
+	setCacheHeaders(w, meta)
 	w.WriteHeader(http.StatusOK)
 
 	response := map[string]interface{}{
 		"success": true,
 		"result":  result,
+		"_meta":   meta,
 	}
 
 	if err := writeJSON(w, response); err != nil {
@@ -668,14 +1391,26 @@ func (s *MCPServer) handleListPodsTool(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// handleCacheStats returns cache statistics
+// handleCacheStats returns cache statistics, broken down by the first key
+// segment with ?by=prefix, or by request priority with ?by=priority
 func (s *MCPServer) handleCacheStats(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed - use GET", http.StatusMethodNotAllowed)
 		return
 	}
 
-	stats := s.cache.GetStatistics()
+	var stats cache.Statistics
+	switch by := r.URL.Query().Get("by"); by {
+	case "":
+		stats = s.cache.GetStatistics()
+	case "prefix":
+		stats = s.cache.GetStatisticsByPrefix()
+	case "priority":
+		stats = s.cache.GetStatisticsByPriority()
+	default:
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("unsupported by=%q (use prefix or priority)", by))
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -698,38 +1433,40 @@ func (s *MCPServer) handleListResources(w http.ResponseWriter, r *http.Request)
 		Name        string `json:"name"`
 		Description string `json:"description"`
 		MimeType    string `json:"mime_type"`
+		// MimeTypes are the representations GET /mcp/resources/{uri}/read can return (Accept header)
+		MimeTypes []string `json:"mime_types"`
+		// ExpiresAt is set on ephemeral resources, the stored artifacts
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
 	}
 
+	// Tenants only see namespace-scoped resources
+	tenant := s.tenantFor(r.Context())
 	resourcesList := []ResourceInfo{}
-	for _, resource := range s.resources {
-		switch r := resource.(type) {
-		case *resources.ClusterHealthResource:
-			resourcesList = append(resourcesList, ResourceInfo{
-				URI:         r.URI(),
-				Name:        r.Name(),
-				Description: r.Description(),
-				MimeType:    r.MimeType(),
-			})
-		case *resources.NodesResource:
-			resourcesList = append(resourcesList, ResourceInfo{
-				URI:         r.URI(),
-				Name:        r.Name(),
-				Description: r.Description(),
-				MimeType:    r.MimeType(),
-			})
-		case *resources.IncidentsResource:
-			resourcesList = append(resourcesList, ResourceInfo{
-				URI:         r.URI(),
-				Name:        r.Name(),
-				Description: r.Description(),
-				MimeType:    r.MimeType(),
-			})
-		case *resources.RemediationHistoryResource:
+	for _, resource := range s.resources.List() {
+		if tenant != nil && !isNamespaceScoped(resource) {
+			continue
+		}
+		resourcesList = append(resourcesList, ResourceInfo{
+			URI:         resource.URI(),
+			Name:        resource.Name(),
+			Description: resource.Description(),
+			MimeType:    resource.MimeType(),
+			MimeTypes:   resourceMimeTypes,
+		})
+	}
+	if s.artifacts != nil {
+		for _, artifact := range s.artifacts.list() {
+			if !artifact.visibleTo(tenant) {
+				continue
+			}
+			expiresAt := artifact.ExpiresAt
 			resourcesList = append(resourcesList, ResourceInfo{
-				URI:         r.URI(),
-				Name:        r.Name(),
-				Description: r.Description(),
-				MimeType:    r.MimeType(),
+				URI:         artifactURIPrefix + artifact.ID,
+				Name:        artifact.Name,
+				Description: fmt.Sprintf("Artifact of %s, served as stored", artifact.Tool),
+				MimeType:    artifact.ContentType,
+				MimeTypes:   []string{artifact.ContentType},
+				ExpiresAt:   &expiresAt,
 			})
 		}
 	}
@@ -801,6 +1538,7 @@ func (s *MCPServer) handleListPrompts(w http.ResponseWriter, r *http.Request) {
 			})
 		}
 	}
+	sort.Slice(promptsList, func(i, j int) bool { return promptsList[i].Name < promptsList[j].Name })
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -870,23 +1608,15 @@ func (s *MCPServer) handleSession(w http.ResponseWriter, r *http.Request) {
 
 // handleCreateSession creates a new session
 func (s *MCPServer) handleCreateSession(w http.ResponseWriter, r *http.Request) {
-	// Parse optional metadata from request body
-	var metadata map[string]interface{}
-	if r.Body != nil {
-		defer func() {
-			if err := r.Body.Close(); err != nil {
-				log.Printf("Error closing request body: %v", err)
-			}
-		}()
-		decoder := json.NewDecoder(r.Body)
-		if err := decoder.Decode(&metadata); err != nil {
-			// If no body or invalid JSON, use empty metadata
-			metadata = make(map[string]interface{})
-		}
+	// Parse optional metadata from request body (empty or invalid JSON uses empty metadata)
+	metadata, err := decodeRequestArgs(r)
+	if err != nil {
+		writeBodyDecodeError(w, err)
+		return
 	}
 
-	// Create session
-	session, err := s.sessionManager.CreateSession(metadata)
+	// Create session, bound to the authenticated user if any
+	session, err := s.sessionManager.CreateSession(bindSessionToPrincipal(r.Context(), metadata))
 	if err != nil {
 		writeJSONError(w, http.StatusServiceUnavailable, err.Error())
 		return
@@ -927,6 +1657,10 @@ func (s *MCPServer) handleSessionByID(w http.ResponseWriter, r *http.Request) {
 		writeJSONError(w, http.StatusBadRequest, "session ID required in path")
 		return
 	}
+	if !s.sessionOwnedByCaller(r.Context(), sessionID) {
+		writeJSONError(w, http.StatusForbidden, "session belongs to another user")
+		return
+	}
 
 	switch r.Method {
 	case http.MethodGet:
@@ -979,6 +1713,10 @@ func (s *MCPServer) handleToolCall(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !requireJSONContentType(w, r) {
+		return
+	}
+
 	// Validate session
 	sessionID := s.getSessionID(r)
 	if sessionID == "" {
@@ -991,6 +1729,10 @@ func (s *MCPServer) handleToolCall(w http.ResponseWriter, r *http.Request) {
 		writeJSONError(w, http.StatusUnauthorized, "invalid or expired session. Create a new session via POST /mcp/session")
 		return
 	}
+	if !s.sessionOwnedByCaller(r.Context(), sessionID) {
+		writeJSONError(w, http.StatusForbidden, "session belongs to another user")
+		return
+	}
 
 	// Extract tool name from path: /mcp/tools/{toolname}/call
 	path := strings.TrimPrefix(r.URL.Path, "/mcp/tools/")
@@ -1002,39 +1744,44 @@ func (s *MCPServer) handleToolCall(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the tool - no type assertion needed since tools map is now typed as map[string]Tool
-	tool, exists := s.tools[toolName]
+	tool, alias, exists := s.resolveTool(toolName)
 	if !exists {
+		if integration, down := s.downIntegration(toolName); down {
+			writeIntegrationUnavailable(w, "tool", toolName, integration, s.currentConfig().CapabilityProbeInterval)
+			return
+		}
 		writeJSONError(w, http.StatusNotFound, fmt.Sprintf("tool '%s' not found", toolName))
 		return
 	}
 
-	// Parse request body for arguments
-	var args map[string]interface{}
-	if r.Body != nil {
-		defer func() {
-			if err := r.Body.Close(); err != nil {
-				log.Printf("Error closing request body: %v", err)
-			}
-		}()
-		decoder := json.NewDecoder(r.Body)
-		if err := decoder.Decode(&args); err != nil {
-			// If no body or invalid JSON, use empty args
-			args = make(map[string]interface{})
-		}
-	} else {
-		args = make(map[string]interface{})
+	// Parse request body for arguments (empty or invalid JSON uses tool defaults)
+	args, err := decodeRequestArgs(r)
+	if err != nil {
+		writeBodyDecodeError(w, err)
+		return
 	}
 
-	ctx := r.Context()
-	result, err := tool.Execute(ctx, args)
+	ctx, cancel := s.callContext(r.Context(), 0)
+	defer cancel()
+	if alias != "" {
+		ctx = withToolAlias(ctx, alias)
+	}
+	result, meta, err := s.executeTool(ctx, tool, args)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("tool execution failed: %v", err))
+		writeToolError(w, err)
 		return
 	}
 
 	// Return result
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("X-MCP-Session-ID", sessionID)
+	if meta.Deprecation != nil {
+		w.Header().Set("Deprecation", "true")
+		if sunset, err := time.Parse(time.DateOnly, meta.Deprecation.Sunset); err == nil {
+			w.Header().Set("Sunset", sunset.Format(http.TimeFormat))
+		}
+	}
+	setCacheHeaders(w, meta)
 	w.WriteHeader(http.StatusOK)
 
 	response := map[string]interface{}{
@@ -1042,6 +1789,7 @@ func (s *MCPServer) handleToolCall(w http.ResponseWriter, r *http.Request) {
 		"tool":       toolName,
 		"session_id": sessionID,
 		"result":     result,
+		"_meta":      meta,
 	}
 
 	if err := writeJSON(w, response); err != nil {
@@ -1053,94 +1801,244 @@ func (s *MCPServer) handleToolCall(w http.ResponseWriter, r *http.Request) {
 
 // handleResourceRead handles resource read via REST API
 // POST /mcp/resources/{uri}/read
-// Requires sessionid query parameter or X-MCP-Session-ID header
+// Requires sessionid query parameter or X-MCP-Session-ID header.
+// The Accept header selects JSON (default), YAML, or a text summary. The
+// response carries an ETag of the content; a matching If-None-Match gets 304 Not Modified.
 func (s *MCPServer) handleResourceRead(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost && r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed - use GET or POST", http.StatusMethodNotAllowed)
 		return
 	}
 
+	sessionID, resourceURI, resourceInterface, ok := s.resolveResourceRequest(w, r, "/read")
+	if !ok {
+		return
+	}
+	ctx, cancel := s.callContext(r.Context(), 0)
+	defer cancel()
+	ctx, err := s.checkTenantResource(ctx, resourceURI, resourceInterface)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	if artifact, ok := resourceInterface.(*artifactMeta); ok {
+		w.Header().Set("X-MCP-Session-ID", sessionID)
+		s.serveArtifact(w, r, artifact.ID)
+		return
+	}
+
+	// Optional freshness bound, for resources whose reads are cached
+	var maxAge time.Duration
+	if raw := r.URL.Query().Get("max_age_seconds"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			writeJSONError(w, http.StatusBadRequest, "max_age_seconds must be a non-negative integer")
+			return
+		}
+		if _, ok := resourceInterface.(*resources.NodesResource); !ok {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("resource '%s' does not support max_age_seconds", resourceURI))
+			return
+		}
+		maxAge = time.Duration(seconds) * time.Second
+	}
+
+	// Optional node filter, applied by the nodes resource
+	var nodeFilter clients.NodeFilter
+	if query := r.URL.Query(); query.Has("label_selector") || query.Has("name_prefix") || query.Has("role") {
+		if _, ok := resourceInterface.(*resources.NodesResource); !ok {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("resource '%s' does not support label_selector, name_prefix or role", resourceURI))
+			return
+		}
+		nodeFilter, err = clients.NewNodeFilter(query.Get("label_selector"), query.Get("name_prefix"), query.Get("role"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	stamped, ok := s.readStampedResource(ctx, w, resourceInterface, maxAge, nodeFilter)
+	if !ok {
+		return
+	}
+
+	// Each representation has its own entity tag
+	format, supported := negotiateResourceFormat(r.Header.Get("Accept"))
+	etag := stamped.ETagFor(format)
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("X-MCP-Session-ID", sessionID)
+	if !supported {
+		w.Header().Set("Warning", fmt.Sprintf(`299 - "unsupported Accept header, serving %s"`, mimeJSON))
+	}
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if format != mimeJSON {
+		body, err := renderResource(stamped.Content, format)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("resource read failed: %v", err))
+			return
+		}
+		w.Header().Set("Content-Type", format+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(body); err != nil {
+			log.Printf("Error writing resource response: %v", err)
+		}
+		log.Printf("Resource '%s' read successfully as %s (session: %s)", resourceURI, format, sessionID)
+		return
+	}
+
+	// Return result
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]interface{}{
+		"success":    true,
+		"uri":        resourceURI,
+		"session_id": sessionID,
+		"content":    stamped.Content,
+	}
+
+	if err := writeJSON(w, response); err != nil {
+		log.Printf("Error writing resource response: %v", err)
+	}
+
+	log.Printf("Resource '%s' read successfully (session: %s)", resourceURI, sessionID)
+}
+
+// handleResourceMetadata reports a resource's content hash without its content,
+// so that pollers can ask whether it changed since the hash they hold
+// GET /mcp/resources/{uri}/metadata?known_hash=<content_hash>
+func (s *MCPServer) handleResourceMetadata(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed - use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionID, resourceURI, resourceInterface, ok := s.resolveResourceRequest(w, r, "/metadata")
+	if !ok {
+		return
+	}
+	if _, ok := resourceInterface.(*artifactMeta); ok {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("resource '%s' is an artifact, which never changes; read it instead", resourceURI))
+		return
+	}
+
+	ctx, err := s.checkTenantResource(r.Context(), resourceURI, resourceInterface)
+	if err != nil {
+		writeJSONError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	stamped, ok := s.readStampedResource(ctx, w, resourceInterface, 0, clients.NodeFilter{})
+	if !ok {
+		return
+	}
+
+	knownHash := r.URL.Query().Get("known_hash")
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", stamped.ETag())
+	w.Header().Set("X-MCP-Session-ID", sessionID)
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]interface{}{
+		"success":      true,
+		"uri":          resourceURI,
+		"content_hash": stamped.Hash,
+		"generated_at": stamped.GeneratedAt.Format(time.RFC3339),
+		"changed":      knownHash != stamped.Hash,
+	}
+	if err := writeJSON(w, response); err != nil {
+		log.Printf("Error writing resource metadata response: %v", err)
+	}
+}
+
+// resolveResourceRequest validates the session of a request to
+// /mcp/resources/{uri}{suffix} and looks up the resource. It writes the error
+// response and returns ok=false when either fails.
+func (s *MCPServer) resolveResourceRequest(w http.ResponseWriter, r *http.Request, suffix string) (sessionID, resourceURI string, resource interface{}, ok bool) {
 	// Validate session
-	sessionID := s.getSessionID(r)
+	sessionID = s.getSessionID(r)
 	if sessionID == "" {
 		writeJSONError(w, http.StatusBadRequest,
 			"sessionid must be provided. Create a session first via POST /mcp/session, then include sessionid as query parameter or X-MCP-Session-ID header")
-		return
+		return "", "", nil, false
 	}
 
 	if !s.sessionManager.TouchSession(sessionID) {
 		writeJSONError(w, http.StatusUnauthorized, "invalid or expired session. Create a new session via POST /mcp/session")
-		return
+		return "", "", nil, false
+	}
+	if !s.sessionOwnedByCaller(r.Context(), sessionID) {
+		writeJSONError(w, http.StatusForbidden, "session belongs to another user")
+		return "", "", nil, false
 	}
 
 	// Extract resource URI from path: /mcp/resources/{uri}/read
 	// The URI is URL-encoded in the path
 	path := strings.TrimPrefix(r.URL.Path, "/mcp/resources/")
-	resourceURI := strings.TrimSuffix(path, "/read")
+	resourceURI = strings.TrimSuffix(path, suffix)
 
 	if resourceURI == "" {
 		writeJSONError(w, http.StatusBadRequest, "resource URI required in path")
-		return
+		return "", "", nil, false
 	}
 
 	// URL decode the resource URI (e.g., cluster%3A%2F%2Fhealth -> cluster://health)
 	// The URI should be provided URL-encoded in the path
 
 	// Find the resource
-	resourceInterface, exists := s.resources[resourceURI]
+	resource, exists := s.lookupResource(resourceURI)
+	if artifact, ok := s.artifactFromURI(resourceURI); ok {
+		resource, exists = artifact, true
+	}
 	if !exists {
 		// Try with cluster:// prefix if not found
 		if !strings.Contains(resourceURI, "://") {
 			resourceURI = "cluster://" + resourceURI
-			resourceInterface, exists = s.resources[resourceURI]
+			resource, exists = s.lookupResource(resourceURI)
 		}
 		if !exists {
+			if integration, down := s.downIntegration(resourceURI); down {
+				writeIntegrationUnavailable(w, "resource", resourceURI, integration, s.currentConfig().CapabilityProbeInterval)
+				return "", "", nil, false
+			}
 			writeJSONError(w, http.StatusNotFound, fmt.Sprintf("resource '%s' not found", resourceURI))
-			return
+			return "", "", nil, false
 		}
 	}
+	return sessionID, resourceURI, resource, true
+}
 
-	// Execute the resource read
-	ctx := r.Context()
-	var result interface{}
+// readStampedResource reads a resource and stamps it with its content hash.
+// maxAge and nodeFilter only apply to the nodes resource. It writes the
+// error response and returns ok=false when the read fails.
+func (s *MCPServer) readStampedResource(ctx context.Context, w http.ResponseWriter, resourceInterface interface{}, maxAge time.Duration, nodeFilter clients.NodeFilter) (*stampedResource, bool) {
+	var result string
 	var err error
 
 	switch res := resourceInterface.(type) {
-	case *resources.ClusterHealthResource:
-		result, err = res.Read(ctx)
 	case *resources.NodesResource:
-		result, err = res.Read(ctx)
-	case *resources.IncidentsResource:
-		result, err = res.Read(ctx)
-	case *resources.RemediationHistoryResource:
+		result, err = res.ReadFiltered(ctx, maxAge, nodeFilter)
+	case Resource:
 		result, err = res.Read(ctx)
 	default:
 		writeJSONError(w, http.StatusInternalServerError, "resource type not supported")
-		return
+		return nil, false
 	}
 
 	if err != nil {
 		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("resource read failed: %v", err))
-		return
+		return nil, false
 	}
 
-	// Return result
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("X-MCP-Session-ID", sessionID)
-	w.WriteHeader(http.StatusOK)
-
-	response := map[string]interface{}{
-		"success":    true,
-		"uri":        resourceURI,
-		"session_id": sessionID,
-		"content":    result,
-	}
-
-	if err := writeJSON(w, response); err != nil {
-		log.Printf("Error writing resource response: %v", err)
+	stamped, err := stampResource(result, s.clock.Now())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, fmt.Sprintf("resource read failed: %v", err))
+		return nil, false
 	}
-
-	log.Printf("Resource '%s' read successfully (session: %s)", resourceURI, sessionID)
+	return stamped, true
 }
 
 // getSessionID extracts session ID from query parameter or header
@@ -1165,6 +2063,9 @@ func (s *MCPServer) getSessionID(r *http.Request) string {
 // writeJSONError writes a JSON error response
 func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
 	w.Header().Set("Content-Type", "application/json")
+	if (statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable) && w.Header().Get("Retry-After") == "" {
+		setRetryAfter(w, defaultRetryAfter)
+	}
 	w.WriteHeader(statusCode)
 	response := map[string]interface{}{
 		"success": false,
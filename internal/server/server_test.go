@@ -4,14 +4,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/resources"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/buildinfo"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/clock"
+	testclock "k8s.io/utils/clock/testing"
 )
 
 func setupTestServer(t *testing.T) *MCPServer {
@@ -38,8 +54,9 @@ func setupTestServer(t *testing.T) *MCPServer {
 		mcpServer: mcpServer,
 		k8sClient: k8sClient,
 		cache:     memoryCache,
-		tools:     make(map[string]Tool),
-		resources: make(map[string]interface{}),
+		tools:     NewToolRegistry(),
+		resources: NewResourceRegistry(),
+		clock:     clock.RealClock{},
 	}
 
 	if err := server.registerTools(); err != nil {
@@ -72,7 +89,7 @@ func TestNewMCPServer(t *testing.T) {
 		t.Errorf("Expected name %s, got %s", config.Name, server.config.Name)
 	}
 
-	if len(server.tools) == 0 {
+	if server.tools.Len() == 0 {
 		t.Error("Expected tools to be registered")
 	}
 }
@@ -88,13 +105,13 @@ func TestMCPServer_RegisterTools(t *testing.T) {
 
 	expectedTools := []string{"get-cluster-health", "list-pods", "calculate-pod-capacity"}
 	for _, toolName := range expectedTools {
-		if _, exists := server.tools[toolName]; !exists {
+		if _, exists := server.tools.Get(toolName); !exists {
 			t.Errorf("Expected tool %s to be registered", toolName)
 		}
 	}
 
-	if len(server.tools) != len(expectedTools) {
-		t.Errorf("Expected %d tools, got %d", len(expectedTools), len(server.tools))
+	if server.tools.Len() != len(expectedTools) {
+		t.Errorf("Expected %d tools, got %d", len(expectedTools), server.tools.Len())
 	}
 }
 
@@ -222,6 +239,46 @@ func TestHandleMCPInfo(t *testing.T) {
 	}
 }
 
+func TestHandleMCPInfo_BuildIntegrationsAndCluster(t *testing.T) {
+	cfg := NewConfig()
+	cfg.EnableKServe = true
+	cfg.ReadOnly = true
+	server := newStubToolServer(t, cfg)
+
+	info := func() MCPInfoResponse {
+		w := httptest.NewRecorder()
+		server.handleMCPInfo(w, httptest.NewRequest(http.MethodGet, "/mcp/info", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		var response MCPInfoResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	response := info()
+	assert.Equal(t, buildinfo.Version, response.Build.Version)
+	assert.NotEmpty(t, response.Build.GoVersion)
+	assert.Equal(t, "http/sse", response.Transport)
+	assert.True(t, response.ReadOnly)
+	assert.Equal(t, map[string]bool{"coordination_engine": false, "kserve": true, "prometheus": false}, response.Integrations)
+	assert.Nil(t, response.Cluster)
+	assert.NotEmpty(t, response.ClusterError, "no cluster without a Kubernetes client")
+
+	clientset := fake.NewClientset()
+	clientset.Discovery().(*discoveryfake.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.31.4"}
+	clientset.Resources = []*metav1.APIResourceList{{GroupVersion: "config.openshift.io/v1"}}
+	server.k8sClient = clients.NewK8sClientWithClientset(clientset)
+	server.cache = cache.NewMemoryCache(time.Minute)
+	t.Cleanup(server.cache.Close)
+
+	response = info()
+	require.NotNil(t, response.Cluster, response.ClusterError)
+	assert.Equal(t, &clients.ClusterInfo{ServerVersion: "v1.31.4", Platform: clients.PlatformOpenShift}, response.Cluster)
+
+	// Discovery is cached rather than repeated per request
+	clientset.Resources = nil
+	assert.Equal(t, clients.PlatformOpenShift, info().Cluster.Platform)
+}
+
 func TestHandleListTools(t *testing.T) {
 	server := setupTestServer(t)
 	defer func() {
@@ -276,7 +333,7 @@ func TestHandleListTools_CountMatchesRegistered(t *testing.T) {
 	defer server.cache.Close()
 
 	// Get the number of registered tools from the internal map
-	registeredCount := len(server.tools)
+	registeredCount := server.tools.Len()
 
 	// Make API request
 	req := httptest.NewRequest(http.MethodGet, "/mcp/tools", nil)
@@ -320,13 +377,65 @@ func TestHandleListTools_CountMatchesRegistered(t *testing.T) {
 		toolNames[name] = true
 	}
 
-	for name := range server.tools {
+	for name := range server.GetTools() {
 		if !toolNames[name] {
 			t.Errorf("Registered tool '%s' not found in API response!", name)
 		}
 	}
 }
 
+func TestHandleListTools_SortedAndStable(t *testing.T) {
+	server := newStubToolServer(t, NewConfig(), "list-pods", "analyze-anomalies", "get-cluster-health", "calculate-pod-capacity")
+	nodes := resources.NewNodesResource(nil, nil)
+	health := resources.NewClusterHealthResource(nil, nil, nil, nil)
+	server.registerResource(nodes)
+	server.registerResource(health)
+
+	list := func(path string) string {
+		w := httptest.NewRecorder()
+		server.httpHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s: status %d", path, w.Code)
+		}
+		return w.Body.String()
+	}
+
+	for _, path := range []string{"/mcp/tools", "/mcp/resources"} {
+		first := list(path)
+		for i := 0; i < 10; i++ {
+			if got := list(path); got != first {
+				t.Fatalf("GET %s changed between calls:\n%s\n---\n%s", path, first, got)
+			}
+		}
+	}
+
+	var tools struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal([]byte(list("/mcp/tools")), &tools); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, tool := range tools.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"analyze-anomalies", "calculate-pod-capacity", "get-cluster-health", "list-pods"}, names)
+
+	var listed struct {
+		Resources []struct {
+			URI string `json:"uri"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal([]byte(list("/mcp/resources")), &listed); err != nil {
+		t.Fatal(err)
+	}
+	require.Len(t, listed.Resources, 2)
+	assert.Equal(t, "cluster://health", listed.Resources[0].URI, "resources are sorted by URI")
+	assert.Equal(t, "cluster://nodes", listed.Resources[1].URI)
+}
+
 func TestHandleListTools_MethodNotAllowed(t *testing.T) {
 	server := setupTestServer(t)
 	defer func() {
@@ -565,6 +674,43 @@ func TestHandleCacheStats(t *testing.T) {
 	}
 }
 
+func TestHandleCacheStats_ByPrefix(t *testing.T) {
+	server := setupTestServer(t)
+	defer func() {
+		if err := server.k8sClient.Close(); err != nil {
+			t.Logf("Error closing k8s client: %v", err)
+		}
+	}()
+	defer server.cache.Close()
+
+	server.cache.Set(cache.Key("tool", "get-cluster-health", "summary"), "ok")
+	server.cache.Get(cache.Key("tool", "get-cluster-health", "summary"))
+	server.cache.Get(cache.Key("resource", "cluster", "nodes"))
+
+	w := httptest.NewRecorder()
+	server.handleCacheStats(w, httptest.NewRequest(http.MethodGet, "/cache/stats?by=prefix", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var stats cache.Statistics
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if got := stats.ByPrefix["tool"]; got.Hits != 1 || got.Entries != 1 {
+		t.Errorf("Expected 1 hit and 1 entry for tool, got %+v", got)
+	}
+	if got := stats.ByPrefix["resource"]; got.Misses != 1 || got.Entries != 0 {
+		t.Errorf("Expected 1 miss and no entries for resource, got %+v", got)
+	}
+
+	w = httptest.NewRecorder()
+	server.handleCacheStats(w, httptest.NewRequest(http.MethodGet, "/cache/stats?by=tool", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown breakdown, got %d", w.Code)
+	}
+}
+
 func TestHandleCacheStats_MethodNotAllowed(t *testing.T) {
 	server := setupTestServer(t)
 	defer func() {
@@ -669,3 +815,314 @@ func TestHTTPServerIntegration(t *testing.T) {
 		}
 	}
 }
+
+// stubTool is a minimal Tool used to exercise registration without cluster access
+type stubTool struct {
+	name   string
+	result interface{} // Returned by Execute when set
+	err    error       // Returned by Execute when set
+}
+
+func (t *stubTool) Name() string        { return t.name }
+func (t *stubTool) Description() string { return "stub tool " + t.name }
+func (t *stubTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{}}
+}
+func (t *stubTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.result != nil {
+		return t.result, nil
+	}
+	return map[string]string{"tool": t.name}, nil
+}
+
+// newStubToolServer builds a server with stub tools registered under cfg's tool selection
+func newStubToolServer(t *testing.T, cfg *Config, names ...string) *MCPServer {
+	t.Helper()
+	server := &MCPServer{
+		config:         cfg,
+		mcpServer:      mcp.NewServer(&mcp.Implementation{Name: cfg.Name, Version: cfg.Version}, nil),
+		sessionManager: NewSessionManager(5*time.Minute, 10),
+		tools:          NewToolRegistry(),
+		resources:      NewResourceRegistry(),
+		clock:          clock.RealClock{},
+	}
+	t.Cleanup(server.sessionManager.Stop)
+	server.liveConfig.Store(cfg)
+
+	for _, name := range names {
+		server.registerTool(&stubTool{name: name})
+	}
+	return server
+}
+
+func TestRegisterTool_DisabledToolsSkipped(t *testing.T) {
+	cfg := NewConfig()
+	cfg.DisabledTools = []string{"trigger-*"}
+
+	server := newStubToolServer(t, cfg, "get-cluster-health", "list-pods", "trigger-remediation")
+
+	assert.Equal(t, 2, server.tools.Len())
+	assert.NotContains(t, server.GetTools(), "trigger-remediation")
+
+	// /mcp/info and /mcp/tools report only the active tools
+	w := httptest.NewRecorder()
+	server.handleMCPInfo(w, httptest.NewRequest(http.MethodGet, "/mcp/info", nil))
+	var info map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, float64(2), info["tools_count"])
+
+	w = httptest.NewRecorder()
+	server.handleListTools(w, httptest.NewRequest(http.MethodGet, "/mcp/tools", nil))
+	var list map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, float64(2), list["count"])
+
+	// Calling the disabled tool over REST returns 404
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp/tools/trigger-remediation/call", bytes.NewBufferString("{}"))
+	req.Header.Set("X-MCP-Session-ID", session.ID)
+	w = httptest.NewRecorder()
+	server.handleToolCall(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/mcp/tools/list-pods/call", bytes.NewBufferString("{}"))
+	req.Header.Set("X-MCP-Session-ID", session.ID)
+	w = httptest.NewRecorder()
+	server.handleToolCall(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRegisterTool_EnabledToolsAllowlist(t *testing.T) {
+	cfg := NewConfig()
+	cfg.EnabledTools = []string{"get-*"}
+
+	server := newStubToolServer(t, cfg, "get-cluster-health", "get-model-status", "list-pods")
+
+	assert.Equal(t, 2, server.tools.Len())
+	assert.Contains(t, server.GetTools(), "get-cluster-health")
+	assert.Contains(t, server.GetTools(), "get-model-status")
+}
+
+// podReadingTool is a composite tool whose three helpers each list the same pods
+type podReadingTool struct {
+	stubTool
+	k8sClient *clients.K8sClient
+}
+
+func (t *podReadingTool) Execute(ctx context.Context, _ map[string]interface{}) (interface{}, error) {
+	total := 0
+	for i := 0; i < 3; i++ {
+		pods, err := t.k8sClient.ListPods(ctx, "shop")
+		if err != nil {
+			return nil, err
+		}
+		total += len(pods.Items)
+	}
+	return map[string]interface{}{"pods": total}, nil
+}
+
+func TestExecuteTool_RequestScopedReads(t *testing.T) {
+	clientset := fake.NewClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}})
+	server := newStubToolServer(t, NewConfig())
+	tool := &podReadingTool{stubTool: stubTool{name: "summarize-pods"}, k8sClient: clients.NewK8sClientWithClientset(clientset)}
+
+	podLists := func() int {
+		count := 0
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+				count++
+			}
+		}
+		return count
+	}
+
+	result, _, err := server.executeTool(context.Background(), tool, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"pods": float64(3)}, result)
+	assert.Equal(t, 1, podLists(), "the helpers share one list within the call")
+
+	// The next call reads again: nothing is kept between calls
+	_, _, err = server.executeTool(context.Background(), tool, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, podLists())
+}
+
+func TestExecuteTool_SanitizesResult(t *testing.T) {
+	cfg := NewConfig()
+	cfg.RedactionPatterns = []string{"session-id"}
+
+	server := newStubToolServer(t, cfg)
+	server.sanitizer = tools.NewSanitizer(cfg.RedactionPatterns)
+
+	tool := &stubTool{name: "get-secret", result: map[string]interface{}{
+		"kind":       "Secret",
+		"data":       map[string]interface{}{"password": "aHVudGVyMg=="},
+		"session-id": "abc",
+		"namespace":  "default",
+	}}
+
+	result, _, err := server.executeTool(context.Background(), tool, nil)
+	require.NoError(t, err)
+
+	out := result.(map[string]interface{})
+	assert.Equal(t, "<redacted, 7 bytes>", out["data"].(map[string]interface{})["password"])
+	assert.Equal(t, "<redacted>", out["session-id"])
+	assert.Equal(t, "default", out["namespace"])
+}
+
+func TestExecuteTool_DeltaTokenSurvivesSanitizing(t *testing.T) {
+	pod := func(name string, restarts int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name, UID: types.UID("uid-" + name)},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "main", RestartCount: restarts}},
+			},
+		}
+	}
+	clientset := fake.NewClientset(pod("web-0", 0), pod("web-1", 0))
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	t.Cleanup(memoryCache.Close)
+
+	server := newStubToolServer(t, NewConfig())
+	server.sanitizer = tools.NewSanitizer(nil)
+	tool := tools.NewListPodsTool(clients.NewK8sClientWithClientset(clientset), memoryCache)
+
+	result, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{"namespace": "shop", "track_changes": true})
+	require.NoError(t, err)
+	token, _ := result.(map[string]interface{})["delta_token"].(string)
+	require.NotEmpty(t, token)
+	assert.NotEqual(t, "<redacted>", token)
+
+	_, err = clientset.CoreV1().Pods("shop").Update(context.Background(), pod("web-1", 3), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// The token handed out is the one the tool knows
+	result, _, err = server.executeTool(context.Background(), tool, map[string]interface{}{"namespace": "shop", "delta_token": token})
+	require.NoError(t, err)
+	delta := result.(map[string]interface{})
+	assert.Equal(t, false, delta["full_resync"])
+	changed := delta["changed"].([]interface{})
+	require.Len(t, changed, 1)
+	assert.Equal(t, "web-1", changed[0].(map[string]interface{})["name"])
+}
+
+func TestExecuteTool_PageTokensSurviveSanitizing(t *testing.T) {
+	// The engine pages by page_token but ignores the namespace filter
+	incidents := make([]clients.Incident, 5)
+	for i := range incidents {
+		namespace := "payments"
+		if i%2 == 1 {
+			namespace = "checkout"
+		}
+		incidents[i] = clients.Incident{ID: fmt.Sprintf("inc-%d", i), Target: namespace + "/api", Severity: "high"}
+	}
+	ce := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("page_token"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		end := min(offset+limit, len(incidents))
+		resp := clients.IncidentListResponse{Incidents: incidents[offset:end]}
+		resp.Summary.Total = len(incidents)
+		if end < len(incidents) {
+			resp.NextPageToken = strconv.Itoa(end)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(ce.Close)
+
+	server := newStubToolServer(t, NewConfig())
+	server.sanitizer = tools.NewSanitizer(nil)
+	tool := tools.NewListIncidentsTool(testutil.NewCoordinationEngineClient(t, ce.URL))
+
+	page := func(args map[string]interface{}) ([]string, string) {
+		t.Helper()
+		result, _, err := server.executeTool(context.Background(), tool, args)
+		require.NoError(t, err)
+		output := result.(map[string]interface{})
+		var ids []string
+		for _, incident := range output["incidents"].([]interface{}) {
+			ids = append(ids, incident.(map[string]interface{})["id"].(string))
+		}
+		if token, ok := args["page_token"]; ok {
+			assert.Equal(t, token, output["filters"].(map[string]interface{})["page_token"], "the echoed token")
+		}
+		next, _ := output["next_page_token"].(string)
+		return ids, next
+	}
+
+	// The engine's own tokens
+	ids, next := page(map[string]interface{}{"limit": 2})
+	assert.Equal(t, []string{"inc-0", "inc-1"}, ids)
+	require.Equal(t, "2", next)
+	ids, next = page(map[string]interface{}{"limit": 2, "page_token": next})
+	assert.Equal(t, []string{"inc-2", "inc-3"}, ids)
+	assert.Equal(t, "4", next)
+
+	// The tokens of locally filtered pages
+	ids, next = page(map[string]interface{}{"namespace": "payments", "limit": 2})
+	assert.Equal(t, []string{"inc-0", "inc-2"}, ids)
+	require.Equal(t, "local:2", next)
+	ids, next = page(map[string]interface{}{"namespace": "payments", "limit": 2, "page_token": next})
+	assert.Equal(t, []string{"inc-4"}, ids)
+	assert.Empty(t, next)
+}
+
+// windowedTool declares a duration and a time argument and returns the
+// arguments it was called with
+type windowedTool struct {
+	stubTool
+}
+
+func (t *windowedTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+		"window": tools.DurationProperty("Window", "15m"),
+		"since":  tools.TimeProperty("Start"),
+		"name":   map[string]interface{}{"type": "string"},
+	}}
+}
+
+func (t *windowedTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	return map[string]interface{}{"window": args["window"], "since": args["since"], "name": args["name"]}, nil
+}
+
+func TestExecuteTool_NormalizesTimeArgs(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.clock = testclock.NewFakePassiveClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	tool := &windowedTool{stubTool{name: "get-window"}}
+
+	result, _, err := server.executeTool(context.Background(), tool, map[string]interface{}{
+		"window": "2h",
+		"since":  "2026-10-15T14:00:00+02:00",
+		"name":   "15m",
+	})
+	require.NoError(t, err)
+	// Sanitizing turns the result into JSON values
+	out := result.(map[string]interface{})
+	assert.Equal(t, float64(2*time.Hour), out["window"])
+	assert.Equal(t, "2026-10-15T12:00:00Z", out["since"])
+	assert.Equal(t, "15m", out["name"], "only declared duration and time arguments are parsed")
+
+	// Relative times are taken from the server's clock
+	result, _, err = server.executeTool(context.Background(), tool, map[string]interface{}{"since": "now-1h"})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-15T11:00:00Z", result.(map[string]interface{})["since"])
+
+	_, _, err = server.executeTool(context.Background(), tool, map[string]interface{}{"since": "2 hours ago"})
+	assert.True(t, tools.IsInvalidArguments(err))
+	assert.ErrorContains(t, err, "since: cannot parse '2 hours ago'")
+}
+
+func TestHandleToolCall_TimeArgParseError(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.registerTool(&windowedTool{stubTool{name: "get-window"}})
+	sessionID := createSession(t, server, "", nil)
+
+	w := authRequest(server, http.MethodPost, "/mcp/tools/get-window/call?sessionid="+sessionID, "", map[string]interface{}{"window": "a while"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "window: cannot parse 'a while'")
+}
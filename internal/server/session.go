@@ -4,8 +4,12 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"maps"
+	"sort"
 	"sync"
 	"time"
+
+	"k8s.io/utils/clock"
 )
 
 // Session represents an MCP session for REST API clients
@@ -25,10 +29,24 @@ type SessionManager struct {
 	ttl        time.Duration
 	maxSessons int
 	stopClean  chan struct{}
+	clock      clock.WithTicker
+
+	// Optional persistence (see session_store.go)
+	store         SessionStore
+	storeMaxBytes int
+	persistDelay  time.Duration
+	dirty         chan struct{}
+	persistDone   chan struct{}
 }
 
 // NewSessionManager creates a new session manager
 func NewSessionManager(sessionTTL time.Duration, maxSessions int) *SessionManager {
+	return NewSessionManagerWithClock(sessionTTL, maxSessions, clock.RealClock{})
+}
+
+// NewSessionManagerWithClock creates a session manager whose sessions expire
+// on clk, so tests can step past the TTL
+func NewSessionManagerWithClock(sessionTTL time.Duration, maxSessions int, clk clock.WithTicker) *SessionManager {
 	if sessionTTL == 0 {
 		sessionTTL = 30 * time.Minute // Default: 30 minutes
 	}
@@ -41,6 +59,7 @@ func NewSessionManager(sessionTTL time.Duration, maxSessions int) *SessionManage
 		ttl:        sessionTTL,
 		maxSessons: maxSessions,
 		stopClean:  make(chan struct{}),
+		clock:      clk,
 	}
 
 	// Start background cleanup goroutine
@@ -69,7 +88,7 @@ func (sm *SessionManager) CreateSession(metadata map[string]interface{}) (*Sessi
 		return nil, fmt.Errorf("failed to generate session ID: %w", err)
 	}
 
-	now := time.Now()
+	now := sm.clock.Now()
 	session := &Session{
 		ID:        sessionID,
 		CreatedAt: now,
@@ -79,6 +98,7 @@ func (sm *SessionManager) CreateSession(metadata map[string]interface{}) (*Sessi
 	}
 
 	sm.sessions[sessionID] = session
+	sm.markDirty()
 	return session, nil
 }
 
@@ -93,7 +113,7 @@ func (sm *SessionManager) GetSession(sessionID string) *Session {
 	}
 
 	// Check if expired
-	if time.Now().After(session.ExpiresAt) {
+	if sm.clock.Now().After(session.ExpiresAt) {
 		sm.DeleteSession(sessionID)
 		return nil
 	}
@@ -112,14 +132,16 @@ func (sm *SessionManager) TouchSession(sessionID string) bool {
 	}
 
 	// Check if expired
-	if time.Now().After(session.ExpiresAt) {
+	now := sm.clock.Now()
+	if now.After(session.ExpiresAt) {
 		delete(sm.sessions, sessionID)
 		return false
 	}
 
 	// Update timestamps
-	session.LastUsed = time.Now()
-	session.ExpiresAt = time.Now().Add(sm.ttl)
+	session.LastUsed = now
+	session.ExpiresAt = now.Add(sm.ttl)
+	sm.markDirty()
 	return true
 }
 
@@ -130,11 +152,38 @@ func (sm *SessionManager) DeleteSession(sessionID string) bool {
 
 	if _, exists := sm.sessions[sessionID]; exists {
 		delete(sm.sessions, sessionID)
+		sm.markDirty()
 		return true
 	}
 	return false
 }
 
+// ListSessions returns copies of all unexpired sessions, oldest first. The
+// copies (including their metadata maps) may be read without holding the lock.
+func (sm *SessionManager) ListSessions() []Session {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	now := sm.clock.Now()
+	sessions := make([]Session, 0, len(sm.sessions))
+	for _, session := range sm.sessions {
+		if now.After(session.ExpiresAt) {
+			continue
+		}
+		copied := *session
+		copied.Metadata = maps.Clone(session.Metadata)
+		sessions = append(sessions, copied)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].CreatedAt.Equal(sessions[j].CreatedAt) {
+			return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+	return sessions
+}
+
 // GetSessionInfo returns session info without sensitive data
 func (sm *SessionManager) GetSessionInfo(sessionID string) *SessionInfo {
 	session := sm.GetSession(sessionID)
@@ -142,12 +191,18 @@ func (sm *SessionManager) GetSessionInfo(sessionID string) *SessionInfo {
 		return nil
 	}
 
-	return &SessionInfo{
+	info := sessionInfo(*session, sm.clock.Now())
+	return &info
+}
+
+// sessionInfo converts a session to its public representation as of now
+func sessionInfo(session Session, now time.Time) SessionInfo {
+	return SessionInfo{
 		ID:          session.ID,
 		CreatedAt:   session.CreatedAt,
 		ExpiresAt:   session.ExpiresAt,
 		LastUsed:    session.LastUsed,
-		TTLSeconds:  int(time.Until(session.ExpiresAt).Seconds()),
+		TTLSeconds:  int(session.ExpiresAt.Sub(now).Seconds()),
 		IsValid:     true,
 		HasMetadata: len(session.Metadata) > 0,
 	}
@@ -171,7 +226,7 @@ func (sm *SessionManager) GetStats() SessionStats {
 
 	activeCount := 0
 	expiredCount := 0
-	now := time.Now()
+	now := sm.clock.Now()
 
 	for _, session := range sm.sessions {
 		if now.After(session.ExpiresAt) {
@@ -199,19 +254,23 @@ type SessionStats struct {
 	SessionTTL      string `json:"session_ttl"`
 }
 
-// Stop stops the session manager cleanup goroutine
+// Stop stops the session manager cleanup goroutine, writing pending
+// changes to the session store first when persistence is enabled
 func (sm *SessionManager) Stop() {
 	close(sm.stopClean)
+	if sm.persistDone != nil {
+		<-sm.persistDone
+	}
 }
 
 // cleanupLoop runs periodic cleanup of expired sessions
 func (sm *SessionManager) cleanupLoop() {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := sm.clock.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-ticker.C():
 			sm.cleanupExpired()
 		case <-sm.stopClean:
 			return
@@ -228,10 +287,11 @@ func (sm *SessionManager) cleanupExpired() {
 
 // cleanupExpiredLocked removes expired sessions (caller must hold lock)
 func (sm *SessionManager) cleanupExpiredLocked() {
-	now := time.Now()
+	now := sm.clock.Now()
 	for id, session := range sm.sessions {
 		if now.After(session.ExpiresAt) {
 			delete(sm.sessions, id)
+			sm.markDirty()
 		}
 	}
 }
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// Page size bounds of GET /mcp/sessions
+const (
+	defaultSessionPageSize = 100
+	maxSessionPageSize     = 1000
+)
+
+// AdminSessionInfo is a session as listed to operators: unlike SessionInfo it
+// includes the metadata, which identifies the user and client behind it
+type AdminSessionInfo struct {
+	SessionInfo
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// SessionListResponse is one page of GET /mcp/sessions
+type SessionListResponse struct {
+	Sessions   []AdminSessionInfo `json:"sessions"`
+	Count      int                `json:"count"`
+	TotalCount int                `json:"total_count"` // Sessions matching the filter across all pages
+	Offset     int                `json:"offset"`
+	Limit      int                `json:"limit"`
+	NextOffset int                `json:"next_offset,omitempty"` // Offset of the following page, if any
+}
+
+// sessionFilter selects sessions by a metadata key and, optionally, its value
+type sessionFilter struct {
+	key   string
+	value string
+}
+
+// parseSessionFilter reads metadata_key and metadata_value from the query
+func parseSessionFilter(r *http.Request) (sessionFilter, error) {
+	query := r.URL.Query()
+	filter := sessionFilter{key: query.Get("metadata_key"), value: query.Get("metadata_value")}
+	if filter.key == "" && filter.value != "" {
+		return filter, fmt.Errorf("metadata_value requires metadata_key")
+	}
+	return filter, nil
+}
+
+// matches reports whether the session passes the filter. Values compare as
+// text, so metadata_value=true matches a boolean true.
+func (f sessionFilter) matches(session Session) bool {
+	if f.key == "" {
+		return true
+	}
+	value, ok := session.Metadata[f.key]
+	if !ok {
+		return false
+	}
+	return f.value == "" || fmt.Sprint(value) == f.value
+}
+
+// handleSessions lists or revokes sessions across all users. Both need the admin token.
+// GET /mcp/sessions?sort=last_used&metadata_key=user&metadata_value=alice&limit=100&offset=0
+// DELETE /mcp/sessions?metadata_key=user&metadata_value=alice, or ?all=true to revoke every session
+func (s *MCPServer) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed - use GET or DELETE", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.requireAdmin(w, r) {
+		return
+	}
+
+	filter, err := parseSessionFilter(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		s.revokeSessions(w, r, filter)
+		return
+	}
+	s.listSessions(w, r, filter)
+}
+
+// listSessions writes one page of the sessions matching filter
+func (s *MCPServer) listSessions(w http.ResponseWriter, r *http.Request, filter sessionFilter) {
+	query := r.URL.Query()
+	limit, err := queryInt(query.Get("limit"), defaultSessionPageSize)
+	if err != nil || limit < 1 || limit > maxSessionPageSize {
+		writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxSessionPageSize))
+		return
+	}
+	offset, err := queryInt(query.Get("offset"), 0)
+	if err != nil || offset < 0 {
+		writeJSONError(w, http.StatusBadRequest, "offset must be a non-negative integer")
+		return
+	}
+
+	var sessions []Session
+	for _, session := range s.sessionManager.ListSessions() {
+		if filter.matches(session) {
+			sessions = append(sessions, session)
+		}
+	}
+
+	switch query.Get("sort") {
+	case "", "created":
+		// ListSessions returns the oldest first
+	case "last_used":
+		sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].LastUsed.After(sessions[j].LastUsed) })
+	default:
+		writeJSONError(w, http.StatusBadRequest, "sort must be created or last_used")
+		return
+	}
+
+	response := SessionListResponse{
+		Sessions:   []AdminSessionInfo{},
+		TotalCount: len(sessions),
+		Offset:     offset,
+		Limit:      limit,
+	}
+	if offset < len(sessions) {
+		end := min(offset+limit, len(sessions))
+		now := s.sessionManager.clock.Now()
+		for _, session := range sessions[offset:end] {
+			response.Sessions = append(response.Sessions, AdminSessionInfo{
+				SessionInfo: sessionInfo(session, now),
+				Metadata:    session.Metadata,
+			})
+		}
+		if end < len(sessions) {
+			response.NextOffset = end
+		}
+	}
+	response.Count = len(response.Sessions)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := writeJSON(w, response); err != nil {
+		log.Printf("Error writing session list: %v", err)
+	}
+}
+
+// revokeSessions deletes the sessions matching filter. Without a filter every
+// session would go, which must be asked for explicitly with all=true.
+func (s *MCPServer) revokeSessions(w http.ResponseWriter, r *http.Request, filter sessionFilter) {
+	all := r.URL.Query().Get("all") == "true"
+	if filter.key == "" && !all {
+		writeJSONError(w, http.StatusBadRequest, "revoking every session requires all=true (or filter with metadata_key)")
+		return
+	}
+	if filter.key != "" && all {
+		writeJSONError(w, http.StatusBadRequest, "all=true cannot be combined with a metadata filter")
+		return
+	}
+
+	revoked := 0
+	for _, session := range s.sessionManager.ListSessions() {
+		if filter.matches(session) && s.sessionManager.DeleteSession(session.ID) {
+			revoked++
+		}
+	}
+	log.Printf("Admin revoked %d sessions (metadata_key=%q metadata_value=%q all=%t)", revoked, filter.key, filter.value, all)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := writeJSON(w, map[string]int{"revoked": revoked}); err != nil {
+		log.Printf("Error writing revoke response: %v", err)
+	}
+}
+
+// queryInt parses an integer query parameter, returning def when it is absent
+func queryInt(value string, def int) (int, error) {
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
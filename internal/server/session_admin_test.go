@@ -0,0 +1,151 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+// newSessionAdminServer holds sessions for alice (3), bob (1), and one anonymous session
+func newSessionAdminServer(t *testing.T) *MCPServer {
+	t.Helper()
+	cfg := NewConfig()
+	cfg.AdminToken = "s3cr3t"
+	server := newStubToolServer(t, cfg)
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	server.sessionManager = NewSessionManagerWithClock(30*time.Minute, 1000, clk)
+	t.Cleanup(server.sessionManager.Stop)
+
+	for _, metadata := range []map[string]interface{}{
+		{sessionUserKey: "alice", "client": "lightspeed"},
+		{sessionUserKey: "bob", "client": "cursor"},
+		{sessionUserKey: "alice", "client": "cursor"},
+		{sessionUserKey: "alice", "client": "lightspeed"},
+		nil,
+	} {
+		_, err := server.sessionManager.CreateSession(metadata)
+		require.NoError(t, err)
+		clk.Step(time.Millisecond) // Distinct creation times
+	}
+	return server
+}
+
+func listSessionsPage(t *testing.T, server *MCPServer, query string) SessionListResponse {
+	t.Helper()
+	w := authRequest(server, http.MethodGet, "/mcp/sessions"+query, "s3cr3t", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var page SessionListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	return page
+}
+
+func TestHandleSessions_List(t *testing.T) {
+	server := newSessionAdminServer(t)
+
+	page := listSessionsPage(t, server, "")
+	assert.Equal(t, 5, page.TotalCount)
+	require.Len(t, page.Sessions, 5)
+	assert.Equal(t, "alice", page.Sessions[0].Metadata[sessionUserKey], "oldest first by default")
+	assert.Zero(t, page.NextOffset)
+
+	t.Run("filter by key and value", func(t *testing.T) {
+		page := listSessionsPage(t, server, "?metadata_key=user&metadata_value=alice")
+		assert.Equal(t, 3, page.TotalCount)
+		for _, session := range page.Sessions {
+			assert.Equal(t, "alice", session.Metadata[sessionUserKey])
+		}
+
+		page = listSessionsPage(t, server, "?metadata_key=client")
+		assert.Equal(t, 4, page.TotalCount, "a key alone matches any value")
+	})
+
+	t.Run("sort by last use", func(t *testing.T) {
+		oldest := listSessionsPage(t, server, "").Sessions[0]
+		require.True(t, server.sessionManager.TouchSession(oldest.ID))
+
+		page := listSessionsPage(t, server, "?sort=last_used")
+		assert.Equal(t, oldest.ID, page.Sessions[0].ID)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		var ids []string
+		query := "?limit=2"
+		for {
+			page := listSessionsPage(t, server, query)
+			assert.LessOrEqual(t, page.Count, 2)
+			for _, session := range page.Sessions {
+				ids = append(ids, session.ID)
+			}
+			if page.NextOffset == 0 {
+				break
+			}
+			query = fmt.Sprintf("?limit=2&offset=%d", page.NextOffset)
+		}
+		assert.Len(t, ids, 5)
+		seen := make(map[string]bool)
+		for _, id := range ids {
+			seen[id] = true
+		}
+		assert.Len(t, seen, 5, "no session appears on two pages")
+
+		page := listSessionsPage(t, server, "?offset=10")
+		assert.Empty(t, page.Sessions)
+		assert.Equal(t, 5, page.TotalCount)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"?limit=0", "?limit=1001", "?offset=-1", "?sort=name", "?metadata_value=alice"} {
+			w := authRequest(server, http.MethodGet, "/mcp/sessions"+query, "s3cr3t", nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+}
+
+func TestHandleSessions_RequiresAdminToken(t *testing.T) {
+	server := newSessionAdminServer(t)
+	server.config.EnableAuth = true // The admin token is accepted without a TokenReview
+
+	assert.Equal(t, http.StatusUnauthorized, authRequest(server, http.MethodGet, "/mcp/sessions", "", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, authRequest(server, http.MethodDelete, "/mcp/sessions?all=true", "alice-token", nil).Code)
+	assert.Equal(t, http.StatusOK, authRequest(server, http.MethodGet, "/mcp/sessions", "s3cr3t", nil).Code)
+	assert.Equal(t, 5, server.sessionManager.GetStats().TotalSessions)
+
+	server.config.AdminToken = ""
+	assert.Equal(t, http.StatusForbidden, authRequest(server, http.MethodGet, "/mcp/sessions", "s3cr3t", nil).Code)
+}
+
+func TestHandleSessions_BulkRevoke(t *testing.T) {
+	server := newSessionAdminServer(t)
+	revoke := func(query string) (int, int) {
+		w := authRequest(server, http.MethodDelete, "/mcp/sessions"+query, "s3cr3t", nil)
+		var body map[string]int
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body["revoked"]
+	}
+
+	// Revoking everything needs the guard
+	code, _ := revoke("")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = revoke("?all=1")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = revoke("?all=true&metadata_key=user&metadata_value=bob")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, 5, server.sessionManager.GetStats().TotalSessions)
+
+	code, revoked := revoke("?metadata_key=user&metadata_value=alice")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 3, revoked)
+	assert.Equal(t, 0, listSessionsPage(t, server, "?metadata_key=user&metadata_value=alice").TotalCount)
+	assert.Equal(t, 1, listSessionsPage(t, server, "?metadata_key=user&metadata_value=bob").TotalCount)
+
+	code, revoked = revoke("?all=true")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, revoked)
+	assert.Zero(t, server.sessionManager.GetStats().TotalSessions)
+}
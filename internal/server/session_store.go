@@ -0,0 +1,360 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// sessionStoreDataKey is the ConfigMap/Secret key holding the session snapshot
+const sessionStoreDataKey = "sessions.json"
+
+// sessionPersistDelay batches the session changes written in one store update,
+// so touching sessions on every request costs at most one write per second
+const sessionPersistDelay = time.Second
+
+// sessionStoreTimeout bounds each load from and save to the store
+const sessionStoreTimeout = 10 * time.Second
+
+// sessionSnapshot is the persisted form of the session table
+type sessionSnapshot struct {
+	Version  int               `json:"version"`
+	Sessions []json.RawMessage `json:"sessions"`
+}
+
+// SessionStore persists the REST session snapshot so sessions survive restarts.
+// Load returns nil data when nothing has been saved yet.
+type SessionStore interface {
+	Load(ctx context.Context) ([]byte, error)
+	Save(ctx context.Context, data []byte) error
+}
+
+// FileSessionStore keeps the snapshot in a JSON file, typically on a PVC
+type FileSessionStore struct {
+	path string
+}
+
+// NewFileSessionStore creates a store writing to path
+func NewFileSessionStore(path string) *FileSessionStore {
+	return &FileSessionStore{path: path}
+}
+
+// Load reads the snapshot file
+func (s *FileSessionStore) Load(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save replaces the snapshot file atomically: the data is written and fsynced
+// to a temporary file that is then renamed over the old snapshot
+func (s *FileSessionStore) Save(ctx context.Context, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create session snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // Already renamed on success
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // The write error is reported
+		return fmt.Errorf("failed to write session snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // The sync error is reported
+		return fmt.Errorf("failed to sync session snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close session snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace session snapshot: %w", err)
+	}
+	return nil
+}
+
+// ConfigMapSessionStore keeps the snapshot in a ConfigMap for in-cluster deployments
+type ConfigMapSessionStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMapSessionStore creates a store using the ConfigMap namespace/name
+func NewConfigMapSessionStore(client kubernetes.Interface, namespace, name string) *ConfigMapSessionStore {
+	return &ConfigMapSessionStore{client: client, namespace: namespace, name: name}
+}
+
+// Load reads the snapshot from the ConfigMap
+func (s *ConfigMapSessionStore) Load(ctx context.Context) ([]byte, error) {
+	configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", s.namespace, s.name, err)
+	}
+	if data, ok := configMap.Data[sessionStoreDataKey]; ok {
+		return []byte(data), nil
+	}
+	return nil, nil
+}
+
+// Save writes the snapshot to the ConfigMap, creating it on first use
+func (s *ConfigMapSessionStore) Save(ctx context.Context, data []byte) error {
+	configMaps := s.client.CoreV1().ConfigMaps(s.namespace)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace, Labels: sessionStoreLabels},
+		Data:       map[string]string{sessionStoreDataKey: string(data)},
+	}
+	_, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save configmap %s/%s: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
+
+// SecretSessionStore keeps the snapshot in a Secret, for deployments where
+// session metadata (which includes the authenticated user) must not be world-readable
+type SecretSessionStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewSecretSessionStore creates a store using the Secret namespace/name
+func NewSecretSessionStore(client kubernetes.Interface, namespace, name string) *SecretSessionStore {
+	return &SecretSessionStore{client: client, namespace: namespace, name: name}
+}
+
+// Load reads the snapshot from the Secret
+func (s *SecretSessionStore) Load(ctx context.Context) ([]byte, error) {
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", s.namespace, s.name, err)
+	}
+	return secret.Data[sessionStoreDataKey], nil
+}
+
+// Save writes the snapshot to the Secret, creating it on first use
+func (s *SecretSessionStore) Save(ctx context.Context, data []byte) error {
+	secrets := s.client.CoreV1().Secrets(s.namespace)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace, Labels: sessionStoreLabels},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{sessionStoreDataKey: data},
+	}
+	_, err := secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save secret %s/%s: %w", s.namespace, s.name, err)
+	}
+	return nil
+}
+
+// sessionStoreLabels mark the objects the server creates for its sessions
+var sessionStoreLabels = map[string]string{
+	"app.kubernetes.io/name":      "openshift-cluster-health-mcp",
+	"app.kubernetes.io/component": "sessions",
+}
+
+// newSessionStore returns the store selected by SESSION_STORE, or nil when
+// sessions live in memory only
+func newSessionStore(config *Config, k8sClient *clients.K8sClient) SessionStore {
+	switch config.SessionStore {
+	case "file":
+		return NewFileSessionStore(config.SessionStorePath)
+	case "configmap":
+		return NewConfigMapSessionStore(k8sClient.Clientset(), config.SessionStoreNamespace, config.SessionStoreName)
+	case "secret":
+		return NewSecretSessionStore(k8sClient.Clientset(), config.SessionStoreNamespace, config.SessionStoreName)
+	}
+	return nil
+}
+
+// NewPersistentSessionManager creates a session manager that restores the
+// unexpired sessions saved in store and persists later changes to it in the
+// background. A snapshot larger than maxBytes drops the least recently used
+// sessions; an unreadable or corrupted store is logged and starts empty.
+func NewPersistentSessionManager(sessionTTL time.Duration, maxSessions int, store SessionStore, maxBytes int) *SessionManager {
+	return newPersistentSessionManager(sessionTTL, maxSessions, store, maxBytes, clock.RealClock{})
+}
+
+// newPersistentSessionManager is NewPersistentSessionManager on clk, which
+// also times the persist delay
+func newPersistentSessionManager(sessionTTL time.Duration, maxSessions int, store SessionStore, maxBytes int, clk clock.WithTicker) *SessionManager {
+	sm := NewSessionManagerWithClock(sessionTTL, maxSessions, clk)
+	sm.mutex.Lock() // The cleanup loop is already running
+	sm.store = store
+	sm.storeMaxBytes = maxBytes
+	sm.persistDelay = sessionPersistDelay
+	sm.dirty = make(chan struct{}, 1)
+	sm.persistDone = make(chan struct{})
+	sm.mutex.Unlock()
+
+	restored, err := sm.restore()
+	if err != nil {
+		log.Printf("WARNING: ignoring session store, starting without sessions: %v", err)
+	} else if restored > 0 {
+		log.Printf("Restored %d sessions from the session store", restored)
+	}
+
+	go sm.persistLoop()
+	return sm
+}
+
+// restore loads the unexpired sessions from the store, most recently used
+// first up to the session limit. Expired sessions are never resurrected.
+func (sm *SessionManager) restore() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+
+	data, err := sm.store.Load(ctx)
+	if err != nil || len(data) == 0 {
+		return 0, err
+	}
+
+	var snapshot sessionSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return 0, fmt.Errorf("corrupted session snapshot: %w", err)
+	}
+
+	now := sm.clock.Now()
+	var sessions []*Session
+	for _, raw := range snapshot.Sessions {
+		var session Session
+		if err := json.Unmarshal(raw, &session); err != nil || session.ID == "" {
+			continue // Skip a damaged entry, keep the rest
+		}
+		if now.After(session.ExpiresAt) {
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastUsed.After(sessions[j].LastUsed) })
+	if len(sessions) > sm.maxSessons {
+		sessions = sessions[:sm.maxSessons]
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+	for _, session := range sessions {
+		sm.sessions[session.ID] = session
+	}
+	return len(sessions), nil
+}
+
+// markDirty schedules a store update (caller must hold lock)
+func (sm *SessionManager) markDirty() {
+	if sm.dirty == nil {
+		return
+	}
+	select {
+	case sm.dirty <- struct{}{}:
+	default: // An update is already pending
+	}
+}
+
+// persistLoop writes the session table to the store after each batch of
+// changes, and once more on Stop if changes are pending
+func (sm *SessionManager) persistLoop() {
+	defer close(sm.persistDone)
+
+	for {
+		select {
+		case <-sm.dirty:
+			select {
+			case <-sm.clock.After(sm.persistDelay):
+			case <-sm.stopClean:
+			}
+			sm.persist()
+		case <-sm.stopClean:
+			select {
+			case <-sm.dirty:
+				sm.persist()
+			default:
+			}
+			return
+		}
+	}
+}
+
+// persist saves a snapshot of the session table, logging failures: the
+// sessions stay usable in memory and the next change retries the write
+func (sm *SessionManager) persist() {
+	data, dropped, err := sm.snapshot()
+	if err != nil {
+		log.Printf("WARNING: failed to encode sessions: %v", err)
+		return
+	}
+	if dropped > 0 {
+		log.Printf("WARNING: session store limit of %d bytes reached, %d least recently used sessions not persisted", sm.storeMaxBytes, dropped)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	if err := sm.store.Save(ctx, data); err != nil {
+		log.Printf("WARNING: failed to persist sessions: %v", err)
+	}
+}
+
+// snapshot encodes the unexpired sessions, most recently used first, leaving
+// out whichever no longer fit in storeMaxBytes
+func (sm *SessionManager) snapshot() (data []byte, dropped int, err error) {
+	sm.mutex.RLock()
+	now := sm.clock.Now()
+	sessions := make([]*Session, 0, len(sm.sessions))
+	for _, session := range sm.sessions {
+		if !now.After(session.ExpiresAt) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].LastUsed.After(sessions[j].LastUsed) })
+	encoded := make([]json.RawMessage, 0, len(sessions))
+	for _, session := range sessions {
+		raw, err := json.Marshal(session)
+		if err != nil {
+			sm.mutex.RUnlock()
+			return nil, 0, err
+		}
+		encoded = append(encoded, raw)
+	}
+	sm.mutex.RUnlock()
+
+	// Envelope plus one separator per session
+	size := len(`{"version":1,"sessions":[]}`)
+	kept := 0
+	for _, raw := range encoded {
+		if size+len(raw)+1 > sm.storeMaxBytes {
+			break
+		}
+		size += len(raw) + 1
+		kept++
+	}
+
+	data, err = json.Marshal(sessionSnapshot{Version: 1, Sessions: encoded[:kept]})
+	return data, len(encoded) - kept, err
+}
@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+func TestPersistentSessionManager_SurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store := NewFileSessionStore(path)
+
+	sm := NewPersistentSessionManager(5*time.Minute, 100, store, 64*1024)
+	kept, err := sm.CreateSession(map[string]interface{}{"client": "lightspeed", sessionUserKey: "alice"})
+	require.NoError(t, err)
+	deleted, err := sm.CreateSession(nil)
+	require.NoError(t, err)
+	require.True(t, sm.DeleteSession(deleted.ID))
+
+	// Changes reach the store in the background, without waiting for Stop
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	sm.Stop()
+
+	// A new manager from the same store is the restarted server
+	restarted := NewPersistentSessionManager(5*time.Minute, 100, store, 64*1024)
+	defer restarted.Stop()
+
+	session := restarted.GetSession(kept.ID)
+	require.NotNil(t, session)
+	assert.Equal(t, "alice", session.Metadata[sessionUserKey])
+	assert.Equal(t, "lightspeed", session.Metadata["client"])
+	assert.WithinDuration(t, kept.ExpiresAt, session.ExpiresAt, time.Millisecond)
+	assert.Nil(t, restarted.GetSession(deleted.ID))
+	assert.True(t, restarted.TouchSession(kept.ID))
+}
+
+func TestPersistentSessionManager_DoesNotResurrectExpired(t *testing.T) {
+	now := time.Now()
+	encode := func(id string, expiresAt time.Time) json.RawMessage {
+		raw, err := json.Marshal(Session{ID: id, CreatedAt: now.Add(-time.Hour), ExpiresAt: expiresAt, LastUsed: now})
+		require.NoError(t, err)
+		return raw
+	}
+	data, err := json.Marshal(sessionSnapshot{Version: 1, Sessions: []json.RawMessage{
+		encode("live", now.Add(time.Minute)),
+		encode("expired", now.Add(-time.Second)),
+	}})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	sm := NewPersistentSessionManager(5*time.Minute, 100, NewFileSessionStore(path), 64*1024)
+	defer sm.Stop()
+
+	assert.NotNil(t, sm.GetSession("live"))
+	assert.Nil(t, sm.GetSession("expired"))
+	assert.Equal(t, 1, sm.GetStats().TotalSessions)
+}
+
+func TestPersistentSessionManager_RecoversFromCorruptedStore(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "truncated", data: `{"version":1,"sessions":[{"session_id":"abc"`},
+		{name: "not json", data: "\x00\x01garbage"},
+		{name: "wrong shape", data: `["abc"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "sessions.json")
+			require.NoError(t, os.WriteFile(path, []byte(tt.data), 0o600))
+			store := NewFileSessionStore(path)
+
+			// The server starts without sessions instead of failing
+			sm := NewPersistentSessionManager(5*time.Minute, 100, store, 64*1024)
+			assert.Zero(t, sm.GetStats().TotalSessions)
+
+			// and the next write replaces the corrupted snapshot
+			session, err := sm.CreateSession(nil)
+			require.NoError(t, err)
+			sm.Stop()
+
+			restarted := NewPersistentSessionManager(5*time.Minute, 100, store, 64*1024)
+			defer restarted.Stop()
+			assert.NotNil(t, restarted.GetSession(session.ID))
+		})
+	}
+}
+
+func TestPersistentSessionManager_SkipsDamagedEntries(t *testing.T) {
+	valid, err := json.Marshal(Session{ID: "valid", ExpiresAt: time.Now().Add(time.Minute)})
+	require.NoError(t, err)
+	data, err := json.Marshal(sessionSnapshot{Version: 1, Sessions: []json.RawMessage{
+		json.RawMessage(`{"session_id":42}`),
+		json.RawMessage(`{}`),
+		valid,
+	}})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	sm := NewPersistentSessionManager(5*time.Minute, 100, NewFileSessionStore(path), 64*1024)
+	defer sm.Stop()
+
+	assert.NotNil(t, sm.GetSession("valid"))
+	assert.Equal(t, 1, sm.GetStats().TotalSessions)
+}
+
+func TestPersistentSessionManager_CapsPersistedSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store := NewFileSessionStore(path)
+	const maxBytes = 2048
+
+	clk := testclock.NewFakeClock(time.Now())
+	sm := newPersistentSessionManager(5*time.Minute, 100, store, maxBytes, clk)
+	var ids []string
+	for i := 0; i < 10; i++ {
+		session, err := sm.CreateSession(map[string]interface{}{"note": strings.Repeat("x", 300)})
+		require.NoError(t, err)
+		ids = append(ids, session.ID)
+		clk.Step(time.Millisecond)
+	}
+	// The oldest session becomes the most recently used one
+	require.True(t, sm.TouchSession(ids[0]))
+	sm.Stop()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(data), maxBytes)
+
+	restarted := NewPersistentSessionManager(5*time.Minute, 100, store, maxBytes)
+	defer restarted.Stop()
+
+	restored := restarted.GetStats().TotalSessions
+	assert.Greater(t, restored, 0)
+	assert.Less(t, restored, len(ids), "sessions beyond the cap are not persisted")
+	assert.NotNil(t, restarted.GetSession(ids[0]), "the most recently used sessions are kept")
+	assert.Nil(t, restarted.GetSession(ids[1]), "the least recently used sessions are dropped")
+}
+
+func TestKubernetesSessionStores(t *testing.T) {
+	clientset := fake.NewClientset()
+	stores := map[string]SessionStore{
+		"configmap": NewConfigMapSessionStore(clientset, "self-healing-platform", "cluster-health-mcp-sessions"),
+		"secret":    NewSecretSessionStore(clientset, "self-healing-platform", "cluster-health-mcp-sessions"),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			data, err := store.Load(ctx)
+			require.NoError(t, err)
+			assert.Nil(t, data, "nothing saved yet")
+
+			// The first save creates the object, later ones update it
+			require.NoError(t, store.Save(ctx, []byte(`{"version":1,"sessions":[]}`)))
+			require.NoError(t, store.Save(ctx, []byte(`{"version":1,"sessions":[{}]}`)))
+
+			data, err = store.Load(ctx)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"version":1,"sessions":[{}]}`, string(data))
+		})
+	}
+
+	// Restart simulation against the Secret store
+	sm := NewPersistentSessionManager(5*time.Minute, 100, stores["secret"], 64*1024)
+	session, err := sm.CreateSession(nil)
+	require.NoError(t, err)
+	sm.Stop()
+
+	restarted := NewPersistentSessionManager(5*time.Minute, 100, stores["secret"], 64*1024)
+	defer restarted.Stop()
+	assert.NotNil(t, restarted.GetSession(session.ID))
+}
@@ -6,8 +6,19 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	testclock "k8s.io/utils/clock/testing"
 )
 
+// newFakeClockSessionManager returns a session manager on a fake clock that
+// tests step instead of sleeping
+func newFakeClockSessionManager(t *testing.T, ttl time.Duration) (*SessionManager, *testclock.FakeClock) {
+	t.Helper()
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	sm := NewSessionManagerWithClock(ttl, 100, clk)
+	t.Cleanup(sm.Stop)
+	return sm, clk
+}
+
 func TestNewSessionManager(t *testing.T) {
 	sm := NewSessionManager(5*time.Minute, 100)
 	defer sm.Stop()
@@ -82,14 +93,13 @@ func TestGetSession(t *testing.T) {
 
 func TestGetSession_Expired(t *testing.T) {
 	// Use very short TTL
-	sm := NewSessionManager(1*time.Millisecond, 100)
-	defer sm.Stop()
+	sm, clk := newFakeClockSessionManager(t, 1*time.Millisecond)
 
 	session, err := sm.CreateSession(nil)
 	require.NoError(t, err)
 
 	// Wait for expiration
-	time.Sleep(10 * time.Millisecond)
+	clk.Step(10 * time.Millisecond)
 
 	// Session should be nil (expired)
 	retrieved := sm.GetSession(session.ID)
@@ -97,15 +107,14 @@ func TestGetSession_Expired(t *testing.T) {
 }
 
 func TestTouchSession(t *testing.T) {
-	sm := NewSessionManager(5*time.Minute, 100)
-	defer sm.Stop()
+	sm, clk := newFakeClockSessionManager(t, 5*time.Minute)
 
 	session, err := sm.CreateSession(nil)
 	require.NoError(t, err)
 	originalExpiry := session.ExpiresAt
 
 	// Wait a bit
-	time.Sleep(100 * time.Millisecond)
+	clk.Step(100 * time.Millisecond)
 
 	// Touch session
 	success := sm.TouchSession(session.ID)
@@ -113,7 +122,7 @@ func TestTouchSession(t *testing.T) {
 
 	// Verify expiration was extended
 	retrieved := sm.GetSession(session.ID)
-	assert.True(t, retrieved.ExpiresAt.After(originalExpiry))
+	assert.Equal(t, originalExpiry.Add(100*time.Millisecond), retrieved.ExpiresAt)
 }
 
 func TestTouchSession_NonExistent(t *testing.T) {
@@ -185,8 +194,7 @@ func TestGetStats(t *testing.T) {
 
 func TestCleanupExpired(t *testing.T) {
 	// Use very short TTL
-	sm := NewSessionManager(1*time.Millisecond, 100)
-	defer sm.Stop()
+	sm, clk := newFakeClockSessionManager(t, 1*time.Millisecond)
 
 	// Create sessions
 	_, _ = sm.CreateSession(nil)
@@ -197,7 +205,7 @@ func TestCleanupExpired(t *testing.T) {
 	assert.Equal(t, 2, stats.TotalSessions)
 
 	// Wait for expiration
-	time.Sleep(10 * time.Millisecond)
+	clk.Step(10 * time.Millisecond)
 
 	// Manually trigger cleanup
 	sm.cleanupExpired()
@@ -218,3 +226,30 @@ func TestGenerateSessionID_Uniqueness(t *testing.T) {
 	}
 }
 
+func TestListSessions_ReturnsCopies(t *testing.T) {
+	sm, clk := newFakeClockSessionManager(t, 5*time.Minute)
+
+	first, err := sm.CreateSession(map[string]interface{}{"client": "lightspeed"})
+	require.NoError(t, err)
+	clk.Step(time.Millisecond)
+	second, err := sm.CreateSession(nil)
+	require.NoError(t, err)
+
+	sessions := sm.ListSessions()
+	require.Len(t, sessions, 2)
+	assert.Equal(t, first.ID, sessions[0].ID, "oldest first")
+	assert.Equal(t, second.ID, sessions[1].ID)
+
+	// Changing a listed session leaves the manager's copy alone
+	sessions[0].Metadata["client"] = "changed"
+	sessions[0].ExpiresAt = clk.Now().Add(-time.Hour)
+	session := sm.GetSession(first.ID)
+	require.NotNil(t, session)
+	assert.Equal(t, "lightspeed", session.Metadata["client"])
+
+	// Expired sessions are not listed
+	sm.mutex.Lock()
+	sm.sessions[second.ID].ExpiresAt = clk.Now().Add(-time.Second)
+	sm.mutex.Unlock()
+	assert.Len(t, sm.ListSessions(), 1)
+}
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// errTenantDenied is returned for calls outside the caller's tenant profile
+var errTenantDenied = errors.New("denied by tenant profile")
+
+// TenantProfile scopes the authenticated callers it matches, by user name or
+// group, to a set of namespaces and tools. Namespaced reads are silently
+// filtered to the profile's namespaces; cluster-scoped tools and resources
+// are refused. Callers no profile matches keep full access.
+type TenantProfile struct {
+	Name       string   `json:"name"`
+	Users      []string `json:"users,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Namespaces []string `json:"namespaces"`
+	Tools      []string `json:"tools,omitempty"` // Names or glob patterns (empty = every namespaced tool)
+}
+
+// equal reports whether two profiles are identical
+func (p TenantProfile) equal(other TenantProfile) bool {
+	return p.Name == other.Name &&
+		slices.Equal(p.Users, other.Users) &&
+		slices.Equal(p.Groups, other.Groups) &&
+		slices.Equal(p.Namespaces, other.Namespaces) &&
+		slices.Equal(p.Tools, other.Tools)
+}
+
+// matches reports whether the profile lists the principal's user or one of its groups
+func (p *TenantProfile) matches(principal clients.Principal) bool {
+	if slices.Contains(p.Users, principal.User) {
+		return true
+	}
+	for _, group := range principal.Groups {
+		if slices.Contains(p.Groups, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsTool reports whether the profile's tool patterns admit name
+func (p *TenantProfile) allowsTool(name string) bool {
+	if len(p.Tools) == 0 {
+		return true
+	}
+	for _, pattern := range p.Tools {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// canCall reports whether a tenant may call tool at all: it must be admitted
+// by the profile and read namespaced data only
+func (p *TenantProfile) canCall(tool Tool) bool {
+	return p.allowsTool(tool.Name()) && isNamespaceScoped(tool)
+}
+
+// resolveTenant returns the first profile, in configuration order, that
+// matches principal, or nil when none does
+func resolveTenant(profiles []TenantProfile, principal clients.Principal) *TenantProfile {
+	for i := range profiles {
+		if profiles[i].matches(principal) {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// tenantFor returns the tenant profile of the authenticated caller, nil for
+// unauthenticated requests and callers without a profile
+func (s *MCPServer) tenantFor(ctx context.Context) *TenantProfile {
+	principal, ok := clients.PrincipalFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return resolveTenant(s.currentConfig().TenantProfiles, principal)
+}
+
+// tenantKey carries the tenant profile a tool call was made under
+type tenantKey struct{}
+
+// withTenant records the tenant profile of the call, for the audit log
+func withTenant(ctx context.Context, tenant *TenantProfile) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// tenantFromContext returns the tenant profile the call was made under
+func tenantFromContext(ctx context.Context) *TenantProfile {
+	tenant, _ := ctx.Value(tenantKey{}).(*TenantProfile)
+	return tenant
+}
+
+// NamespacedTool is implemented by tools and resources that only read
+// namespaced objects and honor the namespace scope in their context
+// (clients.NamespaceScopeFromContext). Only these are offered to tenants.
+type NamespacedTool interface {
+	NamespaceScoped() bool
+}
+
+// isNamespaceScoped reports whether a tool or resource declares itself
+// namespace-scoped
+func isNamespaceScoped(v interface{}) bool {
+	namespaced, ok := v.(NamespacedTool)
+	return ok && namespaced.NamespaceScoped()
+}
+
+// checkTenant refuses tool calls outside the tenant's profile and otherwise
+// returns a context scoped to the tenant's namespaces
+func (s *MCPServer) checkTenant(ctx context.Context, tenant *TenantProfile, tool Tool, args map[string]interface{}) (context.Context, error) {
+	if !tenant.allowsTool(tool.Name()) {
+		return ctx, fmt.Errorf("%w: tool %s is not available to tenant %q; available tools: %s",
+			errTenantDenied, tool.Name(), tenant.Name, s.describeTenantTools(tenant))
+	}
+	if !isNamespaceScoped(tool) {
+		return ctx, fmt.Errorf("%w: tool %s works on cluster-wide data such as nodes, operators or incidents, which tenant %q cannot see; available tools: %s",
+			errTenantDenied, tool.Name(), tenant.Name, s.describeTenantTools(tenant))
+	}
+	if namespace, _ := args["namespace"].(string); namespace != "" && !slices.Contains(tenant.Namespaces, namespace) {
+		return ctx, fmt.Errorf("%w: namespace %q is outside tenant %q; available namespaces: %s",
+			errTenantDenied, namespace, tenant.Name, strings.Join(tenant.Namespaces, ", "))
+	}
+	return clients.WithNamespaceScope(ctx, tenant.Namespaces), nil
+}
+
+// checkAllowedNamespaces holds namespace-scoped tools to ALLOWED_NAMESPACES:
+// a namespace outside them is refused, and listings without one are scoped
+// to them. Tenants are already scoped to their profile, which validation
+// keeps within ALLOWED_NAMESPACES.
+func (s *MCPServer) checkAllowedNamespaces(ctx context.Context, tool Tool, args map[string]interface{}) (context.Context, error) {
+	allowed := s.currentConfig().AllowedNamespaces
+	if len(allowed) == 0 || !isNamespaceScoped(tool) {
+		return ctx, nil
+	}
+	if namespace, _ := args["namespace"].(string); namespace != "" && !slices.Contains(allowed, namespace) {
+		return ctx, fmt.Errorf("%w: namespace %q is not in the allowed namespaces", tools.ErrForbidden, namespace)
+	}
+	if _, scoped := clients.NamespaceScopeFromContext(ctx); scoped {
+		return ctx, nil
+	}
+	return clients.WithNamespaceScope(ctx, allowed), nil
+}
+
+// describeTenantTools lists the registered tools a tenant may call
+func (s *MCPServer) describeTenantTools(tenant *TenantProfile) string {
+	var names []string
+	for _, tool := range s.GetTools() {
+		if tenant.canCall(tool) {
+			names = append(names, tool.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+// checkTenantResource refuses resources that are not namespace-scoped to
+// tenants and otherwise returns a context scoped to the tenant's namespaces.
+// Artifacts are only readable by the tenant whose call produced them.
+func (s *MCPServer) checkTenantResource(ctx context.Context, uri string, resource interface{}) (context.Context, error) {
+	tenant := s.tenantFor(ctx)
+	if tenant == nil {
+		return ctx, nil
+	}
+	if artifact, ok := resource.(*artifactMeta); ok {
+		if !artifact.visibleTo(tenant) {
+			return ctx, fmt.Errorf("%w: artifact %s was not produced under tenant %q", errTenantDenied, uri, tenant.Name)
+		}
+		return ctx, nil
+	}
+	if !isNamespaceScoped(resource) {
+		return ctx, fmt.Errorf("%w: resource %s covers the whole cluster, which tenant %q cannot see",
+			errTenantDenied, uri, tenant.Name)
+	}
+	return clients.WithNamespaceScope(ctx, tenant.Namespaces), nil
+}
+
+// tenantDeniedPaths are REST routes reporting on the whole cluster or the
+// whole server, refused to tenants
+var tenantDeniedPaths = map[string]bool{
+	"/export/health":   true,
+	"/export/events":   true,
+	"/stream/health":   true,
+	"/stream/events":   true,
+	"/cache/stats":     true,
+	"/reports/status":  true,
+	"/mcp/tools/stats": true,
+}
+
+// withTenantRoutes refuses cluster-wide REST routes to callers with a tenant
+// profile. It runs after withAuth, which identifies the caller.
+func (s *MCPServer) withTenantRoutes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if tenantDeniedPaths[r.URL.Path] {
+			if tenant := s.tenantFor(r.Context()); tenant != nil {
+				writeJSONError(w, http.StatusForbidden, fmt.Sprintf("%s reports on the whole cluster, which tenant %q cannot see", r.URL.Path, tenant.Name))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validateTenantProfiles checks every profile names its callers and
+// namespaces, and that profiles can take effect at all
+func (c *Config) validateTenantProfiles() []string {
+	var problems []string
+	if len(c.TenantProfiles) > 0 && !c.EnableAuth {
+		problems = append(problems, "tenant profiles require authentication (ENABLE_AUTH), which identifies callers")
+	}
+
+	seen := make(map[string]bool, len(c.TenantProfiles))
+	for i, profile := range c.TenantProfiles {
+		label := fmt.Sprintf("tenant profile %q", profile.Name)
+		switch {
+		case profile.Name == "":
+			label = fmt.Sprintf("tenant profile #%d", i+1)
+			problems = append(problems, label+" has no name")
+		case seen[profile.Name]:
+			problems = append(problems, label+" is defined more than once")
+		}
+		seen[profile.Name] = true
+
+		if len(profile.Users) == 0 && len(profile.Groups) == 0 {
+			problems = append(problems, label+" must list at least one user or group")
+		}
+		if len(profile.Namespaces) == 0 {
+			problems = append(problems, label+" must list at least one namespace")
+		}
+		for _, namespace := range profile.Namespaces {
+			if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+				problems = append(problems, fmt.Sprintf("%s: invalid namespace %q: %s", label, namespace, strings.Join(errs, "; ")))
+			} else if len(c.AllowedNamespaces) > 0 && !slices.Contains(c.AllowedNamespaces, namespace) {
+				problems = append(problems, fmt.Sprintf("%s: namespace %q is not in the allowed namespaces", label, namespace))
+			}
+		}
+		for _, problem := range validateToolPatterns(profile.Tools, nil) {
+			problems = append(problems, fmt.Sprintf("%s: %s", label, problem))
+		}
+	}
+	return problems
+}
+
+// describeTenantProfiles summarizes profiles for the effective settings as
+// "name=namespace|namespace" entries
+func describeTenantProfiles(profiles []TenantProfile) string {
+	entries := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		entries = append(entries, profile.Name+"="+strings.Join(profile.Namespaces, "|"))
+	}
+	return strings.Join(entries, ",")
+}
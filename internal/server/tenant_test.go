@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// namespacedStubTool is a namespace-scoped stub tool returning the scope it
+// was called with
+type namespacedStubTool struct {
+	stubTool
+}
+
+func (t *namespacedStubTool) NamespaceScoped() bool { return true }
+
+func (t *namespacedStubTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	scope, _ := clients.NamespaceScopeFromContext(ctx)
+	return map[string]interface{}{"tool": t.name, "scope": scope}, nil
+}
+
+// teamA is a tenant profile for the team-a-devs group and carol
+var teamA = TenantProfile{
+	Name:       "team-a",
+	Users:      []string{"carol"},
+	Groups:     []string{"team-a-devs"},
+	Namespaces: []string{"team-a", "team-a-dev"},
+	Tools:      []string{"list-*", "aggregate-events", "get-nodes"},
+}
+
+// newTenantServer serves list-pods and aggregate-events (namespace-scoped),
+// get-nodes (cluster-scoped) and search-logs (outside teamA's tools) with
+// teamA configured
+func newTenantServer(t *testing.T) *MCPServer {
+	t.Helper()
+	cfg := NewConfig()
+	cfg.EnableAuth = true
+	cfg.TenantProfiles = []TenantProfile{teamA}
+	server := newStubToolServer(t, cfg, "get-nodes")
+	for _, name := range []string{"list-pods", "aggregate-events", "search-logs"} {
+		server.registerTool(&namespacedStubTool{stubTool{name: name}})
+	}
+	return server
+}
+
+func TestResolveTenant(t *testing.T) {
+	everyone := TenantProfile{Name: "everyone", Groups: []string{"system:authenticated"}, Namespaces: []string{"shared"}}
+	profiles := []TenantProfile{teamA, everyone}
+
+	tests := []struct {
+		name      string
+		principal clients.Principal
+		want      string
+	}{
+		{name: "by user", principal: clients.Principal{User: "carol"}, want: "team-a"},
+		{name: "by group", principal: clients.Principal{User: "dave", Groups: []string{"team-a-devs"}}, want: "team-a"},
+		{name: "first match wins", principal: clients.Principal{User: "carol", Groups: []string{"system:authenticated"}}, want: "team-a"},
+		{name: "later profile", principal: clients.Principal{User: "erin", Groups: []string{"system:authenticated"}}, want: "everyone"},
+		{name: "no match", principal: clients.Principal{User: "alice", Groups: []string{"sre"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tenant := resolveTenant(profiles, tt.principal)
+			if tt.want == "" {
+				assert.Nil(t, tenant)
+				return
+			}
+			require.NotNil(t, tenant)
+			assert.Equal(t, tt.want, tenant.Name)
+		})
+	}
+}
+
+func TestExecuteTool_TenantScope(t *testing.T) {
+	server := newTenantServer(t)
+	carol := clients.WithPrincipal(context.Background(), clients.Principal{User: "carol"})
+	call := func(ctx context.Context, name string, args map[string]interface{}) (interface{}, error) {
+		tool, ok := server.lookupTool(name)
+		require.True(t, ok, name)
+		result, _, err := server.executeTool(ctx, tool, args)
+		return result, err
+	}
+
+	result, err := call(carol, "list-pods", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"team-a", "team-a-dev"}, result.(map[string]interface{})["scope"], "results are sanitized into JSON values")
+
+	_, err = call(carol, "list-pods", map[string]interface{}{"namespace": "team-a-dev"})
+	require.NoError(t, err)
+
+	_, err = call(carol, "list-pods", map[string]interface{}{"namespace": "kube-system"})
+	require.ErrorIs(t, err, errTenantDenied)
+	assert.Contains(t, err.Error(), `namespace "kube-system" is outside tenant "team-a"; available namespaces: team-a, team-a-dev`)
+
+	_, err = call(carol, "get-nodes", nil)
+	require.ErrorIs(t, err, errTenantDenied)
+	assert.Contains(t, err.Error(), "works on cluster-wide data such as nodes, operators or incidents")
+	assert.Contains(t, err.Error(), "available tools: aggregate-events, list-pods")
+
+	_, err = call(carol, "search-logs", map[string]interface{}{"namespace": "team-a"})
+	require.ErrorIs(t, err, errTenantDenied)
+	assert.Contains(t, err.Error(), `tool search-logs is not available to tenant "team-a"`)
+	assert.Equal(t, "forbidden", classifyToolError(err).name)
+	assert.Equal(t, outcomeBlocked, classifyToolOutcome(err))
+
+	// Callers without a profile are not scoped
+	alice := clients.WithPrincipal(context.Background(), clients.Principal{User: "alice", Groups: []string{"sre"}})
+	result, err = call(alice, "list-pods", nil)
+	require.NoError(t, err)
+	assert.Nil(t, result.(map[string]interface{})["scope"])
+	_, err = call(alice, "get-nodes", nil)
+	require.NoError(t, err)
+}
+
+func TestExecuteTool_AllowedNamespaces(t *testing.T) {
+	server := newTenantServer(t)
+	cfg := *server.config
+	cfg.AllowedNamespaces = []string{"team-a", "team-a-dev", "shared"}
+	server.liveConfig.Store(&cfg)
+	call := func(ctx context.Context, name string, args map[string]interface{}) (interface{}, error) {
+		tool, ok := server.lookupTool(name)
+		require.True(t, ok, name)
+		result, _, err := server.executeTool(ctx, tool, args)
+		return result, err
+	}
+
+	// Namespace-scoped tools only read the allowed namespaces
+	result, err := call(context.Background(), "list-pods", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"team-a", "team-a-dev", "shared"}, result.(map[string]interface{})["scope"])
+	_, err = call(context.Background(), "search-logs", map[string]interface{}{"namespace": "shared"})
+	require.NoError(t, err)
+	_, err = call(context.Background(), "search-logs", map[string]interface{}{"namespace": "kube-system"})
+	require.ErrorIs(t, err, tools.ErrForbidden)
+	assert.Contains(t, err.Error(), `namespace "kube-system" is not in the allowed namespaces`)
+
+	// Tenants keep their own, narrower scope
+	carol := clients.WithPrincipal(context.Background(), clients.Principal{User: "carol"})
+	result, err = call(carol, "list-pods", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"team-a", "team-a-dev"}, result.(map[string]interface{})["scope"])
+
+	// Cluster-scoped tools are not limited
+	_, err = call(context.Background(), "get-nodes", nil)
+	require.NoError(t, err)
+}
+
+func TestExecuteTool_TenantCallsAudited(t *testing.T) {
+	server := newTenantServer(t)
+	logs := captureLog(t)
+	carol := clients.WithPrincipal(context.Background(), clients.Principal{User: "carol"})
+
+	listPods, _ := server.lookupTool("list-pods")
+	_, _, err := server.executeTool(carol, listPods, map[string]interface{}{"namespace": "team-a"})
+	require.NoError(t, err)
+	getNodes, _ := server.lookupTool("get-nodes")
+	_, _, err = server.executeTool(carol, getNodes, nil)
+	require.Error(t, err)
+	_, _, err = server.executeTool(clients.WithPrincipal(context.Background(), clients.Principal{User: "alice"}), listPods, nil)
+	require.NoError(t, err)
+
+	entries := auditEntries(t, logs.String())
+	require.Len(t, entries, 2, "read-only calls are audited only under a tenant profile")
+	assert.Equal(t, "list-pods", entries[0].Tool)
+	assert.Equal(t, "team-a", entries[0].Tenant)
+	assert.Equal(t, []string{"team-a", "team-a-dev"}, entries[0].TenantNamespaces)
+	assert.Equal(t, outcomeSuccess, entries[0].Outcome)
+	assert.Equal(t, "get-nodes", entries[1].Tool)
+	assert.Equal(t, outcomeBlocked, entries[1].Outcome)
+	assert.Contains(t, entries[1].Error, "cluster-wide")
+}
+
+func TestTenant_RESTViews(t *testing.T) {
+	server, _, _ := newAuthServer(t, true)
+	cfg := *server.config
+	cfg.TenantProfiles = []TenantProfile{{Name: "bob-team", Users: []string{"bob"}, Namespaces: []string{"bob-ns"}}}
+	server.liveConfig.Store(&cfg)
+	server.registerTool(&namespacedStubTool{stubTool{name: "list-pods"}})
+
+	w := authRequest(server, http.MethodGet, "/export/health", "bob-token", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `reports on the whole cluster, which tenant \"bob-team\" cannot see`)
+
+	// Tenants only see the tools they may call
+	w = authRequest(server, http.MethodGet, "/mcp/tools", "bob-token", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var listing struct {
+		Count int `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listing))
+	assert.Equal(t, 1, listing.Count)
+	w = authRequest(server, http.MethodGet, "/mcp/tools", "alice-token", nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &listing))
+	assert.Equal(t, 2, listing.Count)
+
+	session := createSession(t, server, "bob-token", nil)
+	w = authRequest(server, http.MethodPost, "/mcp/tools/create-incident/call?sessionid="+session, "bob-token", map[string]interface{}{})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "available tools: list-pods")
+
+	w = authRequest(server, http.MethodPost, "/mcp/tools/list-pods/call?sessionid="+session, "bob-token", map[string]interface{}{})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var called struct {
+		Result struct {
+			Scope []string `json:"scope"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &called))
+	assert.Equal(t, []string{"bob-ns"}, called.Result.Scope)
+
+	// Resources that are not namespace-scoped are refused
+	require.NoError(t, server.addResource(&stubResource{uri: "billing://invoices"}))
+	w = authRequest(server, http.MethodGet, "/mcp/resources/billing://invoices/read?sessionid="+session, "bob-token", nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "covers the whole cluster")
+	w = authRequest(server, http.MethodGet, "/mcp/resources", "bob-token", nil)
+	assert.NotContains(t, w.Body.String(), "billing://invoices")
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// defaultRetryAfter is the Retry-After of 429 and 503 responses that know no
+// better delay
+const defaultRetryAfter = 5 * time.Second
+
+// toolErrorClass is how a failed tool call is reported over REST
+type toolErrorClass struct {
+	name      string // error_class in the response
+	status    int
+	retryable bool // Whether repeating the call unchanged may succeed
+}
+
+// classifyToolError maps a tool error to its response status. Errors that
+// carry no failure class are internal errors.
+func classifyToolError(err error) toolErrorClass {
+	switch {
+	case errors.Is(err, tools.ErrInvalidArgs):
+		return toolErrorClass{name: "invalid_arguments", status: http.StatusBadRequest}
+	case errors.Is(err, errReadOnly), errors.Is(err, errTenantDenied), errors.Is(err, tools.ErrForbidden):
+		return toolErrorClass{name: "forbidden", status: http.StatusForbidden}
+	case errors.Is(err, tools.ErrNotFound):
+		return toolErrorClass{name: "not_found", status: http.StatusNotFound}
+	case errors.Is(err, errToolBusy):
+		return toolErrorClass{name: "busy", status: http.StatusTooManyRequests, retryable: true}
+	case isRateLimited(err):
+		return toolErrorClass{name: "throttled", status: http.StatusTooManyRequests, retryable: true}
+	case errors.Is(err, tools.ErrUpstreamUnavailable):
+		return toolErrorClass{name: "upstream_unavailable", status: http.StatusBadGateway, retryable: true}
+	case errors.Is(err, tools.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return toolErrorClass{name: "timeout", status: http.StatusGatewayTimeout, retryable: true}
+	default:
+		return toolErrorClass{name: "internal", status: http.StatusInternalServerError}
+	}
+}
+
+// writeToolError writes a failed tool call with the standard error envelope,
+// adding the failure class and whether the caller may retry
+func writeToolError(w http.ResponseWriter, err error) {
+	class := classifyToolError(err)
+	w.Header().Set("Content-Type", "application/json")
+	if class.status == http.StatusTooManyRequests {
+		setRetryAfter(w, retryAfterOf(err))
+	}
+	w.WriteHeader(class.status)
+	response := map[string]interface{}{
+		"success":     false,
+		"error":       fmt.Sprintf("tool execution failed: %v", err),
+		"error_class": class.name,
+		"retryable":   class.retryable,
+	}
+	if err := writeJSON(w, response); err != nil {
+		log.Printf("Error writing error response: %v", err)
+	}
+}
+
+// throttledError is a failed call that was rate limited on the way; retryAfter
+// is the longest delay asked for or waited, zero when unknown
+type throttledError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *throttledError) Error() string {
+	return e.err.Error()
+}
+
+func (e *throttledError) Unwrap() error {
+	return e.err
+}
+
+// withThrottling attaches the rate limiting in throttles to a failed call's err
+func withThrottling(err error, throttles *clients.ThrottleLog) error {
+	if !throttles.Throttled() {
+		return err
+	}
+	return &throttledError{err: err, retryAfter: throttles.RetryAfter()}
+}
+
+// isRateLimited reports whether err is a 429 from the API server or a dependency
+func isRateLimited(err error) bool {
+	if apierrors.IsTooManyRequests(err) {
+		return true
+	}
+	status, ok := clients.HTTPStatusCode(err)
+	return ok && status == http.StatusTooManyRequests
+}
+
+// retryAfterOf returns how long the caller of a call that failed with err
+// should wait: the delay the rate limited call asked for or waited, or one
+// second for a busy server, whose slots free up as calls finish
+func retryAfterOf(err error) time.Duration {
+	var throttled *throttledError
+	if errors.As(err, &throttled) && throttled.retryAfter > 0 {
+		return throttled.retryAfter
+	}
+	if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Second
+}
+
+// retryAfterSeconds rounds a delay up to the whole seconds of a Retry-After
+// header, at least one
+func retryAfterSeconds(delay time.Duration) int {
+	return max(1, int(math.Ceil(delay.Seconds())))
+}
+
+// setRetryAfter sets the Retry-After header of a 429 or 503 response
+func setRetryAfter(w http.ResponseWriter, delay time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(delay)))
+}
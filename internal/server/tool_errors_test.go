@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+func TestHandleToolCall_MapsFailureClasses(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantStatus    int
+		wantClass     string
+		wantRetryable bool
+	}{
+		{name: "invalid arguments", err: &tools.InvalidArgumentsError{Err: errors.New("limit must be positive")}, wantStatus: http.StatusBadRequest, wantClass: "invalid_arguments"},
+		{name: "forbidden", err: fmt.Errorf("failed to list pods: %w", tools.ErrForbidden), wantStatus: http.StatusForbidden, wantClass: "forbidden"},
+		{name: "not found", err: fmt.Errorf("incident inc-1: %w", tools.ErrNotFound), wantStatus: http.StatusNotFound, wantClass: "not_found"},
+		{name: "busy", err: fmt.Errorf("%w: all 2 heavy tool slots are in use", errToolBusy), wantStatus: http.StatusTooManyRequests, wantClass: "busy", wantRetryable: true},
+		{name: "throttled", err: apiError429(4), wantStatus: http.StatusTooManyRequests, wantClass: "throttled", wantRetryable: true},
+		{name: "upstream unavailable", err: fmt.Errorf("engine down: %w", tools.ErrUpstreamUnavailable), wantStatus: http.StatusBadGateway, wantClass: "upstream_unavailable", wantRetryable: true},
+		{name: "timeout", err: fmt.Errorf("failed to list pods: %w", tools.ErrTimeout), wantStatus: http.StatusGatewayTimeout, wantClass: "timeout", wantRetryable: true},
+		{name: "tool deadline", err: context.DeadlineExceeded, wantStatus: http.StatusGatewayTimeout, wantClass: "timeout", wantRetryable: true},
+		{name: "unclassified", err: errors.New("failed to render manifest"), wantStatus: http.StatusInternalServerError, wantClass: "internal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStubToolServer(t, NewConfig())
+			server.registerTool(&stubTool{name: "failing", err: tt.err})
+			sessionID := createSession(t, server, "", nil)
+
+			w := authRequest(server, http.MethodPost, "/mcp/tools/failing/call?sessionid="+sessionID, "", map[string]interface{}{})
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var body map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, false, body["success"])
+			assert.Equal(t, "tool execution failed: "+tt.err.Error(), body["error"])
+			assert.Equal(t, tt.wantClass, body["error_class"])
+			assert.Equal(t, tt.wantRetryable, body["retryable"])
+		})
+	}
+}
+
+// apiError429 is the API server turning a request away for retryAfter seconds
+func apiError429(retryAfter int) error {
+	return fmt.Errorf("failed to list pods: %w", apierrors.NewTooManyRequests("too many requests", retryAfter))
+}
+
+func TestHandleToolCall_RetryAfter(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.registerTool(&stubTool{name: "rejected", err: apiError429(4)})
+	server.registerTool(&throttlingStubTool{stubTool{name: "held-back", err: apiError429(0)}, 2500 * time.Millisecond})
+	server.registerTool(&stubTool{name: "busy", err: errToolBusy})
+	sessionID := createSession(t, server, "", nil)
+
+	for tool, want := range map[string]string{
+		"rejected":  "4", // As the API server asked
+		"held-back": "3", // The longest delay the call ran into
+		"busy":      "1",
+	} {
+		w := authRequest(server, http.MethodPost, "/mcp/tools/"+tool+"/call?sessionid="+sessionID, "", map[string]interface{}{})
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, tool)
+		assert.Equal(t, want, w.Header().Get("Retry-After"), tool)
+	}
+
+	// 503s tell when to come back too
+	w := httptest.NewRecorder()
+	writeIntegrationUnavailable(w, "tool", "list-incidents", "coordination-engine", 30*time.Second)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	w = httptest.NewRecorder()
+	writeJSONError(w, http.StatusServiceUnavailable, "server is shutting down")
+	assert.Equal(t, "5", w.Header().Get("Retry-After"))
+}
+
+func TestHandleToolCall_ReadOnlyIsForbidden(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ReadOnly = true
+	server := newStubToolServer(t, cfg)
+	server.registerTool(&mutatingStubTool{stubTool: stubTool{name: "trigger-remediation"}})
+	sessionID := createSession(t, server, "", nil)
+
+	w := authRequest(server, http.MethodPost, "/mcp/tools/trigger-remediation/call?sessionid="+sessionID, "", map[string]interface{}{})
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"error_class": "forbidden"`)
+}
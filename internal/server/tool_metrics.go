@@ -0,0 +1,335 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Tool call outcomes used as metric labels and in /mcp/tools/stats
+const (
+	outcomeSuccess       = "success"
+	outcomeUpstreamError = "upstream_error"
+	outcomeTimeout       = "timeout"
+	outcomeInvalidArgs   = "invalid_args"
+	outcomeBlocked       = "blocked"
+	outcomeBusy          = "busy"
+)
+
+// toolLatencyWindow is the number of recent calls used for p50/p95 per tool
+const toolLatencyWindow = 100
+
+// toolMetrics records per-tool latency, outcomes, and result sizes.
+// Prometheus metrics cover long-term trends; the in-memory window gives
+// operators a quick read via /mcp/tools/stats without Prometheus.
+type toolMetrics struct {
+	registry   *prometheus.Registry
+	duration   *prometheus.HistogramVec
+	calls      *prometheus.CounterVec
+	resultSize *prometheus.HistogramVec
+
+	// Execution slots per weight class (see toolScheduler)
+	running   *prometheus.GaugeVec
+	queued    *prometheus.GaugeVec
+	queueWait *prometheus.HistogramVec
+
+	// KServe inference calls, labeled by model
+	inferenceDuration *prometheus.HistogramVec
+	inferenceCalls    *prometheus.CounterVec
+
+	mu    sync.Mutex
+	stats map[string]*toolCallStats
+}
+
+// toolCallStats holds the in-memory summary for one tool
+type toolCallStats struct {
+	calls       int64
+	outcomes    map[string]int64
+	latencies   []time.Duration // Ring buffer of the most recent calls
+	next        int
+	lastError   string
+	lastErrorAt time.Time
+}
+
+// ToolStats is the per-tool summary returned by GET /mcp/tools/stats
+type ToolStats struct {
+	Tool        string           `json:"tool"`
+	Calls       int64            `json:"calls"`
+	Outcomes    map[string]int64 `json:"outcomes"`
+	P50Ms       float64          `json:"p50_ms"`
+	P95Ms       float64          `json:"p95_ms"`
+	LastError   string           `json:"last_error,omitempty"`
+	LastErrorAt *time.Time       `json:"last_error_at,omitempty"`
+}
+
+// newToolMetrics creates the tool metrics and registers them on a private registry
+func newToolMetrics() *toolMetrics {
+	m := &toolMetrics{
+		registry: prometheus.NewRegistry(),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_tool_execution_duration_seconds",
+			Help:    "Tool execution duration in seconds.",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"tool"}),
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_tool_executions_total",
+			Help: "Tool executions by outcome (success, upstream_error, timeout, invalid_args, blocked, busy).",
+		}, []string{"tool", "outcome"}),
+		resultSize: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_tool_result_size_bytes",
+			Help:    "Size of serialized tool results in bytes.",
+			Buckets: prometheus.ExponentialBuckets(256, 4, 8),
+		}, []string{"tool"}),
+		running: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcp_tool_running",
+			Help: "Tool calls running, by weight class (light, heavy).",
+		}, []string{"weight"}),
+		queued: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "mcp_tool_queue_depth",
+			Help: "Tool calls waiting for an execution slot, by weight class (light, heavy).",
+		}, []string{"weight"}),
+		queueWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_tool_queue_wait_seconds",
+			Help:    "Time tool calls waited for an execution slot, by weight class.",
+			Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"weight"}),
+		inferenceDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mcp_kserve_inference_duration_seconds",
+			Help:    "KServe inference call duration in seconds, including retries.",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"model"}),
+		inferenceCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "mcp_kserve_inference_total",
+			Help: "KServe inference calls by outcome (success, upstream_error, timeout).",
+		}, []string{"model", "outcome"}),
+		stats: make(map[string]*toolCallStats),
+	}
+	m.registry.MustRegister(m.duration, m.calls, m.resultSize, m.running, m.queued, m.queueWait, m.inferenceDuration, m.inferenceCalls)
+	return m
+}
+
+// recordInference adds one KServe inference call; it is the KServe client's OnInference hook
+func (m *toolMetrics) recordInference(model string, latency time.Duration, err error) {
+	outcome := outcomeSuccess
+	switch {
+	case err == nil:
+	case errors.Is(err, context.DeadlineExceeded):
+		outcome = outcomeTimeout
+	default:
+		outcome = outcomeUpstreamError
+	}
+	m.inferenceDuration.WithLabelValues(model).Observe(latency.Seconds())
+	m.inferenceCalls.WithLabelValues(model, outcome).Inc()
+}
+
+// setToolQueue sets the running and waiting calls of a weight class; a nil m ignores it
+func (m *toolMetrics) setToolQueue(weight tools.Weight, running, waiting int) {
+	if m == nil {
+		return
+	}
+	m.running.WithLabelValues(string(weight)).Set(float64(running))
+	m.queued.WithLabelValues(string(weight)).Set(float64(waiting))
+}
+
+// observeQueueWait records how long a call of a weight class waited for its slot
+func (m *toolMetrics) observeQueueWait(weight tools.Weight, waited time.Duration) {
+	if m == nil {
+		return
+	}
+	m.queueWait.WithLabelValues(string(weight)).Observe(waited.Seconds())
+}
+
+// record adds one tool execution to the metrics and the in-memory window
+func (m *toolMetrics) record(tool string, duration time.Duration, outcome string, resultSize int, err error) {
+	m.duration.WithLabelValues(tool).Observe(duration.Seconds())
+	m.calls.WithLabelValues(tool, outcome).Inc()
+	if outcome == outcomeSuccess {
+		m.resultSize.WithLabelValues(tool).Observe(float64(resultSize))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, ok := m.stats[tool]
+	if !ok {
+		stats = &toolCallStats{
+			outcomes:  make(map[string]int64),
+			latencies: make([]time.Duration, 0, toolLatencyWindow),
+		}
+		m.stats[tool] = stats
+	}
+
+	stats.calls++
+	stats.outcomes[outcome]++
+	if len(stats.latencies) < toolLatencyWindow {
+		stats.latencies = append(stats.latencies, duration)
+	} else {
+		stats.latencies[stats.next] = duration
+	}
+	stats.next = (stats.next + 1) % toolLatencyWindow
+
+	if err != nil {
+		stats.lastError = err.Error()
+		stats.lastErrorAt = time.Now()
+	}
+}
+
+// snapshot returns the per-tool summary sorted by tool name
+func (m *toolMetrics) snapshot() []ToolStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]ToolStats, 0, len(m.stats))
+	for tool, stats := range m.stats {
+		sorted := make([]time.Duration, len(stats.latencies))
+		copy(sorted, stats.latencies)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		outcomes := make(map[string]int64, len(stats.outcomes))
+		for outcome, count := range stats.outcomes {
+			outcomes[outcome] = count
+		}
+
+		entry := ToolStats{
+			Tool:      tool,
+			Calls:     stats.calls,
+			Outcomes:  outcomes,
+			P50Ms:     durationMs(percentile(sorted, 0.50)),
+			P95Ms:     durationMs(percentile(sorted, 0.95)),
+			LastError: stats.lastError,
+		}
+		if !stats.lastErrorAt.IsZero() {
+			lastErrorAt := stats.lastErrorAt
+			entry.LastErrorAt = &lastErrorAt
+		}
+		result = append(result, entry)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Tool < result[j].Tool })
+	return result
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// durationMs converts a duration to fractional milliseconds
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// classifyToolOutcome maps a tool error to its outcome label
+func classifyToolOutcome(err error) string {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case tools.IsInvalidArguments(err):
+		return outcomeInvalidArgs
+	case errors.Is(err, errReadOnly), errors.Is(err, errTenantDenied):
+		return outcomeBlocked
+	case errors.Is(err, errToolBusy):
+		return outcomeBusy
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, tools.ErrTimeout):
+		return outcomeTimeout
+	default:
+		return outcomeUpstreamError
+	}
+}
+
+// argsHash fingerprints tool arguments for logs without exposing their values
+func argsHash(args map[string]interface{}) string {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "unhashable"
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:6])
+}
+
+// observeToolCall records metrics for one execution and logs it, as a
+// warning when slow
+func (s *MCPServer) observeToolCall(ctx context.Context, tool string, args map[string]interface{}, duration time.Duration, result interface{}, err error) {
+	outcome := classifyToolOutcome(err)
+
+	if s.toolMetrics != nil {
+		resultSize := 0
+		if err == nil {
+			if data, marshalErr := json.Marshal(result); marshalErr == nil {
+				resultSize = len(data)
+			}
+		}
+		s.toolMetrics.record(tool, duration, outcome, resultSize, err)
+	}
+
+	requestID := requestIDFromContext(ctx)
+	if requestID == "" {
+		requestID = "-"
+	}
+	threshold := s.currentConfig().SlowToolThreshold
+	if threshold > 0 && duration > threshold {
+		log.Printf("WARNING: slow tool call tool=%s duration=%s threshold=%s outcome=%s args_hash=%s request_id=%s",
+			tool, duration.Round(time.Millisecond), threshold, outcome, argsHash(args), requestID)
+		return
+	}
+	log.Printf("DEBUG: tool call tool=%s duration=%s outcome=%s args_hash=%s request_id=%s",
+		tool, duration.Round(time.Millisecond), outcome, argsHash(args), requestID)
+}
+
+// handleToolStats returns per-tool call counts, latency percentiles, and last errors
+// GET /mcp/tools/stats
+func (s *MCPServer) handleToolStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed - use GET", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stats := []ToolStats{}
+	if s.toolMetrics != nil {
+		stats = s.toolMetrics.snapshot()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]interface{}{
+		"tools":       stats,
+		"count":       len(stats),
+		"window_size": toolLatencyWindow,
+	}
+
+	if err := writeJSON(w, response); err != nil {
+		log.Printf("Error writing tool stats response: %v", err)
+	}
+}
+
+// handleMetrics serves Prometheus metrics
+// GET /metrics
+func (s *MCPServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	if s.toolMetrics == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	promhttp.HandlerFor(s.toolMetrics.registry, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+func TestClassifyToolOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"success", nil, outcomeSuccess},
+		{"invalid args", &tools.InvalidArgumentsError{Err: errors.New("metric is required")}, outcomeInvalidArgs},
+		{"wrapped timeout", fmt.Errorf("failed to list pods: %w", context.DeadlineExceeded), outcomeTimeout},
+		{"upstream", errors.New("connection refused"), outcomeUpstreamError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyToolOutcome(tt.err))
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(sorted, 0.50))
+	assert.Equal(t, 95*time.Millisecond, percentile(sorted, 0.95))
+	assert.Equal(t, 7*time.Millisecond, percentile(sorted[6:7], 0.95))
+	assert.Equal(t, time.Duration(0), percentile(nil, 0.5))
+}
+
+func TestToolMetrics_SlidingWindow(t *testing.T) {
+	m := newToolMetrics()
+
+	// Fill the window with slow calls, then push them all out with fast ones
+	for i := 0; i < toolLatencyWindow; i++ {
+		m.record("list-pods", time.Second, outcomeSuccess, 100, nil)
+	}
+	for i := 0; i < toolLatencyWindow; i++ {
+		m.record("list-pods", 10*time.Millisecond, outcomeSuccess, 100, nil)
+	}
+	m.record("list-pods", 10*time.Millisecond, outcomeUpstreamError, 0, errors.New("api server unavailable"))
+
+	stats := m.snapshot()
+	require.Len(t, stats, 1)
+	assert.Equal(t, int64(2*toolLatencyWindow+1), stats[0].Calls)
+	assert.Equal(t, int64(2*toolLatencyWindow), stats[0].Outcomes[outcomeSuccess])
+	assert.Equal(t, int64(1), stats[0].Outcomes[outcomeUpstreamError])
+	assert.Equal(t, 10.0, stats[0].P50Ms)
+	assert.Equal(t, 10.0, stats[0].P95Ms)
+	assert.Equal(t, "api server unavailable", stats[0].LastError)
+	assert.NotNil(t, stats[0].LastErrorAt)
+}
+
+func TestToolMetrics_RecordInference(t *testing.T) {
+	m := newToolMetrics()
+	m.recordInference("anomaly-detector", 20*time.Millisecond, nil)
+	m.recordInference("anomaly-detector", time.Second, fmt.Errorf("failed to execute request: %w", context.DeadlineExceeded))
+	m.recordInference("anomaly-detector", 5*time.Millisecond, errors.New("unexpected status code 503"))
+
+	server := &MCPServer{toolMetrics: m}
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, outcome := range []string{outcomeSuccess, outcomeTimeout, outcomeUpstreamError} {
+		assert.Contains(t, body, fmt.Sprintf(`mcp_kserve_inference_total{model="anomaly-detector",outcome="%s"} 1`, outcome))
+	}
+	assert.Contains(t, body, `mcp_kserve_inference_duration_seconds_count{model="anomaly-detector"} 3`)
+}
+
+func TestHandleToolStats(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.toolMetrics = newToolMetrics()
+
+	ok := &stubTool{name: "get-cluster-health"}
+	failing := &stubTool{name: "create-incident", err: &tools.InvalidArgumentsError{Err: errors.New("title is required")}}
+
+	for i := 0; i < 3; i++ {
+		_, _, err := server.executeTool(context.Background(), ok, nil)
+		require.NoError(t, err)
+	}
+	_, _, err := server.executeTool(context.Background(), failing, nil)
+	require.Error(t, err)
+
+	w := httptest.NewRecorder()
+	server.handleToolStats(w, httptest.NewRequest(http.MethodGet, "/mcp/tools/stats", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Tools []ToolStats `json:"tools"`
+		Count int         `json:"count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Equal(t, 2, response.Count)
+
+	// Sorted by tool name
+	assert.Equal(t, "create-incident", response.Tools[0].Tool)
+	assert.Equal(t, int64(1), response.Tools[0].Outcomes[outcomeInvalidArgs])
+	assert.Equal(t, "title is required", response.Tools[0].LastError)
+
+	assert.Equal(t, "get-cluster-health", response.Tools[1].Tool)
+	assert.Equal(t, int64(3), response.Tools[1].Calls)
+	assert.Empty(t, response.Tools[1].LastError)
+}
+
+func TestHandleMetrics_ExposesToolMetrics(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	server.toolMetrics = newToolMetrics()
+
+	_, _, err := server.executeTool(context.Background(), &stubTool{name: "list-pods"}, nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	assert.Contains(t, body, `mcp_tool_executions_total{outcome="success",tool="list-pods"} 1`)
+	assert.Contains(t, body, `mcp_tool_execution_duration_seconds_count{tool="list-pods"} 1`)
+	assert.Contains(t, body, `mcp_tool_result_size_bytes_count{tool="list-pods"} 1`)
+}
+
+func TestObserveToolCall_LogsSlowCalls(t *testing.T) {
+	var logs bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&logs)
+	t.Cleanup(func() { log.SetOutput(previous) })
+
+	cfg := NewConfig()
+	cfg.SlowToolThreshold = time.Second
+	server := newStubToolServer(t, cfg)
+
+	args := map[string]interface{}{"namespace": "default"}
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-123")
+
+	server.observeToolCall(ctx, "list-pods", args, 500*time.Millisecond, nil, nil)
+	assert.NotContains(t, logs.String(), "slow tool call")
+
+	server.observeToolCall(ctx, "list-pods", args, 2*time.Second, nil, nil)
+	assert.Contains(t, logs.String(), "slow tool call tool=list-pods duration=2s")
+	assert.Contains(t, logs.String(), "args_hash="+argsHash(args))
+	assert.Contains(t, logs.String(), "request_id=req-123")
+	assert.NotContains(t, logs.String(), "default")
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// errToolBusy is returned for calls that found no free execution slot within
+// TOOL_QUEUE_TIMEOUT
+var errToolBusy = errors.New("too many concurrent tool calls")
+
+// WeightedTool is implemented by tools whose calls cost more than
+// tools.DefaultWeight
+type WeightedTool interface {
+	Weight() tools.Weight
+}
+
+// toolWeight returns the weight class tool's calls are scheduled in
+func toolWeight(tool Tool) tools.Weight {
+	if weighted, ok := tool.(WeightedTool); ok && weighted.Weight() == tools.WeightHeavy {
+		return tools.WeightHeavy
+	}
+	return tools.WeightLight
+}
+
+// toolScheduler limits how many tool calls run at once, per weight class.
+// Light and heavy calls have slots of their own, so heavy calls never hold
+// the slots of light ones. A call finding its class full waits for a slot,
+// up to a timeout. The zero value is ready to use.
+type toolScheduler struct {
+	mu      sync.Mutex
+	running map[tools.Weight]int
+	waiting map[tools.Weight]int
+	freed   map[tools.Weight]chan struct{} // Closed and replaced whenever a slot of the class is freed
+}
+
+// acquire takes a slot of weight's class, of which limit may be taken at
+// once, waiting up to timeout for one to free up. The returned release frees
+// the slot; waited is how long the call queued. metrics may be nil.
+func (s *toolScheduler) acquire(ctx context.Context, weight tools.Weight, limit int, timeout time.Duration, metrics *toolMetrics) (release func(), waited time.Duration, err error) {
+	start := time.Now()
+	var deadline <-chan time.Time
+	queued := false
+	defer func() {
+		if queued {
+			s.mu.Lock()
+			s.waiting[weight]--
+			metrics.setToolQueue(weight, s.running[weight], s.waiting[weight])
+			s.mu.Unlock()
+		}
+		if err == nil {
+			metrics.observeQueueWait(weight, waited)
+		}
+	}()
+
+	for {
+		s.mu.Lock()
+		if s.running == nil {
+			s.running = make(map[tools.Weight]int)
+			s.waiting = make(map[tools.Weight]int)
+			s.freed = make(map[tools.Weight]chan struct{})
+		}
+		if s.running[weight] < limit {
+			s.running[weight]++
+			metrics.setToolQueue(weight, s.running[weight], s.waiting[weight])
+			s.mu.Unlock()
+			return func() { s.release(weight, metrics) }, time.Since(start), nil
+		}
+		if timeout <= 0 {
+			s.mu.Unlock()
+			return nil, 0, fmt.Errorf("%w: all %d %s tool slots are in use", errToolBusy, limit, weight)
+		}
+		if !queued {
+			queued = true
+			s.waiting[weight]++
+			metrics.setToolQueue(weight, s.running[weight], s.waiting[weight])
+			deadline = time.After(timeout)
+		}
+		freed, ok := s.freed[weight]
+		if !ok {
+			freed = make(chan struct{})
+			s.freed[weight] = freed
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-freed:
+		case <-deadline:
+			return nil, time.Since(start), fmt.Errorf("%w: all %d %s tool slots stayed in use for %s", errToolBusy, limit, weight, timeout)
+		case <-ctx.Done():
+			return nil, time.Since(start), ctx.Err()
+		}
+	}
+}
+
+// release frees a slot of weight's class and wakes the calls waiting for one
+func (s *toolScheduler) release(weight tools.Weight, metrics *toolMetrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running[weight]--
+	metrics.setToolQueue(weight, s.running[weight], s.waiting[weight])
+	if freed, ok := s.freed[weight]; ok {
+		close(freed)
+		delete(s.freed, weight)
+	}
+}
+
+// toolLimit returns how many calls of weight's class may run at once
+func (c *Config) toolLimit(weight tools.Weight) int {
+	if weight == tools.WeightHeavy {
+		return c.MaxConcurrentHeavyTools
+	}
+	return c.MaxConcurrentTools
+}
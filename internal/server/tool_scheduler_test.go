@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+)
+
+// slowTool is a heavy stub whose calls run until released
+type slowTool struct {
+	stubTool
+	started chan struct{}
+	release chan struct{}
+}
+
+func newSlowTool(name string) *slowTool {
+	return &slowTool{stubTool: stubTool{name: name}, started: make(chan struct{}, 16), release: make(chan struct{})}
+}
+
+func (t *slowTool) Weight() tools.Weight { return tools.WeightHeavy }
+
+func (t *slowTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	t.started <- struct{}{}
+	select {
+	case <-t.release:
+		return map[string]interface{}{"done": true}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// waitForQueue waits until n calls of weight's class wait for a slot
+func waitForQueue(t *testing.T, scheduler *toolScheduler, weight tools.Weight, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		scheduler.mu.Lock()
+		defer scheduler.mu.Unlock()
+		return scheduler.waiting[weight] == n
+	}, 5*time.Second, 5*time.Millisecond)
+}
+
+func TestToolWeight(t *testing.T) {
+	assert.Equal(t, tools.WeightLight, toolWeight(&stubTool{name: "get-cluster-health"}))
+	assert.Equal(t, tools.WeightHeavy, toolWeight(newSlowTool("search-logs")))
+}
+
+func TestToolScheduler_LightCallsFlowWhileHeaviesQueue(t *testing.T) {
+	cfg := NewConfig()
+	cfg.MaxConcurrentTools = 4
+	cfg.MaxConcurrentHeavyTools = 2
+	server := newStubToolServer(t, cfg)
+	server.toolMetrics = newToolMetrics()
+	heavy := newSlowTool("search-logs")
+
+	// Distinct arguments keep the heavy calls from being coalesced
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func(i int) {
+			_, _, err := server.executeTool(context.Background(), heavy, map[string]interface{}{"call": i})
+			errs <- err
+		}(i)
+	}
+	<-heavy.started
+	<-heavy.started
+	waitForQueue(t, &server.scheduler, tools.WeightHeavy, 1)
+
+	// Both heavy slots are taken and a third heavy call queues; light calls
+	// beyond the heavy limit still run right away
+	for i := 0; i < 10; i++ {
+		_, _, err := server.executeTool(context.Background(), &stubTool{name: "get-cluster-health"}, map[string]interface{}{"call": i})
+		require.NoError(t, err)
+	}
+	select {
+	case <-heavy.started:
+		t.Fatal("a third heavy call ran past the heavy limit")
+	default:
+	}
+
+	w := httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	assert.Contains(t, body, `mcp_tool_queue_depth{weight="heavy"} 1`)
+	assert.Contains(t, body, `mcp_tool_running{weight="heavy"} 2`)
+	assert.Contains(t, body, `mcp_tool_queue_wait_seconds_count{weight="light"} 10`)
+
+	close(heavy.release)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, <-errs)
+	}
+	w = httptest.NewRecorder()
+	server.handleMetrics(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body = w.Body.String()
+	assert.Contains(t, body, `mcp_tool_queue_depth{weight="heavy"} 0`)
+	assert.Contains(t, body, `mcp_tool_running{weight="heavy"} 0`)
+	assert.Contains(t, body, `mcp_tool_queue_wait_seconds_count{weight="heavy"} 3`)
+}
+
+func TestToolScheduler_QueueTimeout(t *testing.T) {
+	cfg := NewConfig()
+	cfg.MaxConcurrentHeavyTools = 1
+	cfg.ToolQueueTimeout = 20 * time.Millisecond
+	server := newStubToolServer(t, cfg)
+	server.toolMetrics = newToolMetrics()
+	heavy := newSlowTool("generate-health-report")
+	defer close(heavy.release)
+
+	go func() {
+		_, _, _ = server.executeTool(context.Background(), heavy, map[string]interface{}{"call": 1})
+	}()
+	<-heavy.started
+
+	start := time.Now()
+	_, _, err := server.executeTool(context.Background(), heavy, map[string]interface{}{"call": 2})
+	require.ErrorIs(t, err, errToolBusy)
+	assert.GreaterOrEqual(t, time.Since(start), cfg.ToolQueueTimeout, "the call queued before it was refused")
+	assert.Equal(t, outcomeBusy, classifyToolOutcome(err))
+
+	// Without a queue timeout, a full class refuses at once
+	cfg.ToolQueueTimeout = 0
+	_, _, err = server.executeTool(context.Background(), heavy, map[string]interface{}{"call": 3})
+	require.ErrorIs(t, err, errToolBusy)
+	assert.Contains(t, err.Error(), "all 1 heavy tool slots are in use")
+}
+
+func TestToolScheduler_CanceledWhileQueued(t *testing.T) {
+	var scheduler toolScheduler
+	release, _, err := scheduler.acquire(context.Background(), tools.WeightHeavy, 1, time.Minute, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, _, err := scheduler.acquire(ctx, tools.WeightHeavy, 1, time.Minute, nil)
+		errs <- err
+	}()
+	waitForQueue(t, &scheduler, tools.WeightHeavy, 1)
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+	waitForQueue(t, &scheduler, tools.WeightHeavy, 0)
+
+	// The slot is handed on once released
+	release()
+	release, waited, err := scheduler.acquire(context.Background(), tools.WeightHeavy, 1, 0, nil)
+	require.NoError(t, err)
+	assert.Less(t, waited, time.Second)
+	release()
+}
+
+func TestHandleToolCall_BusyIsTooManyRequests(t *testing.T) {
+	cfg := NewConfig()
+	cfg.MaxConcurrentHeavyTools = 1
+	cfg.ToolQueueTimeout = 0
+	server := newStubToolServer(t, cfg)
+	heavy := newSlowTool("search-logs")
+	server.registerTool(heavy)
+	defer close(heavy.release)
+	sessionID := createSession(t, server, "", nil)
+
+	go func() {
+		_, _, _ = server.executeTool(context.Background(), heavy, map[string]interface{}{"pattern": "panic"})
+	}()
+	<-heavy.started
+
+	w := authRequest(server, http.MethodPost, fmt.Sprintf("/mcp/tools/search-logs/call?sessionid=%s", sessionID), "", map[string]interface{}{"pattern": "OOM"})
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), `"error_class": "busy"`)
+}
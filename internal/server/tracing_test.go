@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// fakeAPIServer serves the node and pod lists used by get-cluster-health
+func fakeAPIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/nodes":
+			fmt.Fprint(w, `{"kind":"NodeList","apiVersion":"v1","items":[{"metadata":{"name":"node-1"},"status":{"conditions":[{"type":"Ready","status":"True"}]}}]}`)
+		case "/api/v1/pods":
+			fmt.Fprint(w, `{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"pod-1","namespace":"default"},"status":{"phase":"Running"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(apiServer.Close)
+	return apiServer
+}
+
+// writeKubeconfig points a kubeconfig at the fake API server
+func writeKubeconfig(t *testing.T, serverURL string) string {
+	t.Helper()
+	content := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: fake
+  cluster:
+    server: %s
+contexts:
+- name: fake
+  context:
+    cluster: fake
+    user: fake
+users:
+- name: fake
+  user: {}
+current-context: fake
+`, serverURL)
+	path := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// installSpanRecorder routes spans to an in-memory recorder for the test
+func installSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestTracing_ClusterHealthSpanHierarchy(t *testing.T) {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		t.Skip("In-cluster config takes precedence over the fake API server")
+	}
+
+	recorder := installSpanRecorder(t)
+
+	// Instrumented clients must be created after the provider is installed
+	apiServer := fakeAPIServer(t)
+	k8sClient, err := clients.NewK8sClient(&clients.K8sClientConfig{KubeconfigPath: writeKubeconfig(t, apiServer.URL)})
+	require.NoError(t, err)
+
+	cfg := NewConfig()
+	memoryCache := cache.NewMemoryCache(cfg.CacheTTL)
+	t.Cleanup(memoryCache.Close)
+
+	server := &MCPServer{
+		config:         cfg,
+		mcpServer:      mcp.NewServer(&mcp.Implementation{Name: cfg.Name, Version: cfg.Version}, nil),
+		k8sClient:      k8sClient,
+		cache:          memoryCache,
+		sessionManager: NewSessionManager(5*time.Minute, 10),
+		tools:          NewToolRegistry(),
+		resources:      NewResourceRegistry(),
+		clock:          clock.RealClock{},
+	}
+	t.Cleanup(server.sessionManager.Stop)
+	server.liveConfig.Store(cfg)
+	server.registerTool(tools.NewClusterHealthTool(k8sClient, memoryCache, nil))
+
+	session, err := server.sessionManager.CreateSession(nil)
+	require.NoError(t, err)
+
+	// Call the tool through the full HTTP handler with an incoming trace context
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	const parentSpanID = "00f067aa0ba902b7"
+	req := httptest.NewRequest(http.MethodPost, "/mcp/tools/get-cluster-health/call?sessionid="+session.ID, bytes.NewBufferString(`{}`))
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+	w := httptest.NewRecorder()
+
+	server.httpHandler().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	spans := recorder.Ended()
+	var httpSpan, toolSpan, computeSpan sdktrace.ReadOnlySpan
+	var apiSpans []sdktrace.ReadOnlySpan
+	for _, span := range spans {
+		switch {
+		case span.Name() == "POST /mcp/tools/{tool}/call":
+			httpSpan = span
+		case span.Name() == "tool get-cluster-health":
+			toolSpan = span
+		case span.Name() == "cache.compute":
+			computeSpan = span
+		case span.SpanKind() == trace.SpanKindClient && span.Parent().IsValid():
+			// API group discovery (for ClusterOperators) is cached across
+			// requests, so it starts its own trace rather than joining this one
+			apiSpans = append(apiSpans, span)
+		}
+	}
+	require.NotNil(t, httpSpan, "missing HTTP server span")
+	require.NotNil(t, toolSpan, "missing tool span")
+	require.NotNil(t, computeSpan, "missing cache compute span")
+	require.Len(t, apiSpans, 2, "expected node and pod list calls")
+
+	// The server joins the caller's trace
+	assert.Equal(t, traceID, httpSpan.SpanContext().TraceID().String())
+	assert.Equal(t, parentSpanID, httpSpan.Parent().SpanID().String())
+	assert.True(t, httpSpan.Parent().IsRemote())
+
+	assert.Equal(t, httpSpan.SpanContext().SpanID(), toolSpan.Parent().SpanID())
+	assert.Equal(t, toolSpan.SpanContext().SpanID(), computeSpan.Parent().SpanID())
+
+	// Compute closures capture the tool's context, so API calls hang off the tool span
+	for _, span := range apiSpans {
+		assert.Equal(t, traceID, span.SpanContext().TraceID().String())
+		assert.Equal(t, toolSpan.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+}
+
+func TestSpanRouteName(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{http.MethodGet, "/health", "GET /health"},
+		{http.MethodPost, "/mcp/tools/list-pods/call", "POST /mcp/tools/{tool}/call"},
+		{http.MethodGet, "/mcp/resources/cluster%3A%2F%2Fhealth/read", "GET /mcp/resources/{uri}/read"},
+		{http.MethodDelete, "/mcp/session/abc123", "DELETE /mcp/session/{sessionid}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			assert.Equal(t, tt.expected, spanRouteName(req))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// warmupTarget is an expensive read whose result the cache warm-up pre-computes
+type warmupTarget struct {
+	name string
+	warm func(ctx context.Context) error
+}
+
+// warmupReport records how each warm-up target fared
+type warmupReport struct {
+	Warmed   []string
+	Failed   []string
+	TimedOut []string
+}
+
+// warmupResult is the outcome of one target
+type warmupResult struct {
+	name    string
+	err     error
+	elapsed time.Duration
+}
+
+// cacheWarmupTargets lists what the warm-up pre-computes: the default
+// get-cluster-health call and every registered resource, all of which cache
+// their results under the keys regular requests use
+func (s *MCPServer) cacheWarmupTargets() []warmupTarget {
+	var targets []warmupTarget
+	if tool, ok := s.lookupTool("get-cluster-health"); ok {
+		targets = append(targets, warmupTarget{
+			name: tool.Name(),
+			warm: func(ctx context.Context) error {
+				_, err := tool.Execute(ctx, map[string]interface{}{})
+				return err
+			},
+		})
+	}
+
+	registered := s.GetResources()
+	uris := make([]string, 0, len(registered))
+	for uri := range registered {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+	for _, uri := range uris {
+		resource := registered[uri]
+		targets = append(targets, warmupTarget{
+			name: uri,
+			warm: func(ctx context.Context) error {
+				_, err := resource.Read(ctx)
+				return err
+			},
+		})
+	}
+	return targets
+}
+
+// warmCache pre-computes the cache warm-up targets concurrently within timeout,
+// then marks the warm-up finished for /ready. Failures are only logged: those
+// reads load lazily on first use as they would without a warm-up.
+func (s *MCPServer) warmCache(ctx context.Context, timeout time.Duration) {
+	defer close(s.warmupDone)
+
+	start := time.Now()
+	report := runWarmup(ctx, timeout, s.cacheWarmupTargets())
+	log.Printf("Cache warm-up finished in %s: %d warmed, %d failed, %d timed out",
+		time.Since(start).Round(time.Millisecond), len(report.Warmed), len(report.Failed), len(report.TimedOut))
+	if len(report.TimedOut) > 0 {
+		log.Printf("WARNING: cache warm-up timed out after %s for: %s", timeout, strings.Join(report.TimedOut, ", "))
+	}
+}
+
+// runWarmup runs targets concurrently and returns once all have finished or
+// timeout has passed. Targets still running then are reported as timed out.
+func runWarmup(ctx context.Context, timeout time.Duration, targets []warmupTarget) warmupReport {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	results := make(chan warmupResult, len(targets))
+	for _, target := range targets {
+		go func() {
+			start := time.Now()
+			err := warmTarget(ctx, target)
+			results <- warmupResult{name: target.name, err: err, elapsed: time.Since(start)}
+		}()
+	}
+
+	var report warmupReport
+	pending := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		pending[target.name] = true
+	}
+	for len(pending) > 0 {
+		select {
+		case result := <-results:
+			delete(pending, result.name)
+			switch {
+			case result.err == nil:
+				log.Printf("Cache warm-up: %s warmed in %s", result.name, result.elapsed.Round(time.Millisecond))
+				report.Warmed = append(report.Warmed, result.name)
+			case ctx.Err() != nil:
+				report.TimedOut = append(report.TimedOut, result.name)
+			default:
+				log.Printf("WARNING: cache warm-up of %s failed, it will load on first use: %v", result.name, result.err)
+				report.Failed = append(report.Failed, result.name)
+			}
+		case <-ctx.Done():
+			for name := range pending {
+				report.TimedOut = append(report.TimedOut, name)
+			}
+			pending = nil
+		}
+	}
+
+	sort.Strings(report.Warmed)
+	sort.Strings(report.Failed)
+	sort.Strings(report.TimedOut)
+	return report
+}
+
+// warmTarget runs one target, turning a panic into an error so a broken
+// dependency cannot take the server down during startup
+func warmTarget(ctx context.Context, target warmupTarget) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return target.warm(ctx)
+}
+
+// warmingUp reports whether /ready should hold off for the cache warm-up
+func (s *MCPServer) warmingUp() bool {
+	if s.warmupDone == nil || !s.config.ReadyAfterWarmup {
+		return false
+	}
+	select {
+	case <-s.warmupDone:
+		return false
+	default:
+		return true
+	}
+}
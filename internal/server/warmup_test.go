@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/resources"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/tools"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestRunWarmup(t *testing.T) {
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	ignoreCancel := func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	start := time.Now()
+	report := runWarmup(context.Background(), 100*time.Millisecond, []warmupTarget{
+		{name: "fast", warm: func(ctx context.Context) error { return nil }},
+		{name: "down", warm: func(ctx context.Context) error { return errors.New("connection refused") }},
+		{name: "panics", warm: func(ctx context.Context) error { panic("nil client") }},
+		{name: "slow", warm: hang},
+		{name: "stuck", warm: ignoreCancel},
+	})
+
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "the budget bounds the warm-up even if a target ignores ctx")
+	assert.Equal(t, []string{"fast"}, report.Warmed)
+	assert.Equal(t, []string{"down", "panics"}, report.Failed)
+	assert.Equal(t, []string{"slow", "stuck"}, report.TimedOut)
+}
+
+func TestWarmCache_PopulatesCache(t *testing.T) {
+	k8sClient := clients.NewK8sClientWithClientset(fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		}},
+	}))
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	defer memoryCache.Close()
+
+	cfg := NewConfig()
+	cfg.EnableCacheWarmup = true
+	cfg.ReadyAfterWarmup = true
+	server := newStubToolServer(t, cfg)
+	server.k8sClient = k8sClient
+	server.cache = memoryCache
+	server.warmupDone = make(chan struct{})
+	server.registerTool(tools.NewClusterHealthTool(k8sClient, memoryCache, nil))
+	server.registerResource(resources.NewClusterHealthResource(k8sClient, nil, memoryCache, nil))
+	server.registerResource(resources.NewNodesResource(k8sClient, memoryCache))
+
+	targets := server.cacheWarmupTargets()
+	require.Len(t, targets, 3)
+	assert.Equal(t, "get-cluster-health", targets[0].name)
+
+	// /ready waits for the warm-up when asked to
+	ready := func() int {
+		w := httptest.NewRecorder()
+		server.httpHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		return w.Code
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, ready())
+
+	server.warmCache(context.Background(), 5*time.Second)
+
+	assert.Equal(t, http.StatusOK, ready())
+	stats := memoryCache.GetStatisticsByPrefix()
+	assert.Equal(t, 1, stats.ByPrefix["tool"].Entries)
+	assert.Equal(t, 2, stats.ByPrefix["resource"].Entries)
+
+	// Without READY_AFTER_WARMUP, /ready does not wait
+	server.config.ReadyAfterWarmup = false
+	server.warmupDone = make(chan struct{})
+	assert.Equal(t, http.StatusOK, ready())
+}
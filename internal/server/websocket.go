@@ -0,0 +1,282 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocket close codes (RFC 6455, section 7.4.1)
+const (
+	wsCloseMessageTooBig = 1009
+	wsCloseTryAgainLater = 1013
+)
+
+// wsWriteTimeout bounds every frame written to a client, so a client that
+// stops reading is dropped instead of holding its connection's writers
+const wsWriteTimeout = 10 * time.Second
+
+// Errors ending a connection from Read
+var (
+	errWebSocketMessageTooBig = errors.New("websocket message exceeds the configured maximum size")
+	errWebSocketSessionEnded  = errors.New("session was revoked or expired")
+)
+
+// handleWebSocket serves the MCP protocol over a WebSocket
+// GET /mcp/ws - Upgrades the connection; every text frame is one JSON-RPC message
+func (s *MCPServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
+	if s.config.Transport != TransportWebSocket {
+		writeJSONError(w, http.StatusNotFound, "WebSocket transport is not enabled (MCP_TRANSPORT=websocket)")
+		return
+	}
+	cfg := s.currentConfig()
+	server := websocket.Server{
+		Handshake: s.checkWebSocketOrigin,
+		Handler: func(ws *websocket.Conn) {
+			s.serveWebSocket(ws, cfg)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// checkWebSocketOrigin rejects cross-site upgrades from browsers. Clients
+// sending no Origin (anything but a browser) are accepted, as are same-host
+// pages and the origins allowed by the CORS policy.
+func (s *MCPServer) checkWebSocketOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := websocket.Origin(config, r)
+	if err != nil {
+		return err
+	}
+	if origin == nil {
+		return nil
+	}
+	if strings.EqualFold(origin.Host, r.Host) || corsOriginAllowed(s.currentConfig().CORSAllowedOrigins, origin.Scheme+"://"+origin.Host) {
+		config.Origin = origin
+		return nil
+	}
+	return fmt.Errorf("origin %q is not allowed", origin)
+}
+
+// serveWebSocket runs one MCP session over ws until either side closes it.
+// Every connection has its own SDK session, reader, and writers, so a slow
+// client only ever holds up itself.
+func (s *MCPServer) serveWebSocket(ws *websocket.Conn, cfg *Config) {
+	remoteAddr := ws.Request().RemoteAddr
+	session, err := s.sessionManager.CreateSession(bindSessionToPrincipal(ws.Request().Context(), map[string]interface{}{
+		"transport":   string(TransportWebSocket),
+		"remote_addr": remoteAddr,
+	}))
+	if err != nil {
+		log.Printf("Rejecting WebSocket connection from %s: %v", remoteAddr, err)
+		_ = ws.WriteClose(wsCloseTryAgainLater)
+		return
+	}
+
+	conn := newWebSocketConnection(ws, session.ID, cfg)
+	conn.touch = func() bool { return s.sessionManager.TouchSession(session.ID) }
+	conn.onClose = func() { s.sessionManager.DeleteSession(session.ID) }
+	s.websockets.add(conn)
+	defer s.websockets.remove(conn)
+	defer func() { _ = conn.Close() }()
+
+	mcpSession, err := s.mcpServer.Connect(ws.Request().Context(), &websocketTransport{conn: conn}, nil)
+	if err != nil {
+		log.Printf("WebSocket session %s failed to start: %v", session.ID, err)
+		return
+	}
+	log.Printf("WebSocket session %s opened from %s", session.ID, remoteAddr)
+
+	go conn.keepalive(cfg.WebSocketPingInterval)
+	if err := mcpSession.Wait(); err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+		log.Printf("WebSocket session %s ended: %v", session.ID, err)
+	}
+	log.Printf("WebSocket session %s closed", session.ID)
+}
+
+// websocketTransport hands an accepted connection to the MCP SDK
+type websocketTransport struct {
+	conn *websocketConnection
+}
+
+// Connect returns the already upgraded connection
+func (t *websocketTransport) Connect(ctx context.Context) (mcp.Connection, error) {
+	return t.conn, nil
+}
+
+// websocketConnection bridges JSON-RPC messages and WebSocket text frames.
+// Reads happen on the SDK's single reader goroutine; writes may come from
+// any handler and are serialized by the websocket package.
+type websocketConnection struct {
+	ws              *websocket.Conn
+	sessionID       string
+	maxMessageBytes int
+	pongWait        time.Duration // Read deadline, refreshed by every frame (0 = none)
+
+	touch   func() bool // Called for every frame read, pongs included; false once the session is revoked
+	onClose func()
+
+	closeOnce sync.Once
+	closeErr  error
+	done      chan struct{}
+}
+
+func newWebSocketConnection(ws *websocket.Conn, sessionID string, cfg *Config) *websocketConnection {
+	// Messages go out through websocket.Message, so Conn.Write only sends pings
+	ws.PayloadType = websocket.PingFrame
+	return &websocketConnection{
+		ws:              ws,
+		sessionID:       sessionID,
+		maxMessageBytes: cfg.WebSocketMaxMessageBytes,
+		pongWait:        2 * cfg.WebSocketPingInterval,
+		done:            make(chan struct{}),
+	}
+}
+
+// Read returns the next JSON-RPC message. Control frames are handled
+// along the way: pings are answered and pongs keep the read deadline fresh.
+func (c *websocketConnection) Read(ctx context.Context) (jsonrpc.Message, error) {
+	for {
+		if c.pongWait > 0 {
+			if err := c.ws.SetReadDeadline(time.Now().Add(c.pongWait)); err != nil {
+				return nil, err
+			}
+		}
+		frame, err := c.ws.NewFrameReader()
+		if err != nil {
+			return nil, err
+		}
+		if c.touch != nil && !c.touch() {
+			return nil, errWebSocketSessionEnded
+		}
+		frame, err = c.ws.HandleFrame(frame)
+		if err != nil {
+			return nil, err
+		}
+		if frame == nil {
+			continue // Control frame
+		}
+
+		data, err := io.ReadAll(io.LimitReader(frame, int64(c.maxMessageBytes)+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > c.maxMessageBytes {
+			_ = c.ws.WriteClose(wsCloseMessageTooBig)
+			return nil, fmt.Errorf("%w (%d bytes)", errWebSocketMessageTooBig, c.maxMessageBytes)
+		}
+		return jsonrpc.DecodeMessage(data)
+	}
+}
+
+// Write sends msg as a single text frame
+func (c *websocketConnection) Write(ctx context.Context, msg jsonrpc.Message) error {
+	data, err := jsonrpc.EncodeMessage(msg)
+	if err != nil {
+		return err
+	}
+	if err := c.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+	return websocket.Message.Send(c.ws, string(data))
+}
+
+// Close closes the connection and ends its session; later calls are no-ops
+func (c *websocketConnection) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		// Don't let a client that stopped reading hold up the close frame
+		_ = c.ws.SetWriteDeadline(time.Now().Add(time.Second))
+		c.closeErr = c.ws.Close()
+		if c.onClose != nil {
+			c.onClose()
+		}
+	})
+	return c.closeErr
+}
+
+// SessionID returns the SessionManager session tracking the connection
+func (c *websocketConnection) SessionID() string {
+	return c.sessionID
+}
+
+// keepalive pings the client every interval until the connection closes.
+// A client that stops answering misses the read deadline and is dropped.
+func (c *websocketConnection) keepalive(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			err := c.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err == nil {
+				_, err = c.ws.Write(nil)
+			}
+			if err != nil {
+				_ = c.Close()
+				return
+			}
+		}
+	}
+}
+
+// websocketHub tracks open WebSocket connections. They are hijacked from
+// the HTTP server, so http.Server.Shutdown knows nothing about them.
+type websocketHub struct {
+	mu    sync.Mutex
+	conns map[*websocketConnection]struct{}
+}
+
+func (h *websocketHub) add(conn *websocketConnection) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.conns == nil {
+		h.conns = make(map[*websocketConnection]struct{})
+	}
+	h.conns[conn] = struct{}{}
+}
+
+func (h *websocketHub) remove(conn *websocketConnection) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.conns, conn)
+}
+
+// count returns the number of open connections
+func (h *websocketHub) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.conns)
+}
+
+// closeAll closes every open connection, ending their sessions
+func (h *websocketHub) closeAll() {
+	h.mu.Lock()
+	conns := make([]*websocketConnection, 0, len(h.conns))
+	for conn := range h.conns {
+		conns = append(conns, conn)
+	}
+	h.mu.Unlock()
+
+	if len(conns) > 0 {
+		log.Printf("Closing %d WebSocket connection(s)", len(conns))
+	}
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// newWebSocketServer serves a stub tool server over the WebSocket transport
+func newWebSocketServer(t *testing.T, cfg *Config, names ...string) (*MCPServer, *httptest.Server) {
+	t.Helper()
+	cfg.Transport = TransportWebSocket
+	server := newStubToolServer(t, cfg, names...)
+	httpServer := httptest.NewServer(server.httpHandler())
+	t.Cleanup(func() {
+		server.websockets.closeAll()
+		httpServer.Close()
+	})
+	return server, httpServer
+}
+
+// wsTestClient speaks raw JSON-RPC over /mcp/ws
+type wsTestClient struct {
+	t      *testing.T
+	ws     *websocket.Conn
+	nextID int
+}
+
+func dialWebSocket(t *testing.T, httpServer *httptest.Server) *wsTestClient {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/mcp/ws"
+	ws, err := websocket.Dial(url, "", httpServer.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+	return &wsTestClient{t: t, ws: ws}
+}
+
+// notify sends a JSON-RPC notification
+func (c *wsTestClient) notify(method string) {
+	c.t.Helper()
+	require.NoError(c.t, websocket.JSON.Send(c.ws, map[string]interface{}{"jsonrpc": "2.0", "method": method}))
+}
+
+// send sends a JSON-RPC request and returns its ID
+func (c *wsTestClient) send(method string, params interface{}) int {
+	c.t.Helper()
+	c.nextID++
+	require.NoError(c.t, websocket.JSON.Send(c.ws, map[string]interface{}{
+		"jsonrpc": "2.0", "id": c.nextID, "method": method, "params": params,
+	}))
+	return c.nextID
+}
+
+// receive returns the result of the next response, which must answer id
+func (c *wsTestClient) receive(id int) map[string]interface{} {
+	c.t.Helper()
+	require.NoError(c.t, c.ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var response struct {
+		ID     int                    `json:"id"`
+		Result map[string]interface{} `json:"result"`
+		Error  map[string]interface{} `json:"error"`
+	}
+	require.NoError(c.t, websocket.JSON.Receive(c.ws, &response))
+	require.Equal(c.t, id, response.ID)
+	require.Nil(c.t, response.Error)
+	return response.Result
+}
+
+// call sends a request and waits for its result
+func (c *wsTestClient) call(method string, params interface{}) map[string]interface{} {
+	c.t.Helper()
+	return c.receive(c.send(method, params))
+}
+
+func (c *wsTestClient) initialize() {
+	c.t.Helper()
+	result := c.call("initialize", map[string]interface{}{
+		"protocolVersion": "2025-06-18",
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "ws-test", "version": "1.0"},
+	})
+	require.Contains(c.t, result, "serverInfo")
+	c.notify("notifications/initialized")
+}
+
+func TestWebSocket_InitializeListAndCallTools(t *testing.T) {
+	server, httpServer := newWebSocketServer(t, NewConfig(), "get-cluster-health", "list-pods")
+	client := dialWebSocket(t, httpServer)
+
+	client.initialize()
+
+	result := client.call("tools/list", map[string]interface{}{})
+	var names []string
+	for _, tool := range result["tools"].([]interface{}) {
+		names = append(names, tool.(map[string]interface{})["name"].(string))
+	}
+	assert.ElementsMatch(t, []string{"get-cluster-health", "list-pods"}, names)
+
+	result = client.call("tools/call", map[string]interface{}{"name": "list-pods", "arguments": map[string]interface{}{}})
+	assert.NotEqual(t, true, result["isError"])
+	content := result["content"].([]interface{})
+	require.Len(t, content, 1)
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal([]byte(content[0].(map[string]interface{})["text"].(string)), &payload))
+	assert.Equal(t, "list-pods", payload["tool"])
+	assert.Contains(t, result["_meta"], "volatility", "the same _meta as the SSE transport")
+
+	// The connection is tracked as a session for its lifetime
+	sessions := server.sessionManager.ListSessions()
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "websocket", sessions[0].Metadata["transport"])
+	require.NoError(t, client.ws.Close())
+	assert.Eventually(t, func() bool { return len(server.sessionManager.ListSessions()) == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebSocket_SlowClientDoesNotBlockOthers(t *testing.T) {
+	server, httpServer := newWebSocketServer(t, NewConfig(), "list-pods")
+	release := make(chan struct{})
+	defer close(release)
+	server.registerTool(&blockingStubTool{stubTool: stubTool{name: "slow-report"}, release: release})
+
+	slow := dialWebSocket(t, httpServer)
+	slow.initialize()
+	slow.send("tools/call", map[string]interface{}{"name": "slow-report", "arguments": map[string]interface{}{}})
+
+	fast := dialWebSocket(t, httpServer)
+	fast.initialize()
+	result := fast.call("tools/call", map[string]interface{}{"name": "list-pods", "arguments": map[string]interface{}{}})
+	assert.NotEqual(t, true, result["isError"])
+	assert.Len(t, server.sessionManager.ListSessions(), 2)
+}
+
+func TestWebSocket_MaxMessageSize(t *testing.T) {
+	cfg := NewConfig()
+	cfg.WebSocketMaxMessageBytes = 256
+	server, httpServer := newWebSocketServer(t, cfg, "list-pods")
+	client := dialWebSocket(t, httpServer)
+	client.initialize()
+
+	client.send("tools/call", map[string]interface{}{"name": "list-pods", "arguments": map[string]interface{}{"namespace": strings.Repeat("x", 512)}})
+	require.NoError(t, client.ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var message string
+	assert.Error(t, websocket.Message.Receive(client.ws, &message), "the server closes the connection")
+	assert.Eventually(t, func() bool { return server.websockets.count() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebSocket_ClosedOnShutdown(t *testing.T) {
+	server, httpServer := newWebSocketServer(t, NewConfig(), "list-pods")
+	client := dialWebSocket(t, httpServer)
+	client.initialize()
+	require.Equal(t, 1, server.websockets.count())
+
+	server.websockets.closeAll()
+
+	require.NoError(t, client.ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+	var message string
+	assert.Error(t, websocket.Message.Receive(client.ws, &message))
+	assert.Eventually(t, func() bool {
+		return server.websockets.count() == 0 && len(server.sessionManager.ListSessions()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebSocket_ClosedWhenSessionRevoked(t *testing.T) {
+	cfg := NewConfig()
+	cfg.WebSocketPingInterval = 20 * time.Millisecond
+	server, httpServer := newWebSocketServer(t, cfg, "list-pods")
+	client := dialWebSocket(t, httpServer)
+	client.initialize()
+
+	sessions := server.sessionManager.ListSessions()
+	require.Len(t, sessions, 1)
+	server.sessionManager.DeleteSession(sessions[0].ID)
+
+	// The next pong finds the session gone
+	go func() {
+		var message string
+		for websocket.Message.Receive(client.ws, &message) == nil {
+		}
+	}()
+	assert.Eventually(t, func() bool { return server.websockets.count() == 0 }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWebSocket_Keepalive(t *testing.T) {
+	cfg := NewConfig()
+	cfg.WebSocketPingInterval = 20 * time.Millisecond
+	server, httpServer := newWebSocketServer(t, cfg, "list-pods")
+	client := dialWebSocket(t, httpServer)
+	client.initialize()
+
+	// Reading answers the server's pings; a connection idle for many ping
+	// intervals stays open as long as the client does
+	go func() {
+		var message string
+		for websocket.Message.Receive(client.ws, &message) == nil {
+		}
+	}()
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 1, server.websockets.count())
+}
+
+func TestWebSocket_SessionBoundToPrincipal(t *testing.T) {
+	server, _, _ := newAuthServer(t, true)
+	server.config.Transport = TransportWebSocket
+	httpServer := httptest.NewServer(server.httpHandler())
+	t.Cleanup(func() {
+		server.websockets.closeAll()
+		httpServer.Close()
+	})
+
+	wsConfig, err := websocket.NewConfig("ws"+strings.TrimPrefix(httpServer.URL, "http")+"/mcp/ws", httpServer.URL)
+	require.NoError(t, err)
+	wsConfig.Header.Set("Authorization", "Bearer alice-token")
+	ws, err := websocket.DialConfig(wsConfig)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ws.Close() })
+	(&wsTestClient{t: t, ws: ws}).initialize()
+
+	// Found by the user filter of /mcp/sessions, like REST sessions
+	sessions := server.sessionManager.ListSessions()
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "alice", sessions[0].Metadata[sessionUserKey])
+	assert.Equal(t, []string{"sre"}, sessions[0].Metadata[sessionGroupsKey])
+	assert.Equal(t, string(TransportWebSocket), sessions[0].Metadata["transport"])
+}
+
+func TestWebSocket_RejectsForeignOrigin(t *testing.T) {
+	_, httpServer := newWebSocketServer(t, NewConfig())
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/mcp/ws"
+
+	_, err := websocket.Dial(url, "", "https://evil.example.com")
+	assert.Error(t, err)
+}
+
+func TestWebSocket_OnlyWithWebSocketTransport(t *testing.T) {
+	server := newStubToolServer(t, NewConfig())
+	w := httptest.NewRecorder()
+	server.httpHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/mcp/ws", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "MCP_TRANSPORT=websocket")
+}
+
+// blockingStubTool is a stub tool that runs until released
+type blockingStubTool struct {
+	stubTool
+	release chan struct{}
+}
+
+func (t *blockingStubTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	select {
+	case <-t.release:
+	case <-ctx.Done():
+	}
+	return t.stubTool.Execute(ctx, args)
+}
@@ -0,0 +1,18 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// NewCoordinationEngineClient creates a client for a test engine at baseURL
+// with default options, failing the test if the URL is rejected
+func NewCoordinationEngineClient(t testing.TB, baseURL string) *clients.CoordinationEngineClient {
+	t.Helper()
+	client, err := clients.NewCoordinationEngineClient(baseURL, clients.CoordinationEngineOptions{})
+	if err != nil {
+		t.Fatalf("NewCoordinationEngineClient(%q) error = %v", baseURL, err)
+	}
+	return client
+}
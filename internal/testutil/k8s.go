@@ -0,0 +1,139 @@
+// Package testutil provides test doubles for the clients tools depend on
+package testutil
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// FakeK8sClient implements the client interfaces of pkg/clients on top of
+// client-go's fake clientset. Reads go to the clientset, so reactors added
+// to Clientset inject API errors; cluster health is computed from it the way
+// K8sClient does unless SetClusterHealth overrides the result.
+type FakeK8sClient struct {
+	Fake *fake.Clientset
+
+	client *clients.K8sClient
+
+	mu           sync.Mutex
+	health       *clients.ClusterHealth
+	healthErr    error
+	override     bool
+	reachability *clients.APIReachability
+	healthCalls  int
+}
+
+// NewFakeK8sClient creates a fake client serving objects
+func NewFakeK8sClient(objects ...runtime.Object) *FakeK8sClient {
+	clientset := fake.NewClientset(objects...)
+	return &FakeK8sClient{
+		Fake:   clientset,
+		client: clients.NewK8sClientWithClientset(clientset),
+	}
+}
+
+// SetClusterHealth makes GetClusterHealth return health and err instead of
+// reading the clientset
+func (f *FakeK8sClient) SetClusterHealth(health *clients.ClusterHealth, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.health, f.healthErr, f.override = health, err, true
+}
+
+// SetAPIReachability makes APIReachability return reachability instead of
+// what the GetClusterHealth calls observed
+func (f *FakeK8sClient) SetAPIReachability(reachability clients.APIReachability) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reachability = &reachability
+}
+
+// HealthCalls returns how often GetClusterHealth was called
+func (f *FakeK8sClient) HealthCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.healthCalls
+}
+
+// GetClusterHealth implements clients.ClusterHealthReader
+func (f *FakeK8sClient) GetClusterHealth(ctx context.Context) (*clients.ClusterHealth, error) {
+	f.mu.Lock()
+	f.healthCalls++
+	health, err, override := f.health, f.healthErr, f.override
+	f.mu.Unlock()
+
+	if override {
+		return health, err
+	}
+	return f.client.GetClusterHealth(ctx)
+}
+
+// APIReachability implements clients.ClusterHealthReader
+func (f *FakeK8sClient) APIReachability() clients.APIReachability {
+	f.mu.Lock()
+	reachability := f.reachability
+	f.mu.Unlock()
+
+	if reachability != nil {
+		return *reachability
+	}
+	return f.client.APIReachability()
+}
+
+// Clientset implements clients.ClientsetProvider
+func (f *FakeK8sClient) Clientset() kubernetes.Interface {
+	return f.Fake
+}
+
+// ListNodes implements clients.NodeReader
+func (f *FakeK8sClient) ListNodes(ctx context.Context) (*corev1.NodeList, error) {
+	return f.client.ListNodes(ctx)
+}
+
+// ListNodesMatching implements clients.NodeReader
+func (f *FakeK8sClient) ListNodesMatching(ctx context.Context, filter clients.NodeFilter) (*corev1.NodeList, error) {
+	return f.client.ListNodesMatching(ctx, filter)
+}
+
+// GetNode implements clients.NodeReader
+func (f *FakeK8sClient) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
+	return f.client.GetNode(ctx, name)
+}
+
+// ListPods implements clients.PodReader
+func (f *FakeK8sClient) ListPods(ctx context.Context, namespace string) (*corev1.PodList, error) {
+	return f.client.ListPods(ctx, namespace)
+}
+
+// GetPod implements clients.PodReader
+func (f *FakeK8sClient) GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	return f.client.GetPod(ctx, namespace, name)
+}
+
+// StreamPodLogs implements clients.PodReader. The fake clientset answers
+// every log request with "fake logs".
+func (f *FakeK8sClient) StreamPodLogs(ctx context.Context, namespace, pod, container string, sinceSeconds, limitBytes int64) (io.ReadCloser, error) {
+	return f.client.StreamPodLogs(ctx, namespace, pod, container, sinceSeconds, limitBytes)
+}
+
+// ListEvents implements clients.EventReader
+func (f *FakeK8sClient) ListEvents(ctx context.Context, namespace string) (*corev1.EventList, error) {
+	return f.client.ListEvents(ctx, namespace)
+}
+
+var (
+	_ clients.NodeReader          = (*FakeK8sClient)(nil)
+	_ clients.PodReader           = (*FakeK8sClient)(nil)
+	_ clients.EventReader         = (*FakeK8sClient)(nil)
+	_ clients.ClusterReader       = (*FakeK8sClient)(nil)
+	_ clients.ClusterHealthReader = (*FakeK8sClient)(nil)
+	_ clients.ClientsetProvider   = (*FakeK8sClient)(nil)
+)
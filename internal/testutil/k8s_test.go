@@ -0,0 +1,48 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestFakeK8sClient_ReadsClientset(t *testing.T) {
+	ctx := context.Background()
+	client := NewFakeK8sClient(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-0"}},
+	)
+
+	if pods, err := client.ListPods(ctx, "shop"); err != nil || len(pods.Items) != 1 {
+		t.Errorf("ListPods() = %v, %v; want 1 pod", pods, err)
+	}
+	if _, err := client.GetNode(ctx, "worker-1"); err != nil {
+		t.Errorf("GetNode() error = %v", err)
+	}
+	health, err := client.GetClusterHealth(ctx)
+	if err != nil || health.Nodes.Total != 1 || health.Pods.Total != 1 {
+		t.Errorf("GetClusterHealth() = %+v, %v; want 1 node and 1 pod", health, err)
+	}
+	if calls := client.HealthCalls(); calls != 1 {
+		t.Errorf("HealthCalls() = %d, want 1", calls)
+	}
+}
+
+func TestFakeK8sClient_Overrides(t *testing.T) {
+	client := NewFakeK8sClient()
+	failure := errors.New("connection refused")
+	client.SetClusterHealth(nil, failure)
+	client.SetAPIReachability(clients.APIReachability{ConsecutiveFailures: 2})
+
+	if _, err := client.GetClusterHealth(context.Background()); !errors.Is(err, failure) {
+		t.Errorf("GetClusterHealth() error = %v, want %v", err, failure)
+	}
+	if got := client.APIReachability().ConsecutiveFailures; got != 2 {
+		t.Errorf("APIReachability().ConsecutiveFailures = %d, want 2", got)
+	}
+}
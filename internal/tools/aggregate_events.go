@@ -0,0 +1,444 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/eventhistory"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// spikeRatio is the window-over-window increase that flags a spike
+	spikeRatio = 3.0
+	// spikeMinEvents keeps a handful of events from counting as a spike
+	spikeMinEvents = 5
+	// topObjectsPerGroup bounds the involved objects listed per group
+	topObjectsPerGroup = 3
+)
+
+// Event sources of aggregate-events
+const (
+	eventSourceLive    = "live"    // Events the API server still holds (one hour by default)
+	eventSourceHistory = "history" // Live events plus the server's event history
+)
+
+// AggregateEventsTool groups recent events by reason and namespace and flags spikes
+type AggregateEventsTool struct {
+	k8sClient clients.EventReader
+	history   *eventhistory.Store // Recorded events for source "history" (nil when disabled)
+}
+
+// NewAggregateEventsTool creates a new aggregate-events tool. history is the
+// server's event history, or nil when EVENT_HISTORY_ENABLED is off.
+func NewAggregateEventsTool(k8sClient clients.EventReader, history *eventhistory.Store) *AggregateEventsTool {
+	return &AggregateEventsTool{
+		k8sClient: k8sClient,
+		history:   history,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *AggregateEventsTool) Name() string {
+	return "aggregate-events"
+}
+
+// NamespaceScoped reports that events are read per namespace, limited to
+// the caller's namespaces when it is scoped
+func (t *AggregateEventsTool) NamespaceScoped() bool {
+	return true
+}
+
+// Description returns the tool description for MCP
+func (t *AggregateEventsTool) Description() string {
+	return "Summarize recent Kubernetes events grouped by reason and namespace, with counts, top involved objects, and a spike flag comparing the window against the previous window of the same length (e.g. FailedScheduling up 10x in the last 15 minutes). Use source \"history\" for windows older than the API server keeps events (one hour by default)."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *AggregateEventsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only aggregate events in this namespace. Leave empty for all namespaces.",
+				"default":     "",
+			},
+			"window": DurationProperty("Length of the window to aggregate, from 1m to 24h. The previous window of the same length is used as the baseline", "15m"),
+			"window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Deprecated: use window. Length of the window in minutes.",
+				"minimum":     1,
+				"maximum":     1440,
+			},
+			"type": map[string]interface{}{
+				"type":        "string",
+				"description": "Only include events of this type. Leave empty for all types.",
+				"enum":        []string{"", "Warning", "Normal"},
+				"default":     "",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of groups to return (spikes first, then by count)",
+				"default":     20,
+				"minimum":     1,
+				"maximum":     200,
+			},
+			"source": map[string]interface{}{
+				"type":        "string",
+				"description": "Where events come from: \"live\" reads the API server only; \"history\" adds the events recorded by the server's event history, when enabled",
+				"enum":        []string{eventSourceLive, eventSourceHistory},
+				"default":     eventSourceLive,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// AggregateEventsInput represents the input parameters
+type AggregateEventsInput struct {
+	Namespace     string        `json:"namespace"`
+	Window        time.Duration `json:"window"` // Parsed by the dispatcher
+	WindowMinutes int           `json:"window_minutes"`
+	Type          string        `json:"type"`
+	Limit         int           `json:"limit"`
+	Source        string        `json:"source"`
+}
+
+// EventObjectCount is an involved object and how often it appeared in a group
+type EventObjectCount struct {
+	Object string `json:"object"` // Kind/name
+	Count  int    `json:"count"`
+}
+
+// EventGroup summarizes the events sharing a reason and namespace
+type EventGroup struct {
+	Reason        string             `json:"reason"`
+	Namespace     string             `json:"namespace"`
+	Type          string             `json:"type"`
+	Count         int                `json:"count"`
+	PreviousCount int                `json:"previous_count"`
+	ChangeRatio   float64            `json:"change_ratio,omitempty"` // Count / PreviousCount (0 when there is no baseline)
+	Spike         bool               `json:"spike"`
+	TopObjects    []EventObjectCount `json:"top_objects"`
+	LastSeen      time.Time          `json:"last_seen"`
+	LatestMessage string             `json:"latest_message,omitempty"`
+}
+
+// EventAggregation is the result of aggregating events over a window
+type EventAggregation struct {
+	WindowMinutes int          `json:"window_minutes"`
+	WindowStart   time.Time    `json:"window_start"`
+	TotalEvents   int          `json:"total_events"`
+	TotalGroups   int          `json:"total_groups"`
+	SpikeCount    int          `json:"spike_count"`
+	Truncated     bool         `json:"truncated"`
+	Groups        []EventGroup `json:"groups"`
+	Source        string       `json:"source,omitempty"` // Set by the tool, not by /export/events
+	// HistoryStart is the time the event history is complete from, for
+	// source "history": groups may miss events before it
+	HistoryStart *time.Time `json:"history_start,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access aggregate-events needs
+func (t *AggregateEventsTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "events", Verb: "list"},
+	}
+}
+
+// Execute runs the aggregate-events operation
+func (t *AggregateEventsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := AggregateEventsInput{
+		Window: 15 * time.Minute,
+		Limit:  20,
+		Source: eventSourceLive,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	window, err := windowArg(args, "window", input.Window, "window_minutes", time.Duration(input.WindowMinutes)*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	if window < time.Minute || window > 24*time.Hour {
+		return nil, invalidArgs("window must be between 1m and 24h")
+	}
+	if input.Limit < 1 || input.Limit > 200 {
+		return nil, invalidArgs("limit must be between 1 and 200")
+	}
+	if input.Type != "" && input.Type != corev1.EventTypeWarning && input.Type != corev1.EventTypeNormal {
+		return nil, invalidArgs("type must be Warning or Normal")
+	}
+	switch input.Source {
+	case eventSourceLive:
+	case eventSourceHistory:
+		if t.history == nil {
+			return nil, invalidArgs("source history needs the event history, which is disabled (EVENT_HISTORY_ENABLED)")
+		}
+	default:
+		return nil, invalidArgs("source must be live or history")
+	}
+
+	now := time.Now()
+	var events []corev1.Event
+	for _, namespace := range clients.NamespacesToList(ctx, input.Namespace) {
+		eventList, err := t.k8sClient.ListEvents(ctx, namespace)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		namespaceEvents := eventList.Items
+		if input.Source == eventSourceHistory {
+			// The baseline window needs history too
+			namespaceEvents = mergeEvents(namespaceEvents, t.history.Events(namespace, now.Add(-2*window)))
+		}
+		for _, event := range namespaceEvents {
+			if input.Type == "" || event.Type == input.Type {
+				events = append(events, event)
+			}
+		}
+	}
+
+	result := aggregateEvents(events, now, window, input.Limit)
+	result.Source = input.Source
+	if input.Source == eventSourceHistory {
+		if since := t.history.Stats().Since; !since.IsZero() {
+			result.HistoryStart = &since
+		}
+	}
+	return result, nil
+}
+
+// mergeEvents adds the recorded events to the live ones, skipping those the
+// API server still holds: a live event is the same or a later version of
+// its record
+func mergeEvents(live, recorded []corev1.Event) []corev1.Event {
+	seen := make(map[string]bool, len(live))
+	for _, event := range live {
+		if event.UID != "" {
+			seen[string(event.UID)] = true
+		}
+	}
+	merged := live
+	for _, event := range recorded {
+		if event.UID == "" || !seen[string(event.UID)] {
+			merged = append(merged, event)
+		}
+	}
+	return merged
+}
+
+// Truncate cuts an aggregate-events result down to budget bytes of JSON,
+// keeping spikes and Warning groups over the rest, and reports how many
+// groups it left out
+func (t *AggregateEventsTool) Truncate(result interface{}, budget int) (interface{}, map[string]int) {
+	aggregation, ok := result.(EventAggregation)
+	if !ok {
+		return result, nil
+	}
+	groups := importantFirst(aggregation.Groups, func(group EventGroup) bool {
+		return group.Spike || group.Type == corev1.EventTypeWarning
+	})
+	aggregation.Truncated = true
+	keep := keepWithinBudget(len(groups), func(n int) bool {
+		aggregation.Groups = groups[:n]
+		return jsonSize(aggregation) <= budget
+	})
+	aggregation.Groups = groups[:keep]
+	return aggregation, map[string]int{"groups": len(groups) - keep}
+}
+
+// AggregateEvents groups events by reason and namespace for the window ending
+// at now, without a group limit. Used by the /export/events endpoint.
+func AggregateEvents(events []corev1.Event, now time.Time, window time.Duration) EventAggregation {
+	return aggregateEvents(events, now, window, 0)
+}
+
+// aggregateEvents groups events by reason and namespace for the window ending at now
+// and compares each group against the previous window of the same length.
+// Groups are ordered spikes first, then by count, and capped at limit.
+func aggregateEvents(events []corev1.Event, now time.Time, window time.Duration, limit int) EventAggregation {
+	windowStart := now.Add(-window)
+	previousStart := windowStart.Add(-window)
+
+	type groupKey struct{ reason, namespace string }
+	type groupState struct {
+		group   EventGroup
+		objects map[string]int
+	}
+	groups := make(map[groupKey]*groupState)
+
+	result := EventAggregation{
+		WindowMinutes: int(window.Minutes()),
+		WindowStart:   windowStart,
+	}
+
+	for i := range events {
+		event := &events[i]
+		current := eventOccurrences(event, windowStart, now)
+		previous := eventOccurrences(event, previousStart, windowStart)
+		if current == 0 && previous == 0 {
+			continue
+		}
+
+		key := groupKey{reason: event.Reason, namespace: event.Namespace}
+		state, ok := groups[key]
+		if !ok {
+			state = &groupState{
+				group:   EventGroup{Reason: event.Reason, Namespace: event.Namespace, Type: event.Type},
+				objects: make(map[string]int),
+			}
+			groups[key] = state
+		}
+
+		state.group.Count += current
+		state.group.PreviousCount += previous
+		if event.Type == corev1.EventTypeWarning {
+			state.group.Type = corev1.EventTypeWarning
+		}
+		if current > 0 {
+			object := fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name)
+			state.objects[object] += current
+		}
+		if last := eventLastSeen(event); last.After(state.group.LastSeen) {
+			state.group.LastSeen = last
+			state.group.LatestMessage = event.Message
+		}
+	}
+
+	all := make([]EventGroup, 0, len(groups))
+	for _, state := range groups {
+		group := state.group
+		if group.Count == 0 {
+			continue // Only seen in the baseline window
+		}
+		group.ChangeRatio, group.Spike = spikeScore(group.Count, group.PreviousCount)
+		group.TopObjects = topObjects(state.objects, topObjectsPerGroup)
+
+		result.TotalEvents += group.Count
+		if group.Spike {
+			result.SpikeCount++
+		}
+		all = append(all, group)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Spike != all[j].Spike {
+			return all[i].Spike
+		}
+		if all[i].Count != all[j].Count {
+			return all[i].Count > all[j].Count
+		}
+		if all[i].Reason != all[j].Reason {
+			return all[i].Reason < all[j].Reason
+		}
+		return all[i].Namespace < all[j].Namespace
+	})
+
+	result.TotalGroups = len(all)
+	if limit > 0 && len(all) > limit {
+		all = all[:limit]
+		result.Truncated = true
+	}
+	result.Groups = all
+	return result
+}
+
+// spikeScore returns the window-over-window ratio and whether it counts as a spike.
+// A group with no baseline is a spike once it reaches spikeMinEvents.
+func spikeScore(current, previous int) (float64, bool) {
+	if previous == 0 {
+		return 0, current >= spikeMinEvents
+	}
+	ratio := math.Round(float64(current)/float64(previous)*100) / 100
+	return ratio, current >= spikeMinEvents && ratio >= spikeRatio
+}
+
+// eventOccurrences estimates how many occurrences of a (possibly deduplicated)
+// event fall in [start, end). Repeated events spread their count evenly
+// between the first and last timestamp.
+func eventOccurrences(event *corev1.Event, start, end time.Time) int {
+	last := eventLastSeen(event)
+	first := event.FirstTimestamp.Time
+	if first.IsZero() || first.After(last) {
+		first = last
+	}
+
+	count := int(event.Count)
+	if event.Series != nil && int(event.Series.Count) > count {
+		count = int(event.Series.Count)
+	}
+	if count < 1 {
+		count = 1
+	}
+
+	if !last.After(first) {
+		if !last.Before(start) && last.Before(end) {
+			return count
+		}
+		return 0
+	}
+
+	overlapStart := maxTime(first, start)
+	overlapEnd := minTime(last, end)
+	if !overlapEnd.After(overlapStart) {
+		return 0
+	}
+
+	span := last.Sub(first)
+	return int(math.Round(float64(count) * float64(overlapEnd.Sub(overlapStart)) / float64(span)))
+}
+
+// eventLastSeen returns the most recent timestamp recorded on an event
+func eventLastSeen(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// topObjects returns the n objects with the highest counts
+func topObjects(counts map[string]int, n int) []EventObjectCount {
+	objects := make([]EventObjectCount, 0, len(counts))
+	for object, count := range counts {
+		objects = append(objects, EventObjectCount{Object: object, Count: count})
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Count != objects[j].Count {
+			return objects[i].Count > objects[j].Count
+		}
+		return objects[i].Object < objects[j].Object
+	})
+	if len(objects) > n {
+		objects = objects[:n]
+	}
+	return objects
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/eventhistory"
+)
+
+var eventsNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestEvent creates a single-occurrence event minutesAgo before eventsNow
+func newTestEvent(namespace, reason, object string, minutesAgo int) corev1.Event {
+	ts := metav1.NewTime(eventsNow.Add(-time.Duration(minutesAgo) * time.Minute))
+	return corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: fmt.Sprintf("%s.%d", object, minutesAgo), Namespace: namespace},
+		Reason:         reason,
+		Type:           corev1.EventTypeWarning,
+		Message:        reason + " on " + object,
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: object, Namespace: namespace},
+		FirstTimestamp: ts,
+		LastTimestamp:  ts,
+		Count:          1,
+	}
+}
+
+func repeatEvents(n int, namespace, reason, object string, minutesAgo int) []corev1.Event {
+	events := make([]corev1.Event, 0, n)
+	for i := 0; i < n; i++ {
+		events = append(events, newTestEvent(namespace, reason, object, minutesAgo))
+	}
+	return events
+}
+
+func TestAggregateEvents_GroupsAndSpikes(t *testing.T) {
+	var events []corev1.Event
+	// FailedScheduling: 1 in the baseline window, 10 in the current window
+	events = append(events, newTestEvent("shop", "FailedScheduling", "web-1", 20))
+	events = append(events, repeatEvents(6, "shop", "FailedScheduling", "web-1", 5)...)
+	events = append(events, repeatEvents(4, "shop", "FailedScheduling", "web-2", 3)...)
+	// BackOff: steady 4 per window
+	events = append(events, repeatEvents(4, "shop", "BackOff", "api-1", 25)...)
+	events = append(events, repeatEvents(4, "shop", "BackOff", "api-1", 10)...)
+	// Same reason in another namespace is a separate group
+	events = append(events, newTestEvent("billing", "FailedScheduling", "db-0", 1))
+	// Older than both windows
+	events = append(events, newTestEvent("shop", "Killing", "old-1", 120))
+
+	result := aggregateEvents(events, eventsNow, 15*time.Minute, 10)
+
+	assert.Equal(t, 15, result.WindowMinutes)
+	assert.Equal(t, 3, result.TotalGroups)
+	assert.Equal(t, 15, result.TotalEvents)
+	assert.Equal(t, 1, result.SpikeCount)
+	assert.False(t, result.Truncated)
+
+	spike := result.Groups[0]
+	assert.Equal(t, "FailedScheduling", spike.Reason)
+	assert.Equal(t, "shop", spike.Namespace)
+	assert.True(t, spike.Spike)
+	assert.Equal(t, 10, spike.Count)
+	assert.Equal(t, 1, spike.PreviousCount)
+	assert.Equal(t, 10.0, spike.ChangeRatio)
+	assert.Equal(t, []EventObjectCount{{Object: "Pod/web-1", Count: 6}, {Object: "Pod/web-2", Count: 4}}, spike.TopObjects)
+
+	steady := result.Groups[1]
+	assert.Equal(t, "BackOff", steady.Reason)
+	assert.False(t, steady.Spike)
+	assert.Equal(t, 1.0, steady.ChangeRatio)
+
+	assert.Equal(t, "billing", result.Groups[2].Namespace)
+	assert.False(t, result.Groups[2].Spike, "a single new event is below the spike floor")
+}
+
+func TestAggregateEvents_Limit(t *testing.T) {
+	var events []corev1.Event
+	for i := 0; i < 5; i++ {
+		events = append(events, repeatEvents(i+1, "shop", fmt.Sprintf("Reason%d", i), "pod", 1)...)
+	}
+
+	result := aggregateEvents(events, eventsNow, 15*time.Minute, 2)
+	assert.True(t, result.Truncated)
+	assert.Equal(t, 5, result.TotalGroups)
+	require.Len(t, result.Groups, 2)
+	assert.Equal(t, "Reason4", result.Groups[0].Reason)
+	assert.Equal(t, "Reason3", result.Groups[1].Reason)
+}
+
+func TestAggregateEventsTool_TruncateKeepsWarnings(t *testing.T) {
+	var events []corev1.Event
+	for i := 0; i < 40; i++ {
+		// Normal groups outnumber the warnings and sort ahead of them by count
+		normal := repeatEvents(4, fmt.Sprintf("team-%02d", i), "Pulled", "web-1", 2)
+		for j := range normal {
+			normal[j].Type = corev1.EventTypeNormal
+		}
+		events = append(events, normal...)
+	}
+	events = append(events, newTestEvent("shop", "BackOff", "api-1", 1))
+	events = append(events, newTestEvent("billing", "FailedMount", "db-0", 1))
+	result := aggregateEvents(events, eventsNow, 15*time.Minute, 0)
+	require.Len(t, result.Groups, 42)
+	budget := jsonSize(result) / 4
+
+	truncated, omitted := (&AggregateEventsTool{}).Truncate(result, budget)
+	aggregation := truncated.(EventAggregation)
+	assert.LessOrEqual(t, jsonSize(aggregation), budget)
+	assert.True(t, aggregation.Truncated)
+	assert.Equal(t, 42-len(aggregation.Groups), omitted["groups"])
+	assert.Equal(t, 42, aggregation.TotalGroups)
+	require.GreaterOrEqual(t, len(aggregation.Groups), 2)
+	assert.Equal(t, "BackOff", aggregation.Groups[0].Reason)
+	assert.Equal(t, "FailedMount", aggregation.Groups[1].Reason)
+}
+
+func TestSpikeScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       int
+		previous      int
+		expectedRatio float64
+		expectedSpike bool
+	}{
+		{"new burst", 8, 0, 0, true},
+		{"new trickle", 2, 0, 0, false},
+		{"tenfold", 50, 5, 10, true},
+		{"tripled", 15, 5, 3, true},
+		{"doubled", 10, 5, 2, false},
+		{"high ratio but tiny", 3, 1, 3, false},
+		{"decrease", 2, 10, 0.2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratio, spike := spikeScore(tt.current, tt.previous)
+			assert.Equal(t, tt.expectedRatio, ratio)
+			assert.Equal(t, tt.expectedSpike, spike)
+		})
+	}
+}
+
+func TestEventOccurrences_RepeatedEvent(t *testing.T) {
+	windowStart := eventsNow.Add(-15 * time.Minute)
+	previousStart := windowStart.Add(-15 * time.Minute)
+
+	// 30 occurrences spread evenly over the last 30 minutes
+	event := newTestEvent("shop", "BackOff", "api-1", 0)
+	event.FirstTimestamp = metav1.NewTime(eventsNow.Add(-30 * time.Minute))
+	event.Count = 30
+
+	assert.Equal(t, 15, eventOccurrences(&event, windowStart, eventsNow))
+	assert.Equal(t, 15, eventOccurrences(&event, previousStart, windowStart))
+
+	// A single-timestamp event falls entirely in one window
+	single := newTestEvent("shop", "BackOff", "api-1", 5)
+	single.Count = 7
+	assert.Equal(t, 7, eventOccurrences(&single, windowStart, eventsNow))
+	assert.Equal(t, 0, eventOccurrences(&single, previousStart, windowStart))
+
+	// events.k8s.io series data takes precedence over the legacy fields
+	series := newTestEvent("shop", "BackOff", "api-1", 40)
+	series.Series = &corev1.EventSeries{Count: 4, LastObservedTime: metav1.NewMicroTime(eventsNow.Add(-time.Minute))}
+	series.FirstTimestamp = metav1.NewTime(eventsNow.Add(-9 * time.Minute))
+	assert.Equal(t, 4, eventOccurrences(&series, windowStart, eventsNow))
+}
+
+func TestAggregateEventsTool_InvalidArguments(t *testing.T) {
+	tool := NewAggregateEventsTool(nil, nil)
+
+	for _, args := range []map[string]interface{}{
+		{"window_minutes": 0},
+		{"window_minutes": 5000},
+		{"window": 25 * time.Hour},
+		{"window": "a while"},
+		{"window": "15m", "window_minutes": 15},
+		{"limit": 0},
+		{"type": "Error"},
+		{"source": "archive"},
+		{"source": "history"}, // The event history is disabled
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err), "args %v", args)
+	}
+}
+
+func TestAggregateEventsTool_NamespaceScope(t *testing.T) {
+	var objects []runtime.Object
+	for _, namespace := range []string{"shop", "billing"} {
+		event := newTestEvent(namespace, "BackOff", "web-0", 0)
+		event.FirstTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+		event.LastTimestamp = event.FirstTimestamp
+		objects = append(objects, &event)
+	}
+	tool := NewAggregateEventsTool(testutil.NewFakeK8sClient(objects...), nil)
+
+	result, err := tool.Execute(clients.WithNamespaceScope(context.Background(), []string{"shop"}), nil)
+	require.NoError(t, err)
+	groups := result.(EventAggregation).Groups
+	require.Len(t, groups, 1)
+	assert.Equal(t, "shop", groups[0].Namespace)
+}
+
+// eventAt creates an event with a UID that occurred count times, evenly
+// from first to last before now
+func eventAt(uid, reason string, count int32, first, last time.Duration) *corev1.Event {
+	now := time.Now()
+	event := newTestEvent("shop", reason, "api-"+uid, 0)
+	event.UID = types.UID(uid)
+	event.Name = uid
+	event.Count = count
+	event.FirstTimestamp = metav1.NewTime(now.Add(-first))
+	event.LastTimestamp = metav1.NewTime(now.Add(-last))
+	return &event
+}
+
+func TestAggregateEventsTool_HistorySource(t *testing.T) {
+	history, err := eventhistory.NewStore(eventhistory.Options{MaxRecords: 100, Retention: 24 * time.Hour})
+	require.NoError(t, err)
+	// Gone from the API server: 2 in the baseline window, 6 in the current one
+	history.Observe(eventAt("expired-baseline", "BackOff", 2, 170*time.Minute, 130*time.Minute))
+	history.Observe(eventAt("expired-current", "BackOff", 6, 110*time.Minute, 70*time.Minute))
+	// Recorded when it had occurred 3 times; the API server now has 10
+	history.Observe(eventAt("still-live", "BackOff", 3, 50*time.Minute, 30*time.Minute))
+	// Older than the baseline window
+	history.Observe(eventAt("too-old", "Killing", 1, 5*time.Hour, 5*time.Hour))
+
+	live := eventAt("still-live", "BackOff", 10, 50*time.Minute, time.Minute)
+	client := testutil.NewFakeK8sClient(live)
+	args := map[string]interface{}{"window": 2 * time.Hour}
+
+	result, err := NewAggregateEventsTool(client, history).Execute(context.Background(), args)
+	require.NoError(t, err)
+	aggregation := result.(EventAggregation)
+	assert.Equal(t, "live", aggregation.Source)
+	require.Len(t, aggregation.Groups, 1)
+	assert.Equal(t, 10, aggregation.Groups[0].Count, "only the API server's events")
+	assert.Equal(t, 0, aggregation.Groups[0].PreviousCount)
+
+	args["source"] = "history"
+	result, err = NewAggregateEventsTool(client, history).Execute(context.Background(), args)
+	require.NoError(t, err)
+	aggregation = result.(EventAggregation)
+	assert.Equal(t, "history", aggregation.Source)
+	require.Len(t, aggregation.Groups, 1, "events older than both windows are left out")
+	group := aggregation.Groups[0]
+	assert.Equal(t, "BackOff", group.Reason)
+	assert.Equal(t, 16, group.Count, "the live version of an event replaces its record")
+	assert.Equal(t, 2, group.PreviousCount)
+	require.NotNil(t, aggregation.HistoryStart)
+	assert.WithinDuration(t, time.Now().Add(-5*time.Hour), *aggregation.HistoryStart, time.Minute)
+}
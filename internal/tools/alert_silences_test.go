@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// fakeAlertmanager keeps the silences posted to it, listing them after the
+// ones it was seeded with
+type fakeAlertmanager struct {
+	mu       sync.Mutex
+	silences []clients.Silence
+}
+
+func newFakeAlertmanager(t *testing.T, seed ...clients.Silence) (*fakeAlertmanager, *clients.AlertmanagerClient) {
+	t.Helper()
+	am := &fakeAlertmanager{silences: seed}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		am.mu.Lock()
+		defer am.mu.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(am.silences)
+		case http.MethodPost:
+			var silence clients.Silence
+			if err := json.NewDecoder(r.Body).Decode(&silence); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			silence.ID = fmt.Sprintf("silence-%d", len(am.silences)+1)
+			silence.Status = &clients.SilenceStatus{State: "active"}
+			am.silences = append(am.silences, silence)
+			_, _ = fmt.Fprintf(w, `{"silenceID":%q}`, silence.ID)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return am, clients.NewAlertmanagerClient(clients.AlertmanagerConfig{URL: server.URL})
+}
+
+func (am *fakeAlertmanager) posted() []clients.Silence {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	return append([]clients.Silence(nil), am.silences...)
+}
+
+func crashLoopSilenceArgs(duration time.Duration) map[string]interface{} {
+	return map[string]interface{}{
+		"matchers": []interface{}{
+			map[string]interface{}{"name": "alertname", "value": "KubePodCrashLooping"},
+			map[string]interface{}{"name": "namespace", "value": "checkout|payments", "operator": "=~"},
+		},
+		"duration": duration, // Parsed by the dispatcher
+		"comment":  "rolling back the checkout deployment",
+	}
+}
+
+func TestCreateAlertSilenceTool_Execute(t *testing.T) {
+	am, client := newFakeAlertmanager(t)
+	tool := NewCreateAlertSilenceTool(client, 24*time.Hour)
+	assert.True(t, tool.Mutating())
+	assert.True(t, tool.SupportsDryRun())
+
+	ctx := clients.WithPrincipal(context.Background(), clients.Principal{User: "alice"})
+	result, err := tool.Execute(ctx, crashLoopSilenceArgs(2*time.Hour))
+	require.NoError(t, err)
+	output := result.(CreateAlertSilenceOutput)
+
+	posted := am.posted()
+	require.Len(t, posted, 1)
+	assert.Equal(t, []clients.SilenceMatcher{
+		{Name: "alertname", Value: "KubePodCrashLooping", IsEqual: true},
+		{Name: "namespace", Value: "checkout|payments", IsRegex: true, IsEqual: true},
+	}, posted[0].Matchers)
+	assert.Equal(t, 2*time.Hour, posted[0].EndsAt.Sub(posted[0].StartsAt))
+	assert.Equal(t, "alice", posted[0].CreatedBy, "created on behalf of the caller")
+	assert.Equal(t, SilenceCommentTag+" rolling back the checkout deployment", posted[0].Comment)
+
+	assert.Equal(t, "silence-1", output.Silence.ID)
+	assert.Equal(t, []string{`alertname="KubePodCrashLooping"`, `namespace=~"checkout|payments"`}, output.Silence.Matchers)
+	assert.True(t, output.Silence.CreatedHere)
+	assert.False(t, output.DryRun)
+
+	// Without authentication the server is the creator
+	_, err = tool.Execute(context.Background(), crashLoopSilenceArgs(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, anonymousSilenceCreator, am.posted()[1].CreatedBy)
+
+	// A dry-run sends nothing
+	result, err = tool.Execute(WithDryRun(ctx), crashLoopSilenceArgs(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, result.(CreateAlertSilenceOutput).DryRun)
+	assert.Len(t, am.posted(), 2)
+}
+
+func TestCreateAlertSilenceTool_Refusals(t *testing.T) {
+	am, client := newFakeAlertmanager(t)
+	tool := NewCreateAlertSilenceTool(client, 24*time.Hour)
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{name: "longer than the maximum", args: crashLoopSilenceArgs(25 * time.Hour), wantErr: "exceeds the maximum silence duration of 24h0m0s"},
+		{name: "negative duration", args: crashLoopSilenceArgs(-time.Hour), wantErr: "duration must be positive"},
+		{
+			name:    "no matchers",
+			args:    map[string]interface{}{"matchers": []interface{}{}, "comment": "maintenance"},
+			wantErr: "would silence every alert",
+		},
+		{
+			name: "matchers matching everything",
+			args: map[string]interface{}{
+				"matchers": []interface{}{map[string]interface{}{"name": "alertname", "value": ".*", "operator": "=~"}},
+				"comment":  "maintenance",
+			},
+			wantErr: "would silence every alert",
+		},
+		{
+			name: "unknown operator",
+			args: map[string]interface{}{
+				"matchers": []interface{}{map[string]interface{}{"name": "alertname", "value": "Watchdog", "operator": "=="}},
+				"comment":  "maintenance",
+			},
+			wantErr: "invalid operator",
+		},
+		{
+			name:    "no comment",
+			args:    map[string]interface{}{"matchers": []interface{}{map[string]interface{}{"name": "alertname", "value": "Watchdog"}}},
+			wantErr: "comment is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tool.Execute(context.Background(), tt.args)
+			require.Error(t, err)
+			assert.True(t, IsInvalidArguments(err), "%v should be an invalid arguments error", err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+	assert.Empty(t, am.posted(), "nothing reaches Alertmanager")
+}
+
+func TestCreateAlertSilenceTool_AlertmanagerErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	tool := NewCreateAlertSilenceTool(clients.NewAlertmanagerClient(clients.AlertmanagerConfig{URL: server.URL}), time.Hour)
+	_, err := tool.Execute(context.Background(), crashLoopSilenceArgs(time.Hour))
+	assert.True(t, errors.Is(err, ErrForbidden), "got %v", err)
+}
+
+func TestListAlertSilencesTool_Execute(t *testing.T) {
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	silence := func(id, state string, hours int, comment string) clients.Silence {
+		return clients.Silence{
+			ID:        id,
+			Matchers:  []clients.SilenceMatcher{{Name: "alertname", Value: "Watchdog", IsEqual: true}},
+			StartsAt:  start,
+			EndsAt:    start.Add(time.Duration(hours) * time.Hour),
+			CreatedBy: "bob",
+			Comment:   comment,
+			Status:    &clients.SilenceStatus{State: state},
+		}
+	}
+	_, client := newFakeAlertmanager(t,
+		silence("later", "active", 8, "weekly maintenance"),
+		silence("ours", "active", 2, SilenceCommentTag+" rolling back"),
+		silence("old", "expired", -1, SilenceCommentTag+" earlier rollback"),
+		silence("upcoming", "pending", 4, "upgrade window"),
+	)
+	tool := NewListAlertSilencesTool(client)
+
+	ids := func(args map[string]interface{}) []string {
+		t.Helper()
+		result, err := tool.Execute(context.Background(), args)
+		require.NoError(t, err)
+		output := result.(ListAlertSilencesOutput)
+		assert.Equal(t, len(output.Silences), output.Count)
+		var ids []string
+		for _, silence := range output.Silences {
+			ids = append(ids, silence.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"ours", "upcoming", "later"}, ids(nil), "active and pending, soonest to end first")
+	assert.Equal(t, []string{"old"}, ids(map[string]interface{}{"state": "expired"}))
+	assert.Equal(t, []string{"old", "ours", "upcoming", "later"}, ids(map[string]interface{}{"state": "all"}))
+	assert.Equal(t, []string{"ours"}, ids(map[string]interface{}{"created_here": true}))
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"state": "muted"})
+	assert.True(t, IsInvalidArguments(err))
+}
@@ -0,0 +1,20 @@
+package tools
+
+import "time"
+
+// Alias is a former name of a renamed tool. Calls made under it still run
+// the tool, but the response's _meta block carries a deprecation warning
+// pointing at the current name until Sunset, after which the alias may be
+// removed.
+type Alias struct {
+	Name   string
+	Sunset time.Time // Zero when no removal date is set
+}
+
+// SunsetDate returns the sunset as a YYYY-MM-DD date, empty when unset
+func (a Alias) SunsetDate() string {
+	if a.Sunset.IsZero() {
+		return ""
+	}
+	return a.Sunset.UTC().Format(time.DateOnly)
+}
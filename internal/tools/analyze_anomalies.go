@@ -167,7 +167,7 @@ func (t *AnalyzeAnomaliesTool) Execute(ctx context.Context, args map[string]inte
 
 	// Validate required fields
 	if input.Metric == "" {
-		return nil, fmt.Errorf("metric is required")
+		return nil, invalidArgs("metric is required")
 	}
 
 	// Validate mutual exclusivity of deployment and pod filters
@@ -197,7 +197,7 @@ func (t *AnalyzeAnomaliesTool) Execute(ctx context.Context, args map[string]inte
 
 	ceResponse, err := t.coordinationEngine.AnalyzeAnomalies(ctx, ceRequest)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get anomaly predictions: %w", err)
+		return nil, dependencyError(fmt.Errorf("failed to get anomaly predictions: %w", err))
 	}
 
 	// Convert coordination engine response to tool output format
@@ -286,12 +286,12 @@ func getDefaultThreshold() float64 {
 func (t *AnalyzeAnomaliesTool) validateFilters(input AnalyzeAnomaliesInput) error {
 	// Deployment and pod are mutually exclusive
 	if input.Deployment != "" && input.Pod != "" {
-		return fmt.Errorf("'deployment' and 'pod' filters are mutually exclusive; specify only one")
+		return invalidArgs("'deployment' and 'pod' filters are mutually exclusive; specify only one")
 	}
 
 	// Label selector cannot be combined with deployment or pod
 	if input.LabelSelector != "" && (input.Deployment != "" || input.Pod != "") {
-		return fmt.Errorf("'label_selector' cannot be combined with 'deployment' or 'pod' filters")
+		return invalidArgs("'label_selector' cannot be combined with 'deployment' or 'pod' filters")
 	}
 
 	return nil
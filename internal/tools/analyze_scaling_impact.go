@@ -148,29 +148,38 @@ type AnalyzeScalingImpactOutput struct {
 	AnalyzedAt           string                `json:"analyzed_at"`
 }
 
+// RequiredPermissions declares the Kubernetes API access analyze-scaling-impact needs
+func (t *AnalyzeScalingImpactTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "apps", Resource: "deployments", Verb: "get"},
+		{Resource: "pods", Verb: "list"},
+		{Resource: "resourcequotas", Verb: "list"},
+	}
+}
+
 // Execute runs the analyze-scaling-impact tool
 func (t *AnalyzeScalingImpactTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	// Parse input arguments
 	input, err := t.parseInput(args)
 	if err != nil {
-		return nil, fmt.Errorf("invalid input: %w", err)
+		return nil, invalidArgs("invalid input: %w", err)
 	}
 
 	// Validate required fields
 	if input.Deployment == "" {
-		return nil, fmt.Errorf("deployment name is required")
+		return nil, invalidArgs("deployment name is required")
 	}
 	if input.Namespace == "" {
-		return nil, fmt.Errorf("namespace is required")
+		return nil, invalidArgs("namespace is required")
 	}
 	if input.TargetReplicas < 1 {
-		return nil, fmt.Errorf("target_replicas must be at least 1")
+		return nil, invalidArgs("target_replicas must be at least 1")
 	}
 
 	// Get deployment info and current replicas
 	deploymentInfo, err := t.getDeploymentInfo(ctx, input.Namespace, input.Deployment)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get deployment info: %w", err)
+		return nil, apiError(fmt.Errorf("failed to get deployment info: %w", err))
 	}
 
 	// Use provided current_replicas or auto-detected value
@@ -370,7 +379,7 @@ func (t *AnalyzeScalingImpactTool) getCurrentMetrics(ctx context.Context, namesp
 	// Get pods for the deployment
 	podList, err := t.k8sClient.ListPods(ctx, namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		return nil, apiError(fmt.Errorf("failed to list pods: %w", err))
 	}
 
 	var totalCPU, totalMemory int64
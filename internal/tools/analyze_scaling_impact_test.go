@@ -744,7 +744,8 @@ func TestAnalyzeScalingImpactTool_Execute_Integration(t *testing.T) {
 		}
 		defer k8sClient.Close()
 
-		ceClient := clients.NewCoordinationEngineClient("http://localhost:8000")
+		ceClient, err := clients.NewCoordinationEngineClient("http://localhost:8000", clients.CoordinationEngineOptions{})
+		require.NoError(t, err)
 
 		tool := NewAnalyzeScalingImpactTool(ceClient, k8sClient)
 		ctx := context.Background()
@@ -0,0 +1,344 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PromQL for API Priority and Fairness, summed over all API server instances
+const (
+	apfExecutingQuery = `sum by (priority_level) (apiserver_flowcontrol_current_executing_seats)`
+	apfLimitQuery     = `sum by (priority_level) (apiserver_flowcontrol_current_limit_seats)`
+	apfQueuedQuery    = `sum by (priority_level) (apiserver_flowcontrol_current_inqueue_requests)`
+	apfRejectedQuery  = `sum by (priority_level) (rate(apiserver_flowcontrol_rejected_requests_total[%dm]))`
+)
+
+// Priority level statuses reported by get-apf-status, from worst to best
+const (
+	APFStatusSaturated      = "saturated"       // At the concurrency limit or rejecting requests
+	APFStatusNearSaturation = "near_saturation" // Above 80% of the limit or queuing requests
+	APFStatusOK             = "ok"
+	APFStatusUnknown        = "unknown" // No live data (Prometheus not configured)
+	APFStatusExempt         = "exempt"  // Never limited
+)
+
+const (
+	// apfNearSaturation is the seat utilization at which a priority level is flagged
+	apfNearSaturation = 0.8
+	// clientThrottledShare is the share of throttled requests that points at our own QPS
+	clientThrottledShare = 0.1
+)
+
+// apfStatusRank orders statuses from worst to best
+var apfStatusRank = map[string]int{
+	APFStatusSaturated:      0,
+	APFStatusNearSaturation: 1,
+	APFStatusOK:             2,
+	APFStatusUnknown:        3,
+	APFStatusExempt:         4,
+}
+
+// GetAPFStatusTool reports API Priority and Fairness saturation and this server's own
+// client-side throttling
+type GetAPFStatusTool struct {
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient // nil when Prometheus is not configured
+}
+
+// NewGetAPFStatusTool creates a new get-apf-status tool; prometheus may be nil
+func NewGetAPFStatusTool(k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient) *GetAPFStatusTool {
+	return &GetAPFStatusTool{
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetAPFStatusTool) Name() string {
+	return "get-apf-status"
+}
+
+// Description returns the tool description for MCP
+func (t *GetAPFStatusTool) Description() string {
+	return `Check whether the Kubernetes API server is overloaded. Reports every API Priority and Fairness priority level with executing seats vs its concurrency limit, queued requests, and rejected request rate (live values need Prometheus), flagging levels at or near saturation. Also reports how often this server's own client-side rate limit (K8S_CLIENT_QPS) delayed its requests, and a diagnosis that tells "API server overloaded" apart from "our QPS is set too low".
+
+Use this tool for questions like:
+- "Why is everything slow?"
+- "Is the API server throttling requests?"
+- "Are we hitting our own QPS limit?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetAPFStatusTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Window for the rejected request rate and client-side throttle stats",
+				"default":     5,
+				"minimum":     1,
+				"maximum":     60,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetAPFStatusInput represents the input parameters
+type GetAPFStatusInput struct {
+	WindowMinutes int `json:"window_minutes"`
+}
+
+// PriorityLevelStatus is the load of one APF priority level
+type PriorityLevelStatus struct {
+	Name                     string  `json:"name"`
+	Status                   string  `json:"status"`
+	Type                     string  `json:"type,omitempty"` // Limited or Exempt
+	NominalConcurrencyShares int32   `json:"nominal_concurrency_shares,omitempty"`
+	ExecutingSeats           float64 `json:"executing_seats"`
+	LimitSeats               float64 `json:"limit_seats"`
+	UtilizationPercent       float64 `json:"utilization_percent"`
+	QueuedRequests           float64 `json:"queued_requests"`
+	RejectedPerSecond        float64 `json:"rejected_per_second"`
+}
+
+// ClientThrottleStatus summarizes this server's client-side rate limiter waits
+type ClientThrottleStatus struct {
+	QPS              float32 `json:"qps"`
+	Burst            int     `json:"burst"`
+	WindowMinutes    int     `json:"window_minutes"`
+	Requests         int     `json:"requests"`
+	Throttled        int     `json:"throttled"`
+	ThrottledPercent float64 `json:"throttled_percent"`
+	AvgWaitMs        float64 `json:"avg_wait_ms"`
+	MaxWaitMs        float64 `json:"max_wait_ms"`
+	Incomplete       bool    `json:"incomplete,omitempty"` // Older waits were dropped; counts are a lower bound
+}
+
+// GetAPFStatusOutput represents the tool output
+type GetAPFStatusOutput struct {
+	Status         string                `json:"status"`
+	Source         string                `json:"source"` // prometheus or flowcontrol_api
+	Diagnosis      string                `json:"diagnosis"`
+	PriorityLevels []PriorityLevelStatus `json:"priority_levels"`
+	ClientThrottle ClientThrottleStatus  `json:"client_throttle"`
+	Notes          []string              `json:"notes,omitempty"`
+}
+
+// apfSamples holds the live APF series read from Prometheus
+type apfSamples struct {
+	Executing []clients.PromSample
+	Limit     []clients.PromSample
+	Queued    []clients.PromSample
+	Rejected  []clients.PromSample
+}
+
+// RequiredPermissions declares the Kubernetes API access get-apf-status needs
+func (t *GetAPFStatusTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "flowcontrol.apiserver.k8s.io", Resource: "prioritylevelconfigurations", Verb: "list"},
+	}
+}
+
+// Execute runs the get-apf-status operation
+func (t *GetAPFStatusTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetAPFStatusInput{WindowMinutes: 5}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.WindowMinutes < 1 || input.WindowMinutes > 60 {
+		return nil, invalidArgs("window_minutes must be between 1 and 60")
+	}
+
+	output := GetAPFStatusOutput{Source: "flowcontrol_api"}
+
+	// Configuration gives the level types and shares; missing RBAC only loses that detail
+	var configs []flowcontrolv1.PriorityLevelConfiguration
+	list, err := t.k8sClient.Clientset().FlowcontrolV1().PriorityLevelConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		output.Notes = append(output.Notes, fmt.Sprintf("could not list PriorityLevelConfigurations: %v", err))
+	} else {
+		configs = list.Items
+	}
+
+	var live *apfSamples
+	if t.prometheus != nil {
+		live, err = t.queryAPF(ctx, input.WindowMinutes)
+		if err != nil {
+			if configs == nil {
+				return nil, err
+			}
+			output.Notes = append(output.Notes, fmt.Sprintf("Prometheus query failed, showing configuration only: %v", err))
+		} else {
+			output.Source = "prometheus"
+		}
+	} else {
+		output.Notes = append(output.Notes, "Prometheus is not configured (ENABLE_PROMETHEUS); executing, queued, and rejected counts are unavailable")
+	}
+
+	output.PriorityLevels = aggregateAPF(configs, live)
+	output.ClientThrottle = clientThrottleStatus(t.k8sClient.ThrottleStats(time.Duration(input.WindowMinutes) * time.Minute))
+	output.Status = APFStatusOK
+	if len(output.PriorityLevels) > 0 && apfStatusRank[output.PriorityLevels[0].Status] < apfStatusRank[APFStatusOK] {
+		output.Status = output.PriorityLevels[0].Status
+	} else if live == nil {
+		output.Status = APFStatusUnknown
+	}
+	output.Diagnosis = apfDiagnosis(output.PriorityLevels, output.ClientThrottle, live != nil)
+	return output, nil
+}
+
+// queryAPF reads the live APF series from Prometheus
+func (t *GetAPFStatusTool) queryAPF(ctx context.Context, windowMinutes int) (*apfSamples, error) {
+	var samples apfSamples
+	queries := []struct {
+		query  string
+		target *[]clients.PromSample
+	}{
+		{apfExecutingQuery, &samples.Executing},
+		{apfLimitQuery, &samples.Limit},
+		{apfQueuedQuery, &samples.Queued},
+		{fmt.Sprintf(apfRejectedQuery, windowMinutes), &samples.Rejected},
+	}
+	for _, q := range queries {
+		result, err := t.prometheus.Query(ctx, q.query)
+		if err != nil {
+			return nil, dependencyError(err)
+		}
+		*q.target = result
+	}
+	return &samples, nil
+}
+
+// aggregateAPF merges priority level configuration with live Prometheus samples (nil when
+// unavailable) into one status per level, worst first
+func aggregateAPF(configs []flowcontrolv1.PriorityLevelConfiguration, live *apfSamples) []PriorityLevelStatus {
+	levels := make(map[string]*PriorityLevelStatus)
+	level := func(name string) *PriorityLevelStatus {
+		if l, ok := levels[name]; ok {
+			return l
+		}
+		l := &PriorityLevelStatus{Name: name}
+		levels[name] = l
+		return l
+	}
+
+	for i := range configs {
+		config := &configs[i]
+		l := level(config.Name)
+		l.Type = string(config.Spec.Type)
+		if config.Spec.Limited != nil && config.Spec.Limited.NominalConcurrencyShares != nil {
+			l.NominalConcurrencyShares = *config.Spec.Limited.NominalConcurrencyShares
+		}
+	}
+
+	if live != nil {
+		series := []struct {
+			samples []clients.PromSample
+			set     func(*PriorityLevelStatus, float64)
+		}{
+			{live.Executing, func(l *PriorityLevelStatus, v float64) { l.ExecutingSeats = v }},
+			{live.Limit, func(l *PriorityLevelStatus, v float64) { l.LimitSeats = v }},
+			{live.Queued, func(l *PriorityLevelStatus, v float64) { l.QueuedRequests = v }},
+			{live.Rejected, func(l *PriorityLevelStatus, v float64) { l.RejectedPerSecond = v }},
+		}
+		for _, s := range series {
+			for _, sample := range s.samples {
+				if name := sample.Labels["priority_level"]; name != "" {
+					s.set(level(name), sample.Value)
+				}
+			}
+		}
+	}
+
+	result := make([]PriorityLevelStatus, 0, len(levels))
+	for _, l := range levels {
+		if l.LimitSeats > 0 {
+			l.UtilizationPercent = math.Round(l.ExecutingSeats/l.LimitSeats*1000) / 10
+		}
+		l.RejectedPerSecond = math.Round(l.RejectedPerSecond*1000) / 1000
+		switch {
+		case l.Type == string(flowcontrolv1.PriorityLevelEnablementExempt) || (l.Type == "" && l.Name == flowcontrolv1.PriorityLevelConfigurationNameExempt):
+			l.Status = APFStatusExempt
+		case live == nil:
+			l.Status = APFStatusUnknown
+		case l.RejectedPerSecond > 0 || (l.LimitSeats > 0 && l.ExecutingSeats >= l.LimitSeats):
+			l.Status = APFStatusSaturated
+		case l.QueuedRequests > 0 || (l.LimitSeats > 0 && l.ExecutingSeats >= apfNearSaturation*l.LimitSeats):
+			l.Status = APFStatusNearSaturation
+		default:
+			l.Status = APFStatusOK
+		}
+		result = append(result, *l)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Status != result[j].Status {
+			return apfStatusRank[result[i].Status] < apfStatusRank[result[j].Status]
+		}
+		if result[i].UtilizationPercent != result[j].UtilizationPercent {
+			return result[i].UtilizationPercent > result[j].UtilizationPercent
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// clientThrottleStatus converts rate limiter stats for output
+func clientThrottleStatus(stats clients.ThrottleStats) ClientThrottleStatus {
+	status := ClientThrottleStatus{
+		QPS:           stats.QPS,
+		Burst:         stats.Burst,
+		WindowMinutes: int(stats.Window / time.Minute),
+		Requests:      stats.Requests,
+		Throttled:     stats.Throttled,
+		MaxWaitMs:     float64(stats.MaxWait.Microseconds()) / 1000,
+		Incomplete:    stats.Overflowed,
+	}
+	if stats.Requests > 0 {
+		status.ThrottledPercent = math.Round(float64(stats.Throttled)/float64(stats.Requests)*1000) / 10
+		status.AvgWaitMs = math.Round(float64(stats.TotalWait.Microseconds())/float64(stats.Requests)) / 1000
+	}
+	return status
+}
+
+// apfDiagnosis explains whether slowness comes from the API server, our own QPS, or both.
+// levels must be sorted worst first, as aggregateAPF returns them.
+func apfDiagnosis(levels []PriorityLevelStatus, throttle ClientThrottleStatus, haveLive bool) string {
+	var busy []string
+	for _, l := range levels {
+		if l.Status == APFStatusSaturated || l.Status == APFStatusNearSaturation {
+			busy = append(busy, l.Name)
+		}
+	}
+	clientThrottled := throttle.Requests > 0 && float64(throttle.Throttled) >= clientThrottledShare*float64(throttle.Requests)
+	clientSummary := fmt.Sprintf("this server's client-side limit (QPS %g, burst %d) delayed %.1f%% of its requests (max wait %.0fms)",
+		throttle.QPS, throttle.Burst, throttle.ThrottledPercent, throttle.MaxWaitMs)
+
+	switch {
+	case len(busy) > 0 && clientThrottled:
+		return fmt.Sprintf("API server overloaded: priority levels %s are at or near their concurrency limit. In addition, %s; raising K8S_CLIENT_QPS would add load to a saturated API server, so address the API server load first.",
+			strings.Join(busy, ", "), clientSummary)
+	case len(busy) > 0:
+		return fmt.Sprintf("API server overloaded: priority levels %s are at or near their concurrency limit. This server is not throttling itself, so slow responses come from the API server, not from K8S_CLIENT_QPS.",
+			strings.Join(busy, ", "))
+	case clientThrottled && haveLive:
+		return fmt.Sprintf("Our QPS is set too low: %s while every API server priority level has headroom; raise K8S_CLIENT_QPS and K8S_CLIENT_BURST.", clientSummary)
+	case clientThrottled:
+		return fmt.Sprintf("%s; API server load is unknown without Prometheus, but client-side throttling alone explains slow responses. Consider raising K8S_CLIENT_QPS.", clientSummary)
+	case haveLive:
+		return "No API server saturation or client-side throttling detected."
+	default:
+		return "No client-side throttling detected; API server load is unknown without Prometheus (set ENABLE_PROMETHEUS and PROMETHEUS_URL)."
+	}
+}
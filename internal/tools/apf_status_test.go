@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// apfFixture is a Prometheus snapshot of a cluster whose workload-low level is
+// saturated and whose global-default level is queuing
+var apfFixture = map[string]string{
+	apfExecutingQuery: `[
+		{"metric":{"priority_level":"exempt"},"value":[1717243200,"40"]},
+		{"metric":{"priority_level":"workload-low"},"value":[1717243200,"150"]},
+		{"metric":{"priority_level":"global-default"},"value":[1717243200,"30"]},
+		{"metric":{"priority_level":"system"},"value":[1717243200,"12"]}]`,
+	apfLimitQuery: `[
+		{"metric":{"priority_level":"exempt"},"value":[1717243200,"0"]},
+		{"metric":{"priority_level":"workload-low"},"value":[1717243200,"150"]},
+		{"metric":{"priority_level":"global-default"},"value":[1717243200,"60"]},
+		{"metric":{"priority_level":"system"},"value":[1717243200,"150"]}]`,
+	apfQueuedQuery: `[
+		{"metric":{"priority_level":"workload-low"},"value":[1717243200,"37"]},
+		{"metric":{"priority_level":"global-default"},"value":[1717243200,"2"]}]`,
+	fmt.Sprintf(apfRejectedQuery, 5): `[
+		{"metric":{"priority_level":"workload-low"},"value":[1717243200,"3.3333333"]}]`,
+}
+
+func newAPFPrometheus(t *testing.T, fixture map[string]string) *clients.PrometheusClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, ok := fixture[r.URL.Query().Get("query")]
+		if !ok {
+			result = "[]"
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":%s}}`, result)
+	}))
+	t.Cleanup(server.Close)
+	return clients.NewPrometheusClient(clients.PrometheusConfig{URL: server.URL})
+}
+
+func newPriorityLevel(name string, exempt bool, shares int32) *flowcontrolv1.PriorityLevelConfiguration {
+	plc := &flowcontrolv1.PriorityLevelConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if exempt {
+		plc.Spec.Type = flowcontrolv1.PriorityLevelEnablementExempt
+		return plc
+	}
+	plc.Spec.Type = flowcontrolv1.PriorityLevelEnablementLimited
+	plc.Spec.Limited = &flowcontrolv1.LimitedPriorityLevelConfiguration{NominalConcurrencyShares: &shares}
+	return plc
+}
+
+func apfConfigs() []runtime.Object {
+	return []runtime.Object{
+		newPriorityLevel("exempt", true, 0),
+		newPriorityLevel("workload-low", false, 100),
+		newPriorityLevel("global-default", false, 20),
+		newPriorityLevel("system", false, 30),
+	}
+}
+
+func TestAggregateAPF(t *testing.T) {
+	sample := func(level string, value float64) clients.PromSample {
+		return clients.PromSample{Labels: map[string]string{"priority_level": level}, Value: value}
+	}
+	configs := []flowcontrolv1.PriorityLevelConfiguration{
+		*newPriorityLevel("exempt", true, 0),
+		*newPriorityLevel("workload-low", false, 100),
+		*newPriorityLevel("catch-all", false, 5),
+	}
+
+	tests := []struct {
+		name       string
+		live       *apfSamples
+		wantOrder  []string
+		wantStatus map[string]string
+	}{
+		{
+			name:      "configuration only",
+			wantOrder: []string{"catch-all", "workload-low", "exempt"},
+			wantStatus: map[string]string{
+				"catch-all":    APFStatusUnknown,
+				"workload-low": APFStatusUnknown,
+				"exempt":       APFStatusExempt,
+			},
+		},
+		{
+			name: "rejections saturate even below the limit",
+			live: &apfSamples{
+				Executing: []clients.PromSample{sample("workload-low", 10), sample("catch-all", 1)},
+				Limit:     []clients.PromSample{sample("workload-low", 100), sample("catch-all", 10)},
+				Rejected:  []clients.PromSample{sample("workload-low", 0.5)},
+			},
+			wantOrder: []string{"workload-low", "catch-all", "exempt"},
+			wantStatus: map[string]string{
+				"workload-low": APFStatusSaturated,
+				"catch-all":    APFStatusOK,
+				"exempt":       APFStatusExempt,
+			},
+		},
+		{
+			name: "utilization threshold and queuing flag near saturation",
+			live: &apfSamples{
+				Executing: []clients.PromSample{sample("workload-low", 80), sample("catch-all", 1), sample("leader-election", 2)},
+				Limit:     []clients.PromSample{sample("workload-low", 100), sample("catch-all", 10), sample("leader-election", 20)},
+				Queued:    []clients.PromSample{sample("catch-all", 1)},
+			},
+			wantOrder: []string{"workload-low", "catch-all", "leader-election", "exempt"},
+			wantStatus: map[string]string{
+				"workload-low":    APFStatusNearSaturation,
+				"catch-all":       APFStatusNearSaturation,
+				"leader-election": APFStatusOK,
+				"exempt":          APFStatusExempt,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			levels := aggregateAPF(configs, tt.live)
+
+			var order []string
+			for _, l := range levels {
+				order = append(order, l.Name)
+				assert.Equal(t, tt.wantStatus[l.Name], l.Status, l.Name)
+			}
+			assert.Equal(t, tt.wantOrder, order)
+		})
+	}
+}
+
+func TestApfDiagnosis(t *testing.T) {
+	busy := []PriorityLevelStatus{{Name: "workload-low", Status: APFStatusSaturated}, {Name: "system", Status: APFStatusOK}}
+	idle := []PriorityLevelStatus{{Name: "workload-low", Status: APFStatusOK}}
+	throttled := ClientThrottleStatus{QPS: 50, Burst: 100, Requests: 200, Throttled: 80, ThrottledPercent: 40, MaxWaitMs: 1500}
+	quiet := ClientThrottleStatus{QPS: 50, Burst: 100, Requests: 200, Throttled: 2, ThrottledPercent: 1}
+
+	tests := []struct {
+		name     string
+		levels   []PriorityLevelStatus
+		throttle ClientThrottleStatus
+		haveLive bool
+		want     []string
+	}{
+		{"server overloaded", busy, quiet, true, []string{"API server overloaded: priority levels workload-low", "not from K8S_CLIENT_QPS"}},
+		{"both", busy, throttled, true, []string{"API server overloaded", "delayed 40.0% of its requests", "address the API server load first"}},
+		{"qps too low", idle, throttled, true, []string{"Our QPS is set too low", "(QPS 50, burst 100)", "max wait 1500ms"}},
+		{"throttled without prometheus", nil, throttled, false, []string{"unknown without Prometheus", "Consider raising K8S_CLIENT_QPS"}},
+		{"healthy", idle, quiet, true, []string{"No API server saturation or client-side throttling detected."}},
+		{"no data", nil, ClientThrottleStatus{}, false, []string{"API server load is unknown without Prometheus"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnosis := apfDiagnosis(tt.levels, tt.throttle, tt.haveLive)
+			for _, want := range tt.want {
+				assert.Contains(t, diagnosis, want)
+			}
+		})
+	}
+}
+
+func TestClientThrottleStatus(t *testing.T) {
+	status := clientThrottleStatus(clients.ThrottleStats{
+		QPS:       50,
+		Burst:     100,
+		Window:    5 * time.Minute,
+		Requests:  3,
+		Throttled: 1,
+		TotalWait: 301500 * time.Microsecond,
+		MaxWait:   300 * time.Millisecond,
+	})
+
+	assert.Equal(t, 5, status.WindowMinutes)
+	assert.Equal(t, 33.3, status.ThrottledPercent)
+	assert.Equal(t, 100.5, status.AvgWaitMs)
+	assert.Equal(t, 300.0, status.MaxWaitMs)
+}
+
+func TestGetAPFStatusTool_Prometheus(t *testing.T) {
+	client := clients.NewK8sClientWithClientset(fake.NewClientset(apfConfigs()...))
+	tool := NewGetAPFStatusTool(client, newAPFPrometheus(t, apfFixture))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetAPFStatusOutput)
+
+	assert.Equal(t, "prometheus", output.Source)
+	assert.Equal(t, APFStatusSaturated, output.Status)
+	require.Len(t, output.PriorityLevels, 4)
+
+	workloadLow := output.PriorityLevels[0]
+	assert.Equal(t, "workload-low", workloadLow.Name)
+	assert.Equal(t, APFStatusSaturated, workloadLow.Status)
+	assert.Equal(t, int32(100), workloadLow.NominalConcurrencyShares)
+	assert.Equal(t, 100.0, workloadLow.UtilizationPercent)
+	assert.Equal(t, 37.0, workloadLow.QueuedRequests)
+	assert.Equal(t, 3.333, workloadLow.RejectedPerSecond)
+
+	assert.Equal(t, "global-default", output.PriorityLevels[1].Name)
+	assert.Equal(t, APFStatusNearSaturation, output.PriorityLevels[1].Status)
+	assert.Equal(t, APFStatusOK, output.PriorityLevels[2].Status)
+	assert.Equal(t, APFStatusExempt, output.PriorityLevels[3].Status)
+
+	assert.Contains(t, output.Diagnosis, "API server overloaded: priority levels workload-low, global-default")
+	assert.Empty(t, output.Notes)
+}
+
+func TestGetAPFStatusTool_ConfigurationFallback(t *testing.T) {
+	client := clients.NewK8sClientWithClientset(fake.NewClientset(apfConfigs()...))
+	tool := NewGetAPFStatusTool(client, nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"window_minutes": 10})
+	require.NoError(t, err)
+	output := result.(GetAPFStatusOutput)
+
+	assert.Equal(t, "flowcontrol_api", output.Source)
+	assert.Equal(t, APFStatusUnknown, output.Status)
+	require.Len(t, output.PriorityLevels, 4)
+	assert.Equal(t, APFStatusExempt, output.PriorityLevels[3].Status)
+	assert.Equal(t, 10, output.ClientThrottle.WindowMinutes)
+	require.Len(t, output.Notes, 1)
+	assert.Contains(t, output.Notes[0], "Prometheus is not configured")
+}
+
+func TestGetAPFStatusTool_InvalidWindow(t *testing.T) {
+	tool := NewGetAPFStatusTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"window_minutes": 61})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
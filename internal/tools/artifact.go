@@ -0,0 +1,32 @@
+package tools
+
+import "context"
+
+// Artifact is a document a tool produces that is too large to return inline,
+// such as a rendered report
+type Artifact struct {
+	Name        string // File name offered for download, e.g. "health-report.md"
+	ContentType string // e.g. "text/markdown; charset=utf-8"
+	Content     []byte
+}
+
+// ArtifactResult is returned by tools instead of inline data. The dispatcher
+// stores the artifacts and returns the summary with references to fetch
+// them from. Tools only return it under ArtifactsEnabled.
+type ArtifactResult struct {
+	Summary   interface{}
+	Artifacts []Artifact
+}
+
+type artifactsKey struct{}
+
+// WithArtifacts returns a context under which tools may return an ArtifactResult
+func WithArtifacts(ctx context.Context) context.Context {
+	return context.WithValue(ctx, artifactsKey{}, true)
+}
+
+// ArtifactsEnabled reports whether the server stores artifacts for the call
+func ArtifactsEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(artifactsKey{}).(bool)
+	return enabled
+}
@@ -0,0 +1,670 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// autoscalerStatusConfigMap is the ConfigMap cluster-autoscaler writes its status to
+	autoscalerStatusConfigMap = "cluster-autoscaler-status"
+
+	// machineAPINamespace holds MachineSets, Machines and MachineAutoscalers on OpenShift
+	machineAPINamespace = "openshift-machine-api"
+
+	// machineSetLabel names the MachineSet a Machine belongs to
+	machineSetLabel = "machine.openshift.io/cluster-api-machineset"
+
+	// machineStuckAfter is how long a Machine may stay in a transitional phase
+	machineStuckAfter = 15 * time.Minute
+
+	// maxAutoscalerEvents and maxPendingPodSamples cap the lists in the output
+	maxAutoscalerEvents  = 10
+	maxPendingPodSamples = 5
+)
+
+// autoscalerStatusNamespaces are where the status ConfigMap lives on OpenShift and upstream
+var autoscalerStatusNamespaces = []string{machineAPINamespace, "kube-system"}
+
+// autoscalerEventReasons are the events cluster-autoscaler emits for its decisions
+var autoscalerEventReasons = map[string]bool{
+	"TriggeredScaleUp":     true,
+	"NotTriggerScaleUp":    true,
+	"ScaledUpGroup":        true,
+	"FailedToScaleUpGroup": true,
+	"ScaleDown":            true,
+	"ScaleDownEmpty":       true,
+	"ScaleDownFailed":      true,
+	"DeleteUnregistered":   true,
+}
+
+// legacyStatusCount matches "key=value" counters in the pre-1.30 text status format
+var legacyStatusCount = regexp.MustCompile(`(\w+)=(\d+)`)
+
+// GetAutoscalerStatusTool explains what the cluster autoscaler is doing and why pods stay Pending
+type GetAutoscalerStatusTool struct {
+	k8sClient      *clients.K8sClient
+	machineAPI     bool // machine.openshift.io is served
+	autoscalingAPI bool // autoscaling.openshift.io is served
+}
+
+// NewGetAutoscalerStatusTool creates a new get-autoscaler-status tool. machineAPI and
+// autoscalingAPI report whether the OpenShift machine-api and autoscaling groups are served;
+// the MachineSet, Machine and autoscaler CR checks are skipped when they are not.
+func NewGetAutoscalerStatusTool(k8sClient *clients.K8sClient, machineAPI, autoscalingAPI bool) *GetAutoscalerStatusTool {
+	return &GetAutoscalerStatusTool{
+		k8sClient:      k8sClient,
+		machineAPI:     machineAPI,
+		autoscalingAPI: autoscalingAPI,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetAutoscalerStatusTool) Name() string {
+	return "get-autoscaler-status"
+}
+
+// Description returns the tool description for MCP
+func (t *GetAutoscalerStatusTool) Description() string {
+	return `Explain what the cluster autoscaler is doing and why Pending pods are not getting new nodes. Reads the cluster-autoscaler status ConfigMap (and on OpenShift the ClusterAutoscaler and MachineAutoscaler resources) for per-node-group current, min and max size and scale-up/scale-down state, lists recent autoscaler decisions from events, MachineSets whose ready replicas lag the desired count, and Machines stuck provisioning or failed with their error messages. Correlates all of it with the currently unschedulable pods, e.g. "12 pods pending, autoscaler at max on worker-east".
+
+Use this tool for questions like:
+- "Why isn't the autoscaler adding nodes?"
+- "Which node groups are at their maximum size?"
+- "Are any machines failing to provision?"
+- "Why are my pods stuck Pending?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetAutoscalerStatusTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "How far back to report autoscaler events (1-1440)",
+				"default":     60,
+				"minimum":     1,
+				"maximum":     1440,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetAutoscalerStatusInput represents the input parameters
+type GetAutoscalerStatusInput struct {
+	WindowMinutes int `json:"window_minutes"`
+}
+
+// AutoscalerClusterWide is the cluster-wide autoscaler state
+type AutoscalerClusterWide struct {
+	Health    string `json:"health"`
+	ScaleUp   string `json:"scale_up"`
+	ScaleDown string `json:"scale_down"`
+}
+
+// AutoscalerNodeGroup is one node group (a MachineSet on OpenShift) managed by the autoscaler
+type AutoscalerNodeGroup struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"` // status_configmap or machine_autoscaler
+	Health     string `json:"health,omitempty"`
+	Ready      int    `json:"ready"`
+	Registered int    `json:"registered"`
+	Target     int    `json:"target"`
+	MinSize    int    `json:"min_size"`
+	MaxSize    int    `json:"max_size"`
+	AtMax      bool   `json:"at_max"`
+	ScaleUp    string `json:"scale_up,omitempty"`
+	ScaleDown  string `json:"scale_down,omitempty"`
+	Backoff    string `json:"backoff,omitempty"` // Why scale-up is backing off
+}
+
+// AutoscalerEvent is a recent scaling decision
+type AutoscalerEvent struct {
+	Reason   string    `json:"reason"`
+	Object   string    `json:"object"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// MachineSetStatus is a MachineSet whose ready replicas lag the desired count
+type MachineSetStatus struct {
+	Name      string `json:"name"`
+	Desired   int64  `json:"desired"`
+	Current   int64  `json:"current"`
+	Ready     int64  `json:"ready"`
+	Available int64  `json:"available"`
+}
+
+// StuckMachine is a Machine that failed or is stuck in a transitional phase
+type StuckMachine struct {
+	Name         string `json:"name"`
+	MachineSet   string `json:"machineset,omitempty"`
+	Phase        string `json:"phase"`
+	Age          string `json:"age"`
+	ErrorReason  string `json:"error_reason,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// GetAutoscalerStatusOutput represents the tool output
+type GetAutoscalerStatusOutput struct {
+	AutoscalerFound    bool                   `json:"autoscaler_found"`
+	StatusSource       string                 `json:"status_source,omitempty"` // namespace/name of the status ConfigMap
+	ClusterWide        *AutoscalerClusterWide `json:"cluster_wide,omitempty"`
+	NodeGroups         []AutoscalerNodeGroup  `json:"node_groups"`
+	RecentEvents       []AutoscalerEvent      `json:"recent_events"`
+	LaggingMachineSets []MachineSetStatus     `json:"lagging_machinesets,omitempty"`
+	StuckMachines      []StuckMachine         `json:"stuck_machines,omitempty"`
+	PendingPods        int                    `json:"pending_pods"`
+	PendingPodSamples  []string               `json:"pending_pod_samples,omitempty"` // namespace/name: scheduler message
+	Diagnosis          []string               `json:"diagnosis"`
+	Notes              []string               `json:"notes,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access get-autoscaler-status needs
+func (t *GetAutoscalerStatusTool) RequiredPermissions() []PermissionRule {
+	rules := []PermissionRule{
+		{Resource: "configmaps", Verb: "get"},
+		{Resource: "pods", Verb: "list"},
+		{Resource: "events", Verb: "list"},
+	}
+	if t.machineAPI {
+		rules = append(rules,
+			PermissionRule{Group: "machine.openshift.io", Resource: "machinesets", Verb: "list", Namespace: machineAPINamespace},
+			PermissionRule{Group: "machine.openshift.io", Resource: "machines", Verb: "list", Namespace: machineAPINamespace},
+		)
+	}
+	if t.autoscalingAPI {
+		rules = append(rules,
+			PermissionRule{Group: "autoscaling.openshift.io", Resource: "clusterautoscalers", Verb: "list"},
+			PermissionRule{Group: "autoscaling.openshift.io", Resource: "machineautoscalers", Verb: "list", Namespace: machineAPINamespace},
+		)
+	}
+	return rules
+}
+
+// Execute runs the get-autoscaler-status operation
+func (t *GetAutoscalerStatusTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetAutoscalerStatusInput{WindowMinutes: 60}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.WindowMinutes < 1 || input.WindowMinutes > 1440 {
+		return nil, invalidArgs("window_minutes must be between 1 and 1440")
+	}
+
+	now := time.Now()
+	output := GetAutoscalerStatusOutput{
+		NodeGroups:   []AutoscalerNodeGroup{},
+		RecentEvents: []AutoscalerEvent{},
+		Diagnosis:    []string{},
+	}
+
+	for _, namespace := range autoscalerStatusNamespaces {
+		cm, err := t.k8sClient.Clientset().CoreV1().ConfigMaps(namespace).Get(ctx, autoscalerStatusConfigMap, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("cannot read %s/%s: %v", namespace, autoscalerStatusConfigMap, err))
+			continue
+		}
+		clusterWide, groups, err := parseAutoscalerStatus(cm.Data["status"])
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("cannot parse %s/%s: %v", namespace, autoscalerStatusConfigMap, err))
+			continue
+		}
+		output.AutoscalerFound = true
+		output.StatusSource = namespace + "/" + autoscalerStatusConfigMap
+		output.ClusterWide = &clusterWide
+		output.NodeGroups = append(output.NodeGroups, groups...)
+		break
+	}
+
+	var machineSets []unstructured.Unstructured
+	if t.machineAPI {
+		list, err := t.k8sClient.ListResources(ctx, "machine.openshift.io/v1beta1", "MachineSet", machineAPINamespace, metav1.ListOptions{})
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("cannot list MachineSets: %v", err))
+		} else {
+			machineSets = list.Items
+			output.LaggingMachineSets = laggingMachineSets(machineSets)
+		}
+		machines, err := t.k8sClient.ListResources(ctx, "machine.openshift.io/v1beta1", "Machine", machineAPINamespace, metav1.ListOptions{})
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("cannot list Machines: %v", err))
+		} else {
+			output.StuckMachines = stuckMachines(machines.Items, now)
+		}
+	}
+
+	if t.autoscalingAPI {
+		clusterAutoscalers, err := t.k8sClient.ListResources(ctx, "autoscaling.openshift.io/v1", "ClusterAutoscaler", "", metav1.ListOptions{})
+		switch {
+		case err != nil:
+			output.Notes = append(output.Notes, fmt.Sprintf("cannot list ClusterAutoscalers: %v", err))
+		case len(clusterAutoscalers.Items) == 0:
+			output.Notes = append(output.Notes, "no ClusterAutoscaler resource exists, so OpenShift does not run the cluster autoscaler")
+		default:
+			output.AutoscalerFound = true
+		}
+		machineAutoscalers, err := t.k8sClient.ListResources(ctx, "autoscaling.openshift.io/v1beta1", "MachineAutoscaler", machineAPINamespace, metav1.ListOptions{})
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("cannot list MachineAutoscalers: %v", err))
+		} else {
+			output.NodeGroups = mergeMachineAutoscalers(output.NodeGroups, machineAutoscalers.Items, machineSets)
+		}
+	}
+
+	if events, err := t.k8sClient.ListEvents(ctx, ""); err != nil {
+		output.Notes = append(output.Notes, fmt.Sprintf("autoscaler events unavailable: %v", err))
+	} else {
+		output.RecentEvents = autoscalerEvents(events.Items, now.Add(-time.Duration(input.WindowMinutes)*time.Minute))
+	}
+
+	pods, err := t.k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return nil, apiError(err)
+	}
+	unschedulable := unschedulablePods(pods.Items)
+	output.PendingPods = len(unschedulable)
+	for i, pod := range unschedulable {
+		if i == maxPendingPodSamples {
+			break
+		}
+		output.PendingPodSamples = append(output.PendingPodSamples, pod)
+	}
+
+	output.Diagnosis = autoscalerDiagnosis(output)
+	return output, nil
+}
+
+// autoscalerStatusDocument is the YAML status format written by cluster-autoscaler 1.30+
+type autoscalerStatusDocument struct {
+	AutoscalerStatus string `json:"autoscalerStatus"`
+	ClusterWide      struct {
+		Health    autoscalerStatusHealth `json:"health"`
+		ScaleUp   autoscalerStatusState  `json:"scaleUp"`
+		ScaleDown autoscalerStatusState  `json:"scaleDown"`
+	} `json:"clusterWide"`
+	NodeGroups []struct {
+		Name      string                 `json:"name"`
+		Health    autoscalerStatusHealth `json:"health"`
+		ScaleUp   autoscalerStatusState  `json:"scaleUp"`
+		ScaleDown autoscalerStatusState  `json:"scaleDown"`
+	} `json:"nodeGroups"`
+}
+
+type autoscalerStatusHealth struct {
+	Status     string `json:"status"`
+	NodeCounts struct {
+		Registered struct {
+			Total int `json:"total"`
+			Ready int `json:"ready"`
+		} `json:"registered"`
+	} `json:"nodeCounts"`
+	CloudProviderTarget int `json:"cloudProviderTarget"`
+	MinSize             int `json:"minSize"`
+	MaxSize             int `json:"maxSize"`
+}
+
+type autoscalerStatusState struct {
+	Status      string `json:"status"`
+	BackoffInfo struct {
+		ErrorCode    string `json:"errorCode"`
+		ErrorMessage string `json:"errorMessage"`
+	} `json:"backoffInfo"`
+}
+
+// parseAutoscalerStatus parses the "status" key of the cluster-autoscaler status ConfigMap,
+// in either the YAML format of cluster-autoscaler 1.30+ or the older text format
+func parseAutoscalerStatus(data string) (AutoscalerClusterWide, []AutoscalerNodeGroup, error) {
+	if strings.TrimSpace(data) == "" {
+		return AutoscalerClusterWide{}, nil, fmt.Errorf("status is empty")
+	}
+	if !strings.Contains(data, "Cluster-wide:") {
+		return parseAutoscalerStatusYAML(data)
+	}
+	return parseAutoscalerStatusText(data), legacyNodeGroups(data), nil
+}
+
+func parseAutoscalerStatusYAML(data string) (AutoscalerClusterWide, []AutoscalerNodeGroup, error) {
+	var doc autoscalerStatusDocument
+	if err := yaml.Unmarshal([]byte(data), &doc); err != nil {
+		return AutoscalerClusterWide{}, nil, err
+	}
+	clusterWide := AutoscalerClusterWide{
+		Health:    doc.ClusterWide.Health.Status,
+		ScaleUp:   doc.ClusterWide.ScaleUp.Status,
+		ScaleDown: doc.ClusterWide.ScaleDown.Status,
+	}
+	groups := make([]AutoscalerNodeGroup, 0, len(doc.NodeGroups))
+	for _, g := range doc.NodeGroups {
+		group := AutoscalerNodeGroup{
+			Name:       g.Name,
+			Source:     "status_configmap",
+			Health:     g.Health.Status,
+			Ready:      g.Health.NodeCounts.Registered.Ready,
+			Registered: g.Health.NodeCounts.Registered.Total,
+			Target:     g.Health.CloudProviderTarget,
+			MinSize:    g.Health.MinSize,
+			MaxSize:    g.Health.MaxSize,
+			ScaleUp:    g.ScaleUp.Status,
+			ScaleDown:  g.ScaleDown.Status,
+			Backoff:    strings.TrimSpace(g.ScaleUp.BackoffInfo.ErrorCode + " " + g.ScaleUp.BackoffInfo.ErrorMessage),
+		}
+		group.AtMax = group.MaxSize > 0 && group.Target >= group.MaxSize
+		groups = append(groups, group)
+	}
+	return clusterWide, groups, nil
+}
+
+// parseAutoscalerStatusText reads the cluster-wide block of the pre-1.30 text format
+func parseAutoscalerStatusText(data string) AutoscalerClusterWide {
+	var clusterWide AutoscalerClusterWide
+	clusterSection, _, _ := strings.Cut(data, "NodeGroups:")
+	for _, line := range strings.Split(clusterSection, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Health":
+			clusterWide.Health = firstField(value)
+		case "ScaleUp":
+			clusterWide.ScaleUp = firstField(value)
+		case "ScaleDown":
+			clusterWide.ScaleDown = firstField(value)
+		}
+	}
+	return clusterWide
+}
+
+// legacyNodeGroups reads the NodeGroups block of the pre-1.30 text format
+func legacyNodeGroups(data string) []AutoscalerNodeGroup {
+	_, section, found := strings.Cut(data, "NodeGroups:")
+	if !found {
+		return nil
+	}
+	var groups []AutoscalerNodeGroup
+	var current *AutoscalerNodeGroup
+	for _, line := range strings.Split(section, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Name":
+			groups = append(groups, AutoscalerNodeGroup{Name: strings.TrimSpace(value), Source: "status_configmap"})
+			current = &groups[len(groups)-1]
+		case "Health":
+			if current == nil {
+				continue
+			}
+			current.Health = firstField(value)
+			for _, match := range legacyStatusCount.FindAllStringSubmatch(value, -1) {
+				n, _ := strconv.Atoi(match[2])
+				switch match[1] {
+				case "ready":
+					current.Ready = n
+				case "registered":
+					current.Registered = n
+				case "cloudProviderTarget":
+					current.Target = n
+				case "minSize":
+					current.MinSize = n
+				case "maxSize":
+					current.MaxSize = n
+				}
+			}
+			current.AtMax = current.MaxSize > 0 && current.Target >= current.MaxSize
+		case "ScaleUp":
+			if current != nil {
+				current.ScaleUp = firstField(value)
+			}
+		case "ScaleDown":
+			if current != nil {
+				current.ScaleDown = firstField(value)
+			}
+		}
+	}
+	return groups
+}
+
+// firstField returns the first whitespace-separated word of s
+func firstField(s string) string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// nodeGroupMachineSet returns the MachineSet name of a node group, which the status
+// ConfigMap may prefix with the kind and namespace ("MachineSet/openshift-machine-api/worker-a")
+func nodeGroupMachineSet(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// mergeMachineAutoscalers adds node groups for MachineAutoscalers the status ConfigMap did
+// not report, sized from their target MachineSet
+func mergeMachineAutoscalers(groups []AutoscalerNodeGroup, machineAutoscalers, machineSets []unstructured.Unstructured) []AutoscalerNodeGroup {
+	known := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		known[nodeGroupMachineSet(g.Name)] = true
+	}
+	replicas := make(map[string][2]int64, len(machineSets))
+	for i := range machineSets {
+		desired, _, _ := unstructured.NestedInt64(machineSets[i].Object, "spec", "replicas")
+		ready, _, _ := unstructured.NestedInt64(machineSets[i].Object, "status", "readyReplicas")
+		replicas[machineSets[i].GetName()] = [2]int64{desired, ready}
+	}
+
+	for i := range machineAutoscalers {
+		ma := &machineAutoscalers[i]
+		kind, _, _ := unstructured.NestedString(ma.Object, "spec", "scaleTargetRef", "kind")
+		target, _, _ := unstructured.NestedString(ma.Object, "spec", "scaleTargetRef", "name")
+		if kind != "MachineSet" || target == "" || known[target] {
+			continue
+		}
+		minReplicas, _, _ := unstructured.NestedInt64(ma.Object, "spec", "minReplicas")
+		maxReplicas, _, _ := unstructured.NestedInt64(ma.Object, "spec", "maxReplicas")
+		counts := replicas[target]
+		groups = append(groups, AutoscalerNodeGroup{
+			Name:    target,
+			Source:  "machine_autoscaler",
+			Ready:   int(counts[1]),
+			Target:  int(counts[0]),
+			MinSize: int(minReplicas),
+			MaxSize: int(maxReplicas),
+			AtMax:   maxReplicas > 0 && counts[0] >= maxReplicas,
+		})
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+// laggingMachineSets returns MachineSets whose ready replicas are below the desired count
+func laggingMachineSets(machineSets []unstructured.Unstructured) []MachineSetStatus {
+	var lagging []MachineSetStatus
+	for i := range machineSets {
+		ms := &machineSets[i]
+		status := MachineSetStatus{Name: ms.GetName()}
+		status.Desired, _, _ = unstructured.NestedInt64(ms.Object, "spec", "replicas")
+		status.Current, _, _ = unstructured.NestedInt64(ms.Object, "status", "replicas")
+		status.Ready, _, _ = unstructured.NestedInt64(ms.Object, "status", "readyReplicas")
+		status.Available, _, _ = unstructured.NestedInt64(ms.Object, "status", "availableReplicas")
+		if status.Ready < status.Desired {
+			lagging = append(lagging, status)
+		}
+	}
+	return lagging
+}
+
+// stuckMachines returns Machines in the Failed phase, or in a transitional phase for
+// longer than machineStuckAfter, oldest first
+func stuckMachines(machines []unstructured.Unstructured, now time.Time) []StuckMachine {
+	type candidate struct {
+		machine StuckMachine
+		created time.Time
+	}
+	var candidates []candidate
+	for i := range machines {
+		m := &machines[i]
+		phase, _, _ := unstructured.NestedString(m.Object, "status", "phase")
+		age := now.Sub(m.GetCreationTimestamp().Time)
+		switch phase {
+		case "Failed":
+		case "", "Provisioning", "Provisioned", "Deleting":
+			if age < machineStuckAfter {
+				continue
+			}
+		default:
+			continue
+		}
+		if phase == "" {
+			phase = "Pending"
+		}
+		machine := StuckMachine{
+			Name:       m.GetName(),
+			MachineSet: m.GetLabels()[machineSetLabel],
+			Phase:      phase,
+			Age:        formatDuration(age),
+		}
+		machine.ErrorReason, _, _ = unstructured.NestedString(m.Object, "status", "errorReason")
+		machine.ErrorMessage, _, _ = unstructured.NestedString(m.Object, "status", "errorMessage")
+		candidates = append(candidates, candidate{machine: machine, created: m.GetCreationTimestamp().Time})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].created.Before(candidates[j].created) })
+	var stuck []StuckMachine
+	for _, c := range candidates {
+		stuck = append(stuck, c.machine)
+	}
+	return stuck
+}
+
+// autoscalerEvents returns autoscaler decisions seen since start, newest first
+func autoscalerEvents(events []corev1.Event, start time.Time) []AutoscalerEvent {
+	recent := []AutoscalerEvent{}
+	for i := range events {
+		event := &events[i]
+		if !autoscalerEventReasons[event.Reason] && event.Source.Component != "cluster-autoscaler" {
+			continue
+		}
+		lastSeen := eventLastSeen(event)
+		if lastSeen.Before(start) {
+			continue
+		}
+		object := strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name
+		if event.InvolvedObject.Namespace != "" {
+			object = strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+		}
+		recent = append(recent, AutoscalerEvent{
+			Reason:   event.Reason,
+			Object:   object,
+			Message:  event.Message,
+			Count:    event.Count,
+			LastSeen: lastSeen,
+		})
+	}
+	sort.SliceStable(recent, func(i, j int) bool { return recent[i].LastSeen.After(recent[j].LastSeen) })
+	if len(recent) > maxAutoscalerEvents {
+		recent = recent[:maxAutoscalerEvents]
+	}
+	return recent
+}
+
+// unschedulablePods returns "namespace/name: message" for each pod the scheduler could not place
+func unschedulablePods(pods []corev1.Pod) []string {
+	var pending []string
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+				pending = append(pending, fmt.Sprintf("%s/%s: %s", pod.Namespace, pod.Name, condition.Message))
+				break
+			}
+		}
+	}
+	sort.Strings(pending)
+	return pending
+}
+
+// autoscalerDiagnosis correlates pending pods with autoscaler, MachineSet and Machine state
+func autoscalerDiagnosis(output GetAutoscalerStatusOutput) []string {
+	var diagnosis []string
+	pending := fmt.Sprintf("%d pod(s) pending", output.PendingPods)
+
+	var atMax, backoff, scalingUp []string
+	for _, g := range output.NodeGroups {
+		name := nodeGroupMachineSet(g.Name)
+		switch {
+		case g.ScaleUp == "Backoff":
+			backoff = append(backoff, name)
+		case g.ScaleUp == "InProgress":
+			scalingUp = append(scalingUp, name)
+		}
+		if g.AtMax {
+			atMax = append(atMax, name)
+		}
+	}
+
+	if output.PendingPods > 0 {
+		switch {
+		case !output.AutoscalerFound:
+			diagnosis = append(diagnosis, pending+" and no cluster autoscaler is running, so no nodes will be added")
+		case len(output.NodeGroups) > 0 && len(atMax) == len(output.NodeGroups):
+			diagnosis = append(diagnosis, fmt.Sprintf("%s, autoscaler at max on %s; raise the maximum size to add nodes", pending, strings.Join(atMax, ", ")))
+		case len(atMax) > 0:
+			diagnosis = append(diagnosis, fmt.Sprintf("%s, autoscaler at max on %s", pending, strings.Join(atMax, ", ")))
+		}
+		if len(scalingUp) > 0 {
+			diagnosis = append(diagnosis, fmt.Sprintf("%s, scale-up in progress on %s", pending, strings.Join(scalingUp, ", ")))
+		}
+		for _, event := range output.RecentEvents {
+			if event.Reason == "NotTriggerScaleUp" {
+				diagnosis = append(diagnosis, "latest pod that did not trigger a scale-up: "+event.Message)
+				break
+			}
+		}
+	}
+	if len(backoff) > 0 {
+		diagnosis = append(diagnosis, "scale-up backing off after failures on "+strings.Join(backoff, ", "))
+	}
+	for _, ms := range output.LaggingMachineSets {
+		diagnosis = append(diagnosis, fmt.Sprintf("MachineSet %s has %d of %d machines ready", ms.Name, ms.Ready, ms.Desired))
+	}
+	for _, m := range output.StuckMachines {
+		message := fmt.Sprintf("Machine %s is %s for %s", m.Name, m.Phase, m.Age)
+		if m.ErrorMessage != "" {
+			message += ": " + m.ErrorMessage
+		}
+		diagnosis = append(diagnosis, message)
+	}
+	if len(diagnosis) == 0 {
+		if output.PendingPods > 0 {
+			diagnosis = append(diagnosis, pending+"; the autoscaler reports no limit or failure, check the pending pod messages for constraints no node group can satisfy")
+		} else {
+			diagnosis = append(diagnosis, "No unschedulable pods and no autoscaler or machine problems")
+		}
+	}
+	return diagnosis
+}
@@ -0,0 +1,323 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+var (
+	machineSetGVK        = schema.GroupVersionKind{Group: "machine.openshift.io", Version: "v1beta1", Kind: "MachineSet"}
+	machineGVK           = schema.GroupVersionKind{Group: "machine.openshift.io", Version: "v1beta1", Kind: "Machine"}
+	clusterAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.openshift.io", Version: "v1", Kind: "ClusterAutoscaler"}
+	machineAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.openshift.io", Version: "v1beta1", Kind: "MachineAutoscaler"}
+)
+
+const autoscalerStatusYAML = `time: 2026-10-14 11:59:50.123 +0000 UTC
+autoscalerStatus: Running
+clusterWide:
+  health:
+    status: Healthy
+  scaleUp:
+    status: InProgress
+  scaleDown:
+    status: NoCandidates
+nodeGroups:
+- name: MachineSet/openshift-machine-api/worker-east
+  health:
+    status: Healthy
+    nodeCounts:
+      registered:
+        total: 6
+        ready: 6
+    cloudProviderTarget: 6
+    minSize: 1
+    maxSize: 6
+  scaleUp:
+    status: NoActivity
+  scaleDown:
+    status: NoCandidates
+- name: MachineSet/openshift-machine-api/worker-west
+  health:
+    status: Healthy
+    nodeCounts:
+      registered:
+        total: 2
+        ready: 2
+    cloudProviderTarget: 2
+    minSize: 1
+    maxSize: 10
+  scaleUp:
+    status: Backoff
+    backoffInfo:
+      errorCode: QuotaExceeded
+      errorMessage: instance quota exceeded
+  scaleDown:
+    status: NoCandidates
+`
+
+const autoscalerStatusText = `Cluster-autoscaler status at 2023-06-01 10:00:00.000 +0000 UTC:
+Cluster-wide:
+  Health:      Healthy (ready=4 unready=0 notStarted=0 longNotStarted=0 registered=4 longUnregistered=0)
+               LastProbeTime:      2023-06-01 10:00:00 +0000 UTC
+  ScaleUp:     NoActivity (ready=4 registered=4)
+  ScaleDown:   NoCandidates (candidates=0)
+
+NodeGroups:
+  Name:        openshift-machine-api/worker-a
+  Health:      Healthy (ready=3 unready=0 notStarted=0 longNotStarted=0 registered=3 longUnregistered=0 cloudProviderTarget=3 (minSize=1, maxSize=3))
+  ScaleUp:     NoActivity (ready=3 cloudProviderTarget=3)
+  ScaleDown:   NoCandidates (candidates=0)
+
+  Name:        openshift-machine-api/worker-b
+  Health:      Healthy (ready=1 unready=0 notStarted=0 longNotStarted=0 registered=1 longUnregistered=0 cloudProviderTarget=1 (minSize=0, maxSize=5))
+  ScaleUp:     InProgress (ready=1 cloudProviderTarget=2)
+  ScaleDown:   NoCandidates (candidates=0)
+`
+
+func TestParseAutoscalerStatus_YAML(t *testing.T) {
+	clusterWide, groups, err := parseAutoscalerStatus(autoscalerStatusYAML)
+	require.NoError(t, err)
+
+	assert.Equal(t, AutoscalerClusterWide{Health: "Healthy", ScaleUp: "InProgress", ScaleDown: "NoCandidates"}, clusterWide)
+	require.Len(t, groups, 2)
+	assert.Equal(t, AutoscalerNodeGroup{
+		Name: "MachineSet/openshift-machine-api/worker-east", Source: "status_configmap", Health: "Healthy",
+		Ready: 6, Registered: 6, Target: 6, MinSize: 1, MaxSize: 6, AtMax: true, ScaleUp: "NoActivity", ScaleDown: "NoCandidates",
+	}, groups[0])
+	assert.False(t, groups[1].AtMax)
+	assert.Equal(t, "QuotaExceeded instance quota exceeded", groups[1].Backoff)
+}
+
+func TestParseAutoscalerStatus_Text(t *testing.T) {
+	clusterWide, groups, err := parseAutoscalerStatus(autoscalerStatusText)
+	require.NoError(t, err)
+
+	assert.Equal(t, AutoscalerClusterWide{Health: "Healthy", ScaleUp: "NoActivity", ScaleDown: "NoCandidates"}, clusterWide)
+	require.Len(t, groups, 2)
+	assert.Equal(t, AutoscalerNodeGroup{
+		Name: "openshift-machine-api/worker-a", Source: "status_configmap", Health: "Healthy",
+		Ready: 3, Registered: 3, Target: 3, MinSize: 1, MaxSize: 3, AtMax: true, ScaleUp: "NoActivity", ScaleDown: "NoCandidates",
+	}, groups[0])
+	assert.Equal(t, "InProgress", groups[1].ScaleUp)
+	assert.Equal(t, 5, groups[1].MaxSize)
+	assert.False(t, groups[1].AtMax)
+
+	_, _, err = parseAutoscalerStatus("  ")
+	assert.Error(t, err)
+}
+
+func TestMachineChecks(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	machine := func(name, phase string, age time.Duration, errorMessage string) unstructured.Unstructured {
+		status := map[string]interface{}{}
+		if phase != "" {
+			status["phase"] = phase
+		}
+		if errorMessage != "" {
+			status["errorReason"] = "InvalidConfiguration"
+			status["errorMessage"] = errorMessage
+		}
+		m := newUnstructured(machineGVK, machineAPINamespace, name, map[string]interface{}{"status": status})
+		m.SetCreationTimestamp(metav1.Time{Time: now.Add(-age)})
+		m.SetLabels(map[string]string{machineSetLabel: "worker-east"})
+		return *m
+	}
+
+	stuck := stuckMachines([]unstructured.Unstructured{
+		machine("running", "Running", time.Hour, ""),
+		machine("new", "Provisioning", 5*time.Minute, ""),
+		machine("slow", "Provisioned", 40*time.Minute, ""),
+		machine("broken", "Failed", 2*time.Hour, "no subnet found"),
+		machine("unset", "", 20*time.Minute, ""),
+	}, now)
+	require.Len(t, stuck, 3)
+	assert.Equal(t, StuckMachine{Name: "broken", MachineSet: "worker-east", Phase: "Failed", Age: "2h", ErrorReason: "InvalidConfiguration", ErrorMessage: "no subnet found"}, stuck[0])
+	assert.Equal(t, "slow", stuck[1].Name)
+	assert.Equal(t, "Pending", stuck[2].Phase)
+
+	machineSet := func(name string, desired, ready int64) unstructured.Unstructured {
+		return *newUnstructured(machineSetGVK, machineAPINamespace, name, map[string]interface{}{
+			"spec":   map[string]interface{}{"replicas": desired},
+			"status": map[string]interface{}{"replicas": desired, "readyReplicas": ready, "availableReplicas": ready},
+		})
+	}
+	lagging := laggingMachineSets([]unstructured.Unstructured{machineSet("worker-east", 3, 3), machineSet("worker-west", 4, 1)})
+	assert.Equal(t, []MachineSetStatus{{Name: "worker-west", Desired: 4, Current: 4, Ready: 1, Available: 1}}, lagging)
+
+	autoscaler := func(target string, minReplicas, maxReplicas int64) unstructured.Unstructured {
+		return *newUnstructured(machineAutoscalerGVK, machineAPINamespace, target, map[string]interface{}{
+			"spec": map[string]interface{}{
+				"minReplicas":    minReplicas,
+				"maxReplicas":    maxReplicas,
+				"scaleTargetRef": map[string]interface{}{"apiVersion": "machine.openshift.io/v1beta1", "kind": "MachineSet", "name": target},
+			},
+		})
+	}
+	groups := mergeMachineAutoscalers(
+		[]AutoscalerNodeGroup{{Name: "MachineSet/openshift-machine-api/worker-east", Source: "status_configmap"}},
+		[]unstructured.Unstructured{autoscaler("worker-east", 1, 3), autoscaler("worker-west", 1, 4)},
+		[]unstructured.Unstructured{machineSet("worker-east", 3, 3), machineSet("worker-west", 4, 1)},
+	)
+	require.Len(t, groups, 2)
+	assert.Equal(t, AutoscalerNodeGroup{Name: "worker-west", Source: "machine_autoscaler", Ready: 1, Target: 4, MinSize: 1, MaxSize: 4, AtMax: true}, groups[1])
+}
+
+func TestAutoscalerEventsAndPendingPods(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	event := func(reason, component string, ago time.Duration) corev1.Event {
+		return corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: "api-1"},
+			Reason:         reason,
+			Source:         corev1.EventSource{Component: component},
+			Message:        reason + " message",
+			Count:          1,
+			LastTimestamp:  metav1.Time{Time: now.Add(-ago)},
+		}
+	}
+	events := autoscalerEvents([]corev1.Event{
+		event("TriggeredScaleUp", "cluster-autoscaler", 10*time.Minute),
+		event("NotTriggerScaleUp", "cluster-autoscaler", 2*time.Minute),
+		event("ScaleDown", "cluster-autoscaler", 3*time.Hour),
+		event("BackOff", "kubelet", time.Minute),
+	}, now.Add(-time.Hour))
+	require.Len(t, events, 2)
+	assert.Equal(t, "NotTriggerScaleUp", events[0].Reason)
+	assert.Equal(t, "pod/shop/api-1", events[0].Object)
+
+	pending := unschedulablePods([]corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-1"},
+			Status: corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{
+				Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable, Message: "0/6 nodes are available: 6 Insufficient cpu.",
+			}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-2"},
+			Status:     corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}}},
+		},
+	})
+	assert.Equal(t, []string{"shop/api-1: 0/6 nodes are available: 6 Insufficient cpu."}, pending)
+}
+
+func TestAutoscalerDiagnosis(t *testing.T) {
+	tests := []struct {
+		name   string
+		output GetAutoscalerStatusOutput
+		want   []string
+	}{
+		{
+			name:   "no autoscaler",
+			output: GetAutoscalerStatusOutput{PendingPods: 3},
+			want:   []string{"3 pod(s) pending and no cluster autoscaler is running, so no nodes will be added"},
+		},
+		{
+			name: "all groups at max",
+			output: GetAutoscalerStatusOutput{
+				AutoscalerFound: true,
+				PendingPods:     12,
+				NodeGroups:      []AutoscalerNodeGroup{{Name: "MachineSet/openshift-machine-api/worker-east", AtMax: true}},
+			},
+			want: []string{"12 pod(s) pending, autoscaler at max on worker-east; raise the maximum size to add nodes"},
+		},
+		{
+			name: "backoff and failed machine",
+			output: GetAutoscalerStatusOutput{
+				AutoscalerFound: true,
+				PendingPods:     2,
+				NodeGroups: []AutoscalerNodeGroup{
+					{Name: "worker-east", AtMax: true},
+					{Name: "worker-west", ScaleUp: "Backoff"},
+				},
+				RecentEvents:  []AutoscalerEvent{{Reason: "NotTriggerScaleUp", Message: "pod didn't trigger scale-up: 1 max node group size reached"}},
+				StuckMachines: []StuckMachine{{Name: "worker-west-x", Phase: "Failed", Age: "2h", ErrorMessage: "quota exceeded"}},
+			},
+			want: []string{
+				"2 pod(s) pending, autoscaler at max on worker-east",
+				"latest pod that did not trigger a scale-up: pod didn't trigger scale-up: 1 max node group size reached",
+				"scale-up backing off after failures on worker-west",
+				"Machine worker-west-x is Failed for 2h: quota exceeded",
+			},
+		},
+		{
+			name:   "nothing pending",
+			output: GetAutoscalerStatusOutput{AutoscalerFound: true},
+			want:   []string{"No unschedulable pods and no autoscaler or machine problems"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, autoscalerDiagnosis(tt.output))
+		})
+	}
+}
+
+func TestGetAutoscalerStatusTool_Execute(t *testing.T) {
+	clientset := fake.NewClientset(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: machineAPINamespace, Name: autoscalerStatusConfigMap},
+			Data:       map[string]string{"status": autoscalerStatusYAML},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-1"},
+			Status: corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{
+				Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable, Message: "0/8 nodes are available",
+			}}},
+		},
+	)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(machineSetGVK, meta.RESTScopeNamespace)
+	mapper.Add(machineGVK, meta.RESTScopeNamespace)
+	mapper.Add(clusterAutoscalerGVK, meta.RESTScopeRoot)
+	mapper.Add(machineAutoscalerGVK, meta.RESTScopeNamespace)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		machineSetGVK.GroupVersion().WithResource("machinesets"):               "MachineSetList",
+		machineGVK.GroupVersion().WithResource("machines"):                     "MachineList",
+		clusterAutoscalerGVK.GroupVersion().WithResource("clusterautoscalers"): "ClusterAutoscalerList",
+		machineAutoscalerGVK.GroupVersion().WithResource("machineautoscalers"): "MachineAutoscalerList",
+	}, newUnstructured(clusterAutoscalerGVK, "", "default", map[string]interface{}{}))
+
+	tool := NewGetAutoscalerStatusTool(clients.NewK8sClientWithClients(clientset, dynamicClient, mapper), true, true)
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetAutoscalerStatusOutput)
+
+	assert.True(t, output.AutoscalerFound)
+	assert.Equal(t, "openshift-machine-api/cluster-autoscaler-status", output.StatusSource)
+	assert.Len(t, output.NodeGroups, 2)
+	assert.Equal(t, 1, output.PendingPods)
+	assert.Equal(t, []string{
+		"1 pod(s) pending, autoscaler at max on worker-east",
+		"scale-up backing off after failures on worker-west",
+	}, output.Diagnosis)
+	assert.Empty(t, output.Notes)
+}
+
+func TestGetAutoscalerStatusTool_Kubernetes(t *testing.T) {
+	tool := NewGetAutoscalerStatusTool(clients.NewK8sClientWithClientset(fake.NewClientset()), false, false)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetAutoscalerStatusOutput)
+	assert.False(t, output.AutoscalerFound)
+	assert.Equal(t, []string{"No unschedulable pods and no autoscaler or machine problems"}, output.Diagnosis)
+	assert.Len(t, tool.RequiredPermissions(), 3)
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"window_minutes": 0})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
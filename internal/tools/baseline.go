@@ -0,0 +1,643 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Facts a baseline can record. Each fact is a set of keys with a value, so
+// that drift is reported the same way for all of them.
+const (
+	BaselineFactNodeRoles          = "node_roles"           // Role -> node count
+	BaselineFactClusterOperators   = "cluster_operators"    // ClusterOperator -> operator version
+	BaselineFactOperators          = "operators"            // namespace/CSV -> phase
+	BaselineFactMachineConfigPools = "machine_config_pools" // MachineConfigPool -> rendered config
+	BaselineFactNamespaces         = "namespaces"           // "count" -> namespace count
+	BaselineFactWebhooks           = "webhooks"             // kind/name -> webhooks and failure policies
+)
+
+// Drift kinds reported by check-drift
+const (
+	DriftAdded   = "added"
+	DriftRemoved = "removed"
+	DriftChanged = "changed"
+)
+
+// DefaultBaselineName is used when capture-baseline or check-drift get no name
+const DefaultBaselineName = "default"
+
+// baselineNamePattern keeps baseline names usable as file names
+var baselineNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// csvCopiedFromLabel marks the copies OLM makes of an AllNamespaces operator's
+// CSV in every namespace; only the original counts as installed
+const csvCopiedFromLabel = "olm.copiedFrom"
+
+// baselineFact is one fact a baseline can record. read returns the fact's
+// keys and values, or a skipBaselineFact error when the cluster lacks the API.
+type baselineFact struct {
+	name string
+	read func(r *baselineReader, ctx context.Context) (map[string]string, error)
+}
+
+// skipBaselineFact reports that a fact does not apply to this cluster, rather than that reading it failed
+type skipBaselineFact string
+
+func (s skipBaselineFact) Error() string { return string(s) }
+
+// baselineFacts are the facts a baseline records by default, in reporting order
+var baselineFacts = []baselineFact{
+	{BaselineFactNodeRoles, (*baselineReader).readNodeRoles},
+	{BaselineFactClusterOperators, (*baselineReader).readClusterOperators},
+	{BaselineFactOperators, (*baselineReader).readOperators},
+	{BaselineFactMachineConfigPools, (*baselineReader).readMachineConfigPools},
+	{BaselineFactNamespaces, (*baselineReader).readNamespaces},
+	{BaselineFactWebhooks, (*baselineReader).readWebhooks},
+}
+
+// baselineFactNames returns the names of all baseline facts
+func baselineFactNames() []string {
+	names := make([]string, 0, len(baselineFacts))
+	for _, fact := range baselineFacts {
+		names = append(names, fact.name)
+	}
+	return names
+}
+
+// ClusterBaseline is a named snapshot of cluster configuration facts
+type ClusterBaseline struct {
+	Name       string                       `json:"name"`
+	CapturedAt time.Time                    `json:"captured_at"`
+	Facts      map[string]map[string]string `json:"facts"`
+}
+
+// BaselineStore keeps baselines by name. The server provides it, persisting
+// baselines next to its artifacts.
+type BaselineStore interface {
+	SaveBaseline(baseline ClusterBaseline) error
+	// LoadBaseline returns the named baseline, or an error wrapping ErrNotFound
+	LoadBaseline(name string) (*ClusterBaseline, error)
+	BaselineNames() []string
+}
+
+// SkippedBaselineFact is a fact that was not captured or compared
+type SkippedBaselineFact struct {
+	Fact   string `json:"fact"`
+	Reason string `json:"reason"`
+}
+
+// baselineReader reads the facts of a baseline from the cluster
+type baselineReader struct {
+	k8sClient *clients.K8sClient
+	openshift bool
+}
+
+// capture reads the named facts. Facts that do not apply or cannot be read
+// are skipped; the error is only returned when no fact could be read.
+func (r *baselineReader) capture(ctx context.Context, name string, facts []string, now time.Time) (ClusterBaseline, []SkippedBaselineFact, error) {
+	baseline := ClusterBaseline{Name: name, CapturedAt: now, Facts: make(map[string]map[string]string)}
+	var skipped []SkippedBaselineFact
+	var firstErr error
+	for _, fact := range baselineFacts {
+		if !slices.Contains(facts, fact.name) {
+			continue
+		}
+		values, err := fact.read(r, ctx)
+		if err != nil {
+			if _, skip := err.(skipBaselineFact); !skip && firstErr == nil {
+				firstErr = err
+			}
+			skipped = append(skipped, SkippedBaselineFact{Fact: fact.name, Reason: err.Error()})
+			continue
+		}
+		baseline.Facts[fact.name] = values
+	}
+	if len(baseline.Facts) == 0 && firstErr != nil {
+		return baseline, skipped, apiError(firstErr)
+	}
+	return baseline, skipped, nil
+}
+
+func (r *baselineReader) readNodeRoles(ctx context.Context) (map[string]string, error) {
+	nodes, err := r.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return nodeRoleCounts(nodes.Items), nil
+}
+
+func (r *baselineReader) readClusterOperators(ctx context.Context) (map[string]string, error) {
+	if !r.openshift {
+		return nil, skipBaselineFact("not an OpenShift cluster")
+	}
+	list, err := r.k8sClient.ListResources(ctx, "config.openshift.io/v1", "ClusterOperator", "", metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return clusterOperatorVersions(list.Items), nil
+}
+
+func (r *baselineReader) readOperators(ctx context.Context) (map[string]string, error) {
+	list, err := r.k8sClient.ListResources(ctx, "operators.coreos.com/v1alpha1", "ClusterServiceVersion", "", metav1.ListOptions{})
+	if err != nil {
+		if meta.IsNoMatchError(err) || apierrors.IsNotFound(err) {
+			return nil, skipBaselineFact("OLM is not installed (no ClusterServiceVersion API)")
+		}
+		return nil, err
+	}
+	return installedOperators(list.Items), nil
+}
+
+func (r *baselineReader) readMachineConfigPools(ctx context.Context) (map[string]string, error) {
+	if !r.openshift {
+		return nil, skipBaselineFact("not an OpenShift cluster")
+	}
+	list, err := r.k8sClient.ListResources(ctx, "machineconfiguration.openshift.io/v1", "MachineConfigPool", "", metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return renderedMachineConfigs(list.Items), nil
+}
+
+func (r *baselineReader) readNamespaces(ctx context.Context) (map[string]string, error) {
+	namespaces, err := r.k8sClient.ListNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"count": strconv.Itoa(len(namespaces.Items))}, nil
+}
+
+func (r *baselineReader) readWebhooks(ctx context.Context) (map[string]string, error) {
+	admission := r.k8sClient.Clientset().AdmissionregistrationV1()
+	validating, err := admission.ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validating webhook configurations: %w", err)
+	}
+	mutating, err := admission.MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mutating webhook configurations: %w", err)
+	}
+	return webhookConfigurations(validating.Items, mutating.Items), nil
+}
+
+// nodeRoleCounts counts nodes by their primary role
+func nodeRoleCounts(nodes []corev1.Node) map[string]string {
+	counts := make(map[string]int)
+	for i := range nodes {
+		counts[nodeRole(nodes[i].Labels)]++
+	}
+	values := make(map[string]string, len(counts))
+	for role, count := range counts {
+		values[role] = strconv.Itoa(count)
+	}
+	return values
+}
+
+// clusterOperatorVersions maps each ClusterOperator to the operator version it reports
+func clusterOperatorVersions(operators []unstructured.Unstructured) map[string]string {
+	values := make(map[string]string, len(operators))
+	for i := range operators {
+		versions, _, _ := unstructured.NestedSlice(operators[i].Object, "status", "versions")
+		version := ""
+		for _, item := range versions {
+			fields, ok := item.(map[string]interface{})
+			if ok && nestedString(fields, "name") == "operator" {
+				version = nestedString(fields, "version")
+				break
+			}
+		}
+		values[operators[i].GetName()] = version
+	}
+	return values
+}
+
+// installedOperators maps each installed ClusterServiceVersion to its phase,
+// leaving out the copies OLM places in every watched namespace
+func installedOperators(csvs []unstructured.Unstructured) map[string]string {
+	values := make(map[string]string, len(csvs))
+	for i := range csvs {
+		if _, copied := csvs[i].GetLabels()[csvCopiedFromLabel]; copied {
+			continue
+		}
+		phase, _, _ := unstructured.NestedString(csvs[i].Object, "status", "phase")
+		values[csvs[i].GetNamespace()+"/"+csvs[i].GetName()] = phase
+	}
+	return values
+}
+
+// renderedMachineConfigs maps each MachineConfigPool to the rendered config it is at
+func renderedMachineConfigs(pools []unstructured.Unstructured) map[string]string {
+	values := make(map[string]string, len(pools))
+	for i := range pools {
+		rendered, _, _ := unstructured.NestedString(pools[i].Object, "status", "configuration", "name")
+		values[pools[i].GetName()] = rendered
+	}
+	return values
+}
+
+// webhookConfigurations maps each admission webhook configuration to its
+// webhooks and their failure policies, e.g. "vpod.example.com=Fail"
+func webhookConfigurations(validating []admissionregistrationv1.ValidatingWebhookConfiguration, mutating []admissionregistrationv1.MutatingWebhookConfiguration) map[string]string {
+	values := make(map[string]string, len(validating)+len(mutating))
+	describe := func(name string, policy *admissionregistrationv1.FailurePolicyType) string {
+		// The API server defaults an unset policy to Fail
+		failurePolicy := admissionregistrationv1.Fail
+		if policy != nil {
+			failurePolicy = *policy
+		}
+		return name + "=" + string(failurePolicy)
+	}
+	for _, config := range validating {
+		webhooks := make([]string, 0, len(config.Webhooks))
+		for _, webhook := range config.Webhooks {
+			webhooks = append(webhooks, describe(webhook.Name, webhook.FailurePolicy))
+		}
+		sort.Strings(webhooks)
+		values["validating/"+config.Name] = strings.Join(webhooks, ",")
+	}
+	for _, config := range mutating {
+		webhooks := make([]string, 0, len(config.Webhooks))
+		for _, webhook := range config.Webhooks {
+			webhooks = append(webhooks, describe(webhook.Name, webhook.FailurePolicy))
+		}
+		sort.Strings(webhooks)
+		values["mutating/"+config.Name] = strings.Join(webhooks, ",")
+	}
+	return values
+}
+
+// DriftChange is one difference between a baseline and the current cluster
+type DriftChange struct {
+	Fact     string `json:"fact"`
+	Key      string `json:"key"`
+	Kind     string `json:"kind"` // added, removed or changed
+	Baseline string `json:"baseline,omitempty"`
+	Current  string `json:"current,omitempty"`
+}
+
+// diffBaselines compares the facts both snapshots recorded and returns the
+// differences, ordered by fact as in baselineFacts and then by key. Facts
+// only one side recorded are returned as not compared.
+func diffBaselines(baseline, current ClusterBaseline) (changes []DriftChange, notCompared []string) {
+	changes = []DriftChange{}
+	for _, fact := range baselineFactNames() {
+		before, inBaseline := baseline.Facts[fact]
+		after, inCurrent := current.Facts[fact]
+		if !inBaseline && !inCurrent {
+			continue
+		}
+		if !inBaseline || !inCurrent {
+			notCompared = append(notCompared, fact)
+			continue
+		}
+		for key, value := range before {
+			now, ok := after[key]
+			switch {
+			case !ok:
+				changes = append(changes, DriftChange{Fact: fact, Key: key, Kind: DriftRemoved, Baseline: value})
+			case now != value:
+				changes = append(changes, DriftChange{Fact: fact, Key: key, Kind: DriftChanged, Baseline: value, Current: now})
+			}
+		}
+		for key, value := range after {
+			if _, ok := before[key]; !ok {
+				changes = append(changes, DriftChange{Fact: fact, Key: key, Kind: DriftAdded, Current: value})
+			}
+		}
+	}
+
+	order := make(map[string]int, len(baselineFacts))
+	for i, fact := range baselineFacts {
+		order[fact.name] = i
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Fact != changes[j].Fact {
+			return order[changes[i].Fact] < order[changes[j].Fact]
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes, notCompared
+}
+
+// driftSummary counts the changes by kind and describes them in one sentence
+func driftSummary(name string, changes []DriftChange) (added, removed, changed int, summary string) {
+	for _, change := range changes {
+		switch change.Kind {
+		case DriftAdded:
+			added++
+		case DriftRemoved:
+			removed++
+		case DriftChanged:
+			changed++
+		}
+	}
+	if len(changes) == 0 {
+		return 0, 0, 0, fmt.Sprintf("No drift from baseline %s", name)
+	}
+
+	facts := make([]string, 0)
+	for _, change := range changes {
+		if !slices.Contains(facts, change.Fact) {
+			facts = append(facts, change.Fact)
+		}
+	}
+	summary = fmt.Sprintf("Drift from baseline %s: %d added, %d removed, %d changed (in %s)",
+		name, added, removed, changed, strings.Join(facts, ", "))
+	return added, removed, changed, summary
+}
+
+// parseBaselineName validates a baseline name, defaulting it to DefaultBaselineName
+func parseBaselineName(name string) (string, error) {
+	if name == "" {
+		return DefaultBaselineName, nil
+	}
+	if !baselineNamePattern.MatchString(name) {
+		return "", invalidArgs("baseline name %q must be lowercase letters, digits and '-', up to 63 characters", name)
+	}
+	return name, nil
+}
+
+// parseBaselineFacts validates the requested facts, defaulting to all of them
+func parseBaselineFacts(facts []string) ([]string, error) {
+	if len(facts) == 0 {
+		return baselineFactNames(), nil
+	}
+	known := baselineFactNames()
+	for _, fact := range facts {
+		if !slices.Contains(known, fact) {
+			return nil, invalidArgs("unknown fact %q: must be one of %s", fact, strings.Join(known, ", "))
+		}
+	}
+	return facts, nil
+}
+
+// baselineFactsRules lists the API access needed to read every baseline fact
+func baselineFactsRules() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "namespaces", Verb: "list"},
+		{Group: "config.openshift.io", Resource: "clusteroperators", Verb: "list"},
+		{Group: "operators.coreos.com", Resource: "clusterserviceversions", Verb: "list"},
+		{Group: "machineconfiguration.openshift.io", Resource: "machineconfigpools", Verb: "list"},
+		{Group: "admissionregistration.k8s.io", Resource: "validatingwebhookconfigurations", Verb: "list"},
+		{Group: "admissionregistration.k8s.io", Resource: "mutatingwebhookconfigurations", Verb: "list"},
+	}
+}
+
+// CaptureBaselineTool snapshots cluster configuration facts into a named baseline
+type CaptureBaselineTool struct {
+	reader baselineReader
+	store  BaselineStore
+}
+
+// NewCaptureBaselineTool creates a new capture-baseline tool; openshift reports
+// whether the cluster serves the OpenShift config API
+func NewCaptureBaselineTool(k8sClient *clients.K8sClient, store BaselineStore, openshift bool) *CaptureBaselineTool {
+	return &CaptureBaselineTool{
+		reader: baselineReader{k8sClient: k8sClient, openshift: openshift},
+		store:  store,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *CaptureBaselineTool) Name() string {
+	return "capture-baseline"
+}
+
+// Audited reports that captures are audited: they replace stored baselines
+// that drift checks compare against, although they change nothing in the cluster
+func (t *CaptureBaselineTool) Audited() bool {
+	return true
+}
+
+// Volatility is realtime; every call takes a new snapshot
+func (t *CaptureBaselineTool) Volatility() Volatility {
+	return VolatilityRealtime
+}
+
+// Description returns the tool description for MCP
+func (t *CaptureBaselineTool) Description() string {
+	return `Snapshot the cluster's shape into a named baseline that check-drift later compares against: node count per role, ClusterOperator versions, installed operators (ClusterServiceVersions), MachineConfigPool rendered configs, namespace count, and admission webhook configurations. Capturing under an existing name replaces that baseline. Baselines are kept by the server, not in the cluster.
+
+Use this tool for questions like:
+- "Record the cluster configuration before the maintenance window"
+- "Save a baseline named pre-upgrade"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *CaptureBaselineTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Baseline name: lowercase letters, digits and '-'",
+				"default":     DefaultBaselineName,
+			},
+			"facts": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]interface{}{"type": "string", "enum": baselineFactNames()},
+				"description": "Facts to record. Leave empty for all of them.",
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// RequiredPermissions declares the Kubernetes API access capture-baseline needs
+func (t *CaptureBaselineTool) RequiredPermissions() []PermissionRule {
+	return baselineFactsRules()
+}
+
+// CaptureBaselineInput represents the input parameters
+type CaptureBaselineInput struct {
+	Name  string   `json:"name"`
+	Facts []string `json:"facts"`
+}
+
+// CaptureBaselineOutput represents the tool output
+type CaptureBaselineOutput struct {
+	Name       string                `json:"name"`
+	CapturedAt time.Time             `json:"captured_at"`
+	Replaced   bool                  `json:"replaced"` // A baseline of this name existed
+	Facts      map[string]int        `json:"facts"`    // Keys recorded per fact
+	Skipped    []SkippedBaselineFact `json:"skipped,omitempty"`
+	Baselines  []string              `json:"baselines"` // All stored baselines
+	Summary    string                `json:"summary"`
+}
+
+// Execute runs the capture-baseline operation
+func (t *CaptureBaselineTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input CaptureBaselineInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	name, err := parseBaselineName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	facts, err := parseBaselineFacts(input.Facts)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline, skipped, err := t.reader.capture(ctx, name, facts, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	replaced := slices.Contains(t.store.BaselineNames(), name)
+	if err := t.store.SaveBaseline(baseline); err != nil {
+		return nil, err
+	}
+
+	output := CaptureBaselineOutput{
+		Name:       name,
+		CapturedAt: baseline.CapturedAt,
+		Replaced:   replaced,
+		Facts:      make(map[string]int, len(baseline.Facts)),
+		Skipped:    skipped,
+		Baselines:  t.store.BaselineNames(),
+	}
+	for fact, values := range baseline.Facts {
+		output.Facts[fact] = len(values)
+	}
+	output.Summary = fmt.Sprintf("Captured baseline %s with %d of %d facts", name, len(baseline.Facts), len(facts))
+	if len(skipped) > 0 {
+		output.Summary += fmt.Sprintf("; %d skipped", len(skipped))
+	}
+	return output, nil
+}
+
+// CheckDriftTool compares the cluster against a stored baseline
+type CheckDriftTool struct {
+	reader baselineReader
+	store  BaselineStore
+}
+
+// NewCheckDriftTool creates a new check-drift tool; openshift reports whether
+// the cluster serves the OpenShift config API
+func NewCheckDriftTool(k8sClient *clients.K8sClient, store BaselineStore, openshift bool) *CheckDriftTool {
+	return &CheckDriftTool{
+		reader: baselineReader{k8sClient: k8sClient, openshift: openshift},
+		store:  store,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *CheckDriftTool) Name() string {
+	return "check-drift"
+}
+
+// Volatility is slow; configuration drifts over hours
+func (t *CheckDriftTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
+// Description returns the tool description for MCP
+func (t *CheckDriftTool) Description() string {
+	return `Compare the cluster against a baseline stored by capture-baseline and report what was added, removed, or changed: nodes per role, ClusterOperator versions, installed operators, MachineConfigPool rendered configs, namespace count, and admission webhooks. Only the facts the baseline recorded are compared.
+
+Use this tool for questions like:
+- "Has the cluster changed since the pre-upgrade baseline?"
+- "Did anyone install an operator or webhook since last week?"
+- "Which MachineConfigPools rendered a new config?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *CheckDriftTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the baseline to compare against",
+				"default":     DefaultBaselineName,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// RequiredPermissions declares the Kubernetes API access check-drift needs
+func (t *CheckDriftTool) RequiredPermissions() []PermissionRule {
+	return baselineFactsRules()
+}
+
+// CheckDriftInput represents the input parameters
+type CheckDriftInput struct {
+	Name string `json:"name"`
+}
+
+// CheckDriftOutput represents the tool output
+type CheckDriftOutput struct {
+	Baseline   string                `json:"baseline"`
+	CapturedAt time.Time             `json:"captured_at"` // When the baseline was captured
+	Drifted    bool                  `json:"drifted"`
+	Added      int                   `json:"added"`
+	Removed    int                   `json:"removed"`
+	Changed    int                   `json:"changed"`
+	Changes    []DriftChange         `json:"changes"`
+	Compared   []string              `json:"compared"`
+	Skipped    []SkippedBaselineFact `json:"skipped,omitempty"`
+	Summary    string                `json:"summary"`
+}
+
+// Execute runs the check-drift operation
+func (t *CheckDriftTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input CheckDriftInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	name, err := parseBaselineName(input.Name)
+	if err != nil {
+		return nil, err
+	}
+	baseline, err := t.store.LoadBaseline(name)
+	if err != nil {
+		return nil, err
+	}
+
+	facts := make([]string, 0, len(baseline.Facts))
+	for fact := range baseline.Facts {
+		facts = append(facts, fact)
+	}
+	current, skipped, err := t.reader.capture(ctx, name, facts, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	changes, notCompared := diffBaselines(*baseline, current)
+	output := CheckDriftOutput{
+		Baseline:   name,
+		CapturedAt: baseline.CapturedAt,
+		Drifted:    len(changes) > 0,
+		Changes:    changes,
+		Compared:   []string{},
+		Skipped:    skipped,
+	}
+	for _, fact := range baselineFactNames() {
+		if _, ok := baseline.Facts[fact]; ok && !slices.Contains(notCompared, fact) {
+			output.Compared = append(output.Compared, fact)
+		}
+	}
+	output.Added, output.Removed, output.Changed, output.Summary = driftSummary(name, changes)
+	if len(skipped) > 0 {
+		output.Summary += fmt.Sprintf("; %d fact(s) could not be read and were not compared", len(skipped))
+	}
+	return output, nil
+}
@@ -0,0 +1,273 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+var csvGVK = schema.GroupVersionKind{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersion"}
+
+// memoryBaselines is a BaselineStore for tests
+type memoryBaselines map[string]ClusterBaseline
+
+func (m memoryBaselines) SaveBaseline(baseline ClusterBaseline) error {
+	m[baseline.Name] = baseline
+	return nil
+}
+
+func (m memoryBaselines) LoadBaseline(name string) (*ClusterBaseline, error) {
+	baseline, ok := m[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: no baseline named %q", ErrNotFound, name)
+	}
+	return &baseline, nil
+}
+
+func (m memoryBaselines) BaselineNames() []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newBaselineNode(name, role string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"node-role.kubernetes.io/" + role: ""}}}
+}
+
+func newVersionedOperator(name, version string) *unstructured.Unstructured {
+	return newUnstructured(clusterOperatorGVK, "", name, map[string]interface{}{
+		"status": map[string]interface{}{
+			"versions": []interface{}{
+				map[string]interface{}{"name": "raw-internal", "version": "ignored"},
+				map[string]interface{}{"name": "operator", "version": version},
+			},
+		},
+	})
+}
+
+func newCSV(namespace, name, phase string, labels map[string]string) *unstructured.Unstructured {
+	csv := newUnstructured(csvGVK, namespace, name, map[string]interface{}{
+		"status": map[string]interface{}{"phase": phase},
+	})
+	csv.SetLabels(labels)
+	return csv
+}
+
+func newRenderedPool(name, rendered string) *unstructured.Unstructured {
+	return newUnstructured(machineConfigPoolGVK, "", name, map[string]interface{}{
+		"status": map[string]interface{}{"configuration": map[string]interface{}{"name": rendered}},
+	})
+}
+
+func newValidatingWebhook(name string, policy admissionregistrationv1.FailurePolicyType, webhooks ...string) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	config := &admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, webhook := range webhooks {
+		config.Webhooks = append(config.Webhooks, admissionregistrationv1.ValidatingWebhook{Name: webhook, FailurePolicy: &policy})
+	}
+	return config
+}
+
+// newBaselineTestClient builds a K8sClient serving the baseline facts; csvs
+// reports whether the OLM API is served
+func newBaselineTestClient(typed []runtime.Object, csvs bool, dynamic ...runtime.Object) *clients.K8sClient {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(clusterOperatorGVK, meta.RESTScopeRoot)
+	mapper.Add(machineConfigPoolGVK, meta.RESTScopeRoot)
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "config.openshift.io", Version: "v1", Resource: "clusteroperators"}:                 "ClusterOperatorList",
+		{Group: "machineconfiguration.openshift.io", Version: "v1", Resource: "machineconfigpools"}: "MachineConfigPoolList",
+	}
+	if csvs {
+		mapper.Add(csvGVK, meta.RESTScopeNamespace)
+		listKinds[schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "clusterserviceversions"}] = "ClusterServiceVersionList"
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, dynamic...)
+	return clients.NewK8sClientWithClients(fake.NewClientset(typed...), dynamicClient, mapper)
+}
+
+func TestBaselineFacts_Schema(t *testing.T) {
+	nodes := []corev1.Node{
+		*newBaselineNode("master-0", "master"),
+		*newBaselineNode("master-1", "control-plane"),
+		*newBaselineNode("infra-0", "infra"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}},
+	}
+	assert.Equal(t, map[string]string{"control-plane": "2", "infra": "1", "worker": "1"}, nodeRoleCounts(nodes))
+
+	operators := []unstructured.Unstructured{
+		*newVersionedOperator("etcd", "4.15.12"),
+		*newUnstructured(clusterOperatorGVK, "", "console", map[string]interface{}{}),
+	}
+	assert.Equal(t, map[string]string{"etcd": "4.15.12", "console": ""}, clusterOperatorVersions(operators))
+
+	csvs := []unstructured.Unstructured{
+		*newCSV("openshift-operators", "gitops.v1.12.0", "Succeeded", nil),
+		*newCSV("shop", "gitops.v1.12.0", "Succeeded", map[string]string{csvCopiedFromLabel: "openshift-operators"}),
+		*newCSV("logging", "loki.v5.9.0", "Installing", nil),
+	}
+	assert.Equal(t, map[string]string{
+		"openshift-operators/gitops.v1.12.0": "Succeeded",
+		"logging/loki.v5.9.0":                "Installing",
+	}, installedOperators(csvs), "OLM's copies are not separate installs")
+
+	pools := []unstructured.Unstructured{*newRenderedPool("worker", "rendered-worker-abc")}
+	assert.Equal(t, map[string]string{"worker": "rendered-worker-abc"}, renderedMachineConfigs(pools))
+
+	validating := []admissionregistrationv1.ValidatingWebhookConfiguration{
+		*newValidatingWebhook("gatekeeper", admissionregistrationv1.Ignore, "validation.gatekeeper.sh", "check-ignore-label.gatekeeper.sh"),
+	}
+	mutating := []admissionregistrationv1.MutatingWebhookConfiguration{{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector"},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "sidecar-injector.istio.io"}},
+	}}
+	assert.Equal(t, map[string]string{
+		"validating/gatekeeper":           "check-ignore-label.gatekeeper.sh=Ignore,validation.gatekeeper.sh=Ignore",
+		"mutating/istio-sidecar-injector": "sidecar-injector.istio.io=Fail",
+	}, webhookConfigurations(validating, mutating), "an unset failure policy is the API default, Fail")
+}
+
+func TestDiffBaselines(t *testing.T) {
+	baseline := ClusterBaseline{Name: "pre-upgrade", Facts: map[string]map[string]string{
+		BaselineFactNodeRoles:        {"control-plane": "3", "worker": "3"},
+		BaselineFactClusterOperators: {"etcd": "4.15.12", "insights": "4.15.12"},
+		BaselineFactNamespaces:       {"count": "60"},
+		BaselineFactWebhooks:         {"validating/gatekeeper": "validation.gatekeeper.sh=Ignore"},
+	}}
+	current := ClusterBaseline{Name: "pre-upgrade", Facts: map[string]map[string]string{
+		BaselineFactNodeRoles:        {"control-plane": "3", "worker": "5", "infra": "2"},
+		BaselineFactClusterOperators: {"etcd": "4.16.3"},
+		BaselineFactNamespaces:       {"count": "60"},
+		BaselineFactOperators:        {"shop/gitops.v1.12.0": "Succeeded"},
+	}}
+
+	changes, notCompared := diffBaselines(baseline, current)
+	assert.Equal(t, []DriftChange{
+		{Fact: BaselineFactNodeRoles, Key: "infra", Kind: DriftAdded, Current: "2"},
+		{Fact: BaselineFactNodeRoles, Key: "worker", Kind: DriftChanged, Baseline: "3", Current: "5"},
+		{Fact: BaselineFactClusterOperators, Key: "etcd", Kind: DriftChanged, Baseline: "4.15.12", Current: "4.16.3"},
+		{Fact: BaselineFactClusterOperators, Key: "insights", Kind: DriftRemoved, Baseline: "4.15.12"},
+	}, changes)
+	assert.Equal(t, []string{BaselineFactOperators, BaselineFactWebhooks}, notCompared,
+		"facts only one side recorded are not reported as drift")
+
+	added, removed, changed, summary := driftSummary("pre-upgrade", changes)
+	assert.Equal(t, [3]int{1, 1, 2}, [3]int{added, removed, changed})
+	assert.Equal(t, "Drift from baseline pre-upgrade: 1 added, 1 removed, 2 changed (in node_roles, cluster_operators)", summary)
+
+	changes, notCompared = diffBaselines(baseline, baseline)
+	assert.Empty(t, changes)
+	assert.Empty(t, notCompared)
+	_, _, _, summary = driftSummary("pre-upgrade", changes)
+	assert.Equal(t, "No drift from baseline pre-upgrade", summary)
+}
+
+func TestParseBaselineArguments(t *testing.T) {
+	name, err := parseBaselineName("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBaselineName, name)
+
+	for _, invalid := range []string{"Pre-Upgrade", "../etc", "-x", "a.b"} {
+		_, err := parseBaselineName(invalid)
+		assert.True(t, IsInvalidArguments(err), invalid)
+	}
+
+	facts, err := parseBaselineFacts(nil)
+	require.NoError(t, err)
+	assert.Equal(t, baselineFactNames(), facts)
+	_, err = parseBaselineFacts([]string{"nodes"})
+	assert.True(t, IsInvalidArguments(err))
+}
+
+func TestBaselineTools_CaptureAndCheckDrift(t *testing.T) {
+	ctx := context.Background()
+	client := newBaselineTestClient([]runtime.Object{
+		newBaselineNode("master-0", "master"),
+		newBaselineNode("worker-0", "worker"),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		newValidatingWebhook("gatekeeper", admissionregistrationv1.Fail, "validation.gatekeeper.sh"),
+	}, true,
+		newVersionedOperator("etcd", "4.15.12"),
+		newRenderedPool("worker", "rendered-worker-abc"),
+		newCSV("openshift-operators", "gitops.v1.12.0", "Succeeded", nil),
+	)
+	store := memoryBaselines{}
+
+	capture := NewCaptureBaselineTool(client, store, true)
+	result, err := capture.Execute(ctx, map[string]interface{}{"name": "pre-upgrade"})
+	require.NoError(t, err)
+	captured := result.(CaptureBaselineOutput)
+	assert.False(t, captured.Replaced)
+	assert.Equal(t, []string{"pre-upgrade"}, captured.Baselines)
+	assert.Equal(t, map[string]int{
+		BaselineFactNodeRoles: 2, BaselineFactClusterOperators: 1, BaselineFactOperators: 1,
+		BaselineFactMachineConfigPools: 1, BaselineFactNamespaces: 1, BaselineFactWebhooks: 1,
+	}, captured.Facts)
+	assert.Empty(t, captured.Skipped)
+
+	check := NewCheckDriftTool(client, store, true)
+	result, err = check.Execute(ctx, map[string]interface{}{"name": "pre-upgrade"})
+	require.NoError(t, err)
+	assert.False(t, result.(CheckDriftOutput).Drifted)
+	assert.Len(t, result.(CheckDriftOutput).Compared, 6)
+
+	// Change the cluster's shape: a new worker and a webhook turned fail-open
+	_, err = client.Clientset().CoreV1().Nodes().Create(ctx, newBaselineNode("worker-1", "worker"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.Clientset().AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(ctx,
+		newValidatingWebhook("gatekeeper", admissionregistrationv1.Ignore, "validation.gatekeeper.sh"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	result, err = check.Execute(ctx, map[string]interface{}{"name": "pre-upgrade"})
+	require.NoError(t, err)
+	drift := result.(CheckDriftOutput)
+	assert.True(t, drift.Drifted)
+	assert.Equal(t, []DriftChange{
+		{Fact: BaselineFactNodeRoles, Key: "worker", Kind: DriftChanged, Baseline: "1", Current: "2"},
+		{Fact: BaselineFactWebhooks, Key: "validating/gatekeeper", Kind: DriftChanged,
+			Baseline: "validation.gatekeeper.sh=Fail", Current: "validation.gatekeeper.sh=Ignore"},
+	}, drift.Changes)
+	assert.Equal(t, 2, drift.Changed)
+
+	_, err = check.Execute(ctx, map[string]interface{}{"name": "missing"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCaptureBaselineTool_SkipsMissingAPIs(t *testing.T) {
+	client := newBaselineTestClient([]runtime.Object{newBaselineNode("worker-0", "worker")}, false)
+	store := memoryBaselines{}
+
+	result, err := NewCaptureBaselineTool(client, store, false).Execute(context.Background(), map[string]interface{}{
+		"facts": []string{BaselineFactNodeRoles, BaselineFactClusterOperators, BaselineFactOperators},
+	})
+	require.NoError(t, err)
+	captured := result.(CaptureBaselineOutput)
+	assert.Equal(t, DefaultBaselineName, captured.Name)
+	assert.Equal(t, map[string]int{BaselineFactNodeRoles: 1}, captured.Facts)
+	assert.Equal(t, []SkippedBaselineFact{
+		{Fact: BaselineFactClusterOperators, Reason: "not an OpenShift cluster"},
+		{Fact: BaselineFactOperators, Reason: "OLM is not installed (no ClusterServiceVersion API)"},
+	}, captured.Skipped)
+	assert.Equal(t, "Captured baseline default with 1 of 3 facts; 2 skipped", captured.Summary)
+
+	// Only the recorded fact is compared later
+	_, ok := store[DefaultBaselineName].Facts[BaselineFactOperators]
+	assert.False(t, ok)
+}
@@ -27,6 +27,12 @@ func (t *CalculatePodCapacityTool) Name() string {
 	return "calculate-pod-capacity"
 }
 
+// NamespaceScoped reports that a namespace's capacity only reads that
+// namespace; callers scoped to namespaces cannot ask for the cluster
+func (t *CalculatePodCapacityTool) NamespaceScoped() bool {
+	return true
+}
+
 // Description returns the tool description
 func (t *CalculatePodCapacityTool) Description() string {
 	return `Calculate remaining pod capacity based on current resource usage and cluster/namespace limits.
@@ -189,6 +195,15 @@ type TrendingOutput struct {
 	ProjectedDate            string  `json:"projected_date,omitempty"`
 }
 
+// RequiredPermissions declares the Kubernetes API access calculate-pod-capacity needs
+func (t *CalculatePodCapacityTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "pods", Verb: "list"},
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "resourcequotas", Verb: "list"},
+	}
+}
+
 // Execute runs the calculate-pod-capacity tool
 func (t *CalculatePodCapacityTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	// Verify k8sClient is available
@@ -199,16 +214,27 @@ func (t *CalculatePodCapacityTool) Execute(ctx context.Context, args map[string]
 	// Parse input arguments
 	input, err := t.parseInput(args)
 	if err != nil {
-		return nil, fmt.Errorf("invalid input: %w", err)
+		return nil, invalidArgs("invalid input: %w", err)
 	}
 
-	// Apply default namespace if not specified
+	// Apply default namespace if not specified. Callers scoped to namespaces
+	// default to their namespace rather than the cluster.
+	scope, scoped := clients.NamespaceScopeFromContext(ctx)
 	if input.Namespace == "" {
 		input.Namespace = "cluster"
+		if scoped {
+			if len(scope) != 1 {
+				return nil, invalidArgs("namespace is required, one of: %s", strings.Join(scope, ", "))
+			}
+			input.Namespace = scope[0]
+		}
 	}
 
 	// Handle cluster-wide capacity
 	if strings.ToLower(input.Namespace) == "cluster" {
+		if scoped {
+			return nil, invalidArgs("cluster-wide capacity is not available to namespace-scoped callers")
+		}
 		return t.calculateClusterCapacity(ctx, input)
 	}
 
@@ -353,7 +379,7 @@ func (t *CalculatePodCapacityTool) getNamespaceCapacity(ctx context.Context, nam
 	// Get resource quota
 	quota, err := t.k8sClient.GetResourceQuota(ctx, namespace)
 	if err != nil {
-		return nil, err
+		return nil, apiError(err)
 	}
 
 	// Get current pod count
@@ -446,7 +472,7 @@ func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context,
 	// Get all nodes to calculate cluster capacity
 	nodes, err := t.k8sClient.ListNodes(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list nodes: %w", err)
+		return nil, apiError(fmt.Errorf("failed to list nodes: %w", err))
 	}
 
 	var totalCPU, totalMemory int64
@@ -485,7 +511,7 @@ func (t *CalculatePodCapacityTool) calculateClusterCapacity(ctx context.Context,
 	// Get all pods to calculate used resources
 	pods, err := t.k8sClient.ListPods(ctx, "") // All namespaces
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		return nil, apiError(fmt.Errorf("failed to list pods: %w", err))
 	}
 
 	var usedCPU, usedMemory int64
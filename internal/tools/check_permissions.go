@@ -0,0 +1,328 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// PermissionRule is one Kubernetes API permission a tool needs.
+// An empty Namespace means the permission is needed cluster-wide.
+type PermissionRule struct {
+	Group       string `json:"group"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	Verb        string `json:"verb"`
+	Namespace   string `json:"namespace,omitempty"`
+}
+
+// String renders the rule like "list pods" or "get apps/deployments in shop"
+func (r PermissionRule) String() string {
+	resource := r.Resource
+	if r.Subresource != "" {
+		resource += "/" + r.Subresource
+	}
+	if r.Group != "" {
+		resource = r.Group + "/" + resource
+	}
+	if r.Namespace != "" {
+		return fmt.Sprintf("%s %s in %s", r.Verb, resource, r.Namespace)
+	}
+	return fmt.Sprintf("%s %s (cluster-wide)", r.Verb, resource)
+}
+
+// PermissionRequirer is implemented by tools that call the Kubernetes API.
+// The declared rules are checked by check-permissions and at startup.
+type PermissionRequirer interface {
+	RequiredPermissions() []PermissionRule
+}
+
+// Tool usability levels reported by check-permissions
+const (
+	PermissionStatusUsable  = "usable"
+	PermissionStatusPartial = "partial"
+	PermissionStatusBlocked = "blocked"
+)
+
+// ToolPermissionStatus reports how usable one tool is with the current RBAC
+type ToolPermissionStatus struct {
+	Tool    string           `json:"tool"`
+	Status  string           `json:"status"`
+	Missing []PermissionRule `json:"missing,omitempty"`
+}
+
+// PermissionReport is the output of check-permissions
+type PermissionReport struct {
+	CheckedAt time.Time              `json:"checked_at"`
+	Usable    int                    `json:"usable"`
+	Partial   int                    `json:"partial"`
+	Blocked   int                    `json:"blocked"`
+	Tools     []ToolPermissionStatus `json:"tools"`
+	Missing   []PermissionRule       `json:"missing,omitempty"`
+	// RoleSnippet grants every missing rule; apply it and bind it to the server's service account
+	RoleSnippet string `json:"role_snippet,omitempty"`
+	Cached      bool   `json:"cached"`
+}
+
+// CheckPermissionsTool checks the server's own RBAC against what each tool needs
+type CheckPermissionsTool struct {
+	k8sClient    clients.ClientsetProvider
+	requirements func() map[string][]PermissionRule
+
+	mu     sync.Mutex
+	cached *PermissionReport
+}
+
+// NewCheckPermissionsTool creates a new check-permissions tool.
+// requirements returns the declared rules of every registered tool by name.
+func NewCheckPermissionsTool(k8sClient clients.ClientsetProvider, requirements func() map[string][]PermissionRule) *CheckPermissionsTool {
+	return &CheckPermissionsTool{
+		k8sClient:    k8sClient,
+		requirements: requirements,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *CheckPermissionsTool) Name() string {
+	return "check-permissions"
+}
+
+// Volatility is slow because RBAC bindings rarely change
+func (t *CheckPermissionsTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
+// Description returns the tool description for MCP
+func (t *CheckPermissionsTool) Description() string {
+	return "Check whether this server's service account has the RBAC permissions each tool needs (like 'kubectl auth can-i'). Reports usable, partially usable, and blocked tools plus a ready-to-apply ClusterRole/Role snippet for the missing rules."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *CheckPermissionsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"refresh": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Re-run the access reviews instead of returning the cached result",
+				"default":     false,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// CheckPermissionsInput represents the input parameters
+type CheckPermissionsInput struct {
+	Refresh bool `json:"refresh"`
+}
+
+// Execute runs the check-permissions operation
+func (t *CheckPermissionsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input CheckPermissionsInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	return t.Check(ctx, input.Refresh)
+}
+
+// Check returns the permission report, reusing the cached one unless refresh is set
+func (t *CheckPermissionsTool) Check(ctx context.Context, refresh bool) (*PermissionReport, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.cached != nil && !refresh {
+		report := *t.cached
+		report.Cached = true
+		return &report, nil
+	}
+
+	requirements := t.requirements()
+	allowed := make(map[PermissionRule]bool)
+	for _, rule := range uniqueRules(requirements) {
+		ok, err := t.canI(ctx, rule)
+		if err != nil {
+			return nil, err
+		}
+		allowed[rule] = ok
+	}
+
+	report := evaluatePermissions(requirements, allowed)
+	report.CheckedAt = time.Now().UTC()
+	t.cached = report
+
+	result := *report
+	return &result, nil
+}
+
+// canI issues a SelfSubjectAccessReview for rule
+func (t *CheckPermissionsTool) canI(ctx context.Context, rule PermissionRule) (bool, error) {
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace:   rule.Namespace,
+				Verb:        rule.Verb,
+				Group:       rule.Group,
+				Resource:    rule.Resource,
+				Subresource: rule.Subresource,
+			},
+		},
+	}
+
+	result, err := t.k8sClient.Clientset().AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission %s: %w", rule, err)
+	}
+	return result.Status.Allowed, nil
+}
+
+// uniqueRules returns every distinct rule across tools in a stable order
+func uniqueRules(requirements map[string][]PermissionRule) []PermissionRule {
+	seen := make(map[PermissionRule]bool)
+	var rules []PermissionRule
+	for _, toolRules := range requirements {
+		for _, rule := range toolRules {
+			if !seen[rule] {
+				seen[rule] = true
+				rules = append(rules, rule)
+			}
+		}
+	}
+	sortRules(rules)
+	return rules
+}
+
+// evaluatePermissions classifies each tool from the access review results
+func evaluatePermissions(requirements map[string][]PermissionRule, allowed map[PermissionRule]bool) *PermissionReport {
+	report := &PermissionReport{Tools: make([]ToolPermissionStatus, 0, len(requirements))}
+	missingSet := make(map[PermissionRule]bool)
+
+	for tool, rules := range requirements {
+		status := ToolPermissionStatus{Tool: tool, Status: PermissionStatusUsable}
+		for _, rule := range rules {
+			if !allowed[rule] {
+				status.Missing = append(status.Missing, rule)
+				missingSet[rule] = true
+			}
+		}
+		sortRules(status.Missing)
+
+		switch {
+		case len(status.Missing) == 0:
+			report.Usable++
+		case len(status.Missing) == len(rules):
+			status.Status = PermissionStatusBlocked
+			report.Blocked++
+		default:
+			status.Status = PermissionStatusPartial
+			report.Partial++
+		}
+		report.Tools = append(report.Tools, status)
+	}
+
+	sort.Slice(report.Tools, func(i, j int) bool { return report.Tools[i].Tool < report.Tools[j].Tool })
+
+	for rule := range missingSet {
+		report.Missing = append(report.Missing, rule)
+	}
+	sortRules(report.Missing)
+	report.RoleSnippet = buildRoleSnippet(report.Missing)
+	return report
+}
+
+// buildRoleSnippet renders a ClusterRole for cluster-wide rules and a Role per
+// namespace for namespaced ones, merging verbs per API group and resource
+func buildRoleSnippet(missing []PermissionRule) string {
+	if len(missing) == 0 {
+		return ""
+	}
+
+	byNamespace := make(map[string][]PermissionRule)
+	for _, rule := range missing {
+		byNamespace[rule.Namespace] = append(byNamespace[rule.Namespace], rule)
+	}
+	namespaces := make([]string, 0, len(byNamespace))
+	for namespace := range byNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	documents := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		metadata := map[string]interface{}{"name": "cluster-health-mcp-missing"}
+		kind := "ClusterRole"
+		if namespace != "" {
+			kind = "Role"
+			metadata["namespace"] = namespace
+		}
+		doc, err := yaml.Marshal(map[string]interface{}{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       kind,
+			"metadata":   metadata,
+			"rules":      policyRules(byNamespace[namespace]),
+		})
+		if err != nil {
+			continue
+		}
+		documents = append(documents, string(doc))
+	}
+	return strings.Join(documents, "---\n")
+}
+
+// policyRules merges rules into RBAC rule entries keyed by API group and resource
+func policyRules(rules []PermissionRule) []map[string]interface{} {
+	type key struct{ group, resource string }
+	verbs := make(map[key][]string)
+	var order []key
+	for _, rule := range rules {
+		resource := rule.Resource
+		if rule.Subresource != "" {
+			resource += "/" + rule.Subresource
+		}
+		k := key{group: rule.Group, resource: resource}
+		if _, ok := verbs[k]; !ok {
+			order = append(order, k)
+		}
+		verbs[k] = append(verbs[k], rule.Verb)
+	}
+
+	entries := make([]map[string]interface{}, 0, len(order))
+	for _, k := range order {
+		sort.Strings(verbs[k])
+		entries = append(entries, map[string]interface{}{
+			"apiGroups": []string{k.group},
+			"resources": []string{k.resource},
+			"verbs":     verbs[k],
+		})
+	}
+	return entries
+}
+
+// sortRules orders rules by namespace, group, resource, subresource, then verb
+func sortRules(rules []PermissionRule) {
+	sort.Slice(rules, func(i, j int) bool {
+		a, b := rules[i], rules[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		if a.Subresource != b.Subresource {
+			return a.Subresource < b.Subresource
+		}
+		return a.Verb < b.Verb
+	})
+}
@@ -0,0 +1,153 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newAccessReviewClient answers SelfSubjectAccessReviews from the allowed set
+func newAccessReviewClient(allowed ...PermissionRule) (*clients.K8sClient, *int) {
+	allowedSet := make(map[PermissionRule]bool, len(allowed))
+	for _, rule := range allowed {
+		allowedSet[rule] = true
+	}
+
+	reviews := 0
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		rule := PermissionRule{
+			Group:       attrs.Group,
+			Resource:    attrs.Resource,
+			Subresource: attrs.Subresource,
+			Verb:        attrs.Verb,
+			Namespace:   attrs.Namespace,
+		}
+		review.Status.Allowed = allowedSet[rule]
+		return true, review, nil
+	})
+	return clients.NewK8sClientWithClientset(clientset), &reviews
+}
+
+var (
+	listPodsRule    = PermissionRule{Resource: "pods", Verb: "list"}
+	listNodesRule   = PermissionRule{Resource: "nodes", Verb: "list"}
+	getDeployRule   = PermissionRule{Group: "apps", Resource: "deployments", Verb: "get"}
+	listISVCRule    = PermissionRule{Group: "serving.kserve.io", Resource: "inferenceservices", Verb: "list", Namespace: "models"}
+	testPermissions = map[string][]PermissionRule{
+		"get-cluster-health": {listNodesRule, listPodsRule},
+		"list-pods":          {listPodsRule},
+		"get-rollout-status": {getDeployRule},
+		"list-models":        {listISVCRule},
+	}
+)
+
+func TestCheckPermissionsTool_Classification(t *testing.T) {
+	client, _ := newAccessReviewClient(listPodsRule)
+	tool := NewCheckPermissionsTool(client, func() map[string][]PermissionRule { return testPermissions })
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	report := result.(*PermissionReport)
+
+	assert.Equal(t, 1, report.Usable)
+	assert.Equal(t, 1, report.Partial)
+	assert.Equal(t, 2, report.Blocked)
+	assert.False(t, report.Cached)
+
+	statuses := make(map[string]ToolPermissionStatus)
+	for _, status := range report.Tools {
+		statuses[status.Tool] = status
+	}
+	assert.Equal(t, PermissionStatusUsable, statuses["list-pods"].Status)
+	assert.Equal(t, PermissionStatusPartial, statuses["get-cluster-health"].Status)
+	assert.Equal(t, []PermissionRule{listNodesRule}, statuses["get-cluster-health"].Missing)
+	assert.Equal(t, PermissionStatusBlocked, statuses["get-rollout-status"].Status)
+	assert.Equal(t, PermissionStatusBlocked, statuses["list-models"].Status)
+
+	assert.ElementsMatch(t, []PermissionRule{listNodesRule, getDeployRule, listISVCRule}, report.Missing)
+}
+
+func TestCheckPermissionsTool_RoleSnippet(t *testing.T) {
+	client, _ := newAccessReviewClient()
+	tool := NewCheckPermissionsTool(client, func() map[string][]PermissionRule {
+		return map[string][]PermissionRule{
+			"rollback-deployment": {getDeployRule, {Group: "apps", Resource: "deployments", Verb: "update"}},
+			"list-models":         {listISVCRule},
+		}
+	})
+
+	report, err := tool.Check(context.Background(), false)
+	require.NoError(t, err)
+	require.NotEmpty(t, report.RoleSnippet)
+
+	documents := splitYAMLDocuments(report.RoleSnippet)
+	require.Len(t, documents, 2)
+
+	var clusterRole, role map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(documents[0]), &clusterRole))
+	require.NoError(t, yaml.Unmarshal([]byte(documents[1]), &role))
+
+	assert.Equal(t, "ClusterRole", clusterRole["kind"])
+	rules := clusterRole["rules"].([]interface{})
+	require.Len(t, rules, 1)
+	rule := rules[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{"apps"}, rule["apiGroups"])
+	assert.Equal(t, []interface{}{"deployments"}, rule["resources"])
+	assert.Equal(t, []interface{}{"get", "update"}, rule["verbs"])
+
+	assert.Equal(t, "Role", role["kind"])
+	assert.Equal(t, "models", role["metadata"].(map[string]interface{})["namespace"])
+}
+
+func TestCheckPermissionsTool_CachesUntilRefresh(t *testing.T) {
+	client, reviews := newAccessReviewClient(listPodsRule, listNodesRule, getDeployRule, listISVCRule)
+	tool := NewCheckPermissionsTool(client, func() map[string][]PermissionRule { return testPermissions })
+
+	report, err := tool.Check(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Usable)
+	assert.Empty(t, report.RoleSnippet)
+	// Shared rules are reviewed once
+	assert.Equal(t, 4, *reviews)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	assert.True(t, result.(*PermissionReport).Cached)
+	assert.Equal(t, 4, *reviews)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"refresh": true})
+	require.NoError(t, err)
+	assert.False(t, result.(*PermissionReport).Cached)
+	assert.Equal(t, 8, *reviews)
+}
+
+func TestPermissionRule_String(t *testing.T) {
+	assert.Equal(t, "list pods (cluster-wide)", listPodsRule.String())
+	assert.Equal(t, "list serving.kserve.io/inferenceservices in models", listISVCRule.String())
+	assert.Equal(t, "get pods/log (cluster-wide)", PermissionRule{Resource: "pods", Subresource: "log", Verb: "get"}.String())
+}
+
+// splitYAMLDocuments splits a multi-document YAML string
+func splitYAMLDocuments(content string) []string {
+	var documents []string
+	for _, doc := range strings.Split(content, "---\n") {
+		if doc != "" {
+			documents = append(documents, doc)
+		}
+	}
+	return documents
+}
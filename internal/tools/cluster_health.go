@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
@@ -11,15 +14,18 @@ import (
 
 // ClusterHealthTool provides cluster health information via MCP
 type ClusterHealthTool struct {
-	k8sClient *clients.K8sClient
+	k8sClient clients.ClusterHealthReader
 	cache     *cache.MemoryCache
+	lastKnown clients.LastKnownHealthLookup // nil when the health history is disabled
 }
 
-// NewClusterHealthTool creates a new cluster health tool
-func NewClusterHealthTool(k8sClient *clients.K8sClient, memoryCache *cache.MemoryCache) *ClusterHealthTool {
+// NewClusterHealthTool creates a new cluster health tool. While the API
+// server cannot be reached it answers with lastKnown's health, if any.
+func NewClusterHealthTool(k8sClient clients.ClusterHealthReader, memoryCache *cache.MemoryCache, lastKnown clients.LastKnownHealthLookup) *ClusterHealthTool {
 	return &ClusterHealthTool{
 		k8sClient: k8sClient,
 		cache:     memoryCache,
+		lastKnown: lastKnown,
 	}
 }
 
@@ -43,6 +49,11 @@ func (t *ClusterHealthTool) InputSchema() map[string]interface{} {
 				"description": "Include detailed breakdown of pods and nodes",
 				"default":     true,
 			},
+			"max_age_seconds": map[string]interface{}{
+				"type":        "integer",
+				"description": "Recompute if the cached health is older than this many seconds (default: serve anything within the cache TTL)",
+				"minimum":     0,
+			},
 		},
 		"required": []string{},
 	}
@@ -51,15 +62,37 @@ func (t *ClusterHealthTool) InputSchema() map[string]interface{} {
 // ClusterHealthInput represents the input parameters
 type ClusterHealthInput struct {
 	IncludeDetails bool `json:"include_details"`
+	MaxAgeSeconds  int  `json:"max_age_seconds"`
 }
 
 // ClusterHealthOutput represents the tool output
 type ClusterHealthOutput struct {
-	Status  string                 `json:"status"`
-	Nodes   *clients.NodeHealth    `json:"nodes,omitempty"`
-	Pods    *clients.PodHealth     `json:"pods,omitempty"`
-	Message string                 `json:"message,omitempty"`
-	Details map[string]interface{} `json:"details,omitempty"`
+	Status    string                  `json:"status"`
+	Nodes     *clients.NodeHealth     `json:"nodes,omitempty"`
+	Pods      *clients.PodHealth      `json:"pods,omitempty"`
+	Operators *clients.OperatorHealth `json:"operators,omitempty"`
+	Message   string                  `json:"message,omitempty"`
+	Details   map[string]interface{}  `json:"details,omitempty"`
+	// Reasons are the health rules behind a degraded or unhealthy status
+	Reasons []clients.HealthReason `json:"reasons,omitempty"`
+	// Partial is set when some sections could not be read; they carry an error instead of counts
+	Partial bool `json:"partial,omitempty"`
+	// DataAgeSeconds is how long ago the health was read from the cluster (0 when just read)
+	DataAgeSeconds float64 `json:"data_age_seconds"`
+	// LastKnown is the last complete reading, set with status "unknown" while
+	// the API server cannot be reached; Error says why
+	LastKnown           *clients.LastKnownHealth `json:"last_known,omitempty"`
+	Error               string                   `json:"error,omitempty"`
+	ConsecutiveFailures int                      `json:"consecutive_failures,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access get-cluster-health needs
+func (t *ClusterHealthTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Group: "config.openshift.io", Resource: "clusteroperators", Verb: "list"},
+	}
 }
 
 // Execute runs the cluster health check
@@ -74,33 +107,51 @@ func (t *ClusterHealthTool) Execute(ctx context.Context, args map[string]interfa
 	}
 
 	// Cache key based on detail level
-	cacheKey := "cluster-health"
+	cacheKey := cache.Key("tool", t.Name(), "details")
 	if !input.IncludeDetails {
-		cacheKey = "cluster-health-summary"
+		cacheKey = cache.Key("tool", t.Name(), "summary")
+	}
+
+	if input.MaxAgeSeconds < 0 {
+		return nil, invalidArgs("max_age_seconds must not be negative")
 	}
+	maxAge := time.Duration(input.MaxAgeSeconds) * time.Second
 
-	// Try to get from cache using GetOrSet pattern
-	healthInterface, err := t.cache.GetOrSet(ctx, cacheKey, func() (interface{}, error) {
+	// Try to get from cache, unless older than the caller accepts; a value
+	// cached in another shape is recomputed
+	health, age, err := cache.GetOrSetTypedWithMaxAge(ctx, t.cache, cacheKey, t.cache.DefaultTTL(), maxAge, func() (*clients.ClusterHealth, error) {
 		return t.k8sClient.GetClusterHealth(ctx)
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cluster health: %w", err)
+		if output, ok := t.lastKnownOutput(err); ok {
+			return output, nil
+		}
+		return nil, apiError(fmt.Errorf("failed to get cluster health: %w", err))
 	}
-
-	// Type assertion
-	health, ok := healthInterface.(*clients.ClusterHealth)
-	if !ok {
-		return nil, fmt.Errorf("unexpected cache value type")
+	if health.Partial {
+		// Retry the missing sections on the next call rather than serving them from cache
+		t.cache.Delete(cacheKey)
 	}
 
 	// Build output
 	output := ClusterHealthOutput{
-		Status: health.Status,
+		Status:         health.Status,
+		DataAgeSeconds: dataAgeSeconds(age),
+		Partial:        health.Partial,
+		Reasons:        health.Reasons,
 	}
 
-	if input.IncludeDetails {
+	if health.Partial {
+		output.Message = fmt.Sprintf("Cluster health is unknown, could not read %s", strings.Join(health.UnreadSections(), "; "))
+		if input.IncludeDetails {
+			output.Nodes = &health.Nodes
+			output.Pods = &health.Pods
+			output.Operators = health.Operators
+		}
+	} else if input.IncludeDetails {
 		output.Nodes = &health.Nodes
 		output.Pods = &health.Pods
+		output.Operators = health.Operators
 
 		// Add descriptive message
 		output.Message = fmt.Sprintf(
@@ -119,9 +170,47 @@ func (t *ClusterHealthTool) Execute(ctx context.Context, args map[string]interfa
 			"has_failed_pods":       health.Pods.Failed > 0,
 			"has_pending_pods":      health.Pods.Pending > 0,
 		}
+		if health.Operators != nil {
+			output.Details["has_degraded_operators"] = health.Operators.Degraded > 0 || health.Operators.Unavailable > 0
+		}
 	} else {
 		output.Message = fmt.Sprintf("Cluster status: %s", health.Status)
 	}
 
 	return output, nil
 }
+
+// lastKnownOutput answers with the last known health when err means the API
+// server could not be reached, and reports false when it cannot
+func (t *ClusterHealthTool) lastKnownOutput(err error) (ClusterHealthOutput, bool) {
+	if t.lastKnown == nil || !clients.IsConnectivityError(err) {
+		return ClusterHealthOutput{}, false
+	}
+	lastKnown, ok := t.lastKnown()
+	if !ok {
+		return ClusterHealthOutput{}, false
+	}
+
+	now := time.Now()
+	reachability := t.k8sClient.APIReachability()
+	outage := "API server unreachable"
+	if reachability.Unreachable() {
+		outage = reachability.Describe(now)
+	}
+	age := now.Sub(lastKnown.Timestamp)
+	return ClusterHealthOutput{
+		Status: "unknown",
+		Message: fmt.Sprintf("%s; last known health from %s ago: %s, %d/%d nodes ready, %d/%d pods running",
+			outage, age.Truncate(time.Second), lastKnown.Status,
+			lastKnown.NodesReady, lastKnown.NodesTotal, lastKnown.PodsRunning, lastKnown.PodsTotal),
+		DataAgeSeconds:      dataAgeSeconds(age),
+		LastKnown:           &lastKnown,
+		Error:               err.Error(),
+		ConsecutiveFailures: reachability.ConsecutiveFailures,
+	}, true
+}
+
+// dataAgeSeconds reports a cached value's age in seconds, to the millisecond
+func dataAgeSeconds(age time.Duration) float64 {
+	return math.Round(age.Seconds()*1000) / 1000
+}
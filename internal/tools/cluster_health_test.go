@@ -2,28 +2,50 @@ package tools
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	testclock "k8s.io/utils/clock/testing"
 
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
-	"time"
 )
 
-func TestClusterHealthTool_Name(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
+// healthyCluster returns a fake client serving a ready node and a running pod
+func healthyCluster() *testutil.FakeK8sClient {
+	return testutil.NewFakeK8sClient(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-0"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+}
 
+func TestClusterHealthTool_Name(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	tool := NewClusterHealthTool(client, memCache)
+	tool := NewClusterHealthTool(testutil.NewFakeK8sClient(), memCache, nil)
 
 	if tool.Name() != "get-cluster-health" {
 		t.Errorf("Expected name 'get-cluster-health', got '%s'", tool.Name())
@@ -31,20 +53,10 @@ func TestClusterHealthTool_Name(t *testing.T) {
 }
 
 func TestClusterHealthTool_Description(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
-
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	tool := NewClusterHealthTool(client, memCache)
+	tool := NewClusterHealthTool(testutil.NewFakeK8sClient(), memCache, nil)
 
 	desc := tool.Description()
 	if desc == "" {
@@ -56,20 +68,10 @@ func TestClusterHealthTool_Description(t *testing.T) {
 }
 
 func TestClusterHealthTool_InputSchema(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
-
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	tool := NewClusterHealthTool(client, memCache)
+	tool := NewClusterHealthTool(testutil.NewFakeK8sClient(), memCache, nil)
 
 	schema := tool.InputSchema()
 	if schema == nil {
@@ -98,70 +100,46 @@ func TestClusterHealthTool_InputSchema(t *testing.T) {
 }
 
 func TestClusterHealthTool_Execute(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
-
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	tool := NewClusterHealthTool(client, memCache)
-	ctx := context.Background()
+	tool := NewClusterHealthTool(healthyCluster(), memCache, nil)
 
 	// Test with default args (include_details: true)
-	result, err := tool.Execute(ctx, map[string]interface{}{})
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
 	if err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
 	output, ok := result.(ClusterHealthOutput)
 	if !ok {
-		t.Fatal("Expected ClusterHealthOutput type")
+		t.Fatalf("Expected ClusterHealthOutput type, got %T", result)
 	}
-
-	// Validate output structure
-	if output.Status == "" {
-		t.Error("Expected status to be set")
+	if output.Status != "healthy" {
+		t.Errorf("Expected status healthy, got %q", output.Status)
 	}
-
-	if output.Nodes == nil {
-		t.Error("Expected nodes to be present with default args")
+	if output.Nodes == nil || output.Nodes.Total != 1 || output.Nodes.Ready != 1 {
+		t.Errorf("Expected 1 ready node with default args, got %+v", output.Nodes)
 	}
-
-	if output.Pods == nil {
-		t.Error("Expected pods to be present with default args")
+	if output.Pods == nil || output.Pods.Total != 1 || output.Pods.Running != 1 {
+		t.Errorf("Expected 1 running pod with default args, got %+v", output.Pods)
 	}
-
-	if output.Message == "" {
-		t.Error("Expected message to be set")
+	if want := "Cluster is healthy: 1/1 nodes ready, 1/1 pods running"; output.Message != want {
+		t.Errorf("Expected message %q, got %q", want, output.Message)
+	}
+	if output.Details["node_ready_percentage"] != float64(100) || output.Details["has_failed_pods"] != false {
+		t.Errorf("Unexpected details %v", output.Details)
 	}
 }
 
 func TestClusterHealthTool_Execute_WithoutDetails(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
-
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	tool := NewClusterHealthTool(client, memCache)
-	ctx := context.Background()
+	tool := NewClusterHealthTool(healthyCluster(), memCache, nil)
 
 	// Test with include_details: false
-	result, err := tool.Execute(ctx, map[string]interface{}{
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
 		"include_details": false,
 	})
 	if err != nil {
@@ -170,68 +148,297 @@ func TestClusterHealthTool_Execute_WithoutDetails(t *testing.T) {
 
 	output, ok := result.(ClusterHealthOutput)
 	if !ok {
-		t.Fatal("Expected ClusterHealthOutput type")
+		t.Fatalf("Expected ClusterHealthOutput type, got %T", result)
 	}
-
-	// Validate output structure
-	if output.Status == "" {
-		t.Error("Expected status to be set")
+	if output.Message != "Cluster status: healthy" {
+		t.Errorf("Expected the status alone, got %q", output.Message)
 	}
 
 	// Details should not be present
-	if output.Nodes != nil {
-		t.Error("Expected nodes to be nil when include_details is false")
+	if output.Nodes != nil || output.Pods != nil || output.Details != nil {
+		t.Errorf("Expected no breakdown when include_details is false, got %+v", output)
 	}
+}
+
+func TestClusterHealthTool_CacheUsage(t *testing.T) {
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	defer memCache.Close()
 
-	if output.Pods != nil {
-		t.Error("Expected pods to be nil when include_details is false")
+	client := healthyCluster()
+	tool := NewClusterHealthTool(client, memCache, nil)
+	execute := func(args map[string]interface{}) {
+		t.Helper()
+		if _, err := tool.Execute(context.Background(), args); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+	}
+
+	// Repeated calls are served from the cache
+	execute(map[string]interface{}{"include_details": true})
+	execute(map[string]interface{}{"include_details": true})
+	if calls := client.HealthCalls(); calls != 1 {
+		t.Errorf("Expected one health read for two calls, got %d", calls)
+	}
+	if stats := memCache.GetStatistics(); stats.Hits != 1 {
+		t.Errorf("Expected the second call to hit the cache, got %+v", stats)
+	}
+
+	// The summary is cached apart from the detailed result
+	execute(map[string]interface{}{"include_details": false})
+	execute(map[string]interface{}{"include_details": false})
+	if calls := client.HealthCalls(); calls != 2 {
+		t.Errorf("Expected one more health read for the summary, got %d reads", calls)
+	}
+
+	// Failures are not cached
+	failing := testutil.NewFakeK8sClient()
+	failing.SetClusterHealth(nil, apierrors.NewServiceUnavailable("apiserver is shutting down"))
+	tool = NewClusterHealthTool(failing, memCache, nil)
+	memCache.Clear()
+	for i := 0; i < 2; i++ {
+		if _, err := tool.Execute(context.Background(), nil); err == nil {
+			t.Fatal("Expected an error while the API is unavailable")
+		}
+	}
+	if calls := failing.HealthCalls(); calls != 2 {
+		t.Errorf("Expected every call to retry after a failure, got %d reads", calls)
 	}
 }
 
-func TestClusterHealthTool_CacheUsage(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
+func TestClusterHealthTool_ArgumentHandling(t *testing.T) {
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	defer memCache.Close()
+
+	client := healthyCluster()
+	tool := NewClusterHealthTool(client, memCache, nil)
+
+	// Mistyped arguments fall back to the defaults, with details
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"include_details": "no"})
 	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if output := result.(ClusterHealthOutput); output.Nodes == nil {
+		t.Error("Expected details for a mistyped include_details")
+	}
+
+	// Nil arguments are the defaults too
+	if _, err := tool.Execute(context.Background(), nil); err != nil {
+		t.Fatalf("Execute with nil arguments failed: %v", err)
 	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
 
+	// Invalid arguments are rejected before the cluster is read
+	memCache.Clear()
+	calls := client.HealthCalls()
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"max_age_seconds": -5})
+	if !IsInvalidArguments(err) || ErrorClass(err) != ErrInvalidArgs {
+		t.Errorf("Expected invalid arguments, got %v", err)
+	}
+	if client.HealthCalls() != calls {
+		t.Error("Expected no health read for invalid arguments")
+	}
+}
+
+func TestClusterHealthTool_ErrorClassification(t *testing.T) {
+	unreachable := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"forbidden", apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "", errors.New("no access")), ErrForbidden},
+		{"unauthorized", apierrors.NewUnauthorized("token expired"), ErrForbidden},
+		{"deadline", fmt.Errorf("failed to list nodes: %w", context.DeadlineExceeded), ErrTimeout},
+		{"server timeout", apierrors.NewTimeoutError("list took too long", 1), ErrTimeout},
+		{"unavailable", apierrors.NewServiceUnavailable("apiserver is shutting down"), ErrUpstreamUnavailable},
+		{"unreachable", fmt.Errorf("failed to list nodes: %w", unreachable), ErrUpstreamUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memCache := cache.NewMemoryCache(30 * time.Second)
+			defer memCache.Close()
+
+			client := testutil.NewFakeK8sClient()
+			client.SetClusterHealth(nil, tt.err)
+			tool := NewClusterHealthTool(client, memCache, nil)
+
+			_, err := tool.Execute(context.Background(), nil)
+			if class := ErrorClass(err); class != tt.want {
+				t.Errorf("Expected class %v, got %v (%v)", tt.want, class, err)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected the client error to be wrapped, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClusterHealthTool_LastKnownWhileUnreachable(t *testing.T) {
 	memCache := cache.NewMemoryCache(30 * time.Second)
 	defer memCache.Close()
 
-	tool := NewClusterHealthTool(client, memCache)
-	ctx := context.Background()
+	since := time.Now().Add(-3 * time.Minute)
+	client := testutil.NewFakeK8sClient()
+	client.SetClusterHealth(nil, fmt.Errorf("failed to list nodes: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}))
+	client.SetAPIReachability(clients.APIReachability{ConsecutiveFailures: 4, UnreachableSince: since, LastError: "connection refused"})
 
-	// First call - should populate cache
-	_, err = tool.Execute(ctx, map[string]interface{}{
-		"include_details": true,
-	})
+	recorded := clients.LastKnownHealth{
+		Timestamp: since.Add(-time.Minute), Status: "healthy",
+		NodesTotal: 3, NodesReady: 3, PodsTotal: 40, PodsRunning: 40,
+	}
+	lookups := 0
+	lastKnown := func() (clients.LastKnownHealth, bool) {
+		lookups++
+		return recorded, true
+	}
+	tool := NewClusterHealthTool(client, memCache, lastKnown)
+
+	result, err := tool.Execute(context.Background(), nil)
 	if err != nil {
-		t.Fatalf("First execute failed: %v", err)
+		t.Fatalf("Expected the last known health rather than an error, got %v", err)
+	}
+	output := result.(ClusterHealthOutput)
+	if output.Status != "unknown" || output.LastKnown == nil || !reflect.DeepEqual(*output.LastKnown, recorded) {
+		t.Errorf("Expected status unknown with the last known health, got %+v", output)
+	}
+	if output.ConsecutiveFailures != 4 || output.Error == "" {
+		t.Errorf("Expected the outage to be described, got %+v", output)
+	}
+	if !strings.Contains(output.Message, "3/3 nodes ready") {
+		t.Errorf("Expected the last known counts in the message, got %q", output.Message)
+	}
+
+	// Errors the API server answered with are not an outage
+	client.SetClusterHealth(nil, apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "", errors.New("no access")))
+	if _, err := tool.Execute(context.Background(), nil); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Expected a forbidden error, got %v", err)
+	}
+	if lookups != 1 {
+		t.Errorf("Expected the history to be consulted only while unreachable, got %d lookups", lookups)
+	}
+}
+
+func TestClusterHealthTool_RecomputesWrongTypeCached(t *testing.T) {
+	client := clients.NewK8sClientWithClientset(fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		}},
+	}))
+
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	defer memCache.Close()
+
+	tool := NewClusterHealthTool(client, memCache, nil)
+
+	// A value left behind by an older version that cached a different shape
+	key := cache.Key("tool", tool.Name(), "details")
+	memCache.Set(key, map[string]interface{}{"status": "healthy"})
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if output := result.(ClusterHealthOutput); output.Nodes == nil || output.Nodes.Total != 1 {
+		t.Errorf("Expected health recomputed from the cluster, got %+v", output)
+	}
+
+	// The stale value was replaced, so the next call is a hit
+	if _, found := cache.GetTyped[*clients.ClusterHealth](memCache, key); !found {
+		t.Error("Expected the recomputed health to be cached")
 	}
+}
+
+func TestClusterHealthTool_MaxAge(t *testing.T) {
+	client := clients.NewK8sClientWithClientset(fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+	}))
+
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	memCache := cache.NewMemoryCacheWithClock(30*time.Second, clk)
+	defer memCache.Close()
 
-	// Check cache stats
-	stats := memCache.GetStatistics()
-	if stats.Entries == 0 {
-		t.Error("Expected cache to have entries after first call")
+	tool := NewClusterHealthTool(client, memCache, nil)
+	execute := func(args map[string]interface{}) ClusterHealthOutput {
+		t.Helper()
+		result, err := tool.Execute(context.Background(), args)
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		return result.(ClusterHealthOutput)
+	}
+
+	if output := execute(map[string]interface{}{}); output.DataAgeSeconds != 0 {
+		t.Errorf("Expected data_age_seconds 0 for a fresh read, got %v", output.DataAgeSeconds)
 	}
 
-	initialMisses := stats.Misses
+	clk.Step(1100 * time.Millisecond)
+
+	// Fresher than requested: served from cache with its age
+	output := execute(map[string]interface{}{"max_age_seconds": 10})
+	if output.DataAgeSeconds != 1.1 {
+		t.Errorf("Expected data_age_seconds 1.1, got %v", output.DataAgeSeconds)
+	}
+
+	// Older than requested: recomputed although the cache TTL has not expired
+	misses := memCache.GetStatistics().Misses
+	output = execute(map[string]interface{}{"max_age_seconds": 1})
+	if output.DataAgeSeconds != 0 {
+		t.Errorf("Expected a recomputed read, got data_age_seconds %v", output.DataAgeSeconds)
+	}
+	if got := memCache.GetStatistics().Misses; got != misses+1 {
+		t.Errorf("Expected the stale entry to count as a miss, misses %d -> %d", misses, got)
+	}
+
+	if _, err := tool.Execute(context.Background(), map[string]interface{}{"max_age_seconds": -1}); !IsInvalidArguments(err) {
+		t.Errorf("Expected invalid arguments for a negative max_age_seconds, got %v", err)
+	}
+}
 
-	// Second call - should use cache
-	_, err = tool.Execute(ctx, map[string]interface{}{
-		"include_details": true,
+func TestClusterHealthTool_PartialResult(t *testing.T) {
+	clientset := fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		}},
 	})
+	podsFail := true
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if podsFail {
+			return true, nil, errors.New("etcdserver: request timed out")
+		}
+		return false, nil, nil
+	})
+
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	defer memCache.Close()
+
+	tool := NewClusterHealthTool(clients.NewK8sClientWithClientset(clientset), memCache, nil)
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
 	if err != nil {
-		t.Fatalf("Second execute failed: %v", err)
+		t.Fatalf("Expected a partial result rather than an error, got %v", err)
+	}
+
+	output := result.(ClusterHealthOutput)
+	if output.Status != "unknown" || !output.Partial {
+		t.Errorf("Expected status unknown and partial, got %q partial=%v", output.Status, output.Partial)
+	}
+	if output.Nodes == nil || output.Nodes.Ready != 1 {
+		t.Errorf("Expected the node section to be reported, got %+v", output.Nodes)
+	}
+	if output.Pods == nil || !strings.Contains(output.Pods.Error, "request timed out") {
+		t.Errorf("Expected the pod section to carry its error, got %+v", output.Pods)
+	}
+	if !strings.Contains(output.Message, "could not read pods") {
+		t.Errorf("Expected the message to name the missing section, got %q", output.Message)
 	}
 
-	// Verify cache was used (hits should increase or misses should stay same)
-	stats = memCache.GetStatistics()
-	if stats.Misses > initialMisses+1 {
-		t.Error("Expected cache to be used on second call")
+	// Partial results are not cached: the next call reads the cluster again
+	podsFail = false
+	result, err = tool.Execute(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if output := result.(ClusterHealthOutput); output.Status != "healthy" || output.Partial {
+		t.Errorf("Expected a complete healthy result once pods can be read, got %q partial=%v", output.Status, output.Partial)
 	}
 }
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// OperatorCondition is a status condition of an OpenShift operator resource
+type OperatorCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// operatorConditions reads status.conditions from a ClusterOperator or operator config
+func operatorConditions(obj *unstructured.Unstructured) []OperatorCondition {
+	raw, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	conditions := make([]OperatorCondition, 0, len(raw))
+	for _, item := range raw {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		conditions = append(conditions, OperatorCondition{
+			Type:    nestedString(fields, "type"),
+			Status:  nestedString(fields, "status"),
+			Reason:  nestedString(fields, "reason"),
+			Message: nestedString(fields, "message"),
+		})
+	}
+	return conditions
+}
+
+// findCondition returns the condition of the given type, or nil when it is not reported
+func findCondition(conditions []OperatorCondition, conditionType string) *OperatorCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// conditionTrue reports whether the condition of the given type has status True
+func conditionTrue(conditions []OperatorCondition, conditionType string) bool {
+	condition := findCondition(conditions, conditionType)
+	return condition != nil && condition.Status == "True"
+}
+
+// nestedString reads a string field, returning "" when it is missing or not a string
+func nestedString(fields map[string]interface{}, field string) string {
+	value, _, _ := unstructured.NestedString(fields, field)
+	return value
+}
@@ -0,0 +1,410 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// firingAlertsQuery returns every firing alert; alerts are matched to resources by label
+const firingAlertsQuery = `ALERTS{alertstate="firing"}`
+
+// Verdicts on whether an incident's problem is still visible on a resource
+const (
+	verdictStillOccurring  = "still_occurring"
+	verdictAppearsResolved = "appears_resolved"
+	verdictUnknown         = "cannot_determine"
+)
+
+// CorrelateIncidentTool pairs a Coordination Engine incident with the live state
+// of the resources it affects
+type CorrelateIncidentTool struct {
+	ceClient   *clients.CoordinationEngineClient
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient // nil when Prometheus is not configured
+}
+
+// NewCorrelateIncidentTool creates a new correlate-incident tool; prometheus may be nil
+func NewCorrelateIncidentTool(ceClient *clients.CoordinationEngineClient, k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient) *CorrelateIncidentTool {
+	return &CorrelateIncidentTool{
+		ceClient:   ceClient,
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *CorrelateIncidentTool) Name() string {
+	return "correlate-incident"
+}
+
+// Description returns the tool description for MCP
+func (t *CorrelateIncidentTool) Description() string {
+	return `Pair a Coordination Engine incident with what its affected resources look like right now: pod phase, readiness and restarts, Deployment availability, node readiness, recent Warning events, and firing alerts (when Prometheus is configured). Gives each resource a verdict of still_occurring, appears_resolved, or cannot_determine. Pods, Deployments, and nodes are inspected; other kinds are reported as cannot_determine.
+
+Use this tool for questions like:
+- "Is incident inc-42 still happening?"
+- "What is broken right now in the resources of this incident?"
+- "Can I close this incident?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *CorrelateIncidentTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"incident_id": map[string]interface{}{
+				"type":        "string",
+				"description": "ID of the incident in the Coordination Engine",
+			},
+			"event_window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "How far back Warning events count as still occurring",
+				"default":     30,
+				"minimum":     1,
+				"maximum":     1440,
+			},
+		},
+		"required": []string{"incident_id"},
+	}
+}
+
+// CorrelateIncidentInput represents the input parameters
+type CorrelateIncidentInput struct {
+	IncidentID         string `json:"incident_id"`
+	EventWindowMinutes int    `json:"event_window_minutes"`
+}
+
+// CorrelatedResource is the live state of one resource affected by an incident
+type CorrelatedResource struct {
+	Reference      string   `json:"reference"` // As recorded on the incident
+	Kind           string   `json:"kind,omitempty"`
+	Namespace      string   `json:"namespace,omitempty"`
+	Name           string   `json:"name,omitempty"`
+	State          string   `json:"state,omitempty"`
+	RecentWarnings []string `json:"recent_warnings,omitempty"`
+	FiringAlerts   []string `json:"firing_alerts,omitempty"`
+	Verdict        string   `json:"verdict"` // still_occurring, appears_resolved, or cannot_determine
+	Reason         string   `json:"reason"`
+}
+
+// CorrelateIncidentOutput represents the tool output
+type CorrelateIncidentOutput struct {
+	Status         string               `json:"status"`
+	IncidentID     string               `json:"incident_id"`
+	Title          string               `json:"title"`
+	Severity       string               `json:"severity"`
+	IncidentStatus string               `json:"incident_status"`
+	CreatedAt      string               `json:"created_at,omitempty"`
+	Resources      []CorrelatedResource `json:"resources"`
+	Summary        string               `json:"summary"`
+	Notes          []string             `json:"notes,omitempty"`
+}
+
+// resourceObservation is what was learned about one resource, the input to resourceVerdict
+type resourceObservation struct {
+	Supported bool  // The kind is one the tool inspects
+	LookupErr error // Failed to read the resource for a reason other than NotFound
+	Exists    bool
+	Healthy   bool
+	Detail    string // Why the resource is unhealthy
+	Warnings  int    // Warning events within the window
+	Alerts    int    // Firing alerts naming the resource
+}
+
+// RequiredPermissions declares the Kubernetes API access correlate-incident needs
+func (t *CorrelateIncidentTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "pods", Verb: "get"},
+		{Resource: "nodes", Verb: "get"},
+		{Resource: "events", Verb: "list"},
+		{Group: "apps", Resource: "deployments", Verb: "get"},
+	}
+}
+
+// Execute runs the correlate-incident operation
+func (t *CorrelateIncidentTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := CorrelateIncidentInput{EventWindowMinutes: 30}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.IncidentID == "" {
+		return nil, invalidArgs("incident_id is required")
+	}
+	if input.EventWindowMinutes < 1 || input.EventWindowMinutes > 1440 {
+		return nil, invalidArgs("event_window_minutes must be between 1 and 1440")
+	}
+
+	incident, err := t.ceClient.GetIncident(ctx, input.IncidentID)
+	if err != nil {
+		return nil, dependencyError(fmt.Errorf("failed to get incident: %w", err))
+	}
+
+	output := CorrelateIncidentOutput{
+		Status:         "success",
+		IncidentID:     incident.ID,
+		Title:          incident.Title,
+		Severity:       incident.Severity,
+		IncidentStatus: incident.Status,
+		CreatedAt:      incident.CreatedAt,
+		Resources:      []CorrelatedResource{},
+	}
+
+	references := incident.AffectedResources
+	if len(references) == 0 && incident.Target != "" && incident.Target != "multiple" {
+		references = []string{incident.Target}
+	}
+	if len(references) == 0 {
+		output.Summary = "The incident records no affected resources to correlate"
+		return output, nil
+	}
+
+	var alerts []clients.PromSample
+	if t.prometheus != nil {
+		if alerts, err = t.prometheus.Query(ctx, firingAlertsQuery); err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("firing alerts unavailable: %v", err))
+		}
+	} else {
+		output.Notes = append(output.Notes, "Prometheus is not configured (ENABLE_PROMETHEUS); firing alerts are not checked")
+	}
+
+	since := time.Now().Add(-time.Duration(input.EventWindowMinutes) * time.Minute)
+	events := map[string][]corev1.Event{} // By namespace, listed once
+	for _, reference := range references {
+		resource := parseResourceReference(reference, incident.Namespace)
+		observation := t.observe(ctx, &resource)
+
+		if observation.Supported && observation.LookupErr == nil {
+			namespace := resource.Namespace
+			if resource.Kind == "Node" {
+				namespace = corev1.NamespaceDefault // Node events are recorded in default
+			}
+			if _, ok := events[namespace]; !ok {
+				list, err := t.k8sClient.ListEvents(ctx, namespace)
+				if err != nil {
+					output.Notes = append(output.Notes, fmt.Sprintf("events in %s unavailable: %v", namespace, err))
+				}
+				if list != nil {
+					events[namespace] = list.Items
+				} else {
+					events[namespace] = nil
+				}
+			}
+			resource.RecentWarnings = recentWarnings(events[namespace], resource, since)
+			resource.FiringAlerts = matchingAlerts(alerts, resource)
+			observation.Warnings = len(resource.RecentWarnings)
+			observation.Alerts = len(resource.FiringAlerts)
+		}
+
+		resource.Verdict, resource.Reason = resourceVerdict(observation)
+		output.Resources = append(output.Resources, resource)
+	}
+
+	output.Summary = correlationSummary(output.Resources)
+	return output, nil
+}
+
+// observe reads the current state of a pod, Deployment, or node into resource.State
+func (t *CorrelateIncidentTool) observe(ctx context.Context, resource *CorrelatedResource) resourceObservation {
+	var observation resourceObservation
+	var err error
+	switch resource.Kind {
+	case "Pod":
+		observation.Supported = true
+		var pod *corev1.Pod
+		if pod, err = t.k8sClient.GetPod(ctx, resource.Namespace, resource.Name); err == nil {
+			observation.Healthy, observation.Detail, resource.State = podCondition(pod)
+		}
+	case "Deployment":
+		observation.Supported = true
+		var deployment *clients.DeploymentInfo
+		if deployment, err = t.k8sClient.GetDeployment(ctx, resource.Namespace, resource.Name); err == nil {
+			resource.State = fmt.Sprintf("%d/%d replicas available", deployment.AvailableReplicas, deployment.Replicas)
+			observation.Healthy = deployment.AvailableReplicas >= deployment.Replicas
+			observation.Detail = resource.State
+		}
+	case "Node":
+		observation.Supported = true
+		var node *corev1.Node
+		if node, err = t.k8sClient.GetNode(ctx, resource.Name); err == nil {
+			observation.Healthy, resource.State = nodeCondition(node)
+			observation.Detail = resource.State
+		}
+	default:
+		return observation
+	}
+
+	switch {
+	case err == nil:
+		observation.Exists = true
+	case apierrors.IsNotFound(err):
+		resource.State = "not found"
+	default:
+		observation.LookupErr = err
+	}
+	return observation
+}
+
+// resourceVerdict decides whether an incident still shows on a resource. Firing
+// alerts, recent Warning events, or an unhealthy state mean it is still occurring;
+// a healthy or deleted resource with none of those appears resolved.
+func resourceVerdict(o resourceObservation) (string, string) {
+	switch {
+	case !o.Supported:
+		return verdictUnknown, "this kind of resource is not inspected"
+	case o.LookupErr != nil:
+		return verdictUnknown, fmt.Sprintf("could not read the resource: %v", o.LookupErr)
+	case o.Alerts > 0:
+		return verdictStillOccurring, fmt.Sprintf("%d alert(s) firing", o.Alerts)
+	case o.Exists && !o.Healthy:
+		return verdictStillOccurring, o.Detail
+	case o.Warnings > 0:
+		return verdictStillOccurring, fmt.Sprintf("%d recent Warning event(s)", o.Warnings)
+	case !o.Exists:
+		return verdictAppearsResolved, "the resource no longer exists"
+	default:
+		return verdictAppearsResolved, "healthy with no recent Warning events or firing alerts"
+	}
+}
+
+// parseResourceReference interprets "namespace/name" (a pod), "kind/namespace/name",
+// or "node/name". A bare name is a pod in the incident's namespace.
+func parseResourceReference(reference, incidentNamespace string) CorrelatedResource {
+	resource := CorrelatedResource{Reference: reference}
+	parts := strings.Split(strings.TrimSpace(reference), "/")
+	switch len(parts) {
+	case 1:
+		resource.Kind, resource.Namespace, resource.Name = "Pod", incidentNamespace, parts[0]
+	case 2:
+		if kind := normalizeKind(parts[0]); kind == "Node" {
+			resource.Kind, resource.Name = kind, parts[1]
+		} else {
+			resource.Kind, resource.Namespace, resource.Name = "Pod", parts[0], parts[1]
+		}
+	case 3:
+		resource.Kind, resource.Namespace, resource.Name = normalizeKind(parts[0]), parts[1], parts[2]
+	default:
+		resource.Kind = ""
+	}
+	if resource.Kind == "Pod" && resource.Namespace == "" {
+		resource.Kind = "" // Without a namespace the pod cannot be found
+	}
+	return resource
+}
+
+// normalizeKind maps kind spellings used in references (pods, deploy, Node) to a Kind
+func normalizeKind(kind string) string {
+	switch strings.ToLower(kind) {
+	case "pod", "pods", "po":
+		return "Pod"
+	case "deployment", "deployments", "deploy":
+		return "Deployment"
+	case "node", "nodes", "no":
+		return "Node"
+	default:
+		return kind
+	}
+}
+
+// podCondition reports whether a pod is healthy, why not, and a one-line state
+func podCondition(pod *corev1.Pod) (bool, string, string) {
+	ready, restarts := 0, int32(0)
+	var waiting []string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+		restarts += status.RestartCount
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			waiting = append(waiting, status.State.Waiting.Reason)
+		}
+	}
+	state := fmt.Sprintf("%s, %d/%d containers ready, %d restarts", pod.Status.Phase, ready, len(pod.Spec.Containers), restarts)
+	if len(waiting) > 0 {
+		state += " (" + strings.Join(waiting, ", ") + ")"
+	}
+
+	switch {
+	case pod.Status.Phase == corev1.PodSucceeded:
+		return true, "", state
+	case pod.Status.Phase != corev1.PodRunning:
+		return false, fmt.Sprintf("pod is %s", pod.Status.Phase), state
+	case ready < len(pod.Spec.Containers):
+		return false, state, state
+	default:
+		return true, "", state
+	}
+}
+
+// nodeCondition reports whether a node is Ready and a one-line state
+func nodeCondition(node *corev1.Node) (bool, string) {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			if condition.Status == corev1.ConditionTrue {
+				return true, "Ready"
+			}
+			return false, fmt.Sprintf("NotReady: %s", condition.Reason)
+		}
+	}
+	return false, "Ready condition not reported"
+}
+
+// recentWarnings summarizes Warning events about resource seen since the cutoff
+func recentWarnings(events []corev1.Event, resource CorrelatedResource, since time.Time) []string {
+	var warnings []string
+	for i := range events {
+		event := &events[i]
+		if event.Type != corev1.EventTypeWarning || event.InvolvedObject.Kind != resource.Kind || event.InvolvedObject.Name != resource.Name {
+			continue
+		}
+		if eventLastSeen(event).Before(since) {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: %s (x%d)", event.Reason, event.Message, max(int(event.Count), 1)))
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+// matchingAlerts returns the names of firing alerts whose labels name resource
+func matchingAlerts(alerts []clients.PromSample, resource CorrelatedResource) []string {
+	label := strings.ToLower(resource.Kind)
+	var names []string
+	for _, alert := range alerts {
+		if alert.Labels[label] != resource.Name {
+			continue
+		}
+		if resource.Namespace != "" && alert.Labels["namespace"] != resource.Namespace {
+			continue
+		}
+		names = append(names, alert.Labels["alertname"])
+	}
+	sort.Strings(names)
+	return names
+}
+
+// correlationSummary counts resources per verdict
+func correlationSummary(resources []CorrelatedResource) string {
+	counts := map[string]int{}
+	for _, resource := range resources {
+		counts[resource.Verdict]++
+	}
+	switch {
+	case counts[verdictStillOccurring] > 0:
+		return fmt.Sprintf("Still occurring on %d of %d resource(s) (%d appear resolved, %d undetermined)",
+			counts[verdictStillOccurring], len(resources), counts[verdictAppearsResolved], counts[verdictUnknown])
+	case counts[verdictAppearsResolved] == len(resources):
+		return fmt.Sprintf("All %d resource(s) appear resolved", len(resources))
+	default:
+		return fmt.Sprintf("No resource shows the problem now (%d appear resolved, %d undetermined)", counts[verdictAppearsResolved], counts[verdictUnknown])
+	}
+}
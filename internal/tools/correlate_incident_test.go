@@ -0,0 +1,180 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestResourceVerdict(t *testing.T) {
+	tests := []struct {
+		name        string
+		observation resourceObservation
+		want        string
+	}{
+		{"unsupported kind", resourceObservation{}, verdictUnknown},
+		{"lookup failed", resourceObservation{Supported: true, LookupErr: errors.New("forbidden")}, verdictUnknown},
+		{"healthy and quiet", resourceObservation{Supported: true, Exists: true, Healthy: true}, verdictAppearsResolved},
+		{"deleted", resourceObservation{Supported: true}, verdictAppearsResolved},
+		{"unhealthy", resourceObservation{Supported: true, Exists: true, Detail: "pod is Pending"}, verdictStillOccurring},
+		{"healthy with recent warnings", resourceObservation{Supported: true, Exists: true, Healthy: true, Warnings: 2}, verdictStillOccurring},
+		{"healthy with firing alert", resourceObservation{Supported: true, Exists: true, Healthy: true, Alerts: 1}, verdictStillOccurring},
+		{"deleted with firing alert", resourceObservation{Supported: true, Alerts: 1}, verdictStillOccurring},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, reason := resourceVerdict(tt.observation)
+			assert.Equal(t, tt.want, verdict)
+			assert.NotEmpty(t, reason)
+		})
+	}
+}
+
+func TestParseResourceReference(t *testing.T) {
+	tests := []struct {
+		reference string
+		kind      string
+		namespace string
+		name      string
+	}{
+		{"payments/api-7d9", "Pod", "payments", "api-7d9"},
+		{"deployment/payments/api", "Deployment", "payments", "api"},
+		{"node/worker-1", "Node", "", "worker-1"},
+		{"api-7d9", "Pod", "checkout", "api-7d9"},
+		{"statefulset/payments/db", "statefulset", "payments", "db"},
+	}
+
+	for _, tt := range tests {
+		got := parseResourceReference(tt.reference, "checkout")
+		assert.Equal(t, tt.kind, got.Kind, tt.reference)
+		assert.Equal(t, tt.namespace, got.Namespace, tt.reference)
+		assert.Equal(t, tt.name, got.Name, tt.reference)
+	}
+}
+
+func TestCorrelateIncidentTool_Execute(t *testing.T) {
+	incident := clients.Incident{
+		ID:        "inc-42",
+		Title:     "API crash loop",
+		Severity:  "high",
+		Status:    "pending",
+		Namespace: "payments",
+		AffectedResources: []string{
+			"payments/api-crashing",
+			"payments/api-recovered",
+			"deployment/payments/api",
+			"node/worker-1",
+			"payments/api-deleted",
+			"statefulset/payments/db",
+		},
+	}
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/incidents/inc-42" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(incident)
+	}))
+	t.Cleanup(engine.Close)
+
+	ready := corev1.ContainerStatus{Name: "api", Ready: true}
+	replicas := int32(3)
+	now := time.Now()
+	objects := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-crashing", Namespace: "payments"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "api"}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "api", RestartCount: 7,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-recovered", Namespace: "payments"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "api"}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{ready}},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "payments"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{Replicas: 3, ReadyReplicas: 3, AvailableReplicas: 3},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}},
+		},
+		// An old warning on the recovered pod falls outside the window
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "api-recovered.1", Namespace: "payments"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "api-recovered", Namespace: "payments"},
+			Type:           corev1.EventTypeWarning, Reason: "BackOff", Message: "Back-off restarting failed container",
+			LastTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)), Count: 12,
+		},
+		// A recent warning keeps the Deployment still occurring despite full availability
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "api.1", Namespace: "payments"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Deployment", Name: "api", Namespace: "payments"},
+			Type:           corev1.EventTypeWarning, Reason: "ProgressDeadlineExceeded", Message: "ReplicaSet has timed out progressing",
+			LastTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)), Count: 1,
+		},
+	}
+	k8s := clients.NewK8sClientWithClientset(fake.NewClientset(objects...))
+	prometheus := newAPFPrometheus(t, map[string]string{
+		firingAlertsQuery: `[{"metric":{"alertname":"KubeNodeNotReady","node":"worker-1"},"value":[1717243200,"1"]}]`,
+	})
+
+	tool := NewCorrelateIncidentTool(testutil.NewCoordinationEngineClient(t, engine.URL), k8s, prometheus)
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"incident_id": "inc-42"})
+	require.NoError(t, err)
+
+	output := result.(CorrelateIncidentOutput)
+	assert.Equal(t, "API crash loop", output.Title)
+	require.Len(t, output.Resources, 6)
+
+	verdicts := map[string]string{}
+	for _, resource := range output.Resources {
+		verdicts[resource.Reference] = resource.Verdict
+	}
+	assert.Equal(t, map[string]string{
+		"payments/api-crashing":   verdictStillOccurring,
+		"payments/api-recovered":  verdictAppearsResolved,
+		"deployment/payments/api": verdictStillOccurring,
+		"node/worker-1":           verdictStillOccurring,
+		"payments/api-deleted":    verdictAppearsResolved,
+		"statefulset/payments/db": verdictUnknown,
+	}, verdicts)
+
+	assert.Contains(t, output.Resources[0].State, "CrashLoopBackOff")
+	assert.Equal(t, []string{"KubeNodeNotReady"}, output.Resources[3].FiringAlerts)
+	assert.Len(t, output.Resources[2].RecentWarnings, 1)
+	assert.Equal(t, "not found", output.Resources[4].State)
+	assert.Contains(t, output.Summary, "3 of 6")
+}
+
+func TestCorrelateIncidentTool_InvalidArgs(t *testing.T) {
+	tool := NewCorrelateIncidentTool(testutil.NewCoordinationEngineClient(t, "http://127.0.0.1:1"), nil, nil)
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{})
+	assert.True(t, IsInvalidArguments(err), "%v", err)
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"incident_id": "inc-1", "event_window_minutes": 0})
+	assert.True(t, IsInvalidArguments(err), "%v", err)
+}
@@ -0,0 +1,241 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// SilenceCommentTag starts the comment of every silence created through this
+// server, so silences in Alertmanager can be attributed to it
+const SilenceCommentTag = "[cluster-health-mcp]"
+
+// anonymousSilenceCreator is the creator of silences when auth is disabled
+const anonymousSilenceCreator = "cluster-health-mcp"
+
+// CreateAlertSilenceTool silences alerts in Alertmanager for a while, such as
+// during a remediation
+type CreateAlertSilenceTool struct {
+	alertmanager *clients.AlertmanagerClient
+	maxDuration  time.Duration
+}
+
+// NewCreateAlertSilenceTool creates a new create-alert-silence tool, refusing
+// silences longer than maxDuration
+func NewCreateAlertSilenceTool(alertmanager *clients.AlertmanagerClient, maxDuration time.Duration) *CreateAlertSilenceTool {
+	return &CreateAlertSilenceTool{alertmanager: alertmanager, maxDuration: maxDuration}
+}
+
+// Name returns the tool name for MCP registration
+func (t *CreateAlertSilenceTool) Name() string {
+	return "create-alert-silence"
+}
+
+// Description returns the tool description for MCP
+func (t *CreateAlertSilenceTool) Description() string {
+	return fmt.Sprintf(`Silence firing alerts in Alertmanager for a while, typically while their cause is being remediated. Alerts matching all of the matchers are muted from now until the duration has passed (at most %s). The silence is created on behalf of the authenticated user, and its comment is tagged %s. Matchers that would silence every alert are refused. Refused when the server is in read-only mode.
+
+Use this tool for requests like:
+- "Silence KubePodCrashLooping in the checkout namespace for an hour while I roll back"
+- "Mute the etcd alerts during the maintenance window"
+
+Use list-alert-silences to see the existing silences.`, t.maxDuration, SilenceCommentTag)
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *CreateAlertSilenceTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"matchers": map[string]interface{}{
+				"type":        "array",
+				"description": "Label matchers an alert must all satisfy to be silenced, e.g. alertname and namespace",
+				"minItems":    1,
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"name": map[string]interface{}{
+							"type":        "string",
+							"description": "Label name, e.g. alertname",
+						},
+						"value": map[string]interface{}{
+							"type":        "string",
+							"description": "Label value, or a regular expression for =~ and !~",
+						},
+						"operator": map[string]interface{}{
+							"type":        "string",
+							"description": "How the label is compared to value",
+							"enum":        []string{"=", "!=", "=~", "!~"},
+							"default":     "=",
+						},
+					},
+					"required": []string{"name", "value"},
+				},
+			},
+			"duration": DurationProperty(fmt.Sprintf("How long the silence lasts, at most %s", t.maxDuration), "1h"),
+			"comment": map[string]interface{}{
+				"type":        "string",
+				"description": "Why the alerts are silenced, e.g. the remediation in progress",
+			},
+		},
+		"required": []string{"matchers", "comment"},
+	}
+}
+
+// AlertSilenceMatcher is one label matcher of a silence
+type AlertSilenceMatcher struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Operator string `json:"operator"`
+}
+
+// CreateAlertSilenceInput represents the input parameters
+type CreateAlertSilenceInput struct {
+	Matchers []AlertSilenceMatcher `json:"matchers"`
+	Duration time.Duration         `json:"duration"` // Parsed by the dispatcher
+	Comment  string                `json:"comment"`
+}
+
+// AlertSilence is a silence as the silence tools report it
+type AlertSilence struct {
+	ID          string    `json:"id,omitempty"`
+	State       string    `json:"state,omitempty"` // active, pending or expired
+	Matchers    []string  `json:"matchers"`        // Like alertname="Watchdog"
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	CreatedBy   string    `json:"created_by"`
+	Comment     string    `json:"comment"`
+	CreatedHere bool      `json:"created_here"` // Created through this server, by its comment tag
+}
+
+// newAlertSilence converts an Alertmanager silence
+func newAlertSilence(silence clients.Silence) AlertSilence {
+	matchers := make([]string, 0, len(silence.Matchers))
+	for _, matcher := range silence.Matchers {
+		matchers = append(matchers, formatSilenceMatcher(matcher))
+	}
+	var state string
+	if silence.Status != nil {
+		state = silence.Status.State
+	}
+	return AlertSilence{
+		ID:          silence.ID,
+		State:       state,
+		Matchers:    matchers,
+		StartsAt:    silence.StartsAt,
+		EndsAt:      silence.EndsAt,
+		CreatedBy:   silence.CreatedBy,
+		Comment:     silence.Comment,
+		CreatedHere: strings.HasPrefix(silence.Comment, SilenceCommentTag),
+	}
+}
+
+// formatSilenceMatcher renders a matcher like alertname="Watchdog"
+func formatSilenceMatcher(matcher clients.SilenceMatcher) string {
+	var operator string
+	switch {
+	case matcher.IsRegex && matcher.IsEqual:
+		operator = "=~"
+	case matcher.IsRegex:
+		operator = "!~"
+	case matcher.IsEqual:
+		operator = "="
+	default:
+		operator = "!="
+	}
+	return matcher.Name + operator + strconv.Quote(matcher.Value)
+}
+
+// CreateAlertSilenceOutput represents the tool output
+type CreateAlertSilenceOutput struct {
+	Silence AlertSilence `json:"silence"`
+	Message string       `json:"message"`
+	DryRun  bool         `json:"dry_run,omitempty"`
+}
+
+// Mutating marks create-alert-silence as changing alerting state
+func (t *CreateAlertSilenceTool) Mutating() bool {
+	return true
+}
+
+// SupportsDryRun reports that create-alert-silence honors dry_run by
+// returning the silence it would create
+func (t *CreateAlertSilenceTool) SupportsDryRun() bool {
+	return true
+}
+
+// Execute runs the create-alert-silence operation
+func (t *CreateAlertSilenceTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := CreateAlertSilenceInput{Duration: time.Hour}
+	argsJSON, _ := json.Marshal(args) //nolint:errcheck // Tool arguments always marshal
+	if err := json.Unmarshal(argsJSON, &input); err != nil {
+		return nil, invalidArgs("invalid arguments: %v", err)
+	}
+
+	if strings.TrimSpace(input.Comment) == "" {
+		return nil, invalidArgs("comment is required")
+	}
+	if input.Duration <= 0 {
+		return nil, invalidArgs("duration must be positive")
+	}
+	if input.Duration > t.maxDuration {
+		return nil, invalidArgs("duration %s exceeds the maximum silence duration of %s", input.Duration, t.maxDuration)
+	}
+	matchers := make([]clients.SilenceMatcher, 0, len(input.Matchers))
+	for _, matcher := range input.Matchers {
+		silenceMatcher := clients.SilenceMatcher{Name: matcher.Name, Value: matcher.Value}
+		switch matcher.Operator {
+		case "=", "":
+			silenceMatcher.IsEqual = true
+		case "!=":
+		case "=~":
+			silenceMatcher.IsEqual, silenceMatcher.IsRegex = true, true
+		case "!~":
+			silenceMatcher.IsRegex = true
+		default:
+			return nil, invalidArgs("invalid operator %q for label %s, must be one of: =, !=, =~, !~", matcher.Operator, matcher.Name)
+		}
+		matchers = append(matchers, silenceMatcher)
+	}
+	if err := clients.ValidateSilenceMatchers(matchers); err != nil {
+		return nil, invalidArgs("%w", err)
+	}
+
+	creator := anonymousSilenceCreator
+	if principal, ok := clients.PrincipalFromContext(ctx); ok && principal.User != "" {
+		creator = principal.User
+	}
+	now := time.Now().UTC()
+	silence := clients.Silence{
+		Matchers:  matchers,
+		StartsAt:  now,
+		EndsAt:    now.Add(input.Duration),
+		CreatedBy: creator,
+		Comment:   SilenceCommentTag + " " + strings.TrimSpace(input.Comment),
+	}
+
+	if IsDryRun(ctx) {
+		return CreateAlertSilenceOutput{
+			Silence: newAlertSilence(silence),
+			Message: fmt.Sprintf("DRY RUN: a silence of %s would be created; nothing was sent to Alertmanager", input.Duration),
+			DryRun:  true,
+		}, nil
+	}
+
+	id, err := t.alertmanager.CreateSilence(ctx, silence)
+	if err != nil {
+		return nil, dependencyError(fmt.Errorf("failed to create silence in Alertmanager: %w", err))
+	}
+	silence.ID = id
+	output := CreateAlertSilenceOutput{
+		Silence: newAlertSilence(silence),
+		Message: fmt.Sprintf("Silence %s mutes the matching alerts until %s", id, silence.EndsAt.Format(time.RFC3339)),
+	}
+	output.Silence.State = "active"
+	return output, nil
+}
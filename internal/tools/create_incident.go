@@ -4,20 +4,39 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
 )
 
+// incidentDedupeWindow is how long a created incident is returned again for a
+// retried request with the same fingerprint instead of creating another one
+const incidentDedupeWindow = 15 * time.Minute
+
 // CreateIncidentTool allows manual incident creation for tracking
-// Useful for creating correlated parent incidents or manually tracking issues
+// Useful for creating correlated parent incidents, manually tracking issues, or
+// recording what this server's own analysis found
 type CreateIncidentTool struct {
 	ceClient *clients.CoordinationEngineClient
+
+	mu      sync.Mutex
+	created map[string]createdIncident // By fingerprint, for incidentDedupeWindow
+}
+
+// createdIncident is a recently created incident kept for deduplication
+type createdIncident struct {
+	output CreateIncidentOutput
+	at     time.Time
 }
 
 // NewCreateIncidentTool creates a new incident creation tool
 func NewCreateIncidentTool(ceClient *clients.CoordinationEngineClient) *CreateIncidentTool {
 	return &CreateIncidentTool{
 		ceClient: ceClient,
+		created:  make(map[string]createdIncident),
 	}
 }
 
@@ -28,7 +47,7 @@ func (t *CreateIncidentTool) Name() string {
 
 // Description returns the tool description for MCP
 func (t *CreateIncidentTool) Description() string {
-	return "Manually create an incident in the Coordination Engine for tracking - useful for correlated parent incidents or manual issue tracking"
+	return "Create an incident in the Coordination Engine for tracking - useful for correlated parent incidents, manual issue tracking, or recording a problem found by another tool (pass its output as findings). When findings holds a list of findings, as the analysis tools return, severity, affected_resources and finding_type default to what they describe. Retries with the same finding_type and affected_resources return the existing incident instead of creating a duplicate."
 }
 
 // InputSchema returns the JSON schema for tool inputs
@@ -48,7 +67,7 @@ func (t *CreateIncidentTool) InputSchema() map[string]interface{} {
 			},
 			"severity": map[string]interface{}{
 				"type":        "string",
-				"description": "Incident severity level",
+				"description": "Incident severity level; defaults to the most severe of the findings (critical, warning as medium, info as low)",
 				"enum":        []string{"critical", "high", "medium", "low"},
 			},
 			"target": map[string]interface{}{
@@ -77,6 +96,14 @@ func (t *CreateIncidentTool) InputSchema() map[string]interface{} {
 					"type": "string",
 				},
 			},
+			"finding_type": map[string]interface{}{
+				"type":        "string",
+				"description": "Kind of problem found (e.g. crashloop, node-pressure, pvc-full); with affected_resources it identifies the incident for deduplication",
+			},
+			"findings": map[string]interface{}{
+				"type":        "object",
+				"description": "Evidence attached to the incident, such as the output of the tool that found the problem. Its findings list, if any, fills in the fields left out.",
+			},
 			"confidence": map[string]interface{}{
 				"type":        "number",
 				"description": "Confidence score if created based on ML prediction (0.0-1.0)",
@@ -84,21 +111,23 @@ func (t *CreateIncidentTool) InputSchema() map[string]interface{} {
 				"maximum":     1.0,
 			},
 		},
-		"required": []string{"title", "description", "severity"},
+		"required": []string{"title", "description"},
 	}
 }
 
 // CreateIncidentInput represents the input parameters
 type CreateIncidentInput struct {
-	Title             string            `json:"title"`
-	Description       string            `json:"description"`
-	Severity          string            `json:"severity"`
-	Target            string            `json:"target,omitempty"`
-	AffectedResources []string          `json:"affected_resources,omitempty"`
-	CorrelationID     string            `json:"correlation_id,omitempty"`
-	ExternalID        string            `json:"external_id,omitempty"`
-	Labels            map[string]string `json:"labels,omitempty"`
-	Confidence        float64           `json:"confidence,omitempty"`
+	Title             string                 `json:"title"`
+	Description       string                 `json:"description"`
+	Severity          string                 `json:"severity"`
+	Target            string                 `json:"target,omitempty"`
+	AffectedResources []string               `json:"affected_resources,omitempty"`
+	CorrelationID     string                 `json:"correlation_id,omitempty"`
+	ExternalID        string                 `json:"external_id,omitempty"`
+	Labels            map[string]string      `json:"labels,omitempty"`
+	Confidence        float64                `json:"confidence,omitempty"`
+	FindingType       string                 `json:"finding_type,omitempty"`
+	Findings          map[string]interface{} `json:"findings,omitempty"`
 }
 
 // CreateIncidentOutput represents the tool output
@@ -111,6 +140,13 @@ type CreateIncidentOutput struct {
 	Status      string `json:"status"`
 	CreatedAt   string `json:"created_at"`
 	Message     string `json:"message"`
+	URL         string `json:"url"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Deduplicated is true when a retried request returned an incident created earlier
+	Deduplicated bool `json:"deduplicated,omitempty"`
+	DryRun       bool `json:"dry_run,omitempty"`
+	// Request is the incident that would be sent to the Coordination Engine, for dry-runs
+	Request *clients.CreateIncidentRequest `json:"request,omitempty"`
 }
 
 // Execute creates a new incident
@@ -124,24 +160,40 @@ func (t *CreateIncidentTool) Execute(ctx context.Context, args map[string]interf
 	}
 
 	if err := json.Unmarshal(argsJSON, &input); err != nil {
-		return nil, fmt.Errorf("failed to parse arguments: %w", err)
+		return nil, invalidArgs("failed to parse arguments: %w", err)
 	}
 
 	// Validate required fields
 	if input.Title == "" {
-		return nil, fmt.Errorf("title is required")
+		return nil, invalidArgs("title is required")
 	}
 	if input.Description == "" {
-		return nil, fmt.Errorf("description is required")
+		return nil, invalidArgs("description is required")
 	}
+	applyIncidentFindings(&input)
 	if input.Severity == "" {
-		return nil, fmt.Errorf("severity is required")
+		return nil, invalidArgs("severity is required")
 	}
 
 	// Validate severity
 	validSeverities := map[string]bool{"critical": true, "high": true, "medium": true, "low": true}
 	if !validSeverities[input.Severity] {
-		return nil, fmt.Errorf("invalid severity '%s', must be one of: critical, high, medium, low", input.Severity)
+		return nil, invalidArgs("invalid severity '%s', must be one of: critical, high, medium, low", input.Severity)
+	}
+
+	// Retries of the same finding return the incident created for it
+	var fingerprint string
+	if input.FindingType != "" || len(input.AffectedResources) > 0 {
+		fingerprint = clients.IncidentFingerprint(input.FindingType, input.AffectedResources)
+		if existing, ok := t.recent(fingerprint); ok {
+			existing.Deduplicated = true
+			existing.Message = fmt.Sprintf("Incident %s already exists for this finding", existing.IncidentID)
+			if IsDryRun(ctx) {
+				existing.DryRun = true
+				existing.Message = fmt.Sprintf("DRY RUN: incident %s already exists for this finding and would be returned", existing.IncidentID)
+			}
+			return existing, nil
+		}
 	}
 
 	// Build request to Coordination Engine
@@ -172,11 +224,36 @@ func (t *CreateIncidentTool) Execute(ctx context.Context, args map[string]interf
 	if len(input.AffectedResources) > 0 {
 		req.AffectedResources = input.AffectedResources
 	}
+	if len(input.Findings) > 0 {
+		req.Findings = input.Findings
+	}
+	if input.FindingType != "" {
+		if req.Labels == nil {
+			req.Labels = map[string]string{}
+		}
+		req.Labels["finding_type"] = input.FindingType
+	}
+	if fingerprint != "" {
+		req.Fingerprint = &fingerprint
+	}
+
+	// The engine cannot validate without creating, so a dry-run stops here
+	if IsDryRun(ctx) {
+		return CreateIncidentOutput{
+			Title:       input.Title,
+			Description: input.Description,
+			Severity:    input.Severity,
+			Fingerprint: fingerprint,
+			Message:     fmt.Sprintf("DRY RUN: a %s incident %q would be created; nothing was sent to the Coordination Engine", input.Severity, input.Title),
+			DryRun:      true,
+			Request:     req,
+		}, nil
+	}
 
 	// Call Coordination Engine API
 	resp, err := t.ceClient.CreateIncident(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create incident in Coordination Engine: %w", err)
+		return nil, dependencyError(fmt.Errorf("failed to create incident in Coordination Engine: %w", err))
 	}
 
 	// Build output
@@ -189,11 +266,96 @@ func (t *CreateIncidentTool) Execute(ctx context.Context, args map[string]interf
 		Status:      resp.Status,
 		CreatedAt:   resp.CreatedAt,
 		Message:     resp.Message,
+		URL:         resp.URL,
+		Fingerprint: fingerprint,
+	}
+	if output.URL == "" {
+		output.URL = t.ceClient.IncidentURL(resp.IncidentID)
+	}
+	if fingerprint != "" {
+		t.remember(fingerprint, output)
 	}
 
 	return output, nil
 }
 
+// Mutating marks create-incident as changing incident state
+func (t *CreateIncidentTool) Mutating() bool {
+	return true
+}
+
+// SupportsDryRun reports that create-incident honors dry_run by returning the
+// incident it would create
+func (t *CreateIncidentTool) SupportsDryRun() bool {
+	return true
+}
+
+// applyIncidentFindings fills in the severity, affected resources and finding
+// type left out of input from the findings list of its evidence, if it has one
+func applyIncidentFindings(input *CreateIncidentInput) {
+	raw, ok := input.Findings["findings"]
+	if !ok {
+		return
+	}
+	var list []findings.Finding
+	if data, err := json.Marshal(raw); err != nil || json.Unmarshal(data, &list) != nil || len(list) == 0 {
+		return
+	}
+
+	if input.Severity == "" {
+		input.Severity = incidentSeverity(findings.Highest(list))
+	}
+	if len(input.AffectedResources) == 0 {
+		for _, finding := range list {
+			if resource := finding.Resource.String(); !slices.Contains(input.AffectedResources, resource) {
+				input.AffectedResources = append(input.AffectedResources, resource)
+			}
+		}
+	}
+	if input.FindingType == "" {
+		category := list[0].Category
+		for _, finding := range list[1:] {
+			if finding.Category != category {
+				return
+			}
+		}
+		input.FindingType = category
+	}
+}
+
+// incidentSeverity maps a finding severity to an incident severity
+func incidentSeverity(severity findings.Severity) string {
+	switch severity {
+	case findings.SeverityCritical:
+		return "critical"
+	case findings.SeverityWarning:
+		return "medium"
+	case findings.SeverityInfo:
+		return "low"
+	default:
+		return ""
+	}
+}
+
+// recent returns the incident created for fingerprint within incidentDedupeWindow
+func (t *CreateIncidentTool) recent(fingerprint string) (CreateIncidentOutput, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, entry := range t.created {
+		if time.Since(entry.at) > incidentDedupeWindow {
+			delete(t.created, key)
+		}
+	}
+	entry, ok := t.created[fingerprint]
+	return entry.output, ok
+}
+
+func (t *CreateIncidentTool) remember(fingerprint string, output CreateIncidentOutput) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.created[fingerprint] = createdIncident{output: output, at: time.Now()}
+}
+
 // stringPtr is a helper to create a string pointer
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+func TestCreateIncidentTool_Execute(t *testing.T) {
+	var calls atomic.Int32
+	var got clients.CreateIncidentRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"incident_id":"inc-7","title":"Crash loop in payments","severity":"high","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	tool := NewCreateIncidentTool(testutil.NewCoordinationEngineClient(t, server.URL))
+	assert.True(t, tool.Mutating())
+
+	args := map[string]interface{}{
+		"title":              "Crash loop in payments",
+		"description":        "api pods restart every few minutes",
+		"severity":           "high",
+		"affected_resources": []interface{}{"payments/api-1", "payments/api-0"},
+		"finding_type":       "crashloop",
+		"findings":           map[string]interface{}{"restarts": 12},
+	}
+	result, err := tool.Execute(context.Background(), args)
+	require.NoError(t, err)
+	output := result.(CreateIncidentOutput)
+
+	assert.Equal(t, "inc-7", output.IncidentID)
+	assert.Equal(t, server.URL+"/api/v1/incidents/inc-7", output.URL)
+	assert.Equal(t, clients.IncidentFingerprint("crashloop", []string{"payments/api-0", "payments/api-1"}), output.Fingerprint)
+	assert.False(t, output.Deduplicated)
+	require.NotNil(t, got.Fingerprint)
+	assert.Equal(t, output.Fingerprint, *got.Fingerprint)
+	assert.Equal(t, "crashloop", got.Labels["finding_type"])
+	assert.Equal(t, float64(12), got.Findings["restarts"])
+
+	// A retry of the same finding returns the incident without calling the engine again
+	args["affected_resources"] = []interface{}{"payments/api-0", "payments/api-1"}
+	result, err = tool.Execute(context.Background(), args)
+	require.NoError(t, err)
+	retried := result.(CreateIncidentOutput)
+	assert.True(t, retried.Deduplicated)
+	assert.Equal(t, "inc-7", retried.IncidentID)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// A different finding on the same resources is a new incident
+	args["finding_type"] = "oom"
+	_, err = tool.Execute(context.Background(), args)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCreateIncidentTool_NoFingerprintWithoutFinding(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Empty(t, r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"incident_id":"inc-8","url":"https://ce.example.com/incidents/inc-8"}`))
+	}))
+	defer server.Close()
+
+	tool := NewCreateIncidentTool(testutil.NewCoordinationEngineClient(t, server.URL))
+	args := map[string]interface{}{"title": "Manual tracking", "description": "tracked by hand", "severity": "low"}
+	for range 2 {
+		result, err := tool.Execute(context.Background(), args)
+		require.NoError(t, err)
+		output := result.(CreateIncidentOutput)
+		assert.Empty(t, output.Fingerprint)
+		assert.Equal(t, "https://ce.example.com/incidents/inc-8", output.URL)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCreateIncidentTool_DefaultsFromFindings(t *testing.T) {
+	tool := NewCreateIncidentTool(testutil.NewCoordinationEngineClient(t, "http://coordination-engine:8080"))
+	output := GetCSIHealthOutput{Findings: []findings.Finding{
+		{Severity: findings.SeverityWarning, Category: findings.CategoryStorage, Resource: resourceRef("csidriver", "", "rbd.csi.ceph.com"), Title: "CSI driver is degraded"},
+		{Severity: findings.SeverityCritical, Category: findings.CategoryStorage, Resource: resourceRef("csidriver", "", "ebs.csi.aws.com"), Title: "CSI driver cannot attach or mount volumes"},
+	}}
+	data, err := json.Marshal(output)
+	require.NoError(t, err)
+	var evidence map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &evidence))
+
+	result, err := tool.Execute(WithDryRun(context.Background()), map[string]interface{}{
+		"title":       "Volumes cannot be attached",
+		"description": "get-csi-health reports attach errors",
+		"findings":    evidence,
+	})
+	require.NoError(t, err)
+	request := result.(CreateIncidentOutput).Request
+	require.NotNil(t, request)
+	assert.Equal(t, "critical", request.Severity)
+	assert.Equal(t, []string{"csidriver/rbd.csi.ceph.com", "csidriver/ebs.csi.aws.com"}, request.AffectedResources)
+	assert.Equal(t, findings.CategoryStorage, request.Labels["finding_type"])
+
+	// What the caller gives wins over the findings
+	result, err = tool.Execute(WithDryRun(context.Background()), map[string]interface{}{
+		"title":        "Volumes cannot be attached",
+		"description":  "get-csi-health reports attach errors",
+		"severity":     "low",
+		"finding_type": "csi",
+		"findings":     evidence,
+	})
+	require.NoError(t, err)
+	request = result.(CreateIncidentOutput).Request
+	assert.Equal(t, "low", request.Severity)
+	assert.Equal(t, "csi", request.Labels["finding_type"])
+
+	// Without severity or findings the severity is still required
+	_, err = tool.Execute(WithDryRun(context.Background()), map[string]interface{}{
+		"title": "Volumes cannot be attached", "description": "no evidence attached", "findings": map[string]interface{}{"restarts": 3},
+	})
+	assert.Error(t, err)
+}
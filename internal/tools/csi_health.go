@@ -0,0 +1,647 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// Kubelet and attach/detach controller event reasons for volumes that could not be set up
+const (
+	failedAttachVolumeReason = "FailedAttachVolume"
+	failedMountReason        = "FailedMount"
+	failedMapVolumeReason    = "FailedMapVolume"
+)
+
+// Driver finding severities reported by get-csi-health
+const (
+	CSISeverityCritical = "critical" // Volumes cannot be attached or mounted, or the node plugin is down
+	CSISeverityWarning  = "warning"  // The node plugin is partly unavailable or attachments are orphaned
+)
+
+// stuckPodsPerDriver bounds the pods listed in a driver finding
+const stuckPodsPerDriver = 10
+
+var (
+	// quotedVolumePattern captures the volume named in kubelet and attach/detach controller messages,
+	// e.g. `AttachVolume.Attach failed for volume "pvc-0f3a" : rpc error: ...`
+	quotedVolumePattern = regexp.MustCompile(`volume "([^"]+)"`)
+	// unmountedVolumesPattern captures the pod volumes of "Unable to attach or mount volumes" timeouts
+	unmountedVolumesPattern = regexp.MustCompile(`unmounted volumes=\[([^\]]*)\]`)
+)
+
+// GetCSIHealthTool reports CSI driver, node plugin and volume attachment health
+type GetCSIHealthTool struct {
+	k8sClient clients.ClusterReader
+}
+
+// NewGetCSIHealthTool creates a new get-csi-health tool
+func NewGetCSIHealthTool(k8sClient clients.ClusterReader) *GetCSIHealthTool {
+	return &GetCSIHealthTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetCSIHealthTool) Name() string {
+	return "get-csi-health"
+}
+
+// Description returns the tool description for MCP
+func (t *GetCSIHealthTool) Description() string {
+	return `Check CSI storage drivers: each CSIDriver with the readiness of its node plugin DaemonSet and the nodes it is registered on, VolumeAttachments failing to attach or detach (with the driver's error), VolumeAttachments left pointing at deleted nodes, and pods stuck in ContainerCreating on attach or mount errors. Findings are grouped per driver, so one misbehaving driver shows up as one finding.
+
+Use this tool for questions like:
+- "Why is my StatefulSet pod stuck in ContainerCreating?"
+- "Are volumes failing to attach after a node failure?"
+- "Is the EBS / ODF CSI driver healthy?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetCSIHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"driver": map[string]interface{}{
+				"type":        "string",
+				"description": "Check only this CSI driver (e.g. 'ebs.csi.aws.com'). Leave empty for all drivers.",
+				"default":     "",
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetCSIHealthInput represents the input parameters
+type GetCSIHealthInput struct {
+	Driver string `json:"driver"`
+}
+
+// CSINodePluginStatus summarizes the DaemonSet running a driver's node plugin
+type CSINodePluginStatus struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	Desired     int32  `json:"desired"`
+	Ready       int32  `json:"ready"`
+	Unavailable int32  `json:"unavailable"`
+}
+
+// CSIAttachmentIssue is a VolumeAttachment that failed or outlived its node
+type CSIAttachmentIssue struct {
+	Name             string `json:"name"`
+	PersistentVolume string `json:"persistent_volume,omitempty"`
+	Node             string `json:"node"`
+	Attached         bool   `json:"attached"`
+	Operation        string `json:"operation,omitempty"` // attach or detach, for errors
+	Error            string `json:"error,omitempty"`
+	Since            string `json:"since,omitempty"`
+}
+
+// CSIStuckPod is a pod held in ContainerCreating by a volume of the driver
+type CSIStuckPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Node      string `json:"node"`
+	Volume    string `json:"volume,omitempty"`
+	Reason    string `json:"reason"`
+	Message   string `json:"message"`
+	Count     int32  `json:"count,omitempty"`
+	LastSeen  string `json:"last_seen"`
+}
+
+// CSIDriverFinding groups the state and problems of one CSI driver
+type CSIDriverFinding struct {
+	Driver              string               `json:"driver"`
+	Healthy             bool                 `json:"healthy"`
+	Severity            string               `json:"severity,omitempty"`
+	Summary             string               `json:"summary"`
+	Installed           bool                 `json:"installed"` // A CSIDriver object exists
+	AttachRequired      bool                 `json:"attach_required"`
+	RegisteredNodes     int                  `json:"registered_nodes"` // Nodes whose CSINode lists the driver
+	NodePlugin          *CSINodePluginStatus `json:"node_plugin,omitempty"`
+	VolumeAttachments   int                  `json:"volume_attachments"`
+	AttachmentErrors    []CSIAttachmentIssue `json:"attachment_errors,omitempty"`
+	OrphanedAttachments []CSIAttachmentIssue `json:"orphaned_attachments,omitempty"`
+	StuckPods           []CSIStuckPod        `json:"stuck_pods,omitempty"`
+	StuckPodCount       int                  `json:"stuck_pod_count"`
+}
+
+// GetCSIHealthOutput represents the tool output
+type GetCSIHealthOutput struct {
+	Status         string             `json:"status"`
+	TotalDrivers   int                `json:"total_drivers"`
+	HealthyDrivers int                `json:"healthy_drivers"`
+	Drivers        []CSIDriverFinding `json:"drivers"`
+	Findings       []findings.Finding `json:"findings"` // Unhealthy drivers in the common findings shape
+}
+
+// csiInventory is the cluster state get-csi-health assesses
+type csiInventory struct {
+	drivers     []storagev1.CSIDriver
+	csiNodes    []storagev1.CSINode
+	daemonSets  []appsv1.DaemonSet
+	attachments []storagev1.VolumeAttachment
+	nodes       []corev1.Node
+	volumes     []corev1.PersistentVolume
+	claims      []corev1.PersistentVolumeClaim
+	pods        []corev1.Pod
+	events      []corev1.Event
+}
+
+// RequiredPermissions declares the Kubernetes API access get-csi-health needs
+func (t *GetCSIHealthTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "storage.k8s.io", Resource: "csidrivers", Verb: "list"},
+		{Group: "storage.k8s.io", Resource: "csinodes", Verb: "list"},
+		{Group: "storage.k8s.io", Resource: "volumeattachments", Verb: "list"},
+		{Group: "apps", Resource: "daemonsets", Verb: "list"},
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "persistentvolumes", Verb: "list"},
+		{Resource: "persistentvolumeclaims", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Resource: "events", Verb: "list"},
+	}
+}
+
+// Execute runs the get-csi-health operation
+func (t *GetCSIHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input GetCSIHealthInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	inventory, err := t.readInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := csiFindings(inventory, time.Now())
+	if input.Driver != "" {
+		filtered := findings[:0]
+		for _, finding := range findings {
+			if finding.Driver == input.Driver {
+				filtered = append(filtered, finding)
+			}
+		}
+		findings = filtered
+	}
+
+	output := GetCSIHealthOutput{Status: "healthy", TotalDrivers: len(findings), Drivers: findings}
+	for _, finding := range findings {
+		if finding.Healthy {
+			output.HealthyDrivers++
+		} else {
+			output.Status = "degraded"
+		}
+	}
+	output.Findings = csiDriverFindings(output.Drivers)
+	return output, nil
+}
+
+// readInventory lists the objects get-csi-health assesses. Pods are limited
+// to pending ones and events to the volume failure reasons where the API
+// server supports the field selectors.
+func (t *GetCSIHealthTool) readInventory(ctx context.Context) (csiInventory, error) {
+	clientset := t.k8sClient.Clientset()
+	var inventory csiInventory
+
+	drivers, err := clientset.StorageV1().CSIDrivers().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return inventory, apiError(fmt.Errorf("failed to list CSI drivers: %w", err))
+	}
+	inventory.drivers = drivers.Items
+
+	csiNodes, err := clientset.StorageV1().CSINodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return inventory, apiError(fmt.Errorf("failed to list CSI nodes: %w", err))
+	}
+	inventory.csiNodes = csiNodes.Items
+
+	attachments, err := clientset.StorageV1().VolumeAttachments().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return inventory, apiError(fmt.Errorf("failed to list volume attachments: %w", err))
+	}
+	inventory.attachments = attachments.Items
+
+	daemonSets, err := clientset.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return inventory, apiError(fmt.Errorf("failed to list daemonsets: %w", err))
+	}
+	inventory.daemonSets = daemonSets.Items
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return inventory, apiError(err)
+	}
+	inventory.nodes = nodes.Items
+
+	volumes, err := clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return inventory, apiError(fmt.Errorf("failed to list persistent volumes: %w", err))
+	}
+	inventory.volumes = volumes.Items
+
+	claims, err := clientset.CoreV1().PersistentVolumeClaims("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return inventory, apiError(fmt.Errorf("failed to list persistent volume claims: %w", err))
+	}
+	inventory.claims = claims.Items
+
+	pendingPods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("status.phase", string(corev1.PodPending)).String(),
+	})
+	if err != nil {
+		return inventory, apiError(fmt.Errorf("failed to list pending pods: %w", err))
+	}
+	inventory.pods = pendingPods.Items
+
+	for _, reason := range []string{failedAttachVolumeReason, failedMountReason, failedMapVolumeReason} {
+		events, err := clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("reason", reason).String(),
+		})
+		if err != nil {
+			return inventory, apiError(fmt.Errorf("failed to list %s events: %w", reason, err))
+		}
+		inventory.events = append(inventory.events, events.Items...)
+	}
+	return inventory, nil
+}
+
+// csiFindings assesses every CSI driver that is installed, registered on a
+// node, or referenced by a VolumeAttachment or a stuck pod. Drivers with
+// problems come first, critical before warning.
+func csiFindings(in csiInventory, now time.Time) []CSIDriverFinding {
+	findings := make(map[string]*CSIDriverFinding)
+	finding := func(driver string) *CSIDriverFinding {
+		if f, ok := findings[driver]; ok {
+			return f
+		}
+		f := &CSIDriverFinding{Driver: driver, AttachRequired: true}
+		findings[driver] = f
+		return f
+	}
+
+	for i := range in.drivers {
+		driver := &in.drivers[i]
+		f := finding(driver.Name)
+		f.Installed = true
+		if driver.Spec.AttachRequired != nil {
+			f.AttachRequired = *driver.Spec.AttachRequired
+		}
+	}
+	for i := range in.csiNodes {
+		for _, driver := range in.csiNodes[i].Spec.Drivers {
+			finding(driver.Name).RegisteredNodes++
+		}
+	}
+
+	// Node plugins are found after every driver is known, by matching registrar arguments
+	for i := range in.daemonSets {
+		daemonSet := &in.daemonSets[i]
+		driver := nodePluginDriver(daemonSet, findings)
+		if driver == "" {
+			continue
+		}
+		f := findings[driver]
+		if f.NodePlugin != nil {
+			continue
+		}
+		f.NodePlugin = &CSINodePluginStatus{
+			Namespace:   daemonSet.Namespace,
+			Name:        daemonSet.Name,
+			Desired:     daemonSet.Status.DesiredNumberScheduled,
+			Ready:       daemonSet.Status.NumberReady,
+			Unavailable: daemonSet.Status.NumberUnavailable,
+		}
+	}
+
+	nodeExists := make(map[string]bool, len(in.nodes))
+	for i := range in.nodes {
+		nodeExists[in.nodes[i].Name] = true
+	}
+	for i := range in.attachments {
+		attachment := &in.attachments[i]
+		f := finding(attachment.Spec.Attacher)
+		f.VolumeAttachments++
+		issue := CSIAttachmentIssue{
+			Name:     attachment.Name,
+			Node:     attachment.Spec.NodeName,
+			Attached: attachment.Status.Attached,
+		}
+		if attachment.Spec.Source.PersistentVolumeName != nil {
+			issue.PersistentVolume = *attachment.Spec.Source.PersistentVolumeName
+		}
+		if !nodeExists[attachment.Spec.NodeName] {
+			orphan := issue
+			orphan.Since = attachment.CreationTimestamp.UTC().Format(time.RFC3339)
+			f.OrphanedAttachments = append(f.OrphanedAttachments, orphan)
+		}
+		for _, failure := range []struct {
+			operation string
+			err       *storagev1.VolumeError
+		}{{"attach", attachment.Status.AttachError}, {"detach", attachment.Status.DetachError}} {
+			if failure.err == nil {
+				continue
+			}
+			failed := issue
+			failed.Operation = failure.operation
+			failed.Error = failure.err.Message
+			failed.Since = failure.err.Time.UTC().Format(time.RFC3339)
+			f.AttachmentErrors = append(f.AttachmentErrors, failed)
+		}
+	}
+
+	for _, stuck := range csiStuckPods(in, now) {
+		f := finding(stuck.driver)
+		f.StuckPodCount++
+		if len(f.StuckPods) < stuckPodsPerDriver {
+			f.StuckPods = append(f.StuckPods, stuck.pod)
+		}
+	}
+
+	result := make([]CSIDriverFinding, 0, len(findings))
+	for _, f := range findings {
+		sortAttachmentIssues(f.AttachmentErrors)
+		sortAttachmentIssues(f.OrphanedAttachments)
+		f.Severity = csiDriverSeverity(f)
+		f.Healthy = f.Severity == ""
+		f.Summary = csiFindingSummary(f)
+		result = append(result, *f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Healthy != result[j].Healthy {
+			return !result[i].Healthy
+		}
+		if result[i].Severity != result[j].Severity {
+			return result[i].Severity == CSISeverityCritical
+		}
+		return result[i].Driver < result[j].Driver
+	})
+	return result
+}
+
+// stuckCSIPod is a stuck pod and the driver of the volume holding it
+type stuckCSIPod struct {
+	driver string
+	pod    CSIStuckPod
+}
+
+// csiStuckPods correlates pods stuck in ContainerCreating with their latest
+// attach or mount failure event, keeping those whose failing volume is
+// served by a CSI driver. Mount failures of other volumes (a missing
+// ConfigMap, say) are not the driver's and are skipped.
+func csiStuckPods(in csiInventory, now time.Time) []stuckCSIPod {
+	pvDrivers := make(map[string]string, len(in.volumes))
+	for i := range in.volumes {
+		if csi := in.volumes[i].Spec.CSI; csi != nil {
+			pvDrivers[in.volumes[i].Name] = csi.Driver
+		}
+	}
+	claimVolumes := make(map[string]string, len(in.claims))
+	for i := range in.claims {
+		claimVolumes[in.claims[i].Namespace+"/"+in.claims[i].Name] = in.claims[i].Spec.VolumeName
+	}
+
+	// Keep the latest failure per pod; older events may predate a fix
+	latest := make(map[string]*corev1.Event)
+	for i := range in.events {
+		event := &in.events[i]
+		switch event.Reason {
+		case failedAttachVolumeReason, failedMountReason, failedMapVolumeReason:
+		default:
+			continue
+		}
+		if event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		key := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+		if current, ok := latest[key]; !ok || eventLastSeen(event).After(eventLastSeen(current)) {
+			latest[key] = event
+		}
+	}
+
+	var stuck []stuckCSIPod
+	for i := range in.pods {
+		pod := &in.pods[i]
+		if pod.Status.Phase != corev1.PodPending || pod.Spec.NodeName == "" || now.Sub(pod.CreationTimestamp.Time) < stuckCreatingAfter || !containerCreating(pod) {
+			continue
+		}
+		event, ok := latest[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			continue
+		}
+		volumes := podCSIVolumes(pod, claimVolumes, pvDrivers)
+		driver, volume, ok := eventVolumeDriver(event.Message, volumes)
+		if !ok {
+			continue
+		}
+		stuck = append(stuck, stuckCSIPod{driver: driver, pod: CSIStuckPod{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Node:      pod.Spec.NodeName,
+			Volume:    volume,
+			Reason:    event.Reason,
+			Message:   event.Message,
+			Count:     event.Count,
+			LastSeen:  eventLastSeen(event).UTC().Format(time.RFC3339),
+		}})
+	}
+	sort.Slice(stuck, func(i, j int) bool {
+		if stuck[i].pod.Namespace != stuck[j].pod.Namespace {
+			return stuck[i].pod.Namespace < stuck[j].pod.Namespace
+		}
+		return stuck[i].pod.Name < stuck[j].pod.Name
+	})
+	return stuck
+}
+
+// podCSIVolumes maps the names a pod's CSI volumes go by in events, the pod
+// volume name and the PersistentVolume name, to their driver. Claims not yet
+// bound and non-CSI volumes are left out.
+func podCSIVolumes(pod *corev1.Pod, claimVolumes, pvDrivers map[string]string) map[string]string {
+	volumes := make(map[string]string)
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.CSI != nil:
+			volumes[volume.Name] = volume.CSI.Driver
+		case volume.PersistentVolumeClaim != nil:
+			pv := claimVolumes[pod.Namespace+"/"+volume.PersistentVolumeClaim.ClaimName]
+			if driver, ok := pvDrivers[pv]; ok {
+				volumes[volume.Name] = driver
+				volumes[pv] = driver
+			}
+		}
+	}
+	return volumes
+}
+
+// eventVolumeDriver returns the CSI driver and volume an attach or mount
+// failure message is about. The message names the volume in quotes, or
+// lists the pod volumes still unmounted after a timeout; when it names
+// neither, a pod with a single CSI driver is attributed to it.
+func eventVolumeDriver(message string, volumes map[string]string) (string, string, bool) {
+	if match := quotedVolumePattern.FindStringSubmatch(message); match != nil {
+		driver, ok := volumes[match[1]]
+		return driver, match[1], ok
+	}
+	if match := unmountedVolumesPattern.FindStringSubmatch(message); match != nil {
+		for _, name := range strings.FieldsFunc(match[1], func(r rune) bool { return r == ' ' || r == ',' }) {
+			if driver, ok := volumes[name]; ok {
+				return driver, name, true
+			}
+		}
+		return "", "", false
+	}
+
+	var only string
+	for _, driver := range volumes {
+		if only != "" && driver != only {
+			return "", "", false
+		}
+		only = driver
+	}
+	return only, "", only != ""
+}
+
+// nodePluginDriver returns the known driver whose node plugin a DaemonSet
+// runs, or "". Node plugins run the node-driver-registrar sidecar, which is
+// given the driver's socket path under /var/lib/kubelet/plugins/<driver>/;
+// the driver name is looked for in its containers' arguments and
+// environment and in its host path volumes.
+func nodePluginDriver(daemonSet *appsv1.DaemonSet, drivers map[string]*CSIDriverFinding) string {
+	spec := &daemonSet.Spec.Template.Spec
+	var registrar bool
+	var haystack []string
+	for _, container := range spec.Containers {
+		if strings.Contains(container.Name, "driver-registrar") || strings.Contains(container.Image, "driver-registrar") {
+			registrar = true
+		}
+		haystack = append(haystack, container.Command...)
+		haystack = append(haystack, container.Args...)
+		for _, env := range container.Env {
+			haystack = append(haystack, env.Value)
+		}
+	}
+	if !registrar {
+		return ""
+	}
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			haystack = append(haystack, volume.HostPath.Path)
+		}
+	}
+
+	// Prefer the longest name so a.csi.example.com does not claim b.a.csi.example.com's plugin
+	var match string
+	text := strings.Join(haystack, "\n")
+	for driver := range drivers {
+		if len(driver) > len(match) && strings.Contains(text, driver) {
+			match = driver
+		}
+	}
+	return match
+}
+
+// csiDriverSeverity grades a driver finding, or returns "" when it is healthy
+func csiDriverSeverity(f *CSIDriverFinding) string {
+	switch {
+	case len(f.AttachmentErrors) > 0 || f.StuckPodCount > 0,
+		f.NodePlugin != nil && f.NodePlugin.Desired > 0 && f.NodePlugin.Ready == 0:
+		return CSISeverityCritical
+	case len(f.OrphanedAttachments) > 0,
+		f.NodePlugin != nil && f.NodePlugin.Ready < f.NodePlugin.Desired:
+		return CSISeverityWarning
+	default:
+		return ""
+	}
+}
+
+// csiDriverFindings converts the unhealthy driver findings to findings, with
+// the attachment errors and the failure events of stuck pods as evidence
+func csiDriverFindings(drivers []CSIDriverFinding) []findings.Finding {
+	result := []findings.Finding{}
+	for _, driver := range drivers {
+		if driver.Healthy {
+			continue
+		}
+		finding := findings.Finding{
+			Severity:        findings.SeverityWarning,
+			Category:        findings.CategoryStorage,
+			Resource:        resourceRef("csidriver", "", driver.Driver),
+			Title:           "CSI driver is degraded",
+			Detail:          driver.Summary,
+			SuggestedAction: "Check the node plugin pods of the driver and delete the VolumeAttachments left on deleted nodes",
+			Source:          "get-csi-health",
+		}
+		if driver.Severity == CSISeverityCritical {
+			finding.Severity = findings.SeverityCritical
+			finding.Title = "CSI driver cannot attach or mount volumes"
+			finding.SuggestedAction = "Check the driver's controller and node plugin logs for the attach or mount errors; pods using its volumes cannot start"
+		}
+		for _, issue := range driver.AttachmentErrors {
+			finding.Evidence = append(finding.Evidence, findings.Evidence{
+				Type: findings.EvidenceCondition, Ref: "volumeattachment/" + issue.Name, Message: issue.Error,
+			})
+		}
+		for _, pod := range driver.StuckPods {
+			finding.Evidence = append(finding.Evidence, findings.Evidence{
+				Type: findings.EvidenceEvent, Ref: pod.Reason, Message: fmt.Sprintf("%s/%s: %s", pod.Namespace, pod.Name, pod.Message),
+			})
+			if seen, err := time.Parse(time.RFC3339, pod.LastSeen); err == nil && seen.After(finding.LastSeen) {
+				finding.LastSeen = seen
+			}
+		}
+		result = append(result, finding)
+	}
+	findings.Sort(result)
+	return result
+}
+
+// csiFindingSummary renders a finding as one sentence, e.g.
+// "ebs.csi.aws.com has 2 attachment errors and 3 pods stuck in ContainerCreating"
+func csiFindingSummary(f *CSIDriverFinding) string {
+	var parts []string
+	if f.NodePlugin != nil && f.NodePlugin.Ready < f.NodePlugin.Desired {
+		parts = append(parts, fmt.Sprintf("%d of %d node plugin pods ready", f.NodePlugin.Ready, f.NodePlugin.Desired))
+	}
+	if len(f.AttachmentErrors) > 0 {
+		parts = append(parts, fmt.Sprintf("%d attachment errors", len(f.AttachmentErrors)))
+	}
+	if len(f.OrphanedAttachments) > 0 {
+		parts = append(parts, fmt.Sprintf("%d attachments on deleted nodes", len(f.OrphanedAttachments)))
+	}
+	if f.StuckPodCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d pods stuck in ContainerCreating", f.StuckPodCount))
+	}
+
+	switch len(parts) {
+	case 0:
+		return fmt.Sprintf("%s has no problems, registered on %d nodes", f.Driver, f.RegisteredNodes)
+	case 1:
+		return fmt.Sprintf("%s has %s", f.Driver, parts[0])
+	default:
+		return fmt.Sprintf("%s has %s and %s", f.Driver, strings.Join(parts[:len(parts)-1], ", "), parts[len(parts)-1])
+	}
+}
+
+// sortAttachmentIssues orders issues by node, then VolumeAttachment name
+func sortAttachmentIssues(issues []CSIAttachmentIssue) {
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Node != issues[j].Node {
+			return issues[i].Node < issues[j].Node
+		}
+		return issues[i].Name < issues[j].Name
+	})
+}
@@ -0,0 +1,280 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+// newCSIVolume creates a PersistentVolume served by a CSI driver and its bound claim
+func newCSIVolume(driver, pv, namespace, claim string) (*corev1.PersistentVolume, *corev1.PersistentVolumeClaim) {
+	volume := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: pv},
+		Spec: corev1.PersistentVolumeSpec{PersistentVolumeSource: corev1.PersistentVolumeSource{
+			CSI: &corev1.CSIPersistentVolumeSource{Driver: driver, VolumeHandle: "vol-" + pv},
+		}},
+	}
+	boundClaim := newClaim(namespace, claim, "gp3")
+	boundClaim.Spec.VolumeName = pv
+	return volume, boundClaim
+}
+
+// withVolumes adds volumes to a pod
+func withVolumes(pod *corev1.Pod, volumes ...corev1.Volume) *corev1.Pod {
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
+	return pod
+}
+
+func claimVolume(name, claim string) corev1.Volume {
+	return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}}}
+}
+
+func volumeEvent(namespace, pod, reason, message string, ago time.Duration) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: namespace, Name: pod + "." + reason + ago.String()},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: pod},
+		Reason:         reason,
+		Message:        message,
+		Type:           corev1.EventTypeWarning,
+		Count:          4,
+		LastTimestamp:  metav1.NewTime(time.Now().Add(-ago)),
+	}
+}
+
+// newNodePlugin creates a node plugin DaemonSet registering driver with the kubelet
+func newNodePlugin(namespace, name, driver string, desired, ready int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "csi-driver", Image: "registry.example.com/csi-driver:v1"},
+			{
+				Name:  "node-driver-registrar",
+				Image: "registry.k8s.io/sig-storage/csi-node-driver-registrar:v2.10.0",
+				Args:  []string{"--csi-address=/csi/csi.sock", "--kubelet-registration-path=/var/lib/kubelet/plugins/" + driver + "/csi.sock"},
+			},
+		}}}},
+		Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: desired, NumberReady: ready, NumberUnavailable: desired - ready},
+	}
+}
+
+func csiFixtures() []runtime.Object {
+	dataPV, dataClaim := newCSIVolume("ebs.csi.aws.com", "pvc-data", "db", "data-db-0")
+	cephPV, cephClaim := newCSIVolume("rbd.csi.ceph.com", "pvc-uploads", "shop", "uploads")
+	attachRequired := true
+	orphanedPV, failedPV := "pvc-old", "pvc-data"
+
+	return []runtime.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}},
+		&storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "ebs.csi.aws.com"}, Spec: storagev1.CSIDriverSpec{AttachRequired: &attachRequired}},
+		&storagev1.CSIDriver{ObjectMeta: metav1.ObjectMeta{Name: "rbd.csi.ceph.com"}},
+		&storagev1.CSINode{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}, Spec: storagev1.CSINodeSpec{Drivers: []storagev1.CSINodeDriver{{Name: "ebs.csi.aws.com"}, {Name: "rbd.csi.ceph.com"}}}},
+		&storagev1.CSINode{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}, Spec: storagev1.CSINodeSpec{Drivers: []storagev1.CSINodeDriver{{Name: "rbd.csi.ceph.com"}}}},
+		newNodePlugin("openshift-cluster-csi-drivers", "aws-ebs-csi-driver-node", "ebs.csi.aws.com", 2, 1),
+		newNodePlugin("openshift-storage", "csi-rbdplugin", "rbd.csi.ceph.com", 2, 2),
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-dns", Name: "dns-default"}},
+		&storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "csi-failed"},
+			Spec:       storagev1.VolumeAttachmentSpec{Attacher: "ebs.csi.aws.com", NodeName: "worker-1", Source: storagev1.VolumeAttachmentSource{PersistentVolumeName: &failedPV}},
+			Status: storagev1.VolumeAttachmentStatus{AttachError: &storagev1.VolumeError{
+				Time:    metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+				Message: "rpc error: code = Internal desc = volume vol-pvc-data is attached to another instance",
+			}},
+		},
+		&storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: "csi-orphaned"},
+			Spec:       storagev1.VolumeAttachmentSpec{Attacher: "ebs.csi.aws.com", NodeName: "worker-gone", Source: storagev1.VolumeAttachmentSource{PersistentVolumeName: &orphanedPV}},
+			Status:     storagev1.VolumeAttachmentStatus{Attached: true},
+		},
+		dataPV, dataClaim, cephPV, cephClaim,
+		withVolumes(newCreatingPod("db", "db-0", "worker-1", 10*time.Minute), claimVolume("data", "data-db-0")),
+		withVolumes(newCreatingPod("web", "config-missing", "worker-2", 10*time.Minute), corev1.Volume{Name: "config"}),
+		withVolumes(newCreatingPod("shop", "uploader", "worker-2", 10*time.Minute), claimVolume("uploads", "uploads")),
+		volumeEvent("db", "db-0", failedMountReason, `Unable to attach or mount volumes: unmounted volumes=[data], unattached volumes=[data kube-api-access]: timed out waiting for the condition`, time.Minute),
+		volumeEvent("db", "db-0", failedAttachVolumeReason, `AttachVolume.Attach failed for volume "pvc-data" : rpc error: code = Internal`, 8*time.Minute),
+		volumeEvent("web", "config-missing", failedMountReason, `MountVolume.SetUp failed for volume "config" : configmap "web-config" not found`, time.Minute),
+		volumeEvent("shop", "uploader", failedMountReason, `MountVolume.MountDevice failed for volume "pvc-uploads" : rpc error: code = Aborted desc = an operation with the given Volume ID already exists`, 2*time.Minute),
+	}
+}
+
+// csiFixtureInventory sorts the fixtures into the inventory csiFindings takes
+func csiFixtureInventory() csiInventory {
+	var in csiInventory
+	for _, object := range csiFixtures() {
+		switch o := object.(type) {
+		case *corev1.Node:
+			in.nodes = append(in.nodes, *o)
+		case *storagev1.CSIDriver:
+			in.drivers = append(in.drivers, *o)
+		case *storagev1.CSINode:
+			in.csiNodes = append(in.csiNodes, *o)
+		case *appsv1.DaemonSet:
+			in.daemonSets = append(in.daemonSets, *o)
+		case *storagev1.VolumeAttachment:
+			in.attachments = append(in.attachments, *o)
+		case *corev1.PersistentVolume:
+			in.volumes = append(in.volumes, *o)
+		case *corev1.PersistentVolumeClaim:
+			in.claims = append(in.claims, *o)
+		case *corev1.Pod:
+			in.pods = append(in.pods, *o)
+		case *corev1.Event:
+			in.events = append(in.events, *o)
+		}
+	}
+	return in
+}
+
+func TestCSIFindings(t *testing.T) {
+	findings := csiFindings(csiFixtureInventory(), time.Now())
+
+	require.Len(t, findings, 2)
+	ebs, ceph := findings[0], findings[1]
+
+	assert.Equal(t, "ebs.csi.aws.com", ebs.Driver)
+	assert.Equal(t, CSISeverityCritical, ebs.Severity)
+	assert.True(t, ebs.Installed)
+	assert.Equal(t, 1, ebs.RegisteredNodes)
+	require.NotNil(t, ebs.NodePlugin)
+	assert.Equal(t, "aws-ebs-csi-driver-node", ebs.NodePlugin.Name)
+	assert.Equal(t, int32(1), ebs.NodePlugin.Unavailable)
+	assert.Equal(t, 2, ebs.VolumeAttachments)
+	require.Len(t, ebs.AttachmentErrors, 1)
+	assert.Equal(t, "attach", ebs.AttachmentErrors[0].Operation)
+	assert.Contains(t, ebs.AttachmentErrors[0].Error, "attached to another instance")
+	require.Len(t, ebs.OrphanedAttachments, 1)
+	assert.Equal(t, "worker-gone", ebs.OrphanedAttachments[0].Node)
+	require.Len(t, ebs.StuckPods, 1)
+	assert.Equal(t, "db-0", ebs.StuckPods[0].Name)
+	assert.Equal(t, "data", ebs.StuckPods[0].Volume, "the latest event is the mount timeout")
+	assert.Equal(t, "ebs.csi.aws.com has 1 of 2 node plugin pods ready, 1 attachment errors, 1 attachments on deleted nodes and 1 pods stuck in ContainerCreating", ebs.Summary)
+
+	assert.Equal(t, "rbd.csi.ceph.com", ceph.Driver)
+	assert.Equal(t, CSISeverityCritical, ceph.Severity)
+	assert.True(t, ceph.AttachRequired, "attachRequired defaults to true")
+	assert.Equal(t, 2, ceph.RegisteredNodes)
+	require.Len(t, ceph.StuckPods, 1, "the ConfigMap mount failure is not the driver's")
+	assert.Equal(t, "uploader", ceph.StuckPods[0].Name)
+	assert.Equal(t, "pvc-uploads", ceph.StuckPods[0].Volume)
+}
+
+func TestCSIFindings_Healthy(t *testing.T) {
+	in := csiInventory{
+		drivers:    []storagev1.CSIDriver{{ObjectMeta: metav1.ObjectMeta{Name: "rbd.csi.ceph.com"}}},
+		daemonSets: []appsv1.DaemonSet{*newNodePlugin("openshift-storage", "csi-rbdplugin", "rbd.csi.ceph.com", 3, 3)},
+		csiNodes:   []storagev1.CSINode{{Spec: storagev1.CSINodeSpec{Drivers: []storagev1.CSINodeDriver{{Name: "rbd.csi.ceph.com"}}}}},
+	}
+
+	findings := csiFindings(in, time.Now())
+
+	require.Len(t, findings, 1)
+	assert.True(t, findings[0].Healthy)
+	assert.Empty(t, findings[0].Severity)
+	assert.Equal(t, "rbd.csi.ceph.com has no problems, registered on 1 nodes", findings[0].Summary)
+}
+
+func TestCSIDriverFindings(t *testing.T) {
+	drivers := csiFindings(csiFixtureInventory(), time.Now())
+	drivers = append(drivers, CSIDriverFinding{Driver: "nfs.csi.k8s.io", Healthy: true, Summary: "nfs.csi.k8s.io has no problems"})
+
+	converted := csiDriverFindings(drivers)
+	require.Len(t, converted, 2, "healthy drivers have no finding")
+
+	ebs := converted[0]
+	assert.Equal(t, findings.SeverityCritical, ebs.Severity)
+	assert.Equal(t, findings.CategoryStorage, ebs.Category)
+	assert.Equal(t, "csidriver/ebs.csi.aws.com", ebs.Resource.String())
+	assert.Equal(t, "CSI driver cannot attach or mount volumes", ebs.Title)
+	assert.Equal(t, drivers[0].Summary, ebs.Detail)
+	require.Len(t, ebs.Evidence, 2)
+	assert.Equal(t, findings.EvidenceCondition, ebs.Evidence[0].Type)
+	assert.Contains(t, ebs.Evidence[0].Message, "attached to another instance")
+	assert.Equal(t, findings.EvidenceEvent, ebs.Evidence[1].Type)
+	assert.Contains(t, ebs.Evidence[1].Message, "db-0")
+	assert.False(t, ebs.LastSeen.IsZero())
+
+	assert.Equal(t, "csidriver/rbd.csi.ceph.com", converted[1].Resource.String())
+
+	warning := csiDriverFindings([]CSIDriverFinding{{Driver: "rbd.csi.ceph.com", Severity: CSISeverityWarning, Summary: "rbd.csi.ceph.com has 2 of 3 node plugin pods ready"}})
+	require.Len(t, warning, 1)
+	assert.Equal(t, findings.SeverityWarning, warning[0].Severity)
+	assert.Equal(t, "CSI driver is degraded", warning[0].Title)
+	assert.Empty(t, warning[0].Evidence)
+}
+
+func TestEventVolumeDriver(t *testing.T) {
+	volumes := map[string]string{"data": "ebs.csi.aws.com", "pvc-data": "ebs.csi.aws.com", "scratch": "inline.csi.example.com"}
+	tests := []struct {
+		name    string
+		message string
+		volumes map[string]string
+		driver  string
+		volume  string
+		ok      bool
+	}{
+		{"attach failure names the PV", `AttachVolume.Attach failed for volume "pvc-data" : timed out`, volumes, "ebs.csi.aws.com", "pvc-data", true},
+		{"multi-attach", `Multi-Attach error for volume "pvc-data" Volume is already exclusively attached to one node`, volumes, "ebs.csi.aws.com", "pvc-data", true},
+		{"non-CSI volume", `MountVolume.SetUp failed for volume "config" : configmap "app" not found`, volumes, "", "config", false},
+		{"mount timeout", `Unable to attach or mount volumes: unmounted volumes=[kube-api-access scratch], unattached volumes=[]: timed out`, volumes, "inline.csi.example.com", "scratch", true},
+		{"mount timeout of non-CSI volumes", `Unable to attach or mount volumes: unmounted volumes=[config], unattached volumes=[]: timed out`, volumes, "", "", false},
+		{"unnamed, single driver", `MapVolume.WaitForAttach failed: timed out`, map[string]string{"data": "ebs.csi.aws.com", "pvc-data": "ebs.csi.aws.com"}, "ebs.csi.aws.com", "", true},
+		{"unnamed, several drivers", `MapVolume.WaitForAttach failed: timed out`, volumes, "", "", false},
+		{"no CSI volumes", `MapVolume.WaitForAttach failed: timed out`, map[string]string{}, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, volume, ok := eventVolumeDriver(tt.message, tt.volumes)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.driver, driver)
+			assert.Equal(t, tt.volume, volume)
+		})
+	}
+}
+
+func TestNodePluginDriver(t *testing.T) {
+	drivers := map[string]*CSIDriverFinding{"csi.example.com": {}, "block.csi.example.com": {}}
+
+	assert.Equal(t, "block.csi.example.com", nodePluginDriver(newNodePlugin("storage", "block-node", "block.csi.example.com", 1, 1), drivers))
+	assert.Equal(t, "csi.example.com", nodePluginDriver(newNodePlugin("storage", "file-node", "csi.example.com", 1, 1), drivers))
+
+	withoutRegistrar := newNodePlugin("storage", "agent", "csi.example.com", 1, 1)
+	withoutRegistrar.Spec.Template.Spec.Containers = withoutRegistrar.Spec.Template.Spec.Containers[:1]
+	assert.Empty(t, nodePluginDriver(withoutRegistrar, drivers))
+}
+
+func TestGetCSIHealthTool_Execute(t *testing.T) {
+	tool := NewGetCSIHealthTool(clients.NewK8sClientWithClientset(fake.NewClientset(csiFixtures()...)))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetCSIHealthOutput)
+	assert.Equal(t, "degraded", output.Status)
+	assert.Equal(t, 2, output.TotalDrivers)
+	assert.Zero(t, output.HealthyDrivers)
+	require.Len(t, output.Drivers, 2)
+	assert.Equal(t, 1, output.Drivers[0].StuckPodCount)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"driver": "rbd.csi.ceph.com"})
+	require.NoError(t, err)
+	output = result.(GetCSIHealthOutput)
+	require.Len(t, output.Drivers, 1)
+	assert.Equal(t, "rbd.csi.ceph.com", output.Drivers[0].Driver)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"driver": "nfs.csi.k8s.io"})
+	require.NoError(t, err)
+	output = result.(GetCSIHealthOutput)
+	assert.Equal(t, "healthy", output.Status)
+	assert.Empty(t, output.Drivers)
+}
@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DryRunArg is the argument that asks a mutating tool to report what it
+// would do without changing anything. The dispatcher takes it out of the
+// arguments and marks the context with WithDryRun instead.
+const DryRunArg = "dry_run"
+
+type dryRunKey struct{}
+
+// WithDryRun returns a context under which mutating tools only predict their effect
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether the call runs as a dry-run
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// dryRunOption is the DryRun field of Kubernetes write options: with
+// DryRun=All the API server validates and admits the request without
+// persisting it
+func dryRunOption(ctx context.Context) []string {
+	if IsDryRun(ctx) {
+		return []string{metav1.DryRunAll}
+	}
+	return nil
+}
+
+// DryRunProperty is the input schema of DryRunArg
+func DryRunProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "boolean",
+		"description": "If true, validate the call and return its predicted effect without changing anything",
+		"default":     false,
+	}
+}
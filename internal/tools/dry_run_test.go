@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// honorDryRun makes the fake clientset behave like the API server for
+// DryRun=All writes: the object is returned but never persisted
+func honorDryRun(clientset *fake.Clientset) {
+	dryRun := func(options []string) bool {
+		return len(options) == 1 && options[0] == metav1.DryRunAll
+	}
+	clientset.PrependReactor("*", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		switch action := action.(type) {
+		case k8stesting.CreateActionImpl:
+			return dryRun(action.GetCreateOptions().DryRun), action.GetObject(), nil
+		case k8stesting.UpdateActionImpl:
+			return dryRun(action.GetUpdateOptions().DryRun), action.GetObject(), nil
+		}
+		return false, nil, nil
+	})
+}
+
+// writeActions returns the create and update actions sent to the clientset
+func writeActions(clientset *fake.Clientset) []k8stesting.Action {
+	var writes []k8stesting.Action
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "create" || action.GetVerb() == "update" {
+			writes = append(writes, action)
+		}
+	}
+	return writes
+}
+
+func TestIsDryRun(t *testing.T) {
+	assert.False(t, IsDryRun(context.Background()))
+	assert.Nil(t, dryRunOption(context.Background()))
+
+	ctx := WithDryRun(context.Background())
+	assert.True(t, IsDryRun(ctx))
+	assert.Equal(t, []string{metav1.DryRunAll}, dryRunOption(ctx))
+}
+
+func TestRollbackDeploymentTool_DryRun(t *testing.T) {
+	client, clientset := newRolloutClient(
+		newRolloutDeployment(),
+		newRolloutReplicaSet("web-2", "2", "web:v2", 0),
+		newRolloutReplicaSet("web-3", "3", "web:v3", 3),
+	)
+	honorDryRun(clientset)
+	tool := NewRollbackDeploymentTool(client)
+	assert.True(t, tool.SupportsDryRun())
+
+	result, err := tool.Execute(WithDryRun(context.Background()), map[string]interface{}{"namespace": "shop", "name": "web"})
+	require.NoError(t, err)
+	output := result.(RollbackDeploymentOutput)
+	assert.True(t, output.DryRun)
+	assert.False(t, output.RolledBack)
+	assert.Equal(t, int64(2), output.RolledBackToRevision)
+	assert.Contains(t, output.Message, "would be rolled back from revision 3 to revision 2")
+
+	writes := writeActions(clientset)
+	require.Len(t, writes, 1, "the update is sent so the API server can validate it")
+	assert.Equal(t, []string{metav1.DryRunAll}, writes[0].(k8stesting.UpdateActionImpl).GetUpdateOptions().DryRun)
+
+	deployment, err := clientset.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web:v3", deployment.Spec.Template.Spec.Containers[0].Image, "unchanged")
+}
+
+func TestTriggerMustGatherTool_DryRun(t *testing.T) {
+	clientset := fake.NewClientset()
+	honorDryRun(clientset)
+	tool := NewTriggerMustGatherTool(clients.NewK8sClientWithClientset(clientset), testMustGatherConfig)
+	assert.True(t, tool.SupportsDryRun())
+
+	result, err := tool.Execute(WithDryRun(context.Background()), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(TriggerMustGatherOutput)
+	assert.True(t, output.DryRun)
+	assert.Contains(t, output.Message, "would be started in support")
+
+	writes := writeActions(clientset)
+	require.Len(t, writes, 1)
+	assert.Equal(t, []string{metav1.DryRunAll}, writes[0].(k8stesting.CreateActionImpl).GetCreateOptions().DryRun)
+
+	jobs, err := clientset.BatchV1().Jobs("support").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, jobs.Items, "no job was created")
+}
+
+func TestTriggerRemediationTool_DryRun(t *testing.T) {
+	ce, _, triggerCalls := newPlaybookEngine(t, http.StatusOK)
+	tool := NewTriggerRemediationTool(ce, nil)
+	assert.True(t, tool.SupportsDryRun())
+
+	result, err := tool.Execute(WithDryRun(context.Background()), map[string]interface{}{
+		"incident_id":   "inc-1",
+		"namespace":     "payments",
+		"resource_name": "api",
+		"resource_kind": "Deployment",
+		"issue_type":    "pod_crash",
+		"severity":      "high",
+		"playbook":      "rollout-restart",
+	})
+	require.NoError(t, err)
+	output := result.(TriggerRemediationOutput)
+	assert.True(t, output.DryRun)
+	assert.Empty(t, output.WorkflowID)
+	require.NotNil(t, output.Request)
+	assert.Equal(t, "rollout-restart", output.Request.Playbook)
+	assert.Equal(t, "api", output.Request.Resource.Name)
+	assert.Contains(t, output.Message, "would run rollout-restart against Deployment payments/api")
+	assert.Zero(t, *triggerCalls, "nothing was sent to the engine")
+
+	// Validation still applies
+	_, err = tool.Execute(WithDryRun(context.Background()), map[string]interface{}{"incident_id": "inc-1"})
+	assert.True(t, IsInvalidArguments(err))
+}
+
+func TestCreateIncidentTool_DryRun(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"incident_id":"inc-7","title":"Crash loop in payments","severity":"high","status":"pending"}`))
+	}))
+	defer server.Close()
+	tool := NewCreateIncidentTool(testutil.NewCoordinationEngineClient(t, server.URL))
+	assert.True(t, tool.SupportsDryRun())
+
+	args := map[string]interface{}{
+		"title":              "Crash loop in payments",
+		"description":        "api pods restart every few minutes",
+		"severity":           "high",
+		"affected_resources": []interface{}{"payments/api-0"},
+		"finding_type":       "crashloop",
+	}
+	result, err := tool.Execute(WithDryRun(context.Background()), args)
+	require.NoError(t, err)
+	output := result.(CreateIncidentOutput)
+	assert.True(t, output.DryRun)
+	assert.Empty(t, output.IncidentID)
+	require.NotNil(t, output.Request)
+	assert.Equal(t, "crashloop", output.Request.Labels["finding_type"])
+	assert.Zero(t, calls.Load(), "nothing was sent to the engine")
+
+	// A dry-run is not remembered for deduplication
+	result, err = tool.Execute(context.Background(), args)
+	require.NoError(t, err)
+	assert.False(t, result.(CreateIncidentOutput).Deduplicated)
+	assert.Equal(t, int32(1), calls.Load())
+
+	// but predicts that a retry returns the existing incident
+	result, err = tool.Execute(WithDryRun(context.Background()), args)
+	require.NoError(t, err)
+	output = result.(CreateIncidentOutput)
+	assert.True(t, output.DryRun)
+	assert.True(t, output.Deduplicated)
+	assert.Equal(t, "inc-7", output.IncidentID)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Failure classes of tool errors. Tools wrap client errors with apiError or
+// dependencyError so that callers can test the class with errors.Is and
+// decide whether to retry, back off, or report missing permissions.
+var (
+	ErrInvalidArgs         = errors.New("invalid arguments")
+	ErrForbidden           = errors.New("forbidden")
+	ErrNotFound            = errors.New("not found")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrTimeout             = errors.New("timeout")
+)
+
+// failureClasses lists the classes in the order ErrorClass checks them
+var failureClasses = []error{ErrInvalidArgs, ErrForbidden, ErrNotFound, ErrUpstreamUnavailable, ErrTimeout}
+
+// InvalidArgumentsError reports tool arguments that failed validation.
+// It lets callers tell bad input apart from upstream failures.
+type InvalidArgumentsError struct {
+	Err error
+}
+
+func (e *InvalidArgumentsError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidArgumentsError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every InvalidArgumentsError match ErrInvalidArgs
+func (e *InvalidArgumentsError) Is(target error) bool {
+	return target == ErrInvalidArgs
+}
+
+// invalidArgs creates an InvalidArgumentsError with a formatted message
+func invalidArgs(format string, a ...interface{}) error {
+	return &InvalidArgumentsError{Err: fmt.Errorf(format, a...)}
+}
+
+// IsInvalidArguments reports whether err was caused by invalid tool arguments
+func IsInvalidArguments(err error) bool {
+	return errors.Is(err, ErrInvalidArgs)
+}
+
+// classifiedError adds a failure class to an error without changing its message
+type classifiedError struct {
+	class error
+	err   error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return []error{e.class, e.err}
+}
+
+// ErrorClass returns the failure class of err, or nil when it has none
+func ErrorClass(err error) error {
+	for _, class := range failureClasses {
+		if errors.Is(err, class) {
+			return class
+		}
+	}
+	return nil
+}
+
+// apiError classifies an error from the Kubernetes API. Errors that match no
+// class are returned unchanged.
+func apiError(err error) error {
+	if err == nil || ErrorClass(err) != nil {
+		return err
+	}
+
+	var class error
+	switch {
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		class = ErrForbidden
+	case apierrors.IsNotFound(err):
+		class = ErrNotFound
+	case apierrors.IsBadRequest(err), apierrors.IsInvalid(err):
+		class = ErrInvalidArgs
+	case isTimeout(err), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		class = ErrTimeout
+	case apierrors.IsServiceUnavailable(err), apierrors.IsTooManyRequests(err),
+		apierrors.IsInternalError(err), isConnectionError(err):
+		class = ErrUpstreamUnavailable
+	default:
+		return err
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+// dependencyError classifies an error from the Coordination Engine, KServe,
+// or Prometheus. Besides timeouts and the status codes that name a caller
+// problem, any failure means the dependency is unavailable.
+func dependencyError(err error) error {
+	if err == nil || ErrorClass(err) != nil {
+		return err
+	}
+
+	class := ErrUpstreamUnavailable
+	if errors.Is(err, clients.ErrNotFound) {
+		class = ErrNotFound
+	} else if isTimeout(err) {
+		class = ErrTimeout
+	} else if status, ok := clients.HTTPStatusCode(err); ok {
+		switch status {
+		case http.StatusBadRequest, http.StatusUnprocessableEntity:
+			class = ErrInvalidArgs
+		case http.StatusUnauthorized, http.StatusForbidden:
+			class = ErrForbidden
+		case http.StatusNotFound:
+			class = ErrNotFound
+		case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+			class = ErrTimeout
+		}
+	}
+	return &classifiedError{class: class, err: err}
+}
+
+// isTimeout reports whether err is a deadline or a network timeout
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// isConnectionError reports whether err is a failure to reach the server
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	return errors.Is(err, syscall.ECONNREFUSED) || errors.As(err, &opErr)
+}
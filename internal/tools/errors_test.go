@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestAPIError_Classes(t *testing.T) {
+	pods := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "forbidden", err: apierrors.NewForbidden(pods, "", errors.New("no RBAC")), want: ErrForbidden},
+		{name: "unauthorized", err: apierrors.NewUnauthorized("token expired"), want: ErrForbidden},
+		{name: "not found", err: apierrors.NewNotFound(pods, "api-0"), want: ErrNotFound},
+		{name: "bad request", err: apierrors.NewBadRequest("invalid label selector"), want: ErrInvalidArgs},
+		{name: "server timeout", err: apierrors.NewTimeoutError("list pods", 1), want: ErrTimeout},
+		{name: "deadline", err: context.DeadlineExceeded, want: ErrTimeout},
+		{name: "unavailable", err: apierrors.NewServiceUnavailable("apiserver restarting"), want: ErrUpstreamUnavailable},
+		{name: "throttled", err: apierrors.NewTooManyRequests("slow down", 1), want: ErrUpstreamUnavailable},
+		{name: "unclassified", err: errors.New("decode failed"), want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := apiError(fmt.Errorf("failed to list pods: %w", tt.err))
+			assert.Equal(t, tt.want, ErrorClass(err))
+			assert.Equal(t, "failed to list pods: "+tt.err.Error(), err.Error(), "the message is unchanged")
+			assert.ErrorIs(t, err, tt.err, "the client error stays reachable")
+		})
+	}
+
+	assert.NoError(t, apiError(nil))
+	assert.True(t, IsInvalidArguments(invalidArgs("limit must be positive")))
+	assert.Equal(t, ErrInvalidArgs, ErrorClass(apiError(invalidArgs("limit must be positive"))), "a classified error keeps its class")
+}
+
+func TestDependencyError_Classes(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{name: "not found", status: http.StatusNotFound, want: ErrNotFound},
+		{name: "forbidden", status: http.StatusForbidden, want: ErrForbidden},
+		{name: "unprocessable", status: http.StatusUnprocessableEntity, want: ErrInvalidArgs},
+		{name: "gateway timeout", status: http.StatusGatewayTimeout, want: ErrTimeout},
+		{name: "unavailable", status: http.StatusServiceUnavailable, want: ErrUpstreamUnavailable},
+		{name: "internal error", status: http.StatusInternalServerError, want: ErrUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ce := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+			defer ce.Close()
+
+			_, err := testutil.NewCoordinationEngineClient(t, ce.URL).ListIncidents(context.Background(), "", "", 0, 0)
+			require.Error(t, err)
+			assert.Equal(t, tt.want, ErrorClass(dependencyError(err)))
+		})
+	}
+
+	t.Run("unreachable", func(t *testing.T) {
+		ce := httptest.NewServer(http.NotFoundHandler())
+		ce.Close()
+		_, err := testutil.NewCoordinationEngineClient(t, ce.URL).ListIncidents(context.Background(), "", "", 0, 0)
+		require.Error(t, err)
+		assert.Equal(t, ErrUpstreamUnavailable, ErrorClass(dependencyError(err)))
+	})
+}
+
+func TestTools_ClassifyFailures(t *testing.T) {
+	t.Run("list-pods without RBAC", func(t *testing.T) {
+		clientset := fake.NewClientset()
+		clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no RBAC"))
+		})
+		tool := NewListPodsTool(clients.NewK8sClientWithClientset(clientset), nil)
+
+		_, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "payments"})
+		assert.ErrorIs(t, err, ErrForbidden)
+	})
+
+	t.Run("correlate-incident for a missing incident", func(t *testing.T) {
+		ce := httptest.NewServer(http.NotFoundHandler())
+		defer ce.Close()
+		tool := NewCorrelateIncidentTool(testutil.NewCoordinationEngineClient(t, ce.URL), clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+		_, err := tool.Execute(context.Background(), map[string]interface{}{"incident_id": "inc-missing"})
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Contains(t, err.Error(), "incident inc-missing not found")
+	})
+
+	t.Run("list-incidents with the engine down", func(t *testing.T) {
+		ce := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ce.Close()
+		tool := NewListIncidentsTool(testutil.NewCoordinationEngineClient(t, ce.URL))
+
+		_, err := tool.Execute(context.Background(), map[string]interface{}{})
+		assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+	})
+}
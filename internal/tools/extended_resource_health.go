@@ -0,0 +1,348 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// failedSchedulingReason is the scheduler event reason for pods that could not be placed
+const failedSchedulingReason = "FailedScheduling"
+
+// Extended resource statuses reported by get-extended-resource-health
+const (
+	ExtendedResourceHealthy       = "healthy"
+	ExtendedResourceDegraded      = "degraded"       // A node advertises the resource but cannot offer it
+	ExtendedResourceExhausted     = "exhausted"      // Pods are pending because no node has enough of it
+	ExtendedResourceNotAdvertised = "not_advertised" // No node has the resource in its capacity
+)
+
+// GetExtendedResourceHealthTool reports capacity, requests, and device plugin health for
+// extended resources such as GPUs and huge pages
+type GetExtendedResourceHealthTool struct {
+	k8sClient clients.ClusterReader
+	resources []string
+}
+
+// NewGetExtendedResourceHealthTool creates a new get-extended-resource-health tool
+func NewGetExtendedResourceHealthTool(k8sClient clients.ClusterReader, resources []string) *GetExtendedResourceHealthTool {
+	return &GetExtendedResourceHealthTool{
+		k8sClient: k8sClient,
+		resources: resources,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetExtendedResourceHealthTool) Name() string {
+	return "get-extended-resource-health"
+}
+
+// Description returns the tool description for MCP
+func (t *GetExtendedResourceHealthTool) Description() string {
+	return fmt.Sprintf(`Check extended resources such as GPUs and huge pages (%s by default). For every node that advertises a resource it reports capacity, allocatable, and requested amounts, and flags nodes whose device plugin is broken (capacity present but allocatable zero, or the device plugin pod not ready or crashlooping). Pods that the scheduler could not place because of "Insufficient <resource>" are listed per resource.
+
+Use this tool for questions like:
+- "Why is my GPU workload pending?"
+- "How many GPUs are free?"
+- "Is the NVIDIA device plugin healthy on every GPU node?"`, strings.Join(t.resources, ", "))
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetExtendedResourceHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"resource": map[string]interface{}{
+				"type":        "string",
+				"description": "Check only this resource (e.g. 'nvidia.com/gpu'). Leave empty for all configured resources.",
+				"default":     "",
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetExtendedResourceHealthInput represents the input parameters
+type GetExtendedResourceHealthInput struct {
+	Resource string `json:"resource"`
+}
+
+// NodeExtendedResource is one node's accounting for an extended resource
+type NodeExtendedResource struct {
+	Node            string `json:"node"`
+	Capacity        string `json:"capacity"`
+	Allocatable     string `json:"allocatable"`
+	Requested       string `json:"requested"`
+	Available       string `json:"available"`
+	Healthy         bool   `json:"healthy"`
+	Problem         string `json:"problem,omitempty"`
+	DevicePluginPod string `json:"device_plugin_pod,omitempty"` // namespace/name
+}
+
+// PendingExtendedResourcePod is a pod the scheduler could not place for lack of the resource
+type PendingExtendedResourcePod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Requested string `json:"requested"`
+	Message   string `json:"message"`
+	LastSeen  string `json:"last_seen"`
+}
+
+// ExtendedResourceReport summarizes one extended resource across the cluster
+type ExtendedResourceReport struct {
+	Resource       string                       `json:"resource"`
+	Status         string                       `json:"status"`
+	Capacity       string                       `json:"capacity"`
+	Allocatable    string                       `json:"allocatable"`
+	Requested      string                       `json:"requested"`
+	Available      string                       `json:"available"`
+	UnhealthyNodes int                          `json:"unhealthy_nodes"`
+	Nodes          []NodeExtendedResource       `json:"nodes"`
+	PendingPods    []PendingExtendedResourcePod `json:"pending_pods"`
+}
+
+// GetExtendedResourceHealthOutput represents the tool output
+type GetExtendedResourceHealthOutput struct {
+	Status    string                   `json:"status"`
+	Resources []ExtendedResourceReport `json:"resources"`
+}
+
+// RequiredPermissions declares the Kubernetes API access get-extended-resource-health needs
+func (t *GetExtendedResourceHealthTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Resource: "events", Verb: "list"},
+	}
+}
+
+// Execute runs the get-extended-resource-health operation
+func (t *GetExtendedResourceHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input GetExtendedResourceHealthInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	resources := t.resources
+	if input.Resource != "" {
+		resources = []string{input.Resource}
+	}
+	if len(resources) == 0 {
+		return nil, invalidArgs("no extended resources configured; set EXTENDED_RESOURCES or pass resource")
+	}
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	pods, err := t.k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return nil, apiError(err)
+	}
+	events, err := t.k8sClient.Clientset().CoreV1().Events("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("reason", failedSchedulingReason).String(),
+	})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list scheduling events: %w", err))
+	}
+
+	output := GetExtendedResourceHealthOutput{Status: ExtendedResourceHealthy}
+	for _, name := range resources {
+		report := accountExtendedResource(corev1.ResourceName(name), nodes.Items, pods.Items, events.Items)
+		if report.Status == ExtendedResourceDegraded || report.Status == ExtendedResourceExhausted {
+			output.Status = ExtendedResourceDegraded
+		}
+		output.Resources = append(output.Resources, report)
+	}
+	return output, nil
+}
+
+// accountExtendedResource computes per-node capacity, allocatable, and requested amounts of
+// one resource, flags nodes whose device plugin is not serving it, and lists unscheduled pods
+// whose latest FailedScheduling event reports "Insufficient <resource>". Nodes that neither
+// advertise nor run pods requesting the resource are omitted; unhealthy nodes come first.
+func accountExtendedResource(name corev1.ResourceName, nodes []corev1.Node, pods []corev1.Pod, events []corev1.Event) ExtendedResourceReport {
+	requested := make(map[string]*resource.Quantity, len(nodes))
+	plugins := make(map[string]*corev1.Pod)
+	podsByKey := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		podsByKey[pod.Namespace+"/"+pod.Name] = pod
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if isDevicePluginPod(pod, name) {
+			plugins[pod.Spec.NodeName] = pod
+		}
+		request := podResourceRequest(pod, name)
+		if request.IsZero() {
+			continue
+		}
+		if requested[pod.Spec.NodeName] == nil {
+			requested[pod.Spec.NodeName] = &resource.Quantity{}
+		}
+		requested[pod.Spec.NodeName].Add(request)
+	}
+
+	report := ExtendedResourceReport{
+		Resource:    string(name),
+		Nodes:       []NodeExtendedResource{},
+		PendingPods: []PendingExtendedResourcePod{},
+	}
+	var totalCapacity, totalAllocatable, totalRequested resource.Quantity
+	for i := range nodes {
+		node := &nodes[i]
+		capacity := node.Status.Capacity[name]
+		allocatable := node.Status.Allocatable[name]
+		nodeRequested := resource.Quantity{}
+		if q := requested[node.Name]; q != nil {
+			nodeRequested = *q
+		}
+		plugin := plugins[node.Name]
+		if capacity.IsZero() && nodeRequested.IsZero() && plugin == nil {
+			continue
+		}
+
+		available := allocatable.DeepCopy()
+		available.Sub(nodeRequested)
+		entry := NodeExtendedResource{
+			Node:        node.Name,
+			Capacity:    capacity.String(),
+			Allocatable: allocatable.String(),
+			Requested:   nodeRequested.String(),
+			Available:   available.String(),
+			Healthy:     true,
+		}
+
+		var problems []string
+		if !capacity.IsZero() && allocatable.IsZero() {
+			problems = append(problems, fmt.Sprintf("capacity is %s but allocatable is 0; the device plugin is not offering any devices", capacity.String()))
+		}
+		if plugin != nil {
+			entry.DevicePluginPod = plugin.Namespace + "/" + plugin.Name
+			if !podReady(plugin) {
+				problems = append(problems, fmt.Sprintf("device plugin pod %s is not ready (%s)", plugin.Name, podNotReadyReason(plugin)))
+			}
+		}
+		if len(problems) > 0 {
+			entry.Healthy = false
+			entry.Problem = strings.Join(problems, "; ")
+			report.UnhealthyNodes++
+		}
+
+		totalCapacity.Add(capacity)
+		totalAllocatable.Add(allocatable)
+		totalRequested.Add(nodeRequested)
+		report.Nodes = append(report.Nodes, entry)
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool {
+		if report.Nodes[i].Healthy != report.Nodes[j].Healthy {
+			return !report.Nodes[i].Healthy
+		}
+		return report.Nodes[i].Node < report.Nodes[j].Node
+	})
+
+	totalAvailable := totalAllocatable.DeepCopy()
+	totalAvailable.Sub(totalRequested)
+	report.Capacity = totalCapacity.String()
+	report.Allocatable = totalAllocatable.String()
+	report.Requested = totalRequested.String()
+	report.Available = totalAvailable.String()
+
+	// Keep the latest scheduler verdict per pod; older events may predate a fix
+	latest := make(map[string]*corev1.Event)
+	for i := range events {
+		event := &events[i]
+		if event.Reason != failedSchedulingReason || event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		key := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+		if current, ok := latest[key]; !ok || eventLastSeen(event).After(eventLastSeen(current)) {
+			latest[key] = event
+		}
+	}
+	insufficient := "Insufficient " + string(name)
+	for key, event := range latest {
+		pod, ok := podsByKey[key]
+		if !ok || pod.Spec.NodeName != "" || pod.Status.Phase != corev1.PodPending || !strings.Contains(event.Message, insufficient) {
+			continue
+		}
+		request := podResourceRequest(pod, name)
+		report.PendingPods = append(report.PendingPods, PendingExtendedResourcePod{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			Requested: request.String(),
+			Message:   event.Message,
+			LastSeen:  eventLastSeen(event).UTC().Format(time.RFC3339),
+		})
+	}
+	sort.Slice(report.PendingPods, func(i, j int) bool {
+		if report.PendingPods[i].Namespace != report.PendingPods[j].Namespace {
+			return report.PendingPods[i].Namespace < report.PendingPods[j].Namespace
+		}
+		return report.PendingPods[i].Name < report.PendingPods[j].Name
+	})
+
+	switch {
+	case report.UnhealthyNodes > 0:
+		report.Status = ExtendedResourceDegraded
+	case len(report.PendingPods) > 0:
+		report.Status = ExtendedResourceExhausted
+	case totalCapacity.IsZero():
+		report.Status = ExtendedResourceNotAdvertised
+	default:
+		report.Status = ExtendedResourceHealthy
+	}
+	return report
+}
+
+// podResourceRequest returns the amount of a resource the scheduler reserves for a pod:
+// the larger of the summed app container requests and any single init container request,
+// plus pod overhead. Limits stand in for requests, as they do for extended resources.
+func podResourceRequest(pod *corev1.Pod, name corev1.ResourceName) resource.Quantity {
+	total := resource.Quantity{}
+	for i := range pod.Spec.Containers {
+		total.Add(containerResourceRequest(&pod.Spec.Containers[i], name))
+	}
+	for i := range pod.Spec.InitContainers {
+		if request := containerResourceRequest(&pod.Spec.InitContainers[i], name); request.Cmp(total) > 0 {
+			total = request
+		}
+	}
+	if overhead, ok := pod.Spec.Overhead[name]; ok {
+		total.Add(overhead)
+	}
+	return total
+}
+
+// containerResourceRequest returns a container's request for a resource, falling back to its limit
+func containerResourceRequest(container *corev1.Container, name corev1.ResourceName) resource.Quantity {
+	if request, ok := container.Resources.Requests[name]; ok {
+		return request.DeepCopy()
+	}
+	if limit, ok := container.Resources.Limits[name]; ok {
+		return limit.DeepCopy()
+	}
+	return resource.Quantity{}
+}
+
+// isDevicePluginPod reports whether a pod looks like the device plugin serving a vendor
+// resource, e.g. nvidia-device-plugin-daemonset-x7k2p for nvidia.com/gpu. Resources
+// without a vendor domain (hugepages-2Mi) are served by the kubelet and have no plugin.
+func isDevicePluginPod(pod *corev1.Pod, name corev1.ResourceName) bool {
+	domain, _, ok := strings.Cut(string(name), "/")
+	if !ok {
+		return false
+	}
+	vendor, _, _ := strings.Cut(domain, ".")
+	return strings.Contains(pod.Name, "device-plugin") && strings.Contains(pod.Name, vendor)
+}
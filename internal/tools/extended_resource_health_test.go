@@ -0,0 +1,272 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+const gpuResource = corev1.ResourceName("nvidia.com/gpu")
+
+func newGPUNode(name, capacity, allocatable string) corev1.Node {
+	node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if capacity != "" {
+		node.Status.Capacity = corev1.ResourceList{gpuResource: resource.MustParse(capacity)}
+		node.Status.Allocatable = corev1.ResourceList{gpuResource: resource.MustParse(allocatable)}
+	}
+	return node
+}
+
+func newGPUPod(namespace, name, node, gpus string, phase corev1.PodPhase) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PodSpec{
+			NodeName:   node,
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+	if gpus != "" {
+		pod.Spec.Containers[0].Resources.Limits = corev1.ResourceList{gpuResource: resource.MustParse(gpus)}
+	}
+	return pod
+}
+
+func newDevicePluginPod(node string, ready bool) corev1.Pod {
+	status := corev1.ConditionTrue
+	var state corev1.ContainerState
+	if !ready {
+		status = corev1.ConditionFalse
+		state.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "nvidia-device-plugin-daemonset-" + node, Namespace: "nvidia-gpu-operator"},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "nvidia-device-plugin", State: state}},
+		},
+	}
+}
+
+func newSchedulingEvent(namespace, pod, message string, last time.Time) corev1.Event {
+	return corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: pod + "." + last.Format("150405"), Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: pod},
+		Reason:         failedSchedulingReason,
+		Type:           corev1.EventTypeWarning,
+		Message:        message,
+		FirstTimestamp: metav1.NewTime(last),
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func TestAccountExtendedResource(t *testing.T) {
+	now := time.Now()
+	insufficient := "0/3 nodes are available: 1 node(s) had untolerated taint, 2 Insufficient nvidia.com/gpu."
+
+	tests := []struct {
+		name           string
+		nodes          []corev1.Node
+		pods           []corev1.Pod
+		events         []corev1.Event
+		wantStatus     string
+		wantTotals     [4]string // capacity, allocatable, requested, available
+		wantNodes      []string
+		wantUnhealthy  map[string]string // node -> problem substring
+		wantPendingPod []string
+	}{
+		{
+			name: "requests summed per node, terminated and non-GPU pods ignored",
+			nodes: []corev1.Node{
+				newGPUNode("gpu-0", "4", "4"),
+				newGPUNode("gpu-1", "2", "2"),
+				newGPUNode("cpu-0", "", ""),
+			},
+			pods: []corev1.Pod{
+				newGPUPod("ml", "train-a", "gpu-0", "2", corev1.PodRunning),
+				newGPUPod("ml", "train-b", "gpu-0", "1", corev1.PodRunning),
+				newGPUPod("ml", "train-done", "gpu-0", "1", corev1.PodSucceeded),
+				newGPUPod("web", "frontend", "cpu-0", "", corev1.PodRunning),
+				newDevicePluginPod("gpu-0", true),
+				newDevicePluginPod("gpu-1", true),
+			},
+			wantStatus: ExtendedResourceHealthy,
+			wantTotals: [4]string{"6", "6", "3", "3"},
+			wantNodes:  []string{"gpu-0", "gpu-1"},
+		},
+		{
+			name: "capacity without allocatable and a crashlooping plugin are unhealthy",
+			nodes: []corev1.Node{
+				newGPUNode("gpu-0", "4", "4"),
+				newGPUNode("gpu-1", "4", "0"),
+				newGPUNode("gpu-2", "4", "4"),
+			},
+			pods: []corev1.Pod{
+				newDevicePluginPod("gpu-0", true),
+				newDevicePluginPod("gpu-1", true),
+				newDevicePluginPod("gpu-2", false),
+			},
+			wantStatus: ExtendedResourceDegraded,
+			wantTotals: [4]string{"12", "8", "0", "8"},
+			wantNodes:  []string{"gpu-1", "gpu-2", "gpu-0"},
+			wantUnhealthy: map[string]string{
+				"gpu-1": "allocatable is 0",
+				"gpu-2": "not ready (CrashLoopBackOff)",
+			},
+		},
+		{
+			name:  "pods pending on insufficient GPUs use the latest scheduler event",
+			nodes: []corev1.Node{newGPUNode("gpu-0", "1", "1")},
+			pods: []corev1.Pod{
+				newGPUPod("ml", "train-a", "gpu-0", "1", corev1.PodRunning),
+				newGPUPod("ml", "train-b", "", "1", corev1.PodPending),
+				newGPUPod("ml", "train-fixed", "", "1", corev1.PodPending),
+				newGPUPod("ml", "train-scheduled", "gpu-0", "1", corev1.PodPending),
+				newGPUPod("web", "frontend", "", "", corev1.PodPending),
+			},
+			events: []corev1.Event{
+				newSchedulingEvent("ml", "train-b", insufficient, now.Add(-time.Minute)),
+				newSchedulingEvent("ml", "train-fixed", insufficient, now.Add(-10*time.Minute)),
+				newSchedulingEvent("ml", "train-fixed", "0/3 nodes are available: 3 node(s) didn't match pod anti-affinity rules.", now.Add(-time.Minute)),
+				newSchedulingEvent("ml", "train-scheduled", insufficient, now.Add(-time.Minute)),
+				newSchedulingEvent("web", "frontend", "0/3 nodes are available: 3 Insufficient cpu.", now.Add(-time.Minute)),
+				newSchedulingEvent("ml", "deleted", insufficient, now.Add(-time.Minute)),
+			},
+			wantStatus:     ExtendedResourceExhausted,
+			wantTotals:     [4]string{"1", "1", "2", "-1"},
+			wantNodes:      []string{"gpu-0"},
+			wantPendingPod: []string{"ml/train-b"},
+		},
+		{
+			name:       "resource not advertised anywhere",
+			nodes:      []corev1.Node{newGPUNode("cpu-0", "", "")},
+			pods:       []corev1.Pod{newGPUPod("web", "frontend", "cpu-0", "", corev1.PodRunning)},
+			wantStatus: ExtendedResourceNotAdvertised,
+			wantTotals: [4]string{"0", "0", "0", "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := accountExtendedResource(gpuResource, tt.nodes, tt.pods, tt.events)
+
+			assert.Equal(t, string(gpuResource), report.Resource)
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.wantTotals, [4]string{report.Capacity, report.Allocatable, report.Requested, report.Available})
+			assert.Equal(t, len(tt.wantUnhealthy), report.UnhealthyNodes)
+
+			var nodes []string
+			for _, node := range report.Nodes {
+				nodes = append(nodes, node.Node)
+				if problem, ok := tt.wantUnhealthy[node.Node]; ok {
+					assert.False(t, node.Healthy, node.Node)
+					assert.Contains(t, node.Problem, problem)
+				} else {
+					assert.True(t, node.Healthy, node.Node)
+				}
+			}
+			assert.Equal(t, tt.wantNodes, nodes)
+
+			var pending []string
+			for _, pod := range report.PendingPods {
+				pending = append(pending, pod.Namespace+"/"+pod.Name)
+				assert.Contains(t, pod.Message, "Insufficient nvidia.com/gpu")
+			}
+			assert.Equal(t, tt.wantPendingPod, pending)
+		})
+	}
+}
+
+func TestAccountExtendedResource_HugePages(t *testing.T) {
+	hugePages := corev1.ResourceName("hugepages-2Mi")
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-0"},
+		Status: corev1.NodeStatus{
+			Capacity:    corev1.ResourceList{hugePages: resource.MustParse("1Gi")},
+			Allocatable: corev1.ResourceList{hugePages: resource.MustParse("1Gi")},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "dpdk", Namespace: "net"},
+		Spec: corev1.PodSpec{
+			NodeName: "worker-0",
+			InitContainers: []corev1.Container{{
+				Name:      "setup",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{hugePages: resource.MustParse("512Mi")}},
+			}},
+			Containers: []corev1.Container{{
+				Name:      "app",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{hugePages: resource.MustParse("256Mi")}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	// The nvidia plugin pod must not be tied to a kubelet-managed resource
+	report := accountExtendedResource(hugePages, []corev1.Node{node}, []corev1.Pod{pod, newDevicePluginPod("worker-0", false)}, nil)
+
+	assert.Equal(t, ExtendedResourceHealthy, report.Status)
+	require.Len(t, report.Nodes, 1)
+	assert.Equal(t, "512Mi", report.Nodes[0].Requested)
+	assert.Equal(t, "512Mi", report.Nodes[0].Available)
+	assert.Empty(t, report.Nodes[0].DevicePluginPod)
+}
+
+func TestGetExtendedResourceHealthTool_Execute(t *testing.T) {
+	gpu0 := newGPUNode("gpu-0", "2", "2")
+	gpu1 := newGPUNode("gpu-1", "2", "0")
+	running := newGPUPod("ml", "train-a", "gpu-0", "2", corev1.PodRunning)
+	pending := newGPUPod("ml", "train-b", "", "1", corev1.PodPending)
+	plugin := newDevicePluginPod("gpu-1", false)
+	event := newSchedulingEvent("ml", "train-b", "0/2 nodes are available: 2 Insufficient nvidia.com/gpu.", time.Now())
+	client := clients.NewK8sClientWithClientset(fake.NewClientset([]runtime.Object{&gpu0, &gpu1, &running, &pending, &plugin, &event}...))
+
+	tool := NewGetExtendedResourceHealthTool(client, []string{"nvidia.com/gpu", "hugepages-1Gi"})
+	assert.Equal(t, "get-extended-resource-health", tool.Name())
+	assert.Contains(t, tool.Description(), "nvidia.com/gpu, hugepages-1Gi")
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetExtendedResourceHealthOutput)
+
+	assert.Equal(t, ExtendedResourceDegraded, output.Status)
+	require.Len(t, output.Resources, 2)
+
+	gpus := output.Resources[0]
+	assert.Equal(t, ExtendedResourceDegraded, gpus.Status)
+	assert.Equal(t, 1, gpus.UnhealthyNodes)
+	assert.Equal(t, "gpu-1", gpus.Nodes[0].Node)
+	assert.Equal(t, "nvidia-gpu-operator/nvidia-device-plugin-daemonset-gpu-1", gpus.Nodes[0].DevicePluginPod)
+	require.Len(t, gpus.PendingPods, 1)
+	assert.Equal(t, "train-b", gpus.PendingPods[0].Name)
+	assert.Equal(t, "1", gpus.PendingPods[0].Requested)
+
+	assert.Equal(t, "hugepages-1Gi", output.Resources[1].Resource)
+	assert.Equal(t, ExtendedResourceNotAdvertised, output.Resources[1].Status)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"resource": "hugepages-1Gi"})
+	require.NoError(t, err)
+	output = result.(GetExtendedResourceHealthOutput)
+	assert.Equal(t, ExtendedResourceHealthy, output.Status)
+	require.Len(t, output.Resources, 1)
+}
+
+func TestGetExtendedResourceHealthTool_NoResources(t *testing.T) {
+	tool := NewGetExtendedResourceHealthTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
@@ -0,0 +1,603 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Controller health states reported for each remaining finalizer
+const (
+	FinalizerControllerHealthy   = "healthy"   // At least one controller pod is ready
+	FinalizerControllerUnhealthy = "unhealthy" // Controller pods exist but none is ready
+	FinalizerControllerMissing   = "missing"   // No controller pods found; the finalizer will not clear on its own
+	FinalizerControllerUnknown   = "unknown"   // Controller not identified, or not visible from inside the cluster
+)
+
+// finalizerController describes the controller responsible for removing a finalizer
+type finalizerController struct {
+	Name         string   // Human-readable controller name
+	Namespaces   []string // Namespaces its pods run in, across distributions
+	PodPrefix    string   // Name prefix of its pods
+	ControlPlane bool     // Runs on the control plane, which managed clusters do not expose as pods
+}
+
+var (
+	kubeControllerManager = finalizerController{
+		Name:         "kube-controller-manager",
+		Namespaces:   []string{"kube-system", "openshift-kube-controller-manager"},
+		PodPrefix:    "kube-controller-manager",
+		ControlPlane: true,
+	}
+	kubeAPIServer = finalizerController{
+		Name:         "kube-apiserver",
+		Namespaces:   []string{"kube-system", "openshift-kube-apiserver"},
+		PodPrefix:    "kube-apiserver",
+		ControlPlane: true,
+	}
+)
+
+// knownFinalizerControllers maps finalizers to the controller that removes them. Keys
+// ending in "/" match any finalizer with that prefix; all others match exactly.
+var knownFinalizerControllers = []struct {
+	finalizer  string
+	controller finalizerController
+}{
+	{"kubernetes", kubeControllerManager},
+	{"foregroundDeletion", kubeControllerManager},
+	{"orphan", kubeControllerManager},
+	{"kubernetes.io/pvc-protection", kubeControllerManager},
+	{"kubernetes.io/pv-protection", kubeControllerManager},
+	{"kubernetes.io/pv-controller", kubeControllerManager},
+	{"batch.kubernetes.io/job-tracking", kubeControllerManager},
+	{"customresourcecleanup.apiextensions.k8s.io", kubeAPIServer},
+	{"snapshot.storage.kubernetes.io/", finalizerController{
+		Name:       "csi-snapshot-controller",
+		Namespaces: []string{"kube-system", "openshift-cluster-storage-operator"},
+		PodPrefix:  "csi-snapshot-controller",
+	}},
+	{"machine.machine.openshift.io", finalizerController{
+		Name:       "machine-api-controllers",
+		Namespaces: []string{"openshift-machine-api"},
+		PodPrefix:  "machine-api-controllers",
+	}},
+	{"inferenceservice.finalizers", finalizerController{
+		Name:       "kserve-controller-manager",
+		Namespaces: []string{"kserve", "redhat-ods-applications", "opendatahub"},
+		PodPrefix:  "kserve-controller-manager",
+	}},
+}
+
+// genericDomainLabels are finalizer domain labels too common to identify a controller
+var genericDomainLabels = map[string]bool{
+	"io": true, "com": true, "org": true, "net": true, "dev": true, "k8s": true, "x-k8s": true,
+	"kubernetes": true, "openshift": true, "cluster": true, "operator": true, "operators": true,
+	"finalizer": true, "finalizers": true, "apps": true, "api": true, "github": true,
+}
+
+// AuditFinalizersTool reports stuck deletions and the health of the controllers that own their finalizers
+type AuditFinalizersTool struct {
+	k8sClient  *clients.K8sClient
+	extraKinds []string // Additional "apiVersion/Kind" entries to scan
+}
+
+// NewAuditFinalizersTool creates a new audit-finalizers tool. extraKinds lists additional
+// kinds to scan as "apiVersion/Kind", on top of namespaces, PVCs and CRDs.
+func NewAuditFinalizersTool(k8sClient *clients.K8sClient, extraKinds []string) *AuditFinalizersTool {
+	return &AuditFinalizersTool{
+		k8sClient:  k8sClient,
+		extraKinds: extraKinds,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *AuditFinalizersTool) Name() string {
+	return "audit-finalizers"
+}
+
+// Description returns the tool description for MCP
+func (t *AuditFinalizersTool) Description() string {
+	return `Find objects stuck in deletion and explain why. Scans namespaces, PVCs, CRDs and any kinds configured in FINALIZER_AUDIT_KINDS for objects whose deletion started more than min_stuck_minutes ago, lists their remaining finalizers, and checks whether the controller that removes each finalizer still has ready pods (from a table of known finalizers, else guessed from the finalizer domain). Also lists ReplicaSets scaled to zero with no owning Deployment as cleanup candidates. Read-only: nothing is modified.
+
+Use this tool for questions like:
+- "Why is this namespace stuck in Terminating?"
+- "Which PVCs can't be deleted?"
+- "Are there finalizers whose operator has been uninstalled?"
+- "Are there orphaned ReplicaSets I can clean up?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *AuditFinalizersTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"min_stuck_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Report objects whose deletion started at least this many minutes ago (1-10080)",
+				"default":     10,
+				"minimum":     1,
+				"maximum":     10080,
+			},
+			"orphan_days": map[string]interface{}{
+				"type":        "integer",
+				"description": "Report orphaned zero-replica ReplicaSets at least this many days old (1-365)",
+				"default":     7,
+				"minimum":     1,
+				"maximum":     365,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of stuck objects and of orphaned ReplicaSets to return (1-100)",
+				"default":     20,
+				"minimum":     1,
+				"maximum":     100,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// AuditFinalizersInput represents the input parameters
+type AuditFinalizersInput struct {
+	MinStuckMinutes int `json:"min_stuck_minutes"`
+	OrphanDays      int `json:"orphan_days"`
+	Limit           int `json:"limit"`
+}
+
+// FinalizerStatus is one remaining finalizer and the health of its controller
+type FinalizerStatus struct {
+	Finalizer        string   `json:"finalizer"`
+	Controller       string   `json:"controller,omitempty"`
+	MatchedBy        string   `json:"matched_by"` // known, domain, or none
+	ControllerStatus string   `json:"controller_status"`
+	ControllerPods   []string `json:"controller_pods,omitempty"` // namespace/name of matching pods
+	Hint             string   `json:"hint,omitempty"`
+}
+
+// StuckObject is an object whose deletion has not completed
+type StuckObject struct {
+	Kind              string            `json:"kind"`
+	APIVersion        string            `json:"api_version"`
+	Namespace         string            `json:"namespace,omitempty"`
+	Name              string            `json:"name"`
+	DeletionTimestamp time.Time         `json:"deletion_timestamp"`
+	StuckFor          string            `json:"stuck_for"`
+	Finalizers        []FinalizerStatus `json:"finalizers"`
+	Conditions        []string          `json:"conditions,omitempty"` // Namespace deletion conditions, e.g. content remaining
+}
+
+// OrphanedReplicaSet is a zero-replica ReplicaSet no Deployment owns
+type OrphanedReplicaSet struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Age       string `json:"age"`
+	Reason    string `json:"reason"`
+}
+
+// AuditFinalizersOutput represents the tool output
+type AuditFinalizersOutput struct {
+	StuckObjects        []StuckObject        `json:"stuck_objects"`
+	TotalStuck          int                  `json:"total_stuck"`
+	OrphanedReplicaSets []OrphanedReplicaSet `json:"orphaned_replicasets"`
+	TotalOrphaned       int                  `json:"total_orphaned"`
+	KindsScanned        []string             `json:"kinds_scanned"`
+	Errors              []string             `json:"errors,omitempty"` // Kinds that could not be listed
+	Summary             string               `json:"summary"`
+	Findings            []findings.Finding   `json:"findings"` // The listed objects in the common findings shape
+}
+
+// deletingObject is the part of any object the audit needs, whatever its type
+type deletingObject struct {
+	APIVersion string
+	Kind       string
+	Meta       metav1.Object
+	Conditions []string
+}
+
+// RequiredPermissions declares the Kubernetes API access audit-finalizers needs. Kinds
+// added through FINALIZER_AUDIT_KINDS additionally need list access.
+func (t *AuditFinalizersTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "namespaces", Verb: "list"},
+		{Resource: "persistentvolumeclaims", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions", Verb: "list"},
+		{Group: "apps", Resource: "replicasets", Verb: "list"},
+		{Group: "apps", Resource: "deployments", Verb: "list"},
+	}
+}
+
+// Execute runs the audit-finalizers operation
+func (t *AuditFinalizersTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := AuditFinalizersInput{MinStuckMinutes: 10, OrphanDays: 7, Limit: 20}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.MinStuckMinutes < 1 || input.MinStuckMinutes > 10080 {
+		return nil, invalidArgs("min_stuck_minutes must be between 1 and 10080")
+	}
+	if input.OrphanDays < 1 || input.OrphanDays > 365 {
+		return nil, invalidArgs("orphan_days must be between 1 and 365")
+	}
+	if input.Limit < 1 || input.Limit > 100 {
+		return nil, invalidArgs("limit must be between 1 and 100")
+	}
+
+	now := time.Now()
+	output := AuditFinalizersOutput{
+		StuckObjects:        []StuckObject{},
+		OrphanedReplicaSets: []OrphanedReplicaSet{},
+		KindsScanned:        []string{},
+	}
+
+	objects, scanned, errs := t.listDeletingObjects(ctx)
+	output.KindsScanned = scanned
+	output.Errors = errs
+
+	stuck := stuckDeletions(objects, now, time.Duration(input.MinStuckMinutes)*time.Minute)
+	if len(stuck) > 0 {
+		pods, err := t.k8sClient.ListPods(ctx, "")
+		if err != nil {
+			return nil, apiError(err)
+		}
+		for i := range stuck {
+			for j := range stuck[i].Finalizers {
+				resolveFinalizerController(&stuck[i].Finalizers[j], pods.Items)
+			}
+		}
+	}
+	output.TotalStuck = len(stuck)
+	if len(stuck) > input.Limit {
+		stuck = stuck[:input.Limit]
+	}
+	output.StuckObjects = append(output.StuckObjects, stuck...)
+
+	replicaSets, err := t.k8sClient.Clientset().AppsV1().ReplicaSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		output.Errors = append(output.Errors, fmt.Sprintf("ReplicaSet: failed to list replicasets: %v", err))
+	} else {
+		deployments, err := t.k8sClient.ListDeployments(ctx, "")
+		if err != nil {
+			return nil, apiError(err)
+		}
+		orphans := orphanedReplicaSets(replicaSets.Items, deployments.Items, now, time.Duration(input.OrphanDays)*24*time.Hour)
+		output.TotalOrphaned = len(orphans)
+		if len(orphans) > input.Limit {
+			orphans = orphans[:input.Limit]
+		}
+		output.OrphanedReplicaSets = append(output.OrphanedReplicaSets, orphans...)
+	}
+
+	output.Summary = finalizerAuditSummary(output)
+	output.Findings = finalizerAuditFindings(&output)
+	return output, nil
+}
+
+// finalizerAuditFindings converts the listed stuck deletions and orphaned
+// ReplicaSets to findings. A deletion waiting on a missing or unhealthy
+// controller will not finish on its own and is critical; other stuck
+// deletions are warnings and orphaned ReplicaSets info.
+func finalizerAuditFindings(output *AuditFinalizersOutput) []findings.Finding {
+	result := []findings.Finding{}
+	for _, obj := range output.StuckObjects {
+		finding := findings.Finding{
+			Severity:  findings.SeverityWarning,
+			Category:  findings.CategoryWorkload,
+			Resource:  resourceRef(obj.Kind, obj.Namespace, obj.Name),
+			Title:     "Deletion is stuck on finalizers",
+			FirstSeen: obj.DeletionTimestamp,
+			Source:    "audit-finalizers",
+		}
+		if obj.Kind == "PersistentVolumeClaim" {
+			finding.Category = findings.CategoryStorage
+		}
+		waiting := make([]string, 0, len(obj.Finalizers))
+		for _, status := range obj.Finalizers {
+			waiting = append(waiting, fmt.Sprintf("%s (controller %s)", status.Finalizer, status.ControllerStatus))
+			blocked := status.ControllerStatus == FinalizerControllerMissing || status.ControllerStatus == FinalizerControllerUnhealthy
+			if blocked && finding.Severity != findings.SeverityCritical {
+				// The first blocked finalizer's hint says what to fix
+				finding.Severity, finding.SuggestedAction = findings.SeverityCritical, status.Hint
+			} else if finding.SuggestedAction == "" {
+				finding.SuggestedAction = status.Hint
+			}
+		}
+		finding.Detail = fmt.Sprintf("deleting for %s, waiting on %s", obj.StuckFor, strings.Join(waiting, ", "))
+		for _, condition := range obj.Conditions {
+			conditionType, message, _ := strings.Cut(condition, ": ")
+			finding.Evidence = append(finding.Evidence, findings.Evidence{Type: findings.EvidenceCondition, Ref: conditionType, Message: message})
+		}
+		result = append(result, finding)
+	}
+	for _, orphan := range output.OrphanedReplicaSets {
+		result = append(result, findings.Finding{
+			Severity:        findings.SeverityInfo,
+			Category:        findings.CategoryWorkload,
+			Resource:        resourceRef("replicaset", orphan.Namespace, orphan.Name),
+			Title:           "ReplicaSet is orphaned",
+			Detail:          fmt.Sprintf("%s, scaled to zero and %s old", orphan.Reason, orphan.Age),
+			SuggestedAction: fmt.Sprintf("Delete it: oc delete replicaset -n %s %s", orphan.Namespace, orphan.Name),
+			Source:          "audit-finalizers",
+		})
+	}
+	findings.Sort(result)
+	return result
+}
+
+// listDeletingObjects lists every scanned kind, keeping only objects being deleted. Kinds that
+// cannot be listed are reported rather than failing the audit.
+func (t *AuditFinalizersTool) listDeletingObjects(ctx context.Context) ([]deletingObject, []string, []string) {
+	var objects []deletingObject
+	var scanned, errs []string
+
+	if namespaces, err := t.k8sClient.ListNamespaces(ctx); err != nil {
+		errs = append(errs, fmt.Sprintf("Namespace: %v", err))
+	} else {
+		scanned = append(scanned, "v1/Namespace")
+		for i := range namespaces.Items {
+			ns := &namespaces.Items[i]
+			if ns.DeletionTimestamp != nil {
+				objects = append(objects, deletingObject{APIVersion: "v1", Kind: "Namespace", Meta: ns, Conditions: namespaceDeletionConditions(ns)})
+			}
+		}
+	}
+
+	if pvcs, err := t.k8sClient.Clientset().CoreV1().PersistentVolumeClaims("").List(ctx, metav1.ListOptions{}); err != nil {
+		errs = append(errs, fmt.Sprintf("PersistentVolumeClaim: failed to list persistentvolumeclaims: %v", err))
+	} else {
+		scanned = append(scanned, "v1/PersistentVolumeClaim")
+		for i := range pvcs.Items {
+			if pvcs.Items[i].DeletionTimestamp != nil {
+				objects = append(objects, deletingObject{APIVersion: "v1", Kind: "PersistentVolumeClaim", Meta: &pvcs.Items[i]})
+			}
+		}
+	}
+
+	kinds := append([]string{"apiextensions.k8s.io/v1/CustomResourceDefinition"}, t.extraKinds...)
+	for _, ref := range kinds {
+		slash := strings.LastIndex(ref, "/")
+		apiVersion, kind := ref[:slash], ref[slash+1:]
+		list, err := t.k8sClient.ListResources(ctx, apiVersion, kind, "", metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", kind, err))
+			continue
+		}
+		scanned = append(scanned, ref)
+		for i := range list.Items {
+			if list.Items[i].GetDeletionTimestamp() != nil {
+				objects = append(objects, deletingObject{APIVersion: apiVersion, Kind: kind, Meta: &list.Items[i]})
+			}
+		}
+	}
+	return objects, scanned, errs
+}
+
+// namespaceDeletionConditions returns the messages of the namespace deletion conditions
+// that are currently true, which name the content and finalizers still blocking deletion
+func namespaceDeletionConditions(ns *corev1.Namespace) []string {
+	var messages []string
+	for _, condition := range ns.Status.Conditions {
+		if condition.Status == corev1.ConditionTrue && condition.Message != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+		}
+	}
+	return messages
+}
+
+// stuckDeletions returns the objects whose deletion started at least threshold ago, oldest
+// first. A namespace's spec finalizers are reported alongside its metadata finalizers.
+func stuckDeletions(objects []deletingObject, now time.Time, threshold time.Duration) []StuckObject {
+	stuck := []StuckObject{}
+	for _, obj := range objects {
+		deletion := obj.Meta.GetDeletionTimestamp()
+		if deletion == nil || now.Sub(deletion.Time) < threshold {
+			continue
+		}
+
+		finalizers := append([]string{}, obj.Meta.GetFinalizers()...)
+		if ns, ok := obj.Meta.(*corev1.Namespace); ok {
+			for _, finalizer := range ns.Spec.Finalizers {
+				finalizers = append(finalizers, string(finalizer))
+			}
+		}
+		statuses := make([]FinalizerStatus, 0, len(finalizers))
+		for _, finalizer := range finalizers {
+			statuses = append(statuses, FinalizerStatus{Finalizer: finalizer, MatchedBy: "none", ControllerStatus: FinalizerControllerUnknown})
+		}
+
+		stuck = append(stuck, StuckObject{
+			Kind:              obj.Kind,
+			APIVersion:        obj.APIVersion,
+			Namespace:         obj.Meta.GetNamespace(),
+			Name:              obj.Meta.GetName(),
+			DeletionTimestamp: deletion.Time,
+			StuckFor:          formatDuration(now.Sub(deletion.Time)),
+			Finalizers:        statuses,
+			Conditions:        obj.Conditions,
+		})
+	}
+	sort.SliceStable(stuck, func(i, j int) bool {
+		return stuck[i].DeletionTimestamp.Before(stuck[j].DeletionTimestamp)
+	})
+	return stuck
+}
+
+// knownFinalizerController looks finalizer up in knownFinalizerControllers
+func knownFinalizerController(finalizer string) (finalizerController, bool) {
+	for _, known := range knownFinalizerControllers {
+		if finalizer == known.finalizer || (strings.HasSuffix(known.finalizer, "/") && strings.HasPrefix(finalizer, known.finalizer)) {
+			return known.controller, true
+		}
+	}
+	return finalizerController{}, false
+}
+
+// finalizerDomainTokens returns the distinctive labels of a finalizer's domain, e.g.
+// "serving.knative.dev/finalizer" gives [serving knative]. Finalizers without a
+// domain give nil.
+func finalizerDomainTokens(finalizer string) []string {
+	domain, _, found := strings.Cut(finalizer, "/")
+	if !found || !strings.Contains(domain, ".") {
+		return nil
+	}
+	var tokens []string
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) >= 3 && !genericDomainLabels[label] {
+			tokens = append(tokens, label)
+		}
+	}
+	return tokens
+}
+
+// resolveFinalizerController fills in the controller of a finalizer and its health. Known
+// finalizers are looked up by pod name prefix in the controller's namespaces; others are
+// matched against controller-like pods whose namespace or name contains a domain token.
+func resolveFinalizerController(status *FinalizerStatus, pods []corev1.Pod) {
+	var matched []*corev1.Pod
+	if controller, ok := knownFinalizerController(status.Finalizer); ok {
+		status.Controller = controller.Name
+		status.MatchedBy = "known"
+		for i := range pods {
+			if slices.Contains(controller.Namespaces, pods[i].Namespace) && strings.HasPrefix(pods[i].Name, controller.PodPrefix) {
+				matched = append(matched, &pods[i])
+			}
+		}
+		if len(matched) == 0 && controller.ControlPlane {
+			status.ControllerStatus = FinalizerControllerUnknown
+			status.Hint = controller.Name + " pods are not visible; on managed clusters the control plane runs outside the cluster"
+			return
+		}
+	} else if tokens := finalizerDomainTokens(status.Finalizer); len(tokens) > 0 {
+		status.Controller = "guessed from domain: " + strings.Join(tokens, ", ")
+		status.MatchedBy = "domain"
+		for i := range pods {
+			if controllerLikePod(&pods[i]) && podMatchesTokens(&pods[i], tokens) {
+				matched = append(matched, &pods[i])
+			}
+		}
+	} else {
+		status.ControllerStatus = FinalizerControllerUnknown
+		status.Hint = "no controller is known for this finalizer"
+		return
+	}
+
+	ready := 0
+	for _, pod := range matched {
+		status.ControllerPods = append(status.ControllerPods, pod.Namespace+"/"+pod.Name)
+		if podReady(pod) {
+			ready++
+		}
+	}
+	switch {
+	case ready > 0:
+		status.ControllerStatus = FinalizerControllerHealthy
+		status.Hint = "controller is running; check its logs for why it has not removed the finalizer"
+	case len(matched) > 0:
+		status.ControllerStatus = FinalizerControllerUnhealthy
+		status.Hint = "controller pods are not ready; fix the controller and the finalizer should clear"
+	case status.MatchedBy == "known":
+		status.ControllerStatus = FinalizerControllerMissing
+		status.Hint = "no controller pods found; the finalizer will not be removed until the controller is restored"
+	default:
+		status.ControllerStatus = FinalizerControllerUnknown
+		status.Hint = "no controller pods match the finalizer domain; if its operator was uninstalled the finalizer will never clear"
+	}
+}
+
+// controllerLikePod reports whether a pod's name looks like an operator or controller
+func controllerLikePod(pod *corev1.Pod) bool {
+	for _, word := range []string{"operator", "controller", "manager"} {
+		if strings.Contains(pod.Name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// podMatchesTokens reports whether a pod's namespace or name contains any of tokens
+func podMatchesTokens(pod *corev1.Pod, tokens []string) bool {
+	for _, token := range tokens {
+		if strings.Contains(pod.Namespace, token) || strings.Contains(pod.Name, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// orphanedReplicaSets returns ReplicaSets scaled to zero, at least minAge old, whose
+// controlling Deployment is gone or that have no controller at all, oldest first.
+// Zero-replica ReplicaSets kept by a live Deployment's revision history are not orphans.
+func orphanedReplicaSets(replicaSets []appsv1.ReplicaSet, deployments []appsv1.Deployment, now time.Time, minAge time.Duration) []OrphanedReplicaSet {
+	live := make(map[string]bool, len(deployments))
+	for _, d := range deployments {
+		live[string(d.UID)] = true
+	}
+
+	type candidate struct {
+		orphan  OrphanedReplicaSet
+		created time.Time
+	}
+	var candidates []candidate
+	for _, rs := range replicaSets {
+		if rs.Spec.Replicas == nil || *rs.Spec.Replicas != 0 || rs.Status.Replicas != 0 {
+			continue
+		}
+		age := now.Sub(rs.CreationTimestamp.Time)
+		if age < minAge {
+			continue
+		}
+
+		var reason string
+		owner := metav1.GetControllerOf(&rs)
+		switch {
+		case owner == nil:
+			reason = "no owning controller"
+		case owner.Kind == "Deployment" && !live[string(owner.UID)]:
+			reason = fmt.Sprintf("owning Deployment %s no longer exists", owner.Name)
+		default:
+			continue
+		}
+		candidates = append(candidates, candidate{
+			orphan:  OrphanedReplicaSet{Namespace: rs.Namespace, Name: rs.Name, Age: formatDuration(age), Reason: reason},
+			created: rs.CreationTimestamp.Time,
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].created.Before(candidates[j].created) })
+	orphans := make([]OrphanedReplicaSet, 0, len(candidates))
+	for _, c := range candidates {
+		orphans = append(orphans, c.orphan)
+	}
+	return orphans
+}
+
+// finalizerAuditSummary describes the audit result in one line
+func finalizerAuditSummary(output AuditFinalizersOutput) string {
+	if output.TotalStuck == 0 && output.TotalOrphaned == 0 {
+		return "No stuck deletions or orphaned ReplicaSets found"
+	}
+	blocked := 0
+	for _, obj := range output.StuckObjects {
+		for _, f := range obj.Finalizers {
+			if f.ControllerStatus == FinalizerControllerMissing || f.ControllerStatus == FinalizerControllerUnhealthy {
+				blocked++
+				break
+			}
+		}
+	}
+	summary := fmt.Sprintf("%d object(s) stuck in deletion", output.TotalStuck)
+	if blocked > 0 {
+		summary += fmt.Sprintf(" (%d waiting on a missing or unhealthy controller)", blocked)
+	}
+	return summary + fmt.Sprintf("; %d orphaned ReplicaSet(s) can be cleaned up", output.TotalOrphaned)
+}
@@ -0,0 +1,296 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+var (
+	crdGVK            = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+	knativeServiceGVK = schema.GroupVersionKind{Group: "serving.knative.dev", Version: "v1", Kind: "Service"}
+)
+
+func newControllerPod(namespace, name string, ready bool) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestKnownFinalizerController(t *testing.T) {
+	tests := []struct {
+		finalizer string
+		want      string
+	}{
+		{"kubernetes", "kube-controller-manager"},
+		{"kubernetes.io/pvc-protection", "kube-controller-manager"},
+		{"foregroundDeletion", "kube-controller-manager"},
+		{"customresourcecleanup.apiextensions.k8s.io", "kube-apiserver"},
+		{"snapshot.storage.kubernetes.io/volumesnapshot-bound-protection", "csi-snapshot-controller"},
+		{"machine.machine.openshift.io", "machine-api-controllers"},
+		{"inferenceservice.finalizers", "kserve-controller-manager"},
+		{"kubernetes.io/pvc-protection-extra", ""},
+		{"serving.knative.dev/finalizer", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.finalizer, func(t *testing.T) {
+			controller, ok := knownFinalizerController(tt.finalizer)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, controller.Name)
+		})
+	}
+}
+
+func TestFinalizerDomainTokens(t *testing.T) {
+	tests := []struct {
+		finalizer string
+		want      []string
+	}{
+		{"serving.knative.dev/finalizer", []string{"serving", "knative"}},
+		{"cert-manager.io/cleanup", []string{"cert-manager"}},
+		{"operator.openshift.io/cleanup", nil},
+		{"external-attacher/ebs-csi-aws-com", nil},
+		{"orphan", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.finalizer, func(t *testing.T) {
+			assert.Equal(t, tt.want, finalizerDomainTokens(tt.finalizer))
+		})
+	}
+}
+
+func TestResolveFinalizerController(t *testing.T) {
+	pods := []corev1.Pod{
+		newControllerPod("openshift-kube-controller-manager", "kube-controller-manager-master-0", true),
+		newControllerPod("openshift-kube-controller-manager", "installer-7-master-0", false),
+		newControllerPod("openshift-cluster-storage-operator", "csi-snapshot-controller-5d8f9-abcde", false),
+		newControllerPod("knative-serving", "controller-7f9c8-xyz", true),
+		newControllerPod("knative-serving", "activator-6b7c9-xyz", true),
+	}
+
+	tests := []struct {
+		name      string
+		finalizer string
+		pods      []corev1.Pod
+		status    string
+		matchedBy string
+		podCount  int
+	}{
+		{"known and ready", "kubernetes.io/pvc-protection", pods, FinalizerControllerHealthy, "known", 1},
+		{"known but not ready", "snapshot.storage.kubernetes.io/volumesnapshot-bound-protection", pods, FinalizerControllerUnhealthy, "known", 1},
+		{"known and gone", "machine.machine.openshift.io", pods, FinalizerControllerMissing, "known", 0},
+		{"control plane hidden", "kubernetes", nil, FinalizerControllerUnknown, "known", 0},
+		{"domain match", "serving.knative.dev/finalizer", pods, FinalizerControllerHealthy, "domain", 1},
+		{"domain without pods", "cert-manager.io/cleanup", pods, FinalizerControllerUnknown, "domain", 0},
+		{"no domain", "example-finalizer", pods, FinalizerControllerUnknown, "none", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := FinalizerStatus{Finalizer: tt.finalizer, MatchedBy: "none", ControllerStatus: FinalizerControllerUnknown}
+			resolveFinalizerController(&status, tt.pods)
+			assert.Equal(t, tt.status, status.ControllerStatus)
+			assert.Equal(t, tt.matchedBy, status.MatchedBy)
+			assert.Len(t, status.ControllerPods, tt.podCount)
+			assert.NotEmpty(t, status.Hint)
+		})
+	}
+}
+
+func TestStuckDeletions(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	deleting := func(name string, ago time.Duration, finalizers ...string) *metav1.ObjectMeta {
+		return &metav1.ObjectMeta{Name: name, Namespace: "shop", DeletionTimestamp: &metav1.Time{Time: now.Add(-ago)}, Finalizers: finalizers}
+	}
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "old-project", DeletionTimestamp: &metav1.Time{Time: now.Add(-72 * time.Hour)}},
+		Spec:       corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes}},
+	}
+
+	stuck := stuckDeletions([]deletingObject{
+		{APIVersion: "v1", Kind: "PersistentVolumeClaim", Meta: deleting("data", 2*time.Hour, "kubernetes.io/pvc-protection")},
+		{APIVersion: "v1", Kind: "PersistentVolumeClaim", Meta: deleting("fresh", 2*time.Minute, "kubernetes.io/pvc-protection")},
+		{APIVersion: "v1", Kind: "Namespace", Meta: ns, Conditions: []string{"NamespaceContentRemaining: Some resources are remaining"}},
+	}, now, 10*time.Minute)
+
+	require.Len(t, stuck, 2)
+	assert.Equal(t, "old-project", stuck[0].Name)
+	assert.Equal(t, "3d", stuck[0].StuckFor)
+	assert.Equal(t, "kubernetes", stuck[0].Finalizers[0].Finalizer)
+	assert.Len(t, stuck[0].Conditions, 1)
+	assert.Equal(t, "data", stuck[1].Name)
+	assert.Equal(t, "2h", stuck[1].StuckFor)
+	assert.Equal(t, FinalizerControllerUnknown, stuck[1].Finalizers[0].ControllerStatus)
+}
+
+func TestOrphanedReplicaSets(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	rs := func(name string, replicas int32, age time.Duration, owner *metav1.OwnerReference) appsv1.ReplicaSet {
+		r := appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name, CreationTimestamp: metav1.Time{Time: now.Add(-age)}},
+			Spec:       appsv1.ReplicaSetSpec{Replicas: int32Ptr(replicas)},
+			Status:     appsv1.ReplicaSetStatus{Replicas: replicas},
+		}
+		if owner != nil {
+			r.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return r
+	}
+	isController := true
+	ownedBy := func(name, uid string) *metav1.OwnerReference {
+		return &metav1.OwnerReference{Kind: "Deployment", Name: name, UID: types.UID(uid), Controller: &isController}
+	}
+	deployments := []appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api", UID: "api-uid"}}}
+	week := 7 * 24 * time.Hour
+
+	orphans := orphanedReplicaSets([]appsv1.ReplicaSet{
+		rs("api-old", 0, 30*24*time.Hour, ownedBy("api", "api-uid")),
+		rs("api-live", 3, 30*24*time.Hour, ownedBy("api", "api-uid")),
+		rs("legacy-5f6d", 0, 20*24*time.Hour, ownedBy("legacy", "legacy-uid")),
+		rs("manual", 0, 40*24*time.Hour, nil),
+		rs("recent", 0, 24*time.Hour, nil),
+	}, deployments, now, week)
+
+	require.Len(t, orphans, 2)
+	assert.Equal(t, OrphanedReplicaSet{Namespace: "shop", Name: "manual", Age: "40d", Reason: "no owning controller"}, orphans[0])
+	assert.Equal(t, "owning Deployment legacy no longer exists", orphans[1].Reason)
+}
+
+func TestFinalizerAuditFindings(t *testing.T) {
+	deleted := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	output := AuditFinalizersOutput{
+		StuckObjects: []StuckObject{
+			{Kind: "Namespace", APIVersion: "v1", Name: "legacy", DeletionTimestamp: deleted, StuckFor: "2h",
+				Finalizers: []FinalizerStatus{
+					{Finalizer: "kubernetes", ControllerStatus: FinalizerControllerHealthy, Hint: "controller is running"},
+					{Finalizer: "example.com/cleanup", ControllerStatus: FinalizerControllerMissing, Hint: "no controller pods found"},
+				},
+				Conditions: []string{"NamespaceContentRemaining: Some resources are remaining: widgets.example.com has 1 resource instances"}},
+			{Kind: "PersistentVolumeClaim", APIVersion: "v1", Namespace: "shop", Name: "data", DeletionTimestamp: deleted, StuckFor: "2h",
+				Finalizers: []FinalizerStatus{{Finalizer: "kubernetes.io/pvc-protection", ControllerStatus: FinalizerControllerUnknown, Hint: "still mounted"}}},
+		},
+		OrphanedReplicaSets: []OrphanedReplicaSet{{Namespace: "shop", Name: "manual", Age: "30d", Reason: "no owning controller"}},
+	}
+
+	converted := finalizerAuditFindings(&output)
+	require.Len(t, converted, 3)
+	for _, finding := range converted {
+		assert.Equal(t, "audit-finalizers", finding.Source)
+	}
+
+	namespace := converted[0]
+	assert.Equal(t, findings.SeverityCritical, namespace.Severity, "a missing controller never removes its finalizer")
+	assert.Equal(t, findings.CategoryWorkload, namespace.Category)
+	assert.Equal(t, "namespace/legacy", namespace.Resource.String())
+	assert.Equal(t, "Deletion is stuck on finalizers", namespace.Title)
+	assert.Equal(t, "deleting for 2h, waiting on kubernetes (controller healthy), example.com/cleanup (controller missing)", namespace.Detail)
+	assert.Equal(t, "no controller pods found", namespace.SuggestedAction)
+	assert.Equal(t, []findings.Evidence{{Type: findings.EvidenceCondition, Ref: "NamespaceContentRemaining",
+		Message: "Some resources are remaining: widgets.example.com has 1 resource instances"}}, namespace.Evidence)
+	assert.Equal(t, deleted, namespace.FirstSeen)
+
+	claim := converted[1]
+	assert.Equal(t, findings.SeverityWarning, claim.Severity)
+	assert.Equal(t, findings.CategoryStorage, claim.Category)
+	assert.Equal(t, "persistentvolumeclaim/shop/data", claim.Resource.String())
+	assert.Equal(t, "still mounted", claim.SuggestedAction)
+
+	orphan := converted[2]
+	assert.Equal(t, findings.SeverityInfo, orphan.Severity)
+	assert.Equal(t, "replicaset/shop/manual", orphan.Resource.String())
+	assert.Equal(t, "ReplicaSet is orphaned", orphan.Title)
+	assert.Equal(t, "no owning controller, scaled to zero and 30d old", orphan.Detail)
+
+	assert.Empty(t, finalizerAuditFindings(&AuditFinalizersOutput{}))
+}
+
+func TestAuditFinalizersTool_Execute(t *testing.T) {
+	deleted := &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+	clientset := fake.NewClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: "data", DeletionTimestamp: deleted, Finalizers: []string{"kubernetes.io/pvc-protection"},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "manual", CreationTimestamp: metav1.Time{Time: time.Now().Add(-30 * 24 * time.Hour)}},
+			Spec:       appsv1.ReplicaSetSpec{Replicas: int32Ptr(0)},
+		},
+	)
+
+	scheme := runtime.NewScheme()
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(crdGVK, meta.RESTScopeRoot)
+	mapper.Add(knativeServiceGVK, meta.RESTScopeNamespace)
+	knativeService := newUnstructured(knativeServiceGVK, "shop", "frontend", map[string]interface{}{})
+	knativeService.SetDeletionTimestamp(deleted)
+	knativeService.SetFinalizers([]string{"serving.knative.dev/finalizer"})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		crdGVK.GroupVersion().WithResource("customresourcedefinitions"):   "CustomResourceDefinitionList",
+		knativeServiceGVK.GroupVersion().WithResource("services"):         "ServiceList",
+		{Group: "missing.example.io", Version: "v1", Resource: "widgets"}: "WidgetList",
+	}, knativeService)
+
+	tool := NewAuditFinalizersTool(
+		clients.NewK8sClientWithClients(clientset, dynamicClient, mapper),
+		[]string{"serving.knative.dev/v1/Service", "missing.example.io/v1/Widget"},
+	)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(AuditFinalizersOutput)
+
+	assert.Equal(t, []string{"v1/Namespace", "v1/PersistentVolumeClaim", "apiextensions.k8s.io/v1/CustomResourceDefinition", "serving.knative.dev/v1/Service"}, output.KindsScanned)
+	require.Len(t, output.Errors, 1)
+	assert.Contains(t, output.Errors[0], "Widget")
+
+	require.Equal(t, 2, output.TotalStuck)
+	kinds := []string{output.StuckObjects[0].Kind, output.StuckObjects[1].Kind}
+	assert.ElementsMatch(t, []string{"PersistentVolumeClaim", "Service"}, kinds)
+	for _, obj := range output.StuckObjects {
+		if obj.Kind == "Service" {
+			assert.Equal(t, "domain", obj.Finalizers[0].MatchedBy)
+			assert.Equal(t, FinalizerControllerUnknown, obj.Finalizers[0].ControllerStatus)
+		}
+	}
+
+	require.Len(t, output.OrphanedReplicaSets, 1)
+	assert.Equal(t, "manual", output.OrphanedReplicaSets[0].Name)
+	assert.Equal(t, "2 object(s) stuck in deletion; 1 orphaned ReplicaSet(s) can be cleaned up", output.Summary)
+	assert.Len(t, output.Findings, 3)
+}
+
+func TestAuditFinalizersTool_InvalidArgs(t *testing.T) {
+	tool := NewAuditFinalizersTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	for _, args := range []map[string]interface{}{
+		{"min_stuck_minutes": 0},
+		{"orphan_days": 400},
+		{"limit": 101},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err), "args %v", args)
+	}
+}
@@ -0,0 +1,31 @@
+package tools
+
+import (
+	"strings"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+// Titles of the problems several tools find, so that findings.Merge sees
+// them as the same problem
+const (
+	nodeNotReadyTitle = "Node is NotReady"
+	nodeCordonedTitle = "Node is cordoned"
+	alertFiringTitle  = "Alert is firing"
+)
+
+// resourceRef returns the findings reference of a resource; kind is lower cased
+func resourceRef(kind, namespace, name string) findings.ResourceRef {
+	return findings.ResourceRef{Kind: strings.ToLower(kind), Namespace: namespace, Name: name}
+}
+
+// alertSeverity maps the severity label of an alert to a finding severity;
+// alerts without a known severity are warnings
+func alertSeverity(label string) findings.Severity {
+	switch severity := findings.Severity(label); severity {
+	case findings.SeverityCritical, findings.SeverityInfo:
+		return severity
+	default:
+		return findings.SeverityWarning
+	}
+}
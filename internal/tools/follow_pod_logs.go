@@ -0,0 +1,157 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LogFollower follows container logs in the background for follow-pod-logs;
+// the server, which owns the artifact store and the log stream limits,
+// provides it
+type LogFollower interface {
+	// FollowLogs opens the log of target and starts appending it to a new
+	// artifact, returning once the log is open
+	FollowLogs(ctx context.Context, target LogFollowTarget) (*LogFollowJob, error)
+}
+
+// LogFollowTarget is the container whose log is followed
+type LogFollowTarget struct {
+	Namespace    string
+	Pod          string
+	Container    string // Empty for the pod's only (or default) container
+	SinceSeconds int64  // Start with the lines of the last SinceSeconds; 0 starts with new lines
+}
+
+// LogFollowJob describes a started log follow
+type LogFollowJob struct {
+	Artifact interface{} `json:"artifact"`  // Reference of the artifact the lines are appended to
+	EndsBy   time.Time   `json:"ends_by"`   // The follow stops by then at the latest
+	MaxBytes int64       `json:"max_bytes"` // The follow stops once this many bytes of log were appended
+}
+
+// FollowPodLogsTool follows a container's log into an artifact in the background
+type FollowPodLogsTool struct {
+	follower LogFollower
+}
+
+// NewFollowPodLogsTool creates a new follow-pod-logs tool
+func NewFollowPodLogsTool(follower LogFollower) *FollowPodLogsTool {
+	return &FollowPodLogsTool{follower: follower}
+}
+
+// Name returns the tool name for MCP registration
+func (t *FollowPodLogsTool) Name() string {
+	return "follow-pod-logs"
+}
+
+// Volatility is realtime: the log keeps growing after the call
+func (t *FollowPodLogsTool) Volatility() Volatility {
+	return VolatilityRealtime
+}
+
+// NamespaceScoped reports that logs are always followed in one named namespace
+func (t *FollowPodLogsTool) NamespaceScoped() bool {
+	return true
+}
+
+// Audited reports that follows are audited: each holds a log stream open
+// and fills an artifact long after the call returns
+func (t *FollowPodLogsTool) Audited() bool {
+	return true
+}
+
+// Description returns the tool description for MCP
+func (t *FollowPodLogsTool) Description() string {
+	return `Start following the live log of one pod container in the background, like kubectl logs -f. The call returns at once with an artifact reference; new lines are appended to the artifact as the container writes them, so read it again to see more. The follow stops when the container exits, at ends_by, or after max_bytes of log, whichever comes first; the last line of the artifact then says why.
+
+Use this tool for questions like:
+- "Watch the checkout pod's log while I retry the payment"
+- "Capture what the migration job logs until it finishes"
+
+For a one-off search of past logs use search-logs instead.`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *FollowPodLogsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the pod",
+			},
+			"pod": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the pod",
+			},
+			"container": map[string]interface{}{
+				"type":        "string",
+				"description": "Container to follow; required when the pod has several",
+			},
+			"since_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Start with the lines of the last N minutes (default: only new lines)",
+				"minimum":     0,
+			},
+		},
+		"required": []string{"namespace", "pod"},
+	}
+}
+
+// FollowPodLogsInput represents the input parameters
+type FollowPodLogsInput struct {
+	Namespace    string `json:"namespace"`
+	Pod          string `json:"pod"`
+	Container    string `json:"container"`
+	SinceMinutes int    `json:"since_minutes"`
+}
+
+// FollowPodLogsOutput represents the tool output
+type FollowPodLogsOutput struct {
+	Status    string `json:"status"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	Container string `json:"container,omitempty"`
+	*LogFollowJob
+}
+
+// RequiredPermissions declares the Kubernetes API access follow-pod-logs needs
+func (t *FollowPodLogsTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "pods", Subresource: "log", Verb: "get"},
+	}
+}
+
+// Execute runs the follow-pod-logs operation
+func (t *FollowPodLogsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input FollowPodLogsInput
+	argsJSON, _ := json.Marshal(args) //nolint:errcheck // Tool arguments always marshal
+	if err := json.Unmarshal(argsJSON, &input); err != nil {
+		return nil, invalidArgs("invalid arguments: %v", err)
+	}
+	if input.Namespace == "" || input.Pod == "" {
+		return nil, invalidArgs("namespace and pod are required")
+	}
+	if input.SinceMinutes < 0 {
+		return nil, invalidArgs("since_minutes must not be negative")
+	}
+
+	job, err := t.follower.FollowLogs(ctx, LogFollowTarget{
+		Namespace:    input.Namespace,
+		Pod:          input.Pod,
+		Container:    input.Container,
+		SinceSeconds: int64(input.SinceMinutes) * 60,
+	})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to follow logs of %s/%s: %w", input.Namespace, input.Pod, err))
+	}
+	return FollowPodLogsOutput{
+		Status:       "following",
+		Namespace:    input.Namespace,
+		Pod:          input.Pod,
+		Container:    input.Container,
+		LogFollowJob: job,
+	}, nil
+}
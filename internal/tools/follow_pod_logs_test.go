@@ -0,0 +1,48 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubLogFollower records the target it is asked to follow
+type stubLogFollower struct {
+	target LogFollowTarget
+	err    error
+}
+
+func (s *stubLogFollower) FollowLogs(_ context.Context, target LogFollowTarget) (*LogFollowJob, error) {
+	s.target = target
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &LogFollowJob{Artifact: "ref", EndsBy: time.Date(2026, 10, 15, 12, 10, 0, 0, time.UTC), MaxBytes: 1024}, nil
+}
+
+func TestFollowPodLogsTool_Execute(t *testing.T) {
+	follower := &stubLogFollower{}
+	tool := NewFollowPodLogsTool(follower)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"namespace": "shop", "pod": "api-1", "container": "app", "since_minutes": 5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, LogFollowTarget{Namespace: "shop", Pod: "api-1", Container: "app", SinceSeconds: 300}, follower.target)
+	output := result.(FollowPodLogsOutput)
+	assert.Equal(t, "following", output.Status)
+	assert.Equal(t, int64(1024), output.MaxBytes)
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop"})
+	assert.True(t, IsInvalidArguments(err))
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "pod": "api-1", "since_minutes": -1})
+	assert.True(t, IsInvalidArguments(err))
+
+	follower.err = errors.New("boom")
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "pod": "api-1"})
+	assert.ErrorContains(t, err, "failed to follow logs of shop/api-1: boom")
+}
@@ -0,0 +1,459 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/analysis"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// PromQL for per-node resource requests (of pods still holding them) and allocatable
+const (
+	capacityRequestsQuery    = `sum by (node) (kube_pod_container_resource_requests{resource="%s"} * on (namespace, pod) group_left() max by (namespace, pod) (kube_pod_status_phase{phase=~"Pending|Running"} == 1))`
+	capacityAllocatableQuery = `sum by (node) (kube_node_status_allocatable{resource="%s"})`
+)
+
+const (
+	// capacityClusterGroup is the node group covering every node
+	capacityClusterGroup = "cluster"
+	// capacityMaxPoints bounds the history fetched per series; the step grows with the lookback
+	capacityMaxPoints = 400
+	// capacityHorizonDays is how far ahead a threshold crossing is reported
+	capacityHorizonDays = 365
+)
+
+// capacityResources are the resources forecast-capacity projects
+var capacityResources = []string{"cpu", "memory"}
+
+// ForecastCapacityTool projects when cluster and node group resource requests
+// reach a utilization threshold of allocatable capacity
+type ForecastCapacityTool struct {
+	k8sClient     clients.NodeReader
+	prometheus    *clients.PrometheusClient
+	kserveClient  *clients.KServeClient // nil when KServe is not enabled
+	forecastModel string
+}
+
+// NewForecastCapacityTool creates a new forecast-capacity tool. History comes from
+// Prometheus; forecasts come from forecastModel on KServe when kserveClient is
+// non-nil, falling back to the local forecaster in pkg/analysis.
+func NewForecastCapacityTool(k8sClient clients.NodeReader, prometheus *clients.PrometheusClient, kserveClient *clients.KServeClient, forecastModel string) *ForecastCapacityTool {
+	return &ForecastCapacityTool{
+		k8sClient:     k8sClient,
+		prometheus:    prometheus,
+		kserveClient:  kserveClient,
+		forecastModel: forecastModel,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *ForecastCapacityTool) Name() string {
+	return "forecast-capacity"
+}
+
+// Volatility is slow, as forecasts are fitted to hours of usage history
+func (t *ForecastCapacityTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
+// Weight is heavy: every forecast is a KServe inference call
+func (t *ForecastCapacityTool) Weight() Weight {
+	return WeightHeavy
+}
+
+// Description returns the tool description for MCP
+func (t *ForecastCapacityTool) Description() string {
+	return `Forecast when CPU and memory requests will reach a utilization threshold of allocatable capacity, for the whole cluster and per node group (node role by default, or any node label). Reads the request/allocatable history from Prometheus and projects it with the configured KServe forecasting model when KServe is enabled, otherwise with a local Holt-Winters / linear regression forecaster. Reports current utilization, growth per day, days until the threshold, and the projected date.
+
+Use this tool for questions like:
+- "When do we run out of memory capacity?"
+- "How many days until worker CPU requests hit 85%?"
+- "Which node group fills up first?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *ForecastCapacityTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"threshold_percent": map[string]interface{}{
+				"type":        "number",
+				"description": "Utilization (requests as a percentage of allocatable) to forecast",
+				"default":     85,
+				"minimum":     1,
+				"maximum":     100,
+			},
+			"lookback_days": map[string]interface{}{
+				"type":        "integer",
+				"description": "Days of history to fit the trend to",
+				"default":     14,
+				"minimum":     1,
+				"maximum":     90,
+			},
+			"group_by_label": map[string]interface{}{
+				"type":        "string",
+				"description": "Node label whose values define node groups (e.g. node.kubernetes.io/instance-type). Defaults to the node role.",
+			},
+		},
+	}
+}
+
+// ForecastCapacityInput represents the input parameters
+type ForecastCapacityInput struct {
+	ThresholdPercent float64 `json:"threshold_percent"`
+	LookbackDays     int     `json:"lookback_days"`
+	GroupByLabel     string  `json:"group_by_label"`
+}
+
+// CapacityForecast is the projection of one resource in one node group
+type CapacityForecast struct {
+	Group               string  `json:"group"`
+	Resource            string  `json:"resource"`
+	Nodes               int     `json:"nodes,omitempty"` // Current nodes in the group
+	CurrentPercent      float64 `json:"current_percent"`
+	GrowthPerDayPercent float64 `json:"growth_per_day_percent"`
+	// DaysUntilThreshold is null when utilization is flat, decreasing, or does
+	// not reach the threshold within a year
+	DaysUntilThreshold *float64 `json:"days_until_threshold"`
+	ProjectedDate      string   `json:"projected_date,omitempty"`
+	Method             string   `json:"method"` // linear_regression, holt_winters, or kserve
+	Samples            int      `json:"samples"`
+}
+
+// ForecastCapacityOutput represents the tool output
+type ForecastCapacityOutput struct {
+	Status           string             `json:"status"`
+	ThresholdPercent float64            `json:"threshold_percent"`
+	LookbackDays     int                `json:"lookback_days"`
+	GroupBy          string             `json:"group_by"`
+	Source           string             `json:"source"` // kserve or local
+	Model            string             `json:"model,omitempty"`
+	Forecasts        []CapacityForecast `json:"forecasts"`
+	Summary          string             `json:"summary"`
+	Notes            []string           `json:"notes,omitempty"`
+}
+
+// capacitySeries is the utilization history of one resource in one node group
+type capacitySeries struct {
+	Group    string
+	Resource string
+	Nodes    int
+	Points   []analysis.Point
+}
+
+// RequiredPermissions declares the Kubernetes API access forecast-capacity needs
+func (t *ForecastCapacityTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+	}
+}
+
+// Execute runs the forecast-capacity operation
+func (t *ForecastCapacityTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := ForecastCapacityInput{ThresholdPercent: 85, LookbackDays: 14}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.ThresholdPercent < 1 || input.ThresholdPercent > 100 {
+		return nil, invalidArgs("threshold_percent must be between 1 and 100")
+	}
+	if input.LookbackDays < 1 || input.LookbackDays > 90 {
+		return nil, invalidArgs("lookback_days must be between 1 and 90")
+	}
+	if input.GroupByLabel != "" {
+		if errs := validation.IsQualifiedName(input.GroupByLabel); len(errs) > 0 {
+			return nil, invalidArgs("invalid group_by_label %q: %s", input.GroupByLabel, strings.Join(errs, "; "))
+		}
+	}
+	if t.prometheus == nil {
+		return nil, fmt.Errorf("forecast-capacity needs utilization history from Prometheus (ENABLE_PROMETHEUS)")
+	}
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list nodes: %w", err))
+	}
+	groups := nodeGroups(nodes.Items, input.GroupByLabel)
+
+	end := time.Now()
+	start := end.Add(-time.Duration(input.LookbackDays) * 24 * time.Hour)
+	step := capacityStep(input.LookbackDays)
+
+	var series []capacitySeries
+	for _, resource := range capacityResources {
+		requests, err := t.prometheus.QueryRange(ctx, fmt.Sprintf(capacityRequestsQuery, resource), start, end, step)
+		if err != nil {
+			return nil, dependencyError(fmt.Errorf("failed to query %s requests: %w", resource, err))
+		}
+		allocatable, err := t.prometheus.QueryRange(ctx, fmt.Sprintf(capacityAllocatableQuery, resource), start, end, step)
+		if err != nil {
+			return nil, dependencyError(fmt.Errorf("failed to query %s allocatable: %w", resource, err))
+		}
+		series = append(series, utilizationSeries(resource, requests, allocatable, groups)...)
+	}
+
+	output := ForecastCapacityOutput{
+		Status:           "success",
+		ThresholdPercent: input.ThresholdPercent,
+		LookbackDays:     input.LookbackDays,
+		GroupBy:          "node role",
+		Source:           "local",
+		Forecasts:        []CapacityForecast{},
+	}
+	if input.GroupByLabel != "" {
+		output.GroupBy = input.GroupByLabel
+	}
+
+	if t.kserveClient != nil {
+		forecasts, err := t.forecastWithModel(ctx, series, input.ThresholdPercent)
+		if err == nil {
+			output.Source = "kserve"
+			output.Model = t.forecastModel
+			output.Forecasts = forecasts
+		} else {
+			output.Notes = append(output.Notes, fmt.Sprintf("KServe model %s failed, using the local forecaster: %v", t.forecastModel, err))
+		}
+	}
+	if output.Source == "local" {
+		output.Forecasts = forecastLocally(series, input.ThresholdPercent)
+	}
+
+	for i := range output.Forecasts {
+		if days := output.Forecasts[i].DaysUntilThreshold; days != nil && *days > 0 {
+			output.Forecasts[i].ProjectedDate = end.Add(time.Duration(*days * 24 * float64(time.Hour))).Format("2006-01-02")
+		}
+	}
+	if skipped := len(series) - len(output.Forecasts); skipped > 0 {
+		output.Notes = append(output.Notes, fmt.Sprintf("%d series had too little history to forecast", skipped))
+	}
+	output.Summary = capacitySummary(output)
+	return output, nil
+}
+
+// forecastWithModel sends every series to the KServe forecasting model. Each
+// instance carries group, resource, timestamps (Unix seconds), values (percent),
+// and threshold_percent; the model returns one prediction per instance with
+// current_percent, growth_per_day_percent, and days_until_threshold (null when
+// the threshold is never reached).
+func (t *ForecastCapacityTool) forecastWithModel(ctx context.Context, series []capacitySeries, threshold float64) ([]CapacityForecast, error) {
+	if len(series) == 0 {
+		return []CapacityForecast{}, nil
+	}
+
+	instances := make([]map[string]interface{}, len(series))
+	for i, s := range series {
+		timestamps := make([]int64, len(s.Points))
+		values := make([]float64, len(s.Points))
+		for j, p := range s.Points {
+			timestamps[j] = p.Time.Unix()
+			values[j] = p.Value
+		}
+		instances[i] = map[string]interface{}{
+			"group":             s.Group,
+			"resource":          s.Resource,
+			"timestamps":        timestamps,
+			"values":            values,
+			"threshold_percent": threshold,
+		}
+	}
+
+	resp, err := t.kserveClient.Predict(ctx, t.forecastModel, instances)
+	if err != nil {
+		return nil, dependencyError(err)
+	}
+	return modelForecasts(series, resp.Predictions)
+}
+
+// modelForecasts converts the forecasting model's predictions, one per series
+func modelForecasts(series []capacitySeries, raw interface{}) ([]CapacityForecast, error) {
+	predictions, ok := raw.([]interface{})
+	if !ok || len(predictions) != len(series) {
+		return nil, fmt.Errorf("expected %d predictions, got %v", len(series), raw)
+	}
+
+	forecasts := make([]CapacityForecast, len(series))
+	for i, entry := range predictions {
+		prediction, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("prediction %d is not an object", i)
+		}
+		current, ok := prediction["current_percent"].(float64)
+		if !ok {
+			return nil, fmt.Errorf("prediction %d has no current_percent", i)
+		}
+		growth, _ := prediction["growth_per_day_percent"].(float64)
+		var days *float64
+		if d, ok := prediction["days_until_threshold"].(float64); ok {
+			days = &d
+		}
+		forecasts[i] = newCapacityForecast(series[i], "kserve", current, growth, days)
+	}
+	return forecasts, nil
+}
+
+// forecastLocally projects every series with pkg/analysis. Series too short
+// to fit a trend are skipped.
+func forecastLocally(series []capacitySeries, threshold float64) []CapacityForecast {
+	forecasts := make([]CapacityForecast, 0, len(series))
+	for _, s := range series {
+		projection, err := analysis.Project(s.Points, threshold)
+		if err != nil {
+			continue
+		}
+		forecasts = append(forecasts, newCapacityForecast(s, string(projection.Method), projection.Current, projection.GrowthPerDay, projection.DaysUntilThreshold))
+	}
+	return forecasts
+}
+
+// newCapacityForecast rounds a projection and drops crossings beyond the horizon
+func newCapacityForecast(s capacitySeries, method string, current, growth float64, days *float64) CapacityForecast {
+	forecast := CapacityForecast{
+		Group:               s.Group,
+		Resource:            s.Resource,
+		Nodes:               s.Nodes,
+		CurrentPercent:      math.Round(current*10) / 10,
+		GrowthPerDayPercent: math.Round(growth*100) / 100,
+		Method:              method,
+		Samples:             len(s.Points),
+	}
+	if days != nil && *days >= 0 && *days <= capacityHorizonDays {
+		rounded := math.Round(*days*10) / 10
+		forecast.DaysUntilThreshold = &rounded
+	}
+	return forecast
+}
+
+// capacityGroups maps nodes to node groups
+type capacityGroups struct {
+	ByNode map[string]string
+	Nodes  map[string]int // Current node count per group
+	Names  []string       // Sorted group names
+}
+
+// nodeGroups groups nodes by the value of label, or by primary node role when
+// label is empty
+func nodeGroups(nodes []corev1.Node, label string) capacityGroups {
+	groups := capacityGroups{ByNode: map[string]string{}, Nodes: map[string]int{}}
+	for _, node := range nodes {
+		group := nodeRole(node.Labels)
+		if label != "" {
+			group = node.Labels[label]
+			if group == "" {
+				group = "<none>"
+			}
+		}
+		groups.ByNode[node.Name] = group
+		if groups.Nodes[group] == 0 {
+			groups.Names = append(groups.Names, group)
+		}
+		groups.Nodes[group]++
+	}
+	sort.Strings(groups.Names)
+	return groups
+}
+
+// nodeRole returns a node's primary role: control-plane, infra, or worker
+func nodeRole(labels map[string]string) string {
+	for _, role := range []struct{ label, name string }{
+		{"node-role.kubernetes.io/control-plane", "control-plane"},
+		{"node-role.kubernetes.io/master", "control-plane"},
+		{"node-role.kubernetes.io/infra", "infra"},
+	} {
+		if _, ok := labels[role.label]; ok {
+			return role.name
+		}
+	}
+	return "worker"
+}
+
+// capacityStep returns a query step that keeps each series under capacityMaxPoints
+// and divides a day evenly, so the local forecaster can model the daily cycle
+func capacityStep(lookbackDays int) time.Duration {
+	hours := int(math.Ceil(float64(lookbackDays*24) / capacityMaxPoints))
+	for 24%hours != 0 {
+		hours++
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// utilizationSeries sums per-node requests and allocatable into the cluster and
+// each node group, giving requests as a percentage of allocatable at every step.
+// Nodes that no longer exist still count toward the cluster.
+func utilizationSeries(resource string, requests, allocatable []clients.PromSeries, groups capacityGroups) []capacitySeries {
+	type totals struct{ requested, allocatable map[int64]float64 }
+	byGroup := map[string]*totals{}
+	add := func(group string, series clients.PromSeries, allocatableSeries bool) {
+		t := byGroup[group]
+		if t == nil {
+			t = &totals{requested: map[int64]float64{}, allocatable: map[int64]float64{}}
+			byGroup[group] = t
+		}
+		target := t.requested
+		if allocatableSeries {
+			target = t.allocatable
+		}
+		for _, p := range series.Points {
+			target[p.Time.Unix()] += p.Value
+		}
+	}
+	for i, set := range [][]clients.PromSeries{requests, allocatable} {
+		for _, series := range set {
+			add(capacityClusterGroup, series, i == 1)
+			if group, ok := groups.ByNode[series.Labels["node"]]; ok {
+				add(group, series, i == 1)
+			}
+		}
+	}
+
+	result := make([]capacitySeries, 0, len(groups.Names)+1)
+	for _, group := range append([]string{capacityClusterGroup}, groups.Names...) {
+		t := byGroup[group]
+		if t == nil {
+			continue
+		}
+		s := capacitySeries{Group: group, Resource: resource, Nodes: groups.Nodes[group]}
+		if group == capacityClusterGroup {
+			s.Nodes = len(groups.ByNode)
+		}
+		for ts, total := range t.allocatable {
+			if total > 0 {
+				s.Points = append(s.Points, analysis.Point{Time: time.Unix(ts, 0), Value: t.requested[ts] / total * 100})
+			}
+		}
+		sort.Slice(s.Points, func(i, j int) bool { return s.Points[i].Time.Before(s.Points[j].Time) })
+		result = append(result, s)
+	}
+	return result
+}
+
+// capacitySummary names the forecast that reaches the threshold first
+func capacitySummary(output ForecastCapacityOutput) string {
+	var first *CapacityForecast
+	for i := range output.Forecasts {
+		f := &output.Forecasts[i]
+		if f.DaysUntilThreshold != nil && (first == nil || *f.DaysUntilThreshold < *first.DaysUntilThreshold) {
+			first = f
+		}
+	}
+	switch {
+	case len(output.Forecasts) == 0:
+		return "Not enough utilization history to forecast capacity"
+	case first == nil:
+		return fmt.Sprintf("No node group is projected to reach %.0f%% CPU or memory requests within %d days", output.ThresholdPercent, capacityHorizonDays)
+	case *first.DaysUntilThreshold == 0:
+		return fmt.Sprintf("%s %s requests are already at %.1f%% of allocatable (threshold %.0f%%)", first.Group, first.Resource, first.CurrentPercent, output.ThresholdPercent)
+	default:
+		return fmt.Sprintf("%s %s requests reach %.0f%% of allocatable first, in about %.0f days (%s); currently %.1f%%, growing %.2f points/day",
+			first.Group, first.Resource, output.ThresholdPercent, math.Ceil(*first.DaysUntilThreshold), first.ProjectedDate, first.CurrentPercent, first.GrowthPerDayPercent)
+	}
+}
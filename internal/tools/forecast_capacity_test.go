@@ -0,0 +1,218 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/analysis"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newRangePrometheus serves range query results keyed by query; each fixture
+// entry maps a node to its hourly values
+func newRangePrometheus(t *testing.T, fixture map[string]map[string][]float64) *clients.PrometheusClient {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := []map[string]interface{}{}
+		for node, values := range fixture[r.URL.Query().Get("query")] {
+			points := make([][2]interface{}, len(values))
+			for i, v := range values {
+				points[i] = [2]interface{}{start.Add(time.Duration(i) * time.Hour).Unix(), fmt.Sprint(v)}
+			}
+			result = append(result, map[string]interface{}{"metric": map[string]string{"node": node}, "values": points})
+		}
+		body, _ := json.Marshal(result)
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"matrix","result":%s}}`, body)
+	}))
+	t.Cleanup(server.Close)
+	return clients.NewPrometheusClient(clients.PrometheusConfig{URL: server.URL})
+}
+
+// hourly returns n hourly values following value(day)
+func hourly(n int, value func(day float64) float64) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = value(float64(i) / 24)
+	}
+	return values
+}
+
+func capacityNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func newForecastFixture(t *testing.T) (*clients.K8sClient, *clients.PrometheusClient) {
+	clientset := fake.NewClientset(
+		capacityNode("master-0", map[string]string{"node-role.kubernetes.io/master": ""}),
+		capacityNode("worker-0", map[string]string{"node-role.kubernetes.io/worker": "", "node.kubernetes.io/instance-type": "m5.xlarge"}),
+		capacityNode("worker-1", map[string]string{"node-role.kubernetes.io/worker": ""}),
+	)
+
+	const points = 72
+	allocatable := map[string][]float64{
+		"master-0": hourly(points, func(float64) float64 { return 100 }),
+		"worker-0": hourly(points, func(float64) float64 { return 100 }),
+		"worker-1": hourly(points, func(float64) float64 { return 100 }),
+	}
+	growing := func(d float64) float64 { return 40 + 2*d }
+	prometheus := newRangePrometheus(t, map[string]map[string][]float64{
+		fmt.Sprintf(capacityRequestsQuery, "memory"): {
+			"master-0": hourly(points, func(float64) float64 { return 50 }),
+			"worker-0": hourly(points, growing),
+			"worker-1": hourly(points, growing),
+		},
+		fmt.Sprintf(capacityAllocatableQuery, "memory"): allocatable,
+		fmt.Sprintf(capacityRequestsQuery, "cpu"): {
+			"master-0": hourly(points, func(float64) float64 { return 30 }),
+			"worker-0": hourly(points, func(float64) float64 { return 30 }),
+			"worker-1": hourly(points, func(float64) float64 { return 30 }),
+		},
+		fmt.Sprintf(capacityAllocatableQuery, "cpu"): {
+			"master-0":    allocatable["master-0"],
+			"worker-0":    allocatable["worker-0"],
+			"worker-1":    allocatable["worker-1"],
+			"worker-gone": hourly(24, func(float64) float64 { return 100 }), // Removed after the first day
+		},
+	})
+	return clients.NewK8sClientWithClientset(clientset), prometheus
+}
+
+func findForecast(t *testing.T, forecasts []CapacityForecast, group, resource string) CapacityForecast {
+	t.Helper()
+	for _, f := range forecasts {
+		if f.Group == group && f.Resource == resource {
+			return f
+		}
+	}
+	t.Fatalf("no %s forecast for group %s in %+v", resource, group, forecasts)
+	return CapacityForecast{}
+}
+
+func TestForecastCapacityTool_Execute(t *testing.T) {
+	k8sClient, prometheus := newForecastFixture(t)
+	tool := NewForecastCapacityTool(k8sClient, prometheus, nil, "capacity-forecast")
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(ForecastCapacityOutput)
+
+	assert.Equal(t, "local", output.Source)
+	assert.Equal(t, "node role", output.GroupBy)
+	require.Len(t, output.Forecasts, 6, "cluster, control-plane, and worker for cpu and memory")
+
+	worker := findForecast(t, output.Forecasts, "worker", "memory")
+	assert.Equal(t, 2, worker.Nodes)
+	assert.Equal(t, "holt_winters", worker.Method)
+	assert.InDelta(t, 45.9, worker.CurrentPercent, 0.5)
+	assert.InDelta(t, 2, worker.GrowthPerDayPercent, 0.1)
+	require.NotNil(t, worker.DaysUntilThreshold)
+	assert.InDelta(t, 19.5, *worker.DaysUntilThreshold, 1)
+	assert.NotEmpty(t, worker.ProjectedDate)
+
+	cpu := findForecast(t, output.Forecasts, "worker", "cpu")
+	assert.Nil(t, cpu.DaysUntilThreshold, "flat utilization never reaches the threshold")
+	assert.Equal(t, 30.0, cpu.CurrentPercent)
+
+	// The removed node's allocatable counts toward the cluster while it existed
+	cluster := findForecast(t, output.Forecasts, capacityClusterGroup, "cpu")
+	assert.Equal(t, 3, cluster.Nodes)
+	assert.Equal(t, 72, cluster.Samples)
+
+	assert.Contains(t, output.Summary, "worker memory requests reach 85% of allocatable first")
+}
+
+func TestForecastCapacityTool_GroupByLabel(t *testing.T) {
+	k8sClient, prometheus := newForecastFixture(t)
+	tool := NewForecastCapacityTool(k8sClient, prometheus, nil, "capacity-forecast")
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"group_by_label":    "node.kubernetes.io/instance-type",
+		"threshold_percent": 45,
+	})
+	require.NoError(t, err)
+	output := result.(ForecastCapacityOutput)
+
+	assert.Equal(t, "node.kubernetes.io/instance-type", output.GroupBy)
+	assert.Equal(t, 1, findForecast(t, output.Forecasts, "m5.xlarge", "memory").Nodes)
+	assert.Equal(t, 2, findForecast(t, output.Forecasts, "<none>", "memory").Nodes)
+
+	over := findForecast(t, output.Forecasts, "<none>", "memory") // master-0 at 50% keeps the group over 45%
+	require.NotNil(t, over.DaysUntilThreshold)
+	assert.Equal(t, 0.0, *over.DaysUntilThreshold)
+}
+
+func TestForecastCapacityTool_KServeFallback(t *testing.T) {
+	k8sClient, prometheus := newForecastFixture(t)
+	kserve := clients.NewKServeClient(clients.KServeConfig{Namespace: "models", Enabled: true, PredictorPort: 1, Timeout: 2 * time.Second})
+	tool := NewForecastCapacityTool(k8sClient, prometheus, kserve, "capacity-forecast")
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(ForecastCapacityOutput)
+
+	assert.Equal(t, "local", output.Source)
+	require.NotEmpty(t, output.Notes)
+	assert.Contains(t, output.Notes[0], "KServe model capacity-forecast failed, using the local forecaster")
+	assert.Len(t, output.Forecasts, 6)
+}
+
+func TestModelForecasts(t *testing.T) {
+	series := []capacitySeries{
+		{Group: "worker", Resource: "memory", Nodes: 2, Points: make([]analysis.Point, 3)},
+		{Group: "worker", Resource: "cpu", Nodes: 2},
+	}
+
+	forecasts, err := modelForecasts(series, []interface{}{
+		map[string]interface{}{"current_percent": 61.04, "growth_per_day_percent": 1.234, "days_until_threshold": 19.66},
+		map[string]interface{}{"current_percent": 30.0, "growth_per_day_percent": -0.5, "days_until_threshold": nil},
+	})
+	require.NoError(t, err)
+	require.Len(t, forecasts, 2)
+	assert.Equal(t, "kserve", forecasts[0].Method)
+	assert.Equal(t, 61.0, forecasts[0].CurrentPercent)
+	assert.Equal(t, 1.23, forecasts[0].GrowthPerDayPercent)
+	assert.Equal(t, 19.7, *forecasts[0].DaysUntilThreshold)
+	assert.Equal(t, 3, forecasts[0].Samples)
+	assert.Nil(t, forecasts[1].DaysUntilThreshold)
+
+	_, err = modelForecasts(series, []interface{}{map[string]interface{}{"current_percent": 1.0}})
+	assert.ErrorContains(t, err, "expected 2 predictions")
+	_, err = modelForecasts(series, []interface{}{map[string]interface{}{}, map[string]interface{}{}})
+	assert.ErrorContains(t, err, "no current_percent")
+}
+
+func TestCapacityStep(t *testing.T) {
+	assert.Equal(t, time.Hour, capacityStep(1))
+	assert.Equal(t, time.Hour, capacityStep(14))
+	assert.Equal(t, 2*time.Hour, capacityStep(30))
+	assert.Equal(t, 6*time.Hour, capacityStep(90)) // 5.4h rounded up to divide a day
+}
+
+func TestForecastCapacityTool_Errors(t *testing.T) {
+	k8sClient, prometheus := newForecastFixture(t)
+	tool := NewForecastCapacityTool(k8sClient, prometheus, nil, "capacity-forecast")
+
+	for _, args := range []map[string]interface{}{
+		{"threshold_percent": 0},
+		{"lookback_days": 91},
+		{"group_by_label": "not a label"},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err, "%v", args)
+		assert.True(t, IsInvalidArguments(err), "%v", args)
+	}
+
+	_, err := NewForecastCapacityTool(k8sClient, nil, nil, "capacity-forecast").Execute(context.Background(), map[string]interface{}{})
+	assert.ErrorContains(t, err, "needs utilization history from Prometheus")
+}
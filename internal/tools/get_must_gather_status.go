@@ -0,0 +1,242 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// must-gather phases reported by get-must-gather-status
+const (
+	MustGatherPhasePending   = "Pending"
+	MustGatherPhaseGathering = "Gathering"
+	MustGatherPhaseArchiving = "Archiving"
+	MustGatherPhaseReady     = "Ready"     // Archive is in the pod and can be copied out
+	MustGatherPhaseSucceeded = "Succeeded" // Archive is on the PVC
+	MustGatherPhaseExpired   = "Expired"   // Retention ended and the in-pod archive is gone
+	MustGatherPhaseFailed    = "Failed"
+)
+
+// GetMustGatherStatusTool follows must-gather Jobs started by trigger-must-gather
+type GetMustGatherStatusTool struct {
+	k8sClient clients.ClientsetProvider
+	namespace string
+}
+
+// NewGetMustGatherStatusTool creates a new get-must-gather-status tool
+func NewGetMustGatherStatusTool(k8sClient clients.ClientsetProvider, namespace string) *GetMustGatherStatusTool {
+	return &GetMustGatherStatusTool{
+		k8sClient: k8sClient,
+		namespace: namespace,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetMustGatherStatusTool) Name() string {
+	return "get-must-gather-status"
+}
+
+// Description returns the tool description for MCP
+func (t *GetMustGatherStatusTool) Description() string {
+	return "Get the progress of a must-gather started by trigger-must-gather (Pending, Gathering, Archiving, Ready, Succeeded, Expired, Failed) and where its archive landed, including the 'oc cp' command when the archive is kept in the pod. Defaults to the most recent run."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetMustGatherStatusTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"job_name": map[string]interface{}{
+				"type":        "string",
+				"description": "Job name returned by trigger-must-gather. Leave empty for the most recent run.",
+				"default":     "",
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetMustGatherStatusInput represents the input parameters
+type GetMustGatherStatusInput struct {
+	JobName string `json:"job_name"`
+}
+
+// MustGatherStatus represents the tool output
+type MustGatherStatus struct {
+	Namespace       string `json:"namespace"`
+	JobName         string `json:"job_name"`
+	Phase           string `json:"phase"`
+	Pod             string `json:"pod,omitempty"`
+	StartedAt       string `json:"started_at,omitempty"`
+	CompletedAt     string `json:"completed_at,omitempty"`
+	ArchiveLocation string `json:"archive_location,omitempty"`
+	CopyCommand     string `json:"copy_command,omitempty"`
+	Message         string `json:"message"`
+}
+
+// RequiredPermissions declares the Kubernetes API access get-must-gather-status needs
+func (t *GetMustGatherStatusTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "batch", Resource: "jobs", Verb: "list", Namespace: t.namespace},
+		{Resource: "pods", Verb: "list", Namespace: t.namespace},
+	}
+}
+
+// Execute runs the get-must-gather-status operation
+func (t *GetMustGatherStatusTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input GetMustGatherStatusInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	clientset := t.k8sClient.Clientset()
+	jobs, err := clientset.BatchV1().Jobs(t.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(mustGatherLabels).String(),
+	})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list must-gather jobs in %s: %w", t.namespace, err))
+	}
+
+	var job *batchv1.Job
+	for i := range jobs.Items {
+		candidate := &jobs.Items[i]
+		if input.JobName != "" {
+			if candidate.Name == input.JobName {
+				job = candidate
+				break
+			}
+			continue
+		}
+		if job == nil || candidate.CreationTimestamp.After(job.CreationTimestamp.Time) {
+			job = candidate
+		}
+	}
+	if job == nil {
+		if input.JobName != "" {
+			return nil, invalidArgs("must-gather job %s not found in %s", input.JobName, t.namespace)
+		}
+		return nil, invalidArgs("no must-gather jobs found in %s; start one with trigger-must-gather", t.namespace)
+	}
+
+	pods, err := clientset.CoreV1().Pods(t.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{batchv1.JobNameLabel: job.Name}).String(),
+	})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list pods of must-gather job %s: %w", job.Name, err))
+	}
+	var pod *corev1.Pod
+	for i := range pods.Items {
+		if pod == nil || pods.Items[i].CreationTimestamp.After(pod.CreationTimestamp.Time) {
+			pod = &pods.Items[i]
+		}
+	}
+
+	return mustGatherStatus(job, pod), nil
+}
+
+// mustGatherStatus derives the phase and archive location from the Job and its pod
+func mustGatherStatus(job *batchv1.Job, pod *corev1.Pod) MustGatherStatus {
+	status := MustGatherStatus{
+		Namespace: job.Namespace,
+		JobName:   job.Name,
+		Phase:     MustGatherPhasePending,
+	}
+	if job.Status.StartTime != nil {
+		status.StartedAt = job.Status.StartTime.Format(time.RFC3339)
+	}
+	if job.Status.CompletionTime != nil {
+		status.CompletedAt = job.Status.CompletionTime.Format(time.RFC3339)
+	}
+
+	podName := ""
+	if pod != nil {
+		podName = pod.Name
+		status.Pod = pod.Name
+	}
+	location := mustGatherArchiveLocation(job, podName)
+	onPVC := mustGatherUsesPVC(job)
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobFailed:
+			status.Phase = MustGatherPhaseFailed
+			status.Message = fmt.Sprintf("must-gather failed: %s %s", condition.Reason, condition.Message)
+			if pod != nil {
+				status.Message += fmt.Sprintf(" (see 'oc logs -n %s %s -c gather')", pod.Namespace, pod.Name)
+			}
+			return status
+		case batchv1.JobComplete:
+			if onPVC {
+				status.Phase = MustGatherPhaseSucceeded
+				status.ArchiveLocation = location
+				status.Message = "must-gather finished; the archive is on the PVC"
+			} else {
+				status.Phase = MustGatherPhaseExpired
+				status.Message = "must-gather finished and its retention period ended; the archive kept in the pod is gone. Set MUST_GATHER_PVC to keep archives."
+			}
+			return status
+		}
+	}
+
+	if pod == nil {
+		status.Message = "waiting for the must-gather pod to be created"
+		return status
+	}
+
+	for _, container := range pod.Status.InitContainerStatuses {
+		if container.Name != "gather" {
+			continue
+		}
+		switch {
+		case container.State.Running != nil:
+			status.Phase = MustGatherPhaseGathering
+			status.Message = "collecting cluster data"
+			return status
+		case container.State.Terminated == nil:
+			status.Message = "waiting for the must-gather pod to start"
+			return status
+		}
+	}
+
+	for _, container := range pod.Status.ContainerStatuses {
+		if container.Name != mustGatherArchiveContainer {
+			continue
+		}
+		if container.Ready && !onPVC {
+			status.Phase = MustGatherPhaseReady
+			status.ArchiveLocation = location
+			status.CopyCommand = fmt.Sprintf("oc cp -n %s -c %s %s:%s/%s ./%s",
+				pod.Namespace, mustGatherArchiveContainer, pod.Name, mustGatherDir, mustGatherArchive, mustGatherArchive)
+			status.Message = "archive is ready; copy it out before the retention period ends"
+			return status
+		}
+		if container.State.Running != nil {
+			status.Phase = MustGatherPhaseArchiving
+			status.Message = "compressing the gathered data"
+			return status
+		}
+	}
+
+	status.Message = "waiting for the must-gather pod to start"
+	return status
+}
+
+// mustGatherUsesPVC reports whether the Job writes its archive to a PVC
+func mustGatherUsesPVC(job *batchv1.Job) bool {
+	for _, volume := range job.Spec.Template.Spec.Volumes {
+		if volume.Name == mustGatherVolume {
+			return volume.PersistentVolumeClaim != nil
+		}
+	}
+	return false
+}
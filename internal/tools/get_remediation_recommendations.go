@@ -103,7 +103,7 @@ func (t *GetRemediationRecommendationsTool) Execute(ctx context.Context, args ma
 	// Call Coordination Engine API
 	resp, err := t.ceClient.AnalyzeAnomalies(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get recommendations from Coordination Engine: %w", err)
+		return nil, dependencyError(fmt.Errorf("failed to get recommendations from Coordination Engine: %w", err))
 	}
 
 	// Build output
@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"sigs.k8s.io/yaml"
+)
+
+// lastAppliedAnnotation holds a full copy of the applied object, including Secret data
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// ManifestPolicy restricts which objects get-resource-manifest may return
+type ManifestPolicy struct {
+	AllowedNamespaces func() []string // Namespaces objects may be read from (nil or empty = all), read on every call
+	DeniedKinds       []string        // Kinds that are never returned (case-insensitive)
+}
+
+// allowedNamespaces returns the current allowlist of a policy, nil for all
+func allowedNamespaces(provider func() []string) []string {
+	if provider == nil {
+		return nil
+	}
+	return provider()
+}
+
+// GetResourceManifestTool returns the live manifest of any Kubernetes object
+type GetResourceManifestTool struct {
+	k8sClient *clients.K8sClient
+	sanitizer *Sanitizer
+	policy    ManifestPolicy
+}
+
+// NewGetResourceManifestTool creates a new get-resource-manifest tool.
+// The sanitizer masks secret material before the manifest is rendered;
+// nil uses the default redaction patterns.
+func NewGetResourceManifestTool(k8sClient *clients.K8sClient, sanitizer *Sanitizer, policy ManifestPolicy) *GetResourceManifestTool {
+	if sanitizer == nil {
+		sanitizer = defaultSanitizer
+	}
+	return &GetResourceManifestTool{
+		k8sClient: k8sClient,
+		sanitizer: sanitizer,
+		policy:    policy,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetResourceManifestTool) Name() string {
+	return "get-resource-manifest"
+}
+
+// Description returns the tool description for MCP
+func (t *GetResourceManifestTool) Description() string {
+	return "Get the live YAML manifest of any Kubernetes or OpenShift object, including custom resources. Noisy fields (managedFields, resourceVersion) are removed, status is optional, and Secret data is redacted."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetResourceManifestTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"api_version": map[string]interface{}{
+				"type":        "string",
+				"description": "API version of the object (e.g., 'v1', 'apps/v1', 'serving.kserve.io/v1beta1')",
+			},
+			"kind": map[string]interface{}{
+				"type":        "string",
+				"description": "Kind of the object (e.g., 'ConfigMap', 'Deployment', 'InferenceService')",
+			},
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the object. Leave empty for cluster-scoped kinds.",
+				"default":     "",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the object",
+			},
+			"include_status": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include the status section in the manifest",
+				"default":     false,
+			},
+		},
+		"required": []string{"api_version", "kind", "name"},
+	}
+}
+
+// GetResourceManifestInput represents the input parameters
+type GetResourceManifestInput struct {
+	APIVersion    string `json:"api_version"`
+	Kind          string `json:"kind"`
+	Namespace     string `json:"namespace"`
+	Name          string `json:"name"`
+	IncludeStatus bool   `json:"include_status"`
+}
+
+// GetResourceManifestOutput represents the tool output
+type GetResourceManifestOutput struct {
+	APIVersion string `json:"api_version"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Redacted   bool   `json:"redacted"` // Secret data was replaced by sizes
+	Manifest   string `json:"manifest"` // YAML
+}
+
+// Execute fetches the object and renders it as YAML
+func (t *GetResourceManifestTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input GetResourceManifestInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, required fields are checked below
+	}
+
+	if input.APIVersion == "" || input.Kind == "" || input.Name == "" {
+		return nil, invalidArgs("api_version, kind, and name are required")
+	}
+	if t.kindDenied(input.Kind) {
+		return nil, invalidArgs("kind %s is not allowed by the server configuration", input.Kind)
+	}
+	if input.Namespace != "" && !t.namespaceAllowed(input.Namespace) {
+		return nil, invalidArgs("namespace %q is not in the allowed namespaces", input.Namespace)
+	}
+
+	obj, err := t.k8sClient.GetResource(ctx, input.APIVersion, input.Kind, input.Namespace, input.Name)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	content := obj.UnstructuredContent()
+	stripNoisyFields(content, input.IncludeStatus)
+
+	// Secret data is replaced by sizes; other sensitive keys are masked
+	sanitized, err := t.sanitizer.Sanitize(content)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := yaml.Marshal(sanitized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render manifest: %w", err)
+	}
+
+	return GetResourceManifestOutput{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Redacted:   obj.GetKind() == "Secret",
+		Manifest:   string(manifest),
+	}, nil
+}
+
+// kindDenied reports whether the policy denylist contains kind
+func (t *GetResourceManifestTool) kindDenied(kind string) bool {
+	for _, denied := range t.policy.DeniedKinds {
+		if strings.EqualFold(denied, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceAllowed reports whether objects in namespace may be read
+func (t *GetResourceManifestTool) namespaceAllowed(namespace string) bool {
+	allowlist := allowedNamespaces(t.policy.AllowedNamespaces)
+	if len(allowlist) == 0 {
+		return true
+	}
+	for _, allowed := range allowlist {
+		if allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// stripNoisyFields removes server-managed metadata that adds no insight
+func stripNoisyFields(content map[string]interface{}, includeStatus bool) {
+	if !includeStatus {
+		delete(content, "status")
+	}
+
+	metadata, ok := content["metadata"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	delete(metadata, "managedFields")
+	delete(metadata, "resourceVersion")
+	delete(metadata, "selfLink")
+
+	if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+		delete(annotations, lastAppliedAnnotation)
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+var (
+	deploymentGVK       = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	secretGVK           = schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+	nodeGVK             = schema.GroupVersionKind{Version: "v1", Kind: "Node"}
+	inferenceServiceGVK = schema.GroupVersionKind{Group: "serving.kserve.io", Version: "v1beta1", Kind: "InferenceService"}
+)
+
+// newManifestTestClient builds a K8sClient over a fake dynamic client seeded with objects
+func newManifestTestClient(objects ...runtime.Object) *clients.K8sClient {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(deploymentGVK, meta.RESTScopeNamespace)
+	mapper.Add(secretGVK, meta.RESTScopeNamespace)
+	mapper.Add(inferenceServiceGVK, meta.RESTScopeNamespace)
+	mapper.Add(nodeGVK, meta.RESTScopeRoot)
+
+	return clients.NewK8sClientWithDynamic(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...), mapper)
+}
+
+func newUnstructured(gvk schema.GroupVersionKind, namespace, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: fields}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+// executeManifest runs the tool and decodes the returned YAML
+func executeManifest(t *testing.T, tool *GetResourceManifestTool, args map[string]interface{}) (GetResourceManifestOutput, map[string]interface{}) {
+	t.Helper()
+	result, err := tool.Execute(context.Background(), args)
+	require.NoError(t, err)
+
+	output, ok := result.(GetResourceManifestOutput)
+	require.True(t, ok, "unexpected result type %T", result)
+
+	var manifest map[string]interface{}
+	require.NoError(t, yaml.Unmarshal([]byte(output.Manifest), &manifest))
+	return output, manifest
+}
+
+// staticNamespaces is a namespace allowlist that never changes
+func staticNamespaces(namespaces ...string) func() []string {
+	return func() []string { return namespaces }
+}
+
+func TestGetResourceManifestTool_CoreKind(t *testing.T) {
+	deployment := newUnstructured(deploymentGVK, "web", "frontend", map[string]interface{}{
+		"spec":   map[string]interface{}{"replicas": int64(3)},
+		"status": map[string]interface{}{"readyReplicas": int64(2)},
+	})
+	deployment.SetResourceVersion("12345")
+	deployment.SetAnnotations(map[string]string{lastAppliedAnnotation: `{"spec":{}}`})
+	deployment.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+
+	tool := NewGetResourceManifestTool(newManifestTestClient(deployment), nil, ManifestPolicy{})
+
+	output, manifest := executeManifest(t, tool, map[string]interface{}{
+		"api_version": "apps/v1",
+		"kind":        "Deployment",
+		"namespace":   "web",
+		"name":        "frontend",
+	})
+
+	assert.Equal(t, "Deployment", output.Kind)
+	assert.Equal(t, "web", output.Namespace)
+	assert.False(t, output.Redacted)
+	assert.Equal(t, float64(3), manifest["spec"].(map[string]interface{})["replicas"])
+	assert.NotContains(t, manifest, "status")
+
+	metadata := manifest["metadata"].(map[string]interface{})
+	assert.NotContains(t, metadata, "managedFields")
+	assert.NotContains(t, metadata, "resourceVersion")
+	assert.NotContains(t, metadata, "annotations")
+
+	// Status is kept on request
+	_, manifest = executeManifest(t, tool, map[string]interface{}{
+		"api_version":    "apps/v1",
+		"kind":           "Deployment",
+		"namespace":      "web",
+		"name":           "frontend",
+		"include_status": true,
+	})
+	assert.Equal(t, float64(2), manifest["status"].(map[string]interface{})["readyReplicas"])
+}
+
+func TestGetResourceManifestTool_CRDKind(t *testing.T) {
+	isvc := newUnstructured(inferenceServiceGVK, "models", "anomaly-detector", map[string]interface{}{
+		"spec": map[string]interface{}{
+			"predictor": map[string]interface{}{"model": map[string]interface{}{"modelFormat": map[string]interface{}{"name": "sklearn"}}},
+		},
+	})
+	tool := NewGetResourceManifestTool(newManifestTestClient(isvc), nil, ManifestPolicy{})
+
+	output, manifest := executeManifest(t, tool, map[string]interface{}{
+		"api_version": "serving.kserve.io/v1beta1",
+		"kind":        "InferenceService",
+		"namespace":   "models",
+		"name":        "anomaly-detector",
+	})
+
+	assert.Equal(t, "serving.kserve.io/v1beta1", output.APIVersion)
+	assert.Contains(t, output.Manifest, "sklearn")
+	assert.Equal(t, "InferenceService", manifest["kind"])
+}
+
+func TestGetResourceManifestTool_ClusterScopedKind(t *testing.T) {
+	node := newUnstructured(nodeGVK, "", "worker-1", map[string]interface{}{})
+	tool := NewGetResourceManifestTool(newManifestTestClient(node), nil, ManifestPolicy{AllowedNamespaces: staticNamespaces("web")})
+
+	output, _ := executeManifest(t, tool, map[string]interface{}{
+		"api_version": "v1",
+		"kind":        "Node",
+		"name":        "worker-1",
+	})
+	assert.Equal(t, "worker-1", output.Name)
+	assert.Empty(t, output.Namespace)
+}
+
+func TestGetResourceManifestTool_RedactsSecretData(t *testing.T) {
+	secret := newUnstructured(secretGVK, "web", "db-credentials", map[string]interface{}{
+		"type": "Opaque",
+		"data": map[string]interface{}{"password": "aHVudGVyMg=="}, // hunter2
+	})
+	secret.SetAnnotations(map[string]string{lastAppliedAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`})
+	tool := NewGetResourceManifestTool(newManifestTestClient(secret), nil, ManifestPolicy{})
+
+	output, manifest := executeManifest(t, tool, map[string]interface{}{
+		"api_version": "v1",
+		"kind":        "Secret",
+		"namespace":   "web",
+		"name":        "db-credentials",
+	})
+
+	assert.True(t, output.Redacted)
+	assert.NotContains(t, output.Manifest, "aHVudGVyMg==")
+	assert.Equal(t, "Opaque", manifest["type"])
+	assert.Equal(t, "<redacted, 7 bytes>", manifest["data"].(map[string]interface{})["password"])
+}
+
+func TestGetResourceManifestTool_Policy(t *testing.T) {
+	deployment := newUnstructured(deploymentGVK, "kube-system", "coredns", map[string]interface{}{})
+	tool := NewGetResourceManifestTool(newManifestTestClient(deployment), nil, ManifestPolicy{
+		AllowedNamespaces: staticNamespaces("web"),
+		DeniedKinds:       []string{"secret"},
+	})
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{
+		"api_version": "apps/v1",
+		"kind":        "Deployment",
+		"namespace":   "kube-system",
+		"name":        "coredns",
+	})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+	assert.Contains(t, err.Error(), "not in the allowed namespaces")
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{
+		"api_version": "v1",
+		"kind":        "Secret",
+		"namespace":   "web",
+		"name":        "db-credentials",
+	})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+	assert.Contains(t, err.Error(), "kind Secret is not allowed")
+}
+
+func TestGetResourceManifestTool_AllowlistReadPerCall(t *testing.T) {
+	deployment := newUnstructured(deploymentGVK, "kube-system", "coredns", map[string]interface{}{})
+	allowed := []string{"web"}
+	tool := NewGetResourceManifestTool(newManifestTestClient(deployment), nil, ManifestPolicy{
+		AllowedNamespaces: func() []string { return allowed },
+	})
+	args := map[string]interface{}{"api_version": "apps/v1", "kind": "Deployment", "namespace": "kube-system", "name": "coredns"}
+
+	_, err := tool.Execute(context.Background(), args)
+	require.Error(t, err)
+
+	// A reloaded allowlist applies to the next call
+	allowed = []string{"web", "kube-system"}
+	output, _ := executeManifest(t, tool, args)
+	assert.Equal(t, "kube-system", output.Namespace)
+}
+
+func TestGetResourceManifestTool_Errors(t *testing.T) {
+	tool := NewGetResourceManifestTool(newManifestTestClient(), nil, ManifestPolicy{})
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"kind": "Deployment"})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{
+		"api_version": "apps/v1",
+		"kind":        "Deployment",
+		"name":        "frontend",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "namespace is required")
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{
+		"api_version": "example.com/v1",
+		"kind":        "Widget",
+		"namespace":   "web",
+		"name":        "w",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve")
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{
+		"api_version": "apps/v1",
+		"kind":        "Deployment",
+		"namespace":   "web",
+		"name":        "missing",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
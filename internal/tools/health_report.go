@@ -0,0 +1,1117 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Sections of the health report, in the order they are rendered
+const (
+	ReportSectionHealth    = "health"
+	ReportSectionNodes     = "nodes"
+	ReportSectionOperators = "operators"
+	ReportSectionEvents    = "events"
+	ReportSectionAlerts    = "alerts"
+	ReportSectionCapacity  = "capacity"
+	ReportSectionTrend     = "trend"
+)
+
+// healthReportSections lists every section in rendering order
+var healthReportSections = []string{
+	ReportSectionHealth, ReportSectionNodes, ReportSectionOperators, ReportSectionEvents,
+	ReportSectionAlerts, ReportSectionCapacity, ReportSectionTrend,
+}
+
+// HealthReportSections returns every report section in rendering order
+func HealthReportSections() []string {
+	return slices.Clone(healthReportSections)
+}
+
+const (
+	// healthReportEventWindow is the period a daily report covers for events
+	healthReportEventWindow = 24 * time.Hour
+	// healthReportEventGroups is how many warning event groups are listed
+	healthReportEventGroups = 5
+	// reportAlertsQuery selects the alerts firing right now, without the
+	// always-firing alerts that only prove the alerting pipeline works
+	reportAlertsQuery = `ALERTS{alertstate="firing", alertname!~"Watchdog|InfoInhibitor"}`
+)
+
+// HealthBaseline is an earlier cluster health reading the report compares against
+type HealthBaseline struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Status        string    `json:"status"`
+	NodesTotal    int       `json:"nodes_total"`
+	NodesReady    int       `json:"nodes_ready"`
+	PodsTotal     int       `json:"pods_total"`
+	PodsRunning   int       `json:"pods_running"`
+	PodsPending   int       `json:"pods_pending"`
+	PodsFailed    int       `json:"pods_failed"`
+	PodsSucceeded int       `json:"pods_succeeded"`
+}
+
+// HealthHistoryLookup returns the last complete health sample taken at or
+// before the given time, and false when the history has none
+type HealthHistoryLookup func(at time.Time) (HealthBaseline, bool)
+
+// GenerateHealthReportTool renders a cluster health report for people rather than programs
+type GenerateHealthReportTool struct {
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient // nil when Prometheus is not configured
+	openshift  bool
+	history    HealthHistoryLookup // nil when the health history is disabled
+
+	mu       sync.Mutex
+	previous *HealthBaseline // Health at the last report, the default trend baseline
+}
+
+// NewGenerateHealthReportTool creates a new generate-health-report tool. prometheus
+// and history may be nil; openshift reports whether the cluster serves the
+// OpenShift config API.
+func NewGenerateHealthReportTool(k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient, openshift bool, history HealthHistoryLookup) *GenerateHealthReportTool {
+	return &GenerateHealthReportTool{
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+		openshift:  openshift,
+		history:    history,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GenerateHealthReportTool) Name() string {
+	return "generate-health-report"
+}
+
+// Volatility is slow: a report is a snapshot meant to be shared and read later
+func (t *GenerateHealthReportTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
+// Weight is heavy since a report runs most of the health checks at once
+func (t *GenerateHealthReportTool) Weight() Weight {
+	return WeightHeavy
+}
+
+// Description returns the tool description for MCP
+func (t *GenerateHealthReportTool) Description() string {
+	return `Generate a cluster health report as a Markdown document (and optionally HTML) for sharing with people: overall health, node problems, degraded ClusterOperators, the top warning event groups of the last 24 hours, firing alerts, requested and limit overcommit of CPU and memory, and the trend since the previous report or a given time. Sections whose integration is not available (OpenShift, Prometheus) are left out.
+
+Use this tool for questions like:
+- "Write me today's cluster health report"
+- "Summarize cluster health for the weekly ops review"
+- "How has the cluster changed since yesterday morning?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GenerateHealthReportTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"sections": map[string]interface{}{
+				"type":        "array",
+				"description": "Sections to include (default all)",
+				"items": map[string]interface{}{
+					"type": "string",
+					"enum": healthReportSections,
+				},
+			},
+			"compare_to": map[string]interface{}{
+				"type":        "string",
+				"description": "RFC 3339 timestamp to compute the trend from, using the health history. Defaults to the previous report.",
+			},
+			"html": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Also render the report as an HTML document",
+				"default":     false,
+			},
+			"artifact": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Store the rendered documents as artifacts and return links to them with a short summary, instead of the documents inline. Needs the server's artifact store.",
+				"default":     false,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GenerateHealthReportInput represents the input parameters
+type GenerateHealthReportInput struct {
+	Sections  []string `json:"sections"`
+	CompareTo string   `json:"compare_to"`
+	HTML      bool     `json:"html"`
+	Artifact  bool     `json:"artifact"`
+}
+
+// OmittedReportSection is a requested section left out of the report
+type OmittedReportSection struct {
+	Section string `json:"section"`
+	Reason  string `json:"reason"`
+}
+
+// GenerateHealthReportOutput represents the tool output
+type GenerateHealthReportOutput struct {
+	Sections []string               `json:"sections"`
+	Omitted  []OmittedReportSection `json:"omitted,omitempty"`
+	Report   HealthReport           `json:"report"`
+	Markdown string                 `json:"markdown"`
+	HTML     string                 `json:"html,omitempty"`
+}
+
+// HealthReportSummary is returned inline when the rendered report is stored as artifacts
+type HealthReportSummary struct {
+	GeneratedAt time.Time              `json:"generated_at"`
+	Status      string                 `json:"status,omitempty"`
+	Sections    []string               `json:"sections"`
+	Omitted     []OmittedReportSection `json:"omitted,omitempty"`
+}
+
+// HealthReport is the data a health report is rendered from. Sections not
+// included are nil; a section that could not be read carries its error.
+type HealthReport struct {
+	GeneratedAt time.Time              `json:"generated_at"`
+	Health      *ReportHealth          `json:"health,omitempty"`
+	Nodes       *ReportNodes           `json:"nodes,omitempty"`
+	Operators   *ReportOperators       `json:"operators,omitempty"`
+	Events      *ReportEvents          `json:"events,omitempty"`
+	Alerts      *ReportAlerts          `json:"alerts,omitempty"`
+	Capacity    *ReportCapacity        `json:"capacity,omitempty"`
+	Trend       *ReportTrend           `json:"trend,omitempty"`
+	current     *clients.ClusterHealth // Health read for the health and trend sections
+
+	// Findings are the problems of the nodes, operators and alerts sections,
+	// most severe first
+	Findings []findings.Finding `json:"findings,omitempty"`
+}
+
+// ReportHealth is the overall cluster health section
+type ReportHealth struct {
+	Status    string                  `json:"status,omitempty"`
+	Nodes     clients.NodeHealth      `json:"nodes"`
+	Pods      clients.PodHealth       `json:"pods"`
+	Operators *clients.OperatorHealth `json:"operators,omitempty"`
+	Error     string                  `json:"error,omitempty"`
+}
+
+// ReportNodes is the node status section
+type ReportNodes struct {
+	Total    int          `json:"total"`
+	Ready    int          `json:"ready"`
+	Problems []ReportNode `json:"problems,omitempty"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// ReportNode is a node that is not ready, cordoned, or under pressure
+type ReportNode struct {
+	Name   string   `json:"name"`
+	Issues []string `json:"issues"`
+}
+
+// ReportOperators is the ClusterOperator section
+type ReportOperators struct {
+	Total    int              `json:"total"`
+	Degraded []ReportOperator `json:"degraded,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// ReportOperator is a degraded or unavailable ClusterOperator
+type ReportOperator struct {
+	Name    string `json:"name"`
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+}
+
+// ReportEvents is the warning event section
+type ReportEvents struct {
+	WindowHours   int          `json:"window_hours"`
+	TotalWarnings int          `json:"total_warnings"`
+	Groups        []EventGroup `json:"groups,omitempty"`
+	Error         string       `json:"error,omitempty"`
+}
+
+// ReportAlerts is the firing alert section
+type ReportAlerts struct {
+	Firing int           `json:"firing"`
+	Alerts []ReportAlert `json:"alerts,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// ReportAlert is a firing alert, counted over its series
+type ReportAlert struct {
+	Name      string `json:"name"`
+	Severity  string `json:"severity,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Count     int    `json:"count"`
+}
+
+// ReportCapacity is the capacity and overcommit section
+type ReportCapacity struct {
+	Resources []ReportResourceCapacity `json:"resources,omitempty"`
+	Error     string                   `json:"error,omitempty"`
+}
+
+// ReportResourceCapacity compares the requests and limits of scheduled pods
+// with the allocatable capacity of the nodes
+type ReportResourceCapacity struct {
+	Resource         string  `json:"resource"`
+	Allocatable      string  `json:"allocatable"`
+	Requested        string  `json:"requested"`
+	Limits           string  `json:"limits"`
+	RequestedPercent float64 `json:"requested_percent"`
+	LimitsPercent    float64 `json:"limits_percent"`
+	UnlimitedPods    int     `json:"unlimited_pods"` // Pods without a limit, left out of the limits
+	Overcommitted    bool    `json:"overcommitted"`  // Limits exceed allocatable capacity
+}
+
+// ReportTrend is the section comparing current health with an earlier reading
+type ReportTrend struct {
+	Since          time.Time      `json:"since,omitempty"`
+	Baseline       string         `json:"baseline,omitempty"` // previous_report or health_history
+	PreviousStatus string         `json:"previous_status,omitempty"`
+	Status         string         `json:"status,omitempty"`
+	Changes        []ReportChange `json:"changes,omitempty"`
+	Note           string         `json:"note,omitempty"` // Why there is nothing to compare
+	Error          string         `json:"error,omitempty"`
+}
+
+// ReportChange is how one health metric moved since the baseline
+type ReportChange struct {
+	Metric   string `json:"metric"`
+	Previous int    `json:"previous"`
+	Current  int    `json:"current"`
+	Delta    int    `json:"delta"`
+}
+
+// skipReportSection reports that a section does not apply, rather than that it failed
+type skipReportSection string
+
+func (s skipReportSection) Error() string { return string(s) }
+
+// RequiredPermissions declares the Kubernetes API access generate-health-report needs
+func (t *GenerateHealthReportTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Resource: "events", Verb: "list"},
+		{Group: "config.openshift.io", Resource: "clusteroperators", Verb: "list"},
+	}
+}
+
+// Execute runs the generate-health-report operation
+func (t *GenerateHealthReportTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input GenerateHealthReportInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	sections, err := reportSections(input.Sections)
+	if err != nil {
+		return nil, err
+	}
+	var compareTo time.Time
+	if input.CompareTo != "" {
+		if compareTo, err = time.Parse(time.RFC3339, input.CompareTo); err != nil {
+			return nil, invalidArgs("compare_to must be an RFC 3339 timestamp such as 2026-10-14T08:00:00Z")
+		}
+		if t.history == nil {
+			return nil, invalidArgs("compare_to needs the health history, which is disabled (HEALTH_HISTORY_INTERVAL=0)")
+		}
+	}
+	if input.Artifact && !ArtifactsEnabled(ctx) {
+		return nil, invalidArgs("artifact needs the artifact store, which is disabled (ARTIFACT_DIRECTORY is not set)")
+	}
+
+	now := time.Now().UTC()
+	report := HealthReport{GeneratedAt: now}
+	output := GenerateHealthReportOutput{Sections: []string{}}
+	for _, section := range sections {
+		if err := t.collectSection(ctx, &report, section, compareTo); err != nil {
+			output.Omitted = append(output.Omitted, OmittedReportSection{Section: section, Reason: err.Error()})
+			continue
+		}
+		output.Sections = append(output.Sections, section)
+	}
+	if report.current != nil && !report.current.Partial {
+		t.remember(healthBaseline(now, report.current))
+	}
+	report.Findings = reportFindings(&report)
+
+	output.Report = report
+	if output.Markdown, err = renderHealthReportMarkdown(&report); err != nil {
+		return nil, fmt.Errorf("failed to render health report: %w", err)
+	}
+	if input.HTML {
+		if output.HTML, err = renderHealthReportHTML(&report); err != nil {
+			return nil, fmt.Errorf("failed to render health report: %w", err)
+		}
+	}
+	if input.Artifact {
+		return healthReportArtifacts(output), nil
+	}
+	return output, nil
+}
+
+// healthReportArtifacts returns the rendered documents of a report as
+// artifacts, with a summary of the report to return inline
+func healthReportArtifacts(output GenerateHealthReportOutput) ArtifactResult {
+	summary := HealthReportSummary{
+		GeneratedAt: output.Report.GeneratedAt,
+		Sections:    output.Sections,
+		Omitted:     output.Omitted,
+	}
+	if output.Report.Health != nil {
+		summary.Status = output.Report.Health.Status
+	}
+
+	name := "health-report-" + output.Report.GeneratedAt.UTC().Format("20060102T150405Z")
+	result := ArtifactResult{
+		Summary: summary,
+		Artifacts: []Artifact{
+			{Name: name + ".md", ContentType: "text/markdown; charset=utf-8", Content: []byte(output.Markdown)},
+		},
+	}
+	if output.HTML != "" {
+		result.Artifacts = append(result.Artifacts, Artifact{Name: name + ".html", ContentType: "text/html; charset=utf-8", Content: []byte(output.HTML)})
+	}
+	return result
+}
+
+// reportSections validates the requested sections and puts them in rendering order
+func reportSections(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return healthReportSections, nil
+	}
+	for _, section := range requested {
+		if !slices.Contains(healthReportSections, section) {
+			return nil, invalidArgs("unknown section %q; valid sections are %s", section, strings.Join(healthReportSections, ", "))
+		}
+	}
+	var sections []string
+	for _, section := range healthReportSections {
+		if slices.Contains(requested, section) {
+			sections = append(sections, section)
+		}
+	}
+	return sections, nil
+}
+
+// collectSection fills one section of the report. Failures are recorded in
+// the section; a skipReportSection error leaves the section out.
+func (t *GenerateHealthReportTool) collectSection(ctx context.Context, report *HealthReport, section string, compareTo time.Time) error {
+	switch section {
+	case ReportSectionHealth:
+		report.Health = &ReportHealth{}
+		health, err := t.clusterHealth(ctx, report)
+		if err != nil {
+			report.Health.Error = err.Error()
+			return nil
+		}
+		report.Health.Status = health.Status
+		report.Health.Nodes = health.Nodes
+		report.Health.Pods = health.Pods
+		report.Health.Operators = health.Operators
+	case ReportSectionNodes:
+		report.Nodes = &ReportNodes{}
+		nodes, err := t.k8sClient.ListNodes(ctx)
+		if err != nil {
+			report.Nodes.Error = err.Error()
+			return nil
+		}
+		*report.Nodes = reportNodes(nodes.Items)
+	case ReportSectionOperators:
+		if !t.openshift {
+			return skipReportSection("not an OpenShift cluster")
+		}
+		report.Operators = &ReportOperators{}
+		list, err := t.k8sClient.ListResources(ctx, "config.openshift.io/v1", "ClusterOperator", "", metav1.ListOptions{})
+		if err != nil {
+			report.Operators.Error = err.Error()
+			return nil
+		}
+		*report.Operators = reportOperators(list.Items)
+	case ReportSectionEvents:
+		report.Events = &ReportEvents{WindowHours: int(healthReportEventWindow.Hours())}
+		events, err := t.k8sClient.ListEvents(ctx, "")
+		if err != nil {
+			report.Events.Error = err.Error()
+			return nil
+		}
+		*report.Events = reportEvents(events.Items, report.GeneratedAt)
+	case ReportSectionAlerts:
+		if t.prometheus == nil {
+			return skipReportSection("Prometheus is not configured (ENABLE_PROMETHEUS)")
+		}
+		report.Alerts = &ReportAlerts{}
+		samples, err := t.prometheus.Query(ctx, reportAlertsQuery)
+		if err != nil {
+			report.Alerts.Error = err.Error()
+			return nil
+		}
+		*report.Alerts = reportAlerts(samples)
+	case ReportSectionCapacity:
+		report.Capacity = &ReportCapacity{}
+		nodes, err := t.k8sClient.ListNodes(ctx)
+		if err != nil {
+			report.Capacity.Error = err.Error()
+			return nil
+		}
+		pods, err := t.k8sClient.ListPods(ctx, "")
+		if err != nil {
+			report.Capacity.Error = err.Error()
+			return nil
+		}
+		*report.Capacity = reportCapacity(nodes.Items, pods.Items)
+	case ReportSectionTrend:
+		report.Trend = t.trend(ctx, report, compareTo)
+	}
+	return nil
+}
+
+// clusterHealth reads cluster health once for the health and trend sections
+func (t *GenerateHealthReportTool) clusterHealth(ctx context.Context, report *HealthReport) (*clients.ClusterHealth, error) {
+	if report.current != nil {
+		return report.current, nil
+	}
+	health, err := t.k8sClient.GetClusterHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if health.Partial {
+		return nil, fmt.Errorf("incomplete: %s", strings.Join(health.UnreadSections(), "; "))
+	}
+	report.current = health
+	return health, nil
+}
+
+// trend compares current health with the sample at compareTo, or with the
+// previous report when compareTo is zero
+func (t *GenerateHealthReportTool) trend(ctx context.Context, report *HealthReport, compareTo time.Time) *ReportTrend {
+	var baseline HealthBaseline
+	trend := &ReportTrend{}
+	if compareTo.IsZero() {
+		previous, ok := t.last()
+		if !ok {
+			trend.Note = "No previous report to compare with; pass compare_to to compare with the health history."
+			return trend
+		}
+		baseline, trend.Baseline = previous, "previous_report"
+	} else {
+		sample, ok := t.history(compareTo)
+		if !ok {
+			trend.Note = fmt.Sprintf("The health history has no sample at or before %s.", compareTo.UTC().Format(time.RFC3339))
+			return trend
+		}
+		baseline, trend.Baseline = sample, "health_history"
+	}
+
+	health, err := t.clusterHealth(ctx, report)
+	if err != nil {
+		trend.Error = err.Error()
+		return trend
+	}
+	current := healthBaseline(report.GeneratedAt, health)
+	trend.Since = baseline.Timestamp
+	trend.PreviousStatus = baseline.Status
+	trend.Status = current.Status
+	trend.Changes = healthChanges(baseline, current)
+	return trend
+}
+
+// remember keeps the health of the latest report as the next default baseline
+func (t *GenerateHealthReportTool) remember(baseline HealthBaseline) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.previous = &baseline
+}
+
+func (t *GenerateHealthReportTool) last() (HealthBaseline, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.previous == nil {
+		return HealthBaseline{}, false
+	}
+	return *t.previous, true
+}
+
+// healthBaseline flattens a cluster health reading taken at the given time
+func healthBaseline(at time.Time, health *clients.ClusterHealth) HealthBaseline {
+	return HealthBaseline{
+		Timestamp:     at,
+		Status:        health.Status,
+		NodesTotal:    health.Nodes.Total,
+		NodesReady:    health.Nodes.Ready,
+		PodsTotal:     health.Pods.Total,
+		PodsRunning:   health.Pods.Running,
+		PodsPending:   health.Pods.Pending,
+		PodsFailed:    health.Pods.Failed,
+		PodsSucceeded: health.Pods.Succeeded,
+	}
+}
+
+// healthChanges lists how each health metric moved from previous to current
+func healthChanges(previous, current HealthBaseline) []ReportChange {
+	change := func(metric string, before, after int) ReportChange {
+		return ReportChange{Metric: metric, Previous: before, Current: after, Delta: after - before}
+	}
+	return []ReportChange{
+		change("Nodes", previous.NodesTotal, current.NodesTotal),
+		change("Ready nodes", previous.NodesReady, current.NodesReady),
+		change("Pods", previous.PodsTotal, current.PodsTotal),
+		change("Running pods", previous.PodsRunning, current.PodsRunning),
+		change("Pending pods", previous.PodsPending, current.PodsPending),
+		change("Failed pods", previous.PodsFailed, current.PodsFailed),
+	}
+}
+
+// reportNodes lists the nodes that are not ready, cordoned, or under pressure
+func reportNodes(nodes []corev1.Node) ReportNodes {
+	result := ReportNodes{Total: len(nodes)}
+	pressures := []corev1.NodeConditionType{corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure}
+	for i := range nodes {
+		node := &nodes[i]
+		var issues []string
+		if nodeReady(node) {
+			result.Ready++
+		} else {
+			issues = append(issues, "NotReady")
+		}
+		if node.Spec.Unschedulable {
+			issues = append(issues, "cordoned")
+		}
+		for _, condition := range node.Status.Conditions {
+			if slices.Contains(pressures, condition.Type) && condition.Status == corev1.ConditionTrue {
+				issues = append(issues, string(condition.Type))
+			}
+		}
+		if len(issues) > 0 {
+			result.Problems = append(result.Problems, ReportNode{Name: node.Name, Issues: issues})
+		}
+	}
+	sort.Slice(result.Problems, func(i, j int) bool { return result.Problems[i].Name < result.Problems[j].Name })
+	return result
+}
+
+// reportOperators lists the degraded and unavailable ClusterOperators
+func reportOperators(operators []unstructured.Unstructured) ReportOperators {
+	result := ReportOperators{Total: len(operators)}
+	for i := range operators {
+		conditions := operatorConditions(&operators[i])
+		var states, messages []string
+		if degraded := findCondition(conditions, "Degraded"); degraded != nil && degraded.Status == "True" {
+			states = append(states, "degraded")
+			messages = append(messages, degraded.Message)
+		}
+		if available := findCondition(conditions, "Available"); available == nil || available.Status != "True" {
+			states = append(states, "unavailable")
+			if available != nil {
+				messages = append(messages, available.Message)
+			}
+		}
+		if len(states) > 0 {
+			result.Degraded = append(result.Degraded, ReportOperator{
+				Name:    operators[i].GetName(),
+				State:   strings.Join(states, ", "),
+				Message: strings.Join(slices.DeleteFunc(messages, func(m string) bool { return m == "" }), "; "),
+			})
+		}
+	}
+	sort.Slice(result.Degraded, func(i, j int) bool { return result.Degraded[i].Name < result.Degraded[j].Name })
+	return result
+}
+
+// reportFindings lists the problems of the node, operator and alert sections
+// as findings, with the titles the analysis tools use for the same problems
+func reportFindings(report *HealthReport) []findings.Finding {
+	var result []findings.Finding
+	add := func(finding findings.Finding) {
+		finding.Source = "generate-health-report"
+		finding.LastSeen = report.GeneratedAt
+		result = append(result, finding)
+	}
+
+	if report.Nodes != nil {
+		for _, node := range report.Nodes.Problems {
+			for _, issue := range node.Issues {
+				finding := findings.Finding{
+					Severity: findings.SeverityWarning,
+					Category: findings.CategoryNode,
+					Resource: resourceRef("node", "", node.Name),
+					Title:    "Node has " + issue,
+					Detail:   fmt.Sprintf("node %s: %s", node.Name, strings.Join(node.Issues, ", ")),
+					Evidence: []findings.Evidence{{Type: findings.EvidenceCondition, Ref: issue}},
+				}
+				switch issue {
+				case "NotReady":
+					finding.Severity, finding.Title = findings.SeverityCritical, nodeNotReadyTitle
+					finding.Evidence[0].Ref = string(corev1.NodeReady)
+				case "cordoned":
+					finding.Title, finding.Evidence = nodeCordonedTitle, nil
+				}
+				add(finding)
+			}
+		}
+	}
+	if report.Operators != nil {
+		for _, operator := range report.Operators.Degraded {
+			for _, state := range strings.Split(operator.State, ", ") {
+				title := "ClusterOperator is " + state
+				if state == "unavailable" {
+					title = "ClusterOperator is not available" // As assess-upgrade-readiness words it
+				}
+				add(findings.Finding{
+					Severity: findings.SeverityCritical,
+					Category: findings.CategoryOperator,
+					Resource: resourceRef("clusteroperator", "", operator.Name),
+					Title:    title,
+					Detail:   operator.Message,
+				})
+			}
+		}
+	}
+	if report.Alerts != nil {
+		for _, alert := range report.Alerts.Alerts {
+			add(findings.Finding{
+				Severity: alertSeverity(alert.Severity),
+				Category: findings.CategoryAlert,
+				Resource: resourceRef("alert", alert.Namespace, alert.Name),
+				Title:    alertFiringTitle,
+				Detail:   fmt.Sprintf("%d firing series", alert.Count),
+				Evidence: []findings.Evidence{{Type: findings.EvidenceAlert, Ref: alert.Name}},
+			})
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return findings.Merge(result)
+}
+
+// reportEvents summarizes the warning events of the report window
+func reportEvents(events []corev1.Event, now time.Time) ReportEvents {
+	warnings := make([]corev1.Event, 0, len(events))
+	for _, event := range events {
+		if event.Type == corev1.EventTypeWarning {
+			warnings = append(warnings, event)
+		}
+	}
+	aggregation := aggregateEvents(warnings, now, healthReportEventWindow, healthReportEventGroups)
+	return ReportEvents{
+		WindowHours:   int(healthReportEventWindow.Hours()),
+		TotalWarnings: aggregation.TotalEvents,
+		Groups:        aggregation.Groups,
+	}
+}
+
+// reportAlerts groups firing alert series by name, severity and namespace,
+// most severe first
+func reportAlerts(samples []clients.PromSample) ReportAlerts {
+	type alertKey struct{ name, severity, namespace string }
+	counts := make(map[alertKey]int)
+	for _, sample := range samples {
+		counts[alertKey{sample.Labels["alertname"], sample.Labels["severity"], sample.Labels["namespace"]}]++
+	}
+
+	result := ReportAlerts{Firing: len(samples)}
+	for key, count := range counts {
+		result.Alerts = append(result.Alerts, ReportAlert{Name: key.name, Severity: key.severity, Namespace: key.namespace, Count: count})
+	}
+	rank := map[string]int{"critical": 0, "warning": 1, "info": 2}
+	severityRank := func(severity string) int {
+		if r, ok := rank[severity]; ok {
+			return r
+		}
+		return len(rank)
+	}
+	sort.Slice(result.Alerts, func(i, j int) bool {
+		a, b := result.Alerts[i], result.Alerts[j]
+		if severityRank(a.Severity) != severityRank(b.Severity) {
+			return severityRank(a.Severity) < severityRank(b.Severity)
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Namespace < b.Namespace
+	})
+	return result
+}
+
+// reportCapacity compares the CPU and memory requests and limits of the pods
+// scheduled on the nodes with the nodes' allocatable capacity
+func reportCapacity(nodes []corev1.Node, pods []corev1.Pod) ReportCapacity {
+	var result ReportCapacity
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		allocatable := resource.Quantity{}
+		for i := range nodes {
+			if quantity, ok := nodes[i].Status.Allocatable[name]; ok {
+				allocatable.Add(quantity)
+			}
+		}
+
+		requested, limits := resource.Quantity{}, resource.Quantity{}
+		unlimited := 0
+		for i := range pods {
+			pod := &pods[i]
+			if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			requested.Add(podResourceRequest(pod, name))
+			if limit, ok := podResourceLimit(pod, name); ok {
+				limits.Add(limit)
+			} else {
+				unlimited++
+			}
+		}
+
+		capacity := ReportResourceCapacity{
+			Resource:         string(name),
+			Allocatable:      formatReportQuantity(name, allocatable),
+			Requested:        formatReportQuantity(name, requested),
+			Limits:           formatReportQuantity(name, limits),
+			RequestedPercent: quantityPercent(requested, allocatable),
+			LimitsPercent:    quantityPercent(limits, allocatable),
+			UnlimitedPods:    unlimited,
+		}
+		capacity.Overcommitted = capacity.LimitsPercent > 100
+		result.Resources = append(result.Resources, capacity)
+	}
+	return result
+}
+
+// quantityPercent returns part as a percentage of whole, to one decimal
+func quantityPercent(part, whole resource.Quantity) float64 {
+	if whole.IsZero() {
+		return 0
+	}
+	return math.Round(float64(part.MilliValue())/float64(whole.MilliValue())*1000) / 10
+}
+
+// formatReportQuantity renders CPU in cores and memory in GiB
+func formatReportQuantity(name corev1.ResourceName, quantity resource.Quantity) string {
+	if name == corev1.ResourceCPU {
+		return fmt.Sprintf("%.1f cores", float64(quantity.MilliValue())/1000)
+	}
+	return fmt.Sprintf("%.1f GiB", float64(quantity.Value())/(1<<30))
+}
+
+// healthReportFuncs are the helpers shared by the Markdown and HTML templates
+var healthReportFuncs = map[string]interface{}{
+	"timestamp": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+	"signed": func(delta int) string {
+		if delta > 0 {
+			return fmt.Sprintf("+%d", delta)
+		}
+		return fmt.Sprintf("%d", delta)
+	},
+	"percent": func(value float64) string { return fmt.Sprintf("%.1f%%", value) },
+	"join":    strings.Join,
+	// cell keeps free text from breaking a Markdown table row
+	"cell": func(text string) string {
+		return strings.NewReplacer("|", `\|`, "\r", " ", "\n", " ").Replace(text)
+	},
+}
+
+var healthReportMarkdown = template.Must(template.New("markdown").Funcs(healthReportFuncs).Parse(healthReportMarkdownTemplate))
+
+var healthReportHTML = htmltemplate.Must(htmltemplate.New("html").Funcs(healthReportFuncs).Parse(healthReportHTMLTemplate))
+
+// renderHealthReportMarkdown renders the report as Markdown
+func renderHealthReportMarkdown(report *HealthReport) (string, error) {
+	var buf bytes.Buffer
+	if err := healthReportMarkdown.Execute(&buf, report); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderHealthReportHTML renders the report as a standalone HTML document
+func renderHealthReportHTML(report *HealthReport) (string, error) {
+	var buf bytes.Buffer
+	if err := healthReportHTML.Execute(&buf, report); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+const healthReportMarkdownTemplate = `# Cluster Health Report
+
+Generated {{ timestamp .GeneratedAt }}
+{{- with .Findings }}
+
+## Findings
+
+| Severity | Resource | Finding | Detail |
+|---|---|---|---|
+{{- range . }}
+| {{ .Severity }} | {{ .Resource }} | {{ .Title }} | {{ cell .Detail }} |
+{{- end }}
+{{- end }}
+{{- with .Health }}
+
+## Cluster Health
+{{ if .Error }}
+_Could not be read: {{ .Error }}_
+{{- else }}
+**Status: {{ .Status }}**
+
+| | Total | Ready / Running | Not Ready / Pending | Failed |
+|---|---:|---:|---:|---:|
+| Nodes | {{ .Nodes.Total }} | {{ .Nodes.Ready }} | {{ .Nodes.NotReady }} | |
+| Pods | {{ .Pods.Total }} | {{ .Pods.Running }} | {{ .Pods.Pending }} | {{ .Pods.Failed }} |
+{{- with .Operators }}
+| ClusterOperators | {{ .Total }} | | {{ .Unavailable }} unavailable | {{ .Degraded }} degraded |
+{{- end }}
+{{- end }}
+{{- end }}
+{{- with .Nodes }}
+
+## Nodes
+{{ if .Error }}
+_Could not be read: {{ .Error }}_
+{{- else }}
+{{ .Ready }} of {{ .Total }} nodes are ready.
+{{- if .Problems }}
+
+| Node | Issues |
+|---|---|
+{{- range .Problems }}
+| {{ .Name }} | {{ join .Issues ", " }} |
+{{- end }}
+{{- else }} No node is cordoned or under pressure.
+{{- end }}
+{{- end }}
+{{- end }}
+{{- with .Operators }}
+
+## Cluster Operators
+{{ if .Error }}
+_Could not be read: {{ .Error }}_
+{{- else if .Degraded }}
+{{ len .Degraded }} of {{ .Total }} ClusterOperators need attention.
+
+| Operator | State | Message |
+|---|---|---|
+{{- range .Degraded }}
+| {{ .Name }} | {{ .State }} | {{ cell .Message }} |
+{{- end }}
+{{- else }}
+All {{ .Total }} ClusterOperators are available and not degraded.
+{{- end }}
+{{- end }}
+{{- with .Events }}
+
+## Warning Events (last {{ .WindowHours }}h)
+{{ if .Error }}
+_Could not be read: {{ .Error }}_
+{{- else if .Groups }}
+{{ .TotalWarnings }} warning events. Top groups:
+
+| Reason | Namespace | Count | Spike | Latest message |
+|---|---|---:|---|---|
+{{- range .Groups }}
+| {{ .Reason }} | {{ .Namespace }} | {{ .Count }} | {{ if .Spike }}yes{{ end }} | {{ cell .LatestMessage }} |
+{{- end }}
+{{- else }}
+No warning events.
+{{- end }}
+{{- end }}
+{{- with .Alerts }}
+
+## Firing Alerts
+{{ if .Error }}
+_Could not be read: {{ .Error }}_
+{{- else if .Alerts }}
+{{ .Firing }} alerts firing.
+
+| Alert | Severity | Namespace | Series |
+|---|---|---|---:|
+{{- range .Alerts }}
+| {{ .Name }} | {{ .Severity }} | {{ .Namespace }} | {{ .Count }} |
+{{- end }}
+{{- else }}
+No alerts firing.
+{{- end }}
+{{- end }}
+{{- with .Capacity }}
+
+## Capacity
+{{ if .Error }}
+_Could not be read: {{ .Error }}_
+{{- else }}
+| Resource | Allocatable | Requested | Limits | Overcommitted |
+|---|---:|---:|---:|---|
+{{- range .Resources }}
+| {{ .Resource }} | {{ .Allocatable }} | {{ .Requested }} ({{ percent .RequestedPercent }}) | {{ .Limits }} ({{ percent .LimitsPercent }}){{ if .UnlimitedPods }}, {{ .UnlimitedPods }} pods unlimited{{ end }} | {{ if .Overcommitted }}yes{{ else }}no{{ end }} |
+{{- end }}
+{{- end }}
+{{- end }}
+{{- with .Trend }}
+
+## Trend
+{{ if .Error }}
+_Could not be read: {{ .Error }}_
+{{- else if .Note }}
+{{ .Note }}
+{{- else }}
+Since {{ timestamp .Since }} ({{ if eq .Baseline "previous_report" }}previous report{{ else }}health history{{ end }}): status {{ if eq .PreviousStatus .Status }}unchanged at {{ .Status }}{{ else }}{{ .PreviousStatus }} → {{ .Status }}{{ end }}.
+
+| Metric | Then | Now | Change |
+|---|---:|---:|---:|
+{{- range .Changes }}
+| {{ .Metric }} | {{ .Previous }} | {{ .Current }} | {{ signed .Delta }} |
+{{- end }}
+{{- end }}
+{{- end }}
+`
+
+const healthReportHTMLTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Cluster Health Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+.error { color: #a00; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>Cluster Health Report</h1>
+<p>Generated {{ timestamp .GeneratedAt }}</p>
+{{- with .Findings }}
+<h2>Findings</h2>
+<table>
+<tr><th>Severity</th><th>Resource</th><th>Finding</th><th>Detail</th></tr>
+{{- range . }}
+<tr><td>{{ .Severity }}</td><td>{{ .Resource }}</td><td>{{ .Title }}</td><td>{{ .Detail }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- with .Health }}
+<h2>Cluster Health</h2>
+{{- if .Error }}
+<p class="error">Could not be read: {{ .Error }}</p>
+{{- else }}
+<p><strong>Status: {{ .Status }}</strong></p>
+<table>
+<tr><th></th><th>Total</th><th>Ready / Running</th><th>Not Ready / Pending</th><th>Failed</th></tr>
+<tr><td>Nodes</td><td>{{ .Nodes.Total }}</td><td>{{ .Nodes.Ready }}</td><td>{{ .Nodes.NotReady }}</td><td></td></tr>
+<tr><td>Pods</td><td>{{ .Pods.Total }}</td><td>{{ .Pods.Running }}</td><td>{{ .Pods.Pending }}</td><td>{{ .Pods.Failed }}</td></tr>
+{{- with .Operators }}
+<tr><td>ClusterOperators</td><td>{{ .Total }}</td><td></td><td>{{ .Unavailable }} unavailable</td><td>{{ .Degraded }} degraded</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+{{- with .Nodes }}
+<h2>Nodes</h2>
+{{- if .Error }}
+<p class="error">Could not be read: {{ .Error }}</p>
+{{- else }}
+<p>{{ .Ready }} of {{ .Total }} nodes are ready.{{ if not .Problems }} No node is cordoned or under pressure.{{ end }}</p>
+{{- if .Problems }}
+<table>
+<tr><th>Node</th><th>Issues</th></tr>
+{{- range .Problems }}
+<tr><td>{{ .Name }}</td><td>{{ join .Issues ", " }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+{{- end }}
+{{- with .Operators }}
+<h2>Cluster Operators</h2>
+{{- if .Error }}
+<p class="error">Could not be read: {{ .Error }}</p>
+{{- else if .Degraded }}
+<p>{{ len .Degraded }} of {{ .Total }} ClusterOperators need attention.</p>
+<table>
+<tr><th>Operator</th><th>State</th><th>Message</th></tr>
+{{- range .Degraded }}
+<tr><td>{{ .Name }}</td><td>{{ .State }}</td><td>{{ .Message }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>All {{ .Total }} ClusterOperators are available and not degraded.</p>
+{{- end }}
+{{- end }}
+{{- with .Events }}
+<h2>Warning Events (last {{ .WindowHours }}h)</h2>
+{{- if .Error }}
+<p class="error">Could not be read: {{ .Error }}</p>
+{{- else if .Groups }}
+<p>{{ .TotalWarnings }} warning events. Top groups:</p>
+<table>
+<tr><th>Reason</th><th>Namespace</th><th>Count</th><th>Spike</th><th>Latest message</th></tr>
+{{- range .Groups }}
+<tr><td>{{ .Reason }}</td><td>{{ .Namespace }}</td><td>{{ .Count }}</td><td>{{ if .Spike }}yes{{ end }}</td><td>{{ .LatestMessage }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>No warning events.</p>
+{{- end }}
+{{- end }}
+{{- with .Alerts }}
+<h2>Firing Alerts</h2>
+{{- if .Error }}
+<p class="error">Could not be read: {{ .Error }}</p>
+{{- else if .Alerts }}
+<p>{{ .Firing }} alerts firing.</p>
+<table>
+<tr><th>Alert</th><th>Severity</th><th>Namespace</th><th>Series</th></tr>
+{{- range .Alerts }}
+<tr><td>{{ .Name }}</td><td>{{ .Severity }}</td><td>{{ .Namespace }}</td><td>{{ .Count }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>No alerts firing.</p>
+{{- end }}
+{{- end }}
+{{- with .Capacity }}
+<h2>Capacity</h2>
+{{- if .Error }}
+<p class="error">Could not be read: {{ .Error }}</p>
+{{- else }}
+<table>
+<tr><th>Resource</th><th>Allocatable</th><th>Requested</th><th>Limits</th><th>Overcommitted</th></tr>
+{{- range .Resources }}
+<tr><td>{{ .Resource }}</td><td>{{ .Allocatable }}</td><td>{{ .Requested }} ({{ percent .RequestedPercent }})</td><td>{{ .Limits }} ({{ percent .LimitsPercent }}){{ if .UnlimitedPods }}, {{ .UnlimitedPods }} pods unlimited{{ end }}</td><td>{{ if .Overcommitted }}yes{{ else }}no{{ end }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+{{- with .Trend }}
+<h2>Trend</h2>
+{{- if .Error }}
+<p class="error">Could not be read: {{ .Error }}</p>
+{{- else if .Note }}
+<p>{{ .Note }}</p>
+{{- else }}
+<p>Since {{ timestamp .Since }} ({{ if eq .Baseline "previous_report" }}previous report{{ else }}health history{{ end }}): status {{ if eq .PreviousStatus .Status }}unchanged at {{ .Status }}{{ else }}{{ .PreviousStatus }} → {{ .Status }}{{ end }}.</p>
+<table>
+<tr><th>Metric</th><th>Then</th><th>Now</th><th>Change</th></tr>
+{{- range .Changes }}
+<tr><td>{{ .Metric }}</td><td>{{ .Previous }}</td><td>{{ .Current }}</td><td>{{ signed .Delta }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- end }}
+</body>
+</html>
+`
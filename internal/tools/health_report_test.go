@@ -0,0 +1,313 @@
+package tools
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// assertGolden compares got with testdata/name, rewriting the file with -update
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "run go test -update to create %s", path)
+	assert.Equal(t, string(want), got)
+}
+
+// healthReportFixture is a report with every section filled from fixed data
+func healthReportFixture() *HealthReport {
+	generated := time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC)
+	return &HealthReport{
+		GeneratedAt: generated,
+		Health: &ReportHealth{
+			Status:    "degraded",
+			Nodes:     clients.NodeHealth{Total: 6, Ready: 5, NotReady: 1},
+			Pods:      clients.PodHealth{Total: 240, Running: 228, Pending: 7, Failed: 2, Succeeded: 3},
+			Operators: &clients.OperatorHealth{Total: 33, Unavailable: 0, Degraded: 1},
+		},
+		Nodes: &ReportNodes{
+			Total: 6,
+			Ready: 5,
+			Problems: []ReportNode{
+				{Name: "worker-2", Issues: []string{"NotReady"}},
+				{Name: "worker-4", Issues: []string{"cordoned", "DiskPressure"}},
+			},
+		},
+		Operators: &ReportOperators{
+			Total:    33,
+			Degraded: []ReportOperator{{Name: "ingress", State: "degraded", Message: "Some ingresscontrollers are degraded | default"}},
+		},
+		Events: &ReportEvents{
+			WindowHours:   24,
+			TotalWarnings: 57,
+			Groups: []EventGroup{
+				{Reason: "BackOff", Namespace: "payments", Count: 41, Spike: true, LatestMessage: "Back-off restarting failed container api"},
+				{Reason: "FailedScheduling", Namespace: "batch", Count: 16, LatestMessage: "0/6 nodes are available: 6 Insufficient memory."},
+			},
+		},
+		Alerts: &ReportAlerts{
+			Firing: 4,
+			Alerts: []ReportAlert{
+				{Name: "KubeNodeNotReady", Severity: "critical", Count: 1},
+				{Name: "KubePodCrashLooping", Severity: "warning", Namespace: "payments", Count: 3},
+			},
+		},
+		Capacity: &ReportCapacity{Resources: []ReportResourceCapacity{
+			{Resource: "cpu", Allocatable: "48.0 cores", Requested: "31.5 cores", Limits: "62.0 cores", RequestedPercent: 65.6, LimitsPercent: 129.2, UnlimitedPods: 12, Overcommitted: true},
+			{Resource: "memory", Allocatable: "192.0 GiB", Requested: "120.5 GiB", Limits: "180.0 GiB", RequestedPercent: 62.8, LimitsPercent: 93.8},
+		}},
+		Trend: &ReportTrend{
+			Since:          generated.Add(-24 * time.Hour),
+			Baseline:       "previous_report",
+			PreviousStatus: "healthy",
+			Status:         "degraded",
+			Changes: healthChanges(
+				HealthBaseline{NodesTotal: 6, NodesReady: 6, PodsTotal: 236, PodsRunning: 230, PodsPending: 1, PodsFailed: 0},
+				HealthBaseline{NodesTotal: 6, NodesReady: 5, PodsTotal: 240, PodsRunning: 228, PodsPending: 7, PodsFailed: 2},
+			),
+		},
+	}
+}
+
+func TestRenderHealthReport_Golden(t *testing.T) {
+	report := healthReportFixture()
+	report.Findings = reportFindings(report)
+
+	markdown, err := renderHealthReportMarkdown(report)
+	require.NoError(t, err)
+	assertGolden(t, "health_report.golden.md", markdown)
+
+	html, err := renderHealthReportHTML(report)
+	require.NoError(t, err)
+	assertGolden(t, "health_report.golden.html", html)
+}
+
+func TestRenderHealthReport_GoldenPartial(t *testing.T) {
+	// A subset of sections, one unreadable, on a quiet cluster with no trend baseline
+	report := &HealthReport{
+		GeneratedAt: time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC),
+		Nodes:       &ReportNodes{Total: 3, Ready: 3},
+		Events:      &ReportEvents{WindowHours: 24, Error: "events is forbidden"},
+		Alerts:      &ReportAlerts{},
+		Trend:       &ReportTrend{Note: "No previous report to compare with; pass compare_to to compare with the health history."},
+	}
+
+	markdown, err := renderHealthReportMarkdown(report)
+	require.NoError(t, err)
+	assertGolden(t, "health_report_partial.golden.md", markdown)
+}
+
+func TestReportFindings(t *testing.T) {
+	report := healthReportFixture()
+	reported := reportFindings(report)
+	require.Len(t, reported, 6)
+	assert.Equal(t, "alert/KubeNodeNotReady", reported[0].Resource.String())
+	assert.Equal(t, findings.SeverityCritical, reported[0].Severity)
+	assert.Equal(t, "generate-health-report", reported[0].Source)
+	assert.Equal(t, report.GeneratedAt, reported[0].LastSeen)
+	assert.Equal(t, []findings.Evidence{{Type: findings.EvidenceAlert, Ref: "KubeNodeNotReady"}}, reported[0].Evidence)
+	assert.Equal(t, findings.SeverityWarning, reported[3].Severity, "warning alerts stay warnings")
+
+	// The NotReady node is the same problem assess-upgrade-readiness reports
+	upgrade := nodeUpgradeFindings([]corev1.Node{{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-2"},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}},
+	}})
+	upgrade[0].Check = "nodes"
+	merged := findings.Merge(reported, upgradeFindings(upgrade))
+	require.Len(t, merged, 6)
+	assert.Equal(t, "node/worker-2", merged[1].Resource.String())
+	assert.Equal(t, "generate-health-report,assess-upgrade-readiness", merged[1].Source)
+
+	assert.Nil(t, reportFindings(&HealthReport{Nodes: &ReportNodes{Total: 3, Ready: 3}}))
+}
+
+func newReportTestClient(objects ...runtime.Object) *clients.K8sClient {
+	return clients.NewK8sClientWithClientset(fake.NewClientset(objects...))
+}
+
+func TestGenerateHealthReportTool_Execute(t *testing.T) {
+	notReady := newCapacityNode("worker-2", "4", "16Gi")
+	notReady.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}
+	ready := newCapacityNode("worker-1", "4", "16Gi")
+	ready.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	pod := newNeighborPod("shop", "api", "worker-1", "2", "6", "4Gi")
+	pod.Status.Phase = corev1.PodRunning
+	event := newTestEvent("shop", "BackOff", "api", 0)
+	event.FirstTimestamp = metav1.NewTime(time.Now().Add(-5 * time.Minute))
+	event.LastTimestamp = event.FirstTimestamp
+	client := newReportTestClient(&ready, &notReady, &pod, &event)
+
+	prometheus := newAPFPrometheus(t, map[string]string{
+		reportAlertsQuery: `[{"metric":{"alertname":"KubeNodeNotReady","severity":"critical"},"value":[1717243200,"1"]}]`,
+	})
+	tool := NewGenerateHealthReportTool(client, prometheus, false, nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"html": true})
+	require.NoError(t, err)
+	output := result.(GenerateHealthReportOutput)
+
+	assert.Equal(t, []string{"health", "nodes", "events", "alerts", "capacity", "trend"}, output.Sections)
+	assert.Equal(t, []OmittedReportSection{{Section: "operators", Reason: "not an OpenShift cluster"}}, output.Omitted)
+	assert.NotContains(t, output.Markdown, "## Cluster Operators")
+	assert.Contains(t, output.Markdown, "| worker-2 | NotReady |")
+	assert.Contains(t, output.Markdown, "| BackOff | shop |")
+	assert.Contains(t, output.Markdown, "| KubeNodeNotReady | critical |")
+	assert.Contains(t, output.Markdown, "| cpu | 8.0 cores | 2.0 cores (25.0%) | 6.0 cores (75.0%) | no |")
+	assert.Contains(t, output.Markdown, "No previous report to compare with")
+	assert.Contains(t, output.HTML, "<h2>Firing Alerts</h2>")
+
+	// The next report compares with this one
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"sections": []interface{}{"trend"}})
+	require.NoError(t, err)
+	output = result.(GenerateHealthReportOutput)
+	assert.Equal(t, []string{"trend"}, output.Sections)
+	require.NotNil(t, output.Report.Trend)
+	assert.Equal(t, "previous_report", output.Report.Trend.Baseline)
+	assert.Contains(t, output.Markdown, "| Ready nodes | 1 | 1 | 0 |")
+	assert.Empty(t, output.HTML)
+}
+
+func TestGenerateHealthReportTool_CompareTo(t *testing.T) {
+	node := newCapacityNode("worker-1", "4", "16Gi")
+	node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	client := newReportTestClient(&node)
+	yesterday := time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC)
+
+	var asked time.Time
+	history := func(at time.Time) (HealthBaseline, bool) {
+		asked = at
+		if at.Before(yesterday) {
+			return HealthBaseline{}, false
+		}
+		return HealthBaseline{Timestamp: yesterday, Status: "degraded", NodesTotal: 2, NodesReady: 1}, true
+	}
+	tool := NewGenerateHealthReportTool(client, nil, false, history)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"sections":   []interface{}{"trend", "health"},
+		"compare_to": "2026-10-14T08:00:00Z",
+	})
+	require.NoError(t, err)
+	output := result.(GenerateHealthReportOutput)
+	assert.Equal(t, []string{"health", "trend"}, output.Sections, "rendering order, not request order")
+	assert.Equal(t, time.Date(2026, 10, 14, 8, 0, 0, 0, time.UTC), asked)
+	trend := output.Report.Trend
+	require.NotNil(t, trend)
+	assert.Equal(t, "health_history", trend.Baseline)
+	assert.Equal(t, yesterday, trend.Since)
+	assert.Contains(t, output.Markdown, "status degraded → healthy")
+	assert.Contains(t, output.Markdown, "| Nodes | 2 | 1 | -1 |")
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"sections": []interface{}{"trend"}, "compare_to": "2026-10-01T00:00:00Z"})
+	require.NoError(t, err)
+	assert.Contains(t, result.(GenerateHealthReportOutput).Markdown, "no sample at or before 2026-10-01T00:00:00Z")
+}
+
+func TestGenerateHealthReportTool_InvalidArgs(t *testing.T) {
+	tool := NewGenerateHealthReportTool(newReportTestClient(), nil, false, nil)
+
+	for _, args := range []map[string]interface{}{
+		{"sections": []interface{}{"health", "weather"}},
+		{"compare_to": "yesterday"},
+		{"compare_to": "2026-10-14T08:00:00Z"}, // The health history is disabled
+		{"artifact": true},                     // The artifact store is disabled
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err, args)
+		assert.True(t, IsInvalidArguments(err), args)
+	}
+}
+
+func TestGenerateHealthReportTool_Artifact(t *testing.T) {
+	node := newCapacityNode("worker-1", "4", "16Gi")
+	node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	tool := NewGenerateHealthReportTool(newReportTestClient(&node), nil, false, nil)
+
+	result, err := tool.Execute(WithArtifacts(context.Background()), map[string]interface{}{
+		"sections": []interface{}{"health", "nodes"},
+		"html":     true,
+		"artifact": true,
+	})
+	require.NoError(t, err)
+	artifacts, ok := result.(ArtifactResult)
+	require.True(t, ok, "got %T", result)
+
+	summary := artifacts.Summary.(HealthReportSummary)
+	assert.Equal(t, []string{"health", "nodes"}, summary.Sections)
+	assert.NotEmpty(t, summary.Status)
+
+	require.Len(t, artifacts.Artifacts, 2)
+	markdown, html := artifacts.Artifacts[0], artifacts.Artifacts[1]
+	assert.Regexp(t, `^health-report-\d{8}T\d{6}Z\.md$`, markdown.Name)
+	assert.Equal(t, "text/markdown; charset=utf-8", markdown.ContentType)
+	assert.Contains(t, string(markdown.Content), "1 of 1 nodes are ready")
+	assert.Equal(t, "text/html; charset=utf-8", html.ContentType)
+	assert.Contains(t, string(html.Content), "<h2>")
+}
+
+func TestGenerateHealthReportTool_SharesReadsWithinCall(t *testing.T) {
+	node := newCapacityNode("worker-1", "4", "16Gi")
+	node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}
+	clientset := fake.NewClientset(&node)
+	tool := NewGenerateHealthReportTool(clients.NewK8sClientWithClientset(clientset), nil, false, nil)
+
+	// The health, nodes and capacity sections each read the nodes
+	_, err := tool.Execute(clients.WithRequestCache(context.Background()), map[string]interface{}{
+		"sections": []interface{}{"health", "nodes", "capacity"},
+	})
+	require.NoError(t, err)
+
+	lists := map[string]int{}
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "list" {
+			lists[action.GetResource().Resource]++
+		}
+	}
+	assert.Equal(t, 1, lists["nodes"])
+	assert.Equal(t, 1, lists["pods"])
+}
+
+func TestReportCapacity_Overcommit(t *testing.T) {
+	nodes := []corev1.Node{newCapacityNode("worker-1", "4", "8Gi")}
+	pods := []corev1.Pod{
+		newNeighborPod("shop", "api", "worker-1", "1", "3", "2Gi"),
+		newNeighborPod("shop", "worker", "worker-1", "1", "3", "2Gi"),
+		newNeighborPod("shop", "pending", "", "1", "3", "2Gi"),
+	}
+	done := newNeighborPod("shop", "job", "worker-1", "1", "3", "2Gi")
+	done.Status.Phase = corev1.PodSucceeded
+	pods = append(pods, done)
+
+	capacity := reportCapacity(nodes, pods)
+	require.Len(t, capacity.Resources, 2)
+	cpu := capacity.Resources[0]
+	assert.Equal(t, "2.0 cores", cpu.Requested, "unscheduled and finished pods do not count")
+	assert.Equal(t, 50.0, cpu.RequestedPercent)
+	assert.Equal(t, 150.0, cpu.LimitsPercent)
+	assert.True(t, cpu.Overcommitted)
+	memory := capacity.Resources[1]
+	assert.Equal(t, "4.0 GiB", memory.Requested)
+	assert.Equal(t, 2, memory.UnlimitedPods)
+	assert.False(t, memory.Overcommitted)
+}
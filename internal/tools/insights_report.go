@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// insightsArchiveLocation is where the insights-operator keeps gathered archives
+const insightsArchiveLocation = "openshift-insights/insights-operator pod: /var/lib/insights-operator"
+
+// insightsRiskNames maps Insights total risk levels to their console names
+var insightsRiskNames = map[int64]string{
+	1: "Low",
+	2: "Moderate",
+	3: "Important",
+	4: "Critical",
+}
+
+// GetInsightsReportTool reports the Insights operator state and active recommendations
+type GetInsightsReportTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewGetInsightsReportTool creates a new get-insights-report tool
+func NewGetInsightsReportTool(k8sClient *clients.K8sClient) *GetInsightsReportTool {
+	return &GetInsightsReportTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetInsightsReportTool) Name() string {
+	return "get-insights-report"
+}
+
+// Volatility is slow since Insights only re-analyses the cluster a few times a day
+func (t *GetInsightsReportTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
+// Description returns the tool description for MCP
+func (t *GetInsightsReportTool) Description() string {
+	return "Get the OpenShift Insights report for support escalations: insights-operator conditions, when the last archive was gathered, whether uploads are disabled, and the active Insights recommendations sorted by risk."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetInsightsReportTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"min_risk": map[string]interface{}{
+				"type":        "integer",
+				"description": "Only return recommendations at or above this total risk (1=Low, 2=Moderate, 3=Important, 4=Critical)",
+				"default":     1,
+				"minimum":     1,
+				"maximum":     4,
+			},
+			"include_disabled": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Include recommendations that have been disabled for this cluster",
+				"default":     false,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetInsightsReportInput represents the input parameters
+type GetInsightsReportInput struct {
+	MinRisk         int64 `json:"min_risk"`
+	IncludeDisabled bool  `json:"include_disabled"`
+}
+
+// InsightsRecommendation is an Insights health check reported for the cluster
+type InsightsRecommendation struct {
+	Description string `json:"description"`
+	TotalRisk   int64  `json:"total_risk"`
+	Risk        string `json:"risk"`
+	State       string `json:"state"`
+	AdvisorURI  string `json:"advisor_uri,omitempty"`
+}
+
+// GetInsightsReportOutput represents the tool output
+type GetInsightsReportOutput struct {
+	OperatorAvailable  bool                     `json:"operator_available"`
+	OperatorDegraded   bool                     `json:"operator_degraded"`
+	UploadsDisabled    bool                     `json:"uploads_disabled"`
+	Conditions         []OperatorCondition      `json:"conditions"`
+	ArchiveAvailable   bool                     `json:"archive_available"`
+	ArchiveLocation    string                   `json:"archive_location,omitempty"`
+	LastGatherTime     string                   `json:"last_gather_time,omitempty"`
+	ReportDownloadedAt string                   `json:"report_downloaded_at,omitempty"`
+	Recommendations    []InsightsRecommendation `json:"recommendations"`
+	TotalRisks         map[string]int           `json:"total_risks"` // Count per risk name before filtering
+	Notes              []string                 `json:"notes,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access get-insights-report needs
+func (t *GetInsightsReportTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "config.openshift.io", Resource: "clusteroperators", Verb: "get"},
+		{Group: "operator.openshift.io", Resource: "insightsoperators", Verb: "get"},
+	}
+}
+
+// Execute runs the get-insights-report operation
+func (t *GetInsightsReportTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetInsightsReportInput{MinRisk: 1}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.MinRisk < 1 || input.MinRisk > 4 {
+		return nil, invalidArgs("min_risk must be between 1 and 4")
+	}
+
+	clusterOperator, err := t.k8sClient.GetResource(ctx, "config.openshift.io/v1", "ClusterOperator", "", "insights")
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	output := GetInsightsReportOutput{}
+	applyInsightsConditions(&output, clusterOperator)
+
+	insightsOperator, err := t.k8sClient.GetResource(ctx, "operator.openshift.io/v1", "InsightsOperator", "", "cluster")
+	switch {
+	case err == nil:
+		applyInsightsReport(&output, insightsOperator, input.MinRisk, input.IncludeDisabled)
+	case apierrors.IsNotFound(err) || meta.IsNoMatchError(err):
+		output.Recommendations = []InsightsRecommendation{}
+		output.TotalRisks = map[string]int{}
+		output.Notes = append(output.Notes, "InsightsOperator resource not found; gather status and recommendations are not available on this OpenShift version")
+	default:
+		return nil, err
+	}
+
+	if output.UploadsDisabled {
+		output.Notes = append(output.Notes, "Insights uploads are disabled, so recommendations may be missing or stale; attach a must-gather to support cases instead")
+	}
+	return output, nil
+}
+
+// applyInsightsConditions copies the insights ClusterOperator conditions into output
+func applyInsightsConditions(output *GetInsightsReportOutput, clusterOperator *unstructured.Unstructured) {
+	output.Conditions = operatorConditions(clusterOperator)
+	output.OperatorAvailable = conditionTrue(output.Conditions, "Available")
+	output.OperatorDegraded = conditionTrue(output.Conditions, "Degraded")
+	output.UploadsDisabled = conditionTrue(output.Conditions, "Disabled")
+}
+
+// applyInsightsReport copies gather status and recommendations from the InsightsOperator into output
+func applyInsightsReport(output *GetInsightsReportOutput, insightsOperator *unstructured.Unstructured, minRisk int64, includeDisabled bool) {
+	if gathered := formatInsightsTime(insightsOperator, "status", "gatherStatus", "lastGatherTime"); gathered != "" {
+		output.LastGatherTime = gathered
+		output.ArchiveAvailable = true
+		output.ArchiveLocation = insightsArchiveLocation
+	}
+	output.ReportDownloadedAt = formatInsightsTime(insightsOperator, "status", "insightsReport", "downloadedAt")
+
+	healthChecks, _, _ := unstructured.NestedSlice(insightsOperator.Object, "status", "insightsReport", "healthChecks")
+	output.Recommendations = make([]InsightsRecommendation, 0, len(healthChecks))
+	output.TotalRisks = make(map[string]int)
+	for _, raw := range healthChecks {
+		fields, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		totalRisk, _, _ := unstructured.NestedInt64(fields, "totalRisk")
+		recommendation := InsightsRecommendation{
+			Description: nestedString(fields, "description"),
+			TotalRisk:   totalRisk,
+			Risk:        insightsRiskNames[totalRisk],
+			State:       nestedString(fields, "state"),
+			AdvisorURI:  nestedString(fields, "advisorURI"),
+		}
+		if recommendation.Risk == "" {
+			recommendation.Risk = "Unknown"
+		}
+
+		enabled := recommendation.State != "Disabled"
+		if enabled {
+			output.TotalRisks[recommendation.Risk]++
+		}
+		if (!enabled && !includeDisabled) || totalRisk < minRisk {
+			continue
+		}
+		output.Recommendations = append(output.Recommendations, recommendation)
+	}
+
+	sort.SliceStable(output.Recommendations, func(i, j int) bool {
+		return output.Recommendations[i].TotalRisk > output.Recommendations[j].TotalRisk
+	})
+}
+
+// formatInsightsTime returns the timestamp at fields as RFC3339, or "" when unset
+func formatInsightsTime(obj *unstructured.Unstructured, fields ...string) string {
+	value, _, _ := unstructured.NestedString(obj.Object, fields...)
+	if value == "" {
+		return ""
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return parsed.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+var (
+	clusterOperatorGVK  = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ClusterOperator"}
+	insightsOperatorGVK = schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1", Kind: "InsightsOperator"}
+)
+
+func newInsightsTestClient(objects ...runtime.Object) *clients.K8sClient {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(clusterOperatorGVK, meta.RESTScopeRoot)
+	mapper.Add(insightsOperatorGVK, meta.RESTScopeRoot)
+	return clients.NewK8sClientWithDynamic(dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...), mapper)
+}
+
+func newInsightsClusterOperator(disabled string) runtime.Object {
+	return newUnstructured(clusterOperatorGVK, "", "insights", map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+				map[string]interface{}{"type": "Degraded", "status": "False"},
+				map[string]interface{}{"type": "Disabled", "status": disabled, "reason": "NoToken", "message": "Health reporting is disabled"},
+			},
+		},
+	})
+}
+
+func TestGetInsightsReportTool_Recommendations(t *testing.T) {
+	insightsOperator := newUnstructured(insightsOperatorGVK, "", "cluster", map[string]interface{}{
+		"status": map[string]interface{}{
+			"gatherStatus": map[string]interface{}{"lastGatherTime": "2026-10-14T08:00:00Z"},
+			"insightsReport": map[string]interface{}{
+				"downloadedAt": "2026-10-14T08:05:00Z",
+				"healthChecks": []interface{}{
+					map[string]interface{}{"description": "Low risk check", "totalRisk": int64(1), "state": "Enabled"},
+					map[string]interface{}{"description": "Critical etcd issue", "totalRisk": int64(4), "state": "Enabled", "advisorURI": "https://console.redhat.com/openshift/insights/advisor/clusters/abc"},
+					map[string]interface{}{"description": "Muted check", "totalRisk": int64(3), "state": "Disabled"},
+				},
+			},
+		},
+	})
+	tool := NewGetInsightsReportTool(newInsightsTestClient(newInsightsClusterOperator("False"), insightsOperator))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetInsightsReportOutput)
+
+	assert.True(t, output.OperatorAvailable)
+	assert.False(t, output.OperatorDegraded)
+	assert.False(t, output.UploadsDisabled)
+	assert.Len(t, output.Conditions, 3)
+	assert.True(t, output.ArchiveAvailable)
+	assert.Equal(t, "2026-10-14T08:00:00Z", output.LastGatherTime)
+	assert.Equal(t, "2026-10-14T08:05:00Z", output.ReportDownloadedAt)
+	assert.Equal(t, map[string]int{"Low": 1, "Critical": 1}, output.TotalRisks)
+
+	require.Len(t, output.Recommendations, 2)
+	assert.Equal(t, "Critical etcd issue", output.Recommendations[0].Description)
+	assert.Equal(t, "Critical", output.Recommendations[0].Risk)
+	assert.Equal(t, "Low risk check", output.Recommendations[1].Description)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"min_risk": 3, "include_disabled": true})
+	require.NoError(t, err)
+	output = result.(GetInsightsReportOutput)
+	require.Len(t, output.Recommendations, 2)
+	assert.Equal(t, "Critical etcd issue", output.Recommendations[0].Description)
+	assert.Equal(t, "Muted check", output.Recommendations[1].Description)
+}
+
+func TestGetInsightsReportTool_NoInsightsOperator(t *testing.T) {
+	tool := NewGetInsightsReportTool(newInsightsTestClient(newInsightsClusterOperator("True")))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetInsightsReportOutput)
+
+	assert.True(t, output.UploadsDisabled)
+	assert.False(t, output.ArchiveAvailable)
+	assert.Empty(t, output.Recommendations)
+	assert.Len(t, output.Notes, 2)
+}
+
+func TestGetInsightsReportTool_InvalidMinRisk(t *testing.T) {
+	tool := NewGetInsightsReportTool(newInsightsTestClient())
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"min_risk": 5})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
@@ -0,0 +1,518 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+const (
+	// kubeletMaxMinorSkew is how many minor versions a kubelet may lag the API server
+	kubeletMaxMinorSkew = 2
+
+	// nodeLeaseNamespace holds the leases kubelets renew as their heartbeat
+	nodeLeaseNamespace = "kube-node-lease"
+
+	// nodeEventWindow is how far back node health events are reported
+	nodeEventWindow = time.Hour
+
+	// maxNodeEvents caps the events reported per node
+	maxNodeEvents = 5
+
+	// kubeletHealthzTimeout bounds each /healthz call through the node proxy
+	kubeletHealthzTimeout = 5 * time.Second
+
+	// kubeletHealthzConcurrency bounds the /healthz calls in flight
+	kubeletHealthzConcurrency = 10
+)
+
+// Version skew states between a kubelet and the API server
+const (
+	VersionSkewMatch       = "match"       // Same minor version
+	VersionSkewSupported   = "supported"   // Older, within the supported window
+	VersionSkewUnsupported = "unsupported" // Too old, or newer than the API server
+	VersionSkewUnknown     = "unknown"     // A version could not be parsed
+)
+
+// nodeHealthEventReasons are Normal-type node events that still indicate a kubelet or node problem
+var nodeHealthEventReasons = map[string]bool{
+	"NodeNotReady":              true,
+	"Rebooted":                  true,
+	"NodeHasDiskPressure":       true,
+	"NodeHasInsufficientMemory": true,
+	"NodeHasInsufficientPID":    true,
+	"SystemOOM":                 true,
+	"KubeletSetupFailed":        true,
+}
+
+// KubeletHealthConfig configures get-kubelet-health
+type KubeletHealthConfig struct {
+	LeaseStaleAfter  time.Duration // Node lease age after which the kubelet heartbeat is stale
+	StatusStaleAfter time.Duration // Node condition heartbeat age after which node status is stale
+	HealthzProbe     bool          // Call the kubelet /healthz through the API server node proxy
+}
+
+// GetKubeletHealthTool reports per-node kubelet health beyond the Ready condition
+type GetKubeletHealthTool struct {
+	k8sClient clients.ClusterReader
+	config    KubeletHealthConfig
+}
+
+// NewGetKubeletHealthTool creates a new get-kubelet-health tool
+func NewGetKubeletHealthTool(k8sClient clients.ClusterReader, config KubeletHealthConfig) *GetKubeletHealthTool {
+	return &GetKubeletHealthTool{
+		k8sClient: k8sClient,
+		config:    config,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetKubeletHealthTool) Name() string {
+	return "get-kubelet-health"
+}
+
+// Description returns the tool description for MCP
+func (t *GetKubeletHealthTool) Description() string {
+	return `Check kubelet health on each node, beyond the Ready condition which can lag reality. Reports node conditions with the age of their last heartbeat, the age of the node lease the kubelet renews every few seconds, kubelet and container runtime versions with version skew against the API server (kubelets may be at most two minor versions older, never newer), and recent node events such as NodeNotReady, Rebooted, SystemOOM or PLEG problems. When ENABLE_KUBELET_HEALTHZ_PROBE is set, also calls each kubelet's /healthz through the API server node proxy.
+
+Use this tool for questions like:
+- "Are all kubelets healthy?"
+- "Why does node worker-3 keep flapping NotReady?"
+- "Are any nodes on an unsupported kubelet version?"
+- "Which node has a stale heartbeat?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetKubeletHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"node": map[string]interface{}{
+				"type":        "string",
+				"description": "Check only this node (empty = all nodes)",
+				"default":     "",
+			},
+			"only_unhealthy": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Return only nodes with at least one problem",
+				"default":     false,
+			},
+			"label_selector": map[string]interface{}{
+				"type":        "string",
+				"description": "Check only nodes matching this label selector (e.g., 'topology.kubernetes.io/zone=us-east-1a')",
+			},
+			"name_prefix": map[string]interface{}{
+				"type":        "string",
+				"description": "Check only nodes whose name starts with this prefix",
+			},
+			"role": map[string]interface{}{
+				"type":        "string",
+				"description": "Check only nodes of this role, by their node-role.kubernetes.io labels: master (or control-plane), worker, or infra",
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetKubeletHealthInput represents the input parameters
+type GetKubeletHealthInput struct {
+	Node          string `json:"node"`
+	OnlyUnhealthy bool   `json:"only_unhealthy"`
+	LabelSelector string `json:"label_selector"`
+	NamePrefix    string `json:"name_prefix"`
+	Role          string `json:"role"`
+}
+
+// KubeletCondition is a node condition with the age of its heartbeat
+type KubeletCondition struct {
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	Reason        string    `json:"reason,omitempty"`
+	Message       string    `json:"message,omitempty"`
+	LastHeartbeat time.Time `json:"last_heartbeat"`
+	HeartbeatAge  string    `json:"heartbeat_age,omitempty"`
+}
+
+// KubeletVersionSkew compares a kubelet version with the API server's
+type KubeletVersionSkew struct {
+	MinorVersionsBehind int    `json:"minor_versions_behind"` // Negative when the kubelet is newer
+	Status              string `json:"status"`
+	Message             string `json:"message"`
+}
+
+// NodeHealthEvent is a recent event about a node's health
+type NodeHealthEvent struct {
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// KubeletHealth is the kubelet health of one node
+type KubeletHealth struct {
+	Node                    string             `json:"node"`
+	Healthy                 bool               `json:"healthy"`
+	Problems                []string           `json:"problems,omitempty"`
+	Ready                   bool               `json:"ready"`
+	Conditions              []KubeletCondition `json:"conditions"`
+	LeaseRenewTime          *time.Time         `json:"lease_renew_time,omitempty"`
+	LeaseAge                string             `json:"lease_age,omitempty"`
+	KubeletVersion          string             `json:"kubelet_version"`
+	ContainerRuntimeVersion string             `json:"container_runtime_version"`
+	VersionSkew             KubeletVersionSkew `json:"version_skew"`
+	Events                  []NodeHealthEvent  `json:"events,omitempty"`
+	Healthz                 string             `json:"healthz,omitempty"` // Body of /healthz, or the probe error
+}
+
+// GetKubeletHealthOutput represents the tool output
+type GetKubeletHealthOutput struct {
+	APIServerVersion string              `json:"api_server_version"`
+	Filter           *clients.NodeFilter `json:"filter,omitempty"` // The node filter applied, if any
+	TotalNodes       int                 `json:"total_nodes"`
+	UnhealthyNodes   int                 `json:"unhealthy_nodes"`
+	Nodes            []KubeletHealth     `json:"nodes"`
+	Notes            []string            `json:"notes,omitempty"`
+	Summary          string              `json:"summary"`
+	Findings         []findings.Finding  `json:"findings"` // Unhealthy nodes in the common findings shape
+}
+
+// RequiredPermissions declares the Kubernetes API access get-kubelet-health needs.
+// nodes/proxy is only used when ENABLE_KUBELET_HEALTHZ_PROBE is set.
+func (t *GetKubeletHealthTool) RequiredPermissions() []PermissionRule {
+	rules := []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "events", Verb: "list"},
+		{Group: "coordination.k8s.io", Resource: "leases", Verb: "list", Namespace: nodeLeaseNamespace},
+	}
+	if t.config.HealthzProbe {
+		rules = append(rules, PermissionRule{Resource: "nodes", Subresource: "proxy", Verb: "get"})
+	}
+	return rules
+}
+
+// Execute runs the get-kubelet-health operation
+func (t *GetKubeletHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input GetKubeletHealthInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	filter, err := clients.NewNodeFilter(input.LabelSelector, input.NamePrefix, input.Role)
+	if err != nil {
+		return nil, invalidArgs("%v", err)
+	}
+
+	var nodes []corev1.Node
+	if input.Node != "" {
+		node, err := t.k8sClient.GetNode(ctx, input.Node)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		if filter.Matches(node) {
+			nodes = []corev1.Node{*node}
+		}
+	} else {
+		list, err := t.k8sClient.ListNodesMatching(ctx, filter)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		nodes = list.Items
+	}
+
+	output := GetKubeletHealthOutput{TotalNodes: len(nodes), Nodes: []KubeletHealth{}}
+	if !filter.IsZero() {
+		output.Filter = &filter
+	}
+	if info, err := t.k8sClient.Clientset().Discovery().ServerVersion(); err != nil {
+		output.Notes = append(output.Notes, fmt.Sprintf("API server version unavailable, version skew not checked: %v", err))
+	} else {
+		output.APIServerVersion = info.GitVersion
+	}
+
+	leases := map[string]time.Time{}
+	if list, err := t.k8sClient.Clientset().CoordinationV1().Leases(nodeLeaseNamespace).List(ctx, metav1.ListOptions{}); err != nil {
+		output.Notes = append(output.Notes, fmt.Sprintf("node leases unavailable, using condition heartbeats only: %v", err))
+	} else {
+		for _, lease := range list.Items {
+			if lease.Spec.RenewTime != nil {
+				leases[lease.Name] = lease.Spec.RenewTime.Time
+			}
+		}
+	}
+
+	now := time.Now()
+	events := map[string][]NodeHealthEvent{}
+	if list, err := t.k8sClient.ListEvents(ctx, ""); err != nil {
+		output.Notes = append(output.Notes, fmt.Sprintf("node events unavailable: %v", err))
+	} else {
+		events = nodeHealthEvents(list.Items, now)
+	}
+
+	var healthz map[string]string
+	if t.config.HealthzProbe {
+		healthz = t.probeKubelets(ctx, nodes)
+	}
+
+	for i := range nodes {
+		health := kubeletHealth(&nodes[i], leases, now, t.config, output.APIServerVersion)
+		health.Events = events[nodes[i].Name]
+		if t.config.HealthzProbe {
+			health.Healthz = healthz[nodes[i].Name]
+			if health.Healthz != "ok" {
+				health.Problems = append(health.Problems, "kubelet /healthz: "+health.Healthz)
+			}
+		}
+		health.Healthy = len(health.Problems) == 0
+		if !health.Healthy {
+			output.UnhealthyNodes++
+		} else if input.OnlyUnhealthy {
+			continue
+		}
+		output.Nodes = append(output.Nodes, health)
+	}
+
+	sort.SliceStable(output.Nodes, func(i, j int) bool {
+		if output.Nodes[i].Healthy != output.Nodes[j].Healthy {
+			return !output.Nodes[i].Healthy
+		}
+		return output.Nodes[i].Node < output.Nodes[j].Node
+	})
+
+	output.Summary = fmt.Sprintf("%d of %d node(s) have kubelet problems", output.UnhealthyNodes, output.TotalNodes)
+	if output.UnhealthyNodes == 0 {
+		output.Summary = fmt.Sprintf("All %d kubelet(s) healthy", output.TotalNodes)
+	}
+	output.Findings = kubeletFindings(output.Nodes)
+	return output, nil
+}
+
+// kubeletFindings converts the unhealthy nodes to findings, with their failing
+// conditions and health events as evidence. NotReady nodes are critical and
+// share the NotReady title of the other node tools; other kubelet problems
+// are warnings.
+func kubeletFindings(nodes []KubeletHealth) []findings.Finding {
+	result := []findings.Finding{}
+	for _, node := range nodes {
+		if node.Healthy {
+			continue
+		}
+		finding := findings.Finding{
+			Severity:        findings.SeverityWarning,
+			Category:        findings.CategoryNode,
+			Resource:        resourceRef("node", "", node.Node),
+			Title:           "Kubelet is unhealthy",
+			Detail:          strings.Join(node.Problems, "; "),
+			SuggestedAction: "Check the kubelet and container runtime on the node (oc adm node-logs -u kubelet)",
+			Source:          "get-kubelet-health",
+		}
+		if !node.Ready {
+			finding.Severity, finding.Title = findings.SeverityCritical, nodeNotReadyTitle
+		}
+		for _, condition := range node.Conditions {
+			failing := condition.Status == string(corev1.ConditionTrue)
+			if condition.Type == string(corev1.NodeReady) {
+				failing = !failing
+			}
+			if failing {
+				finding.Evidence = append(finding.Evidence, findings.Evidence{
+					Type: findings.EvidenceCondition, Ref: condition.Type, Message: condition.Message,
+				})
+			}
+		}
+		for _, event := range node.Events {
+			finding.Evidence = append(finding.Evidence, findings.Evidence{
+				Type: findings.EvidenceEvent, Ref: event.Reason, Message: event.Message,
+			})
+			if finding.FirstSeen.IsZero() || event.LastSeen.Before(finding.FirstSeen) {
+				finding.FirstSeen = event.LastSeen
+			}
+			if event.LastSeen.After(finding.LastSeen) {
+				finding.LastSeen = event.LastSeen
+			}
+		}
+		result = append(result, finding)
+	}
+	findings.Sort(result)
+	return result
+}
+
+// probeKubelets calls /healthz on every node's kubelet through the API server node proxy,
+// returning "ok" or the error per node
+func (t *GetKubeletHealthTool) probeKubelets(ctx context.Context, nodes []corev1.Node) map[string]string {
+	results := make(map[string]string, len(nodes))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, kubeletHealthzConcurrency)
+	for i := range nodes {
+		name := nodes[i].Name
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			result := t.kubeletHealthz(ctx, name)
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// kubeletHealthz calls /healthz on one kubelet through the API server node proxy
+func (t *GetKubeletHealthTool) kubeletHealthz(ctx context.Context, node string) string {
+	ctx, cancel := context.WithTimeout(ctx, kubeletHealthzTimeout)
+	defer cancel()
+	body, err := t.k8sClient.Clientset().CoreV1().RESTClient().Get().
+		AbsPath("/api/v1/nodes", node, "proxy", "healthz").
+		DoRaw(ctx)
+	if err != nil {
+		return fmt.Sprintf("probe failed: %v", err)
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// kubeletHealth assesses one node from its status and lease. Events and the /healthz
+// probe are added by the caller.
+func kubeletHealth(node *corev1.Node, leases map[string]time.Time, now time.Time, config KubeletHealthConfig, apiServerVersion string) KubeletHealth {
+	info := node.Status.NodeInfo
+	health := KubeletHealth{
+		Node:                    node.Name,
+		Ready:                   nodeReady(node),
+		Conditions:              []KubeletCondition{},
+		KubeletVersion:          info.KubeletVersion,
+		ContainerRuntimeVersion: info.ContainerRuntimeVersion,
+	}
+
+	var latestHeartbeat time.Time
+	for _, condition := range node.Status.Conditions {
+		heartbeat := condition.LastHeartbeatTime.Time
+		if heartbeat.After(latestHeartbeat) {
+			latestHeartbeat = heartbeat
+		}
+		reported := KubeletCondition{
+			Type:          string(condition.Type),
+			Status:        string(condition.Status),
+			Reason:        condition.Reason,
+			Message:       condition.Message,
+			LastHeartbeat: heartbeat,
+		}
+		if !heartbeat.IsZero() {
+			reported.HeartbeatAge = formatDuration(now.Sub(heartbeat))
+		}
+		health.Conditions = append(health.Conditions, reported)
+
+		switch {
+		case condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue:
+			problem := fmt.Sprintf("NotReady (%s)", condition.Reason)
+			if strings.Contains(condition.Message, "PLEG is not healthy") {
+				problem = "NotReady: PLEG is not healthy, the container runtime is not responding"
+			}
+			health.Problems = append(health.Problems, problem)
+		case condition.Type != corev1.NodeReady && condition.Status == corev1.ConditionTrue:
+			health.Problems = append(health.Problems, fmt.Sprintf("%s (%s)", condition.Type, condition.Reason))
+		}
+	}
+	if !latestHeartbeat.IsZero() && now.Sub(latestHeartbeat) > config.StatusStaleAfter {
+		health.Problems = append(health.Problems, fmt.Sprintf("node status not updated for %s", formatDuration(now.Sub(latestHeartbeat))))
+	}
+
+	if renewed, ok := leases[node.Name]; ok {
+		health.LeaseRenewTime = &renewed
+		health.LeaseAge = formatDuration(now.Sub(renewed))
+		if now.Sub(renewed) > config.LeaseStaleAfter {
+			health.Problems = append(health.Problems, fmt.Sprintf("kubelet lease not renewed for %s", health.LeaseAge))
+		}
+	}
+
+	if apiServerVersion != "" {
+		health.VersionSkew = kubeletVersionSkew(apiServerVersion, info.KubeletVersion)
+		if health.VersionSkew.Status == VersionSkewUnsupported {
+			health.Problems = append(health.Problems, health.VersionSkew.Message)
+		}
+	} else {
+		health.VersionSkew = KubeletVersionSkew{Status: VersionSkewUnknown, Message: "API server version unavailable"}
+	}
+	return health
+}
+
+// kubeletVersionSkew checks a kubelet version against the API server's under the n-2
+// policy: a kubelet may be up to kubeletMaxMinorSkew minor versions older than the API
+// server and never newer. Versions may carry a "v" prefix and build suffixes.
+func kubeletVersionSkew(apiServerVersion, kubeletVersion string) KubeletVersionSkew {
+	server, err := version.ParseGeneric(apiServerVersion)
+	if err != nil {
+		return KubeletVersionSkew{Status: VersionSkewUnknown, Message: fmt.Sprintf("cannot parse API server version %q", apiServerVersion)}
+	}
+	kubelet, err := version.ParseGeneric(kubeletVersion)
+	if err != nil {
+		return KubeletVersionSkew{Status: VersionSkewUnknown, Message: fmt.Sprintf("cannot parse kubelet version %q", kubeletVersion)}
+	}
+	if server.Major() != kubelet.Major() {
+		return KubeletVersionSkew{Status: VersionSkewUnsupported, Message: fmt.Sprintf("kubelet %s and API server %s differ in major version", kubeletVersion, apiServerVersion)}
+	}
+
+	behind := int(server.Minor()) - int(kubelet.Minor())
+	skew := KubeletVersionSkew{MinorVersionsBehind: behind}
+	switch {
+	case behind == 0:
+		skew.Status = VersionSkewMatch
+		skew.Message = "kubelet matches the API server minor version"
+	case behind < 0:
+		skew.Status = VersionSkewUnsupported
+		skew.Message = fmt.Sprintf("kubelet %s is newer than API server %s, which is never supported", kubeletVersion, apiServerVersion)
+	case behind <= kubeletMaxMinorSkew:
+		skew.Status = VersionSkewSupported
+		skew.Message = fmt.Sprintf("kubelet is %d minor version(s) behind the API server, within the supported window of %d", behind, kubeletMaxMinorSkew)
+	default:
+		skew.Status = VersionSkewUnsupported
+		skew.Message = fmt.Sprintf("kubelet %s is %d minor versions behind API server %s, beyond the supported window of %d", kubeletVersion, behind, apiServerVersion, kubeletMaxMinorSkew)
+	}
+	return skew
+}
+
+// nodeHealthEvents groups recent node health events by node, newest first and at most
+// maxNodeEvents per node. Warnings, known health reasons and PLEG messages are kept.
+func nodeHealthEvents(events []corev1.Event, now time.Time) map[string][]NodeHealthEvent {
+	byNode := map[string][]NodeHealthEvent{}
+	for i := range events {
+		event := &events[i]
+		if event.InvolvedObject.Kind != "Node" {
+			continue
+		}
+		lastSeen := eventLastSeen(event)
+		if now.Sub(lastSeen) > nodeEventWindow {
+			continue
+		}
+		if event.Type != corev1.EventTypeWarning && !nodeHealthEventReasons[event.Reason] && !strings.Contains(event.Message, "PLEG") {
+			continue
+		}
+		byNode[event.InvolvedObject.Name] = append(byNode[event.InvolvedObject.Name], NodeHealthEvent{
+			Type:     event.Type,
+			Reason:   event.Reason,
+			Message:  event.Message,
+			Count:    event.Count,
+			LastSeen: lastSeen,
+		})
+	}
+	for node, list := range byNode {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].LastSeen.After(list[j].LastSeen) })
+		if len(list) > maxNodeEvents {
+			list = list[:maxNodeEvents]
+		}
+		byNode[node] = list
+	}
+	return byNode
+}
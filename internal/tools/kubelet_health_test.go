@@ -0,0 +1,296 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	versioninfo "k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+var testKubeletConfig = KubeletHealthConfig{LeaseStaleAfter: time.Minute, StatusStaleAfter: 10 * time.Minute}
+
+func newKubeletNode(name, kubeletVersion string, heartbeat time.Time, conditions ...corev1.NodeCondition) *corev1.Node {
+	if len(conditions) == 0 {
+		conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady"}}
+	}
+	for i := range conditions {
+		conditions[i].LastHeartbeatTime = metav1.Time{Time: heartbeat}
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: conditions,
+			NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: kubeletVersion, ContainerRuntimeVersion: "cri-o://1.29.1"},
+		},
+	}
+}
+
+func TestKubeletVersionSkew(t *testing.T) {
+	tests := []struct {
+		name      string
+		apiServer string
+		kubelet   string
+		behind    int
+		status    string
+	}{
+		{"same minor", "v1.29.3", "v1.29.1", 0, VersionSkewMatch},
+		{"openshift build suffix", "v1.29.5+4cf12a3", "v1.28.9+416ecaf", 1, VersionSkewSupported},
+		{"n-2 is supported", "v1.30.0", "v1.28.4", 2, VersionSkewSupported},
+		{"n-3 is beyond the window", "v1.30.0", "v1.27.4", 3, VersionSkewUnsupported},
+		{"kubelet newer", "v1.29.0", "v1.30.1", -1, VersionSkewUnsupported},
+		{"major mismatch", "v1.29.0", "v2.0.0", 0, VersionSkewUnsupported},
+		{"unparsable kubelet", "v1.29.0", "unknown", 0, VersionSkewUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skew := kubeletVersionSkew(tt.apiServer, tt.kubelet)
+			assert.Equal(t, tt.status, skew.Status)
+			assert.Equal(t, tt.behind, skew.MinorVersionsBehind)
+			assert.NotEmpty(t, skew.Message)
+		})
+	}
+}
+
+func TestKubeletHealth(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		node     *corev1.Node
+		leases   map[string]time.Time
+		problems []string
+	}{
+		{
+			name:   "healthy",
+			node:   newKubeletNode("ok", "v1.29.1", now.Add(-3*time.Minute)),
+			leases: map[string]time.Time{"ok": now.Add(-5 * time.Second)},
+		},
+		{
+			name:     "stale lease",
+			node:     newKubeletNode("quiet", "v1.29.1", now.Add(-3*time.Minute)),
+			leases:   map[string]time.Time{"quiet": now.Add(-5 * time.Minute)},
+			problems: []string{"kubelet lease not renewed for 5m"},
+		},
+		{
+			name:     "stale status without lease",
+			node:     newKubeletNode("frozen", "v1.29.1", now.Add(-2*time.Hour)),
+			problems: []string{"node status not updated for 2h"},
+		},
+		{
+			name: "PLEG and pressure",
+			node: newKubeletNode("sick", "v1.29.1", now.Add(-time.Minute),
+				corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Reason: "KubeletNotReady", Message: "PLEG is not healthy: pleg was last seen active 3m0s ago"},
+				corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasDiskPressure"},
+				corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+			),
+			problems: []string{"NotReady: PLEG is not healthy, the container runtime is not responding", "DiskPressure (KubeletHasDiskPressure)"},
+		},
+		{
+			name:     "unsupported skew",
+			node:     newKubeletNode("old", "v1.26.0", now.Add(-time.Minute)),
+			problems: []string{"kubelet v1.26.0 is 3 minor versions behind API server v1.29.3, beyond the supported window of 2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := kubeletHealth(tt.node, tt.leases, now, testKubeletConfig, "v1.29.3")
+			assert.Equal(t, tt.problems, health.Problems)
+			assert.Equal(t, tt.node.Name, health.Node)
+			assert.Equal(t, "cri-o://1.29.1", health.ContainerRuntimeVersion)
+		})
+	}
+}
+
+func TestKubeletFindings(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	var nodes []KubeletHealth
+	for _, node := range []*corev1.Node{
+		newKubeletNode("ok", "v1.29.1", now.Add(-time.Minute)),
+		newKubeletNode("old", "v1.26.0", now.Add(-time.Minute)),
+		newKubeletNode("sick", "v1.29.1", now.Add(-time.Minute),
+			corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Reason: "KubeletNotReady", Message: "PLEG is not healthy"},
+			corev1.NodeCondition{Type: corev1.NodeDiskPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasDiskPressure"},
+			corev1.NodeCondition{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+		),
+	} {
+		health := kubeletHealth(node, nil, now, testKubeletConfig, "v1.29.3")
+		health.Healthy = len(health.Problems) == 0
+		nodes = append(nodes, health)
+	}
+	nodes[2].Events = []NodeHealthEvent{{Type: corev1.EventTypeWarning, Reason: "NodeNotReady", Message: "Node sick status is now: NodeNotReady", LastSeen: now.Add(-2 * time.Minute)}}
+
+	converted := kubeletFindings(nodes)
+	require.Len(t, converted, 2, "healthy nodes have no finding")
+
+	sick := converted[0]
+	assert.Equal(t, findings.SeverityCritical, sick.Severity)
+	assert.Equal(t, findings.CategoryNode, sick.Category)
+	assert.Equal(t, "node/sick", sick.Resource.String())
+	assert.Equal(t, nodeNotReadyTitle, sick.Title)
+	assert.Equal(t, "NotReady: PLEG is not healthy, the container runtime is not responding; DiskPressure (KubeletHasDiskPressure)", sick.Detail)
+	assert.Equal(t, []findings.Evidence{
+		{Type: findings.EvidenceCondition, Ref: "Ready", Message: "PLEG is not healthy"},
+		{Type: findings.EvidenceCondition, Ref: "DiskPressure"},
+		{Type: findings.EvidenceEvent, Ref: "NodeNotReady", Message: "Node sick status is now: NodeNotReady"},
+	}, sick.Evidence)
+	assert.Equal(t, now.Add(-2*time.Minute), sick.LastSeen)
+	assert.Equal(t, "get-kubelet-health", sick.Source)
+
+	old := converted[1]
+	assert.Equal(t, findings.SeverityWarning, old.Severity)
+	assert.Equal(t, "node/old", old.Resource.String())
+	assert.Equal(t, "Kubelet is unhealthy", old.Title)
+	assert.Contains(t, old.Detail, "beyond the supported window")
+	assert.Empty(t, old.Evidence)
+}
+
+func TestNodeHealthEvents(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	event := func(node, eventType, reason, message string, ago time.Duration) corev1.Event {
+		return corev1.Event{
+			InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: node},
+			Type:           eventType,
+			Reason:         reason,
+			Message:        message,
+			Count:          1,
+			LastTimestamp:  metav1.Time{Time: now.Add(-ago)},
+		}
+	}
+	events := []corev1.Event{
+		event("worker-1", corev1.EventTypeNormal, "NodeNotReady", "Node worker-1 status is now: NodeNotReady", 10*time.Minute),
+		event("worker-1", corev1.EventTypeNormal, "Rebooted", "Node worker-1 has been rebooted", 5*time.Minute),
+		event("worker-1", corev1.EventTypeNormal, "NodeReady", "Node worker-1 status is now: NodeReady", 4*time.Minute),
+		event("worker-1", corev1.EventTypeWarning, "ContainerGCFailed", "rpc error", 2*time.Hour),
+		event("worker-2", corev1.EventTypeNormal, "NodeSchedulable", "PLEG is not healthy: pleg has yet to be successful", time.Minute),
+		{InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "worker-1"}, Type: corev1.EventTypeWarning, Reason: "BackOff", LastTimestamp: metav1.Time{Time: now}},
+	}
+
+	byNode := nodeHealthEvents(events, now)
+	require.Len(t, byNode["worker-1"], 2)
+	assert.Equal(t, "Rebooted", byNode["worker-1"][0].Reason)
+	assert.Equal(t, "NodeNotReady", byNode["worker-1"][1].Reason)
+	require.Len(t, byNode["worker-2"], 1)
+}
+
+func TestGetKubeletHealthTool_Execute(t *testing.T) {
+	now := time.Now()
+	clientset := fake.NewClientset(
+		newKubeletNode("worker-1", "v1.29.1", now.Add(-time.Minute)),
+		newKubeletNode("worker-2", "v1.29.1", now.Add(-time.Minute)),
+		&coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: nodeLeaseNamespace, Name: "worker-1"},
+			Spec:       coordinationv1.LeaseSpec{RenewTime: &metav1.MicroTime{Time: now.Add(-5 * time.Second)}},
+		},
+		&coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{Namespace: nodeLeaseNamespace, Name: "worker-2"},
+			Spec:       coordinationv1.LeaseSpec{RenewTime: &metav1.MicroTime{Time: now.Add(-3 * time.Minute)}},
+		},
+	)
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &versioninfo.Info{GitVersion: "v1.29.3"}
+	tool := NewGetKubeletHealthTool(clients.NewK8sClientWithClientset(clientset), testKubeletConfig)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetKubeletHealthOutput)
+
+	assert.Equal(t, "v1.29.3", output.APIServerVersion)
+	assert.Equal(t, 2, output.TotalNodes)
+	assert.Equal(t, 1, output.UnhealthyNodes)
+	require.Len(t, output.Nodes, 2)
+	assert.Equal(t, "worker-2", output.Nodes[0].Node, "unhealthy nodes come first")
+	assert.False(t, output.Nodes[0].Healthy)
+	assert.Equal(t, VersionSkewMatch, output.Nodes[1].VersionSkew.Status)
+	assert.Equal(t, "1 of 2 node(s) have kubelet problems", output.Summary)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"only_unhealthy": true})
+	require.NoError(t, err)
+	output = result.(GetKubeletHealthOutput)
+	require.Len(t, output.Nodes, 1)
+	assert.Equal(t, "worker-2", output.Nodes[0].Node)
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"node": "missing"})
+	require.Error(t, err)
+}
+
+func TestGetKubeletHealthTool_NodeFilter(t *testing.T) {
+	now := time.Now()
+	master := newKubeletNode("master-0", "v1.29.1", now)
+	master.Labels = map[string]string{"node-role.kubernetes.io/control-plane": ""}
+	worker := newKubeletNode("worker-1", "v1.29.1", now)
+	worker.Labels = map[string]string{"node-role.kubernetes.io/worker": "", "topology.kubernetes.io/zone": "a"}
+	infra := newKubeletNode("infra-1", "v1.29.1", now)
+	infra.Labels = map[string]string{"node-role.kubernetes.io/infra": "", "topology.kubernetes.io/zone": "a"}
+	tool := NewGetKubeletHealthTool(clients.NewK8sClientWithClientset(fake.NewClientset(master, worker, infra)), testKubeletConfig)
+
+	nodeNames := func(args map[string]interface{}) []string {
+		t.Helper()
+		result, err := tool.Execute(context.Background(), args)
+		require.NoError(t, err)
+		output := result.(GetKubeletHealthOutput)
+		names := []string{}
+		for _, node := range output.Nodes {
+			names = append(names, node.Node)
+		}
+		assert.Len(t, names, output.TotalNodes)
+		return names
+	}
+
+	assert.Equal(t, []string{"master-0"}, nodeNames(map[string]interface{}{"role": "master"}))
+	assert.Equal(t, []string{"worker-1"}, nodeNames(map[string]interface{}{"role": "worker"}))
+	assert.Equal(t, []string{"infra-1", "worker-1"}, nodeNames(map[string]interface{}{"label_selector": "topology.kubernetes.io/zone=a"}))
+	assert.Equal(t, []string{"infra-1"}, nodeNames(map[string]interface{}{"name_prefix": "infra"}))
+	assert.Empty(t, nodeNames(map[string]interface{}{"node": "master-0", "role": "worker"}), "a named node must pass the filter too")
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"role": "infra", "name_prefix": "infra"})
+	require.NoError(t, err)
+	filter := result.(GetKubeletHealthOutput).Filter
+	require.NotNil(t, filter, "the filter is echoed")
+	assert.Equal(t, "name_prefix=infra,role=infra", filter.String())
+
+	for _, args := range []map[string]interface{}{
+		{"label_selector": "zone in (a"},
+		{"role": "gpu"},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		assert.True(t, IsInvalidArguments(err), "args %v: got %v", args, err)
+	}
+}
+
+func TestGetKubeletHealthTool_Healthz(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/nodes/worker-1/proxy/healthz":
+			_, _ = w.Write([]byte("ok"))
+		default:
+			http.Error(w, "dial tcp 10.0.0.2:10250: connect: connection refused", http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	tool := NewGetKubeletHealthTool(clients.NewK8sClientWithClientset(clientset), KubeletHealthConfig{HealthzProbe: true})
+
+	results := tool.probeKubelets(context.Background(), []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-2"}},
+	})
+	assert.Equal(t, "ok", results["worker-1"])
+	assert.Contains(t, results["worker-2"], "probe failed")
+	assert.Contains(t, tool.RequiredPermissions(), PermissionRule{Resource: "nodes", Subresource: "proxy", Verb: "get"})
+}
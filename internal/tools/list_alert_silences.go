@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// ListAlertSilencesTool lists the silences in Alertmanager
+type ListAlertSilencesTool struct {
+	alertmanager *clients.AlertmanagerClient
+}
+
+// NewListAlertSilencesTool creates a new list-alert-silences tool
+func NewListAlertSilencesTool(alertmanager *clients.AlertmanagerClient) *ListAlertSilencesTool {
+	return &ListAlertSilencesTool{alertmanager: alertmanager}
+}
+
+// Name returns the tool name for MCP registration
+func (t *ListAlertSilencesTool) Name() string {
+	return "list-alert-silences"
+}
+
+// Description returns the tool description for MCP
+func (t *ListAlertSilencesTool) Description() string {
+	return `List the alert silences in Alertmanager with their matchers, creator, comment and end time, soonest to end first. By default only the active and pending silences are listed; created_here limits them to the silences created with create-alert-silence.
+
+Use this tool for questions like:
+- "Which alerts are silenced right now?"
+- "Are the silences I created during the rollback still active?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *ListAlertSilencesTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"state": map[string]interface{}{
+				"type":        "string",
+				"description": "Only list silences in this state (default: active and pending)",
+				"enum":        []string{"active", "pending", "expired", "all"},
+			},
+			"created_here": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only list silences created through this server",
+				"default":     false,
+			},
+		},
+	}
+}
+
+// ListAlertSilencesInput represents the input parameters
+type ListAlertSilencesInput struct {
+	State       string `json:"state"`
+	CreatedHere bool   `json:"created_here"`
+}
+
+// ListAlertSilencesOutput represents the tool output
+type ListAlertSilencesOutput struct {
+	Silences []AlertSilence `json:"silences"`
+	Count    int            `json:"count"`
+}
+
+// Execute runs the list-alert-silences operation
+func (t *ListAlertSilencesTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input ListAlertSilencesInput
+	argsJSON, _ := json.Marshal(args) //nolint:errcheck // Tool arguments always marshal
+	if err := json.Unmarshal(argsJSON, &input); err != nil {
+		return nil, invalidArgs("invalid arguments: %v", err)
+	}
+	switch input.State {
+	case "", "active", "pending", "expired", "all":
+	default:
+		return nil, invalidArgs("invalid state '%s', must be one of: active, pending, expired, all", input.State)
+	}
+
+	silences, err := t.alertmanager.ListSilences(ctx)
+	if err != nil {
+		return nil, dependencyError(fmt.Errorf("failed to list silences in Alertmanager: %w", err))
+	}
+
+	output := ListAlertSilencesOutput{Silences: []AlertSilence{}}
+	for _, silence := range silences {
+		listed := newAlertSilence(silence)
+		switch {
+		case input.State == "" && listed.State == "expired":
+			continue
+		case input.State != "" && input.State != "all" && listed.State != input.State:
+			continue
+		case input.CreatedHere && !listed.CreatedHere:
+			continue
+		}
+		output.Silences = append(output.Silences, listed)
+	}
+	sort.Slice(output.Silences, func(i, j int) bool {
+		a, b := output.Silences[i], output.Silences[j]
+		if !a.EndsAt.Equal(b.EndsAt) {
+			return a.EndsAt.Before(b.EndsAt)
+		}
+		return a.ID < b.ID
+	})
+	output.Count = len(output.Silences)
+	return output, nil
+}
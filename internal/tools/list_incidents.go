@@ -4,10 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
 )
 
+const (
+	// localIncidentFetchCap bounds how many incidents are fetched when filters are applied locally
+	localIncidentFetchCap = 1000
+	// localPageTokenPrefix marks page tokens issued for locally filtered pages
+	localPageTokenPrefix = "local:"
+)
+
 // ListIncidentsTool provides MCP tool for listing incidents from Coordination Engine
 type ListIncidentsTool struct {
 	ceClient *clients.CoordinationEngineClient
@@ -27,7 +39,7 @@ func (t *ListIncidentsTool) Name() string {
 
 // Description returns the tool description
 func (t *ListIncidentsTool) Description() string {
-	return "List and filter incidents from the Coordination Engine. Supports filtering by status (all, active, completed, failed) and severity (all, low, medium, high, critical)."
+	return "List and filter incidents from the Coordination Engine. Supports filtering by status (all, active, completed, failed), severity (all, low, medium, high, critical), namespace, and creation time (since), with paging via page_token. When the engine ignores the namespace or since filters they are applied locally over its most recent incidents, and the response carries a warning."
 }
 
 // InputSchema returns the JSON schema for tool inputs
@@ -47,6 +59,14 @@ func (t *ListIncidentsTool) InputSchema() map[string]interface{} {
 				"enum":        []string{"all", "low", "medium", "high", "critical"},
 				"default":     "all",
 			},
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only incidents in this namespace",
+			},
+			"since": map[string]interface{}{
+				"type":        "string",
+				"description": "Only incidents created after this time: an RFC 3339 timestamp or a duration before now such as '2h' or '30m'",
+			},
 			"limit": map[string]interface{}{
 				"type":        "integer",
 				"description": "Maximum number of incidents to return",
@@ -54,9 +74,13 @@ func (t *ListIncidentsTool) InputSchema() map[string]interface{} {
 				"minimum":     1,
 				"maximum":     1000,
 			},
+			"page_token": map[string]interface{}{
+				"type":        "string",
+				"description": "next_page_token from the previous response, to fetch the following page",
+			},
 			"offset": map[string]interface{}{
 				"type":        "integer",
-				"description": "Number of incidents to skip for pagination",
+				"description": "Number of incidents to skip for pagination (ignored when page_token is set)",
 				"default":     0,
 				"minimum":     0,
 			},
@@ -67,24 +91,34 @@ func (t *ListIncidentsTool) InputSchema() map[string]interface{} {
 
 // ListIncidentsInput represents the input parameters
 type ListIncidentsInput struct {
-	Status   string `json:"status"`
-	Severity string `json:"severity"`
-	Limit    int    `json:"limit"`
-	Offset   int    `json:"offset"`
+	Status    string `json:"status"`
+	Severity  string `json:"severity"`
+	Namespace string `json:"namespace"`
+	Since     string `json:"since"`
+	Limit     int    `json:"limit"`
+	PageToken string `json:"page_token"`
+	Offset    int    `json:"offset"`
 }
 
 // ListIncidentsOutput represents the tool output
 type ListIncidentsOutput struct {
-	Status    string                       `json:"status"`
-	Incidents []clients.Incident           `json:"incidents"`
-	Summary   clients.IncidentListResponse `json:"summary"`
-	Message   string                       `json:"message"`
-	Count     int                          `json:"count"`
-	Filters   struct {
-		Status   string `json:"status"`
-		Severity string `json:"severity"`
-		Limit    int    `json:"limit"`
-		Offset   int    `json:"offset"`
+	Status        string                       `json:"status"`
+	Incidents     []clients.Incident           `json:"incidents"`
+	Summary       clients.IncidentListResponse `json:"summary"`
+	Message       string                       `json:"message"`
+	Count         int                          `json:"count"`
+	TotalCount    int                          `json:"total_count"`
+	NextPageToken string                       `json:"next_page_token,omitempty"`
+	FilterMode    string                       `json:"filter_mode"` // server, or local when the engine ignored namespace or since
+	Warning       string                       `json:"warning,omitempty"`
+	Filters       struct {
+		Status    string `json:"status"`
+		Severity  string `json:"severity"`
+		Namespace string `json:"namespace,omitempty"`
+		Since     string `json:"since,omitempty"`
+		Limit     int    `json:"limit"`
+		Offset    int    `json:"offset"`
+		PageToken string `json:"page_token,omitempty"`
 	} `json:"filters"`
 }
 
@@ -102,25 +136,165 @@ func (t *ListIncidentsTool) Execute(ctx context.Context, args map[string]interfa
 		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
 	}
 
-	// Call Coordination Engine API
-	resp, err := t.ceClient.ListIncidents(ctx, input.Status, input.Severity, input.Limit, input.Offset)
+	if input.Limit < 1 || input.Limit > 1000 {
+		return nil, invalidArgs("limit must be between 1 and 1000")
+	}
+	if input.Offset < 0 {
+		return nil, invalidArgs("offset must not be negative")
+	}
+	if input.Namespace != "" {
+		if errs := validation.IsDNS1123Label(input.Namespace); len(errs) > 0 {
+			return nil, invalidArgs("invalid namespace %q: %s", input.Namespace, strings.Join(errs, "; "))
+		}
+	}
+	since, err := parseSince(input.Since, time.Now())
+	if err != nil {
+		return nil, invalidArgs("%v", err)
+	}
+
+	opts := clients.IncidentListOptions{
+		Status:    input.Status,
+		Severity:  input.Severity,
+		Namespace: input.Namespace,
+		Since:     since,
+		Limit:     input.Limit,
+		Offset:    input.Offset,
+		PageToken: input.PageToken,
+	}
+
+	output := ListIncidentsOutput{Status: "success", FilterMode: "server"}
+	var resp *clients.IncidentListResponse
+	if offset, ok := localPageOffset(input.PageToken); ok {
+		// A token issued by a local-filter page continues in local mode
+		opts.Offset = offset
+		resp, output.Warning, err = t.listLocally(ctx, opts)
+		output.FilterMode = "local"
+	} else {
+		// Call Coordination Engine API
+		resp, err = t.ceClient.ListIncidentsPage(ctx, opts)
+		if err == nil && !incidentsMatch(resp.Incidents, opts) {
+			resp, output.Warning, err = t.listLocally(ctx, opts)
+			output.FilterMode = "local"
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to list incidents: %w", err)
+		return nil, dependencyError(fmt.Errorf("failed to list incidents: %w", err))
 	}
 
 	// Build output
-	output := ListIncidentsOutput{
-		Status:    "success",
-		Incidents: resp.Incidents,
-		Summary:   *resp,
-		Count:     len(resp.Incidents),
-		Message:   fmt.Sprintf("Retrieved %d incidents (total: %d)", len(resp.Incidents), resp.Summary.Total),
-	}
+	output.Incidents = resp.Incidents
+	output.Summary = *resp
+	output.Count = len(resp.Incidents)
+	output.TotalCount = resp.Summary.Total
+	output.NextPageToken = resp.NextPageToken
+	output.Message = fmt.Sprintf("Retrieved %d incidents (total: %d)", len(resp.Incidents), resp.Summary.Total)
 
 	output.Filters.Status = input.Status
 	output.Filters.Severity = input.Severity
+	output.Filters.Namespace = input.Namespace
+	output.Filters.Since = input.Since
 	output.Filters.Limit = input.Limit
 	output.Filters.Offset = input.Offset
+	output.Filters.PageToken = input.PageToken
 
 	return output, nil
 }
+
+// listLocally fetches up to localIncidentFetchCap incidents filtered only by status and
+// severity, which every engine supports, and applies the namespace and since filters
+// and paging here
+func (t *ListIncidentsTool) listLocally(ctx context.Context, opts clients.IncidentListOptions) (*clients.IncidentListResponse, string, error) {
+	all, err := t.ceClient.ListIncidentsPage(ctx, clients.IncidentListOptions{
+		Status:   opts.Status,
+		Severity: opts.Severity,
+		Limit:    localIncidentFetchCap,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	matched := make([]clients.Incident, 0, len(all.Incidents))
+	for _, incident := range all.Incidents {
+		if incidentMatches(incident, opts) {
+			matched = append(matched, incident)
+		}
+	}
+
+	resp := &clients.IncidentListResponse{Incidents: []clients.Incident{}}
+	resp.Summary.Total = len(matched)
+	if opts.Offset < len(matched) {
+		end := min(opts.Offset+opts.Limit, len(matched))
+		resp.Incidents = matched[opts.Offset:end]
+		if end < len(matched) {
+			resp.NextPageToken = localPageTokenPrefix + strconv.Itoa(end)
+		}
+	}
+
+	warning := fmt.Sprintf("The Coordination Engine does not filter by namespace or since; filtering was done locally over its %d most recent incidents", len(all.Incidents))
+	if len(all.Incidents) >= localIncidentFetchCap {
+		warning += " (fetch capped, older incidents are not included)"
+	}
+	return resp, warning, nil
+}
+
+// incidentsMatch reports whether the engine applied the namespace and since filters
+// to every returned incident
+func incidentsMatch(incidents []clients.Incident, opts clients.IncidentListOptions) bool {
+	for _, incident := range incidents {
+		if !incidentMatches(incident, opts) {
+			return false
+		}
+	}
+	return true
+}
+
+// incidentMatches applies the namespace and since filters to one incident. Incidents
+// whose creation time cannot be parsed are kept.
+func incidentMatches(incident clients.Incident, opts clients.IncidentListOptions) bool {
+	if opts.Namespace != "" && incidentNamespace(incident) != opts.Namespace {
+		return false
+	}
+	if !opts.Since.IsZero() {
+		if created, err := time.Parse(time.RFC3339, incident.CreatedAt); err == nil && created.Before(opts.Since) {
+			return false
+		}
+	}
+	return true
+}
+
+// incidentNamespace returns the incident's namespace, or the namespace part of a
+// namespace/name target
+func incidentNamespace(incident clients.Incident) string {
+	if incident.Namespace != "" {
+		return incident.Namespace
+	}
+	if namespace, _, ok := strings.Cut(incident.Target, "/"); ok {
+		return namespace
+	}
+	return ""
+}
+
+// localPageOffset decodes a page token issued by listLocally
+func localPageOffset(token string) (int, bool) {
+	rest, ok := strings.CutPrefix(token, localPageTokenPrefix)
+	if !ok {
+		return 0, false
+	}
+	offset, err := strconv.Atoi(rest)
+	return offset, err == nil && offset >= 0
+}
+
+// parseSince accepts an RFC 3339 timestamp or a duration before now; empty means no limit
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return ts, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid since %q: use an RFC 3339 timestamp or a positive duration such as 2h", value)
+	}
+	return now.Add(-d), nil
+}
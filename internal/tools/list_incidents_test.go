@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// incidentFixture returns n incidents alternating between the payments and
+// checkout namespaces, one hour apart with the newest first
+func incidentFixture(n int, now time.Time) []clients.Incident {
+	incidents := make([]clients.Incident, n)
+	for i := range incidents {
+		namespace := "payments"
+		if i%2 == 1 {
+			namespace = "checkout"
+		}
+		incidents[i] = clients.Incident{
+			ID:        fmt.Sprintf("inc-%d", i),
+			Target:    namespace + "/api",
+			Severity:  "high",
+			CreatedAt: now.Add(-time.Duration(i) * time.Hour).Format(time.RFC3339),
+		}
+	}
+	return incidents
+}
+
+// newIncidentEngine serves incidents; when filtering is true it applies the
+// namespace, since, and page_token parameters like a current engine, otherwise
+// it ignores them like an older one
+func newIncidentEngine(t *testing.T, incidents []clients.Incident, filtering bool, requests *[]url.Values) *clients.CoordinationEngineClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*requests = append(*requests, query)
+
+		matched := incidents
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		if filtering {
+			since, _ := time.Parse(time.RFC3339, query.Get("since"))
+			matched = nil
+			for _, incident := range incidents {
+				created, _ := time.Parse(time.RFC3339, incident.CreatedAt)
+				if (query.Get("namespace") == "" || incidentNamespace(incident) == query.Get("namespace")) && !created.Before(since) {
+					matched = append(matched, incident)
+				}
+			}
+			if token := query.Get("page_token"); token != "" {
+				offset, _ = strconv.Atoi(token)
+			}
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		resp := clients.IncidentListResponse{Incidents: []clients.Incident{}}
+		resp.Summary.Total = len(matched)
+		if offset < len(matched) {
+			end := min(offset+limit, len(matched))
+			resp.Incidents = matched[offset:end]
+			if filtering && end < len(matched) {
+				resp.NextPageToken = strconv.Itoa(end)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return testutil.NewCoordinationEngineClient(t, server.URL)
+}
+
+func incidentIDs(incidents []clients.Incident) []string {
+	ids := make([]string, len(incidents))
+	for i, incident := range incidents {
+		ids[i] = incident.ID
+	}
+	return ids
+}
+
+func TestListIncidentsTool_PassThrough(t *testing.T) {
+	now := time.Now()
+	var requests []url.Values
+	tool := NewListIncidentsTool(newIncidentEngine(t, incidentFixture(10, now), true, &requests))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"namespace": "payments", "since": "5h", "limit": 2, "severity": "high",
+	})
+	require.NoError(t, err)
+	output := result.(ListIncidentsOutput)
+
+	assert.Equal(t, "server", output.FilterMode)
+	assert.Empty(t, output.Warning)
+	assert.Equal(t, []string{"inc-0", "inc-2"}, incidentIDs(output.Incidents))
+	assert.Equal(t, 3, output.TotalCount) // inc-0, inc-2, inc-4
+	assert.Equal(t, "2", output.NextPageToken)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "payments", requests[0].Get("namespace"))
+	assert.Equal(t, "high", requests[0].Get("severity"))
+	assert.Equal(t, "2", requests[0].Get("limit"))
+	assert.NotEmpty(t, requests[0].Get("since"))
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{
+		"namespace": "payments", "since": "5h", "limit": 2, "page_token": output.NextPageToken,
+	})
+	require.NoError(t, err)
+	output = result.(ListIncidentsOutput)
+	assert.Equal(t, []string{"inc-4"}, incidentIDs(output.Incidents))
+	assert.Empty(t, output.NextPageToken)
+	assert.Equal(t, "2", requests[1].Get("page_token"))
+	assert.Empty(t, requests[1].Get("offset"), "offset is not sent with a page token")
+}
+
+func TestListIncidentsTool_LocalFilter(t *testing.T) {
+	now := time.Now()
+	var requests []url.Values
+	tool := NewListIncidentsTool(newIncidentEngine(t, incidentFixture(10, now), false, &requests))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"namespace": "checkout", "since": now.Add(-6 * time.Hour).Add(-time.Minute).Format(time.RFC3339), "limit": 2,
+	})
+	require.NoError(t, err)
+	output := result.(ListIncidentsOutput)
+
+	assert.Equal(t, "local", output.FilterMode)
+	assert.Contains(t, output.Warning, "filtering was done locally over its 10 most recent incidents")
+	assert.Equal(t, []string{"inc-1", "inc-3"}, incidentIDs(output.Incidents))
+	assert.Equal(t, 3, output.TotalCount) // inc-1, inc-3, inc-5
+	assert.Equal(t, "local:2", output.NextPageToken)
+
+	// The first request is the filtered page, the second the capped unfiltered fetch
+	require.Len(t, requests, 2)
+	assert.Equal(t, strconv.Itoa(localIncidentFetchCap), requests[1].Get("limit"))
+	assert.Empty(t, requests[1].Get("namespace"))
+
+	// A local page token goes straight to the capped fetch
+	result, err = tool.Execute(context.Background(), map[string]interface{}{
+		"namespace": "checkout", "since": "6h1m", "limit": 2, "page_token": output.NextPageToken,
+	})
+	require.NoError(t, err)
+	output = result.(ListIncidentsOutput)
+	assert.Equal(t, []string{"inc-5"}, incidentIDs(output.Incidents))
+	assert.Empty(t, output.NextPageToken)
+	assert.Len(t, requests, 3)
+}
+
+func TestListIncidentsTool_NoFilters(t *testing.T) {
+	var requests []url.Values
+	tool := NewListIncidentsTool(newIncidentEngine(t, incidentFixture(3, time.Now()), false, &requests))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(ListIncidentsOutput)
+
+	assert.Equal(t, "server", output.FilterMode)
+	assert.Len(t, output.Incidents, 3)
+	assert.Equal(t, "all", requests[0].Get("status"))
+	assert.Equal(t, "0", requests[0].Get("offset"))
+}
+
+func TestListIncidentsTool_InvalidArgs(t *testing.T) {
+	tool := NewListIncidentsTool(testutil.NewCoordinationEngineClient(t, "http://127.0.0.1:1"))
+	for _, args := range []map[string]interface{}{
+		{"limit": 0},
+		{"offset": -1},
+		{"namespace": "Not_A_Namespace"},
+		{"since": "yesterday"},
+		{"since": "-2h"},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err, "%v", args)
+		assert.True(t, IsInvalidArguments(err), "%v", args)
+	}
+}
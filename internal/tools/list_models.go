@@ -24,6 +24,11 @@ func (t *ListModelsTool) Name() string {
 	return "list-models"
 }
 
+// Volatility is slow: models are deployed and removed rarely
+func (t *ListModelsTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
 // Description returns the tool description for MCP
 func (t *ListModelsTool) Description() string {
 	return "List all available KServe InferenceService models in the namespace. Use this tool when the user asks 'what models are available', 'show me models', or wants to know model names before checking specific model status."
@@ -55,6 +60,16 @@ type ModelInfo struct {
 	Runtime string `json:"runtime,omitempty"`
 }
 
+// RequiredPermissions declares the Kubernetes API access list-models needs
+func (t *ListModelsTool) RequiredPermissions() []PermissionRule {
+	if t.kserve == nil {
+		return nil
+	}
+	return []PermissionRule{
+		{Group: "serving.kserve.io", Resource: "inferenceservices", Verb: "list", Namespace: t.kserve.GetNamespace()},
+	}
+}
+
 // Execute lists all KServe models
 func (t *ListModelsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	if t.kserve == nil {
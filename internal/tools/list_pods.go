@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,13 +16,22 @@ import (
 
 // ListPodsTool provides pod listing functionality via MCP
 type ListPodsTool struct {
-	k8sClient *clients.K8sClient
+	k8sClient clients.ClientsetProvider
+	cache     *cache.MemoryCache // Holds the snapshots behind delta tokens (nil = no change tracking)
+
+	ownersOnce sync.Once
+	owners     *clients.OwnerResolver // Resolves pods to workloads for group_by "owner"; see ownerResolver
+
+	snapshotsMu    sync.Mutex
+	snapshotTokens []string // Live delta tokens, oldest first; see saveSnapshot
 }
 
-// NewListPodsTool creates a new list-pods tool
-func NewListPodsTool(k8sClient *clients.K8sClient) *ListPodsTool {
+// NewListPodsTool creates a new list-pods tool. Listings are never cached;
+// memoryCache only holds the pod snapshots that delta tokens refer to.
+func NewListPodsTool(k8sClient clients.ClientsetProvider, memoryCache *cache.MemoryCache) *ListPodsTool {
 	return &ListPodsTool{
 		k8sClient: k8sClient,
+		cache:     memoryCache,
 	}
 }
 
@@ -28,9 +40,15 @@ func (t *ListPodsTool) Name() string {
 	return "list-pods"
 }
 
+// NamespaceScoped reports that pods are listed per namespace, limited to
+// the caller's namespaces when it is scoped
+func (t *ListPodsTool) NamespaceScoped() bool {
+	return true
+}
+
 // Description returns the tool description for MCP
 func (t *ListPodsTool) Description() string {
-	return "List pods in the OpenShift cluster with optional filtering by namespace, labels, and fields. Returns pod status, restarts, age, and readiness information."
+	return "List pods in the OpenShift cluster with optional filtering by namespace, labels, and fields. Returns pod status, restarts, age, and readiness information, or with group_by \"owner\" one entry per workload (Deployment, StatefulSet, DaemonSet, CronJob, ...) with desired vs ready pods, total restarts, and only the names of unhealthy pods. For repeated polling, set track_changes to get a delta_token, then pass it back to receive only the pods created, deleted, or whose phase or restart count changed since; an unknown or expired token returns the full listing with full_resync true."
 }
 
 // InputSchema returns the JSON schema for tool inputs
@@ -59,6 +77,20 @@ func (t *ListPodsTool) InputSchema() map[string]interface{} {
 				"default":     100,
 				"minimum":     0,
 			},
+			"group_by": map[string]interface{}{
+				"type":        "string",
+				"description": "Aggregate the listed pods per workload instead of returning each pod. Pods are resolved through their owners (ReplicaSet to Deployment, Job to CronJob); pods without an owner are listed as their own workload.",
+				"enum":        []string{"owner"},
+			},
+			"track_changes": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Return a delta_token with the listing, for a later call to fetch only what changed. limit does not apply while tracking changes, and it cannot be combined with group_by.",
+				"default":     false,
+			},
+			"delta_token": map[string]interface{}{
+				"type":        "string",
+				"description": "Token from a previous call with the same namespace and selectors. Returns only pods created, deleted, or whose phase or restart count changed since, and a new token. Tokens expire after 15 minutes unused; an unknown or expired token returns the full listing with full_resync true.",
+			},
 		},
 		"required": []string{},
 	}
@@ -70,6 +102,9 @@ type ListPodsInput struct {
 	LabelSelector string `json:"label_selector"`
 	FieldSelector string `json:"field_selector"`
 	Limit         int    `json:"limit"`
+	GroupBy       string `json:"group_by"`
+	TrackChanges  bool   `json:"track_changes"`
+	DeltaToken    string `json:"delta_token"`
 }
 
 // PodInfo represents simplified pod information
@@ -107,13 +142,46 @@ type ListPodsOutput struct {
 		LabelSelector string `json:"label_selector,omitempty"`
 		FieldSelector string `json:"field_selector,omitempty"`
 	} `json:"filters,omitempty"`
-	Summary struct {
-		Running   int `json:"running"`
-		Pending   int `json:"pending"`
-		Failed    int `json:"failed"`
-		Succeeded int `json:"succeeded"`
-		Unknown   int `json:"unknown"`
-	} `json:"summary"`
+	Summary PodPhaseSummary `json:"summary"`
+
+	// Set with track_changes or delta_token; see ListPodsDeltaOutput
+	DeltaToken       string `json:"delta_token,omitempty"`
+	FullResync       bool   `json:"full_resync,omitempty"` // The delta_token passed in was unknown or expired
+	DeltaUnavailable string `json:"delta_unavailable,omitempty"`
+}
+
+// PodPhaseSummary counts the listed pods by phase
+type PodPhaseSummary struct {
+	Running   int `json:"running"`
+	Pending   int `json:"pending"`
+	Failed    int `json:"failed"`
+	Succeeded int `json:"succeeded"`
+	Unknown   int `json:"unknown"`
+}
+
+// ListPodsByOwnerOutput represents the tool output for group_by "owner"
+type ListPodsByOwnerOutput struct {
+	Workloads []WorkloadPods  `json:"workloads"`
+	Count     int             `json:"count"` // Pods listed
+	Namespace string          `json:"namespace,omitempty"`
+	Summary   PodPhaseSummary `json:"summary"`
+}
+
+// WorkloadPods aggregates the listed pods of one workload
+type WorkloadPods struct {
+	clients.Workload
+	Desired       *int32   `json:"desired,omitempty"` // From the workload's spec; absent for Jobs, CronJobs and bare pods
+	Ready         int      `json:"ready"`             // Listed pods that are running and ready
+	Pods          int      `json:"pods"`
+	Restarts      int32    `json:"restarts"`
+	UnhealthyPods []string `json:"unhealthy_pods,omitempty"` // Neither ready nor completed
+}
+
+// RequiredPermissions declares the Kubernetes API access list-pods needs
+func (t *ListPodsTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "pods", Verb: "list"},
+	}
 }
 
 // Execute runs the list-pods operation
@@ -128,6 +196,16 @@ func (t *ListPodsTool) Execute(ctx context.Context, args map[string]interface{})
 		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
 	}
 
+	if input.GroupBy != "" && input.GroupBy != "owner" {
+		return nil, invalidArgs("invalid group_by '%s', must be: owner", input.GroupBy)
+	}
+	if input.DeltaToken != "" {
+		input.TrackChanges = true
+	}
+	if input.TrackChanges && input.GroupBy != "" {
+		return nil, invalidArgs("track_changes and delta_token cannot be combined with group_by")
+	}
+
 	// Build list options
 	listOpts := metav1.ListOptions{}
 	if input.LabelSelector != "" {
@@ -136,31 +214,42 @@ func (t *ListPodsTool) Execute(ctx context.Context, args map[string]interface{})
 	if input.FieldSelector != "" {
 		listOpts.FieldSelector = input.FieldSelector
 	}
-	if input.Limit > 0 {
+	// Tracking changes needs every matching pod, or the ones past the limit
+	// would show up as deleted
+	if input.Limit > 0 && !input.TrackChanges {
 		limit := int64(input.Limit)
 		listOpts.Limit = limit
 	}
 
-	// Get pods from K8s client
-	var podList *corev1.PodList
-	var err error
-
-	if input.Namespace != "" {
-		// List pods in specific namespace
-		podList, err = t.k8sClient.Clientset().CoreV1().Pods(input.Namespace).List(ctx, listOpts)
-	} else {
-		// List pods in all namespaces
-		podList, err = t.k8sClient.Clientset().CoreV1().Pods("").List(ctx, listOpts)
+	// Get pods from K8s client: the requested namespace, the caller's
+	// namespaces when it is scoped, or all namespaces
+	var pods []corev1.Pod
+	for _, namespace := range clients.NamespacesToList(ctx, input.Namespace) {
+		podList, err := t.k8sClient.Clientset().CoreV1().Pods(namespace).List(ctx, listOpts)
+		if err != nil {
+			return nil, apiError(fmt.Errorf("failed to list pods: %w", err))
+		}
+		pods = append(pods, podList.Items...)
+	}
+	// Each namespace was listed up to the limit; keep it for all of them
+	if listOpts.Limit > 0 && int64(len(pods)) > listOpts.Limit {
+		pods = pods[:listOpts.Limit]
 	}
 
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+	if input.GroupBy == "owner" {
+		return t.groupByOwner(ctx, pods, input.Namespace), nil
 	}
+	if input.TrackChanges {
+		return t.changesSince(ctx, input, pods), nil
+	}
+	return t.listOutput(input, pods), nil
+}
 
-	// Build output
+// listOutput builds the full listing, sorted by namespace and name
+func (t *ListPodsTool) listOutput(input ListPodsInput, pods []corev1.Pod) ListPodsOutput {
 	output := ListPodsOutput{
-		Pods:  make([]PodInfo, 0, len(podList.Items)),
-		Count: len(podList.Items),
+		Pods:  make([]PodInfo, 0, len(pods)),
+		Count: len(pods),
 	}
 
 	if input.Namespace != "" {
@@ -174,26 +263,165 @@ func (t *ListPodsTool) Execute(ctx context.Context, args map[string]interface{})
 	}
 
 	// Process each pod
-	for _, pod := range podList.Items {
+	for _, pod := range pods {
 		podInfo := t.podToPodInfo(&pod)
 		output.Pods = append(output.Pods, podInfo)
 
-		// Update summary counts
-		switch pod.Status.Phase {
-		case corev1.PodRunning:
-			output.Summary.Running++
-		case corev1.PodPending:
-			output.Summary.Pending++
-		case corev1.PodFailed:
-			output.Summary.Failed++
-		case corev1.PodSucceeded:
-			output.Summary.Succeeded++
-		default:
-			output.Summary.Unknown++
+		output.Summary.add(pod.Status.Phase)
+	}
+	sort.Slice(output.Pods, func(i, j int) bool {
+		if output.Pods[i].Namespace != output.Pods[j].Namespace {
+			return output.Pods[i].Namespace < output.Pods[j].Namespace
+		}
+		return output.Pods[i].Name < output.Pods[j].Name
+	})
+	return output
+}
+
+// add counts a pod in the given phase
+func (s *PodPhaseSummary) add(phase corev1.PodPhase) {
+	switch phase {
+	case corev1.PodRunning:
+		s.Running++
+	case corev1.PodPending:
+		s.Pending++
+	case corev1.PodFailed:
+		s.Failed++
+	case corev1.PodSucceeded:
+		s.Succeeded++
+	default:
+		s.Unknown++
+	}
+}
+
+// groupByOwner aggregates pods per workload, sorted by namespace, kind, and name
+func (t *ListPodsTool) groupByOwner(ctx context.Context, pods []corev1.Pod, namespace string) ListPodsByOwnerOutput {
+	output := ListPodsByOwnerOutput{Count: len(pods), Namespace: namespace}
+	groups := make(map[clients.Workload]*WorkloadPods)
+	for i := range pods {
+		pod := &pods[i]
+		output.Summary.add(pod.Status.Phase)
+
+		workload := t.ownerResolver().Resolve(ctx, pod)
+		group, ok := groups[workload]
+		if !ok {
+			group = &WorkloadPods{Workload: workload}
+			groups[workload] = group
+		}
+		group.Pods++
+		for _, cs := range pod.Status.ContainerStatuses {
+			group.Restarts += cs.RestartCount
+		}
+		switch {
+		case pod.Status.Phase == corev1.PodRunning && podReady(pod):
+			group.Ready++
+		case pod.Status.Phase != corev1.PodSucceeded:
+			group.UnhealthyPods = append(group.UnhealthyPods, pod.Name)
 		}
 	}
 
-	return output, nil
+	output.Workloads = make([]WorkloadPods, 0, len(groups))
+	for _, group := range groups {
+		group.Desired = t.desiredPods(ctx, group.Workload)
+		sort.Strings(group.UnhealthyPods)
+		output.Workloads = append(output.Workloads, *group)
+	}
+	sort.Slice(output.Workloads, func(i, j int) bool {
+		a, b := output.Workloads[i], output.Workloads[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return output
+}
+
+// Truncate cuts a list-pods result down to budget bytes of JSON, dropping
+// healthy pods (or workloads) before unhealthy ones, and reports how many
+// it left out
+func (t *ListPodsTool) Truncate(result interface{}, budget int) (interface{}, map[string]int) {
+	switch output := result.(type) {
+	case ListPodsOutput:
+		pods := importantFirst(output.Pods, func(pod PodInfo) bool { return !podInfoHealthy(pod) })
+		keep := keepWithinBudget(len(pods), func(n int) bool {
+			output.Pods = pods[:n]
+			return jsonSize(output) <= budget
+		})
+		output.Pods = pods[:keep]
+		return output, map[string]int{"pods": len(pods) - keep}
+	case ListPodsByOwnerOutput:
+		workloads := importantFirst(output.Workloads, func(workload WorkloadPods) bool {
+			return len(workload.UnhealthyPods) > 0 || (workload.Desired != nil && int32(workload.Ready) < *workload.Desired)
+		})
+		keep := keepWithinBudget(len(workloads), func(n int) bool {
+			output.Workloads = workloads[:n]
+			return jsonSize(output) <= budget
+		})
+		output.Workloads = workloads[:keep]
+		return output, map[string]int{"workloads": len(workloads) - keep}
+	}
+	return result, nil
+}
+
+// podInfoHealthy reports whether a listed pod completed, or is running with
+// every container ready
+func podInfoHealthy(pod PodInfo) bool {
+	if pod.Phase == string(corev1.PodSucceeded) {
+		return true
+	}
+	if pod.Status != string(corev1.PodRunning) {
+		return false
+	}
+	for _, container := range pod.Containers {
+		if !container.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// ownerResolver returns the resolver shared by all calls, so its owner cache
+// carries over between them
+func (t *ListPodsTool) ownerResolver() *clients.OwnerResolver {
+	t.ownersOnce.Do(func() {
+		t.owners = clients.NewOwnerResolver(t.k8sClient.Clientset())
+	})
+	return t.owners
+}
+
+// desiredPods returns the pods a Deployment, StatefulSet, ReplicaSet, or
+// DaemonSet wants, or nil for other workloads and ones that cannot be read
+func (t *ListPodsTool) desiredPods(ctx context.Context, workload clients.Workload) *int32 {
+	apps := t.k8sClient.Clientset().AppsV1()
+	opts := metav1.GetOptions{}
+	switch workload.Kind {
+	case "Deployment":
+		if deployment, err := apps.Deployments(workload.Namespace).Get(ctx, workload.Name, opts); err == nil {
+			return replicasPtr(deployment.Spec.Replicas)
+		}
+	case "StatefulSet":
+		if statefulSet, err := apps.StatefulSets(workload.Namespace).Get(ctx, workload.Name, opts); err == nil {
+			return replicasPtr(statefulSet.Spec.Replicas)
+		}
+	case "ReplicaSet":
+		if replicaSet, err := apps.ReplicaSets(workload.Namespace).Get(ctx, workload.Name, opts); err == nil {
+			return replicasPtr(replicaSet.Spec.Replicas)
+		}
+	case "DaemonSet":
+		if daemonSet, err := apps.DaemonSets(workload.Namespace).Get(ctx, workload.Name, opts); err == nil {
+			return &daemonSet.Status.DesiredNumberScheduled
+		}
+	}
+	return nil
+}
+
+// replicasPtr returns the desired replicas, which default to 1
+func replicasPtr(replicas *int32) *int32 {
+	desired := replicasOrOne(replicas)
+	return &desired
 }
 
 // podToPodInfo converts a Kubernetes Pod to PodInfo
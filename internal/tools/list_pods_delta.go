@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// Bounds on the pod snapshots kept for delta_token. A snapshot holds a few
+// fields per pod, so the worst case stays in the tens of megabytes.
+const (
+	maxDeltaSnapshots    = 32               // Snapshots kept; minting one more drops the oldest
+	maxDeltaSnapshotPods = 5000             // Listings with more pods are not snapshotted
+	deltaSnapshotTTL     = 15 * time.Minute // A token unused for this long expires
+)
+
+// ListPodsDeltaOutput is the list-pods output for a known delta_token: only
+// the pods that changed since the listing that minted it
+type ListPodsDeltaOutput struct {
+	DeltaToken string `json:"delta_token,omitempty"` // Pass to the next call; empty when DeltaUnavailable is set
+	FullResync bool   `json:"full_resync"`           // Always false here; true on the full listing that replaces an unknown token
+	// DeltaUnavailable explains why no new token was minted
+	DeltaUnavailable string          `json:"delta_unavailable,omitempty"`
+	Namespace        string          `json:"namespace,omitempty"`
+	Created          []PodInfo       `json:"created"` // Pods new since the token, including pods recreated under the same name
+	Changed          []PodChange     `json:"changed"` // Pods whose phase or restart count changed
+	Deleted          []DeletedPod    `json:"deleted"`
+	Unchanged        int             `json:"unchanged"`
+	Count            int             `json:"count"` // Pods matching now
+	Summary          PodPhaseSummary `json:"summary"`
+}
+
+// PodChange is a pod whose phase or restart count changed, with the values
+// it had when the token was minted
+type PodChange struct {
+	PodInfo
+	PreviousPhase    string `json:"previous_phase"`
+	PreviousRestarts int32  `json:"previous_restarts"`
+}
+
+// DeletedPod names a pod that no longer matches the listing
+type DeletedPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// podState is what a snapshot remembers of one pod
+type podState struct {
+	UID      types.UID
+	Phase    corev1.PodPhase
+	Restarts int32
+}
+
+// podSnapshot is the state of one listing, keyed by namespace/name
+type podSnapshot struct {
+	query string // The filters it was listed with; see deltaQuery
+	pods  map[string]podState
+}
+
+// podDelta lists the namespace/name keys that differ between two snapshots,
+// each sorted
+type podDelta struct {
+	Created []string
+	Changed []string
+	Deleted []string
+}
+
+// podKey identifies a pod within a snapshot
+func podKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// snapshotPods records the state of the listed pods
+func snapshotPods(pods []corev1.Pod) map[string]podState {
+	states := make(map[string]podState, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		var restarts int32
+		for _, cs := range pod.Status.ContainerStatuses {
+			restarts += cs.RestartCount
+		}
+		states[podKey(pod.Namespace, pod.Name)] = podState{UID: pod.UID, Phase: pod.Status.Phase, Restarts: restarts}
+	}
+	return states
+}
+
+// diffPodSnapshots compares two snapshots. A pod whose UID differs was
+// deleted and recreated under the same name, and counts as created.
+func diffPodSnapshots(previous, current map[string]podState) podDelta {
+	delta := podDelta{Created: []string{}, Changed: []string{}, Deleted: []string{}}
+	for key, state := range current {
+		old, ok := previous[key]
+		switch {
+		case !ok || old.UID != state.UID:
+			delta.Created = append(delta.Created, key)
+		case old.Phase != state.Phase || old.Restarts != state.Restarts:
+			delta.Changed = append(delta.Changed, key)
+		}
+	}
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			delta.Deleted = append(delta.Deleted, key)
+		}
+	}
+	sort.Strings(delta.Created)
+	sort.Strings(delta.Changed)
+	sort.Strings(delta.Deleted)
+	return delta
+}
+
+// deltaQuery identifies the filters and namespace scope of a listing; a
+// token only diffs against a listing made with the same ones
+func deltaQuery(ctx context.Context, input ListPodsInput) string {
+	scope, _ := clients.NamespaceScopeFromContext(ctx)
+	return strings.Join([]string{input.Namespace, input.LabelSelector, input.FieldSelector, strings.Join(scope, ",")}, "\x00")
+}
+
+// changesSince answers a call with track_changes or delta_token set: the
+// pods changed since the token's listing, or the full listing when the
+// token is unknown, expired, or was minted for other filters
+func (t *ListPodsTool) changesSince(ctx context.Context, input ListPodsInput, pods []corev1.Pod) interface{} {
+	query := deltaQuery(ctx, input)
+	current := snapshotPods(pods)
+	previous, found := t.loadSnapshot(input.DeltaToken, query)
+	token, unavailable := t.saveSnapshot(&podSnapshot{query: query, pods: current})
+
+	if !found {
+		output := t.listOutput(input, pods)
+		output.DeltaToken = token
+		output.FullResync = input.DeltaToken != ""
+		output.DeltaUnavailable = unavailable
+		return output
+	}
+
+	byKey := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		byKey[podKey(pods[i].Namespace, pods[i].Name)] = &pods[i]
+	}
+	delta := diffPodSnapshots(previous.pods, current)
+	output := ListPodsDeltaOutput{
+		DeltaToken:       token,
+		DeltaUnavailable: unavailable,
+		Namespace:        input.Namespace,
+		Created:          make([]PodInfo, 0, len(delta.Created)),
+		Changed:          make([]PodChange, 0, len(delta.Changed)),
+		Deleted:          make([]DeletedPod, 0, len(delta.Deleted)),
+		Unchanged:        len(current) - len(delta.Created) - len(delta.Changed),
+		Count:            len(pods),
+	}
+	for _, key := range delta.Created {
+		output.Created = append(output.Created, t.podToPodInfo(byKey[key]))
+	}
+	for _, key := range delta.Changed {
+		old := previous.pods[key]
+		output.Changed = append(output.Changed, PodChange{
+			PodInfo:          t.podToPodInfo(byKey[key]),
+			PreviousPhase:    string(old.Phase),
+			PreviousRestarts: old.Restarts,
+		})
+	}
+	for _, key := range delta.Deleted {
+		namespace, name, _ := strings.Cut(key, "/")
+		output.Deleted = append(output.Deleted, DeletedPod{Namespace: namespace, Name: name})
+	}
+	for i := range pods {
+		output.Summary.add(pods[i].Status.Phase)
+	}
+	return output
+}
+
+// snapshotKey is the cache key of the snapshot behind a token
+func snapshotKey(token string) string {
+	return cache.Key("tool", "list-pods", "snapshot", token)
+}
+
+// loadSnapshot returns the snapshot minted as token for the same query
+func (t *ListPodsTool) loadSnapshot(token, query string) (*podSnapshot, bool) {
+	if token == "" || t.cache == nil {
+		return nil, false
+	}
+	value, ok := t.cache.Get(snapshotKey(token))
+	if !ok {
+		return nil, false
+	}
+	snapshot, ok := value.(*podSnapshot)
+	if !ok || snapshot.query != query {
+		return nil, false
+	}
+	return snapshot, true
+}
+
+// saveSnapshot stores snapshot under a new token, dropping the oldest
+// snapshot once maxDeltaSnapshots are kept. When it cannot, it returns no
+// token and the reason.
+func (t *ListPodsTool) saveSnapshot(snapshot *podSnapshot) (token string, unavailable string) {
+	if t.cache == nil {
+		return "", "change tracking is not available on this server"
+	}
+	if len(snapshot.pods) > maxDeltaSnapshotPods {
+		return "", fmt.Sprintf("%d pods match, over the %d tracked per listing; narrow the namespace or selectors", len(snapshot.pods), maxDeltaSnapshotPods)
+	}
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "failed to mint a delta token"
+	}
+	token = hex.EncodeToString(bytes)
+
+	t.snapshotsMu.Lock()
+	defer t.snapshotsMu.Unlock()
+	t.cache.SetWithTTL(snapshotKey(token), snapshot, deltaSnapshotTTL)
+	t.snapshotTokens = append(t.snapshotTokens, token)
+	for len(t.snapshotTokens) > maxDeltaSnapshots {
+		t.cache.Delete(snapshotKey(t.snapshotTokens[0]))
+		t.snapshotTokens = t.snapshotTokens[1:]
+	}
+	return token, ""
+}
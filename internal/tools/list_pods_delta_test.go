@@ -0,0 +1,262 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestDiffPodSnapshots(t *testing.T) {
+	running := podState{UID: "a", Phase: corev1.PodRunning}
+	tests := []struct {
+		name     string
+		previous map[string]podState
+		current  map[string]podState
+		want     podDelta
+	}{
+		{
+			name:     "no changes",
+			previous: map[string]podState{"shop/web-0": running},
+			current:  map[string]podState{"shop/web-0": running},
+			want:     podDelta{Created: []string{}, Changed: []string{}, Deleted: []string{}},
+		},
+		{
+			name:    "empty previous snapshot",
+			current: map[string]podState{"shop/web-1": running, "shop/web-0": running},
+			want:    podDelta{Created: []string{"shop/web-0", "shop/web-1"}, Changed: []string{}, Deleted: []string{}},
+		},
+		{
+			name:     "deleted",
+			previous: map[string]podState{"shop/web-0": running, "auth/api-0": running, "shop/db-0": running},
+			current:  map[string]podState{"shop/web-0": running},
+			want:     podDelta{Created: []string{}, Changed: []string{}, Deleted: []string{"auth/api-0", "shop/db-0"}},
+		},
+		{
+			name:     "phase change",
+			previous: map[string]podState{"shop/web-0": {UID: "a", Phase: corev1.PodPending}},
+			current:  map[string]podState{"shop/web-0": running},
+			want:     podDelta{Created: []string{}, Changed: []string{"shop/web-0"}, Deleted: []string{}},
+		},
+		{
+			name:     "restart count change",
+			previous: map[string]podState{"shop/web-0": running},
+			current:  map[string]podState{"shop/web-0": {UID: "a", Phase: corev1.PodRunning, Restarts: 3}},
+			want:     podDelta{Created: []string{}, Changed: []string{"shop/web-0"}, Deleted: []string{}},
+		},
+		{
+			name:     "recreated under the same name",
+			previous: map[string]podState{"shop/db-0": {UID: "a", Phase: corev1.PodRunning, Restarts: 5}},
+			current:  map[string]podState{"shop/db-0": {UID: "b", Phase: corev1.PodPending}},
+			want:     podDelta{Created: []string{"shop/db-0"}, Changed: []string{}, Deleted: []string{}},
+		},
+		{
+			name: "mixed",
+			previous: map[string]podState{
+				"shop/web-0": running,
+				"shop/web-1": running,
+				"shop/web-2": running,
+			},
+			current: map[string]podState{
+				"shop/web-0": running,
+				"shop/web-1": {UID: "a", Phase: corev1.PodFailed},
+				"shop/web-3": running,
+			},
+			want: podDelta{Created: []string{"shop/web-3"}, Changed: []string{"shop/web-1"}, Deleted: []string{"shop/web-2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffPodSnapshots(tt.previous, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffPodSnapshots() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffPodSnapshots_Symmetric(t *testing.T) {
+	// Swapping the snapshots swaps created and deleted
+	a := map[string]podState{"shop/web-0": {UID: "a"}, "shop/web-1": {UID: "b"}}
+	b := map[string]podState{"shop/web-1": {UID: "b"}, "shop/web-2": {UID: "c"}}
+
+	forward, backward := diffPodSnapshots(a, b), diffPodSnapshots(b, a)
+	if !reflect.DeepEqual(forward.Created, backward.Deleted) || !reflect.DeepEqual(forward.Deleted, backward.Created) {
+		t.Errorf("forward %+v and backward %+v diffs do not mirror each other", forward, backward)
+	}
+}
+
+// deltaPod returns a running pod in shop with the given restarts
+func deltaPod(name string, restarts int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name, UID: types.UID("uid-" + name)},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "main", RestartCount: restarts}},
+		},
+	}
+}
+
+func newDeltaTestTool(t *testing.T, clientset *fake.Clientset) *ListPodsTool {
+	t.Helper()
+	memCache := cache.NewMemoryCache(30 * time.Second)
+	t.Cleanup(memCache.Close)
+	return NewListPodsTool(clients.NewK8sClientWithClientset(clientset), memCache)
+}
+
+func executeListPods(t *testing.T, tool *ListPodsTool, args map[string]interface{}) interface{} {
+	t.Helper()
+	result, err := tool.Execute(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	return result
+}
+
+func TestListPodsTool_DeltaToken(t *testing.T) {
+	clientset := fake.NewClientset(deltaPod("web-0", 0), deltaPod("web-1", 0), deltaPod("web-2", 0))
+	tool := newDeltaTestTool(t, clientset)
+	ctx := context.Background()
+
+	// The first listing is complete whatever the limit, and mints a token
+	full, ok := executeListPods(t, tool, map[string]interface{}{"namespace": "shop", "track_changes": true, "limit": 1}).(ListPodsOutput)
+	if !ok {
+		t.Fatal("Expected a full ListPodsOutput for track_changes")
+	}
+	if full.Count != 3 || full.DeltaToken == "" || full.FullResync {
+		t.Fatalf("count %d, token %q, full_resync %v", full.Count, full.DeltaToken, full.FullResync)
+	}
+
+	pods := clientset.CoreV1().Pods("shop")
+	if err := pods.Delete(ctx, "web-0", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pods.Update(ctx, deltaPod("web-1", 4), metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pods.Create(ctx, deltaPod("web-3", 0), metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	delta, ok := executeListPods(t, tool, map[string]interface{}{"namespace": "shop", "delta_token": full.DeltaToken}).(ListPodsDeltaOutput)
+	if !ok {
+		t.Fatal("Expected a ListPodsDeltaOutput for a known token")
+	}
+	if len(delta.Created) != 1 || delta.Created[0].Name != "web-3" {
+		t.Errorf("Created = %+v, want web-3", delta.Created)
+	}
+	if len(delta.Changed) != 1 || delta.Changed[0].Name != "web-1" || delta.Changed[0].Restarts != 4 || delta.Changed[0].PreviousRestarts != 0 {
+		t.Errorf("Changed = %+v, want web-1 at 4 restarts, previously 0", delta.Changed)
+	}
+	if want := []DeletedPod{{Namespace: "shop", Name: "web-0"}}; !reflect.DeepEqual(delta.Deleted, want) {
+		t.Errorf("Deleted = %+v, want %+v", delta.Deleted, want)
+	}
+	if delta.Unchanged != 1 || delta.Count != 3 || delta.Summary.Running != 3 {
+		t.Errorf("unchanged %d, count %d, summary %+v", delta.Unchanged, delta.Count, delta.Summary)
+	}
+	if delta.DeltaToken == "" || delta.DeltaToken == full.DeltaToken {
+		t.Errorf("Expected a new token, got %q", delta.DeltaToken)
+	}
+
+	// Nothing changed since the new token
+	quiet := executeListPods(t, tool, map[string]interface{}{"namespace": "shop", "delta_token": delta.DeltaToken}).(ListPodsDeltaOutput)
+	if len(quiet.Created)+len(quiet.Changed)+len(quiet.Deleted) != 0 || quiet.Unchanged != 3 {
+		t.Errorf("Expected no changes, got %+v", quiet)
+	}
+
+	// Tokens stay valid until they expire or are evicted
+	again := executeListPods(t, tool, map[string]interface{}{"namespace": "shop", "delta_token": full.DeltaToken}).(ListPodsDeltaOutput)
+	if len(again.Deleted) != 1 {
+		t.Errorf("Expected the first token to still diff against its listing, got %+v", again)
+	}
+}
+
+func TestListPodsTool_DeltaTokenFullResync(t *testing.T) {
+	tool := newDeltaTestTool(t, fake.NewClientset(deltaPod("web-0", 0), deltaPod("web-1", 0)))
+	first := executeListPods(t, tool, map[string]interface{}{"namespace": "shop", "track_changes": true}).(ListPodsOutput)
+
+	tests := []struct {
+		name string
+		args map[string]interface{}
+	}{
+		{"unknown token", map[string]interface{}{"namespace": "shop", "delta_token": "not-a-token"}},
+		{"token for other filters", map[string]interface{}{"namespace": "shop", "label_selector": "app=web", "delta_token": first.DeltaToken}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, ok := executeListPods(t, tool, tt.args).(ListPodsOutput)
+			if !ok {
+				t.Fatal("Expected a full listing")
+			}
+			if !output.FullResync || output.DeltaToken == "" {
+				t.Errorf("full_resync %v, token %q; want a resync with a fresh token", output.FullResync, output.DeltaToken)
+			}
+		})
+	}
+}
+
+func TestListPodsTool_DeltaSnapshotsBounded(t *testing.T) {
+	tool := newDeltaTestTool(t, fake.NewClientset(deltaPod("web-0", 0)))
+	args := map[string]interface{}{"namespace": "shop", "track_changes": true}
+
+	oldest := executeListPods(t, tool, args).(ListPodsOutput).DeltaToken
+	var newest string
+	for i := 0; i < maxDeltaSnapshots; i++ {
+		newest = executeListPods(t, tool, args).(ListPodsOutput).DeltaToken
+	}
+	if len(tool.snapshotTokens) != maxDeltaSnapshots {
+		t.Errorf("%d snapshots kept, want %d", len(tool.snapshotTokens), maxDeltaSnapshots)
+	}
+	if _, ok := tool.loadSnapshot(oldest, deltaQuery(context.Background(), ListPodsInput{Namespace: "shop"})); ok {
+		t.Error("Expected the oldest snapshot to be evicted")
+	}
+	if output, ok := executeListPods(t, tool, map[string]interface{}{"namespace": "shop", "delta_token": oldest}).(ListPodsOutput); !ok || !output.FullResync {
+		t.Errorf("Expected a full resync for an evicted token, got %+v", output)
+	}
+	if _, ok := executeListPods(t, tool, map[string]interface{}{"namespace": "shop", "delta_token": newest}).(ListPodsDeltaOutput); !ok {
+		t.Error("Expected the newest token to still work")
+	}
+}
+
+func TestListPodsTool_DeltaSnapshotTooLarge(t *testing.T) {
+	tool := newDeltaTestTool(t, fake.NewClientset())
+	pods := make(map[string]podState, maxDeltaSnapshotPods+1)
+	for i := 0; i <= maxDeltaSnapshotPods; i++ {
+		pods[fmt.Sprintf("shop/web-%d", i)] = podState{}
+	}
+
+	token, unavailable := tool.saveSnapshot(&podSnapshot{pods: pods})
+	if token != "" || unavailable == "" {
+		t.Errorf("token %q, unavailable %q; want no token and a reason", token, unavailable)
+	}
+	if len(tool.snapshotTokens) != 0 {
+		t.Error("Expected nothing stored")
+	}
+}
+
+func TestListPodsTool_DeltaWithoutCache(t *testing.T) {
+	tool := NewListPodsTool(clients.NewK8sClientWithClientset(fake.NewClientset(deltaPod("web-0", 0))), nil)
+
+	output := executeListPods(t, tool, map[string]interface{}{"track_changes": true}).(ListPodsOutput)
+	if output.DeltaToken != "" || output.DeltaUnavailable == "" || output.Count != 1 {
+		t.Errorf("Expected the listing without a token, got %+v", output)
+	}
+}
+
+func TestListPodsTool_DeltaRejectsGroupBy(t *testing.T) {
+	tool := newDeltaTestTool(t, fake.NewClientset())
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"group_by": "owner", "delta_token": "abc"})
+	if !IsInvalidArguments(err) {
+		t.Errorf("Expected an invalid arguments error, got %v", err)
+	}
+}
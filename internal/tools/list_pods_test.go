@@ -2,24 +2,71 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
 )
 
-func TestListPodsTool_Name(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
+// podFixtures returns a fake client serving a running pod in kube-system and
+// a running and a pending pod in shop
+func podFixtures() *testutil.FakeK8sClient {
+	pod := func(namespace, name string, phase corev1.PodPhase) *corev1.Pod {
+		image := "quay.io/example/" + name
+		state := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		if phase == corev1.PodPending {
+			state = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace, Name: name,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			},
+			Spec: corev1.PodSpec{NodeName: "worker-1", Containers: []corev1.Container{{Name: "main", Image: image}}},
+			Status: corev1.PodStatus{
+				Phase:             phase,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "main", Image: image, Ready: phase == corev1.PodRunning, State: state}},
+			},
+		}
 	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
+	return testutil.NewFakeK8sClient(
+		pod("kube-system", "coredns-0", corev1.PodRunning),
+		pod("shop", "web-0", corev1.PodRunning),
+		pod("shop", "db-0", corev1.PodPending),
+	)
+}
+
+// listRestrictions returns the namespace and selectors of the first pod list
+// the tool sent to the API
+func listRestrictions(t *testing.T, client *testutil.FakeK8sClient) (string, k8stesting.ListRestrictions) {
+	t.Helper()
+	for _, action := range client.Fake.Actions() {
+		if list, ok := action.(k8stesting.ListActionImpl); ok && list.GetResource().Resource == "pods" {
+			return list.GetNamespace(), list.GetListRestrictions()
 		}
-	}()
+	}
+	t.Fatal("Expected a pod list request")
+	return "", k8stesting.ListRestrictions{}
+}
 
-	tool := NewListPodsTool(client)
+func TestListPodsTool_Name(t *testing.T) {
+	tool := NewListPodsTool(testutil.NewFakeK8sClient(), nil)
 
 	if tool.Name() != "list-pods" {
 		t.Errorf("Expected name 'list-pods', got '%s'", tool.Name())
@@ -27,17 +74,7 @@ func TestListPodsTool_Name(t *testing.T) {
 }
 
 func TestListPodsTool_Description(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
-
-	tool := NewListPodsTool(client)
+	tool := NewListPodsTool(testutil.NewFakeK8sClient(), nil)
 
 	desc := tool.Description()
 	if desc == "" {
@@ -49,24 +86,13 @@ func TestListPodsTool_Description(t *testing.T) {
 }
 
 func TestListPodsTool_InputSchema(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
-
-	tool := NewListPodsTool(client)
+	tool := NewListPodsTool(testutil.NewFakeK8sClient(), nil)
 
 	schema := tool.InputSchema()
 	if schema == nil {
 		t.Fatal("InputSchema returned nil")
 	}
 
-	// Check schema structure
 	if schema["type"] != "object" {
 		t.Error("Expected schema type to be 'object'")
 	}
@@ -76,72 +102,48 @@ func TestListPodsTool_InputSchema(t *testing.T) {
 		t.Fatal("Expected properties to be a map")
 	}
 
-	// Check required properties
-	expectedProps := []string{"namespace", "label_selector", "field_selector", "limit"}
-	for _, prop := range expectedProps {
-		if _, exists := properties[prop]; !exists {
-			t.Errorf("Expected property '%s' to exist", prop)
+	for _, name := range []string{"namespace", "label_selector", "field_selector", "limit"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("Expected %s property", name)
 		}
 	}
 }
 
 func TestListPodsTool_Execute_Default(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
-
-	tool := NewListPodsTool(client)
-	ctx := context.Background()
+	client := podFixtures()
+	tool := NewListPodsTool(client, nil)
 
-	// Test with default args
-	result, err := tool.Execute(ctx, map[string]interface{}{})
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
 	if err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
 	output, ok := result.(ListPodsOutput)
 	if !ok {
-		t.Fatal("Expected ListPodsOutput type")
+		t.Fatalf("Expected ListPodsOutput type, got %T", result)
 	}
-
-	// Should have limited results (default limit 100)
-	if output.Count > 100 {
-		t.Errorf("Expected count <= 100 with default limit, got %d", output.Count)
+	if output.Count != 3 || len(output.Pods) != 3 {
+		t.Errorf("Expected all 3 pods, got count %d with %d pods", output.Count, len(output.Pods))
 	}
-
-	// Should have pods array
-	if output.Pods == nil {
-		t.Error("Expected pods array to be initialized")
+	if output.Summary != (PodPhaseSummary{Running: 2, Pending: 1}) {
+		t.Errorf("Expected 2 running and 1 pending pod, got %+v", output.Summary)
 	}
 
-	// Should have summary
-	if output.Summary.Running < 0 {
-		t.Error("Expected valid summary counts")
+	// All namespaces are listed, with the default limit
+	namespace, restrictions := listRestrictions(t, client)
+	if namespace != "" {
+		t.Errorf("Expected pods of all namespaces to be listed, got namespace %q", namespace)
+	}
+	if restrictions.Labels != nil && !restrictions.Labels.Empty() {
+		t.Errorf("Expected no label selector, got %q", restrictions.Labels)
 	}
 }
 
 func TestListPodsTool_Execute_WithNamespace(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
-
-	tool := NewListPodsTool(client)
-	ctx := context.Background()
+	client := podFixtures()
+	tool := NewListPodsTool(client, nil)
 
-	// Test with specific namespace
-	result, err := tool.Execute(ctx, map[string]interface{}{
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
 		"namespace": "kube-system",
 		"limit":     5,
 	})
@@ -151,45 +153,26 @@ func TestListPodsTool_Execute_WithNamespace(t *testing.T) {
 
 	output, ok := result.(ListPodsOutput)
 	if !ok {
-		t.Fatal("Expected ListPodsOutput type")
+		t.Fatalf("Expected ListPodsOutput type, got %T", result)
 	}
-
-	// Verify namespace filter was applied
 	if output.Namespace != "kube-system" {
 		t.Errorf("Expected namespace 'kube-system', got '%s'", output.Namespace)
 	}
-
-	// Verify limit was applied
-	if output.Count > 5 {
-		t.Errorf("Expected count <= 5, got %d", output.Count)
+	if output.Count != 1 || output.Pods[0].Name != "coredns-0" {
+		t.Errorf("Expected only coredns-0, got %+v", output.Pods)
 	}
-
-	// Verify all returned pods are from the specified namespace
-	for _, pod := range output.Pods {
-		if pod.Namespace != "kube-system" {
-			t.Errorf("Expected pod namespace 'kube-system', got '%s'", pod.Namespace)
-		}
+	if namespace, _ := listRestrictions(t, client); namespace != "kube-system" {
+		t.Errorf("Expected pods of kube-system to be listed, got namespace %q", namespace)
 	}
 }
 
 func TestListPodsTool_Execute_WithFieldSelector(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
-	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
+	client := podFixtures()
+	tool := NewListPodsTool(client, nil)
 
-	tool := NewListPodsTool(client)
-	ctx := context.Background()
-
-	// Test with field selector for Running pods
-	result, err := tool.Execute(ctx, map[string]interface{}{
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
 		"field_selector": "status.phase=Running",
-		"limit":          10,
+		"label_selector": "app=web",
 	})
 	if err != nil {
 		t.Fatalf("Execute failed: %v", err)
@@ -197,98 +180,117 @@ func TestListPodsTool_Execute_WithFieldSelector(t *testing.T) {
 
 	output, ok := result.(ListPodsOutput)
 	if !ok {
-		t.Fatal("Expected ListPodsOutput type")
+		t.Fatalf("Expected ListPodsOutput type, got %T", result)
 	}
-
-	// Verify field selector in output
-	if output.Filters.FieldSelector != "status.phase=Running" {
-		t.Errorf("Expected field selector to be recorded in output")
+	if output.Filters.FieldSelector != "status.phase=Running" || output.Filters.LabelSelector != "app=web" {
+		t.Errorf("Expected the selectors to be recorded in output, got %+v", output.Filters)
 	}
 
-	// Verify all returned pods are Running
-	for _, pod := range output.Pods {
-		if pod.Phase != "Running" {
-			t.Errorf("Expected pod phase 'Running', got '%s'", pod.Phase)
-		}
+	// The API server does the filtering; the fake clientset does not apply
+	// field selectors, so check what was asked for
+	_, restrictions := listRestrictions(t, client)
+	if got := restrictions.Fields.String(); got != "status.phase=Running" {
+		t.Errorf("Expected field selector status.phase=Running in the request, got %q", got)
+	}
+	if got := restrictions.Labels.String(); got != "app=web" {
+		t.Errorf("Expected label selector app=web in the request, got %q", got)
 	}
+}
+
+func TestListPodsTool_Limit(t *testing.T) {
+	tool := NewListPodsTool(podFixtures(), nil)
 
-	// Summary should only have running pods
-	if output.Summary.Running != output.Count {
-		t.Errorf("Expected all %d pods to be running, got %d", output.Count, output.Summary.Running)
+	tests := []struct {
+		name string
+		args map[string]interface{}
+		want int
+	}{
+		{"limit", map[string]interface{}{"limit": 2}, 2},
+		{"no limit", map[string]interface{}{"limit": 0}, 3},
+		{"above the pod count", map[string]interface{}{"limit": 50}, 3},
+		// Mistyped arguments fall back to the defaults
+		{"mistyped limit", map[string]interface{}{"limit": "two"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tool.Execute(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			if output := result.(ListPodsOutput); output.Count != tt.want || len(output.Pods) != tt.want {
+				t.Errorf("Expected %d pods, got count %d with %d pods", tt.want, output.Count, len(output.Pods))
+			}
+		})
 	}
 }
 
-func TestListPodsTool_PodInfo(t *testing.T) {
-	client, err := clients.NewK8sClient(nil)
-	if err != nil {
-		t.Skipf("Skipping: unable to create Kubernetes client: %v", err)
+func TestListPodsTool_APIErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"forbidden", apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no access")), ErrForbidden},
+		{"bad selector", apierrors.NewBadRequest("unable to parse requirement"), ErrInvalidArgs},
+		{"timeout", apierrors.NewTimeoutError("list took too long", 1), ErrTimeout},
+		{"unavailable", apierrors.NewServiceUnavailable("apiserver is shutting down"), ErrUpstreamUnavailable},
 	}
-	defer func() {
-		if err := client.Close(); err != nil {
-			t.Logf("Error closing client: %v", err)
-		}
-	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := podFixtures()
+			client.Fake.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+				return true, nil, tt.err
+			})
+			tool := NewListPodsTool(client, nil)
+
+			_, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop"})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v (class %v)", tt.want, err, ErrorClass(err))
+			}
+		})
+	}
+}
 
-	tool := NewListPodsTool(client)
-	ctx := context.Background()
+func TestListPodsTool_PodInfo(t *testing.T) {
+	tool := NewListPodsTool(podFixtures(), nil)
 
-	// Get a pod to verify structure
-	result, err := tool.Execute(ctx, map[string]interface{}{
-		"limit": 1,
-	})
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop"})
 	if err != nil {
 		t.Fatalf("Execute failed: %v", err)
 	}
 
 	output, ok := result.(ListPodsOutput)
 	if !ok {
-		t.Fatal("Expected ListPodsOutput type")
-	}
-
-	if len(output.Pods) == 0 {
-		t.Skip("No pods available for testing")
-	}
-
-	pod := output.Pods[0]
-
-	// Verify pod structure
-	if pod.Name == "" {
-		t.Error("Expected pod name to be set")
+		t.Fatalf("Expected ListPodsOutput type, got %T", result)
 	}
-
-	if pod.Namespace == "" {
-		t.Error("Expected pod namespace to be set")
+	if len(output.Pods) != 2 {
+		t.Fatalf("Expected 2 pods in shop, got %d", len(output.Pods))
 	}
 
-	if pod.Status == "" {
-		t.Error("Expected pod status to be set")
+	// Sorted by name: db-0 is pending, web-0 running
+	pending, running := output.Pods[0], output.Pods[1]
+	if running.Name != "web-0" || running.Namespace != "shop" || running.Phase != "Running" {
+		t.Errorf("Expected the running pod shop/web-0, got %+v", running)
 	}
-
-	if pod.Ready == "" {
-		t.Error("Expected pod ready status to be set")
+	if running.Ready != "1/1" || pending.Ready != "0/1" {
+		t.Errorf("Expected readiness 1/1 and 0/1, got %s and %s", running.Ready, pending.Ready)
 	}
-
-	if pod.Age == "" {
-		t.Error("Expected pod age to be set")
+	if running.Age != "2h" {
+		t.Errorf("Expected age 2h, got %s", running.Age)
 	}
-
-	// Containers should be populated
-	if len(pod.Containers) == 0 {
-		t.Error("Expected at least one container")
+	if running.Node != "worker-1" {
+		t.Errorf("Expected node worker-1, got %s", running.Node)
 	}
 
-	// Verify container structure
-	container := pod.Containers[0]
-	if container.Name == "" {
-		t.Error("Expected container name to be set")
+	if len(running.Containers) != 1 {
+		t.Fatalf("Expected one container, got %+v", running.Containers)
 	}
-
-	if container.Image == "" {
-		t.Error("Expected container image to be set")
+	container := running.Containers[0]
+	if container.Name != "main" || container.Image != "quay.io/example/web-0" || container.State != "Running" {
+		t.Errorf("Unexpected container info %+v", container)
 	}
-
-	if container.State == "" {
-		t.Error("Expected container state to be set")
+	if waiting := pending.Containers[0]; waiting.State != "Waiting" || waiting.Reason != "ContainerCreating" {
+		t.Errorf("Expected a waiting container, got %+v", waiting)
 	}
 }
 
@@ -312,3 +314,184 @@ func TestFormatDuration(t *testing.T) {
 		}
 	}
 }
+
+// ownedPod returns a pod in shop controlled by kind/name, ready when running
+func ownedPod(name, kind, owner string, phase corev1.PodPhase, restarts int32) *corev1.Pod {
+	controller := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: name},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "main", RestartCount: restarts}},
+		},
+	}
+	if kind != "" {
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}}
+	}
+	if phase == corev1.PodRunning {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	return pod
+}
+
+func TestListPodsTool_GroupByOwner(t *testing.T) {
+	controller := true
+	three := int32(3)
+	crashing := ownedPod("web-7d9f-c", "ReplicaSet", "web-7d9f", corev1.PodRunning, 7)
+	crashing.Status.Conditions = nil
+	clientset := fake.NewClientset(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}, Spec: appsv1.DeploymentSpec{Replicas: &three}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: "web-7d9f",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &controller}},
+		}},
+		&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "agent"}, Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 2}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: "report-2910",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "report", Controller: &controller}},
+		}},
+		ownedPod("web-7d9f-a", "ReplicaSet", "web-7d9f", corev1.PodRunning, 1),
+		ownedPod("web-7d9f-b", "ReplicaSet", "web-7d9f", corev1.PodPending, 0),
+		crashing,
+		ownedPod("agent-x", "DaemonSet", "agent", corev1.PodRunning, 0),
+		ownedPod("report-2910-q", "Job", "report-2910", corev1.PodSucceeded, 0),
+		ownedPod("debug", "", "", corev1.PodFailed, 2),
+	)
+	tool := NewListPodsTool(clients.NewK8sClientWithClientset(clientset), nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "group_by": "owner"})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	output, ok := result.(ListPodsByOwnerOutput)
+	if !ok {
+		t.Fatalf("Expected ListPodsByOwnerOutput type, got %T", result)
+	}
+	if output.Count != 6 || output.Summary.Running != 3 || output.Summary.Failed != 1 {
+		t.Errorf("unexpected count %d or summary %+v", output.Count, output.Summary)
+	}
+
+	two := int32(2)
+	want := []WorkloadPods{
+		{Workload: clients.Workload{Kind: "CronJob", Namespace: "shop", Name: "report"}, Pods: 1},
+		{Workload: clients.Workload{Kind: "DaemonSet", Namespace: "shop", Name: "agent"}, Desired: &two, Ready: 1, Pods: 1},
+		{Workload: clients.Workload{Kind: "Deployment", Namespace: "shop", Name: "web"}, Desired: &three, Ready: 1, Pods: 3, Restarts: 8,
+			UnhealthyPods: []string{"web-7d9f-b", "web-7d9f-c"}},
+		{Workload: clients.Workload{Kind: "Pod", Namespace: "shop", Name: "debug"}, Pods: 1, Restarts: 2, UnhealthyPods: []string{"debug"}},
+	}
+	if !reflect.DeepEqual(output.Workloads, want) {
+		t.Errorf("Workloads = %+v, want %+v", output.Workloads, want)
+	}
+}
+
+func TestListPodsTool_StableOutput(t *testing.T) {
+	// Pods of several namespaces, created out of order; the zero creation
+	// timestamps keep their age fixed for the golden file
+	var objects []runtime.Object
+	for _, key := range []string{"shop/web-b", "auth/login-0", "shop/web-a", "shop/debug", "auth/api-1", "billing/worker"} {
+		namespace, name, _ := strings.Cut(key, "/")
+		pod := ownedPod(name, "", "", corev1.PodRunning, 0)
+		pod.Namespace = namespace
+		pod.Labels = map[string]string{"app": name, "tier": namespace}
+		objects = append(objects, pod)
+	}
+	tool := NewListPodsTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)), nil)
+
+	render := func() string {
+		result, err := tool.Execute(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data) + "\n"
+	}
+
+	first := render()
+	for i := 0; i < 10; i++ {
+		if got := render(); got != first {
+			t.Fatalf("list-pods output changed between calls:\n%s\n---\n%s", first, got)
+		}
+	}
+	assertGolden(t, "list_pods.golden.json", first)
+}
+
+func TestListPodsTool_InvalidGroupBy(t *testing.T) {
+	tool := NewListPodsTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"group_by": "node"})
+	if !IsInvalidArguments(err) {
+		t.Errorf("Expected an invalid arguments error, got %v", err)
+	}
+}
+
+func TestListPodsTool_TruncateKeepsUnhealthyPods(t *testing.T) {
+	output := ListPodsOutput{}
+	for i := 0; i < 50; i++ {
+		output.Pods = append(output.Pods, PodInfo{
+			Name: fmt.Sprintf("web-%02d", i), Namespace: "shop", Status: "Running", Phase: "Running",
+			Containers: []ContainerInfo{{Name: "main", Ready: true, State: "Running"}},
+		})
+	}
+	output.Pods = append(output.Pods,
+		PodInfo{Name: "api-0", Namespace: "shop", Status: "Running", Phase: "Running",
+			Containers: []ContainerInfo{{Name: "main", State: "Waiting", Reason: "CrashLoopBackOff"}}},
+		PodInfo{Name: "job-0", Namespace: "shop", Status: "Succeeded", Phase: "Succeeded"},
+		PodInfo{Name: "db-0", Namespace: "shop", Status: "Pending", Phase: "Pending"},
+	)
+	output.Count = len(output.Pods)
+	budget := jsonSize(output) / 5
+
+	truncated, omitted := (&ListPodsTool{}).Truncate(output, budget)
+	result, ok := truncated.(ListPodsOutput)
+	if !ok {
+		t.Fatalf("Expected ListPodsOutput type, got %T", truncated)
+	}
+	if size := jsonSize(result); size > budget {
+		t.Errorf("truncated result is %d bytes, over the %d byte budget", size, budget)
+	}
+	if omitted["pods"] != 53-len(result.Pods) || omitted["pods"] == 0 {
+		t.Errorf("omitted %d pods, kept %d", omitted["pods"], len(result.Pods))
+	}
+	if result.Count != 53 {
+		t.Errorf("Count = %d, want the 53 listed pods", result.Count)
+	}
+	if len(result.Pods) < 2 || result.Pods[0].Name != "api-0" || result.Pods[1].Name != "db-0" {
+		t.Errorf("Expected the unhealthy pods api-0 and db-0 first, got %+v", result.Pods)
+	}
+}
+
+func TestListPodsTool_NamespaceScope(t *testing.T) {
+	var objects []runtime.Object
+	for _, key := range []string{"shop/web-0", "shop/web-1", "auth/login-0", "billing/worker"} {
+		namespace, name, _ := strings.Cut(key, "/")
+		pod := ownedPod(name, "", "", corev1.PodRunning, 0)
+		pod.Namespace = namespace
+		objects = append(objects, pod)
+	}
+	tool := NewListPodsTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)), nil)
+	ctx := clients.WithNamespaceScope(context.Background(), []string{"shop", "auth"})
+
+	result, err := tool.Execute(ctx, nil)
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	var names []string
+	for _, pod := range result.(ListPodsOutput).Pods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	if want := []string{"auth/login-0", "shop/web-0", "shop/web-1"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("scoped pods = %v, want %v", names, want)
+	}
+
+	// The limit holds across the scope's namespaces
+	result, err = tool.Execute(ctx, map[string]interface{}{"limit": 2})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if count := result.(ListPodsOutput).Count; count != 2 {
+		t.Errorf("Count = %d, want 2", count)
+	}
+}
@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// playbookCatalogKey caches the Coordination Engine's playbook catalog
+var playbookCatalogKey = cache.Key("tool", "remediation-playbooks", "catalog")
+
+const (
+	// playbookCatalogTTL is long because playbooks change only when the engine is upgraded
+	playbookCatalogTTL = time.Hour
+	// maxPlaybookSuggestions bounds the "did you mean" list for an unknown playbook
+	maxPlaybookSuggestions = 3
+)
+
+// ListRemediationPlaybooksTool lists the remediations the Coordination Engine can perform
+type ListRemediationPlaybooksTool struct {
+	ceClient *clients.CoordinationEngineClient
+	cache    *cache.MemoryCache
+}
+
+// NewListRemediationPlaybooksTool creates a new list-remediation-playbooks tool
+func NewListRemediationPlaybooksTool(ceClient *clients.CoordinationEngineClient, memoryCache *cache.MemoryCache) *ListRemediationPlaybooksTool {
+	return &ListRemediationPlaybooksTool{
+		ceClient: ceClient,
+		cache:    memoryCache,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *ListRemediationPlaybooksTool) Name() string {
+	return "list-remediation-playbooks"
+}
+
+// Volatility is static: the playbook catalog only changes when the
+// Coordination Engine is reconfigured
+func (t *ListRemediationPlaybooksTool) Volatility() Volatility {
+	return VolatilityStatic
+}
+
+// Description returns the tool description for MCP
+func (t *ListRemediationPlaybooksTool) Description() string {
+	return `List the remediation playbooks the Coordination Engine can run, with each playbook's description, required parameters, target resource kinds, destructiveness, and, when the engine tracks it, average duration and success rate. The names are the valid values of trigger-remediation's playbook argument.
+
+Use this tool for questions like:
+- "What remediations can you actually perform here?"
+- "Which playbooks work on StatefulSets?"
+- "Is there a non-destructive fix for a crash loop?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *ListRemediationPlaybooksTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"target_kind": map[string]interface{}{
+				"type":        "string",
+				"description": "Only list playbooks that act on this resource kind (e.g. Deployment)",
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// ListRemediationPlaybooksInput represents the input parameters
+type ListRemediationPlaybooksInput struct {
+	TargetKind string `json:"target_kind"`
+}
+
+// RemediationPlaybookInfo is one playbook in the catalog
+type RemediationPlaybookInfo struct {
+	Name               string   `json:"name"`
+	Description        string   `json:"description"`
+	RequiredParameters []string `json:"required_parameters"`
+	TargetKinds        []string `json:"target_kinds"`
+	Destructiveness    string   `json:"destructiveness"`
+	AverageDuration    string   `json:"average_duration,omitempty"`
+	SuccessRatePercent *float64 `json:"success_rate_percent,omitempty"`
+}
+
+// ListRemediationPlaybooksOutput represents the tool output
+type ListRemediationPlaybooksOutput struct {
+	Status    string                    `json:"status"`
+	Count     int                       `json:"count"`
+	Playbooks []RemediationPlaybookInfo `json:"playbooks"`
+}
+
+// Execute runs the list-remediation-playbooks operation
+func (t *ListRemediationPlaybooksTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input ListRemediationPlaybooksInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	playbooks, err := playbookCatalog(ctx, t.ceClient, t.cache)
+	if err != nil {
+		return nil, dependencyError(fmt.Errorf("failed to list remediation playbooks: %w", err))
+	}
+
+	output := ListRemediationPlaybooksOutput{
+		Status:    "success",
+		Playbooks: []RemediationPlaybookInfo{},
+	}
+	for _, playbook := range playbooks {
+		if input.TargetKind != "" && !containsFold(playbook.TargetKinds, input.TargetKind) {
+			continue
+		}
+		info := RemediationPlaybookInfo{
+			Name:               playbook.Name,
+			Description:        playbook.Description,
+			RequiredParameters: playbook.RequiredParameters,
+			TargetKinds:        playbook.TargetKinds,
+			Destructiveness:    playbook.Destructiveness,
+		}
+		if info.RequiredParameters == nil {
+			info.RequiredParameters = []string{}
+		}
+		if info.TargetKinds == nil {
+			info.TargetKinds = []string{}
+		}
+		if info.Destructiveness == "" {
+			info.Destructiveness = "unknown"
+		}
+		if playbook.AverageDurationSeconds != nil {
+			info.AverageDuration = formatDuration(time.Duration(*playbook.AverageDurationSeconds * float64(time.Second)))
+		}
+		if playbook.SuccessRate != nil {
+			percent := *playbook.SuccessRate * 100
+			info.SuccessRatePercent = &percent
+		}
+		output.Playbooks = append(output.Playbooks, info)
+	}
+	sort.Slice(output.Playbooks, func(i, j int) bool { return output.Playbooks[i].Name < output.Playbooks[j].Name })
+	output.Count = len(output.Playbooks)
+
+	return output, nil
+}
+
+// playbookCatalog returns the Coordination Engine's playbooks, cached for playbookCatalogTTL
+func playbookCatalog(ctx context.Context, ceClient *clients.CoordinationEngineClient, memoryCache *cache.MemoryCache) ([]clients.RemediationPlaybook, error) {
+	if memoryCache == nil {
+		return ceClient.ListPlaybooks(ctx)
+	}
+
+	return cache.GetOrSetTyped(ctx, memoryCache, playbookCatalogKey, playbookCatalogTTL, func() ([]clients.RemediationPlaybook, error) {
+		return ceClient.ListPlaybooks(ctx)
+	})
+}
+
+// suggestPlaybooks returns up to maxPlaybookSuggestions catalog names closest to
+// name by edit distance, ignoring those too far away to be a typo
+func suggestPlaybooks(name string, playbooks []clients.RemediationPlaybook) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+	name = strings.ToLower(name)
+	limit := max(2, len(name)/3)
+
+	var candidates []candidate
+	for _, playbook := range playbooks {
+		if distance := editDistance(name, strings.ToLower(playbook.Name)); distance <= limit {
+			candidates = append(candidates, candidate{playbook.Name, distance})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	suggestions := make([]string, 0, maxPlaybookSuggestions)
+	for i := 0; i < len(candidates) && i < maxPlaybookSuggestions; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/internal/testutil"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+const playbookCatalogJSON = `{"playbooks":[
+	{"name":"restart-pod","description":"Delete the pod so its controller recreates it","target_kinds":["Pod"],"destructiveness":"low","average_duration_seconds":45,"success_rate":0.92},
+	{"name":"rollout-restart","description":"Restart every pod of a workload","required_parameters":["namespace","resource_name"],"target_kinds":["Deployment","StatefulSet"],"destructiveness":"medium"},
+	{"name":"scale-up","description":"Add replicas","required_parameters":["replicas"],"target_kinds":["Deployment"]}
+]}`
+
+// newPlaybookEngine serves the playbook catalog and accepts remediations,
+// counting the requests to each
+func newPlaybookEngine(t *testing.T, catalogStatus int) (*clients.CoordinationEngineClient, *int, *int) {
+	var catalogCalls, triggerCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/remediation/playbooks":
+			catalogCalls++
+			w.WriteHeader(catalogStatus)
+			_, _ = w.Write([]byte(playbookCatalogJSON))
+		case "/api/v1/remediation/trigger":
+			triggerCalls++
+			_, _ = w.Write([]byte(`{"workflow_id":"wf-1","status":"running"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return testutil.NewCoordinationEngineClient(t, server.URL), &catalogCalls, &triggerCalls
+}
+
+func TestListRemediationPlaybooksTool_Execute(t *testing.T) {
+	ce, catalogCalls, _ := newPlaybookEngine(t, http.StatusOK)
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	defer memoryCache.Close()
+	tool := NewListRemediationPlaybooksTool(ce, memoryCache)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(ListRemediationPlaybooksOutput)
+	require.Equal(t, 3, output.Count)
+
+	restart := output.Playbooks[0]
+	assert.Equal(t, "restart-pod", restart.Name)
+	assert.Equal(t, "45s", restart.AverageDuration)
+	require.NotNil(t, restart.SuccessRatePercent)
+	assert.InDelta(t, 92, *restart.SuccessRatePercent, 0.001)
+	assert.Equal(t, "unknown", output.Playbooks[2].Destructiveness)
+	assert.Equal(t, []string{}, output.Playbooks[0].RequiredParameters)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"target_kind": "statefulset"})
+	require.NoError(t, err)
+	output = result.(ListRemediationPlaybooksOutput)
+	require.Equal(t, 1, output.Count)
+	assert.Equal(t, "rollout-restart", output.Playbooks[0].Name)
+
+	assert.Equal(t, 1, *catalogCalls, "the catalog should be served from cache")
+}
+
+func TestSuggestPlaybooks(t *testing.T) {
+	var playbooks []clients.RemediationPlaybook
+	for _, name := range []string{"restart-pod", "rollout-restart", "scale-up", "scale-down"} {
+		playbooks = append(playbooks, clients.RemediationPlaybook{Name: name})
+	}
+
+	assert.Equal(t, []string{"restart-pod"}, suggestPlaybooks("restrat-pod", playbooks))
+	assert.Equal(t, []string{"scale-down", "scale-up"}, suggestPlaybooks("scale-dn", playbooks))
+	assert.Equal(t, []string{"restart-pod"}, suggestPlaybooks("Restart-Pod", playbooks))
+	assert.Empty(t, suggestPlaybooks("drain-node", playbooks))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("scale-up", "scale-up"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+	assert.Equal(t, 5, editDistance("", "drain"))
+}
+
+func TestTriggerRemediationTool_Playbook(t *testing.T) {
+	args := func(playbook string) map[string]interface{} {
+		return map[string]interface{}{
+			"incident_id":   "inc-1",
+			"namespace":     "payments",
+			"resource_name": "api",
+			"resource_kind": "Deployment",
+			"issue_type":    "pod_crash",
+			"severity":      "high",
+			"playbook":      playbook,
+		}
+	}
+
+	t.Run("typo fails fast with a suggestion", func(t *testing.T) {
+		ce, _, triggerCalls := newPlaybookEngine(t, http.StatusOK)
+		tool := NewTriggerRemediationTool(ce, nil)
+
+		_, err := tool.Execute(context.Background(), args("rollout-restrat"))
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err))
+		assert.Contains(t, err.Error(), "did you mean rollout-restart?")
+		assert.Zero(t, *triggerCalls)
+	})
+
+	t.Run("known playbook is sent", func(t *testing.T) {
+		ce, _, triggerCalls := newPlaybookEngine(t, http.StatusOK)
+		tool := NewTriggerRemediationTool(ce, nil)
+
+		result, err := tool.Execute(context.Background(), args("rollout-restart"))
+		require.NoError(t, err)
+		assert.Equal(t, "wf-1", result.(TriggerRemediationOutput).WorkflowID)
+		assert.Equal(t, 1, *triggerCalls)
+	})
+
+	t.Run("unavailable catalog defers to the engine", func(t *testing.T) {
+		ce, _, triggerCalls := newPlaybookEngine(t, http.StatusNotFound)
+		tool := NewTriggerRemediationTool(ce, nil)
+
+		_, err := tool.Execute(context.Background(), args("custom-playbook"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, *triggerCalls)
+	})
+}
@@ -0,0 +1,484 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// PromQL for KServe Serverless models, read from the Knative queue-proxy series of the
+// predictor's revisions. Latencies are in milliseconds.
+const (
+	// modelServingSelector matches a model's predictor revisions (older KServe appends -default)
+	modelServingSelector      = `namespace_name="%s",configuration_name=~"%s-predictor(-default)?"`
+	modelRequestRateQuery     = `sum by (revision_name) (rate(revision_request_count{%s}[%dm]))`
+	modelErrorRateQuery       = `sum by (revision_name) (rate(revision_request_count{%s,response_code_class="5xx"}[%dm]))`
+	modelRevisionLatencyQuery = `histogram_quantile(%g, sum by (revision_name, le) (rate(revision_request_latencies_bucket{%s}[%dm])))`
+	modelLatencyQuery         = `histogram_quantile(%g, sum by (le) (rate(revision_request_latencies_bucket{%s}[%dm])))`
+)
+
+// modelPredictHistogram is the predict latency histogram (seconds) exposed by KServe
+// model servers on /metrics, used when Prometheus is not configured
+const modelPredictHistogram = "request_predict_seconds"
+
+const (
+	// canaryErrorRateMargin is how many percentage points a canary's error rate may exceed the stable revision's
+	canaryErrorRateMargin = 1.0
+	// canaryLatencyFactor is how much slower a canary's p95 may be than the stable revision's
+	canaryLatencyFactor = 1.5
+)
+
+// modelQuantiles are the latency percentiles reported by get-model-metrics
+var modelQuantiles = []float64{0.5, 0.95, 0.99}
+
+// GetModelMetricsTool reports how well a KServe model is serving: request rate,
+// latency percentiles, error rate, and per-revision comparisons behind a canary split
+type GetModelMetricsTool struct {
+	kserveClient *clients.KServeClient
+	prometheus   *clients.PrometheusClient // nil when Prometheus is not configured
+}
+
+// NewGetModelMetricsTool creates a new get-model-metrics tool; prometheus may be nil
+func NewGetModelMetricsTool(kserveClient *clients.KServeClient, prometheus *clients.PrometheusClient) *GetModelMetricsTool {
+	return &GetModelMetricsTool{
+		kserveClient: kserveClient,
+		prometheus:   prometheus,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetModelMetricsTool) Name() string {
+	return "get-model-metrics"
+}
+
+// Description returns the tool description for MCP
+func (t *GetModelMetricsTool) Description() string {
+	return `Report how well a KServe model is serving over a recent window: request rate, p50/p95/p99 latency, and 5xx error rate, overall and per predictor revision with its share of traffic. When a canary revision is receiving part of the traffic, compares it with the stable revision and flags error rate or latency regressions. Uses Prometheus when configured; otherwise scrapes the model server's own /metrics, which only gives cumulative latency since it started.
+
+Use this tool for questions like:
+- "How is the anomaly-detector model performing?"
+- "Is the canary revision of my model slower than the stable one?"
+- "What is the error rate of predictive-analytics over the last hour?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetModelMetricsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"model_name": map[string]interface{}{
+				"type":        "string",
+				"description": "The name of the KServe InferenceService",
+			},
+			"window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Window for rates and latency percentiles",
+				"default":     15,
+				"minimum":     1,
+				"maximum":     1440,
+			},
+		},
+		"required": []string{"model_name"},
+	}
+}
+
+// GetModelMetricsInput represents the input parameters
+type GetModelMetricsInput struct {
+	ModelName     string `json:"model_name"`
+	WindowMinutes int    `json:"window_minutes"`
+}
+
+// ModelLatency holds latency percentiles in milliseconds
+type ModelLatency struct {
+	P50Ms float64 `json:"p50_ms"`
+	P95Ms float64 `json:"p95_ms"`
+	P99Ms float64 `json:"p99_ms"`
+}
+
+// RevisionMetrics is the serving performance of one predictor revision
+type RevisionMetrics struct {
+	Revision          string       `json:"revision"`
+	TrafficPercent    int          `json:"traffic_percent"`
+	Latest            bool         `json:"latest,omitempty"`
+	Tag               string       `json:"tag,omitempty"`
+	RequestsPerSecond float64      `json:"requests_per_second"`
+	ErrorRatePercent  float64      `json:"error_rate_percent"`
+	Latency           ModelLatency `json:"latency"`
+}
+
+// GetModelMetricsOutput represents the tool output
+type GetModelMetricsOutput struct {
+	Status            string            `json:"status"`
+	ModelName         string            `json:"model_name"`
+	Namespace         string            `json:"namespace"`
+	Source            string            `json:"source"` // prometheus or model_metrics_endpoint
+	WindowMinutes     int               `json:"window_minutes,omitempty"`
+	RequestsPerSecond float64           `json:"requests_per_second"`
+	RequestsTotal     float64           `json:"requests_total,omitempty"` // Cumulative count from the model's own /metrics
+	ErrorRatePercent  float64           `json:"error_rate_percent"`
+	Latency           ModelLatency      `json:"latency"`
+	Revisions         []RevisionMetrics `json:"revisions,omitempty"`
+	Regressions       []string          `json:"regressions,omitempty"`
+	Summary           string            `json:"summary"`
+	Notes             []string          `json:"notes,omitempty"`
+}
+
+// modelSamples holds the live model series read from Prometheus
+type modelSamples struct {
+	Requests        []clients.PromSample
+	Errors          []clients.PromSample
+	RevisionLatency [][]clients.PromSample // One entry per modelQuantiles
+	Latency         [][]clients.PromSample
+}
+
+// RequiredPermissions declares the Kubernetes API access get-model-metrics needs
+func (t *GetModelMetricsTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "serving.kserve.io", Resource: "inferenceservices", Verb: "get"},
+	}
+}
+
+// Execute runs the get-model-metrics operation
+func (t *GetModelMetricsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetModelMetricsInput{WindowMinutes: 15}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.ModelName == "" {
+		return nil, invalidArgs("model_name is required")
+	}
+	// The name is interpolated into PromQL, so it must be a plain object name
+	if errs := validation.IsDNS1123Label(input.ModelName); len(errs) > 0 {
+		return nil, invalidArgs("invalid model_name %q: %s", input.ModelName, strings.Join(errs, "; "))
+	}
+	if input.WindowMinutes < 1 || input.WindowMinutes > 1440 {
+		return nil, invalidArgs("window_minutes must be between 1 and 1440")
+	}
+
+	output := GetModelMetricsOutput{
+		Status:    "success",
+		ModelName: input.ModelName,
+		Namespace: t.kserveClient.GetNamespace(),
+	}
+
+	// The traffic split is optional detail; RawDeployment models have none
+	traffic, err := t.kserveClient.GetPredictorTraffic(ctx, input.ModelName)
+	if err != nil {
+		output.Notes = append(output.Notes, fmt.Sprintf("could not read the InferenceService traffic split: %v", err))
+	}
+
+	if t.prometheus != nil {
+		live, err := t.queryModel(ctx, output.Namespace, input.ModelName, input.WindowMinutes)
+		if err == nil {
+			output.Source = "prometheus"
+			output.WindowMinutes = input.WindowMinutes
+			applyModelSamples(&output, live, traffic)
+			output.Regressions = canaryRegressions(output.Revisions)
+			output.Summary = modelMetricsSummary(output)
+			return output, nil
+		}
+		output.Notes = append(output.Notes, fmt.Sprintf("Prometheus query failed, falling back to the model's /metrics: %v", err))
+	} else {
+		output.Notes = append(output.Notes, "Prometheus is not configured (ENABLE_PROMETHEUS); showing cumulative latency from the model's /metrics")
+	}
+
+	exposition, err := t.kserveClient.ScrapeMetrics(ctx, input.ModelName)
+	if err != nil {
+		return nil, dependencyError(fmt.Errorf("failed to read metrics for model %s: %w", input.ModelName, err))
+	}
+	buckets, count := parseHistogram(exposition, modelPredictHistogram)
+	if count == 0 {
+		output.Notes = append(output.Notes, fmt.Sprintf("the model server exposes no %s samples; it may not have served requests yet", modelPredictHistogram))
+	}
+	output.Source = "model_metrics_endpoint"
+	output.RequestsTotal = count
+	output.Latency = ModelLatency{
+		P50Ms: roundMs(histogramQuantile(0.5, buckets) * 1000),
+		P95Ms: roundMs(histogramQuantile(0.95, buckets) * 1000),
+		P99Ms: roundMs(histogramQuantile(0.99, buckets) * 1000),
+	}
+	output.Notes = append(output.Notes, "request rate, error rate, and per-revision figures need Prometheus")
+	for _, split := range traffic {
+		output.Revisions = append(output.Revisions, RevisionMetrics{Revision: split.RevisionName, TrafficPercent: split.Percent, Latest: split.LatestRevision, Tag: split.Tag})
+	}
+	output.Summary = modelMetricsSummary(output)
+	return output, nil
+}
+
+// queryModel reads the live model series from Prometheus
+func (t *GetModelMetricsTool) queryModel(ctx context.Context, namespace, model string, windowMinutes int) (*modelSamples, error) {
+	queries := modelMetricsQueries(namespace, model, windowMinutes)
+	samples := modelSamples{
+		RevisionLatency: make([][]clients.PromSample, len(modelQuantiles)),
+		Latency:         make([][]clients.PromSample, len(modelQuantiles)),
+	}
+	targets := []*[]clients.PromSample{&samples.Requests, &samples.Errors}
+	for i := range modelQuantiles {
+		targets = append(targets, &samples.RevisionLatency[i], &samples.Latency[i])
+	}
+	for i, query := range queries {
+		result, err := t.prometheus.Query(ctx, query)
+		if err != nil {
+			return nil, dependencyError(err)
+		}
+		*targets[i] = result
+	}
+	return &samples, nil
+}
+
+// modelMetricsQueries builds the PromQL for one model: request rate and error rate
+// by revision, then for each of modelQuantiles the latency by revision and overall
+func modelMetricsQueries(namespace, model string, windowMinutes int) []string {
+	selector := fmt.Sprintf(modelServingSelector, namespace, model)
+	queries := []string{
+		fmt.Sprintf(modelRequestRateQuery, selector, windowMinutes),
+		fmt.Sprintf(modelErrorRateQuery, selector, windowMinutes),
+	}
+	for _, q := range modelQuantiles {
+		queries = append(queries,
+			fmt.Sprintf(modelRevisionLatencyQuery, q, selector, windowMinutes),
+			fmt.Sprintf(modelLatencyQuery, q, selector, windowMinutes))
+	}
+	return queries
+}
+
+// applyModelSamples fills the overall and per-revision figures from live samples,
+// including revisions that receive traffic but served no requests in the window
+func applyModelSamples(output *GetModelMetricsOutput, live *modelSamples, traffic []clients.RevisionTraffic) {
+	revisions := make(map[string]*RevisionMetrics)
+	revision := func(name string) *RevisionMetrics {
+		if r, ok := revisions[name]; ok {
+			return r
+		}
+		r := &RevisionMetrics{Revision: name}
+		revisions[name] = r
+		return r
+	}
+	for _, split := range traffic {
+		r := revision(split.RevisionName)
+		r.TrafficPercent, r.Latest, r.Tag = split.Percent, split.LatestRevision, split.Tag
+	}
+
+	errorRates := make(map[string]float64)
+	var totalRequests, totalErrors float64
+	for _, sample := range live.Requests {
+		revision(sample.Labels["revision_name"]).RequestsPerSecond = sample.Value
+		totalRequests += sample.Value
+	}
+	for _, sample := range live.Errors {
+		errorRates[sample.Labels["revision_name"]] = sample.Value
+		totalErrors += sample.Value
+	}
+	for i, q := range modelQuantiles {
+		for _, sample := range live.RevisionLatency[i] {
+			setQuantile(&revision(sample.Labels["revision_name"]).Latency, q, sample.Value)
+		}
+		for _, sample := range live.Latency[i] {
+			setQuantile(&output.Latency, q, sample.Value)
+		}
+	}
+
+	output.RequestsPerSecond = math.Round(totalRequests*1000) / 1000
+	output.ErrorRatePercent = percentOf(totalErrors, totalRequests)
+	for name, r := range revisions {
+		r.ErrorRatePercent = percentOf(errorRates[name], r.RequestsPerSecond)
+		r.RequestsPerSecond = math.Round(r.RequestsPerSecond*1000) / 1000
+		output.Revisions = append(output.Revisions, *r)
+	}
+	sort.Slice(output.Revisions, func(i, j int) bool {
+		if output.Revisions[i].TrafficPercent != output.Revisions[j].TrafficPercent {
+			return output.Revisions[i].TrafficPercent > output.Revisions[j].TrafficPercent
+		}
+		return output.Revisions[i].Revision < output.Revisions[j].Revision
+	})
+}
+
+// setQuantile stores a latency percentile in milliseconds
+func setQuantile(latency *ModelLatency, q, ms float64) {
+	switch q {
+	case 0.5:
+		latency.P50Ms = roundMs(ms)
+	case 0.95:
+		latency.P95Ms = roundMs(ms)
+	case 0.99:
+		latency.P99Ms = roundMs(ms)
+	}
+}
+
+// canaryRegressions compares the latest revision, when it receives only part of the
+// traffic, with the revision receiving the most of the rest
+func canaryRegressions(revisions []RevisionMetrics) []string {
+	var canary, stable *RevisionMetrics
+	for i := range revisions {
+		r := &revisions[i]
+		switch {
+		case r.Latest && r.TrafficPercent > 0 && r.TrafficPercent < 100:
+			canary = r
+		case !r.Latest && r.TrafficPercent > 0 && (stable == nil || r.TrafficPercent > stable.TrafficPercent):
+			stable = r
+		}
+	}
+	if canary == nil || stable == nil {
+		return nil
+	}
+
+	var regressions []string
+	if canary.ErrorRatePercent >= stable.ErrorRatePercent+canaryErrorRateMargin {
+		regressions = append(regressions, fmt.Sprintf("canary %s (%d%% of traffic) has a %.1f%% error rate vs %.1f%% on stable %s",
+			canary.Revision, canary.TrafficPercent, canary.ErrorRatePercent, stable.ErrorRatePercent, stable.Revision))
+	}
+	if stable.Latency.P95Ms > 0 && canary.Latency.P95Ms >= canaryLatencyFactor*stable.Latency.P95Ms {
+		regressions = append(regressions, fmt.Sprintf("canary %s p95 latency is %.0fms vs %.0fms on stable %s (%.1fx)",
+			canary.Revision, canary.Latency.P95Ms, stable.Latency.P95Ms, stable.Revision, canary.Latency.P95Ms/stable.Latency.P95Ms))
+	}
+	return regressions
+}
+
+// modelMetricsSummary describes the model's serving performance in one sentence
+func modelMetricsSummary(output GetModelMetricsOutput) string {
+	if output.Source == "model_metrics_endpoint" {
+		return fmt.Sprintf("Model '%s' served %.0f predictions since its server started (p50 %.0fms, p95 %.0fms, p99 %.0fms)",
+			output.ModelName, output.RequestsTotal, output.Latency.P50Ms, output.Latency.P95Ms, output.Latency.P99Ms)
+	}
+	summary := fmt.Sprintf("Model '%s' is serving %.2f req/s over the last %dm with a %.1f%% error rate (p50 %.0fms, p95 %.0fms, p99 %.0fms)",
+		output.ModelName, output.RequestsPerSecond, output.WindowMinutes, output.ErrorRatePercent,
+		output.Latency.P50Ms, output.Latency.P95Ms, output.Latency.P99Ms)
+	if output.RequestsPerSecond == 0 {
+		summary = fmt.Sprintf("Model '%s' served no requests over the last %dm", output.ModelName, output.WindowMinutes)
+	}
+	if len(output.Regressions) > 0 {
+		summary += fmt.Sprintf("; %d canary regression(s) detected", len(output.Regressions))
+	}
+	return summary
+}
+
+// histogramBucket is one cumulative bucket of a Prometheus histogram
+type histogramBucket struct {
+	UpperBound float64
+	Count      float64
+}
+
+// parseHistogram sums the buckets of a histogram across all label sets in a
+// Prometheus text exposition and returns them sorted, with the total count
+func parseHistogram(exposition, name string) ([]histogramBucket, float64) {
+	byBound := make(map[float64]float64)
+	var count float64
+	scanner := bufio.NewScanner(strings.NewReader(exposition))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		series, rawValue, ok := cutLastField(line)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(rawValue, 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(series, name+"_bucket{"):
+			le, ok := labelValue(series, "le")
+			if !ok {
+				continue
+			}
+			bound, err := strconv.ParseFloat(le, 64)
+			if err != nil {
+				continue
+			}
+			byBound[bound] += value
+		case series == name+"_count" || strings.HasPrefix(series, name+"_count{"):
+			count += value
+		}
+	}
+
+	buckets := make([]histogramBucket, 0, len(byBound))
+	for bound, c := range byBound {
+		buckets = append(buckets, histogramBucket{UpperBound: bound, Count: c})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].UpperBound < buckets[j].UpperBound })
+	return buckets, count
+}
+
+// cutLastField splits an exposition line into its series and value, ignoring an
+// optional trailing timestamp
+func cutLastField(line string) (series, value string, ok bool) {
+	end := strings.LastIndex(line, "}")
+	rest := line
+	if end >= 0 {
+		series, rest = line[:end+1], line[end+1:]
+	}
+	fields := strings.Fields(rest)
+	if end < 0 {
+		if len(fields) < 2 {
+			return "", "", false
+		}
+		series, fields = fields[0], fields[1:]
+	}
+	if len(fields) == 0 {
+		return "", "", false
+	}
+	return series, fields[0], true
+}
+
+// labelValue extracts one label's value from a series such as name{a="1",le="0.5"}
+func labelValue(series, label string) (string, bool) {
+	for _, prefix := range []string{"{", ","} {
+		if i := strings.Index(series, prefix+label+`="`); i >= 0 {
+			rest := series[i+len(prefix)+len(label)+2:]
+			if end := strings.IndexByte(rest, '"'); end >= 0 {
+				return rest[:end], true
+			}
+		}
+	}
+	return "", false
+}
+
+// histogramQuantile estimates a quantile from cumulative buckets by linear
+// interpolation within the bucket, as PromQL's histogram_quantile does
+func histogramQuantile(q float64, buckets []histogramBucket) float64 {
+	if len(buckets) == 0 || buckets[len(buckets)-1].Count == 0 {
+		return 0
+	}
+	total := buckets[len(buckets)-1].Count
+	rank := q * total
+	lowerBound, lowerCount := 0.0, 0.0
+	for _, b := range buckets {
+		if b.Count >= rank {
+			if math.IsInf(b.UpperBound, 1) {
+				// Beyond the largest finite bucket; report its bound
+				return lowerBound
+			}
+			if b.Count == lowerCount {
+				return b.UpperBound
+			}
+			return lowerBound + (b.UpperBound-lowerBound)*(rank-lowerCount)/(b.Count-lowerCount)
+		}
+		lowerBound, lowerCount = b.UpperBound, b.Count
+	}
+	return lowerBound
+}
+
+// percentOf returns part/total as a percentage rounded to one decimal (0 when total is 0)
+func percentOf(part, total float64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(part/total*1000) / 10
+}
+
+// roundMs rounds milliseconds to one decimal
+func roundMs(ms float64) float64 {
+	return math.Round(ms*10) / 10
+}
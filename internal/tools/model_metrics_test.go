@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestModelMetricsQueries(t *testing.T) {
+	queries := modelMetricsQueries("models", "anomaly-detector", 15)
+	selector := `namespace_name="models",configuration_name=~"anomaly-detector-predictor(-default)?"`
+
+	require.Len(t, queries, 2+2*len(modelQuantiles))
+	assert.Equal(t, `sum by (revision_name) (rate(revision_request_count{`+selector+`}[15m]))`, queries[0])
+	assert.Equal(t, `sum by (revision_name) (rate(revision_request_count{`+selector+`,response_code_class="5xx"}[15m]))`, queries[1])
+	assert.Equal(t, `histogram_quantile(0.5, sum by (revision_name, le) (rate(revision_request_latencies_bucket{`+selector+`}[15m])))`, queries[2])
+	assert.Equal(t, `histogram_quantile(0.5, sum by (le) (rate(revision_request_latencies_bucket{`+selector+`}[15m])))`, queries[3])
+	assert.Equal(t, `histogram_quantile(0.99, sum by (le) (rate(revision_request_latencies_bucket{`+selector+`}[15m])))`, queries[7])
+}
+
+func TestParseHistogram(t *testing.T) {
+	exposition := `# HELP request_predict_seconds predict latency
+# TYPE request_predict_seconds histogram
+request_predict_seconds_bucket{model_name="anomaly-detector",le="0.1"} 50
+request_predict_seconds_bucket{model_name="anomaly-detector",le="0.5"} 90
+request_predict_seconds_bucket{model_name="anomaly-detector",le="+Inf"} 100 1717243200000
+request_predict_seconds_bucket{model_name="canary",le="0.1"} 10
+request_predict_seconds_bucket{model_name="canary",le="0.5"} 10
+request_predict_seconds_bucket{model_name="canary",le="+Inf"} 10
+request_predict_seconds_count{model_name="anomaly-detector"} 100
+request_predict_seconds_count{model_name="canary"} 10
+request_predict_seconds_sum{model_name="anomaly-detector"} 12.5
+request_preprocess_seconds_bucket{le="0.1"} 999
+`
+	buckets, count := parseHistogram(exposition, modelPredictHistogram)
+	assert.Equal(t, 110.0, count)
+	require.Len(t, buckets, 3)
+	assert.Equal(t, histogramBucket{UpperBound: 0.1, Count: 60}, buckets[0])
+	assert.Equal(t, 110.0, buckets[2].Count)
+
+	assert.InDelta(t, 0.0917, histogramQuantile(0.5, buckets), 0.001) // 55th of 60 requests in [0, 0.1]
+	assert.InDelta(t, 0.5, histogramQuantile(0.99, buckets), 0.001)   // In the +Inf bucket: report the largest finite bound
+	assert.Equal(t, 0.0, histogramQuantile(0.5, nil))
+}
+
+func TestCanaryRegressions(t *testing.T) {
+	stable := RevisionMetrics{Revision: "model-predictor-00001", TrafficPercent: 90, ErrorRatePercent: 0.2, Latency: ModelLatency{P95Ms: 100}}
+
+	tests := []struct {
+		name    string
+		canary  RevisionMetrics
+		flagged []string
+	}{
+		{
+			name:   "healthy canary",
+			canary: RevisionMetrics{Revision: "model-predictor-00002", TrafficPercent: 10, Latest: true, ErrorRatePercent: 0.5, Latency: ModelLatency{P95Ms: 120}},
+		},
+		{
+			name:    "errors and latency",
+			canary:  RevisionMetrics{Revision: "model-predictor-00002", TrafficPercent: 10, Latest: true, ErrorRatePercent: 5, Latency: ModelLatency{P95Ms: 300}},
+			flagged: []string{"error rate", "p95 latency is 300ms vs 100ms"},
+		},
+		{
+			name:   "latest at full traffic is not a canary",
+			canary: RevisionMetrics{Revision: "model-predictor-00002", TrafficPercent: 100, Latest: true, ErrorRatePercent: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regressions := canaryRegressions([]RevisionMetrics{stable, tt.canary})
+			require.Len(t, regressions, len(tt.flagged))
+			for i, want := range tt.flagged {
+				assert.Contains(t, regressions[i], want)
+			}
+		})
+	}
+}
+
+func TestGetModelMetricsTool_Execute(t *testing.T) {
+	selector := fmt.Sprintf(modelServingSelector, "models", "anomaly-detector")
+	latency := func(q float64, byRevision bool, stable, canary string) (string, string) {
+		if byRevision {
+			return fmt.Sprintf(modelRevisionLatencyQuery, q, selector, 15), fmt.Sprintf(`[
+				{"metric":{"revision_name":"anomaly-detector-predictor-00001"},"value":[1717243200,"%s"]},
+				{"metric":{"revision_name":"anomaly-detector-predictor-00002"},"value":[1717243200,"%s"]}]`, stable, canary)
+		}
+		return fmt.Sprintf(modelLatencyQuery, q, selector, 15), fmt.Sprintf(`[{"metric":{},"value":[1717243200,"%s"]}]`, stable)
+	}
+	fixture := map[string]string{
+		fmt.Sprintf(modelRequestRateQuery, selector, 15): `[
+			{"metric":{"revision_name":"anomaly-detector-predictor-00001"},"value":[1717243200,"9"]},
+			{"metric":{"revision_name":"anomaly-detector-predictor-00002"},"value":[1717243200,"1"]}]`,
+		fmt.Sprintf(modelErrorRateQuery, selector, 15): `[
+			{"metric":{"revision_name":"anomaly-detector-predictor-00002"},"value":[1717243200,"0.2"]}]`,
+	}
+	for _, q := range []struct {
+		q              float64
+		stable, canary string
+	}{{0.5, "20", "25"}, {0.95, "80", "95"}, {0.99, "150", "400"}} {
+		for _, byRevision := range []bool{true, false} {
+			query, result := latency(q.q, byRevision, q.stable, q.canary)
+			fixture[query] = result
+		}
+	}
+
+	isvcGVR := schema.GroupVersionResource{Group: "serving.kserve.io", Version: "v1beta1", Resource: "inferenceservices"}
+	isvc := newUnstructured(schema.GroupVersionKind{Group: "serving.kserve.io", Version: "v1beta1", Kind: "InferenceService"}, "models", "anomaly-detector", map[string]interface{}{
+		"status": map[string]interface{}{"components": map[string]interface{}{"predictor": map[string]interface{}{"traffic": []interface{}{
+			map[string]interface{}{"revisionName": "anomaly-detector-predictor-00001", "percent": int64(90)},
+			map[string]interface{}{"revisionName": "anomaly-detector-predictor-00002", "percent": int64(10), "latestRevision": true, "tag": "prev"},
+		}}}},
+	})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{isvcGVR: "InferenceServiceList"}, isvc)
+	kserve := clients.NewKServeClient(clients.KServeConfig{Namespace: "models", Enabled: true, DynamicClient: dynamicClient})
+	tool := NewGetModelMetricsTool(kserve, newAPFPrometheus(t, fixture))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"model_name": "anomaly-detector"})
+	require.NoError(t, err)
+	output := result.(GetModelMetricsOutput)
+
+	assert.Equal(t, "prometheus", output.Source)
+	assert.Equal(t, 10.0, output.RequestsPerSecond)
+	assert.Equal(t, 2.0, output.ErrorRatePercent)
+	assert.Equal(t, ModelLatency{P50Ms: 20, P95Ms: 80, P99Ms: 150}, output.Latency)
+	require.Len(t, output.Revisions, 2)
+	assert.Equal(t, "anomaly-detector-predictor-00001", output.Revisions[0].Revision)
+	assert.Equal(t, 90, output.Revisions[0].TrafficPercent)
+	assert.Equal(t, 20.0, output.Revisions[1].ErrorRatePercent)
+	assert.Equal(t, 400.0, output.Revisions[1].Latency.P99Ms)
+	require.Len(t, output.Regressions, 1, "only the error rate regressed beyond its margin")
+	assert.Contains(t, output.Regressions[0], "20.0% error rate vs 0.0%")
+	assert.Contains(t, output.Summary, "1 canary regression(s) detected")
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"model_name": `x"} or vector(1)`})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
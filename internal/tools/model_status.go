@@ -94,13 +94,13 @@ func (t *GetModelStatusTool) Execute(ctx context.Context, args map[string]interf
 
 	// Validate required fields
 	if input.ModelName == "" {
-		return nil, fmt.Errorf("model_name is required")
+		return nil, invalidArgs("model_name is required")
 	}
 
 	// Get model status from KServe
 	modelStatus, err := t.kserveClient.GetModelStatus(ctx, input.ModelName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get model status: %w", err)
+		return nil, dependencyError(fmt.Errorf("failed to get model status: %w", err))
 	}
 
 	// Build endpoints list if requested
@@ -0,0 +1,166 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+var testMustGatherConfig = MustGatherConfig{
+	Image:          "quay.io/openshift/origin-must-gather:latest",
+	Namespace:      "support",
+	ServiceAccount: "must-gather",
+	Retention:      time.Hour,
+}
+
+func TestBuildMustGatherJob(t *testing.T) {
+	now := time.Date(2026, 10, 14, 8, 30, 0, 0, time.UTC)
+
+	t.Run("archive kept in pod", func(t *testing.T) {
+		job := buildMustGatherJob(testMustGatherConfig, "/usr/bin/gather_audit_logs", now)
+
+		assert.Equal(t, "must-gather-20261014-083000", job.Name)
+		assert.Equal(t, "support", job.Namespace)
+		assert.Equal(t, mustGatherLabels, job.Labels)
+
+		spec := job.Spec.Template.Spec
+		assert.Equal(t, "must-gather", spec.ServiceAccountName)
+		require.Len(t, spec.InitContainers, 1)
+		assert.Equal(t, []string{"/usr/bin/gather_audit_logs"}, spec.InitContainers[0].Command)
+		require.Len(t, spec.Containers, 1)
+		assert.Contains(t, spec.Containers[0].Command[2], "sleep 3600")
+		require.Len(t, spec.Volumes, 1)
+		assert.NotNil(t, spec.Volumes[0].EmptyDir)
+		assert.False(t, mustGatherUsesPVC(job))
+	})
+
+	t.Run("archive on PVC", func(t *testing.T) {
+		config := testMustGatherConfig
+		config.PVC = "support-archives"
+		job := buildMustGatherJob(config, defaultGatherScript, now)
+
+		spec := job.Spec.Template.Spec
+		require.NotNil(t, spec.Volumes[0].PersistentVolumeClaim)
+		assert.Equal(t, "support-archives", spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+		assert.Equal(t, job.Name, spec.Containers[0].VolumeMounts[0].SubPath)
+		assert.NotContains(t, spec.Containers[0].Command[2], "sleep")
+		assert.True(t, mustGatherUsesPVC(job))
+		assert.Equal(t, "pvc/support-archives: must-gather-20261014-083000/must-gather.tar.gz", mustGatherArchiveLocation(job, ""))
+	})
+}
+
+func TestTriggerMustGatherTool_Execute(t *testing.T) {
+	clientset := fake.NewClientset()
+	tool := NewTriggerMustGatherTool(clients.NewK8sClientWithClientset(clientset), testMustGatherConfig)
+	assert.True(t, tool.Mutating())
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(TriggerMustGatherOutput)
+	assert.Equal(t, "support", output.Namespace)
+	assert.Equal(t, defaultGatherScript, output.GatherScript)
+	assert.Contains(t, output.ArchiveLocation, "/must-gather/must-gather.tar.gz")
+
+	job, err := clientset.BatchV1().Jobs("support").Get(context.Background(), output.JobName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, testMustGatherConfig.Image, job.Spec.Template.Spec.InitContainers[0].Image)
+}
+
+func TestTriggerMustGatherTool_RejectsArbitraryCommands(t *testing.T) {
+	tool := NewTriggerMustGatherTool(clients.NewK8sClientWithClientset(fake.NewClientset()), testMustGatherConfig)
+
+	for _, script := range []string{"/bin/sh", "/usr/bin/gather; rm -rf /", "/usr/bin/gather $(id)"} {
+		_, err := tool.Execute(context.Background(), map[string]interface{}{"gather_script": script})
+		require.Error(t, err, script)
+		assert.True(t, IsInvalidArguments(err), script)
+	}
+}
+
+func TestMustGatherStatus_Phases(t *testing.T) {
+	now := time.Now()
+	job := buildMustGatherJob(testMustGatherConfig, defaultGatherScript, now)
+	pvcConfig := testMustGatherConfig
+	pvcConfig.PVC = "support-archives"
+	pvcJob := buildMustGatherJob(pvcConfig, defaultGatherScript, now)
+
+	withCondition := func(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.Job {
+		job = job.DeepCopy()
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+		return job
+	}
+	podWith := func(gather corev1.ContainerState, archiveReady bool, archive corev1.ContainerState) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: job.Name + "-abcde", Namespace: "support"},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: "gather", State: gather}},
+				ContainerStatuses:     []corev1.ContainerStatus{{Name: mustGatherArchiveContainer, Ready: archiveReady, State: archive}},
+			},
+		}
+	}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	done := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	waiting := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}
+
+	tests := []struct {
+		name  string
+		job   *batchv1.Job
+		pod   *corev1.Pod
+		phase string
+	}{
+		{"no pod yet", job, nil, MustGatherPhasePending},
+		{"gathering", job, podWith(running, false, waiting), MustGatherPhaseGathering},
+		{"archiving", job, podWith(done, false, running), MustGatherPhaseArchiving},
+		{"ready in pod", job, podWith(done, true, running), MustGatherPhaseReady},
+		{"retention over", withCondition(job, batchv1.JobComplete), nil, MustGatherPhaseExpired},
+		{"on PVC", withCondition(pvcJob, batchv1.JobComplete), nil, MustGatherPhaseSucceeded},
+		{"failed", withCondition(job, batchv1.JobFailed), podWith(done, false, waiting), MustGatherPhaseFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := mustGatherStatus(tt.job, tt.pod)
+			assert.Equal(t, tt.phase, status.Phase)
+			assert.NotEmpty(t, status.Message)
+		})
+	}
+
+	ready := mustGatherStatus(job, podWith(done, true, running))
+	assert.Equal(t, "oc cp -n support -c archive "+job.Name+"-abcde:/must-gather/must-gather.tar.gz ./must-gather.tar.gz", ready.CopyCommand)
+}
+
+func TestGetMustGatherStatusTool_LatestRun(t *testing.T) {
+	older := buildMustGatherJob(testMustGatherConfig, defaultGatherScript, time.Now().Add(-2*time.Hour))
+	older.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	newer := buildMustGatherJob(testMustGatherConfig, defaultGatherScript, time.Now())
+	newer.CreationTimestamp = metav1.NewTime(time.Now())
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      newer.Name + "-xyz12",
+		Namespace: "support",
+		Labels:    map[string]string{batchv1.JobNameLabel: newer.Name},
+	}}
+
+	tool := NewGetMustGatherStatusTool(clients.NewK8sClientWithClientset(fake.NewClientset(older, newer, pod)), "support")
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	status := result.(MustGatherStatus)
+	assert.Equal(t, newer.Name, status.JobName)
+	assert.Equal(t, pod.Name, status.Pod)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"job_name": older.Name})
+	require.NoError(t, err)
+	assert.Equal(t, older.Name, result.(MustGatherStatus).JobName)
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"job_name": "must-gather-missing"})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
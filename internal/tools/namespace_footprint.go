@@ -0,0 +1,440 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Orders accepted by get-namespace-footprint's sort_by
+const (
+	FootprintSortCPURequests    = "cpu_requests"
+	FootprintSortMemoryRequests = "memory_requests"
+	FootprintSortStorage        = "storage"
+)
+
+// footprintTTL is short: footprints only move as pods come and go, but a
+// caller comparing teams expects to see a deployment it just scaled
+const footprintTTL = 30 * time.Second
+
+// GetNamespaceFootprintTool sums what each namespace requests and uses,
+// whether or not the cluster defines ResourceQuotas
+type GetNamespaceFootprintTool struct {
+	k8sClient *clients.K8sClient
+	cache     *cache.MemoryCache // Nil disables caching
+}
+
+// NewGetNamespaceFootprintTool creates a new get-namespace-footprint tool.
+// Results are cached in memoryCache for footprintTTL.
+func NewGetNamespaceFootprintTool(k8sClient *clients.K8sClient, memoryCache *cache.MemoryCache) *GetNamespaceFootprintTool {
+	return &GetNamespaceFootprintTool{
+		k8sClient: k8sClient,
+		cache:     memoryCache,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetNamespaceFootprintTool) Name() string {
+	return "get-namespace-footprint"
+}
+
+// NamespaceScoped reports that scoped callers only see their own
+// namespaces, and no cluster allocatable
+func (t *GetNamespaceFootprintTool) NamespaceScoped() bool {
+	return true
+}
+
+// Description returns the tool description for MCP
+func (t *GetNamespaceFootprintTool) Description() string {
+	return `Show what each namespace consumes without relying on ResourceQuotas: the CPU and memory requests and limits of its pods, their current usage from metrics-server when available, the storage its persistent volume claims request, and object counts (pods, PVCs, Deployments, StatefulSets, Services). Completed pods are left out of the sums; terminating pods are counted separately and also left out. Namespaces are sorted by sort_by and cut to top, with cluster totals and the share of cluster allocatable CPU and memory for context. On OpenShift, namespaces are shown by project display name, e.g. "Payments Team (payments-prod)", with their requester, and requester narrows the list to one user's projects.
+
+Use this tool for questions like:
+- "Which teams request the most CPU?"
+- "How much of the cluster does namespace X hold?"
+- "Which namespaces request the most storage?"
+- "How much do the projects alice requested use?"
+- "We have no quotas: who is using the capacity?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetNamespaceFootprintTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"sort_by": map[string]interface{}{
+				"type":        "string",
+				"description": "Order namespaces by CPU requests, memory requests, or PVC storage requests, largest first",
+				"enum":        []string{FootprintSortCPURequests, FootprintSortMemoryRequests, FootprintSortStorage},
+				"default":     FootprintSortCPURequests,
+			},
+			"top": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of namespaces to list; the totals always cover all of them",
+				"default":     20,
+				"minimum":     1,
+				"maximum":     200,
+			},
+			"requester": map[string]interface{}{
+				"type":        "string",
+				"description": "Only list the OpenShift projects this user requested; the totals cover just those (OpenShift only)",
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetNamespaceFootprintInput represents the input parameters
+type GetNamespaceFootprintInput struct {
+	SortBy    string `json:"sort_by"`
+	Top       int    `json:"top"`
+	Requester string `json:"requester"`
+}
+
+// NamespaceFootprint is what one namespace requests, uses and holds
+type NamespaceFootprint struct {
+	Namespace              string  `json:"namespace"`
+	Pods                   int     `json:"pods"` // Counted in the sums: neither completed nor terminating
+	TerminatingPods        int     `json:"terminating_pods,omitempty"`
+	CompletedPods          int     `json:"completed_pods,omitempty"`
+	PodsWithoutRequests    int     `json:"pods_without_requests,omitempty"`
+	PodsWithoutLimits      int     `json:"pods_without_limits,omitempty"` // Not in the limit sums
+	CPURequestMillicores   int64   `json:"cpu_request_millicores"`
+	CPULimitMillicores     int64   `json:"cpu_limit_millicores"`
+	MemoryRequestBytes     int64   `json:"memory_request_bytes"`
+	MemoryLimitBytes       int64   `json:"memory_limit_bytes"`
+	CPUUsageMillicores     int64   `json:"cpu_usage_millicores"`
+	MemoryUsageBytes       int64   `json:"memory_usage_bytes"`
+	StorageRequestBytes    int64   `json:"storage_request_bytes"`
+	PersistentVolumeClaims int     `json:"persistent_volume_claims"`
+	Deployments            int     `json:"deployments"`
+	StatefulSets           int     `json:"statefulsets"`
+	Services               int     `json:"services"`
+	CPURequestPercent      float64 `json:"cpu_request_percent,omitempty"`    // Of cluster allocatable CPU
+	MemoryRequestPercent   float64 `json:"memory_request_percent,omitempty"` // Of cluster allocatable memory
+
+	// Project metadata on OpenShift; Label is "Display Name (namespace)"
+	// when the project has a display name
+	clients.ProjectInfo
+	Label string `json:"label,omitempty"`
+}
+
+// FootprintTotals sums the footprints of all namespaces, listed or not
+type FootprintTotals struct {
+	Namespaces               int     `json:"namespaces"`
+	Pods                     int     `json:"pods"`
+	TerminatingPods          int     `json:"terminating_pods"`
+	CPURequestMillicores     int64   `json:"cpu_request_millicores"`
+	CPULimitMillicores       int64   `json:"cpu_limit_millicores"`
+	MemoryRequestBytes       int64   `json:"memory_request_bytes"`
+	MemoryLimitBytes         int64   `json:"memory_limit_bytes"`
+	CPUUsageMillicores       int64   `json:"cpu_usage_millicores"`
+	MemoryUsageBytes         int64   `json:"memory_usage_bytes"`
+	StorageRequestBytes      int64   `json:"storage_request_bytes"`
+	AllocatableCPUMillicores int64   `json:"allocatable_cpu_millicores,omitempty"` // Zero for namespace-scoped callers
+	AllocatableMemoryBytes   int64   `json:"allocatable_memory_bytes,omitempty"`
+	CPURequestPercent        float64 `json:"cpu_request_percent,omitempty"`
+	MemoryRequestPercent     float64 `json:"memory_request_percent,omitempty"`
+}
+
+// GetNamespaceFootprintOutput represents the tool output
+type GetNamespaceFootprintOutput struct {
+	SortBy         string               `json:"sort_by"`
+	UsageAvailable bool                 `json:"usage_available"` // False when metrics-server could not be read
+	Namespaces     []NamespaceFootprint `json:"namespaces"`
+	Omitted        int                  `json:"omitted,omitempty"` // Namespaces beyond top
+	Totals         FootprintTotals      `json:"totals"`
+	Notes          []string             `json:"notes,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access get-namespace-footprint needs
+func (t *GetNamespaceFootprintTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "pods", Verb: "list"},
+		{Resource: "persistentvolumeclaims", Verb: "list"},
+		{Group: clients.ProjectAPIGroup, Resource: "projects", Verb: "list"},
+	}
+}
+
+// Execute runs the get-namespace-footprint operation
+func (t *GetNamespaceFootprintTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetNamespaceFootprintInput{SortBy: FootprintSortCPURequests, Top: 20}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	switch input.SortBy {
+	case FootprintSortCPURequests, FootprintSortMemoryRequests, FootprintSortStorage:
+	default:
+		return nil, invalidArgs("sort_by must be one of %s, %s, %s", FootprintSortCPURequests, FootprintSortMemoryRequests, FootprintSortStorage)
+	}
+	if input.Top < 1 || input.Top > 200 {
+		return nil, invalidArgs("top must be between 1 and 200")
+	}
+	if input.Requester != "" && !t.k8sClient.DiscoverGroup(clients.ProjectAPIGroup) {
+		return nil, invalidArgs("requester needs OpenShift projects, which this cluster does not serve (%s)", clients.ProjectAPIGroup)
+	}
+
+	if t.cache == nil {
+		return t.footprint(ctx, input)
+	}
+	// The scope is part of the key: a scoped caller must never be served
+	// another tenant's footprint
+	scope, _ := clients.NamespaceScopeFromContext(ctx)
+	key := cache.Key("tool", t.Name(), input.SortBy, strconv.Itoa(input.Top), input.Requester, strings.Join(scope, ","))
+	return cache.GetOrSetTyped(ctx, t.cache, key, footprintTTL, func() (GetNamespaceFootprintOutput, error) {
+		return t.footprint(ctx, input)
+	})
+}
+
+// footprint reads the cluster and builds the output for input
+func (t *GetNamespaceFootprintTool) footprint(ctx context.Context, input GetNamespaceFootprintInput) (GetNamespaceFootprintOutput, error) {
+	output := GetNamespaceFootprintOutput{SortBy: input.SortBy, Namespaces: []NamespaceFootprint{}}
+	clientset := t.k8sClient.Clientset()
+
+	var pods []corev1.Pod
+	var claims []corev1.PersistentVolumeClaim
+	counts := make(map[string]*NamespaceFootprint)
+	count := func(namespace string) *NamespaceFootprint {
+		if counts[namespace] == nil {
+			counts[namespace] = &NamespaceFootprint{Namespace: namespace}
+		}
+		return counts[namespace]
+	}
+	var unavailable []string
+	usage := make(map[string]podUsage)
+	for _, namespace := range clients.NamespacesToList(ctx, "") {
+		podList, err := t.k8sClient.ListPods(ctx, namespace)
+		if err != nil {
+			return output, apiError(err)
+		}
+		pods = append(pods, podList.Items...)
+		claimList, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return output, apiError(fmt.Errorf("failed to list persistent volume claims: %w", err))
+		}
+		claims = append(claims, claimList.Items...)
+
+		// Object counts are context only: a kind that cannot be read is noted, not fatal
+		if deployments, err := t.k8sClient.ListDeployments(ctx, namespace); err != nil {
+			unavailable = append(unavailable, fmt.Sprintf("deployments (%v)", err))
+		} else {
+			for i := range deployments.Items {
+				count(deployments.Items[i].Namespace).Deployments++
+			}
+		}
+		if statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+			unavailable = append(unavailable, fmt.Sprintf("statefulsets (%v)", err))
+		} else {
+			for i := range statefulSets.Items {
+				count(statefulSets.Items[i].Namespace).StatefulSets++
+			}
+		}
+		if services, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{}); err != nil {
+			unavailable = append(unavailable, fmt.Sprintf("services (%v)", err))
+		} else {
+			for i := range services.Items {
+				count(services.Items[i].Namespace).Services++
+			}
+		}
+
+		if usage != nil {
+			namespaceUsage, err := listPodMetrics(ctx, t.k8sClient, namespace)
+			if err != nil {
+				output.Notes = append(output.Notes, fmt.Sprintf("metrics-server is not available, usage is left at zero: %v", err))
+				usage = nil
+				continue
+			}
+			for key, u := range namespaceUsage {
+				usage[key] = u
+			}
+		}
+	}
+	output.UsageAvailable = usage != nil
+	if len(unavailable) > 0 {
+		output.Notes = append(output.Notes, "object counts are missing for "+strings.Join(unavailable, ", "))
+	}
+
+	footprints := namespaceFootprints(pods, claims, usage, counts)
+	projects, err := t.k8sClient.ListProjects(ctx)
+	switch {
+	case err != nil && input.Requester != "":
+		return output, apiError(fmt.Errorf("failed to list projects: %w", err))
+	case err != nil:
+		output.Notes = append(output.Notes, fmt.Sprintf("projects could not be listed, namespaces are shown without display names: %v", err))
+	}
+	footprints = withProjects(footprints, projects, input.Requester)
+
+	var allocatableCPU, allocatableMemory int64
+	if _, scoped := clients.NamespaceScopeFromContext(ctx); scoped {
+		output.Notes = append(output.Notes, "cluster allocatable is not shown to namespace-scoped callers")
+	} else if nodes, err := t.k8sClient.ListNodes(ctx); err != nil {
+		output.Notes = append(output.Notes, fmt.Sprintf("nodes could not be listed, shares of cluster allocatable are omitted: %v", err))
+	} else {
+		allocatableCPU, allocatableMemory = clusterAllocatable(nodes.Items)
+	}
+
+	output.Totals = footprintTotals(footprints, allocatableCPU, allocatableMemory)
+	sortFootprints(footprints, input.SortBy)
+	if len(footprints) > input.Top {
+		output.Omitted = len(footprints) - input.Top
+		footprints = footprints[:input.Top]
+	}
+	output.Namespaces = append(output.Namespaces, footprints...)
+	return output, nil
+}
+
+// namespaceFootprints sums pods and claims per namespace on top of the object
+// counts already gathered (keyed by namespace, may be empty). Completed pods
+// are counted but not summed; terminating pods are counted apart and not
+// summed either, since what they hold is about to be released. usage is keyed
+// by namespace/pod, nil when metrics-server is not available.
+func namespaceFootprints(pods []corev1.Pod, claims []corev1.PersistentVolumeClaim, usage map[string]podUsage, counts map[string]*NamespaceFootprint) []NamespaceFootprint {
+	byNamespace := make(map[string]*NamespaceFootprint, len(counts))
+	for namespace, footprint := range counts {
+		copied := *footprint
+		byNamespace[namespace] = &copied
+	}
+	get := func(namespace string) *NamespaceFootprint {
+		if byNamespace[namespace] == nil {
+			byNamespace[namespace] = &NamespaceFootprint{Namespace: namespace}
+		}
+		return byNamespace[namespace]
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		footprint := get(pod.Namespace)
+		switch {
+		case pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed:
+			footprint.CompletedPods++
+			continue
+		case pod.DeletionTimestamp != nil:
+			footprint.TerminatingPods++
+			continue
+		}
+
+		footprint.Pods++
+		cpuRequest := podResourceRequest(pod, corev1.ResourceCPU)
+		memoryRequest := podResourceRequest(pod, corev1.ResourceMemory)
+		if cpuRequest.IsZero() && memoryRequest.IsZero() {
+			footprint.PodsWithoutRequests++
+		}
+		footprint.CPURequestMillicores += cpuRequest.MilliValue()
+		footprint.MemoryRequestBytes += memoryRequest.Value()
+
+		cpuLimit, cpuLimited := podResourceLimit(pod, corev1.ResourceCPU)
+		memoryLimit, memoryLimited := podResourceLimit(pod, corev1.ResourceMemory)
+		if !cpuLimited || !memoryLimited {
+			footprint.PodsWithoutLimits++
+		}
+		footprint.CPULimitMillicores += cpuLimit.MilliValue()
+		footprint.MemoryLimitBytes += memoryLimit.Value()
+
+		if u, ok := usage[pod.Namespace+"/"+pod.Name]; ok {
+			footprint.CPUUsageMillicores += u.CPUMillicores
+			footprint.MemoryUsageBytes += u.MemoryBytes
+		}
+	}
+
+	for i := range claims {
+		claim := &claims[i]
+		footprint := get(claim.Namespace)
+		footprint.PersistentVolumeClaims++
+		if storage, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			footprint.StorageRequestBytes += storage.Value()
+		}
+	}
+
+	footprints := make([]NamespaceFootprint, 0, len(byNamespace))
+	for _, footprint := range byNamespace {
+		footprints = append(footprints, *footprint)
+	}
+	return footprints
+}
+
+// withProjects adds the Project metadata of each footprint's namespace and,
+// when requester is set, keeps only that user's projects
+func withProjects(footprints []NamespaceFootprint, projects map[string]clients.ProjectInfo, requester string) []NamespaceFootprint {
+	kept := footprints[:0]
+	for _, footprint := range footprints {
+		project := projects[footprint.Namespace]
+		if requester != "" && project.Requester != requester {
+			continue
+		}
+		footprint.ProjectInfo = project
+		if label := project.Label(footprint.Namespace); label != footprint.Namespace {
+			footprint.Label = label
+		}
+		kept = append(kept, footprint)
+	}
+	return kept
+}
+
+// clusterAllocatable sums the allocatable CPU and memory of schedulable nodes
+func clusterAllocatable(nodes []corev1.Node) (cpuMillicores, memoryBytes int64) {
+	for i := range nodes {
+		if nodes[i].Spec.Unschedulable {
+			continue
+		}
+		allocatable := nodes[i].Status.Allocatable
+		cpuMillicores += allocatable.Cpu().MilliValue()
+		memoryBytes += allocatable.Memory().Value()
+	}
+	return cpuMillicores, memoryBytes
+}
+
+// footprintTotals sums all footprints and, when the cluster allocatable is
+// known, sets each footprint's share of it as well as the cluster's
+func footprintTotals(footprints []NamespaceFootprint, allocatableCPU, allocatableMemory int64) FootprintTotals {
+	totals := FootprintTotals{
+		Namespaces:               len(footprints),
+		AllocatableCPUMillicores: allocatableCPU,
+		AllocatableMemoryBytes:   allocatableMemory,
+	}
+	for i := range footprints {
+		f := &footprints[i]
+		totals.Pods += f.Pods
+		totals.TerminatingPods += f.TerminatingPods
+		totals.CPURequestMillicores += f.CPURequestMillicores
+		totals.CPULimitMillicores += f.CPULimitMillicores
+		totals.MemoryRequestBytes += f.MemoryRequestBytes
+		totals.MemoryLimitBytes += f.MemoryLimitBytes
+		totals.CPUUsageMillicores += f.CPUUsageMillicores
+		totals.MemoryUsageBytes += f.MemoryUsageBytes
+		totals.StorageRequestBytes += f.StorageRequestBytes
+		f.CPURequestPercent = percentage(f.CPURequestMillicores, allocatableCPU)
+		f.MemoryRequestPercent = percentage(f.MemoryRequestBytes, allocatableMemory)
+	}
+	totals.CPURequestPercent = percentage(totals.CPURequestMillicores, allocatableCPU)
+	totals.MemoryRequestPercent = percentage(totals.MemoryRequestBytes, allocatableMemory)
+	return totals
+}
+
+// sortFootprints orders footprints by sortBy, largest first, then by name
+func sortFootprints(footprints []NamespaceFootprint, sortBy string) {
+	value := func(f *NamespaceFootprint) int64 {
+		switch sortBy {
+		case FootprintSortMemoryRequests:
+			return f.MemoryRequestBytes
+		case FootprintSortStorage:
+			return f.StorageRequestBytes
+		default:
+			return f.CPURequestMillicores
+		}
+	}
+	sort.SliceStable(footprints, func(i, j int) bool {
+		if a, b := value(&footprints[i]), value(&footprints[j]); a != b {
+			return a > b
+		}
+		return footprints[i].Namespace < footprints[j].Namespace
+	})
+}
@@ -0,0 +1,316 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// newFootprintPod creates a running pod in namespace from newSizedPod
+func newFootprintPod(namespace, name, cpuRequest, cpuLimit, memoryRequest, memoryLimit string) corev1.Pod {
+	pod := newSizedPod(name, "", "", "", cpuRequest, cpuLimit, memoryRequest, memoryLimit)
+	pod.Namespace = namespace
+	return pod
+}
+
+func newFootprintClaim(namespace, name, storage string) corev1.PersistentVolumeClaim {
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(storage)}},
+		},
+	}
+}
+
+func footprintFixture() ([]corev1.Pod, []corev1.PersistentVolumeClaim) {
+	completed := newFootprintPod("shop", "migrate", "2", "", "4Gi", "")
+	completed.Status.Phase = corev1.PodSucceeded
+	terminating := newFootprintPod("shop", "api-old", "1", "1", "1Gi", "1Gi")
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Date(2026, 3, 2, 6, 30, 0, 0, time.UTC)}
+
+	pods := []corev1.Pod{
+		newFootprintPod("shop", "api-1", "500m", "1", "512Mi", "1Gi"),
+		newFootprintPod("shop", "api-2", "500m", "1", "512Mi", "1Gi"),
+		newFootprintPod("shop", "cache", "", "", "", ""),
+		completed,
+		terminating,
+		newFootprintPod("analytics", "spark", "4", "", "16Gi", "16Gi"),
+	}
+	claims := []corev1.PersistentVolumeClaim{
+		newFootprintClaim("shop", "db", "10Gi"),
+		newFootprintClaim("archive", "cold", "500Gi"),
+		newFootprintClaim("archive", "colder", "1Ti"),
+	}
+	return pods, claims
+}
+
+func TestNamespaceFootprints(t *testing.T) {
+	pods, claims := footprintFixture()
+	usage := map[string]podUsage{
+		"shop/api-1":      {CPUMillicores: 120, MemoryBytes: 300 << 20},
+		"shop/api-2":      {CPUMillicores: 80, MemoryBytes: 200 << 20},
+		"shop/api-old":    {CPUMillicores: 900, MemoryBytes: 900 << 20}, // Terminating: not summed
+		"analytics/spark": {CPUMillicores: 3500, MemoryBytes: 12 << 30},
+	}
+	counts := map[string]*NamespaceFootprint{
+		"shop":  {Namespace: "shop", Deployments: 2, Services: 1},
+		"empty": {Namespace: "empty", Services: 1},
+	}
+
+	footprints := namespaceFootprints(pods, claims, usage, counts)
+	sortFootprints(footprints, FootprintSortCPURequests)
+	require.Len(t, footprints, 4)
+	assert.Equal(t, []string{"analytics", "shop", "archive", "empty"}, footprintNamespaces(footprints))
+
+	shop := footprints[1]
+	assert.Equal(t, NamespaceFootprint{
+		Namespace:              "shop",
+		Pods:                   3,
+		TerminatingPods:        1,
+		CompletedPods:          1,
+		PodsWithoutRequests:    1,
+		PodsWithoutLimits:      1,
+		CPURequestMillicores:   1000,
+		CPULimitMillicores:     2000,
+		MemoryRequestBytes:     1 << 30,
+		MemoryLimitBytes:       2 << 30,
+		CPUUsageMillicores:     200,
+		MemoryUsageBytes:       500 << 20,
+		StorageRequestBytes:    10 << 30,
+		PersistentVolumeClaims: 1,
+		Deployments:            2,
+		Services:               1,
+	}, shop)
+
+	// A limit missing on CPU keeps the pod out of the CPU limit sum only
+	assert.Equal(t, int64(0), footprints[0].CPULimitMillicores)
+	assert.Equal(t, int64(16<<30), footprints[0].MemoryLimitBytes)
+	assert.Equal(t, 1, footprints[0].PodsWithoutLimits)
+
+	assert.Zero(t, counts["shop"].Pods, "the counts passed in are not modified")
+}
+
+func footprintNamespaces(footprints []NamespaceFootprint) []string {
+	names := make([]string, 0, len(footprints))
+	for _, f := range footprints {
+		names = append(names, f.Namespace)
+	}
+	return names
+}
+
+func TestSortFootprints(t *testing.T) {
+	pods, claims := footprintFixture()
+	footprints := namespaceFootprints(pods, claims, nil, nil)
+
+	sortFootprints(footprints, FootprintSortMemoryRequests)
+	assert.Equal(t, []string{"analytics", "shop", "archive"}, footprintNamespaces(footprints))
+	sortFootprints(footprints, FootprintSortStorage)
+	assert.Equal(t, []string{"archive", "shop", "analytics"}, footprintNamespaces(footprints))
+}
+
+func TestFootprintTotals(t *testing.T) {
+	pods, claims := footprintFixture()
+	footprints := namespaceFootprints(pods, claims, nil, nil)
+	sortFootprints(footprints, FootprintSortCPURequests)
+
+	totals := footprintTotals(footprints, 10000, 64<<30)
+	assert.Equal(t, 3, totals.Namespaces)
+	assert.Equal(t, 4, totals.Pods)
+	assert.Equal(t, 1, totals.TerminatingPods)
+	assert.Equal(t, int64(5000), totals.CPURequestMillicores)
+	assert.Equal(t, int64(17<<30), totals.MemoryRequestBytes)
+	assert.Equal(t, int64(1534<<30), totals.StorageRequestBytes)
+	assert.InDelta(t, 50.0, totals.CPURequestPercent, 0.01)
+	assert.InDelta(t, 26.6, totals.MemoryRequestPercent, 0.01)
+	assert.InDelta(t, 40.0, footprints[0].CPURequestPercent, 0.01)
+	assert.InDelta(t, 10.0, footprints[1].CPURequestPercent, 0.01)
+
+	// Without the allocatable (namespace-scoped callers) there are no shares
+	unscoped := footprintTotals(footprints, 0, 0)
+	assert.Zero(t, unscoped.CPURequestPercent)
+	assert.Zero(t, footprints[0].CPURequestPercent)
+}
+
+func TestClusterAllocatable(t *testing.T) {
+	cordoned := newCapacityNode("cordoned", "4", "16Gi")
+	cordoned.Spec.Unschedulable = true
+	cpu, memory := clusterAllocatable([]corev1.Node{
+		newCapacityNode("worker-1", "4", "16Gi"),
+		newCapacityNode("worker-2", "4", "16Gi"),
+		cordoned,
+	})
+	assert.Equal(t, int64(8000), cpu)
+	assert.Equal(t, int64(32<<30), memory)
+}
+
+func newFootprintClientset() *fake.Clientset {
+	pods, claims := footprintFixture()
+	large, small := newCapacityNode("worker-1", "8", "32Gi"), newCapacityNode("worker-2", "2", "32Gi")
+	objects := []runtime.Object{
+		&large,
+		&small,
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}},
+	}
+	for i := range pods {
+		objects = append(objects, &pods[i])
+	}
+	for i := range claims {
+		objects = append(objects, &claims[i])
+	}
+	return fake.NewClientset(objects...)
+}
+
+func TestGetNamespaceFootprintTool(t *testing.T) {
+	tool := NewGetNamespaceFootprintTool(clients.NewK8sClientWithClientset(newFootprintClientset()), nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"sort_by": "storage", "top": 2})
+	require.NoError(t, err)
+	output := result.(GetNamespaceFootprintOutput)
+
+	assert.Equal(t, FootprintSortStorage, output.SortBy)
+	assert.Equal(t, []string{"archive", "shop"}, footprintNamespaces(output.Namespaces))
+	assert.Equal(t, 1, output.Omitted)
+	shop := output.Namespaces[1]
+	assert.Equal(t, 1, shop.Deployments)
+	assert.Equal(t, 1, shop.StatefulSets)
+	assert.Equal(t, 1, shop.Services)
+	assert.Equal(t, 3, shop.Pods)
+	assert.Equal(t, 1, shop.TerminatingPods)
+
+	assert.Equal(t, 3, output.Totals.Namespaces)
+	assert.Equal(t, int64(10000), output.Totals.AllocatableCPUMillicores)
+	assert.InDelta(t, 50.0, output.Totals.CPURequestPercent, 0.01)
+
+	// The fake clients serve no PodMetrics
+	assert.False(t, output.UsageAvailable)
+	require.Len(t, output.Notes, 1)
+	assert.Contains(t, output.Notes[0], "metrics-server is not available")
+}
+
+func TestGetNamespaceFootprintTool_NamespaceScoped(t *testing.T) {
+	tool := NewGetNamespaceFootprintTool(clients.NewK8sClientWithClientset(newFootprintClientset()), nil)
+	ctx := clients.WithNamespaceScope(context.Background(), []string{"shop", "archive"})
+
+	result, err := tool.Execute(ctx, map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetNamespaceFootprintOutput)
+
+	assert.Equal(t, []string{"shop", "archive"}, footprintNamespaces(output.Namespaces))
+	assert.Zero(t, output.Totals.AllocatableCPUMillicores)
+	assert.Zero(t, output.Namespaces[0].CPURequestPercent)
+	assert.Contains(t, output.Notes, "cluster allocatable is not shown to namespace-scoped callers")
+}
+
+func TestGetNamespaceFootprintTool_Cached(t *testing.T) {
+	clientset := newFootprintClientset()
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	defer memoryCache.Close()
+	tool := NewGetNamespaceFootprintTool(clients.NewK8sClientWithClientset(clientset), memoryCache)
+
+	podLists := func() int {
+		count := 0
+		for _, action := range clientset.Actions() {
+			if action.GetVerb() == "list" && action.GetResource().Resource == "pods" {
+				count++
+			}
+		}
+		return count
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := tool.Execute(context.Background(), map[string]interface{}{"top": 5})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, podLists(), "the same sort and top are served from the cache")
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"top": 5, "sort_by": "storage"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, podLists(), "another sort is another entry")
+
+	scoped := clients.WithNamespaceScope(context.Background(), []string{"shop"})
+	result, err := tool.Execute(scoped, map[string]interface{}{"top": 5})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shop"}, footprintNamespaces(result.(GetNamespaceFootprintOutput).Namespaces),
+		"a scoped caller is not served the unscoped entry")
+}
+
+func newFootprintProject(namespace, displayName, requester string) *unstructured.Unstructured {
+	return newUnstructured(schema.GroupVersionKind{Group: clients.ProjectAPIGroup, Version: "v1", Kind: "Project"}, "", namespace, map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": map[string]interface{}{
+			clients.ProjectDisplayNameAnnotation: displayName,
+			clients.ProjectRequesterAnnotation:   requester,
+		}},
+	})
+}
+
+func TestWithProjects(t *testing.T) {
+	pods, claims := footprintFixture()
+	projects := map[string]clients.ProjectInfo{
+		"shop":      {DisplayName: "Web Shop", Requester: "alice"},
+		"analytics": {Requester: "bob"},
+	}
+
+	footprints := withProjects(namespaceFootprints(pods, claims, nil, nil), projects, "")
+	sortFootprints(footprints, FootprintSortCPURequests)
+	require.Len(t, footprints, 3)
+	assert.Equal(t, "bob", footprints[0].Requester)
+	assert.Empty(t, footprints[0].Label, "no display name")
+	assert.Equal(t, "Web Shop (shop)", footprints[1].Label)
+	assert.Equal(t, clients.ProjectInfo{}, footprints[2].ProjectInfo, "archive is a plain namespace")
+
+	alice := withProjects(namespaceFootprints(pods, claims, nil, nil), projects, "alice")
+	assert.Equal(t, []string{"shop"}, footprintNamespaces(alice))
+}
+
+func TestGetNamespaceFootprintTool_Requester(t *testing.T) {
+	clientset := newFootprintClientset()
+	clientset.Resources = []*metav1.APIResourceList{{GroupVersion: clients.ProjectAPIGroup + "/v1"}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{{Group: clients.ProjectAPIGroup, Version: "v1", Resource: "projects"}: "ProjectList"},
+		newFootprintProject("shop", "Web Shop", "alice"),
+		newFootprintProject("analytics", "Analytics", "bob"),
+		newFootprintProject("archive", "", "alice"),
+	)
+	tool := NewGetNamespaceFootprintTool(clients.NewK8sClientWithClients(clientset, dynamicClient, nil), nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"requester": "alice"})
+	require.NoError(t, err)
+	output := result.(GetNamespaceFootprintOutput)
+	assert.Equal(t, []string{"shop", "archive"}, footprintNamespaces(output.Namespaces))
+	assert.Equal(t, "Web Shop (shop)", output.Namespaces[0].Label)
+	assert.Equal(t, 2, output.Totals.Namespaces, "the totals cover the requester's projects")
+	assert.Equal(t, int64(1000), output.Totals.CPURequestMillicores)
+
+	// Vanilla clusters have no requesters to filter by
+	vanilla := NewGetNamespaceFootprintTool(clients.NewK8sClientWithClientset(newFootprintClientset()), nil)
+	_, err = vanilla.Execute(context.Background(), map[string]interface{}{"requester": "alice"})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
+
+func TestGetNamespaceFootprintTool_InvalidArgs(t *testing.T) {
+	tool := NewGetNamespaceFootprintTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	for _, args := range []map[string]interface{}{{"sort_by": "pods"}, {"top": 0}, {"top": 201}} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err))
+	}
+}
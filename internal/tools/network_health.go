@@ -0,0 +1,458 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+const (
+	// sandboxFailureReason is the kubelet event reason for CNI/sandbox setup failures
+	sandboxFailureReason = "FailedCreatePodSandBox"
+	// stuckCreatingAfter is how long a pod may sit in ContainerCreating before it counts as stuck
+	stuckCreatingAfter = 2 * time.Minute
+	// stuckPodsPerNode bounds the pods listed in a node finding
+	stuckPodsPerNode = 5
+	// unknownNode groups findings whose node could not be determined
+	unknownNode = "(unknown)"
+)
+
+// Network finding severities reported by get-network-health
+const (
+	NetworkSeverityCritical = "critical"
+	NetworkSeverityWarning  = "warning"
+)
+
+// cniDaemonSet identifies the node agent DaemonSet of a network plugin
+type cniDaemonSet struct {
+	Namespace string
+	Name      string
+}
+
+// cniDaemonSets maps the cluster network type to its per-node DaemonSet
+var cniDaemonSets = map[string]cniDaemonSet{
+	"OVNKubernetes": {Namespace: "openshift-ovn-kubernetes", Name: "ovnkube-node"},
+	"OpenShiftSDN":  {Namespace: "openshift-sdn", Name: "sdn"},
+}
+
+// GetNetworkHealthTool reports cluster network (OVN-Kubernetes / OpenShift SDN) health per node
+type GetNetworkHealthTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewGetNetworkHealthTool creates a new get-network-health tool
+func NewGetNetworkHealthTool(k8sClient *clients.K8sClient) *GetNetworkHealthTool {
+	return &GetNetworkHealthTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetNetworkHealthTool) Name() string {
+	return "get-network-health"
+}
+
+// Description returns the tool description for MCP
+func (t *GetNetworkHealthTool) Description() string {
+	return `Check cluster networking (OVN-Kubernetes or OpenShift SDN): the network ClusterOperator, the CNI pod on every node, nodes reporting NetworkNotReady, and pods stuck in ContainerCreating with "failed to create pod sandbox" errors. Findings are grouped per node, so a node with a broken CNI pod and many sandbox failures shows up as one finding.
+
+Use this tool for questions like:
+- "Why are pods stuck in ContainerCreating?"
+- "Is the cluster network healthy?"
+- "Which nodes have networking problems?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetNetworkHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Count pod sandbox failures from the last N minutes",
+				"default":     60,
+				"minimum":     1,
+				"maximum":     1440,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetNetworkHealthInput represents the input parameters
+type GetNetworkHealthInput struct {
+	WindowMinutes int `json:"window_minutes"`
+}
+
+// CNIDaemonSetStatus summarizes the network plugin's node DaemonSet
+type CNIDaemonSetStatus struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Desired   int32  `json:"desired"`
+	Ready     int32  `json:"ready"`
+	Found     bool   `json:"found"`
+}
+
+// NodeNetworkFinding groups the network problems seen on one node
+type NodeNetworkFinding struct {
+	Node            string   `json:"node"`
+	Severity        string   `json:"severity"`
+	Summary         string   `json:"summary"`
+	CNIPod          string   `json:"cni_pod,omitempty"`
+	CNIPodReady     bool     `json:"cni_pod_ready"`
+	CNIPodReason    string   `json:"cni_pod_reason,omitempty"`
+	NetworkNotReady bool     `json:"network_not_ready"`
+	NetworkMessage  string   `json:"network_message,omitempty"`
+	NotReadySince   string   `json:"not_ready_since,omitempty"`
+	SandboxFailures int      `json:"sandbox_failures"`
+	StuckPods       []string `json:"stuck_pods,omitempty"` // namespace/name, capped
+	StuckPodCount   int      `json:"stuck_pod_count"`
+	SampleError     string   `json:"sample_error,omitempty"`
+}
+
+// GetNetworkHealthOutput represents the tool output
+type GetNetworkHealthOutput struct {
+	Status             string               `json:"status"`
+	NetworkType        string               `json:"network_type"`
+	OperatorConditions []OperatorCondition  `json:"operator_conditions"`
+	DaemonSet          *CNIDaemonSetStatus  `json:"daemon_set,omitempty"`
+	WindowMinutes      int                  `json:"window_minutes"`
+	TotalNodes         int                  `json:"total_nodes"`
+	HealthyNodes       int                  `json:"healthy_nodes"`
+	Nodes              []NodeNetworkFinding `json:"nodes"`    // Network problems per node
+	Findings           []findings.Finding   `json:"findings"` // The same problems in the common findings shape
+}
+
+// RequiredPermissions declares the Kubernetes API access get-network-health needs
+func (t *GetNetworkHealthTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "config.openshift.io", Resource: "clusteroperators", Verb: "get"},
+		{Group: "config.openshift.io", Resource: "networks", Verb: "get"},
+		{Group: "apps", Resource: "daemonsets", Verb: "get"},
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Resource: "events", Verb: "list"},
+	}
+}
+
+// Execute runs the get-network-health operation
+func (t *GetNetworkHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetNetworkHealthInput{WindowMinutes: 60}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.WindowMinutes < 1 || input.WindowMinutes > 1440 {
+		return nil, invalidArgs("window_minutes must be between 1 and 1440")
+	}
+
+	output := GetNetworkHealthOutput{WindowMinutes: input.WindowMinutes}
+
+	clusterOperator, err := t.k8sClient.GetResource(ctx, "config.openshift.io/v1", "ClusterOperator", "", "network")
+	if err != nil {
+		return nil, apiError(err)
+	}
+	output.OperatorConditions = operatorConditions(clusterOperator)
+
+	networkConfig, err := t.k8sClient.GetResource(ctx, "config.openshift.io/v1", "Network", "", "cluster")
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if networkConfig != nil {
+		output.NetworkType, _, _ = unstructured.NestedString(networkConfig.Object, "status", "networkType")
+		if output.NetworkType == "" {
+			output.NetworkType, _, _ = unstructured.NestedString(networkConfig.Object, "spec", "networkType")
+		}
+	}
+
+	clientset := t.k8sClient.Clientset()
+
+	var cniPods []corev1.Pod
+	if target, ok := cniDaemonSets[output.NetworkType]; ok {
+		output.DaemonSet = &CNIDaemonSetStatus{Namespace: target.Namespace, Name: target.Name}
+		daemonSet, err := clientset.AppsV1().DaemonSets(target.Namespace).Get(ctx, target.Name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return nil, apiError(fmt.Errorf("failed to get daemonset %s/%s: %w", target.Namespace, target.Name, err))
+		default:
+			output.DaemonSet.Found = true
+			output.DaemonSet.Desired = daemonSet.Status.DesiredNumberScheduled
+			output.DaemonSet.Ready = daemonSet.Status.NumberReady
+			cniPods, err = daemonSetPods(ctx, t.k8sClient, daemonSet)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	pendingPods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("status.phase", string(corev1.PodPending)).String(),
+	})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list pending pods: %w", err))
+	}
+
+	events, err := clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("reason", sandboxFailureReason).String(),
+	})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list pod sandbox events: %w", err))
+	}
+
+	window := time.Duration(input.WindowMinutes) * time.Minute
+	output.Nodes = networkFindings(nodes.Items, cniPods, pendingPods.Items, events.Items, time.Now(), window)
+	output.TotalNodes = len(nodes.Items)
+	output.HealthyNodes = output.TotalNodes
+	for _, finding := range output.Nodes {
+		if finding.Node != unknownNode {
+			output.HealthyNodes--
+		}
+	}
+	output.Status = networkStatus(output.OperatorConditions, output.Nodes)
+	output.Findings = nodeNetworkFindings(output.Nodes)
+	return output, nil
+}
+
+// daemonSetPods lists the pods selected by a DaemonSet
+func daemonSetPods(ctx context.Context, k8sClient *clients.K8sClient, daemonSet *appsv1.DaemonSet) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(daemonSet.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on daemonset %s/%s: %w", daemonSet.Namespace, daemonSet.Name, err)
+	}
+	pods, err := k8sClient.Clientset().CoreV1().Pods(daemonSet.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list pods of daemonset %s/%s: %w", daemonSet.Namespace, daemonSet.Name, err))
+	}
+	return pods.Items, nil
+}
+
+// networkFindings groups CNI pod, node condition, and sandbox failure signals per node.
+// Nodes without any signal are omitted; findings are ordered critical first, then by
+// sandbox failures.
+func networkFindings(nodes []corev1.Node, cniPods, pendingPods []corev1.Pod, events []corev1.Event, now time.Time, window time.Duration) []NodeNetworkFinding {
+	findings := make(map[string]*NodeNetworkFinding)
+	finding := func(node string) *NodeNetworkFinding {
+		if node == "" {
+			node = unknownNode
+		}
+		if f, ok := findings[node]; ok {
+			return f
+		}
+		f := &NodeNetworkFinding{Node: node, CNIPodReady: true}
+		findings[node] = f
+		return f
+	}
+
+	// A node whose CNI pod is missing or not ready cannot network new pods
+	cniByNode := make(map[string]*corev1.Pod, len(cniPods))
+	for i := range cniPods {
+		cniByNode[cniPods[i].Spec.NodeName] = &cniPods[i]
+	}
+	for i := range nodes {
+		node := &nodes[i]
+		pod, ok := cniByNode[node.Name]
+		if len(cniPods) > 0 && !ok {
+			f := finding(node.Name)
+			f.CNIPodReady = false
+			f.CNIPodReason = "no CNI pod scheduled on this node"
+		} else if ok && !podReady(pod) {
+			f := finding(node.Name)
+			f.CNIPod = pod.Name
+			f.CNIPodReady = false
+			f.CNIPodReason = podNotReadyReason(pod)
+		}
+
+		if notReady, message, since := nodeNetworkNotReady(node); notReady {
+			f := finding(node.Name)
+			f.NetworkNotReady = true
+			f.NetworkMessage = message
+			f.NotReadySince = since
+		}
+	}
+
+	podNodes := make(map[string]string, len(pendingPods))
+	for i := range pendingPods {
+		pod := &pendingPods[i]
+		podNodes[pod.Namespace+"/"+pod.Name] = pod.Spec.NodeName
+		if pod.Status.Phase != corev1.PodPending || pod.Spec.NodeName == "" || now.Sub(pod.CreationTimestamp.Time) < stuckCreatingAfter || !containerCreating(pod) {
+			continue
+		}
+		f := finding(pod.Spec.NodeName)
+		f.StuckPodCount++
+		if len(f.StuckPods) < stuckPodsPerNode {
+			f.StuckPods = append(f.StuckPods, pod.Namespace+"/"+pod.Name)
+		}
+	}
+
+	windowStart := now.Add(-window)
+	latestError := make(map[string]time.Time)
+	for i := range events {
+		event := &events[i]
+		if event.Reason != sandboxFailureReason {
+			continue
+		}
+		count := eventOccurrences(event, windowStart, now)
+		if count == 0 {
+			continue
+		}
+		node := podNodes[event.InvolvedObject.Namespace+"/"+event.InvolvedObject.Name]
+		if node == "" {
+			node = event.Source.Host
+		}
+		f := finding(node)
+		f.SandboxFailures += count
+		if seen := eventLastSeen(event); seen.After(latestError[f.Node]) {
+			latestError[f.Node] = seen
+			f.SampleError = event.Message
+		}
+	}
+
+	result := make([]NodeNetworkFinding, 0, len(findings))
+	for _, f := range findings {
+		f.Severity = NetworkSeverityWarning
+		if !f.CNIPodReady || f.NetworkNotReady {
+			f.Severity = NetworkSeverityCritical
+		}
+		f.Summary = networkFindingSummary(f)
+		result = append(result, *f)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Severity != result[j].Severity {
+			return result[i].Severity == NetworkSeverityCritical
+		}
+		if result[i].SandboxFailures != result[j].SandboxFailures {
+			return result[i].SandboxFailures > result[j].SandboxFailures
+		}
+		return result[i].Node < result[j].Node
+	})
+	return result
+}
+
+// nodeNetworkFindings converts the node findings to findings. A node whose
+// CNI pod or network is down is critical; sandbox failures and stuck pods
+// alone are warnings.
+func nodeNetworkFindings(nodes []NodeNetworkFinding) []findings.Finding {
+	result := make([]findings.Finding, 0, len(nodes))
+	for _, node := range nodes {
+		finding := findings.Finding{
+			Severity: findings.SeverityWarning,
+			Category: findings.CategoryNetwork,
+			Resource: resourceRef("node", "", node.Node),
+			Title:    "Pod sandbox creation is failing",
+			Detail:   node.Summary,
+			SuggestedAction: "Read the sample sandbox error; CNI plugin errors usually clear once the CNI pod on the node " +
+				"is restarted, IP exhaustion needs a larger pod network",
+			Source: "get-network-health",
+		}
+		if node.Severity == NetworkSeverityCritical {
+			finding.Severity = findings.SeverityCritical
+			finding.Title = "Node network is down"
+			finding.SuggestedAction = "Check the CNI pod on the node and the network ClusterOperator; new pods on the node cannot start until it recovers"
+		}
+		if !node.CNIPodReady {
+			finding.Evidence = append(finding.Evidence, findings.Evidence{Type: findings.EvidenceCondition, Ref: "Ready", Message: "CNI pod: " + node.CNIPodReason})
+		}
+		if node.NetworkNotReady {
+			finding.Evidence = append(finding.Evidence, findings.Evidence{Type: findings.EvidenceCondition, Ref: "NetworkReady", Message: node.NetworkMessage})
+		}
+		if node.SandboxFailures > 0 {
+			finding.Evidence = append(finding.Evidence, findings.Evidence{Type: findings.EvidenceEvent, Ref: sandboxFailureReason, Message: node.SampleError})
+		}
+		if since, err := time.Parse(time.RFC3339, node.NotReadySince); err == nil {
+			finding.FirstSeen = since
+		}
+		result = append(result, finding)
+	}
+	findings.Sort(result)
+	return result
+}
+
+// networkFindingSummary renders a finding as one sentence, e.g.
+// "node worker-1 has a broken CNI pod and 14 sandbox failures"
+func networkFindingSummary(f *NodeNetworkFinding) string {
+	var parts []string
+	if !f.CNIPodReady {
+		parts = append(parts, "a broken CNI pod")
+	}
+	if f.NetworkNotReady {
+		parts = append(parts, "NetworkNotReady")
+	}
+	if f.SandboxFailures > 0 {
+		parts = append(parts, fmt.Sprintf("%d sandbox failures", f.SandboxFailures))
+	}
+	if f.StuckPodCount > 0 {
+		parts = append(parts, fmt.Sprintf("%d pods stuck in ContainerCreating", f.StuckPodCount))
+	}
+
+	switch len(parts) {
+	case 0:
+		return fmt.Sprintf("node %s has no network problems", f.Node)
+	case 1:
+		return fmt.Sprintf("node %s has %s", f.Node, parts[0])
+	default:
+		return fmt.Sprintf("node %s has %s and %s", f.Node, strings.Join(parts[:len(parts)-1], ", "), parts[len(parts)-1])
+	}
+}
+
+// networkStatus derives the overall status from the operator conditions and findings
+func networkStatus(conditions []OperatorCondition, findings []NodeNetworkFinding) string {
+	if available := findCondition(conditions, "Available"); available == nil || available.Status != "True" {
+		return "unavailable"
+	}
+	if conditionTrue(conditions, "Degraded") || len(findings) > 0 {
+		return "degraded"
+	}
+	return "healthy"
+}
+
+// nodeNetworkNotReady reports whether the kubelet says the node's network is not ready
+func nodeNetworkNotReady(node *corev1.Node) (bool, string, string) {
+	for _, condition := range node.Status.Conditions {
+		switch {
+		case condition.Type == corev1.NodeNetworkUnavailable && condition.Status == corev1.ConditionTrue,
+			condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue &&
+				(strings.Contains(condition.Message, "NetworkReady=false") || strings.Contains(condition.Message, "NetworkPluginNotReady")):
+			return true, condition.Message, condition.LastTransitionTime.UTC().Format(time.RFC3339)
+		}
+	}
+	return false, "", ""
+}
+
+// podReady reports whether the pod's Ready condition is True
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// containerCreating reports whether any container is waiting in ContainerCreating
+func containerCreating(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "ContainerCreating" {
+			return true
+		}
+	}
+	// The kubelet reports no container statuses until the sandbox exists
+	return len(pod.Status.ContainerStatuses) == 0 && len(pod.Spec.Containers) > 0
+}
@@ -0,0 +1,234 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+var networkConfigGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "Network"}
+
+func newNetworkTestClient(typed []runtime.Object, dynamic ...runtime.Object) *clients.K8sClient {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(clusterOperatorGVK, meta.RESTScopeRoot)
+	mapper.Add(networkConfigGVK, meta.RESTScopeRoot)
+	return clients.NewK8sClientWithClients(fake.NewClientset(typed...), dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), dynamic...), mapper)
+}
+
+func newNetworkOperator(degraded string) runtime.Object {
+	return newUnstructured(clusterOperatorGVK, "", "network", map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+				map[string]interface{}{"type": "Degraded", "status": degraded},
+			},
+		},
+	})
+}
+
+func newNetworkConfig(networkType string) runtime.Object {
+	return newUnstructured(networkConfigGVK, "", "cluster", map[string]interface{}{
+		"status": map[string]interface{}{"networkType": networkType},
+	})
+}
+
+func newNetworkNode(name string, networkReady bool) *corev1.Node {
+	ready := corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue}
+	if !networkReady {
+		ready = corev1.NodeCondition{
+			Type:    corev1.NodeReady,
+			Status:  corev1.ConditionFalse,
+			Message: "container runtime network not ready: NetworkReady=false reason:NetworkPluginNotReady message:Network plugin returns error: no CNI configuration file",
+		}
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{ready}},
+	}
+}
+
+func newOVNPod(node string, ready bool) *corev1.Pod {
+	status := corev1.ConditionTrue
+	var state corev1.ContainerState
+	if !ready {
+		status = corev1.ConditionFalse
+		state.Waiting = &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovnkube-node-" + node, Namespace: "openshift-ovn-kubernetes", Labels: map[string]string{"app": "ovnkube-node"}},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "ovnkube-controller", State: state}},
+		},
+	}
+}
+
+func newOVNDaemonSet() *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovnkube-node", Namespace: "openshift-ovn-kubernetes"},
+		Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ovnkube-node"}}},
+		Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 2},
+	}
+}
+
+func newCreatingPod(namespace, name, node string, age time.Duration) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+		Spec:       corev1.PodSpec{NodeName: node, Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "app",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+			}},
+		},
+	}
+}
+
+func newSandboxEvent(namespace, pod string, count int32, last time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: pod + ".sandbox", Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: pod},
+		Reason:         sandboxFailureReason,
+		Type:           corev1.EventTypeWarning,
+		Message:        "Failed to create pod sandbox: rpc error: code = Unknown desc = failed to create pod network sandbox: error adding container to network \"ovn-kubernetes\": CNI request failed",
+		Count:          count,
+		FirstTimestamp: metav1.NewTime(last),
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func TestGetNetworkHealthTool_GroupsFindingsPerNode(t *testing.T) {
+	now := time.Now()
+	typed := []runtime.Object{
+		newOVNDaemonSet(),
+		newNetworkNode("worker-0", true),
+		newNetworkNode("worker-1", true),
+		newNetworkNode("worker-2", false),
+		newOVNPod("worker-0", true),
+		newOVNPod("worker-1", false),
+		newOVNPod("worker-2", true),
+		newCreatingPod("web", "frontend-a", "worker-1", 10*time.Minute),
+		newCreatingPod("web", "frontend-b", "worker-1", 10*time.Minute),
+		newCreatingPod("web", "frontend-new", "worker-0", 30*time.Second),
+		newSandboxEvent("web", "frontend-a", 8, now.Add(-5*time.Minute)),
+		newSandboxEvent("web", "frontend-b", 6, now.Add(-5*time.Minute)),
+		newSandboxEvent("web", "old-pod", 20, now.Add(-3*time.Hour)),
+	}
+	tool := NewGetNetworkHealthTool(newNetworkTestClient(typed, newNetworkOperator("False"), newNetworkConfig("OVNKubernetes")))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetNetworkHealthOutput)
+
+	assert.Equal(t, "degraded", output.Status)
+	assert.Equal(t, "OVNKubernetes", output.NetworkType)
+	require.NotNil(t, output.DaemonSet)
+	assert.True(t, output.DaemonSet.Found)
+	assert.Equal(t, int32(2), output.DaemonSet.Ready)
+	assert.Equal(t, 3, output.TotalNodes)
+	assert.Equal(t, 1, output.HealthyNodes)
+
+	require.Len(t, output.Nodes, 2)
+	worker1 := output.Nodes[0]
+	assert.Equal(t, "worker-1", worker1.Node)
+	assert.Equal(t, NetworkSeverityCritical, worker1.Severity)
+	assert.False(t, worker1.CNIPodReady)
+	assert.Equal(t, "CrashLoopBackOff", worker1.CNIPodReason)
+	assert.Equal(t, 14, worker1.SandboxFailures)
+	assert.Equal(t, 2, worker1.StuckPodCount)
+	assert.Contains(t, worker1.SampleError, "failed to create pod network sandbox")
+	assert.Equal(t, "node worker-1 has a broken CNI pod, 14 sandbox failures and 2 pods stuck in ContainerCreating", worker1.Summary)
+
+	worker2 := output.Nodes[1]
+	assert.Equal(t, "worker-2", worker2.Node)
+	assert.True(t, worker2.NetworkNotReady)
+	assert.Equal(t, "node worker-2 has NetworkNotReady", worker2.Summary)
+}
+
+func TestGetNetworkHealthTool_Healthy(t *testing.T) {
+	typed := []runtime.Object{
+		newOVNDaemonSet(),
+		newNetworkNode("worker-0", true),
+		newOVNPod("worker-0", true),
+	}
+	tool := NewGetNetworkHealthTool(newNetworkTestClient(typed, newNetworkOperator("False"), newNetworkConfig("OVNKubernetes")))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"window_minutes": 15})
+	require.NoError(t, err)
+	output := result.(GetNetworkHealthOutput)
+
+	assert.Equal(t, "healthy", output.Status)
+	assert.Empty(t, output.Nodes)
+	assert.Equal(t, 1, output.HealthyNodes)
+}
+
+func TestGetNetworkHealthTool_MissingCNIPod(t *testing.T) {
+	typed := []runtime.Object{
+		newOVNDaemonSet(),
+		newNetworkNode("worker-0", true),
+		newNetworkNode("worker-1", true),
+		newOVNPod("worker-0", true),
+	}
+	tool := NewGetNetworkHealthTool(newNetworkTestClient(typed, newNetworkOperator("True"), newNetworkConfig("OVNKubernetes")))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetNetworkHealthOutput)
+
+	require.Len(t, output.Nodes, 1)
+	assert.Equal(t, "worker-1", output.Nodes[0].Node)
+	assert.Equal(t, "no CNI pod scheduled on this node", output.Nodes[0].CNIPodReason)
+}
+
+func TestNodeNetworkFindings(t *testing.T) {
+	converted := nodeNetworkFindings([]NodeNetworkFinding{
+		{Node: "worker-2", Severity: NetworkSeverityWarning, Summary: "node worker-2 has 3 sandbox failures", CNIPodReady: true,
+			SandboxFailures: 3, SampleError: "failed to create pod network sandbox: timed out"},
+		{Node: "worker-1", Severity: NetworkSeverityCritical, Summary: "node worker-1 has a broken CNI pod and NetworkNotReady",
+			CNIPodReason: "CrashLoopBackOff", NetworkNotReady: true, NetworkMessage: "NetworkReady=false", NotReadySince: "2026-10-15T07:00:00Z"},
+	})
+	require.Len(t, converted, 2)
+
+	down := converted[0]
+	assert.Equal(t, findings.SeverityCritical, down.Severity)
+	assert.Equal(t, findings.CategoryNetwork, down.Category)
+	assert.Equal(t, "node/worker-1", down.Resource.String())
+	assert.Equal(t, "Node network is down", down.Title)
+	assert.Equal(t, "node worker-1 has a broken CNI pod and NetworkNotReady", down.Detail)
+	assert.Equal(t, []findings.Evidence{
+		{Type: findings.EvidenceCondition, Ref: "Ready", Message: "CNI pod: CrashLoopBackOff"},
+		{Type: findings.EvidenceCondition, Ref: "NetworkReady", Message: "NetworkReady=false"},
+	}, down.Evidence)
+	assert.Equal(t, time.Date(2026, 10, 15, 7, 0, 0, 0, time.UTC), down.FirstSeen)
+
+	failing := converted[1]
+	assert.Equal(t, findings.SeverityWarning, failing.Severity)
+	assert.Equal(t, "Pod sandbox creation is failing", failing.Title)
+	assert.Equal(t, []findings.Evidence{{Type: findings.EvidenceEvent, Ref: sandboxFailureReason, Message: "failed to create pod network sandbox: timed out"}}, failing.Evidence)
+	assert.True(t, failing.FirstSeen.IsZero())
+}
+
+func TestGetNetworkHealthTool_InvalidWindow(t *testing.T) {
+	tool := NewGetNetworkHealthTool(newNetworkTestClient(nil))
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"window_minutes": 0})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
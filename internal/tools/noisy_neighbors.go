@@ -0,0 +1,460 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// latencySensitiveAnnotation marks pods that detect-noisy-neighbors reports as affected
+// when they share a node with a noisy pod
+const latencySensitiveAnnotation = "cluster-health-mcp.openshift-aiops.io/latency-sensitive"
+
+// noisyMinNodeShare keeps pods using a negligible share of the node from being flagged,
+// however far above their (tiny) requests they are
+const noisyMinNodeShare = 0.05
+
+// DetectNoisyNeighborsTool finds pods that use far more than they requested and the
+// co-located pods likely to suffer for it
+type DetectNoisyNeighborsTool struct {
+	k8sClient          *clients.K8sClient
+	criticalNamespaces []string
+}
+
+// NewDetectNoisyNeighborsTool creates a new detect-noisy-neighbors tool. Pods in
+// criticalNamespaces are reported as affected when they share a node with a noisy pod.
+func NewDetectNoisyNeighborsTool(k8sClient *clients.K8sClient, criticalNamespaces []string) *DetectNoisyNeighborsTool {
+	return &DetectNoisyNeighborsTool{
+		k8sClient:          k8sClient,
+		criticalNamespaces: criticalNamespaces,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *DetectNoisyNeighborsTool) Name() string {
+	return "detect-noisy-neighbors"
+}
+
+// Description returns the tool description for MCP
+func (t *DetectNoisyNeighborsTool) Description() string {
+	return fmt.Sprintf(`Find noisy neighbors: pods using far more CPU or memory than they requested, or pods without a CPU limit taking a large share of their node. For each node, compares node usage from metrics-server with the sum of its pods' usage (the difference is system daemons and the container runtime), lists the top offenders, and lists the co-located pods likely to suffer: those annotated %s=true or running in a critical namespace. Nodes are returned worst first.
+
+Use this tool for questions like:
+- "Why is my pod slow only on some nodes?"
+- "Is something hogging node worker-3?"
+- "Which pods are using far more than they request?"`, latencySensitiveAnnotation)
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *DetectNoisyNeighborsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"node": map[string]interface{}{
+				"type":        "string",
+				"description": "Only analyze this node (always returned, even without noisy pods). Leave empty for all nodes.",
+				"default":     "",
+			},
+			"overuse_factor": map[string]interface{}{
+				"type":        "number",
+				"description": "Flag pods using more than this multiple of their CPU or memory request",
+				"default":     2.0,
+				"minimum":     1,
+			},
+			"node_share_percent": map[string]interface{}{
+				"type":        "number",
+				"description": "Flag pods without a CPU limit using more than this percentage of their node's allocatable CPU",
+				"default":     50,
+				"minimum":     1,
+				"maximum":     100,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of nodes to return",
+				"default":     10,
+				"minimum":     1,
+				"maximum":     100,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// DetectNoisyNeighborsInput represents the input parameters
+type DetectNoisyNeighborsInput struct {
+	Node             string  `json:"node"`
+	OveruseFactor    float64 `json:"overuse_factor"`
+	NodeSharePercent float64 `json:"node_share_percent"`
+	Limit            int     `json:"limit"`
+}
+
+// NoisyPod is a pod using far more than it requested
+type NoisyPod struct {
+	Namespace            string   `json:"namespace"`
+	Name                 string   `json:"name"`
+	CPUMillicores        int64    `json:"cpu_millicores"`
+	CPURequestMillicores int64    `json:"cpu_request_millicores"`
+	CPUNodePercent       float64  `json:"cpu_node_percent"`
+	MemoryBytes          int64    `json:"memory_bytes"`
+	MemoryRequestBytes   int64    `json:"memory_request_bytes"`
+	MemoryNodePercent    float64  `json:"memory_node_percent"`
+	NoCPULimit           bool     `json:"no_cpu_limit"`
+	Reasons              []string `json:"reasons"`
+}
+
+// AffectedPod is a sensitive pod sharing a node with a noisy pod
+type AffectedPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Reason    string `json:"reason"`
+}
+
+// NodeNoisyNeighbors is the noisy neighbor analysis of one node
+type NodeNoisyNeighbors struct {
+	Node                     string        `json:"node"`
+	CPUAllocatableMillicores int64         `json:"cpu_allocatable_millicores"`
+	CPUUsageMillicores       int64         `json:"cpu_usage_millicores"`
+	CPUPercent               float64       `json:"cpu_percent"`
+	MemoryAllocatableBytes   int64         `json:"memory_allocatable_bytes"`
+	MemoryUsageBytes         int64         `json:"memory_usage_bytes"`
+	MemoryPercent            float64       `json:"memory_percent"`
+	PodCPUMillicores         int64         `json:"pod_cpu_millicores"`         // Sum of per-pod usage
+	PodMemoryBytes           int64         `json:"pod_memory_bytes"`           // Sum of per-pod usage
+	UnaccountedCPUMillicores int64         `json:"unaccounted_cpu_millicores"` // Node usage outside pods (kubelet, runtime, system daemons)
+	UnaccountedMemoryBytes   int64         `json:"unaccounted_memory_bytes"`
+	Pods                     int           `json:"pods"`
+	NoisyPods                []NoisyPod    `json:"noisy_pods"`
+	AffectedPods             []AffectedPod `json:"affected_pods"`
+	Summary                  string        `json:"summary"`
+}
+
+// DetectNoisyNeighborsOutput represents the tool output
+type DetectNoisyNeighborsOutput struct {
+	NodesAnalyzed int                  `json:"nodes_analyzed"`
+	NoisyNodes    int                  `json:"noisy_nodes"`
+	Nodes         []NodeNoisyNeighbors `json:"nodes"`
+	Notes         []string             `json:"notes,omitempty"`
+	Findings      []findings.Finding   `json:"findings"` // Noisy pods in the common findings shape
+}
+
+// noisyOptions are the thresholds used by nodeNoisyNeighbors
+type noisyOptions struct {
+	OveruseFactor      float64
+	NodeShare          float64 // Share of node CPU (0-1) above which unlimited pods are flagged
+	CriticalNamespaces map[string]bool
+}
+
+// RequiredPermissions declares the Kubernetes API access detect-noisy-neighbors needs
+func (t *DetectNoisyNeighborsTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Group: "metrics.k8s.io", Resource: "nodes", Verb: "list"},
+		{Group: "metrics.k8s.io", Resource: "pods", Verb: "list"},
+	}
+}
+
+// Execute runs the detect-noisy-neighbors operation
+func (t *DetectNoisyNeighborsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := DetectNoisyNeighborsInput{OveruseFactor: 2, NodeSharePercent: 50, Limit: 10}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.OveruseFactor < 1 {
+		return nil, invalidArgs("overuse_factor must be at least 1")
+	}
+	if input.NodeSharePercent < 1 || input.NodeSharePercent > 100 {
+		return nil, invalidArgs("node_share_percent must be between 1 and 100")
+	}
+	if input.Limit < 1 || input.Limit > 100 {
+		return nil, invalidArgs("limit must be between 1 and 100")
+	}
+
+	var nodes []corev1.Node
+	if input.Node != "" {
+		node, err := t.k8sClient.GetNode(ctx, input.Node)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		nodes = []corev1.Node{*node}
+	} else {
+		list, err := t.k8sClient.ListNodes(ctx)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		nodes = list.Items
+	}
+	pods, err := t.k8sClient.ListPods(ctx, "")
+	if err != nil {
+		return nil, apiError(err)
+	}
+	usageByPod, err := listPodMetrics(ctx, t.k8sClient, "")
+	if err != nil {
+		return nil, fmt.Errorf("pod usage is unavailable (is metrics-server running?): %w", err)
+	}
+
+	output := DetectNoisyNeighborsOutput{}
+	nodeUsage, err := listNodeMetrics(ctx, t.k8sClient)
+	if err != nil {
+		output.Notes = append(output.Notes, fmt.Sprintf("node usage is unavailable, using the sum of pod usage instead: %v", err))
+	}
+
+	opts := noisyOptions{
+		OveruseFactor:      input.OveruseFactor,
+		NodeShare:          input.NodeSharePercent / 100,
+		CriticalNamespaces: make(map[string]bool, len(t.criticalNamespaces)),
+	}
+	for _, namespace := range t.criticalNamespaces {
+		opts.CriticalNamespaces[namespace] = true
+	}
+
+	podsByNode := make(map[string][]corev1.Pod)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" && pod.Status.Phase == corev1.PodRunning {
+			podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+		}
+	}
+
+	for i := range nodes {
+		node := &nodes[i]
+		var usage *podUsage
+		if u, ok := nodeUsage[node.Name]; ok {
+			usage = &u
+		}
+		result := nodeNoisyNeighbors(node, usage, podsByNode[node.Name], usageByPod, opts)
+		output.NodesAnalyzed++
+		if len(result.NoisyPods) > 0 {
+			output.NoisyNodes++
+		} else if input.Node == "" {
+			continue
+		}
+		output.Nodes = append(output.Nodes, result)
+	}
+	sortNoisyNodes(output.Nodes)
+	if len(output.Nodes) > input.Limit {
+		output.Nodes = output.Nodes[:input.Limit]
+	}
+	output.Findings = noisyNeighborFindings(output.Nodes)
+	return output, nil
+}
+
+// noisyNeighborFindings converts the noisy pods of nodes to findings, naming
+// the sensitive pods sharing their node
+func noisyNeighborFindings(nodes []NodeNoisyNeighbors) []findings.Finding {
+	result := []findings.Finding{}
+	for _, node := range nodes {
+		affected := ""
+		if len(node.AffectedPods) > 0 {
+			names := make([]string, 0, len(node.AffectedPods))
+			for _, pod := range node.AffectedPods {
+				names = append(names, pod.Namespace+"/"+pod.Name)
+			}
+			affected = "; sensitive pods on the node: " + strings.Join(names, ", ")
+		}
+		for _, pod := range node.NoisyPods {
+			result = append(result, findings.Finding{
+				Severity:        findings.SeverityWarning,
+				Category:        findings.CategoryWorkload,
+				Resource:        resourceRef("pod", pod.Namespace, pod.Name),
+				Title:           "Pod is a noisy neighbor",
+				Detail:          fmt.Sprintf("on %s: %s%s", node.Node, strings.Join(pod.Reasons, "; "), affected),
+				SuggestedAction: "Raise the pod's requests to its usage, or set a CPU limit, so the scheduler accounts for it",
+				Source:          "detect-noisy-neighbors",
+			})
+		}
+	}
+	findings.Sort(result)
+	return result
+}
+
+// listNodeMetrics reads the current usage of every node from the metrics.k8s.io API
+func listNodeMetrics(ctx context.Context, k8sClient *clients.K8sClient) (map[string]podUsage, error) {
+	list, err := k8sClient.ListResources(ctx, "metrics.k8s.io/v1beta1", "NodeMetrics", "", metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	usage := make(map[string]podUsage, len(list.Items))
+	for _, item := range list.Items {
+		u := podUsage{ThrottledRatio: -1}
+		cpuUsage, _, _ := unstructured.NestedString(item.Object, "usage", "cpu")
+		if cpu, err := resource.ParseQuantity(cpuUsage); err == nil {
+			u.CPUMillicores = cpu.MilliValue()
+		}
+		memoryUsage, _, _ := unstructured.NestedString(item.Object, "usage", "memory")
+		if memory, err := resource.ParseQuantity(memoryUsage); err == nil {
+			u.MemoryBytes = memory.Value()
+		}
+		usage[item.GetName()] = u
+	}
+	return usage, nil
+}
+
+// nodeNoisyNeighbors finds the noisy pods among the running pods of one node and the
+// sensitive pods they share it with. nodeUsage may be nil, in which case the node is
+// assumed to use exactly what its pods use.
+func nodeNoisyNeighbors(node *corev1.Node, nodeUsage *podUsage, pods []corev1.Pod, usage map[string]podUsage, opts noisyOptions) NodeNoisyNeighbors {
+	result := NodeNoisyNeighbors{
+		Node:                     node.Name,
+		CPUAllocatableMillicores: node.Status.Allocatable.Cpu().MilliValue(),
+		MemoryAllocatableBytes:   node.Status.Allocatable.Memory().Value(),
+		Pods:                     len(pods),
+		NoisyPods:                []NoisyPod{},
+		AffectedPods:             []AffectedPod{},
+	}
+
+	noisy := make(map[string]bool)
+	for i := range pods {
+		pod := &pods[i]
+		u, ok := usage[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			continue
+		}
+		result.PodCPUMillicores += u.CPUMillicores
+		result.PodMemoryBytes += u.MemoryBytes
+
+		cpuRequest := podResourceRequest(pod, corev1.ResourceCPU)
+		memoryRequest := podResourceRequest(pod, corev1.ResourceMemory)
+		_, cpuLimited := podResourceLimit(pod, corev1.ResourceCPU)
+		candidate := NoisyPod{
+			Namespace:            pod.Namespace,
+			Name:                 pod.Name,
+			CPUMillicores:        u.CPUMillicores,
+			CPURequestMillicores: cpuRequest.MilliValue(),
+			CPUNodePercent:       sharePercent(u.CPUMillicores, result.CPUAllocatableMillicores),
+			MemoryBytes:          u.MemoryBytes,
+			MemoryRequestBytes:   memoryRequest.Value(),
+			MemoryNodePercent:    sharePercent(u.MemoryBytes, result.MemoryAllocatableBytes),
+			NoCPULimit:           !cpuLimited,
+		}
+		candidate.Reasons = noisyReasons(candidate, opts)
+		if len(candidate.Reasons) > 0 {
+			result.NoisyPods = append(result.NoisyPods, candidate)
+			noisy[pod.Namespace+"/"+pod.Name] = true
+		}
+	}
+
+	result.CPUUsageMillicores, result.MemoryUsageBytes = result.PodCPUMillicores, result.PodMemoryBytes
+	if nodeUsage != nil {
+		result.CPUUsageMillicores, result.MemoryUsageBytes = nodeUsage.CPUMillicores, nodeUsage.MemoryBytes
+		result.UnaccountedCPUMillicores = max64(nodeUsage.CPUMillicores-result.PodCPUMillicores, 0)
+		result.UnaccountedMemoryBytes = max64(nodeUsage.MemoryBytes-result.PodMemoryBytes, 0)
+	}
+	result.CPUPercent = sharePercent(result.CPUUsageMillicores, result.CPUAllocatableMillicores)
+	result.MemoryPercent = sharePercent(result.MemoryUsageBytes, result.MemoryAllocatableBytes)
+
+	sort.Slice(result.NoisyPods, func(i, j int) bool {
+		a, b := result.NoisyPods[i], result.NoisyPods[j]
+		if a.CPUNodePercent != b.CPUNodePercent {
+			return a.CPUNodePercent > b.CPUNodePercent
+		}
+		if a.MemoryNodePercent != b.MemoryNodePercent {
+			return a.MemoryNodePercent > b.MemoryNodePercent
+		}
+		return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+	})
+
+	if len(result.NoisyPods) > 0 {
+		for i := range pods {
+			pod := &pods[i]
+			if noisy[pod.Namespace+"/"+pod.Name] {
+				continue
+			}
+			switch {
+			case pod.Annotations[latencySensitiveAnnotation] == "true":
+				result.AffectedPods = append(result.AffectedPods, AffectedPod{Namespace: pod.Namespace, Name: pod.Name, Reason: "annotated latency-sensitive"})
+			case opts.CriticalNamespaces[pod.Namespace]:
+				result.AffectedPods = append(result.AffectedPods, AffectedPod{Namespace: pod.Namespace, Name: pod.Name, Reason: "in critical namespace"})
+			}
+		}
+		sort.Slice(result.AffectedPods, func(i, j int) bool {
+			a, b := result.AffectedPods[i], result.AffectedPods[j]
+			return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
+		})
+	}
+
+	result.Summary = noisySummary(result)
+	return result
+}
+
+// noisyReasons explains why a pod is noisy; an empty result means it is not. Pods below
+// noisyMinNodeShare of the node are never noisy for that resource.
+func noisyReasons(pod NoisyPod, opts noisyOptions) []string {
+	var reasons []string
+	cpuShare := pod.CPUNodePercent / 100
+	memoryShare := pod.MemoryNodePercent / 100
+
+	if cpuShare >= noisyMinNodeShare {
+		switch {
+		case pod.CPURequestMillicores == 0:
+			reasons = append(reasons, fmt.Sprintf("using %dm CPU with no CPU request", pod.CPUMillicores))
+		case float64(pod.CPUMillicores) > opts.OveruseFactor*float64(pod.CPURequestMillicores):
+			reasons = append(reasons, fmt.Sprintf("using %dm CPU, %.1fx its %dm request",
+				pod.CPUMillicores, float64(pod.CPUMillicores)/float64(pod.CPURequestMillicores), pod.CPURequestMillicores))
+		}
+	}
+	if memoryShare >= noisyMinNodeShare {
+		switch {
+		case pod.MemoryRequestBytes == 0:
+			reasons = append(reasons, fmt.Sprintf("using %dMi memory with no memory request", pod.MemoryBytes>>20))
+		case float64(pod.MemoryBytes) > opts.OveruseFactor*float64(pod.MemoryRequestBytes):
+			reasons = append(reasons, fmt.Sprintf("using %dMi memory, %.1fx its %dMi request",
+				pod.MemoryBytes>>20, float64(pod.MemoryBytes)/float64(pod.MemoryRequestBytes), pod.MemoryRequestBytes>>20))
+		}
+	}
+	if pod.NoCPULimit && cpuShare >= opts.NodeShare {
+		reasons = append(reasons, fmt.Sprintf("no CPU limit and using %.0f%% of the node's CPU", pod.CPUNodePercent))
+	}
+	return reasons
+}
+
+// noisySummary describes a node's noisy neighbor situation in one sentence
+func noisySummary(node NodeNoisyNeighbors) string {
+	if len(node.NoisyPods) == 0 {
+		return fmt.Sprintf("no noisy pods on %s (CPU %.0f%%, memory %.0f%%)", node.Node, node.CPUPercent, node.MemoryPercent)
+	}
+	names := make([]string, 0, len(node.NoisyPods))
+	for _, pod := range node.NoisyPods {
+		names = append(names, pod.Namespace+"/"+pod.Name)
+	}
+	summary := fmt.Sprintf("%d noisy pod(s) on %s (CPU %.0f%%, memory %.0f%%): %s",
+		len(node.NoisyPods), node.Node, node.CPUPercent, node.MemoryPercent, strings.Join(names, ", "))
+	if len(node.AffectedPods) > 0 {
+		summary += fmt.Sprintf("; %d sensitive pod(s) share the node", len(node.AffectedPods))
+	}
+	return summary
+}
+
+// sortNoisyNodes orders nodes with noisy pods first, then by their busiest resource
+func sortNoisyNodes(nodes []NodeNoisyNeighbors) {
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if (len(a.NoisyPods) > 0) != (len(b.NoisyPods) > 0) {
+			return len(a.NoisyPods) > 0
+		}
+		aBusy, bBusy := math.Max(a.CPUPercent, a.MemoryPercent), math.Max(b.CPUPercent, b.MemoryPercent)
+		if aBusy != bBusy {
+			return aBusy > bBusy
+		}
+		return a.Node < b.Node
+	})
+}
+
+// sharePercent returns used as a percentage of total, rounded to one decimal
+func sharePercent(used, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(used)/float64(total)*1000) / 10
+}
@@ -0,0 +1,261 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+var nodeMetricsGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}
+
+func newNeighborPod(namespace, name, node, cpuRequest, cpuLimit, memoryRequest string) corev1.Pod {
+	pod := newSizedPod(name, "", "", "", cpuRequest, cpuLimit, memoryRequest, "")
+	pod.Namespace = namespace
+	pod.Spec.NodeName = node
+	return pod
+}
+
+func newCapacityNode(name, cpu, memory string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(memory),
+		}},
+	}
+}
+
+// neighborFixture is worker-1 with a CPU hog and a memory hog next to a latency-sensitive
+// pod and a DNS pod, and an idle worker-2
+func neighborFixture() ([]corev1.Node, []corev1.Pod, map[string]podUsage) {
+	api := newNeighborPod("shop", "api", "worker-1", "500m", "1", "256Mi")
+	api.Annotations = map[string]string{latencySensitiveAnnotation: "true"}
+	pods := []corev1.Pod{
+		newNeighborPod("batch", "cruncher", "worker-1", "500m", "", "1Gi"),
+		api,
+		newNeighborPod("openshift-dns", "dns-default-x", "worker-1", "100m", "", "64Mi"),
+		newNeighborPod("shop", "web", "worker-1", "200m", "", "256Mi"),
+		newNeighborPod("tiny", "ticker", "worker-1", "10m", "", "64Mi"),
+		newNeighborPod("cache", "redis-0", "worker-1", "100m", "", "1Gi"),
+		newNeighborPod("quiet", "idle", "worker-2", "100m", "", "128Mi"),
+	}
+	usage := map[string]podUsage{
+		"batch/cruncher":              {CPUMillicores: 2500, MemoryBytes: 1 << 30},
+		"shop/api":                    {CPUMillicores: 300, MemoryBytes: 256 << 20},
+		"openshift-dns/dns-default-x": {CPUMillicores: 50, MemoryBytes: 64 << 20},
+		"shop/web":                    {CPUMillicores: 100, MemoryBytes: 256 << 20},
+		"tiny/ticker":                 {CPUMillicores: 100, MemoryBytes: 64 << 20},
+		"cache/redis-0":               {CPUMillicores: 150, MemoryBytes: 3 << 30},
+		"quiet/idle":                  {CPUMillicores: 200, MemoryBytes: 128 << 20},
+	}
+	nodes := []corev1.Node{newCapacityNode("worker-1", "4", "16Gi"), newCapacityNode("worker-2", "4", "16Gi")}
+	return nodes, pods, usage
+}
+
+func TestNoisyReasons(t *testing.T) {
+	opts := noisyOptions{OveruseFactor: 2, NodeShare: 0.5}
+
+	tests := []struct {
+		name string
+		pod  NoisyPod
+		want []string
+	}{
+		{
+			name: "cpu over request without limit",
+			pod:  NoisyPod{CPUMillicores: 2500, CPURequestMillicores: 500, CPUNodePercent: 62.5, NoCPULimit: true, MemoryRequestBytes: 1 << 30},
+			want: []string{"using 2500m CPU, 5.0x its 500m request", "no CPU limit and using 62% of the node's CPU"},
+		},
+		{
+			name: "memory over request",
+			pod:  NoisyPod{CPUMillicores: 150, CPURequestMillicores: 100, CPUNodePercent: 3.8, MemoryBytes: 3 << 30, MemoryRequestBytes: 1 << 30, MemoryNodePercent: 18.8},
+			want: []string{"using 3072Mi memory, 3.0x its 1024Mi request"},
+		},
+		{
+			name: "best effort pod",
+			pod:  NoisyPod{CPUMillicores: 400, CPUNodePercent: 10, MemoryBytes: 1 << 30, MemoryNodePercent: 6.3},
+			want: []string{"using 400m CPU with no CPU request", "using 1024Mi memory with no memory request"},
+		},
+		{
+			name: "far over a tiny request but a negligible share of the node",
+			pod:  NoisyPod{CPUMillicores: 100, CPURequestMillicores: 10, CPUNodePercent: 2.5},
+		},
+		{
+			name: "within the overuse factor",
+			pod:  NoisyPod{CPUMillicores: 900, CPURequestMillicores: 500, CPUNodePercent: 22.5, MemoryRequestBytes: 1 << 30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, noisyReasons(tt.pod, opts))
+		})
+	}
+}
+
+func TestNodeNoisyNeighbors(t *testing.T) {
+	nodes, pods, usage := neighborFixture()
+	opts := noisyOptions{OveruseFactor: 2, NodeShare: 0.5, CriticalNamespaces: map[string]bool{"openshift-dns": true}}
+
+	result := nodeNoisyNeighbors(&nodes[0], &podUsage{CPUMillicores: 3600, MemoryBytes: 12 << 30}, pods[:6], usage, opts)
+
+	assert.Equal(t, 6, result.Pods)
+	assert.Equal(t, int64(3200), result.PodCPUMillicores)
+	assert.Equal(t, int64(400), result.UnaccountedCPUMillicores)
+	assert.Equal(t, int64(7552<<20), result.UnaccountedMemoryBytes)
+	assert.Equal(t, 90.0, result.CPUPercent)
+	assert.Equal(t, 75.0, result.MemoryPercent)
+
+	require.Len(t, result.NoisyPods, 2)
+	assert.Equal(t, "cruncher", result.NoisyPods[0].Name)
+	assert.Len(t, result.NoisyPods[0].Reasons, 2)
+	assert.Equal(t, "redis-0", result.NoisyPods[1].Name)
+
+	assert.Equal(t, []AffectedPod{
+		{Namespace: "openshift-dns", Name: "dns-default-x", Reason: "in critical namespace"},
+		{Namespace: "shop", Name: "api", Reason: "annotated latency-sensitive"},
+	}, result.AffectedPods)
+	assert.Equal(t, "2 noisy pod(s) on worker-1 (CPU 90%, memory 75%): batch/cruncher, cache/redis-0; 2 sensitive pod(s) share the node", result.Summary)
+
+	// Without node metrics the node uses what its pods use
+	result = nodeNoisyNeighbors(&nodes[0], nil, pods[:6], usage, opts)
+	assert.Equal(t, int64(3200), result.CPUUsageMillicores)
+	assert.Zero(t, result.UnaccountedCPUMillicores)
+
+	quiet := nodeNoisyNeighbors(&nodes[1], nil, pods[6:], usage, opts)
+	assert.Empty(t, quiet.NoisyPods)
+	assert.Empty(t, quiet.AffectedPods)
+	assert.Equal(t, "no noisy pods on worker-2 (CPU 5%, memory 1%)", quiet.Summary)
+}
+
+func TestNoisyNeighborFindings(t *testing.T) {
+	nodes, pods, usage := neighborFixture()
+	opts := noisyOptions{OveruseFactor: 2, NodeShare: 0.5, CriticalNamespaces: map[string]bool{"openshift-dns": true}}
+	noisy := nodeNoisyNeighbors(&nodes[0], &podUsage{CPUMillicores: 3600, MemoryBytes: 12 << 30}, pods[:6], usage, opts)
+	quiet := nodeNoisyNeighbors(&nodes[1], nil, pods[6:], usage, opts)
+
+	converted := noisyNeighborFindings([]NodeNoisyNeighbors{noisy, quiet})
+	require.Len(t, converted, 2, "quiet nodes have no finding")
+
+	cruncher := converted[0]
+	assert.Equal(t, findings.SeverityWarning, cruncher.Severity)
+	assert.Equal(t, findings.CategoryWorkload, cruncher.Category)
+	assert.Equal(t, "pod/batch/cruncher", cruncher.Resource.String())
+	assert.Equal(t, "Pod is a noisy neighbor", cruncher.Title)
+	assert.Equal(t, "on worker-1: "+strings.Join(noisy.NoisyPods[0].Reasons, "; ")+
+		"; sensitive pods on the node: openshift-dns/dns-default-x, shop/api", cruncher.Detail)
+	assert.Equal(t, "detect-noisy-neighbors", cruncher.Source)
+	assert.Equal(t, "pod/cache/redis-0", converted[1].Resource.String())
+
+	assert.Empty(t, noisyNeighborFindings([]NodeNoisyNeighbors{quiet}))
+}
+
+// newMetricsTestClient builds a K8sClient whose dynamic client serves metrics.k8s.io
+// PodMetrics and NodeMetrics for the given usage
+func newMetricsTestClient(t *testing.T, typed []runtime.Object, pods, nodes map[string]podUsage) *clients.K8sClient {
+	podMetrics := podMetricsGVK.GroupVersion().WithResource("pods")
+	nodeMetrics := nodeMetricsGVK.GroupVersion().WithResource("nodes")
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.AddSpecific(podMetricsGVK, podMetrics, podMetricsGVK.GroupVersion().WithResource("pod"), meta.RESTScopeNamespace)
+	mapper.AddSpecific(nodeMetricsGVK, nodeMetrics, nodeMetricsGVK.GroupVersion().WithResource("node"), meta.RESTScopeRoot)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		podMetrics:  "PodMetricsList",
+		nodeMetrics: "NodeMetricsList",
+	})
+
+	usageFields := func(u podUsage) map[string]interface{} {
+		return map[string]interface{}{
+			"cpu":    resource.NewMilliQuantity(u.CPUMillicores, resource.DecimalSI).String(),
+			"memory": resource.NewQuantity(u.MemoryBytes, resource.BinarySI).String(),
+		}
+	}
+	// Metrics are served as "pods" and "nodes", which the tracker cannot guess from the kind
+	for key, u := range pods {
+		namespace, name, _ := strings.Cut(key, "/")
+		require.NoError(t, dynamicClient.Tracker().Create(podMetrics, newUnstructured(podMetricsGVK, namespace, name, map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "main", "usage": usageFields(u)}},
+		}), namespace))
+	}
+	for name, u := range nodes {
+		require.NoError(t, dynamicClient.Tracker().Create(nodeMetrics, newUnstructured(nodeMetricsGVK, "", name, map[string]interface{}{
+			"usage": usageFields(u),
+		}), ""))
+	}
+	return clients.NewK8sClientWithClients(fake.NewClientset(typed...), dynamicClient, mapper)
+}
+
+func TestDetectNoisyNeighborsTool_Execute(t *testing.T) {
+	nodes, pods, usage := neighborFixture()
+	var objects []runtime.Object
+	for i := range nodes {
+		objects = append(objects, &nodes[i])
+	}
+	for i := range pods {
+		objects = append(objects, &pods[i])
+	}
+	client := newMetricsTestClient(t, objects, usage, map[string]podUsage{
+		"worker-1": {CPUMillicores: 3600, MemoryBytes: 12 << 30},
+		"worker-2": {CPUMillicores: 400, MemoryBytes: 1 << 30},
+	})
+	tool := NewDetectNoisyNeighborsTool(client, []string{"openshift-dns"})
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(DetectNoisyNeighborsOutput)
+
+	assert.Equal(t, 2, output.NodesAnalyzed)
+	assert.Equal(t, 1, output.NoisyNodes)
+	require.Len(t, output.Nodes, 1)
+	assert.Equal(t, "worker-1", output.Nodes[0].Node)
+	assert.Len(t, output.Nodes[0].NoisyPods, 2)
+	assert.Len(t, output.Nodes[0].AffectedPods, 2)
+	assert.Len(t, output.Findings, 2)
+	assert.Empty(t, output.Notes)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"node": "worker-2"})
+	require.NoError(t, err)
+	output = result.(DetectNoisyNeighborsOutput)
+	require.Len(t, output.Nodes, 1)
+	assert.Equal(t, "worker-2", output.Nodes[0].Node)
+	assert.Equal(t, 10.0, output.Nodes[0].CPUPercent)
+	assert.Empty(t, output.Nodes[0].NoisyPods)
+
+	// Stricter thresholds clear the memory hog but not the unlimited CPU hog
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"overuse_factor": 6, "node_share_percent": 60})
+	require.NoError(t, err)
+	output = result.(DetectNoisyNeighborsOutput)
+	require.Len(t, output.Nodes, 1)
+	require.Len(t, output.Nodes[0].NoisyPods, 1)
+	assert.Equal(t, []string{"no CPU limit and using 62% of the node's CPU"}, output.Nodes[0].NoisyPods[0].Reasons)
+}
+
+func TestDetectNoisyNeighborsTool_NoMetrics(t *testing.T) {
+	tool := NewDetectNoisyNeighborsTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is metrics-server running?")
+}
+
+func TestDetectNoisyNeighborsTool_InvalidArgs(t *testing.T) {
+	tool := NewDetectNoisyNeighborsTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	for _, args := range []map[string]interface{}{{"overuse_factor": 0.5}, {"node_share_percent": 0}, {"node_share_percent": 101}, {"limit": 0}} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err))
+	}
+}
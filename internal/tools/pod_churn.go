@@ -0,0 +1,496 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+const (
+	// churnAnomalyPerReplicaHour is the replacement rate per replica that counts as a
+	// restart storm; a rollout replaces each replica about once
+	churnAnomalyPerReplicaHour = 3.0
+	// churnAnomalyMinEvents keeps small absolute numbers from being flagged
+	churnAnomalyMinEvents = 10
+	// eventRetention is how long the API server keeps events on OpenShift (kube default is 1h)
+	eventRetention = 3 * time.Hour
+)
+
+// GetPodChurnTool reports pod creation/deletion churn per namespace and workload
+type GetPodChurnTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewGetPodChurnTool creates a new get-pod-churn tool
+func NewGetPodChurnTool(k8sClient *clients.K8sClient) *GetPodChurnTool {
+	return &GetPodChurnTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetPodChurnTool) Name() string {
+	return "get-pod-churn"
+}
+
+// Description returns the tool description for MCP
+func (t *GetPodChurnTool) Description() string {
+	return `Measure pod churn: pods created and deleted and containers restarted per namespace and per workload over the last window, from pod creation timestamps and Scheduled/Killing/Created events. Workloads replacing pods much faster than their replica count explains (e.g. a 3-replica Deployment creating 50 pods an hour) are flagged as restart storms. Slow leaks usually show up here before they become outages.
+
+Use this tool for questions like:
+- "Is anything crash-looping or being recreated constantly?"
+- "Which workloads churn the most pods?"
+- "Is there a restart storm in namespace X?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetPodChurnTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only measure churn in this namespace. Leave empty for all namespaces.",
+				"default":     "",
+			},
+			"window": DurationProperty("Measure churn over this window, from 1m to 24h (events older than about 3 hours have usually expired)", "1h"),
+			"window_hours": map[string]interface{}{
+				"type":        "integer",
+				"description": "Deprecated: use window. Measure churn over the last N hours.",
+				"minimum":     1,
+				"maximum":     24,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of workloads and namespaces to return",
+				"default":     10,
+				"minimum":     1,
+				"maximum":     100,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetPodChurnInput represents the input parameters
+type GetPodChurnInput struct {
+	Namespace   string        `json:"namespace"`
+	Window      time.Duration `json:"window"` // Parsed by the dispatcher
+	WindowHours int           `json:"window_hours"`
+	Limit       int           `json:"limit"`
+}
+
+// WorkloadChurn is the churn of one workload's pods
+type WorkloadChurn struct {
+	Kind                string  `json:"kind"`
+	Namespace           string  `json:"namespace"`
+	Name                string  `json:"name"`
+	Replicas            int32   `json:"replicas"`
+	PodsCreated         int     `json:"pods_created"`
+	PodsDeleted         int     `json:"pods_deleted"`
+	ContainerRestarts   int     `json:"container_restarts"`
+	ChurnPerHour        float64 `json:"churn_per_hour"`         // Pods created plus container restarts per hour
+	ChurnPerReplicaHour float64 `json:"churn_per_replica_hour"` // ChurnPerHour divided by replicas
+	Anomalous           bool    `json:"anomalous"`
+	Reason              string  `json:"reason,omitempty"`
+}
+
+// NamespaceChurn totals churn in one namespace
+type NamespaceChurn struct {
+	Namespace         string `json:"namespace"`
+	PodsCreated       int    `json:"pods_created"`
+	PodsDeleted       int    `json:"pods_deleted"`
+	ContainerRestarts int    `json:"container_restarts"`
+}
+
+// GetPodChurnOutput represents the tool output
+type GetPodChurnOutput struct {
+	Window             string           `json:"window"`       // Such as "1h0m0s"
+	WindowHours        int              `json:"window_hours"` // Whole hours of Window
+	TotalPodsCreated   int              `json:"total_pods_created"`
+	TotalPodsDeleted   int              `json:"total_pods_deleted"`
+	TotalRestarts      int              `json:"total_container_restarts"`
+	AnomalousWorkloads int              `json:"anomalous_workloads"`
+	Workloads          []WorkloadChurn  `json:"workloads"`
+	Namespaces         []NamespaceChurn `json:"namespaces"`
+	Notes              []string         `json:"notes,omitempty"`
+}
+
+// churnWorkload identifies the controller a pod belongs to
+type churnWorkload struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// churnResolver maps pods, including ones already deleted, to their workload and replica count
+type churnResolver struct {
+	replicaSets map[string]churnWorkload // namespace/name -> owning Deployment (or the ReplicaSet itself)
+	jobs        map[string]churnWorkload // namespace/name -> owning CronJob (or the Job itself)
+	controllers map[string]churnWorkload // namespace/name -> StatefulSet or DaemonSet
+	replicas    map[churnWorkload]int32
+}
+
+// RequiredPermissions declares the Kubernetes API access get-pod-churn needs
+func (t *GetPodChurnTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "pods", Verb: "list"},
+		{Resource: "events", Verb: "list"},
+		{Group: "apps", Resource: "deployments", Verb: "list"},
+		{Group: "apps", Resource: "replicasets", Verb: "list"},
+		{Group: "apps", Resource: "statefulsets", Verb: "list"},
+		{Group: "apps", Resource: "daemonsets", Verb: "list"},
+		{Group: "batch", Resource: "jobs", Verb: "list"},
+	}
+}
+
+// Execute runs the get-pod-churn operation
+func (t *GetPodChurnTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetPodChurnInput{Window: time.Hour, Limit: 10}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	window, err := windowArg(args, "window", input.Window, "window_hours", time.Duration(input.WindowHours)*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	if window < time.Minute || window > 24*time.Hour {
+		return nil, invalidArgs("window must be between 1m and 24h")
+	}
+	if input.Limit < 1 || input.Limit > 100 {
+		return nil, invalidArgs("limit must be between 1 and 100")
+	}
+
+	resolver, err := t.loadResolver(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := t.k8sClient.ListPods(ctx, input.Namespace)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	// Scheduled, Killing, and Created are all Normal events, so no cheaper selector narrows this further
+	events, err := t.k8sClient.Clientset().CoreV1().Events(input.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{"involvedObject.kind": "Pod", "type": corev1.EventTypeNormal}.String(),
+	})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list pod events: %w", err))
+	}
+
+	workloads := podChurn(resolver, pods.Items, events.Items, time.Now(), window)
+
+	output := GetPodChurnOutput{
+		Window:      window.String(),
+		WindowHours: int(window.Hours()),
+		Workloads:   workloads,
+		Namespaces:  namespaceChurn(workloads),
+	}
+	for _, w := range workloads {
+		output.TotalPodsCreated += w.PodsCreated
+		output.TotalPodsDeleted += w.PodsDeleted
+		output.TotalRestarts += w.ContainerRestarts
+		if w.Anomalous {
+			output.AnomalousWorkloads++
+		}
+	}
+	if len(output.Workloads) > input.Limit {
+		output.Workloads = output.Workloads[:input.Limit]
+	}
+	if len(output.Namespaces) > input.Limit {
+		output.Namespaces = output.Namespaces[:input.Limit]
+	}
+	if window > eventRetention {
+		output.Notes = append(output.Notes, fmt.Sprintf("events expire after about %gh, so deletions and restarts older than that are not counted; creations of live pods still come from their timestamps", eventRetention.Hours()))
+	}
+	return output, nil
+}
+
+// loadResolver lists the controllers pods can belong to
+func (t *GetPodChurnTool) loadResolver(ctx context.Context, namespace string) (*churnResolver, error) {
+	apps := t.k8sClient.Clientset().AppsV1()
+	deployments, err := t.k8sClient.ListDeployments(ctx, namespace)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	replicaSets, err := apps.ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list replicasets in namespace %s: %w", namespace, err))
+	}
+	statefulSets, err := apps.StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list statefulsets in namespace %s: %w", namespace, err))
+	}
+	daemonSets, err := apps.DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list daemonsets in namespace %s: %w", namespace, err))
+	}
+	jobs, err := t.k8sClient.Clientset().BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list jobs in namespace %s: %w", namespace, err))
+	}
+
+	resolver := newChurnResolver()
+	for _, d := range deployments.Items {
+		resolver.replicas[churnWorkload{"Deployment", d.Namespace, d.Name}] = replicasOrOne(d.Spec.Replicas)
+	}
+	for _, rs := range replicaSets.Items {
+		resolver.addOwned(resolver.replicaSets, "ReplicaSet", rs.ObjectMeta, "Deployment")
+		if owner := resolver.replicaSets[rs.Namespace+"/"+rs.Name]; owner.Kind == "ReplicaSet" {
+			resolver.replicas[owner] = replicasOrOne(rs.Spec.Replicas)
+		}
+	}
+	for _, s := range statefulSets.Items {
+		workload := churnWorkload{"StatefulSet", s.Namespace, s.Name}
+		resolver.controllers[s.Namespace+"/"+s.Name] = workload
+		resolver.replicas[workload] = replicasOrOne(s.Spec.Replicas)
+	}
+	for _, ds := range daemonSets.Items {
+		workload := churnWorkload{"DaemonSet", ds.Namespace, ds.Name}
+		resolver.controllers[ds.Namespace+"/"+ds.Name] = workload
+		resolver.replicas[workload] = ds.Status.DesiredNumberScheduled
+	}
+	for _, job := range jobs.Items {
+		resolver.addOwned(resolver.jobs, "Job", job.ObjectMeta, "CronJob")
+	}
+	return resolver, nil
+}
+
+func newChurnResolver() *churnResolver {
+	return &churnResolver{
+		replicaSets: make(map[string]churnWorkload),
+		jobs:        make(map[string]churnWorkload),
+		controllers: make(map[string]churnWorkload),
+		replicas:    make(map[churnWorkload]int32),
+	}
+}
+
+// addOwned records which workload an intermediate object (ReplicaSet, Job) rolls up to
+func (r *churnResolver) addOwned(index map[string]churnWorkload, kind string, meta metav1.ObjectMeta, ownerKind string) {
+	workload := churnWorkload{kind, meta.Namespace, meta.Name}
+	for _, owner := range meta.OwnerReferences {
+		if owner.Kind == ownerKind && owner.Controller != nil && *owner.Controller {
+			workload = churnWorkload{ownerKind, meta.Namespace, owner.Name}
+		}
+	}
+	index[meta.Namespace+"/"+meta.Name] = workload
+}
+
+// resolve returns the workload of a pod. pod may be nil for pods that were already
+// deleted, in which case the controller is derived from the generated pod name.
+func (r *churnResolver) resolve(namespace, name string, pod *corev1.Pod) churnWorkload {
+	if pod != nil {
+		for _, owner := range pod.OwnerReferences {
+			if owner.Controller == nil || !*owner.Controller {
+				continue
+			}
+			key := namespace + "/" + owner.Name
+			switch owner.Kind {
+			case "ReplicaSet":
+				if workload, ok := r.replicaSets[key]; ok {
+					return workload
+				}
+			case "Job":
+				if workload, ok := r.jobs[key]; ok {
+					return workload
+				}
+			}
+			return churnWorkload{owner.Kind, namespace, owner.Name}
+		}
+		return churnWorkload{"Pod", namespace, name}
+	}
+
+	// ReplicaSet, Job, and DaemonSet pods are <owner>-<random>; StatefulSet pods are <owner>-<ordinal>
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		key := namespace + "/" + name[:i]
+		if workload, ok := r.replicaSets[key]; ok {
+			return workload
+		}
+		if workload, ok := r.jobs[key]; ok {
+			return workload
+		}
+		if workload, ok := r.controllers[key]; ok {
+			return workload
+		}
+	}
+	return churnWorkload{"Pod", namespace, name}
+}
+
+// podChurn counts pods created and deleted and containers restarted per workload within
+// window of now. A pod counts as created when it was scheduled or created in the window
+// (StatefulSet pods reuse names, so every Scheduled occurrence counts), as deleted when it
+// was killed in the window and no longer exists, and container starts beyond a pod's
+// first start count as restarts. Results are sorted by churn per replica, highest first.
+func podChurn(resolver *churnResolver, pods []corev1.Pod, events []corev1.Event, now time.Time, window time.Duration) []WorkloadChurn {
+	windowStart := now.Add(-window)
+
+	type podCounts struct {
+		pod       *corev1.Pod
+		scheduled int
+		killed    int
+		starts    int
+	}
+	byPod := make(map[string]*podCounts)
+	counts := func(key string) *podCounts {
+		if c, ok := byPod[key]; ok {
+			return c
+		}
+		c := &podCounts{}
+		byPod[key] = c
+		return c
+	}
+	for i := range pods {
+		counts(pods[i].Namespace + "/" + pods[i].Name).pod = &pods[i]
+	}
+	for i := range events {
+		event := &events[i]
+		if event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		occurrences := eventOccurrences(event, windowStart, now)
+		if occurrences == 0 {
+			continue
+		}
+		c := counts(event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name)
+		switch event.Reason {
+		case "Scheduled":
+			c.scheduled += occurrences
+		case "Killing":
+			c.killed += occurrences
+		case "Created":
+			c.starts += occurrences
+		}
+	}
+
+	workloads := make(map[churnWorkload]*WorkloadChurn)
+	for key, c := range byPod {
+		namespace, name, _ := strings.Cut(key, "/")
+		workload := resolver.resolve(namespace, name, c.pod)
+		w, ok := workloads[workload]
+		if !ok {
+			w = &WorkloadChurn{Kind: workload.Kind, Namespace: workload.Namespace, Name: workload.Name, Replicas: 1}
+			if replicas, ok := resolver.replicas[workload]; ok {
+				w.Replicas = replicas
+			}
+			workloads[workload] = w
+		}
+
+		created := c.scheduled
+		createdInWindow := c.pod != nil && !c.pod.CreationTimestamp.Time.Before(windowStart)
+		if created == 0 && createdInWindow {
+			created = 1
+		}
+		w.PodsCreated += created
+
+		gone := c.pod == nil || c.pod.DeletionTimestamp != nil
+		if gone && c.killed > 0 {
+			w.PodsDeleted++
+		}
+
+		// The first start of every container in a new pod is not a restart
+		restarts := c.starts
+		if created > 0 {
+			containers := 1
+			if c.pod != nil {
+				containers = len(c.pod.Spec.InitContainers) + len(c.pod.Spec.Containers)
+			}
+			restarts -= created * containers
+		}
+		w.ContainerRestarts += max(restarts, 0)
+	}
+
+	result := make([]WorkloadChurn, 0, len(workloads))
+	for _, w := range workloads {
+		if w.PodsCreated == 0 && w.PodsDeleted == 0 && w.ContainerRestarts == 0 {
+			continue
+		}
+		churn := w.PodsCreated + w.ContainerRestarts
+		w.ChurnPerHour, w.ChurnPerReplicaHour = churnRate(churn, w.Replicas, window)
+		w.Anomalous = churnAnomalous(w.Kind, churn, w.ChurnPerReplicaHour)
+		if w.Anomalous {
+			w.Reason = fmt.Sprintf("%d replicas created %d pods", w.Replicas, w.PodsCreated)
+			if w.ContainerRestarts > 0 {
+				w.Reason += fmt.Sprintf(" and restarted %d containers", w.ContainerRestarts)
+			}
+			w.Reason += fmt.Sprintf(" in %gh (%.1f per replica per hour)", window.Hours(), w.ChurnPerReplicaHour)
+		}
+		result = append(result, *w)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ChurnPerReplicaHour != result[j].ChurnPerReplicaHour {
+			return result[i].ChurnPerReplicaHour > result[j].ChurnPerReplicaHour
+		}
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// churnRate returns churn per hour and per replica per hour; replicas below one count as one
+func churnRate(churn int, replicas int32, window time.Duration) (float64, float64) {
+	hours := window.Hours()
+	if hours <= 0 {
+		return 0, 0
+	}
+	perHour := float64(churn) / hours
+	if replicas < 1 {
+		replicas = 1
+	}
+	perReplica := perHour / float64(replicas)
+	return math.Round(perHour*10) / 10, math.Round(perReplica*10) / 10
+}
+
+// churnAnomalous reports whether a workload replaces pods or containers much faster than
+// its replica count explains. Jobs and CronJobs create pods by design and are never flagged.
+func churnAnomalous(kind string, churn int, perReplicaHour float64) bool {
+	if kind == "Job" || kind == "CronJob" {
+		return false
+	}
+	return churn >= churnAnomalyMinEvents && perReplicaHour >= churnAnomalyPerReplicaHour
+}
+
+// namespaceChurn totals workload churn per namespace, most pods created first
+func namespaceChurn(workloads []WorkloadChurn) []NamespaceChurn {
+	byNamespace := make(map[string]*NamespaceChurn)
+	for _, w := range workloads {
+		n, ok := byNamespace[w.Namespace]
+		if !ok {
+			n = &NamespaceChurn{Namespace: w.Namespace}
+			byNamespace[w.Namespace] = n
+		}
+		n.PodsCreated += w.PodsCreated
+		n.PodsDeleted += w.PodsDeleted
+		n.ContainerRestarts += w.ContainerRestarts
+	}
+
+	result := make([]NamespaceChurn, 0, len(byNamespace))
+	for _, n := range byNamespace {
+		result = append(result, *n)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].PodsCreated != result[j].PodsCreated {
+			return result[i].PodsCreated > result[j].PodsCreated
+		}
+		return result[i].Namespace < result[j].Namespace
+	})
+	return result
+}
+
+// replicasOrOne returns the desired replicas, defaulting to one like the API server does
+func replicasOrOne(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
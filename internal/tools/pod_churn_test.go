@@ -0,0 +1,247 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func newChurnEvent(namespace, reason, pod string, count int32, last time.Time) corev1.Event {
+	return corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: fmt.Sprintf("%s.%s", pod, reason), Namespace: namespace},
+		Reason:         reason,
+		Type:           corev1.EventTypeNormal,
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: pod, Namespace: namespace},
+		FirstTimestamp: metav1.NewTime(last),
+		LastTimestamp:  metav1.NewTime(last),
+		Count:          count,
+	}
+}
+
+func newChurnPod(namespace, name, ownerKind, owner string, created time.Time) corev1.Pod {
+	controller := true
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(created),
+			OwnerReferences:   []metav1.OwnerReference{{Kind: ownerKind, Name: owner, Controller: &controller}},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}}},
+	}
+}
+
+// churnFixture is a namespace where Deployment api (3 replicas) replaced 47 pods in the last
+// hour, StatefulSet db restarted a container 4 times, and CronJob backup ran 12 jobs
+func churnFixture(now time.Time) ([]corev1.Pod, []corev1.Event) {
+	pods := []corev1.Pod{
+		newChurnPod("shop", "api-7d9f-aaaaa", "ReplicaSet", "api-7d9f", now.Add(-5*time.Minute)),
+		newChurnPod("shop", "api-7d9f-bbbbb", "ReplicaSet", "api-7d9f", now.Add(-5*time.Minute)),
+		newChurnPod("shop", "api-7d9f-ccccc", "ReplicaSet", "api-7d9f", now.Add(-5*time.Minute)),
+		newChurnPod("shop", "db-0", "StatefulSet", "db", now.Add(-48*time.Hour)),
+		newChurnPod("shop", "web-5c4b-zzzzz", "ReplicaSet", "web-5c4b", now.Add(-48*time.Hour)),
+	}
+	events := []corev1.Event{
+		newChurnEvent("shop", "Created", "db-0", 4, now.Add(-10*time.Minute)),
+		newChurnEvent("shop", "BackOff", "db-0", 30, now.Add(-10*time.Minute)),
+		// Killed two hours ago, outside a 1h window
+		newChurnEvent("shop", "Killing", "web-5c4b-yyyyy", 1, now.Add(-2*time.Hour)),
+	}
+	for i := 0; i < 47; i++ {
+		pod := fmt.Sprintf("api-7d9f-x%04d", i)
+		at := now.Add(-time.Duration(i+6) * time.Minute)
+		events = append(events,
+			newChurnEvent("shop", "Scheduled", pod, 1, at),
+			newChurnEvent("shop", "Created", pod, 1, at),
+			newChurnEvent("shop", "Killing", pod, 1, at.Add(30*time.Second)),
+		)
+	}
+	for i := 0; i < 12; i++ {
+		pod := fmt.Sprintf("backup-2906%02d-q%04d", i, i)
+		at := now.Add(-time.Duration(i*4+2) * time.Minute)
+		events = append(events,
+			newChurnEvent("shop", "Scheduled", pod, 1, at),
+			newChurnEvent("shop", "Killing", pod, 1, at.Add(time.Minute)),
+		)
+	}
+	return pods, events
+}
+
+func TestChurnRate(t *testing.T) {
+	tests := []struct {
+		name           string
+		churn          int
+		replicas       int32
+		window         time.Duration
+		wantPerHour    float64
+		wantPerReplica float64
+	}{
+		{"restart storm", 50, 3, time.Hour, 50, 16.7},
+		{"spread over the window", 24, 2, 6 * time.Hour, 4, 2},
+		{"scaled to zero counts as one replica", 6, 0, time.Hour, 6, 6},
+		{"no window", 5, 1, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			perHour, perReplica := churnRate(tt.churn, tt.replicas, tt.window)
+			assert.Equal(t, tt.wantPerHour, perHour)
+			assert.Equal(t, tt.wantPerReplica, perReplica)
+		})
+	}
+}
+
+func TestChurnAnomalous(t *testing.T) {
+	tests := []struct {
+		name           string
+		kind           string
+		churn          int
+		perReplicaHour float64
+		want           bool
+	}{
+		{"restart storm", "Deployment", 50, 16.7, true},
+		{"at both thresholds", "StatefulSet", churnAnomalyMinEvents, churnAnomalyPerReplicaHour, true},
+		{"rollout of a large deployment", "Deployment", 40, 1, false},
+		{"single pod flapping a few times", "Pod", 4, 4, false},
+		{"jobs create pods by design", "CronJob", 120, 120, false},
+		{"bare job", "Job", 20, 20, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, churnAnomalous(tt.kind, tt.churn, tt.perReplicaHour))
+		})
+	}
+}
+
+func TestPodChurn(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	pods, events := churnFixture(now)
+
+	resolver := newChurnResolver()
+	resolver.replicaSets["shop/api-7d9f"] = churnWorkload{"Deployment", "shop", "api"}
+	resolver.replicaSets["shop/web-5c4b"] = churnWorkload{"Deployment", "shop", "web"}
+	resolver.controllers["shop/db"] = churnWorkload{"StatefulSet", "shop", "db"}
+	for i := 0; i < 12; i++ {
+		resolver.jobs[fmt.Sprintf("shop/backup-2906%02d", i)] = churnWorkload{"CronJob", "shop", "backup"}
+	}
+	resolver.replicas[churnWorkload{"Deployment", "shop", "api"}] = 3
+
+	workloads := podChurn(resolver, pods, events, now, time.Hour)
+
+	require.Len(t, workloads, 3)
+	api := workloads[0]
+	assert.Equal(t, "Deployment", api.Kind)
+	assert.Equal(t, "api", api.Name)
+	assert.Equal(t, int32(3), api.Replicas)
+	assert.Equal(t, 50, api.PodsCreated)
+	assert.Equal(t, 47, api.PodsDeleted)
+	assert.Equal(t, 0, api.ContainerRestarts)
+	assert.Equal(t, 16.7, api.ChurnPerReplicaHour)
+	assert.True(t, api.Anomalous)
+	assert.Equal(t, "3 replicas created 50 pods in 1h (16.7 per replica per hour)", api.Reason)
+
+	assert.Equal(t, "CronJob", workloads[1].Kind)
+	assert.Equal(t, 12, workloads[1].PodsCreated)
+	assert.Equal(t, 12, workloads[1].PodsDeleted)
+	assert.False(t, workloads[1].Anomalous)
+
+	assert.Equal(t, "StatefulSet", workloads[2].Kind)
+	assert.Equal(t, 0, workloads[2].PodsCreated)
+	assert.Equal(t, 4, workloads[2].ContainerRestarts)
+	assert.False(t, workloads[2].Anomalous)
+
+	namespaces := namespaceChurn(workloads)
+	assert.Equal(t, []NamespaceChurn{{Namespace: "shop", PodsCreated: 62, PodsDeleted: 59, ContainerRestarts: 4}}, namespaces)
+}
+
+func TestGetPodChurnTool_Execute(t *testing.T) {
+	now := time.Now()
+	pods, events := churnFixture(now)
+	replicas := int32(3)
+	controller := true
+
+	objects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:            "api-7d9f",
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "api", Controller: &controller}},
+		}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"}},
+	}
+	for i := 0; i < 12; i++ {
+		objects = append(objects, &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("backup-2906%02d", i),
+			Namespace:       "shop",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "CronJob", Name: "backup", Controller: &controller}},
+		}})
+	}
+	for i := range pods {
+		objects = append(objects, &pods[i])
+	}
+	for i := range events {
+		objects = append(objects, &events[i])
+	}
+	tool := NewGetPodChurnTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "limit": 2})
+	require.NoError(t, err)
+	output := result.(GetPodChurnOutput)
+
+	assert.Equal(t, 1, output.WindowHours)
+	assert.Equal(t, 62, output.TotalPodsCreated)
+	assert.Equal(t, 59, output.TotalPodsDeleted)
+	assert.Equal(t, 4, output.TotalRestarts)
+	assert.Equal(t, 1, output.AnomalousWorkloads)
+	require.Len(t, output.Workloads, 2)
+	assert.Equal(t, "api", output.Workloads[0].Name)
+	assert.True(t, output.Workloads[0].Anomalous)
+	assert.Equal(t, "backup", output.Workloads[1].Name)
+	require.Len(t, output.Namespaces, 1)
+	assert.Empty(t, output.Notes)
+
+	for _, args := range []map[string]interface{}{{"window_hours": 6}, {"window": 6 * time.Hour}, {"window": "6h"}} {
+		result, err = tool.Execute(context.Background(), args)
+		require.NoError(t, err)
+		output = result.(GetPodChurnOutput)
+		assert.Equal(t, "6h0m0s", output.Window, "%v", args)
+		assert.Equal(t, 6, output.WindowHours)
+		assert.Equal(t, 60, output.TotalPodsDeleted)
+		require.Len(t, output.Notes, 1)
+		assert.Contains(t, output.Notes[0], "events expire after about 3h")
+	}
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"window": 30 * time.Minute})
+	require.NoError(t, err)
+	output = result.(GetPodChurnOutput)
+	assert.Equal(t, "30m0s", output.Window)
+	assert.Zero(t, output.WindowHours)
+}
+
+func TestGetPodChurnTool_InvalidArgs(t *testing.T) {
+	tool := NewGetPodChurnTool(clients.NewK8sClientWithClientset(fake.NewClientset()))
+
+	for _, args := range []map[string]interface{}{
+		{"window_hours": 0}, {"window_hours": 25}, {"window": 25 * time.Hour}, {"window": "30s"}, {"window": "6h", "window_hours": 6}, {"limit": 101},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err))
+	}
+}
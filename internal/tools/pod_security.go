@@ -0,0 +1,614 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Pod Security Standards levels, from least to most restrictive
+const (
+	PodSecurityPrivileged = "privileged"
+	PodSecurityBaseline   = "baseline"
+	PodSecurityRestricted = "restricted"
+)
+
+// Namespace labels read by pod security admission
+const (
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	podSecurityAuditLabel   = "pod-security.kubernetes.io/audit"
+	podSecurityWarnLabel    = "pod-security.kubernetes.io/warn"
+)
+
+// podSecurityRejection is in the message of the FailedCreate events of
+// controllers whose pods pod security admission refused
+const podSecurityRejection = "violates PodSecurity"
+
+// The check tables below are a subset of k8s.io/pod-security-admission's
+// policy checks at their latest version, kept here to avoid the dependency.
+// Check names match the upstream check IDs.
+var (
+	// baselineCapabilities may be added under the baseline level
+	baselineCapabilities = []corev1.Capability{
+		"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+		"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+	}
+	// baselineSELinuxTypes are the SELinux types pods may set under the baseline level
+	baselineSELinuxTypes = []string{"", "container_t", "container_init_t", "container_kvm_t", "container_engine_t"}
+	// baselineSysctls are the namespaced sysctls considered safe
+	baselineSysctls = []string{
+		"kernel.shm_rmid_forced", "net.ipv4.ip_local_port_range", "net.ipv4.ip_unprivileged_port_start",
+		"net.ipv4.tcp_syncookies", "net.ipv4.ping_group_range", "net.ipv4.ip_local_reserved_ports",
+		"net.ipv4.tcp_keepalive_time", "net.ipv4.tcp_fin_timeout", "net.ipv4.tcp_keepalive_intvl",
+		"net.ipv4.tcp_keepalive_probes",
+	}
+)
+
+// GetPodSecurityViolationsTool reports workloads that a stricter pod security level would reject
+type GetPodSecurityViolationsTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewGetPodSecurityViolationsTool creates a new get-pod-security-violations tool
+func NewGetPodSecurityViolationsTool(k8sClient *clients.K8sClient) *GetPodSecurityViolationsTool {
+	return &GetPodSecurityViolationsTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetPodSecurityViolationsTool) Name() string {
+	return "get-pod-security-violations"
+}
+
+// NamespaceScoped reports that namespaces and their pods are read one by
+// one, limited to the caller's namespaces when it is scoped
+func (t *GetPodSecurityViolationsTool) NamespaceScoped() bool {
+	return true
+}
+
+// Volatility is slow; pod specs change with rollouts, not by the minute
+func (t *GetPodSecurityViolationsTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
+// Description returns the tool description for MCP
+func (t *GetPodSecurityViolationsTool) Description() string {
+	return `Report which workloads would violate a Pod Security Standards level, per namespace. Reads each namespace's pod security admission labels (enforce, audit and warn levels), checks the specs of running and pending pods against target_level (baseline or restricted) and lists the failing workloads with the checks they fail (hostPath volumes, privileged containers, running as root, added capabilities, host namespaces and ports, seccomp, privilege escalation...). Also lists controllers whose pods the current enforce level already refused (FailedCreate events), which otherwise fail silently.
+
+Use this tool for questions like:
+- "What breaks if we enforce the restricted profile?"
+- "Which workloads in namespace X run privileged or as root?"
+- "Why does my Deployment have no pods after enabling pod security?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetPodSecurityViolationsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only check this namespace. Leave empty for all namespaces.",
+				"default":     "",
+			},
+			"target_level": map[string]interface{}{
+				"type":        "string",
+				"description": "Pod Security Standards level to check pods against",
+				"enum":        []string{PodSecurityBaseline, PodSecurityRestricted},
+				"default":     PodSecurityRestricted,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetPodSecurityViolationsInput represents the input parameters
+type GetPodSecurityViolationsInput struct {
+	Namespace   string `json:"namespace"`
+	TargetLevel string `json:"target_level"`
+}
+
+// PodSecurityViolation is one failed check, e.g. hostPathVolumes: volume "data"
+type PodSecurityViolation struct {
+	Check  string `json:"check"`
+	Detail string `json:"detail"`
+}
+
+// PodSecurityWorkload is a workload whose pods fail the target level
+type PodSecurityWorkload struct {
+	Kind       string                 `json:"kind"`
+	Name       string                 `json:"name"`
+	Pods       int                    `json:"pods"`
+	Violations []PodSecurityViolation `json:"violations"`
+}
+
+// PodSecurityBlocked is a controller whose pods pod security admission refused
+type PodSecurityBlocked struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Count   int32  `json:"count"`
+	Message string `json:"message"`
+}
+
+// PodSecurityNamespace is the pod security state of one namespace. Levels
+// are empty when the label is unset and the cluster default applies.
+type PodSecurityNamespace struct {
+	Namespace string `json:"namespace"`
+	Enforce   string `json:"enforce,omitempty"`
+	Audit     string `json:"audit,omitempty"`
+	Warn      string `json:"warn,omitempty"`
+	// Enforced is set when the namespace already enforces the target level,
+	// so its violating pods are exempt or predate the label
+	Enforced  bool                  `json:"enforced,omitempty"`
+	Workloads []PodSecurityWorkload `json:"workloads,omitempty"`
+	Blocked   []PodSecurityBlocked  `json:"blocked,omitempty"`
+}
+
+// GetPodSecurityViolationsOutput represents the tool output
+type GetPodSecurityViolationsOutput struct {
+	TargetLevel        string                 `json:"target_level"`
+	NamespacesChecked  int                    `json:"namespaces_checked"`
+	PodsChecked        int                    `json:"pods_checked"`
+	WorkloadsViolating int                    `json:"workloads_violating"`
+	Namespaces         []PodSecurityNamespace `json:"namespaces"` // Only namespaces with violations or blocked pods
+	Notes              []string               `json:"notes,omitempty"`
+	Findings           []findings.Finding     `json:"findings"` // Violating and refused workloads in the common findings shape
+}
+
+// RequiredPermissions declares the Kubernetes API access get-pod-security-violations needs
+func (t *GetPodSecurityViolationsTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "namespaces", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Resource: "events", Verb: "list"},
+	}
+}
+
+// Execute runs the get-pod-security-violations operation
+func (t *GetPodSecurityViolationsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetPodSecurityViolationsInput{TargetLevel: PodSecurityRestricted}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+	if input.TargetLevel != PodSecurityBaseline && input.TargetLevel != PodSecurityRestricted {
+		return nil, invalidArgs("target_level must be %s or %s", PodSecurityBaseline, PodSecurityRestricted)
+	}
+
+	namespaces, err := t.namespaces(ctx, input.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	output := GetPodSecurityViolationsOutput{
+		TargetLevel:       input.TargetLevel,
+		NamespacesChecked: len(namespaces),
+		Namespaces:        []PodSecurityNamespace{},
+	}
+	var eventFailures []string
+	for i := range namespaces {
+		pods, err := t.k8sClient.ListPods(ctx, namespaces[i].Name)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		result := podSecurityNamespace(&namespaces[i], pods.Items, input.TargetLevel)
+		for j := range pods.Items {
+			if phase := pods.Items[j].Status.Phase; phase == corev1.PodRunning || phase == corev1.PodPending {
+				output.PodsChecked++
+			}
+		}
+
+		if events, err := t.k8sClient.ListEvents(ctx, namespaces[i].Name); err != nil {
+			eventFailures = append(eventFailures, namespaces[i].Name)
+		} else {
+			result.Blocked = podSecurityBlocked(events.Items)
+		}
+
+		if len(result.Workloads) == 0 && len(result.Blocked) == 0 {
+			continue
+		}
+		output.WorkloadsViolating += len(result.Workloads)
+		output.Namespaces = append(output.Namespaces, result)
+	}
+	if len(eventFailures) > 0 {
+		output.Notes = append(output.Notes, "events could not be read, refused pods are not listed for: "+strings.Join(eventFailures, ", "))
+	}
+	output.Findings = podSecurityFindings(output.Namespaces, input.TargetLevel)
+	return output, nil
+}
+
+// namespaces returns the namespaces to check: the requested one, the
+// caller's scope, or every namespace
+func (t *GetPodSecurityViolationsTool) namespaces(ctx context.Context, namespace string) ([]corev1.Namespace, error) {
+	names := clients.NamespacesToList(ctx, namespace)
+	if len(names) == 1 && names[0] == "" {
+		list, err := t.k8sClient.ListNamespaces(ctx)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		return list.Items, nil
+	}
+
+	namespaces := make([]corev1.Namespace, 0, len(names))
+	for _, name := range names {
+		ns, err := t.k8sClient.Clientset().CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, apiError(fmt.Errorf("failed to get namespace %s: %w", name, err))
+		}
+		namespaces = append(namespaces, *ns)
+	}
+	return namespaces, nil
+}
+
+// podSecurityNamespace checks the running and pending pods of a namespace
+// against level and groups the failing ones by workload
+func podSecurityNamespace(namespace *corev1.Namespace, pods []corev1.Pod, level string) PodSecurityNamespace {
+	result := PodSecurityNamespace{
+		Namespace: namespace.Name,
+		Enforce:   namespace.Labels[podSecurityEnforceLabel],
+		Audit:     namespace.Labels[podSecurityAuditLabel],
+		Warn:      namespace.Labels[podSecurityWarnLabel],
+	}
+	result.Enforced = podSecurityLevelRank(result.Enforce) >= podSecurityLevelRank(level)
+
+	workloads := make(map[string]*PodSecurityWorkload)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		violations := evaluatePodSecurity(pod, level)
+		if len(violations) == 0 {
+			continue
+		}
+		kind, name := podWorkload(pod)
+		key := kind + "/" + name
+		workload, ok := workloads[key]
+		if !ok {
+			workload = &PodSecurityWorkload{Kind: kind, Name: name}
+			workloads[key] = workload
+		}
+		workload.Pods++
+		// Pods of one workload usually fail the same way; keep each violation once
+		for _, violation := range violations {
+			if !slices.Contains(workload.Violations, violation) {
+				workload.Violations = append(workload.Violations, violation)
+			}
+		}
+	}
+
+	for _, workload := range workloads {
+		result.Workloads = append(result.Workloads, *workload)
+	}
+	sort.Slice(result.Workloads, func(i, j int) bool {
+		a, b := result.Workloads[i], result.Workloads[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return result
+}
+
+// podSecurityFindings converts the namespaces' violations to findings.
+// Controllers whose pods are refused now are critical; workloads that would
+// be refused once the namespace enforces level are warnings, or info when it
+// already does and their pods predate the label.
+func podSecurityFindings(namespaces []PodSecurityNamespace, level string) []findings.Finding {
+	result := []findings.Finding{}
+	for _, namespace := range namespaces {
+		for _, blocked := range namespace.Blocked {
+			result = append(result, findings.Finding{
+				Severity:        findings.SeverityCritical,
+				Category:        findings.CategorySecurity,
+				Resource:        resourceRef(blocked.Kind, namespace.Namespace, blocked.Name),
+				Title:           "Pod security admission refuses pods",
+				Detail:          fmt.Sprintf("%d pod creations refused under the namespace's enforce level %q", blocked.Count, namespace.Enforce),
+				Evidence:        []findings.Evidence{{Type: findings.EvidenceEvent, Ref: "FailedCreate", Message: blocked.Message}},
+				SuggestedAction: "Fix the pod template's securityContext as the event describes, or lower the namespace's enforce level",
+				Source:          "get-pod-security-violations",
+			})
+		}
+		for _, workload := range namespace.Workloads {
+			checks := make([]string, 0, len(workload.Violations))
+			for _, violation := range workload.Violations {
+				checks = append(checks, violation.Check+": "+violation.Detail)
+			}
+			severity := findings.SeverityWarning
+			if namespace.Enforced {
+				severity = findings.SeverityInfo
+			}
+			result = append(result, findings.Finding{
+				Severity:        severity,
+				Category:        findings.CategorySecurity,
+				Resource:        resourceRef(workload.Kind, namespace.Namespace, workload.Name),
+				Title:           fmt.Sprintf("Pods fail the %s pod security level", level),
+				Detail:          fmt.Sprintf("%d pods fail %s", workload.Pods, strings.Join(checks, "; ")),
+				SuggestedAction: "Set the securityContext fields the failed checks name before enforcing the level on the namespace",
+				Source:          "get-pod-security-violations",
+			})
+		}
+	}
+	findings.Sort(result)
+	return result
+}
+
+// podSecurityBlocked returns the controllers whose FailedCreate events say
+// pod security admission refused their pods, most refused first
+func podSecurityBlocked(events []corev1.Event) []PodSecurityBlocked {
+	byObject := make(map[string]*PodSecurityBlocked)
+	for i := range events {
+		event := &events[i]
+		if event.Reason != "FailedCreate" || !strings.Contains(event.Message, podSecurityRejection) {
+			continue
+		}
+		count := event.Count
+		if count < 1 {
+			count = 1
+		}
+		key := event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name
+		if blocked, ok := byObject[key]; ok {
+			blocked.Count += count
+			continue
+		}
+		byObject[key] = &PodSecurityBlocked{
+			Kind:    event.InvolvedObject.Kind,
+			Name:    event.InvolvedObject.Name,
+			Count:   count,
+			Message: event.Message,
+		}
+	}
+
+	var blocked []PodSecurityBlocked
+	for _, b := range byObject {
+		blocked = append(blocked, *b)
+	}
+	sort.Slice(blocked, func(i, j int) bool {
+		if blocked[i].Count != blocked[j].Count {
+			return blocked[i].Count > blocked[j].Count
+		}
+		return blocked[i].Kind+"/"+blocked[i].Name < blocked[j].Kind+"/"+blocked[j].Name
+	})
+	return blocked
+}
+
+// podSecurityLevelRank orders levels; an unset or unknown level is privileged
+func podSecurityLevelRank(level string) int {
+	switch level {
+	case PodSecurityRestricted:
+		return 2
+	case PodSecurityBaseline:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// securedContainer is what the checks need of a container, init container
+// or ephemeral container
+type securedContainer struct {
+	name            string
+	securityContext *corev1.SecurityContext
+	ports           []corev1.ContainerPort
+}
+
+// securedContainers returns all containers of a pod, in spec order
+func securedContainers(spec *corev1.PodSpec) []securedContainer {
+	var containers []securedContainer
+	for i := range spec.InitContainers {
+		c := &spec.InitContainers[i]
+		containers = append(containers, securedContainer{c.Name, c.SecurityContext, c.Ports})
+	}
+	for i := range spec.Containers {
+		c := &spec.Containers[i]
+		containers = append(containers, securedContainer{c.Name, c.SecurityContext, c.Ports})
+	}
+	for i := range spec.EphemeralContainers {
+		c := &spec.EphemeralContainers[i]
+		containers = append(containers, securedContainer{c.Name, c.SecurityContext, c.Ports})
+	}
+	return containers
+}
+
+// evaluatePodSecurity returns the checks of level that pod fails; restricted
+// includes the baseline checks. Linux-only restricted checks are skipped for
+// Windows pods, as upstream does.
+func evaluatePodSecurity(pod *corev1.Pod, level string) []PodSecurityViolation {
+	violations := baselineViolations(pod)
+	if level == PodSecurityRestricted {
+		violations = append(violations, restrictedViolations(&pod.Spec)...)
+	}
+	return violations
+}
+
+// baselineViolations runs the checks of the baseline level
+func baselineViolations(pod *corev1.Pod) []PodSecurityViolation {
+	spec := &pod.Spec
+	containers := securedContainers(spec)
+	podContext := spec.SecurityContext
+	if podContext == nil {
+		podContext = &corev1.PodSecurityContext{}
+	}
+	var violations []PodSecurityViolation
+	add := func(check, format string, args ...interface{}) {
+		violations = append(violations, PodSecurityViolation{Check: check, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	var hostNamespaces []string
+	if spec.HostNetwork {
+		hostNamespaces = append(hostNamespaces, "hostNetwork")
+	}
+	if spec.HostPID {
+		hostNamespaces = append(hostNamespaces, "hostPID")
+	}
+	if spec.HostIPC {
+		hostNamespaces = append(hostNamespaces, "hostIPC")
+	}
+	if len(hostNamespaces) > 0 {
+		add("hostNamespaces", "%s=true", strings.Join(hostNamespaces, ", "))
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			add("hostPathVolumes", "volume %q mounts host path %s", volume.Name, volume.HostPath.Path)
+		}
+	}
+
+	if podContext.WindowsOptions != nil && podContext.WindowsOptions.HostProcess != nil && *podContext.WindowsOptions.HostProcess {
+		add("windowsHostProcess", "pod runs as a Windows host process")
+	}
+	if profile := podContext.SeccompProfile; profile != nil && profile.Type == corev1.SeccompProfileTypeUnconfined {
+		add("seccompProfile_baseline", "pod seccompProfile is Unconfined")
+	}
+	if options := podContext.SELinuxOptions; options != nil {
+		if detail := seLinuxViolation(options); detail != "" {
+			add("seLinuxOptions", "pod %s", detail)
+		}
+	}
+	for _, sysctl := range podContext.Sysctls {
+		if !slices.Contains(baselineSysctls, sysctl.Name) {
+			add("sysctls", "sysctl %s is not in the safe set", sysctl.Name)
+		}
+	}
+	for key, value := range pod.Annotations {
+		if strings.HasPrefix(key, corev1.DeprecatedAppArmorBetaContainerAnnotationKeyPrefix) && value == corev1.DeprecatedAppArmorBetaProfileNameUnconfined {
+			add("appArmorProfile", "annotation %s is unconfined", key)
+		}
+	}
+	if profile := podContext.AppArmorProfile; profile != nil && profile.Type == corev1.AppArmorProfileTypeUnconfined {
+		add("appArmorProfile", "pod appArmorProfile is Unconfined")
+	}
+
+	for _, c := range containers {
+		for _, port := range c.ports {
+			if port.HostPort != 0 {
+				add("hostPorts", "container %q uses host port %d", c.name, port.HostPort)
+			}
+		}
+		sc := c.securityContext
+		if sc == nil {
+			continue
+		}
+		if sc.Privileged != nil && *sc.Privileged {
+			add("privileged", "container %q is privileged", c.name)
+		}
+		if sc.WindowsOptions != nil && sc.WindowsOptions.HostProcess != nil && *sc.WindowsOptions.HostProcess {
+			add("windowsHostProcess", "container %q runs as a Windows host process", c.name)
+		}
+		if sc.Capabilities != nil {
+			for _, capability := range sc.Capabilities.Add {
+				if !slices.Contains(baselineCapabilities, capability) {
+					add("capabilities_baseline", "container %q adds capability %s", c.name, capability)
+				}
+			}
+		}
+		if sc.ProcMount != nil && *sc.ProcMount != corev1.DefaultProcMount {
+			add("procMount", "container %q sets procMount %s", c.name, *sc.ProcMount)
+		}
+		if sc.SeccompProfile != nil && sc.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+			add("seccompProfile_baseline", "container %q seccompProfile is Unconfined", c.name)
+		}
+		if sc.SELinuxOptions != nil {
+			if detail := seLinuxViolation(sc.SELinuxOptions); detail != "" {
+				add("seLinuxOptions", "container %q %s", c.name, detail)
+			}
+		}
+		if sc.AppArmorProfile != nil && sc.AppArmorProfile.Type == corev1.AppArmorProfileTypeUnconfined {
+			add("appArmorProfile", "container %q appArmorProfile is Unconfined", c.name)
+		}
+	}
+	return violations
+}
+
+// seLinuxViolation describes what options set beyond the baseline, "" when nothing
+func seLinuxViolation(options *corev1.SELinuxOptions) string {
+	var set []string
+	if !slices.Contains(baselineSELinuxTypes, options.Type) {
+		set = append(set, "type "+options.Type)
+	}
+	if options.User != "" {
+		set = append(set, "user "+options.User)
+	}
+	if options.Role != "" {
+		set = append(set, "role "+options.Role)
+	}
+	if len(set) == 0 {
+		return ""
+	}
+	return "sets SELinux " + strings.Join(set, ", ")
+}
+
+// restrictedViolations runs the checks the restricted level adds to baseline
+func restrictedViolations(spec *corev1.PodSpec) []PodSecurityViolation {
+	containers := securedContainers(spec)
+	podContext := spec.SecurityContext
+	if podContext == nil {
+		podContext = &corev1.PodSecurityContext{}
+	}
+	var violations []PodSecurityViolation
+	add := func(check, format string, args ...interface{}) {
+		violations = append(violations, PodSecurityViolation{Check: check, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	for _, volume := range spec.Volumes {
+		source := volume.VolumeSource
+		if source.ConfigMap == nil && source.CSI == nil && source.DownwardAPI == nil && source.EmptyDir == nil &&
+			source.Ephemeral == nil && source.PersistentVolumeClaim == nil && source.Projected == nil && source.Secret == nil {
+			add("restrictedVolumes", "volume %q has a restricted volume type", volume.Name)
+		}
+	}
+
+	podNonRoot := podContext.RunAsNonRoot != nil && *podContext.RunAsNonRoot
+	if podContext.RunAsNonRoot != nil && !*podContext.RunAsNonRoot {
+		add("runAsNonRoot", "pod sets runAsNonRoot=false")
+	}
+	if podContext.RunAsUser != nil && *podContext.RunAsUser == 0 {
+		add("runAsUser", "pod sets runAsUser=0")
+	}
+	podSeccomp := podContext.SeccompProfile != nil && podContext.SeccompProfile.Type != corev1.SeccompProfileTypeUnconfined
+	windows := spec.OS != nil && spec.OS.Name == corev1.Windows
+
+	for _, c := range containers {
+		sc := c.securityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		switch {
+		case sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot:
+			add("runAsNonRoot", "container %q sets runAsNonRoot=false", c.name)
+		case sc.RunAsNonRoot == nil && !podNonRoot:
+			add("runAsNonRoot", "container %q does not set runAsNonRoot=true", c.name)
+		}
+		if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+			add("runAsUser", "container %q sets runAsUser=0", c.name)
+		}
+		if windows {
+			continue
+		}
+		if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+			add("allowPrivilegeEscalation", "container %q does not set allowPrivilegeEscalation=false", c.name)
+		}
+		if sc.SeccompProfile == nil && !podSeccomp {
+			add("seccompProfile_restricted", "container %q does not set seccompProfile RuntimeDefault or Localhost", c.name)
+		}
+		if sc.Capabilities == nil || !slices.Contains(sc.Capabilities.Drop, "ALL") {
+			add("capabilities_restricted", "container %q does not drop ALL capabilities", c.name)
+		}
+		if sc.Capabilities != nil {
+			for _, capability := range sc.Capabilities.Add {
+				if capability != "NET_BIND_SERVICE" && slices.Contains(baselineCapabilities, capability) {
+					add("capabilities_restricted", "container %q adds capability %s", c.name, capability)
+				}
+			}
+		}
+	}
+	return violations
+}
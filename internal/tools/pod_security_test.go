@@ -0,0 +1,302 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+// newRestrictedPod creates a running pod that passes the restricted level;
+// mutate changes it into the spec under test
+func newRestrictedPod(name string, mutate func(*corev1.Pod)) corev1.Pod {
+	nonRoot, escalation := true, false
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot:   &nonRoot,
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+			Containers: []corev1.Container{{
+				Name: "main",
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: &escalation,
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+				},
+			}},
+			Volumes: []corev1.Volume{{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{}}}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if mutate != nil {
+		mutate(&pod)
+	}
+	return pod
+}
+
+func violationChecks(violations []PodSecurityViolation) []string {
+	checks := []string{}
+	for _, v := range violations {
+		checks = append(checks, v.Check)
+	}
+	return checks
+}
+
+func TestEvaluatePodSecurity(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+	int64Ptr := func(i int64) *int64 { return &i }
+
+	tests := []struct {
+		name       string
+		mutate     func(*corev1.Pod)
+		baseline   []string
+		restricted []string // In addition to baseline
+	}{
+		{
+			name:       "restricted pod",
+			baseline:   []string{},
+			restricted: []string{},
+		},
+		{
+			name: "hostPath volume",
+			mutate: func(p *corev1.Pod) {
+				p.Spec.Volumes = append(p.Spec.Volumes, corev1.Volume{Name: "logs", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"}}})
+			},
+			baseline:   []string{"hostPathVolumes"},
+			restricted: []string{"restrictedVolumes"},
+		},
+		{
+			name:       "privileged container",
+			mutate:     func(p *corev1.Pod) { p.Spec.Containers[0].SecurityContext.Privileged = boolPtr(true) },
+			baseline:   []string{"privileged"},
+			restricted: []string{},
+		},
+		{
+			name: "runs as root",
+			mutate: func(p *corev1.Pod) {
+				p.Spec.SecurityContext.RunAsNonRoot = nil
+				p.Spec.Containers[0].SecurityContext.RunAsUser = int64Ptr(0)
+			},
+			baseline:   []string{},
+			restricted: []string{"runAsNonRoot", "runAsUser"},
+		},
+		{
+			name: "added capabilities",
+			mutate: func(p *corev1.Pod) {
+				p.Spec.Containers[0].SecurityContext.Capabilities.Add = []corev1.Capability{"NET_ADMIN", "CHOWN", "NET_BIND_SERVICE"}
+			},
+			baseline:   []string{"capabilities_baseline"},
+			restricted: []string{"capabilities_restricted"}, // CHOWN is baseline only; NET_BIND_SERVICE is allowed
+		},
+		{
+			name: "host namespaces and ports",
+			mutate: func(p *corev1.Pod) {
+				p.Spec.HostNetwork = true
+				p.Spec.HostPID = true
+				p.Spec.Containers[0].Ports = []corev1.ContainerPort{{ContainerPort: 8080, HostPort: 8080}}
+			},
+			baseline:   []string{"hostNamespaces", "hostPorts"},
+			restricted: []string{},
+		},
+		{
+			name: "no security context",
+			mutate: func(p *corev1.Pod) {
+				p.Spec.SecurityContext = nil
+				p.Spec.Containers[0].SecurityContext = nil
+			},
+			baseline:   []string{},
+			restricted: []string{"runAsNonRoot", "allowPrivilegeEscalation", "seccompProfile_restricted", "capabilities_restricted"},
+		},
+		{
+			name: "unconfined profiles and unsafe sysctls",
+			mutate: func(p *corev1.Pod) {
+				p.Spec.SecurityContext.Sysctls = []corev1.Sysctl{{Name: "net.ipv4.tcp_syncookies"}, {Name: "kernel.msgmax"}}
+				p.Spec.Containers[0].SecurityContext.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}
+				p.Spec.Containers[0].SecurityContext.SELinuxOptions = &corev1.SELinuxOptions{Type: "spc_t"}
+			},
+			baseline:   []string{"sysctls", "seccompProfile_baseline", "seLinuxOptions"},
+			restricted: []string{},
+		},
+		{
+			name: "init container escalates privileges",
+			mutate: func(p *corev1.Pod) {
+				p.Spec.InitContainers = []corev1.Container{{Name: "setup", SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+				}}}
+			},
+			baseline:   []string{},
+			restricted: []string{"allowPrivilegeEscalation"},
+		},
+		{
+			name: "Windows pods skip Linux-only checks",
+			mutate: func(p *corev1.Pod) {
+				p.Spec.OS = &corev1.PodOS{Name: corev1.Windows}
+				p.Spec.SecurityContext.SeccompProfile = nil
+				p.Spec.Containers[0].SecurityContext = nil
+			},
+			baseline:   []string{},
+			restricted: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := newRestrictedPod("api", tt.mutate)
+			assert.Equal(t, tt.baseline, violationChecks(evaluatePodSecurity(&pod, PodSecurityBaseline)))
+			assert.Equal(t, append(append([]string{}, tt.baseline...), tt.restricted...),
+				violationChecks(evaluatePodSecurity(&pod, PodSecurityRestricted)))
+		})
+	}
+}
+
+func TestPodSecurityNamespace(t *testing.T) {
+	privileged := func(p *corev1.Pod) {
+		privileged := true
+		p.Spec.Containers[0].SecurityContext.Privileged = &privileged
+		controller := true
+		p.OwnerReferences = []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &controller}}
+	}
+	done := newRestrictedPod("job-1", privileged)
+	done.Status.Phase = corev1.PodSucceeded
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{
+		podSecurityEnforceLabel: PodSecurityBaseline,
+		podSecurityWarnLabel:    PodSecurityRestricted,
+	}}}
+
+	result := podSecurityNamespace(namespace, []corev1.Pod{
+		newRestrictedPod("agent-1", privileged),
+		newRestrictedPod("agent-2", privileged),
+		newRestrictedPod("api", nil),
+		done,
+	}, PodSecurityRestricted)
+
+	assert.Equal(t, PodSecurityBaseline, result.Enforce)
+	assert.Equal(t, PodSecurityRestricted, result.Warn)
+	assert.Empty(t, result.Audit)
+	assert.False(t, result.Enforced)
+	require.Len(t, result.Workloads, 1)
+	assert.Equal(t, "DaemonSet", result.Workloads[0].Kind)
+	assert.Equal(t, 2, result.Workloads[0].Pods)
+	assert.Len(t, result.Workloads[0].Violations, 1, "the same violation of two pods is listed once")
+
+	assert.True(t, podSecurityNamespace(namespace, nil, PodSecurityBaseline).Enforced)
+}
+
+func TestPodSecurityBlocked(t *testing.T) {
+	events := []corev1.Event{
+		{Reason: "FailedCreate", Count: 3, InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Name: "api-6d8f9"},
+			Message: `Error creating: pods "api-6d8f9-x" is forbidden: violates PodSecurity "restricted:latest": privileged`},
+		{Reason: "FailedCreate", Count: 4, InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Name: "api-6d8f9"},
+			Message: `Error creating: pods "api-6d8f9-y" is forbidden: violates PodSecurity "restricted:latest": privileged`},
+		{Reason: "FailedCreate", InvolvedObject: corev1.ObjectReference{Kind: "Job", Name: "backup"},
+			Message: `Error creating: pods "backup-z" is forbidden: violates PodSecurity "baseline:latest": hostPath volumes`},
+		{Reason: "FailedCreate", Count: 9, InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Name: "web"},
+			Message: `Error creating: pods "web-a" is forbidden: exceeded quota`},
+	}
+
+	blocked := podSecurityBlocked(events)
+	require.Len(t, blocked, 2)
+	assert.Equal(t, "api-6d8f9", blocked[0].Name)
+	assert.Equal(t, int32(7), blocked[0].Count)
+	assert.Equal(t, "backup", blocked[1].Name)
+	assert.Equal(t, int32(1), blocked[1].Count)
+}
+
+func TestPodSecurityFindings(t *testing.T) {
+	converted := podSecurityFindings([]PodSecurityNamespace{
+		{
+			Namespace: "logging",
+			Enforce:   PodSecurityRestricted,
+			Enforced:  true,
+			Workloads: []PodSecurityWorkload{{Kind: "DaemonSet", Name: "collector", Pods: 3,
+				Violations: []PodSecurityViolation{{Check: "hostPathVolumes", Detail: `volume "logs"`}}}},
+		},
+		{
+			Namespace: "shop",
+			Enforce:   PodSecurityRestricted,
+			Workloads: []PodSecurityWorkload{{Kind: "Deployment", Name: "legacy", Pods: 2,
+				Violations: []PodSecurityViolation{{Check: "runAsNonRoot", Detail: "pod or containers must set runAsNonRoot=true"}}}},
+			Blocked: []PodSecurityBlocked{{Kind: "ReplicaSet", Name: "web-55c7", Count: 2, Message: "violates PodSecurity"}},
+		},
+	}, PodSecurityRestricted)
+	require.Len(t, converted, 3)
+
+	refused := converted[0]
+	assert.Equal(t, findings.SeverityCritical, refused.Severity)
+	assert.Equal(t, findings.CategorySecurity, refused.Category)
+	assert.Equal(t, "replicaset/shop/web-55c7", refused.Resource.String())
+	assert.Equal(t, []findings.Evidence{{Type: findings.EvidenceEvent, Ref: "FailedCreate", Message: "violates PodSecurity"}}, refused.Evidence)
+
+	violating := converted[1]
+	assert.Equal(t, findings.SeverityWarning, violating.Severity)
+	assert.Equal(t, "deployment/shop/legacy", violating.Resource.String())
+	assert.Equal(t, "Pods fail the restricted pod security level", violating.Title)
+	assert.Equal(t, "2 pods fail runAsNonRoot: pod or containers must set runAsNonRoot=true", violating.Detail)
+
+	exempt := converted[2]
+	assert.Equal(t, findings.SeverityInfo, exempt.Severity, "the namespace already enforces the level")
+	assert.Equal(t, "daemonset/logging/collector", exempt.Resource.String())
+}
+
+func TestGetPodSecurityViolationsTool(t *testing.T) {
+	hostPath := newRestrictedPod("collector", func(p *corev1.Pod) {
+		p.Namespace = "logging"
+		p.Spec.Volumes = []corev1.Volume{{Name: "logs", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log"}}}}
+	})
+	noContext := newRestrictedPod("legacy", func(p *corev1.Pod) {
+		p.Spec.SecurityContext = nil
+		p.Spec.Containers[0].SecurityContext = nil
+	})
+	compliant := newRestrictedPod("api", nil)
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "logging", Labels: map[string]string{podSecurityEnforceLabel: PodSecurityPrivileged}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "quiet"}},
+		&hostPath, &noContext, &compliant,
+		&corev1.Event{ObjectMeta: metav1.ObjectMeta{Name: "web.1", Namespace: "shop"}, Reason: "FailedCreate", Count: 2,
+			InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Name: "web-55c7"},
+			Message:        `Error creating: pods "web-55c7-q" is forbidden: violates PodSecurity "restricted:latest": runAsNonRoot != true`},
+	}
+	tool := NewGetPodSecurityViolationsTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetPodSecurityViolationsOutput)
+
+	assert.Equal(t, PodSecurityRestricted, output.TargetLevel)
+	assert.Equal(t, 3, output.NamespacesChecked)
+	assert.Equal(t, 3, output.PodsChecked)
+	assert.Equal(t, 2, output.WorkloadsViolating)
+	require.Len(t, output.Namespaces, 2)
+	assert.Equal(t, "logging", output.Namespaces[0].Namespace)
+	assert.Equal(t, PodSecurityPrivileged, output.Namespaces[0].Enforce)
+	shop := output.Namespaces[1]
+	require.Len(t, shop.Workloads, 1)
+	assert.Equal(t, "legacy", shop.Workloads[0].Name)
+	require.Len(t, shop.Blocked, 1)
+	assert.Equal(t, "web-55c7", shop.Blocked[0].Name)
+
+	// At baseline only the hostPath volume fails; the namespace filter narrows the check
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"target_level": "baseline", "namespace": "logging"})
+	require.NoError(t, err)
+	output = result.(GetPodSecurityViolationsOutput)
+	assert.Equal(t, 1, output.NamespacesChecked)
+	require.Len(t, output.Namespaces, 1)
+	assert.Equal(t, []string{"hostPathVolumes"}, violationChecks(output.Namespaces[0].Workloads[0].Violations))
+}
+
+func TestGetPodSecurityViolationsTool_InvalidArgs(t *testing.T) {
+	tool := NewGetPodSecurityViolationsTool(clients.NewK8sClientWithClientset(fake.NewClientset()))
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"target_level": "privileged"})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
@@ -137,6 +137,14 @@ type PredictResourceUsageOutput struct {
 	ModelVersion     string           `json:"model_version"`
 }
 
+// RequiredPermissions declares the Kubernetes API access predict-resource-usage needs
+func (t *PredictResourceUsageTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+	}
+}
+
 // Execute runs the predict-resource-usage tool
 func (t *PredictResourceUsageTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	// Parse input arguments with defaults
@@ -152,7 +160,7 @@ func (t *PredictResourceUsageTool) Execute(ctx context.Context, args map[string]
 	// Parse and validate target datetime
 	targetTime, err := t.parseTargetDatetime(input.TargetTime, input.TargetDate)
 	if err != nil {
-		return nil, fmt.Errorf("invalid target time/date: %w", err)
+		return nil, invalidArgs("invalid target time/date: %w", err)
 	}
 
 	// Determine the target based on scope
@@ -164,7 +172,7 @@ func (t *PredictResourceUsageTool) Execute(ctx context.Context, args map[string]
 	// Get current metrics
 	currentMetrics, err := t.getCurrentMetrics(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current metrics: %w", err)
+		return nil, apiError(fmt.Errorf("failed to get current metrics: %w", err))
 	}
 
 	// Extract hour and day_of_week from target time
@@ -191,7 +199,7 @@ func (t *PredictResourceUsageTool) Execute(ctx context.Context, args map[string]
 
 	predResp, err := t.ceClient.PredictResourceUsage(ctx, predReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get prediction from coordination engine: %w", err)
+		return nil, dependencyError(fmt.Errorf("failed to get prediction from coordination engine: %w", err))
 	}
 
 	// Build output - use current metrics from Coordination Engine (Prometheus-based)
@@ -273,7 +281,7 @@ func (t *PredictResourceUsageTool) determineTarget(input PredictResourceUsageInp
 	switch input.Scope {
 	case "pod":
 		if input.Pod == "" {
-			return "", fmt.Errorf("pod name is required when scope is 'pod'")
+			return "", invalidArgs("pod name is required when scope is 'pod'")
 		}
 		if input.Namespace != "" {
 			return fmt.Sprintf("%s/%s", input.Namespace, input.Pod), nil
@@ -281,7 +289,7 @@ func (t *PredictResourceUsageTool) determineTarget(input PredictResourceUsageInp
 		return input.Pod, nil
 	case "deployment":
 		if input.Deployment == "" {
-			return "", fmt.Errorf("deployment name is required when scope is 'deployment'")
+			return "", invalidArgs("deployment name is required when scope is 'deployment'")
 		}
 		if input.Namespace != "" {
 			return fmt.Sprintf("%s/%s", input.Namespace, input.Deployment), nil
@@ -295,7 +303,7 @@ func (t *PredictResourceUsageTool) determineTarget(input PredictResourceUsageInp
 	case "cluster":
 		return "cluster-wide", nil
 	default:
-		return "", fmt.Errorf("invalid scope: %s", input.Scope)
+		return "", invalidArgs("invalid scope: %s", input.Scope)
 	}
 }
 
@@ -321,7 +329,7 @@ func (t *PredictResourceUsageTool) getClusterMetrics(ctx context.Context) (*Curr
 	// Get cluster health which includes node metrics
 	health, err := t.k8sClient.GetClusterHealth(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cluster health: %w", err)
+		return nil, apiError(fmt.Errorf("failed to get cluster health: %w", err))
 	}
 
 	// Calculate cluster-wide CPU and memory usage from node readiness ratio
@@ -350,7 +358,7 @@ func (t *PredictResourceUsageTool) getNamespaceMetrics(ctx context.Context, name
 	// Get pods in namespace
 	podList, err := t.k8sClient.ListPods(ctx, namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+		return nil, apiError(fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err))
 	}
 
 	// Calculate metrics from pod states
@@ -388,7 +396,7 @@ func (t *PredictResourceUsageTool) getDeploymentMetrics(ctx context.Context, nam
 	// Get pods for the deployment
 	podList, err := t.k8sClient.ListPods(ctx, namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		return nil, apiError(fmt.Errorf("failed to list pods: %w", err))
 	}
 
 	// Filter pods belonging to the deployment
@@ -426,7 +434,7 @@ func (t *PredictResourceUsageTool) getPodMetrics(ctx context.Context, namespace,
 	// Get pods to find the specific one
 	podList, err := t.k8sClient.ListPods(ctx, namespace)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+		return nil, apiError(fmt.Errorf("failed to list pods: %w", err))
 	}
 
 	// Find the specific pod
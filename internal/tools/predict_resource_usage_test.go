@@ -664,7 +664,8 @@ func TestPredictResourceUsageTool_Execute_Integration(t *testing.T) {
 		}
 		defer k8sClient.Close()
 
-		ceClient := clients.NewCoordinationEngineClient("http://localhost:8000")
+		ceClient, err := clients.NewCoordinationEngineClient("http://localhost:8000", clients.CoordinationEngineOptions{})
+		require.NoError(t, err)
 
 		tool := NewPredictResourceUsageTool(ceClient, k8sClient)
 		ctx := context.Background()
@@ -0,0 +1,284 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// rawAPIDeniedResources are refused by raw-get whatever the configuration:
+// Secret payloads and token reviews must not be reachable through an escape hatch
+var rawAPIDeniedResources = []string{"secrets", "tokenreviews"}
+
+// rawAPIDeniedSubresources open streams into containers or reach services
+// behind the API server, so a GET on them is never a plain read
+var rawAPIDeniedSubresources = []string{"proxy", "exec", "attach", "portforward"}
+
+// RawAPIPolicy restricts which API paths raw-get may read
+type RawAPIPolicy struct {
+	AllowedPrefixes   []string        // Paths must be at or under one of these (empty = nothing allowed)
+	AllowedNamespaces func() []string // Namespaces objects may be read from (nil or empty = all), read on every call
+	DeniedResources   []string        // Refused in addition to rawAPIDeniedResources
+}
+
+// rawAPIPath is an API server path broken into the parts the policy checks
+type rawAPIPath struct {
+	Namespace   string // Empty for cluster-scoped and discovery paths
+	Resource    string // Empty for discovery paths such as /apis/apps/v1
+	Name        string
+	Subresource string
+}
+
+// RawGetTool reads an arbitrary Kubernetes API path for resources no other tool covers
+type RawGetTool struct {
+	k8sClient *clients.K8sClient
+	sanitizer *Sanitizer
+	policy    RawAPIPolicy
+}
+
+// NewRawGetTool creates a new raw-get tool. The sanitizer masks secret material
+// in responses; nil uses the default redaction patterns.
+func NewRawGetTool(k8sClient *clients.K8sClient, sanitizer *Sanitizer, policy RawAPIPolicy) *RawGetTool {
+	if sanitizer == nil {
+		sanitizer = defaultSanitizer
+	}
+	return &RawGetTool{
+		k8sClient: k8sClient,
+		sanitizer: sanitizer,
+		policy:    policy,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *RawGetTool) Name() string {
+	return "raw-get"
+}
+
+// Description returns the tool description for MCP
+func (t *RawGetTool) Description() string {
+	return fmt.Sprintf(`Read any Kubernetes API path with a GET request and return the JSON, for resources no other tool covers. Only paths under the configured prefixes (%s) and in allowed namespaces are served; Secrets, token reviews, and proxy/exec/attach/portforward subresources are always refused. managedFields are removed and Secret data is redacted. Every call is audited.
+
+Use this tool for questions like:
+- "Show me /apis/apps/v1/namespaces/payments/replicasets"
+- "What does the API server return for this custom resource path?"`, strings.Join(t.policy.AllowedPrefixes, ", "))
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *RawGetTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"path": map[string]interface{}{
+				"type":        "string",
+				"description": "API server path, e.g. /apis/apps/v1/namespaces/foo/deployments (no query string)",
+			},
+			"method": map[string]interface{}{
+				"type":        "string",
+				"description": "HTTP method; only GET is allowed",
+				"enum":        []string{"GET"},
+				"default":     "GET",
+			},
+		},
+		"required": []string{"path"},
+	}
+}
+
+// RawGetInput represents the input parameters
+type RawGetInput struct {
+	Path   string `json:"path"`
+	Method string `json:"method"`
+}
+
+// RawGetOutput represents the tool output
+type RawGetOutput struct {
+	Path     string      `json:"path"`
+	Redacted bool        `json:"redacted"` // Secret data was replaced by sizes
+	Object   interface{} `json:"object"`
+}
+
+// Audited marks raw-get calls for the audit log even though they only read
+func (t *RawGetTool) Audited() bool {
+	return true
+}
+
+// Execute validates the path against the policy and reads it
+func (t *RawGetTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := RawGetInput{Method: "GET"}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, required fields are checked below
+	}
+
+	if !strings.EqualFold(input.Method, "GET") {
+		return nil, invalidArgs("raw-get only performs GET requests, not %s", input.Method)
+	}
+	if err := t.policy.check(input.Path); err != nil {
+		return nil, invalidArgs("%v", err)
+	}
+
+	body, err := clients.WithRetry(ctx, t.k8sClient, func(c *clients.K8sClient) ([]byte, error) {
+		return c.GetRaw(ctx, input.Path)
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	var object interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, fmt.Errorf("response from %s is not JSON: %w", input.Path, err)
+	}
+
+	kind := ""
+	if content, ok := object.(map[string]interface{}); ok {
+		kind, _ = content["kind"].(string)
+		stripRawManagedFields(content)
+	}
+
+	sanitized, err := t.sanitizer.Sanitize(object)
+	if err != nil {
+		return nil, err
+	}
+
+	return RawGetOutput{
+		Path:     input.Path,
+		Redacted: kind == "Secret" || kind == "SecretList",
+		Object:   sanitized,
+	}, nil
+}
+
+// check reports why the policy refuses rawPath, or nil when it may be read
+func (p RawAPIPolicy) check(rawPath string) error {
+	if rawPath == "" {
+		return fmt.Errorf("path is required")
+	}
+	if err := ValidateRawAPIPrefix(rawPath); err != nil {
+		return err
+	}
+	if !p.prefixAllowed(rawPath) {
+		return fmt.Errorf("path %s is not under an allowed prefix", rawPath)
+	}
+
+	parsed, err := parseRawAPIPath(rawPath)
+	if err != nil {
+		return err
+	}
+	if containsFold(rawAPIDeniedSubresources, parsed.Resource) || containsFold(rawAPIDeniedSubresources, parsed.Subresource) {
+		return fmt.Errorf("path %s reaches a proxy or stream subresource", rawPath)
+	}
+	if containsFold(rawAPIDeniedResources, parsed.Resource) || containsFold(p.DeniedResources, parsed.Resource) {
+		return fmt.Errorf("resource %s is denied", parsed.Resource)
+	}
+
+	if allowed := allowedNamespaces(p.AllowedNamespaces); len(allowed) > 0 && parsed.Resource != "" {
+		// A cluster-wide list of a namespaced resource would include other
+		// namespaces, and paths do not say which resources are namespaced
+		if parsed.Namespace == "" {
+			return fmt.Errorf("path %s is not in a namespace; only namespaced paths are allowed while namespaces are restricted", rawPath)
+		}
+		if !slices.Contains(allowed, parsed.Namespace) {
+			return fmt.Errorf("namespace %q is not in the allowed namespaces", parsed.Namespace)
+		}
+	}
+	return nil
+}
+
+// prefixAllowed reports whether rawPath is at or under an allowed prefix,
+// matching whole segments so /api/v1/pod does not admit /api/v1/pods
+func (p RawAPIPolicy) prefixAllowed(rawPath string) bool {
+	for _, prefix := range p.AllowedPrefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if rawPath == prefix || strings.HasPrefix(rawPath, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRawAPIPrefix checks that p is a canonical API server path under /api
+// or /apis: no query, fragment, percent-encoding, dot segments, empty segments,
+// or trailing slash that could make it mean something other than it reads
+func ValidateRawAPIPrefix(p string) error {
+	for _, r := range p {
+		if r <= ' ' || r == 0x7f {
+			return fmt.Errorf("path must not contain whitespace or control characters")
+		}
+	}
+	if strings.ContainsAny(p, "?#%\\") {
+		return fmt.Errorf("path must not contain a query, fragment, percent-encoding, or backslash")
+	}
+	if !strings.HasPrefix(p, "/") || path.Clean(p) != p {
+		return fmt.Errorf("path must be absolute and canonical (no '.', '..', '//', or trailing '/')")
+	}
+	if p != "/api" && p != "/apis" && !strings.HasPrefix(p, "/api/") && !strings.HasPrefix(p, "/apis/") {
+		return fmt.Errorf("path must be under /api or /apis")
+	}
+	return nil
+}
+
+// parseRawAPIPath splits a canonical /api or /apis path into namespace,
+// resource, name, and subresource. Watch paths are refused because they stream.
+func parseRawAPIPath(rawPath string) (rawAPIPath, error) {
+	segments := strings.Split(strings.TrimPrefix(rawPath, "/"), "/")
+
+	// Skip "api/<version>" or "apis/<group>/<version>"
+	skip := 2
+	if segments[0] == "apis" {
+		skip = 3
+	}
+	if len(segments) <= skip {
+		return rawAPIPath{}, nil // Discovery
+	}
+	rest := segments[skip:]
+
+	if rest[0] == "watch" {
+		return rawAPIPath{}, fmt.Errorf("watch paths are not allowed")
+	}
+
+	var parsed rawAPIPath
+	if rest[0] == "namespaces" && len(rest) >= 2 {
+		parsed.Namespace = rest[1]
+		if len(rest) == 2 {
+			// The Namespace object itself
+			parsed.Resource, parsed.Name = "namespaces", rest[1]
+			return parsed, nil
+		}
+		rest = rest[2:]
+	}
+	parsed.Resource = rest[0]
+	if len(rest) > 1 {
+		parsed.Name = rest[1]
+	}
+	if len(rest) > 2 {
+		parsed.Subresource = rest[2]
+	}
+	return parsed, nil
+}
+
+// stripRawManagedFields removes managedFields from an object and from the
+// items of a list. List items carry no kind, so it is filled in from the list
+// kind for the sanitizer to recognize Secrets.
+func stripRawManagedFields(content map[string]interface{}) {
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "managedFields")
+	}
+
+	items, ok := content["items"].([]interface{})
+	if !ok {
+		return
+	}
+	listKind, _ := content["kind"].(string)
+	for _, item := range items {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, hasKind := object["kind"]; !hasKind && strings.HasSuffix(listKind, "List") {
+			object["kind"] = strings.TrimSuffix(listKind, "List")
+		}
+		stripRawManagedFields(object)
+	}
+}
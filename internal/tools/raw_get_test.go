@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func TestValidateRawAPIPrefix(t *testing.T) {
+	valid := []string{
+		"/api",
+		"/apis",
+		"/api/v1",
+		"/apis/apps/v1/namespaces/payments/deployments",
+		"/apis/serving.kserve.io/v1beta1",
+	}
+	for _, p := range valid {
+		assert.NoError(t, ValidateRawAPIPrefix(p), p)
+	}
+
+	invalid := []string{
+		"",
+		"api/v1",
+		"/",
+		"/healthz",
+		"/metrics",
+		"/apix/apps/v1",
+		"/api/",
+		"/api/v1/",
+		"/api//v1",
+		"/api/v1/./pods",
+		"/api/v1/namespaces/a/../../../../healthz",
+		"/apis/apps/v1/..",
+		"/api/v1/pods?watch=true",
+		"/api/v1/pods#items",
+		"/api/v1/namespaces/a/%73ecrets",
+		"/api/v1/namespaces/a/secrets%2F..",
+		`/api/v1\secrets`,
+		"/api/v1/pods ",
+		"/api/v1/\tpods",
+		"/api/v1/pods\n",
+		"/api/v1/pods\x00",
+	}
+	for _, p := range invalid {
+		assert.Error(t, ValidateRawAPIPrefix(p), "%q", p)
+	}
+}
+
+func TestParseRawAPIPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want rawAPIPath
+	}{
+		{"/api/v1", rawAPIPath{}},
+		{"/apis/apps", rawAPIPath{}},
+		{"/apis/apps/v1", rawAPIPath{}},
+		{"/api/v1/nodes", rawAPIPath{Resource: "nodes"}},
+		{"/api/v1/nodes/worker-1/status", rawAPIPath{Resource: "nodes", Name: "worker-1", Subresource: "status"}},
+		{"/api/v1/namespaces", rawAPIPath{Resource: "namespaces"}},
+		{"/api/v1/namespaces/payments", rawAPIPath{Namespace: "payments", Resource: "namespaces", Name: "payments"}},
+		{"/api/v1/namespaces/payments/pods", rawAPIPath{Namespace: "payments", Resource: "pods"}},
+		{"/api/v1/namespaces/payments/pods/api-1/log", rawAPIPath{Namespace: "payments", Resource: "pods", Name: "api-1", Subresource: "log"}},
+		{"/apis/apps/v1/namespaces/payments/deployments/api", rawAPIPath{Namespace: "payments", Resource: "deployments", Name: "api"}},
+		// A namespace named like a denied resource is still just a namespace
+		{"/api/v1/namespaces/secrets/configmaps", rawAPIPath{Namespace: "secrets", Resource: "configmaps"}},
+	}
+	for _, tt := range tests {
+		got, err := parseRawAPIPath(tt.path)
+		require.NoError(t, err, tt.path)
+		assert.Equal(t, tt.want, got, tt.path)
+	}
+
+	_, err := parseRawAPIPath("/api/v1/watch/namespaces/payments/pods")
+	assert.Error(t, err)
+}
+
+func TestRawAPIPolicy_Check(t *testing.T) {
+	open := RawAPIPolicy{AllowedPrefixes: []string{"/api", "/apis"}}
+	restricted := RawAPIPolicy{
+		AllowedPrefixes:   []string{"/api/v1", "/apis/apps/v1/"},
+		AllowedNamespaces: staticNamespaces("payments"),
+		DeniedResources:   []string{"configmaps"},
+	}
+
+	tests := []struct {
+		name    string
+		policy  RawAPIPolicy
+		path    string
+		allowed bool
+	}{
+		{"namespaced list", open, "/api/v1/namespaces/payments/pods", true},
+		{"cluster-scoped list", open, "/api/v1/nodes", true},
+		{"discovery", open, "/apis/apps/v1", true},
+		{"empty path", open, "", false},
+		{"no prefixes allows nothing", RawAPIPolicy{}, "/api/v1/nodes", false},
+		{"non-API path", open, "/healthz", false},
+		{"traversal out of the API", open, "/api/v1/../../healthz", false},
+		{"query string", open, "/api/v1/pods?fieldSelector=x", false},
+
+		{"secrets list", open, "/api/v1/namespaces/payments/secrets", false},
+		{"secret by name", open, "/api/v1/namespaces/payments/secrets/db-password", false},
+		{"secrets across namespaces", open, "/api/v1/secrets", false},
+		{"secrets in mixed case", open, "/api/v1/namespaces/payments/Secrets", false},
+		{"percent-encoded secrets", open, "/api/v1/namespaces/payments/%73ecrets", false},
+		{"token reviews", open, "/apis/authentication.k8s.io/v1/tokenreviews", false},
+		{"watch", open, "/api/v1/watch/pods", false},
+		{"pod exec", open, "/api/v1/namespaces/payments/pods/api-1/exec", false},
+		{"pod attach", open, "/api/v1/namespaces/payments/pods/api-1/attach", false},
+		{"pod portforward", open, "/api/v1/namespaces/payments/pods/api-1/portforward", false},
+		{"service proxy", open, "/api/v1/namespaces/payments/services/api/proxy/admin", false},
+		{"node proxy", open, "/api/v1/nodes/worker-1/proxy/configz", false},
+		{"legacy proxy", open, "/api/v1/proxy/namespaces/payments/services/api", false},
+		{"pod log is a plain read", open, "/api/v1/namespaces/payments/pods/api-1/log", true},
+
+		{"allowed prefix and namespace", restricted, "/apis/apps/v1/namespaces/payments/deployments", true},
+		{"prefix matches whole segments", restricted, "/api/v10/namespaces/payments/pods", false},
+		{"prefix not allowlisted", restricted, "/apis/batch/v1/namespaces/payments/jobs", false},
+		{"namespace not allowlisted", restricted, "/api/v1/namespaces/checkout/pods", false},
+		{"namespace object not allowlisted", restricted, "/api/v1/namespaces/checkout", false},
+		{"namespace object allowlisted", restricted, "/api/v1/namespaces/payments", true},
+		{"cluster-wide list while namespaces are restricted", restricted, "/api/v1/pods", false},
+		{"discovery while namespaces are restricted", restricted, "/api/v1", true},
+		{"configured denied resource", restricted, "/api/v1/namespaces/payments/configmaps", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.check(tt.path)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// newRawAPIServer serves body for every request, recording the paths requested
+func newRawAPIServer(t *testing.T, body string, paths *[]string) *clients.K8sClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	return clients.NewK8sClientWithClientset(clientset)
+}
+
+func TestRawGetTool_Execute(t *testing.T) {
+	var paths []string
+	k8s := newRawAPIServer(t, `{
+		"kind": "DeploymentList",
+		"apiVersion": "apps/v1",
+		"metadata": {"resourceVersion": "42"},
+		"items": [{
+			"metadata": {"name": "api", "namespace": "payments", "managedFields": [{"manager": "kubectl"}]},
+			"spec": {"replicas": 3}
+		}]
+	}`, &paths)
+	tool := NewRawGetTool(k8s, nil, RawAPIPolicy{AllowedPrefixes: []string{"/apis/apps/v1"}, AllowedNamespaces: staticNamespaces("payments")})
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"path": "/apis/apps/v1/namespaces/payments/deployments"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/apis/apps/v1/namespaces/payments/deployments"}, paths)
+
+	output := result.(RawGetOutput)
+	assert.False(t, output.Redacted)
+	object := output.Object.(map[string]interface{})
+	item := object["items"].([]interface{})[0].(map[string]interface{})
+	assert.NotContains(t, item["metadata"], "managedFields")
+	assert.Equal(t, "Deployment", item["kind"])
+	assert.Equal(t, float64(3), item["spec"].(map[string]interface{})["replicas"])
+}
+
+func TestRawGetTool_RedactsSecretsInResponses(t *testing.T) {
+	// Secrets are denied by path, but a response carrying them anyway is still redacted
+	var paths []string
+	k8s := newRawAPIServer(t, `{"kind":"SecretList","items":[{"metadata":{"name":"db"},"data":{"password":"aHVudGVyMg=="}}]}`, &paths)
+	tool := NewRawGetTool(k8s, nil, RawAPIPolicy{AllowedPrefixes: []string{"/apis/example.com"}})
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"path": "/apis/example.com/v1/vaults"})
+	require.NoError(t, err)
+
+	output := result.(RawGetOutput)
+	assert.True(t, output.Redacted)
+	item := output.Object.(map[string]interface{})["items"].([]interface{})[0].(map[string]interface{})
+	assert.NotEqual(t, "aHVudGVyMg==", item["data"].(map[string]interface{})["password"])
+}
+
+func TestRawGetTool_Refusals(t *testing.T) {
+	var paths []string
+	k8s := newRawAPIServer(t, `{}`, &paths)
+	tool := NewRawGetTool(k8s, nil, RawAPIPolicy{AllowedPrefixes: []string{"/api"}})
+
+	for _, args := range []map[string]interface{}{
+		{},
+		{"path": "/api/v1/namespaces/payments/pods", "method": "DELETE"},
+		{"path": "/api/v1/namespaces/payments/pods", "method": "POST"},
+		{"path": "/api/v1/namespaces/payments/secrets"},
+		{"path": "/apis/apps/v1/deployments"},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		assert.True(t, IsInvalidArguments(err), "%v: %v", args, err)
+	}
+	assert.Empty(t, paths, "refused calls must not reach the API server")
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"path": "/api/v1/nodes", "method": "get"})
+	assert.NoError(t, err)
+}
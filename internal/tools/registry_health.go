@@ -0,0 +1,553 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// registryNamespace holds the integrated image registry
+	registryNamespace = "openshift-image-registry"
+	// registryDeployment is the integrated registry's Deployment
+	registryDeployment = "image-registry"
+	// registryPodSelector selects the integrated registry's pods
+	registryPodSelector = "docker-registry=default"
+)
+
+// Registry health levels reported by get-registry-health
+const (
+	RegistryStatusHealthy     = "healthy"
+	RegistryStatusDegraded    = "degraded"
+	RegistryStatusUnavailable = "unavailable"
+)
+
+// registryStorageTypes are the storage backends of the image registry config
+var registryStorageTypes = []string{"pvc", "s3", "gcs", "azure", "swift", "ibmcos", "oss", "emptyDir"}
+
+// GetRegistryHealthTool checks the integrated image registry and recent image imports and builds
+type GetRegistryHealthTool struct {
+	k8sClient     *clients.K8sClient
+	buildsEnabled bool
+}
+
+// NewGetRegistryHealthTool creates a new get-registry-health tool.
+// buildsEnabled reports whether the cluster serves build.openshift.io.
+func NewGetRegistryHealthTool(k8sClient *clients.K8sClient, buildsEnabled bool) *GetRegistryHealthTool {
+	return &GetRegistryHealthTool{
+		k8sClient:     k8sClient,
+		buildsEnabled: buildsEnabled,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetRegistryHealthTool) Name() string {
+	return "get-registry-health"
+}
+
+// Description returns the tool description for MCP
+func (t *GetRegistryHealthTool) Description() string {
+	return `Check OpenShift image registry and build health: the image-registry ClusterOperator, registry pods, storage (PVC/S3/...) configuration, failing ImageStream imports, and Builds that failed in the last N hours with their log snippet.
+
+Use this tool for questions like:
+- "Why are image pushes failing?"
+- "Is the internal registry healthy?"
+- "Which builds failed today?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetRegistryHealthTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only check ImageStreams and Builds in this namespace. Leave empty for all namespaces.",
+				"default":     "",
+			},
+			"hours": map[string]interface{}{
+				"type":        "integer",
+				"description": "Look back this many hours for failed Builds",
+				"default":     24,
+				"minimum":     1,
+				"maximum":     168,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of import failures and failed Builds to return (each)",
+				"default":     20,
+				"minimum":     1,
+				"maximum":     100,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetRegistryHealthInput represents the input parameters
+type GetRegistryHealthInput struct {
+	Namespace string `json:"namespace"`
+	Hours     int    `json:"hours"`
+	Limit     int    `json:"limit"`
+}
+
+// RegistryStorage describes the registry's storage backend
+type RegistryStorage struct {
+	Type          string             `json:"type"` // pvc, s3, emptyDir, ... ("" when not configured)
+	StorageExists *OperatorCondition `json:"storage_exists,omitempty"`
+}
+
+// RegistryPod is the state of one registry pod
+type RegistryPod struct {
+	Name     string `json:"name"`
+	Phase    string `json:"phase"`
+	Ready    bool   `json:"ready"`
+	Restarts int32  `json:"restarts"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ImageImportFailure is an ImageStream tag whose last import failed
+type ImageImportFailure struct {
+	Namespace   string `json:"namespace"`
+	ImageStream string `json:"image_stream"`
+	Tag         string `json:"tag"`
+	Reason      string `json:"reason,omitempty"`
+	Message     string `json:"message"`
+	Since       string `json:"since,omitempty"`
+}
+
+// FailedBuild is a Build that ended in the Failed or Error phase
+type FailedBuild struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	BuildConfig string `json:"build_config,omitempty"`
+	Phase       string `json:"phase"`
+	Reason      string `json:"reason,omitempty"`
+	Message     string `json:"message,omitempty"`
+	CompletedAt string `json:"completed_at"`
+	LogSnippet  string `json:"log_snippet,omitempty"`
+	LogsCommand string `json:"logs_command"`
+}
+
+// GetRegistryHealthOutput represents the tool output
+type GetRegistryHealthOutput struct {
+	Status              string               `json:"status"`
+	Issues              []string             `json:"issues"`
+	ManagementState     string               `json:"management_state,omitempty"`
+	OperatorConditions  []OperatorCondition  `json:"operator_conditions"`
+	Storage             RegistryStorage      `json:"storage"`
+	DesiredReplicas     int32                `json:"desired_replicas"`
+	ReadyReplicas       int32                `json:"ready_replicas"`
+	Pods                []RegistryPod        `json:"pods"`
+	ImportFailures      []ImageImportFailure `json:"import_failures"`
+	TotalImportFailures int                  `json:"total_import_failures"`
+	BuildsChecked       bool                 `json:"builds_checked"`
+	FailedBuilds        []FailedBuild        `json:"failed_builds"`
+	TotalFailedBuilds   int                  `json:"total_failed_builds"`
+	Findings            []findings.Finding   `json:"findings"` // The registry's state, failing imports and failed builds in the common findings shape
+}
+
+// RequiredPermissions declares the Kubernetes API access get-registry-health needs
+func (t *GetRegistryHealthTool) RequiredPermissions() []PermissionRule {
+	rules := []PermissionRule{
+		{Group: "config.openshift.io", Resource: "clusteroperators", Verb: "get"},
+		{Group: "imageregistry.operator.openshift.io", Resource: "configs", Verb: "get"},
+		{Group: "apps", Resource: "deployments", Verb: "get", Namespace: registryNamespace},
+		{Resource: "pods", Verb: "list", Namespace: registryNamespace},
+		{Group: "image.openshift.io", Resource: "imagestreams", Verb: "list"},
+	}
+	if t.buildsEnabled {
+		rules = append(rules, PermissionRule{Group: "build.openshift.io", Resource: "builds", Verb: "list"})
+	}
+	return rules
+}
+
+// Execute runs the get-registry-health operation
+func (t *GetRegistryHealthTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetRegistryHealthInput{
+		Hours: 24,
+		Limit: 20,
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.Hours < 1 || input.Hours > 168 {
+		return nil, invalidArgs("hours must be between 1 and 168")
+	}
+	if input.Limit < 1 || input.Limit > 100 {
+		return nil, invalidArgs("limit must be between 1 and 100")
+	}
+
+	output := GetRegistryHealthOutput{
+		Issues:         []string{},
+		Pods:           []RegistryPod{},
+		ImportFailures: []ImageImportFailure{},
+		FailedBuilds:   []FailedBuild{},
+		BuildsChecked:  t.buildsEnabled,
+	}
+
+	clusterOperator, err := t.k8sClient.GetResource(ctx, "config.openshift.io/v1", "ClusterOperator", "", "image-registry")
+	if err != nil {
+		return nil, apiError(err)
+	}
+	output.OperatorConditions = operatorConditions(clusterOperator)
+
+	registryConfig, err := t.k8sClient.GetResource(ctx, "imageregistry.operator.openshift.io/v1", "Config", "", "cluster")
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if registryConfig != nil {
+		output.ManagementState, _, _ = unstructured.NestedString(registryConfig.Object, "spec", "managementState")
+		output.Storage = registryStorage(registryConfig)
+	}
+
+	if err := t.checkRegistryPods(ctx, &output); err != nil {
+		return nil, err
+	}
+
+	imageStreams, err := t.k8sClient.ListResources(ctx, "image.openshift.io/v1", "ImageStream", input.Namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	output.ImportFailures = imageImportFailures(imageStreams.Items)
+	output.TotalImportFailures = len(output.ImportFailures)
+	if len(output.ImportFailures) > input.Limit {
+		output.ImportFailures = output.ImportFailures[:input.Limit]
+	}
+
+	if t.buildsEnabled {
+		builds, err := t.k8sClient.ListResources(ctx, "build.openshift.io/v1", "Build", input.Namespace, metav1.ListOptions{})
+		if err != nil {
+			return nil, apiError(err)
+		}
+		since := time.Now().Add(-time.Duration(input.Hours) * time.Hour)
+		output.FailedBuilds = failedBuilds(builds.Items, since)
+		output.TotalFailedBuilds = len(output.FailedBuilds)
+		if len(output.FailedBuilds) > input.Limit {
+			output.FailedBuilds = output.FailedBuilds[:input.Limit]
+		}
+	}
+
+	output.Status, output.Issues = registryHealthStatus(&output)
+	output.Findings = registryFindings(&output)
+	return output, nil
+}
+
+// registryFindings converts the registry's health to findings: an unavailable
+// registry is critical and a degraded one a warning, with the operator
+// conditions as evidence; each failing ImageStream import and failed Build
+// is a warning
+func registryFindings(output *GetRegistryHealthOutput) []findings.Finding {
+	result := []findings.Finding{}
+	if output.Status != RegistryStatusHealthy {
+		finding := findings.Finding{
+			Severity:        findings.SeverityWarning,
+			Category:        findings.CategoryOperator,
+			Resource:        resourceRef("clusteroperator", "", "image-registry"),
+			Title:           "Image registry is degraded",
+			Detail:          strings.Join(output.Issues, "; "),
+			SuggestedAction: "Check the registry storage and the pods in " + registryNamespace,
+			Source:          "get-registry-health",
+		}
+		if output.Status == RegistryStatusUnavailable {
+			finding.Severity, finding.Title = findings.SeverityCritical, "Image registry is unavailable"
+			finding.SuggestedAction = "Restore the registry first: builds and pushes to the internal registry fail until it is available"
+		}
+		if available := findCondition(output.OperatorConditions, "Available"); available != nil && available.Status != "True" {
+			finding.Evidence = append(finding.Evidence, findings.Evidence{Type: findings.EvidenceCondition, Ref: "Available", Message: available.Message})
+		}
+		if degraded := findCondition(output.OperatorConditions, "Degraded"); degraded != nil && degraded.Status == "True" {
+			finding.Evidence = append(finding.Evidence, findings.Evidence{Type: findings.EvidenceCondition, Ref: "Degraded", Message: degraded.Message})
+		}
+		result = append(result, finding)
+	}
+
+	for _, failure := range output.ImportFailures {
+		finding := findings.Finding{
+			Severity:        findings.SeverityWarning,
+			Category:        findings.CategoryWorkload,
+			Resource:        resourceRef("imagestreamtag", failure.Namespace, failure.ImageStream+":"+failure.Tag),
+			Title:           "ImageStream tag fails to import",
+			Detail:          failure.Message,
+			Evidence:        []findings.Evidence{{Type: findings.EvidenceCondition, Ref: "ImportSuccess", Message: failure.Message}},
+			SuggestedAction: "Check that the source image exists and that the pull secret for its registry is valid",
+			Source:          "get-registry-health",
+		}
+		if since, err := time.Parse(time.RFC3339, failure.Since); err == nil {
+			finding.FirstSeen = since
+		}
+		result = append(result, finding)
+	}
+
+	for _, build := range output.FailedBuilds {
+		detail := build.Reason
+		if build.Message != "" && detail != "" {
+			detail += ": "
+		}
+		detail += build.Message
+		finding := findings.Finding{
+			Severity:        findings.SeverityWarning,
+			Category:        findings.CategoryWorkload,
+			Resource:        resourceRef("build", build.Namespace, build.Name),
+			Title:           "Build failed",
+			Detail:          detail,
+			SuggestedAction: "Read the build log: " + build.LogsCommand,
+			Source:          "get-registry-health",
+		}
+		if build.LogSnippet != "" {
+			finding.Evidence = []findings.Evidence{{Type: findings.EvidenceLog, Ref: "build/" + build.Name, Message: build.LogSnippet}}
+		}
+		if completed, err := time.Parse(time.RFC3339, build.CompletedAt); err == nil {
+			finding.LastSeen = completed
+		}
+		result = append(result, finding)
+	}
+	findings.Sort(result)
+	return result
+}
+
+// checkRegistryPods records the registry Deployment's replicas and pod states
+func (t *GetRegistryHealthTool) checkRegistryPods(ctx context.Context, output *GetRegistryHealthOutput) error {
+	clientset := t.k8sClient.Clientset()
+
+	deployment, err := clientset.AppsV1().Deployments(registryNamespace).Get(ctx, registryDeployment, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// Removed registries have no Deployment; reported through managementState
+	case err != nil:
+		return fmt.Errorf("failed to get registry deployment: %w", err)
+	default:
+		if deployment.Spec.Replicas != nil {
+			output.DesiredReplicas = *deployment.Spec.Replicas
+		}
+		output.ReadyReplicas = deployment.Status.ReadyReplicas
+	}
+
+	pods, err := clientset.CoreV1().Pods(registryNamespace).List(ctx, metav1.ListOptions{LabelSelector: registryPodSelector})
+	if err != nil {
+		return fmt.Errorf("failed to list registry pods: %w", err)
+	}
+	for i := range pods.Items {
+		output.Pods = append(output.Pods, registryPod(&pods.Items[i]))
+	}
+	sort.Slice(output.Pods, func(i, j int) bool { return output.Pods[i].Name < output.Pods[j].Name })
+	return nil
+}
+
+// registryHealthStatus derives the overall status and issue list. Only the registry
+// itself decides the status; import and build failures are listed as issues.
+func registryHealthStatus(output *GetRegistryHealthOutput) (string, []string) {
+	status := RegistryStatusHealthy
+	issues := []string{}
+	degrade := func(format string, a ...interface{}) {
+		if status == RegistryStatusHealthy {
+			status = RegistryStatusDegraded
+		}
+		issues = append(issues, fmt.Sprintf(format, a...))
+	}
+
+	if output.ManagementState == "Removed" {
+		return RegistryStatusUnavailable, append(issues, "image registry managementState is Removed; pushes to the internal registry are not possible")
+	}
+
+	if available := findCondition(output.OperatorConditions, "Available"); available == nil || available.Status != "True" {
+		status = RegistryStatusUnavailable
+		message := "image-registry operator is not Available"
+		if available != nil && available.Message != "" {
+			message += ": " + available.Message
+		}
+		issues = append(issues, message)
+	}
+	if degraded := findCondition(output.OperatorConditions, "Degraded"); degraded != nil && degraded.Status == "True" {
+		degrade("image-registry operator is Degraded: %s", degraded.Message)
+	}
+
+	if output.Storage.Type == "" {
+		degrade("no registry storage is configured")
+	} else if output.Storage.Type == "emptyDir" {
+		issues = append(issues, "registry uses emptyDir storage; images are lost when the registry pod restarts")
+	}
+	if exists := output.Storage.StorageExists; exists != nil && exists.Status != "True" {
+		degrade("registry %s storage is not ready: %s", output.Storage.Type, exists.Message)
+	}
+
+	if output.DesiredReplicas > 0 && output.ReadyReplicas == 0 {
+		status = RegistryStatusUnavailable
+		issues = append(issues, "no registry pods are ready")
+	} else if output.ReadyReplicas < output.DesiredReplicas {
+		degrade("%d of %d registry pods are ready", output.ReadyReplicas, output.DesiredReplicas)
+	}
+	for _, pod := range output.Pods {
+		if !pod.Ready && pod.Reason != "" {
+			issues = append(issues, fmt.Sprintf("registry pod %s is not ready: %s", pod.Name, pod.Reason))
+		}
+	}
+
+	if output.TotalImportFailures > 0 {
+		issues = append(issues, fmt.Sprintf("%d ImageStream tags are failing to import", output.TotalImportFailures))
+	}
+	if output.TotalFailedBuilds > 0 {
+		issues = append(issues, fmt.Sprintf("%d Builds failed in the selected window", output.TotalFailedBuilds))
+	}
+	return status, issues
+}
+
+// registryStorage reads the effective storage backend from the registry config
+func registryStorage(registryConfig *unstructured.Unstructured) RegistryStorage {
+	storage := RegistryStorage{}
+	for _, path := range [][]string{{"status", "storage"}, {"spec", "storage"}} {
+		backends, found, _ := unstructured.NestedMap(registryConfig.Object, path...)
+		if !found {
+			continue
+		}
+		for _, storageType := range registryStorageTypes {
+			if _, ok := backends[storageType]; ok {
+				storage.Type = storageType
+				break
+			}
+		}
+		if storage.Type != "" {
+			break
+		}
+	}
+	storage.StorageExists = findCondition(operatorConditions(registryConfig), "StorageExists")
+	return storage
+}
+
+// registryPod summarizes a registry pod, with the first waiting or termination reason
+func registryPod(pod *corev1.Pod) RegistryPod {
+	summary := RegistryPod{
+		Name:  pod.Name,
+		Phase: string(pod.Status.Phase),
+		Ready: podReady(pod),
+	}
+	for _, container := range pod.Status.ContainerStatuses {
+		summary.Restarts += container.RestartCount
+	}
+	if !summary.Ready {
+		summary.Reason = podNotReadyReason(pod)
+	}
+	return summary
+}
+
+// podNotReadyReason returns the first container waiting or termination reason, or the pod phase
+func podNotReadyReason(pod *corev1.Pod) string {
+	for _, container := range pod.Status.ContainerStatuses {
+		switch {
+		case container.State.Waiting != nil && container.State.Waiting.Reason != "":
+			return container.State.Waiting.Reason
+		case container.State.Terminated != nil && container.State.Terminated.Reason != "":
+			return container.State.Terminated.Reason
+		}
+	}
+	return string(pod.Status.Phase)
+}
+
+// imageImportFailures returns ImageStream tags whose ImportSuccess condition is False, newest first
+func imageImportFailures(imageStreams []unstructured.Unstructured) []ImageImportFailure {
+	failures := []ImageImportFailure{}
+	for i := range imageStreams {
+		imageStream := &imageStreams[i]
+		tags, _, _ := unstructured.NestedSlice(imageStream.Object, "status", "tags")
+		for _, rawTag := range tags {
+			tag, ok := rawTag.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			conditions, _, _ := unstructured.NestedSlice(tag, "conditions")
+			for _, rawCondition := range conditions {
+				condition, ok := rawCondition.(map[string]interface{})
+				if !ok || nestedString(condition, "type") != "ImportSuccess" || nestedString(condition, "status") != "False" {
+					continue
+				}
+				failures = append(failures, ImageImportFailure{
+					Namespace:   imageStream.GetNamespace(),
+					ImageStream: imageStream.GetName(),
+					Tag:         nestedString(tag, "tag"),
+					Reason:      nestedString(condition, "reason"),
+					Message:     nestedString(condition, "message"),
+					Since:       nestedString(condition, "lastTransitionTime"),
+				})
+			}
+		}
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		if failures[i].Since != failures[j].Since {
+			return failures[i].Since > failures[j].Since // RFC3339 sorts chronologically
+		}
+		if failures[i].Namespace != failures[j].Namespace {
+			return failures[i].Namespace < failures[j].Namespace
+		}
+		return failures[i].ImageStream < failures[j].ImageStream
+	})
+	return failures
+}
+
+// failedBuilds returns Builds in the Failed or Error phase that finished after since, newest first
+func failedBuilds(builds []unstructured.Unstructured, since time.Time) []FailedBuild {
+	type timedBuild struct {
+		build    FailedBuild
+		finished time.Time
+	}
+	var matched []timedBuild
+
+	for i := range builds {
+		build := &builds[i]
+		phase, _, _ := unstructured.NestedString(build.Object, "status", "phase")
+		if phase != "Failed" && phase != "Error" {
+			continue
+		}
+
+		finished := build.GetCreationTimestamp().Time
+		if completed, _, _ := unstructured.NestedString(build.Object, "status", "completionTimestamp"); completed != "" {
+			if parsed, err := time.Parse(time.RFC3339, completed); err == nil {
+				finished = parsed
+			}
+		}
+		if finished.Before(since) {
+			continue
+		}
+
+		buildConfig, _, _ := unstructured.NestedString(build.Object, "status", "config", "name")
+		if buildConfig == "" {
+			buildConfig = build.GetLabels()["openshift.io/build-config.name"]
+		}
+		reason, _, _ := unstructured.NestedString(build.Object, "status", "reason")
+		message, _, _ := unstructured.NestedString(build.Object, "status", "message")
+		logSnippet, _, _ := unstructured.NestedString(build.Object, "status", "logSnippet")
+
+		matched = append(matched, timedBuild{
+			finished: finished,
+			build: FailedBuild{
+				Namespace:   build.GetNamespace(),
+				Name:        build.GetName(),
+				BuildConfig: buildConfig,
+				Phase:       phase,
+				Reason:      reason,
+				Message:     message,
+				CompletedAt: finished.UTC().Format(time.RFC3339),
+				LogSnippet:  logSnippet,
+				LogsCommand: fmt.Sprintf("oc logs -n %s build/%s", build.GetNamespace(), build.GetName()),
+			},
+		})
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].finished.After(matched[j].finished) })
+	result := make([]FailedBuild, 0, len(matched))
+	for _, m := range matched {
+		result = append(result, m.build)
+	}
+	return result
+}
@@ -0,0 +1,298 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+var (
+	registryConfigGVK = schema.GroupVersionKind{Group: "imageregistry.operator.openshift.io", Version: "v1", Kind: "Config"}
+	imageStreamGVK    = schema.GroupVersionKind{Group: "image.openshift.io", Version: "v1", Kind: "ImageStream"}
+	buildGVK          = schema.GroupVersionKind{Group: "build.openshift.io", Version: "v1", Kind: "Build"}
+)
+
+// newRegistryTestClient builds a K8sClient over fake typed and dynamic clients
+func newRegistryTestClient(typed []runtime.Object, dynamic ...runtime.Object) *clients.K8sClient {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(clusterOperatorGVK, meta.RESTScopeRoot)
+	mapper.Add(registryConfigGVK, meta.RESTScopeRoot)
+	mapper.Add(imageStreamGVK, meta.RESTScopeNamespace)
+	mapper.Add(buildGVK, meta.RESTScopeNamespace)
+
+	scheme := runtime.NewScheme()
+	listKinds := map[schema.GroupVersionResource]string{
+		{Group: "image.openshift.io", Version: "v1", Resource: "imagestreams"}: "ImageStreamList",
+		{Group: "build.openshift.io", Version: "v1", Resource: "builds"}:       "BuildList",
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds, dynamic...)
+	return clients.NewK8sClientWithClients(fake.NewClientset(typed...), dynamicClient, mapper)
+}
+
+func newRegistryOperator(available, degraded string) runtime.Object {
+	return newUnstructured(clusterOperatorGVK, "", "image-registry", map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": available, "message": "Available: The registry is ready"},
+				map[string]interface{}{"type": "Degraded", "status": degraded, "message": "Degraded: storage is full"},
+			},
+		},
+	})
+}
+
+func newRegistryConfig(storage map[string]interface{}, storageExists string) runtime.Object {
+	return newUnstructured(registryConfigGVK, "", "cluster", map[string]interface{}{
+		"spec": map[string]interface{}{"managementState": "Managed", "storage": storage},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "StorageExists", "status": storageExists, "message": "S3 bucket exists"},
+			},
+		},
+	})
+}
+
+func newRegistryDeployment(desired, ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: registryDeployment, Namespace: registryNamespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &desired},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: ready},
+	}
+}
+
+func newRegistryPod(name string, ready bool, waitingReason string) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: registryNamespace, Labels: map[string]string{"docker-registry": "default"}},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "registry",
+				RestartCount: 2,
+			}},
+		},
+	}
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses[0].State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason}
+	}
+	return pod
+}
+
+func newImageStream(namespace, name string, tags ...map[string]interface{}) *unstructured.Unstructured {
+	rawTags := make([]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		rawTags = append(rawTags, tag)
+	}
+	return newUnstructured(imageStreamGVK, namespace, name, map[string]interface{}{
+		"status": map[string]interface{}{"tags": rawTags},
+	})
+}
+
+func importTag(tag, status, since string) map[string]interface{} {
+	return map[string]interface{}{
+		"tag": tag,
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"type":               "ImportSuccess",
+				"status":             status,
+				"reason":             "InternalError",
+				"message":            "unauthorized: authentication required",
+				"lastTransitionTime": since,
+			},
+		},
+	}
+}
+
+func newBuild(namespace, name, phase string, completed time.Time) *unstructured.Unstructured {
+	return newUnstructured(buildGVK, namespace, name, map[string]interface{}{
+		"status": map[string]interface{}{
+			"phase":               phase,
+			"reason":              "PushImageToRegistryFailed",
+			"message":             "Failed to push the image to the registry.",
+			"completionTimestamp": completed.UTC().Format(time.RFC3339),
+			"config":              map[string]interface{}{"name": "frontend"},
+			"logSnippet":          "error: build error: Failed to push image: 500 Internal Server Error",
+		},
+	})
+}
+
+func TestGetRegistryHealthTool_Healthy(t *testing.T) {
+	client := newRegistryTestClient(
+		[]runtime.Object{newRegistryDeployment(2, 2), newRegistryPod("image-registry-a", true, ""), newRegistryPod("image-registry-b", true, "")},
+		newRegistryOperator("True", "False"),
+		newRegistryConfig(map[string]interface{}{"s3": map[string]interface{}{"bucket": "registry"}}, "True"),
+		newImageStream("web", "frontend", importTag("latest", "True", "2026-10-14T08:00:00Z")),
+	)
+	tool := NewGetRegistryHealthTool(client, true)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetRegistryHealthOutput)
+
+	assert.Equal(t, RegistryStatusHealthy, output.Status)
+	assert.Empty(t, output.Issues)
+	assert.Equal(t, "Managed", output.ManagementState)
+	assert.Equal(t, "s3", output.Storage.Type)
+	assert.Equal(t, int32(2), output.ReadyReplicas)
+	assert.Len(t, output.Pods, 2)
+	assert.Empty(t, output.ImportFailures)
+	assert.True(t, output.BuildsChecked)
+	assert.Empty(t, output.FailedBuilds)
+}
+
+func TestGetRegistryHealthTool_PushesFailing(t *testing.T) {
+	now := time.Now()
+	client := newRegistryTestClient(
+		[]runtime.Object{newRegistryDeployment(2, 1), newRegistryPod("image-registry-a", true, ""), newRegistryPod("image-registry-b", false, "CrashLoopBackOff")},
+		newRegistryOperator("True", "True"),
+		newRegistryConfig(map[string]interface{}{"pvc": map[string]interface{}{"claim": "registry-storage"}}, "False"),
+		newImageStream("web", "frontend",
+			importTag("latest", "False", "2026-10-14T08:00:00Z"),
+			importTag("v2", "False", "2026-10-14T09:00:00Z")),
+		newImageStream("shop", "cart", importTag("latest", "True", "2026-10-14T08:00:00Z")),
+		newBuild("web", "frontend-7", "Failed", now.Add(-time.Hour)),
+		newBuild("web", "frontend-6", "Error", now.Add(-2*time.Hour)),
+		newBuild("web", "frontend-5", "Complete", now.Add(-3*time.Hour)),
+		newBuild("web", "frontend-2", "Failed", now.Add(-48*time.Hour)),
+	)
+	tool := NewGetRegistryHealthTool(client, true)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"hours": 24})
+	require.NoError(t, err)
+	output := result.(GetRegistryHealthOutput)
+
+	assert.Equal(t, RegistryStatusDegraded, output.Status)
+	assert.Equal(t, "pvc", output.Storage.Type)
+	require.NotNil(t, output.Storage.StorageExists)
+	assert.Equal(t, "False", output.Storage.StorageExists.Status)
+
+	require.Equal(t, 2, output.TotalImportFailures)
+	assert.Equal(t, "v2", output.ImportFailures[0].Tag)
+	assert.Equal(t, "unauthorized: authentication required", output.ImportFailures[0].Message)
+
+	require.Equal(t, 2, output.TotalFailedBuilds)
+	assert.Equal(t, "frontend-7", output.FailedBuilds[0].Name)
+	assert.Equal(t, "frontend", output.FailedBuilds[0].BuildConfig)
+	assert.Equal(t, "Error", output.FailedBuilds[1].Phase)
+	assert.Equal(t, "oc logs -n web build/frontend-7", output.FailedBuilds[0].LogsCommand)
+	assert.Contains(t, output.FailedBuilds[0].LogSnippet, "Failed to push image")
+
+	assert.Contains(t, output.Issues, "image-registry operator is Degraded: Degraded: storage is full")
+	assert.Contains(t, output.Issues, "1 of 2 registry pods are ready")
+	assert.Contains(t, output.Issues, "registry pod image-registry-b is not ready: CrashLoopBackOff")
+	assert.Contains(t, output.Issues, "2 ImageStream tags are failing to import")
+	assert.Contains(t, output.Issues, "2 Builds failed in the selected window")
+	assert.Len(t, output.Findings, 5, "the registry, 2 tags and 2 builds")
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"limit": 1})
+	require.NoError(t, err)
+	output = result.(GetRegistryHealthOutput)
+	assert.Len(t, output.ImportFailures, 1)
+	assert.Len(t, output.FailedBuilds, 1)
+	assert.Equal(t, 2, output.TotalFailedBuilds)
+}
+
+func TestRegistryFindings(t *testing.T) {
+	output := GetRegistryHealthOutput{
+		Status:             RegistryStatusDegraded,
+		Issues:             []string{"1 of 2 registry pods are ready", "2 Builds failed in the selected window"},
+		OperatorConditions: []OperatorCondition{{Type: "Available", Status: "True"}, {Type: "Degraded", Status: "True", Message: "storage is full"}},
+		ImportFailures: []ImageImportFailure{
+			{Namespace: "web", ImageStream: "frontend", Tag: "v2", Reason: "InternalError", Message: "unauthorized: authentication required", Since: "2026-10-14T09:00:00Z"},
+		},
+		FailedBuilds: []FailedBuild{
+			{Namespace: "web", Name: "frontend-7", Reason: "PushImageToRegistryFailed", Message: "Failed to push the image to the registry.",
+				CompletedAt: "2026-10-14T10:00:00Z", LogSnippet: "error: build error: Failed to push image", LogsCommand: "oc logs -n web build/frontend-7"},
+			{Namespace: "web", Name: "frontend-6", Message: "Build was cancelled", CompletedAt: "2026-10-14T09:30:00Z"},
+		},
+	}
+
+	converted := registryFindings(&output)
+	require.Len(t, converted, 4)
+	for _, finding := range converted {
+		assert.Equal(t, findings.SeverityWarning, finding.Severity)
+		assert.Equal(t, "get-registry-health", finding.Source)
+	}
+
+	registry := converted[0]
+	assert.Equal(t, findings.CategoryOperator, registry.Category)
+	assert.Equal(t, "clusteroperator/image-registry", registry.Resource.String())
+	assert.Equal(t, "Image registry is degraded", registry.Title)
+	assert.Equal(t, "1 of 2 registry pods are ready; 2 Builds failed in the selected window", registry.Detail)
+	assert.Equal(t, []findings.Evidence{{Type: findings.EvidenceCondition, Ref: "Degraded", Message: "storage is full"}}, registry.Evidence)
+
+	assert.Equal(t, "build/web/frontend-6", converted[1].Resource.String())
+	assert.Equal(t, "Build was cancelled", converted[1].Detail)
+	assert.Empty(t, converted[1].Evidence)
+
+	build := converted[2]
+	assert.Equal(t, "build/web/frontend-7", build.Resource.String())
+	assert.Equal(t, "Build failed", build.Title)
+	assert.Equal(t, "PushImageToRegistryFailed: Failed to push the image to the registry.", build.Detail)
+	assert.Equal(t, []findings.Evidence{{Type: findings.EvidenceLog, Ref: "build/frontend-7", Message: "error: build error: Failed to push image"}}, build.Evidence)
+	assert.Equal(t, time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC), build.LastSeen)
+
+	tag := converted[3]
+	assert.Equal(t, "imagestreamtag/web/frontend:v2", tag.Resource.String())
+	assert.Equal(t, "ImageStream tag fails to import", tag.Title)
+	assert.Equal(t, time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC), tag.FirstSeen)
+
+	unavailable := registryFindings(&GetRegistryHealthOutput{
+		Status:             RegistryStatusUnavailable,
+		Issues:             []string{"no registry pods are ready"},
+		OperatorConditions: []OperatorCondition{{Type: "Available", Status: "False", Message: "no replicas available"}},
+	})
+	require.Len(t, unavailable, 1)
+	assert.Equal(t, findings.SeverityCritical, unavailable[0].Severity)
+	assert.Equal(t, "Image registry is unavailable", unavailable[0].Title)
+	assert.Equal(t, "Available", unavailable[0].Evidence[0].Ref)
+
+	assert.Empty(t, registryFindings(&GetRegistryHealthOutput{Status: RegistryStatusHealthy}))
+}
+
+func TestGetRegistryHealthTool_Unavailable(t *testing.T) {
+	client := newRegistryTestClient(
+		[]runtime.Object{newRegistryDeployment(1, 0)},
+		newRegistryOperator("False", "False"),
+		newRegistryConfig(map[string]interface{}{"emptyDir": map[string]interface{}{}}, "True"),
+	)
+	tool := NewGetRegistryHealthTool(client, false)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetRegistryHealthOutput)
+
+	assert.Equal(t, RegistryStatusUnavailable, output.Status)
+	assert.False(t, output.BuildsChecked)
+	assert.Contains(t, output.Issues, "no registry pods are ready")
+	assert.Contains(t, output.Issues, "registry uses emptyDir storage; images are lost when the registry pod restarts")
+	assert.NotContains(t, tool.RequiredPermissions(), PermissionRule{Group: "build.openshift.io", Resource: "builds", Verb: "list"})
+}
+
+func TestGetRegistryHealthTool_InvalidArgs(t *testing.T) {
+	tool := NewGetRegistryHealthTool(newRegistryTestClient(nil), true)
+
+	for _, args := range []map[string]interface{}{{"hours": 0}, {"hours": 169}, {"limit": 101}} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err))
+	}
+}
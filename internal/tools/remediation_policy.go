@@ -0,0 +1,249 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/policy"
+)
+
+// RemediationPolicySource gives test-remediation-policy the remediation
+// policies of the server, which provides it
+type RemediationPolicySource interface {
+	// RemediationPolicies returns the configured rules and the evaluator
+	// whose cooldowns and remembered OOM kills apply
+	RemediationPolicies() ([]policy.Rule, *policy.Evaluator)
+	// RemediationBlocked returns why the policy engine would not act now,
+	// or "" when it would
+	RemediationBlocked() string
+}
+
+// CollectPolicySnapshot reads the cluster state rules are matched against:
+// the deployments and pods of the namespaces they name, and the nodes when
+// a rule is about nodes
+func CollectPolicySnapshot(ctx context.Context, k8sClient *clients.K8sClient, rules []policy.Rule) (policy.Snapshot, error) {
+	var snapshot policy.Snapshot
+	var deploymentNamespaces, podNamespaces []string
+	readNodes := false
+	for _, rule := range rules {
+		switch rule.Condition.Type {
+		case policy.ConditionDeploymentUnavailable:
+			if !slices.Contains(deploymentNamespaces, rule.Condition.Namespace) {
+				deploymentNamespaces = append(deploymentNamespaces, rule.Condition.Namespace)
+			}
+		case policy.ConditionPodOOMKilled:
+			if !slices.Contains(podNamespaces, rule.Condition.Namespace) {
+				podNamespaces = append(podNamespaces, rule.Condition.Namespace)
+			}
+		case policy.ConditionNodeNotReady:
+			readNodes = true
+		}
+	}
+
+	for _, namespace := range deploymentNamespaces {
+		deployments, err := k8sClient.ListDeployments(ctx, namespace)
+		if err != nil {
+			return policy.Snapshot{}, err
+		}
+		for i := range deployments.Items {
+			snapshot.Deployments = append(snapshot.Deployments, deploymentPolicyState(&deployments.Items[i]))
+		}
+	}
+	for _, namespace := range podNamespaces {
+		pods, err := k8sClient.ListPods(ctx, namespace)
+		if err != nil {
+			return policy.Snapshot{}, err
+		}
+		for i := range pods.Items {
+			snapshot.OOMKills = append(snapshot.OOMKills, podOOMKills(&pods.Items[i])...)
+		}
+	}
+	if readNodes {
+		nodes, err := k8sClient.ListNodes(ctx)
+		if err != nil {
+			return policy.Snapshot{}, err
+		}
+		for i := range nodes.Items {
+			snapshot.Nodes = append(snapshot.Nodes, nodePolicyState(&nodes.Items[i]))
+		}
+	}
+	return snapshot, nil
+}
+
+// deploymentPolicyState reads since when a deployment has been unavailable,
+// from its Available condition
+func deploymentPolicyState(deployment *appsv1.Deployment) policy.DeploymentState {
+	state := policy.DeploymentState{Namespace: deployment.Namespace, Name: deployment.Name}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type != appsv1.DeploymentAvailable || condition.Status != corev1.ConditionFalse {
+			continue
+		}
+		state.UnavailableSince = condition.LastTransitionTime.Time
+		if state.UnavailableSince.IsZero() {
+			state.UnavailableSince = condition.LastUpdateTime.Time
+		}
+	}
+	return state
+}
+
+// nodePolicyState reads since when a node has not been ready. A node whose
+// kubelet stopped reporting has Ready=Unknown, which counts as not ready.
+func nodePolicyState(node *corev1.Node) policy.NodeState {
+	state := policy.NodeState{Name: node.Name}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
+			state.NotReadySince = condition.LastTransitionTime.Time
+		}
+	}
+	return state
+}
+
+// podOOMKills returns the OOM kills visible in a pod's container statuses:
+// the current or last termination of each container
+func podOOMKills(pod *corev1.Pod) []policy.OOMKill {
+	kind, name := podWorkload(pod)
+	workload := policy.Target{Kind: kind, Namespace: pod.Namespace, Name: name}
+	var kills []policy.OOMKill
+	statuses := append(append([]corev1.ContainerStatus(nil), pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+			if terminated != nil && terminated.Reason == "OOMKilled" && !terminated.FinishedAt.IsZero() {
+				kills = append(kills, policy.OOMKill{Workload: workload, Pod: pod.Name, Container: status.Name, At: terminated.FinishedAt.Time})
+			}
+		}
+	}
+	return kills
+}
+
+// TestRemediationPolicyTool evaluates remediation policies against the cluster without acting
+type TestRemediationPolicyTool struct {
+	k8sClient *clients.K8sClient
+	policies  RemediationPolicySource
+	now       func() time.Time
+}
+
+// NewTestRemediationPolicyTool creates a new test-remediation-policy tool
+func NewTestRemediationPolicyTool(k8sClient *clients.K8sClient, policies RemediationPolicySource) *TestRemediationPolicyTool {
+	return &TestRemediationPolicyTool{k8sClient: k8sClient, policies: policies, now: time.Now}
+}
+
+// Name returns the tool name
+func (t *TestRemediationPolicyTool) Name() string {
+	return "test-remediation-policy"
+}
+
+// Volatility is realtime: a match depends on how long a condition has held right now
+func (t *TestRemediationPolicyTool) Volatility() Volatility {
+	return VolatilityRealtime
+}
+
+// Description returns the tool description
+func (t *TestRemediationPolicyTool) Description() string {
+	return `Dry-run the automatic remediation policies: evaluate the configured rules (or one of them, by name), or a rule passed in, against the cluster as it is now, and list what they match and what the policy engine would do. Nothing is restarted, triggered or announced.
+
+Each decision names the rule, its target, why it matched and the action; decisions held off by the rule's cooldown carry cooldown_until. "blocked" explains why the engine would not act at all right now (policies disabled, read-only mode, or another replica leading). Use it to check a new rule before adding it to the configuration.`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *TestRemediationPolicyTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Evaluate only the configured rule of this name",
+			},
+			"rule": map[string]interface{}{
+				"type":        "object",
+				"description": `A rule to evaluate instead of the configured ones, written as in the remediation_policies configuration, e.g. {"name": "api-down", "condition": {"type": "deployment_unavailable", "namespace": "shop", "name": "api", "for": "10m"}, "action": {"type": "restart_workload"}}`,
+			},
+		},
+	}
+}
+
+// TestRemediationPolicyInput represents the input parameters
+type TestRemediationPolicyInput struct {
+	Name string       `json:"name"`
+	Rule *policy.Rule `json:"rule"`
+}
+
+// TestRemediationPolicyOutput is what the evaluated rules match
+type TestRemediationPolicyOutput struct {
+	EvaluatedAt time.Time         `json:"evaluated_at"`
+	Rules       []string          `json:"rules"`
+	Decisions   []policy.Decision `json:"decisions"`
+	WouldAct    int               `json:"would_act"` // Decisions the engine would act on now
+	Blocked     string            `json:"blocked,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access test-remediation-policy needs
+func (t *TestRemediationPolicyTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "apps", Resource: "deployments", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Resource: "nodes", Verb: "list"},
+	}
+}
+
+// Execute runs the test-remediation-policy operation
+func (t *TestRemediationPolicyTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input TestRemediationPolicyInput
+	argsJSON, _ := json.Marshal(args) //nolint:errcheck // Tool arguments always marshal
+	if err := json.Unmarshal(argsJSON, &input); err != nil {
+		return nil, invalidArgs("invalid rule: %v", err)
+	}
+
+	configured, evaluator := t.policies.RemediationPolicies()
+	rules := configured
+	switch {
+	case input.Rule != nil:
+		if problems := policy.Validate([]policy.Rule{*input.Rule}); len(problems) > 0 {
+			return nil, invalidArgs("%s", strings.Join(problems, "; "))
+		}
+		rules = []policy.Rule{*input.Rule}
+	case input.Name != "":
+		index := slices.IndexFunc(configured, func(rule policy.Rule) bool { return rule.Name == input.Name })
+		if index < 0 {
+			return nil, invalidArgs("no remediation policy named %q is configured", input.Name)
+		}
+		rules = configured[index : index+1]
+	}
+	if evaluator == nil {
+		evaluator = policy.NewEvaluator()
+	}
+
+	output := TestRemediationPolicyOutput{
+		EvaluatedAt: t.now().UTC(),
+		Rules:       make([]string, 0, len(rules)),
+		Decisions:   []policy.Decision{},
+		Blocked:     t.policies.RemediationBlocked(),
+	}
+	for _, rule := range rules {
+		output.Rules = append(output.Rules, rule.Name)
+	}
+	if len(rules) == 0 {
+		return output, nil
+	}
+
+	snapshot, err := CollectPolicySnapshot(ctx, t.k8sClient, rules)
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to read the cluster state of the policies: %w", err))
+	}
+	if decisions := evaluator.Evaluate(output.EvaluatedAt, snapshot, rules); decisions != nil {
+		output.Decisions = decisions
+	}
+	for _, decision := range output.Decisions {
+		if decision.Acts() && output.Blocked == "" {
+			output.WouldAct++
+		}
+	}
+	return output, nil
+}
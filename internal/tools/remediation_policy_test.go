@@ -0,0 +1,221 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/policy"
+)
+
+var policyTestNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+// stubPolicySource serves fixed rules to test-remediation-policy
+type stubPolicySource struct {
+	rules     []policy.Rule
+	evaluator *policy.Evaluator
+	blocked   string
+}
+
+func (s stubPolicySource) RemediationPolicies() ([]policy.Rule, *policy.Evaluator) {
+	return s.rules, s.evaluator
+}
+
+func (s stubPolicySource) RemediationBlocked() string {
+	return s.blocked
+}
+
+func unavailableDeployment(namespace, name string, since time.Time) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue},
+			{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(since)},
+		}},
+	}
+}
+
+func oomKilledPod(name, replicaSet string, current, last time.Time) *corev1.Pod {
+	controller := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "shop",
+			Name:            name,
+			Labels:          map[string]string{"pod-template-hash": "5d4f8"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: replicaSet + "-5d4f8", Controller: &controller}},
+		},
+	}
+	status := corev1.ContainerStatus{Name: "app"}
+	if !current.IsZero() {
+		status.State.Terminated = &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: metav1.NewTime(current)}
+	}
+	if !last.IsZero() {
+		status.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: metav1.NewTime(last)}
+	}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{status}
+	return pod
+}
+
+func policyTestObjects() []runtime.Object {
+	return []runtime.Object{
+		unavailableDeployment("shop", "api", policyTestNow.Add(-20*time.Minute)),
+		unavailableDeployment("shop", "web", policyTestNow.Add(-2*time.Minute)),
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart"}},
+		unavailableDeployment("other", "api", policyTestNow.Add(-time.Hour)),
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, LastTransitionTime: metav1.NewTime(policyTestNow.Add(-30 * time.Minute))},
+			}},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-2"},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+		},
+		oomKilledPod("worker-a", "worker", time.Time{}, policyTestNow.Add(-10*time.Minute)),
+		oomKilledPod("worker-b", "worker", policyTestNow.Add(-5*time.Minute), policyTestNow.Add(-40*time.Minute)),
+	}
+}
+
+func policyTestRules() []policy.Rule {
+	return []policy.Rule{
+		{
+			Name:      "api-down",
+			Condition: policy.Condition{Type: policy.ConditionDeploymentUnavailable, Namespace: "shop", For: policy.Duration(10 * time.Minute)},
+			Action:    policy.Action{Type: policy.ActionRestartWorkload},
+		},
+		{
+			Name:      "node-down",
+			Condition: policy.Condition{Type: policy.ConditionNodeNotReady, For: policy.Duration(15 * time.Minute)},
+			Action:    policy.Action{Type: policy.ActionNotify},
+		},
+		{
+			Name:      "oom",
+			Condition: policy.Condition{Type: policy.ConditionPodOOMKilled, Namespace: "shop", Count: 3, Window: policy.Duration(time.Hour)},
+			Action:    policy.Action{Type: policy.ActionNotify},
+		},
+	}
+}
+
+func TestCollectPolicySnapshot(t *testing.T) {
+	clientset := fake.NewClientset(policyTestObjects()...)
+	snapshot, err := CollectPolicySnapshot(context.Background(), clients.NewK8sClientWithClientset(clientset), policyTestRules())
+	require.NoError(t, err)
+
+	require.Len(t, snapshot.Deployments, 3, "only the namespaces the rules name are read")
+	since := map[string]time.Time{}
+	for _, deployment := range snapshot.Deployments {
+		assert.Equal(t, "shop", deployment.Namespace)
+		since[deployment.Name] = deployment.UnavailableSince
+	}
+	assert.True(t, since["api"].Equal(policyTestNow.Add(-20*time.Minute)))
+	assert.True(t, since["cart"].IsZero(), "a deployment without an Available=False condition is available")
+
+	require.Len(t, snapshot.Nodes, 2)
+	assert.Equal(t, "worker-1", snapshot.Nodes[0].Name)
+	assert.False(t, snapshot.Nodes[0].NotReadySince.IsZero(), "Ready=Unknown counts as not ready")
+	assert.True(t, snapshot.Nodes[1].NotReadySince.IsZero())
+
+	require.Len(t, snapshot.OOMKills, 3, "both the current and last terminations count")
+	for _, kill := range snapshot.OOMKills {
+		assert.Equal(t, policy.Target{Kind: "Deployment", Namespace: "shop", Name: "worker"}, kill.Workload)
+	}
+}
+
+func TestCollectPolicySnapshot_ReadsOnlyWhatRulesNeed(t *testing.T) {
+	clientset := fake.NewClientset(policyTestObjects()...)
+	_, err := CollectPolicySnapshot(context.Background(), clients.NewK8sClientWithClientset(clientset), policyTestRules()[1:2])
+	require.NoError(t, err)
+
+	for _, action := range clientset.Actions() {
+		assert.Equal(t, "nodes", action.GetResource().Resource, "a node rule reads only nodes")
+	}
+}
+
+func TestTestRemediationPolicyTool_Execute(t *testing.T) {
+	clientset := fake.NewClientset(policyTestObjects()...)
+	evaluator := policy.NewEvaluator()
+	tool := NewTestRemediationPolicyTool(clients.NewK8sClientWithClientset(clientset), stubPolicySource{rules: policyTestRules(), evaluator: evaluator})
+	tool.now = func() time.Time { return policyTestNow }
+
+	// The node rule acted ten minutes ago and is in its cooldown
+	evaluator.Acted(policy.Decision{Rule: "node-down", Target: policy.Target{Kind: "Node", Name: "worker-1"}}, policyTestNow.Add(-10*time.Minute))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(TestRemediationPolicyOutput)
+	assert.Equal(t, []string{"api-down", "node-down", "oom"}, output.Rules)
+	require.Len(t, output.Decisions, 3)
+	assert.Equal(t, "api-down", output.Decisions[0].Rule)
+	assert.Equal(t, policy.Target{Kind: "Deployment", Namespace: "shop", Name: "api"}, output.Decisions[0].Target)
+	assert.Equal(t, "node-down", output.Decisions[1].Rule)
+	require.NotNil(t, output.Decisions[1].CooldownUntil)
+	assert.Equal(t, policyTestNow.Add(20*time.Minute), *output.Decisions[1].CooldownUntil)
+	assert.Equal(t, "oom", output.Decisions[2].Rule)
+	assert.Equal(t, 2, output.WouldAct)
+	assert.Empty(t, output.Blocked)
+
+	for _, action := range clientset.Actions() {
+		assert.Equal(t, "list", action.GetVerb(), "a dry-run only reads")
+	}
+}
+
+func TestTestRemediationPolicyTool_Execute_ByNameAndInlineRule(t *testing.T) {
+	tool := NewTestRemediationPolicyTool(clients.NewK8sClientWithClientset(fake.NewClientset(policyTestObjects()...)),
+		stubPolicySource{rules: policyTestRules(), blocked: "remediation policies are disabled"})
+	tool.now = func() time.Time { return policyTestNow }
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"name": "oom"})
+	require.NoError(t, err)
+	output := result.(TestRemediationPolicyOutput)
+	assert.Equal(t, []string{"oom"}, output.Rules)
+	require.Len(t, output.Decisions, 1)
+	assert.Equal(t, 0, output.WouldAct, "nothing would act while the engine is blocked")
+	assert.Equal(t, "remediation policies are disabled", output.Blocked)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"rule": map[string]interface{}{
+		"name":      "web-down",
+		"condition": map[string]interface{}{"type": "deployment_unavailable", "namespace": "shop", "name": "web", "for": "1m"},
+		"action":    map[string]interface{}{"type": "notify"},
+	}})
+	require.NoError(t, err)
+	output = result.(TestRemediationPolicyOutput)
+	assert.Equal(t, []string{"web-down"}, output.Rules)
+	require.Len(t, output.Decisions, 1)
+	assert.Equal(t, "web", output.Decisions[0].Target.Name)
+}
+
+func TestTestRemediationPolicyTool_Execute_InvalidArguments(t *testing.T) {
+	tool := NewTestRemediationPolicyTool(clients.NewK8sClientWithClientset(fake.NewClientset()), stubPolicySource{rules: policyTestRules()})
+
+	for name, args := range map[string]map[string]interface{}{
+		"unknown name":      {"name": "missing"},
+		"invalid rule":      {"rule": map[string]interface{}{"name": "r", "condition": map[string]interface{}{"type": "node_not_ready"}, "action": map[string]interface{}{"type": "notify"}}},
+		"malformed rule":    {"rule": map[string]interface{}{"name": "r", "cooldown": 60}},
+		"restart on a node": {"rule": map[string]interface{}{"name": "r", "condition": map[string]interface{}{"type": "node_not_ready", "for": "1m"}, "action": map[string]interface{}{"type": "restart_workload"}}},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		assert.True(t, IsInvalidArguments(err), "%s: %v", name, err)
+	}
+}
+
+func TestTestRemediationPolicyTool_Execute_NoRules(t *testing.T) {
+	clientset := fake.NewClientset()
+	tool := NewTestRemediationPolicyTool(clients.NewK8sClientWithClientset(clientset), stubPolicySource{})
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(TestRemediationPolicyOutput)
+	assert.Empty(t, output.Rules)
+	assert.Empty(t, output.Decisions)
+	assert.Empty(t, clientset.Actions())
+}
@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+)
+
+// MaxBytesArg is the argument that overrides the server's result size budget
+// for one call. The dispatcher takes it out of the arguments; tools only see
+// the budget through their Truncate method.
+const MaxBytesArg = "_max_bytes"
+
+// MinResultBudget is the smallest result budget accepted, leaving room for
+// the truncation metadata and the fields around the trimmed lists
+const MinResultBudget = 1024
+
+// MaxBytesProperty is the input schema of MaxBytesArg
+func MaxBytesProperty() map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "integer",
+		"description": "Size budget for the JSON result in bytes, overriding the server default. Larger results are truncated, least important items first, and marked with truncated: true. 0 disables the budget.",
+		"minimum":     0,
+	}
+}
+
+// jsonSize returns the length of v encoded as compact JSON
+func jsonSize(v interface{}) int {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(raw)
+}
+
+// keepWithinBudget returns how many of total items to keep so that fits
+// holds: the largest n for which fits(n) is true, or 0. fits must turn false
+// at most once as n grows.
+func keepWithinBudget(total int, fits func(n int) bool) int {
+	n := sort.Search(total+1, func(n int) bool { return !fits(n) }) - 1
+	return max(n, 0)
+}
+
+// importantFirst returns a copy of items with the important ones moved to
+// the front, each group in its original order. Truncation trims the tail,
+// so the important items are the last to go.
+func importantFirst[T any](items []T, important func(T) bool) []T {
+	ordered := slices.Clone(items)
+	slices.SortStableFunc(ordered, func(a, b T) int {
+		switch ia, ib := important(a), important(b); {
+		case ia == ib:
+			return 0
+		case ia:
+			return -1
+		default:
+			return 1
+		}
+	})
+	return ordered
+}
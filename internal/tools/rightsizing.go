@@ -0,0 +1,502 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// PromQL for per-pod usage over a window. %[1]s adds label matchers, %[2]d is the window in minutes.
+const (
+	rightsizingCPUQuery       = `quantile_over_time(0.95, sum by (namespace, pod) (rate(container_cpu_usage_seconds_total{container!="", container!="POD"%[1]s}[5m]))[%[2]dm:5m])`
+	rightsizingMemoryQuery    = `quantile_over_time(0.95, sum by (namespace, pod) (container_memory_working_set_bytes{container!="", container!="POD"%[1]s})[%[2]dm:5m])`
+	rightsizingPeakQuery      = `max_over_time(sum by (namespace, pod) (container_memory_working_set_bytes{container!="", container!="POD"%[1]s})[%[2]dm:5m])`
+	rightsizingThrottledQuery = `sum by (namespace, pod) (increase(container_cpu_cfs_throttled_periods_total{container!=""%[1]s}[%[2]dm])) / sum by (namespace, pod) (increase(container_cpu_cfs_periods_total{container!=""%[1]s}[%[2]dm]))`
+)
+
+// Usage sources reported by get-rightsizing-recommendations
+const (
+	RightsizingSourcePrometheus    = "prometheus"     // p95 over window
+	RightsizingSourceMetricsServer = "metrics_server" // A single current sample
+	RightsizingSourceNone          = "none"           // Requests and limits only
+)
+
+const (
+	// rightsizingHeadroom is added on top of observed p95 usage when suggesting requests
+	rightsizingHeadroom = 1.2
+	// Suggested requests never go below these floors
+	minSuggestedCPUMillicores = 10
+	minSuggestedMemoryBytes   = 64 << 20
+	// cpuThrottledRatio is the share of CFS periods throttled that counts as routine throttling
+	cpuThrottledRatio = 0.25
+	// memoryLimitNearRatio is the share of the memory limit at which OOM kills become likely
+	memoryLimitNearRatio = 0.9
+)
+
+// GetRightsizingRecommendationsTool compares workload requests and limits with observed usage
+type GetRightsizingRecommendationsTool struct {
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient // nil when Prometheus is not configured
+}
+
+// NewGetRightsizingRecommendationsTool creates a new get-rightsizing-recommendations tool; prometheus may be nil
+func NewGetRightsizingRecommendationsTool(k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient) *GetRightsizingRecommendationsTool {
+	return &GetRightsizingRecommendationsTool{
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetRightsizingRecommendationsTool) Name() string {
+	return "get-rightsizing-recommendations"
+}
+
+// Volatility is slow; recommendations follow days of usage, not the last scrape
+func (t *GetRightsizingRecommendationsTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
+// Description returns the tool description for MCP
+func (t *GetRightsizingRecommendationsTool) Description() string {
+	return `Compare workload CPU and memory requests and limits with observed usage and recommend new requests. Usage is the p95 over window from Prometheus when enabled, otherwise the current sample from metrics-server. Reports the most over-requested workloads (usage far below requests) with the capacity they could give back, workloads with no requests or limits, workloads whose CPU is routinely throttled, and workloads running close to their memory limit.
+
+Use this tool for questions like:
+- "Which workloads are wasting the most CPU?"
+- "How much capacity could we reclaim by right-sizing?"
+- "Which pods have no resource requests?"
+- "Is anything being CPU throttled or about to be OOM killed?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetRightsizingRecommendationsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only analyze workloads in this namespace. Leave empty for all namespaces.",
+				"default":     "",
+			},
+			"min_waste_millicores": map[string]interface{}{
+				"type":        "integer",
+				"description": "Only report over-requested workloads that could give back at least this much CPU across all their pods",
+				"default":     100,
+				"minimum":     0,
+			},
+			"window": DurationProperty("Usage window for the p95 when Prometheus is enabled, from 1h to 14d", "24h"),
+			"window_hours": map[string]interface{}{
+				"type":        "integer",
+				"description": "Deprecated: use window. Usage window in hours.",
+				"minimum":     1,
+				"maximum":     336,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of workloads in each list",
+				"default":     10,
+				"minimum":     1,
+				"maximum":     100,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetRightsizingRecommendationsInput represents the input parameters
+type GetRightsizingRecommendationsInput struct {
+	Namespace          string        `json:"namespace"`
+	MinWasteMillicores int64         `json:"min_waste_millicores"`
+	Window             time.Duration `json:"window"` // Parsed by the dispatcher
+	WindowHours        int           `json:"window_hours"`
+	Limit              int           `json:"limit"`
+}
+
+// RightsizingWorkload compares one workload's per-pod requests with its observed usage
+type RightsizingWorkload struct {
+	Kind                     string  `json:"kind"`
+	Namespace                string  `json:"namespace"`
+	Name                     string  `json:"name"`
+	Pods                     int     `json:"pods"`
+	CPURequestMillicores     int64   `json:"cpu_request_millicores"`
+	CPULimitMillicores       int64   `json:"cpu_limit_millicores,omitempty"` // Zero when unlimited
+	MemoryRequestBytes       int64   `json:"memory_request_bytes"`
+	MemoryLimitBytes         int64   `json:"memory_limit_bytes,omitempty"` // Zero when unlimited
+	CPUP95Millicores         int64   `json:"cpu_p95_millicores"`
+	MemoryP95Bytes           int64   `json:"memory_p95_bytes"`
+	MemoryPeakBytes          int64   `json:"memory_peak_bytes"`
+	CPUThrottledPercent      float64 `json:"cpu_throttled_percent,omitempty"`
+	SuggestedCPUMillicores   int64   `json:"suggested_cpu_millicores,omitempty"`
+	SuggestedMemoryBytes     int64   `json:"suggested_memory_bytes,omitempty"`
+	ReclaimableCPUMillicores int64   `json:"reclaimable_cpu_millicores"` // Across all pods
+	ReclaimableMemoryBytes   int64   `json:"reclaimable_memory_bytes"`   // Across all pods
+	HasUsage                 bool    `json:"has_usage"`
+	NoRequests               bool    `json:"no_requests,omitempty"`
+	NoLimits                 bool    `json:"no_limits,omitempty"`
+	CPUThrottled             bool    `json:"cpu_throttled,omitempty"`
+	NearMemoryLimit          bool    `json:"near_memory_limit,omitempty"`
+}
+
+// GetRightsizingRecommendationsOutput represents the tool output
+type GetRightsizingRecommendationsOutput struct {
+	Source                   string                `json:"source"`
+	Window                   string                `json:"window,omitempty"`
+	WindowHours              int                   `json:"window_hours,omitempty"` // Whole hours of Window
+	WorkloadsAnalyzed        int                   `json:"workloads_analyzed"`
+	ReclaimableCPUMillicores int64                 `json:"reclaimable_cpu_millicores"`
+	ReclaimableMemoryBytes   int64                 `json:"reclaimable_memory_bytes"`
+	OverRequested            []RightsizingWorkload `json:"over_requested"`
+	MissingRequests          []RightsizingWorkload `json:"missing_requests"`
+	CPUThrottled             []RightsizingWorkload `json:"cpu_throttled"`
+	NearMemoryLimit          []RightsizingWorkload `json:"near_memory_limit"`
+	Notes                    []string              `json:"notes,omitempty"`
+	Findings                 []findings.Finding    `json:"findings"` // The listed workloads in the common findings shape
+}
+
+// podUsage is the observed usage of one pod, summed over its containers
+type podUsage struct {
+	CPUMillicores   int64
+	MemoryBytes     int64
+	PeakMemoryBytes int64
+	ThrottledRatio  float64 // Share of CFS periods throttled; -1 when unknown
+}
+
+// RequiredPermissions declares the Kubernetes API access get-rightsizing-recommendations needs
+func (t *GetRightsizingRecommendationsTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "pods", Verb: "list"},
+		{Group: "metrics.k8s.io", Resource: "pods", Verb: "list"},
+	}
+}
+
+// Execute runs the get-rightsizing-recommendations operation
+func (t *GetRightsizingRecommendationsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetRightsizingRecommendationsInput{MinWasteMillicores: 100, Window: 24 * time.Hour, Limit: 10}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.MinWasteMillicores < 0 {
+		return nil, invalidArgs("min_waste_millicores must not be negative")
+	}
+	window, err := windowArg(args, "window", input.Window, "window_hours", time.Duration(input.WindowHours)*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	if window < time.Hour || window > 14*24*time.Hour {
+		return nil, invalidArgs("window must be between 1h and 14d")
+	}
+	if input.Limit < 1 || input.Limit > 100 {
+		return nil, invalidArgs("limit must be between 1 and 100")
+	}
+
+	pods, err := t.k8sClient.ListPods(ctx, input.Namespace)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	output := GetRightsizingRecommendationsOutput{Source: RightsizingSourceNone}
+	var usage map[string]podUsage
+	if t.prometheus != nil {
+		usage, err = t.queryUsage(ctx, input.Namespace, window)
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("Prometheus query failed, falling back to metrics-server: %v", err))
+		} else {
+			output.Source = RightsizingSourcePrometheus
+			output.Window = window.String()
+			output.WindowHours = int(window.Hours())
+		}
+	}
+	if usage == nil {
+		usage, err = listPodMetrics(ctx, t.k8sClient, input.Namespace)
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("no usage data, reporting missing requests and limits only: %v", err))
+		} else {
+			output.Source = RightsizingSourceMetricsServer
+			output.Notes = append(output.Notes, "usage is a single metrics-server sample, not a p95; enable Prometheus (ENABLE_PROMETHEUS) for windowed recommendations and CPU throttling")
+		}
+	}
+
+	workloads := rightsizeWorkloads(pods.Items, usage)
+	output.WorkloadsAnalyzed = len(workloads)
+	for _, w := range workloads {
+		output.ReclaimableCPUMillicores += w.ReclaimableCPUMillicores
+		output.ReclaimableMemoryBytes += w.ReclaimableMemoryBytes
+		if w.ReclaimableCPUMillicores > 0 && w.ReclaimableCPUMillicores >= input.MinWasteMillicores {
+			output.OverRequested = append(output.OverRequested, w)
+		}
+		if w.NoRequests || w.NoLimits {
+			output.MissingRequests = append(output.MissingRequests, w)
+		}
+		if w.CPUThrottled {
+			output.CPUThrottled = append(output.CPUThrottled, w)
+		}
+		if w.NearMemoryLimit {
+			output.NearMemoryLimit = append(output.NearMemoryLimit, w)
+		}
+	}
+	sort.SliceStable(output.OverRequested, func(i, j int) bool {
+		return output.OverRequested[i].ReclaimableCPUMillicores > output.OverRequested[j].ReclaimableCPUMillicores
+	})
+	sort.SliceStable(output.CPUThrottled, func(i, j int) bool {
+		return output.CPUThrottled[i].CPUThrottledPercent > output.CPUThrottled[j].CPUThrottledPercent
+	})
+	output.OverRequested = truncateWorkloads(output.OverRequested, input.Limit)
+	output.MissingRequests = truncateWorkloads(output.MissingRequests, input.Limit)
+	output.CPUThrottled = truncateWorkloads(output.CPUThrottled, input.Limit)
+	output.NearMemoryLimit = truncateWorkloads(output.NearMemoryLimit, input.Limit)
+	output.Findings = rightsizingFindings(&output)
+	return output, nil
+}
+
+// rightsizingFindings converts the listed workloads to findings: OOM kill
+// risk and CPU throttling are warnings, missing requests and over-requests
+// are info
+func rightsizingFindings(output *GetRightsizingRecommendationsOutput) []findings.Finding {
+	result := []findings.Finding{}
+	add := func(w RightsizingWorkload, severity findings.Severity, title, detail, action string) {
+		result = append(result, findings.Finding{
+			Severity:        severity,
+			Category:        findings.CategoryWorkload,
+			Resource:        resourceRef(w.Kind, w.Namespace, w.Name),
+			Title:           title,
+			Detail:          detail,
+			SuggestedAction: action,
+			Source:          "get-rightsizing-recommendations",
+		})
+	}
+	for _, w := range output.NearMemoryLimit {
+		add(w, findings.SeverityWarning, "Workload is at risk of OOM kills",
+			fmt.Sprintf("peak memory of %d MiB per pod is %.0f%% of the %d MiB limit", w.MemoryPeakBytes>>20, 100*float64(w.MemoryPeakBytes)/float64(w.MemoryLimitBytes), w.MemoryLimitBytes>>20),
+			fmt.Sprintf("Raise the memory limit above the peak, e.g. to %d MiB, or reduce the workload's memory use", roundUp(int64(float64(w.MemoryPeakBytes)*rightsizingHeadroom), 1<<20)>>20))
+	}
+	for _, w := range output.CPUThrottled {
+		add(w, findings.SeverityWarning, "Workload is CPU throttled",
+			fmt.Sprintf("%.1f%% of CFS periods throttled under a %dm CPU limit", w.CPUThrottledPercent, w.CPULimitMillicores),
+			"Raise or remove the CPU limit")
+	}
+	for _, w := range output.MissingRequests {
+		if w.NoRequests {
+			add(w, findings.SeverityInfo, "Workload has no resource requests", fmt.Sprintf("%d pods request no CPU or memory", w.Pods),
+				"Set CPU and memory requests from observed usage so the scheduler can place the pods")
+			continue
+		}
+		add(w, findings.SeverityInfo, "Workload has no resource limits", fmt.Sprintf("%d pods limit neither CPU nor memory", w.Pods),
+			"Set a memory limit so a leak cannot exhaust the node")
+	}
+	for _, w := range output.OverRequested {
+		add(w, findings.SeverityInfo, "Workload is over-requested",
+			fmt.Sprintf("requests %dm CPU per pod, p95 usage is %dm; %dm CPU and %d MiB memory could be given back", w.CPURequestMillicores, w.CPUP95Millicores, w.ReclaimableCPUMillicores, w.ReclaimableMemoryBytes>>20),
+			fmt.Sprintf("Lower the requests to %dm CPU and %d MiB memory per pod", w.SuggestedCPUMillicores, w.SuggestedMemoryBytes>>20))
+	}
+	findings.Sort(result)
+	return result
+}
+
+// queryUsage reads per-pod p95 usage, peak memory, and CPU throttling from Prometheus
+func (t *GetRightsizingRecommendationsTool) queryUsage(ctx context.Context, namespace string, window time.Duration) (map[string]podUsage, error) {
+	matcher := ""
+	if namespace != "" {
+		matcher = fmt.Sprintf(", namespace=%q", namespace)
+	}
+
+	usage := make(map[string]podUsage)
+	queries := []struct {
+		query string
+		set   func(*podUsage, float64)
+	}{
+		{rightsizingCPUQuery, func(u *podUsage, v float64) { u.CPUMillicores = int64(math.Round(v * 1000)) }},
+		{rightsizingMemoryQuery, func(u *podUsage, v float64) { u.MemoryBytes = int64(v) }},
+		{rightsizingPeakQuery, func(u *podUsage, v float64) { u.PeakMemoryBytes = int64(v) }},
+		{rightsizingThrottledQuery, func(u *podUsage, v float64) { u.ThrottledRatio = v }},
+	}
+	for _, q := range queries {
+		samples, err := t.prometheus.Query(ctx, fmt.Sprintf(q.query, matcher, int(window.Minutes())))
+		if err != nil {
+			return nil, dependencyError(err)
+		}
+		for _, sample := range samples {
+			key := sample.Labels["namespace"] + "/" + sample.Labels["pod"]
+			u, ok := usage[key]
+			if !ok {
+				u = podUsage{ThrottledRatio: -1}
+			}
+			q.set(&u, sample.Value)
+			usage[key] = u
+		}
+	}
+	return usage, nil
+}
+
+// listPodMetrics reads the current usage of every pod from the metrics.k8s.io API, keyed by namespace/pod
+func listPodMetrics(ctx context.Context, k8sClient *clients.K8sClient, namespace string) (map[string]podUsage, error) {
+	list, err := k8sClient.ListResources(ctx, "metrics.k8s.io/v1beta1", "PodMetrics", namespace, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	usage := make(map[string]podUsage, len(list.Items))
+	for _, item := range list.Items {
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		u := podUsage{ThrottledRatio: -1}
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			cpuUsage, _, _ := unstructured.NestedString(container, "usage", "cpu")
+			if cpu, err := resource.ParseQuantity(cpuUsage); err == nil {
+				u.CPUMillicores += cpu.MilliValue()
+			}
+			memoryUsage, _, _ := unstructured.NestedString(container, "usage", "memory")
+			if memory, err := resource.ParseQuantity(memoryUsage); err == nil {
+				u.MemoryBytes += memory.Value()
+			}
+		}
+		u.PeakMemoryBytes = u.MemoryBytes
+		usage[item.GetNamespace()+"/"+item.GetName()] = u
+	}
+	return usage, nil
+}
+
+// rightsizeWorkloads groups running pods by workload and compares their per-pod requests and
+// limits with observed usage (keyed by namespace/pod; nil when no usage source is available).
+// The busiest pod of a workload sets its p95, since all pods share one request.
+func rightsizeWorkloads(pods []corev1.Pod, usage map[string]podUsage) []RightsizingWorkload {
+	workloads := make(map[string]*RightsizingWorkload)
+	var order []string
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		kind, name := podWorkload(pod)
+		key := pod.Namespace + "/" + kind + "/" + name
+		w, ok := workloads[key]
+		if !ok {
+			w = &RightsizingWorkload{Kind: kind, Namespace: pod.Namespace, Name: name}
+			workloads[key] = w
+			order = append(order, key)
+		}
+		w.Pods++
+
+		cpuRequest := podResourceRequest(pod, corev1.ResourceCPU)
+		memoryRequest := podResourceRequest(pod, corev1.ResourceMemory)
+		w.CPURequestMillicores = max64(w.CPURequestMillicores, cpuRequest.MilliValue())
+		w.MemoryRequestBytes = max64(w.MemoryRequestBytes, memoryRequest.Value())
+		cpuLimit, cpuLimited := podResourceLimit(pod, corev1.ResourceCPU)
+		memoryLimit, memoryLimited := podResourceLimit(pod, corev1.ResourceMemory)
+		w.CPULimitMillicores = max64(w.CPULimitMillicores, cpuLimit.MilliValue())
+		w.MemoryLimitBytes = max64(w.MemoryLimitBytes, memoryLimit.Value())
+		w.NoRequests = w.NoRequests || (cpuRequest.IsZero() && memoryRequest.IsZero())
+		w.NoLimits = w.NoLimits || (!cpuLimited && !memoryLimited)
+
+		u, ok := usage[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			continue
+		}
+		w.HasUsage = true
+		w.CPUP95Millicores = max64(w.CPUP95Millicores, u.CPUMillicores)
+		w.MemoryP95Bytes = max64(w.MemoryP95Bytes, u.MemoryBytes)
+		w.MemoryPeakBytes = max64(w.MemoryPeakBytes, max64(u.PeakMemoryBytes, u.MemoryBytes))
+		if u.ThrottledRatio >= 0 {
+			w.CPUThrottledPercent = math.Max(w.CPUThrottledPercent, math.Round(u.ThrottledRatio*1000)/10)
+		}
+	}
+
+	result := make([]RightsizingWorkload, 0, len(order))
+	for _, key := range order {
+		w := workloads[key]
+		if w.HasUsage {
+			w.SuggestedCPUMillicores = suggestRequest(w.CPUP95Millicores, minSuggestedCPUMillicores, 10)
+			// Memory is not compressible, so never suggest less than the observed peak
+			w.SuggestedMemoryBytes = max64(suggestRequest(w.MemoryP95Bytes, minSuggestedMemoryBytes, 1<<20), roundUp(w.MemoryPeakBytes, 1<<20))
+			w.ReclaimableCPUMillicores = reclaimable(w.CPURequestMillicores, w.SuggestedCPUMillicores, w.Pods)
+			w.ReclaimableMemoryBytes = reclaimable(w.MemoryRequestBytes, w.SuggestedMemoryBytes, w.Pods)
+			w.CPUThrottled = w.CPUThrottledPercent >= cpuThrottledRatio*100
+			w.NearMemoryLimit = nearLimit(w.MemoryPeakBytes, w.MemoryLimitBytes)
+		}
+		result = append(result, *w)
+	}
+	return result
+}
+
+// suggestRequest returns p95 usage plus headroom, rounded up to step and never below floor
+func suggestRequest(p95, floor, step int64) int64 {
+	suggested := roundUp(int64(math.Ceil(float64(p95)*rightsizingHeadroom)), step)
+	return max64(suggested, floor)
+}
+
+// reclaimable returns how much of a per-pod request the suggestion gives back across all pods
+func reclaimable(request, suggested int64, pods int) int64 {
+	if request <= suggested {
+		return 0
+	}
+	return (request - suggested) * int64(pods)
+}
+
+// nearLimit reports whether peak usage is within reach of a limit; zero limits are unlimited
+func nearLimit(peak, limit int64) bool {
+	return limit > 0 && float64(peak) >= memoryLimitNearRatio*float64(limit)
+}
+
+// podResourceLimit sums a resource's limits over app containers. ok is false when any
+// container is unlimited, since the pod as a whole is then unlimited too.
+func podResourceLimit(pod *corev1.Pod, name corev1.ResourceName) (resource.Quantity, bool) {
+	total := resource.Quantity{}
+	for i := range pod.Spec.Containers {
+		limit, ok := pod.Spec.Containers[i].Resources.Limits[name]
+		if !ok {
+			return resource.Quantity{}, false
+		}
+		total.Add(limit)
+	}
+	return total, len(pod.Spec.Containers) > 0
+}
+
+// podWorkload returns the kind and name of the controller owning a pod, resolving
+// ReplicaSets to their Deployment through the pod-template-hash label
+func podWorkload(pod *corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "Pod", pod.Name
+	}
+	if hash := pod.Labels["pod-template-hash"]; owner.Kind == "ReplicaSet" && hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+		return "Deployment", strings.TrimSuffix(owner.Name, "-"+hash)
+	}
+	return owner.Kind, owner.Name
+}
+
+// roundUp rounds v up to a multiple of step
+func roundUp(v, step int64) int64 {
+	if step <= 1 || v%step == 0 {
+		return v
+	}
+	return (v/step + 1) * step
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// truncateWorkloads returns at most n workloads
+func truncateWorkloads(workloads []RightsizingWorkload, n int) []RightsizingWorkload {
+	if len(workloads) > n {
+		return workloads[:n]
+	}
+	return workloads
+}
@@ -0,0 +1,354 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+var podMetricsGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}
+
+// newSizedPod creates a running single-container pod; empty quantities are left unset
+func newSizedPod(name, ownerKind, owner, hash, cpuRequest, cpuLimit, memoryRequest, memoryLimit string) corev1.Pod {
+	resources := corev1.ResourceRequirements{Requests: corev1.ResourceList{}, Limits: corev1.ResourceList{}}
+	for _, q := range []struct {
+		list  corev1.ResourceList
+		name  corev1.ResourceName
+		value string
+	}{
+		{resources.Requests, corev1.ResourceCPU, cpuRequest},
+		{resources.Limits, corev1.ResourceCPU, cpuLimit},
+		{resources.Requests, corev1.ResourceMemory, memoryRequest},
+		{resources.Limits, corev1.ResourceMemory, memoryLimit},
+	} {
+		if q.value != "" {
+			q.list[q.name] = resource.MustParse(q.value)
+		}
+	}
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Resources: resources}}},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if owner != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: owner, Controller: &controller}}
+	}
+	if hash != "" {
+		pod.Labels["pod-template-hash"] = hash
+	}
+	return pod
+}
+
+func rightsizingPods() []corev1.Pod {
+	finished := newSizedPod("report-1", "Job", "report", "", "4", "", "8Gi", "")
+	finished.Status.Phase = corev1.PodSucceeded
+	return []corev1.Pod{
+		newSizedPod("api-6d8f9-aaaaa", "ReplicaSet", "api-6d8f9", "6d8f9", "1", "2", "1Gi", "2Gi"),
+		newSizedPod("api-6d8f9-bbbbb", "ReplicaSet", "api-6d8f9", "6d8f9", "1", "2", "1Gi", "2Gi"),
+		newSizedPod("api-6d8f9-ccccc", "ReplicaSet", "api-6d8f9", "6d8f9", "1", "2", "1Gi", "2Gi"),
+		newSizedPod("db-0", "StatefulSet", "db", "", "500m", "", "1Gi", "1Gi"),
+		newSizedPod("scratch", "", "", "", "", "", "", ""),
+		newSizedPod("worker-77c4-xxxxx", "ReplicaSet", "worker-77c4", "77c4", "200m", "200m", "256Mi", "512Mi"),
+		finished,
+	}
+}
+
+func rightsizingUsage() map[string]podUsage {
+	return map[string]podUsage{
+		"shop/api-6d8f9-aaaaa":   {CPUMillicores: 100, MemoryBytes: 150 << 20, PeakMemoryBytes: 200 << 20, ThrottledRatio: 0.01},
+		"shop/api-6d8f9-bbbbb":   {CPUMillicores: 120, MemoryBytes: 200 << 20, PeakMemoryBytes: 300 << 20, ThrottledRatio: 0.01},
+		"shop/api-6d8f9-ccccc":   {CPUMillicores: 150, MemoryBytes: 180 << 20, PeakMemoryBytes: 250 << 20, ThrottledRatio: 0.01},
+		"shop/db-0":              {CPUMillicores: 480, MemoryBytes: 900 << 20, PeakMemoryBytes: 980 << 20, ThrottledRatio: -1},
+		"shop/scratch":           {CPUMillicores: 5, MemoryBytes: 10 << 20, PeakMemoryBytes: 10 << 20, ThrottledRatio: -1},
+		"shop/worker-77c4-xxxxx": {CPUMillicores: 195, MemoryBytes: 100 << 20, PeakMemoryBytes: 120 << 20, ThrottledRatio: 0.4},
+	}
+}
+
+func TestSuggestRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		p95   int64
+		floor int64
+		step  int64
+		want  int64
+	}{
+		{"headroom and rounding", 150, minSuggestedCPUMillicores, 10, 180},
+		{"rounds up to the step", 195, minSuggestedCPUMillicores, 10, 240},
+		{"floor for idle workloads", 5, minSuggestedCPUMillicores, 10, 10},
+		{"memory in MiB", 100 << 20, minSuggestedMemoryBytes, 1 << 20, 120 << 20},
+		{"memory floor", 10 << 20, minSuggestedMemoryBytes, 1 << 20, 64 << 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, suggestRequest(tt.p95, tt.floor, tt.step))
+		})
+	}
+}
+
+func TestReclaimableAndNearLimit(t *testing.T) {
+	assert.Equal(t, int64(2460), reclaimable(1000, 180, 3))
+	assert.Equal(t, int64(0), reclaimable(500, 580, 1))
+	assert.Equal(t, int64(0), reclaimable(0, 10, 4))
+
+	assert.True(t, nearLimit(980<<20, 1<<30))
+	assert.False(t, nearLimit(900<<20, 1<<30))
+	assert.False(t, nearLimit(4<<30, 0), "no limit is never near")
+}
+
+func TestPodWorkload(t *testing.T) {
+	tests := []struct {
+		name     string
+		pod      corev1.Pod
+		wantKind string
+		wantName string
+	}{
+		{"deployment through pod-template-hash", newSizedPod("api-6d8f9-aaaaa", "ReplicaSet", "api-6d8f9", "6d8f9", "", "", "", ""), "Deployment", "api"},
+		{"bare replicaset", newSizedPod("legacy-x", "ReplicaSet", "legacy", "", "", "", "", ""), "ReplicaSet", "legacy"},
+		{"statefulset", newSizedPod("db-0", "StatefulSet", "db", "", "", "", "", ""), "StatefulSet", "db"},
+		{"unowned pod", newSizedPod("scratch", "", "", "", "", "", "", ""), "Pod", "scratch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, name := podWorkload(&tt.pod)
+			assert.Equal(t, tt.wantKind, kind)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
+
+func TestRightsizeWorkloads(t *testing.T) {
+	workloads := rightsizeWorkloads(rightsizingPods(), rightsizingUsage())
+	require.Len(t, workloads, 4)
+	byName := make(map[string]RightsizingWorkload)
+	for _, w := range workloads {
+		byName[w.Name] = w
+	}
+
+	api := byName["api"]
+	assert.Equal(t, "Deployment", api.Kind)
+	assert.Equal(t, 3, api.Pods)
+	assert.Equal(t, int64(1000), api.CPURequestMillicores)
+	assert.Equal(t, int64(150), api.CPUP95Millicores)
+	assert.Equal(t, int64(180), api.SuggestedCPUMillicores)
+	assert.Equal(t, int64(2460), api.ReclaimableCPUMillicores)
+	assert.Equal(t, int64(300<<20), api.SuggestedMemoryBytes, "never below the observed peak")
+	assert.Equal(t, int64(3*724<<20), api.ReclaimableMemoryBytes)
+	assert.False(t, api.NoRequests || api.NoLimits || api.CPUThrottled || api.NearMemoryLimit)
+
+	db := byName["db"]
+	assert.Equal(t, int64(0), db.ReclaimableCPUMillicores)
+	assert.True(t, db.NearMemoryLimit)
+	assert.False(t, db.NoLimits, "a memory limit alone counts")
+	assert.Equal(t, 0.0, db.CPUThrottledPercent)
+
+	scratch := byName["scratch"]
+	assert.True(t, scratch.NoRequests)
+	assert.True(t, scratch.NoLimits)
+	assert.Equal(t, int64(10), scratch.SuggestedCPUMillicores)
+
+	worker := byName["worker"]
+	assert.True(t, worker.CPUThrottled)
+	assert.Equal(t, 40.0, worker.CPUThrottledPercent)
+	assert.Equal(t, int64(136<<20), worker.ReclaimableMemoryBytes)
+
+	withoutUsage := rightsizeWorkloads(rightsizingPods(), nil)
+	require.Len(t, withoutUsage, 4)
+	for _, w := range withoutUsage {
+		assert.False(t, w.HasUsage)
+		assert.Zero(t, w.SuggestedCPUMillicores)
+		assert.Zero(t, w.ReclaimableCPUMillicores)
+	}
+}
+
+func TestRightsizingFindings(t *testing.T) {
+	output := GetRightsizingRecommendationsOutput{}
+	for _, w := range rightsizeWorkloads(rightsizingPods(), rightsizingUsage()) {
+		if w.ReclaimableCPUMillicores > 0 {
+			output.OverRequested = append(output.OverRequested, w)
+		}
+		if w.NoRequests || w.NoLimits {
+			output.MissingRequests = append(output.MissingRequests, w)
+		}
+		if w.CPUThrottled {
+			output.CPUThrottled = append(output.CPUThrottled, w)
+		}
+		if w.NearMemoryLimit {
+			output.NearMemoryLimit = append(output.NearMemoryLimit, w)
+		}
+	}
+
+	converted := rightsizingFindings(&output)
+	require.Len(t, converted, 4)
+	for _, finding := range converted {
+		assert.Equal(t, findings.CategoryWorkload, finding.Category)
+		assert.Equal(t, "get-rightsizing-recommendations", finding.Source)
+	}
+
+	throttled := converted[0]
+	assert.Equal(t, findings.SeverityWarning, throttled.Severity)
+	assert.Equal(t, "deployment/shop/worker", throttled.Resource.String())
+	assert.Equal(t, "Workload is CPU throttled", throttled.Title)
+	assert.Equal(t, "40.0% of CFS periods throttled under a 200m CPU limit", throttled.Detail)
+
+	oom := converted[1]
+	assert.Equal(t, findings.SeverityWarning, oom.Severity)
+	assert.Equal(t, "statefulset/shop/db", oom.Resource.String())
+	assert.Equal(t, "Workload is at risk of OOM kills", oom.Title)
+	assert.Equal(t, "peak memory of 980 MiB per pod is 96% of the 1024 MiB limit", oom.Detail)
+	assert.Contains(t, oom.SuggestedAction, "1176 MiB")
+
+	assert.Equal(t, findings.SeverityInfo, converted[2].Severity)
+	assert.Equal(t, "deployment/shop/api", converted[2].Resource.String())
+	assert.Equal(t, "Workload is over-requested", converted[2].Title)
+	assert.Equal(t, "pod/shop/scratch", converted[3].Resource.String())
+	assert.Equal(t, "Workload has no resource requests", converted[3].Title)
+
+	assert.Empty(t, rightsizingFindings(&GetRightsizingRecommendationsOutput{}))
+}
+
+func TestGetRightsizingRecommendationsTool_Prometheus(t *testing.T) {
+	matcher := `, namespace="shop"`
+	fixture := map[string]string{
+		fmt.Sprintf(rightsizingCPUQuery, matcher, 24*60): `[
+			{"metric":{"namespace":"shop","pod":"api-6d8f9-aaaaa"},"value":[1717243200,"0.1"]},
+			{"metric":{"namespace":"shop","pod":"api-6d8f9-ccccc"},"value":[1717243200,"0.15"]},
+			{"metric":{"namespace":"shop","pod":"db-0"},"value":[1717243200,"0.48"]},
+			{"metric":{"namespace":"shop","pod":"worker-77c4-xxxxx"},"value":[1717243200,"0.195"]}]`,
+		fmt.Sprintf(rightsizingMemoryQuery, matcher, 24*60): `[
+			{"metric":{"namespace":"shop","pod":"api-6d8f9-aaaaa"},"value":[1717243200,"209715200"]},
+			{"metric":{"namespace":"shop","pod":"db-0"},"value":[1717243200,"943718400"]}]`,
+		fmt.Sprintf(rightsizingPeakQuery, matcher, 24*60): `[
+			{"metric":{"namespace":"shop","pod":"api-6d8f9-aaaaa"},"value":[1717243200,"314572800"]},
+			{"metric":{"namespace":"shop","pod":"db-0"},"value":[1717243200,"1027604480"]}]`,
+		fmt.Sprintf(rightsizingThrottledQuery, matcher, 24*60): `[
+			{"metric":{"namespace":"shop","pod":"worker-77c4-xxxxx"},"value":[1717243200,"0.4"]}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, ok := fixture[r.URL.Query().Get("query")]
+		if !ok {
+			result = "[]"
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":%s}}`, result)
+	}))
+	t.Cleanup(server.Close)
+
+	var objects []runtime.Object
+	for _, pod := range rightsizingPods() {
+		objects = append(objects, &pod)
+	}
+	tool := NewGetRightsizingRecommendationsTool(
+		clients.NewK8sClientWithClientset(fake.NewClientset(objects...)),
+		clients.NewPrometheusClient(clients.PrometheusConfig{URL: server.URL}),
+	)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop"})
+	require.NoError(t, err)
+	output := result.(GetRightsizingRecommendationsOutput)
+
+	assert.Equal(t, RightsizingSourcePrometheus, output.Source)
+	assert.Equal(t, "24h0m0s", output.Window)
+	assert.Equal(t, 24, output.WindowHours)
+	assert.Equal(t, 4, output.WorkloadsAnalyzed)
+	assert.Equal(t, int64(2460), output.ReclaimableCPUMillicores)
+	require.Len(t, output.OverRequested, 1)
+	assert.Equal(t, "api", output.OverRequested[0].Name)
+	require.Len(t, output.MissingRequests, 1)
+	assert.Equal(t, "scratch", output.MissingRequests[0].Name)
+	assert.False(t, output.MissingRequests[0].HasUsage)
+	require.Len(t, output.CPUThrottled, 1)
+	assert.Equal(t, "worker", output.CPUThrottled[0].Name)
+	require.Len(t, output.NearMemoryLimit, 1)
+	assert.Equal(t, "db", output.NearMemoryLimit[0].Name)
+	assert.Empty(t, output.Notes)
+	require.Len(t, output.Findings, 4)
+	assert.Equal(t, "Workload is at risk of OOM kills", output.Findings[1].Title)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "min_waste_millicores": 5000})
+	require.NoError(t, err)
+	output = result.(GetRightsizingRecommendationsOutput)
+	assert.Empty(t, output.OverRequested)
+	assert.Equal(t, int64(2460), output.ReclaimableCPUMillicores, "the filter does not change the cluster-wide estimate")
+}
+
+func TestGetRightsizingRecommendationsTool_MetricsServer(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.AddSpecific(podMetricsGVK, podMetricsGVK.GroupVersion().WithResource("pods"), podMetricsGVK.GroupVersion().WithResource("pod"), meta.RESTScopeNamespace)
+	podMetrics := podMetricsGVK.GroupVersion().WithResource("pods")
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{podMetrics: "PodMetricsList"})
+	// PodMetrics are served as "pods", which the tracker cannot guess from the kind
+	require.NoError(t, dynamicClient.Tracker().Create(podMetrics, newUnstructured(podMetricsGVK, "shop", "api-6d8f9-aaaaa", map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "main", "usage": map[string]interface{}{"cpu": "140m", "memory": "200Mi"}},
+			map[string]interface{}{"name": "proxy", "usage": map[string]interface{}{"cpu": "10m", "memory": "56Mi"}},
+		},
+	}), "shop"))
+
+	var objects []runtime.Object
+	for _, pod := range rightsizingPods()[:3] {
+		objects = append(objects, &pod)
+	}
+	tool := NewGetRightsizingRecommendationsTool(clients.NewK8sClientWithClients(fake.NewClientset(objects...), dynamicClient, mapper), nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetRightsizingRecommendationsOutput)
+
+	assert.Equal(t, RightsizingSourceMetricsServer, output.Source)
+	assert.Zero(t, output.WindowHours)
+	require.Len(t, output.OverRequested, 1)
+	assert.Equal(t, int64(150), output.OverRequested[0].CPUP95Millicores)
+	assert.Equal(t, int64(256<<20), output.OverRequested[0].MemoryP95Bytes)
+	require.Len(t, output.Notes, 1)
+	assert.Contains(t, output.Notes[0], "single metrics-server sample")
+}
+
+func TestGetRightsizingRecommendationsTool_NoUsage(t *testing.T) {
+	var objects []runtime.Object
+	for _, pod := range rightsizingPods() {
+		objects = append(objects, &pod)
+	}
+	tool := NewGetRightsizingRecommendationsTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)), nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetRightsizingRecommendationsOutput)
+
+	assert.Equal(t, RightsizingSourceNone, output.Source)
+	assert.Empty(t, output.OverRequested)
+	require.Len(t, output.MissingRequests, 1)
+	require.Len(t, output.Notes, 1)
+	assert.Contains(t, output.Notes[0], "reporting missing requests and limits only")
+}
+
+func TestGetRightsizingRecommendationsTool_InvalidArgs(t *testing.T) {
+	tool := NewGetRightsizingRecommendationsTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	for _, args := range []map[string]interface{}{
+		{"min_waste_millicores": -1}, {"window_hours": 0}, {"window": 30 * time.Minute}, {"window": "15d"}, {"limit": 101},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err))
+	}
+}
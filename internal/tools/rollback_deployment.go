@@ -0,0 +1,197 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RollbackDeploymentTool rolls a Deployment back to an earlier ReplicaSet revision
+type RollbackDeploymentTool struct {
+	k8sClient clients.ClientsetProvider
+}
+
+// NewRollbackDeploymentTool creates a new rollback-deployment tool
+func NewRollbackDeploymentTool(k8sClient clients.ClientsetProvider) *RollbackDeploymentTool {
+	return &RollbackDeploymentTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *RollbackDeploymentTool) Name() string {
+	return "rollback-deployment"
+}
+
+// Description returns the tool description for MCP
+func (t *RollbackDeploymentTool) Description() string {
+	return "Roll a Deployment back to a previous revision (like 'kubectl rollout undo') by re-applying the pod template of the chosen ReplicaSet. Defaults to the previous revision. Refused when the server is in read-only mode."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *RollbackDeploymentTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the Deployment",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the Deployment",
+			},
+			"to_revision": map[string]interface{}{
+				"type":        "integer",
+				"description": "Revision to roll back to (0 = previous revision). See get-rollout-status for available revisions.",
+				"default":     0,
+				"minimum":     0,
+			},
+		},
+		"required": []string{"namespace", "name"},
+	}
+}
+
+// Mutating marks rollback-deployment as changing cluster state
+func (t *RollbackDeploymentTool) Mutating() bool {
+	return true
+}
+
+// SupportsDryRun reports that rollback-deployment honors dry_run with a server-side dry-run update
+func (t *RollbackDeploymentTool) SupportsDryRun() bool {
+	return true
+}
+
+// EventTarget returns the Deployment a rollback's Kubernetes Event is recorded on
+func (t *RollbackDeploymentTool) EventTarget(args map[string]interface{}) *corev1.ObjectReference {
+	namespace, _ := args["namespace"].(string)
+	name, _ := args["name"].(string)
+	return &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: namespace, Name: name}
+}
+
+// RollbackDeploymentInput represents the input parameters
+type RollbackDeploymentInput struct {
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+	ToRevision int64  `json:"to_revision"`
+}
+
+// RollbackDeploymentOutput represents the tool output
+type RollbackDeploymentOutput struct {
+	Namespace            string   `json:"namespace"`
+	Name                 string   `json:"name"`
+	RolledBack           bool     `json:"rolled_back"`
+	FromRevision         int64    `json:"from_revision"`
+	RolledBackToRevision int64    `json:"rolled_back_to_revision"`
+	ReplicaSet           string   `json:"replica_set"`
+	Images               []string `json:"images"`
+	Message              string   `json:"message"`
+	DryRun               bool     `json:"dry_run,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access rollback-deployment needs
+func (t *RollbackDeploymentTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "apps", Resource: "deployments", Verb: "get"},
+		{Group: "apps", Resource: "deployments", Verb: "update"},
+		{Group: "apps", Resource: "replicasets", Verb: "list"},
+	}
+}
+
+// Execute runs the rollback-deployment operation
+func (t *RollbackDeploymentTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input RollbackDeploymentInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, required fields are checked below
+	}
+
+	if input.Namespace == "" || input.Name == "" {
+		return nil, invalidArgs("namespace and name are required")
+	}
+	if input.ToRevision < 0 {
+		return nil, invalidArgs("to_revision must not be negative")
+	}
+
+	deployments := t.k8sClient.Clientset().AppsV1().Deployments(input.Namespace)
+	deployment, err := deployments.Get(ctx, input.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to get deployment %s/%s: %w", input.Namespace, input.Name, err))
+	}
+	if deployment.Spec.Paused {
+		return nil, invalidArgs("deployment %s/%s is paused; resume it before rolling back", input.Namespace, input.Name)
+	}
+
+	replicaSets, err := ownedReplicaSets(ctx, t.k8sClient, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	currentRevision := parseRevision(deployment.Annotations)
+	target, err := rollbackTarget(replicaSets, currentRevision, input.ToRevision)
+	if err != nil {
+		return nil, err
+	}
+
+	output := RollbackDeploymentOutput{
+		Namespace:            input.Namespace,
+		Name:                 input.Name,
+		FromRevision:         currentRevision,
+		RolledBackToRevision: parseRevision(target.Annotations),
+		ReplicaSet:           target.Name,
+		Images:               templateImages(target.Spec.Template),
+		DryRun:               IsDryRun(ctx),
+	}
+
+	// The ReplicaSet template carries the pod-template-hash label added by the controller
+	template := target.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	if apiequality.Semantic.DeepEqual(template, &deployment.Spec.Template) {
+		output.Message = fmt.Sprintf("deployment %s/%s already matches revision %d; nothing to do", input.Namespace, input.Name, output.RolledBackToRevision)
+		return output, nil
+	}
+
+	deployment.Spec.Template = *template
+	if _, err := deployments.Update(ctx, deployment, metav1.UpdateOptions{DryRun: dryRunOption(ctx)}); err != nil {
+		return nil, apiError(fmt.Errorf("failed to roll back deployment %s/%s: %w", input.Namespace, input.Name, err))
+	}
+
+	if output.DryRun {
+		output.Message = fmt.Sprintf("DRY RUN: deployment %s/%s would be rolled back from revision %d to revision %d (%s); the API server accepted the update",
+			input.Namespace, input.Name, currentRevision, output.RolledBackToRevision, target.Name)
+		return output, nil
+	}
+
+	log.Printf("Rolled back deployment %s/%s from revision %d to revision %d (%s)",
+		input.Namespace, input.Name, currentRevision, output.RolledBackToRevision, target.Name)
+
+	output.RolledBack = true
+	output.Message = fmt.Sprintf("deployment %s/%s rolled back to revision %d", input.Namespace, input.Name, output.RolledBackToRevision)
+	return output, nil
+}
+
+// rollbackTarget picks the ReplicaSet to restore from replicaSets (sorted newest first).
+// toRevision 0 selects the newest revision older than currentRevision.
+func rollbackTarget(replicaSets []*appsv1.ReplicaSet, currentRevision, toRevision int64) (*appsv1.ReplicaSet, error) {
+	for _, rs := range replicaSets {
+		revision := parseRevision(rs.Annotations)
+		if toRevision == 0 && revision > 0 && revision < currentRevision {
+			return rs, nil
+		}
+		if toRevision != 0 && revision == toRevision {
+			return rs, nil
+		}
+	}
+
+	if toRevision == 0 {
+		return nil, invalidArgs("no previous revision found to roll back to")
+	}
+	return nil, invalidArgs("revision %d not found", toRevision)
+}
@@ -0,0 +1,392 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// revisionAnnotation is set by the deployment controller on Deployments and their ReplicaSets
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// GetRolloutStatusTool reports rollout progress for Deployments, StatefulSets, and DaemonSets
+type GetRolloutStatusTool struct {
+	k8sClient clients.ClientsetProvider
+}
+
+// NewGetRolloutStatusTool creates a new get-rollout-status tool
+func NewGetRolloutStatusTool(k8sClient clients.ClientsetProvider) *GetRolloutStatusTool {
+	return &GetRolloutStatusTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetRolloutStatusTool) Name() string {
+	return "get-rollout-status"
+}
+
+// NamespaceScoped reports that a rollout is always read in one named namespace
+func (t *GetRolloutStatusTool) NamespaceScoped() bool {
+	return true
+}
+
+// Description returns the tool description for MCP
+func (t *GetRolloutStatusTool) Description() string {
+	return "Get the rollout status of a Deployment, StatefulSet, or DaemonSet (like 'kubectl rollout status'): observed generation, updated/ready/available replicas, progress deadline, and the new and old ReplicaSets with their images."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetRolloutStatusTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the workload",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the workload",
+			},
+			"kind": map[string]interface{}{
+				"type":        "string",
+				"description": "Kind of workload",
+				"enum":        []string{"Deployment", "StatefulSet", "DaemonSet"},
+				"default":     "Deployment",
+			},
+		},
+		"required": []string{"namespace", "name"},
+	}
+}
+
+// GetRolloutStatusInput represents the input parameters
+type GetRolloutStatusInput struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Kind      string `json:"kind"`
+}
+
+// RolloutCondition is the Progressing condition of a Deployment
+type RolloutCondition struct {
+	Status         string `json:"status"`
+	Reason         string `json:"reason,omitempty"`
+	Message        string `json:"message,omitempty"`
+	LastUpdateTime string `json:"last_update_time,omitempty"`
+}
+
+// ReplicaSetRevision describes one ReplicaSet owned by a Deployment
+type ReplicaSetRevision struct {
+	Name          string   `json:"name"`
+	Revision      int64    `json:"revision"`
+	Replicas      int32    `json:"replicas"`
+	ReadyReplicas int32    `json:"ready_replicas"`
+	Images        []string `json:"images"`
+	CreatedAt     string   `json:"created_at"`
+}
+
+// GetRolloutStatusOutput represents the tool output
+type GetRolloutStatusOutput struct {
+	Kind               string `json:"kind"`
+	Namespace          string `json:"namespace"`
+	Name               string `json:"name"`
+	Generation         int64  `json:"generation"`
+	ObservedGeneration int64  `json:"observed_generation"`
+	DesiredReplicas    int32  `json:"desired_replicas"`
+	UpdatedReplicas    int32  `json:"updated_replicas"`
+	ReadyReplicas      int32  `json:"ready_replicas"`
+	AvailableReplicas  int32  `json:"available_replicas"`
+	Complete           bool   `json:"complete"`
+	DeadlineExceeded   bool   `json:"deadline_exceeded"`
+	Paused             bool   `json:"paused,omitempty"`
+	Message            string `json:"message"`
+
+	// Deployment only
+	Revision       int64                `json:"revision,omitempty"`
+	Progressing    *RolloutCondition    `json:"progressing,omitempty"`
+	NewReplicaSet  *ReplicaSetRevision  `json:"new_replica_set,omitempty"`
+	OldReplicaSets []ReplicaSetRevision `json:"old_replica_sets,omitempty"`
+
+	// StatefulSet only
+	CurrentRevision string `json:"current_revision,omitempty"`
+	UpdateRevision  string `json:"update_revision,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access get-rollout-status needs
+func (t *GetRolloutStatusTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "apps", Resource: "deployments", Verb: "get"},
+		{Group: "apps", Resource: "statefulsets", Verb: "get"},
+		{Group: "apps", Resource: "daemonsets", Verb: "get"},
+		{Group: "apps", Resource: "replicasets", Verb: "list"},
+	}
+}
+
+// Execute runs the get-rollout-status operation
+func (t *GetRolloutStatusTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetRolloutStatusInput{Kind: "Deployment"}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, required fields are checked below
+	}
+
+	if input.Namespace == "" || input.Name == "" {
+		return nil, invalidArgs("namespace and name are required")
+	}
+
+	switch input.Kind {
+	case "Deployment":
+		return t.deploymentStatus(ctx, input.Namespace, input.Name)
+	case "StatefulSet":
+		return t.statefulSetStatus(ctx, input.Namespace, input.Name)
+	case "DaemonSet":
+		return t.daemonSetStatus(ctx, input.Namespace, input.Name)
+	default:
+		return nil, invalidArgs("unsupported kind %q (use Deployment, StatefulSet, or DaemonSet)", input.Kind)
+	}
+}
+
+// deploymentStatus mirrors kubectl's DeploymentStatusViewer
+func (t *GetRolloutStatusTool) deploymentStatus(ctx context.Context, namespace, name string) (*GetRolloutStatusOutput, error) {
+	deployment, err := t.k8sClient.Clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err))
+	}
+
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+
+	output := &GetRolloutStatusOutput{
+		Kind:               "Deployment",
+		Namespace:          namespace,
+		Name:               name,
+		Generation:         deployment.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		DesiredReplicas:    desired,
+		UpdatedReplicas:    status.UpdatedReplicas,
+		ReadyReplicas:      status.ReadyReplicas,
+		AvailableReplicas:  status.AvailableReplicas,
+		Paused:             deployment.Spec.Paused,
+		Revision:           parseRevision(deployment.Annotations),
+	}
+
+	for _, cond := range status.Conditions {
+		if cond.Type != appsv1.DeploymentProgressing {
+			continue
+		}
+		output.Progressing = &RolloutCondition{
+			Status:  string(cond.Status),
+			Reason:  cond.Reason,
+			Message: cond.Message,
+		}
+		if !cond.LastUpdateTime.IsZero() {
+			output.Progressing.LastUpdateTime = cond.LastUpdateTime.UTC().Format(time.RFC3339)
+		}
+		output.DeadlineExceeded = cond.Reason == "ProgressDeadlineExceeded"
+	}
+
+	switch {
+	case deployment.Generation > status.ObservedGeneration:
+		output.Message = "Waiting for deployment spec update to be observed"
+	case output.DeadlineExceeded:
+		output.Message = fmt.Sprintf("deployment %q exceeded its progress deadline", name)
+	case status.UpdatedReplicas < desired:
+		output.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated", name, status.UpdatedReplicas, desired)
+	case status.Replicas > status.UpdatedReplicas:
+		output.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination", name, status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		output.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available", name, status.AvailableReplicas, status.UpdatedReplicas)
+	default:
+		output.Complete = true
+		output.Message = fmt.Sprintf("deployment %q successfully rolled out", name)
+	}
+
+	replicaSets, err := ownedReplicaSets(ctx, t.k8sClient, deployment)
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range replicaSets {
+		revision := replicaSetRevision(rs)
+		if revision.Revision == output.Revision && output.NewReplicaSet == nil {
+			output.NewReplicaSet = &revision
+			continue
+		}
+		output.OldReplicaSets = append(output.OldReplicaSets, revision)
+	}
+
+	return output, nil
+}
+
+// statefulSetStatus mirrors kubectl's StatefulSetStatusViewer
+func (t *GetRolloutStatusTool) statefulSetStatus(ctx context.Context, namespace, name string) (*GetRolloutStatusOutput, error) {
+	sts, err := t.k8sClient.Clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to get statefulset %s/%s: %w", namespace, name, err))
+	}
+
+	desired := int32(1)
+	if sts.Spec.Replicas != nil {
+		desired = *sts.Spec.Replicas
+	}
+	status := sts.Status
+
+	output := &GetRolloutStatusOutput{
+		Kind:               "StatefulSet",
+		Namespace:          namespace,
+		Name:               name,
+		Generation:         sts.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		DesiredReplicas:    desired,
+		UpdatedReplicas:    status.UpdatedReplicas,
+		ReadyReplicas:      status.ReadyReplicas,
+		AvailableReplicas:  status.AvailableReplicas,
+		CurrentRevision:    status.CurrentRevision,
+		UpdateRevision:     status.UpdateRevision,
+	}
+
+	var partition int32
+	if rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		partition = *rollingUpdate.Partition
+	}
+
+	switch {
+	case status.ObservedGeneration == 0 || sts.Generation > status.ObservedGeneration:
+		output.Message = "Waiting for statefulset spec update to be observed"
+	case status.ReadyReplicas < desired:
+		output.Message = fmt.Sprintf("Waiting for %d pods to be ready", desired-status.ReadyReplicas)
+	case sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType:
+		output.Complete = true
+		output.Message = "statefulset uses the OnDelete update strategy; pods are updated only when deleted"
+	case partition > 0:
+		if status.UpdatedReplicas < desired-partition {
+			output.Message = fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated", status.UpdatedReplicas, desired-partition)
+		} else {
+			output.Complete = true
+			output.Message = fmt.Sprintf("partitioned roll out complete: %d new pods have been updated", status.UpdatedReplicas)
+		}
+	case status.UpdateRevision != status.CurrentRevision:
+		output.Message = fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s", status.UpdatedReplicas, status.UpdateRevision)
+	default:
+		output.Complete = true
+		output.Message = fmt.Sprintf("statefulset rolling update complete %d pods at revision %s", status.CurrentReplicas, status.CurrentRevision)
+	}
+
+	return output, nil
+}
+
+// daemonSetStatus mirrors kubectl's DaemonSetStatusViewer
+func (t *GetRolloutStatusTool) daemonSetStatus(ctx context.Context, namespace, name string) (*GetRolloutStatusOutput, error) {
+	ds, err := t.k8sClient.Clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to get daemonset %s/%s: %w", namespace, name, err))
+	}
+
+	status := ds.Status
+	output := &GetRolloutStatusOutput{
+		Kind:               "DaemonSet",
+		Namespace:          namespace,
+		Name:               name,
+		Generation:         ds.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		DesiredReplicas:    status.DesiredNumberScheduled,
+		UpdatedReplicas:    status.UpdatedNumberScheduled,
+		ReadyReplicas:      status.NumberReady,
+		AvailableReplicas:  status.NumberAvailable,
+	}
+
+	switch {
+	case ds.Generation > status.ObservedGeneration:
+		output.Message = "Waiting for daemon set spec update to be observed"
+	case status.UpdatedNumberScheduled < status.DesiredNumberScheduled:
+		output.Message = fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated", name, status.UpdatedNumberScheduled, status.DesiredNumberScheduled)
+	case status.NumberAvailable < status.DesiredNumberScheduled:
+		output.Message = fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available", name, status.NumberAvailable, status.DesiredNumberScheduled)
+	default:
+		output.Complete = true
+		output.Message = fmt.Sprintf("daemon set %q successfully rolled out", name)
+	}
+
+	return output, nil
+}
+
+// ownedReplicaSets lists the ReplicaSets controlled by deployment, newest revision first
+func ownedReplicaSets(ctx context.Context, k8sClient clients.ClientsetProvider, deployment *appsv1.Deployment) ([]*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on deployment %s/%s: %w", deployment.Namespace, deployment.Name, err)
+	}
+
+	list, err := k8sClient.Clientset().AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list replicasets for deployment %s/%s: %w", deployment.Namespace, deployment.Name, err))
+	}
+
+	owned := make([]*appsv1.ReplicaSet, 0, len(list.Items))
+	for i := range list.Items {
+		if isControlledBy(list.Items[i].OwnerReferences, deployment.UID) {
+			owned = append(owned, &list.Items[i])
+		}
+	}
+
+	sort.Slice(owned, func(i, j int) bool {
+		return parseRevision(owned[i].Annotations) > parseRevision(owned[j].Annotations)
+	})
+	return owned, nil
+}
+
+// isControlledBy reports whether the controller owner reference points at uid
+func isControlledBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.Controller != nil && *ref.Controller && ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRevision reads the deployment revision annotation (0 if absent)
+func parseRevision(annotations map[string]string) int64 {
+	revision, err := strconv.ParseInt(annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+// replicaSetRevision summarizes a ReplicaSet for rollout output
+func replicaSetRevision(rs *appsv1.ReplicaSet) ReplicaSetRevision {
+	replicas := int32(0)
+	if rs.Spec.Replicas != nil {
+		replicas = *rs.Spec.Replicas
+	}
+	return ReplicaSetRevision{
+		Name:          rs.Name,
+		Revision:      parseRevision(rs.Annotations),
+		Replicas:      replicas,
+		ReadyReplicas: rs.Status.ReadyReplicas,
+		Images:        templateImages(rs.Spec.Template),
+		CreatedAt:     rs.CreationTimestamp.UTC().Format(time.RFC3339),
+	}
+}
+
+// templateImages lists the container images of a pod template
+func templateImages(template corev1.PodTemplateSpec) []string {
+	images := make([]string, 0, len(template.Spec.Containers))
+	for _, container := range template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+	return images
+}
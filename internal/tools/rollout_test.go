@@ -0,0 +1,271 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func podTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web", Image: image}}},
+	}
+}
+
+// newRolloutDeployment returns a deployment at revision 3 running web:v3
+func newRolloutDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "shop",
+			UID:         types.UID("deploy-uid"),
+			Generation:  4,
+			Annotations: map[string]string{revisionAnnotation: "3"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(3),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Template: podTemplate("web:v3"),
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 4,
+			Replicas:           3,
+			UpdatedReplicas:    3,
+			ReadyReplicas:      3,
+			AvailableReplicas:  3,
+		},
+	}
+}
+
+// newRolloutReplicaSet returns a ReplicaSet owned by the test deployment
+func newRolloutReplicaSet(name, revision, image string, replicas int32) *appsv1.ReplicaSet {
+	controller := true
+	template := podTemplate(image)
+	template.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = name
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "shop",
+			Labels:      map[string]string{"app": "web"},
+			Annotations: map[string]string{revisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "web", UID: "deploy-uid", Controller: &controller,
+			}},
+		},
+		Spec:   appsv1.ReplicaSetSpec{Replicas: int32Ptr(replicas), Template: template},
+		Status: appsv1.ReplicaSetStatus{ReadyReplicas: replicas},
+	}
+}
+
+func newRolloutClient(objects ...runtime.Object) (*clients.K8sClient, *fake.Clientset) {
+	clientset := fake.NewClientset(objects...)
+	return clients.NewK8sClientWithClientset(clientset), clientset
+}
+
+func TestGetRolloutStatusTool_DeploymentComplete(t *testing.T) {
+	client, _ := newRolloutClient(
+		newRolloutDeployment(),
+		newRolloutReplicaSet("web-1", "1", "web:v1", 0),
+		newRolloutReplicaSet("web-3", "3", "web:v3", 3),
+		newRolloutReplicaSet("web-2", "2", "web:v2", 0),
+	)
+	tool := NewGetRolloutStatusTool(client)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web"})
+	require.NoError(t, err)
+	status := result.(*GetRolloutStatusOutput)
+
+	assert.True(t, status.Complete)
+	assert.Equal(t, int64(3), status.Revision)
+	require.NotNil(t, status.NewReplicaSet)
+	assert.Equal(t, "web-3", status.NewReplicaSet.Name)
+	assert.Equal(t, []string{"web:v3"}, status.NewReplicaSet.Images)
+	require.Len(t, status.OldReplicaSets, 2)
+	assert.Equal(t, "web-2", status.OldReplicaSets[0].Name)
+	assert.Equal(t, "web-1", status.OldReplicaSets[1].Name)
+}
+
+func TestGetRolloutStatusTool_DeploymentProgress(t *testing.T) {
+	tests := []struct {
+		name            string
+		mutate          func(d *appsv1.Deployment)
+		expectedMessage string
+		deadline        bool
+	}{
+		{
+			name:            "spec not observed",
+			mutate:          func(d *appsv1.Deployment) { d.Status.ObservedGeneration = 3 },
+			expectedMessage: "spec update to be observed",
+		},
+		{
+			name: "replicas updating",
+			mutate: func(d *appsv1.Deployment) {
+				d.Status.UpdatedReplicas = 1
+				d.Status.Replicas = 4
+			},
+			expectedMessage: "1 out of 3 new replicas have been updated",
+		},
+		{
+			name:            "old replicas terminating",
+			mutate:          func(d *appsv1.Deployment) { d.Status.Replicas = 5 },
+			expectedMessage: "2 old replicas are pending termination",
+		},
+		{
+			name:            "waiting for availability",
+			mutate:          func(d *appsv1.Deployment) { d.Status.AvailableReplicas = 2 },
+			expectedMessage: "2 of 3 updated replicas are available",
+		},
+		{
+			name: "progress deadline exceeded",
+			mutate: func(d *appsv1.Deployment) {
+				d.Status.UpdatedReplicas = 1
+				d.Status.Conditions = []appsv1.DeploymentCondition{{
+					Type:   appsv1.DeploymentProgressing,
+					Status: corev1.ConditionFalse,
+					Reason: "ProgressDeadlineExceeded",
+				}}
+			},
+			expectedMessage: "exceeded its progress deadline",
+			deadline:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := newRolloutDeployment()
+			tt.mutate(deployment)
+			client, _ := newRolloutClient(deployment)
+
+			result, err := NewGetRolloutStatusTool(client).Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web"})
+			require.NoError(t, err)
+			status := result.(*GetRolloutStatusOutput)
+
+			assert.False(t, status.Complete)
+			assert.Equal(t, tt.deadline, status.DeadlineExceeded)
+			assert.Contains(t, status.Message, tt.expectedMessage)
+		})
+	}
+}
+
+func TestGetRolloutStatusTool_StatefulSetAndDaemonSet(t *testing.T) {
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop", Generation: 2},
+		Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(3)},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 2, ReadyReplicas: 3, UpdatedReplicas: 1,
+			CurrentRevision: "db-a", UpdateRevision: "db-b",
+		},
+	}
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "shop", Generation: 1},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration: 1, DesiredNumberScheduled: 4, UpdatedNumberScheduled: 4, NumberAvailable: 4, NumberReady: 4,
+		},
+	}
+	client, _ := newRolloutClient(sts, ds)
+	tool := NewGetRolloutStatusTool(client)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "db", "kind": "StatefulSet"})
+	require.NoError(t, err)
+	status := result.(*GetRolloutStatusOutput)
+	assert.False(t, status.Complete)
+	assert.Equal(t, "db-b", status.UpdateRevision)
+	assert.Contains(t, status.Message, "rolling update to complete")
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "agent", "kind": "DaemonSet"})
+	require.NoError(t, err)
+	status = result.(*GetRolloutStatusOutput)
+	assert.True(t, status.Complete)
+	assert.Equal(t, int32(4), status.DesiredReplicas)
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web", "kind": "CronJob"})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
+
+func TestRollbackDeploymentTool_PreviousRevision(t *testing.T) {
+	client, clientset := newRolloutClient(
+		newRolloutDeployment(),
+		newRolloutReplicaSet("web-1", "1", "web:v1", 0),
+		newRolloutReplicaSet("web-2", "2", "web:v2", 0),
+		newRolloutReplicaSet("web-3", "3", "web:v3", 3),
+	)
+	tool := NewRollbackDeploymentTool(client)
+	assert.True(t, tool.Mutating())
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web"})
+	require.NoError(t, err)
+	output := result.(RollbackDeploymentOutput)
+
+	assert.True(t, output.RolledBack)
+	assert.Equal(t, int64(3), output.FromRevision)
+	assert.Equal(t, int64(2), output.RolledBackToRevision)
+	assert.Equal(t, "web-2", output.ReplicaSet)
+
+	updated, err := clientset.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web:v2", updated.Spec.Template.Spec.Containers[0].Image)
+	assert.NotContains(t, updated.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+}
+
+func TestRollbackDeploymentTool_ToRevision(t *testing.T) {
+	client, clientset := newRolloutClient(
+		newRolloutDeployment(),
+		newRolloutReplicaSet("web-1", "1", "web:v1", 0),
+		newRolloutReplicaSet("web-3", "3", "web:v3", 3),
+	)
+	tool := NewRollbackDeploymentTool(client)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web", "to_revision": 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.(RollbackDeploymentOutput).RolledBackToRevision)
+
+	updated, err := clientset.AppsV1().Deployments("shop").Get(context.Background(), "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web:v1", updated.Spec.Template.Spec.Containers[0].Image)
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web", "to_revision": 7})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+	assert.Contains(t, err.Error(), "revision 7 not found")
+}
+
+func TestRollbackDeploymentTool_NoOpAndRefusals(t *testing.T) {
+	client, clientset := newRolloutClient(
+		newRolloutDeployment(),
+		newRolloutReplicaSet("web-3", "3", "web:v3", 3),
+	)
+	tool := NewRollbackDeploymentTool(client)
+
+	// Rolling back to the current template changes nothing
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web", "to_revision": 3})
+	require.NoError(t, err)
+	assert.False(t, result.(RollbackDeploymentOutput).RolledBack)
+	for _, action := range clientset.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb())
+	}
+
+	_, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no previous revision")
+
+	paused := newRolloutDeployment()
+	paused.Spec.Paused = true
+	client, _ = newRolloutClient(paused)
+	_, err = NewRollbackDeploymentTool(client).Execute(context.Background(), map[string]interface{}{"namespace": "shop", "name": "web"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is paused")
+}
@@ -0,0 +1,160 @@
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefaultRedactionPatterns are key fragments whose values are always masked in tool output.
+// Matching is case-insensitive and by substring, so "token" also covers "bearer_token".
+var DefaultRedactionPatterns = []string{
+	"password",
+	"passwd",
+	"token",
+	"secret",
+	"authorization",
+	"apikey",
+	"api_key",
+	"private_key",
+	"privatekey",
+	"credential",
+	"tls.key",
+	"ssh-privatekey",
+}
+
+// CursorKeys are keys of opaque cursors that callers pass back on their next
+// call. They contain "token" but are issued by the server, not secrets, so
+// they are never masked. Matching is exact.
+var CursorKeys = []string{
+	"delta_token",
+	"page_token",
+	"next_page_token",
+}
+
+// redactedPlaceholder replaces values under sensitive keys
+const redactedPlaceholder = "<redacted>"
+
+// Sanitizer masks secret material in tool results before they leave the server.
+// Tools that need to expose derived, non-secret facts (e.g. certificate expiry)
+// must parse them into dedicated fields before the result is sanitized.
+type Sanitizer struct {
+	patterns []string
+}
+
+// NewSanitizer creates a sanitizer using the default patterns plus any extra ones
+func NewSanitizer(extraPatterns []string) *Sanitizer {
+	patterns := make([]string, 0, len(DefaultRedactionPatterns)+len(extraPatterns))
+	for _, pattern := range append(append([]string{}, DefaultRedactionPatterns...), extraPatterns...) {
+		if pattern = strings.ToLower(strings.TrimSpace(pattern)); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return &Sanitizer{patterns: patterns}
+}
+
+// defaultSanitizer is used by the package-level Sanitize helper
+var defaultSanitizer = NewSanitizer(nil)
+
+// Sanitize masks secret material in result using the default patterns
+func Sanitize(result interface{}) (interface{}, error) {
+	return defaultSanitizer.Sanitize(result)
+}
+
+// Sanitize converts result to its JSON shape and masks sensitive values.
+// Values under keys matching a pattern become "<redacted>", and the data/stringData
+// of any Secret object become "<redacted, N bytes>".
+func (s *Sanitizer) Sanitize(result interface{}) (interface{}, error) {
+	if result == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result for sanitization: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal result for sanitization: %w", err)
+	}
+
+	return s.walk(generic), nil
+}
+
+// walk recursively sanitizes a decoded JSON value
+func (s *Sanitizer) walk(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		isSecret := isSecretObject(v)
+		for key, child := range v {
+			switch {
+			case isSecret && (key == "data" || key == "stringData"):
+				v[key] = redactSecretData(key, child)
+			case s.isSensitiveKey(key):
+				if child != nil {
+					v[key] = redactedPlaceholder
+				}
+			default:
+				v[key] = s.walk(child)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = s.walk(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isSensitiveKey reports whether key matches any redaction pattern. Cursor
+// keys never do.
+func (s *Sanitizer) isSensitiveKey(key string) bool {
+	for _, cursor := range CursorKeys {
+		if key == cursor {
+			return false
+		}
+	}
+	lower := strings.ToLower(key)
+	for _, pattern := range s.patterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretObject detects a serialized Kubernetes Secret
+func isSecretObject(obj map[string]interface{}) bool {
+	kind, _ := obj["kind"].(string)
+	return kind == "Secret"
+}
+
+// redactSecretData replaces each Secret data entry with its size only.
+// data values are base64-encoded, so the decoded length is reported.
+func redactSecretData(field string, value interface{}) interface{} {
+	entries, ok := value.(map[string]interface{})
+	if !ok {
+		if value == nil {
+			return nil
+		}
+		return redactedPlaceholder
+	}
+
+	redacted := make(map[string]interface{}, len(entries))
+	for key, entry := range entries {
+		str, _ := entry.(string)
+		size := len(str)
+		if field == "data" {
+			if decoded, err := base64.StdEncoding.DecodeString(str); err == nil {
+				size = len(decoded)
+			}
+		}
+		redacted[key] = fmt.Sprintf("<redacted, %d bytes>", size)
+	}
+	return redacted
+}
@@ -0,0 +1,625 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// searchLogsMaxPods caps the pods whose logs one search reads
+	searchLogsMaxPods = 50
+
+	// searchLogsMaxBytesPerPod caps the log bytes read from one pod across its containers
+	searchLogsMaxBytesPerPod = 8 << 20
+
+	// searchLogsMaxMatches caps max_matches, and so the matches one search returns
+	searchLogsMaxMatches = 500
+
+	// searchLogsConcurrency bounds the pods whose logs are read at once
+	searchLogsConcurrency = 5
+
+	// searchLogsMaxContextLines caps context_lines
+	searchLogsMaxContextLines = 10
+
+	// searchLogsMaxPatternLength bounds the regex a caller may send
+	searchLogsMaxPatternLength = 512
+
+	// searchLogsMaxLineLength truncates returned lines; longer lines are still searched
+	searchLogsMaxLineLength = 2048
+
+	// searchLogsMaxScanLine is the longest log line that is searched at all
+	searchLogsMaxScanLine = 1 << 20
+)
+
+// podLogSource opens container log streams. The Kubernetes client implements
+// it; tests substitute a fake.
+type podLogSource interface {
+	StreamPodLogs(ctx context.Context, namespace, pod, container string, sinceSeconds, limitBytes int64) (io.ReadCloser, error)
+}
+
+// SearchLogsTool searches the logs of every pod of a workload for a pattern
+type SearchLogsTool struct {
+	k8sClient clients.ClientsetProvider
+	logs      podLogSource
+}
+
+// NewSearchLogsTool creates a new search-logs tool
+func NewSearchLogsTool(k8sClient clients.ClusterReader) *SearchLogsTool {
+	return &SearchLogsTool{
+		k8sClient: k8sClient,
+		logs:      k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *SearchLogsTool) Name() string {
+	return "search-logs"
+}
+
+// Volatility is realtime: new log lines arrive all the time
+func (t *SearchLogsTool) Volatility() Volatility {
+	return VolatilityRealtime
+}
+
+// Weight is heavy: a search streams the logs of every matching container
+func (t *SearchLogsTool) Weight() Weight {
+	return WeightHeavy
+}
+
+// NamespaceScoped reports that logs are always searched in one named namespace
+func (t *SearchLogsTool) NamespaceScoped() bool {
+	return true
+}
+
+// Description returns the tool description for MCP
+func (t *SearchLogsTool) Description() string {
+	return fmt.Sprintf(`Search the recent logs of every pod matched by a label selector or workload for a regular expression (RE2 syntax), and return the matching lines with pod, container, timestamp, and surrounding context lines. At most %d pods, %d MiB of logs per pod, and %d matches are read, so results may be partial; the output says when a cap was hit.
+
+Use this tool for questions like:
+- "Which replica of checkout logged a NullPointerException?"
+- "Search the api pods for 'connection refused' in the last 30 minutes"
+- "Did any payments pod log a panic today?"`, searchLogsMaxPods, searchLogsMaxBytesPerPod>>20, searchLogsMaxMatches)
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *SearchLogsTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace of the pods",
+			},
+			"label_selector": map[string]interface{}{
+				"type":        "string",
+				"description": "Label selector for the pods (e.g. 'app=checkout'); use this or workload",
+			},
+			"workload": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the workload whose pods are searched; use this or label_selector",
+			},
+			"workload_kind": map[string]interface{}{
+				"type":        "string",
+				"description": "Kind of the workload",
+				"enum":        []string{"Deployment", "StatefulSet", "DaemonSet"},
+				"default":     "Deployment",
+			},
+			"container": map[string]interface{}{
+				"type":        "string",
+				"description": "Only search this container (default: every container)",
+			},
+			"pattern": map[string]interface{}{
+				"type":        "string",
+				"description": "Regular expression (RE2) matched against each log line; prefix with (?i) to ignore case",
+			},
+			"since": TimeProperty("Search log lines written after this time, at most 24h ago (default: now-1h)"),
+			"since_minutes": map[string]interface{}{
+				"type":        "integer",
+				"description": "Deprecated: use since. How far back to search, in minutes.",
+				"minimum":     1,
+				"maximum":     1440,
+			},
+			"max_matches": map[string]interface{}{
+				"type":        "integer",
+				"description": "Stop after this many matching lines",
+				"default":     50,
+				"minimum":     1,
+				"maximum":     searchLogsMaxMatches,
+			},
+			"context_lines": map[string]interface{}{
+				"type":        "integer",
+				"description": "Lines of context returned before and after each match",
+				"default":     2,
+				"minimum":     0,
+				"maximum":     searchLogsMaxContextLines,
+			},
+		},
+		"required": []string{"namespace", "pattern"},
+	}
+}
+
+// SearchLogsInput represents the input parameters
+type SearchLogsInput struct {
+	Namespace     string `json:"namespace"`
+	LabelSelector string `json:"label_selector"`
+	Workload      string `json:"workload"`
+	WorkloadKind  string `json:"workload_kind"`
+	Container     string `json:"container"`
+	Pattern       string `json:"pattern"`
+	SinceMinutes  int    `json:"since_minutes"`
+	MaxMatches    int    `json:"max_matches"`
+	ContextLines  int    `json:"context_lines"`
+
+	Since time.Time `json:"since"` // Parsed by the dispatcher
+}
+
+// LogMatch is one log line that matched the pattern
+type LogMatch struct {
+	Pod        string   `json:"pod"`
+	Container  string   `json:"container"`
+	Timestamp  string   `json:"timestamp,omitempty"`
+	LineNumber int      `json:"line_number"` // 1-based, within the lines read from the container
+	Line       string   `json:"line"`
+	Before     []string `json:"before,omitempty"`
+	After      []string `json:"after,omitempty"`
+}
+
+// SearchLogsOutput represents the tool output
+type SearchLogsOutput struct {
+	Status         string     `json:"status"`
+	Namespace      string     `json:"namespace"`
+	Selector       string     `json:"selector"`
+	Pattern        string     `json:"pattern"`
+	PodsMatched    int        `json:"pods_matched"`
+	PodsScanned    int        `json:"pods_scanned"`
+	BytesRead      int64      `json:"bytes_read"`
+	MatchCount     int        `json:"match_count"`
+	Matches        []LogMatch `json:"matches"`
+	Partial        bool       `json:"partial"`                    // A cap, deadline, or read error cut the search short
+	ByteCappedPods []string   `json:"byte_capped_pods,omitempty"` // Pods with more logs than the per-pod byte cap
+	Notes          []string   `json:"notes,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access search-logs needs
+func (t *SearchLogsTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "pods", Verb: "list"},
+		{Resource: "pods", Subresource: "log", Verb: "get"},
+		{Group: "apps", Resource: "deployments", Verb: "get"},
+		{Group: "apps", Resource: "statefulsets", Verb: "get"},
+		{Group: "apps", Resource: "daemonsets", Verb: "get"},
+	}
+}
+
+// Execute runs the search-logs operation
+func (t *SearchLogsTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	now := time.Now()
+	input := SearchLogsInput{
+		WorkloadKind: "Deployment",
+		MaxMatches:   50,
+		ContextLines: 2,
+		Since:        now.Add(-time.Hour),
+	}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	since, err := timeArg(args, "since", input.Since, "since_minutes", now.Add(-time.Duration(input.SinceMinutes)*time.Minute), now)
+	if err != nil {
+		return nil, err
+	}
+	lookback := now.Sub(since)
+	if lookback <= 0 || lookback > 24*time.Hour {
+		return nil, invalidArgs("since must be in the last 24h")
+	}
+	pattern, err := validateSearchLogsInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := t.podSelector(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := t.k8sClient.Clientset().CoreV1().Pods(input.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list pods in namespace %s: %w", input.Namespace, err))
+	}
+
+	output := SearchLogsOutput{
+		Status:      "success",
+		Namespace:   input.Namespace,
+		Selector:    selector,
+		Pattern:     input.Pattern,
+		PodsMatched: len(pods.Items),
+		Matches:     []LogMatch{},
+	}
+
+	// Newest pods first: they are the replicas running now
+	targets := pods.Items
+	sort.Slice(targets, func(i, j int) bool {
+		if !targets[i].CreationTimestamp.Equal(&targets[j].CreationTimestamp) {
+			return targets[j].CreationTimestamp.Before(&targets[i].CreationTimestamp)
+		}
+		return targets[i].Name < targets[j].Name
+	})
+	if len(targets) > searchLogsMaxPods {
+		output.Partial = true
+		output.Notes = append(output.Notes, fmt.Sprintf("%d pods matched; only the newest %d were searched", len(targets), searchLogsMaxPods))
+		targets = targets[:searchLogsMaxPods]
+	}
+
+	search := &logSearch{
+		source:       t.logs,
+		namespace:    input.Namespace,
+		container:    input.Container,
+		pattern:      pattern,
+		sinceSeconds: int64(math.Ceil(lookback.Seconds())),
+		maxMatches:   input.MaxMatches,
+		contextLines: input.ContextLines,
+	}
+	results := search.run(ctx, targets)
+
+	for _, result := range results {
+		if result.scanned {
+			output.PodsScanned++
+		}
+		output.BytesRead += result.bytesRead
+		if result.byteCapped {
+			output.Partial = true
+			output.ByteCappedPods = append(output.ByteCappedPods, result.pod)
+		}
+		for _, err := range result.errors {
+			output.Partial = true
+			output.Notes = append(output.Notes, err)
+		}
+	}
+	if search.matchesCapped() {
+		output.Partial = true
+		output.Notes = append(output.Notes, fmt.Sprintf("stopped after %d matches (max_matches)", input.MaxMatches))
+	}
+	if ctx.Err() != nil {
+		output.Partial = true
+		output.Notes = append(output.Notes, fmt.Sprintf("search aborted before all pods were read: %v", ctx.Err()))
+	}
+
+	output.Matches = search.sortedMatches()
+	output.MatchCount = len(output.Matches)
+	sort.Strings(output.ByteCappedPods)
+	if output.PodsMatched == 0 {
+		output.Notes = append(output.Notes, fmt.Sprintf("no pods match selector %q", selector))
+	}
+	return output, nil
+}
+
+// validateSearchLogsInput checks the arguments and compiles the pattern
+func validateSearchLogsInput(input SearchLogsInput) (*regexp.Regexp, error) {
+	if input.Namespace == "" {
+		return nil, invalidArgs("namespace is required")
+	}
+	if (input.LabelSelector == "") == (input.Workload == "") {
+		return nil, invalidArgs("exactly one of label_selector or workload is required")
+	}
+	if input.Pattern == "" {
+		return nil, invalidArgs("pattern is required")
+	}
+	if len(input.Pattern) > searchLogsMaxPatternLength {
+		return nil, invalidArgs("pattern must be at most %d characters", searchLogsMaxPatternLength)
+	}
+	if input.MaxMatches < 1 || input.MaxMatches > searchLogsMaxMatches {
+		return nil, invalidArgs("max_matches must be between 1 and %d", searchLogsMaxMatches)
+	}
+	if input.ContextLines < 0 || input.ContextLines > searchLogsMaxContextLines {
+		return nil, invalidArgs("context_lines must be between 0 and %d", searchLogsMaxContextLines)
+	}
+
+	pattern, err := regexp.Compile(input.Pattern)
+	if err != nil {
+		return nil, invalidArgs("invalid pattern: %v", err)
+	}
+	return pattern, nil
+}
+
+// podSelector returns the label selector of the pods to search
+func (t *SearchLogsTool) podSelector(ctx context.Context, input SearchLogsInput) (string, error) {
+	if input.LabelSelector != "" {
+		if _, err := labels.Parse(input.LabelSelector); err != nil {
+			return "", invalidArgs("invalid label_selector: %v", err)
+		}
+		return input.LabelSelector, nil
+	}
+
+	apps := t.k8sClient.Clientset().AppsV1()
+	var selector *metav1.LabelSelector
+	switch input.WorkloadKind {
+	case "Deployment":
+		deployment, err := apps.Deployments(input.Namespace).Get(ctx, input.Workload, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get deployment %s/%s: %w", input.Namespace, input.Workload, err)
+		}
+		selector = deployment.Spec.Selector
+	case "StatefulSet":
+		statefulSet, err := apps.StatefulSets(input.Namespace).Get(ctx, input.Workload, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get statefulset %s/%s: %w", input.Namespace, input.Workload, err)
+		}
+		selector = statefulSet.Spec.Selector
+	case "DaemonSet":
+		daemonSet, err := apps.DaemonSets(input.Namespace).Get(ctx, input.Workload, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get daemonset %s/%s: %w", input.Namespace, input.Workload, err)
+		}
+		selector = daemonSet.Spec.Selector
+	default:
+		return "", invalidArgs("workload_kind must be Deployment, StatefulSet, or DaemonSet")
+	}
+
+	parsed, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector on %s %s/%s: %w", input.WorkloadKind, input.Namespace, input.Workload, err)
+	}
+	if parsed.Empty() {
+		return "", fmt.Errorf("%s %s/%s has an empty selector", input.WorkloadKind, input.Namespace, input.Workload)
+	}
+	return parsed.String(), nil
+}
+
+// logSearch fans a pattern search out over pods, collecting matches up to maxMatches
+type logSearch struct {
+	source       podLogSource
+	namespace    string
+	container    string // Empty searches every container
+	pattern      *regexp.Regexp
+	sinceSeconds int64
+	maxMatches   int
+	contextLines int
+
+	mu      sync.Mutex
+	matches []LogMatch
+	capped  bool
+	cancel  context.CancelFunc // Stops the remaining reads once maxMatches is reached
+}
+
+// podSearchResult is what one pod's search read and what went wrong
+type podSearchResult struct {
+	pod        string
+	scanned    bool
+	bytesRead  int64
+	byteCapped bool
+	errors     []string
+}
+
+// run searches pods with at most searchLogsConcurrency reads in flight. Reads
+// stop early when ctx is done or maxMatches is reached; results are per pod.
+func (s *logSearch) run(ctx context.Context, pods []corev1.Pod) []podSearchResult {
+	ctx, s.cancel = context.WithCancel(ctx)
+	defer s.cancel()
+
+	results := make([]podSearchResult, len(pods))
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, searchLogsConcurrency)
+	for i := range pods {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = podSearchResult{pod: pods[i].Name}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			results[i] = s.searchPod(ctx, &pods[i])
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// searchPod reads each container of pod in turn within the per-pod byte cap
+func (s *logSearch) searchPod(ctx context.Context, pod *corev1.Pod) podSearchResult {
+	result := podSearchResult{pod: pod.Name}
+	if ctx.Err() != nil {
+		return result
+	}
+
+	remaining := int64(searchLogsMaxBytesPerPod)
+	for _, container := range pod.Spec.Containers {
+		if s.container != "" && container.Name != s.container {
+			continue
+		}
+		if remaining <= 0 {
+			result.byteCapped = true
+			break
+		}
+
+		n, err := s.searchContainer(ctx, pod.Name, container.Name, remaining)
+		result.scanned = true
+		result.bytesRead += n
+		remaining -= n
+		if remaining <= 0 {
+			result.byteCapped = true
+		}
+		if err != nil && ctx.Err() == nil {
+			result.errors = append(result.errors, fmt.Sprintf("%s/%s: %v", pod.Name, container.Name, err))
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return result
+}
+
+// searchContainer scans one container's log stream, reading at most limit bytes
+func (s *logSearch) searchContainer(ctx context.Context, pod, container string, limit int64) (int64, error) {
+	stream, err := s.source.StreamPodLogs(ctx, s.namespace, pod, container, s.sinceSeconds, limit)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	counter := &countingReader{reader: io.LimitReader(stream, limit)}
+	err = scanLogLines(counter, s.pattern, s.contextLines, func(match LogMatch) bool {
+		match.Pod, match.Container = pod, container
+		return s.add(match)
+	})
+	return counter.count, err
+}
+
+// add records a match, reporting false once maxMatches is reached
+func (s *logSearch) add(match LogMatch) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.matches) >= s.maxMatches {
+		s.capped = true
+		s.cancel()
+		return false
+	}
+	s.matches = append(s.matches, match)
+	if len(s.matches) == s.maxMatches {
+		s.capped = true
+		s.cancel()
+		return false
+	}
+	return true
+}
+
+// matchesCapped reports whether the search stopped at maxMatches
+func (s *logSearch) matchesCapped() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.capped
+}
+
+// sortedMatches returns the matches ordered by pod, container, and line
+func (s *logSearch) sortedMatches() []LogMatch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	matches := make([]LogMatch, len(s.matches))
+	copy(matches, s.matches)
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Pod != matches[j].Pod {
+			return matches[i].Pod < matches[j].Pod
+		}
+		if matches[i].Container != matches[j].Container {
+			return matches[i].Container < matches[j].Container
+		}
+		return matches[i].LineNumber < matches[j].LineNumber
+	})
+	return matches
+}
+
+// scanLogLines matches pattern against each line of r and passes every match,
+// with contextLines lines before and after it, to emit. Scanning stops when
+// emit returns false. Lines longer than searchLogsMaxScanLine end the scan with an error.
+func scanLogLines(r io.Reader, pattern *regexp.Regexp, contextLines int, emit func(LogMatch) bool) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), searchLogsMaxScanLine)
+
+	var before []string    // The last contextLines lines
+	var pending []LogMatch // Matches still collecting After lines
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		timestamp, full := SplitLogTimestamp(scanner.Text())
+		text := truncateLogLine(full)
+
+		// Complete matches whose After context this line finishes
+		kept := pending[:0]
+		for _, match := range pending {
+			match.After = append(match.After, text)
+			if len(match.After) < contextLines {
+				kept = append(kept, match)
+				continue
+			}
+			if !emit(match) {
+				return nil
+			}
+		}
+		pending = kept
+
+		if pattern.MatchString(full) {
+			match := LogMatch{Timestamp: timestamp, LineNumber: lineNumber, Line: text}
+			if len(before) > 0 {
+				match.Before = append([]string(nil), before...)
+			}
+			if contextLines == 0 {
+				if !emit(match) {
+					return nil
+				}
+			} else {
+				pending = append(pending, match)
+			}
+		}
+
+		if contextLines > 0 {
+			before = append(before, text)
+			if len(before) > contextLines {
+				before = before[1:]
+			}
+		}
+	}
+
+	// Matches near the end of the log have less After context
+	for _, match := range pending {
+		if !emit(match) {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("log line longer than %d bytes; the rest of the log was not searched", searchLogsMaxScanLine)
+		}
+		return err
+	}
+	return nil
+}
+
+// SplitLogTimestamp separates the RFC 3339 timestamp the API server prefixes
+// to each line when timestamps are requested
+func SplitLogTimestamp(line string) (string, string) {
+	timestamp, text, found := strings.Cut(line, " ")
+	if !found {
+		return "", line
+	}
+	if _, err := time.Parse(time.RFC3339Nano, timestamp); err != nil {
+		return "", line
+	}
+	return timestamp, text
+}
+
+// truncateLogLine shortens a line for output to searchLogsMaxLineLength bytes
+func truncateLogLine(line string) string {
+	if len(line) <= searchLogsMaxLineLength {
+		return line
+	}
+	return line[:searchLogsMaxLineLength] + "...(truncated)"
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
@@ -0,0 +1,362 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// fakeLogSource serves fixed logs per "pod/container". Pods listed in endless
+// stream noise forever and pods in hang block until the read is canceled.
+type fakeLogSource struct {
+	logs    map[string]string
+	endless map[string]bool
+	hang    map[string]bool
+
+	mu     sync.Mutex
+	opened []string
+	since  []int64 // sinceSeconds of each read
+}
+
+func (f *fakeLogSource) StreamPodLogs(ctx context.Context, namespace, pod, container string, sinceSeconds, limitBytes int64) (io.ReadCloser, error) {
+	f.mu.Lock()
+	f.opened = append(f.opened, pod+"/"+container)
+	f.since = append(f.since, sinceSeconds)
+	f.mu.Unlock()
+
+	switch {
+	case f.hang[pod]:
+		return io.NopCloser(&blockingReader{ctx: ctx}), nil
+	case f.endless[pod]:
+		return io.NopCloser(&repeatReader{line: []byte("2024-06-01T12:00:00Z noise\n")}), nil
+	}
+	logs, ok := f.logs[pod+"/"+container]
+	if !ok {
+		return nil, fmt.Errorf("container %s is waiting to start", container)
+	}
+	return io.NopCloser(strings.NewReader(logs)), nil
+}
+
+// repeatReader returns line over and over
+type repeatReader struct {
+	line []byte
+	pos  int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		copied := copy(p[n:], r.line[r.pos:])
+		n += copied
+		r.pos = (r.pos + copied) % len(r.line)
+	}
+	return n, nil
+}
+
+// blockingReader blocks until its context is done, like a stalled log stream
+type blockingReader struct {
+	ctx context.Context
+}
+
+func (r *blockingReader) Read([]byte) (int, error) {
+	<-r.ctx.Done()
+	return 0, r.ctx.Err()
+}
+
+func logPod(name string, created time.Time, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "shop",
+			Labels:            map[string]string{"app": "checkout"},
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container})
+	}
+	return pod
+}
+
+func newSearchLogsTool(source *fakeLogSource, objects ...runtime.Object) *SearchLogsTool {
+	tool := NewSearchLogsTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)))
+	tool.logs = source
+	return tool
+}
+
+const checkoutLog = `2024-06-01T12:00:00Z starting checkout
+2024-06-01T12:00:01Z handling order 1
+2024-06-01T12:00:02Z panic: nil pointer dereference
+2024-06-01T12:00:03Z goroutine 1 [running]:
+2024-06-01T12:00:04Z main.handle()
+2024-06-01T12:00:05Z restarting
+`
+
+func TestScanLogLines(t *testing.T) {
+	var matches []LogMatch
+	err := scanLogLines(strings.NewReader(checkoutLog), regexp.MustCompile(`panic|restarting`), 2, func(m LogMatch) bool {
+		matches = append(matches, m)
+		return true
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+
+	assert.Equal(t, LogMatch{
+		Timestamp:  "2024-06-01T12:00:02Z",
+		LineNumber: 3,
+		Line:       "panic: nil pointer dereference",
+		Before:     []string{"starting checkout", "handling order 1"},
+		After:      []string{"goroutine 1 [running]:", "main.handle()"},
+	}, matches[0])
+
+	// The last line has no After context left
+	assert.Equal(t, 6, matches[1].LineNumber)
+	assert.Equal(t, []string{"goroutine 1 [running]:", "main.handle()"}, matches[1].Before)
+	assert.Empty(t, matches[1].After)
+}
+
+func TestScanLogLines_StopsWhenEmitDeclines(t *testing.T) {
+	calls := 0
+	err := scanLogLines(strings.NewReader(checkoutLog), regexp.MustCompile(`.`), 0, func(LogMatch) bool {
+		calls++
+		return calls < 2
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestScanLogLines_LongLines(t *testing.T) {
+	long := strings.Repeat("x", searchLogsMaxLineLength+10) + " ERROR at the end\n"
+	var matches []LogMatch
+	err := scanLogLines(strings.NewReader(long), regexp.MustCompile(`ERROR`), 0, func(m LogMatch) bool {
+		matches = append(matches, m)
+		return true
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 1, "the whole line is searched even though output is truncated")
+	assert.True(t, strings.HasSuffix(matches[0].Line, "...(truncated)"))
+}
+
+func TestSearchLogsTool_Execute(t *testing.T) {
+	now := time.Now()
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "shop"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "checkout"}},
+		},
+	}
+	other := logPod("other-1", now, "app")
+	other.Labels = map[string]string{"app": "other"}
+
+	source := &fakeLogSource{logs: map[string]string{
+		"checkout-1/app":     checkoutLog,
+		"checkout-1/sidecar": "2024-06-01T12:00:00Z proxy ready\n",
+		"checkout-2/app":     "2024-06-01T12:00:00Z starting checkout\n",
+		"other-1/app":        "2024-06-01T12:00:00Z panic: not searched\n",
+	}}
+	tool := newSearchLogsTool(source,
+		deployment,
+		logPod("checkout-1", now, "app", "sidecar"),
+		logPod("checkout-2", now.Add(-time.Hour), "app"),
+		logPod("checkout-3", now.Add(-2*time.Hour), "app"),
+		other,
+	)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"namespace": "shop",
+		"workload":  "checkout",
+		"pattern":   "(?i)PANIC",
+	})
+	require.NoError(t, err)
+
+	output := result.(SearchLogsOutput)
+	assert.Equal(t, "app=checkout", output.Selector)
+	assert.Equal(t, 3, output.PodsMatched)
+	assert.Equal(t, 3, output.PodsScanned)
+	require.Equal(t, 1, output.MatchCount)
+	assert.Equal(t, "checkout-1", output.Matches[0].Pod)
+	assert.Equal(t, "app", output.Matches[0].Container)
+	assert.Len(t, output.Matches[0].Before, 2)
+
+	// checkout-3 has no logs yet: reported, not fatal
+	assert.True(t, output.Partial)
+	require.Len(t, output.Notes, 1)
+	assert.Contains(t, output.Notes[0], "checkout-3/app")
+}
+
+func TestSearchLogsTool_MatchCap(t *testing.T) {
+	now := time.Now()
+	lines := strings.Repeat("2024-06-01T12:00:00Z ERROR timeout\n", 100)
+	source := &fakeLogSource{logs: map[string]string{"checkout-1/app": lines, "checkout-2/app": lines}}
+	tool := newSearchLogsTool(source, logPod("checkout-1", now, "app"), logPod("checkout-2", now, "app"))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"namespace":      "shop",
+		"label_selector": "app=checkout",
+		"pattern":        "ERROR",
+		"max_matches":    7,
+		"context_lines":  0,
+	})
+	require.NoError(t, err)
+
+	output := result.(SearchLogsOutput)
+	assert.Equal(t, 7, output.MatchCount)
+	assert.True(t, output.Partial)
+	assert.Contains(t, output.Notes, "stopped after 7 matches (max_matches)")
+}
+
+func TestSearchLogsTool_ByteCap(t *testing.T) {
+	now := time.Now()
+	source := &fakeLogSource{
+		logs:    map[string]string{"quiet-1/app": "2024-06-01T12:00:00Z ok\n"},
+		endless: map[string]bool{"chatty-1": true},
+	}
+	chatty := logPod("chatty-1", now, "app", "sidecar")
+	quiet := logPod("quiet-1", now, "app")
+	tool := newSearchLogsTool(source, chatty, quiet)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"namespace":      "shop",
+		"label_selector": "app=checkout",
+		"pattern":        "never-matches",
+	})
+	require.NoError(t, err)
+
+	output := result.(SearchLogsOutput)
+	assert.True(t, output.Partial)
+	assert.Equal(t, []string{"chatty-1"}, output.ByteCappedPods)
+	assert.Equal(t, int64(searchLogsMaxBytesPerPod)+int64(len("2024-06-01T12:00:00Z ok\n")), output.BytesRead)
+	assert.NotContains(t, source.opened, "chatty-1/sidecar", "the per-pod budget is shared across containers")
+}
+
+func TestSearchLogsTool_PodCap(t *testing.T) {
+	now := time.Now()
+	source := &fakeLogSource{logs: map[string]string{}}
+	var objects []runtime.Object
+	for i := 0; i < searchLogsMaxPods+5; i++ {
+		name := fmt.Sprintf("checkout-%02d", i)
+		source.logs[name+"/app"] = "2024-06-01T12:00:00Z ok\n"
+		objects = append(objects, logPod(name, now.Add(-time.Duration(i)*time.Minute), "app"))
+	}
+	tool := newSearchLogsTool(source, objects...)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{
+		"namespace":      "shop",
+		"label_selector": "app=checkout",
+		"pattern":        "ok",
+		"max_matches":    searchLogsMaxMatches,
+	})
+	require.NoError(t, err)
+
+	output := result.(SearchLogsOutput)
+	assert.Equal(t, searchLogsMaxPods+5, output.PodsMatched)
+	assert.Equal(t, searchLogsMaxPods, output.PodsScanned)
+	assert.True(t, output.Partial)
+	assert.NotContains(t, source.opened, fmt.Sprintf("checkout-%02d/app", searchLogsMaxPods), "the oldest pods are skipped")
+}
+
+func TestSearchLogsTool_DeadlineReturnsPartialResults(t *testing.T) {
+	now := time.Now()
+	source := &fakeLogSource{
+		logs: map[string]string{"checkout-1/app": checkoutLog},
+		hang: map[string]bool{"checkout-2": true},
+	}
+	tool := newSearchLogsTool(source, logPod("checkout-1", now, "app"), logPod("checkout-2", now, "app"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	result, err := tool.Execute(ctx, map[string]interface{}{
+		"namespace":      "shop",
+		"label_selector": "app=checkout",
+		"pattern":        "panic",
+	})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "the stalled stream must be abandoned at the deadline")
+
+	output := result.(SearchLogsOutput)
+	assert.True(t, output.Partial)
+	require.Equal(t, 1, output.MatchCount)
+	assert.Equal(t, "checkout-1", output.Matches[0].Pod)
+	assert.Contains(t, strings.Join(output.Notes, "\n"), "search aborted")
+}
+
+func TestSearchLogsTool_Since(t *testing.T) {
+	now := time.Now()
+	source := &fakeLogSource{logs: map[string]string{"checkout-1/app": checkoutLog}}
+	pod := logPod("checkout-1", now, "app")
+	pod.Labels = map[string]string{"app": "checkout"}
+	tool := newSearchLogsTool(source, pod)
+	search := map[string]interface{}{"namespace": "shop", "label_selector": "app=checkout", "pattern": "panic"}
+
+	for _, tc := range []struct {
+		since interface{}
+		want  int64
+	}{
+		{nil, 3600},                         // Default: the last hour
+		{now.Add(-2 * time.Hour), 2 * 3600}, // Parsed by the dispatcher
+		{"now-30m", 30 * 60},                // Called directly
+		{"2h", 2 * 3600},                    // A bare duration is that long ago
+		{now.Add(-90 * time.Second).UTC().Format(time.RFC3339), 90},
+	} {
+		args := maps.Clone(search)
+		if tc.since != nil {
+			args["since"] = tc.since
+		}
+		source.since = nil
+		_, err := tool.Execute(context.Background(), args)
+		require.NoError(t, err, "%v", tc.since)
+		require.Len(t, source.since, 1)
+		assert.InDelta(t, tc.want, source.since[0], 2, "%v", tc.since)
+	}
+
+	args := maps.Clone(search)
+	args["since_minutes"] = 10
+	source.since = nil
+	_, err := tool.Execute(context.Background(), args)
+	require.NoError(t, err)
+	assert.InDelta(t, 600, source.since[0], 2, "the deprecated since_minutes")
+}
+
+func TestSearchLogsTool_InvalidArgs(t *testing.T) {
+	tool := newSearchLogsTool(&fakeLogSource{})
+	for _, args := range []map[string]interface{}{
+		{"label_selector": "app=x", "pattern": "x"},
+		{"namespace": "shop", "pattern": "x"},
+		{"namespace": "shop", "label_selector": "app=x", "workload": "x", "pattern": "x"},
+		{"namespace": "shop", "label_selector": "app=x"},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": "("},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": strings.Repeat("a", searchLogsMaxPatternLength+1)},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": "x", "max_matches": searchLogsMaxMatches + 1},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": "x", "since_minutes": 0},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": "x", "since": "now-25h"},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": "x", "since": "now+5m"},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": "x", "since": "2 hours ago"},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": "x", "since": "1h", "since_minutes": 60},
+		{"namespace": "shop", "label_selector": "app=x", "pattern": "x", "context_lines": searchLogsMaxContextLines + 1},
+		{"namespace": "shop", "label_selector": "app in (", "pattern": "x"},
+		{"namespace": "shop", "workload": "x", "workload_kind": "Job", "pattern": "x"},
+	} {
+		_, err := tool.Execute(context.Background(), args)
+		assert.True(t, IsInvalidArguments(err), "%v: %v", args, err)
+	}
+}
@@ -0,0 +1,225 @@
+package tools
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of string arguments the dispatcher parses before Execute. Tools
+// declare them with DurationProperty and TimeProperty and receive a
+// time.Duration or time.Time in place of the string. They are not JSON
+// Schema formats, so clients validating formats let them through.
+const (
+	FormatDuration = "go-duration"   // "15m", "2h", "7d", "now-1h", or an RFC3339 time it has been since
+	FormatTime     = "relative-time" // "now-1h", "15m" (ago), "now", or an RFC3339 timestamp
+)
+
+// timeArgHint follows every parse error, so callers learn what is accepted
+const timeArgHint = `use a duration such as "15m" or "2h", "now-1h", or an RFC3339 timestamp such as "2026-10-15T12:00:00Z"`
+
+// DurationProperty is the input schema of a duration argument
+func DurationProperty(description string, defaultValue string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"format":      FormatDuration,
+		"description": description + ` (a duration such as "15m", "2h" or "7d"; "now-1h" and RFC3339 timestamps count back from now)`,
+		"default":     defaultValue,
+	}
+}
+
+// TimeProperty is the input schema of a point-in-time argument
+func TimeProperty(description string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":        "string",
+		"format":      FormatTime,
+		"description": description + ` (an RFC3339 timestamp, "now-1h", or a duration such as "15m" meaning that long ago)`,
+	}
+}
+
+// NormalizeTimeArgs replaces the string values of the duration and time
+// arguments declared in schema with a time.Duration or time.Time, relative to
+// now. Empty strings are dropped so that tools apply their defaults; values
+// already parsed are kept. args is not modified.
+func NormalizeTimeArgs(schema map[string]interface{}, args map[string]interface{}, now time.Time) (map[string]interface{}, error) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	var normalized map[string]interface{}
+	for name, value := range args {
+		property, _ := properties[name].(map[string]interface{})
+		format, _ := property["format"].(string)
+		if format != FormatDuration && format != FormatTime {
+			continue
+		}
+
+		var parsed interface{}
+		switch v := value.(type) {
+		case time.Duration, time.Time:
+			continue
+		case string:
+			if strings.TrimSpace(v) == "" {
+				parsed = nil
+				break
+			}
+			var err error
+			if format == FormatDuration {
+				parsed, err = ParseDurationArg(v, now)
+			} else {
+				parsed, err = ParseTimeArg(v, now)
+			}
+			if err != nil {
+				return nil, invalidArgs("%s: %v", name, err)
+			}
+		default:
+			return nil, invalidArgs("%s: cannot parse '%v': %s", name, value, timeArgHint)
+		}
+
+		if normalized == nil {
+			normalized = maps.Clone(args)
+		}
+		if parsed == nil {
+			delete(normalized, name)
+		} else {
+			normalized[name] = parsed
+		}
+	}
+	if normalized == nil {
+		return args, nil
+	}
+	return normalized, nil
+}
+
+// ParseDurationArg parses a duration argument: a Go duration with the extra
+// units d (24h) and w (7d), or a time as accepted by ParseTimeArg, which
+// stands for the time since then. The duration must be positive.
+func ParseDurationArg(value string, now time.Time) (time.Duration, error) {
+	s := strings.TrimSpace(value)
+	d, err := parseDuration(s)
+	if err != nil {
+		t, timeErr := ParseTimeArg(s, now)
+		if timeErr != nil {
+			return 0, timeErr
+		}
+		d = now.Sub(t)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("'%s' is not a positive duration: %s", value, timeArgHint)
+	}
+	return d, nil
+}
+
+// ParseTimeArg parses a time argument: an RFC3339 timestamp with a time
+// zone, "now", "now-<duration>" or "now+<duration>", or a bare duration
+// meaning that long before now. Times are returned in UTC.
+func ParseTimeArg(value string, now time.Time) (time.Time, error) {
+	now = now.UTC()
+	s := strings.TrimSpace(value)
+	switch {
+	case s == "now":
+		return now, nil
+	case strings.HasPrefix(s, "now-"), strings.HasPrefix(s, "now+"):
+		d, err := parseDuration(s[len("now+"):])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot parse '%s': %s", value, timeArgHint)
+		}
+		if s[len("now")] == '-' {
+			d = -d
+		}
+		return now.Add(d), nil
+	}
+	if d, err := parseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+
+	// RFC3339 allows a lowercase t and z, and people write a space for the T
+	s = strings.ToUpper(s)
+	if len(s) > len("2006-01-02") && s[len("2006-01-02")] == ' ' {
+		s = s[:len("2006-01-02")] + "T" + s[len("2006-01-02")+1:]
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.UTC(), nil
+	}
+	if _, err := time.Parse("2006-01-02T15:04:05.999999999", s); err == nil {
+		return time.Time{}, fmt.Errorf("'%s' has no time zone: add Z for UTC or an offset such as +02:00 (RFC3339)", value)
+	}
+	return time.Time{}, fmt.Errorf("cannot parse '%s': %s", value, timeArgHint)
+}
+
+// longUnits matches the leading weeks and days of a duration
+var longUnits = regexp.MustCompile(`^(?:(\d+)w)?(?:(\d+)d)?`)
+
+// parseDuration is time.ParseDuration with leading weeks and days, and
+// without negative durations
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" || strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	var d time.Duration
+	m := longUnits.FindStringSubmatch(s)
+	for i, unit := range []time.Duration{7 * 24 * time.Hour, 24 * time.Hour} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.Atoi(m[i+1])
+		if err != nil || n > 10000 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		d += time.Duration(n) * unit
+	}
+	rest := s[len(m[0]):]
+	if rest == "" {
+		return d, nil
+	}
+	if m[0] != "" && !strings.ContainsAny(rest[:1], "0123456789.") {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	r, err := time.ParseDuration(rest)
+	if err != nil {
+		return 0, err
+	}
+	return d + r, nil
+}
+
+// windowArg returns the duration argument name, given its decoded value, or
+// legacyValue when only the deprecated argument legacy it replaces is set.
+// A string value is parsed here for callers that bypass the dispatcher.
+func windowArg(args map[string]interface{}, name string, value time.Duration, legacy string, legacyValue time.Duration) (time.Duration, error) {
+	_, hasLegacy := args[legacy]
+	raw, has := args[name]
+	if has && hasLegacy {
+		return 0, invalidArgs("use %s or %s, not both", name, legacy)
+	}
+	if hasLegacy {
+		return legacyValue, nil
+	}
+	if s, ok := raw.(string); ok && strings.TrimSpace(s) != "" {
+		d, err := ParseDurationArg(s, time.Now())
+		if err != nil {
+			return 0, invalidArgs("%s: %v", name, err)
+		}
+		return d, nil
+	}
+	return value, nil
+}
+
+// timeArg is windowArg for a point-in-time argument, relative to now
+func timeArg(args map[string]interface{}, name string, value time.Time, legacy string, legacyValue time.Time, now time.Time) (time.Time, error) {
+	_, hasLegacy := args[legacy]
+	raw, has := args[name]
+	if has && hasLegacy {
+		return time.Time{}, invalidArgs("use %s or %s, not both", name, legacy)
+	}
+	if hasLegacy {
+		return legacyValue, nil
+	}
+	if s, ok := raw.(string); ok && strings.TrimSpace(s) != "" {
+		t, err := ParseTimeArg(s, now)
+		if err != nil {
+			return time.Time{}, invalidArgs("%s: %v", name, err)
+		}
+		return t, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,169 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var timeArgsNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+func TestParseDurationArg(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"15m":                       15 * time.Minute,
+		"2h":                        2 * time.Hour,
+		"1h30m":                     90 * time.Minute,
+		"1.5h":                      90 * time.Minute,
+		"90s":                       90 * time.Second,
+		" 45m ":                     45 * time.Minute,
+		"1d":                        24 * time.Hour,
+		"7d":                        7 * 24 * time.Hour,
+		"2w":                        14 * 24 * time.Hour,
+		"1w2d":                      9 * 24 * time.Hour,
+		"1d12h":                     36 * time.Hour,
+		"now-1h":                    time.Hour,
+		"now-2d":                    48 * time.Hour,
+		"2026-10-15T10:00:00Z":      2 * time.Hour,
+		"2026-10-15T10:00:00+02:00": 4 * time.Hour,
+	} {
+		got, err := ParseDurationArg(value, timeArgsNow)
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, want, got, value)
+		}
+	}
+
+	for value, want := range map[string]string{
+		"2 hours ago":               "cannot parse '2 hours ago'",
+		"":                          "cannot parse ''",
+		"15":                        "cannot parse '15'",
+		"-15m":                      "cannot parse '-15m'",
+		"+15m":                      "cannot parse '+15m'",
+		"1dx":                       "cannot parse '1dx'",
+		"1d-3h":                     "cannot parse '1d-3h'",
+		"0s":                        "'0s' is not a positive duration",
+		"now":                       "'now' is not a positive duration",
+		"now+1h":                    "'now+1h' is not a positive duration",
+		"2026-10-15T10:00:00-02:00": "is not a positive duration", // 12:00Z: no time has passed
+		"2026-10-15T10:00:00":       "has no time zone",
+	} {
+		_, err := ParseDurationArg(value, timeArgsNow)
+		if assert.Error(t, err, value) {
+			assert.Contains(t, err.Error(), want, value)
+		}
+	}
+}
+
+func TestParseTimeArg(t *testing.T) {
+	for value, want := range map[string]time.Time{
+		"now":                            timeArgsNow,
+		"now-1h":                         timeArgsNow.Add(-time.Hour),
+		"now+30m":                        timeArgsNow.Add(30 * time.Minute),
+		"now-1d12h":                      timeArgsNow.Add(-36 * time.Hour),
+		"15m":                            timeArgsNow.Add(-15 * time.Minute),
+		"2026-10-15T10:00:00Z":           time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+		"2026-10-15T10:00:00.5Z":         time.Date(2026, 10, 15, 10, 0, 0, 5e8, time.UTC),
+		"2026-10-15 10:00:00Z":           time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+		"2026-10-15T14:00:00+02:00":      time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		"2026-10-15T07:30:00-04:30":      time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		"2026-10-16T01:00:00+13:00":      time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		"2026-03-29T03:30:00+02:00":      time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC), // Just after a DST change
+		"2026-10-15t10:00:00z":           time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC),
+		" 2026-10-15T10:00:00.123+00:00": time.Date(2026, 10, 15, 10, 0, 0, 123e6, time.UTC),
+	} {
+		got, err := ParseTimeArg(value, timeArgsNow)
+		if assert.NoError(t, err, value) {
+			assert.True(t, want.Equal(got), "%s: got %s, want %s", value, got, want)
+			assert.Equal(t, time.UTC, got.Location(), "%s: times are returned in UTC", value)
+		}
+	}
+
+	for value, want := range map[string]string{
+		"2 hours ago":               "cannot parse '2 hours ago'",
+		"yesterday":                 "cannot parse 'yesterday'",
+		"now-":                      "cannot parse 'now-'",
+		"now-an hour":               "cannot parse 'now-an hour'",
+		"now - 1h":                  "cannot parse 'now - 1h'",
+		"2026-10-15":                "cannot parse '2026-10-15'",
+		"2026-10-15T10:00:00":       "'2026-10-15T10:00:00' has no time zone",
+		"2026-10-15 10:00:00":       "'2026-10-15 10:00:00' has no time zone",
+		"2026-10-15T10:00:00.5":     "has no time zone",
+		"2026-10-15T25:00:00Z":      "cannot parse",
+		"2026-10-15T10:00:00+25:00": "cannot parse",
+		"15/10/2026 10:00":          "cannot parse",
+		"1700000000":                "cannot parse '1700000000'",
+	} {
+		_, err := ParseTimeArg(value, timeArgsNow)
+		if assert.Error(t, err, value) {
+			assert.Contains(t, err.Error(), want, value)
+			assert.Contains(t, err.Error(), "RFC3339", value)
+		}
+	}
+}
+
+func TestNormalizeTimeArgs(t *testing.T) {
+	schema := map[string]interface{}{"properties": map[string]interface{}{
+		"window":    DurationProperty("Window", "15m"),
+		"since":     TimeProperty("Start"),
+		"namespace": map[string]interface{}{"type": "string"},
+	}}
+
+	args := map[string]interface{}{"window": "2h", "since": "now-30m", "namespace": "15m"}
+	normalized, err := NormalizeTimeArgs(schema, args, timeArgsNow)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"window":    2 * time.Hour,
+		"since":     timeArgsNow.Add(-30 * time.Minute),
+		"namespace": "15m",
+	}, normalized)
+	assert.Equal(t, "2h", args["window"], "the caller's arguments are left alone")
+
+	// Parsed values pass through, empty strings leave the default to the tool
+	normalized, err = NormalizeTimeArgs(schema, map[string]interface{}{"window": time.Hour, "since": " "}, timeArgsNow)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"window": time.Hour}, normalized)
+
+	unchanged := map[string]interface{}{"namespace": "shop"}
+	normalized, err = NormalizeTimeArgs(schema, unchanged, timeArgsNow)
+	require.NoError(t, err)
+	assert.Equal(t, unchanged, normalized)
+	normalized, err = NormalizeTimeArgs(map[string]interface{}{}, map[string]interface{}{"window": "x"}, timeArgsNow)
+	require.NoError(t, err)
+	assert.Equal(t, "x", normalized["window"], "undeclared arguments are not parsed")
+
+	for _, tc := range []struct {
+		args map[string]interface{}
+		want string
+	}{
+		{map[string]interface{}{"since": "2 hours ago"}, "since: cannot parse '2 hours ago'"},
+		{map[string]interface{}{"window": "now+1h"}, "window: 'now+1h' is not a positive duration"},
+		{map[string]interface{}{"window": float64(60)}, "window: cannot parse '60'"},
+		{map[string]interface{}{"since": true}, "since: cannot parse 'true'"},
+	} {
+		_, err := NormalizeTimeArgs(schema, tc.args, timeArgsNow)
+		assert.True(t, IsInvalidArguments(err), "%v", tc.args)
+		assert.ErrorContains(t, err, tc.want)
+	}
+}
+
+func TestWindowArg(t *testing.T) {
+	window, err := windowArg(map[string]interface{}{}, "window", time.Hour, "window_hours", 0)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, window, "the decoded value or default")
+
+	window, err = windowArg(map[string]interface{}{"window_hours": 6}, "window", time.Hour, "window_hours", 6*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, window, "the deprecated argument")
+
+	window, err = windowArg(map[string]interface{}{"window": "90m"}, "window", 0, "window_hours", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, window, "a string from a caller bypassing the dispatcher")
+
+	_, err = windowArg(map[string]interface{}{"window": "2h", "window_hours": 2}, "window", 0, "window_hours", 2*time.Hour)
+	assert.True(t, IsInvalidArguments(err))
+	assert.ErrorContains(t, err, "use window or window_hours, not both")
+
+	_, err = windowArg(map[string]interface{}{"window": "soon"}, "window", 0, "window_hours", 0)
+	assert.ErrorContains(t, err, "window: cannot parse 'soon'")
+}
@@ -0,0 +1,435 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// defaultTopologyKey groups nodes by availability zone
+	defaultTopologyKey = "topology.kubernetes.io/zone"
+	// unlabeledDomain groups nodes that lack the topology label
+	unlabeledDomain = "(unlabeled)"
+)
+
+// AnalyzeTopologySpreadTool reports how nodes, capacity, and workload replicas are spread across zones
+type AnalyzeTopologySpreadTool struct {
+	k8sClient *clients.K8sClient
+}
+
+// NewAnalyzeTopologySpreadTool creates a new analyze-topology-spread tool
+func NewAnalyzeTopologySpreadTool(k8sClient *clients.K8sClient) *AnalyzeTopologySpreadTool {
+	return &AnalyzeTopologySpreadTool{
+		k8sClient: k8sClient,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *AnalyzeTopologySpreadTool) Name() string {
+	return "analyze-topology-spread"
+}
+
+// Description returns the tool description for MCP
+func (t *AnalyzeTopologySpreadTool) Description() string {
+	return `Analyze how the cluster and its workloads are spread across availability zones (or any other node label). Reports node count and allocatable CPU/memory per zone, and for every Deployment and StatefulSet with at least min_replicas replicas, the zones its running pods are in. Flags workloads with all replicas in a single zone and workloads that violate their own topologySpreadConstraints.
+
+Use this tool for questions like:
+- "Would losing one zone take down any service?"
+- "Are my replicas spread across zones?"
+- "How is capacity distributed between zones?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *AnalyzeTopologySpreadTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only analyze workloads in this namespace. Leave empty for all namespaces.",
+				"default":     "",
+			},
+			"min_replicas": map[string]interface{}{
+				"type":        "integer",
+				"description": "Only analyze workloads with at least this many desired replicas",
+				"default":     2,
+				"minimum":     1,
+			},
+			"topology_key": map[string]interface{}{
+				"type":        "string",
+				"description": "Node label that defines the failure domains (e.g. 'topology.kubernetes.io/region' or 'kubernetes.io/hostname')",
+				"default":     defaultTopologyKey,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// AnalyzeTopologySpreadInput represents the input parameters
+type AnalyzeTopologySpreadInput struct {
+	Namespace   string `json:"namespace"`
+	MinReplicas int    `json:"min_replicas"`
+	TopologyKey string `json:"topology_key"`
+}
+
+// TopologyDomain summarizes the nodes and capacity in one zone
+type TopologyDomain struct {
+	Name          string  `json:"name"`
+	Nodes         int     `json:"nodes"`
+	ReadyNodes    int     `json:"ready_nodes"`
+	CPUMillicores int64   `json:"cpu_millicores"`
+	MemoryBytes   int64   `json:"memory_bytes"`
+	CPUPercent    float64 `json:"cpu_percent"`    // Share of cluster allocatable CPU
+	MemoryPercent float64 `json:"memory_percent"` // Share of cluster allocatable memory
+}
+
+// SpreadViolation is a topologySpreadConstraint the running pods do not satisfy
+type SpreadViolation struct {
+	TopologyKey       string `json:"topology_key"`
+	MaxSkew           int32  `json:"max_skew"`
+	Skew              int    `json:"skew"`
+	WhenUnsatisfiable string `json:"when_unsatisfiable"`
+}
+
+// WorkloadSpread is the zone spread of one workload's running pods
+type WorkloadSpread struct {
+	Kind        string            `json:"kind"`
+	Namespace   string            `json:"namespace"`
+	Name        string            `json:"name"`
+	Replicas    int32             `json:"replicas"`
+	RunningPods int               `json:"running_pods"`
+	Domains     map[string]int    `json:"domains"` // Running pods per zone
+	SingleZone  bool              `json:"single_zone"`
+	Violations  []SpreadViolation `json:"violations,omitempty"`
+	Summary     string            `json:"summary"`
+}
+
+// AnalyzeTopologySpreadOutput represents the tool output
+type AnalyzeTopologySpreadOutput struct {
+	TopologyKey       string             `json:"topology_key"`
+	Domains           []TopologyDomain   `json:"domains"`
+	WorkloadsAnalyzed int                `json:"workloads_analyzed"`
+	Flagged           []WorkloadSpread   `json:"flagged"`
+	Notes             []string           `json:"notes,omitempty"`
+	Findings          []findings.Finding `json:"findings"` // Flagged workloads in the common findings shape
+}
+
+// spreadWorkload is the part of a Deployment or StatefulSet the spread analysis needs
+type spreadWorkload struct {
+	Kind        string
+	Namespace   string
+	Name        string
+	Replicas    int32
+	Selector    labels.Selector
+	Constraints []corev1.TopologySpreadConstraint
+}
+
+// RequiredPermissions declares the Kubernetes API access analyze-topology-spread needs
+func (t *AnalyzeTopologySpreadTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+		{Group: "apps", Resource: "deployments", Verb: "list"},
+		{Group: "apps", Resource: "statefulsets", Verb: "list"},
+	}
+}
+
+// Execute runs the analyze-topology-spread operation
+func (t *AnalyzeTopologySpreadTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := AnalyzeTopologySpreadInput{MinReplicas: 2, TopologyKey: defaultTopologyKey}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.MinReplicas < 1 {
+		return nil, invalidArgs("min_replicas must be at least 1")
+	}
+	if input.TopologyKey == "" {
+		input.TopologyKey = defaultTopologyKey
+	}
+
+	nodes, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	pods, err := t.k8sClient.ListPods(ctx, input.Namespace)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	deployments, err := t.k8sClient.ListDeployments(ctx, input.Namespace)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	statefulSets, err := t.k8sClient.Clientset().AppsV1().StatefulSets(input.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list statefulsets in namespace %s: %w", input.Namespace, err))
+	}
+
+	var workloads []spreadWorkload
+	addWorkload := func(kind, namespace, name string, replicas *int32, selector *metav1.LabelSelector, template *corev1.PodTemplateSpec) {
+		desired := int32(1)
+		if replicas != nil {
+			desired = *replicas
+		}
+		if int(desired) < input.MinReplicas {
+			return
+		}
+		parsed, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil || parsed.Empty() {
+			return
+		}
+		workloads = append(workloads, spreadWorkload{
+			Kind:        kind,
+			Namespace:   namespace,
+			Name:        name,
+			Replicas:    desired,
+			Selector:    parsed,
+			Constraints: template.Spec.TopologySpreadConstraints,
+		})
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		addWorkload("Deployment", d.Namespace, d.Name, d.Spec.Replicas, d.Spec.Selector, &d.Spec.Template)
+	}
+	for i := range statefulSets.Items {
+		s := &statefulSets.Items[i]
+		addWorkload("StatefulSet", s.Namespace, s.Name, s.Spec.Replicas, s.Spec.Selector, &s.Spec.Template)
+	}
+
+	output := AnalyzeTopologySpreadOutput{
+		TopologyKey:       input.TopologyKey,
+		Domains:           topologyDomains(nodes.Items, input.TopologyKey),
+		WorkloadsAnalyzed: len(workloads),
+		Flagged:           []WorkloadSpread{},
+	}
+	zones := 0
+	for _, domain := range output.Domains {
+		if domain.Name != unlabeledDomain {
+			zones++
+		}
+	}
+	switch zones {
+	case 0:
+		output.Notes = append(output.Notes, fmt.Sprintf("no node has the %s label; zone spread cannot be analyzed", input.TopologyKey))
+	case 1:
+		output.Notes = append(output.Notes, fmt.Sprintf("all nodes are in one %s domain, so single-zone workloads are not flagged", input.TopologyKey))
+	}
+
+	for _, workload := range workloads {
+		spread := workloadSpread(workload, pods.Items, nodes.Items, input.TopologyKey)
+		if spread.SingleZone || len(spread.Violations) > 0 {
+			output.Flagged = append(output.Flagged, spread)
+		}
+	}
+	sort.Slice(output.Flagged, func(i, j int) bool {
+		a, b := output.Flagged[i], output.Flagged[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	output.Findings = topologySpreadFindings(output.Flagged)
+	return output, nil
+}
+
+// topologySpreadFindings converts the flagged workloads to findings. Running
+// in a single zone and breaking a DoNotSchedule constraint are warnings;
+// breaking a ScheduleAnyway constraint, which the scheduler only prefers, is
+// info.
+func topologySpreadFindings(flagged []WorkloadSpread) []findings.Finding {
+	result := []findings.Finding{}
+	for _, spread := range flagged {
+		resource := resourceRef(spread.Kind, spread.Namespace, spread.Name)
+		if spread.SingleZone {
+			result = append(result, findings.Finding{
+				Severity:        findings.SeverityWarning,
+				Category:        findings.CategoryWorkload,
+				Resource:        resource,
+				Title:           "Workload runs in a single zone",
+				Detail:          spread.Summary,
+				SuggestedAction: "Add a topologySpreadConstraint on topology.kubernetes.io/zone, or pod anti-affinity, so replicas land in several zones",
+				Source:          "analyze-topology-spread",
+			})
+		}
+		for _, violation := range spread.Violations {
+			severity := findings.SeverityWarning
+			if violation.WhenUnsatisfiable == string(corev1.ScheduleAnyway) {
+				severity = findings.SeverityInfo
+			}
+			result = append(result, findings.Finding{
+				Severity:        severity,
+				Category:        findings.CategoryWorkload,
+				Resource:        resource,
+				Title:           "Workload breaks its topology spread constraint on " + violation.TopologyKey,
+				Detail:          fmt.Sprintf("skew %d across %s, above its maxSkew of %d (%s)", violation.Skew, violation.TopologyKey, violation.MaxSkew, violation.WhenUnsatisfiable),
+				SuggestedAction: "Check for domains without schedulable capacity, then restart the workload's pods so the scheduler spreads them again",
+				Source:          "analyze-topology-spread",
+			})
+		}
+	}
+	findings.Sort(result)
+	return result
+}
+
+// topologyDomains groups nodes by the topology label and sums their allocatable capacity
+func topologyDomains(nodes []corev1.Node, topologyKey string) []TopologyDomain {
+	domains := make(map[string]*TopologyDomain)
+	var totalCPU, totalMemory int64
+	for i := range nodes {
+		node := &nodes[i]
+		name, ok := node.Labels[topologyKey]
+		if !ok {
+			name = unlabeledDomain
+		}
+		domain, ok := domains[name]
+		if !ok {
+			domain = &TopologyDomain{Name: name}
+			domains[name] = domain
+		}
+		domain.Nodes++
+		if nodeReady(node) {
+			domain.ReadyNodes++
+		}
+		cpu := node.Status.Allocatable.Cpu().MilliValue()
+		memory := node.Status.Allocatable.Memory().Value()
+		domain.CPUMillicores += cpu
+		domain.MemoryBytes += memory
+		totalCPU += cpu
+		totalMemory += memory
+	}
+
+	result := make([]TopologyDomain, 0, len(domains))
+	for _, domain := range domains {
+		if totalCPU > 0 {
+			domain.CPUPercent = math.Round(float64(domain.CPUMillicores)/float64(totalCPU)*1000) / 10
+		}
+		if totalMemory > 0 {
+			domain.MemoryPercent = math.Round(float64(domain.MemoryBytes)/float64(totalMemory)*1000) / 10
+		}
+		result = append(result, *domain)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// workloadSpread counts a workload's running pods per topology domain and checks them
+// against its topologySpreadConstraints. Constraints are evaluated over every domain that
+// has nodes, using the workload's own selector; node affinity and minDomains are not
+// taken into account, so a reported skew is an upper bound of what the scheduler sees.
+func workloadSpread(workload spreadWorkload, pods []corev1.Pod, nodes []corev1.Node, topologyKey string) WorkloadSpread {
+	nodeLabels := make(map[string]map[string]string, len(nodes))
+	for i := range nodes {
+		nodeLabels[nodes[i].Name] = nodes[i].Labels
+	}
+
+	var running []*corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Namespace != workload.Namespace || pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" ||
+			pod.DeletionTimestamp != nil || !workload.Selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		running = append(running, pod)
+	}
+
+	spread := WorkloadSpread{
+		Kind:        workload.Kind,
+		Namespace:   workload.Namespace,
+		Name:        workload.Name,
+		Replicas:    workload.Replicas,
+		RunningPods: len(running),
+		Domains:     podsPerDomain(running, nodeLabels, topologyKey),
+	}
+
+	zones := make(map[string]bool)
+	for _, node := range nodes {
+		if value, ok := node.Labels[topologyKey]; ok {
+			zones[value] = true
+		}
+	}
+	if len(zones) > 1 && len(spread.Domains) == 1 {
+		for domain := range spread.Domains {
+			spread.SingleZone = domain != unlabeledDomain
+		}
+	}
+
+	for _, constraint := range workload.Constraints {
+		counts := make(map[string]int)
+		for _, node := range nodes {
+			if value, ok := node.Labels[constraint.TopologyKey]; ok {
+				counts[value] = 0 // Domains without pods count toward the skew
+			}
+		}
+		for domain, count := range podsPerDomain(running, nodeLabels, constraint.TopologyKey) {
+			if domain != unlabeledDomain {
+				counts[domain] = count
+			}
+		}
+		if len(counts) == 0 {
+			continue
+		}
+		lowest, highest := math.MaxInt, 0
+		for _, count := range counts {
+			lowest = min(lowest, count)
+			highest = max(highest, count)
+		}
+		if skew := highest - lowest; skew > int(constraint.MaxSkew) {
+			spread.Violations = append(spread.Violations, SpreadViolation{
+				TopologyKey:       constraint.TopologyKey,
+				MaxSkew:           constraint.MaxSkew,
+				Skew:              skew,
+				WhenUnsatisfiable: string(constraint.WhenUnsatisfiable),
+			})
+		}
+	}
+
+	switch {
+	case spread.SingleZone:
+		for domain := range spread.Domains {
+			spread.Summary = fmt.Sprintf("all %d running replicas of %s %s/%s are in %s; losing that zone takes the workload down",
+				spread.RunningPods, workload.Kind, workload.Namespace, workload.Name, domain)
+		}
+	case len(spread.Violations) > 0:
+		v := spread.Violations[0]
+		spread.Summary = fmt.Sprintf("%s %s/%s has skew %d across %s, above its maxSkew of %d",
+			workload.Kind, workload.Namespace, workload.Name, v.Skew, v.TopologyKey, v.MaxSkew)
+	default:
+		spread.Summary = fmt.Sprintf("%s %s/%s is spread across %d domains", workload.Kind, workload.Namespace, workload.Name, len(spread.Domains))
+	}
+	return spread
+}
+
+// podsPerDomain counts pods by the topology label of the node they run on
+func podsPerDomain(pods []*corev1.Pod, nodeLabels map[string]map[string]string, topologyKey string) map[string]int {
+	counts := make(map[string]int)
+	for _, pod := range pods {
+		domain, ok := nodeLabels[pod.Spec.NodeName][topologyKey]
+		if !ok {
+			domain = unlabeledDomain
+		}
+		counts[domain]++
+	}
+	return counts
+}
+
+// nodeReady reports whether the node's Ready condition is True
+func nodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
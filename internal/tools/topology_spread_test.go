@@ -0,0 +1,258 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+func newZoneNode(name, zone, cpu, memory string) corev1.Node {
+	node := corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"kubernetes.io/hostname": name}},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)},
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	if zone != "" {
+		node.Labels[defaultTopologyKey] = zone
+	}
+	return node
+}
+
+func newSpreadPod(namespace, name, app, node string, phase corev1.PodPhase) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"app": app}},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func zoneFixture() []corev1.Node {
+	return []corev1.Node{
+		newZoneNode("a-1", "us-east-1a", "4", "16Gi"),
+		newZoneNode("a-2", "us-east-1a", "4", "16Gi"),
+		newZoneNode("b-1", "us-east-1b", "4", "16Gi"),
+		newZoneNode("c-1", "us-east-1c", "4", "16Gi"),
+	}
+}
+
+func TestTopologyDomains(t *testing.T) {
+	nodes := append(zoneFixture(), newZoneNode("edge-1", "", "8", "32Gi"))
+	nodes[1].Status.Conditions[0].Status = corev1.ConditionFalse
+
+	domains := topologyDomains(nodes, defaultTopologyKey)
+
+	require.Len(t, domains, 4)
+	assert.Equal(t, TopologyDomain{Name: unlabeledDomain, Nodes: 1, ReadyNodes: 1, CPUMillicores: 8000, MemoryBytes: 32 << 30, CPUPercent: 33.3, MemoryPercent: 33.3}, domains[0])
+	assert.Equal(t, TopologyDomain{Name: "us-east-1a", Nodes: 2, ReadyNodes: 1, CPUMillicores: 8000, MemoryBytes: 32 << 30, CPUPercent: 33.3, MemoryPercent: 33.3}, domains[1])
+	assert.Equal(t, "us-east-1b", domains[2].Name)
+	assert.Equal(t, 16.7, domains[2].CPUPercent)
+}
+
+func TestWorkloadSpread(t *testing.T) {
+	selector := labels.SelectorFromSet(labels.Set{"app": "api"})
+	zoneConstraint := corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       defaultTopologyKey,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+	}
+
+	tests := []struct {
+		name           string
+		nodes          []corev1.Node
+		pods           []corev1.Pod
+		constraints    []corev1.TopologySpreadConstraint
+		wantDomains    map[string]int
+		wantRunning    int
+		wantSingleZone bool
+		wantSkews      []int
+		wantSummary    string
+	}{
+		{
+			name:  "all replicas in one zone",
+			nodes: zoneFixture(),
+			pods: []corev1.Pod{
+				newSpreadPod("shop", "api-1", "api", "a-1", corev1.PodRunning),
+				newSpreadPod("shop", "api-2", "api", "a-2", corev1.PodRunning),
+				newSpreadPod("shop", "api-3", "api", "b-1", corev1.PodPending),
+				newSpreadPod("shop", "web-1", "web", "c-1", corev1.PodRunning),
+				newSpreadPod("other", "api-1", "api", "c-1", corev1.PodRunning),
+			},
+			wantDomains:    map[string]int{"us-east-1a": 2},
+			wantRunning:    2,
+			wantSingleZone: true,
+			wantSummary:    "all 2 running replicas of Deployment shop/api are in us-east-1a; losing that zone takes the workload down",
+		},
+		{
+			name:  "balanced across zones",
+			nodes: zoneFixture(),
+			pods: []corev1.Pod{
+				newSpreadPod("shop", "api-1", "api", "a-1", corev1.PodRunning),
+				newSpreadPod("shop", "api-2", "api", "b-1", corev1.PodRunning),
+				newSpreadPod("shop", "api-3", "api", "c-1", corev1.PodRunning),
+			},
+			constraints: []corev1.TopologySpreadConstraint{zoneConstraint},
+			wantDomains: map[string]int{"us-east-1a": 1, "us-east-1b": 1, "us-east-1c": 1},
+			wantRunning: 3,
+			wantSummary: "Deployment shop/api is spread across 3 domains",
+		},
+		{
+			name:  "empty zone counts toward the skew",
+			nodes: zoneFixture(),
+			pods: []corev1.Pod{
+				newSpreadPod("shop", "api-1", "api", "a-1", corev1.PodRunning),
+				newSpreadPod("shop", "api-2", "api", "a-2", corev1.PodRunning),
+				newSpreadPod("shop", "api-3", "api", "b-1", corev1.PodRunning),
+				newSpreadPod("shop", "api-4", "api", "b-1", corev1.PodRunning),
+			},
+			constraints: []corev1.TopologySpreadConstraint{
+				zoneConstraint,
+				{MaxSkew: 2, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: corev1.ScheduleAnyway},
+			},
+			wantDomains: map[string]int{"us-east-1a": 2, "us-east-1b": 2},
+			wantRunning: 4,
+			wantSkews:   []int{2},
+			wantSummary: "Deployment shop/api has skew 2 across topology.kubernetes.io/zone, above its maxSkew of 1",
+		},
+		{
+			name:  "single-zone cluster is not flagged",
+			nodes: []corev1.Node{newZoneNode("a-1", "us-east-1a", "4", "16Gi"), newZoneNode("a-2", "us-east-1a", "4", "16Gi")},
+			pods: []corev1.Pod{
+				newSpreadPod("shop", "api-1", "api", "a-1", corev1.PodRunning),
+				newSpreadPod("shop", "api-2", "api", "a-2", corev1.PodRunning),
+			},
+			wantDomains: map[string]int{"us-east-1a": 2},
+			wantRunning: 2,
+			wantSummary: "Deployment shop/api is spread across 1 domains",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := spreadWorkload{Kind: "Deployment", Namespace: "shop", Name: "api", Replicas: 3, Selector: selector, Constraints: tt.constraints}
+
+			spread := workloadSpread(workload, tt.pods, tt.nodes, defaultTopologyKey)
+
+			assert.Equal(t, tt.wantDomains, spread.Domains)
+			assert.Equal(t, tt.wantRunning, spread.RunningPods)
+			assert.Equal(t, tt.wantSingleZone, spread.SingleZone)
+			var skews []int
+			for _, v := range spread.Violations {
+				skews = append(skews, v.Skew)
+			}
+			assert.Equal(t, tt.wantSkews, skews)
+			assert.Equal(t, tt.wantSummary, spread.Summary)
+		})
+	}
+}
+
+func TestTopologySpreadFindings(t *testing.T) {
+	converted := topologySpreadFindings([]WorkloadSpread{
+		{Kind: "StatefulSet", Namespace: "shop", Name: "db", SingleZone: true, Summary: "all 3 running replicas of StatefulSet shop/db are in zone-c; losing that zone takes the workload down"},
+		{Kind: "Deployment", Namespace: "shop", Name: "api", Violations: []SpreadViolation{
+			{TopologyKey: "kubernetes.io/hostname", MaxSkew: 1, Skew: 3, WhenUnsatisfiable: "DoNotSchedule"},
+			{TopologyKey: defaultTopologyKey, MaxSkew: 1, Skew: 2, WhenUnsatisfiable: "ScheduleAnyway"},
+		}},
+	})
+	require.Len(t, converted, 3)
+	for _, finding := range converted {
+		assert.Equal(t, findings.CategoryWorkload, finding.Category)
+		assert.Equal(t, "analyze-topology-spread", finding.Source)
+	}
+
+	hostname := converted[0]
+	assert.Equal(t, findings.SeverityWarning, hostname.Severity)
+	assert.Equal(t, "deployment/shop/api", hostname.Resource.String())
+	assert.Equal(t, "Workload breaks its topology spread constraint on kubernetes.io/hostname", hostname.Title)
+	assert.Equal(t, "skew 3 across kubernetes.io/hostname, above its maxSkew of 1 (DoNotSchedule)", hostname.Detail)
+
+	singleZone := converted[1]
+	assert.Equal(t, findings.SeverityWarning, singleZone.Severity)
+	assert.Equal(t, "statefulset/shop/db", singleZone.Resource.String())
+	assert.Equal(t, "Workload runs in a single zone", singleZone.Title)
+	assert.Contains(t, singleZone.Detail, "losing that zone")
+
+	assert.Equal(t, findings.SeverityInfo, converted[2].Severity, "ScheduleAnyway is only a preference")
+	assert.Equal(t, "deployment/shop/api", converted[2].Resource.String())
+
+	assert.Empty(t, topologySpreadFindings(nil))
+}
+
+func TestAnalyzeTopologySpreadTool_Execute(t *testing.T) {
+	replicas := func(n int32) *int32 { return &n }
+	selector := func(app string) *metav1.LabelSelector {
+		return &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}
+	}
+
+	objects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas(2), Selector: selector("api")},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "cron-runner", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Replicas: replicas(1), Selector: selector("cron")},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: replicas(3), Selector: selector("db")},
+		},
+	}
+	for _, node := range zoneFixture() {
+		objects = append(objects, &node)
+	}
+	for _, pod := range []corev1.Pod{
+		newSpreadPod("shop", "api-1", "api", "a-1", corev1.PodRunning),
+		newSpreadPod("shop", "api-2", "api", "b-1", corev1.PodRunning),
+		newSpreadPod("shop", "cron-1", "cron", "a-1", corev1.PodRunning),
+		newSpreadPod("shop", "db-0", "db", "c-1", corev1.PodRunning),
+		newSpreadPod("shop", "db-1", "db", "c-1", corev1.PodRunning),
+		newSpreadPod("shop", "db-2", "db", "c-1", corev1.PodRunning),
+	} {
+		objects = append(objects, &pod)
+	}
+	tool := NewAnalyzeTopologySpreadTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)))
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "shop"})
+	require.NoError(t, err)
+	output := result.(AnalyzeTopologySpreadOutput)
+
+	assert.Equal(t, defaultTopologyKey, output.TopologyKey)
+	assert.Len(t, output.Domains, 3)
+	assert.Equal(t, 2, output.WorkloadsAnalyzed)
+	require.Len(t, output.Flagged, 1)
+	assert.Equal(t, "StatefulSet", output.Flagged[0].Kind)
+	assert.Equal(t, "db", output.Flagged[0].Name)
+	assert.True(t, output.Flagged[0].SingleZone)
+	assert.Empty(t, output.Notes)
+	require.Len(t, output.Findings, 1)
+	assert.Equal(t, "Workload runs in a single zone", output.Findings[0].Title)
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"topology_key": "topology.kubernetes.io/region"})
+	require.NoError(t, err)
+	output = result.(AnalyzeTopologySpreadOutput)
+	assert.Empty(t, output.Flagged)
+	require.Len(t, output.Notes, 1)
+	assert.Contains(t, output.Notes[0], "no node has the topology.kubernetes.io/region label")
+}
+
+func TestAnalyzeTopologySpreadTool_InvalidMinReplicas(t *testing.T) {
+	tool := NewAnalyzeTopologySpreadTool(clients.NewK8sClientWithClientset(fake.NewClientset()))
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"min_replicas": 0})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
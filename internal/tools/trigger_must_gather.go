@@ -0,0 +1,240 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// mustGatherDir is where the gather scripts write their output
+	mustGatherDir = "/must-gather"
+	// mustGatherArchive is the archive file name inside mustGatherDir
+	mustGatherArchive = "must-gather.tar.gz"
+	// mustGatherVolume is the volume shared by the gather and archive containers
+	mustGatherVolume = "must-gather-output"
+	// mustGatherArchiveContainer tars the output and holds it for copying
+	mustGatherArchiveContainer = "archive"
+	// defaultGatherScript collects the standard must-gather data set
+	defaultGatherScript = "/usr/bin/gather"
+	// mustGatherJobTTL keeps finished Jobs around long enough to read their status
+	mustGatherJobTTL = 24 * time.Hour
+)
+
+// mustGatherLabels identify the Jobs created by trigger-must-gather
+var mustGatherLabels = map[string]string{
+	"app.kubernetes.io/name":       "must-gather",
+	"app.kubernetes.io/managed-by": "openshift-cluster-health-mcp",
+}
+
+// MustGatherConfig configures the must-gather Jobs
+type MustGatherConfig struct {
+	Image          string        // must-gather image
+	Namespace      string        // Namespace the Job runs in
+	ServiceAccount string        // Service account with cluster-wide read access (empty = namespace default)
+	PVC            string        // PVC the archive is written to (empty = keep it in the pod)
+	Retention      time.Duration // How long the pod keeps the archive when no PVC is set
+}
+
+// TriggerMustGatherTool starts a must-gather Job for support escalations
+type TriggerMustGatherTool struct {
+	k8sClient clients.ClientsetProvider
+	config    MustGatherConfig
+}
+
+// NewTriggerMustGatherTool creates a new trigger-must-gather tool
+func NewTriggerMustGatherTool(k8sClient clients.ClientsetProvider, config MustGatherConfig) *TriggerMustGatherTool {
+	return &TriggerMustGatherTool{
+		k8sClient: k8sClient,
+		config:    config,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *TriggerMustGatherTool) Name() string {
+	return "trigger-must-gather"
+}
+
+// Description returns the tool description for MCP
+func (t *TriggerMustGatherTool) Description() string {
+	return "Start an OpenShift must-gather as a Job for Red Hat support cases. Returns immediately with the Job name; poll get-must-gather-status to see progress and where the archive landed (PVC path or an 'oc cp' command). The archive is never streamed through MCP. Refused when the server is in read-only mode."
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *TriggerMustGatherTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"gather_script": map[string]interface{}{
+				"type":        "string",
+				"description": "Gather script to run from the must-gather image (e.g. '/usr/bin/gather_audit_logs'). Defaults to the standard data set.",
+				"default":     defaultGatherScript,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// Mutating marks trigger-must-gather as changing cluster state
+func (t *TriggerMustGatherTool) Mutating() bool {
+	return true
+}
+
+// SupportsDryRun reports that trigger-must-gather honors dry_run with a server-side dry-run create
+func (t *TriggerMustGatherTool) SupportsDryRun() bool {
+	return true
+}
+
+// TriggerMustGatherInput represents the input parameters
+type TriggerMustGatherInput struct {
+	GatherScript string `json:"gather_script"`
+}
+
+// TriggerMustGatherOutput represents the tool output
+type TriggerMustGatherOutput struct {
+	Namespace       string `json:"namespace"`
+	JobName         string `json:"job_name"`
+	Image           string `json:"image"`
+	GatherScript    string `json:"gather_script"`
+	ArchiveLocation string `json:"archive_location"`
+	Message         string `json:"message"`
+	DryRun          bool   `json:"dry_run,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access trigger-must-gather needs
+func (t *TriggerMustGatherTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Group: "batch", Resource: "jobs", Verb: "create", Namespace: t.config.Namespace},
+	}
+}
+
+// Execute runs the trigger-must-gather operation
+func (t *TriggerMustGatherTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := TriggerMustGatherInput{GatherScript: defaultGatherScript}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	// Only scripts shipped in the image may run; the value is passed as a single argv entry
+	if !strings.HasPrefix(input.GatherScript, "/usr/bin/gather") || strings.ContainsAny(input.GatherScript, " \t;&|$`'\"\\") {
+		return nil, invalidArgs("gather_script must be a gather script from the must-gather image, such as /usr/bin/gather or /usr/bin/gather_audit_logs")
+	}
+
+	job := buildMustGatherJob(t.config, input.GatherScript, time.Now())
+	created, err := t.k8sClient.Clientset().BatchV1().Jobs(t.config.Namespace).Create(ctx, job, metav1.CreateOptions{DryRun: dryRunOption(ctx)})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to create must-gather job in %s: %w", t.config.Namespace, err))
+	}
+
+	if IsDryRun(ctx) {
+		return TriggerMustGatherOutput{
+			Namespace:       created.Namespace,
+			JobName:         created.Name,
+			Image:           t.config.Image,
+			GatherScript:    input.GatherScript,
+			ArchiveLocation: mustGatherArchiveLocation(created, ""),
+			Message:         fmt.Sprintf("DRY RUN: must-gather job %s would be started in %s; the API server accepted the job", created.Name, created.Namespace),
+			DryRun:          true,
+		}, nil
+	}
+
+	log.Printf("Started must-gather job %s/%s (image %s, script %s)", created.Namespace, created.Name, t.config.Image, input.GatherScript)
+
+	return TriggerMustGatherOutput{
+		Namespace:       created.Namespace,
+		JobName:         created.Name,
+		Image:           t.config.Image,
+		GatherScript:    input.GatherScript,
+		ArchiveLocation: mustGatherArchiveLocation(created, ""),
+		Message:         fmt.Sprintf("must-gather job %s started; gathering usually takes several minutes. Call get-must-gather-status with job_name %q to follow it.", created.Name, created.Name),
+	}, nil
+}
+
+// buildMustGatherJob builds a Job whose init container runs the gather script and whose
+// main container archives the output, either onto the PVC or into the pod for retention
+func buildMustGatherJob(config MustGatherConfig, gatherScript string, now time.Time) *batchv1.Job {
+	name := "must-gather-" + now.UTC().Format("20060102-150405")
+
+	mount := corev1.VolumeMount{Name: mustGatherVolume, MountPath: mustGatherDir}
+	volume := corev1.Volume{
+		Name:         mustGatherVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	archiveScript := fmt.Sprintf("tar czf %[1]s/%[2]s.tmp --exclude=./%[2]s* -C %[1]s . && mv %[1]s/%[2]s.tmp %[1]s/%[2]s",
+		mustGatherDir, mustGatherArchive)
+	if config.PVC != "" {
+		// One directory per run so the PVC can hold several archives
+		mount.SubPath = name
+		volume.VolumeSource = corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: config.PVC},
+		}
+	} else {
+		// Keep the pod running so the archive can be copied out before it is discarded
+		archiveScript += fmt.Sprintf(" && sleep %d", int64(config.Retention.Seconds()))
+	}
+
+	backoffLimit := int32(0)
+	ttl := int32(mustGatherJobTTL.Seconds())
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: config.Namespace,
+			Labels:    maps.Clone(mustGatherLabels),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            &backoffLimit,
+			TTLSecondsAfterFinished: &ttl,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: maps.Clone(mustGatherLabels)},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: config.ServiceAccount,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					// Like 'oc adm must-gather', run even when every node is tainted
+					Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					InitContainers: []corev1.Container{{
+						Name:         "gather",
+						Image:        config.Image,
+						Command:      []string{gatherScript},
+						VolumeMounts: []corev1.VolumeMount{mount},
+					}},
+					Containers: []corev1.Container{{
+						Name:         mustGatherArchiveContainer,
+						Image:        config.Image,
+						Command:      []string{"/bin/bash", "-c", archiveScript},
+						VolumeMounts: []corev1.VolumeMount{mount},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								Exec: &corev1.ExecAction{Command: []string{"test", "-f", mustGatherDir + "/" + mustGatherArchive}},
+							},
+							PeriodSeconds: 10,
+						},
+					}},
+					Volumes: []corev1.Volume{volume},
+				},
+			},
+		},
+	}
+}
+
+// mustGatherArchiveLocation describes where the Job's archive lands. For archives
+// kept in the pod, pod is the pod name (empty when it is not known yet).
+func mustGatherArchiveLocation(job *batchv1.Job, pod string) string {
+	for _, volume := range job.Spec.Template.Spec.Volumes {
+		if volume.Name == mustGatherVolume && volume.PersistentVolumeClaim != nil {
+			return fmt.Sprintf("pvc/%s: %s/%s", volume.PersistentVolumeClaim.ClaimName, job.Name, mustGatherArchive)
+		}
+	}
+	if pod == "" {
+		pod = "<pod of job " + job.Name + ">"
+	}
+	return fmt.Sprintf("pod %s/%s (container %s): %s/%s", job.Namespace, pod, mustGatherArchiveContainer, mustGatherDir, mustGatherArchive)
+}
@@ -4,19 +4,25 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
 	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
 )
 
 // TriggerRemediationTool provides MCP tool for triggering remediation actions
 type TriggerRemediationTool struct {
 	ceClient *clients.CoordinationEngineClient
+	cache    *cache.MemoryCache // Holds the playbook catalog used to validate the playbook argument
 }
 
 // NewTriggerRemediationTool creates a new trigger-remediation tool
-func NewTriggerRemediationTool(ceClient *clients.CoordinationEngineClient) *TriggerRemediationTool {
+func NewTriggerRemediationTool(ceClient *clients.CoordinationEngineClient, memoryCache *cache.MemoryCache) *TriggerRemediationTool {
 	return &TriggerRemediationTool{
 		ceClient: ceClient,
+		cache:    memoryCache,
 	}
 }
 
@@ -27,7 +33,7 @@ func (t *TriggerRemediationTool) Name() string {
 
 // Description returns the tool description
 func (t *TriggerRemediationTool) Description() string {
-	return "Trigger automated remediation actions for incidents through the Coordination Engine. Requires incident_id, namespace, resource details, and issue information."
+	return "Trigger automated remediation actions for incidents through the Coordination Engine. Requires incident_id, namespace, resource details, and issue information. Optionally names the playbook to run; see list-remediation-playbooks for valid names."
 }
 
 // InputSchema returns the JSON schema for tool inputs
@@ -66,10 +72,9 @@ func (t *TriggerRemediationTool) InputSchema() map[string]interface{} {
 				"type":        "string",
 				"description": "Description of the issue",
 			},
-			"dry_run": map[string]interface{}{
-				"type":        "boolean",
-				"description": "If true, validate without executing",
-				"default":     false,
+			"playbook": map[string]interface{}{
+				"type":        "string",
+				"description": "Playbook to run, from list-remediation-playbooks; the engine chooses one when omitted",
 			},
 		},
 		"required": []string{"incident_id", "namespace", "resource_name", "resource_kind", "issue_type", "severity"},
@@ -85,7 +90,7 @@ type TriggerRemediationInput struct {
 	IssueType    string `json:"issue_type"`
 	Severity     string `json:"severity"`
 	Description  string `json:"description"`
-	DryRun       bool   `json:"dry_run"`
+	Playbook     string `json:"playbook"`
 }
 
 // TriggerRemediationOutput represents the tool output
@@ -97,44 +102,48 @@ type TriggerRemediationOutput struct {
 	EstimatedDuration string `json:"estimated_duration"`
 	Message           string `json:"message"`
 	DryRun            bool   `json:"dry_run,omitempty"`
+	// Request is the remediation that would be sent to the Coordination Engine, for dry-runs
+	Request *clients.TriggerRemediationRequest `json:"request,omitempty"`
 }
 
 // Execute runs the trigger-remediation tool
 func (t *TriggerRemediationTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	// Parse input arguments
-	input := TriggerRemediationInput{
-		DryRun: false,
-	}
-
+	var input TriggerRemediationInput
 	if argsJSON, err := json.Marshal(args); err == nil {
 		_ = json.Unmarshal(argsJSON, &input)
 	}
 
 	// Validate required fields
 	if input.IncidentID == "" {
-		return nil, fmt.Errorf("incident_id is required")
+		return nil, invalidArgs("incident_id is required")
 	}
 	if input.Namespace == "" {
-		return nil, fmt.Errorf("namespace is required")
+		return nil, invalidArgs("namespace is required")
 	}
 	if input.ResourceName == "" {
-		return nil, fmt.Errorf("resource_name is required")
+		return nil, invalidArgs("resource_name is required")
 	}
 	if input.ResourceKind == "" {
-		return nil, fmt.Errorf("resource_kind is required")
+		return nil, invalidArgs("resource_kind is required")
 	}
 	if input.IssueType == "" {
-		return nil, fmt.Errorf("issue_type is required")
+		return nil, invalidArgs("issue_type is required")
 	}
 	if input.Severity == "" {
-		return nil, fmt.Errorf("severity is required")
+		return nil, invalidArgs("severity is required")
+	}
+	if input.Playbook != "" {
+		if err := t.validatePlaybook(ctx, input.Playbook); err != nil {
+			return nil, err
+		}
 	}
 
 	// Build remediation request
 	req := &clients.TriggerRemediationRequest{
 		IncidentID: input.IncidentID,
 		Namespace:  input.Namespace,
-		DryRun:     input.DryRun,
+		Playbook:   input.Playbook,
 	}
 	req.Resource.Kind = input.ResourceKind
 	req.Resource.Name = input.ResourceName
@@ -142,10 +151,26 @@ func (t *TriggerRemediationTool) Execute(ctx context.Context, args map[string]in
 	req.Issue.Severity = input.Severity
 	req.Issue.Description = input.Description
 
+	// The engine has no side-effect-free mode, so a dry-run stops here
+	if IsDryRun(ctx) {
+		playbook := req.Playbook
+		if playbook == "" {
+			playbook = "the playbook chosen by the engine"
+		}
+		return TriggerRemediationOutput{
+			Status:     "dry_run",
+			IncidentID: input.IncidentID,
+			DryRun:     true,
+			Request:    req,
+			Message: fmt.Sprintf("DRY RUN: would run %s against %s %s/%s for %s (%s); nothing was sent to the Coordination Engine",
+				playbook, input.ResourceKind, input.Namespace, input.ResourceName, input.IssueType, input.Severity),
+		}, nil
+	}
+
 	// Call Coordination Engine API
 	resp, err := t.ceClient.TriggerRemediation(ctx, req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to trigger remediation: %w", err)
+		return nil, dependencyError(fmt.Errorf("failed to trigger remediation: %w", err))
 	}
 
 	// Build output
@@ -155,14 +180,47 @@ func (t *TriggerRemediationTool) Execute(ctx context.Context, args map[string]in
 		IncidentID:        input.IncidentID,
 		DeploymentMethod:  resp.DeploymentMethod,
 		EstimatedDuration: resp.EstimatedDuration,
-		DryRun:            input.DryRun,
+		Message:           fmt.Sprintf("Remediation triggered successfully (workflow: %s)", resp.WorkflowID),
 	}
 
-	if input.DryRun {
-		output.Message = fmt.Sprintf("DRY RUN: Remediation for %s/%s validated successfully", input.ResourceKind, input.ResourceName)
-	} else {
-		output.Message = fmt.Sprintf("Remediation triggered successfully (workflow: %s)", resp.WorkflowID)
+	return output, nil
+}
+
+// Mutating marks trigger-remediation as changing cluster state
+func (t *TriggerRemediationTool) Mutating() bool {
+	return true
+}
+
+// SupportsDryRun reports that trigger-remediation honors dry_run by returning
+// the request it would send
+func (t *TriggerRemediationTool) SupportsDryRun() bool {
+	return true
+}
+
+// EventTarget returns the resource being remediated, which the Kubernetes
+// Event of the call is recorded on
+func (t *TriggerRemediationTool) EventTarget(args map[string]interface{}) *corev1.ObjectReference {
+	namespace, _ := args["namespace"].(string)
+	kind, _ := args["resource_kind"].(string)
+	name, _ := args["resource_name"].(string)
+	return &corev1.ObjectReference{Kind: kind, Namespace: namespace, Name: name}
+}
+
+// validatePlaybook rejects a playbook missing from the engine's catalog, suggesting
+// the closest names. When the catalog cannot be fetched the engine validates instead.
+func (t *TriggerRemediationTool) validatePlaybook(ctx context.Context, playbook string) error {
+	playbooks, err := playbookCatalog(ctx, t.ceClient, t.cache)
+	if err != nil {
+		return nil
+	}
+	for _, known := range playbooks {
+		if known.Name == playbook {
+			return nil
+		}
 	}
 
-	return output, nil
+	if suggestions := suggestPlaybooks(playbook, playbooks); len(suggestions) > 0 {
+		return invalidArgs("unknown playbook %q; did you mean %s?", playbook, strings.Join(suggestions, ", "))
+	}
+	return invalidArgs("unknown playbook %q; use list-remediation-playbooks to see the catalog", playbook)
 }
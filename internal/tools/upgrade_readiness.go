@@ -0,0 +1,563 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// criticalAlertsQuery selects the critical alerts firing right now
+const criticalAlertsQuery = `ALERTS{alertstate="firing", severity="critical"}`
+
+// openShiftKubeMinorOffset maps OpenShift 4.N to the Kubernetes 1.(N+13) it ships
+const openShiftKubeMinorOffset = 13
+
+// Upgrade readiness verdicts and finding severities
+const (
+	UpgradeVerdictGo   = "go"
+	UpgradeVerdictNoGo = "no-go"
+
+	UpgradeSeverityBlocker = "blocker" // Upgrade will fail or stall
+	UpgradeSeverityWarning = "warning" // Worth fixing first, but not fatal
+)
+
+// AssessUpgradeReadinessTool runs the pre-upgrade checklist and returns a go/no-go verdict
+type AssessUpgradeReadinessTool struct {
+	k8sClient  *clients.K8sClient
+	prometheus *clients.PrometheusClient // nil when Prometheus is not configured
+	openshift  bool
+}
+
+// NewAssessUpgradeReadinessTool creates a new assess-upgrade-readiness tool. prometheus may
+// be nil; openshift reports whether the cluster serves the OpenShift config API.
+func NewAssessUpgradeReadinessTool(k8sClient *clients.K8sClient, prometheus *clients.PrometheusClient, openshift bool) *AssessUpgradeReadinessTool {
+	return &AssessUpgradeReadinessTool{
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+		openshift:  openshift,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *AssessUpgradeReadinessTool) Name() string {
+	return "assess-upgrade-readiness"
+}
+
+// Volatility is slow; upgrade blockers come and go over hours
+func (t *AssessUpgradeReadinessTool) Volatility() Volatility {
+	return VolatilitySlow
+}
+
+// Weight is heavy, as the assessment walks operators, pools, PDBs, nodes and API usage
+func (t *AssessUpgradeReadinessTool) Weight() Weight {
+	return WeightHeavy
+}
+
+// Description returns the tool description for MCP
+func (t *AssessUpgradeReadinessTool) Description() string {
+	return `Run the pre-upgrade checklist and return a go/no-go verdict with blockers and warnings. Checks degraded or non-upgradeable ClusterOperators, MachineConfigPools not fully updated, PodDisruptionBudgets that allow no disruptions (they block node drains), pending CSRs, NotReady or cordoned nodes, deprecated APIs still in use that the target version removes, and firing critical alerts. Checks that do not apply (no OpenShift, no Prometheus) are listed as skipped.
+
+Use this tool for questions like:
+- "Is the cluster ready to upgrade?"
+- "What would block an upgrade to 4.16?"
+- "Are any workloads still using APIs removed in the next release?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *AssessUpgradeReadinessTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"target_version": map[string]interface{}{
+				"type":        "string",
+				"description": "Version to upgrade to, as OpenShift (4.16, 4.16.3) or Kubernetes (1.29). Deprecated APIs removed in or before it are blockers; without it they are warnings.",
+				"default":     "",
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// AssessUpgradeReadinessInput represents the input parameters
+type AssessUpgradeReadinessInput struct {
+	TargetVersion string `json:"target_version"`
+}
+
+// UpgradeFinding is one problem found by an upgrade readiness check
+type UpgradeFinding struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Object   string `json:"object,omitempty"`
+	Message  string `json:"message"`
+
+	// What the finding is about and how to fix it, for its findings.Finding
+	resource findings.ResourceRef
+	title    string
+	action   string
+}
+
+// SkippedUpgradeCheck is a check that could not run
+type SkippedUpgradeCheck struct {
+	Check  string `json:"check"`
+	Reason string `json:"reason"`
+}
+
+// AssessUpgradeReadinessOutput represents the tool output
+type AssessUpgradeReadinessOutput struct {
+	Verdict        string                `json:"verdict"`
+	CurrentVersion string                `json:"current_version,omitempty"`
+	TargetVersion  string                `json:"target_version,omitempty"`
+	TargetKube     string                `json:"target_kubernetes_version,omitempty"`
+	Blockers       []UpgradeFinding      `json:"blockers"`
+	Warnings       []UpgradeFinding      `json:"warnings"`
+	ChecksRun      []string              `json:"checks_run"`
+	Skipped        []SkippedUpgradeCheck `json:"skipped,omitempty"`
+	Summary        string                `json:"summary"`
+	Findings       []findings.Finding    `json:"findings"` // Blockers and warnings in the common findings shape
+}
+
+// upgradeCheck is one item of the checklist. run gathers what the check needs and
+// passes it to a pure function that returns the findings; it returns a
+// skipUpgradeCheck error when the check does not apply to this cluster.
+type upgradeCheck struct {
+	name string
+	run  func(t *AssessUpgradeReadinessTool, ctx context.Context, targetKubeMinor int) ([]UpgradeFinding, error)
+}
+
+// skipUpgradeCheck reports that a check does not apply, rather than that it failed
+type skipUpgradeCheck string
+
+func (s skipUpgradeCheck) Error() string { return string(s) }
+
+// upgradeChecks is the checklist, in the order the findings are reported
+var upgradeChecks = []upgradeCheck{
+	{"cluster-operators", (*AssessUpgradeReadinessTool).checkClusterOperators},
+	{"machine-config-pools", (*AssessUpgradeReadinessTool).checkMachineConfigPools},
+	{"pod-disruption-budgets", (*AssessUpgradeReadinessTool).checkPodDisruptionBudgets},
+	{"pending-csrs", (*AssessUpgradeReadinessTool).checkPendingCSRs},
+	{"nodes", (*AssessUpgradeReadinessTool).checkNodes},
+	{"deprecated-apis", (*AssessUpgradeReadinessTool).checkDeprecatedAPIs},
+	{"critical-alerts", (*AssessUpgradeReadinessTool).checkCriticalAlerts},
+}
+
+// RequiredPermissions declares the Kubernetes API access assess-upgrade-readiness needs
+func (t *AssessUpgradeReadinessTool) RequiredPermissions() []PermissionRule {
+	return []PermissionRule{
+		{Resource: "nodes", Verb: "list"},
+		{Group: "policy", Resource: "poddisruptionbudgets", Verb: "list"},
+		{Group: "certificates.k8s.io", Resource: "certificatesigningrequests", Verb: "list"},
+		{Group: "config.openshift.io", Resource: "clusterversions", Verb: "get"},
+		{Group: "config.openshift.io", Resource: "clusteroperators", Verb: "list"},
+		{Group: "machineconfiguration.openshift.io", Resource: "machineconfigpools", Verb: "list"},
+		{Group: "apiserver.openshift.io", Resource: "apirequestcounts", Verb: "list"},
+	}
+}
+
+// Execute runs the assess-upgrade-readiness operation
+func (t *AssessUpgradeReadinessTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	var input AssessUpgradeReadinessInput
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	output := AssessUpgradeReadinessOutput{
+		TargetVersion: input.TargetVersion,
+		Blockers:      []UpgradeFinding{},
+		Warnings:      []UpgradeFinding{},
+		ChecksRun:     []string{},
+	}
+	targetMinor := 0
+	if input.TargetVersion != "" {
+		minor, err := targetKubeMinor(input.TargetVersion)
+		if err != nil {
+			return nil, invalidArgs("%v", err)
+		}
+		targetMinor = minor
+		output.TargetKube = fmt.Sprintf("1.%d", minor)
+	}
+	if t.openshift {
+		output.CurrentVersion = t.currentVersion(ctx)
+	}
+
+	for _, check := range upgradeChecks {
+		findings, err := check.run(t, ctx, targetMinor)
+		if err != nil {
+			output.Skipped = append(output.Skipped, SkippedUpgradeCheck{Check: check.name, Reason: err.Error()})
+			continue
+		}
+		output.ChecksRun = append(output.ChecksRun, check.name)
+		for _, finding := range findings {
+			finding.Check = check.name
+			if finding.Severity == UpgradeSeverityBlocker {
+				output.Blockers = append(output.Blockers, finding)
+			} else {
+				output.Warnings = append(output.Warnings, finding)
+			}
+		}
+	}
+
+	output.Verdict, output.Summary = upgradeVerdict(output.Blockers, output.Warnings, output.Skipped)
+	output.Findings = upgradeFindings(append(slices.Clone(output.Blockers), output.Warnings...))
+	return output, nil
+}
+
+// currentVersion returns the version the ClusterVersion is at or moving to, or "" if unknown
+func (t *AssessUpgradeReadinessTool) currentVersion(ctx context.Context) string {
+	clusterVersion, err := t.k8sClient.GetResource(ctx, "config.openshift.io/v1", "ClusterVersion", "", "version")
+	if err != nil {
+		return ""
+	}
+	version, _, _ := unstructured.NestedString(clusterVersion.Object, "status", "desired", "version")
+	return version
+}
+
+func (t *AssessUpgradeReadinessTool) checkClusterOperators(ctx context.Context, _ int) ([]UpgradeFinding, error) {
+	if !t.openshift {
+		return nil, skipUpgradeCheck("not an OpenShift cluster")
+	}
+	list, err := t.k8sClient.ListResources(ctx, "config.openshift.io/v1", "ClusterOperator", "", metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return clusterOperatorFindings(list.Items), nil
+}
+
+func (t *AssessUpgradeReadinessTool) checkMachineConfigPools(ctx context.Context, _ int) ([]UpgradeFinding, error) {
+	if !t.openshift {
+		return nil, skipUpgradeCheck("not an OpenShift cluster")
+	}
+	list, err := t.k8sClient.ListResources(ctx, "machineconfiguration.openshift.io/v1", "MachineConfigPool", "", metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return machineConfigPoolFindings(list.Items), nil
+}
+
+func (t *AssessUpgradeReadinessTool) checkPodDisruptionBudgets(ctx context.Context, _ int) ([]UpgradeFinding, error) {
+	list, err := t.k8sClient.Clientset().PolicyV1().PodDisruptionBudgets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list pod disruption budgets: %w", err))
+	}
+	return podDisruptionBudgetFindings(list.Items), nil
+}
+
+func (t *AssessUpgradeReadinessTool) checkPendingCSRs(ctx context.Context, _ int) ([]UpgradeFinding, error) {
+	list, err := t.k8sClient.Clientset().CertificatesV1().CertificateSigningRequests().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, apiError(fmt.Errorf("failed to list certificate signing requests: %w", err))
+	}
+	return pendingCSRFindings(list.Items), nil
+}
+
+func (t *AssessUpgradeReadinessTool) checkNodes(ctx context.Context, _ int) ([]UpgradeFinding, error) {
+	list, err := t.k8sClient.ListNodes(ctx)
+	if err != nil {
+		return nil, apiError(err)
+	}
+	return nodeUpgradeFindings(list.Items), nil
+}
+
+func (t *AssessUpgradeReadinessTool) checkDeprecatedAPIs(ctx context.Context, targetKubeMinor int) ([]UpgradeFinding, error) {
+	if !t.openshift {
+		return nil, skipUpgradeCheck("APIRequestCount is only served by OpenShift")
+	}
+	list, err := t.k8sClient.ListResources(ctx, "apiserver.openshift.io/v1", "APIRequestCount", "", metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, skipUpgradeCheck("APIRequestCount is not served by this cluster")
+		}
+		return nil, err
+	}
+	return deprecatedAPIFindings(list.Items, targetKubeMinor), nil
+}
+
+func (t *AssessUpgradeReadinessTool) checkCriticalAlerts(ctx context.Context, _ int) ([]UpgradeFinding, error) {
+	if t.prometheus == nil {
+		return nil, skipUpgradeCheck("Prometheus is not configured (ENABLE_PROMETHEUS)")
+	}
+	samples, err := t.prometheus.Query(ctx, criticalAlertsQuery)
+	if err != nil {
+		return nil, dependencyError(err)
+	}
+	return criticalAlertFindings(samples), nil
+}
+
+// clusterOperatorFindings blocks on operators that are degraded, unavailable, or report
+// Upgradeable=False, and warns on operators still progressing
+func clusterOperatorFindings(operators []unstructured.Unstructured) []UpgradeFinding {
+	var findings []UpgradeFinding
+	for i := range operators {
+		name := operators[i].GetName()
+		conditions := operatorConditions(&operators[i])
+		add := func(severity, state string, condition *OperatorCondition) {
+			message := fmt.Sprintf("ClusterOperator %s is %s", name, state)
+			if condition != nil && condition.Message != "" {
+				message += ": " + condition.Message
+			}
+			findings = append(findings, UpgradeFinding{Severity: severity, Object: "clusteroperator/" + name, Message: message,
+				resource: resourceRef("clusteroperator", "", name), title: "ClusterOperator is " + state})
+		}
+
+		if conditionTrue(conditions, "Degraded") {
+			add(UpgradeSeverityBlocker, "degraded", findCondition(conditions, "Degraded"))
+		}
+		if available := findCondition(conditions, "Available"); available == nil || available.Status != "True" {
+			add(UpgradeSeverityBlocker, "not available", available)
+		}
+		if upgradeable := findCondition(conditions, "Upgradeable"); upgradeable != nil && upgradeable.Status == "False" {
+			add(UpgradeSeverityBlocker, "not upgradeable", upgradeable)
+		}
+		if conditionTrue(conditions, "Progressing") {
+			add(UpgradeSeverityWarning, "still progressing", findCondition(conditions, "Progressing"))
+		}
+	}
+	return findings
+}
+
+// machineConfigPoolFindings blocks on pools that are degraded or still rolling out a
+// config, and warns on paused pools, which the upgrade will not update
+func machineConfigPoolFindings(pools []unstructured.Unstructured) []UpgradeFinding {
+	var findings []UpgradeFinding
+	for i := range pools {
+		pool := &pools[i]
+		object := "machineconfigpool/" + pool.GetName()
+		conditions := operatorConditions(pool)
+		machines, _, _ := unstructured.NestedInt64(pool.Object, "status", "machineCount")
+		updated, _, _ := unstructured.NestedInt64(pool.Object, "status", "updatedMachineCount")
+		degraded, _, _ := unstructured.NestedInt64(pool.Object, "status", "degradedMachineCount")
+
+		switch {
+		case conditionTrue(conditions, "Degraded") || degraded > 0:
+			findings = append(findings, UpgradeFinding{Severity: UpgradeSeverityBlocker, Object: object,
+				Message:  fmt.Sprintf("MachineConfigPool %s is degraded (%d of %d machines degraded)", pool.GetName(), degraded, machines),
+				resource: resourceRef("machineconfigpool", "", pool.GetName()), title: "MachineConfigPool is degraded"})
+		case conditionTrue(conditions, "Updating") || !conditionTrue(conditions, "Updated") || updated < machines:
+			findings = append(findings, UpgradeFinding{Severity: UpgradeSeverityBlocker, Object: object,
+				Message:  fmt.Sprintf("MachineConfigPool %s is not fully updated (%d of %d machines updated)", pool.GetName(), updated, machines),
+				resource: resourceRef("machineconfigpool", "", pool.GetName()), title: "MachineConfigPool is not fully updated",
+				action: "Wait for the pool to finish updating before starting the upgrade"})
+		}
+		if paused, _, _ := unstructured.NestedBool(pool.Object, "spec", "paused"); paused {
+			findings = append(findings, UpgradeFinding{Severity: UpgradeSeverityWarning, Object: object,
+				Message:  fmt.Sprintf("MachineConfigPool %s is paused; its nodes will not be updated until it is unpaused", pool.GetName()),
+				resource: resourceRef("machineconfigpool", "", pool.GetName()), title: "MachineConfigPool is paused",
+				action: "Unpause the pool, or plan to update its nodes after the upgrade"})
+		}
+	}
+	return findings
+}
+
+// podDisruptionBudgetFindings blocks on PDBs that currently allow no disruptions, since
+// the node drains of the upgrade will wait on them forever
+func podDisruptionBudgetFindings(pdbs []policyv1.PodDisruptionBudget) []UpgradeFinding {
+	var findings []UpgradeFinding
+	for _, pdb := range pdbs {
+		if pdb.Status.ExpectedPods == 0 || pdb.Status.DisruptionsAllowed > 0 {
+			continue
+		}
+		findings = append(findings, UpgradeFinding{
+			Severity: UpgradeSeverityBlocker,
+			Object:   fmt.Sprintf("poddisruptionbudget/%s/%s", pdb.Namespace, pdb.Name),
+			Message: fmt.Sprintf("PodDisruptionBudget %s/%s allows 0 disruptions (%d of %d pods healthy, %d desired); node drains will block",
+				pdb.Namespace, pdb.Name, pdb.Status.CurrentHealthy, pdb.Status.ExpectedPods, pdb.Status.DesiredHealthy),
+			resource: resourceRef("poddisruptionbudget", pdb.Namespace, pdb.Name),
+			title:    "PodDisruptionBudget allows no disruptions",
+			action:   "Scale the workload up or relax the budget so that node drains can evict its pods",
+		})
+	}
+	return findings
+}
+
+// pendingCSRFindings warns on CSRs that are neither approved nor denied; pending kubelet
+// CSRs leave nodes unable to rejoin after their reboot
+func pendingCSRFindings(csrs []certificatesv1.CertificateSigningRequest) []UpgradeFinding {
+	var findings []UpgradeFinding
+	for _, csr := range csrs {
+		pending := true
+		for _, condition := range csr.Status.Conditions {
+			if condition.Type == certificatesv1.CertificateApproved || condition.Type == certificatesv1.CertificateDenied || condition.Type == certificatesv1.CertificateFailed {
+				pending = false
+			}
+		}
+		if !pending {
+			continue
+		}
+		findings = append(findings, UpgradeFinding{
+			Severity: UpgradeSeverityWarning,
+			Object:   "csr/" + csr.Name,
+			Message:  fmt.Sprintf("CSR %s from %s for %s is pending approval", csr.Name, csr.Spec.Username, csr.Spec.SignerName),
+			resource: resourceRef("csr", "", csr.Name),
+			title:    "CSR is pending approval",
+			action:   "Approve or deny the CSR (oc adm certificate approve|deny)",
+		})
+	}
+	return findings
+}
+
+// nodeUpgradeFindings blocks on NotReady nodes and warns on cordoned ones
+func nodeUpgradeFindings(nodes []corev1.Node) []UpgradeFinding {
+	var findings []UpgradeFinding
+	for i := range nodes {
+		node := &nodes[i]
+		object := "node/" + node.Name
+		if !nodeReady(node) {
+			findings = append(findings, UpgradeFinding{Severity: UpgradeSeverityBlocker, Object: object,
+				Message:  fmt.Sprintf("node %s is NotReady", node.Name),
+				resource: resourceRef("node", "", node.Name), title: nodeNotReadyTitle})
+		}
+		if node.Spec.Unschedulable {
+			findings = append(findings, UpgradeFinding{Severity: UpgradeSeverityWarning, Object: object,
+				Message:  fmt.Sprintf("node %s is cordoned; uncordon it or confirm it is intentionally out of service", node.Name),
+				resource: resourceRef("node", "", node.Name), title: nodeCordonedTitle})
+		}
+	}
+	return findings
+}
+
+// deprecatedAPIFindings reports APIs scheduled for removal that were requested in the last
+// 24 hours. APIs removed in or before targetKubeMinor are blockers; without a target
+// (zero) every scheduled removal is a warning.
+func deprecatedAPIFindings(requestCounts []unstructured.Unstructured, targetKubeMinor int) []UpgradeFinding {
+	var findings []UpgradeFinding
+	for i := range requestCounts {
+		item := &requestCounts[i]
+		removedIn, _, _ := unstructured.NestedString(item.Object, "status", "removedInRelease")
+		requests, _, _ := unstructured.NestedInt64(item.Object, "status", "requestCount")
+		if removedIn == "" || requests == 0 {
+			continue
+		}
+		removedMinor, err := kubeMinor(removedIn)
+		if err != nil {
+			continue
+		}
+
+		severity := UpgradeSeverityWarning
+		if targetKubeMinor > 0 {
+			if removedMinor > targetKubeMinor {
+				continue
+			}
+			severity = UpgradeSeverityBlocker
+		}
+		findings = append(findings, UpgradeFinding{
+			Severity: severity,
+			Object:   "apirequestcount/" + item.GetName(),
+			Message:  fmt.Sprintf("%s is removed in Kubernetes %s and was requested %d times in the last 24h", item.GetName(), removedIn, requests),
+			resource: resourceRef("apirequestcount", "", item.GetName()),
+			title:    "Removed API is still in use",
+			action:   "Move the clients of this API to its replacement; the APIRequestCount lists them by user agent",
+		})
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Object < findings[j].Object })
+	return findings
+}
+
+// criticalAlertFindings blocks on every firing critical alert
+func criticalAlertFindings(alerts []clients.PromSample) []UpgradeFinding {
+	findings := make([]UpgradeFinding, 0, len(alerts))
+	for _, alert := range alerts {
+		name := alert.Labels["alertname"]
+		object := "alert/" + name
+		message := fmt.Sprintf("critical alert %s is firing", name)
+		if namespace := alert.Labels["namespace"]; namespace != "" {
+			object += "/" + namespace
+			message += " in namespace " + namespace
+		}
+		findings = append(findings, UpgradeFinding{Severity: UpgradeSeverityBlocker, Object: object, Message: message,
+			resource: resourceRef("alert", alert.Labels["namespace"], name), title: alertFiringTitle})
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].Object < findings[j].Object })
+	return findings
+}
+
+// upgradeFindings converts blockers and warnings to findings: blockers are
+// critical, and every finding also counts as an upgrade problem
+func upgradeFindings(upgrade []UpgradeFinding) []findings.Finding {
+	result := make([]findings.Finding, 0, len(upgrade))
+	for _, finding := range upgrade {
+		severity := findings.SeverityWarning
+		if finding.Severity == UpgradeSeverityBlocker {
+			severity = findings.SeverityCritical
+		}
+		result = append(result, findings.Finding{
+			Severity:        severity,
+			Category:        upgradeCheckCategories[finding.Check],
+			Resource:        finding.resource,
+			Title:           finding.title,
+			Detail:          finding.Message,
+			SuggestedAction: finding.action,
+			Source:          "assess-upgrade-readiness",
+		})
+	}
+	findings.Sort(result)
+	return result
+}
+
+// upgradeCheckCategories maps each check to the findings category of its
+// problems; the checks only about upgrades fall in the upgrade category
+var upgradeCheckCategories = map[string]string{
+	"cluster-operators":      findings.CategoryOperator,
+	"machine-config-pools":   findings.CategoryUpgrade,
+	"pod-disruption-budgets": findings.CategoryUpgrade,
+	"pending-csrs":           findings.CategoryNode,
+	"nodes":                  findings.CategoryNode,
+	"deprecated-apis":        findings.CategoryUpgrade,
+	"critical-alerts":        findings.CategoryAlert,
+}
+
+// upgradeVerdict turns the findings into go/no-go and a one-line summary
+func upgradeVerdict(blockers, warnings []UpgradeFinding, skipped []SkippedUpgradeCheck) (string, string) {
+	if len(blockers) > 0 {
+		return UpgradeVerdictNoGo, fmt.Sprintf("No-go: %d blocker(s) and %d warning(s); resolve the blockers before upgrading", len(blockers), len(warnings))
+	}
+	summary := "Go: no blockers found"
+	if len(warnings) > 0 {
+		summary += fmt.Sprintf(", but review %d warning(s) first", len(warnings))
+	}
+	if len(skipped) > 0 {
+		summary += fmt.Sprintf("; %d check(s) were skipped", len(skipped))
+	}
+	return UpgradeVerdictGo, summary
+}
+
+// targetKubeMinor returns the Kubernetes minor version of an OpenShift (4.N[.z]) or
+// Kubernetes (1.N[.z]) version
+func targetKubeMinor(version string) (int, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return 0, fmt.Errorf("target_version %q must look like 4.16 or 1.29", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return 0, fmt.Errorf("target_version %q must look like 4.16 or 1.29", version)
+	}
+	switch parts[0] {
+	case "1":
+		return minor, nil
+	case "4":
+		return minor + openShiftKubeMinorOffset, nil
+	default:
+		return 0, fmt.Errorf("target_version %q is neither an OpenShift 4.x nor a Kubernetes 1.x version", version)
+	}
+}
+
+// kubeMinor parses the minor version out of a Kubernetes release such as "1.29"
+func kubeMinor(release string) (int, error) {
+	_, minor, ok := strings.Cut(release, ".")
+	if !ok {
+		return 0, fmt.Errorf("invalid Kubernetes release %q", release)
+	}
+	return strconv.Atoi(minor)
+}
@@ -0,0 +1,363 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+var (
+	clusterVersionGVK    = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ClusterVersion"}
+	machineConfigPoolGVK = schema.GroupVersionKind{Group: "machineconfiguration.openshift.io", Version: "v1", Kind: "MachineConfigPool"}
+	apiRequestCountGVK   = schema.GroupVersionKind{Group: "apiserver.openshift.io", Version: "v1", Kind: "APIRequestCount"}
+)
+
+func newConditionsObject(gvk schema.GroupVersionKind, name string, status map[string]interface{}, conditions map[string]string) unstructured.Unstructured {
+	var list []interface{}
+	for _, conditionType := range []string{"Available", "Degraded", "Progressing", "Upgradeable", "Updated", "Updating"} {
+		if value, ok := conditions[conditionType]; ok {
+			list = append(list, map[string]interface{}{"type": conditionType, "status": value, "message": conditionType + " message"})
+		}
+	}
+	if status == nil {
+		status = map[string]interface{}{}
+	}
+	status["conditions"] = list
+	return *newUnstructured(gvk, "", name, map[string]interface{}{"status": status})
+}
+
+func newAPIRequestCount(name, removedIn string, requests int64) unstructured.Unstructured {
+	return *newUnstructured(apiRequestCountGVK, "", name, map[string]interface{}{
+		"status": map[string]interface{}{"removedInRelease": removedIn, "requestCount": requests},
+	})
+}
+
+func upgradeObjects(findings []UpgradeFinding) []string {
+	objects := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		objects = append(objects, finding.Severity+" "+finding.Object)
+	}
+	return objects
+}
+
+func TestClusterOperatorFindings(t *testing.T) {
+	operators := []unstructured.Unstructured{
+		newConditionsObject(clusterOperatorGVK, "healthy", nil, map[string]string{"Available": "True", "Degraded": "False", "Upgradeable": "True"}),
+		newConditionsObject(clusterOperatorGVK, "etcd", nil, map[string]string{"Available": "True", "Degraded": "True"}),
+		newConditionsObject(clusterOperatorGVK, "ingress", nil, map[string]string{"Available": "False"}),
+		newConditionsObject(clusterOperatorGVK, "storage", nil, map[string]string{"Available": "True", "Upgradeable": "False"}),
+		newConditionsObject(clusterOperatorGVK, "dns", nil, map[string]string{"Available": "True", "Progressing": "True"}),
+	}
+
+	findings := clusterOperatorFindings(operators)
+	assert.Equal(t, []string{
+		"blocker clusteroperator/etcd",
+		"blocker clusteroperator/ingress",
+		"blocker clusteroperator/storage",
+		"warning clusteroperator/dns",
+	}, upgradeObjects(findings))
+	assert.Equal(t, "ClusterOperator etcd is degraded: Degraded message", findings[0].Message)
+	assert.Equal(t, "ClusterOperator storage is not upgradeable: Upgradeable message", findings[2].Message)
+}
+
+func TestMachineConfigPoolFindings(t *testing.T) {
+	counts := func(machines, updated, degraded int64) map[string]interface{} {
+		return map[string]interface{}{"machineCount": machines, "updatedMachineCount": updated, "degradedMachineCount": degraded}
+	}
+	paused := newConditionsObject(machineConfigPoolGVK, "infra", counts(2, 2, 0), map[string]string{"Updated": "True"})
+	paused.Object["spec"] = map[string]interface{}{"paused": true}
+	pools := []unstructured.Unstructured{
+		newConditionsObject(machineConfigPoolGVK, "master", counts(3, 3, 0), map[string]string{"Updated": "True", "Updating": "False", "Degraded": "False"}),
+		newConditionsObject(machineConfigPoolGVK, "worker", counts(5, 3, 0), map[string]string{"Updated": "False", "Updating": "True"}),
+		newConditionsObject(machineConfigPoolGVK, "gpu", counts(2, 1, 1), map[string]string{"Updated": "False", "Degraded": "True"}),
+		paused,
+	}
+
+	findings := machineConfigPoolFindings(pools)
+	assert.Equal(t, []string{
+		"blocker machineconfigpool/worker",
+		"blocker machineconfigpool/gpu",
+		"warning machineconfigpool/infra",
+	}, upgradeObjects(findings))
+	assert.Equal(t, "MachineConfigPool worker is not fully updated (3 of 5 machines updated)", findings[0].Message)
+	assert.Equal(t, "MachineConfigPool gpu is degraded (1 of 2 machines degraded)", findings[1].Message)
+}
+
+func TestPodDisruptionBudgetFindings(t *testing.T) {
+	pdb := func(name string, expected, healthy, desired, allowed int32) policyv1.PodDisruptionBudget {
+		return policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Status:     policyv1.PodDisruptionBudgetStatus{ExpectedPods: expected, CurrentHealthy: healthy, DesiredHealthy: desired, DisruptionsAllowed: allowed},
+		}
+	}
+
+	findings := podDisruptionBudgetFindings([]policyv1.PodDisruptionBudget{
+		pdb("api", 3, 3, 2, 1),
+		pdb("db", 1, 1, 1, 0),
+		pdb("unused", 0, 0, 1, 0),
+	})
+	require.Len(t, findings, 1)
+	assert.Equal(t, UpgradeSeverityBlocker, findings[0].Severity)
+	assert.Equal(t, "poddisruptionbudget/shop/db", findings[0].Object)
+	assert.Contains(t, findings[0].Message, "allows 0 disruptions (1 of 1 pods healthy, 1 desired)")
+}
+
+func TestPendingCSRFindings(t *testing.T) {
+	csr := func(name string, conditions ...certificatesv1.RequestConditionType) certificatesv1.CertificateSigningRequest {
+		request := certificatesv1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       certificatesv1.CertificateSigningRequestSpec{Username: "system:node:worker-1", SignerName: certificatesv1.KubeletServingSignerName},
+		}
+		for _, condition := range conditions {
+			request.Status.Conditions = append(request.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{Type: condition, Status: corev1.ConditionTrue})
+		}
+		return request
+	}
+
+	findings := pendingCSRFindings([]certificatesv1.CertificateSigningRequest{
+		csr("csr-approved", certificatesv1.CertificateApproved),
+		csr("csr-denied", certificatesv1.CertificateDenied),
+		csr("csr-pending"),
+	})
+	assert.Equal(t, []string{"warning csr/csr-pending"}, upgradeObjects(findings))
+	assert.Equal(t, "CSR csr-pending from system:node:worker-1 for kubernetes.io/kubelet-serving is pending approval", findings[0].Message)
+}
+
+func TestNodeUpgradeFindings(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus, cordoned bool) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Unschedulable: cordoned},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+
+	findings := nodeUpgradeFindings([]corev1.Node{
+		node("ok", corev1.ConditionTrue, false),
+		node("cordoned", corev1.ConditionTrue, true),
+		node("down", corev1.ConditionFalse, true),
+	})
+	assert.Equal(t, []string{"warning node/cordoned", "blocker node/down", "warning node/down"}, upgradeObjects(findings))
+}
+
+func TestUpgradeFindings(t *testing.T) {
+	upgrade := nodeUpgradeFindings([]corev1.Node{{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Spec:       corev1.NodeSpec{Unschedulable: true},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}},
+	}})
+	pdbs := podDisruptionBudgetFindings([]policyv1.PodDisruptionBudget{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "db"},
+		Status:     policyv1.PodDisruptionBudgetStatus{ExpectedPods: 1, CurrentHealthy: 1, DesiredHealthy: 1},
+	}})
+	for i := range upgrade {
+		upgrade[i].Check = "nodes"
+	}
+	pdbs[0].Check = "pod-disruption-budgets"
+
+	converted := upgradeFindings(append(upgrade, pdbs...))
+	require.Len(t, converted, 3)
+
+	notReady := converted[0]
+	assert.Equal(t, findings.SeverityCritical, notReady.Severity)
+	assert.Equal(t, findings.CategoryNode, notReady.Category)
+	assert.Equal(t, "node/worker-1", notReady.Resource.String())
+	assert.Equal(t, nodeNotReadyTitle, notReady.Title)
+	assert.Equal(t, "node worker-1 is NotReady", notReady.Detail)
+	assert.Equal(t, "assess-upgrade-readiness", notReady.Source)
+
+	pdb := converted[1]
+	assert.Equal(t, findings.SeverityCritical, pdb.Severity)
+	assert.Equal(t, findings.CategoryUpgrade, pdb.Category)
+	assert.Equal(t, findings.ResourceRef{Kind: "poddisruptionbudget", Namespace: "shop", Name: "db"}, pdb.Resource)
+	assert.NotEmpty(t, pdb.SuggestedAction)
+
+	assert.Equal(t, findings.SeverityWarning, converted[2].Severity)
+	assert.Equal(t, nodeCordonedTitle, converted[2].Title)
+}
+
+func TestDeprecatedAPIFindings(t *testing.T) {
+	counts := []unstructured.Unstructured{
+		newAPIRequestCount("flowschemas.v1beta2.flowcontrol.apiserver.k8s.io", "1.29", 42),
+		newAPIRequestCount("horizontalpodautoscalers.v2beta2.autoscaling", "1.26", 7),
+		newAPIRequestCount("podsecuritypolicies.v1beta1.policy", "1.25", 0),
+		newAPIRequestCount("deployments.v1.apps", "", 900),
+		newAPIRequestCount("future.v1alpha1.example.io", "1.32", 3),
+	}
+
+	tests := []struct {
+		name   string
+		target int
+		want   []string
+	}{
+		{"no target warns on every scheduled removal", 0, []string{
+			"warning apirequestcount/flowschemas.v1beta2.flowcontrol.apiserver.k8s.io",
+			"warning apirequestcount/future.v1alpha1.example.io",
+			"warning apirequestcount/horizontalpodautoscalers.v2beta2.autoscaling",
+		}},
+		{"target 1.29 blocks on removals up to 1.29", 29, []string{
+			"blocker apirequestcount/flowschemas.v1beta2.flowcontrol.apiserver.k8s.io",
+			"blocker apirequestcount/horizontalpodautoscalers.v2beta2.autoscaling",
+		}},
+		{"target 1.27 ignores later removals", 27, []string{
+			"blocker apirequestcount/horizontalpodautoscalers.v2beta2.autoscaling",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, upgradeObjects(deprecatedAPIFindings(counts, tt.target)))
+		})
+	}
+}
+
+func TestCriticalAlertFindings(t *testing.T) {
+	findings := criticalAlertFindings([]clients.PromSample{
+		{Labels: map[string]string{"alertname": "KubeAPIDown"}},
+		{Labels: map[string]string{"alertname": "EtcdMembersDown", "namespace": "openshift-etcd"}},
+	})
+	assert.Equal(t, []string{"blocker alert/EtcdMembersDown/openshift-etcd", "blocker alert/KubeAPIDown"}, upgradeObjects(findings))
+	assert.Equal(t, "critical alert EtcdMembersDown is firing in namespace openshift-etcd", findings[0].Message)
+}
+
+func TestTargetKubeMinor(t *testing.T) {
+	tests := []struct {
+		version string
+		want    int
+		wantErr bool
+	}{
+		{"4.16", 29, false},
+		{"4.16.3", 29, false},
+		{"1.30", 30, false},
+		{"v1.28.2", 28, false},
+		{"4", 0, true},
+		{"5.1", 0, true},
+		{"4.x", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := targetKubeMinor(tt.version)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUpgradeVerdict(t *testing.T) {
+	blocker := UpgradeFinding{Severity: UpgradeSeverityBlocker}
+	warning := UpgradeFinding{Severity: UpgradeSeverityWarning}
+
+	verdict, summary := upgradeVerdict([]UpgradeFinding{blocker}, []UpgradeFinding{warning}, nil)
+	assert.Equal(t, UpgradeVerdictNoGo, verdict)
+	assert.Contains(t, summary, "1 blocker(s) and 1 warning(s)")
+
+	verdict, summary = upgradeVerdict(nil, []UpgradeFinding{warning}, []SkippedUpgradeCheck{{Check: "critical-alerts"}})
+	assert.Equal(t, UpgradeVerdictGo, verdict)
+	assert.Equal(t, "Go: no blockers found, but review 1 warning(s) first; 1 check(s) were skipped", summary)
+}
+
+func TestAssessUpgradeReadinessTool_OpenShift(t *testing.T) {
+	scheme := runtime.NewScheme()
+	mapper := meta.NewDefaultRESTMapper(nil)
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, gvk := range []schema.GroupVersionKind{clusterOperatorGVK, clusterVersionGVK, machineConfigPoolGVK, apiRequestCountGVK} {
+		mapper.Add(gvk, meta.RESTScopeRoot)
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		require.NoError(t, err)
+		listKinds[mapping.Resource] = gvk.Kind + "List"
+	}
+	operator := newConditionsObject(clusterOperatorGVK, "etcd", nil, map[string]string{"Available": "True", "Degraded": "True"})
+	pool := newConditionsObject(machineConfigPoolGVK, "worker", map[string]interface{}{"machineCount": int64(3), "updatedMachineCount": int64(3)}, map[string]string{"Updated": "True"})
+	requestCount := newAPIRequestCount("flowschemas.v1beta2.flowcontrol.apiserver.k8s.io", "1.29", 5)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, listKinds,
+		&operator, &pool, &requestCount,
+		newUnstructured(clusterVersionGVK, "", "version", map[string]interface{}{
+			"status": map[string]interface{}{"desired": map[string]interface{}{"version": "4.15.12"}},
+		}),
+	)
+	clientset := fake.NewClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Spec:       corev1.NodeSpec{Unschedulable: true},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+		},
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result := "[]"
+		if r.URL.Query().Get("query") == criticalAlertsQuery {
+			result = `[{"metric":{"alertname":"KubeAPIDown"},"value":[1717243200,"1"]}]`
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":%s}}`, result)
+	}))
+	t.Cleanup(server.Close)
+
+	tool := NewAssessUpgradeReadinessTool(
+		clients.NewK8sClientWithClients(clientset, dynamicClient, mapper),
+		clients.NewPrometheusClient(clients.PrometheusConfig{URL: server.URL}),
+		true,
+	)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"target_version": "4.16"})
+	require.NoError(t, err)
+	output := result.(AssessUpgradeReadinessOutput)
+
+	assert.Equal(t, UpgradeVerdictNoGo, output.Verdict)
+	assert.Equal(t, "4.15.12", output.CurrentVersion)
+	assert.Equal(t, "1.29", output.TargetKube)
+	assert.Len(t, output.ChecksRun, len(upgradeChecks))
+	assert.Empty(t, output.Skipped)
+	assert.Equal(t, []string{
+		"blocker clusteroperator/etcd",
+		"blocker apirequestcount/flowschemas.v1beta2.flowcontrol.apiserver.k8s.io",
+		"blocker alert/KubeAPIDown",
+	}, upgradeObjects(output.Blockers))
+	assert.Equal(t, "cluster-operators", output.Blockers[0].Check)
+	assert.Equal(t, []string{"warning node/worker-1"}, upgradeObjects(output.Warnings))
+	require.Len(t, output.Findings, 4)
+	assert.Equal(t, "alert/KubeAPIDown", output.Findings[0].Resource.String())
+	assert.Equal(t, findings.SeverityWarning, output.Findings[3].Severity)
+}
+
+func TestAssessUpgradeReadinessTool_Kubernetes(t *testing.T) {
+	tool := NewAssessUpgradeReadinessTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil, false)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(AssessUpgradeReadinessOutput)
+
+	assert.Equal(t, UpgradeVerdictGo, output.Verdict)
+	assert.Equal(t, []string{"pod-disruption-budgets", "pending-csrs", "nodes"}, output.ChecksRun)
+	require.Len(t, output.Skipped, 4)
+	assert.Equal(t, "critical-alerts", output.Skipped[3].Check)
+	assert.Contains(t, output.Summary, "4 check(s) were skipped")
+}
+
+func TestAssessUpgradeReadinessTool_InvalidTargetVersion(t *testing.T) {
+	tool := NewAssessUpgradeReadinessTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil, false)
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"target_version": "latest"})
+	require.Error(t, err)
+	assert.True(t, IsInvalidArguments(err))
+}
@@ -0,0 +1,32 @@
+package tools
+
+import "time"
+
+// Volatility is how quickly the data a tool returns goes stale. The
+// dispatcher turns it into the revalidation interval of the response's
+// _meta block when the data did not come from the cache.
+type Volatility string
+
+const (
+	VolatilityStatic   Volatility = "static"   // Changes on configuration changes, e.g. catalogs
+	VolatilitySlow     Volatility = "slow"     // Changes over hours, e.g. capacity trends and reports
+	VolatilityFast     Volatility = "fast"     // Changes within a minute, e.g. pod and node state
+	VolatilityRealtime Volatility = "realtime" // Only valid when read, e.g. logs and mutations
+)
+
+// DefaultVolatility is assumed for tools that do not declare one
+const DefaultVolatility = VolatilityFast
+
+// RevalidateAfter returns how long a result of this volatility may be reused
+func (v Volatility) RevalidateAfter() time.Duration {
+	switch v {
+	case VolatilityStatic:
+		return time.Hour
+	case VolatilitySlow:
+		return 5 * time.Minute
+	case VolatilityRealtime:
+		return 0
+	default:
+		return 30 * time.Second
+	}
+}
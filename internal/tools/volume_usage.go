@@ -0,0 +1,584 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// PromQL for the kubelet's per-PVC volume stats. %s adds label matchers. A
+// volume mounted on several nodes reports from each; they see the same
+// filesystem, so the series are folded with max.
+const (
+	volumeUsedBytesQuery     = `max by (namespace, persistentvolumeclaim) (kubelet_volume_stats_used_bytes{%s})`
+	volumeCapacityBytesQuery = `max by (namespace, persistentvolumeclaim) (kubelet_volume_stats_capacity_bytes{%s})`
+	volumeAvailableQuery     = `max by (namespace, persistentvolumeclaim) (kubelet_volume_stats_available_bytes{%s})`
+	volumeInodesUsedQuery    = `max by (namespace, persistentvolumeclaim) (kubelet_volume_stats_inodes_used{%s})`
+	volumeInodesQuery        = `max by (namespace, persistentvolumeclaim) (kubelet_volume_stats_inodes{%s})`
+)
+
+// Usage sources reported by get-volume-usage
+const (
+	VolumeUsageSourcePrometheus = "prometheus"    // kubelet_volume_stats metrics
+	VolumeUsageSourceKubelet    = "kubelet_stats" // The kubelet stats summary through the node proxy
+)
+
+const (
+	// kubeletStatsTimeout bounds each /stats/summary call through the node proxy
+	kubeletStatsTimeout = 10 * time.Second
+
+	// kubeletStatsConcurrency bounds the /stats/summary calls in flight
+	kubeletStatsConcurrency = 10
+
+	// volumeFullPercent is the space or inode use at which a volume's finding is critical
+	volumeFullPercent = 95
+)
+
+// GetVolumeUsageTool reports persistent volume claims that are filling up
+type GetVolumeUsageTool struct {
+	k8sClient  clients.ClusterReader
+	prometheus *clients.PrometheusClient // nil when Prometheus is not configured
+}
+
+// NewGetVolumeUsageTool creates a new get-volume-usage tool; prometheus may be nil
+func NewGetVolumeUsageTool(k8sClient clients.ClusterReader, prometheus *clients.PrometheusClient) *GetVolumeUsageTool {
+	return &GetVolumeUsageTool{
+		k8sClient:  k8sClient,
+		prometheus: prometheus,
+	}
+}
+
+// Name returns the tool name for MCP registration
+func (t *GetVolumeUsageTool) Name() string {
+	return "get-volume-usage"
+}
+
+// NamespaceScoped reports that claims are listed per namespace, limited to
+// the caller's namespaces when it is scoped
+func (t *GetVolumeUsageTool) NamespaceScoped() bool {
+	return true
+}
+
+// Description returns the tool description for MCP
+func (t *GetVolumeUsageTool) Description() string {
+	return `Report persistent volume claims whose volumes are filling up: bytes and inodes actually used, not the requested capacity. Usage comes from the kubelet_volume_stats metrics when Prometheus is enabled, otherwise from each kubelet's stats summary through the API server node proxy (nodes whose stats cannot be read, e.g. without nodes/proxy access, are listed in notes and the result is partial). Each claim above the threshold is listed with the pods mounting it and their workloads, fullest first.
+
+Use this tool for questions like:
+- "Are any volumes about to run out of space?"
+- "Which database PVCs are more than 90% full?"
+- "Which workloads would be hit if this volume fills up?"`
+}
+
+// InputSchema returns the JSON schema for tool inputs
+func (t *GetVolumeUsageTool) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Only check claims in this namespace. Leave empty for all namespaces.",
+				"default":     "",
+			},
+			"threshold_percent": map[string]interface{}{
+				"type":        "number",
+				"description": "Report claims whose used bytes or inodes are at least this percentage of the volume",
+				"default":     80,
+				"minimum":     0,
+				"maximum":     100,
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"description": "Maximum number of claims to report",
+				"default":     20,
+				"minimum":     1,
+				"maximum":     200,
+			},
+		},
+		"required": []string{},
+	}
+}
+
+// GetVolumeUsageInput represents the input parameters
+type GetVolumeUsageInput struct {
+	Namespace        string  `json:"namespace"`
+	ThresholdPercent float64 `json:"threshold_percent"`
+	Limit            int     `json:"limit"`
+}
+
+// VolumeUsage is the usage of one claim's volume
+type VolumeUsage struct {
+	Namespace         string             `json:"namespace"`
+	PVC               string             `json:"pvc"`
+	StorageClass      string             `json:"storage_class,omitempty"`
+	UsedBytes         int64              `json:"used_bytes"`
+	CapacityBytes     int64              `json:"capacity_bytes"` // Of the filesystem, which may differ from the claim's request
+	AvailableBytes    int64              `json:"available_bytes"`
+	UsedPercent       float64            `json:"used_percent"`
+	InodesUsedPercent float64            `json:"inodes_used_percent,omitempty"` // Zero when the filesystem does not report inodes
+	Pods              []string           `json:"pods,omitempty"`                // Pods mounting the claim
+	Workloads         []clients.Workload `json:"workloads,omitempty"`
+}
+
+// GetVolumeUsageOutput represents the tool output
+type GetVolumeUsageOutput struct {
+	Source              string             `json:"source"`
+	ThresholdPercent    float64            `json:"threshold_percent"`
+	VolumesChecked      int                `json:"volumes_checked"`                 // Bound claims with usage data
+	VolumesWithoutUsage int                `json:"volumes_without_usage,omitempty"` // Bound claims without, e.g. not mounted by a running pod
+	AboveThreshold      []VolumeUsage      `json:"above_threshold"`
+	Partial             bool               `json:"partial,omitempty"` // Usage of some nodes could not be read
+	Notes               []string           `json:"notes,omitempty"`
+	Summary             string             `json:"summary"`
+	Findings            []findings.Finding `json:"findings"` // The listed volumes in the common findings shape
+}
+
+// volumeStats is the usage one source reports for a volume
+type volumeStats struct {
+	UsedBytes      int64
+	CapacityBytes  int64
+	AvailableBytes int64
+	InodesUsed     int64
+	Inodes         int64
+}
+
+// kubeletStatsSummary is the part of the kubelet /stats/summary payload
+// get-volume-usage reads
+type kubeletStatsSummary struct {
+	Pods []struct {
+		PodRef  kubeletObjectRef     `json:"podRef"`
+		Volumes []kubeletVolumeStats `json:"volume"`
+	} `json:"pods"`
+}
+
+// kubeletObjectRef names a namespaced object in the stats summary
+type kubeletObjectRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// kubeletVolumeStats is one pod volume in the stats summary; pvcRef is only
+// set for volumes backed by a claim
+type kubeletVolumeStats struct {
+	Name           string            `json:"name"`
+	PVCRef         *kubeletObjectRef `json:"pvcRef,omitempty"`
+	UsedBytes      *uint64           `json:"usedBytes,omitempty"`
+	CapacityBytes  *uint64           `json:"capacityBytes,omitempty"`
+	AvailableBytes *uint64           `json:"availableBytes,omitempty"`
+	InodesUsed     *uint64           `json:"inodesUsed,omitempty"`
+	Inodes         *uint64           `json:"inodes,omitempty"`
+}
+
+// RequiredPermissions declares the Kubernetes API access get-volume-usage needs.
+// nodes/proxy is only used without Prometheus.
+func (t *GetVolumeUsageTool) RequiredPermissions() []PermissionRule {
+	rules := []PermissionRule{
+		{Resource: "persistentvolumeclaims", Verb: "list"},
+		{Resource: "pods", Verb: "list"},
+	}
+	if t.prometheus == nil {
+		rules = append(rules, PermissionRule{Resource: "nodes", Subresource: "proxy", Verb: "get"})
+	}
+	return rules
+}
+
+// Execute runs the get-volume-usage operation
+func (t *GetVolumeUsageTool) Execute(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input := GetVolumeUsageInput{ThresholdPercent: 80, Limit: 20}
+	if argsJSON, err := json.Marshal(args); err == nil {
+		_ = json.Unmarshal(argsJSON, &input) //nolint:errcheck // Intentionally ignore error, use defaults if unmarshal fails
+	}
+
+	if input.ThresholdPercent < 0 || input.ThresholdPercent > 100 {
+		return nil, invalidArgs("threshold_percent must be between 0 and 100")
+	}
+	if input.Limit < 1 || input.Limit > 200 {
+		return nil, invalidArgs("limit must be between 1 and 200")
+	}
+
+	var claims []corev1.PersistentVolumeClaim
+	var pods []corev1.Pod
+	for _, namespace := range clients.NamespacesToList(ctx, input.Namespace) {
+		claimList, err := t.k8sClient.Clientset().CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, apiError(fmt.Errorf("failed to list persistent volume claims: %w", err))
+		}
+		claims = append(claims, claimList.Items...)
+		podList, err := t.k8sClient.ListPods(ctx, namespace)
+		if err != nil {
+			return nil, apiError(err)
+		}
+		pods = append(pods, podList.Items...)
+	}
+	mounts := claimMounts(pods)
+
+	output := GetVolumeUsageOutput{ThresholdPercent: input.ThresholdPercent, AboveThreshold: []VolumeUsage{}}
+	var stats map[string]volumeStats
+	if t.prometheus != nil {
+		var err error
+		stats, err = t.queryVolumeStats(ctx, input.Namespace)
+		if err != nil {
+			output.Notes = append(output.Notes, fmt.Sprintf("Prometheus query failed, falling back to the kubelet stats summary: %v", err))
+		} else {
+			output.Source = VolumeUsageSourcePrometheus
+		}
+	}
+	if stats == nil {
+		output.Source = VolumeUsageSourceKubelet
+		summaries, failures := t.readKubeletStats(ctx, mountingNodes(pods, mounts))
+		stats = aggregateVolumeStats(summaries)
+		if len(failures) > 0 {
+			output.Partial = true
+			output.Notes = append(output.Notes, kubeletStatsNote(failures))
+		}
+	}
+
+	usage, withoutUsage := volumeUsageReport(claims, stats, mounts)
+	output.VolumesChecked = len(usage)
+	output.VolumesWithoutUsage = withoutUsage
+
+	owners := clients.NewOwnerResolver(t.k8sClient.Clientset())
+	podsByKey := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		podsByKey[pods[i].Namespace+"/"+pods[i].Name] = &pods[i]
+	}
+	for _, volume := range usage {
+		if math.Max(volume.UsedPercent, volume.InodesUsedPercent) < input.ThresholdPercent {
+			continue
+		}
+		if len(output.AboveThreshold) == input.Limit {
+			output.Notes = append(output.Notes, fmt.Sprintf("only the %d fullest claims above the threshold are listed", input.Limit))
+			break
+		}
+		volume.Workloads = volumeWorkloads(ctx, owners, volume, podsByKey)
+		output.AboveThreshold = append(output.AboveThreshold, volume)
+	}
+
+	above := 0
+	for _, volume := range usage {
+		if math.Max(volume.UsedPercent, volume.InodesUsedPercent) >= input.ThresholdPercent {
+			above++
+		}
+	}
+	output.Summary = fmt.Sprintf("%d of %d volume(s) at or above %g%% used", above, output.VolumesChecked, input.ThresholdPercent)
+	if output.Partial {
+		output.Summary += " (partial: usage of some nodes unavailable)"
+	}
+	output.Findings = volumeUsageFindings(output.AboveThreshold)
+	return output, nil
+}
+
+// volumeUsageFindings converts the volumes above the threshold to findings;
+// volumes at volumeFullPercent of their space or inodes are critical
+func volumeUsageFindings(volumes []VolumeUsage) []findings.Finding {
+	result := []findings.Finding{}
+	for _, volume := range volumes {
+		detail := fmt.Sprintf("%.1f%% of %d MiB used, %d MiB available", volume.UsedPercent, volume.CapacityBytes>>20, volume.AvailableBytes>>20)
+		if volume.InodesUsedPercent > 0 {
+			detail += fmt.Sprintf("; %.1f%% of inodes used", volume.InodesUsedPercent)
+		}
+		if len(volume.Workloads) > 0 {
+			names := make([]string, 0, len(volume.Workloads))
+			for _, workload := range volume.Workloads {
+				names = append(names, strings.ToLower(workload.Kind)+"/"+workload.Name)
+			}
+			detail += "; mounted by " + strings.Join(names, ", ")
+		}
+		severity := findings.SeverityWarning
+		if math.Max(volume.UsedPercent, volume.InodesUsedPercent) >= volumeFullPercent {
+			severity = findings.SeverityCritical
+		}
+		result = append(result, findings.Finding{
+			Severity:        severity,
+			Category:        findings.CategoryStorage,
+			Resource:        resourceRef("persistentvolumeclaim", volume.Namespace, volume.PVC),
+			Title:           "Volume is filling up",
+			Detail:          detail,
+			SuggestedAction: "Expand the claim if its storage class allows volume expansion, or delete data the workload no longer needs",
+			Source:          "get-volume-usage",
+		})
+	}
+	findings.Sort(result)
+	return result
+}
+
+// queryVolumeStats reads the kubelet volume stats of every claim from Prometheus, keyed by namespace/claim
+func (t *GetVolumeUsageTool) queryVolumeStats(ctx context.Context, namespace string) (map[string]volumeStats, error) {
+	matcher := ""
+	if namespace != "" {
+		matcher = fmt.Sprintf("namespace=%q", namespace)
+	}
+
+	stats := make(map[string]volumeStats)
+	queries := []struct {
+		query string
+		set   func(*volumeStats, int64)
+	}{
+		{volumeUsedBytesQuery, func(s *volumeStats, v int64) { s.UsedBytes = v }},
+		{volumeCapacityBytesQuery, func(s *volumeStats, v int64) { s.CapacityBytes = v }},
+		{volumeAvailableQuery, func(s *volumeStats, v int64) { s.AvailableBytes = v }},
+		{volumeInodesUsedQuery, func(s *volumeStats, v int64) { s.InodesUsed = v }},
+		{volumeInodesQuery, func(s *volumeStats, v int64) { s.Inodes = v }},
+	}
+	for _, q := range queries {
+		samples, err := t.prometheus.Query(ctx, fmt.Sprintf(q.query, matcher))
+		if err != nil {
+			return nil, dependencyError(err)
+		}
+		for _, sample := range samples {
+			key := sample.Labels["namespace"] + "/" + sample.Labels["persistentvolumeclaim"]
+			s := stats[key]
+			q.set(&s, int64(sample.Value))
+			stats[key] = s
+		}
+	}
+	return stats, nil
+}
+
+// readKubeletStats reads the stats summary of each node through the API
+// server node proxy, returning the summaries read and the error per node
+// that could not be
+func (t *GetVolumeUsageTool) readKubeletStats(ctx context.Context, nodes []string) ([]kubeletStatsSummary, map[string]error) {
+	var summaries []kubeletStatsSummary
+	failures := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, kubeletStatsConcurrency)
+	for _, node := range nodes {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			summary, err := t.kubeletStats(ctx, node)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[node] = err
+				return
+			}
+			summaries = append(summaries, summary)
+		}()
+	}
+	wg.Wait()
+	return summaries, failures
+}
+
+// kubeletStats reads one node's stats summary through the API server node proxy
+func (t *GetVolumeUsageTool) kubeletStats(ctx context.Context, node string) (kubeletStatsSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, kubeletStatsTimeout)
+	defer cancel()
+
+	restClient := t.k8sClient.Clientset().CoreV1().RESTClient()
+	if client, ok := restClient.(*rest.RESTClient); restClient == nil || (ok && client == nil) {
+		return kubeletStatsSummary{}, fmt.Errorf("node proxy not available")
+	}
+	body, err := restClient.Get().
+		AbsPath("/api/v1/nodes", node, "proxy", "stats", "summary").
+		DoRaw(ctx)
+	if err != nil {
+		return kubeletStatsSummary{}, err
+	}
+	var summary kubeletStatsSummary
+	if err := json.Unmarshal(body, &summary); err != nil {
+		return kubeletStatsSummary{}, fmt.Errorf("invalid stats summary: %w", err)
+	}
+	return summary, nil
+}
+
+// kubeletStatsNote describes the nodes whose stats could not be read,
+// calling out RBAC denials apart from other failures
+func kubeletStatsNote(failures map[string]error) string {
+	var denied, failed []string
+	for node, err := range failures {
+		if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+			denied = append(denied, node)
+		} else {
+			failed = append(failed, fmt.Sprintf("%s (%v)", node, err))
+		}
+	}
+	sort.Strings(denied)
+	sort.Strings(failed)
+
+	var parts []string
+	if len(denied) > 0 {
+		parts = append(parts, fmt.Sprintf("kubelet stats denied on %s (needs get on nodes/proxy, or enable Prometheus)", strings.Join(denied, ", ")))
+	}
+	if len(failed) > 0 {
+		parts = append(parts, "kubelet stats unavailable on "+strings.Join(failed, ", "))
+	}
+	return strings.Join(parts, "; ") + "; claims mounted there are not checked"
+}
+
+// claimMounts maps namespace/claim to the pods mounting it that have not
+// terminated, sorted by name
+func claimMounts(pods []corev1.Pod) map[string][]string {
+	mounts := make(map[string][]string)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			key := pod.Namespace + "/" + volume.PersistentVolumeClaim.ClaimName
+			if len(mounts[key]) == 0 || mounts[key][len(mounts[key])-1] != pod.Name {
+				mounts[key] = append(mounts[key], pod.Name)
+			}
+		}
+	}
+	for _, names := range mounts {
+		sort.Strings(names)
+	}
+	return mounts
+}
+
+// mountingNodes returns the nodes running a pod that mounts a claim, sorted;
+// only their kubelets can report claim usage
+func mountingNodes(pods []corev1.Pod, mounts map[string][]string) []string {
+	seen := make(map[string]bool)
+	var nodes []string
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || seen[pod.Spec.NodeName] {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil && len(mounts[pod.Namespace+"/"+volume.PersistentVolumeClaim.ClaimName]) > 0 {
+				seen[pod.Spec.NodeName] = true
+				nodes = append(nodes, pod.Spec.NodeName)
+				break
+			}
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// aggregateVolumeStats folds the claim-backed volumes of kubelet stats
+// summaries into usage per namespace/claim. A claim mounted by several pods
+// appears once per pod; they share a filesystem, so the highest reading
+// (the most recent write) is kept. Volumes without a claim are skipped.
+func aggregateVolumeStats(summaries []kubeletStatsSummary) map[string]volumeStats {
+	stats := make(map[string]volumeStats)
+	for _, summary := range summaries {
+		for _, pod := range summary.Pods {
+			for _, volume := range pod.Volumes {
+				if volume.PVCRef == nil || volume.UsedBytes == nil || volume.CapacityBytes == nil {
+					continue
+				}
+				key := volume.PVCRef.Namespace + "/" + volume.PVCRef.Name
+				reading := volumeStats{
+					UsedBytes:      uint64Value(volume.UsedBytes),
+					CapacityBytes:  uint64Value(volume.CapacityBytes),
+					AvailableBytes: uint64Value(volume.AvailableBytes),
+					InodesUsed:     uint64Value(volume.InodesUsed),
+					Inodes:         uint64Value(volume.Inodes),
+				}
+				if existing, ok := stats[key]; !ok || reading.UsedBytes > existing.UsedBytes {
+					stats[key] = reading
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// volumeUsageReport joins bound claims with their usage and mounting pods,
+// fullest first (by the larger of bytes and inodes used, then by name). It
+// also counts the bound claims without usage data.
+func volumeUsageReport(claims []corev1.PersistentVolumeClaim, stats map[string]volumeStats, mounts map[string][]string) ([]VolumeUsage, int) {
+	var usage []VolumeUsage
+	withoutUsage := 0
+	for _, claim := range claims {
+		if claim.Status.Phase != corev1.ClaimBound {
+			continue
+		}
+		key := claim.Namespace + "/" + claim.Name
+		s, ok := stats[key]
+		if !ok || s.CapacityBytes <= 0 {
+			withoutUsage++
+			continue
+		}
+		volume := VolumeUsage{
+			Namespace:      claim.Namespace,
+			PVC:            claim.Name,
+			UsedBytes:      s.UsedBytes,
+			CapacityBytes:  s.CapacityBytes,
+			AvailableBytes: s.AvailableBytes,
+			UsedPercent:    percentage(s.UsedBytes, s.CapacityBytes),
+			Pods:           mounts[key],
+		}
+		if claim.Spec.StorageClassName != nil {
+			volume.StorageClass = *claim.Spec.StorageClassName
+		}
+		if s.Inodes > 0 {
+			volume.InodesUsedPercent = percentage(s.InodesUsed, s.Inodes)
+		}
+		usage = append(usage, volume)
+	}
+
+	sort.SliceStable(usage, func(i, j int) bool {
+		fullest := func(v VolumeUsage) float64 { return math.Max(v.UsedPercent, v.InodesUsedPercent) }
+		if fullest(usage[i]) != fullest(usage[j]) {
+			return fullest(usage[i]) > fullest(usage[j])
+		}
+		if usage[i].Namespace != usage[j].Namespace {
+			return usage[i].Namespace < usage[j].Namespace
+		}
+		return usage[i].PVC < usage[j].PVC
+	})
+	return usage, withoutUsage
+}
+
+// volumeWorkloads resolves the pods mounting a volume to their workloads,
+// sorted by kind and name
+func volumeWorkloads(ctx context.Context, owners *clients.OwnerResolver, volume VolumeUsage, pods map[string]*corev1.Pod) []clients.Workload {
+	seen := make(map[clients.Workload]bool)
+	var workloads []clients.Workload
+	for _, name := range volume.Pods {
+		pod, ok := pods[volume.Namespace+"/"+name]
+		if !ok {
+			continue
+		}
+		workload := owners.Resolve(ctx, pod)
+		if !seen[workload] {
+			seen[workload] = true
+			workloads = append(workloads, workload)
+		}
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].Kind != workloads[j].Kind {
+			return workloads[i].Kind < workloads[j].Kind
+		}
+		return workloads[i].Name < workloads[j].Name
+	})
+	return workloads
+}
+
+// percentage returns part as a percentage of total, to one decimal place
+func percentage(part, total int64) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 10
+}
+
+// uint64Value returns *v as an int64, or 0 when v is nil
+func uint64Value(v *uint64) int64 {
+	if v == nil {
+		return 0
+	}
+	return int64(*v)
+}
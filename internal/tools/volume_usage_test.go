@@ -0,0 +1,288 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+)
+
+// newClaim creates a bound claim in the given storage class
+func newClaim(namespace, name, storageClass string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClass},
+		Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+	}
+}
+
+// newMountingPod creates a running StatefulSet pod on node mounting the claims
+func newMountingPod(namespace, name, statefulSet, node string, claims ...string) *corev1.Pod {
+	controller := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       namespace,
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{{Kind: "StatefulSet", Name: statefulSet, Controller: &controller}},
+		},
+		Spec:   corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	for _, claim := range claims {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name:         claim,
+			VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+		})
+	}
+	return pod
+}
+
+// statsSummary builds a kubelet stats summary payload with one pod per claim
+// reading; each reading is namespace, claim, used bytes, capacity bytes
+func statsSummary(t *testing.T, readings ...[]interface{}) []byte {
+	t.Helper()
+	var pods []map[string]interface{}
+	for i, r := range readings {
+		used, capacity := r[2].(int), r[3].(int)
+		pods = append(pods, map[string]interface{}{
+			"podRef": map[string]string{"name": fmt.Sprintf("pod-%d", i), "namespace": r[0].(string)},
+			"volume": []map[string]interface{}{
+				{"name": "kube-api-access", "usedBytes": 4096, "capacityBytes": 1 << 30},
+				{
+					"name":           "data",
+					"pvcRef":         map[string]string{"name": r[1].(string), "namespace": r[0].(string)},
+					"usedBytes":      used,
+					"capacityBytes":  capacity,
+					"availableBytes": capacity - used,
+					"inodesUsed":     10,
+					"inodes":         1000,
+				},
+			},
+		})
+	}
+	body, err := json.Marshal(map[string]interface{}{"node": map[string]string{"nodeName": "worker"}, "pods": pods})
+	require.NoError(t, err)
+	return body
+}
+
+func TestAggregateVolumeStats(t *testing.T) {
+	var first, second kubeletStatsSummary
+	require.NoError(t, json.Unmarshal(statsSummary(t,
+		[]interface{}{"db", "data-db-0", 90, 100},
+		[]interface{}{"shop", "uploads", 40, 100},
+	), &first))
+	// uploads is ReadWriteMany and also mounted on a second node, which read it later
+	require.NoError(t, json.Unmarshal(statsSummary(t, []interface{}{"shop", "uploads", 45, 100}), &second))
+
+	stats := aggregateVolumeStats([]kubeletStatsSummary{first, second})
+
+	require.Len(t, stats, 2, "volumes without a claim are skipped")
+	assert.Equal(t, volumeStats{UsedBytes: 90, CapacityBytes: 100, AvailableBytes: 10, InodesUsed: 10, Inodes: 1000}, stats["db/data-db-0"])
+	assert.Equal(t, int64(45), stats["shop/uploads"].UsedBytes)
+	assert.Empty(t, aggregateVolumeStats(nil))
+}
+
+func TestVolumeUsageReport(t *testing.T) {
+	pending := newClaim("shop", "pending", "gp3")
+	pending.Status.Phase = corev1.ClaimPending
+	claims := []corev1.PersistentVolumeClaim{
+		*newClaim("shop", "uploads", "efs"),
+		*newClaim("db", "data-db-0", "gp3"),
+		*newClaim("db", "wal-db-0", "gp3"),
+		*newClaim("shop", "unmounted", "gp3"),
+		*pending,
+	}
+	stats := map[string]volumeStats{
+		"shop/uploads":  {UsedBytes: 40, CapacityBytes: 100, AvailableBytes: 60, InodesUsed: 950, Inodes: 1000},
+		"db/data-db-0":  {UsedBytes: 90, CapacityBytes: 100, AvailableBytes: 10},
+		"db/wal-db-0":   {UsedBytes: 10, CapacityBytes: 100, AvailableBytes: 90, InodesUsed: 1, Inodes: 1000},
+		"shop/orphaned": {UsedBytes: 99, CapacityBytes: 100},
+	}
+	mounts := map[string][]string{"db/data-db-0": {"db-0"}, "shop/uploads": {"web-a", "web-b"}}
+
+	usage, withoutUsage := volumeUsageReport(claims, stats, mounts)
+
+	require.Len(t, usage, 3)
+	assert.Equal(t, 1, withoutUsage, "pending claims are not counted")
+	assert.Equal(t, "uploads", usage[0].PVC, "inode exhaustion ranks with byte usage")
+	assert.Equal(t, 95.0, usage[0].InodesUsedPercent)
+	assert.Equal(t, []string{"web-a", "web-b"}, usage[0].Pods)
+	assert.Equal(t, "efs", usage[0].StorageClass)
+	assert.Equal(t, "data-db-0", usage[1].PVC)
+	assert.Equal(t, 90.0, usage[1].UsedPercent)
+	assert.Zero(t, usage[1].InodesUsedPercent)
+	assert.Equal(t, "wal-db-0", usage[2].PVC)
+}
+
+func TestVolumeUsageFindings(t *testing.T) {
+	converted := volumeUsageFindings([]VolumeUsage{
+		{Namespace: "db", PVC: "data-db-0", UsedBytes: 8 << 30, CapacityBytes: 10 << 30, AvailableBytes: 2 << 30, UsedPercent: 80,
+			Workloads: []clients.Workload{{Kind: "StatefulSet", Namespace: "db", Name: "db"}}},
+		{Namespace: "logs", PVC: "spool", UsedBytes: 1 << 30, CapacityBytes: 10 << 30, AvailableBytes: 9 << 30, UsedPercent: 10, InodesUsedPercent: 97.5},
+	})
+	require.Len(t, converted, 2)
+
+	inodes := converted[0]
+	assert.Equal(t, findings.SeverityCritical, inodes.Severity, "inode use counts too")
+	assert.Equal(t, findings.CategoryStorage, inodes.Category)
+	assert.Equal(t, "persistentvolumeclaim/logs/spool", inodes.Resource.String())
+	assert.Equal(t, "Volume is filling up", inodes.Title)
+	assert.Equal(t, "10.0% of 10240 MiB used, 9216 MiB available; 97.5% of inodes used", inodes.Detail)
+	assert.Equal(t, "get-volume-usage", inodes.Source)
+
+	data := converted[1]
+	assert.Equal(t, findings.SeverityWarning, data.Severity)
+	assert.Equal(t, "persistentvolumeclaim/db/data-db-0", data.Resource.String())
+	assert.Equal(t, "80.0% of 10240 MiB used, 2048 MiB available; mounted by statefulset/db", data.Detail)
+
+	assert.Empty(t, volumeUsageFindings(nil))
+}
+
+func TestClaimMounts(t *testing.T) {
+	done := newMountingPod("db", "backup-1", "backup", "worker-1", "data-db-0")
+	done.Status.Phase = corev1.PodSucceeded
+	pods := []corev1.Pod{
+		*newMountingPod("db", "db-1", "db", "worker-2", "data-db-1"),
+		*newMountingPod("db", "db-0", "db", "worker-1", "data-db-0", "wal-db-0"),
+		*done,
+		*newMountingPod("db", "stateless", "web", "worker-3"),
+	}
+
+	mounts := claimMounts(pods)
+
+	assert.Equal(t, map[string][]string{"db/data-db-0": {"db-0"}, "db/wal-db-0": {"db-0"}, "db/data-db-1": {"db-1"}}, mounts)
+	assert.Equal(t, []string{"worker-1", "worker-2"}, mountingNodes(pods, mounts))
+}
+
+func TestGetVolumeUsageTool_Prometheus(t *testing.T) {
+	matcher := `namespace="db"`
+	fixture := map[string]string{
+		fmt.Sprintf(volumeUsedBytesQuery, matcher): `[
+			{"metric":{"namespace":"db","persistentvolumeclaim":"data-db-0"},"value":[1717243200,"8589934592"]},
+			{"metric":{"namespace":"db","persistentvolumeclaim":"data-db-1"},"value":[1717243200,"1073741824"]}]`,
+		fmt.Sprintf(volumeCapacityBytesQuery, matcher): `[
+			{"metric":{"namespace":"db","persistentvolumeclaim":"data-db-0"},"value":[1717243200,"10737418240"]},
+			{"metric":{"namespace":"db","persistentvolumeclaim":"data-db-1"},"value":[1717243200,"10737418240"]}]`,
+		fmt.Sprintf(volumeAvailableQuery, matcher): `[
+			{"metric":{"namespace":"db","persistentvolumeclaim":"data-db-0"},"value":[1717243200,"2147483648"]}]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, ok := fixture[r.URL.Query().Get("query")]
+		if !ok {
+			result = "[]"
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":%s}}`, result)
+	}))
+	t.Cleanup(server.Close)
+
+	tool := NewGetVolumeUsageTool(
+		clients.NewK8sClientWithClientset(fake.NewClientset(
+			newClaim("db", "data-db-0", "gp3"),
+			newClaim("db", "data-db-1", "gp3"),
+			newMountingPod("db", "db-0", "db", "worker-1", "data-db-0"),
+			newMountingPod("db", "db-1", "db", "worker-2", "data-db-1"),
+		)),
+		clients.NewPrometheusClient(clients.PrometheusConfig{URL: server.URL}),
+	)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{"namespace": "db"})
+	require.NoError(t, err)
+	output := result.(GetVolumeUsageOutput)
+
+	assert.Equal(t, VolumeUsageSourcePrometheus, output.Source)
+	assert.Equal(t, 2, output.VolumesChecked)
+	require.Len(t, output.AboveThreshold, 1)
+	volume := output.AboveThreshold[0]
+	assert.Equal(t, "data-db-0", volume.PVC)
+	assert.Equal(t, 80.0, volume.UsedPercent)
+	assert.Equal(t, int64(2147483648), volume.AvailableBytes)
+	assert.Equal(t, []string{"db-0"}, volume.Pods)
+	assert.Equal(t, []clients.Workload{{Kind: "StatefulSet", Namespace: "db", Name: "db"}}, volume.Workloads)
+	assert.False(t, output.Partial)
+	require.Len(t, output.Findings, 1)
+	assert.Equal(t, "persistentvolumeclaim/db/data-db-0", output.Findings[0].Resource.String())
+	assert.Empty(t, output.Notes)
+	assert.NotContains(t, tool.RequiredPermissions(), PermissionRule{Resource: "nodes", Subresource: "proxy", Verb: "get"})
+
+	result, err = tool.Execute(context.Background(), map[string]interface{}{"namespace": "db", "threshold_percent": 5})
+	require.NoError(t, err)
+	assert.Len(t, result.(GetVolumeUsageOutput).AboveThreshold, 2)
+}
+
+func TestGetVolumeUsageTool_KubeletStats(t *testing.T) {
+	summary := statsSummary(t, []interface{}{"db", "data-db-0", 95, 100})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/nodes/worker-1/proxy/stats/summary":
+			_, _ = w.Write(summary)
+		case "/api/v1/nodes/worker-2/proxy/stats/summary":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403,
+				"message":"nodes \"worker-2\" is forbidden: User \"system:serviceaccount:mcp:reader\" cannot get resource \"nodes/proxy\""}`))
+		default:
+			http.Error(w, "dial tcp 10.0.0.3:10250: connect: connection refused", http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	require.NoError(t, err)
+	tool := NewGetVolumeUsageTool(clients.NewK8sClientWithClientset(clientset), nil)
+
+	summaries, failures := tool.readKubeletStats(context.Background(), []string{"worker-1", "worker-2", "worker-3"})
+	require.Len(t, summaries, 1)
+	assert.Equal(t, int64(95), aggregateVolumeStats(summaries)["db/data-db-0"].UsedBytes)
+	require.Len(t, failures, 2)
+
+	note := kubeletStatsNote(failures)
+	assert.Contains(t, note, "denied on worker-2 (needs get on nodes/proxy")
+	assert.Contains(t, note, "unavailable on worker-3")
+	assert.Contains(t, tool.RequiredPermissions(), PermissionRule{Resource: "nodes", Subresource: "proxy", Verb: "get"})
+}
+
+func TestGetVolumeUsageTool_PartialWithoutNodeProxy(t *testing.T) {
+	objects := []runtime.Object{
+		newClaim("db", "data-db-0", "gp3"),
+		newMountingPod("db", "db-0", "db", "worker-1", "data-db-0"),
+	}
+	// The fake clientset has no node proxy, so every node fails like one the server may not proxy to
+	tool := NewGetVolumeUsageTool(clients.NewK8sClientWithClientset(fake.NewClientset(objects...)), nil)
+
+	result, err := tool.Execute(context.Background(), map[string]interface{}{})
+	require.NoError(t, err)
+	output := result.(GetVolumeUsageOutput)
+
+	assert.Equal(t, VolumeUsageSourceKubelet, output.Source)
+	assert.True(t, output.Partial)
+	assert.Zero(t, output.VolumesChecked)
+	assert.Equal(t, 1, output.VolumesWithoutUsage)
+	assert.Empty(t, output.AboveThreshold)
+	require.Len(t, output.Notes, 1)
+	assert.Contains(t, output.Notes[0], "worker-1")
+	assert.Contains(t, output.Summary, "partial")
+}
+
+func TestGetVolumeUsageTool_InvalidArgs(t *testing.T) {
+	tool := NewGetVolumeUsageTool(clients.NewK8sClientWithClientset(fake.NewClientset()), nil)
+
+	for _, args := range []map[string]interface{}{{"threshold_percent": -1}, {"threshold_percent": 101}, {"limit": 0}, {"limit": 201}} {
+		_, err := tool.Execute(context.Background(), args)
+		require.Error(t, err)
+		assert.True(t, IsInvalidArguments(err))
+	}
+}
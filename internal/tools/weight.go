@@ -0,0 +1,14 @@
+package tools
+
+// Weight is how expensive a tool call is. The dispatcher runs heavy calls
+// within a limit of their own, so that a few log searches or reports cannot
+// hold every execution slot while cheap health reads wait behind them.
+type Weight string
+
+const (
+	WeightLight Weight = "light" // A handful of API reads, e.g. health checks and listings
+	WeightHeavy Weight = "heavy" // Many reads, log scans, model calls or long-running actions
+)
+
+// DefaultWeight is assumed for tools that do not declare one
+const DefaultWeight = WeightLight
@@ -0,0 +1,169 @@
+// Package analysis provides local time-series forecasting used when no
+// forecasting model is served by KServe.
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Method names the algorithm that produced a Projection
+type Method string
+
+const (
+	// MethodLinearRegression fits a least-squares line through the whole series
+	MethodLinearRegression Method = "linear_regression"
+	// MethodHoltWinters is additive Holt-Winters smoothing with a daily season,
+	// which follows recent trend changes without mistaking the daily cycle for growth
+	MethodHoltWinters Method = "holt_winters"
+)
+
+const (
+	// holtWintersSeasons is the number of whole days a series must cover before
+	// its daily season can be estimated
+	holtWintersSeasons = 2
+
+	holtAlpha = 0.3 // Level smoothing factor
+	holtBeta  = 0.1 // Trend smoothing factor
+	holtGamma = 0.1 // Season smoothing factor
+
+	// flatGrowthPerDay is the growth below which a series is treated as flat
+	flatGrowthPerDay = 1e-6
+)
+
+// Point is one observation of a series
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Projection is a series' trend projected against a threshold
+type Projection struct {
+	Method       Method
+	Current      float64 // Fitted value at the last point
+	GrowthPerDay float64 // Change in value per day; negative when decreasing
+	// DaysUntilThreshold is 0 when Current is already at or over the threshold,
+	// and nil when the series is flat or decreasing and never reaches it
+	DaysUntilThreshold *float64
+}
+
+// Project fits a trend to points and estimates when it crosses threshold.
+// Evenly spaced series covering at least two days use Holt-Winters with a daily
+// season, others linear regression. Points need not be sorted.
+func Project(points []Point, threshold float64) (Projection, error) {
+	if len(points) < 2 {
+		return Projection{}, fmt.Errorf("need at least 2 points to forecast, got %d", len(points))
+	}
+
+	sorted := make([]Point, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	span := sorted[len(sorted)-1].Time.Sub(sorted[0].Time)
+	if span <= 0 {
+		return Projection{}, fmt.Errorf("points span no time")
+	}
+
+	var projection Projection
+	if season := dailySeason(sorted, span); season > 0 {
+		projection = holtWinters(sorted, season)
+	} else {
+		projection = linearRegression(sorted)
+	}
+
+	switch {
+	case projection.Current >= threshold:
+		days := 0.0
+		projection.DaysUntilThreshold = &days
+	case projection.GrowthPerDay > flatGrowthPerDay:
+		days := (threshold - projection.Current) / projection.GrowthPerDay
+		projection.DaysUntilThreshold = &days
+	}
+	return projection, nil
+}
+
+// linearRegression fits value = intercept + slope*days by least squares
+func linearRegression(points []Point) Projection {
+	origin := points[0].Time
+	n := float64(len(points))
+	var sumX, sumY, sumXY, sumX2 float64
+	for _, p := range points {
+		x := p.Time.Sub(origin).Hours() / 24
+		sumX += x
+		sumY += p.Value
+		sumXY += x * p.Value
+		sumX2 += x * x
+	}
+
+	slope := 0.0
+	if denominator := n*sumX2 - sumX*sumX; denominator != 0 {
+		slope = (n*sumXY - sumX*sumY) / denominator
+	}
+	intercept := (sumY - slope*sumX) / n
+	last := points[len(points)-1].Time.Sub(origin).Hours() / 24
+
+	return Projection{
+		Method:       MethodLinearRegression,
+		Current:      intercept + slope*last,
+		GrowthPerDay: slope,
+	}
+}
+
+// dailySeason returns the number of points per day when the series is evenly
+// spaced, a day is a whole number of steps, and the series covers
+// holtWintersSeasons days; otherwise 0
+func dailySeason(points []Point, span time.Duration) int {
+	step := span / time.Duration(len(points)-1)
+	if step <= 0 || (24*time.Hour)%step != 0 {
+		return 0
+	}
+	for i := 1; i < len(points); i++ {
+		if points[i].Time.Sub(points[i-1].Time) != step {
+			return 0
+		}
+	}
+	season := int(24 * time.Hour / step)
+	if season < 2 || len(points) < holtWintersSeasons*season {
+		return 0
+	}
+	return season
+}
+
+// holtWinters smooths the series with additive Holt-Winters. The level, trend,
+// and season are seeded from a regression fit so the first days do not dominate
+// them. Current is the deseasonalized level at the last point.
+func holtWinters(points []Point, season int) Projection {
+	fit := linearRegression(points)
+	origin := points[0].Time
+	days := func(p Point) float64 { return p.Time.Sub(origin).Hours() / 24 }
+	intercept := fit.Current - fit.GrowthPerDay*days(points[len(points)-1])
+
+	// Seed each phase of the season with its average deviation from the fit
+	seasonal := make([]float64, season)
+	counts := make([]int, season)
+	for i, p := range points {
+		seasonal[i%season] += p.Value - (intercept + fit.GrowthPerDay*days(p))
+		counts[i%season]++
+	}
+	for i := range seasonal {
+		seasonal[i] /= float64(counts[i])
+	}
+
+	stepDays := days(points[1])
+	trend := fit.GrowthPerDay * stepDays
+	level := intercept - trend // The first update lands the level on the fit
+	for i, p := range points {
+		phase := i % season
+		previous := level
+		level = holtAlpha*(p.Value-seasonal[phase]) + (1-holtAlpha)*(level+trend)
+		trend = holtBeta*(level-previous) + (1-holtBeta)*trend
+		seasonal[phase] = holtGamma*(p.Value-level) + (1-holtGamma)*seasonal[phase]
+	}
+
+	return Projection{
+		Method:       MethodHoltWinters,
+		Current:      level,
+		GrowthPerDay: trend / stepDays,
+	}
+}
@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// series returns n hourly points following value(day)
+func series(n int, value func(day float64) float64) []Point {
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	points := make([]Point, n)
+	for i := range points {
+		at := start.Add(time.Duration(i) * time.Hour)
+		points[i] = Point{Time: at, Value: value(at.Sub(start).Hours() / 24)}
+	}
+	return points
+}
+
+func TestProject(t *testing.T) {
+	tests := []struct {
+		name       string
+		points     []Point
+		threshold  float64
+		wantMethod Method
+		wantGrowth float64
+		wantDays   float64 // -1 when the threshold is never reached
+	}{
+		{
+			name:       "increasing, short series uses regression",
+			points:     series(12, func(d float64) float64 { return 50 + 24*d }), // 1/hour
+			threshold:  85,
+			wantMethod: MethodLinearRegression,
+			wantGrowth: 24,
+			wantDays:   (85 - 61) / 24.0,
+		},
+		{
+			name:       "increasing, long series uses Holt-Winters",
+			points:     series(14*24, func(d float64) float64 { return 40 + 2*d }),
+			threshold:  85,
+			wantMethod: MethodHoltWinters,
+			wantGrowth: 2,
+			wantDays:   (85 - (40 + 2*(14*24-1)/24.0)) / 2,
+		},
+		{
+			name:       "noisy daily cycle on a trend",
+			points:     series(14*24, func(d float64) float64 { return 40 + 1.5*d + 5*math.Sin(2*math.Pi*d) }),
+			threshold:  90,
+			wantMethod: MethodHoltWinters,
+			wantGrowth: 1.5,
+			wantDays:   (90 - 61) / 1.5,
+		},
+		{
+			name:       "flat",
+			points:     series(48, func(float64) float64 { return 60 }),
+			threshold:  85,
+			wantMethod: MethodHoltWinters,
+			wantGrowth: 0,
+			wantDays:   -1,
+		},
+		{
+			name:       "decreasing",
+			points:     series(10, func(d float64) float64 { return 70 - 3*d }),
+			threshold:  85,
+			wantMethod: MethodLinearRegression,
+			wantGrowth: -3,
+			wantDays:   -1,
+		},
+		{
+			name:       "already over the threshold",
+			points:     series(10, func(d float64) float64 { return 90 - d }),
+			threshold:  85,
+			wantMethod: MethodLinearRegression,
+			wantGrowth: -1,
+			wantDays:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Project(tt.points, tt.threshold)
+			if err != nil {
+				t.Fatalf("Project() error = %v", err)
+			}
+			if got.Method != tt.wantMethod {
+				t.Errorf("Method = %s, want %s", got.Method, tt.wantMethod)
+			}
+			if math.Abs(got.GrowthPerDay-tt.wantGrowth) > 0.25 {
+				t.Errorf("GrowthPerDay = %.3f, want ~%.3f", got.GrowthPerDay, tt.wantGrowth)
+			}
+			switch {
+			case tt.wantDays < 0 && got.DaysUntilThreshold != nil:
+				t.Errorf("DaysUntilThreshold = %.2f, want never", *got.DaysUntilThreshold)
+			case tt.wantDays >= 0 && got.DaysUntilThreshold == nil:
+				t.Errorf("DaysUntilThreshold = never, want ~%.2f", tt.wantDays)
+			case tt.wantDays >= 0 && math.Abs(*got.DaysUntilThreshold-tt.wantDays) > 0.1*tt.wantDays+0.05:
+				t.Errorf("DaysUntilThreshold = %.2f, want ~%.2f", *got.DaysUntilThreshold, tt.wantDays)
+			}
+		})
+	}
+}
+
+func TestProject_UnsortedMatchesSorted(t *testing.T) {
+	points := series(8, func(d float64) float64 { return 10 + 6*d })
+	reversed := make([]Point, len(points))
+	for i, p := range points {
+		reversed[len(points)-1-i] = p
+	}
+
+	want, _ := Project(points, 50)
+	got, err := Project(reversed, 50)
+	if err != nil {
+		t.Fatalf("Project() error = %v", err)
+	}
+	if got.Current != want.Current || got.GrowthPerDay != want.GrowthPerDay {
+		t.Errorf("unsorted projection %+v differs from sorted %+v", got, want)
+	}
+}
+
+func TestProject_Errors(t *testing.T) {
+	at := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := Project([]Point{{Time: at, Value: 1}}, 85); err == nil {
+		t.Error("Project() with one point should fail")
+	}
+	if _, err := Project([]Point{{Time: at, Value: 1}, {Time: at, Value: 2}}, 85); err == nil {
+		t.Error("Project() with no time span should fail")
+	}
+}
@@ -0,0 +1,47 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromBuildInfo_UsesVCSSettings(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.24.4",
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0b96c4a1f2e3d4c5b6a7980112233445566778899"},
+			{Key: "vcs.time", Value: "2026-10-01T12:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	info := fromBuildInfo(bi, true)
+	assert.Equal(t, Version, info.Version)
+	assert.Equal(t, "0b96c4a1f2e3d4c5b6a7980112233445566778899", info.Commit)
+	assert.Equal(t, "2026-10-01T12:00:00Z", info.BuildDate)
+	assert.Equal(t, "go1.24.4", info.GoVersion)
+	assert.True(t, info.Modified)
+	assert.Equal(t, Version+" (commit 0b96c4a1f2e3-dirty, built 2026-10-01T12:00:00Z, go1.24.4)", info.String())
+}
+
+func TestFromBuildInfo_PrefersLdflags(t *testing.T) {
+	previousCommit, previousDate := Commit, BuildDate
+	t.Cleanup(func() { Commit, BuildDate = previousCommit, previousDate })
+	Commit, BuildDate = "abc1234", "2026-10-15"
+
+	bi := &debug.BuildInfo{Settings: []debug.BuildSetting{
+		{Key: "vcs.revision", Value: "ffffffff"},
+		{Key: "vcs.time", Value: "2020-01-01T00:00:00Z"},
+	}}
+	info := fromBuildInfo(bi, true)
+	assert.Equal(t, "abc1234", info.Commit)
+	assert.Equal(t, "2026-10-15", info.BuildDate)
+}
+
+func TestFromBuildInfo_WithoutBuildInfo(t *testing.T) {
+	info := fromBuildInfo(nil, false)
+	assert.NotEmpty(t, info.GoVersion, "falls back to the runtime version")
+	assert.Contains(t, info.String(), "commit unknown, built unknown")
+}
@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// keySeparator joins the segments of a cache key
+const keySeparator = ":"
+
+// keyEscaper keeps a separator inside a segment (e.g. a label selector) from
+// splitting it, so distinct segment lists always build distinct keys
+var keyEscaper = strings.NewReplacer("%", "%25", keySeparator, "%3A")
+
+// Key builds a cache key from segments, e.g. Key("tool", "list-pods", namespace, Hash(selector)).
+// The first segment names the kind of entry ("tool", "resource", ...); statistics
+// are grouped by it and DeletePrefix matches whole segments.
+func Key(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = keyEscaper.Replace(segment)
+	}
+	return strings.Join(escaped, keySeparator)
+}
+
+// Hash shortens an unbounded value such as a label selector or request body
+// into a fixed-length key segment
+func Hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// keyPrefix returns the first segment of key
+func keyPrefix(key string) string {
+	prefix, _, _ := strings.Cut(key, keySeparator)
+	return prefix
+}
+
+// hasKeyPrefix reports whether key is prefix or starts with prefix's segments
+func hasKeyPrefix(key, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, keySeparator)
+	return key == prefix || strings.HasPrefix(key, prefix+keySeparator)
+}
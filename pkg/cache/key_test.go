@@ -0,0 +1,56 @@
+package cache
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	if got := Key("tool", "list-pods", "payments"); got != "tool:list-pods:payments" {
+		t.Errorf("Key() = %q, want tool:list-pods:payments", got)
+	}
+
+	// A separator inside a segment is escaped, so segment lists never collide
+	a := Key("tool", "list-pods", "app=api:v1")
+	b := Key("tool", "list-pods:app=api", "v1")
+	if a == b {
+		t.Errorf("Key() built %q for two different segment lists", a)
+	}
+	if got := keyPrefix(Key("resource:cluster", "nodes")); got != "resource%3Acluster" {
+		t.Errorf("keyPrefix() = %q, want the escaped first segment", got)
+	}
+	if Key("a%3Ab") == Key("a:b") {
+		t.Error("Key() should escape % so escaped and literal separators differ")
+	}
+}
+
+func TestHash(t *testing.T) {
+	h := Hash("app=api,tier=backend")
+	if len(h) != 16 {
+		t.Errorf("Hash() length = %d, want 16", len(h))
+	}
+	if h != Hash("app=api,tier=backend") {
+		t.Error("Hash() should be deterministic")
+	}
+	if h == Hash("app=api,tier=frontend") {
+		t.Error("Hash() returned the same value for different inputs")
+	}
+}
+
+func TestHasKeyPrefix(t *testing.T) {
+	tests := []struct {
+		key    string
+		prefix string
+		want   bool
+	}{
+		{"tool:list-pods:payments", "tool", true},
+		{"tool:list-pods:payments", "tool:", true},
+		{"tool:list-pods:payments", "tool:list-pods", true},
+		{"tool:list-pods:payments", "tool:list-pods:payments", true},
+		{"tool:list-pods:payments-v2", "tool:list-pods:payments", false},
+		{"tools:list-pods", "tool", false},
+		{"resource:cluster", "tool", false},
+	}
+	for _, tt := range tests {
+		if got := hasKeyPrefix(tt.key, tt.prefix); got != tt.want {
+			t.Errorf("hasKeyPrefix(%q, %q) = %v, want %v", tt.key, tt.prefix, got, tt.want)
+		}
+	}
+}
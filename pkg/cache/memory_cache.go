@@ -2,54 +2,163 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"k8s.io/utils/clock"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
 )
 
 // CacheEntry represents a cached item with expiration
 type CacheEntry struct {
 	Value      interface{}
+	Err        error // Set for negative entries, which cache a failure instead of a value
+	Created    time.Time
 	Expiration time.Time
 }
 
 // IsExpired checks if the cache entry has expired
 func (e *CacheEntry) IsExpired() bool {
-	return time.Now().After(e.Expiration)
+	return e.expiredAt(time.Now())
+}
+
+// expiredAt reports whether the entry has expired at now
+func (e *CacheEntry) expiredAt(now time.Time) bool {
+	return now.After(e.Expiration)
+}
+
+// staleUntil is when the entry stops being served to background requests,
+// BackgroundStaleFactor TTLs after it was stored. Failures are never stale.
+func (e *CacheEntry) staleUntil() time.Time {
+	if e.Err != nil {
+		return e.Expiration
+	}
+	return e.Created.Add(BackgroundStaleFactor * e.Expiration.Sub(e.Created))
+}
+
+// CachedError is a failure served from a negative cache entry instead of a
+// fresh attempt. Age is how long ago the failure was observed.
+type CachedError struct {
+	Err error
+	Age time.Duration
+}
+
+func (e *CachedError) Error() string {
+	return fmt.Sprintf("%v (cached failure, age %ds)", e.Err, int(e.Age.Seconds()))
+}
+
+func (e *CachedError) Unwrap() error {
+	return e.Err
+}
+
+// IsCachedError reports whether err is, or wraps, a failure served from the cache
+func IsCachedError(err error) bool {
+	var cached *CachedError
+	return errors.As(err, &cached)
 }
 
 // Statistics tracks cache performance metrics
 type Statistics struct {
+	Hits         int64   `json:"hits"`
+	Misses       int64   `json:"misses"`
+	NegativeHits int64   `json:"negative_hits"` // Cached failures served instead of a fresh attempt
+	StaleHits    int64   `json:"stale_hits"`    // Expired values served to background requests, included in Hits
+	Evictions    int64   `json:"evictions"`
+	Entries      int     `json:"entries"`
+	HitRate      float64 `json:"hit_rate"`
+
+	// ByPrefix breaks the counts down by first key segment (see Key);
+	// only filled by GetStatisticsByPrefix
+	ByPrefix map[string]PrefixStatistics `json:"by_prefix,omitempty"`
+
+	// ByPriority breaks the lookups down by priority class (see Priority);
+	// only filled by GetStatisticsByPriority
+	ByPriority map[Priority]PriorityStatistics `json:"by_priority,omitempty"`
+}
+
+// PrefixStatistics are the cache metrics of the keys sharing a first segment
+type PrefixStatistics struct {
+	Hits         int64   `json:"hits"`
+	Misses       int64   `json:"misses"`
+	NegativeHits int64   `json:"negative_hits"`
+	Entries      int     `json:"entries"`
+	HitRate      float64 `json:"hit_rate"`
+}
+
+// PriorityStatistics are the cache metrics of the lookups of one priority class
+type PriorityStatistics struct {
 	Hits      int64   `json:"hits"`
 	Misses    int64   `json:"misses"`
-	Evictions int64   `json:"evictions"`
-	Entries   int     `json:"entries"`
+	StaleHits int64   `json:"stale_hits"`
 	HitRate   float64 `json:"hit_rate"`
 }
 
+// lookupCounters counts cache lookups by outcome
+type lookupCounters struct {
+	hits         int64
+	misses       int64
+	negativeHits int64
+	staleHits    int64
+}
+
+// hitRate is the percentage of value lookups that hit
+func (l *lookupCounters) hitRate() float64 {
+	total := l.hits + l.misses
+	if total == 0 {
+		return 0
+	}
+	return float64(l.hits) / float64(total) * 100
+}
+
 // MemoryCache provides a thread-safe in-memory cache with TTL
 type MemoryCache struct {
 	mu            sync.RWMutex
 	data          map[string]*CacheEntry
 	defaultTTL    time.Duration
-	cleanupTicker *time.Ticker
+	clock         clock.WithTicker
+	cleanupTicker clock.Ticker
 	stopCleanup   chan bool
 	stats         struct {
-		hits      int64
-		misses    int64
+		lookupCounters
 		evictions int64
 	}
+	byPrefix   map[string]*lookupCounters   // Lookups per first key segment
+	byPriority map[Priority]*lookupCounters // Lookups per priority class
+	inflight   map[string]*flight           // Computes that background reads of their key share
+}
+
+// flight is a compute in progress, shared by the background reads of its key
+type flight struct {
+	done  chan struct{}
+	value interface{}
+	err   error
 }
 
 // NewMemoryCache creates a new in-memory cache with the specified default TTL
 func NewMemoryCache(defaultTTL time.Duration) *MemoryCache {
+	return NewMemoryCacheWithClock(defaultTTL, clock.RealClock{})
+}
+
+// NewMemoryCacheWithClock is NewMemoryCache on clk, which tests step instead
+// of sleeping past TTLs
+func NewMemoryCacheWithClock(defaultTTL time.Duration, clk clock.WithTicker) *MemoryCache {
 	cache := &MemoryCache{
 		data:        make(map[string]*CacheEntry),
+		byPrefix:    make(map[string]*lookupCounters),
+		byPriority:  make(map[Priority]*lookupCounters),
+		inflight:    make(map[string]*flight),
 		defaultTTL:  defaultTTL,
+		clock:       clk,
 		stopCleanup: make(chan bool),
 	}
 
 	// Start background cleanup every minute
-	cache.cleanupTicker = time.NewTicker(1 * time.Minute)
+	cache.cleanupTicker = clk.NewTicker(1 * time.Minute)
 	go cache.cleanupExpired()
 
 	return cache
@@ -57,28 +166,129 @@ func NewMemoryCache(defaultTTL time.Duration) *MemoryCache {
 
 // Get retrieves a value from the cache
 func (c *MemoryCache) Get(key string) (interface{}, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	value, _, _, found := c.lookup(key, 0, nil, PriorityInteractive)
+	return value, found
+}
+
+// GetWithAge is Get that also returns how long ago the value was stored
+func (c *MemoryCache) GetWithAge(key string) (interface{}, time.Duration, bool) {
+	value, age, _, found := c.lookup(key, 0, nil, PriorityInteractive)
+	return value, age, found
+}
+
+// GetWithMaxAge is GetWithAge that misses when the value was stored more than
+// maxAge ago, even if its TTL has not expired (maxAge <= 0 accepts any age)
+func (c *MemoryCache) GetWithMaxAge(key string, maxAge time.Duration) (interface{}, time.Duration, bool) {
+	value, age, _, found := c.lookup(key, maxAge, nil, PriorityInteractive)
+	return value, age, found
+}
+
+// lookup returns the value under key if it is no older than maxAge (any age
+// when maxAge <= 0) and accept reports it usable (any value when accept is nil).
+// An unusable value counts as a miss and is evicted, so it gets recomputed.
+// Background lookups ignore maxAge and also take expired values within the
+// stale window, reported as stale.
+func (c *MemoryCache) lookup(key string, maxAge time.Duration, accept func(interface{}) bool, priority Priority) (value interface{}, age time.Duration, stale, found bool) {
+	// A write lock: lookups update the statistics
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	entry, exists := c.data[key]
 	if !exists {
-		c.stats.misses++
-		return nil, false
+		c.count(key, priority, false, false)
+		return nil, 0, false, false
+	}
+
+	// Check if expired or too old for the caller; negative entries hold no value
+	now := c.clock.Now()
+	age = now.Sub(entry.Created)
+	usable := !entry.expiredAt(now) && (maxAge <= 0 || age <= maxAge)
+	if priority == PriorityBackground {
+		usable = now.Before(entry.staleUntil())
+	}
+	if !usable || entry.Err != nil {
+		c.count(key, priority, false, false)
+		return nil, 0, false, false
 	}
 
-	// Check if expired
-	if entry.IsExpired() {
-		c.stats.misses++
+	if accept != nil && !accept(entry.Value) {
+		delete(c.data, key)
+		c.stats.evictions++
+		c.count(key, priority, false, false)
+		return nil, 0, false, false
+	}
+
+	stale = entry.expiredAt(now)
+	c.count(key, priority, true, stale)
+	return entry.Value, age, stale, true
+}
+
+// count records the outcome of a lookup overall, for the key's prefix, and
+// for the priority class. Callers hold c.mu.
+func (c *MemoryCache) count(key string, priority Priority, hit, stale bool) {
+	class, ok := c.byPriority[priority]
+	if !ok {
+		class = &lookupCounters{}
+		c.byPriority[priority] = class
+	}
+	for _, counters := range []*lookupCounters{&c.stats.lookupCounters, c.prefixCounters(key), class} {
+		switch {
+		case !hit:
+			counters.misses++
+		case stale:
+			counters.hits++
+			counters.staleHits++
+		default:
+			counters.hits++
+		}
+	}
+}
+
+// prefixCounters returns the lookup counters for key's first segment. Callers hold c.mu.
+func (c *MemoryCache) prefixCounters(key string) *lookupCounters {
+	prefix := keyPrefix(key)
+	counters, ok := c.byPrefix[prefix]
+	if !ok {
+		counters = &lookupCounters{}
+		c.byPrefix[prefix] = counters
+	}
+	return counters
+}
+
+// GetError returns the failure cached under key by SetError, if it has not expired
+func (c *MemoryCache) GetError(key string) (*CachedError, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.data[key]
+	now := c.clock.Now()
+	if !exists || entry.Err == nil || entry.expiredAt(now) {
 		return nil, false
 	}
 
-	c.stats.hits++
-	return entry.Value, true
+	c.stats.negativeHits++
+	c.prefixCounters(key).negativeHits++
+	return &CachedError{Err: entry.Err, Age: now.Sub(entry.Created)}, true
 }
 
 // Set stores a value in the cache with the default TTL
 func (c *MemoryCache) Set(key string, value interface{}) {
-	c.SetWithTTL(key, value, c.defaultTTL)
+	c.SetWithTTL(key, value, c.DefaultTTL())
+}
+
+// DefaultTTL returns the TTL applied by Set
+func (c *MemoryCache) DefaultTTL() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.defaultTTL
+}
+
+// SetDefaultTTL changes the TTL applied by Set for future entries.
+// Existing entries keep their original expiration.
+func (c *MemoryCache) SetDefaultTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.defaultTTL = ttl
 }
 
 // SetWithTTL stores a value in the cache with a custom TTL
@@ -86,9 +296,25 @@ func (c *MemoryCache) SetWithTTL(key string, value interface{}, ttl time.Duratio
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	now := c.clock.Now()
 	c.data[key] = &CacheEntry{
 		Value:      value,
-		Expiration: time.Now().Add(ttl),
+		Created:    now,
+		Expiration: now.Add(ttl),
+	}
+}
+
+// SetError caches a failure under key for ttl, replacing any value.
+// Get misses on the key meanwhile; GetError returns the failure.
+func (c *MemoryCache) SetError(key string, err error, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	c.data[key] = &CacheEntry{
+		Err:        err,
+		Created:    now,
+		Expiration: now.Add(ttl),
 	}
 }
 
@@ -103,6 +329,25 @@ func (c *MemoryCache) Delete(key string) {
 	}
 }
 
+// DeletePrefix removes every entry whose key starts with the segments of
+// prefix, e.g. DeletePrefix(Key("tool", "list-pods", "payments")) drops the
+// payments entries of list-pods but not those of a "payments-v2" namespace.
+// It returns the number of entries removed.
+func (c *MemoryCache) DeletePrefix(prefix string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	removed := 0
+	for key := range c.data {
+		if hasKeyPrefix(key, prefix) {
+			delete(c.data, key)
+			removed++
+		}
+	}
+	c.stats.evictions += int64(removed)
+	return removed
+}
+
 // Clear removes all entries from the cache
 func (c *MemoryCache) Clear() {
 	c.mu.Lock()
@@ -117,20 +362,68 @@ func (c *MemoryCache) Clear() {
 func (c *MemoryCache) GetStatistics() Statistics {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+	return c.statistics()
+}
 
-	total := c.stats.hits + c.stats.misses
-	hitRate := 0.0
-	if total > 0 {
-		hitRate = float64(c.stats.hits) / float64(total) * 100
+// statistics builds the overall statistics. Callers hold c.mu.
+func (c *MemoryCache) statistics() Statistics {
+	return Statistics{
+		Hits:         c.stats.hits,
+		Misses:       c.stats.misses,
+		NegativeHits: c.stats.negativeHits,
+		StaleHits:    c.stats.staleHits,
+		Evictions:    c.stats.evictions,
+		Entries:      len(c.data),
+		HitRate:      c.stats.hitRate(),
 	}
+}
 
-	return Statistics{
-		Hits:      c.stats.hits,
-		Misses:    c.stats.misses,
-		Evictions: c.stats.evictions,
-		Entries:   len(c.data),
-		HitRate:   hitRate,
+// GetStatisticsByPrefix returns current cache statistics with ByPrefix filled in.
+// A prefix appears once it has been looked up or holds an entry.
+func (c *MemoryCache) GetStatisticsByPrefix() Statistics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := c.statistics()
+	stats.ByPrefix = make(map[string]PrefixStatistics, len(c.byPrefix))
+	for prefix, counters := range c.byPrefix {
+		stats.ByPrefix[prefix] = PrefixStatistics{
+			Hits:         counters.hits,
+			Misses:       counters.misses,
+			NegativeHits: counters.negativeHits,
+			HitRate:      counters.hitRate(),
+		}
+	}
+	for key := range c.data {
+		prefix := keyPrefix(key)
+		prefixStats := stats.ByPrefix[prefix]
+		prefixStats.Entries++
+		stats.ByPrefix[prefix] = prefixStats
+	}
+	return stats
+}
+
+// GetStatisticsByPriority returns current cache statistics with ByPriority
+// filled in, for every priority class
+func (c *MemoryCache) GetStatisticsByPriority() Statistics {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := c.statistics()
+	stats.ByPriority = make(map[Priority]PriorityStatistics, len(Priorities))
+	for _, priority := range Priorities {
+		counters := c.byPriority[priority]
+		if counters == nil {
+			counters = &lookupCounters{}
+		}
+		stats.ByPriority[priority] = PriorityStatistics{
+			Hits:      counters.hits,
+			Misses:    counters.misses,
+			StaleHits: counters.staleHits,
+			HitRate:   counters.hitRate(),
+		}
 	}
+	return stats
 }
 
 // ResetStatistics resets cache statistics counters
@@ -138,23 +431,24 @@ func (c *MemoryCache) ResetStatistics() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.stats.hits = 0
-	c.stats.misses = 0
+	c.stats.lookupCounters = lookupCounters{}
 	c.stats.evictions = 0
+	c.byPrefix = make(map[string]*lookupCounters)
+	c.byPriority = make(map[Priority]*lookupCounters)
 }
 
 // cleanupExpired removes expired entries from the cache
 func (c *MemoryCache) cleanupExpired() {
 	for {
 		select {
-		case <-c.cleanupTicker.C:
+		case <-c.cleanupTicker.C():
 			c.mu.Lock()
-			now := time.Now()
+			now := c.clock.Now()
 			expiredKeys := []string{}
 
-			// Find expired entries
+			// Find entries past their stale window
 			for key, entry := range c.data {
-				if now.After(entry.Expiration) {
+				if now.After(entry.staleUntil()) {
 					expiredKeys = append(expiredKeys, key)
 				}
 			}
@@ -182,38 +476,129 @@ func (c *MemoryCache) Close() {
 // GetOrSet retrieves a value from cache or computes it if not present
 // This is useful for lazy-loading patterns
 func (c *MemoryCache) GetOrSet(ctx context.Context, key string, compute func() (interface{}, error)) (interface{}, error) {
-	// Try to get from cache first
-	if value, found := c.Get(key); found {
-		return value, nil
+	value, _, err := c.getOrCompute(ctx, key, c.DefaultTTL(), 0, 0, nil, compute)
+	return value, err
+}
+
+// GetOrSetWithTTL retrieves a value from cache or computes it with custom TTL
+func (c *MemoryCache) GetOrSetWithTTL(ctx context.Context, key string, ttl time.Duration, compute func() (interface{}, error)) (interface{}, error) {
+	value, _, err := c.getOrCompute(ctx, key, ttl, 0, 0, nil, compute)
+	return value, err
+}
+
+// GetOrSetWithNegativeTTL is GetOrSetWithTTL that also caches failures, for
+// negativeTTL, so a failing dependency is not retried on every call. Fresh
+// failures are returned as-is; cached ones as a *CachedError. Failures caused
+// by ctx ending, and failures that are already cached errors, are not cached.
+func (c *MemoryCache) GetOrSetWithNegativeTTL(ctx context.Context, key string, ttl, negativeTTL time.Duration, compute func() (interface{}, error)) (interface{}, error) {
+	value, _, err := c.getOrCompute(ctx, key, ttl, 0, negativeTTL, nil, compute)
+	return value, err
+}
+
+// getOrCompute is the path of every GetOrSet function: a cached value no
+// older than maxAge that accept takes, else compute's, stored for ttl (and a
+// failure for negativeTTL, when positive). It also returns the age of the
+// value served. Under PriorityBackground (see WithPriority) a stale value is
+// served and revalidated in the background, and a miss waits for a compute
+// of the key already in progress rather than starting another.
+func (c *MemoryCache) getOrCompute(ctx context.Context, key string, ttl, maxAge, negativeTTL time.Duration, accept func(interface{}) bool, compute func() (interface{}, error)) (interface{}, time.Duration, error) {
+	priority := PriorityFromContext(ctx)
+	if value, age, stale, found := c.lookup(key, maxAge, accept, priority); found {
+		recordHit(ctx, age, ttl)
+		if stale {
+			c.revalidate(ctx, key, ttl, compute)
+		}
+		return value, age, nil
+	}
+	if negativeTTL > 0 {
+		if cached, found := c.GetError(key); found {
+			return nil, 0, cached
+		}
 	}
 
-	// Not in cache, compute the value
-	value, err := compute()
+	var value interface{}
+	var err error
+	if priority == PriorityBackground {
+		value, err = c.computeShared(ctx, key, compute)
+	} else {
+		value, err = c.traceCompute(ctx, key, compute)
+	}
 	if err != nil {
-		return nil, err
+		if negativeTTL > 0 && ctx.Err() == nil && !IsCachedError(err) {
+			c.SetError(key, err, negativeTTL)
+		}
+		return nil, 0, err
 	}
 
-	// Store in cache
-	c.Set(key, value)
-
-	return value, nil
+	c.SetWithTTL(key, value, ttl)
+	recordCompute(ctx, ttl)
+	return value, 0, nil
 }
 
-// GetOrSetWithTTL retrieves a value from cache or computes it with custom TTL
-func (c *MemoryCache) GetOrSetWithTTL(ctx context.Context, key string, ttl time.Duration, compute func() (interface{}, error)) (interface{}, error) {
-	// Try to get from cache first
-	if value, found := c.Get(key); found {
-		return value, nil
+// computeShared runs compute, or waits for the compute of key in progress
+func (c *MemoryCache) computeShared(ctx context.Context, key string, compute func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if running, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-running.done:
+			return running.value, running.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
+	f := &flight{done: make(chan struct{})}
+	c.inflight[key] = f
+	c.mu.Unlock()
 
-	// Not in cache, compute the value
-	value, err := compute()
-	if err != nil {
-		return nil, err
+	f.value, f.err = c.traceCompute(ctx, key, compute)
+	c.land(key, f)
+	return f.value, f.err
+}
+
+// revalidate recomputes a stale value in the background and stores it for
+// ttl, unless a compute of key is already in progress. A failure keeps the
+// stale value until its stale window ends.
+func (c *MemoryCache) revalidate(ctx context.Context, key string, ttl time.Duration, compute func() (interface{}, error)) {
+	c.mu.Lock()
+	if _, running := c.inflight[key]; running {
+		c.mu.Unlock()
+		return
 	}
+	f := &flight{done: make(chan struct{})}
+	c.inflight[key] = f
+	c.mu.Unlock()
+
+	// Only background reads are served stale, so ctx carries a priority state
+	state := priorityFrom(ctx)
+	state.revalidations.Add(1)
+	go func() {
+		defer state.revalidations.Done()
+		f.value, f.err = c.traceCompute(ctx, key, compute)
+		if f.err == nil {
+			c.SetWithTTL(key, f.value, ttl)
+		} else {
+			log.Printf("cache: revalidating %q failed, serving the stale value meanwhile: %v", key, f.err)
+		}
+		c.land(key, f)
+	}()
+}
 
-	// Store in cache with custom TTL
-	c.SetWithTTL(key, value, ttl)
+// land ends the flight of key, releasing the reads waiting for it
+func (c *MemoryCache) land(key string, f *flight) {
+	c.mu.Lock()
+	if c.inflight[key] == f {
+		delete(c.inflight, key)
+	}
+	c.mu.Unlock()
+	close(f.done)
+}
 
-	return value, nil
+// traceCompute runs a cache-miss compute inside its own span so slow
+// computations are visible in traces
+func (c *MemoryCache) traceCompute(ctx context.Context, key string, compute func() (interface{}, error)) (interface{}, error) {
+	_, span := tracing.StartSpan(ctx, "cache.compute", attribute.String("cache.key", key))
+	value, err := compute()
+	tracing.EndSpan(span, err)
+	return value, err
 }
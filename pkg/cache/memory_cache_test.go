@@ -2,10 +2,23 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
+
+	testclock "k8s.io/utils/clock/testing"
 )
 
+// newFakeClockCache returns a cache on a fake clock that tests step past TTLs
+func newFakeClockCache(t *testing.T, defaultTTL time.Duration) (*MemoryCache, *testclock.FakeClock) {
+	t.Helper()
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	cache := NewMemoryCacheWithClock(defaultTTL, clk)
+	t.Cleanup(cache.Close)
+	return cache, clk
+}
+
 func TestNewMemoryCache(t *testing.T) {
 	cache := NewMemoryCache(30 * time.Second)
 	if cache == nil {
@@ -41,8 +54,7 @@ func TestMemoryCache_SetAndGet(t *testing.T) {
 }
 
 func TestMemoryCache_SetWithTTL(t *testing.T) {
-	cache := NewMemoryCache(1 * time.Minute)
-	defer cache.Close()
+	cache, clk := newFakeClockCache(t, 1*time.Minute)
 
 	// Set with short TTL
 	cache.SetWithTTL("shortlived", "value", 100*time.Millisecond)
@@ -54,7 +66,7 @@ func TestMemoryCache_SetWithTTL(t *testing.T) {
 	}
 
 	// Wait for expiration
-	time.Sleep(150 * time.Millisecond)
+	clk.Step(150 * time.Millisecond)
 
 	// Should be expired
 	_, found = cache.Get("shortlived")
@@ -209,8 +221,7 @@ func TestMemoryCache_GetOrSet(t *testing.T) {
 }
 
 func TestMemoryCache_GetOrSetWithTTL(t *testing.T) {
-	cache := NewMemoryCache(1 * time.Minute)
-	defer cache.Close()
+	cache, clk := newFakeClockCache(t, 1*time.Minute)
 
 	ctx := context.Background()
 	callCount := 0
@@ -239,7 +250,7 @@ func TestMemoryCache_GetOrSetWithTTL(t *testing.T) {
 	}
 
 	// Wait for expiration
-	time.Sleep(150 * time.Millisecond)
+	clk.Step(150 * time.Millisecond)
 
 	// Should compute again
 	_, err = cache.GetOrSetWithTTL(ctx, "key1", 100*time.Millisecond, compute)
@@ -251,17 +262,243 @@ func TestMemoryCache_GetOrSetWithTTL(t *testing.T) {
 	}
 }
 
-func TestMemoryCache_CleanupExpired(t *testing.T) {
+func TestMemoryCache_GetOrSetWithNegativeTTL(t *testing.T) {
+	cache, clk := newFakeClockCache(t, 1*time.Minute)
+
+	ctx := context.Background()
+	callCount := 0
+	failure := errors.New("connection refused")
+
+	compute := func() (interface{}, error) {
+		callCount++
+		return nil, failure
+	}
+
+	// First call - fresh failure, returned as-is
+	_, err := cache.GetOrSetWithNegativeTTL(ctx, "dep", time.Minute, 100*time.Millisecond, compute)
+	if err != failure {
+		t.Fatalf("Expected the fresh failure, got %v", err)
+	}
+	if IsCachedError(err) {
+		t.Error("A fresh failure should not be reported as cached")
+	}
+
+	// Within the negative TTL - served from cache without calling compute
+	for i := 0; i < 3; i++ {
+		_, err = cache.GetOrSetWithNegativeTTL(ctx, "dep", time.Minute, 100*time.Millisecond, compute)
+		var cached *CachedError
+		if !errors.As(err, &cached) {
+			t.Fatalf("Expected a cached failure, got %v", err)
+		}
+		if !errors.Is(err, failure) {
+			t.Error("Expected the cached failure to wrap the original error")
+		}
+		if !strings.Contains(err.Error(), "cached failure, age 0s") {
+			t.Errorf("Expected the age in the message, got %q", err.Error())
+		}
+	}
+	if callCount != 1 {
+		t.Errorf("Expected compute not to be re-invoked within the negative TTL, got %d calls", callCount)
+	}
+	if stats := cache.GetStatistics(); stats.NegativeHits != 3 {
+		t.Errorf("Expected 3 negative hits, got %d", stats.NegativeHits)
+	}
+
+	// A negative entry is not a value
+	if _, found := cache.Get("dep"); found {
+		t.Error("Expected Get to miss on a negative entry")
+	}
+
+	// After the negative TTL - compute runs again, and a success is cached normally
+	clk.Step(150 * time.Millisecond)
+	compute = func() (interface{}, error) {
+		callCount++
+		return "up", nil
+	}
+	value, err := cache.GetOrSetWithNegativeTTL(ctx, "dep", time.Minute, 100*time.Millisecond, compute)
+	if err != nil || value != "up" {
+		t.Fatalf("Expected recovery, got %v, %v", value, err)
+	}
+	if callCount != 2 {
+		t.Errorf("Expected compute to run again after the negative TTL, got %d calls", callCount)
+	}
+	if _, err := cache.GetOrSetWithNegativeTTL(ctx, "dep", time.Minute, 100*time.Millisecond, compute); err != nil || callCount != 2 {
+		t.Errorf("Expected the success to be cached, got %v after %d calls", err, callCount)
+	}
+}
+
+func TestMemoryCache_GetOrSetWithNegativeTTL_SkipsUncacheableFailures(t *testing.T) {
 	cache := NewMemoryCache(1 * time.Minute)
 	defer cache.Close()
 
+	// Failures caused by the caller giving up say nothing about the dependency
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _ = cache.GetOrSetWithNegativeTTL(ctx, "cancelled", time.Minute, time.Minute, func() (interface{}, error) {
+		return nil, ctx.Err()
+	})
+	if _, found := cache.GetError("cancelled"); found {
+		t.Error("Expected a failure from a cancelled context not to be cached")
+	}
+
+	// Cached failures expire on their own schedule instead of being cached again
+	cached := &CachedError{Err: errors.New("refused"), Age: 5 * time.Second}
+	_, _ = cache.GetOrSetWithNegativeTTL(context.Background(), "nested", time.Minute, time.Minute, func() (interface{}, error) {
+		return nil, cached
+	})
+	if _, found := cache.GetError("nested"); found {
+		t.Error("Expected a cached failure not to be cached again")
+	}
+}
+
+func TestMemoryCache_SetError(t *testing.T) {
+	cache, clk := newFakeClockCache(t, 1*time.Minute)
+
+	cache.Set("key1", "value1")
+	cache.SetError("key1", errors.New("timeout"), 50*time.Millisecond)
+
+	cached, found := cache.GetError("key1")
+	if !found {
+		t.Fatal("Expected the cached failure")
+	}
+	if cached.Err.Error() != "timeout" {
+		t.Errorf("Expected timeout, got %v", cached.Err)
+	}
+
+	clk.Step(100 * time.Millisecond)
+	if _, found := cache.GetError("key1"); found {
+		t.Error("Expected the cached failure to expire")
+	}
+}
+
+func TestMemoryCache_GetWithAge(t *testing.T) {
+	cache, clk := newFakeClockCache(t, time.Minute)
+
+	cache.Set("nodes", "data")
+	clk.Step(50 * time.Millisecond)
+
+	value, age, found := cache.GetWithAge("nodes")
+	if !found || value != "data" {
+		t.Fatalf("GetWithAge() = %v, %v; want the stored value", value, found)
+	}
+	if age != 50*time.Millisecond {
+		t.Errorf("GetWithAge() age = %v, want 50ms", age)
+	}
+
+	// Too old for the caller: a miss, but the entry stays for others
+	if _, _, found := cache.GetWithMaxAge("nodes", 10*time.Millisecond); found {
+		t.Error("GetWithMaxAge() should miss on an entry older than maxAge")
+	}
+	if _, _, found := cache.GetWithMaxAge("nodes", time.Minute); !found {
+		t.Error("GetWithMaxAge() should hit on an entry younger than maxAge")
+	}
+
+	stats := cache.GetStatistics()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Evictions != 0 {
+		t.Errorf("stats = %+v, want 2 hits, 1 miss, no evictions", stats)
+	}
+}
+
+func TestMemoryCache_DeletePrefix(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	cache.Set(Key("tool", "list-pods", "payments", Hash("app=api")), 1)
+	cache.Set(Key("tool", "list-pods", "payments", Hash("app=db")), 2)
+	cache.Set(Key("tool", "list-pods", "payments-v2"), 3)
+	cache.Set(Key("tool", "get-events"), 4)
+	cache.Set(Key("resource", "cluster", "nodes"), 5)
+
+	if removed := cache.DeletePrefix(Key("tool", "list-pods", "payments")); removed != 2 {
+		t.Errorf("DeletePrefix() removed %d entries, want 2", removed)
+	}
+	if _, found := cache.Get(Key("tool", "list-pods", "payments-v2")); !found {
+		t.Error("DeletePrefix() should match whole segments, not payments-v2")
+	}
+
+	if removed := cache.DeletePrefix("tool"); removed != 2 {
+		t.Errorf("DeletePrefix(tool) removed %d entries, want 2", removed)
+	}
+	if removed := cache.DeletePrefix("tool"); removed != 0 {
+		t.Errorf("second DeletePrefix(tool) removed %d entries, want 0", removed)
+	}
+
+	stats := cache.GetStatistics()
+	if stats.Entries != 1 {
+		t.Errorf("Expected 1 entry left, got %d", stats.Entries)
+	}
+	if stats.Evictions != 4 {
+		t.Errorf("Expected 4 evictions, got %d", stats.Evictions)
+	}
+}
+
+func TestMemoryCache_StatisticsByPrefix(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	cache.Set(Key("tool", "list-pods", "payments"), 1)
+	cache.Set(Key("tool", "get-events"), 2)
+	cache.Set(Key("resource", "cluster", "nodes"), 3)
+	cache.SetError(Key("dependency-failure", "kserve", "predictor"), errors.New("refused"), time.Minute)
+
+	// tool: 3 hits, 1 miss; resource: 1 miss; dependency-failure: 1 negative hit, 1 miss
+	cache.Get(Key("tool", "list-pods", "payments"))
+	cache.Get(Key("tool", "list-pods", "payments"))
+	cache.Get(Key("tool", "get-events"))
+	cache.Get(Key("tool", "list-nodes"))
+	cache.Get(Key("resource", "cluster", "pods"))
+	cache.GetError(Key("dependency-failure", "kserve", "predictor"))
+	cache.Get(Key("dependency-failure", "kserve", "predictor"))
+
+	stats := cache.GetStatisticsByPrefix()
+	want := map[string]PrefixStatistics{
+		"tool":               {Hits: 3, Misses: 1, Entries: 2, HitRate: 75},
+		"resource":           {Misses: 1, Entries: 1},
+		"dependency-failure": {Misses: 1, NegativeHits: 1, Entries: 1},
+	}
+	if len(stats.ByPrefix) != len(want) {
+		t.Fatalf("ByPrefix = %+v, want %d prefixes", stats.ByPrefix, len(want))
+	}
+	var hits, misses int64
+	entries := 0
+	for prefix, expected := range want {
+		if got := stats.ByPrefix[prefix]; got != expected {
+			t.Errorf("ByPrefix[%q] = %+v, want %+v", prefix, got, expected)
+		}
+		hits += stats.ByPrefix[prefix].Hits
+		misses += stats.ByPrefix[prefix].Misses
+		entries += stats.ByPrefix[prefix].Entries
+	}
+
+	// The breakdown adds up to the overall counts
+	if hits != stats.Hits || misses != stats.Misses || entries != stats.Entries {
+		t.Errorf("prefix totals %d/%d/%d, want overall %d/%d/%d", hits, misses, entries, stats.Hits, stats.Misses, stats.Entries)
+	}
+	if stats.NegativeHits != 1 {
+		t.Errorf("Expected 1 negative hit, got %d", stats.NegativeHits)
+	}
+	if cache.GetStatistics().ByPrefix != nil {
+		t.Error("GetStatistics() should leave ByPrefix empty")
+	}
+
+	// After a reset, only prefixes holding entries are listed
+	cache.ResetStatistics()
+	stats = cache.GetStatisticsByPrefix()
+	if got := stats.ByPrefix["tool"]; got != (PrefixStatistics{Entries: 2}) {
+		t.Errorf("ByPrefix[tool] after reset = %+v, want only entries", got)
+	}
+}
+
+func TestMemoryCache_CleanupExpired(t *testing.T) {
+	cache, clk := newFakeClockCache(t, 1*time.Minute)
+
 	// Add items with very short TTL
 	cache.SetWithTTL("expire1", "value1", 50*time.Millisecond)
 	cache.SetWithTTL("expire2", "value2", 50*time.Millisecond)
 	cache.SetWithTTL("longlived", "value3", 10*time.Minute)
 
 	// Wait for short items to expire
-	time.Sleep(100 * time.Millisecond)
+	clk.Step(100 * time.Millisecond)
 
 	// Manually trigger cleanup by accessing
 	_, found := cache.Get("expire1")
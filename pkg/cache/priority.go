@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Priority is the freshness class of a request. Interactive requests (the
+// default) get values within their TTL and may ask for fresher ones with a
+// max age. Background requests, such as dashboard polls, accept values up to
+// BackgroundStaleFactor TTLs old and never wait for a recompute while one is
+// cached: a stale value is served and refreshed in the background
+// (stale-while-revalidate), once however many requests read it.
+type Priority string
+
+const (
+	PriorityInteractive Priority = "interactive"
+	PriorityBackground  Priority = "background"
+)
+
+// BackgroundStaleFactor is how many TTLs old a value background requests accept
+const BackgroundStaleFactor = 3
+
+// Priorities lists the priority classes, the default first
+var Priorities = []Priority{PriorityInteractive, PriorityBackground}
+
+// ParsePriority parses a priority class; empty means interactive
+func ParsePriority(s string) (Priority, error) {
+	switch Priority(s) {
+	case "", PriorityInteractive:
+		return PriorityInteractive, nil
+	case PriorityBackground:
+		return PriorityBackground, nil
+	}
+	return "", fmt.Errorf("unknown priority %q (use %s or %s)", s, PriorityInteractive, PriorityBackground)
+}
+
+// priorityState is the priority of a context's cache reads and the
+// revalidations they started
+type priorityState struct {
+	priority      Priority
+	revalidations sync.WaitGroup
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose cache reads follow priority. The
+// returned wait blocks until the background revalidations started by those
+// reads have finished; they run on the context, so it must not be cancelled
+// before then for them to complete.
+func WithPriority(ctx context.Context, priority Priority) (context.Context, func()) {
+	state := &priorityState{priority: priority}
+	return context.WithValue(ctx, priorityKey{}, state), state.revalidations.Wait
+}
+
+// PriorityFromContext returns the priority of ctx's cache reads
+func PriorityFromContext(ctx context.Context) Priority {
+	if state := priorityFrom(ctx); state != nil {
+		return state.priority
+	}
+	return PriorityInteractive
+}
+
+func priorityFrom(ctx context.Context) *priorityState {
+	state, _ := ctx.Value(priorityKey{}).(*priorityState)
+	return state
+}
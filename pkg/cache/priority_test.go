@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParsePriority(t *testing.T) {
+	for value, want := range map[string]Priority{
+		"":            PriorityInteractive,
+		"interactive": PriorityInteractive,
+		"background":  PriorityBackground,
+	} {
+		got, err := ParsePriority(value)
+		if err != nil || got != want {
+			t.Errorf("ParsePriority(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParsePriority("urgent"); err == nil {
+		t.Error("Expected an error for an unknown priority")
+	}
+	if got := PriorityFromContext(context.Background()); got != PriorityInteractive {
+		t.Errorf("Expected interactive by default, got %q", got)
+	}
+}
+
+func TestGetOrSet_BackgroundServedStaleWhileInteractiveRecomputes(t *testing.T) {
+	cache, clk := newFakeClockCache(t, time.Minute)
+	cache.SetWithTTL("health", "stale", 50*time.Millisecond)
+	clk.Step(70 * time.Millisecond) // Expired, but within 3x the TTL
+
+	background, revalidated := WithPriority(context.Background(), PriorityBackground)
+	release := make(chan struct{})
+	var revalidations atomic.Int32
+	revalidate := func() (interface{}, error) {
+		revalidations.Add(1)
+		<-release
+		return "revalidated", nil
+	}
+
+	// Background reads get the stale value without waiting, and start one revalidation
+	for i := 0; i < 3; i++ {
+		value, err := cache.GetOrSetWithTTL(background, "health", 50*time.Millisecond, revalidate)
+		if err != nil || value != "stale" {
+			t.Fatalf("Expected the stale value for a background read, got %v, %v", value, err)
+		}
+	}
+
+	// An interactive read recomputes meanwhile
+	var computes int
+	value, err := cache.GetOrSetWithTTL(context.Background(), "health", 50*time.Millisecond, func() (interface{}, error) {
+		computes++
+		return "fresh", nil
+	})
+	if err != nil || value != "fresh" || computes != 1 {
+		t.Fatalf("Expected an interactive read to recompute, got %v, %v after %d computes", value, err, computes)
+	}
+
+	close(release)
+	revalidated()
+	if got := revalidations.Load(); got != 1 {
+		t.Errorf("Expected one revalidation for all background reads, got %d", got)
+	}
+	if value, found := cache.Get("health"); !found || value != "revalidated" {
+		t.Errorf("Expected the revalidated value to be stored, got %v", value)
+	}
+
+	stats := cache.GetStatisticsByPriority()
+	if bg := stats.ByPriority[PriorityBackground]; bg.Hits != 3 || bg.StaleHits != 3 || bg.Misses != 0 {
+		t.Errorf("Unexpected background statistics: %+v", bg)
+	}
+	if fg := stats.ByPriority[PriorityInteractive]; fg.Misses != 1 || fg.HitRate != 50 {
+		t.Errorf("Unexpected interactive statistics: %+v", fg)
+	}
+	if stats.StaleHits != 3 {
+		t.Errorf("Expected 3 stale hits overall, got %d", stats.StaleHits)
+	}
+}
+
+func TestGetOrSet_BackgroundStaleWindow(t *testing.T) {
+	cache, clk := newFakeClockCache(t, time.Minute)
+	cache.SetWithTTL("health", "too old", 20*time.Millisecond)
+	clk.Step(70 * time.Millisecond) // More than 3x the TTL
+
+	background, _ := WithPriority(context.Background(), PriorityBackground)
+	release := make(chan struct{})
+	var computes atomic.Int32
+	compute := func() (interface{}, error) {
+		computes.Add(1)
+		<-release
+		return "recomputed", nil
+	}
+
+	// Concurrent background misses share one compute
+	var wg sync.WaitGroup
+	results := make(chan interface{}, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, _ := cache.GetOrSetWithTTL(background, "health", 20*time.Millisecond, compute)
+			results <- value
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	if got := computes.Load(); got != 1 {
+		t.Errorf("Expected one compute for concurrent background misses, got %d", got)
+	}
+	for value := range results {
+		if value != "recomputed" {
+			t.Errorf("Expected the recomputed value, got %v", value)
+		}
+	}
+}
+
+func TestGetOrSetTyped_BackgroundIgnoresMaxAge(t *testing.T) {
+	cache, clk := newFakeClockCache(t, time.Minute)
+	cache.SetWithTTL("nodes", 3, time.Minute)
+	clk.Step(5 * time.Millisecond)
+
+	background, _ := WithPriority(context.Background(), PriorityBackground)
+	value, age, err := GetOrSetTypedWithMaxAge(background, cache, "nodes", time.Minute, time.Millisecond, func() (int, error) {
+		return 4, nil
+	})
+	if err != nil || value != 3 || age == 0 {
+		t.Errorf("Expected the cached value for a background read, got %d (age %v), %v", value, age, err)
+	}
+
+	value, age, err = GetOrSetTypedWithMaxAge(context.Background(), cache, "nodes", time.Minute, time.Millisecond, func() (int, error) {
+		return 4, nil
+	})
+	if err != nil || value != 4 || age != 0 {
+		t.Errorf("Expected an interactive max age to force a refresh, got %d (age %v), %v", value, age, err)
+	}
+}
+
+func TestGetOrSet_FailedRevalidationKeepsStaleValue(t *testing.T) {
+	cache, clk := newFakeClockCache(t, time.Minute)
+	cache.SetWithTTL("health", "stale", 30*time.Millisecond)
+	clk.Step(40 * time.Millisecond)
+
+	background, revalidated := WithPriority(context.Background(), PriorityBackground)
+	failing := func() (interface{}, error) { return nil, errors.New("api server unavailable") }
+	if value, err := cache.GetOrSetWithNegativeTTL(background, "health", 30*time.Millisecond, time.Minute, failing); err != nil || value != "stale" {
+		t.Fatalf("Expected the stale value, got %v, %v", value, err)
+	}
+	revalidated()
+
+	if value, err := cache.GetOrSetWithNegativeTTL(background, "health", 30*time.Millisecond, time.Minute, failing); err != nil || value != "stale" {
+		t.Errorf("Expected the stale value after a failed revalidation, got %v, %v", value, err)
+	}
+	revalidated()
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ReadLog records the cache reads made through the GetOrSet functions under
+// a context, so the caller can tell whether a result was served from the
+// cache, how old its data is and when it expires.
+type ReadLog struct {
+	mu        sync.Mutex
+	hits      int
+	computes  int
+	oldest    time.Duration // Age of the oldest value served from the cache
+	expiresIn time.Duration // Time until the first value read expires
+}
+
+type readLogKey struct{}
+
+// WithReadLog returns a context whose cache reads are recorded in the returned log
+func WithReadLog(ctx context.Context) (context.Context, *ReadLog) {
+	log := &ReadLog{}
+	return context.WithValue(ctx, readLogKey{}, log), log
+}
+
+// readLogFrom returns the read log of ctx, nil when reads are not recorded
+func readLogFrom(ctx context.Context) *ReadLog {
+	log, _ := ctx.Value(readLogKey{}).(*ReadLog)
+	return log
+}
+
+// recordHit records a value served from the cache, age old, stored for ttl
+func recordHit(ctx context.Context, age, ttl time.Duration) {
+	if log := readLogFrom(ctx); log != nil {
+		log.record(true, age, ttl-age)
+	}
+}
+
+// recordCompute records a value computed on a miss and stored for ttl
+func recordCompute(ctx context.Context, ttl time.Duration) {
+	if log := readLogFrom(ctx); log != nil {
+		log.record(false, 0, ttl)
+	}
+}
+
+func (l *ReadLog) record(hit bool, age, expiresIn time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if hit {
+		l.hits++
+		l.oldest = max(l.oldest, age)
+	} else {
+		l.computes++
+	}
+	expiresIn = max(expiresIn, 0)
+	if l.hits+l.computes == 1 || expiresIn < l.expiresIn {
+		l.expiresIn = expiresIn
+	}
+}
+
+// Hits returns the number of values served from the cache
+func (l *ReadLog) Hits() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.hits
+}
+
+// Computes returns the number of values computed on a cache miss
+func (l *ReadLog) Computes() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.computes
+}
+
+// Age returns the age of the oldest value served from the cache, zero when
+// every value was computed
+func (l *ReadLog) Age() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.oldest
+}
+
+// ExpiresIn returns the time until the first of the values read expires
+// from the cache; ok is false when nothing was read through the cache
+func (l *ReadLog) ExpiresIn() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.expiresIn, l.hits+l.computes > 0
+}
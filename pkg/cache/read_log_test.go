@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReadLog_RecordsHitsAndComputes(t *testing.T) {
+	cache, clk := newFakeClockCache(t, time.Minute)
+
+	compute := func() (interface{}, error) { return "value", nil }
+	ctx, reads := WithReadLog(context.Background())
+	if _, err := cache.GetOrSetWithTTL(ctx, "a", 10*time.Second, compute); err != nil {
+		t.Fatal(err)
+	}
+	if reads.Hits() != 0 || reads.Computes() != 1 {
+		t.Errorf("after a miss: hits=%d computes=%d, want 0 and 1", reads.Hits(), reads.Computes())
+	}
+	if expiresIn, ok := reads.ExpiresIn(); !ok || expiresIn != 10*time.Second {
+		t.Errorf("ExpiresIn() = %v, %v; want the TTL of the computed value", expiresIn, ok)
+	}
+
+	clk.Step(10 * time.Millisecond)
+	if _, err := cache.GetOrSetWithTTL(ctx, "a", 10*time.Second, compute); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := GetOrSetTypedWithMaxAge(ctx, cache, "b", time.Minute, 0, func() (string, error) { return "b", nil }); err != nil {
+		t.Fatal(err)
+	}
+	if reads.Hits() != 1 || reads.Computes() != 2 {
+		t.Errorf("hits=%d computes=%d, want 1 and 2", reads.Hits(), reads.Computes())
+	}
+	if reads.Age() != 10*time.Millisecond {
+		t.Errorf("Age() = %v, want the age of the cached value", reads.Age())
+	}
+	if expiresIn, _ := reads.ExpiresIn(); expiresIn != 10*time.Second-10*time.Millisecond {
+		t.Errorf("ExpiresIn() = %v, want what is left of the first value's TTL", expiresIn)
+	}
+}
+
+func TestReadLog_OnlyUnderItsContext(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	_, reads := WithReadLog(context.Background())
+	if _, err := cache.GetOrSet(context.Background(), "a", func() (interface{}, error) { return 1, nil }); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := reads.ExpiresIn(); ok || reads.Computes() != 0 {
+		t.Error("reads under other contexts must not be recorded")
+	}
+}
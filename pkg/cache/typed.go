@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// GetTyped returns the value cached under key as a T. A value of another
+// dynamic type (e.g. one stored before a struct changed shape) counts as a
+// miss and is evicted, instead of panicking in the caller's type assertion.
+func GetTyped[T any](c *MemoryCache, key string) (T, bool) {
+	value, _, found := getTyped[T](c, key, 0)
+	return value, found
+}
+
+// GetOrSetTyped returns the T cached under key, or computes and caches it
+// for ttl. A value of the wrong type is treated as a miss and recomputed.
+func GetOrSetTyped[T any](ctx context.Context, c *MemoryCache, key string, ttl time.Duration, compute func() (T, error)) (T, error) {
+	value, _, err := GetOrSetTypedWithMaxAge(ctx, c, key, ttl, 0, compute)
+	return value, err
+}
+
+// GetOrSetTypedWithMaxAge is GetOrSetTyped for callers with their own
+// freshness needs: a cached value stored more than maxAge ago is recomputed
+// even if its TTL has not expired (maxAge <= 0 accepts any age). It also
+// returns the age of the value served, zero when it was just computed.
+func GetOrSetTypedWithMaxAge[T any](ctx context.Context, c *MemoryCache, key string, ttl, maxAge time.Duration, compute func() (T, error)) (T, time.Duration, error) {
+	value, age, err := c.getOrCompute(ctx, key, ttl, maxAge, 0, acceptType[T](key), func() (interface{}, error) {
+		return compute()
+	})
+	if err != nil {
+		var zero T
+		return zero, 0, err
+	}
+	if typed, ok := value.(T); ok {
+		return typed, age, nil
+	}
+
+	// A background read shared the compute of a caller expecting another type
+	typed, err := compute()
+	if err != nil {
+		var zero T
+		return zero, 0, err
+	}
+	c.SetWithTTL(key, typed, ttl)
+	return typed, 0, nil
+}
+
+// getTyped looks up a T no older than maxAge, evicting a value of another type
+func getTyped[T any](c *MemoryCache, key string, maxAge time.Duration) (T, time.Duration, bool) {
+	value, age, _, found := c.lookup(key, maxAge, acceptType[T](key), PriorityInteractive)
+	if !found {
+		var zero T
+		return zero, 0, false
+	}
+	return value.(T), age, true
+}
+
+// acceptType accepts the values of key that are a T, logging the others
+func acceptType[T any](key string) func(interface{}) bool {
+	return func(value interface{}) bool {
+		if _, ok := value.(T); ok {
+			return true
+		}
+		var want T
+		log.Printf("cache: evicting %q, holding %T instead of %T", key, value, want)
+		return false
+	}
+}
@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type healthV1 struct{ Status string }
+
+type healthV2 struct {
+	Status string
+	Score  int
+}
+
+func TestGetTyped(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	cache.Set("health", &healthV2{Status: "healthy", Score: 90})
+	got, found := GetTyped[*healthV2](cache, "health")
+	if !found || got.Score != 90 {
+		t.Errorf("GetTyped() = %+v, %v; want the stored value", got, found)
+	}
+
+	if _, found := GetTyped[*healthV2](cache, "missing"); found {
+		t.Error("GetTyped() should miss on an absent key")
+	}
+}
+
+func TestGetTyped_WrongTypeIsEvicted(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	// A value cached before the struct changed shape
+	cache.Set("health", &healthV1{Status: "healthy"})
+
+	got, found := GetTyped[*healthV2](cache, "health")
+	if found || got != nil {
+		t.Errorf("GetTyped() = %+v, %v; want a miss for the wrong type", got, found)
+	}
+	if _, found := cache.Get("health"); found {
+		t.Error("the wrong-typed value should have been evicted")
+	}
+
+	stats := cache.GetStatistics()
+	if stats.Hits != 0 || stats.Misses != 2 || stats.Evictions != 1 {
+		t.Errorf("stats = %+v, want 0 hits, 2 misses, 1 eviction", stats)
+	}
+}
+
+func TestGetOrSetTyped_RecomputesWrongType(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	cache.Set("health", "not a struct")
+
+	calls := 0
+	compute := func() (*healthV2, error) {
+		calls++
+		return &healthV2{Status: "degraded", Score: 40}, nil
+	}
+	for i := 0; i < 2; i++ {
+		got, err := GetOrSetTyped(context.Background(), cache, "health", time.Minute, compute)
+		if err != nil {
+			t.Fatalf("GetOrSetTyped() error = %v", err)
+		}
+		if got.Score != 40 {
+			t.Errorf("GetOrSetTyped() = %+v, want the computed value", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("compute called %d times, want 1", calls)
+	}
+}
+
+func TestGetOrSetTyped_ErrorNotCached(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	failure := errors.New("api unavailable")
+	if _, err := GetOrSetTyped(context.Background(), cache, "health", time.Minute, func() (*healthV2, error) {
+		return nil, failure
+	}); !errors.Is(err, failure) {
+		t.Errorf("GetOrSetTyped() error = %v, want %v", err, failure)
+	}
+	if stats := cache.GetStatistics(); stats.Entries != 0 {
+		t.Errorf("Expected no entries after a failure, got %d", stats.Entries)
+	}
+}
+
+func TestGetOrSetTyped_ConcurrentAccess(t *testing.T) {
+	cache := NewMemoryCache(time.Minute)
+	defer cache.Close()
+
+	var computes atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("health-%d", i%5)
+
+			// Half the writers store the old shape; readers must never panic
+			if i%2 == 0 {
+				cache.Set(key, &healthV1{Status: "healthy"})
+			}
+			got, err := GetOrSetTyped(context.Background(), cache, key, time.Minute, func() (*healthV2, error) {
+				computes.Add(1)
+				return &healthV2{Status: "healthy", Score: 100}, nil
+			})
+			if err != nil || got == nil || got.Score != 100 {
+				t.Errorf("GetOrSetTyped() = %+v, %v", got, err)
+			}
+			if value, found := GetTyped[*healthV2](cache, key); found && value.Score != 100 {
+				t.Errorf("GetTyped() = %+v, want the computed value", value)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if computes.Load() < 5 {
+		t.Errorf("compute ran %d times, want at least once per key", computes.Load())
+	}
+}
+
+func TestGetOrSetTypedWithMaxAge(t *testing.T) {
+	cache, clk := newFakeClockCache(t, time.Minute)
+
+	calls := 0
+	compute := func() (*healthV2, error) {
+		calls++
+		return &healthV2{Score: calls}, nil
+	}
+
+	got, age, err := GetOrSetTypedWithMaxAge(context.Background(), cache, "health", time.Minute, 0, compute)
+	if err != nil || got.Score != 1 || age != 0 {
+		t.Fatalf("first call = %+v, %v, %v; want a fresh computation", got, age, err)
+	}
+
+	clk.Step(60 * time.Millisecond)
+
+	// Fresher than the bound: served from cache with its age
+	got, age, _ = GetOrSetTypedWithMaxAge(context.Background(), cache, "health", time.Minute, time.Second, compute)
+	if got.Score != 1 || age != 60*time.Millisecond {
+		t.Errorf("second call = %+v, age %v; want the cached value 60ms old", got, age)
+	}
+
+	// Older than the bound: recomputed although the TTL has not expired
+	got, age, _ = GetOrSetTypedWithMaxAge(context.Background(), cache, "health", time.Minute, 50*time.Millisecond, compute)
+	if got.Score != 2 || age != 0 {
+		t.Errorf("third call = %+v, age %v; want a recomputation", got, age)
+	}
+	if calls != 2 {
+		t.Errorf("compute called %d times, want 2", calls)
+	}
+}
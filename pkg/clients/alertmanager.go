@@ -0,0 +1,187 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
+)
+
+// AlertmanagerClient lists and creates silences through the Alertmanager v2 API
+type AlertmanagerClient struct {
+	baseURL    string
+	httpClient *http.Client
+	restConfig *rest.Config // Source of the bearer token (service account or kubeconfig)
+}
+
+// AlertmanagerConfig holds configuration for the Alertmanager client
+type AlertmanagerConfig struct {
+	URL        string
+	Timeout    time.Duration
+	RestConfig *rest.Config // Kubernetes rest config whose bearer token is sent to Alertmanager
+}
+
+// NewAlertmanagerClient creates a new Alertmanager client. The OpenShift
+// service CA is trusted in addition to the system roots when it is mounted.
+func NewAlertmanagerClient(config AlertmanagerConfig) *AlertmanagerClient {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	// Without extra CA files the transport cannot fail; nil falls back to the default
+	transport, _ := newCATransport()
+
+	return &AlertmanagerClient{
+		baseURL: strings.TrimSuffix(config.URL, "/"),
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: tracing.WrapTransport(transport),
+		},
+		restConfig: config.RestConfig,
+	}
+}
+
+// SilenceMatcher selects the alerts a silence applies to by one label
+type SilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"` // False negates the match (!= or !~)
+}
+
+// matchesEmpty reports whether m matches an alert without its label, whose
+// value is the empty string
+func (m SilenceMatcher) matchesEmpty() (bool, error) {
+	matches := m.Value == ""
+	if m.IsRegex {
+		re, err := regexp.Compile("^(?:" + m.Value + ")$")
+		if err != nil {
+			return false, fmt.Errorf("invalid regular expression for label %s: %w", m.Name, err)
+		}
+		matches = re.MatchString("")
+	}
+	return matches == m.IsEqual, nil
+}
+
+// SilenceStatus is where a silence is in its lifetime
+type SilenceStatus struct {
+	State string `json:"state"` // active, pending or expired
+}
+
+// Silence mutes the alerts matching all of its matchers from StartsAt to EndsAt
+type Silence struct {
+	ID        string           `json:"id,omitempty"`
+	Matchers  []SilenceMatcher `json:"matchers"`
+	StartsAt  time.Time        `json:"startsAt"`
+	EndsAt    time.Time        `json:"endsAt"`
+	CreatedBy string           `json:"createdBy"`
+	Comment   string           `json:"comment"`
+	Status    *SilenceStatus   `json:"status,omitempty"` // Set by Alertmanager
+}
+
+// ErrSilencesAll is returned for matchers that would silence every alert
+var ErrSilencesAll = errors.New("matchers would silence every alert")
+
+// ValidateSilenceMatchers rejects matchers that would silence every alert:
+// none at all, or only ones that also match alerts without the label, as
+// Alertmanager itself requires. Unnamed matchers and invalid regular
+// expressions are rejected too.
+func ValidateSilenceMatchers(matchers []SilenceMatcher) error {
+	if len(matchers) == 0 {
+		return fmt.Errorf("%w: at least one matcher is required", ErrSilencesAll)
+	}
+	selective := false
+	for _, matcher := range matchers {
+		if matcher.Name == "" {
+			return errors.New("every matcher needs a label name")
+		}
+		matchesEmpty, err := matcher.matchesEmpty()
+		if err != nil {
+			return err
+		}
+		selective = selective || !matchesEmpty
+	}
+	if !selective {
+		return fmt.Errorf("%w: at least one matcher must not match an empty label value", ErrSilencesAll)
+	}
+	return nil
+}
+
+// ListSilences returns every silence Alertmanager knows, expired ones included
+func (c *AlertmanagerClient) ListSilences(ctx context.Context) ([]Silence, error) {
+	var silences []Silence
+	if err := c.do(ctx, http.MethodGet, "/api/v2/silences", nil, &silences); err != nil {
+		return nil, fmt.Errorf("failed to list silences: %w", err)
+	}
+	return silences, nil
+}
+
+// CreateSilence creates silence and returns its ID. Matchers that would
+// silence every alert are refused without contacting Alertmanager.
+func (c *AlertmanagerClient) CreateSilence(ctx context.Context, silence Silence) (string, error) {
+	if err := ValidateSilenceMatchers(silence.Matchers); err != nil {
+		return "", err
+	}
+	silence.ID = ""
+	silence.Status = nil
+
+	var created struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/api/v2/silences", silence, &created); err != nil {
+		return "", fmt.Errorf("failed to create silence: %w", err)
+	}
+	return created.SilenceID, nil
+}
+
+// do calls an Alertmanager API endpoint, sending body and decoding a
+// successful response into result
+func (c *AlertmanagerClient) do(ctx context.Context, method, path string, body, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode Alertmanager request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create Alertmanager request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := restConfigBearerToken(c.restConfig); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Alertmanager: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Alertmanager response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &httpStatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to decode Alertmanager response: %w", err)
+	}
+	return nil
+}
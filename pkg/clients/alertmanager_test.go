@@ -0,0 +1,131 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestAlertmanagerClient_CreateAndListSilences(t *testing.T) {
+	var created Silence
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"silenceID":"5c4e0a8f"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v2/silences":
+			_, _ = w.Write([]byte(`[{"id":"5c4e0a8f","status":{"state":"active"},
+				"matchers":[{"name":"alertname","value":"KubePodCrashLooping","isRegex":false,"isEqual":true}],
+				"startsAt":"2026-10-15T12:00:00Z","endsAt":"2026-10-15T14:00:00Z",
+				"createdBy":"alice","comment":"restarting the checkout pods"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewAlertmanagerClient(AlertmanagerConfig{URL: server.URL + "/", RestConfig: &rest.Config{BearerToken: "sa-token"}})
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	silence := Silence{
+		Matchers:  []SilenceMatcher{{Name: "alertname", Value: "KubePodCrashLooping", IsEqual: true}},
+		StartsAt:  start,
+		EndsAt:    start.Add(2 * time.Hour),
+		CreatedBy: "alice",
+		Comment:   "restarting the checkout pods",
+	}
+
+	id, err := client.CreateSilence(context.Background(), silence)
+	if err != nil || id != "5c4e0a8f" {
+		t.Fatalf("CreateSilence() = %q, %v; want 5c4e0a8f", id, err)
+	}
+	if !reflect.DeepEqual(created, silence) {
+		t.Errorf("posted silence = %+v, want %+v", created, silence)
+	}
+	if gotAuth != "Bearer sa-token" {
+		t.Errorf("Authorization = %q, want the rest config's bearer token", gotAuth)
+	}
+
+	silences, err := client.ListSilences(context.Background())
+	if err != nil {
+		t.Fatalf("ListSilences() error = %v", err)
+	}
+	silence.ID = "5c4e0a8f"
+	silence.Status = &SilenceStatus{State: "active"}
+	if len(silences) != 1 || !reflect.DeepEqual(silences[0], silence) {
+		t.Errorf("ListSilences() = %+v, want [%+v]", silences, silence)
+	}
+}
+
+func TestAlertmanagerClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `"silence invalid: start time must be before end time"`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewAlertmanagerClient(AlertmanagerConfig{URL: server.URL})
+	_, err := client.CreateSilence(context.Background(), Silence{
+		Matchers: []SilenceMatcher{{Name: "alertname", Value: "Watchdog", IsEqual: true}},
+	})
+	if status, ok := HTTPStatusCode(err); !ok || status != http.StatusBadRequest {
+		t.Errorf("CreateSilence() error = %v, want the 400 status", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "start time must be before end time") {
+		t.Errorf("CreateSilence() error = %v, want Alertmanager's reason", err)
+	}
+}
+
+func TestValidateSilenceMatchers(t *testing.T) {
+	tests := []struct {
+		name     string
+		matchers []SilenceMatcher
+		wantErr  string
+		silences bool // The error is ErrSilencesAll
+	}{
+		{name: "equal", matchers: []SilenceMatcher{{Name: "alertname", Value: "Watchdog", IsEqual: true}}},
+		{name: "regex", matchers: []SilenceMatcher{{Name: "namespace", Value: "shop-.*", IsRegex: true, IsEqual: true}}},
+		{
+			name: "one selective matcher is enough",
+			matchers: []SilenceMatcher{
+				{Name: "severity", Value: ".*", IsRegex: true, IsEqual: true},
+				{Name: "alertname", Value: "Watchdog", IsEqual: true},
+			},
+		},
+		{name: "none", wantErr: "at least one matcher", silences: true},
+		{name: "empty value", matchers: []SilenceMatcher{{Name: "alertname", IsEqual: true}}, wantErr: "empty label value", silences: true},
+		{name: "match-all regex", matchers: []SilenceMatcher{{Name: "alertname", Value: ".*", IsRegex: true, IsEqual: true}}, wantErr: "empty label value", silences: true},
+		{name: "not equal", matchers: []SilenceMatcher{{Name: "alertname", Value: "Watchdog"}}, wantErr: "empty label value", silences: true},
+		{name: "unnamed", matchers: []SilenceMatcher{{Value: "Watchdog", IsEqual: true}}, wantErr: "label name"},
+		{name: "bad regex", matchers: []SilenceMatcher{{Name: "pod", Value: "api-(", IsRegex: true, IsEqual: true}}, wantErr: "invalid regular expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSilenceMatchers(tt.matchers)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateSilenceMatchers() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateSilenceMatchers() error = %v, want %q", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrSilencesAll) != tt.silences {
+				t.Errorf("errors.Is(%v, ErrSilencesAll) = %v, want %v", err, !tt.silences, tt.silences)
+			}
+		})
+	}
+}
@@ -3,47 +3,176 @@ package clients
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
 )
 
+// ErrNotFound is returned when the Coordination Engine has no object with the requested ID
+var ErrNotFound = errors.New("not found")
+
 // CoordinationEngineClient provides client for the Coordination Engine API
 type CoordinationEngineClient struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL         string
+	httpClient      *http.Client
+	bearerTokenFile string
+	failures        *failureCache // Fails calls fast while the engine is unreachable (nil = disabled)
+
+	reachability atomic.Pointer[CoordinationEngineReachability] // Outcome of the last health check
+}
+
+// CoordinationEngineOptions configures a Coordination Engine client
+type CoordinationEngineOptions struct {
+	Timeout time.Duration // Per-request timeout (default: 30s)
+
+	// Engines behind OpenShift OAuth or routes with custom certificates
+	BearerTokenFile string // Sent as "Authorization: Bearer"; re-read per request so rotated tokens are picked up
+	CAFile          string // PEM bundle trusted in addition to the system roots and the service CA
+
+	// DialContext, if set, opens connections instead of the default dialer,
+	// such as a fake in tests
+	DialContext func(ctx context.Context, network, address string) (net.Conn, error)
 }
 
-// NewCoordinationEngineClient creates a new Coordination Engine client
-func NewCoordinationEngineClient(baseURL string) *CoordinationEngineClient {
+// NewCoordinationEngineClient creates a new Coordination Engine client for
+// the engine at baseURL. A malformed base URL or TLS configuration is an
+// error here rather than a failure of every call.
+func NewCoordinationEngineClient(baseURL string, opts CoordinationEngineOptions) (*CoordinationEngineClient, error) {
+	base, err := ParseCoordinationEngineURL(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Coordination Engine URL: %w", err)
+	}
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	var caFiles []string
+	if opts.CAFile != "" {
+		caFiles = append(caFiles, opts.CAFile)
+	}
+	transport, err := newCATransport(caFiles...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Coordination Engine TLS configuration: %w", err)
+	}
+	if opts.DialContext != nil {
+		if transport == nil {
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		}
+		transport.(*http.Transport).DialContext = opts.DialContext
+	}
+
 	return &CoordinationEngineClient{
-		baseURL: baseURL,
+		baseURL: base,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   timeout,
+			Transport: tracing.WrapTransport(transport),
 		},
+		bearerTokenFile: opts.BearerTokenFile,
+	}, nil
+}
+
+// ParseCoordinationEngineURL checks that raw is an http(s) URL with a host
+// and no query or fragment, and returns it without trailing slashes so that
+// API paths can be appended to it
+func ParseCoordinationEngineURL(raw string) (string, error) {
+	// Error messages end up in logs: leave out the password and a query
+	// that may carry a token. Parse errors quote the whole URL, so only their
+	// cause is kept.
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return "", urlErr.Err
+		}
+		return "", err
+	}
+	shown := *u
+	shown.RawQuery, shown.ForceQuery, shown.Fragment = "", false, ""
+	switch {
+	case u.Scheme != "http" && u.Scheme != "https":
+		return "", fmt.Errorf("%q must use http or https", shown.Redacted())
+	case u.Host == "":
+		return "", fmt.Errorf("%q has no host", shown.Redacted())
+	case u.Hostname() == "":
+		return "", fmt.Errorf("%q has no host name", shown.Redacted())
+	case u.RawQuery != "" || u.ForceQuery || u.Fragment != "":
+		return "", fmt.Errorf("%q must not have a query or fragment", shown.Redacted())
+	}
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return "", fmt.Errorf("%q has an invalid port", shown.Redacted())
+		}
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// SetFailureCache makes the client remember connection failures in memoryCache
+// for ttl. Meanwhile calls fail fast with a *cache.CachedError instead of waiting
+// for another timeout, and HealthCheck results are reused. A ttl of 0 disables it.
+func (c *CoordinationEngineClient) SetFailureCache(memoryCache *cache.MemoryCache, ttl time.Duration) {
+	c.failures = newFailureCache(memoryCache, ttl)
+}
+
+// do sends req unless the engine recently failed to respond, recording new
+// failures and, in the call's throttle log, 429 responses
+func (c *CoordinationEngineClient) do(req *http.Request) (*http.Response, error) {
+	dep := dependencyOf("coordination-engine", c.baseURL)
+	if err := c.failures.check(dep); err != nil {
+		return nil, err
+	}
+	if token := c.bearerToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := c.httpClient.Do(req)
+	c.failures.record(req.Context(), dep, err)
+	if err == nil {
+		recordThrottledResponse(req.Context(), resp)
 	}
+	return resp, err
 }
 
 // Incident represents an incident from the Coordination Engine
 type Incident struct {
-	ID              string                 `json:"id"`
-	Title           string                 `json:"title"`
-	Description     string                 `json:"description"`
-	Severity        string                 `json:"severity"` // critical, high, medium, low
-	Status          string                 `json:"status"`   // pending, running, completed, failed
-	Priority        int                    `json:"priority"`
-	Target          string                 `json:"target"`
-	ActionType      string                 `json:"action_type"`
-	Source          string                 `json:"source"`
-	Confidence      float64                `json:"confidence"`
-	Parameters      map[string]interface{} `json:"parameters"`
-	CreatedAt       string                 `json:"created_at"`
-	StartedAt       *string                `json:"started_at"`
-	CompletedAt     *string                `json:"completed_at"`
-	DurationSeconds *float64               `json:"duration_seconds"`
-	Tags            []string               `json:"tags"`
+	ID                string                 `json:"id"`
+	Title             string                 `json:"title"`
+	Description       string                 `json:"description"`
+	Severity          string                 `json:"severity"` // critical, high, medium, low
+	Status            string                 `json:"status"`   // pending, running, completed, failed
+	Priority          int                    `json:"priority"`
+	Target            string                 `json:"target"`
+	Namespace         string                 `json:"namespace,omitempty"`
+	AffectedResources []string               `json:"affectedResources,omitempty"` // "namespace/pod", "deployment/namespace/name", "node/name", ...
+	ActionType        string                 `json:"action_type"`
+	Source            string                 `json:"source"`
+	Confidence        float64                `json:"confidence"`
+	Parameters        map[string]interface{} `json:"parameters"`
+	CreatedAt         string                 `json:"created_at"`
+	StartedAt         *string                `json:"started_at"`
+	CompletedAt       *string                `json:"completed_at"`
+	DurationSeconds   *float64               `json:"duration_seconds"`
+	Tags              []string               `json:"tags"`
 }
 
 // IncidentListResponse represents the response from listing incidents
@@ -56,6 +185,18 @@ type IncidentListResponse struct {
 		Failed     int            `json:"failed"`
 		BySeverity map[string]int `json:"by_severity"`
 	} `json:"summary"`
+	NextPageToken string `json:"next_page_token,omitempty"` // Empty on the last page or when the engine does not paginate by token
+}
+
+// IncidentListOptions selects a page of incidents. Zero values are not sent.
+type IncidentListOptions struct {
+	Status    string // all, active, completed, failed
+	Severity  string // all, low, medium, high, critical
+	Namespace string
+	Since     time.Time // Only incidents created at or after Since
+	Limit     int
+	Offset    int
+	PageToken string // next_page_token of the previous page; takes precedence over Offset
 }
 
 // CreateIncidentRequest represents a request to create an incident
@@ -72,6 +213,8 @@ type CreateIncidentRequest struct {
 	CreatedBy         *string                `json:"createdBy,omitempty"`
 	Confidence        *float64               `json:"confidence,omitempty"`
 	Parameters        map[string]interface{} `json:"parameters,omitempty"`
+	Findings          map[string]interface{} `json:"findings,omitempty"`    // Evidence from the analysis that raised the incident
+	Fingerprint       *string                `json:"fingerprint,omitempty"` // Also sent as Idempotency-Key; see IncidentFingerprint
 }
 
 // CreateIncidentResponse represents the response from creating an incident
@@ -84,6 +227,22 @@ type CreateIncidentResponse struct {
 	Status      string `json:"status"`
 	CreatedAt   string `json:"created_at"`
 	Message     string `json:"message"`
+	URL         string `json:"url,omitempty"`
+}
+
+// IncidentFingerprint identifies a finding independently of when or how often it is
+// reported: the finding type plus the affected resources, ignoring order, case, and
+// duplicates. Retried creations with the same fingerprint refer to the same incident.
+func IncidentFingerprint(findingType string, affectedResources []string) string {
+	resources := make([]string, 0, len(affectedResources))
+	for _, resource := range affectedResources {
+		resources = append(resources, strings.ToLower(strings.TrimSpace(resource)))
+	}
+	sort.Strings(resources)
+	resources = slices.Compact(resources)
+
+	sum := sha256.Sum256([]byte(strings.ToLower(findingType) + "\n" + strings.Join(resources, "\n")))
+	return hex.EncodeToString(sum[:16])
 }
 
 // TriggerRemediationRequest represents a request to trigger remediation
@@ -99,7 +258,8 @@ type TriggerRemediationRequest struct {
 		Description string `json:"description"`
 		Severity    string `json:"severity"`
 	} `json:"issue"`
-	DryRun bool `json:"dry_run,omitempty"`
+	Playbook string `json:"playbook,omitempty"` // Catalog playbook to run; the engine picks one when empty
+	DryRun   bool   `json:"dry_run,omitempty"`
 }
 
 // TriggerRemediationResponse represents the response from triggering remediation
@@ -109,11 +269,24 @@ type TriggerRemediationResponse struct {
 	DeploymentMethod  string `json:"deployment_method"`
 	EstimatedDuration string `json:"estimated_duration"`
 }
+
+// RemediationPlaybook describes a remediation the Coordination Engine can perform
+type RemediationPlaybook struct {
+	Name               string   `json:"name"`
+	Description        string   `json:"description"`
+	RequiredParameters []string `json:"required_parameters,omitempty"`
+	TargetKinds        []string `json:"target_kinds,omitempty"`    // Resource kinds the playbook acts on
+	Destructiveness    string   `json:"destructiveness,omitempty"` // none, low, medium, high
+	// History, when the engine tracks it
+	AverageDurationSeconds *float64 `json:"average_duration_seconds,omitempty"`
+	SuccessRate            *float64 `json:"success_rate,omitempty"` // 0.0-1.0
+}
+
 // AnalyzeAnomaliesRequest represents a request to analyze anomalies
 type AnalyzeAnomaliesRequest struct {
-	TimeRange          string        `json:"timeRange,omitempty"`     // e.g., "1h", "24h"
-	Metrics            []interface{} `json:"metrics,omitempty"`       // Can be metric names or full metric objects
-	Threshold          *float64      `json:"threshold,omitempty"`     // 0.0-1.0
+	TimeRange          string        `json:"timeRange,omitempty"` // e.g., "1h", "24h"
+	Metrics            []interface{} `json:"metrics,omitempty"`   // Can be metric names or full metric objects
+	Threshold          *float64      `json:"threshold,omitempty"` // 0.0-1.0
 	IncludePredictions *bool         `json:"includePredictions,omitempty"`
 	Models             []string      `json:"models,omitempty"`        // Specify which ML models to use
 	ModelName          string        `json:"modelName,omitempty"`     // Primary model name (e.g., "anomaly-detector")
@@ -187,15 +360,36 @@ type ClusterStatus struct {
 
 // ListIncidents retrieves incidents from the Coordination Engine
 func (c *CoordinationEngineClient) ListIncidents(ctx context.Context, status, severity string, limit, offset int) (*IncidentListResponse, error) {
-	url := fmt.Sprintf("%s/api/v1/incidents?status=%s&severity=%s&limit=%d&offset=%d",
-		c.baseURL, status, severity, limit, offset)
+	return c.ListIncidentsPage(ctx, IncidentListOptions{Status: status, Severity: severity, Limit: limit, Offset: offset})
+}
+
+// ListIncidentsPage retrieves one page of incidents, passing every option through
+// as a query parameter. Engines that do not support a filter ignore it, so callers
+// should check the results.
+func (c *CoordinationEngineClient) ListIncidentsPage(ctx context.Context, opts IncidentListOptions) (*IncidentListResponse, error) {
+	params := url.Values{}
+	for key, value := range map[string]string{"status": opts.Status, "severity": opts.Severity, "namespace": opts.Namespace, "page_token": opts.PageToken} {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	if !opts.Since.IsZero() {
+		params.Set("since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.PageToken == "" {
+		params.Set("offset", strconv.Itoa(opts.Offset))
+	}
+	url := fmt.Sprintf("%s/api/v1/incidents?%s", c.baseURL, params.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -203,7 +397,7 @@ func (c *CoordinationEngineClient) ListIncidents(ctx context.Context, status, se
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result IncidentListResponse
@@ -214,7 +408,9 @@ func (c *CoordinationEngineClient) ListIncidents(ctx context.Context, status, se
 	return &result, nil
 }
 
-// CreateIncident creates a new incident
+// CreateIncident creates a new incident. When the request carries a fingerprint it is
+// also sent as the Idempotency-Key header, and the engine may answer 200 with the
+// incident already created for it.
 func (c *CoordinationEngineClient) CreateIncident(ctx context.Context, req *CreateIncidentRequest) (*CreateIncidentResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/incidents", c.baseURL)
 
@@ -228,16 +424,20 @@ func (c *CoordinationEngineClient) CreateIncident(ctx context.Context, req *Crea
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	if req.Fingerprint != nil {
+		httpReq.Header.Set("Idempotency-Key", *req.Fingerprint)
+	}
+	setOnBehalfOf(httpReq)
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusCreated {
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result CreateIncidentResponse
@@ -248,6 +448,42 @@ func (c *CoordinationEngineClient) CreateIncident(ctx context.Context, req *Crea
 	return &result, nil
 }
 
+// GetIncident retrieves one incident by ID
+func (c *CoordinationEngineClient) GetIncident(ctx context.Context, id string) (*Incident, error) {
+	url := c.IncidentURL(id)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("incident %s %w", id, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result Incident
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// IncidentURL returns the Coordination Engine API URL of an incident
+func (c *CoordinationEngineClient) IncidentURL(id string) string {
+	return fmt.Sprintf("%s/api/v1/incidents/%s", c.baseURL, url.PathEscape(id))
+}
+
 // TriggerRemediation triggers a remediation action
 func (c *CoordinationEngineClient) TriggerRemediation(ctx context.Context, req *TriggerRemediationRequest) (*TriggerRemediationResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/remediation/trigger", c.baseURL)
@@ -262,8 +498,9 @@ func (c *CoordinationEngineClient) TriggerRemediation(ctx context.Context, req *
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
+	setOnBehalfOf(httpReq)
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -271,7 +508,7 @@ func (c *CoordinationEngineClient) TriggerRemediation(ctx context.Context, req *
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result TriggerRemediationResponse
@@ -282,6 +519,36 @@ func (c *CoordinationEngineClient) TriggerRemediation(ctx context.Context, req *
 	return &result, nil
 }
 
+// ListPlaybooks returns the catalog of remediation playbooks
+func (c *CoordinationEngineClient) ListPlaybooks(ctx context.Context) ([]RemediationPlaybook, error) {
+	url := fmt.Sprintf("%s/api/v1/remediation/playbooks", c.baseURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var result struct {
+		Playbooks []RemediationPlaybook `json:"playbooks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Playbooks, nil
+}
+
 // AnalyzeAnomalies analyzes metrics for anomalies
 func (c *CoordinationEngineClient) AnalyzeAnomalies(ctx context.Context, req *AnalyzeAnomaliesRequest) (*AnalyzeAnomaliesResponse, error) {
 	url := fmt.Sprintf("%s/api/v1/anomalies/analyze", c.baseURL)
@@ -297,7 +564,7 @@ func (c *CoordinationEngineClient) AnalyzeAnomalies(ctx context.Context, req *An
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -305,7 +572,7 @@ func (c *CoordinationEngineClient) AnalyzeAnomalies(ctx context.Context, req *An
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result AnalyzeAnomaliesResponse
@@ -325,7 +592,7 @@ func (c *CoordinationEngineClient) GetClusterStatus(ctx context.Context) (*Clust
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -333,7 +600,7 @@ func (c *CoordinationEngineClient) GetClusterStatus(ctx context.Context) (*Clust
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result ClusterStatus
@@ -344,8 +611,88 @@ func (c *CoordinationEngineClient) GetClusterStatus(ctx context.Context) (*Clust
 	return &result, nil
 }
 
-// HealthCheck performs a health check on the Coordination Engine
+// bearerToken re-reads the token file so rotated tokens are picked up
+func (c *CoordinationEngineClient) bearerToken() string {
+	if c.bearerTokenFile == "" {
+		return ""
+	}
+	token, err := os.ReadFile(c.bearerTokenFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(token))
+}
+
+// HealthCheck performs a health check on the Coordination Engine.
+// With a failure cache the result is reused for its TTL.
 func (c *CoordinationEngineClient) HealthCheck(ctx context.Context) error {
+	return c.failures.health(ctx, dependencyOf("coordination-engine", c.baseURL), func() error {
+		err := c.healthCheck(ctx)
+		c.recordReachability(ctx, err)
+		return err
+	})
+}
+
+// CoordinationEngineReachability is the outcome of the last health check of
+// the Coordination Engine
+type CoordinationEngineReachability struct {
+	URL       string    `json:"url"`
+	CheckedAt time.Time `json:"checked_at"`
+	Reachable bool      `json:"reachable"`
+	// Stage is where the check failed: dns, connect, tls, timeout or http
+	Stage string `json:"stage,omitempty"`
+	Error string `json:"error,omitempty"` // Including the exact dial or DNS error
+}
+
+// Reachability returns the outcome of the last health check, and false
+// before the first one
+func (c *CoordinationEngineClient) Reachability() (CoordinationEngineReachability, bool) {
+	if r := c.reachability.Load(); r != nil {
+		return *r, true
+	}
+	return CoordinationEngineReachability{}, false
+}
+
+// recordReachability keeps the outcome of a health check; a canceled check
+// proves nothing
+func (c *CoordinationEngineClient) recordReachability(ctx context.Context, err error) {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+	r := &CoordinationEngineReachability{URL: c.baseURL, CheckedAt: time.Now().UTC(), Reachable: err == nil}
+	if err != nil {
+		r.Stage = failureStage(err)
+		r.Error = err.Error()
+	}
+	c.reachability.Store(r)
+}
+
+// failureStage tells where a request failed: resolving the host, connecting,
+// the TLS handshake, a timeout, or an HTTP answer other than success
+func failureStage(err error) string {
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var recordErr tls.RecordHeaderError
+	var netErr net.Error
+	var opErr *net.OpError
+	switch {
+	case errors.As(err, &dnsErr):
+		return "dns"
+	case errors.As(err, &certErr), errors.As(err, &unknownAuthority), errors.As(err, &hostnameErr), errors.As(err, &recordErr):
+		return "tls"
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.As(err, &opErr) && opErr.Op == "dial", errors.Is(err, syscall.ECONNREFUSED):
+		return "connect"
+	default:
+		return "http"
+	}
+}
+
+// healthCheck calls the Coordination Engine health endpoint
+func (c *CoordinationEngineClient) healthCheck(ctx context.Context) error {
 	url := fmt.Sprintf("%s/health", c.baseURL)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -353,7 +700,7 @@ func (c *CoordinationEngineClient) HealthCheck(ctx context.Context) error {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -368,14 +715,14 @@ func (c *CoordinationEngineClient) HealthCheck(ctx context.Context) error {
 
 // PredictResourceUsageRequest represents a request for time-specific resource prediction
 type PredictResourceUsageRequest struct {
-	Hour              int     `json:"hour"`               // Hour of day (0-23)
-	DayOfWeek         int     `json:"day_of_week"`        // Day of week (0=Monday, 6=Sunday)
-	CPURollingMean    float64 `json:"cpu_rolling_mean"`   // Current CPU rolling mean
-	MemoryRollingMean float64 `json:"memory_rolling_mean"` // Current memory rolling mean
-	Namespace         string  `json:"namespace,omitempty"` // Target namespace (optional)
+	Hour              int     `json:"hour"`                 // Hour of day (0-23)
+	DayOfWeek         int     `json:"day_of_week"`          // Day of week (0=Monday, 6=Sunday)
+	CPURollingMean    float64 `json:"cpu_rolling_mean"`     // Current CPU rolling mean
+	MemoryRollingMean float64 `json:"memory_rolling_mean"`  // Current memory rolling mean
+	Namespace         string  `json:"namespace,omitempty"`  // Target namespace (optional)
 	Deployment        string  `json:"deployment,omitempty"` // Target deployment (optional)
-	Pod               string  `json:"pod,omitempty"`       // Target pod (optional)
-	Scope             string  `json:"scope,omitempty"`     // Scope: pod, deployment, namespace, cluster
+	Pod               string  `json:"pod,omitempty"`        // Target pod (optional)
+	Scope             string  `json:"scope,omitempty"`      // Scope: pod, deployment, namespace, cluster
 }
 
 // PredictResourceUsageResponse represents the prediction response (public interface)
@@ -383,10 +730,10 @@ type PredictResourceUsageResponse struct {
 	Status             string  `json:"status"`
 	PredictedCPU       float64 `json:"predicted_cpu_percent"`
 	PredictedMemory    float64 `json:"predicted_memory_percent"`
-	CurrentCPU         float64 `json:"current_cpu_percent"`         // From Prometheus via CE
-	CurrentMemory      float64 `json:"current_memory_percent"`      // From Prometheus via CE
+	CurrentCPU         float64 `json:"current_cpu_percent"`    // From Prometheus via CE
+	CurrentMemory      float64 `json:"current_memory_percent"` // From Prometheus via CE
 	Confidence         float64 `json:"confidence"`
-	Trend              string  `json:"trend"`       // upward, downward, stable
+	Trend              string  `json:"trend"` // upward, downward, stable
 	ModelUsed          string  `json:"model_used"`
 	ModelVersion       string  `json:"model_version,omitempty"`
 	Recommendation     string  `json:"recommendation,omitempty"`
@@ -437,7 +784,7 @@ func (c *CoordinationEngineClient) PredictResourceUsage(ctx context.Context, req
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
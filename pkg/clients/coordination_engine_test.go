@@ -0,0 +1,257 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestCoordinationEngineClient creates a client with default options,
+// failing the test if baseURL is rejected
+func newTestCoordinationEngineClient(t *testing.T, baseURL string) *CoordinationEngineClient {
+	t.Helper()
+	client, err := NewCoordinationEngineClient(baseURL, CoordinationEngineOptions{})
+	if err != nil {
+		t.Fatalf("NewCoordinationEngineClient(%q) error = %v", baseURL, err)
+	}
+	return client
+}
+
+func TestIncidentFingerprint(t *testing.T) {
+	fp := IncidentFingerprint("crashloop", []string{"payments/api-7d9f", "payments/worker-1"})
+	if len(fp) != 32 {
+		t.Fatalf("fingerprint %q, want 32 hex characters", fp)
+	}
+	if got := IncidentFingerprint("CrashLoop", []string{" Payments/Worker-1", "payments/api-7d9f", "payments/worker-1"}); got != fp {
+		t.Errorf("fingerprint depends on order, case, or duplicates: %q != %q", got, fp)
+	}
+	if got := IncidentFingerprint("oom", []string{"payments/api-7d9f", "payments/worker-1"}); got == fp {
+		t.Error("different finding types share a fingerprint")
+	}
+	if got := IncidentFingerprint("crashloop", []string{"payments/api-7d9f"}); got == fp {
+		t.Error("different resources share a fingerprint")
+	}
+}
+
+func TestCoordinationEngineClient_CreateIncident(t *testing.T) {
+	var gotKey string
+	var gotBody CreateIncidentRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("Idempotency-Key")
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		// An engine that already has the fingerprint answers 200 with the existing incident
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"incident_id":"inc-42","status":"pending"}`))
+	}))
+	defer server.Close()
+
+	client := newTestCoordinationEngineClient(t, server.URL)
+	fingerprint := IncidentFingerprint("crashloop", []string{"payments/api"})
+	resp, err := client.CreateIncident(context.Background(), &CreateIncidentRequest{
+		Title:       "Crash loop",
+		Severity:    "high",
+		Findings:    map[string]interface{}{"restarts": float64(12)},
+		Fingerprint: &fingerprint,
+	})
+	if err != nil {
+		t.Fatalf("CreateIncident() error = %v", err)
+	}
+
+	if resp.IncidentID != "inc-42" {
+		t.Errorf("IncidentID = %q", resp.IncidentID)
+	}
+	if gotKey != fingerprint {
+		t.Errorf("Idempotency-Key = %q, want the fingerprint", gotKey)
+	}
+	if gotBody.Fingerprint == nil || *gotBody.Fingerprint != fingerprint || gotBody.Findings["restarts"] != float64(12) {
+		t.Errorf("request body = %+v", gotBody)
+	}
+	if got := client.IncidentURL("inc 42"); got != server.URL+"/api/v1/incidents/inc%2042" {
+		t.Errorf("IncidentURL() = %q", got)
+	}
+}
+
+func TestCoordinationEngineClient_GetIncident(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/incidents/inc-42" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"inc-42","title":"Crash loop","affectedResources":["payments/api-7d9","node/worker-1"]}`))
+	}))
+	defer server.Close()
+
+	client := newTestCoordinationEngineClient(t, server.URL)
+	incident, err := client.GetIncident(context.Background(), "inc-42")
+	if err != nil {
+		t.Fatalf("GetIncident() error = %v", err)
+	}
+	if incident.ID != "inc-42" || len(incident.AffectedResources) != 2 || incident.AffectedResources[1] != "node/worker-1" {
+		t.Errorf("GetIncident() = %+v", incident)
+	}
+
+	if _, err := client.GetIncident(context.Background(), "inc-missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("GetIncident() of a missing incident error = %v", err)
+	}
+}
+
+func TestParseCoordinationEngineURL(t *testing.T) {
+	for raw, want := range map[string]string{
+		"http://coordination-engine:8080":               "http://coordination-engine:8080",
+		"http://coordination-engine:8080/":              "http://coordination-engine:8080",
+		" https://ce.example.com/engine// ":             "https://ce.example.com/engine",
+		"http://[fd00::1]:8080/":                        "http://[fd00::1]:8080",
+		"https://ce.apps.example.com/prefix/v2/engine/": "https://ce.apps.example.com/prefix/v2/engine",
+	} {
+		got, err := ParseCoordinationEngineURL(raw)
+		if err != nil || got != want {
+			t.Errorf("ParseCoordinationEngineURL(%q) = %q, %v; want %q", raw, got, err, want)
+		}
+	}
+
+	for raw, want := range map[string]string{
+		"coordination-engine:8080":           "must use http or https",
+		"ftp://coordination-engine":          "must use http or https",
+		"http://":                            "has no host",
+		"http:///api":                        "has no host",
+		"http://:8080":                       "has no host name",
+		"http://ce:8080/?token=x":            "must not have a query or fragment",
+		"http://ce:8080/?":                   "must not have a query or fragment",
+		"http://ce:8080/#health":             "must not have a query or fragment",
+		"http://ce:99999":                    "has an invalid port",
+		"http://ce:0":                        "has an invalid port",
+		"http://ce engine:8080":              "invalid character",
+		"http://user:secret@ce:8080/?debug=": "must not have a query or fragment",
+		"http://user:secret%zz@ce:8080/":     "invalid URL escape",
+	} {
+		_, err := ParseCoordinationEngineURL(raw)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParseCoordinationEngineURL(%q) error = %v, want one containing %q", raw, err, want)
+		}
+		if err != nil && (strings.Contains(err.Error(), "secret") || strings.Contains(err.Error(), "token=")) {
+			t.Errorf("ParseCoordinationEngineURL(%q) error leaks a credential: %v", raw, err)
+		}
+	}
+}
+
+func TestNewCoordinationEngineClient_FailsFast(t *testing.T) {
+	if _, err := NewCoordinationEngineClient("coordination-engine:8080", CoordinationEngineOptions{}); err == nil ||
+		!strings.Contains(err.Error(), "invalid Coordination Engine URL") {
+		t.Errorf("malformed base URL: error = %v", err)
+	}
+	if _, err := NewCoordinationEngineClient("", CoordinationEngineOptions{}); err == nil {
+		t.Error("empty base URL accepted")
+	}
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+	if _, err := NewCoordinationEngineClient("https://ce:8443", CoordinationEngineOptions{CAFile: missing}); err == nil ||
+		!strings.Contains(err.Error(), "invalid Coordination Engine TLS configuration") {
+		t.Errorf("missing CA file: error = %v", err)
+	}
+}
+
+func TestCoordinationEngineClient_JoinsPaths(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`{"incidents":[],"total":0}`))
+	}))
+	defer server.Close()
+
+	for _, base := range []string{server.URL + "/", server.URL + "/engine//"} {
+		paths = nil
+		client := newTestCoordinationEngineClient(t, base)
+		if _, err := client.ListIncidents(context.Background(), "", "", 0, 0); err != nil {
+			t.Fatalf("ListIncidents() at %q error = %v", base, err)
+		}
+		if err := client.HealthCheck(context.Background()); err != nil {
+			t.Fatalf("HealthCheck() at %q error = %v", base, err)
+		}
+		prefix := strings.TrimRight(strings.TrimPrefix(base, server.URL), "/")
+		want := []string{prefix + "/api/v1/incidents", prefix + "/health"}
+		if strings.Join(paths, " ") != strings.Join(want, " ") {
+			t.Errorf("base %q requested %v, want %v", base, paths, want)
+		}
+	}
+}
+
+func TestCoordinationEngineClient_Reachability(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "ce.invalid", IsNotFound: true}
+	client, err := NewCoordinationEngineClient("http://ce.invalid:8080", CoordinationEngineOptions{
+		DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: dnsErr}
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewCoordinationEngineClient() error = %v", err)
+	}
+	if _, checked := client.Reachability(); checked {
+		t.Fatal("Reachability() reported before any health check")
+	}
+
+	if err := client.HealthCheck(context.Background()); err == nil {
+		t.Fatal("HealthCheck() succeeded against an unresolvable host")
+	}
+	reachability, checked := client.Reachability()
+	if !checked || reachability.Reachable {
+		t.Fatalf("Reachability() = %+v, %v; want unreachable", reachability, checked)
+	}
+	if reachability.Stage != "dns" || !strings.Contains(reachability.Error, "lookup ce.invalid: no such host") {
+		t.Errorf("Reachability() = %+v, want the DNS error", reachability)
+	}
+	if reachability.URL != "http://ce.invalid:8080" || reachability.CheckedAt.IsZero() {
+		t.Errorf("Reachability() = %+v, want the URL and check time", reachability)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = client.HealthCheck(canceled)
+	if again, _ := client.Reachability(); again.CheckedAt != reachability.CheckedAt {
+		t.Error("a canceled health check replaced the last outcome")
+	}
+}
+
+func TestFailureStage(t *testing.T) {
+	for want, err := range map[string]error{
+		"dns":     &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "ce"}},
+		"connect": &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", errors.New("connection refused"))},
+		"timeout": context.DeadlineExceeded,
+		"http":    errors.New("health check failed with status 503"),
+	} {
+		if got := failureStage(err); got != want {
+			t.Errorf("failureStage(%v) = %q, want %q", err, got, want)
+		}
+	}
+}
+
+func TestCoordinationEngineClient_BearerToken(t *testing.T) {
+	var auth []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("first\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewCoordinationEngineClient(server.URL, CoordinationEngineOptions{BearerTokenFile: tokenFile})
+	if err != nil {
+		t.Fatalf("NewCoordinationEngineClient() error = %v", err)
+	}
+	_ = client.HealthCheck(context.Background())
+	if err := os.WriteFile(tokenFile, []byte("rotated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_ = client.HealthCheck(context.Background())
+
+	if strings.Join(auth, ",") != "Bearer first,Bearer rotated" {
+		t.Errorf("Authorization headers = %v, want the token re-read per request", auth)
+	}
+}
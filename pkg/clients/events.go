@@ -0,0 +1,107 @@
+package clients
+
+import (
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// EventComponent is the source component of the Kubernetes Events the server emits
+const EventComponent = "openshift-cluster-health-mcp"
+
+// Per-object bounds on emitted events. The recorder's own spam filter only
+// kicks in after 25 events, so a retry loop could otherwise flood an object.
+const (
+	eventBurst  = 5           // Events an object may get per window
+	eventWindow = time.Minute // Window the burst is counted over
+)
+
+// eventLimiter counts the events recorded per object in fixed windows
+type eventLimiter struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	windows map[string]eventWindowCount
+}
+
+// eventWindowCount is the number of events an object got since start
+type eventWindowCount struct {
+	start time.Time
+	count int
+}
+
+func newEventLimiter() *eventLimiter {
+	return &eventLimiter{now: time.Now, windows: make(map[string]eventWindowCount)}
+}
+
+// allow reports whether another event may be recorded on the object with
+// the given key, and counts it if so
+func (l *eventLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	window := l.windows[key]
+	if now.Sub(window.start) >= eventWindow {
+		window = eventWindowCount{start: now}
+	}
+	if window.count >= eventBurst {
+		return false
+	}
+	window.count++
+	l.windows[key] = window
+
+	// Forget objects whose window is over, so the map tracks recent ones only
+	if len(l.windows) > 1024 {
+		for other, w := range l.windows {
+			if now.Sub(w.start) >= eventWindow {
+				delete(l.windows, other)
+			}
+		}
+	}
+	return true
+}
+
+// eventRecorder returns the recorder of the client, starting the broadcaster
+// that writes events to the API server on first use. It returns nil for
+// clients without a clientset.
+func (c *K8sClient) eventRecorder() record.EventRecorder {
+	c.eventsOnce.Do(func() {
+		if c.limiter == nil {
+			c.limiter = newEventLimiter()
+		}
+		if c.recorder != nil || c.clientset == nil {
+			return
+		}
+		c.broadcaster = record.NewBroadcaster()
+		c.broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: c.clientset.CoreV1().Events("")})
+		c.recorder = c.broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: EventComponent})
+	})
+	return c.recorder
+}
+
+// SetEventRecorder replaces the recorder events are emitted with, such as a
+// record.FakeRecorder in tests. It must be called before the first event.
+func (c *K8sClient) SetEventRecorder(recorder record.EventRecorder) {
+	c.recorder = recorder
+}
+
+// RecordEvent emits a Kubernetes Event on the referenced object. Emission is
+// best-effort and asynchronous: failures are only logged by the recorder,
+// and events beyond eventBurst per object within eventWindow are dropped.
+// It reports whether the event was handed to the recorder.
+func (c *K8sClient) RecordEvent(object *corev1.ObjectReference, annotations map[string]string, eventType, reason, message string) bool {
+	recorder := c.eventRecorder()
+	if recorder == nil {
+		return false
+	}
+	key := object.Kind + "/" + object.Namespace + "/" + object.Name
+	if !c.limiter.allow(key) {
+		return false
+	}
+	recorder.AnnotatedEventf(object, annotations, eventType, reason, "%s", message)
+	return true
+}
@@ -0,0 +1,51 @@
+package clients
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecordEvent_RateLimitedPerObject(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	client := NewK8sClientWithClientset(nil)
+	client.limiter = newEventLimiter()
+	client.limiter.now = func() time.Time { return now }
+	recorder := record.NewFakeRecorder(20)
+	client.SetEventRecorder(recorder)
+
+	api := &corev1.ObjectReference{Kind: "Deployment", Namespace: "shop", Name: "api"}
+	web := &corev1.ObjectReference{Kind: "Deployment", Namespace: "shop", Name: "web"}
+	emit := func(object *corev1.ObjectReference) bool {
+		return client.RecordEvent(object, nil, corev1.EventTypeNormal, "MCPRemediation", "rolled back")
+	}
+
+	for i := 0; i < eventBurst; i++ {
+		if !emit(api) {
+			t.Fatalf("event %d should be recorded", i+1)
+		}
+	}
+	if emit(api) {
+		t.Error("events beyond the burst should be dropped")
+	}
+	if !emit(web) {
+		t.Error("other objects have their own limit")
+	}
+
+	now = now.Add(eventWindow)
+	if !emit(api) {
+		t.Error("the limit should reset with the next window")
+	}
+	if got := len(recorder.Events); got != eventBurst+2 {
+		t.Errorf("recorded %d events, want %d", got, eventBurst+2)
+	}
+}
+
+func TestRecordEvent_NoClientset(t *testing.T) {
+	client := NewK8sClientWithClientset(nil)
+	if client.RecordEvent(&corev1.ObjectReference{Kind: "Namespace", Name: "default"}, nil, corev1.EventTypeNormal, "MCPRemediation", "done") {
+		t.Error("clients without a clientset have no recorder")
+	}
+}
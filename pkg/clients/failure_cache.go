@@ -0,0 +1,71 @@
+package clients
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+)
+
+// failureCache remembers recent connection failures per dependency host, so a
+// dead dependency costs one timeout per TTL instead of one per call. Calls made
+// meanwhile fail fast with a *cache.CachedError. A nil failureCache does nothing.
+type failureCache struct {
+	cache *cache.MemoryCache
+	ttl   time.Duration
+}
+
+// newFailureCache returns nil (disabled) without a cache or a positive TTL
+func newFailureCache(memoryCache *cache.MemoryCache, ttl time.Duration) *failureCache {
+	if memoryCache == nil || ttl <= 0 {
+		return nil
+	}
+	return &failureCache{cache: memoryCache, ttl: ttl}
+}
+
+// dependency identifies a dependency by kind and host
+type dependency struct {
+	kind string
+	host string
+}
+
+// dependencyOf returns the dependency of the given kind that serves rawURL
+func dependencyOf(kind, rawURL string) dependency {
+	host := rawURL
+	if parsed, err := url.Parse(rawURL); err == nil && parsed.Host != "" {
+		host = parsed.Host
+	}
+	return dependency{kind: kind, host: host}
+}
+
+// check returns the cached failure for the dependency, or nil to go ahead
+func (f *failureCache) check(dep dependency) error {
+	if f == nil {
+		return nil
+	}
+	if cached, found := f.cache.GetError(cache.Key("dependency-failure", dep.kind, dep.host)); found {
+		return cached
+	}
+	return nil
+}
+
+// record caches a connection failure. Failures caused by ctx ending are the
+// caller's, not the dependency's, and cached failures keep their original expiry.
+func (f *failureCache) record(ctx context.Context, dep dependency, err error) {
+	if f == nil || err == nil || ctx.Err() != nil || cache.IsCachedError(err) {
+		return
+	}
+	f.cache.SetError(cache.Key("dependency-failure", dep.kind, dep.host), err, f.ttl)
+}
+
+// health runs check at most once per TTL, serving the last result in between
+func (f *failureCache) health(ctx context.Context, dep dependency, check func() error) error {
+	if f == nil {
+		return check()
+	}
+	_, err := f.cache.GetOrSetWithNegativeTTL(ctx, cache.Key("dependency-health", dep.kind, dep.host), f.ttl, f.ttl, func() (interface{}, error) {
+		return true, check()
+	})
+	return err
+}
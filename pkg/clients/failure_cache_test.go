@@ -0,0 +1,154 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+// newHangingServer never answers until the client gives up, like an unreachable dependency
+func newHangingServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = io.Copy(io.Discard, r.Body) // The server notices the client leaving only once the body is read
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCoordinationEngineClient_FailureCache(t *testing.T) {
+	var calls atomic.Int32
+	server := newHangingServer(t, &calls)
+
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	memoryCache := cache.NewMemoryCacheWithClock(time.Minute, clk)
+	defer memoryCache.Close()
+
+	client := newTestCoordinationEngineClient(t, server.URL)
+	client.httpClient.Timeout = 50 * time.Millisecond
+	client.SetFailureCache(memoryCache, 200*time.Millisecond)
+
+	_, err := client.GetClusterStatus(context.Background())
+	if err == nil || cache.IsCachedError(err) {
+		t.Fatalf("first call error = %v, want a fresh timeout", err)
+	}
+
+	// Every call within the TTL fails fast, whichever endpoint it targets
+	start := time.Now()
+	_, err = client.GetClusterStatus(context.Background())
+	if !cache.IsCachedError(err) || !strings.Contains(err.Error(), "cached failure, age") {
+		t.Errorf("second call error = %v, want a cached failure", err)
+	}
+	if _, err := client.ListIncidents(context.Background(), "", "", 10, 0); !cache.IsCachedError(err) {
+		t.Errorf("ListIncidents() error = %v, want a cached failure", err)
+	}
+	if err := client.HealthCheck(context.Background()); !cache.IsCachedError(err) {
+		t.Errorf("HealthCheck() error = %v, want a cached failure", err)
+	}
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("cached failures took %v, want no timeout", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server called %d times within the TTL, want 1", got)
+	}
+
+	// Once the failure expires the engine is tried again
+	clk.Step(250 * time.Millisecond)
+	if _, err := client.GetClusterStatus(context.Background()); cache.IsCachedError(err) {
+		t.Errorf("call after the TTL error = %v, want a fresh attempt", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server called %d times, want 2 after the TTL", got)
+	}
+}
+
+func TestCoordinationEngineClient_FailureCacheIgnoresCallerCancellation(t *testing.T) {
+	var calls atomic.Int32
+	server := newHangingServer(t, &calls)
+
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	defer memoryCache.Close()
+
+	client := newTestCoordinationEngineClient(t, server.URL)
+	client.SetFailureCache(memoryCache, time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetClusterStatus(ctx); err == nil {
+		t.Fatal("GetClusterStatus() should fail when the caller gives up")
+	}
+	if err := client.failures.check(dependencyOf("coordination-engine", server.URL)); err != nil {
+		t.Errorf("caller cancellation was cached: %v", err)
+	}
+}
+
+func TestCoordinationEngineClient_HealthCheckCached(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	defer memoryCache.Close()
+
+	client := newTestCoordinationEngineClient(t, server.URL)
+	client.SetFailureCache(memoryCache, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if err := client.HealthCheck(context.Background()); err != nil {
+			t.Fatalf("HealthCheck() error = %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("health endpoint called %d times, want 1 within the TTL", got)
+	}
+}
+
+func TestKServeClient_FailureCache(t *testing.T) {
+	var calls atomic.Int32
+	server := newHangingServer(t, &calls)
+
+	memoryCache := cache.NewMemoryCache(time.Minute)
+	defer memoryCache.Close()
+
+	var observed []error
+	client := NewKServeClient(KServeConfig{
+		Enabled:      true,
+		Timeout:      30 * time.Millisecond,
+		MaxRetries:   2,
+		RetryBudget:  time.Second,
+		FailureCache: memoryCache,
+		FailureTTL:   time.Minute,
+		OnInference:  func(model string, latency time.Duration, err error) { observed = append(observed, err) },
+	})
+
+	// The first call retries as usual before giving up
+	if _, err := client.callInference(context.Background(), "anomaly-detector", server.URL, &InferenceRequest{}); err == nil || cache.IsCachedError(err) {
+		t.Fatalf("first call error = %v, want a fresh failure", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("server called %d times, want 3 attempts", got)
+	}
+
+	// Later calls to the same predictor fail fast without another attempt
+	if _, err := client.callInference(context.Background(), "anomaly-detector", server.URL, &InferenceRequest{}); !cache.IsCachedError(err) {
+		t.Errorf("second call error = %v, want a cached failure", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server called %d times, want no attempt within the TTL", got)
+	}
+	if len(observed) != 2 || !cache.IsCachedError(observed[1]) {
+		t.Errorf("OnInference errors = %v, want the cached failure reported", observed)
+	}
+}
@@ -0,0 +1,179 @@
+package clients
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Cluster health statuses a rule can set, least severe first
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusDegraded  = "degraded"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// maxHealthReasonObjects caps the objects a section names for the reasons;
+// the observed value still counts all of them
+const maxHealthReasonObjects = 10
+
+// HealthRule sets the cluster status to Status when the value it observes
+// exceeds Threshold, or for a minimum rule such as nodes_ready, falls below it
+type HealthRule struct {
+	Name      string `json:"name"`
+	Threshold int    `json:"threshold"`
+	Status    string `json:"status"` // degraded or unhealthy
+}
+
+// HealthReason records a rule that triggered, and so why the cluster has the
+// status it has
+type HealthReason struct {
+	Rule      string   `json:"rule"`
+	Status    string   `json:"status"`
+	Threshold int      `json:"threshold"`
+	Observed  int      `json:"observed"`
+	Message   string   `json:"message"`
+	Objects   []string `json:"objects,omitempty"` // The first objects observed, e.g. "node/worker-1"
+}
+
+// healthMetric is what a rule of a given name observes
+type healthMetric struct {
+	minimum bool   // Triggers below the threshold instead of above it
+	noun    string // What is counted, for the reason message
+	observe func(health *ClusterHealth) (int, []string)
+}
+
+// healthMetrics are the values rules can observe, by rule name
+var healthMetrics = map[string]healthMetric{
+	"nodes_not_ready": {noun: "nodes NotReady", observe: func(h *ClusterHealth) (int, []string) {
+		return h.Nodes.NotReady, h.Nodes.NotReadyNames
+	}},
+	"nodes_ready": {minimum: true, noun: "nodes Ready", observe: func(h *ClusterHealth) (int, []string) {
+		return h.Nodes.Ready, nil
+	}},
+	"pods_failed": {noun: "pods Failed", observe: func(h *ClusterHealth) (int, []string) {
+		return h.Pods.Failed, h.Pods.FailedNames
+	}},
+	"pods_pending": {noun: "pods Pending", observe: func(h *ClusterHealth) (int, []string) {
+		return h.Pods.Pending, h.Pods.PendingNames
+	}},
+	"operators_degraded": {noun: "ClusterOperators Degraded", observe: func(h *ClusterHealth) (int, []string) {
+		if h.Operators == nil {
+			return 0, nil
+		}
+		return h.Operators.Degraded, h.Operators.DegradedNames
+	}},
+	"operators_unavailable": {noun: "ClusterOperators unavailable", observe: func(h *ClusterHealth) (int, []string) {
+		if h.Operators == nil {
+			return 0, nil
+		}
+		return h.Operators.Unavailable, h.Operators.UnavailableNames
+	}},
+}
+
+// DefaultHealthRules returns the rules GetClusterHealth applies unless
+// configured otherwise: any NotReady node, Failed pod, or Degraded or
+// unavailable ClusterOperator degrades the cluster, and a cluster without a
+// Ready node is unhealthy. pods_pending is available but off by default.
+func DefaultHealthRules() []HealthRule {
+	return []HealthRule{
+		{Name: "nodes_not_ready", Threshold: 0, Status: HealthStatusDegraded},
+		{Name: "pods_failed", Threshold: 0, Status: HealthStatusDegraded},
+		{Name: "operators_degraded", Threshold: 0, Status: HealthStatusDegraded},
+		{Name: "operators_unavailable", Threshold: 0, Status: HealthStatusDegraded},
+		{Name: "nodes_ready", Threshold: 1, Status: HealthStatusUnhealthy},
+	}
+}
+
+// ParseHealthRules applies "rule=threshold[:status]" entries to the default
+// rules; "rule=off" removes a rule. A rule keeps its status unless the entry
+// gives one.
+func ParseHealthRules(entries []string) ([]HealthRule, error) {
+	rules := DefaultHealthRules()
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("%q must be \"rule=threshold[:status]\"", entry)
+		}
+		if _, known := healthMetrics[name]; !known {
+			return nil, fmt.Errorf("%q: unknown health rule %q (known: %s)", entry, name, strings.Join(slices.Sorted(maps.Keys(healthMetrics)), ", "))
+		}
+		index := slices.IndexFunc(rules, func(rule HealthRule) bool { return rule.Name == name })
+		if value == "off" {
+			if index >= 0 {
+				rules = slices.Delete(rules, index, index+1)
+			}
+			continue
+		}
+
+		rule := HealthRule{Name: name, Status: HealthStatusDegraded}
+		if index >= 0 {
+			rule = rules[index]
+		}
+		threshold, status, hasStatus := strings.Cut(value, ":")
+		n, err := strconv.Atoi(strings.TrimSpace(threshold))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%q: threshold must be a non-negative integer", entry)
+		}
+		rule.Threshold = n
+		if hasStatus {
+			rule.Status = strings.ToLower(strings.TrimSpace(status))
+			if rule.Status != HealthStatusDegraded && rule.Status != HealthStatusUnhealthy {
+				return nil, fmt.Errorf("%q: status must be degraded or unhealthy", entry)
+			}
+		}
+		if index >= 0 {
+			rules[index] = rule
+		} else {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// EvaluateHealthRules returns the status rules give a complete health
+// reading, and a reason per rule that triggered, in rule order. The most
+// severe status of the triggered rules wins; none triggering is healthy.
+func EvaluateHealthRules(rules []HealthRule, health *ClusterHealth) (string, []HealthReason) {
+	status := HealthStatusHealthy
+	var reasons []HealthReason
+	for _, rule := range rules {
+		metric, ok := healthMetrics[rule.Name]
+		if !ok {
+			continue
+		}
+		observed, objects := metric.observe(health)
+		triggered := observed > rule.Threshold
+		comparison := "above"
+		if metric.minimum {
+			triggered = observed < rule.Threshold
+			comparison = "below"
+		}
+		if !triggered {
+			continue
+		}
+		reasons = append(reasons, HealthReason{
+			Rule:      rule.Name,
+			Status:    rule.Status,
+			Threshold: rule.Threshold,
+			Observed:  observed,
+			Message:   fmt.Sprintf("%d %s, %s the threshold of %d", observed, metric.noun, comparison, rule.Threshold),
+			Objects:   objects,
+		})
+		if rule.Status == HealthStatusUnhealthy || status == HealthStatusHealthy {
+			status = rule.Status
+		}
+	}
+	return status, reasons
+}
+
+// appendObject adds an object name for the reasons, up to maxHealthReasonObjects
+func appendObject(names []string, name string) []string {
+	if len(names) >= maxHealthReasonObjects {
+		return names
+	}
+	return append(names, name)
+}
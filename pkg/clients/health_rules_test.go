@@ -0,0 +1,172 @@
+package clients
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateHealthRules(t *testing.T) {
+	healthy := func() *ClusterHealth {
+		return &ClusterHealth{
+			Nodes: NodeHealth{Total: 3, Ready: 3},
+			Pods:  PodHealth{Total: 10, Running: 10},
+		}
+	}
+	tests := []struct {
+		name       string
+		rules      []HealthRule
+		health     func() *ClusterHealth
+		wantStatus string
+		wantRules  []string
+	}{
+		{name: "healthy", health: healthy, wantStatus: "healthy"},
+		{
+			name: "not-ready node",
+			health: func() *ClusterHealth {
+				h := healthy()
+				h.Nodes = NodeHealth{Total: 3, Ready: 2, NotReady: 1, NotReadyNames: []string{"node/worker-2"}}
+				return h
+			},
+			wantStatus: "degraded", wantRules: []string{"nodes_not_ready"},
+		},
+		{
+			name: "failed pods and degraded operator",
+			health: func() *ClusterHealth {
+				h := healthy()
+				h.Pods.Failed = 2
+				h.Operators = &OperatorHealth{Total: 30, Degraded: 1}
+				return h
+			},
+			wantStatus: "degraded", wantRules: []string{"pods_failed", "operators_degraded"},
+		},
+		{
+			name: "no ready node outranks degraded",
+			health: func() *ClusterHealth {
+				h := healthy()
+				h.Nodes = NodeHealth{Total: 2, NotReady: 2}
+				return h
+			},
+			wantStatus: "unhealthy", wantRules: []string{"nodes_not_ready", "nodes_ready"},
+		},
+		{
+			name:       "no nodes at all",
+			health:     func() *ClusterHealth { return &ClusterHealth{} },
+			wantStatus: "unhealthy", wantRules: []string{"nodes_ready"},
+		},
+		{
+			name:  "failed pods under a raised threshold",
+			rules: []HealthRule{{Name: "pods_failed", Threshold: 5, Status: "degraded"}},
+			health: func() *ClusterHealth {
+				h := healthy()
+				h.Pods.Failed = 5
+				return h
+			},
+			wantStatus: "healthy",
+		},
+		{
+			name:  "pending pods made unhealthy",
+			rules: []HealthRule{{Name: "pods_pending", Threshold: 3, Status: "unhealthy"}},
+			health: func() *ClusterHealth {
+				h := healthy()
+				h.Pods.Pending = 4
+				return h
+			},
+			wantStatus: "unhealthy", wantRules: []string{"pods_pending"},
+		},
+		{
+			name:       "unknown rules are ignored",
+			rules:      []HealthRule{{Name: "moon_phase", Status: "unhealthy"}},
+			health:     func() *ClusterHealth { return &ClusterHealth{} },
+			wantStatus: "healthy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := tt.rules
+			if rules == nil {
+				rules = DefaultHealthRules()
+			}
+			status, reasons := EvaluateHealthRules(rules, tt.health())
+			if status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", status, tt.wantStatus)
+			}
+			var got []string
+			for _, reason := range reasons {
+				got = append(got, reason.Rule)
+			}
+			if !reflect.DeepEqual(got, tt.wantRules) {
+				t.Errorf("triggered rules = %v, want %v", got, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestEvaluateHealthRules_Reason(t *testing.T) {
+	health := &ClusterHealth{
+		Nodes: NodeHealth{Total: 3, Ready: 2, NotReady: 1, NotReadyNames: []string{"node/worker-2"}},
+	}
+	_, reasons := EvaluateHealthRules(DefaultHealthRules(), health)
+	want := []HealthReason{{
+		Rule:      "nodes_not_ready",
+		Status:    "degraded",
+		Threshold: 0,
+		Observed:  1,
+		Message:   "1 nodes NotReady, above the threshold of 0",
+		Objects:   []string{"node/worker-2"},
+	}}
+	if !reflect.DeepEqual(reasons, want) {
+		t.Errorf("reasons = %+v, want %+v", reasons, want)
+	}
+}
+
+func TestParseHealthRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    []HealthRule
+		wantErr string
+	}{
+		{name: "defaults", want: DefaultHealthRules()},
+		{
+			name:    "override, disable and add",
+			entries: []string{"pods_failed=5", "operators_degraded=off", " pods_pending = 10:unhealthy "},
+			want: []HealthRule{
+				{Name: "nodes_not_ready", Threshold: 0, Status: "degraded"},
+				{Name: "pods_failed", Threshold: 5, Status: "degraded"},
+				{Name: "operators_unavailable", Threshold: 0, Status: "degraded"},
+				{Name: "nodes_ready", Threshold: 1, Status: "unhealthy"},
+				{Name: "pods_pending", Threshold: 10, Status: "unhealthy"},
+			},
+		},
+		{
+			name:    "status kept unless given",
+			entries: []string{"nodes_ready=2"},
+			want: append(DefaultHealthRules()[:4],
+				HealthRule{Name: "nodes_ready", Threshold: 2, Status: "unhealthy"}),
+		},
+		{name: "missing threshold", entries: []string{"pods_failed"}, wantErr: "must be"},
+		{name: "unknown rule", entries: []string{"moon_phase=1"}, wantErr: "unknown health rule"},
+		{name: "negative threshold", entries: []string{"pods_failed=-1"}, wantErr: "non-negative"},
+		{name: "bad status", entries: []string{"pods_failed=1:healthy"}, wantErr: "degraded or unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseHealthRules(tt.entries)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseHealthRules() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseHealthRules() error = %v", err)
+			}
+			if !reflect.DeepEqual(rules, tt.want) {
+				t.Errorf("ParseHealthRules() = %+v, want %+v", rules, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrUnauthenticated is returned by ReviewToken for tokens the API server rejects
+var ErrUnauthenticated = errors.New("token not authenticated")
+
+// OnBehalfOfHeader tells the Coordination Engine which authenticated user a
+// mutating call is made for, so its own audit shows the human behind the agent
+const OnBehalfOfHeader = "X-On-Behalf-Of"
+
+// Principal is an authenticated user, as reported by a TokenReview
+type Principal struct {
+	User   string   `json:"user"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a context carrying the user a request is made for
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the user a request is made for, if authenticated
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// setOnBehalfOf adds the OnBehalfOfHeader when the request carries a principal
+func setOnBehalfOf(req *http.Request) {
+	if principal, ok := PrincipalFromContext(req.Context()); ok && principal.User != "" {
+		req.Header.Set(OnBehalfOfHeader, principal.User)
+	}
+}
+
+// ReviewToken asks the API server who a bearer token belongs to (TokenReview).
+// Rejected tokens return an error wrapping ErrUnauthenticated.
+func (c *K8sClient) ReviewToken(ctx context.Context, token string) (Principal, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	result, err := c.clientset.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return Principal{}, fmt.Errorf("token review failed: %w", err)
+	}
+	if !result.Status.Authenticated {
+		if result.Status.Error != "" {
+			return Principal{}, fmt.Errorf("%w: %s", ErrUnauthenticated, result.Status.Error)
+		}
+		return Principal{}, ErrUnauthenticated
+	}
+	return Principal{User: result.Status.User.Username, Groups: result.Status.User.Groups}, nil
+}
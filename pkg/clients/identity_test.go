@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCoordinationEngineClient_OnBehalfOf(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get(OnBehalfOfHeader))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newTestCoordinationEngineClient(t, server.URL)
+	alice := WithPrincipal(context.Background(), Principal{User: "alice", Groups: []string{"sre"}})
+
+	for _, ctx := range []context.Context{alice, context.Background()} {
+		if _, err := client.TriggerRemediation(ctx, &TriggerRemediationRequest{}); err != nil {
+			t.Fatalf("TriggerRemediation() error = %v", err)
+		}
+		if _, err := client.CreateIncident(ctx, &CreateIncidentRequest{Title: "Crash loop"}); err != nil {
+			t.Fatalf("CreateIncident() error = %v", err)
+		}
+	}
+	// Reads are not made on anyone's behalf
+	if _, err := client.GetClusterStatus(alice); err != nil {
+		t.Fatalf("GetClusterStatus() error = %v", err)
+	}
+
+	want := []string{"alice", "alice", "", "", ""}
+	if len(headers) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(headers), len(want))
+	}
+	for i := range want {
+		if headers[i] != want[i] {
+			t.Errorf("request %d %s = %q, want %q", i, OnBehalfOfHeader, headers[i], want[i])
+		}
+	}
+}
+
+func TestK8sClient_ReviewToken(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid-token" {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: "alice", Groups: []string{"sre", "system:authenticated"}},
+			}
+		} else {
+			review.Status = authenticationv1.TokenReviewStatus{Error: "token expired"}
+		}
+		return true, review, nil
+	})
+	client := NewK8sClientWithClientset(clientset)
+
+	principal, err := client.ReviewToken(context.Background(), "valid-token")
+	if err != nil {
+		t.Fatalf("ReviewToken() error = %v", err)
+	}
+	if principal.User != "alice" || len(principal.Groups) != 2 {
+		t.Errorf("ReviewToken() = %+v, want alice with 2 groups", principal)
+	}
+
+	if _, err := client.ReviewToken(context.Background(), "stale-token"); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("ReviewToken() error = %v, want ErrUnauthenticated", err)
+	}
+}
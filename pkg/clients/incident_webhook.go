@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Incident events the Coordination Engine posts to the incident webhook
+const (
+	IncidentOpened  = "incident.opened"
+	IncidentUpdated = "incident.updated"
+	IncidentClosed  = "incident.closed"
+)
+
+// IncidentSignatureHeader carries the signature of an incident webhook body:
+// "sha256=" followed by the hex HMAC-SHA256 of the body under the shared secret
+const IncidentSignatureHeader = "X-CE-Signature"
+
+// ErrInvalidSignature is returned for webhook bodies whose signature is
+// missing or does not match the shared secret
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// IncidentEvent is the body of an incident webhook call
+type IncidentEvent struct {
+	Event    string   `json:"event"` // IncidentOpened, IncidentUpdated, or IncidentClosed
+	Incident Incident `json:"incident"`
+}
+
+// Closes reports whether the event ends the incident: a close event, or an
+// update to a final status
+func (e *IncidentEvent) Closes() bool {
+	return e.Event == IncidentClosed || e.Incident.Status == "completed" || e.Incident.Status == "failed"
+}
+
+// SignIncidentEvent returns the IncidentSignatureHeader value of body under secret
+func SignIncidentEvent(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) //nolint:errcheck // hash.Hash writes never fail
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseIncidentEvent verifies the signature of an incident webhook body
+// before decoding it. It returns ErrInvalidSignature for unsigned or
+// mis-signed bodies, and an error naming the problem for malformed ones.
+func ParseIncidentEvent(body []byte, signature, secret string) (*IncidentEvent, error) {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return nil, ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) //nolint:errcheck // hash.Hash writes never fail
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event IncidentEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("malformed incident event: %w", err)
+	}
+	switch event.Event {
+	case IncidentOpened, IncidentUpdated, IncidentClosed:
+	default:
+		return nil, fmt.Errorf("unknown incident event %q", event.Event)
+	}
+	if event.Incident.ID == "" {
+		return nil, fmt.Errorf("incident event %s has no incident ID", event.Event)
+	}
+	return &event, nil
+}
@@ -0,0 +1,60 @@
+package clients
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseIncidentEvent(t *testing.T) {
+	const secret = "s3cret"
+	body := []byte(`{"event":"incident.opened","incident":{"id":"inc-1","severity":"high","status":"pending"}}`)
+
+	event, err := ParseIncidentEvent(body, SignIncidentEvent(body, secret), secret)
+	if err != nil {
+		t.Fatalf("ParseIncidentEvent() error = %v", err)
+	}
+	if event.Event != IncidentOpened || event.Incident.ID != "inc-1" || event.Closes() {
+		t.Errorf("event = %+v", event)
+	}
+
+	for name, signature := range map[string]string{
+		"unsigned":     "",
+		"wrong secret": SignIncidentEvent(body, "other"),
+		"not hex":      "sha256=zz",
+		"no prefix":    strings.TrimPrefix(SignIncidentEvent(body, secret), "sha256="),
+	} {
+		if _, err := ParseIncidentEvent(body, signature, secret); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: error = %v, want ErrInvalidSignature", name, err)
+		}
+	}
+
+	for name, malformed := range map[string]string{
+		"not JSON":      `{"event":`,
+		"unknown event": `{"event":"incident.deleted","incident":{"id":"inc-1"}}`,
+		"no ID":         `{"event":"incident.closed","incident":{}}`,
+	} {
+		body := []byte(malformed)
+		_, err := ParseIncidentEvent(body, SignIncidentEvent(body, secret), secret)
+		if err == nil || errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: error = %v, want a malformed payload error", name, err)
+		}
+	}
+}
+
+func TestIncidentEvent_Closes(t *testing.T) {
+	for _, tc := range []struct {
+		event, status string
+		want          bool
+	}{
+		{IncidentClosed, "running", true},
+		{IncidentUpdated, "completed", true},
+		{IncidentUpdated, "failed", true},
+		{IncidentUpdated, "running", false},
+	} {
+		event := IncidentEvent{Event: tc.event, Incident: Incident{Status: tc.status}}
+		if got := event.Closes(); got != tc.want {
+			t.Errorf("Closes(%s, %s) = %v, want %v", tc.event, tc.status, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package clients
+
+import (
+	"context"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The interfaces below cover the K8sClient operations tools call, so a tool
+// can be built on a test double instead of a live cluster. K8sClient
+// satisfies all of them.
+
+// NodeReader reads nodes
+type NodeReader interface {
+	ListNodes(ctx context.Context) (*corev1.NodeList, error)
+	ListNodesMatching(ctx context.Context, filter NodeFilter) (*corev1.NodeList, error)
+	GetNode(ctx context.Context, name string) (*corev1.Node, error)
+}
+
+// PodReader reads pods and their logs
+type PodReader interface {
+	ListPods(ctx context.Context, namespace string) (*corev1.PodList, error)
+	GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error)
+	StreamPodLogs(ctx context.Context, namespace, pod, container string, sinceSeconds, limitBytes int64) (io.ReadCloser, error)
+}
+
+// EventReader reads events
+type EventReader interface {
+	ListEvents(ctx context.Context, namespace string) (*corev1.EventList, error)
+}
+
+// ClusterReader reads pods, nodes and events, and goes to the clientset for
+// everything else
+type ClusterReader interface {
+	NodeReader
+	PodReader
+	EventReader
+	ClientsetProvider
+}
+
+// ClusterHealthReader summarizes cluster health and reports whether the
+// API server could be reached while doing so
+type ClusterHealthReader interface {
+	GetClusterHealth(ctx context.Context) (*ClusterHealth, error)
+	APIReachability() APIReachability
+}
+
+// ClientsetProvider exposes the typed clientset for operations without a
+// helper method
+type ClientsetProvider interface {
+	Clientset() kubernetes.Interface
+}
+
+var (
+	_ NodeReader          = (*K8sClient)(nil)
+	_ PodReader           = (*K8sClient)(nil)
+	_ EventReader         = (*K8sClient)(nil)
+	_ ClusterReader       = (*K8sClient)(nil)
+	_ ClusterHealthReader = (*K8sClient)(nil)
+	_ ClientsetProvider   = (*K8sClient)(nil)
+)
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +17,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/cache"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
 )
 
 // KServeClient provides client for KServe InferenceService models
@@ -24,15 +30,44 @@ type KServeClient struct {
 	dynamicClient dynamic.Interface
 	restConfig    *rest.Config
 	enabled       bool
+
+	timeout         time.Duration
+	maxRetries      int
+	retryBudget     time.Duration
+	bearerTokenFile string
+	headers         map[string]string
+	onInference     func(model string, latency time.Duration, err error)
+	initErr         error         // Invalid TLS configuration; returned by every model call
+	failures        *failureCache // Fails calls fast while a predictor is unreachable (nil = disabled)
 }
 
 // KServeConfig holds configuration for KServe client
 type KServeConfig struct {
 	Namespace     string
 	PredictorPort int           // Port for KServe predictor (default: 8080 for RawDeployment)
-	Timeout       time.Duration
+	Timeout       time.Duration // Per-request timeout (default: 15s)
 	Enabled       bool
-	RestConfig    *rest.Config // Kubernetes rest config for accessing CRDs
+	RestConfig    *rest.Config      // Kubernetes rest config for accessing CRDs
+	DynamicClient dynamic.Interface // Used instead of one built from RestConfig, such as a fake in tests
+
+	// Models exposed behind OpenShift OAuth, Istio, or routes with custom certificates
+	BearerTokenFile string            // Sent as "Authorization: Bearer"; re-read per request so rotated tokens are picked up
+	Headers         map[string]string // Extra headers sent with every request
+	CAFile          string            // PEM bundle trusted in addition to the system roots and the service CA
+
+	// Inference calls are idempotent and retried on 429/502/503/504, timeouts, and refused connections
+	MaxRetries  int           // 0 disables retries
+	RetryBudget time.Duration // Cap on total inference time including retries (default: 3x Timeout)
+
+	// OnInference, if set, is called after every inference call with its total latency
+	// and error (nil on success), e.g. to record per-model metrics
+	OnInference func(model string, latency time.Duration, err error)
+
+	// FailureCache, if set, remembers connection failures per predictor for FailureTTL
+	// so calls fail fast with a *cache.CachedError instead of waiting for another
+	// timeout, and reuses HealthCheck results for the same TTL
+	FailureCache *cache.MemoryCache
+	FailureTTL   time.Duration
 }
 
 // NewKServeClient creates a new KServe client
@@ -41,6 +76,10 @@ func NewKServeClient(config KServeConfig) *KServeClient {
 	if timeout == 0 {
 		timeout = 15 * time.Second
 	}
+	retryBudget := config.RetryBudget
+	if retryBudget == 0 {
+		retryBudget = 3 * timeout
+	}
 
 	// Default port for KServe RawDeployment mode is 8080
 	predictorPort := config.PredictorPort
@@ -48,18 +87,37 @@ func NewKServeClient(config KServeConfig) *KServeClient {
 		predictorPort = 8080
 	}
 
+	var caFiles []string
+	if config.CAFile != "" {
+		caFiles = append(caFiles, config.CAFile)
+	}
+	transport, err := newCATransport(caFiles...)
+	if err != nil {
+		err = fmt.Errorf("invalid KServe TLS configuration: %w", err)
+	}
+
 	client := &KServeClient{
 		namespace:     config.Namespace,
 		predictorPort: predictorPort,
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Transport: tracing.WrapTransport(transport),
 		},
-		restConfig: config.RestConfig,
-		enabled:    config.Enabled,
+		restConfig:      config.RestConfig,
+		enabled:         config.Enabled,
+		timeout:         timeout,
+		maxRetries:      config.MaxRetries,
+		retryBudget:     retryBudget,
+		bearerTokenFile: config.BearerTokenFile,
+		headers:         config.Headers,
+		onInference:     config.OnInference,
+		initErr:         err,
+		failures:        newFailureCache(config.FailureCache, config.FailureTTL),
 	}
 
 	// Initialize dynamic client if rest config is provided
-	if config.RestConfig != nil {
+	if config.DynamicClient != nil {
+		client.dynamicClient = config.DynamicClient
+	} else if config.RestConfig != nil {
 		dynamicClient, err := dynamic.NewForConfig(config.RestConfig)
 		if err == nil {
 			client.dynamicClient = dynamicClient
@@ -178,7 +236,7 @@ func (c *KServeClient) DetectAnomalies(ctx context.Context, metrics []MetricData
 
 	// Call KServe inference endpoint
 	url := c.getModelURL("anomaly-detector", "infer")
-	resp, err := c.callInference(ctx, url, inferReq)
+	resp, err := c.callInference(ctx, "anomaly-detector", url, inferReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call anomaly detector: %w", err)
 	}
@@ -199,7 +257,7 @@ func (c *KServeClient) PredictFailures(ctx context.Context, req *PredictiveAnaly
 
 	// Call KServe inference endpoint
 	url := c.getModelURL("predictive-analytics", "infer")
-	resp, err := c.callInference(ctx, url, inferReq)
+	resp, err := c.callInference(ctx, "predictive-analytics", url, inferReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call predictive analytics: %w", err)
 	}
@@ -220,35 +278,146 @@ func (c *KServeClient) getModelURL(modelName, operation string) string {
 }
 
 // callInference makes an HTTP call to the KServe inference endpoint
-func (c *KServeClient) callInference(ctx context.Context, url string, req *InferenceRequest) (*InferenceResponse, error) {
+func (c *KServeClient) callInference(ctx context.Context, modelName, url string, req *InferenceRequest) (*InferenceResponse, error) {
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	status, respBody, err := c.infer(ctx, modelName, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d: %s", status, string(respBody))
+	}
+
+	var result InferenceResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// infer sends an idempotent inference call, retrying transient failures until
+// MaxRetries or the retry budget runs out, and reports its latency to OnInference.
+// The status and body are those of the last attempt.
+func (c *KServeClient) infer(ctx context.Context, modelName, url string, body []byte) (int, []byte, error) {
+	start := time.Now()
+	var status int
+	var respBody []byte
+
+	// A predictor that just exhausted its retries is not tried again until the failure expires
+	dep := dependencyOf("kserve", url)
+	if err := c.failures.check(dep); err != nil {
+		if c.onInference != nil {
+			c.onInference(modelName, time.Since(start), err)
+		}
+		return 0, nil, err
+	}
+
+	attempt := func(ctx context.Context) error {
+		var err error
+		status, respBody, err = c.send(ctx, http.MethodPost, url, body)
+		if err == nil && status != http.StatusOK {
+			return &httpStatusError{StatusCode: status, Body: string(respBody)}
+		}
+		return err
+	}
+
+	var err error
+	if c.maxRetries > 0 {
+		budgetCtx, cancel := context.WithTimeout(ctx, c.retryBudget)
+		err = RetryWithBackoff(budgetCtx, &RetryConfig{
+			MaxRetries:     c.maxRetries,
+			InitialBackoff: 200 * time.Millisecond,
+			MaxBackoff:     2 * time.Second,
+			Multiplier:     2.0,
+		}, func() error { return attempt(budgetCtx) })
+		cancel()
+	} else {
+		err = attempt(ctx)
+	}
+
+	// Callers report unexpected statuses in their own words
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		err = nil
+	}
+	c.failures.record(ctx, dep, err)
+	if c.onInference != nil {
+		observed := err
+		if observed == nil && status != http.StatusOK {
+			observed = &httpStatusError{StatusCode: status}
+		}
+		c.onInference(modelName, time.Since(start), observed)
+	}
+	return status, respBody, err
+}
+
+// send performs one request with the configured auth headers, bounded by the
+// per-request timeout, and returns the response status and body
+func (c *KServeClient) send(ctx context.Context, method, url string, body []byte) (int, []byte, error) {
+	if c.initErr != nil {
+		return 0, nil, c.initErr
+	}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+	if token := c.bearerToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(httpReq)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return 0, nil, fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
+	recordThrottledResponse(ctx, resp)
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(body))
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("failed to read response: %w", err)
 	}
+	return resp.StatusCode, respBody, nil
+}
 
-	var result InferenceResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+// call is send behind the failure cache, for calls that are not retried
+func (c *KServeClient) call(ctx context.Context, method, url string, body []byte) (int, []byte, error) {
+	dep := dependencyOf("kserve", url)
+	if err := c.failures.check(dep); err != nil {
+		return 0, nil, err
 	}
+	status, respBody, err := c.send(ctx, method, url, body)
+	c.failures.record(ctx, dep, err)
+	return status, respBody, err
+}
 
-	return &result, nil
+// bearerToken re-reads the token file so rotated tokens are picked up
+func (c *KServeClient) bearerToken() string {
+	if c.bearerTokenFile == "" {
+		return ""
+	}
+	token, err := os.ReadFile(c.bearerTokenFile)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(token))
 }
 
 // buildAnomalyDetectionRequest builds an inference request for anomaly detection
@@ -464,22 +633,17 @@ func (c *KServeClient) HealthCheck(ctx context.Context, modelName string) error
 	url := fmt.Sprintf("http://%s-predictor.%s.svc.cluster.local:%d/v2/models/model",
 		modelName, c.namespace, c.predictorPort)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("health check failed with status %d", resp.StatusCode)
-	}
-
-	return nil
+	// With a failure cache the result is reused for its TTL
+	return c.failures.health(ctx, dependencyOf("kserve", url), func() error {
+		status, _, err := c.call(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		if status != http.StatusOK {
+			return fmt.Errorf("health check failed with status %d", status)
+		}
+		return nil
+	})
 }
 
 // GetNamespace returns the KServe namespace
@@ -513,24 +677,16 @@ func (c *KServeClient) GetModelStatus(ctx context.Context, modelName string) (*M
 	url := fmt.Sprintf("http://%s-predictor.%s.svc.cluster.local:%d/v2/models/model",
 		modelName, c.namespace, c.predictorPort)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	code, body, err := c.call(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get model status (code %d): %s", resp.StatusCode, string(body))
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("failed to get model status (code %d): %s", code, string(body))
 	}
 
 	var status ModelStatusResponse
-	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+	if err := json.Unmarshal(body, &status); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -538,11 +694,30 @@ func (c *KServeClient) GetModelStatus(ctx context.Context, modelName string) (*M
 	if status.ModelName == "" {
 		status.ModelName = modelName
 	}
-	status.Ready = resp.StatusCode == http.StatusOK
+	status.Ready = true
 
 	return &status, nil
 }
 
+// ScrapeMetrics returns the Prometheus text exposition served by a model's
+// predictor on /metrics, for use when no Prometheus server is configured
+func (c *KServeClient) ScrapeMetrics(ctx context.Context, modelName string) (string, error) {
+	if !c.enabled {
+		return "", fmt.Errorf("kserve not enabled")
+	}
+
+	url := fmt.Sprintf("http://%s-predictor.%s.svc.cluster.local:%d/metrics",
+		modelName, c.namespace, c.predictorPort)
+	status, body, err := c.call(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to scrape model metrics (code %d): %s", status, string(body))
+	}
+	return string(body), nil
+}
+
 // PredictionRequest represents a generic prediction request
 type PredictionRequest struct {
 	Instances []map[string]interface{} `json:"instances"`
@@ -577,31 +752,71 @@ func (c *KServeClient) Predict(ctx context.Context, modelName string, instances
 	url := fmt.Sprintf("http://%s-predictor.%s.svc.cluster.local:%d/v1/models/model:predict",
 		modelName, c.namespace, c.predictorPort)
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	status, respBody, err := c.infer(ctx, modelName, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("prediction failed (code %d): %s", resp.StatusCode, string(body))
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("prediction failed (code %d): %s", status, string(respBody))
 	}
 
 	var result PredictionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(respBody, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return &result, nil
 }
 
+// inferenceServiceGVR identifies the KServe InferenceService resource
+var inferenceServiceGVR = schema.GroupVersionResource{
+	Group:    "serving.kserve.io",
+	Version:  "v1beta1",
+	Resource: "inferenceservices",
+}
+
+// RevisionTraffic is one entry of an InferenceService predictor's traffic split
+type RevisionTraffic struct {
+	RevisionName   string
+	Percent        int
+	LatestRevision bool
+	Tag            string
+}
+
+// GetPredictorTraffic returns the predictor traffic split of an InferenceService.
+// Only Serverless models have revisions; RawDeployment models return an empty split.
+func (c *KServeClient) GetPredictorTraffic(ctx context.Context, modelName string) ([]RevisionTraffic, error) {
+	if !c.enabled {
+		return nil, fmt.Errorf("kserve not enabled")
+	}
+
+	if c.dynamicClient == nil {
+		return nil, fmt.Errorf("kubernetes client not configured - unable to get InferenceService")
+	}
+
+	obj, err := c.dynamicClient.Resource(inferenceServiceGVR).Namespace(c.namespace).Get(ctx, modelName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inferenceservice %s: %w", modelName, err)
+	}
+
+	entries, _, _ := unstructured.NestedSlice(obj.Object, "status", "components", "predictor", "traffic")
+	split := make([]RevisionTraffic, 0, len(entries))
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		latest, _ := entryMap["latestRevision"].(bool)
+		split = append(split, RevisionTraffic{
+			RevisionName:   getString(entryMap, "revisionName"),
+			Percent:        int(getFloat64(entryMap, "percent")),
+			LatestRevision: latest,
+			Tag:            getString(entryMap, "tag"),
+		})
+	}
+	return split, nil
+}
+
 // InferenceService represents a KServe InferenceService CRD
 type InferenceService struct {
 	Name   string
@@ -643,15 +858,8 @@ func (c *KServeClient) ListInferenceServices(ctx context.Context) ([]InferenceSe
 		return nil, fmt.Errorf("kubernetes client not configured - unable to list InferenceServices")
 	}
 
-	// Define the GVR for InferenceService
-	gvr := schema.GroupVersionResource{
-		Group:    "serving.kserve.io",
-		Version:  "v1beta1",
-		Resource: "inferenceservices",
-	}
-
 	// List InferenceServices in the namespace
-	list, err := c.dynamicClient.Resource(gvr).Namespace(c.namespace).List(ctx, metav1.ListOptions{})
+	list, err := c.dynamicClient.Resource(inferenceServiceGVR).Namespace(c.namespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list inferenceservices: %w", err)
 	}
@@ -0,0 +1,181 @@
+package clients
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestKServeClient_InferRetriesUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "upstream connect error", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"model_name":"anomaly-detector","outputs":[]}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var observed []string
+	client := NewKServeClient(KServeConfig{
+		Enabled:    true,
+		Timeout:    time.Second,
+		MaxRetries: 3,
+		OnInference: func(model string, latency time.Duration, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			observed = append(observed, model)
+			if err != nil {
+				t.Errorf("OnInference error = %v, want nil after a successful retry", err)
+			}
+		},
+	})
+
+	resp, err := client.callInference(context.Background(), "anomaly-detector", server.URL, &InferenceRequest{})
+	if err != nil {
+		t.Fatalf("callInference() error = %v", err)
+	}
+	if resp.ModelName != "anomaly-detector" {
+		t.Errorf("ModelName = %q", resp.ModelName)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server called %d times, want 3", got)
+	}
+	if len(observed) != 1 || observed[0] != "anomaly-detector" {
+		t.Errorf("OnInference calls = %v, want one for anomaly-detector", observed)
+	}
+}
+
+func TestKServeClient_InferDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "bad input shape", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	var observedErr error
+	client := NewKServeClient(KServeConfig{
+		Enabled:     true,
+		MaxRetries:  3,
+		OnInference: func(model string, latency time.Duration, err error) { observedErr = err },
+	})
+	_, err := client.callInference(context.Background(), "anomaly-detector", server.URL, &InferenceRequest{})
+	if err == nil || !strings.Contains(err.Error(), "unexpected status code 400: bad input shape") {
+		t.Errorf("callInference() error = %v, want the 400 body", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server called %d times, want 1", got)
+	}
+	if observedErr == nil {
+		t.Error("OnInference should record the 400 as an error")
+	}
+}
+
+func TestKServeClient_InferTimeout(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewKServeClient(KServeConfig{
+		Enabled:     true,
+		Timeout:     50 * time.Millisecond,
+		MaxRetries:  5,
+		RetryBudget: 400 * time.Millisecond,
+	})
+
+	start := time.Now()
+	_, err := client.callInference(context.Background(), "anomaly-detector", server.URL, &InferenceRequest{})
+	if err == nil {
+		t.Fatal("callInference() should fail when every attempt times out")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("callInference() took %v, want it bounded by the retry budget", elapsed)
+	}
+	if got := calls.Load(); got < 2 {
+		t.Errorf("server called %d times, want timeouts to be retried", got)
+	}
+}
+
+func TestKServeClient_AuthHeaders(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("sa-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotAuth, gotTenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotTenant = r.Header.Get("X-Tenant")
+		_, _ = w.Write([]byte(`{"name":"predictive-analytics","ready":true}`))
+	}))
+	defer server.Close()
+
+	client := NewKServeClient(KServeConfig{
+		Enabled:         true,
+		BearerTokenFile: tokenFile,
+		Headers:         map[string]string{"X-Tenant": "aiops"},
+	})
+	status, _, err := client.send(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("send() = %d, %v", status, err)
+	}
+	if gotAuth != "Bearer sa-token" {
+		t.Errorf("Authorization = %q, want bearer token from the token file", gotAuth)
+	}
+	if gotTenant != "aiops" {
+		t.Errorf("X-Tenant = %q, want the configured header", gotTenant)
+	}
+}
+
+func TestKServeClient_InvalidCAFile(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewKServeClient(KServeConfig{Enabled: true, CAFile: caFile})
+	_, _, err := client.send(context.Background(), http.MethodGet, "https://anomaly-detector.example.com", nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid KServe TLS configuration") {
+		t.Errorf("send() error = %v, want the TLS configuration error", err)
+	}
+}
+
+func TestKServeClient_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, cert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := NewKServeClient(KServeConfig{Enabled: true}).send(context.Background(), http.MethodGet, server.URL, nil); err == nil {
+		t.Error("send() should reject a certificate signed by an unknown CA")
+	}
+	status, _, err := NewKServeClient(KServeConfig{Enabled: true, CAFile: caFile}).send(context.Background(), http.MethodGet, server.URL, nil)
+	if err != nil || status != http.StatusOK {
+		t.Errorf("send() with the CA file = %d, %v", status, err)
+	}
+}
@@ -2,23 +2,53 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
 )
 
 // K8sClient wraps the Kubernetes clientset with additional functionality
 type K8sClient struct {
-	clientset *kubernetes.Clientset
-	config    *rest.Config
+	clientset     kubernetes.Interface
+	dynamicClient dynamic.Interface // Reads arbitrary kinds, including CRDs
+	mapper        meta.RESTMapper   // Resolves kinds to resources via discovery
+	config        *rest.Config
+	throttle      *throttleRecorder // Records client-side rate limiter waits (nil for injected clientsets)
+
+	healthConcurrency int          // GetClusterHealth collectors run at once; 0 runs them all
+	healthRules       []HealthRule // Set the GetClusterHealth status; nil applies DefaultHealthRules
+	reachability      reachabilityTracker
+
+	groupsMu sync.Mutex
+	groups   map[string]bool // Cached discovery result for DiscoverGroup; nil until first successful lookup
+
+	// Kubernetes Events emitted by RecordEvent; set up on first use
+	eventsOnce  sync.Once
+	recorder    record.EventRecorder
+	broadcaster record.EventBroadcaster // nil when the recorder was injected
+	limiter     *eventLimiter
 }
 
 // K8sClientConfig holds configuration for the Kubernetes client
@@ -33,6 +63,13 @@ type K8sClientConfig struct {
 
 	// Timeout for API requests
 	Timeout time.Duration // Default: 30s
+
+	// HealthConcurrency bounds how many GetClusterHealth collectors query the
+	// API server at once; 1 reads the sections one after another
+	HealthConcurrency int // Default: 0 (all sections at once)
+
+	// HealthRules decide the GetClusterHealth status, see ParseHealthRules
+	HealthRules []HealthRule // Default: DefaultHealthRules()
 }
 
 // NewK8sClient creates a new Kubernetes client with connection pooling
@@ -68,20 +105,69 @@ func NewK8sClient(cfg *K8sClientConfig) (*K8sClient, error) {
 	config.Burst = cfg.Burst
 	config.Timeout = cfg.Timeout
 
+	// One recorded limiter shared by the typed, dynamic, and discovery clients,
+	// so ThrottleStats sees every wait caused by our own QPS setting
+	throttle := newThrottleRecorder(flowcontrol.NewTokenBucketRateLimiter(cfg.QPS, cfg.Burst), cfg.Burst)
+	config.RateLimiter = throttle
+
+	// Emit a client span per API server request
+	config.Wrap(tracing.WrapTransport)
+	// Note 429s (API Priority and Fairness rejections) in the call's throttle
+	// log, including those client-go retries on its own
+	config.Wrap(recordThrottledResponses)
+
 	// Create clientset
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kubernetes clientset: %w", err)
 	}
 
+	// Create dynamic client for kinds without a typed client
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
 	client := &K8sClient{
-		clientset: clientset,
-		config:    config,
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery())),
+		config:        config,
+		throttle:      throttle,
+
+		healthConcurrency: cfg.HealthConcurrency,
+		healthRules:       cfg.HealthRules,
 	}
 
 	return client, nil
 }
 
+// NewK8sClientWithClientset creates a client around an existing clientset,
+// such as the one from k8s.io/client-go/kubernetes/fake in tests
+func NewK8sClientWithClientset(clientset kubernetes.Interface) *K8sClient {
+	return &K8sClient{clientset: clientset}
+}
+
+// NewK8sClientWithDynamic creates a client backed only by a dynamic client and
+// REST mapper. Typed helpers are unavailable; this is mainly used in tests
+// with k8s.io/client-go/dynamic/fake.
+func NewK8sClientWithDynamic(dynamicClient dynamic.Interface, mapper meta.RESTMapper) *K8sClient {
+	return &K8sClient{
+		dynamicClient: dynamicClient,
+		mapper:        mapper,
+	}
+}
+
+// NewK8sClientWithClients creates a client from an existing clientset, dynamic
+// client, and REST mapper, such as their fake counterparts in tests
+func NewK8sClientWithClients(clientset kubernetes.Interface, dynamicClient dynamic.Interface, mapper meta.RESTMapper) *K8sClient {
+	return &K8sClient{
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+		mapper:        mapper,
+	}
+}
+
 // getKubeConfig attempts to build a Kubernetes config
 // Priority: 1) in-cluster, 2) provided path, 3) ~/.kube/config, 4) $KUBECONFIG
 func getKubeConfig(kubeconfigPath string) (*rest.Config, error) {
@@ -140,170 +226,687 @@ func (c *K8sClient) GetServerVersion(ctx context.Context) (string, error) {
 	return version.GitVersion, nil
 }
 
-// ListNodes returns all nodes in the cluster
-func (c *K8sClient) ListNodes(ctx context.Context) (*corev1.NodeList, error) {
-	nodes, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+// OpenShiftAPIGroup is served only by OpenShift clusters
+const OpenShiftAPIGroup = "config.openshift.io"
+
+// APIGroups returns the set of API group names the cluster serves
+func (c *K8sClient) APIGroups(ctx context.Context) (map[string]bool, error) {
+	groups, err := c.clientset.Discovery().ServerGroups()
 	if err != nil {
-		return nil, fmt.Errorf("failed to list nodes: %w", err)
+		return nil, fmt.Errorf("failed to discover API groups: %w", err)
 	}
-	return nodes, nil
+	served := make(map[string]bool, len(groups.Groups))
+	for _, group := range groups.Groups {
+		served[group.Name] = true
+	}
+	return served, nil
 }
 
-// GetNode returns a specific node by name
-func (c *K8sClient) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
-	node, err := c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+// IsOpenShift reports whether the cluster serves the OpenShift config API group
+func (c *K8sClient) IsOpenShift(ctx context.Context) (bool, error) {
+	groups, err := c.APIGroups(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+		return false, err
 	}
-	return node, nil
+	return groups[OpenShiftAPIGroup], nil
+}
+
+// Platforms reported by GetClusterInfo
+const (
+	PlatformOpenShift  = "openshift"
+	PlatformKubernetes = "kubernetes"
+)
+
+// ClusterInfo identifies the cluster the server is connected to
+type ClusterInfo struct {
+	ServerVersion string `json:"server_version"`
+	Platform      string `json:"platform"`
+}
+
+// GetClusterInfo returns the cluster's server version and platform, telling
+// OpenShift from vanilla Kubernetes by the config.openshift.io group
+func (c *K8sClient) GetClusterInfo(ctx context.Context) (*ClusterInfo, error) {
+	version, err := c.GetServerVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	openshift, err := c.IsOpenShift(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ClusterInfo{ServerVersion: version, Platform: PlatformKubernetes}
+	if openshift {
+		info.Platform = PlatformOpenShift
+	}
+	return info, nil
+}
+
+// DiscoverGroup reports whether the cluster serves the given API group.
+// The first successful discovery is cached; failures are not, so a later call
+// retries. Returns false for a nil client or one without a clientset.
+func (c *K8sClient) DiscoverGroup(group string) bool {
+	if c == nil || c.clientset == nil {
+		return false
+	}
+
+	c.groupsMu.Lock()
+	defer c.groupsMu.Unlock()
+	if c.groups == nil {
+		groups, err := c.APIGroups(context.Background())
+		if err != nil {
+			return false
+		}
+		c.groups = groups
+	}
+	return c.groups[group]
+}
+
+// invalidateDiscovery drops cached discovery data so the next lookup sees
+// API groups and CRDs installed since it was populated
+func (c *K8sClient) invalidateDiscovery() {
+	if resettable, ok := c.mapper.(meta.ResettableRESTMapper); ok {
+		resettable.Reset()
+	}
+	c.groupsMu.Lock()
+	c.groups = nil
+	c.groupsMu.Unlock()
+}
+
+// restMapping resolves a kind to its resource, refreshing the cached
+// discovery data once when the kind is unknown (e.g. a CRD installed after startup)
+func (c *K8sClient) restMapping(gk schema.GroupKind, version string) (*meta.RESTMapping, error) {
+	mapping, err := c.mapper.RESTMapping(gk, version)
+	if err == nil || !meta.IsNoMatchError(err) {
+		return mapping, err
+	}
+	c.invalidateDiscovery()
+	return c.mapper.RESTMapping(gk, version)
+}
+
+// ListNodes returns all nodes in the cluster
+func (c *K8sClient) ListNodes(ctx context.Context) (*corev1.NodeList, error) {
+	return cachedRead(ctx, "nodes", func() (*corev1.NodeList, error) {
+		nodes, err := c.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list nodes: %w", err)
+		}
+		return nodes, nil
+	}, (*corev1.NodeList).DeepCopy)
+}
+
+// GetNode returns a specific node by name
+func (c *K8sClient) GetNode(ctx context.Context, name string) (*corev1.Node, error) {
+	return cachedRead(ctx, "node/"+name, func() (*corev1.Node, error) {
+		node, err := c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+		}
+		return node, nil
+	}, (*corev1.Node).DeepCopy)
 }
 
 // ListPods returns pods in the specified namespace
 // If namespace is empty, returns pods from all namespaces
 func (c *K8sClient) ListPods(ctx context.Context, namespace string) (*corev1.PodList, error) {
-	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
-	}
-	return pods, nil
+	return cachedRead(ctx, "pods/"+namespace, func() (*corev1.PodList, error) {
+		pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+		}
+		return pods, nil
+	}, (*corev1.PodList).DeepCopy)
 }
 
 // GetPod returns a specific pod
 func (c *K8sClient) GetPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
-	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	return cachedRead(ctx, "pod/"+namespace+"/"+name, func() (*corev1.Pod, error) {
+		pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod %s/%s: %w", namespace, name, err)
+		}
+		return pod, nil
+	}, (*corev1.Pod).DeepCopy)
+}
+
+// StreamPodLogs opens the log stream of one container with RFC 3339 timestamps.
+// sinceSeconds and limitBytes are ignored when zero. The caller closes the stream.
+func (c *K8sClient) StreamPodLogs(ctx context.Context, namespace, pod, container string, sinceSeconds, limitBytes int64) (io.ReadCloser, error) {
+	opts := &corev1.PodLogOptions{Container: container, Timestamps: true}
+	if sinceSeconds > 0 {
+		opts.SinceSeconds = &sinceSeconds
+	}
+	if limitBytes > 0 {
+		opts.LimitBytes = &limitBytes
+	}
+
+	stream, err := c.clientset.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get pod %s/%s: %w", namespace, name, err)
+		return nil, fmt.Errorf("failed to stream logs of %s/%s container %s: %w", namespace, pod, container, err)
 	}
-	return pod, nil
+	return stream, nil
 }
 
-// ListNamespaces returns all namespaces
-func (c *K8sClient) ListNamespaces(ctx context.Context) (*corev1.NamespaceList, error) {
-	namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+// FollowPodLogs opens the log stream of one container and keeps it open for
+// new lines, with RFC 3339 timestamps. The API server ends the stream when
+// the container stops; until then only ctx or closing the stream ends it.
+// The stream starts with the lines of the last sinceSeconds, or with new
+// lines only when sinceSeconds is zero. The caller closes the stream.
+func (c *K8sClient) FollowPodLogs(ctx context.Context, namespace, pod, container string, sinceSeconds int64) (io.ReadCloser, error) {
+	opts := &corev1.PodLogOptions{Container: container, Follow: true, Timestamps: true}
+	if sinceSeconds > 0 {
+		opts.SinceSeconds = &sinceSeconds
+	} else {
+		var none int64
+		opts.TailLines = &none
+	}
+
+	stream, err := c.clientset.CoreV1().Pods(namespace).GetLogs(pod, opts).Stream(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		return nil, fmt.Errorf("failed to follow logs of %s/%s container %s: %w", namespace, pod, container, err)
 	}
-	return namespaces, nil
+	return stream, nil
+}
+
+// ListNamespaces returns all namespaces
+func (c *K8sClient) ListNamespaces(ctx context.Context) (*corev1.NamespaceList, error) {
+	return cachedRead(ctx, "namespaces", func() (*corev1.NamespaceList, error) {
+		namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+		return namespaces, nil
+	}, (*corev1.NamespaceList).DeepCopy)
 }
 
 // ListEvents returns events in the specified namespace
 func (c *K8sClient) ListEvents(ctx context.Context, namespace string) (*corev1.EventList, error) {
-	events, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err)
-	}
-	return events, nil
+	return cachedRead(ctx, "events/"+namespace, func() (*corev1.EventList, error) {
+		events, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events in namespace %s: %w", namespace, err)
+		}
+		return events, nil
+	}, (*corev1.EventList).DeepCopy)
 }
 
-// GetClusterHealth returns a summary of cluster health
+// GetClusterHealth returns a summary of cluster health. Nodes, pods, and (on
+// OpenShift) cluster operators are read by independent collectors, at most
+// HealthConcurrency at a time, each retried on transient API errors. A section
+// that fails, or is cut short by ctx, carries its error and makes the status
+// "unknown" while the other sections are still reported; an error is returned
+// only when neither nodes nor pods could be read. The status of a complete
+// reading comes from the HealthRules, with a reason per rule that triggered.
+// Calls failing to reach the API server are counted in APIReachability.
 func (c *K8sClient) GetClusterHealth(ctx context.Context) (*ClusterHealth, error) {
-	// Get nodes
-	nodes, err := c.ListNodes(ctx)
-	if err != nil {
-		return nil, err
+	rules := c.healthRules
+	if rules == nil {
+		rules = DefaultHealthRules()
 	}
+	health, err := gatherClusterHealth(ctx, c.healthConcurrency, rules, c.healthCollectors())
+	c.reachability.observe(err, time.Now())
+	return health, err
+}
 
-	// Get all pods
-	pods, err := c.ListPods(ctx, "")
-	if err != nil {
-		return nil, err
+// healthCollector reads one section of ClusterHealth
+type healthCollector struct {
+	name string
+	// collect reads the section and returns a function storing it in the result
+	collect func(ctx context.Context) (merge func(*ClusterHealth), err error)
+	// fail stores the reason the section could not be read
+	fail func(health *ClusterHealth, reason string)
+}
+
+// healthCollectors returns the collectors behind GetClusterHealth
+func (c *K8sClient) healthCollectors() []healthCollector {
+	return []healthCollector{
+		{
+			name: "nodes",
+			collect: func(ctx context.Context) (func(*ClusterHealth), error) {
+				nodes, err := c.nodeHealth(ctx)
+				return func(health *ClusterHealth) { health.Nodes = nodes }, err
+			},
+			fail: func(health *ClusterHealth, reason string) { health.Nodes = NodeHealth{Error: reason} },
+		},
+		{
+			name: "pods",
+			collect: func(ctx context.Context) (func(*ClusterHealth), error) {
+				pods, err := c.podHealth(ctx)
+				return func(health *ClusterHealth) { health.Pods = pods }, err
+			},
+			fail: func(health *ClusterHealth, reason string) { health.Pods = PodHealth{Error: reason} },
+		},
+		{
+			name: "operators",
+			collect: func(ctx context.Context) (func(*ClusterHealth), error) {
+				operators, err := c.operatorHealth(ctx)
+				return func(health *ClusterHealth) { health.Operators = operators }, err
+			},
+			fail: func(health *ClusterHealth, reason string) { health.Operators = &OperatorHealth{Error: reason} },
+		},
 	}
+}
 
-	// Calculate node health
-	totalNodes := len(nodes.Items)
-	readyNodes := 0
-	notReadyNodes := 0
+// gatherClusterHealth runs collectors concurrently with a shared ctx, at most
+// concurrency at a time (all at once when concurrency < 1), and merges their
+// results once every collector has finished, evaluating rules on them
+func gatherClusterHealth(ctx context.Context, concurrency int, rules []HealthRule, collectors []healthCollector) (*ClusterHealth, error) {
+	if concurrency < 1 || concurrency > len(collectors) {
+		concurrency = len(collectors)
+	}
+
+	type result struct {
+		merge func(*ClusterHealth)
+		err   error
+	}
+	results := make([]result, len(collectors))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, collector := range collectors {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+			case <-ctx.Done():
+				results[i].err = ctx.Err()
+				return
+			}
+			results[i].merge, results[i].err = collectSection(ctx, collector.collect)
+		}()
+	}
+	wg.Wait()
+
+	health := &ClusterHealth{}
+	failed := make(map[string]error)
+	for i, collector := range collectors {
+		if err := results[i].err; err != nil {
+			failed[collector.name] = err
+			collector.fail(health, sectionError(err))
+			continue
+		}
+		results[i].merge(health)
+	}
+
+	if failed["nodes"] != nil && failed["pods"] != nil {
+		return nil, errors.Join(failed["nodes"], failed["pods"])
+	}
+	if len(failed) > 0 {
+		health.Status = "unknown"
+		health.Partial = true
+		return health, nil
+	}
+
+	health.Status, health.Reasons = EvaluateHealthRules(rules, health)
+	return health, nil
+}
 
+// collectSection runs one GetClusterHealth collector, retrying transient API
+// errors with backoff. It returns as soon as ctx ends even if the underlying
+// call does not honor it.
+func collectSection(ctx context.Context, collect func(context.Context) (func(*ClusterHealth), error)) (func(*ClusterHealth), error) {
+	type result struct {
+		merge func(*ClusterHealth)
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var merge func(*ClusterHealth)
+		var lastErr error
+		err := RetryWithBackoff(ctx, DefaultRetryConfig(), func() error {
+			merge, lastErr = collect(ctx)
+			return lastErr
+		})
+		if err != nil && ctx.Err() == nil {
+			err = lastErr // Report the API error itself, not the retry bookkeeping
+		}
+		done <- result{merge, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.merge, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// sectionError describes why a health section could not be read
+func sectionError(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline exceeded"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	return err.Error()
+}
+
+// nodeHealth counts ready and not-ready nodes, naming the first not-ready ones
+func (c *K8sClient) nodeHealth(ctx context.Context) (NodeHealth, error) {
+	nodes, err := c.ListNodes(ctx)
+	if err != nil {
+		return NodeHealth{}, err
+	}
+
+	health := NodeHealth{Total: len(nodes.Items)}
 	for _, node := range nodes.Items {
 		for _, condition := range node.Status.Conditions {
 			if condition.Type == corev1.NodeReady {
 				if condition.Status == corev1.ConditionTrue {
-					readyNodes++
+					health.Ready++
 				} else {
-					notReadyNodes++
+					health.NotReady++
+					health.NotReadyNames = appendObject(health.NotReadyNames, "node/"+node.Name)
 				}
 				break
 			}
 		}
 	}
+	return health, nil
+}
 
-	// Calculate pod health
-	totalPods := len(pods.Items)
-	runningPods := 0
-	pendingPods := 0
-	failedPods := 0
-	succeededPods := 0
-	unknownPods := 0
+// podHealth counts pods in all namespaces by phase, naming the first pending
+// and failed ones
+func (c *K8sClient) podHealth(ctx context.Context) (PodHealth, error) {
+	pods, err := c.ListPods(ctx, "")
+	if err != nil {
+		return PodHealth{}, err
+	}
 
+	health := PodHealth{Total: len(pods.Items)}
 	for _, pod := range pods.Items {
 		switch pod.Status.Phase {
 		case corev1.PodRunning:
-			runningPods++
+			health.Running++
 		case corev1.PodPending:
-			pendingPods++
+			health.Pending++
+			health.PendingNames = appendObject(health.PendingNames, "pod/"+pod.Namespace+"/"+pod.Name)
 		case corev1.PodFailed:
-			failedPods++
+			health.Failed++
+			health.FailedNames = appendObject(health.FailedNames, "pod/"+pod.Namespace+"/"+pod.Name)
 		case corev1.PodSucceeded:
-			succeededPods++
+			health.Succeeded++
 		default:
-			unknownPods++
+			health.Unknown++
 		}
 	}
+	return health, nil
+}
 
-	// Determine overall health status
-	status := "healthy"
-	if notReadyNodes > 0 || failedPods > 0 {
-		status = "degraded"
+// clusterOperatorsGVR is the OpenShift ClusterOperator resource
+var clusterOperatorsGVR = schema.GroupVersionResource{Group: OpenShiftAPIGroup, Version: "v1", Resource: "clusteroperators"}
+
+// operatorHealth counts and names unavailable and degraded ClusterOperators.
+// It returns nil outside OpenShift, where there are none.
+func (c *K8sClient) operatorHealth(ctx context.Context) (*OperatorHealth, error) {
+	if c.dynamicClient == nil || !c.DiscoverGroup(OpenShiftAPIGroup) {
+		return nil, nil
 	}
-	if readyNodes == 0 || totalNodes == 0 {
-		status = "unhealthy"
+	list, err := c.ListUnstructured(ctx, clusterOperatorsGVR, "", metav1.ListOptions{})
+	if err != nil {
+		return nil, err
 	}
 
-	return &ClusterHealth{
-		Status: status,
-		Nodes: NodeHealth{
-			Total:    totalNodes,
-			Ready:    readyNodes,
-			NotReady: notReadyNodes,
-		},
-		Pods: PodHealth{
-			Total:     totalPods,
-			Running:   runningPods,
-			Pending:   pendingPods,
-			Failed:    failedPods,
-			Succeeded: succeededPods,
-			Unknown:   unknownPods,
-		},
-	}, nil
+	health := &OperatorHealth{Total: len(list.Items)}
+	for _, operator := range list.Items {
+		conditions, _, _ := unstructured.NestedSlice(operator.Object, "status", "conditions")
+		for _, item := range conditions {
+			condition, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			switch condition["type"] {
+			case "Available":
+				if condition["status"] != "True" {
+					health.Unavailable++
+					health.UnavailableNames = appendObject(health.UnavailableNames, "clusteroperator/"+operator.GetName())
+				}
+			case "Degraded":
+				if condition["status"] == "True" {
+					health.Degraded++
+					health.DegradedNames = appendObject(health.DegradedNames, "clusteroperator/"+operator.GetName())
+				}
+			}
+		}
+	}
+	return health, nil
 }
 
 // ClusterHealth represents the overall health of the cluster
 type ClusterHealth struct {
-	Status string     `json:"status"` // healthy, degraded, unhealthy
-	Nodes  NodeHealth `json:"nodes"`
-	Pods   PodHealth  `json:"pods"`
+	Status    string          `json:"status"` // healthy, degraded, unhealthy, or unknown when a section could not be read
+	Nodes     NodeHealth      `json:"nodes"`
+	Pods      PodHealth       `json:"pods"`
+	Operators *OperatorHealth `json:"operators,omitempty"` // OpenShift only
+	Partial   bool            `json:"partial,omitempty"`   // Some sections carry an error instead of counts
+
+	// Reasons are the health rules that triggered, explaining a degraded or
+	// unhealthy status; empty for healthy and partial readings
+	Reasons []HealthReason `json:"reasons,omitempty"`
+}
+
+// UnreadSections describes the sections of a partial result that could not be
+// read, such as "pods: deadline exceeded"
+func (h *ClusterHealth) UnreadSections() []string {
+	var sections []string
+	if h.Nodes.Error != "" {
+		sections = append(sections, "nodes: "+h.Nodes.Error)
+	}
+	if h.Pods.Error != "" {
+		sections = append(sections, "pods: "+h.Pods.Error)
+	}
+	if h.Operators != nil && h.Operators.Error != "" {
+		sections = append(sections, "operators: "+h.Operators.Error)
+	}
+	return sections
 }
 
 // NodeHealth represents node health metrics
 type NodeHealth struct {
-	Total    int `json:"total"`
-	Ready    int `json:"ready"`
-	NotReady int `json:"not_ready"`
+	Total    int    `json:"total"`
+	Ready    int    `json:"ready"`
+	NotReady int    `json:"not_ready"`
+	Error    string `json:"error,omitempty"` // Why the nodes could not be read
+
+	NotReadyNames []string `json:"-"` // The first not-ready nodes, for the health reasons
 }
 
 // PodHealth represents pod health metrics
 type PodHealth struct {
-	Total     int `json:"total"`
-	Running   int `json:"running"`
-	Pending   int `json:"pending"`
-	Failed    int `json:"failed"`
-	Succeeded int `json:"succeeded"`
-	Unknown   int `json:"unknown"`
+	Total     int    `json:"total"`
+	Running   int    `json:"running"`
+	Pending   int    `json:"pending"`
+	Failed    int    `json:"failed"`
+	Succeeded int    `json:"succeeded"`
+	Unknown   int    `json:"unknown"`
+	Error     string `json:"error,omitempty"` // Why the pods could not be read
+
+	// The first pending and failed pods, for the health reasons
+	PendingNames []string `json:"-"`
+	FailedNames  []string `json:"-"`
+}
+
+// OperatorHealth represents OpenShift ClusterOperator health
+type OperatorHealth struct {
+	Total       int    `json:"total"`
+	Unavailable int    `json:"unavailable"`
+	Degraded    int    `json:"degraded"`
+	Error       string `json:"error,omitempty"` // Why the operators could not be read
+
+	// The first unavailable and degraded operators, for the health reasons
+	UnavailableNames []string `json:"-"`
+	DegradedNames    []string `json:"-"`
+}
+
+// sectionFailure is how a section that could not be read is reported: its
+// counts are unknown, not zero, so they are left out
+type sectionFailure struct {
+	Error string `json:"error"`
+}
+
+// MarshalJSON reports only the error of nodes that could not be read
+func (h NodeHealth) MarshalJSON() ([]byte, error) {
+	if h.Error != "" {
+		return json.Marshal(sectionFailure{Error: h.Error})
+	}
+	type plain NodeHealth
+	return json.Marshal(plain(h))
+}
+
+// MarshalJSON reports only the error of pods that could not be read
+func (h PodHealth) MarshalJSON() ([]byte, error) {
+	if h.Error != "" {
+		return json.Marshal(sectionFailure{Error: h.Error})
+	}
+	type plain PodHealth
+	return json.Marshal(plain(h))
+}
+
+// MarshalJSON reports only the error of operators that could not be read
+func (h OperatorHealth) MarshalJSON() ([]byte, error) {
+	if h.Error != "" {
+		return json.Marshal(sectionFailure{Error: h.Error})
+	}
+	type plain OperatorHealth
+	return json.Marshal(plain(h))
+}
+
+// GetResource fetches any object by apiVersion and kind using the dynamic client.
+// The namespace is ignored for cluster-scoped kinds and required otherwise.
+func (c *K8sClient) GetResource(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	key := "resource/" + apiVersion + "/" + kind + "/" + namespace + "/" + name
+	return cachedRead(ctx, key, func() (*unstructured.Unstructured, error) {
+		return c.getResource(ctx, apiVersion, kind, namespace, name)
+	}, (*unstructured.Unstructured).DeepCopy)
+}
+
+func (c *K8sClient) getResource(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	if c.dynamicClient == nil || c.mapper == nil {
+		return nil, fmt.Errorf("dynamic client not initialized")
+	}
+
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", apiVersion, err)
+	}
+
+	mapping, err := c.restMapping(schema.GroupKind{Group: gv.Group, Kind: kind}, gv.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s %s: %w", apiVersion, kind, err)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj, err := c.dynamicClient.Resource(mapping.Resource).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s: %w", kind, name, err)
+		}
+		return obj, nil
+	}
+
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required for namespaced kind %s", kind)
+	}
+	obj, err := c.dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", kind, namespace, name, err)
+	}
+	return obj, nil
+}
+
+// ListResources lists objects of any kind using the dynamic client.
+// An empty namespace lists across all namespaces; it is ignored for cluster-scoped kinds.
+func (c *K8sClient) ListResources(ctx context.Context, apiVersion, kind, namespace string, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	options, err := json.Marshal(opts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list options: %w", err)
+	}
+	key := "resources/" + apiVersion + "/" + kind + "/" + namespace + "?" + string(options)
+	return cachedRead(ctx, key, func() (*unstructured.UnstructuredList, error) {
+		return c.listResources(ctx, apiVersion, kind, namespace, opts)
+	}, (*unstructured.UnstructuredList).DeepCopy)
+}
+
+func (c *K8sClient) listResources(ctx context.Context, apiVersion, kind, namespace string, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	if c.dynamicClient == nil || c.mapper == nil {
+		return nil, fmt.Errorf("dynamic client not initialized")
+	}
+
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid apiVersion %q: %w", apiVersion, err)
+	}
+
+	mapping, err := c.restMapping(schema.GroupKind{Group: gv.Group, Kind: kind}, gv.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s %s: %w", apiVersion, kind, err)
+	}
+
+	resource := c.dynamicClient.Resource(mapping.Resource)
+	var list *unstructured.UnstructuredList
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		list, err = resource.Namespace(namespace).List(ctx, opts)
+	} else {
+		list, err = resource.List(ctx, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s in namespace %s: %w", kind, namespace, err)
+	}
+	return list, nil
+}
+
+// GetUnstructured fetches an object by resource using the dynamic client,
+// skipping kind resolution. An empty namespace addresses a cluster-scoped object.
+func (c *K8sClient) GetUnstructured(ctx context.Context, gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	if c.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client not initialized")
+	}
+
+	obj, err := c.dynamicClient.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if namespace == "" {
+			return nil, fmt.Errorf("failed to get %s %s: %w", gvr.Resource, name, err)
+		}
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", gvr.Resource, namespace, name, err)
+	}
+	return obj, nil
+}
+
+// ListUnstructured lists objects by resource using the dynamic client,
+// skipping kind resolution. An empty namespace lists a cluster-scoped resource
+// or a namespaced one across all namespaces.
+func (c *K8sClient) ListUnstructured(ctx context.Context, gvr schema.GroupVersionResource, namespace string, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	if c.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic client not initialized")
+	}
+
+	list, err := c.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s in namespace %s: %w", gvr.Resource, namespace, err)
+	}
+	return list, nil
+}
+
+// GetRaw performs a GET on an API server path such as
+// /apis/apps/v1/namespaces/foo/deployments and returns the response body.
+// The path is sent as given; callers must validate it.
+func (c *K8sClient) GetRaw(ctx context.Context, path string) ([]byte, error) {
+	restClient := c.clientset.Discovery().RESTClient()
+	if restClient == nil {
+		return nil, fmt.Errorf("raw API access not available")
+	}
+
+	body, err := restClient.Get().AbsPath(path).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", path, err)
+	}
+	return body, nil
 }
 
 // Clientset returns the underlying Kubernetes clientset
 // This is useful for advanced operations not covered by helper methods
-func (c *K8sClient) Clientset() *kubernetes.Clientset {
+func (c *K8sClient) Clientset() kubernetes.Interface {
 	return c.clientset
 }
 
@@ -313,11 +916,12 @@ func (c *K8sClient) GetConfig() *rest.Config {
 	return c.config
 }
 
-// Close cleans up the client resources
-// Note: Kubernetes clientset doesn't require explicit cleanup,
-// but this method is provided for future extensibility
+// Close cleans up the client resources: the event broadcaster, once events
+// have been emitted. The clientset itself needs no cleanup.
 func (c *K8sClient) Close() error {
-	// No-op for now, but can be extended if needed
+	if c.broadcaster != nil {
+		c.broadcaster.Shutdown()
+	}
 	return nil
 }
 
@@ -369,11 +973,13 @@ func (c *K8sClient) GetDeployment(ctx context.Context, namespace, name string) (
 
 // ListDeployments returns all deployments in a namespace
 func (c *K8sClient) ListDeployments(ctx context.Context, namespace string) (*appsv1.DeploymentList, error) {
-	deployments, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list deployments in namespace %s: %w", namespace, err)
-	}
-	return deployments, nil
+	return cachedRead(ctx, "deployments/"+namespace, func() (*appsv1.DeploymentList, error) {
+		deployments, err := c.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deployments in namespace %s: %w", namespace, err)
+		}
+		return deployments, nil
+	}, (*appsv1.DeploymentList).DeepCopy)
 }
 
 // ResourceQuotaInfo represents resource quota information for a namespace
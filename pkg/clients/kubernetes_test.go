@@ -2,8 +2,25 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
 	"testing"
 	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 func TestNewK8sClient(t *testing.T) {
@@ -359,3 +376,495 @@ func TestK8sClient_GetConfig(t *testing.T) {
 		t.Logf("Config host: %s", config.Host)
 	}
 }
+
+func TestK8sClient_IsOpenShift(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		want      bool
+	}{
+		{
+			name:      "vanilla Kubernetes",
+			resources: []*metav1.APIResourceList{{GroupVersion: "apps/v1"}},
+			want:      false,
+		},
+		{
+			name: "OpenShift",
+			resources: []*metav1.APIResourceList{
+				{GroupVersion: "apps/v1"},
+				{GroupVersion: "config.openshift.io/v1"},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewClientset()
+			clientset.Resources = tt.resources
+
+			got, err := NewK8sClientWithClientset(clientset).IsOpenShift(context.Background())
+			if err != nil {
+				t.Fatalf("IsOpenShift() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsOpenShift() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestK8sClient_GetClusterInfo(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.Discovery().(*discoveryfake.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.31.4"}
+	clientset.Resources = []*metav1.APIResourceList{{GroupVersion: "apps/v1"}}
+	client := NewK8sClientWithClientset(clientset)
+
+	info, err := client.GetClusterInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterInfo() error = %v", err)
+	}
+	if info.ServerVersion != "v1.31.4" || info.Platform != PlatformKubernetes {
+		t.Errorf("GetClusterInfo() = %+v, want v1.31.4 on %s", info, PlatformKubernetes)
+	}
+
+	clientset.Resources = append(clientset.Resources, &metav1.APIResourceList{GroupVersion: "config.openshift.io/v1"})
+	info, err = client.GetClusterInfo(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterInfo() error = %v", err)
+	}
+	if info.Platform != PlatformOpenShift {
+		t.Errorf("GetClusterInfo().Platform = %q, want %q", info.Platform, PlatformOpenShift)
+	}
+}
+
+func TestK8sClient_DiscoverGroup(t *testing.T) {
+	clientset := fake.NewClientset()
+	clientset.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "apps/v1"},
+		{GroupVersion: "machine.openshift.io/v1beta1"},
+	}
+	client := NewK8sClientWithClientset(clientset)
+
+	if !client.DiscoverGroup("machine.openshift.io") {
+		t.Error("DiscoverGroup(machine.openshift.io) = false, want true")
+	}
+	if client.DiscoverGroup(OpenShiftAPIGroup) {
+		t.Errorf("DiscoverGroup(%s) = true, want false", OpenShiftAPIGroup)
+	}
+
+	// Groups added after the first lookup stay hidden until discovery is invalidated
+	clientset.Resources = append(clientset.Resources, &metav1.APIResourceList{GroupVersion: "config.openshift.io/v1"})
+	if client.DiscoverGroup(OpenShiftAPIGroup) {
+		t.Error("DiscoverGroup() should serve the cached result")
+	}
+	client.invalidateDiscovery()
+	if !client.DiscoverGroup(OpenShiftAPIGroup) {
+		t.Error("DiscoverGroup() should see the new group after invalidation")
+	}
+
+	var nilClient *K8sClient
+	if nilClient.DiscoverGroup(OpenShiftAPIGroup) {
+		t.Error("DiscoverGroup() on a nil client = true, want false")
+	}
+}
+
+var testWidgetGVR = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+func newTestWidget(namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("example.com/v1")
+	obj.SetKind("Widget")
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return obj
+}
+
+func newTestDynamicClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{testWidgetGVR: "WidgetList"},
+		newTestWidget("team-a", "alpha"),
+		newTestWidget("team-b", "beta"),
+	)
+}
+
+func TestK8sClient_ListUnstructured(t *testing.T) {
+	client := NewK8sClientWithDynamic(newTestDynamicClient(), nil)
+
+	all, err := client.ListUnstructured(context.Background(), testWidgetGVR, "", metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListUnstructured() error = %v", err)
+	}
+	if len(all.Items) != 2 {
+		t.Errorf("ListUnstructured() across namespaces returned %d items, want 2", len(all.Items))
+	}
+
+	scoped, err := client.ListUnstructured(context.Background(), testWidgetGVR, "team-a", metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListUnstructured() error = %v", err)
+	}
+	if len(scoped.Items) != 1 || scoped.Items[0].GetName() != "alpha" {
+		t.Errorf("ListUnstructured(team-a) = %v, want only alpha", scoped.Items)
+	}
+
+	if _, err := NewK8sClientWithClientset(fake.NewClientset()).ListUnstructured(context.Background(), testWidgetGVR, "", metav1.ListOptions{}); err == nil {
+		t.Error("ListUnstructured() without a dynamic client should fail")
+	}
+}
+
+func TestK8sClient_GetUnstructured(t *testing.T) {
+	client := NewK8sClientWithDynamic(newTestDynamicClient(), nil)
+
+	obj, err := client.GetUnstructured(context.Background(), testWidgetGVR, "team-b", "beta")
+	if err != nil {
+		t.Fatalf("GetUnstructured() error = %v", err)
+	}
+	if obj.GetName() != "beta" {
+		t.Errorf("GetUnstructured() name = %s, want beta", obj.GetName())
+	}
+
+	if _, err := client.GetUnstructured(context.Background(), testWidgetGVR, "team-a", "beta"); err == nil {
+		t.Error("GetUnstructured() for a missing object should fail")
+	}
+}
+
+// staleRESTMapper simulates a discovery cache that predates a CRD install:
+// it reports NoMatch until Reset is called
+type staleRESTMapper struct {
+	*meta.DefaultRESTMapper
+	stale  bool
+	resets int
+}
+
+func (m *staleRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	if m.stale {
+		return nil, &meta.NoKindMatchError{GroupKind: gk, SearchedVersions: versions}
+	}
+	return m.DefaultRESTMapper.RESTMapping(gk, versions...)
+}
+
+func (m *staleRESTMapper) Reset() {
+	m.stale = false
+	m.resets++
+}
+
+func TestK8sClient_ListResources_RefreshesStaleMapper(t *testing.T) {
+	mapper := &staleRESTMapper{DefaultRESTMapper: meta.NewDefaultRESTMapper(nil), stale: true}
+	mapper.Add(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, meta.RESTScopeNamespace)
+	client := NewK8sClientWithDynamic(newTestDynamicClient(), mapper)
+
+	list, err := client.ListResources(context.Background(), "example.com/v1", "Widget", "", metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListResources() error = %v", err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("ListResources() returned %d items, want 2", len(list.Items))
+	}
+	if mapper.resets != 1 {
+		t.Errorf("mapper reset %d times, want 1", mapper.resets)
+	}
+
+	// Kinds that are genuinely unknown fail after a single refresh
+	if _, err := client.GetResource(context.Background(), "example.com/v1", "Gadget", "team-a", "alpha"); !meta.IsNoMatchError(err) {
+		t.Errorf("GetResource() error = %v, want NoMatch", err)
+	}
+	if mapper.resets != 2 {
+		t.Errorf("mapper reset %d times, want 2", mapper.resets)
+	}
+}
+
+// newTestClusterOperator returns a ClusterOperator with the given Available and Degraded statuses
+func newTestClusterOperator(name, available, degraded string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": available},
+				map[string]interface{}{"type": "Degraded", "status": degraded},
+			},
+		},
+	}}
+	obj.SetAPIVersion("config.openshift.io/v1")
+	obj.SetKind("ClusterOperator")
+	obj.SetName(name)
+	return obj
+}
+
+// newHealthTestClient serves one ready node, one running pod, and two
+// ClusterOperators, one of them degraded
+func newHealthTestClient() *K8sClient {
+	clientset := fake.NewClientset(
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	)
+	clientset.Resources = []*metav1.APIResourceList{{GroupVersion: "config.openshift.io/v1"}}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{clusterOperatorsGVR: "ClusterOperatorList"},
+		newTestClusterOperator("dns", "True", "False"),
+		newTestClusterOperator("ingress", "True", "True"),
+	)
+	return NewK8sClientWithClients(clientset, dynamicClient, nil)
+}
+
+// injectSections wraps the health collectors: a slow one blocks until the
+// test ends, ignoring ctx as a hung connection would; a failing one returns an error
+func injectSections(t *testing.T, slow, failing []string, collectors []healthCollector) []healthCollector {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	for i, collector := range collectors {
+		collect := collector.collect
+		collectors[i].collect = func(ctx context.Context) (func(*ClusterHealth), error) {
+			if slices.Contains(slow, collector.name) {
+				<-release
+			}
+			if slices.Contains(failing, collector.name) {
+				return nil, fmt.Errorf("%s is forbidden", collector.name)
+			}
+			return collect(ctx)
+		}
+	}
+	return collectors
+}
+
+func TestK8sClient_GetClusterHealth_PartialResults(t *testing.T) {
+	tests := []struct {
+		name          string
+		slow          []string
+		failing       []string
+		wantErr       bool
+		wantStatus    string
+		wantNodes     string // Expected section error, "" when read
+		wantPods      string
+		wantOperators string
+	}{
+		{name: "all sections read", wantStatus: "degraded"},
+		{name: "slow nodes", slow: []string{"nodes"}, wantStatus: "unknown", wantNodes: "deadline exceeded"},
+		{name: "slow pods", slow: []string{"pods"}, wantStatus: "unknown", wantPods: "deadline exceeded"},
+		{name: "slow operators", slow: []string{"operators"}, wantStatus: "unknown", wantOperators: "deadline exceeded"},
+		{name: "failing pods", failing: []string{"pods"}, wantStatus: "unknown", wantPods: "pods is forbidden"},
+		{
+			name: "slow pods and failing operators", slow: []string{"pods"}, failing: []string{"operators"},
+			wantStatus: "unknown", wantPods: "deadline exceeded", wantOperators: "operators is forbidden",
+		},
+		{name: "slow nodes and pods", slow: []string{"nodes", "pods"}, wantErr: true},
+		{name: "failing nodes, slow pods", slow: []string{"pods"}, failing: []string{"nodes"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newHealthTestClient()
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			health, err := gatherClusterHealth(ctx, 0, DefaultHealthRules(), injectSections(t, tt.slow, tt.failing, client.healthCollectors()))
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("GetClusterHealth() took %v, want it to return promptly at the deadline", elapsed)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("GetClusterHealth() = %+v, want an error", health)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetClusterHealth() error = %v", err)
+			}
+
+			if health.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", health.Status, tt.wantStatus)
+			}
+			if health.Partial != (tt.wantStatus == "unknown") {
+				t.Errorf("Partial = %v with status %q", health.Partial, health.Status)
+			}
+			if health.Nodes.Error != tt.wantNodes {
+				t.Errorf("Nodes.Error = %q, want %q", health.Nodes.Error, tt.wantNodes)
+			}
+			if health.Pods.Error != tt.wantPods {
+				t.Errorf("Pods.Error = %q, want %q", health.Pods.Error, tt.wantPods)
+			}
+			if health.Operators == nil {
+				t.Fatal("Operators = nil on an OpenShift cluster")
+			}
+			if health.Operators.Error != tt.wantOperators {
+				t.Errorf("Operators.Error = %q, want %q", health.Operators.Error, tt.wantOperators)
+			}
+
+			// Sections that were read keep their counts
+			if tt.wantNodes == "" && (health.Nodes.Total != 1 || health.Nodes.Ready != 1) {
+				t.Errorf("Nodes = %+v, want 1 ready node", health.Nodes)
+			}
+			if tt.wantPods == "" && (health.Pods.Total != 1 || health.Pods.Running != 1) {
+				t.Errorf("Pods = %+v, want 1 running pod", health.Pods)
+			}
+			if tt.wantOperators == "" && (health.Operators.Total != 2 || health.Operators.Degraded != 1 || health.Operators.Unavailable != 0) {
+				t.Errorf("Operators = %+v, want 2 operators with 1 degraded", health.Operators)
+			}
+		})
+	}
+}
+
+func TestClusterHealth_MarshalPartial(t *testing.T) {
+	health := ClusterHealth{
+		Status:  "unknown",
+		Nodes:   NodeHealth{Total: 3, Ready: 3},
+		Pods:    PodHealth{Error: "deadline exceeded"},
+		Partial: true,
+	}
+	data, err := json.Marshal(health)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"status":"unknown","nodes":{"total":3,"ready":3,"not_ready":0},"pods":{"error":"deadline exceeded"},"partial":true}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	if got := health.UnreadSections(); !slices.Equal(got, []string{"pods: deadline exceeded"}) {
+		t.Errorf("UnreadSections() = %v", got)
+	}
+}
+
+func TestK8sClient_GetClusterHealth_Reasons(t *testing.T) {
+	health, err := newHealthTestClient().GetClusterHealth(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterHealth() error = %v", err)
+	}
+	if health.Status != "degraded" || len(health.Reasons) != 1 {
+		t.Fatalf("Status = %q, Reasons = %+v, want degraded by one rule", health.Status, health.Reasons)
+	}
+	reason := health.Reasons[0]
+	if reason.Rule != "operators_degraded" || reason.Observed != 1 || !slices.Equal(reason.Objects, []string{"clusteroperator/ingress"}) {
+		t.Errorf("Reasons[0] = %+v, want operators_degraded naming ingress", reason)
+	}
+
+	// A raised threshold lets the degraded operator pass
+	client := newHealthTestClient()
+	client.healthRules, _ = ParseHealthRules([]string{"operators_degraded=1"})
+	health, err = client.GetClusterHealth(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterHealth() error = %v", err)
+	}
+	if health.Status != "healthy" || health.Reasons != nil {
+		t.Errorf("Status = %q, Reasons = %+v, want healthy without reasons", health.Status, health.Reasons)
+	}
+}
+
+func TestK8sClient_GetClusterHealth_VanillaKubernetes(t *testing.T) {
+	client := NewK8sClientWithClientset(fake.NewClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+		}},
+	}))
+
+	health, err := client.GetClusterHealth(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterHealth() error = %v", err)
+	}
+	if health.Status != "healthy" || health.Partial {
+		t.Errorf("Status = %q, Partial = %v, want healthy and complete", health.Status, health.Partial)
+	}
+	if health.Operators != nil {
+		t.Errorf("Operators = %+v, want nil without ClusterOperators", health.Operators)
+	}
+}
+
+func TestK8sClient_GetClusterHealth_RetriesTransientErrors(t *testing.T) {
+	client := newHealthTestClient()
+	var attempts int
+	client.clientset.(*fake.Clientset).PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		attempts++
+		if attempts == 1 {
+			return true, nil, apierrors.NewServiceUnavailable("apiserver restarting")
+		}
+		return false, nil, nil
+	})
+
+	health, err := client.GetClusterHealth(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterHealth() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("pods listed %d times, want a retry after the 503", attempts)
+	}
+	if health.Partial || health.Pods.Running != 1 {
+		t.Errorf("Pods = %+v, Partial = %v, want the retried pod counts", health.Pods, health.Partial)
+	}
+}
+
+func TestGatherClusterHealth_BoundsConcurrency(t *testing.T) {
+	for _, concurrency := range []int{1, 2, 0} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			client := newHealthTestClient()
+			var mu sync.Mutex
+			var inFlight, peak int
+			collectors := client.healthCollectors()
+			for i := range collectors {
+				collect := collectors[i].collect
+				collectors[i].collect = func(ctx context.Context) (func(*ClusterHealth), error) {
+					mu.Lock()
+					inFlight++
+					peak = max(peak, inFlight)
+					mu.Unlock()
+					time.Sleep(20 * time.Millisecond)
+					mu.Lock()
+					inFlight--
+					mu.Unlock()
+					return collect(ctx)
+				}
+			}
+
+			health, err := gatherClusterHealth(context.Background(), concurrency, DefaultHealthRules(), collectors)
+			if err != nil {
+				t.Fatalf("gatherClusterHealth() error = %v", err)
+			}
+			if health.Partial {
+				t.Errorf("Partial = true, want every section read: %+v", health)
+			}
+			want := concurrency
+			if want == 0 {
+				want = len(collectors)
+			}
+			if peak != want {
+				t.Errorf("peak concurrent collectors = %d, want %d", peak, want)
+			}
+		})
+	}
+}
+
+// BenchmarkGetClusterHealth compares reading the health sections one after
+// another with reading them concurrently. Each collector waits a simulated API
+// round trip before querying the fake clientset; the latency cannot live in a
+// reactor, because the fake clientset runs reactors under a single lock.
+func BenchmarkGetClusterHealth(b *testing.B) {
+	const latency = 10 * time.Millisecond
+	for _, concurrency := range []int{1, 3} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			client := newHealthTestClient()
+			collectors := client.healthCollectors()
+			for i := range collectors {
+				collect := collectors[i].collect
+				collectors[i].collect = func(ctx context.Context) (func(*ClusterHealth), error) {
+					time.Sleep(latency)
+					return collect(ctx)
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := gatherClusterHealth(context.Background(), concurrency, DefaultHealthRules(), collectors); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package clients
+
+import (
+	"context"
+	"slices"
+)
+
+type namespaceScopeKey struct{}
+
+// WithNamespaceScope returns a context limiting namespaced reads to the
+// given namespaces, e.g. those of a tenant profile. Callers with a scope
+// never see cluster-wide lists.
+func WithNamespaceScope(ctx context.Context, namespaces []string) context.Context {
+	return context.WithValue(ctx, namespaceScopeKey{}, slices.Clone(namespaces))
+}
+
+// NamespaceScopeFromContext returns the namespaces reads are limited to, if
+// the request is scoped
+func NamespaceScopeFromContext(ctx context.Context) ([]string, bool) {
+	namespaces, ok := ctx.Value(namespaceScopeKey{}).([]string)
+	return namespaces, ok
+}
+
+// InNamespaceScope reports whether namespace may be read under the
+// context's scope. Everything is in scope for unscoped requests.
+func InNamespaceScope(ctx context.Context, namespace string) bool {
+	namespaces, ok := NamespaceScopeFromContext(ctx)
+	return !ok || slices.Contains(namespaces, namespace)
+}
+
+// NamespacesToList returns the namespaces a list call has to cover: the
+// requested namespace when one is set, else the scope's namespaces, else
+// "" for all namespaces
+func NamespacesToList(ctx context.Context, namespace string) []string {
+	if namespace != "" {
+		return []string{namespace}
+	}
+	if namespaces, ok := NamespaceScopeFromContext(ctx); ok {
+		return namespaces
+	}
+	return []string{""}
+}
@@ -0,0 +1,46 @@
+package clients
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestNamespacesToList(t *testing.T) {
+	scoped := WithNamespaceScope(context.Background(), []string{"team-a", "team-a-dev"})
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		namespace string
+		want      []string
+	}{
+		{name: "unscoped, all namespaces", ctx: context.Background(), want: []string{""}},
+		{name: "unscoped, one namespace", ctx: context.Background(), namespace: "default", want: []string{"default"}},
+		{name: "scoped, all namespaces", ctx: scoped, want: []string{"team-a", "team-a-dev"}},
+		{name: "scoped, one namespace", ctx: scoped, namespace: "team-a", want: []string{"team-a"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NamespacesToList(tt.ctx, tt.namespace); !slices.Equal(got, tt.want) {
+				t.Errorf("NamespacesToList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInNamespaceScope(t *testing.T) {
+	if !InNamespaceScope(context.Background(), "kube-system") {
+		t.Error("unscoped requests should see every namespace")
+	}
+	scoped := WithNamespaceScope(context.Background(), []string{"team-a"})
+	if !InNamespaceScope(scoped, "team-a") {
+		t.Error("team-a should be in scope")
+	}
+	if InNamespaceScope(scoped, "kube-system") {
+		t.Error("kube-system should be out of scope")
+	}
+	if InNamespaceScope(WithNamespaceScope(context.Background(), nil), "") {
+		t.Error("an empty scope should cover nothing")
+	}
+}
@@ -0,0 +1,162 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// ErrInvalidNodeFilter is wrapped by the errors of NewNodeFilter
+var ErrInvalidNodeFilter = errors.New("invalid node filter")
+
+// nodeRoleLabels maps the roles of NodeFilter to the node-role labels that
+// carry them. Control plane nodes carry either label depending on the
+// release, so the master role matches both.
+var nodeRoleLabels = map[string][]string{
+	"master": {"node-role.kubernetes.io/master", "node-role.kubernetes.io/control-plane"},
+	"worker": {"node-role.kubernetes.io/worker"},
+	"infra":  {"node-role.kubernetes.io/infra"},
+}
+
+// nodeRoleAliases are other names accepted for a role
+var nodeRoleAliases = map[string]string{
+	"control-plane": "master",
+}
+
+// NodeRoles returns the roles a NodeFilter accepts, sorted
+func NodeRoles() []string {
+	roles := make([]string, 0, len(nodeRoleLabels))
+	for role := range nodeRoleLabels {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// NodeFilter selects nodes by label selector, name prefix, and role. The
+// label selector, and a role carried by a single label, are sent to the API
+// server; the name prefix and the master role are matched in memory. The
+// zero NodeFilter selects every node.
+type NodeFilter struct {
+	LabelSelector string `json:"label_selector,omitempty"`
+	NamePrefix    string `json:"name_prefix,omitempty"`
+	Role          string `json:"role,omitempty"`
+
+	selector   labels.Selector // Sent to the API server; nil for none
+	roleLabels []string        // Any of them must be set, checked in memory
+}
+
+// NewNodeFilter validates a filter's arguments, any of which may be empty.
+// Invalid selectors and unknown roles return an error wrapping
+// ErrInvalidNodeFilter.
+func NewNodeFilter(labelSelector, namePrefix, role string) (NodeFilter, error) {
+	filter := NodeFilter{
+		LabelSelector: strings.TrimSpace(labelSelector),
+		NamePrefix:    strings.TrimSpace(namePrefix),
+		Role:          strings.ToLower(strings.TrimSpace(role)),
+	}
+
+	selector := labels.NewSelector()
+	if filter.LabelSelector != "" {
+		parsed, err := labels.Parse(filter.LabelSelector)
+		if err != nil {
+			return NodeFilter{}, fmt.Errorf("%w: label_selector %q: %v", ErrInvalidNodeFilter, filter.LabelSelector, err)
+		}
+		selector = parsed
+	}
+
+	if filter.Role != "" {
+		if canonical, ok := nodeRoleAliases[filter.Role]; ok {
+			filter.Role = canonical
+		}
+		roleLabels, ok := nodeRoleLabels[filter.Role]
+		if !ok {
+			return NodeFilter{}, fmt.Errorf("%w: role %q, must be one of: %s", ErrInvalidNodeFilter, role, strings.Join(NodeRoles(), ", "))
+		}
+		if len(roleLabels) == 1 {
+			requirement, err := labels.NewRequirement(roleLabels[0], selection.Exists, nil)
+			if err != nil {
+				return NodeFilter{}, fmt.Errorf("%w: role %q: %v", ErrInvalidNodeFilter, role, err)
+			}
+			selector = selector.Add(*requirement)
+		} else {
+			filter.roleLabels = roleLabels
+		}
+	}
+
+	if !selector.Empty() {
+		filter.selector = selector
+	}
+	return filter, nil
+}
+
+// IsZero reports whether the filter selects every node
+func (f NodeFilter) IsZero() bool {
+	return f.LabelSelector == "" && f.NamePrefix == "" && f.Role == ""
+}
+
+// String describes the filter, e.g. "role=worker,name_prefix=ip-10"; it is
+// empty for the zero filter
+func (f NodeFilter) String() string {
+	var parts []string
+	if f.LabelSelector != "" {
+		parts = append(parts, "label_selector="+f.LabelSelector)
+	}
+	if f.NamePrefix != "" {
+		parts = append(parts, "name_prefix="+f.NamePrefix)
+	}
+	if f.Role != "" {
+		parts = append(parts, "role="+f.Role)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ListOptions returns the options selecting the filter's nodes on the API server
+func (f NodeFilter) ListOptions() metav1.ListOptions {
+	if f.selector == nil {
+		return metav1.ListOptions{}
+	}
+	return metav1.ListOptions{LabelSelector: f.selector.String()}
+}
+
+// Matches reports whether node passes the whole filter
+func (f NodeFilter) Matches(node *corev1.Node) bool {
+	if f.selector != nil && !f.selector.Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	if !strings.HasPrefix(node.Name, f.NamePrefix) {
+		return false
+	}
+	if len(f.roleLabels) > 0 && !slices.ContainsFunc(f.roleLabels, func(label string) bool {
+		_, ok := node.Labels[label]
+		return ok
+	}) {
+		return false
+	}
+	return true
+}
+
+// ListNodesMatching returns the nodes passing filter: selected by the API
+// server as far as list options allow, and by name and role in memory
+func (c *K8sClient) ListNodesMatching(ctx context.Context, filter NodeFilter) (*corev1.NodeList, error) {
+	nodes, err := c.clientset.CoreV1().Nodes().List(ctx, filter.ListOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	matched := nodes.Items[:0]
+	for i := range nodes.Items {
+		if filter.Matches(&nodes.Items[i]) {
+			matched = append(matched, nodes.Items[i])
+		}
+	}
+	nodes.Items = matched
+	return nodes, nil
+}
@@ -0,0 +1,126 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func roleNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+var filterNodes = []*corev1.Node{
+	roleNode("master-0", map[string]string{"node-role.kubernetes.io/master": "", "topology.kubernetes.io/zone": "a"}),
+	roleNode("cp-1", map[string]string{"node-role.kubernetes.io/control-plane": "", "topology.kubernetes.io/zone": "b"}),
+	roleNode("worker-0", map[string]string{"node-role.kubernetes.io/worker": "", "topology.kubernetes.io/zone": "a"}),
+	roleNode("worker-1", map[string]string{"node-role.kubernetes.io/worker": "", "topology.kubernetes.io/zone": "b"}),
+	roleNode("infra-0", map[string]string{"node-role.kubernetes.io/infra": "", "node-role.kubernetes.io/worker": ""}),
+}
+
+func TestNewNodeFilter_Roles(t *testing.T) {
+	tests := []struct {
+		role        string
+		wantRole    string
+		wantOptions string // Label selector sent to the API server
+		want        []string
+	}{
+		{role: "worker", wantRole: "worker", wantOptions: "node-role.kubernetes.io/worker", want: []string{"worker-0", "worker-1", "infra-0"}},
+		{role: "Infra", wantRole: "infra", wantOptions: "node-role.kubernetes.io/infra", want: []string{"infra-0"}},
+		{role: "master", wantRole: "master", want: []string{"master-0", "cp-1"}},
+		{role: "control-plane", wantRole: "master", want: []string{"master-0", "cp-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			filter, err := NewNodeFilter("", "", tt.role)
+			if err != nil {
+				t.Fatalf("NewNodeFilter() error = %v", err)
+			}
+			if filter.Role != tt.wantRole {
+				t.Errorf("Role = %q, want %q", filter.Role, tt.wantRole)
+			}
+			if got := filter.ListOptions().LabelSelector; got != tt.wantOptions {
+				t.Errorf("ListOptions().LabelSelector = %q, want %q", got, tt.wantOptions)
+			}
+			var matched []string
+			for _, node := range filterNodes {
+				if filter.Matches(node) {
+					matched = append(matched, node.Name)
+				}
+			}
+			if !slices.Equal(matched, tt.want) {
+				t.Errorf("matched %v, want %v", matched, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewNodeFilter_Invalid(t *testing.T) {
+	tests := []struct {
+		name                            string
+		labelSelector, namePrefix, role string
+	}{
+		{name: "unparsable selector", labelSelector: "zone in (a"},
+		{name: "invalid label key", labelSelector: "-bad-=x"},
+		{name: "unknown role", role: "gpu"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewNodeFilter(tt.labelSelector, tt.namePrefix, tt.role); !errors.Is(err, ErrInvalidNodeFilter) {
+				t.Errorf("NewNodeFilter() error = %v, want ErrInvalidNodeFilter", err)
+			}
+		})
+	}
+}
+
+func TestNodeFilter_Combined(t *testing.T) {
+	filter, err := NewNodeFilter("topology.kubernetes.io/zone=b", "worker-", "worker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := filter.ListOptions().LabelSelector, "node-role.kubernetes.io/worker,topology.kubernetes.io/zone=b"; got != want {
+		t.Errorf("ListOptions().LabelSelector = %q, want %q", got, want)
+	}
+	if got, want := filter.String(), "label_selector=topology.kubernetes.io/zone=b,name_prefix=worker-,role=worker"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	var zero NodeFilter
+	if !zero.IsZero() || zero.String() != "" || zero.ListOptions().LabelSelector != "" || !zero.Matches(filterNodes[0]) {
+		t.Error("the zero filter should select every node")
+	}
+}
+
+func TestListNodesMatching(t *testing.T) {
+	var objects []runtime.Object
+	for _, node := range filterNodes {
+		objects = append(objects, node)
+	}
+	clientset := fake.NewClientset(objects...)
+	client := NewK8sClientWithClientset(clientset)
+
+	filter, err := NewNodeFilter("topology.kubernetes.io/zone=a", "", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := client.ListNodesMatching(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("ListNodesMatching() error = %v", err)
+	}
+	if len(nodes.Items) != 1 || nodes.Items[0].Name != "master-0" {
+		t.Errorf("ListNodesMatching() = %v, want master-0 only", nodes.Items)
+	}
+
+	// The label selector went to the API server
+	list := clientset.Actions()[0].(k8stesting.ListActionImpl)
+	if got := list.GetListRestrictions().Labels.String(); got != "topology.kubernetes.io/zone=a" {
+		t.Errorf("label selector sent = %q, want topology.kubernetes.io/zone=a", got)
+	}
+}
@@ -0,0 +1,113 @@
+package clients
+
+import (
+	"context"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ownerCacheSize bounds the intermediate owners an OwnerResolver remembers;
+// the cache starts over once it is full
+const ownerCacheSize = 1024
+
+// Workload identifies the top-level controller a pod belongs to. Pods without
+// a controller are their own workload, of kind Pod.
+type Workload struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// OwnerResolver resolves pods to their workload by walking controller
+// ownerReferences: Pod→ReplicaSet→Deployment and Pod→Job→CronJob, while
+// DaemonSet, StatefulSet and other controllers own their pods directly.
+// The controllers of ReplicaSets and Jobs are cached, as they do not change
+// for the lifetime of the object. It is safe for concurrent use.
+type OwnerResolver struct {
+	clientset kubernetes.Interface
+
+	mu     sync.Mutex
+	owners map[ownerKey]*metav1.OwnerReference // Controller of a ReplicaSet or Job (nil = none)
+}
+
+// ownerKey identifies a ReplicaSet or Job. The UID tells apart objects
+// recreated under the same name.
+type ownerKey struct {
+	kind, namespace, name string
+	uid                   types.UID
+}
+
+// NewOwnerResolver creates a resolver that reads ReplicaSets and Jobs through clientset
+func NewOwnerResolver(clientset kubernetes.Interface) *OwnerResolver {
+	return &OwnerResolver{
+		clientset: clientset,
+		owners:    make(map[ownerKey]*metav1.OwnerReference),
+	}
+}
+
+// Resolve returns the workload of the object with the given metadata,
+// usually a pod. When an intermediate ReplicaSet or Job cannot be read (it
+// was deleted, or access is denied), it is reported as the workload itself.
+func (r *OwnerResolver) Resolve(ctx context.Context, object metav1.Object) Workload {
+	namespace := object.GetNamespace()
+	controller := metav1.GetControllerOfNoCopy(object)
+	if controller == nil {
+		return Workload{Kind: "Pod", Namespace: namespace, Name: object.GetName()}
+	}
+
+	workload := Workload{Kind: controller.Kind, Namespace: namespace, Name: controller.Name}
+	if controller.Kind != "ReplicaSet" && controller.Kind != "Job" {
+		return workload
+	}
+	if parent := r.controllerOf(ctx, namespace, controller); parent != nil {
+		workload = Workload{Kind: parent.Kind, Namespace: namespace, Name: parent.Name}
+	}
+	return workload
+}
+
+// controllerOf returns the controller of the ReplicaSet or Job ref points
+// to, or nil when it has none or cannot be read. Only successful reads are
+// cached, so a transient error is retried on the next call.
+func (r *OwnerResolver) controllerOf(ctx context.Context, namespace string, ref *metav1.OwnerReference) *metav1.OwnerReference {
+	key := ownerKey{kind: ref.Kind, namespace: namespace, name: ref.Name, uid: ref.UID}
+	r.mu.Lock()
+	parent, found := r.owners[key]
+	r.mu.Unlock()
+	if found {
+		return parent
+	}
+
+	var object metav1.Object
+	switch ref.Kind {
+	case "ReplicaSet":
+		replicaSet, err := r.clientset.AppsV1().ReplicaSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		object = replicaSet
+	case "Job":
+		job, err := r.clientset.BatchV1().Jobs(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil
+		}
+		object = job
+	default:
+		return nil
+	}
+	// A different UID is a newer object of the same name, not the pod's owner
+	if object.GetUID() != ref.UID {
+		return nil
+	}
+
+	parent = metav1.GetControllerOf(object)
+	r.mu.Lock()
+	if len(r.owners) >= ownerCacheSize {
+		clear(r.owners)
+	}
+	r.owners[key] = parent
+	r.mu.Unlock()
+	return parent
+}
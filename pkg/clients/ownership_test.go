@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// controllerRef returns a controller ownerReference to kind/name
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, UID: types.UID(kind + "-" + name), Controller: &controller}}
+}
+
+// ownedMeta returns the metadata of an object in shop owned by the given controller
+func ownedMeta(name string, owners []metav1.OwnerReference) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Namespace: "shop", Name: name, OwnerReferences: owners}
+}
+
+func TestOwnerResolver_Resolve(t *testing.T) {
+	objects := []runtime.Object{
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: "web-7d9f", UID: "ReplicaSet-web-7d9f", OwnerReferences: controllerRef("Deployment", "web"),
+		}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "standalone", UID: "ReplicaSet-standalone"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: "report-2910", UID: "Job-report-2910", OwnerReferences: controllerRef("CronJob", "report"),
+		}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "migrate", UID: "Job-migrate"}},
+		// Recreated under the same name after the pod's ReplicaSet was deleted
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: "api-5c4b", UID: "newer", OwnerReferences: controllerRef("Deployment", "api-v2"),
+		}},
+	}
+	resolver := NewOwnerResolver(fake.NewClientset(objects...))
+
+	notController := []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-7d9f"}}
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want Workload
+	}{
+		{"deployment", &corev1.Pod{ObjectMeta: ownedMeta("web-7d9f-abcde", controllerRef("ReplicaSet", "web-7d9f"))}, Workload{"Deployment", "shop", "web"}},
+		{"replicaset without deployment", &corev1.Pod{ObjectMeta: ownedMeta("standalone-x1", controllerRef("ReplicaSet", "standalone"))}, Workload{"ReplicaSet", "shop", "standalone"}},
+		{"cronjob", &corev1.Pod{ObjectMeta: ownedMeta("report-2910-q8z", controllerRef("Job", "report-2910"))}, Workload{"CronJob", "shop", "report"}},
+		{"job without cronjob", &corev1.Pod{ObjectMeta: ownedMeta("migrate-k2m", controllerRef("Job", "migrate"))}, Workload{"Job", "shop", "migrate"}},
+		{"daemonset", &corev1.Pod{ObjectMeta: ownedMeta("agent-p4t", controllerRef("DaemonSet", "agent"))}, Workload{"DaemonSet", "shop", "agent"}},
+		{"statefulset", &corev1.Pod{ObjectMeta: ownedMeta("db-0", controllerRef("StatefulSet", "db"))}, Workload{"StatefulSet", "shop", "db"}},
+		{"orphan", &corev1.Pod{ObjectMeta: ownedMeta("debug", nil)}, Workload{"Pod", "shop", "debug"}},
+		{"owner that is not the controller", &corev1.Pod{ObjectMeta: ownedMeta("adopted", notController)}, Workload{"Pod", "shop", "adopted"}},
+		{"deleted replicaset", &corev1.Pod{ObjectMeta: ownedMeta("gone-7f-abc", controllerRef("ReplicaSet", "gone-7f"))}, Workload{"ReplicaSet", "shop", "gone-7f"}},
+		{"replicaset recreated with another UID", &corev1.Pod{ObjectMeta: ownedMeta("api-5c4b-abc", controllerRef("ReplicaSet", "api-5c4b"))}, Workload{"ReplicaSet", "shop", "api-5c4b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolver.Resolve(context.Background(), tt.pod); got != tt.want {
+				t.Errorf("Resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOwnerResolver_CachesOwners(t *testing.T) {
+	clientset := fake.NewClientset(&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "shop", Name: "web-7d9f", UID: "ReplicaSet-web-7d9f", OwnerReferences: controllerRef("Deployment", "web"),
+	}})
+	gets := 0
+	clientset.PrependReactor("get", "replicasets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		gets++
+		return false, nil, nil
+	})
+	resolver := NewOwnerResolver(clientset)
+
+	for _, name := range []string{"web-7d9f-a", "web-7d9f-b", "web-7d9f-c"} {
+		pod := &corev1.Pod{ObjectMeta: ownedMeta(name, controllerRef("ReplicaSet", "web-7d9f"))}
+		if got := resolver.Resolve(context.Background(), pod); got.Kind != "Deployment" || got.Name != "web" {
+			t.Fatalf("Resolve(%s) = %+v, want Deployment web", name, got)
+		}
+	}
+	if gets != 1 {
+		t.Errorf("expected the ReplicaSet to be read once, got %d reads", gets)
+	}
+}
@@ -0,0 +1,65 @@
+package clients
+
+import (
+	"context"
+	"maps"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ProjectAPIGroup serves OpenShift Projects, the view of namespaces that
+// OpenShift users create and recognize
+const ProjectAPIGroup = "project.openshift.io"
+
+var projectsGVR = schema.GroupVersionResource{Group: ProjectAPIGroup, Version: "v1", Resource: "projects"}
+
+// Annotations OpenShift keeps on a Project when it is requested, e.g. with
+// oc new-project --display-name
+const (
+	ProjectDisplayNameAnnotation = "openshift.io/display-name"
+	ProjectDescriptionAnnotation = "openshift.io/description"
+	ProjectRequesterAnnotation   = "openshift.io/requester"
+)
+
+// ProjectInfo is the Project metadata of a namespace; all of it is empty for
+// plain namespaces
+type ProjectInfo struct {
+	DisplayName string `json:"display_name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Requester   string `json:"requester,omitempty"` // The user who requested the project
+}
+
+// Label renders namespace the way people know it, "Payments Team
+// (payments-prod)", or as the bare name without a display name
+func (p ProjectInfo) Label(namespace string) string {
+	if p.DisplayName == "" || p.DisplayName == namespace {
+		return namespace
+	}
+	return p.DisplayName + " (" + namespace + ")"
+}
+
+// ListProjects returns the Project metadata of every project, keyed by
+// namespace name. On clusters that do not serve project.openshift.io it
+// returns nil and no error, and callers show plain namespaces.
+func (c *K8sClient) ListProjects(ctx context.Context) (map[string]ProjectInfo, error) {
+	if c.dynamicClient == nil || !c.DiscoverGroup(ProjectAPIGroup) {
+		return nil, nil
+	}
+	return cachedRead(ctx, "projects", func() (map[string]ProjectInfo, error) {
+		list, err := c.ListUnstructured(ctx, projectsGVR, "", metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		projects := make(map[string]ProjectInfo, len(list.Items))
+		for _, project := range list.Items {
+			annotations := project.GetAnnotations()
+			projects[project.GetName()] = ProjectInfo{
+				DisplayName: annotations[ProjectDisplayNameAnnotation],
+				Description: annotations[ProjectDescriptionAnnotation],
+				Requester:   annotations[ProjectRequesterAnnotation],
+			}
+		}
+		return projects, nil
+	}, maps.Clone)
+}
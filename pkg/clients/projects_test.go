@@ -0,0 +1,70 @@
+package clients
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestProject(name string, annotations map[string]string) *unstructured.Unstructured {
+	project := &unstructured.Unstructured{}
+	project.SetAPIVersion("project.openshift.io/v1")
+	project.SetKind("Project")
+	project.SetName(name)
+	project.SetAnnotations(annotations)
+	return project
+}
+
+// newProjectsClient serves two projects; openshift sets whether discovery
+// reports the project API group
+func newProjectsClient(openshift bool) *K8sClient {
+	clientset := fake.NewClientset()
+	clientset.Resources = []*metav1.APIResourceList{{GroupVersion: "apps/v1"}}
+	if openshift {
+		clientset.Resources = append(clientset.Resources, &metav1.APIResourceList{GroupVersion: "project.openshift.io/v1"})
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{projectsGVR: "ProjectList"},
+		newTestProject("payments-prod", map[string]string{
+			ProjectDisplayNameAnnotation: "Payments Team",
+			ProjectDescriptionAnnotation: "Card payments, production",
+			ProjectRequesterAnnotation:   "alice",
+		}),
+		newTestProject("scratch", nil),
+	)
+	return NewK8sClientWithClients(clientset, dynamicClient, nil)
+}
+
+func TestK8sClient_ListProjects(t *testing.T) {
+	projects, err := newProjectsClient(true).ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("ListProjects() returned %d projects, want 2", len(projects))
+	}
+	payments := projects["payments-prod"]
+	want := ProjectInfo{DisplayName: "Payments Team", Description: "Card payments, production", Requester: "alice"}
+	if payments != want {
+		t.Errorf("ListProjects()[payments-prod] = %+v, want %+v", payments, want)
+	}
+	if got := payments.Label("payments-prod"); got != "Payments Team (payments-prod)" {
+		t.Errorf("Label() = %q", got)
+	}
+	if got := projects["scratch"].Label("scratch"); got != "scratch" {
+		t.Errorf("Label() without a display name = %q, want the namespace", got)
+	}
+}
+
+func TestK8sClient_ListProjects_Kubernetes(t *testing.T) {
+	projects, err := newProjectsClient(false).ListProjects(context.Background())
+	if err != nil || projects != nil {
+		t.Errorf("ListProjects() on vanilla Kubernetes = %v, %v; want nil, nil", projects, err)
+	}
+}
@@ -0,0 +1,201 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
+)
+
+// PrometheusClient runs PromQL queries against the Prometheus HTTP API
+type PrometheusClient struct {
+	baseURL    string
+	httpClient *http.Client
+	restConfig *rest.Config // Source of the bearer token (service account or kubeconfig)
+}
+
+// PrometheusConfig holds configuration for the Prometheus client
+type PrometheusConfig struct {
+	URL        string
+	Timeout    time.Duration
+	RestConfig *rest.Config // Kubernetes rest config whose bearer token is sent to Prometheus
+}
+
+// NewPrometheusClient creates a new Prometheus client. The OpenShift service CA is
+// trusted in addition to the system roots when it is mounted.
+func NewPrometheusClient(config PrometheusConfig) *PrometheusClient {
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	// Without extra CA files the transport cannot fail; nil falls back to the default
+	transport, _ := newCATransport()
+
+	return &PrometheusClient{
+		baseURL: strings.TrimSuffix(config.URL, "/"),
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: tracing.WrapTransport(transport),
+		},
+		restConfig: config.RestConfig,
+	}
+}
+
+// PromSample is one series of an instant vector
+type PromSample struct {
+	Labels map[string]string `json:"labels"`
+	Value  float64           `json:"value"`
+}
+
+// PromPoint is one timestamped value of a range vector series
+type PromPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// PromSeries is one series of a range vector
+type PromSeries struct {
+	Labels map[string]string `json:"labels"`
+	Points []PromPoint       `json:"points"`
+}
+
+// promResponse is the Prometheus HTTP API envelope for instant and range queries
+type promResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  [2]interface{}    `json:"value"`
+			Values [][2]interface{}  `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// Query evaluates an instant PromQL query at the current time and returns its vector.
+// Samples whose value is NaN or cannot be parsed are dropped.
+func (c *PrometheusClient) Query(ctx context.Context, query string) ([]PromSample, error) {
+	result, err := c.get(ctx, "/api/v1/query", url.Values{"query": {query}})
+	if err != nil {
+		return nil, err
+	}
+	if result.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("prometheus returned %s, expected an instant vector", result.Data.ResultType)
+	}
+
+	samples := make([]PromSample, 0, len(result.Data.Result))
+	for _, series := range result.Data.Result {
+		if _, value, ok := parsePromValue(series.Value); ok {
+			samples = append(samples, PromSample{Labels: series.Metric, Value: value})
+		}
+	}
+	return samples, nil
+}
+
+// QueryRange evaluates a PromQL query over [start, end] at the given step and returns
+// its matrix. Points whose value is NaN or cannot be parsed are dropped.
+func (c *PrometheusClient) QueryRange(ctx context.Context, query string, start, end time.Time, step time.Duration) ([]PromSeries, error) {
+	result, err := c.get(ctx, "/api/v1/query_range", url.Values{
+		"query": {query},
+		"start": {strconv.FormatInt(start.Unix(), 10)},
+		"end":   {strconv.FormatInt(end.Unix(), 10)},
+		"step":  {strconv.FormatFloat(step.Seconds(), 'f', -1, 64)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("prometheus returned %s, expected a range vector", result.Data.ResultType)
+	}
+
+	matrix := make([]PromSeries, 0, len(result.Data.Result))
+	for _, series := range result.Data.Result {
+		points := make([]PromPoint, 0, len(series.Values))
+		for _, raw := range series.Values {
+			if ts, value, ok := parsePromValue(raw); ok {
+				points = append(points, PromPoint{Time: ts, Value: value})
+			}
+		}
+		matrix = append(matrix, PromSeries{Labels: series.Metric, Points: points})
+	}
+	return matrix, nil
+}
+
+// get calls a Prometheus HTTP API endpoint and decodes a successful envelope
+func (c *PrometheusClient) get(ctx context.Context, path string, params url.Values) (*promResponse, error) {
+	endpoint := c.baseURL + path + "?" + params.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Prometheus request: %w", err)
+	}
+	if token := c.bearerToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Prometheus response: %w", err)
+	}
+
+	var result promResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("prometheus returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed (%s): %s", result.ErrorType, result.Error)
+	}
+	return &result, nil
+}
+
+// parsePromValue decodes a [timestamp, "value"] pair, rejecting NaN
+func parsePromValue(pair [2]interface{}) (time.Time, float64, bool) {
+	raw, ok := pair[1].(string)
+	if !ok {
+		return time.Time{}, 0, false
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) {
+		return time.Time{}, 0, false
+	}
+	seconds, _ := pair[0].(float64)
+	return time.Unix(0, int64(seconds*float64(time.Second))), value, true
+}
+
+// bearerToken returns the token from the rest config
+func (c *PrometheusClient) bearerToken() string {
+	return restConfigBearerToken(c.restConfig)
+}
+
+// restConfigBearerToken returns the token of a rest config, re-reading token
+// files so rotated service account tokens are picked up
+func restConfigBearerToken(restConfig *rest.Config) string {
+	if restConfig == nil {
+		return ""
+	}
+	if restConfig.BearerTokenFile != "" {
+		if token, err := os.ReadFile(restConfig.BearerTokenFile); err == nil {
+			return strings.TrimSpace(string(token))
+		}
+	}
+	return restConfig.BearerToken
+}
@@ -0,0 +1,134 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestPrometheusClient_Query(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("sa-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotQuery, gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+		gotQuery = r.URL.Query().Get("query")
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+			{"metric":{"priority_level":"workload-low"},"value":[1717243200,"12.5"]},
+			{"metric":{"priority_level":"global-default"},"value":[1717243200,"NaN"]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := NewPrometheusClient(PrometheusConfig{
+		URL:        server.URL + "/",
+		RestConfig: &rest.Config{BearerTokenFile: tokenFile},
+	})
+	samples, err := client.Query(context.Background(), `sum by (priority_level) (up)`)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+
+	if gotQuery != `sum by (priority_level) (up)` {
+		t.Errorf("query = %q", gotQuery)
+	}
+	if gotAuth != "Bearer sa-token" {
+		t.Errorf("Authorization = %q, want bearer token from the token file", gotAuth)
+	}
+	if len(samples) != 1 {
+		t.Fatalf("got %d samples, want 1 (NaN dropped)", len(samples))
+	}
+	if samples[0].Labels["priority_level"] != "workload-low" || samples[0].Value != 12.5 {
+		t.Errorf("sample = %+v", samples[0])
+	}
+}
+
+func TestPrometheusClient_QueryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "query error",
+			status:  http.StatusBadRequest,
+			body:    `{"status":"error","errorType":"bad_data","error":"parse error at char 4"}`,
+			wantErr: "prometheus query failed (bad_data): parse error at char 4",
+		},
+		{
+			name:    "not JSON",
+			status:  http.StatusForbidden,
+			body:    "Forbidden",
+			wantErr: "prometheus returned status 403: Forbidden",
+		},
+		{
+			name:    "range result",
+			status:  http.StatusOK,
+			body:    `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			wantErr: "expected an instant vector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewPrometheusClient(PrometheusConfig{URL: server.URL}).Query(context.Background(), "up")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Query() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrometheusClient_QueryRange(t *testing.T) {
+	var gotParams url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query_range" {
+			http.NotFound(w, r)
+			return
+		}
+		gotParams = r.URL.Query()
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"node":"worker-0"},"values":[[1717243200,"0.5"],[1717246800,"NaN"],[1717250400,"0.75"]]}
+		]}}`))
+	}))
+	defer server.Close()
+
+	start := time.Unix(1717243200, 0)
+	series, err := NewPrometheusClient(PrometheusConfig{URL: server.URL}).
+		QueryRange(context.Background(), "up", start, start.Add(2*time.Hour), time.Hour)
+	if err != nil {
+		t.Fatalf("QueryRange() error = %v", err)
+	}
+
+	if gotParams.Get("start") != "1717243200" || gotParams.Get("end") != "1717250400" || gotParams.Get("step") != "3600" {
+		t.Errorf("params = %v", gotParams)
+	}
+	if len(series) != 1 || len(series[0].Points) != 2 {
+		t.Fatalf("series = %+v, want one series with 2 points (NaN dropped)", series)
+	}
+	if series[0].Labels["node"] != "worker-0" || !series[0].Points[1].Time.Equal(start.Add(2*time.Hour)) || series[0].Points[1].Value != 0.75 {
+		t.Errorf("series = %+v", series[0])
+	}
+}
@@ -0,0 +1,111 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// IsConnectivityError reports whether err means the API server could not be
+// reached at all: refused or reset connections, DNS failures, unreachable
+// networks and timeouts, as opposed to errors the server answered with
+func IsConnectivityError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)
+}
+
+// APIReachability describes the current run of GetClusterHealth calls that
+// could not reach the API server
+type APIReachability struct {
+	ConsecutiveFailures int       // Zero while the API server is reachable
+	UnreachableSince    time.Time // Time of the first failure of the run
+	LastError           string
+}
+
+// Unreachable reports whether the last GetClusterHealth call could not reach the API server
+func (r APIReachability) Unreachable() bool {
+	return r.ConsecutiveFailures > 0
+}
+
+// Describe summarizes the outage as of now, e.g. "API server unreachable
+// for 5m (3 consecutive failures)"
+func (r APIReachability) Describe(now time.Time) string {
+	failures := "1 failure"
+	if r.ConsecutiveFailures != 1 {
+		failures = fmt.Sprintf("%d consecutive failures", r.ConsecutiveFailures)
+	}
+	return fmt.Sprintf("API server unreachable for %s (%s)", outageDuration(now.Sub(r.UnreachableSince)), failures)
+}
+
+// outageDuration renders d to the second below a minute and to the minute
+// above, e.g. "42s", "5m" or "1h5m"
+func outageDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Truncate(time.Second).String()
+	}
+	return strings.TrimSuffix(d.Truncate(time.Minute).String(), "0s")
+}
+
+// reachabilityTracker follows the outcome of GetClusterHealth calls
+type reachabilityTracker struct {
+	mu    sync.Mutex
+	state APIReachability
+}
+
+// observe records the outcome of a call at now. Errors other than
+// connectivity errors prove the API server answered; canceled calls prove
+// nothing and are ignored.
+func (t *reachabilityTracker) observe(err error, now time.Time) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !IsConnectivityError(err) {
+		t.state = APIReachability{}
+		return
+	}
+	if t.state.ConsecutiveFailures == 0 {
+		t.state.UnreachableSince = now
+	}
+	t.state.ConsecutiveFailures++
+	t.state.LastError = err.Error()
+}
+
+// APIReachability returns whether the last GetClusterHealth calls reached
+// the API server, and since when they have not
+func (c *K8sClient) APIReachability() APIReachability {
+	c.reachability.mu.Lock()
+	defer c.reachability.mu.Unlock()
+	return c.reachability.state
+}
+
+// LastKnownHealth is the most recent complete cluster health reading of the
+// server's health history, served while the API server cannot be reached
+type LastKnownHealth struct {
+	Timestamp     time.Time `json:"timestamp"`
+	Status        string    `json:"status"`
+	NodesTotal    int       `json:"nodes_total"`
+	NodesReady    int       `json:"nodes_ready"`
+	NodesNotReady int       `json:"nodes_not_ready"`
+	PodsTotal     int       `json:"pods_total"`
+	PodsRunning   int       `json:"pods_running"`
+	PodsPending   int       `json:"pods_pending"`
+	PodsFailed    int       `json:"pods_failed"`
+	PodsSucceeded int       `json:"pods_succeeded"`
+
+	Reasons []HealthReason `json:"reasons,omitempty"` // Why the status was what it was
+}
+
+// LastKnownHealthLookup returns the last known cluster health, and false when
+// there is none (e.g. the health history is disabled or still empty)
+type LastKnownHealthLookup func() (LastKnownHealth, bool)
@@ -0,0 +1,70 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsConnectivityError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "dial failure", err: fmt.Errorf("list nodes: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused")}), want: true},
+		{name: "dns failure", err: &net.DNSError{Err: "no such host", Name: "api.cluster.local", IsNotFound: true}, want: true},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: true},
+		{name: "canceled", err: context.Canceled},
+		{name: "forbidden", err: apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "", errors.New("denied"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsConnectivityError(tt.err); got != tt.want {
+				t.Errorf("IsConnectivityError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReachabilityTracker(t *testing.T) {
+	start := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+	unreachable := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: no route to host")}
+	var tracker reachabilityTracker
+
+	tracker.observe(nil, start)
+	tracker.observe(unreachable, start.Add(time.Minute))
+	tracker.observe(context.Canceled, start.Add(2*time.Minute))
+	tracker.observe(unreachable, start.Add(3*time.Minute))
+	state := tracker.state
+	if state.ConsecutiveFailures != 2 || !state.UnreachableSince.Equal(start.Add(time.Minute)) {
+		t.Fatalf("state = %+v, want 2 failures since the first one", state)
+	}
+	if got, want := state.Describe(start.Add(6*time.Minute+30*time.Second)), "API server unreachable for 5m (2 consecutive failures)"; got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+
+	tracker.observe(apierrors.NewServiceUnavailable("etcd leader changed"), start.Add(4*time.Minute))
+	if tracker.state.Unreachable() {
+		t.Error("an answer from the API server ends the outage")
+	}
+}
+
+func TestOutageDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		42*time.Second + 300*time.Millisecond: "42s",
+		5*time.Minute + 59*time.Second:        "5m",
+		65 * time.Minute:                      "1h5m",
+		2 * time.Hour:                         "2h0m",
+	} {
+		if got := outageDuration(d); got != want {
+			t.Errorf("outageDuration(%s) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package clients
+
+import (
+	"context"
+	"sync"
+)
+
+// requestCache memoizes K8sClient reads for the lifetime of one tool call, so
+// that helpers of a composite tool reading the same list share one API call.
+// It has no expiry: it is dropped with the context it is attached to, and is
+// unrelated to the shared MemoryCache. Failed reads are not kept, so a later
+// helper (or a retry) reads again.
+type requestCache struct {
+	mu      sync.Mutex
+	entries map[string]*requestCacheEntry
+}
+
+// requestCacheEntry is one read, done once its done channel is closed
+type requestCacheEntry struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+type requestCacheKey struct{}
+
+// WithRequestCache returns a context under which K8sClient reads are made at
+// most once per operation and parameters. The dispatcher attaches a new one
+// to every tool call; it must not be shared between calls.
+func WithRequestCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, requestCacheKey{}, &requestCache{entries: make(map[string]*requestCacheEntry)})
+}
+
+// requestCacheFrom returns the request cache of ctx, nil when reads are not cached
+func requestCacheFrom(ctx context.Context) *requestCache {
+	cache, _ := ctx.Value(requestCacheKey{}).(*requestCache)
+	return cache
+}
+
+// cachedRead returns the result of read under key, reading only on the first
+// call within the request. Concurrent calls for a key wait for the first one.
+// Every caller gets its own deep copy, so callers may modify what they get.
+func cachedRead[T any](ctx context.Context, key string, read func() (T, error), deepCopy func(T) T) (T, error) {
+	cache := requestCacheFrom(ctx)
+	if cache == nil {
+		return read()
+	}
+
+	cache.mu.Lock()
+	entry, found := cache.entries[key]
+	if !found {
+		entry = &requestCacheEntry{done: make(chan struct{})}
+		cache.entries[key] = entry
+	}
+	cache.mu.Unlock()
+
+	if !found {
+		entry.value, entry.err = read()
+		if entry.err != nil {
+			cache.mu.Lock()
+			delete(cache.entries, key)
+			cache.mu.Unlock()
+		}
+		close(entry.done)
+	} else {
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+
+	if entry.err != nil {
+		var zero T
+		return zero, entry.err
+	}
+	return deepCopy(entry.value.(T)), nil
+}
@@ -0,0 +1,126 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// countListActions returns how many list calls of resource the fake clientset received
+func countListActions(clientset *fake.Clientset, resource string) int {
+	count := 0
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "list" && action.GetResource().Resource == resource {
+			count++
+		}
+	}
+	return count
+}
+
+func newRequestCacheClientset() *fake.Clientset {
+	return fake.NewClientset(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"}},
+	)
+}
+
+func TestRequestCache_OneReadPerRequest(t *testing.T) {
+	clientset := newRequestCacheClientset()
+	client := NewK8sClientWithClientset(clientset)
+	ctx := WithRequestCache(context.Background())
+
+	for i := 0; i < 3; i++ {
+		pods, err := client.ListPods(ctx, "shop")
+		if err != nil {
+			t.Fatalf("ListPods() error = %v", err)
+		}
+		if len(pods.Items) != 2 {
+			t.Fatalf("ListPods() returned %d pods, want 2", len(pods.Items))
+		}
+		// Callers get copies: changing one result does not change the next
+		pods.Items = pods.Items[:0]
+	}
+	if got := countListActions(clientset, "pods"); got != 1 {
+		t.Errorf("pods listed %d times, want 1", got)
+	}
+
+	// Other parameters are other reads
+	if _, err := client.ListPods(ctx, ""); err != nil {
+		t.Fatalf("ListPods() error = %v", err)
+	}
+	if got := countListActions(clientset, "pods"); got != 2 {
+		t.Errorf("pods listed %d times after listing all namespaces, want 2", got)
+	}
+}
+
+func TestRequestCache_NotSharedBetweenRequests(t *testing.T) {
+	clientset := newRequestCacheClientset()
+	client := NewK8sClientWithClientset(clientset)
+
+	for _, ctx := range []context.Context{
+		WithRequestCache(context.Background()),
+		WithRequestCache(context.Background()),
+		context.Background(), // No cache: every call reads
+		context.Background(),
+	} {
+		if _, err := client.ListPods(ctx, "shop"); err != nil {
+			t.Fatalf("ListPods() error = %v", err)
+		}
+	}
+	if got := countListActions(clientset, "pods"); got != 4 {
+		t.Errorf("pods listed %d times, want 4", got)
+	}
+}
+
+func TestRequestCache_FailedReadsAreRetried(t *testing.T) {
+	clientset := newRequestCacheClientset()
+	failures := 1
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if failures > 0 {
+			failures--
+			return true, nil, errors.New("connection refused")
+		}
+		return false, nil, nil
+	})
+	client := NewK8sClientWithClientset(clientset)
+	ctx := WithRequestCache(context.Background())
+
+	if _, err := client.ListPods(ctx, "shop"); err == nil {
+		t.Fatal("ListPods() error = nil, want the injected failure")
+	}
+	pods, err := client.ListPods(ctx, "shop")
+	if err != nil {
+		t.Fatalf("ListPods() after a failure error = %v", err)
+	}
+	if len(pods.Items) != 2 {
+		t.Errorf("ListPods() returned %d pods, want 2", len(pods.Items))
+	}
+}
+
+func TestRequestCache_ConcurrentReadsShareOneCall(t *testing.T) {
+	clientset := newRequestCacheClientset()
+	client := NewK8sClientWithClientset(clientset)
+	ctx := WithRequestCache(context.Background())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.ListNodes(ctx); err != nil {
+				t.Errorf("ListNodes() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := countListActions(clientset, "nodes"); got != 1 {
+		t.Errorf("nodes listed %d times, want 1", got)
+	}
+}
@@ -2,10 +2,15 @@ package clients
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"net"
+	"net/http"
+	"syscall"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/clock"
 )
 
 // RetryConfig defines retry behavior
@@ -14,6 +19,7 @@ type RetryConfig struct {
 	InitialBackoff time.Duration // Initial backoff duration
 	MaxBackoff     time.Duration // Maximum backoff duration
 	Multiplier     float64       // Backoff multiplier
+	Clock          clock.Clock   // Times the backoff waits; nil uses the real clock
 }
 
 // DefaultRetryConfig returns sensible retry defaults
@@ -26,12 +32,19 @@ func DefaultRetryConfig() *RetryConfig {
 	}
 }
 
-// RetryWithBackoff retries a function with exponential backoff
+// RetryWithBackoff retries a function with exponential backoff. Rate limited
+// attempts wait at least as long as the server asks, up to MaxBackoff, and are
+// recorded in ctx's throttle log even when a later attempt succeeds.
 func RetryWithBackoff(ctx context.Context, cfg *RetryConfig, fn func() error) error {
 	if cfg == nil {
 		cfg = DefaultRetryConfig()
 	}
 
+	var clk clock.Clock = clock.RealClock{}
+	if cfg.Clock != nil {
+		clk = cfg.Clock
+	}
+
 	var lastErr error
 	backoff := cfg.InitialBackoff
 
@@ -43,6 +56,11 @@ func RetryWithBackoff(ctx context.Context, cfg *RetryConfig, fn func() error) er
 		}
 
 		lastErr = err
+		wait := backoff
+		if delay, throttled := throttleDelay(err); throttled {
+			RecordThrottle(ctx, max(delay, backoff))
+			wait = min(max(delay, backoff), max(cfg.MaxBackoff, backoff))
+		}
 
 		// Check if error is retryable
 		if !isRetryable(err) {
@@ -58,7 +76,7 @@ func RetryWithBackoff(ctx context.Context, cfg *RetryConfig, fn func() error) er
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("context cancelled after %d attempts: %w", attempt+1, ctx.Err())
-		case <-time.After(backoff):
+		case <-clk.After(wait):
 			// Increase backoff exponentially
 			backoff = time.Duration(float64(backoff) * cfg.Multiplier)
 			if backoff > cfg.MaxBackoff {
@@ -93,9 +111,56 @@ func isRetryable(err error) bool {
 		return true
 	}
 
-	// Temporary network errors (connection refused, etc.)
-	// These would need more sophisticated detection in production
-	return false
+	// Overloaded or restarting plain HTTP services (KServe predictors)
+	var statusErr *httpStatusError
+	if stderrors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	// Per-request timeouts and refused connections (e.g. a predictor pod restarting)
+	var netErr net.Error
+	if stderrors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return stderrors.Is(err, syscall.ECONNREFUSED)
+}
+
+// throttleDelay reports whether err is a rate limiting response, and the
+// delay the server asked for, zero when it gave none
+func throttleDelay(err error) (time.Duration, bool) {
+	if errors.IsTooManyRequests(err) {
+		seconds, _ := errors.SuggestsClientDelay(err)
+		return time.Duration(seconds) * time.Second, true
+	}
+	if status, ok := HTTPStatusCode(err); ok && status == http.StatusTooManyRequests {
+		return 0, true
+	}
+	return 0, false
+}
+
+// httpStatusError is an unexpected status from a service outside the Kubernetes API
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// HTTPStatusCode returns the status of an unexpected response from a service
+// outside the Kubernetes API, such as the Coordination Engine or a KServe
+// predictor, if err was caused by one
+func HTTPStatusCode(err error) (int, bool) {
+	var statusErr *httpStatusError
+	if stderrors.As(err, &statusErr) {
+		return statusErr.StatusCode, true
+	}
+	return 0, false
 }
 
 // WithRetry wraps a Kubernetes operation with retry logic
@@ -0,0 +1,211 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// throttleSamples bounds the rate limiter waits kept for ThrottleStats
+	throttleSamples = 4096
+	// throttledAfter is the wait above which a request counts as throttled; an
+	// unthrottled token bucket returns in microseconds
+	throttledAfter = 10 * time.Millisecond
+)
+
+// ThrottleStats summarizes recent client-side rate limiter waits
+type ThrottleStats struct {
+	QPS        float32
+	Burst      int
+	Window     time.Duration
+	Requests   int
+	Throttled  int // Requests that waited longer than 10ms
+	TotalWait  time.Duration
+	MaxWait    time.Duration
+	Overflowed bool // More requests than samples kept; counts are a lower bound
+}
+
+// throttleSample is one rate limiter wait
+type throttleSample struct {
+	at   time.Time
+	wait time.Duration
+}
+
+// throttleRecorder wraps the client's rate limiter and keeps a ring of recent waits,
+// so tools can tell an overloaded API server from a QPS setting that is too low
+type throttleRecorder struct {
+	flowcontrol.RateLimiter
+	burst int
+
+	mu      sync.Mutex
+	samples []throttleSample
+	next    int
+	full    bool
+	now     func() time.Time
+}
+
+// newThrottleRecorder wraps limiter; burst is only reported, not enforced here
+func newThrottleRecorder(limiter flowcontrol.RateLimiter, burst int) *throttleRecorder {
+	return &throttleRecorder{
+		RateLimiter: limiter,
+		burst:       burst,
+		samples:     make([]throttleSample, throttleSamples),
+		now:         time.Now,
+	}
+}
+
+// Wait blocks until the limiter admits a request and records how long that took
+func (r *throttleRecorder) Wait(ctx context.Context) error {
+	start := r.now()
+	err := r.RateLimiter.Wait(ctx)
+	wait := r.now().Sub(start)
+	r.record(start, wait)
+	if wait > throttledAfter {
+		RecordThrottle(ctx, wait)
+	}
+	return err
+}
+
+// Accept blocks until the limiter admits a request and records how long that took
+func (r *throttleRecorder) Accept() {
+	start := r.now()
+	r.RateLimiter.Accept()
+	r.record(start, r.now().Sub(start))
+}
+
+func (r *throttleRecorder) record(at time.Time, wait time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.samples[r.next] = throttleSample{at: at, wait: wait}
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// stats summarizes the waits recorded within window of now
+func (r *throttleRecorder) stats(window time.Duration) ThrottleStats {
+	stats := ThrottleStats{QPS: r.QPS(), Burst: r.burst, Window: window}
+	since := r.now().Add(-window)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	count := r.next
+	if r.full {
+		count = len(r.samples)
+	}
+	for i := 0; i < count; i++ {
+		sample := r.samples[i]
+		if sample.at.Before(since) {
+			continue
+		}
+		stats.Requests++
+		stats.TotalWait += sample.wait
+		if sample.wait > stats.MaxWait {
+			stats.MaxWait = sample.wait
+		}
+		if sample.wait > throttledAfter {
+			stats.Throttled++
+		}
+	}
+	// The oldest kept sample is still inside the window, so older ones were dropped
+	stats.Overflowed = r.full && !r.samples[r.next].at.Before(since)
+	return stats
+}
+
+// ThrottleStats reports client-side rate limiter waits over the last window.
+// Clients built around an existing clientset have no limiter and report zero values.
+func (c *K8sClient) ThrottleStats(window time.Duration) ThrottleStats {
+	if c.throttle == nil {
+		return ThrottleStats{Window: window}
+	}
+	return c.throttle.stats(window)
+}
+
+// ThrottleLog collects the rate limiting one tool call ran into: waits of
+// the client-side rate limiter and 429 responses from the API server or a
+// dependency. It is safe for concurrent use; a nil log reports nothing.
+type ThrottleLog struct {
+	mu         sync.Mutex
+	throttled  bool
+	retryAfter time.Duration
+}
+
+type throttleLogKey struct{}
+
+// WithThrottleLog returns a context whose client calls record the rate
+// limiting they run into in the returned log
+func WithThrottleLog(ctx context.Context) (context.Context, *ThrottleLog) {
+	log := &ThrottleLog{}
+	return context.WithValue(ctx, throttleLogKey{}, log), log
+}
+
+// RecordThrottle notes in ctx's throttle log, if any, that a request was rate
+// limited. delay is what the server asked for or how long the request was
+// held back, zero when unknown.
+func RecordThrottle(ctx context.Context, delay time.Duration) {
+	log, _ := ctx.Value(throttleLogKey{}).(*ThrottleLog)
+	if log == nil {
+		return
+	}
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	log.throttled = true
+	log.retryAfter = max(log.retryAfter, delay)
+}
+
+// Throttled reports whether any request of the call was rate limited
+func (l *ThrottleLog) Throttled() bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.throttled
+}
+
+// RetryAfter is the longest delay asked for or waited, zero when unknown
+func (l *ThrottleLog) RetryAfter() time.Duration {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.retryAfter
+}
+
+// recordThrottledResponse records a 429 response of a plain HTTP service,
+// with the delay of its Retry-After header when it gives one in seconds
+func recordThrottledResponse(ctx context.Context, resp *http.Response) {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		delay = time.Duration(seconds) * time.Second
+	}
+	RecordThrottle(ctx, delay)
+}
+
+// throttleTransport records the 429 responses of the API server
+type throttleTransport struct {
+	next http.RoundTripper
+}
+
+// recordThrottledResponses wraps an API server transport to record its 429s
+func recordThrottledResponses(next http.RoundTripper) http.RoundTripper {
+	return &throttleTransport{next: next}
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil {
+		recordThrottledResponse(req.Context(), resp)
+	}
+	return resp, err
+}
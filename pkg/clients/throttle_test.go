@@ -0,0 +1,207 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	testclock "k8s.io/utils/clock/testing"
+)
+
+// steppingLimiter advances a fake clock by the next configured wait on every admission
+type steppingLimiter struct {
+	flowcontrol.RateLimiter
+	clock *time.Time
+	waits []time.Duration
+}
+
+func (l *steppingLimiter) Wait(ctx context.Context) error {
+	*l.clock = l.clock.Add(l.waits[0])
+	l.waits = l.waits[1:]
+	return nil
+}
+
+func (l *steppingLimiter) Accept() { _ = l.Wait(context.Background()) }
+
+func (l *steppingLimiter) QPS() float32 { return 5 }
+
+func newSteppingRecorder(clock *time.Time, waits ...time.Duration) *throttleRecorder {
+	recorder := newThrottleRecorder(&steppingLimiter{clock: clock, waits: waits}, 10)
+	recorder.now = func() time.Time { return *clock }
+	return recorder
+}
+
+func TestThrottleRecorder_Stats(t *testing.T) {
+	clock := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder := newSteppingRecorder(&clock, time.Microsecond, 250*time.Millisecond, 10*time.Minute, 0, 2*time.Second)
+
+	for i := 0; i < 5; i++ {
+		if err := recorder.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+
+	// The first two waits started more than 5 minutes ago, before the 10 minute wait
+	stats := recorder.stats(5 * time.Minute)
+	if stats.QPS != 5 || stats.Burst != 10 {
+		t.Errorf("QPS/Burst = %v/%d, want 5/10", stats.QPS, stats.Burst)
+	}
+	if stats.Requests != 2 {
+		t.Errorf("Requests = %d, want 2", stats.Requests)
+	}
+	if stats.Throttled != 1 {
+		t.Errorf("Throttled = %d, want 1", stats.Throttled)
+	}
+	if stats.MaxWait != 2*time.Second {
+		t.Errorf("MaxWait = %v, want 2s", stats.MaxWait)
+	}
+
+	stats = recorder.stats(time.Hour)
+	if stats.Requests != 5 || stats.Throttled != 3 {
+		t.Errorf("Requests/Throttled = %d/%d, want 5/3", stats.Requests, stats.Throttled)
+	}
+	if stats.TotalWait != time.Microsecond+250*time.Millisecond+10*time.Minute+2*time.Second {
+		t.Errorf("TotalWait = %v", stats.TotalWait)
+	}
+	if stats.Overflowed {
+		t.Error("Overflowed = true, want false")
+	}
+}
+
+func TestThrottleRecorder_Overflow(t *testing.T) {
+	clock := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	waits := make([]time.Duration, throttleSamples+10)
+	recorder := newSteppingRecorder(&clock, waits...)
+
+	for range waits {
+		recorder.Accept()
+	}
+
+	stats := recorder.stats(time.Minute)
+	if stats.Requests != throttleSamples {
+		t.Errorf("Requests = %d, want %d", stats.Requests, throttleSamples)
+	}
+	if !stats.Overflowed {
+		t.Error("Overflowed = false, want true")
+	}
+}
+
+func TestK8sClient_ThrottleStatsWithoutLimiter(t *testing.T) {
+	client := NewK8sClientWithClientset(nil)
+
+	stats := client.ThrottleStats(time.Minute)
+	if stats.Requests != 0 || stats.Window != time.Minute {
+		t.Errorf("ThrottleStats() = %+v, want zero values with the window", stats)
+	}
+}
+
+func TestThrottleLog_LimiterWaits(t *testing.T) {
+	clock := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder := newSteppingRecorder(&clock, time.Microsecond, time.Microsecond, 2*time.Second, 300*time.Millisecond)
+
+	ctx, log := WithThrottleLog(context.Background())
+	_ = recorder.Wait(ctx)
+	if log.Throttled() {
+		t.Error("Throttled() = true after an immediate admission")
+	}
+	_ = recorder.Wait(context.Background()) // Another call's wait is not in this log
+	_ = recorder.Wait(context.Background())
+	if log.Throttled() {
+		t.Error("Throttled() = true after another call waited")
+	}
+	_ = recorder.Wait(ctx)
+	if !log.Throttled() || log.RetryAfter() != 300*time.Millisecond {
+		t.Errorf("Throttled(), RetryAfter() = %v, %v; want true, 300ms", log.Throttled(), log.RetryAfter())
+	}
+
+	var none *ThrottleLog
+	if none.Throttled() || none.RetryAfter() != 0 {
+		t.Error("A nil log should report nothing")
+	}
+}
+
+func TestRetryWithBackoff_RecordsThrottling(t *testing.T) {
+	ctx, log := WithThrottleLog(context.Background())
+	clk := testclock.NewFakeClock(time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	attempts := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- RetryWithBackoff(ctx, &RetryConfig{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Multiplier: 2, Clock: clk}, func() error {
+			attempts++
+			if attempts == 1 {
+				return apierrors.NewTooManyRequests("the server is busy", 3)
+			}
+			return nil
+		})
+	}()
+
+	// The server's 3s delay is capped at MaxBackoff
+	for !clk.HasWaiters() {
+		runtime.Gosched()
+	}
+	clk.Step(9 * time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("RetryWithBackoff() = %v before the backoff elapsed", err)
+	default:
+	}
+	clk.Step(time.Millisecond)
+	err := <-done
+	if err != nil || attempts != 2 {
+		t.Fatalf("RetryWithBackoff() = %v after %d attempts, want success after 2", err, attempts)
+	}
+	if !log.Throttled() || log.RetryAfter() != 3*time.Second {
+		t.Errorf("Throttled(), RetryAfter() = %v, %v; want true, 3s from the server", log.Throttled(), log.RetryAfter())
+	}
+}
+
+func TestCoordinationEngineClient_RecordsThrottling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, log := WithThrottleLog(context.Background())
+	if _, err := newTestCoordinationEngineClient(t, server.URL).GetIncident(ctx, "inc-42"); err == nil {
+		t.Fatal("GetIncident() error = nil for a 429")
+	}
+	if !log.Throttled() || log.RetryAfter() != 7*time.Second {
+		t.Errorf("Throttled(), RetryAfter() = %v, %v; want true, 7s", log.Throttled(), log.RetryAfter())
+	}
+}
+
+func TestRecordThrottledResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/busy" {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: recordThrottledResponses(http.DefaultTransport)}
+
+	get := func(ctx context.Context, path string) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("GET %s error = %v", path, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	ctx, log := WithThrottleLog(context.Background())
+	get(ctx, "/api")
+	if log.Throttled() {
+		t.Error("Throttled() = true after a 200")
+	}
+	get(ctx, "/busy")
+	if !log.Throttled() || log.RetryAfter() != 2*time.Second {
+		t.Errorf("Throttled(), RetryAfter() = %v, %v; want true, 2s", log.Throttled(), log.RetryAfter())
+	}
+}
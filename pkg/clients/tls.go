@@ -0,0 +1,44 @@
+package clients
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// serviceCAFile is the OpenShift service CA bundle mounted into every pod; it signs
+// the serving certificates of in-cluster services such as prometheus-k8s
+const serviceCAFile = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+
+// newCATransport returns a transport trusting the system roots, the OpenShift
+// service CA when it is mounted, and every PEM bundle in caFiles. It returns a
+// nil transport (the default) when there is nothing extra to trust.
+func newCATransport(caFiles ...string) (http.RoundTripper, error) {
+	serviceCA, serviceCAErr := os.ReadFile(serviceCAFile)
+	if serviceCAErr != nil && len(caFiles) == 0 {
+		return nil, nil
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if serviceCAErr == nil {
+		roots.AppendCertsFromPEM(serviceCA)
+	}
+	for _, file := range caFiles {
+		pem, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", file)
+		}
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	base.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+	return base, nil
+}
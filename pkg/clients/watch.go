@@ -0,0 +1,323 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchJitter is the fraction of the backoff added at random to each reconnect
+// delay, so watchers dropped together by an API server restart do not return together
+const watchJitter = 0.5
+
+// ListWatch lists and watches one resource. The options carry the resource
+// version and bookmark settings chosen by WatchHelper.
+type ListWatch struct {
+	List  func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error)
+	Watch func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+// ListWatchFor builds a ListWatch for any resource using the dynamic client.
+// An empty namespace watches a cluster-scoped resource or all namespaces.
+func (c *K8sClient) ListWatchFor(gvr schema.GroupVersionResource, namespace string, base metav1.ListOptions) ListWatch {
+	return ListWatch{
+		List: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			opts.LabelSelector, opts.FieldSelector = base.LabelSelector, base.FieldSelector
+			return c.ListUnstructured(ctx, gvr, namespace, opts)
+		},
+		Watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			if c.dynamicClient == nil {
+				return nil, fmt.Errorf("dynamic client not initialized")
+			}
+			opts.LabelSelector, opts.FieldSelector = base.LabelSelector, base.FieldSelector
+			return c.dynamicClient.Resource(gvr).Namespace(namespace).Watch(ctx, opts)
+		},
+	}
+}
+
+// EventListWatch builds a ListWatch for core Events in namespace ("" for all
+// namespaces) using the typed clientset
+func (c *K8sClient) EventListWatch(namespace string) ListWatch {
+	return ListWatch{
+		List: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			if c.clientset == nil {
+				return nil, fmt.Errorf("kubernetes client not initialized")
+			}
+			return c.clientset.CoreV1().Events(namespace).List(ctx, opts)
+		},
+		Watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			if c.clientset == nil {
+				return nil, fmt.Errorf("kubernetes client not initialized")
+			}
+			return c.clientset.CoreV1().Events(namespace).Watch(ctx, opts)
+		},
+	}
+}
+
+// WatchEvent is one change delivered by a WatchHelper
+type WatchEvent[T runtime.Object] struct {
+	Type   watch.EventType // watch.Added, watch.Modified, or watch.Deleted
+	Object T
+}
+
+// WatchStats summarizes a WatchHelper's connection history and delivery lag
+type WatchStats struct {
+	Reconnects      int64         // Watches re-established after a drop, timeout, or error
+	Relists         int64         // Full re-lists after the resource version expired (410 Gone)
+	Events          int64         // Events delivered to the consumer
+	LastEventLag    time.Duration // Time the last event waited for the consumer
+	MaxEventLag     time.Duration
+	LastEventAt     time.Time
+	ResourceVersion string // Resource version the next watch resumes from
+	LastError       string
+}
+
+// WatchHelper keeps a watch on one resource alive across timeouts, dropped
+// connections, and 410 Gone. It resumes from the last seen resource version
+// (advanced by bookmarks), and re-lists when that version has expired,
+// replaying the difference as Added, Modified, and Deleted events so consumers
+// never miss a change.
+type WatchHelper[T runtime.Object] struct {
+	lw    ListWatch
+	retry *RetryConfig
+
+	mu    sync.Mutex
+	stats WatchStats
+}
+
+// NewWatchHelper creates a watch helper. Reconnects back off per retry (nil
+// uses DefaultRetryConfig) with jitter; MaxRetries is ignored because a watch
+// keeps retrying until its context is cancelled.
+func NewWatchHelper[T runtime.Object](lw ListWatch, retry *RetryConfig) *WatchHelper[T] {
+	if retry == nil {
+		retry = DefaultRetryConfig()
+	}
+	return &WatchHelper[T]{lw: lw, retry: retry}
+}
+
+// Start lists the resource, delivers its current objects as Added events, and
+// then streams changes. The channel is closed once ctx is cancelled.
+// Start must be called at most once.
+func (w *WatchHelper[T]) Start(ctx context.Context) <-chan WatchEvent[T] {
+	events := make(chan WatchEvent[T])
+	go w.run(ctx, events)
+	return events
+}
+
+// Stats returns a snapshot of the helper's reconnect counts and event lag
+func (w *WatchHelper[T]) Stats() WatchStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.stats
+}
+
+func (w *WatchHelper[T]) run(ctx context.Context, events chan<- WatchEvent[T]) {
+	defer close(events)
+
+	known := make(map[string]T) // Last seen object per namespace/name, used to diff re-lists
+	backoff := w.retry.InitialBackoff
+	needList := true
+	first := true
+
+	for ctx.Err() == nil {
+		var err error
+		if needList {
+			if err = w.relist(ctx, known, events, first); err == nil {
+				needList, first = false, false
+				continue
+			}
+		} else {
+			var progressed bool
+			progressed, err = w.watch(ctx, known, events)
+			if ctx.Err() != nil {
+				return
+			}
+			if progressed {
+				backoff = w.retry.InitialBackoff
+			}
+			if isExpired(err) {
+				// The resource version is gone; waiting will not bring it back
+				needList = true
+				w.update(func(s *WatchStats) { s.Relists++; s.LastError = err.Error() })
+				continue
+			}
+			w.update(func(s *WatchStats) { s.Reconnects++ })
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		w.update(func(s *WatchStats) { s.LastError = err.Error() })
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait.Jitter(backoff, watchJitter)):
+		}
+		backoff = time.Duration(float64(backoff) * w.retry.Multiplier)
+		if backoff > w.retry.MaxBackoff {
+			backoff = w.retry.MaxBackoff
+		}
+	}
+}
+
+// relist replaces known with a fresh list and delivers the difference. The
+// initial list delivers every object as Added.
+func (w *WatchHelper[T]) relist(ctx context.Context, known map[string]T, events chan<- WatchEvent[T], initial bool) error {
+	list, err := w.lw.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("list failed: %w", err)
+	}
+	listMeta, err := meta.ListAccessor(list)
+	if err != nil {
+		return fmt.Errorf("unexpected list type %T: %w", list, err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to extract list items: %w", err)
+	}
+
+	current := make(map[string]T, len(items))
+	for _, item := range items {
+		obj, ok := item.(T)
+		if !ok {
+			return fmt.Errorf("unexpected list item type %T", item)
+		}
+		key, version := objectKey(obj)
+		current[key] = obj
+
+		previous, seen := known[key]
+		switch {
+		case !seen:
+			err = w.deliver(ctx, events, watch.Added, obj)
+		case !initial && resourceVersion(previous) != version:
+			err = w.deliver(ctx, events, watch.Modified, obj)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for key, obj := range known {
+		if _, ok := current[key]; !ok {
+			if err := w.deliver(ctx, events, watch.Deleted, obj); err != nil {
+				return err
+			}
+		}
+	}
+
+	clear(known)
+	for key, obj := range current {
+		known[key] = obj
+	}
+	w.update(func(s *WatchStats) { s.ResourceVersion = listMeta.GetResourceVersion() })
+	return nil
+}
+
+// watch streams events from the last resource version until the watch ends.
+// progressed reports whether any event arrived, which resets the backoff.
+func (w *WatchHelper[T]) watch(ctx context.Context, known map[string]T, events chan<- WatchEvent[T]) (progressed bool, err error) {
+	watcher, err := w.lw.Watch(ctx, metav1.ListOptions{
+		ResourceVersion:     w.Stats().ResourceVersion,
+		AllowWatchBookmarks: true,
+	})
+	if err != nil {
+		return false, fmt.Errorf("watch failed: %w", err)
+	}
+	defer watcher.Stop()
+
+	for {
+		var event watch.Event
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return progressed, ctx.Err()
+		case event, ok = <-watcher.ResultChan():
+		}
+		if !ok {
+			return progressed, fmt.Errorf("watch closed by server")
+		}
+		if event.Type == watch.Error {
+			return progressed, apierrors.FromObject(event.Object)
+		}
+		progressed = true
+
+		if event.Type == watch.Bookmark {
+			if accessor, err := meta.Accessor(event.Object); err == nil {
+				w.update(func(s *WatchStats) { s.ResourceVersion = accessor.GetResourceVersion() })
+			}
+			continue
+		}
+
+		obj, ok := event.Object.(T)
+		if !ok {
+			return progressed, fmt.Errorf("unexpected watch object type %T", event.Object)
+		}
+		key, version := objectKey(obj)
+		if event.Type == watch.Deleted {
+			delete(known, key)
+		} else {
+			known[key] = obj
+		}
+		if err := w.deliver(ctx, events, event.Type, obj); err != nil {
+			return progressed, err
+		}
+		w.update(func(s *WatchStats) { s.ResourceVersion = version })
+	}
+}
+
+// deliver hands one event to the consumer and records how long it waited
+func (w *WatchHelper[T]) deliver(ctx context.Context, events chan<- WatchEvent[T], eventType watch.EventType, obj T) error {
+	received := time.Now()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case events <- WatchEvent[T]{Type: eventType, Object: obj}:
+	}
+	lag := time.Since(received)
+	w.update(func(s *WatchStats) {
+		s.Events++
+		s.LastEventLag = lag
+		s.LastEventAt = received
+		if lag > s.MaxEventLag {
+			s.MaxEventLag = lag
+		}
+	})
+	return nil
+}
+
+func (w *WatchHelper[T]) update(fn func(*WatchStats)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fn(&w.stats)
+}
+
+// isExpired reports whether err means the resource version is too old to resume from
+func isExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
+
+// objectKey returns the namespace/name key and resource version of obj
+func objectKey(obj runtime.Object) (key, version string) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return "", ""
+	}
+	key = accessor.GetName()
+	if ns := accessor.GetNamespace(); ns != "" {
+		key = ns + "/" + key
+	}
+	return key, accessor.GetResourceVersion()
+}
+
+func resourceVersion(obj runtime.Object) string {
+	_, version := objectKey(obj)
+	return version
+}
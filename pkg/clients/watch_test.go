@@ -0,0 +1,221 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+var testWatchRetry = &RetryConfig{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond, Multiplier: 2}
+
+func watchPod(name, version string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, ResourceVersion: version}}
+}
+
+func podList(version string, pods ...*corev1.Pod) *corev1.PodList {
+	list := &corev1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: version}}
+	for _, pod := range pods {
+		list.Items = append(list.Items, *pod)
+	}
+	return list
+}
+
+// scriptedListWatch serves lists and watch results in order and records the
+// resource version each watch resumed from. A nil watcher fails the watch call.
+type scriptedListWatch struct {
+	mu       sync.Mutex
+	lists    []*corev1.PodList
+	watchers []*watch.FakeWatcher
+	resumes  []string
+}
+
+func (s *scriptedListWatch) listWatch() ListWatch {
+	return ListWatch{
+		List: func(ctx context.Context, opts metav1.ListOptions) (runtime.Object, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			list := s.lists[0]
+			s.lists = s.lists[1:]
+			return list, nil
+		},
+		Watch: func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if !opts.AllowWatchBookmarks {
+				return nil, errors.New("bookmarks not requested")
+			}
+			s.resumes = append(s.resumes, opts.ResourceVersion)
+			if len(s.watchers) == 0 {
+				// Park further watches until the test cancels
+				return watch.NewFake(), nil
+			}
+			watcher := s.watchers[0]
+			s.watchers = s.watchers[1:]
+			if watcher == nil {
+				return nil, errors.New("connection refused")
+			}
+			return watcher, nil
+		},
+	}
+}
+
+func (s *scriptedListWatch) resumedFrom() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.resumes...)
+}
+
+// receive reads n events or fails the test after a timeout
+func receive(t *testing.T, events <-chan WatchEvent[*corev1.Pod], n int) []string {
+	t.Helper()
+	var got []string
+	for len(got) < n {
+		select {
+		case event := <-events:
+			got = append(got, string(event.Type)+" "+event.Object.Name+"@"+event.Object.ResourceVersion)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %d events: %v", len(got), got)
+		}
+	}
+	return got
+}
+
+func assertEvents(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("event %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWatchHelper_ResumesAfterConnectionDrop(t *testing.T) {
+	first := watch.NewFakeWithChanSize(10, false)
+	first.Add(watchPod("b", "11"))
+	first.Action(watch.Bookmark, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "15"}})
+	first.Stop() // Connection drop after the bookmark
+
+	second := watch.NewFakeWithChanSize(10, false)
+	second.Modify(watchPod("a", "16"))
+
+	lw := &scriptedListWatch{
+		lists:    []*corev1.PodList{podList("10", watchPod("a", "1"))},
+		watchers: []*watch.FakeWatcher{nil, first, second},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	helper := NewWatchHelper[*corev1.Pod](lw.listWatch(), testWatchRetry)
+	events := helper.Start(ctx)
+
+	assertEvents(t, receive(t, events, 3), []string{"ADDED a@1", "ADDED b@11", "MODIFIED a@16"})
+
+	resumes := lw.resumedFrom()
+	if len(resumes) < 3 || resumes[0] != "10" || resumes[1] != "10" || resumes[2] != "15" {
+		t.Errorf("watches resumed from %v, want [10 10 15 ...]", resumes)
+	}
+	stats := helper.Stats()
+	if stats.Reconnects != 2 || stats.Relists != 0 || stats.Events != 3 {
+		t.Errorf("Stats() = %+v, want 2 reconnects, 0 relists, 3 events", stats)
+	}
+	if stats.ResourceVersion != "16" {
+		t.Errorf("ResourceVersion = %s, want 16", stats.ResourceVersion)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchHelper_RelistsAfterGone(t *testing.T) {
+	expired := watch.NewFakeWithChanSize(10, false)
+	gone := apierrors.NewResourceExpired("too old resource version: 10 (42)").ErrStatus
+	expired.Error(&gone)
+
+	lw := &scriptedListWatch{
+		lists: []*corev1.PodList{
+			podList("10", watchPod("a", "1"), watchPod("b", "2")),
+			podList("20", watchPod("a", "5"), watchPod("b", "2"), watchPod("c", "6")),
+		},
+		watchers: []*watch.FakeWatcher{expired},
+	}
+	lw.lists[1].Items = append(lw.lists[1].Items[:1], lw.lists[1].Items[2]) // b was deleted while expired
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	helper := NewWatchHelper[*corev1.Pod](lw.listWatch(), testWatchRetry)
+	events := helper.Start(ctx)
+
+	assertEvents(t, receive(t, events, 5), []string{"ADDED a@1", "ADDED b@2", "MODIFIED a@5", "ADDED c@6", "DELETED b@2"})
+
+	stats := helper.Stats()
+	if stats.Relists != 1 || stats.Reconnects != 0 {
+		t.Errorf("Stats() = %+v, want 1 relist, 0 reconnects", stats)
+	}
+	if stats.LastError == "" {
+		t.Error("LastError should record the 410")
+	}
+
+	// The watch after the re-list resumes from the new list's version
+	deadline := time.Now().Add(5 * time.Second)
+	for len(lw.resumedFrom()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if resumes := lw.resumedFrom(); len(resumes) != 2 || resumes[1] != "20" {
+		t.Errorf("watches resumed from %v, want [10 20]", resumes)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchHelper_StopsOnCancel(t *testing.T) {
+	lw := &scriptedListWatch{lists: []*corev1.PodList{podList("1")}}
+	ctx, cancel := context.WithCancel(context.Background())
+	events := NewWatchHelper[*corev1.Pod](lw.listWatch(), nil).Start(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("expected no events from an empty list")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after cancellation")
+	}
+}
+
+func TestK8sClient_ListWatchFor(t *testing.T) {
+	client := NewK8sClientWithDynamic(newTestDynamicClient(), nil)
+	lw := client.ListWatchFor(testWidgetGVR, "team-a", metav1.ListOptions{})
+
+	list, err := lw.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if items := list.(*unstructured.UnstructuredList).Items; len(items) != 1 || items[0].GetName() != "alpha" {
+		t.Errorf("List() = %v, want only alpha", items)
+	}
+	watcher, err := lw.Watch(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	watcher.Stop()
+
+	if _, err := NewK8sClientWithClientset(nil).ListWatchFor(schema.GroupVersionResource{}, "", metav1.ListOptions{}).Watch(context.Background(), metav1.ListOptions{}); err == nil {
+		t.Error("Watch() without a dynamic client should fail")
+	}
+}
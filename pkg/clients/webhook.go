@@ -0,0 +1,69 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/findings"
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/tracing"
+)
+
+// WebhookMessage is the JSON body posted by WebhookNotifier. Text alone is a
+// valid Slack incoming webhook message; generic receivers can use the rest.
+type WebhookMessage struct {
+	Text     string             `json:"text"`
+	Title    string             `json:"title,omitempty"`
+	Status   string             `json:"status,omitempty"`
+	Markdown string             `json:"markdown,omitempty"`
+	Findings []findings.Finding `json:"findings,omitempty"` // The problems the message is about, for receivers that route or deduplicate them
+}
+
+// WebhookNotifier posts messages to a generic JSON webhook such as a Slack
+// incoming webhook
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting to url
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url: url,
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: tracing.WrapTransport(nil),
+		},
+	}
+}
+
+// Notify posts message to the webhook. Any status other than 2xx is an error;
+// 429 and 5xx gateway errors are retryable by RetryWithBackoff.
+func (n *WebhookNotifier) Notify(ctx context.Context, message WebhookMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &httpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+	return nil
+}
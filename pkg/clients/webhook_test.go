@@ -0,0 +1,55 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var got WebhookMessage
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+	if err := notifier.Notify(context.Background(), WebhookMessage{Text: "Cluster health: degraded", Status: "degraded"}); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Content-Type %q, want application/json", contentType)
+	}
+	if got.Text != "Cluster health: degraded" || got.Status != "degraded" {
+		t.Errorf("message %+v", got)
+	}
+}
+
+func TestWebhookNotifier_NotifyStatus(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	notifier := NewWebhookNotifier(server.URL, time.Second)
+
+	err := notifier.Notify(context.Background(), WebhookMessage{Text: "report"})
+	if code, ok := HTTPStatusCode(err); !ok || code != http.StatusServiceUnavailable {
+		t.Fatalf("Notify error %v, want status 503", err)
+	}
+	if !isRetryable(err) {
+		t.Error("503 should be retryable")
+	}
+
+	status = http.StatusNotFound
+	err = notifier.Notify(context.Background(), WebhookMessage{Text: "report"})
+	if err == nil || isRetryable(err) {
+		t.Errorf("Notify error %v, want a non-retryable 404", err)
+	}
+}
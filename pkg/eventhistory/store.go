@@ -0,0 +1,379 @@
+// Package eventhistory keeps compacted Kubernetes events beyond the API
+// server's own retention (one hour by default), in a bounded in-memory ring
+// buffer that can be persisted to disk.
+package eventhistory
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxMessageBytes bounds the message kept per record; the hash still tells
+// full messages apart
+const maxMessageBytes = 256
+
+// ObjectRef is the object an event is about
+type ObjectRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// Record is the compacted form of one Kubernetes event: what is needed to
+// count and group it, without its metadata
+type Record struct {
+	UID         string    `json:"uid"`
+	Namespace   string    `json:"namespace"`
+	Type        string    `json:"type"`
+	Reason      string    `json:"reason"`
+	Object      ObjectRef `json:"object"`
+	Message     string    `json:"message"` // Cut to maxMessageBytes
+	MessageHash string    `json:"message_hash"`
+	Count       int32     `json:"count"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastSeen    time.Time `json:"last_seen"`
+}
+
+// NewRecord compacts event
+func NewRecord(event *corev1.Event) Record {
+	sum := sha256.Sum256([]byte(event.Message))
+	message := event.Message
+	if len(message) > maxMessageBytes {
+		message = message[:maxMessageBytes]
+	}
+	count := event.Count
+	if event.Series != nil && event.Series.Count > count {
+		count = event.Series.Count
+	}
+	if count < 1 {
+		count = 1
+	}
+	lastSeen := lastSeen(event)
+	firstSeen := event.FirstTimestamp.Time
+	if firstSeen.IsZero() || firstSeen.After(lastSeen) {
+		firstSeen = lastSeen
+	}
+	return Record{
+		UID:       string(event.UID),
+		Namespace: event.Namespace,
+		Type:      event.Type,
+		Reason:    event.Reason,
+		Object: ObjectRef{
+			Kind:      event.InvolvedObject.Kind,
+			Namespace: event.InvolvedObject.Namespace,
+			Name:      event.InvolvedObject.Name,
+		},
+		Message:     message,
+		MessageHash: hex.EncodeToString(sum[:8]),
+		Count:       count,
+		FirstSeen:   firstSeen.UTC(),
+		LastSeen:    lastSeen.UTC(),
+	}
+}
+
+// Event expands the record back into an event, with the fields event
+// aggregation reads
+func (r Record) Event() corev1.Event {
+	return corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{UID: types.UID(r.UID), Namespace: r.Namespace},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      r.Object.Kind,
+			Namespace: r.Object.Namespace,
+			Name:      r.Object.Name,
+		},
+		Type:           r.Type,
+		Reason:         r.Reason,
+		Message:        r.Message,
+		Count:          r.Count,
+		FirstTimestamp: metav1.NewTime(r.FirstSeen),
+		LastTimestamp:  metav1.NewTime(r.LastSeen),
+	}
+}
+
+// lastSeen returns the most recent timestamp recorded on an event
+func lastSeen(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// Options bound a Store
+type Options struct {
+	MaxRecords int           // Records kept; the oldest are evicted first
+	Retention  time.Duration // Records last seen longer ago are evicted
+	Path       string        // File the records are persisted to (empty = memory only)
+}
+
+// Stats describes the contents of a Store
+type Stats struct {
+	Records int       `json:"records"`
+	Evicted int64     `json:"evicted"` // Records dropped for the record cap, not for retention
+	Since   time.Time `json:"since"`   // Events before this time may be missing
+}
+
+// Store is a ring buffer of event records, oldest first, bounded by a record
+// count and a retention period. An event seen again (same UID) updates its
+// record in place. It is safe for concurrent use.
+type Store struct {
+	opts Options
+	now  func() time.Time
+
+	mu      sync.Mutex
+	records []Record       // Ring of up to MaxRecords; seq s lives at s % MaxRecords
+	head    int            // Sequence number of the oldest record
+	next    int            // Sequence number of the next record
+	index   map[string]int // UID -> sequence number
+	evicted int64
+	// truncatedAt is the latest last-seen time of a record evicted for the
+	// cap: events up to it may be missing
+	truncatedAt time.Time
+	dirty       bool
+}
+
+// NewStore creates a store. When opts.Path is set, the records persisted
+// there are loaded, minus those past the retention.
+func NewStore(opts Options) (*Store, error) {
+	if opts.MaxRecords < 1 {
+		return nil, fmt.Errorf("max records must be positive, got %d", opts.MaxRecords)
+	}
+	if opts.Retention <= 0 {
+		return nil, fmt.Errorf("retention must be positive, got %v", opts.Retention)
+	}
+	s := &Store{opts: opts, now: time.Now, index: make(map[string]int)}
+	if opts.Path != "" {
+		if err := s.load(); err != nil {
+			return s, err
+		}
+	}
+	return s, nil
+}
+
+// Observe adds an event, or updates the record of an event already seen.
+// Events last seen before the retention period are ignored.
+func (s *Store) Observe(event *corev1.Event) {
+	record := NewRecord(event)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(record)
+}
+
+// add stores record; s.mu must be held
+func (s *Store) add(record Record) {
+	now := s.now()
+	if record.LastSeen.Before(now.Add(-s.opts.Retention)) {
+		return
+	}
+	if seq, ok := s.index[record.UID]; ok && record.UID != "" {
+		slot := &s.records[s.slot(seq)]
+		if record.LastSeen.Before(slot.LastSeen) {
+			return // An older version of the event
+		}
+		record.FirstSeen = slot.FirstSeen
+		*slot = record
+		s.dirty = true
+		return
+	}
+
+	if s.next-s.head == s.opts.MaxRecords {
+		s.evictOldest(true)
+	}
+	if slot := s.slot(s.next); slot == len(s.records) {
+		s.records = append(s.records, record)
+	} else {
+		s.records[slot] = record
+	}
+	if record.UID != "" {
+		s.index[record.UID] = s.next
+	}
+	s.next++
+	s.dirty = true
+	s.prune(now)
+}
+
+func (s *Store) slot(seq int) int {
+	return seq % s.opts.MaxRecords
+}
+
+// evictOldest drops the oldest record; forCap records that it was dropped
+// for room rather than age. s.mu must be held.
+func (s *Store) evictOldest(forCap bool) {
+	slot := &s.records[s.slot(s.head)]
+	if s.index[slot.UID] == s.head {
+		delete(s.index, slot.UID)
+	}
+	if forCap {
+		s.evicted++
+		if slot.LastSeen.After(s.truncatedAt) {
+			s.truncatedAt = slot.LastSeen
+		}
+	}
+	*slot = Record{}
+	s.head++
+	s.dirty = true
+}
+
+// prune evicts records, oldest first, until the oldest was last seen within
+// the retention period. s.mu must be held.
+func (s *Store) prune(now time.Time) {
+	cutoff := now.Add(-s.opts.Retention)
+	for s.head < s.next && s.records[s.slot(s.head)].LastSeen.Before(cutoff) {
+		s.evictOldest(false)
+	}
+}
+
+// Prune evicts the records past the retention period
+func (s *Store) Prune() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(s.now())
+}
+
+// Events returns the events of namespace ("" for all namespaces) last seen
+// at or after since, oldest first
+func (s *Store) Events(namespace string, since time.Time) []corev1.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []corev1.Event
+	for seq := s.head; seq < s.next; seq++ {
+		record := &s.records[s.slot(seq)]
+		if (namespace == "" || record.Namespace == namespace) && !record.LastSeen.Before(since) {
+			events = append(events, record.Event())
+		}
+	}
+	return events
+}
+
+// Stats returns the number of records and the time the store is complete
+// from: the earliest event it holds, or the last one evicted for the record
+// cap if later
+func (s *Store) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := Stats{Records: s.next - s.head, Evicted: s.evicted}
+	var earliest time.Time
+	for seq := s.head; seq < s.next; seq++ {
+		if first := s.records[s.slot(seq)].FirstSeen; earliest.IsZero() || first.Before(earliest) {
+			earliest = first
+		}
+	}
+	stats.Since = earliest
+	if s.truncatedAt.After(earliest) {
+		stats.Since = s.truncatedAt
+	}
+	return stats
+}
+
+// persistedStore is the file format of a persisted store
+type persistedStore struct {
+	TruncatedAt time.Time `json:"truncated_at,omitempty"`
+	Evicted     int64     `json:"evicted,omitempty"`
+	Records     []Record  `json:"records"`
+}
+
+// Flush writes the records to the store's file, if any and if they changed
+// since the last flush. The file is replaced atomically.
+func (s *Store) Flush() error {
+	if s.opts.Path == "" {
+		return nil
+	}
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	persisted := persistedStore{TruncatedAt: s.truncatedAt, Evicted: s.evicted, Records: make([]Record, 0, s.next-s.head)}
+	for seq := s.head; seq < s.next; seq++ {
+		persisted.Records = append(persisted.Records, s.records[s.slot(seq)])
+	}
+	s.dirty = false
+	s.mu.Unlock()
+
+	data, err := json.Marshal(persisted)
+	if err == nil {
+		err = writeFileAtomic(s.opts.Path, data)
+	}
+	if err != nil {
+		s.mu.Lock()
+		s.dirty = true // Retry on the next flush
+		s.mu.Unlock()
+		return fmt.Errorf("failed to persist event history to %s: %w", s.opts.Path, err)
+	}
+	return nil
+}
+
+// Reload replaces the records with those persisted in the store's file, for
+// replicas that read a history another replica records. Without a file it
+// does nothing.
+func (s *Store) Reload() error {
+	if s.opts.Path == "" {
+		return nil
+	}
+	return s.load()
+}
+
+// load replaces the records with those persisted in the store's file. A
+// missing file is an empty store.
+func (s *Store) load() error {
+	data, err := os.ReadFile(s.opts.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read event history %s: %w", s.opts.Path, err)
+	}
+	var persisted persistedStore
+	if err := json.Unmarshal(data, &persisted); err != nil {
+		return fmt.Errorf("failed to parse event history %s: %w", s.opts.Path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records, s.head, s.next = s.records[:0], 0, 0
+	clear(s.index)
+	s.truncatedAt, s.evicted = persisted.TruncatedAt, persisted.Evicted
+	for _, record := range persisted.Records {
+		s.add(record)
+	}
+	s.dirty = false
+	return nil
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the
+// same directory, so a crash never leaves a partial file behind
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck // Already renamed on success
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck,gosec // The write error is reported
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
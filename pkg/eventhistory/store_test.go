@@ -0,0 +1,170 @@
+package eventhistory
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var testNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+func testEvent(uid, namespace, reason string, count int32, first, last time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{UID: types.UID(uid), Namespace: namespace},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Namespace: namespace, Name: "api-" + uid},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        "Back-off restarting failed container " + uid,
+		Count:          count,
+		FirstTimestamp: metav1.NewTime(first),
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func newTestStore(t *testing.T, opts Options) (*Store, *time.Time) {
+	t.Helper()
+	store, err := NewStore(opts)
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	now := testNow
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func uids(events []corev1.Event) string {
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, string(event.UID))
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestStore_UpdatesEventsInPlace(t *testing.T) {
+	store, _ := newTestStore(t, Options{MaxRecords: 10, Retention: 24 * time.Hour})
+	first := testNow.Add(-time.Hour)
+
+	store.Observe(testEvent("a", "shop", "BackOff", 1, first, first))
+	store.Observe(testEvent("b", "shop", "BackOff", 1, first, first))
+	store.Observe(testEvent("a", "shop", "BackOff", 7, first, testNow.Add(-time.Minute)))
+	store.Observe(testEvent("a", "shop", "BackOff", 3, first, testNow.Add(-30*time.Minute))) // Stale update
+
+	events := store.Events("", time.Time{})
+	if got := uids(events); got != "a,b" {
+		t.Fatalf("events = %s, want a,b", got)
+	}
+	if events[0].Count != 7 || !events[0].LastTimestamp.Time.Equal(testNow.Add(-time.Minute)) {
+		t.Errorf("record a = count %d, last seen %v; want the latest update", events[0].Count, events[0].LastTimestamp)
+	}
+}
+
+func TestStore_RetentionEvictsOldestFirst(t *testing.T) {
+	store, now := newTestStore(t, Options{MaxRecords: 10, Retention: 6 * time.Hour})
+
+	store.Observe(testEvent("expired", "shop", "BackOff", 1, testNow.Add(-7*time.Hour), testNow.Add(-7*time.Hour)))
+	for i, age := range []time.Duration{5 * time.Hour, 3 * time.Hour, time.Hour} {
+		at := testNow.Add(-age)
+		store.Observe(testEvent(fmt.Sprint(i), "shop", "BackOff", 1, at, at))
+	}
+	if got := uids(store.Events("", time.Time{})); got != "0,1,2" {
+		t.Fatalf("events = %s, want 0,1,2 (events past the retention are not stored)", got)
+	}
+
+	*now = testNow.Add(2 * time.Hour)
+	store.Prune()
+	if got := uids(store.Events("", time.Time{})); got != "1,2" {
+		t.Errorf("events after 2h = %s, want 1,2", got)
+	}
+	if stats := store.Stats(); stats.Records != 2 || stats.Evicted != 0 {
+		t.Errorf("stats = %+v, want 2 records and no evictions for room", stats)
+	}
+}
+
+func TestStore_CapEvictsOldestFirst(t *testing.T) {
+	store, _ := newTestStore(t, Options{MaxRecords: 3, Retention: 24 * time.Hour})
+	for i := 0; i < 5; i++ {
+		at := testNow.Add(time.Duration(i-10) * time.Minute)
+		store.Observe(testEvent(fmt.Sprint(i), "shop", "BackOff", 1, at, at))
+	}
+
+	if got := uids(store.Events("", time.Time{})); got != "2,3,4" {
+		t.Fatalf("events = %s, want 2,3,4", got)
+	}
+	stats := store.Stats()
+	if stats.Records != 3 || stats.Evicted != 2 {
+		t.Errorf("stats = %+v, want 3 records and 2 evicted", stats)
+	}
+	if want := testNow.Add(-8 * time.Minute); !stats.Since.Equal(want) {
+		t.Errorf("since = %v, want %v (the oldest record kept)", stats.Since, want)
+	}
+
+	// An evicted event seen again starts a new record
+	at := testNow.Add(-9 * time.Minute)
+	store.Observe(testEvent("1", "shop", "BackOff", 2, at, at))
+	if got := uids(store.Events("", time.Time{})); got != "3,4,1" {
+		t.Errorf("events = %s, want 3,4,1", got)
+	}
+}
+
+func TestStore_EventsFiltersByNamespaceAndTime(t *testing.T) {
+	store, _ := newTestStore(t, Options{MaxRecords: 10, Retention: 24 * time.Hour})
+	store.Observe(testEvent("old", "shop", "BackOff", 1, testNow.Add(-5*time.Hour), testNow.Add(-5*time.Hour)))
+	store.Observe(testEvent("long", "shop", "BackOff", 9, testNow.Add(-5*time.Hour), testNow.Add(-time.Hour)))
+	store.Observe(testEvent("other", "payments", "BackOff", 1, testNow.Add(-time.Hour), testNow.Add(-time.Hour)))
+
+	if got := uids(store.Events("shop", testNow.Add(-2*time.Hour))); got != "long" {
+		t.Errorf("events = %s, want long (last seen within the window)", got)
+	}
+}
+
+func TestStore_Persistence(t *testing.T) {
+	// Loading prunes with the real clock
+	now := time.Now()
+	path := filepath.Join(t.TempDir(), "events.json")
+	store, err := NewStore(Options{MaxRecords: 10, Retention: 24 * time.Hour, Path: path})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	long := testEvent("a", "shop", "BackOff", 3, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	long.Message = strings.Repeat("x", 1000)
+	store.Observe(long)
+	if err := store.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	reloaded, err := NewStore(Options{MaxRecords: 10, Retention: 24 * time.Hour, Path: path})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	events := reloaded.Events("", time.Time{})
+	if len(events) != 1 || events[0].Count != 3 || events[0].Reason != "BackOff" {
+		t.Fatalf("reloaded events = %+v", events)
+	}
+	if len(events[0].Message) != maxMessageBytes {
+		t.Errorf("message length = %d, want it cut to %d", len(events[0].Message), maxMessageBytes)
+	}
+
+	// Reload replaces what was recorded since with the file's records
+	reloaded.Observe(testEvent("b", "shop", "BackOff", 1, now, now))
+	if err := reloaded.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if got := uids(reloaded.Events("", time.Time{})); got != "a" {
+		t.Errorf("events after reload = %s, want a", got)
+	}
+
+	// Records past the retention are dropped on load
+	short, err := NewStore(Options{MaxRecords: 10, Retention: 30 * time.Minute, Path: path})
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	if n := short.Stats().Records; n != 0 {
+		t.Errorf("records = %d, want none within a 30m retention", n)
+	}
+}
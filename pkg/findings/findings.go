@@ -0,0 +1,204 @@
+// Package findings is the common shape of the problems the analysis tools
+// report. Each tool keeps its detailed payload and also returns its problems
+// as Findings, so that the agent, the health report, notifications and
+// incidents can combine the results of several tools without knowing each
+// tool's output.
+package findings
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Severity grades a finding, using the severities of Prometheus alerts
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical" // Something is broken or about to break
+	SeverityWarning  Severity = "warning"  // Worth fixing, but nothing fails yet
+	SeverityInfo     Severity = "info"     // Noteworthy only
+)
+
+// Rank orders severities, most severe first; unknown severities rank last
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 0
+	case SeverityWarning:
+		return 1
+	case SeverityInfo:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Categories group findings by the part of the cluster they are about,
+// whichever tool found them
+const (
+	CategoryNode     = "node"
+	CategoryOperator = "operator"
+	CategoryAlert    = "alert"
+	CategoryWorkload = "workload"
+	CategoryStorage  = "storage"
+	CategoryNetwork  = "network"
+	CategorySecurity = "security"
+	CategoryUpgrade  = "upgrade"
+)
+
+// Evidence types
+const (
+	EvidenceEvent     = "event"
+	EvidenceLog       = "log"
+	EvidenceAlert     = "alert"
+	EvidenceCondition = "condition" // A status condition of the resource
+)
+
+// ResourceRef identifies the resource a finding is about. Namespace is empty
+// for cluster-scoped resources; Kind is lower case, as in "node".
+type ResourceRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// String renders the reference as kind/name or kind/namespace/name
+func (r ResourceRef) String() string {
+	if r.Namespace == "" {
+		return r.Kind + "/" + r.Name
+	}
+	return r.Kind + "/" + r.Namespace + "/" + r.Name
+}
+
+// Evidence points at what a finding was concluded from
+type Evidence struct {
+	Type    string `json:"type"`              // EvidenceEvent, EvidenceLog, EvidenceAlert, or EvidenceCondition
+	Ref     string `json:"ref"`               // The event reason, alert name, condition type, or pod/container of a log
+	Message string `json:"message,omitempty"` // The event, log line or condition message
+}
+
+// Finding is one problem found by an analysis tool
+type Finding struct {
+	Severity        Severity    `json:"severity"`
+	Category        string      `json:"category"`
+	Resource        ResourceRef `json:"resource"`
+	Title           string      `json:"title"` // Short and stable, e.g. "Node is NotReady"; the same problem has the same title
+	Detail          string      `json:"detail,omitempty"`
+	Evidence        []Evidence  `json:"evidence,omitempty"`
+	SuggestedAction string      `json:"suggested_action,omitempty"`
+	FirstSeen       time.Time   `json:"first_seen,omitzero"`
+	LastSeen        time.Time   `json:"last_seen,omitzero"`
+	Source          string      `json:"source,omitempty"` // The tool that found it; a comma-separated list once merged
+}
+
+// Key identifies the problem a finding describes: two findings with the same
+// key are the same problem, seen by different tools or at different times
+func (f *Finding) Key() string {
+	return f.Category + "|" + f.Resource.String() + "|" + f.Title
+}
+
+// Merge combines lists of findings into one, sorted with Sort. Findings with
+// the same Key are merged: the most severe one's severity, detail and
+// suggested action are kept, evidence and sources are combined, and the seen
+// times span both.
+func Merge(lists ...[]Finding) []Finding {
+	merged := []Finding{}
+	index := make(map[string]int)
+	for _, list := range lists {
+		for _, finding := range list {
+			key := finding.Key()
+			i, ok := index[key]
+			if !ok {
+				finding.Evidence = slices.Clone(finding.Evidence)
+				index[key] = len(merged)
+				merged = append(merged, finding)
+				continue
+			}
+			merged[i] = mergeFinding(merged[i], finding)
+		}
+	}
+	Sort(merged)
+	return merged
+}
+
+// mergeFinding merges two findings with the same key
+func mergeFinding(a, b Finding) Finding {
+	result := a
+	if b.Severity.Rank() < a.Severity.Rank() {
+		result.Severity, result.Detail, result.SuggestedAction = b.Severity, b.Detail, b.SuggestedAction
+	}
+	if result.Detail == "" {
+		result.Detail = b.Detail
+	}
+	if result.SuggestedAction == "" {
+		result.SuggestedAction = b.SuggestedAction
+	}
+	for _, evidence := range b.Evidence {
+		if !slices.Contains(result.Evidence, evidence) {
+			result.Evidence = append(result.Evidence, evidence)
+		}
+	}
+	if !b.FirstSeen.IsZero() && (result.FirstSeen.IsZero() || b.FirstSeen.Before(result.FirstSeen)) {
+		result.FirstSeen = b.FirstSeen
+	}
+	if b.LastSeen.After(result.LastSeen) {
+		result.LastSeen = b.LastSeen
+	}
+	sources := strings.Split(result.Source, ",")
+	for _, source := range strings.Split(b.Source, ",") {
+		if source != "" && !slices.Contains(sources, source) {
+			sources = append(sources, source)
+		}
+	}
+	result.Source = strings.Join(slices.DeleteFunc(sources, func(s string) bool { return s == "" }), ",")
+	return result
+}
+
+// Sort orders findings most severe first, then by category, resource and title
+func Sort(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := &findings[i], &findings[j]
+		if a.Severity.Rank() != b.Severity.Rank() {
+			return a.Severity.Rank() < b.Severity.Rank()
+		}
+		if a.Category != b.Category {
+			return a.Category < b.Category
+		}
+		if a.Resource.String() != b.Resource.String() {
+			return a.Resource.String() < b.Resource.String()
+		}
+		return a.Title < b.Title
+	})
+}
+
+// Highest returns the most severe severity among findings, or "" for none
+func Highest(findings []Finding) Severity {
+	var highest Severity
+	for i := range findings {
+		if highest == "" || findings[i].Severity.Rank() < highest.Rank() {
+			highest = findings[i].Severity
+		}
+	}
+	return highest
+}
+
+// Summarize counts findings by severity, e.g. "2 critical, 1 warning"
+func Summarize(findings []Finding) string {
+	counts := make(map[Severity]int)
+	for i := range findings {
+		counts[findings[i].Severity]++
+	}
+	var parts []string
+	for _, severity := range []Severity{SeverityCritical, SeverityWarning, SeverityInfo} {
+		if counts[severity] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[severity], severity))
+		}
+	}
+	if len(parts) == 0 {
+		return "no findings"
+	}
+	return strings.Join(parts, ", ")
+}
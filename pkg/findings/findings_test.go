@@ -0,0 +1,113 @@
+package findings
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+func nodeNotReady(severity Severity, source string) Finding {
+	return Finding{
+		Severity: severity,
+		Category: CategoryNode,
+		Resource: ResourceRef{Kind: "node", Name: "worker-1"},
+		Title:    "Node is NotReady",
+		Detail:   "node worker-1 is NotReady (" + source + ")",
+		Source:   source,
+	}
+}
+
+func TestMerge_DeduplicatesSameProblem(t *testing.T) {
+	fromUpgrade := nodeNotReady(SeverityWarning, "assess-upgrade-readiness")
+	fromUpgrade.Evidence = []Evidence{{Type: EvidenceCondition, Ref: "Ready", Message: "kubelet stopped posting status"}}
+	fromUpgrade.FirstSeen = testNow.Add(-time.Hour)
+	fromUpgrade.LastSeen = testNow.Add(-time.Hour)
+
+	fromNetwork := nodeNotReady(SeverityCritical, "get-network-health")
+	fromNetwork.SuggestedAction = "Check the CNI pod on the node"
+	fromNetwork.Evidence = []Evidence{
+		{Type: EvidenceCondition, Ref: "Ready", Message: "kubelet stopped posting status"},
+		{Type: EvidenceEvent, Ref: "FailedCreatePodSandBox"},
+	}
+	fromNetwork.FirstSeen = testNow.Add(-30 * time.Minute)
+	fromNetwork.LastSeen = testNow
+
+	merged := Merge([]Finding{fromUpgrade}, []Finding{fromNetwork})
+	if len(merged) != 1 {
+		t.Fatalf("Expected one finding, got %d: %+v", len(merged), merged)
+	}
+	got := merged[0]
+	if got.Severity != SeverityCritical || got.Detail != fromNetwork.Detail || got.SuggestedAction != fromNetwork.SuggestedAction {
+		t.Errorf("Expected the most severe finding to win, got %+v", got)
+	}
+	if len(got.Evidence) != 2 {
+		t.Errorf("Expected the evidence to be combined without duplicates, got %+v", got.Evidence)
+	}
+	if !got.FirstSeen.Equal(fromUpgrade.FirstSeen) || !got.LastSeen.Equal(fromNetwork.LastSeen) {
+		t.Errorf("Expected the seen times to span both findings, got %v to %v", got.FirstSeen, got.LastSeen)
+	}
+	if got.Source != "assess-upgrade-readiness,get-network-health" {
+		t.Errorf("Unexpected sources %q", got.Source)
+	}
+	if len(fromUpgrade.Evidence) != 1 {
+		t.Errorf("Merge modified its input: %+v", fromUpgrade.Evidence)
+	}
+}
+
+func TestMerge_KeepsDistinctProblemsSorted(t *testing.T) {
+	cordoned := Finding{Severity: SeverityWarning, Category: CategoryNode, Resource: ResourceRef{Kind: "node", Name: "worker-1"}, Title: "Node is cordoned"}
+	operator := Finding{Severity: SeverityCritical, Category: CategoryOperator, Resource: ResourceRef{Kind: "clusteroperator", Name: "dns"}, Title: "ClusterOperator is degraded"}
+	alert := Finding{Severity: SeverityCritical, Category: CategoryAlert, Resource: ResourceRef{Kind: "alert", Namespace: "shop", Name: "KubePodCrashLooping"}, Title: "Alert is firing"}
+
+	merged := Merge([]Finding{cordoned, nodeNotReady(SeverityCritical, "a")}, []Finding{operator, alert})
+	var titles []string
+	for _, finding := range merged {
+		titles = append(titles, finding.Title)
+	}
+	want := "Alert is firing,Node is NotReady,ClusterOperator is degraded,Node is cordoned"
+	if got := strings.Join(titles, ","); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if empty := Merge(); empty == nil || len(empty) != 0 {
+		t.Errorf("Expected an empty, non-nil list, got %#v", empty)
+	}
+}
+
+func TestFinding_JSON(t *testing.T) {
+	finding := nodeNotReady(SeverityCritical, "get-network-health")
+	data, err := json.Marshal(finding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "first_seen") || strings.Contains(string(data), "namespace") {
+		t.Errorf("Expected unset times and namespace to be left out: %s", data)
+	}
+	if finding.Resource.String() != "node/worker-1" {
+		t.Errorf("Unexpected resource %s", finding.Resource)
+	}
+	namespaced := ResourceRef{Kind: "pod", Namespace: "shop", Name: "cart-1"}
+	if namespaced.String() != "pod/shop/cart-1" {
+		t.Errorf("Unexpected resource %s", namespaced)
+	}
+}
+
+func TestHighestAndSummarize(t *testing.T) {
+	list := []Finding{
+		{Severity: SeverityWarning}, {Severity: SeverityCritical}, {Severity: SeverityWarning}, {Severity: SeverityInfo},
+	}
+	if got := Highest(list); got != SeverityCritical {
+		t.Errorf("Expected critical, got %q", got)
+	}
+	if got := Highest(nil); got != "" {
+		t.Errorf("Expected no severity for no findings, got %q", got)
+	}
+	if got := Summarize(list); got != "1 critical, 2 warning, 1 info" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+	if got := Summarize(nil); got != "no findings" {
+		t.Errorf("Unexpected summary %q", got)
+	}
+}
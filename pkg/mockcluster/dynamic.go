@@ -0,0 +1,52 @@
+package mockcluster
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// dynamicClient is the fake dynamic client, except that listing a resource
+// the cluster does not serve fails with NotFound, as it does against a real
+// API server. The fake panics instead, and tools list the kinds of optional
+// operators to find out whether they are installed.
+type dynamicClient struct {
+	*dynamicfake.FakeDynamicClient
+	served map[schema.GroupVersionResource]string // Resource -> list kind
+}
+
+func (c *dynamicClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	resource := c.FakeDynamicClient.Resource(gvr)
+	if _, ok := c.served[gvr]; ok {
+		return resource
+	}
+	return unservedResource{resource, gvr}
+}
+
+// unservedResource is a resource the cluster does not serve
+type unservedResource struct {
+	dynamic.NamespaceableResourceInterface
+	gvr schema.GroupVersionResource
+}
+
+func (r unservedResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return unservedNamespacedResource{r.NamespaceableResourceInterface.Namespace(namespace), r.gvr}
+}
+
+func (r unservedResource) List(context.Context, metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return nil, apierrors.NewNotFound(r.gvr.GroupResource(), "")
+}
+
+type unservedNamespacedResource struct {
+	dynamic.ResourceInterface
+	gvr schema.GroupVersionResource
+}
+
+func (r unservedNamespacedResource) List(context.Context, metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return nil, apierrors.NewNotFound(r.gvr.GroupResource(), "")
+}
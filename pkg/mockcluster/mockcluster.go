@@ -0,0 +1,364 @@
+// Package mockcluster simulates a cluster for demos and tests: the fake
+// typed and dynamic clients of client-go, seeded from fixture files, behind
+// an ordinary clients.K8sClient so tools and resources run against it
+// unchanged. Scenarios mutate the simulated objects at runtime.
+package mockcluster
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/openshift-aiops/openshift-cluster-health-mcp/pkg/clients"
+)
+
+// builtinFixtures is the cluster served when no fixture directory is given:
+// a small OpenShift cluster with a demo application
+//
+//go:embed fixtures/*.yaml
+var builtinFixtures embed.FS
+
+// ServerVersion is the version the simulated API server reports
+const ServerVersion = "v1.31.0-mock"
+
+// clusterScopedKinds are the built-in kinds that are not namespaced. Kinds
+// only found in fixtures are namespaced when their objects have a namespace.
+var clusterScopedKinds = map[string]bool{
+	"APIService":                     true,
+	"CertificateSigningRequest":      true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"ComponentStatus":                true,
+	"CSIDriver":                      true,
+	"CSINode":                        true,
+	"CustomResourceDefinition":       true,
+	"FlowSchema":                     true,
+	"IngressClass":                   true,
+	"MutatingWebhookConfiguration":   true,
+	"Namespace":                      true,
+	"Node":                           true,
+	"PersistentVolume":               true,
+	"PriorityClass":                  true,
+	"PriorityLevelConfiguration":     true,
+	"RuntimeClass":                   true,
+	"StorageClass":                   true,
+	"ValidatingAdmissionPolicy":      true,
+	"ValidatingWebhookConfiguration": true,
+	"VolumeAttachment":               true,
+}
+
+// Cluster is a simulated cluster. It is safe for concurrent use.
+type Cluster struct {
+	clientset *fake.Clientset
+	dynamic   *dynamicfake.FakeDynamicClient
+	client    *clients.K8sClient
+
+	mu       sync.Mutex // Serializes scenarios
+	fixtures []fixtureObject
+	added    []addedObject // Objects scenarios created, removed on reset
+}
+
+// fixtureObject is one object read from the fixtures, with the resource it
+// is stored under
+type fixtureObject struct {
+	gvr       schema.GroupVersionResource
+	typed     runtime.Object // nil for kinds without a typed client, e.g. OpenShift kinds
+	object    *unstructured.Unstructured
+	namespace string
+}
+
+// addedObject identifies an object a scenario created
+type addedObject struct {
+	gvr             schema.GroupVersionResource
+	namespace, name string
+}
+
+// New creates a cluster seeded from the fixtures in dir, or from the
+// built-in fixtures when dir is empty. Fixture files (.yaml, .yml, .json)
+// hold objects or lists of objects (kind: List), as printed by
+// `oc get -o yaml`; YAML files may hold several documents.
+func New(dir string) (*Cluster, error) {
+	var files fs.FS = builtinFixtures
+	root := "fixtures"
+	if dir != "" {
+		files, root = os.DirFS(dir), "."
+	}
+	objects, err := readFixtures(files, root)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no fixture objects found in %s", dir)
+	}
+
+	resources := builtinResources()
+	var fixtures []fixtureObject
+	for _, object := range objects {
+		gvk := object.GroupVersionKind()
+		gvr, ok := resources.lookup(gvk)
+		if !ok {
+			gvr = resources.add(gvk, object.GetNamespace() != "")
+		}
+		fixture := fixtureObject{gvr: gvr, object: object, namespace: object.GetNamespace()}
+		if scheme.Scheme.Recognizes(gvk) {
+			typed, err := scheme.Scheme.New(gvk)
+			if err != nil {
+				return nil, err
+			}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, typed); err != nil {
+				return nil, fmt.Errorf("invalid %s %s: %w", gvk.Kind, objectName(object), err)
+			}
+			fixture.typed = typed
+		}
+		fixtures = append(fixtures, fixture)
+	}
+
+	c := &Cluster{
+		clientset: fake.NewClientset(),
+		dynamic:   dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), resources.listKinds()),
+		fixtures:  fixtures,
+	}
+	addReviewReactors(&c.clientset.Fake)
+	c.clientset.PrependReactor("list", "*", fieldSelectorReactor(c.clientset.Tracker()))
+	c.dynamic.PrependReactor("list", "*", fieldSelectorReactor(c.dynamic.Tracker()))
+	for _, fixture := range fixtures {
+		if err := c.add(fixture); err != nil {
+			return nil, fmt.Errorf("failed to load %s %s: %w", fixture.object.GetKind(), objectName(fixture.object), err)
+		}
+	}
+
+	discovery := c.clientset.Discovery().(*fakediscovery.FakeDiscovery)
+	discovery.FakedServerVersion = &version.Info{GitVersion: ServerVersion, Major: "1", Minor: "31"}
+	discovery.Resources = resources.discovery()
+	groups, err := restmapper.GetAPIGroupResources(discovery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build REST mapper: %w", err)
+	}
+	c.client = clients.NewK8sClientWithClients(c.clientset, &dynamicClient{c.dynamic, resources.listKinds()}, restmapper.NewDiscoveryRESTMapper(groups))
+	return c, nil
+}
+
+// Client returns the Kubernetes client reading the simulated cluster
+func (c *Cluster) Client() *clients.K8sClient {
+	return c.client
+}
+
+// add stores a fixture object in the typed and dynamic clients
+func (c *Cluster) add(fixture fixtureObject) error {
+	if fixture.typed != nil {
+		if err := c.clientset.Tracker().Create(fixture.gvr, fixture.typed.DeepCopyObject(), fixture.namespace); err != nil {
+			return err
+		}
+	}
+	return c.dynamic.Tracker().Create(fixture.gvr, fixture.object.DeepCopy(), fixture.namespace)
+}
+
+// update replaces a typed object in both clients, so typed and dynamic
+// reads agree after a scenario
+func (c *Cluster) update(gvr schema.GroupVersionResource, object runtime.Object, namespace string) error {
+	if err := c.clientset.Tracker().Update(gvr, object, namespace); err != nil {
+		return err
+	}
+	converted, err := toUnstructured(object)
+	if err != nil {
+		return err
+	}
+	return c.dynamic.Tracker().Update(gvr, converted, namespace)
+}
+
+// readFixtures decodes the objects of every fixture file below root, in
+// file name order
+func readFixtures(files fs.FS, root string) ([]*unstructured.Unstructured, error) {
+	var paths []string
+	err := fs.WalkDir(files, root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				paths = append(paths, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+	sort.Strings(paths)
+
+	var objects []*unstructured.Unstructured
+	for _, path := range paths {
+		decoded, err := decodeFixture(files, path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+		}
+		objects = append(objects, decoded...)
+	}
+	return objects, nil
+}
+
+// decodeFixture decodes the objects of one fixture file, expanding lists
+func decodeFixture(files fs.FS, path string) ([]*unstructured.Unstructured, error) {
+	file, err := files.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck // Read only
+
+	var objects []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(file, 4096)
+	for {
+		var content map[string]interface{}
+		if err := decoder.Decode(&content); errors.Is(err, io.EOF) {
+			return objects, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if len(content) == 0 {
+			continue // Empty YAML document
+		}
+		object := &unstructured.Unstructured{Object: content}
+		if !object.IsList() {
+			if err := checkObject(object); err != nil {
+				return nil, err
+			}
+			objects = append(objects, object)
+			continue
+		}
+		err := object.EachListItem(func(item runtime.Object) error {
+			itemObject := item.(*unstructured.Unstructured)
+			if err := checkObject(itemObject); err != nil {
+				return err
+			}
+			objects = append(objects, itemObject)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// checkObject rejects objects the clients could not store
+func checkObject(object *unstructured.Unstructured) error {
+	if object.GetAPIVersion() == "" || object.GetKind() == "" {
+		return fmt.Errorf("object %q has no apiVersion or kind", object.GetName())
+	}
+	if object.GetName() == "" {
+		return fmt.Errorf("%s object has no name", object.GetKind())
+	}
+	return nil
+}
+
+func objectName(object metav1.Object) string {
+	if object.GetNamespace() == "" {
+		return object.GetName()
+	}
+	return object.GetNamespace() + "/" + object.GetName()
+}
+
+// resourceSet is the set of resources the simulated API server serves
+type resourceSet map[schema.GroupVersionKind]servedResource
+
+type servedResource struct {
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+// resourceNames are the resources of kinds whose name is not the
+// conventional plural of the kind
+var resourceNames = map[schema.GroupVersionKind]string{
+	{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "NodeMetrics"}: "nodes",
+	{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetrics"}:  "pods",
+}
+
+// builtinResources returns the resources of every kind client-go has a typed
+// client for
+func builtinResources() resourceSet {
+	resources := resourceSet{}
+	for gvk := range scheme.Scheme.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal || strings.HasSuffix(gvk.Kind, "List") {
+			continue
+		}
+		// Only kinds with a list kind are resources; the rest are options
+		// and status kinds registered in every group
+		if !scheme.Scheme.Recognizes(gvk.GroupVersion().WithKind(gvk.Kind + "List")) {
+			continue
+		}
+		resources.add(gvk, !clusterScopedKinds[gvk.Kind])
+	}
+	return resources
+}
+
+func (r resourceSet) lookup(gvk schema.GroupVersionKind) (schema.GroupVersionResource, bool) {
+	resource, ok := r[gvk]
+	return resource.gvr, ok
+}
+
+// add serves gvk under its conventional plural resource name, unless
+// resourceNames has another
+func (r resourceSet) add(gvk schema.GroupVersionKind, namespaced bool) schema.GroupVersionResource {
+	gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+	if name, ok := resourceNames[gvk]; ok {
+		gvr.Resource = name
+	}
+	r[gvk] = servedResource{gvr: gvr, namespaced: namespaced}
+	return gvr
+}
+
+// listKinds maps every served resource to its list kind, which the fake
+// dynamic client needs to list it
+func (r resourceSet) listKinds() map[schema.GroupVersionResource]string {
+	kinds := make(map[schema.GroupVersionResource]string, len(r))
+	for gvk, resource := range r {
+		kinds[resource.gvr] = gvk.Kind + "List"
+	}
+	return kinds
+}
+
+// discovery returns the served resources as the discovery API lists them
+func (r resourceSet) discovery() []*metav1.APIResourceList {
+	byGroupVersion := map[string]*metav1.APIResourceList{}
+	for gvk, resource := range r {
+		groupVersion := gvk.GroupVersion().String()
+		list, ok := byGroupVersion[groupVersion]
+		if !ok {
+			list = &metav1.APIResourceList{GroupVersion: groupVersion}
+			byGroupVersion[groupVersion] = list
+		}
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       resource.gvr.Resource,
+			Namespaced: resource.namespaced,
+			Kind:       gvk.Kind,
+			Verbs:      metav1.Verbs{"get", "list", "watch", "create", "update", "patch", "delete"},
+		})
+	}
+	lists := make([]*metav1.APIResourceList, 0, len(byGroupVersion))
+	for _, list := range byGroupVersion {
+		sort.Slice(list.APIResources, func(i, j int) bool { return list.APIResources[i].Name < list.APIResources[j].Name })
+		lists = append(lists, list)
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].GroupVersion < lists[j].GroupVersion })
+	return lists
+}
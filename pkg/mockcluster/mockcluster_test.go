@@ -0,0 +1,245 @@
+package mockcluster
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newBuiltinCluster(t *testing.T) *Cluster {
+	t.Helper()
+	cluster, err := New("")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return cluster
+}
+
+func TestNew_BuiltinFixtures(t *testing.T) {
+	ctx := context.Background()
+	client := newBuiltinCluster(t).Client()
+
+	nodes, err := client.ListNodes(ctx)
+	if err != nil || len(nodes.Items) != 3 {
+		t.Fatalf("ListNodes() = %v nodes, %v; want 3", len(nodes.Items), err)
+	}
+	pods, err := client.ListPods(ctx, "demo-shop")
+	if err != nil || len(pods.Items) != 3 {
+		t.Fatalf("ListPods(demo-shop) = %v pods, %v; want 3", len(pods.Items), err)
+	}
+	if version, err := client.GetServerVersion(ctx); err != nil || version != ServerVersion {
+		t.Errorf("GetServerVersion() = %q, %v; want %q", version, err, ServerVersion)
+	}
+	if openshift, err := client.IsOpenShift(ctx); err != nil || !openshift {
+		t.Errorf("IsOpenShift() = %v, %v; want true", openshift, err)
+	}
+
+	// Fixture kinds without a typed client are served through the dynamic client
+	operators, err := client.ListResources(ctx, "config.openshift.io/v1", "ClusterOperator", "", metav1.ListOptions{})
+	if err != nil || len(operators.Items) != 5 {
+		t.Fatalf("ListResources(ClusterOperator) = %d, %v; want 5 operators", len(operators.Items), err)
+	}
+	// Typed kinds are readable both ways
+	deployment, err := client.GetResource(ctx, "apps/v1", "Deployment", "demo-shop", "frontend")
+	if err != nil || deployment.GetName() != "frontend" {
+		t.Fatalf("GetResource(Deployment) = %v, %v", deployment, err)
+	}
+	// Field selectors are honored, which the fakes alone do not do
+	onWorker, err := client.Clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: "spec.nodeName=worker-0"})
+	if err != nil || len(onWorker.Items) != 3 {
+		t.Errorf("pods on worker-0 = %d, %v; want 3", len(onWorker.Items), err)
+	}
+	pending, err := client.Clientset().CoreV1().Pods("demo-shop").List(ctx, metav1.ListOptions{FieldSelector: "status.phase=Pending"})
+	if err != nil || len(pending.Items) != 0 {
+		t.Errorf("pending pods = %d, %v; want none", len(pending.Items), err)
+	}
+	// Kinds the cluster does not serve are not found, as on a real cluster
+	if _, err := client.ListResources(ctx, "machine.openshift.io/v1beta1", "MachineSet", "", metav1.ListOptions{}); err == nil {
+		t.Error("ListResources(MachineSet) succeeded, want an error for an unserved kind")
+	}
+}
+
+func TestNew_FixtureDirectory(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"nodes.json": `{"apiVersion": "v1", "kind": "List", "items": [
+			{"apiVersion": "v1", "kind": "Node", "metadata": {"name": "node-a"}},
+			{"apiVersion": "v1", "kind": "Node", "metadata": {"name": "node-b"}}
+		]}`,
+		"pods.yaml": `apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  namespace: shop
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gear
+  namespace: shop
+`,
+		"README.md": "not a fixture",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cluster, err := New(dir)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+	if nodes, err := cluster.Client().ListNodes(ctx); err != nil || len(nodes.Items) != 2 {
+		t.Errorf("ListNodes() = %v, %v; want 2 nodes", nodes, err)
+	}
+	if _, err := cluster.Client().GetPod(ctx, "shop", "web"); err != nil {
+		t.Errorf("GetPod(shop/web) error = %v", err)
+	}
+	if widget, err := cluster.Client().GetResource(ctx, "example.com/v1", "Widget", "shop", "gear"); err != nil || widget.GetName() != "gear" {
+		t.Errorf("GetResource(Widget) = %v, %v", widget, err)
+	}
+	if openshift, _ := cluster.Client().IsOpenShift(ctx); openshift {
+		t.Error("IsOpenShift() = true without OpenShift fixtures")
+	}
+}
+
+func TestNew_InvalidFixtures(t *testing.T) {
+	for name, content := range map[string]string{
+		"no kind": `{"apiVersion": "v1", "metadata": {"name": "x"}}`,
+		"no name": `{"apiVersion": "v1", "kind": "Node", "metadata": {}}`,
+		"syntax":  `{"apiVersion": `,
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := New(dir); err == nil {
+				t.Error("New() succeeded, want an error")
+			}
+		})
+	}
+	if _, err := New(t.TempDir()); err == nil {
+		t.Error("New() of an empty directory succeeded, want an error")
+	}
+}
+
+func nodeReady(t *testing.T, cluster *Cluster, name string) corev1.ConditionStatus {
+	t.Helper()
+	node, err := cluster.Client().GetNode(context.Background(), name)
+	if err != nil {
+		t.Fatalf("GetNode(%s) error = %v", name, err)
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+func TestApply_NodeNotReady(t *testing.T) {
+	cluster := newBuiltinCluster(t)
+
+	summary, err := cluster.Apply("node-not-ready", nil)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if summary != "node worker-0 is NotReady" {
+		t.Errorf("Apply() = %q, want the first worker to fail", summary)
+	}
+	if status := nodeReady(t, cluster, "worker-0"); status != corev1.ConditionFalse {
+		t.Errorf("worker-0 Ready = %s, want False", status)
+	}
+	// Typed and dynamic reads agree
+	node, err := cluster.Client().GetResource(context.Background(), "v1", "Node", "", "worker-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taints, _, _ := unstructured.NestedSlice(node.Object, "spec", "taints"); len(taints) != 1 {
+		t.Errorf("dynamic worker-0 taints = %v, want the not-ready taint", taints)
+	}
+
+	if _, err := cluster.Apply("node-not-ready", map[string]string{"node": "worker-1"}); err != nil {
+		t.Fatalf("Apply(worker-1) error = %v", err)
+	}
+	if status := nodeReady(t, cluster, "worker-1"); status != corev1.ConditionFalse {
+		t.Errorf("worker-1 Ready = %s, want False", status)
+	}
+
+	if _, err := cluster.Apply("reset", nil); err != nil {
+		t.Fatalf("Apply(reset) error = %v", err)
+	}
+	for _, name := range []string{"worker-0", "worker-1"} {
+		if status := nodeReady(t, cluster, name); status != corev1.ConditionTrue {
+			t.Errorf("%s Ready after reset = %s, want True", name, status)
+		}
+	}
+}
+
+func TestApply_NamespaceCrashLoop(t *testing.T) {
+	ctx := context.Background()
+	cluster := newBuiltinCluster(t)
+
+	if _, err := cluster.Apply("namespace-crashloop", nil); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	pods, err := cluster.Client().ListPods(ctx, "demo-shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pod := range pods.Items {
+		status := pod.Status.ContainerStatuses[0]
+		if status.State.Waiting == nil || status.State.Waiting.Reason != "CrashLoopBackOff" || status.RestartCount != 5 {
+			t.Errorf("pod %s container status = %+v, want CrashLoopBackOff after 5 restarts", pod.Name, status)
+		}
+	}
+	events, err := cluster.Client().ListEvents(ctx, "demo-shop")
+	if err != nil || len(events.Items) != len(pods.Items) {
+		t.Fatalf("ListEvents() = %v, %v; want one BackOff event per pod", events, err)
+	}
+
+	// Applying it again counts the events again rather than duplicating them
+	if _, err := cluster.Apply("namespace-crashloop", map[string]string{"namespace": "demo-shop"}); err != nil {
+		t.Fatal(err)
+	}
+	events, _ = cluster.Client().ListEvents(ctx, "demo-shop")
+	if len(events.Items) != len(pods.Items) || events.Items[0].Count != 10 {
+		t.Errorf("events after a second crash loop = %+v, want the same events counted twice", events.Items)
+	}
+
+	if _, err := cluster.Apply("reset", nil); err != nil {
+		t.Fatal(err)
+	}
+	if events, _ := cluster.Client().ListEvents(ctx, "demo-shop"); len(events.Items) != 0 {
+		t.Errorf("events after reset = %d, want none", len(events.Items))
+	}
+	pod, _ := cluster.Client().GetPod(ctx, "demo-shop", pods.Items[0].Name)
+	if !pod.Status.ContainerStatuses[0].Ready {
+		t.Error("pod not ready after reset")
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	cluster := newBuiltinCluster(t)
+	if _, err := cluster.Apply("meteor-strike", nil); !errors.Is(err, ErrUnknownScenario) {
+		t.Errorf("Apply(unknown) error = %v, want ErrUnknownScenario", err)
+	}
+	if _, err := cluster.Apply("node-not-ready", map[string]string{"zone": "a"}); err == nil {
+		t.Error("Apply() with an unknown parameter succeeded")
+	}
+	if _, err := cluster.Apply("node-not-ready", map[string]string{"node": "missing"}); err == nil {
+		t.Error("Apply() on a missing node succeeded")
+	}
+	if _, err := cluster.Apply("namespace-crashloop", map[string]string{"namespace": "empty"}); err == nil {
+		t.Error("Apply() on a namespace without pods succeeded")
+	}
+}
@@ -0,0 +1,113 @@
+package mockcluster
+
+import (
+	"fmt"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// MockUser is the user every token authenticates as against the simulated
+// cluster
+const MockUser = "mock-user"
+
+// addReviewReactors answers access and token reviews, which a real API
+// server computes rather than stores
+func addReviewReactors(fake *k8stesting.Fake) {
+	fake.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).DeepCopy()
+		review.Status = authorizationv1.SubjectAccessReviewStatus{Allowed: true, Reason: "the simulated cluster allows everything"}
+		return true, review, nil
+	})
+	fake.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview).DeepCopy()
+		review.Status = authenticationv1.TokenReviewStatus{
+			Authenticated: review.Spec.Token != "",
+			User:          authenticationv1.UserInfo{Username: MockUser, Groups: []string{"system:authenticated"}},
+			Audiences:     review.Spec.Audiences,
+		}
+		return true, review, nil
+	})
+}
+
+// fieldSelectorReactor lists the objects matching both the label and the
+// field selector of a list action, as the fakes ignore field selectors.
+// Lists without a field selector are left to the fake's own reactor.
+func fieldSelectorReactor(tracker k8stesting.ObjectTracker) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		list, ok := action.(k8stesting.ListActionImpl)
+		if !ok {
+			return false, nil, nil
+		}
+		restrictions := list.GetListRestrictions()
+		if restrictions.Fields == nil || restrictions.Fields.Empty() {
+			return false, nil, nil
+		}
+		listed, err := tracker.List(list.GetResource(), list.GetKind(), list.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		items, err := meta.ExtractList(listed)
+		if err != nil {
+			return true, nil, err
+		}
+		var matched []runtime.Object
+		for _, item := range items {
+			if matches(item, restrictions.Labels, restrictions.Fields) {
+				matched = append(matched, item)
+			}
+		}
+		if err := meta.SetList(listed, matched); err != nil {
+			return true, nil, err
+		}
+		return true, listed, nil
+	}
+}
+
+// matches reports whether object has the selected labels and fields. Fields
+// are looked up by their JSON path, e.g. status.phase.
+func matches(object runtime.Object, labelSelector labels.Selector, fieldSelector fields.Selector) bool {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return false
+	}
+	if labelSelector != nil && !labelSelector.Matches(labels.Set(accessor.GetLabels())) {
+		return false
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return false
+	}
+	set := fields.Set{}
+	for _, requirement := range fieldSelector.Requirements() {
+		value, found := lookupField(content, strings.Split(requirement.Field, "."))
+		if found {
+			set[requirement.Field] = value
+		}
+	}
+	return fieldSelector.Matches(set)
+}
+
+// lookupField returns the value at path as a string
+func lookupField(content map[string]interface{}, path []string) (string, bool) {
+	var current interface{} = content
+	for _, field := range path {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if current, ok = object[field]; !ok {
+			return "", false
+		}
+	}
+	if current == nil {
+		return "", false
+	}
+	return fmt.Sprint(current), true
+}
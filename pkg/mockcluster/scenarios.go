@@ -0,0 +1,318 @@
+package mockcluster
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// ErrUnknownScenario is returned for a scenario that does not exist
+var ErrUnknownScenario = errors.New("unknown scenario")
+
+// Scenario is a change to the simulated cluster that agents can be
+// exercised against, such as a node failing
+type Scenario struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Params      map[string]string `json:"params,omitempty"` // Parameter -> description; all are optional
+	apply       func(c *Cluster, params map[string]string) (string, error)
+}
+
+var (
+	nodesResource  = corev1.SchemeGroupVersion.WithResource("nodes")
+	podsResource   = corev1.SchemeGroupVersion.WithResource("pods")
+	eventsResource = corev1.SchemeGroupVersion.WithResource("events")
+)
+
+// scenarios are the built-in scenarios, by name
+var scenarios = map[string]Scenario{
+	"node-not-ready": {
+		Name:        "node-not-ready",
+		Description: "A node stops reporting: its Ready condition turns False and it is tainted not-ready",
+		Params:      map[string]string{"node": "Node to fail (default: the first worker node)"},
+		apply:       (*Cluster).nodeNotReady,
+	},
+	"namespace-crashloop": {
+		Name:        "namespace-crashloop",
+		Description: "Every pod of a namespace crash-loops, with BackOff warning events",
+		Params:      map[string]string{"namespace": "Namespace whose pods crash (default: demo-shop)"},
+		apply:       (*Cluster).namespaceCrashLoop,
+	},
+	"reset": {
+		Name:        "reset",
+		Description: "Restore every fixture object and remove the events scenarios added",
+		apply:       (*Cluster).reset,
+	},
+}
+
+// Scenarios returns the scenarios that can be applied, by name
+func Scenarios() []Scenario {
+	list := make([]Scenario, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		list = append(list, scenario)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Apply applies the named scenario and describes what changed. Scenarios
+// build on each other until "reset" restores the fixtures.
+func (c *Cluster) Apply(name string, params map[string]string) (string, error) {
+	scenario, ok := scenarios[name]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownScenario, name)
+	}
+	for param := range params {
+		if _, ok := scenario.Params[param]; !ok {
+			return "", fmt.Errorf("scenario %s has no parameter %q", name, param)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return scenario.apply(c, params)
+}
+
+// nodeNotReady fails params["node"], or the first worker node
+func (c *Cluster) nodeNotReady(params map[string]string) (string, error) {
+	name := params["node"]
+	if name == "" {
+		workers, err := c.nodeNames(func(node *corev1.Node) bool {
+			_, controlPlane := node.Labels["node-role.kubernetes.io/control-plane"]
+			_, master := node.Labels["node-role.kubernetes.io/master"]
+			return !controlPlane && !master
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(workers) == 0 {
+			return "", errors.New("the cluster has no worker node; set the node parameter")
+		}
+		name = workers[0]
+	}
+
+	object, err := c.clientset.Tracker().Get(nodesResource, "", name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+	node := object.(*corev1.Node).DeepCopy()
+	now := metav1.Now()
+	ready := corev1.NodeCondition{
+		Type:               corev1.NodeReady,
+		Status:             corev1.ConditionFalse,
+		Reason:             "KubeletNotReady",
+		Message:            "container runtime is down (simulated)",
+		LastHeartbeatTime:  now,
+		LastTransitionTime: now,
+	}
+	replaced := false
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == corev1.NodeReady {
+			node.Status.Conditions[i], replaced = ready, true
+		}
+	}
+	if !replaced {
+		node.Status.Conditions = append(node.Status.Conditions, ready)
+	}
+	if !hasTaint(node, corev1.TaintNodeNotReady) {
+		node.Spec.Taints = append(node.Spec.Taints, corev1.Taint{Key: corev1.TaintNodeNotReady, Effect: corev1.TaintEffectNoSchedule, TimeAdded: &now})
+	}
+	if err := c.update(nodesResource, node, ""); err != nil {
+		return "", fmt.Errorf("failed to update node %s: %w", name, err)
+	}
+	return fmt.Sprintf("node %s is NotReady", name), nil
+}
+
+// namespaceCrashLoop puts every container of the pods of
+// params["namespace"] in CrashLoopBackOff
+func (c *Cluster) namespaceCrashLoop(params map[string]string) (string, error) {
+	namespace := params["namespace"]
+	if namespace == "" {
+		namespace = "demo-shop"
+	}
+	list, err := c.clientset.Tracker().List(podsResource, corev1.SchemeGroupVersion.WithKind("Pod"), namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods in %s: %w", namespace, err)
+	}
+	pods := list.(*corev1.PodList).Items
+	if len(pods) == 0 {
+		return "", fmt.Errorf("namespace %s has no pods", namespace)
+	}
+
+	now := time.Now()
+	for i := range pods {
+		pod := &pods[i]
+		pod.Status.Phase = corev1.PodRunning
+		setPodCondition(pod, corev1.PodReady, corev1.ConditionFalse, "ContainersNotReady")
+		setPodCondition(pod, corev1.ContainersReady, corev1.ConditionFalse, "ContainersNotReady")
+		var statuses []corev1.ContainerStatus
+		for _, container := range pod.Spec.Containers {
+			status := corev1.ContainerStatus{Name: container.Name, Image: container.Image}
+			for _, previous := range pod.Status.ContainerStatuses {
+				if previous.Name == container.Name {
+					status = previous
+				}
+			}
+			status.Ready = false
+			status.Started = nil
+			status.RestartCount += 5
+			status.State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason:  "CrashLoopBackOff",
+				Message: fmt.Sprintf("back-off 5m0s restarting failed container=%s pod=%s", container.Name, pod.Name),
+			}}
+			status.LastTerminationState = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode:   1,
+				Reason:     "Error",
+				StartedAt:  metav1.NewTime(now.Add(-time.Minute)),
+				FinishedAt: metav1.NewTime(now.Add(-30 * time.Second)),
+			}}
+			statuses = append(statuses, status)
+		}
+		pod.Status.ContainerStatuses = statuses
+		if err := c.update(podsResource, pod, namespace); err != nil {
+			return "", fmt.Errorf("failed to update pod %s/%s: %w", namespace, pod.Name, err)
+		}
+		if err := c.warn(pod, "BackOff", "Back-off restarting failed container", now); err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("%d pods in %s are crash-looping", len(pods), namespace), nil
+}
+
+// warn records a warning event on pod, or counts it again if the scenario
+// already did
+func (c *Cluster) warn(pod *corev1.Pod, reason, message string, now time.Time) error {
+	name := strings.ToLower(fmt.Sprintf("%s.%s.mock", pod.Name, reason))
+	event := &corev1.Event{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Event"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: pod.Namespace, UID: types.UID(name)},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+			UID:        pod.UID,
+		},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        message,
+		Count:          5,
+		FirstTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)),
+		LastTimestamp:  metav1.NewTime(now),
+		Source:         corev1.EventSource{Component: "kubelet", Host: pod.Spec.NodeName},
+	}
+	if existing, err := c.clientset.Tracker().Get(eventsResource, pod.Namespace, name); err == nil {
+		previous := existing.(*corev1.Event)
+		event.Count = previous.Count + 5
+		event.FirstTimestamp = previous.FirstTimestamp
+		return c.update(eventsResource, event, pod.Namespace)
+	}
+	object, err := toUnstructured(event)
+	if err == nil {
+		err = c.add(fixtureObject{gvr: eventsResource, typed: event, object: object, namespace: pod.Namespace})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record event on pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	c.added = append(c.added, addedObject{gvr: eventsResource, namespace: pod.Namespace, name: name})
+	return nil
+}
+
+// reset restores the fixtures: objects changed or deleted since are put
+// back, and the events scenarios added are removed. Objects created by
+// tools are kept.
+func (c *Cluster) reset(map[string]string) (string, error) {
+	for _, added := range c.added {
+		_ = c.clientset.Tracker().Delete(added.gvr, added.namespace, added.name)
+		_ = c.dynamic.Tracker().Delete(added.gvr, added.namespace, added.name)
+	}
+	c.added = nil
+
+	for _, fixture := range c.fixtures {
+		if fixture.typed != nil {
+			if err := restore(c.clientset.Tracker(), fixture.gvr, fixture.typed.DeepCopyObject(), fixture.namespace); err != nil {
+				return "", fmt.Errorf("failed to restore %s %s: %w", fixture.object.GetKind(), objectName(fixture.object), err)
+			}
+		}
+		if err := restore(c.dynamic.Tracker(), fixture.gvr, fixture.object.DeepCopy(), fixture.namespace); err != nil {
+			return "", fmt.Errorf("failed to restore %s %s: %w", fixture.object.GetKind(), objectName(fixture.object), err)
+		}
+	}
+	return fmt.Sprintf("restored %d fixture objects", len(c.fixtures)), nil
+}
+
+// restore updates object, or creates it again if it was deleted
+func restore(tracker k8stesting.ObjectTracker, gvr schema.GroupVersionResource, object runtime.Object, namespace string) error {
+	err := tracker.Update(gvr, object, namespace)
+	if apierrors.IsNotFound(err) {
+		return tracker.Create(gvr, object, namespace)
+	}
+	return err
+}
+
+// nodeNames returns the sorted names of the nodes that match
+func (c *Cluster) nodeNames(match func(*corev1.Node) bool) ([]string, error) {
+	list, err := c.clientset.Tracker().List(nodesResource, corev1.SchemeGroupVersion.WithKind("Node"), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	var names []string
+	for i := range list.(*corev1.NodeList).Items {
+		if node := &list.(*corev1.NodeList).Items[i]; match(node) {
+			names = append(names, node.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func hasTaint(node *corev1.Node, key string) bool {
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// setPodCondition sets a condition of pod, adding it if missing
+func setPodCondition(pod *corev1.Pod, conditionType corev1.PodConditionType, status corev1.ConditionStatus, reason string) {
+	condition := corev1.PodCondition{Type: conditionType, Status: status, Reason: reason, LastTransitionTime: metav1.Now()}
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			pod.Status.Conditions[i] = condition
+			return
+		}
+	}
+	pod.Status.Conditions = append(pod.Status.Conditions, condition)
+}
+
+// toUnstructured converts a typed object for the dynamic client, which
+// needs its kind even where typed objects leave it out
+func toUnstructured(object runtime.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+	converted := &unstructured.Unstructured{Object: content}
+	if converted.GetKind() == "" {
+		kinds, _, err := scheme.Scheme.ObjectKinds(object)
+		if err != nil {
+			return nil, err
+		}
+		converted.SetGroupVersionKind(kinds[0])
+	}
+	return converted, nil
+}
@@ -0,0 +1,398 @@
+// Package policy matches cluster state against automatic remediation rules.
+// A rule maps a condition (a deployment unavailable for a while, a node
+// NotReady for a while, a workload OOMKilled too often) to an action, and
+// holds off repeating it on the same target for a cooldown. Matching is
+// pure: callers collect a Snapshot of the cluster and act on the Decisions.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Condition types
+const (
+	ConditionDeploymentUnavailable = "deployment_unavailable" // Available=False for at least For
+	ConditionNodeNotReady          = "node_not_ready"         // Ready not True for at least For
+	ConditionPodOOMKilled          = "pod_oom_killed"         // Count OOM kills of a workload's containers within Window
+)
+
+// Action types
+const (
+	ActionRestartWorkload = "restart_workload" // Rolling restart of the Deployment, StatefulSet or DaemonSet
+	ActionTriggerPlaybook = "trigger_playbook" // Run a Coordination Engine playbook against the target
+	ActionNotify          = "notify"           // Only record and announce the match
+)
+
+// DefaultCooldown is how long a rule without a cooldown leaves a target alone
+// after acting on it
+const DefaultCooldown = 30 * time.Minute
+
+// Duration is a time.Duration written as a Go duration string ("10m") in JSON
+type Duration time.Duration
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON reads a duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("duration must be a string such as \"10m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Rule is one remediation policy
+type Rule struct {
+	Name      string    `json:"name"`
+	Condition Condition `json:"condition"`
+	Action    Action    `json:"action"`
+	// Cooldown is how long the rule leaves a target alone after acting on
+	// it (DefaultCooldown when zero)
+	Cooldown Duration `json:"cooldown,omitempty"`
+}
+
+// Condition is what a rule matches
+type Condition struct {
+	Type      string `json:"type"`
+	Namespace string `json:"namespace,omitempty"` // Required for deployment and pod conditions
+	// Name is a glob of the deployment, node or workload names matched (empty matches all)
+	Name   string   `json:"name,omitempty"`
+	For    Duration `json:"for,omitempty"`    // deployment_unavailable and node_not_ready
+	Count  int      `json:"count,omitempty"`  // pod_oom_killed
+	Window Duration `json:"window,omitempty"` // pod_oom_killed
+}
+
+// Action is what a rule does on a match
+type Action struct {
+	Type     string `json:"type"`
+	Playbook string `json:"playbook,omitempty"` // trigger_playbook
+}
+
+// cooldown returns the rule's cooldown, defaulted
+func (r Rule) cooldown() time.Duration {
+	if r.Cooldown == 0 {
+		return DefaultCooldown
+	}
+	return time.Duration(r.Cooldown)
+}
+
+// matchesName reports whether name matches the condition's name glob
+func (c Condition) matchesName(name string) bool {
+	if c.Name == "" {
+		return true
+	}
+	matched, _ := path.Match(c.Name, name) // Validate rejects malformed globs
+	return matched
+}
+
+// Validate checks rules, returning one problem per mistake. Rules are
+// validated when the configuration is loaded, so that a typo never turns
+// into an unexpected restart.
+func Validate(rules []Rule) []string {
+	var problems []string
+	seen := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		label := fmt.Sprintf("remediation policy %q", rule.Name)
+		switch {
+		case rule.Name == "":
+			label = fmt.Sprintf("remediation policy #%d", i+1)
+			problems = append(problems, label+" has no name")
+		case seen[rule.Name]:
+			problems = append(problems, label+" is defined more than once")
+		}
+		seen[rule.Name] = true
+
+		for _, problem := range rule.validate() {
+			problems = append(problems, fmt.Sprintf("%s: %s", label, problem))
+		}
+	}
+	return problems
+}
+
+// validate checks one rule's condition and action, and that they fit together
+func (r Rule) validate() []string {
+	var problems []string
+	c := r.Condition
+	if c.Name != "" {
+		if _, err := path.Match(c.Name, ""); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid name pattern %q: %v", c.Name, err))
+		}
+	}
+	switch c.Type {
+	case ConditionDeploymentUnavailable, ConditionPodOOMKilled:
+		if c.Namespace == "" {
+			problems = append(problems, c.Type+" requires a namespace")
+		} else if errs := validation.IsDNS1123Label(c.Namespace); len(errs) > 0 {
+			problems = append(problems, fmt.Sprintf("invalid namespace %q: %s", c.Namespace, strings.Join(errs, "; ")))
+		}
+	case ConditionNodeNotReady:
+		if c.Namespace != "" {
+			problems = append(problems, "node_not_ready takes no namespace")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown condition type %q (deployment_unavailable, node_not_ready or pod_oom_killed)", c.Type))
+	}
+	switch c.Type {
+	case ConditionDeploymentUnavailable, ConditionNodeNotReady:
+		if c.For <= 0 {
+			problems = append(problems, c.Type+" requires a positive for duration")
+		}
+	case ConditionPodOOMKilled:
+		if c.Count < 1 {
+			problems = append(problems, "pod_oom_killed requires a count of at least 1")
+		}
+		if c.Window <= 0 {
+			problems = append(problems, "pod_oom_killed requires a positive window")
+		}
+	}
+
+	switch r.Action.Type {
+	case ActionRestartWorkload:
+		if c.Type == ConditionNodeNotReady {
+			problems = append(problems, "restart_workload cannot act on node_not_ready; use notify or trigger_playbook")
+		}
+	case ActionTriggerPlaybook:
+		if r.Action.Playbook == "" {
+			problems = append(problems, "trigger_playbook requires a playbook")
+		}
+	case ActionNotify:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown action type %q (restart_workload, trigger_playbook or notify)", r.Action.Type))
+	}
+	if r.Cooldown < 0 {
+		problems = append(problems, "cooldown must not be negative")
+	}
+	return problems
+}
+
+// Target is the object a match is about
+type Target struct {
+	Kind      string `json:"kind"` // Deployment, StatefulSet, DaemonSet, Pod or Node
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (t Target) String() string {
+	if t.Namespace == "" {
+		return t.Kind + " " + t.Name
+	}
+	return t.Kind + " " + t.Namespace + "/" + t.Name
+}
+
+// Snapshot is the cluster state rules are matched against
+type Snapshot struct {
+	Deployments []DeploymentState
+	Nodes       []NodeState
+	OOMKills    []OOMKill
+}
+
+// DeploymentState is whether a deployment is available
+type DeploymentState struct {
+	Namespace string
+	Name      string
+	// UnavailableSince is when its Available condition turned False (zero while available)
+	UnavailableSince time.Time
+}
+
+// NodeState is whether a node is ready
+type NodeState struct {
+	Name string
+	// NotReadySince is when its Ready condition left True (zero while ready)
+	NotReadySince time.Time
+}
+
+// OOMKill is one OOM kill of a container, as last seen in its pod status
+type OOMKill struct {
+	Workload  Target // The pod's controller, or the pod itself
+	Pod       string
+	Container string
+	At        time.Time
+}
+
+// key tells kills apart across snapshots
+func (k OOMKill) key() string {
+	return k.Workload.Namespace + "/" + k.Pod + "/" + k.Container + "@" + k.At.UTC().Format(time.RFC3339)
+}
+
+// Decision is one rule matching one target
+type Decision struct {
+	Rule      string `json:"rule"`
+	Condition string `json:"condition"` // Type of the rule's condition
+	Action    Action `json:"action"`
+	Target    Target `json:"target"`
+	Reason    string `json:"reason"`
+	// CooldownUntil is set while the rule's cooldown on the target holds the action off
+	CooldownUntil *time.Time `json:"cooldown_until,omitempty"`
+}
+
+// Acts reports whether the decision is to act now
+func (d Decision) Acts() bool {
+	return d.CooldownUntil == nil
+}
+
+// Evaluator matches rules against snapshots. Since a pod's status only
+// shows its container's last termination, it remembers the OOM kills of
+// earlier snapshots to count them over a window; it also keeps when each
+// rule last acted on each target. It is safe for concurrent use.
+type Evaluator struct {
+	mu        sync.Mutex
+	oomKills  map[string]OOMKill
+	lastActed map[string]time.Time // By rule and target
+}
+
+// NewEvaluator creates an evaluator with no memory
+func NewEvaluator() *Evaluator {
+	return &Evaluator{oomKills: make(map[string]OOMKill), lastActed: make(map[string]time.Time)}
+}
+
+// actedKey is the key of a rule's cooldown on a target
+func actedKey(rule string, target Target) string {
+	return rule + "\x00" + target.String()
+}
+
+// Observe remembers the snapshot's OOM kills, forgetting those older than
+// every rule's window
+func (e *Evaluator) Observe(now time.Time, snapshot Snapshot, rules []Rule) {
+	var window time.Duration
+	for _, rule := range rules {
+		if rule.Condition.Type == ConditionPodOOMKilled {
+			window = max(window, time.Duration(rule.Condition.Window))
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, kill := range snapshot.OOMKills {
+		e.oomKills[kill.key()] = kill
+	}
+	for key, kill := range e.oomKills {
+		if now.Sub(kill.At) > window {
+			delete(e.oomKills, key)
+		}
+	}
+}
+
+// Evaluate returns the decisions of rules on snapshot, sorted by rule and
+// target. It changes nothing, so it also serves dry-runs; OOM kills of the
+// snapshot count whether or not they were observed.
+func (e *Evaluator) Evaluate(now time.Time, snapshot Snapshot, rules []Rule) []Decision {
+	e.mu.Lock()
+	kills := make(map[string]OOMKill, len(e.oomKills)+len(snapshot.OOMKills))
+	for key, kill := range e.oomKills {
+		kills[key] = kill
+	}
+	lastActed := make(map[string]time.Time, len(e.lastActed))
+	for key, at := range e.lastActed {
+		lastActed[key] = at
+	}
+	e.mu.Unlock()
+	for _, kill := range snapshot.OOMKills {
+		kills[kill.key()] = kill
+	}
+
+	var decisions []Decision
+	for _, rule := range rules {
+		for _, match := range matchRule(now, rule, snapshot, kills) {
+			if at, ok := lastActed[actedKey(rule.Name, match.Target)]; ok {
+				if until := at.Add(rule.cooldown()); now.Before(until) {
+					match.CooldownUntil = &until
+				}
+			}
+			decisions = append(decisions, match)
+		}
+	}
+	sort.SliceStable(decisions, func(i, j int) bool {
+		if decisions[i].Rule != decisions[j].Rule {
+			return decisions[i].Rule < decisions[j].Rule
+		}
+		return decisions[i].Target.String() < decisions[j].Target.String()
+	})
+	return decisions
+}
+
+// Acted starts the cooldown of the decision's rule on its target
+func (e *Evaluator) Acted(decision Decision, at time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.lastActed[actedKey(decision.Rule, decision.Target)] = at
+}
+
+// Forget drops the cooldowns of rules no longer configured
+func (e *Evaluator) Forget(rules []Rule) {
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		names[rule.Name] = true
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for key := range e.lastActed {
+		rule, _, _ := strings.Cut(key, "\x00")
+		if !names[rule] {
+			delete(e.lastActed, key)
+		}
+	}
+}
+
+// matchRule returns the decisions of one rule, before cooldowns
+func matchRule(now time.Time, rule Rule, snapshot Snapshot, kills map[string]OOMKill) []Decision {
+	c := rule.Condition
+	decide := func(target Target, reason string) Decision {
+		return Decision{Rule: rule.Name, Condition: c.Type, Action: rule.Action, Target: target, Reason: reason}
+	}
+
+	var decisions []Decision
+	switch c.Type {
+	case ConditionDeploymentUnavailable:
+		for _, deployment := range snapshot.Deployments {
+			if deployment.Namespace != c.Namespace || !c.matchesName(deployment.Name) || deployment.UnavailableSince.IsZero() {
+				continue
+			}
+			if down := now.Sub(deployment.UnavailableSince); down >= time.Duration(c.For) {
+				target := Target{Kind: "Deployment", Namespace: deployment.Namespace, Name: deployment.Name}
+				decisions = append(decisions, decide(target, fmt.Sprintf("unavailable for %s (threshold %s)", down.Round(time.Second), time.Duration(c.For))))
+			}
+		}
+	case ConditionNodeNotReady:
+		for _, node := range snapshot.Nodes {
+			if !c.matchesName(node.Name) || node.NotReadySince.IsZero() {
+				continue
+			}
+			if down := now.Sub(node.NotReadySince); down >= time.Duration(c.For) {
+				target := Target{Kind: "Node", Name: node.Name}
+				decisions = append(decisions, decide(target, fmt.Sprintf("NotReady for %s (threshold %s)", down.Round(time.Second), time.Duration(c.For))))
+			}
+		}
+	case ConditionPodOOMKilled:
+		counts := make(map[Target]int)
+		for _, kill := range kills {
+			if kill.Workload.Namespace != c.Namespace || !c.matchesName(kill.Workload.Name) {
+				continue
+			}
+			if age := now.Sub(kill.At); age >= 0 && age <= time.Duration(c.Window) {
+				counts[kill.Workload]++
+			}
+		}
+		for target, count := range counts {
+			if count >= c.Count {
+				decisions = append(decisions, decide(target, fmt.Sprintf("%d OOM kills within %s (threshold %d)", count, time.Duration(c.Window), c.Count)))
+			}
+		}
+	}
+	return decisions
+}
@@ -0,0 +1,329 @@
+package policy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+func minutes(n int) Duration {
+	return Duration(time.Duration(n) * time.Minute)
+}
+
+func deploymentRule(name, glob string, forMinutes int) Rule {
+	return Rule{
+		Name:      name,
+		Condition: Condition{Type: ConditionDeploymentUnavailable, Namespace: "shop", Name: glob, For: minutes(forMinutes)},
+		Action:    Action{Type: ActionRestartWorkload},
+	}
+}
+
+func oomRule(count, windowMinutes int) Rule {
+	return Rule{
+		Name:      "oom",
+		Condition: Condition{Type: ConditionPodOOMKilled, Namespace: "shop", Count: count, Window: minutes(windowMinutes)},
+		Action:    Action{Type: ActionNotify},
+	}
+}
+
+func oomKill(workload, pod string, ago time.Duration) OOMKill {
+	return OOMKill{
+		Workload:  Target{Kind: "Deployment", Namespace: "shop", Name: workload},
+		Pod:       pod,
+		Container: "app",
+		At:        testNow.Add(-ago),
+	}
+}
+
+// targets lists the decisions as "rule:target" entries, marking those in cooldown
+func targets(decisions []Decision) string {
+	var entries []string
+	for _, decision := range decisions {
+		entry := decision.Rule + ":" + decision.Target.String()
+		if !decision.Acts() {
+			entry += " (cooldown)"
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ", ")
+}
+
+func TestValidate(t *testing.T) {
+	valid := []Rule{
+		deploymentRule("api-down", "api*", 10),
+		{
+			Name:      "node-down",
+			Condition: Condition{Type: ConditionNodeNotReady, For: minutes(15)},
+			Action:    Action{Type: ActionTriggerPlaybook, Playbook: "drain-node"},
+			Cooldown:  minutes(60),
+		},
+		oomRule(3, 60),
+	}
+	if problems := Validate(valid); len(problems) > 0 {
+		t.Fatalf("Validate(valid) = %v", problems)
+	}
+
+	tests := []struct {
+		name string
+		rule Rule
+		want string
+	}{
+		{"no name", Rule{Condition: Condition{Type: ConditionNodeNotReady, For: minutes(1)}, Action: Action{Type: ActionNotify}}, "#1 has no name"},
+		{"unknown condition", Rule{Name: "r", Condition: Condition{Type: "pod_pending"}, Action: Action{Type: ActionNotify}}, `unknown condition type "pod_pending"`},
+		{"unknown action", Rule{Name: "r", Condition: Condition{Type: ConditionNodeNotReady, For: minutes(1)}, Action: Action{Type: "delete"}}, `unknown action type "delete"`},
+		{"namespace required", Rule{Name: "r", Condition: Condition{Type: ConditionDeploymentUnavailable, For: minutes(1)}, Action: Action{Type: ActionNotify}}, "requires a namespace"},
+		{"invalid namespace", Rule{Name: "r", Condition: Condition{Type: ConditionPodOOMKilled, Namespace: "Shop", Count: 1, Window: minutes(1)}, Action: Action{Type: ActionNotify}}, `invalid namespace "Shop"`},
+		{"node with namespace", Rule{Name: "r", Condition: Condition{Type: ConditionNodeNotReady, Namespace: "shop", For: minutes(1)}, Action: Action{Type: ActionNotify}}, "takes no namespace"},
+		{"no for", Rule{Name: "r", Condition: Condition{Type: ConditionDeploymentUnavailable, Namespace: "shop"}, Action: Action{Type: ActionNotify}}, "positive for duration"},
+		{"no count", Rule{Name: "r", Condition: Condition{Type: ConditionPodOOMKilled, Namespace: "shop", Window: minutes(1)}, Action: Action{Type: ActionNotify}}, "count of at least 1"},
+		{"no window", Rule{Name: "r", Condition: Condition{Type: ConditionPodOOMKilled, Namespace: "shop", Count: 1}, Action: Action{Type: ActionNotify}}, "positive window"},
+		{"bad glob", Rule{Name: "r", Condition: Condition{Type: ConditionNodeNotReady, Name: "worker-[", For: minutes(1)}, Action: Action{Type: ActionNotify}}, `invalid name pattern "worker-["`},
+		{"restart a node", Rule{Name: "r", Condition: Condition{Type: ConditionNodeNotReady, For: minutes(1)}, Action: Action{Type: ActionRestartWorkload}}, "cannot act on node_not_ready"},
+		{"no playbook", Rule{Name: "r", Condition: Condition{Type: ConditionNodeNotReady, For: minutes(1)}, Action: Action{Type: ActionTriggerPlaybook}}, "requires a playbook"},
+		{"negative cooldown", Rule{Name: "r", Condition: Condition{Type: ConditionNodeNotReady, For: minutes(1)}, Action: Action{Type: ActionNotify}, Cooldown: minutes(-1)}, "cooldown must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := Validate([]Rule{tt.rule})
+			if len(problems) != 1 || !strings.Contains(problems[0], tt.want) {
+				t.Errorf("Validate() = %q, want one problem containing %q", problems, tt.want)
+			}
+		})
+	}
+
+	problems := Validate([]Rule{valid[0], valid[0]})
+	if len(problems) != 1 || !strings.Contains(problems[0], `"api-down" is defined more than once`) {
+		t.Errorf("Validate(duplicate) = %q", problems)
+	}
+}
+
+func TestRule_UnmarshalJSON(t *testing.T) {
+	var rule Rule
+	data := `{"name": "api-down", "condition": {"type": "deployment_unavailable", "namespace": "shop", "name": "api", "for": "10m"}, "action": {"type": "restart_workload"}, "cooldown": "1h"}`
+	if err := json.Unmarshal([]byte(data), &rule); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if rule.Condition.For != minutes(10) || rule.Cooldown != minutes(60) {
+		t.Errorf("durations = %v, %v", time.Duration(rule.Condition.For), time.Duration(rule.Cooldown))
+	}
+
+	encoded, err := json.Marshal(rule.Condition)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(encoded), `"for":"10m0s"`) {
+		t.Errorf("Marshal() = %s, want the duration as a string", encoded)
+	}
+
+	for _, bad := range []string{`{"for": 600}`, `{"for": "ten minutes"}`} {
+		var c Condition
+		if err := json.Unmarshal([]byte(bad), &c); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestEvaluate_DeploymentUnavailable(t *testing.T) {
+	snapshot := Snapshot{Deployments: []DeploymentState{
+		{Namespace: "shop", Name: "api", UnavailableSince: testNow.Add(-15 * time.Minute)},
+		{Namespace: "shop", Name: "api-gateway", UnavailableSince: testNow.Add(-5 * time.Minute)}, // Not for long enough
+		{Namespace: "shop", Name: "api-worker"},                                                   // Available
+		{Namespace: "shop", Name: "web", UnavailableSince: testNow.Add(-time.Hour)},               // Name does not match
+		{Namespace: "other", Name: "api", UnavailableSince: testNow.Add(-time.Hour)},              // Namespace does not match
+		{Namespace: "shop", Name: "api-exact", UnavailableSince: testNow.Add(-10 * time.Minute)},  // Exactly the threshold
+	}}
+
+	decisions := NewEvaluator().Evaluate(testNow, snapshot, []Rule{deploymentRule("api-down", "api*", 10)})
+	if got, want := targets(decisions), "api-down:Deployment shop/api, api-down:Deployment shop/api-exact"; got != want {
+		t.Fatalf("Evaluate() = %q, want %q", got, want)
+	}
+	d := decisions[0]
+	if d.Condition != ConditionDeploymentUnavailable || d.Action.Type != ActionRestartWorkload {
+		t.Errorf("decision = %+v", d)
+	}
+	if want := "unavailable for 15m0s (threshold 10m0s)"; d.Reason != want {
+		t.Errorf("Reason = %q, want %q", d.Reason, want)
+	}
+}
+
+func TestEvaluate_NodeNotReady(t *testing.T) {
+	snapshot := Snapshot{Nodes: []NodeState{
+		{Name: "worker-1", NotReadySince: testNow.Add(-20 * time.Minute)},
+		{Name: "worker-2", NotReadySince: testNow.Add(-time.Minute)},
+		{Name: "worker-3"},
+		{Name: "master-0", NotReadySince: testNow.Add(-time.Hour)},
+	}}
+	rule := Rule{
+		Name:      "workers-down",
+		Condition: Condition{Type: ConditionNodeNotReady, Name: "worker-*", For: minutes(15)},
+		Action:    Action{Type: ActionNotify},
+	}
+
+	decisions := NewEvaluator().Evaluate(testNow, snapshot, []Rule{rule})
+	if got, want := targets(decisions), "workers-down:Node worker-1"; got != want {
+		t.Errorf("Evaluate() = %q, want %q", got, want)
+	}
+	if len(decisions) == 1 && decisions[0].Target.Namespace != "" {
+		t.Errorf("node target has namespace %q", decisions[0].Target.Namespace)
+	}
+}
+
+func TestEvaluate_OOMKillsWithinWindow(t *testing.T) {
+	snapshot := Snapshot{OOMKills: []OOMKill{
+		oomKill("api", "api-1", 10*time.Minute),
+		oomKill("api", "api-2", 20*time.Minute),
+		oomKill("api", "api-2", 50*time.Minute),
+		oomKill("api", "api-3", 2*time.Hour), // Outside the window
+		oomKill("web", "web-1", 5*time.Minute),
+		oomKill("web", "web-1", 5*time.Minute), // The same kill seen twice counts once
+	}}
+
+	decisions := NewEvaluator().Evaluate(testNow, snapshot, []Rule{oomRule(3, 60)})
+	if got, want := targets(decisions), "oom:Deployment shop/api"; got != want {
+		t.Fatalf("Evaluate() = %q, want %q", got, want)
+	}
+	if want := "3 OOM kills within 1h0m0s (threshold 3)"; decisions[0].Reason != want {
+		t.Errorf("Reason = %q, want %q", decisions[0].Reason, want)
+	}
+
+	decisions = NewEvaluator().Evaluate(testNow, snapshot, []Rule{oomRule(1, 60)})
+	if got, want := targets(decisions), "oom:Deployment shop/api, oom:Deployment shop/web"; got != want {
+		t.Errorf("Evaluate(count 1) = %q, want %q", got, want)
+	}
+}
+
+func TestEvaluator_RemembersOOMKillsAcrossSnapshots(t *testing.T) {
+	evaluator := NewEvaluator()
+	rules := []Rule{oomRule(3, 60)}
+
+	// Each snapshot only shows the pod's last termination
+	for _, ago := range []time.Duration{40 * time.Minute, 25 * time.Minute} {
+		now := testNow.Add(-ago)
+		snapshot := Snapshot{OOMKills: []OOMKill{oomKill("api", "api-1", ago)}}
+		evaluator.Observe(now, snapshot, rules)
+		if decisions := evaluator.Evaluate(now, snapshot, rules); len(decisions) > 0 {
+			t.Fatalf("Evaluate() after %s = %q, want no match yet", ago, targets(decisions))
+		}
+	}
+	snapshot := Snapshot{OOMKills: []OOMKill{oomKill("api", "api-1", 0)}}
+	if got := targets(evaluator.Evaluate(testNow, snapshot, rules)); got != "oom:Deployment shop/api" {
+		t.Errorf("Evaluate() = %q, want the third kill to match", got)
+	}
+
+	// Evaluate changes nothing: without the snapshot's kill, two remain
+	if decisions := evaluator.Evaluate(testNow, Snapshot{}, rules); len(decisions) > 0 {
+		t.Errorf("Evaluate(empty) = %q, want no match", targets(decisions))
+	}
+}
+
+func TestEvaluator_ObserveForgetsKillsOutsideEveryWindow(t *testing.T) {
+	evaluator := NewEvaluator()
+	evaluator.Observe(testNow, Snapshot{OOMKills: []OOMKill{
+		oomKill("api", "api-1", 30*time.Minute),
+		oomKill("api", "api-1", 90*time.Minute),
+		oomKill("api", "api-1", 3*time.Hour),
+	}}, []Rule{oomRule(1, 60), oomRule(1, 120)})
+
+	if got := len(evaluator.oomKills); got != 2 {
+		t.Errorf("remembered %d kills, want 2 within the longest window", got)
+	}
+
+	evaluator.Observe(testNow, Snapshot{}, []Rule{deploymentRule("api-down", "", 10)})
+	if got := len(evaluator.oomKills); got != 0 {
+		t.Errorf("remembered %d kills without OOM rules, want 0", got)
+	}
+}
+
+func TestEvaluator_Cooldown(t *testing.T) {
+	evaluator := NewEvaluator()
+	rule := deploymentRule("api-down", "", 10)
+	rule.Cooldown = minutes(30)
+	other := deploymentRule("api-down-notify", "", 10)
+	other.Action = Action{Type: ActionNotify}
+	rules := []Rule{rule, other}
+	snapshot := Snapshot{Deployments: []DeploymentState{
+		{Namespace: "shop", Name: "api", UnavailableSince: testNow.Add(-time.Hour)},
+		{Namespace: "shop", Name: "web", UnavailableSince: testNow.Add(-time.Hour)},
+	}}
+
+	decisions := evaluator.Evaluate(testNow, snapshot, rules)
+	if len(decisions) != 4 {
+		t.Fatalf("Evaluate() = %q, want 4 decisions", targets(decisions))
+	}
+	evaluator.Acted(decisions[0], testNow)
+
+	want := "api-down:Deployment shop/api (cooldown), api-down:Deployment shop/web, " +
+		"api-down-notify:Deployment shop/api, api-down-notify:Deployment shop/web"
+	later := testNow.Add(29 * time.Minute)
+	decisions = evaluator.Evaluate(later, snapshot, rules)
+	if got := targets(decisions); got != want {
+		t.Errorf("Evaluate() within the cooldown = %q, want %q", got, want)
+	}
+	if until := decisions[0].CooldownUntil; until == nil || !until.Equal(testNow.Add(30*time.Minute)) {
+		t.Errorf("CooldownUntil = %v, want %v", until, testNow.Add(30*time.Minute))
+	}
+
+	if decisions = evaluator.Evaluate(testNow.Add(30*time.Minute), snapshot, rules); !decisions[0].Acts() {
+		t.Errorf("decision still in cooldown once it ended: %+v", decisions[0])
+	}
+}
+
+func TestEvaluator_DefaultCooldown(t *testing.T) {
+	evaluator := NewEvaluator()
+	rules := []Rule{deploymentRule("api-down", "", 10)}
+	snapshot := Snapshot{Deployments: []DeploymentState{{Namespace: "shop", Name: "api", UnavailableSince: testNow.Add(-time.Hour)}}}
+
+	evaluator.Acted(evaluator.Evaluate(testNow, snapshot, rules)[0], testNow)
+	if decision := evaluator.Evaluate(testNow.Add(DefaultCooldown-time.Second), snapshot, rules)[0]; decision.Acts() {
+		t.Error("decision acts within the default cooldown")
+	}
+	if decision := evaluator.Evaluate(testNow.Add(DefaultCooldown), snapshot, rules)[0]; !decision.Acts() {
+		t.Error("decision still in cooldown after the default cooldown")
+	}
+}
+
+func TestEvaluator_ForgetDropsCooldownsOfRemovedRules(t *testing.T) {
+	evaluator := NewEvaluator()
+	kept := deploymentRule("kept", "", 10)
+	removed := deploymentRule("removed", "", 10)
+	snapshot := Snapshot{Deployments: []DeploymentState{{Namespace: "shop", Name: "api", UnavailableSince: testNow.Add(-time.Hour)}}}
+	for _, decision := range evaluator.Evaluate(testNow, snapshot, []Rule{kept, removed}) {
+		evaluator.Acted(decision, testNow)
+	}
+
+	evaluator.Forget([]Rule{kept})
+	if got := len(evaluator.lastActed); got != 1 {
+		t.Fatalf("kept %d cooldowns, want 1", got)
+	}
+	// A rule added back under the same name starts afresh
+	decisions := evaluator.Evaluate(testNow.Add(time.Minute), snapshot, []Rule{kept, removed})
+	if got, want := targets(decisions), "kept:Deployment shop/api (cooldown), removed:Deployment shop/api"; got != want {
+		t.Errorf("Evaluate() = %q, want %q", got, want)
+	}
+}
+
+func TestEvaluate_SortedByRuleAndTarget(t *testing.T) {
+	snapshot := Snapshot{Deployments: []DeploymentState{
+		{Namespace: "shop", Name: "web", UnavailableSince: testNow.Add(-time.Hour)},
+		{Namespace: "shop", Name: "api", UnavailableSince: testNow.Add(-time.Hour)},
+	}}
+	rules := []Rule{deploymentRule("z-rule", "", 10), deploymentRule("a-rule", "", 10)}
+
+	got := targets(NewEvaluator().Evaluate(testNow, snapshot, rules))
+	want := "a-rule:Deployment shop/api, a-rule:Deployment shop/web, z-rule:Deployment shop/api, z-rule:Deployment shop/web"
+	if got != want {
+		t.Errorf("Evaluate() = %q, want %q", got, want)
+	}
+}
+
+func TestTarget_String(t *testing.T) {
+	if got := (Target{Kind: "Node", Name: "worker-1"}).String(); got != "Node worker-1" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (Target{Kind: "StatefulSet", Namespace: "db", Name: "pg"}).String(); got != "StatefulSet db/pg" {
+		t.Errorf("String() = %q", got)
+	}
+}